@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+
+	"github.com/lbpay-lab/conn-bridge/internal/infrastructure/signer"
+	"github.com/lbpay-lab/conn-bridge/internal/simulator"
+)
+
+var (
+	log = logrus.New()
+)
+
+// config holds the simulator settings, read from SIMULATOR_* environment variables
+type config struct {
+	Port        int
+	TLSCertPath string
+	TLSKeyPath  string
+
+	// Signatures
+	RequireSignedRequests bool
+	TrustStorePath        string // verifies the request certificate chain when set
+	SignerCertPath        string // signs responses when set (PKCS#12, or PEM with SignerKeyPath)
+	SignerKeyPath         string
+	SignerPassword        string
+
+	Faults simulator.FaultConfig
+}
+
+func main() {
+	initLogger()
+
+	log.Info("Starting Bacen DICT simulator...")
+
+	cfg, err := loadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	simConfig, err := buildSimulatorConfig(cfg)
+	if err != nil {
+		log.Fatalf("Failed to configure simulator: %v", err)
+	}
+
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", cfg.Port),
+		Handler:           simulator.NewServer(simConfig),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errChan := make(chan error, 1)
+	go func() {
+		log.WithFields(logrus.Fields{
+			"port": cfg.Port,
+			"tls":  cfg.TLSCertPath != "",
+		}).Info("Simulator listening")
+
+		var err error
+		if cfg.TLSCertPath != "" {
+			err = server.ListenAndServeTLS(cfg.TLSCertPath, cfg.TLSKeyPath)
+		} else {
+			err = server.ListenAndServe()
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errChan <- err
+		}
+	}()
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+
+	select {
+	case err := <-errChan:
+		log.Errorf("Server error: %v", err)
+	case sig := <-sigChan:
+		log.Infof("Received signal: %v", sig)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		log.Errorf("Error during shutdown: %v", err)
+	}
+	log.Info("Simulator stopped")
+}
+
+// loadConfig loads configuration from environment variables
+func loadConfig() (*config, error) {
+	viper.AutomaticEnv()
+	viper.SetEnvPrefix("SIMULATOR")
+
+	viper.SetDefault("PORT", 8443)
+
+	cfg := &config{
+		Port:                  viper.GetInt("PORT"),
+		TLSCertPath:           viper.GetString("TLS_CERT_PATH"),
+		TLSKeyPath:            viper.GetString("TLS_KEY_PATH"),
+		RequireSignedRequests: viper.GetBool("REQUIRE_SIGNED_REQUESTS"),
+		TrustStorePath:        viper.GetString("TRUST_STORE_PATH"),
+		SignerCertPath:        viper.GetString("SIGNER_CERT_PATH"),
+		SignerKeyPath:         viper.GetString("SIGNER_KEY_PATH"),
+		SignerPassword:        viper.GetString("SIGNER_PASSWORD"),
+		Faults: simulator.FaultConfig{
+			Latency:         viper.GetDuration("LATENCY"),
+			HTTPErrorRate:   viper.GetFloat64("HTTP_ERROR_RATE"),
+			HTTPErrorStatus: viper.GetInt("HTTP_ERROR_STATUS"),
+			SOAPFaultRate:   viper.GetFloat64("SOAP_FAULT_RATE"),
+		},
+	}
+
+	if (cfg.TLSCertPath == "") != (cfg.TLSKeyPath == "") {
+		return nil, fmt.Errorf("SIMULATOR_TLS_CERT_PATH and SIMULATOR_TLS_KEY_PATH must be set together")
+	}
+	if cfg.TrustStorePath != "" && !cfg.RequireSignedRequests {
+		return nil, fmt.Errorf("SIMULATOR_TRUST_STORE_PATH requires SIMULATOR_REQUIRE_SIGNED_REQUESTS=true")
+	}
+	return cfg, nil
+}
+
+// buildSimulatorConfig loads the optional request verifier and response signer
+func buildSimulatorConfig(cfg *config) (*simulator.Config, error) {
+	simConfig := &simulator.Config{
+		Logger:                log,
+		Faults:                cfg.Faults,
+		RequireSignedRequests: cfg.RequireSignedRequests,
+	}
+
+	if cfg.TrustStorePath != "" {
+		verifier, err := signer.NewResponseVerifier(&signer.ResponseVerifierConfig{
+			TrustStorePath: cfg.TrustStorePath,
+			Logger:         log,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load trust store: %w", err)
+		}
+		simConfig.RequestVerifier = verifier
+	}
+
+	if cfg.SignerCertPath != "" {
+		responseSigner, err := signer.NewNativeXMLSigner(&signer.NativeConfig{
+			CertPath: cfg.SignerCertPath,
+			KeyPath:  cfg.SignerKeyPath,
+			Password: cfg.SignerPassword,
+			Logger:   log,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load response signer: %w", err)
+		}
+		simConfig.ResponseSigner = responseSigner
+	} else {
+		log.Warn("SIMULATOR_SIGNER_CERT_PATH not set - responses are unsigned, run the bridge in dev mode without trust store")
+	}
+
+	return simConfig, nil
+}
+
+// initLogger initializes the logger
+func initLogger() {
+	log.SetFormatter(&logrus.JSONFormatter{})
+	log.SetOutput(os.Stdout)
+
+	switch os.Getenv("LOG_LEVEL") {
+	case "debug":
+		log.SetLevel(logrus.DebugLevel)
+	case "warn":
+		log.SetLevel(logrus.WarnLevel)
+	case "error":
+		log.SetLevel(logrus.ErrorLevel)
+	default:
+		log.SetLevel(logrus.InfoLevel)
+	}
+}
@@ -1,16 +1,22 @@
 package di
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 	"os"
 	"time"
 
-	"github.com/lbpay-lab/conn-bridge/internal/api/grpc"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/lbpay-lab/conn-bridge/internal/application/usecases"
 	"github.com/lbpay-lab/conn-bridge/internal/domain/interfaces"
+	"github.com/lbpay-lab/conn-bridge/internal/grpc"
 	"github.com/lbpay-lab/conn-bridge/internal/infrastructure/bacen"
 	"github.com/lbpay-lab/conn-bridge/internal/infrastructure/circuitbreaker"
 	"github.com/lbpay-lab/conn-bridge/internal/infrastructure/pulsar"
+	"github.com/lbpay-lab/conn-bridge/internal/infrastructure/signer"
+	"github.com/lbpay-lab/dict-contracts/idempotency"
+	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
 )
 
@@ -20,6 +26,14 @@ type Container struct {
 	BacenClient      interfaces.BacenClient
 	MessagePublisher interfaces.MessagePublisher
 	CircuitBreaker   interfaces.CircuitBreaker
+	XMLSigner        signer.Signer
+	SOAPClient       *bacen.SOAPClient
+
+	// Idempotency (nil when DatabaseURL is not set)
+	IdempotencyStore idempotency.Store
+	dbPool           *pgxpool.Pool
+	redisClient      *redis.Client
+	stopPurge        context.CancelFunc
 
 	// Use Cases
 	CreateEntryUseCase *usecases.CreateEntryUseCase
@@ -39,6 +53,16 @@ type Config struct {
 	BacenAPIKey   string
 	BacenCertPath string
 	BacenKeyPath  string
+	// BacenTrustStorePath is the ICP-Brasil CA bundle used to verify Bacen response signatures
+	BacenTrustStorePath string
+
+	// XML Signer configuration (mode "java" uses the sidecar, "native" signs in-process)
+	XMLSignerMode         string
+	XMLSignerURL          string
+	XMLSignerTimeout      time.Duration
+	XMLSignerCertPath     string // PKCS#12 keystore or PEM certificate (native mode)
+	XMLSignerKeyPath      string // PEM private key, only with a PEM certificate (native mode)
+	XMLSignerCertPassword string
 
 	// Pulsar configuration
 	PulsarBrokerURL string
@@ -52,14 +76,28 @@ type Config struct {
 
 	// gRPC configuration
 	GRPCPort int
+
+	// Idempotency of the mutating BridgeService RPCs by request_id (disabled without DatabaseURL)
+	DatabaseURL              string        // Postgres with the idempotency_keys table (migrations/)
+	RedisURL                 string        // Optional cache of completed responses
+	IdempotencyTTL           time.Duration // Replay window of completed responses (default: 24h)
+	IdempotencyLockTTL       time.Duration // Max time a request holds its request_id (default: 60s)
+	IdempotencyPurgeInterval time.Duration // Interval between purges of expired records (default: 1h)
 }
 
 // NewContainer creates a new dependency injection container
 func NewContainer(config *Config) (*Container, error) {
 	container := &Container{}
 
+	// Logger for infrastructure and API components
+	logger := &logrus.Logger{
+		Out:       os.Stdout,
+		Formatter: new(logrus.JSONFormatter),
+		Level:     logrus.InfoLevel,
+	}
+
 	// Initialize infrastructure layer
-	if err := container.initInfrastructure(config); err != nil {
+	if err := container.initInfrastructure(config, logger); err != nil {
 		return nil, fmt.Errorf("failed to initialize infrastructure: %w", err)
 	}
 
@@ -69,7 +107,7 @@ func NewContainer(config *Config) (*Container, error) {
 	}
 
 	// Initialize API layer
-	if err := container.initAPI(config); err != nil {
+	if err := container.initAPI(config, logger); err != nil {
 		return nil, fmt.Errorf("failed to initialize API: %w", err)
 	}
 
@@ -77,14 +115,7 @@ func NewContainer(config *Config) (*Container, error) {
 }
 
 // initInfrastructure initializes the infrastructure layer
-func (c *Container) initInfrastructure(config *Config) error {
-	// Initialize logger for infrastructure components
-	logger := &logrus.Logger{
-		Out:       os.Stdout,
-		Formatter: new(logrus.JSONFormatter),
-		Level:     logrus.InfoLevel,
-	}
-
+func (c *Container) initInfrastructure(config *Config, logger *logrus.Logger) error {
 	// Initialize Bacen HTTP client (base client without circuit breaker)
 	baseBacenClient, err := bacen.NewHTTPClient(&bacen.Config{
 		BaseURL:  config.BacenBaseURL,
@@ -92,6 +123,8 @@ func (c *Container) initInfrastructure(config *Config) error {
 		APIKey:   config.BacenAPIKey,
 		CertPath: config.BacenCertPath,
 		KeyPath:  config.BacenKeyPath,
+
+		TrustStorePath: config.BacenTrustStorePath,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create Bacen client: %w", err)
@@ -110,6 +143,44 @@ func (c *Container) initInfrastructure(config *Config) error {
 	// Wrap Bacen client with circuit breaker protection
 	c.BacenClient = bacen.NewCircuitBreakerClient(baseBacenClient, c.CircuitBreaker, logger)
 
+	// Initialize XML signer (Java sidecar or native, by configuration)
+	xmlSigner, err := signer.NewSigner(config.XMLSignerMode,
+		&signer.Config{
+			BaseURL: config.XMLSignerURL,
+			Timeout: config.XMLSignerTimeout,
+			Logger:  logger,
+		},
+		&signer.NativeConfig{
+			CertPath: config.XMLSignerCertPath,
+			KeyPath:  config.XMLSignerKeyPath,
+			Password: config.XMLSignerCertPassword,
+			Logger:   logger,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create XML signer: %w", err)
+	}
+	c.XMLSigner = xmlSigner
+
+	// Initialize Bacen SOAP client (BridgeService RPCs)
+	soapClient, err := bacen.NewSOAPClient(&bacen.SOAPClientConfig{
+		BaseURL:        config.BacenBaseURL,
+		Timeout:        config.BacenTimeout,
+		CertPath:       config.BacenCertPath,
+		KeyPath:        config.BacenKeyPath,
+		TrustStorePath: config.BacenTrustStorePath,
+		Logger:         logger,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create Bacen SOAP client: %w", err)
+	}
+	c.SOAPClient = soapClient
+
+	// Initialize idempotency store
+	if err := c.initIdempotency(config, logger); err != nil {
+		return err
+	}
+
 	// Initialize Pulsar publisher
 	pulsarPublisher, err := pulsar.NewPublisher(&pulsar.Config{
 		BrokerURL:               config.PulsarBrokerURL,
@@ -125,6 +196,44 @@ func (c *Container) initInfrastructure(config *Config) error {
 	return nil
 }
 
+// initIdempotency connects the request_id idempotency store (Postgres, with completed responses
+// cached in Redis when RedisURL is set). Without DatabaseURL retried requests reach Bacen again.
+func (c *Container) initIdempotency(config *Config, logger *logrus.Logger) error {
+	if config.DatabaseURL == "" {
+		logger.Warn("Idempotency disabled (DATABASE_URL not set): retried requests are sent to Bacen again")
+		return nil
+	}
+
+	pool, err := pgxpool.New(context.Background(), config.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to create idempotency database pool: %w", err)
+	}
+	c.dbPool = pool
+
+	postgresStore := idempotency.NewPostgresStore(pool, "idempotency_keys")
+	c.IdempotencyStore = postgresStore
+
+	if config.RedisURL != "" {
+		options, err := redis.ParseURL(config.RedisURL)
+		if err != nil {
+			return fmt.Errorf("invalid Redis URL: %w", err)
+		}
+		c.redisClient = redis.NewClient(options)
+		c.IdempotencyStore = idempotency.NewCachedStore(postgresStore, c.redisClient, "conn-bridge:idempotency:")
+	}
+
+	purgeCtx, stopPurge := context.WithCancel(context.Background())
+	c.stopPurge = stopPurge
+	go postgresStore.RunPurge(purgeCtx, config.IdempotencyPurgeInterval, slog.New(slog.NewJSONHandler(logger.Out, nil)))
+
+	logger.WithFields(logrus.Fields{
+		"ttl":      config.IdempotencyTTL,
+		"lock_ttl": config.IdempotencyLockTTL,
+		"cache":    c.redisClient != nil,
+	}).Info("Idempotency enabled")
+	return nil
+}
+
 // initApplication initializes the application layer (use cases)
 func (c *Container) initApplication() error {
 	// Initialize use cases
@@ -154,17 +263,18 @@ func (c *Container) initApplication() error {
 	return nil
 }
 
-// initAPI initializes the API layer (gRPC server)
-func (c *Container) initAPI(config *Config) error {
-	grpcServer, err := grpc.NewServer(&grpc.Config{
-		Port:               config.GRPCPort,
-		CreateEntryUseCase: c.CreateEntryUseCase,
-		QueryEntryUseCase:  c.QueryEntryUseCase,
-		DeleteEntryUseCase: c.DeleteEntryUseCase,
-		CreateClaimUseCase: c.CreateClaimUseCase,
-	})
-	if err != nil {
-		return fmt.Errorf("failed to create gRPC server: %w", err)
+// initAPI initializes the API layer (BridgeService gRPC server)
+func (c *Container) initAPI(config *Config, logger *logrus.Logger) error {
+	if config.GRPCPort <= 0 {
+		return fmt.Errorf("invalid port: %d", config.GRPCPort)
+	}
+
+	grpcServer := grpc.NewServer(logger, config.GRPCPort, c.SOAPClient, c.XMLSigner)
+	if c.IdempotencyStore != nil {
+		grpcServer.EnableIdempotency(c.IdempotencyStore, idempotency.Config{
+			TTL:     config.IdempotencyTTL,
+			LockTTL: config.IdempotencyLockTTL,
+		})
 	}
 	c.GRPCServer = grpcServer
 
@@ -183,6 +293,22 @@ func (c *Container) Close() error {
 		c.GRPCServer.Stop()
 	}
 
+	if c.stopPurge != nil {
+		c.stopPurge()
+		c.stopPurge = nil
+	}
+	if c.redisClient != nil {
+		client := c.redisClient
+		c.redisClient = nil
+		if err := client.Close(); err != nil {
+			return fmt.Errorf("failed to close Redis client: %w", err)
+		}
+	}
+	if c.dbPool != nil {
+		c.dbPool.Close()
+		c.dbPool = nil
+	}
+
 	return nil
 }
 
@@ -191,6 +317,9 @@ func DefaultConfig() *Config {
 	return &Config{
 		BacenBaseURL:              "https://api.bacen.gov.br/dict",
 		BacenTimeout:              30 * time.Second,
+		XMLSignerMode:             signer.ModeJava,
+		XMLSignerURL:              "http://localhost:8081",
+		XMLSignerTimeout:          10 * time.Second,
 		PulsarBrokerURL:           "pulsar://localhost:6650",
 		PulsarTimeout:             30 * time.Second,
 		CircuitBreakerName:        "bacen-circuit-breaker",
@@ -198,5 +327,8 @@ func DefaultConfig() *Config {
 		CircuitBreakerTimeout:     60 * time.Second, // 60s before half-open
 		CircuitBreakerMaxRequests: 3,                // 3 requests in half-open
 		GRPCPort:                  50051,
+		IdempotencyTTL:            24 * time.Hour,
+		IdempotencyLockTTL:        60 * time.Second,
+		IdempotencyPurgeInterval:  time.Hour,
 	}
 }
@@ -2,6 +2,7 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -13,6 +14,14 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+const (
+	endpointListClaims   = "/api/v1/dict/claims/list"
+	endpointConfirmClaim = "/api/v1/dict/claims/confirm"
+
+	defaultClaimPageSize = 100
+	maxClaimPageSize     = 1000
+)
+
 // CreateClaim handles the CreateClaim RPC call
 // Creates a new claim (reivindicação) for a DICT key with 30-day ownership claim period
 func (s *Server) CreateClaim(ctx context.Context, req *pb.CreateClaimRequest) (*pb.CreateClaimResponse, error) {
@@ -118,6 +127,42 @@ func (s *Server) GetClaim(ctx context.Context, req *pb.GetClaimRequest) (*pb.Get
 	}, nil
 }
 
+// ConfirmClaim handles the ConfirmClaim RPC call
+// Confirms a claim, either accepted by the donor or after the resolution period expired
+func (s *Server) ConfirmClaim(ctx context.Context, req *pb.ConfirmClaimRequest) (*pb.ConfirmClaimResponse, error) {
+	s.logger.Infof("ConfirmClaim called: claim_id=%s, external_id=%s, reason=%s",
+		req.ClaimId, req.ExternalId, req.ConfirmationReason)
+
+	// Validate request
+	if err := s.validateConfirmClaimRequest(req); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "validation failed: %v", err)
+	}
+
+	// Step 1: Convert gRPC request to XML
+	xmlData, err := xml.ConfirmClaimRequestToXML(req)
+	if err != nil {
+		s.logger.Errorf("Failed to convert ConfirmClaim request to XML: %v", err)
+		return nil, status.Errorf(codes.Internal, "XML conversion failed: %v", err)
+	}
+
+	// Steps 2-4: Sign, send to Bacen and parse the SOAP response
+	bodyXML, err := s.callBacen(ctx, endpointConfirmClaim, xmlData)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 5: Convert XML response to gRPC response
+	response, err := xml.ConfirmClaimResponseFromXML(bodyXML)
+	if err != nil {
+		s.logger.Errorf("Failed to convert ConfirmClaim response: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to convert response: %v", err)
+	}
+
+	s.logger.Infof("ConfirmClaim completed: claim_id=%s, status=%v", response.ClaimId, response.Status)
+
+	return response, nil
+}
+
 // CompleteClaim handles the CompleteClaim RPC call
 // Completes a claim, transferring ownership to the claimer
 func (s *Server) CompleteClaim(ctx context.Context, req *pb.CompleteClaimRequest) (*pb.CompleteClaimResponse, error) {
@@ -204,6 +249,48 @@ func (s *Server) CancelClaim(ctx context.Context, req *pb.CancelClaimRequest) (*
 	}, nil
 }
 
+// ListClaims handles the ListClaims RPC call
+// Lists the claims where the participant is donor and/or claimer, one page at a time
+func (s *Server) ListClaims(ctx context.Context, req *pb.ListClaimsRequest) (*pb.ListClaimsResponse, error) {
+	s.logger.Infof("ListClaims called: participant=%s, is_donor=%v, is_claimer=%v, page_size=%d",
+		req.ParticipantIspb, req.GetIsDonor(), req.GetIsClaimer(), req.PageSize)
+
+	// Validate request
+	if err := s.validateListClaimsRequest(req); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "validation failed: %v", err)
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = defaultClaimPageSize
+	}
+
+	// Step 1: Convert gRPC request to XML (page token → Bacen cursor)
+	xmlData, err := xml.ListClaimsRequestToXML(req)
+	if err != nil {
+		if errors.Is(err, xml.ErrInvalidPageToken) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		s.logger.Errorf("Failed to convert ListClaims request to XML: %v", err)
+		return nil, status.Errorf(codes.Internal, "XML conversion failed: %v", err)
+	}
+
+	// Steps 2-4: Sign, send to Bacen and parse the SOAP response
+	bodyXML, err := s.callBacen(ctx, endpointListClaims, xmlData)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 5: Convert XML response to gRPC response
+	response, err := xml.ListClaimsResponseFromXML(bodyXML, req)
+	if err != nil {
+		s.logger.Errorf("Failed to convert ListClaims response: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to convert response: %v", err)
+	}
+
+	s.logger.Infof("ListClaims completed: claims=%d, has_more=%v", len(response.Claims), response.NextPageToken != "")
+
+	return response, nil
+}
+
 // ========== VALIDATION FUNCTIONS ==========
 
 // validateCreateClaimRequest validates the CreateClaim request
@@ -254,6 +341,14 @@ func (s *Server) validateGetClaimRequest(req *pb.GetClaimRequest) error {
 	return nil
 }
 
+// validateConfirmClaimRequest validates the ConfirmClaim request
+func (s *Server) validateConfirmClaimRequest(req *pb.ConfirmClaimRequest) error {
+	if req.ClaimId == "" && req.ExternalId == "" {
+		return fmt.Errorf("either claim_id or external_id is required")
+	}
+	return nil
+}
+
 // validateCompleteClaimRequest validates the CompleteClaim request
 func (s *Server) validateCompleteClaimRequest(req *pb.CompleteClaimRequest) error {
 	if req.ClaimId == "" && req.ExternalId == "" {
@@ -276,6 +371,17 @@ func (s *Server) validateCancelClaimRequest(req *pb.CancelClaimRequest) error {
 
 // ========== HELPER FUNCTIONS ==========
 
+// validateListClaimsRequest validates the ListClaims request
+func (s *Server) validateListClaimsRequest(req *pb.ListClaimsRequest) error {
+	if req.ParticipantIspb == "" {
+		return fmt.Errorf("participant_ispb is required")
+	}
+	if req.PageSize > maxClaimPageSize {
+		return fmt.Errorf("page_size cannot exceed %d", maxClaimPageSize)
+	}
+	return nil
+}
+
 // maskKey masks sensitive key data for logging
 func maskKey(key string) string {
 	if len(key) <= 4 {
@@ -0,0 +1,96 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	pb "github.com/lbpay-lab/dict-contracts/gen/proto/bridge/v1"
+	commonv1 "github.com/lbpay-lab/dict-contracts/gen/proto/common/v1"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/lbpay-lab/conn-bridge/internal/infrastructure/bacen"
+)
+
+// fakeXMLSigner returns the XML unchanged
+type fakeXMLSigner struct{}
+
+func (fakeXMLSigner) SignXML(_ context.Context, xmlData string) (string, error) { return xmlData, nil }
+func (fakeXMLSigner) HealthCheck(_ context.Context) error                       { return nil }
+
+// fakeSOAPClient records the last request and answers with body (or err)
+type fakeSOAPClient struct {
+	body     string
+	err      error
+	endpoint string
+	request  string
+}
+
+func (c *fakeSOAPClient) SendSOAPRequest(_ context.Context, endpoint string, soapEnvelope []byte) ([]byte, error) {
+	c.endpoint = endpoint
+	c.request = string(soapEnvelope)
+	if c.err != nil {
+		return nil, c.err
+	}
+	return []byte(`<soap:Envelope><soap:Body>` + c.body + `</soap:Body></soap:Envelope>`), nil
+}
+
+func (c *fakeSOAPClient) BuildSOAPEnvelope(bodyXML string, _ string) ([]byte, error) {
+	return []byte(`<soap:Envelope><soap:Body>` + bodyXML + `</soap:Body></soap:Envelope>`), nil
+}
+
+func (c *fakeSOAPClient) ParseSOAPResponse(soapResponse []byte) ([]byte, error) {
+	body := strings.TrimPrefix(string(soapResponse), `<soap:Envelope><soap:Body>`)
+	return []byte(strings.TrimSuffix(body, `</soap:Body></soap:Envelope>`)), nil
+}
+
+func (c *fakeSOAPClient) HealthCheck(_ context.Context) error { return nil }
+
+func newTestServer(soapClient SOAPClient) *Server {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	return NewServer(logger, 0, soapClient, fakeXMLSigner{})
+}
+
+func TestConfirmClaim_SendsToBacen(t *testing.T) {
+	soapClient := &fakeSOAPClient{body: `<ConfirmClaimResponse>
+<ResponseTime>2026-10-18T10:00:01Z</ResponseTime>
+<CorrelationId>corr-123</CorrelationId>
+<Claim><ClaimId>bacen-claim-1</ClaimId><Type>OWNERSHIP</Type><Status>CONFIRMED</Status>
+<LastModified>2026-10-18T10:00:00Z</LastModified></Claim>
+</ConfirmClaimResponse>`}
+	server := newTestServer(soapClient)
+
+	resp, err := server.ConfirmClaim(context.Background(), &pb.ConfirmClaimRequest{
+		ClaimId:            "claim-1",
+		ExternalId:         "bacen-claim-1",
+		ConfirmationReason: "DEFAULT_OPERATION",
+		RequestId:          "req-1",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, endpointConfirmClaim, soapClient.endpoint)
+	assert.Contains(t, soapClient.request, "<ClaimId>bacen-claim-1</ClaimId>")
+	assert.Equal(t, "bacen-claim-1", resp.ClaimId)
+	assert.Equal(t, commonv1.ClaimStatus_CLAIM_STATUS_CONFIRMED, resp.Status)
+	assert.Equal(t, "corr-123", resp.BacenTransactionId)
+	assert.Equal(t, "2026-10-18T10:00:00Z", resp.ConfirmedAt.AsTime().Format("2006-01-02T15:04:05Z07:00"))
+}
+
+func TestConfirmClaim_BacenRejection(t *testing.T) {
+	soapClient := &fakeSOAPClient{err: fmt.Errorf("confirm: %w", &bacen.FaultError{
+		Code:       bacen.ReasonInvalidClaimStatus,
+		Reason:     "claim is not waiting for resolution",
+		HTTPStatus: 400,
+	})}
+	server := newTestServer(soapClient)
+
+	_, err := server.ConfirmClaim(context.Background(), &pb.ConfirmClaimRequest{ExternalId: "bacen-claim-1", RequestId: "req-1"})
+
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
@@ -2,91 +2,95 @@ package grpc
 
 import (
 	"context"
+	"errors"
 
+	"github.com/lbpay-lab/conn-bridge/internal/xml"
 	pb "github.com/lbpay-lab/dict-contracts/gen/proto/bridge/v1"
-	commonv1 "github.com/lbpay-lab/dict-contracts/gen/proto/common/v1"
+	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// Bacen DICT directory endpoints
+	endpointGetDirectory  = "/api/v1/dict/directory"
+	endpointSearchEntries = "/api/v1/dict/entries/search"
+
+	defaultDirectoryPageSize = 100
+	maxDirectoryPageSize     = 1000
 )
 
 // GetDirectory handles the GetDirectory RPC call
-// Queries the complete DICT directory from Bacen with optional filters
+// Queries the DICT directory from Bacen with optional key type, status and ISPB filters
+// Flow: gRPC Request → XML → Sign XML → SOAP Envelope → mTLS POST → Parse Response → gRPC Response
 func (s *Server) GetDirectory(ctx context.Context, req *pb.GetDirectoryRequest) (*pb.GetDirectoryResponse, error) {
-	s.logger.Infof("GetDirectory called: request_id=%s, key_type=%v, status=%v, page_size=%d",
-		req.RequestId, req.KeyType, req.Status, req.PageSize)
-
-	// Validate request
+	s.logger.WithFields(logrus.Fields{
+		"requestId": req.RequestId,
+		"keyType":   req.GetKeyType(),
+		"status":    req.GetStatus(),
+		"ispb":      req.GetIspb(),
+		"pageSize":  req.PageSize,
+	}).Info("GetDirectory called")
+
+	// Step 1: Validate request
 	if req.RequestId == "" {
 		return nil, status.Error(codes.InvalidArgument, "request_id is required")
 	}
-
-	// Validate page size (max 1000 for performance)
 	if req.PageSize <= 0 {
-		req.PageSize = 100 // Default page size
+		req.PageSize = defaultDirectoryPageSize
 	}
-	if req.PageSize > 1000 {
+	if req.PageSize > maxDirectoryPageSize {
 		return nil, status.Error(codes.InvalidArgument, "page_size cannot exceed 1000")
 	}
 
-	// TODO: Step 1: Convert gRPC request to SOAP XML (GetDirectory)
-	// TODO: Step 2: Sign XML with ICP-Brasil A3
-	// TODO: Step 3: Query Bacen DICT directory
-	// TODO: Step 4: Parse SOAP response
-	// TODO: Step 5: Convert XML entries to proto Entry messages
-
-	s.logger.Warnf("GetDirectory not fully implemented - returning mock data")
-
-	// Placeholder response (mock data for now)
-	mockEntries := []*pb.Entry{
-		{
-			EntryId:    "entry-001",
-			ExternalId: "bacen-001",
-			KeyType:    commonv1.KeyType_KEY_TYPE_CPF,
-			KeyValue:   "12345678900",
-			Account: &commonv1.Account{
-				Ispb:              "12345678",
-				AccountType:       commonv1.AccountType_ACCOUNT_TYPE_CHECKING,
-				AccountNumber:     "123456",
-				AccountCheckDigit: "7",
-				BranchCode:        "0001",
-			},
-			Status:    commonv1.EntryStatus_ENTRY_STATUS_ACTIVE,
-			CreatedAt: timestamppb.Now(),
-			UpdatedAt: timestamppb.Now(),
-		},
-		{
-			EntryId:    "entry-002",
-			ExternalId: "bacen-002",
-			KeyType:    commonv1.KeyType_KEY_TYPE_EMAIL,
-			KeyValue:   "user@example.com",
-			Account: &commonv1.Account{
-				Ispb:              "12345678",
-				AccountType:       commonv1.AccountType_ACCOUNT_TYPE_CHECKING,
-				AccountNumber:     "654321",
-				AccountCheckDigit: "0",
-				BranchCode:        "0001",
-			},
-			Status:    commonv1.EntryStatus_ENTRY_STATUS_ACTIVE,
-			CreatedAt: timestamppb.Now(),
-			UpdatedAt: timestamppb.Now(),
-		},
-	}
-
-	return &pb.GetDirectoryResponse{
-		Entries:       mockEntries,
-		NextPageToken: "", // No more pages in this mock
-		TotalCount:    int32(len(mockEntries)),
-	}, nil
+	// Step 2: Convert gRPC request to XML (page token → Bacen cursor)
+	xmlData, err := xml.GetDirectoryRequestToXML(req)
+	if err != nil {
+		if errors.Is(err, xml.ErrInvalidPageToken) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		s.logger.WithError(err).Error("Failed to convert request to XML")
+		return nil, status.Errorf(codes.Internal, "failed to convert request to XML: %v", err)
+	}
+
+	// Steps 3-6: Sign, send to Bacen and parse the SOAP response
+	bodyXML, err := s.callBacen(ctx, endpointGetDirectory, xmlData)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 7: Convert XML response to gRPC response
+	response, err := xml.GetDirectoryResponseFromXML(bodyXML, req)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to convert XML response to gRPC")
+		return nil, status.Errorf(codes.Internal, "failed to convert response: %v", err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"entries":    len(response.Entries),
+		"totalCount": response.TotalCount,
+		"hasMore":    response.NextPageToken != "",
+	}).Info("GetDirectory completed successfully")
+
+	return response, nil
 }
 
 // SearchEntries handles the SearchEntries RPC call
-// Searches for entries by specific criteria (account holder, account number, ISPB)
+// Searches for entries by account holder document, account number and/or ISPB,
+// optionally narrowed by key type and status
+// Flow: gRPC Request → XML → Sign XML → SOAP Envelope → mTLS POST → Parse Response → gRPC Response
 func (s *Server) SearchEntries(ctx context.Context, req *pb.SearchEntriesRequest) (*pb.SearchEntriesResponse, error) {
-	s.logger.Infof("SearchEntries called: request_id=%s, document=%s, account=%s, ispb=%s, page_size=%d",
-		req.RequestId, req.GetAccountHolderDocument(), req.GetAccountNumber(), req.GetIspb(), req.PageSize)
-
-	// Validate request
+	s.logger.WithFields(logrus.Fields{
+		"requestId": req.RequestId,
+		"document":  maskKey(req.GetAccountHolderDocument()),
+		"account":   req.GetAccountNumber(),
+		"ispb":      req.GetIspb(),
+		"keyType":   req.GetKeyType(),
+		"status":    req.GetStatus(),
+		"pageSize":  req.PageSize,
+	}).Info("SearchEntries called")
+
+	// Step 1: Validate request
 	if req.RequestId == "" {
 		return nil, status.Error(codes.InvalidArgument, "request_id is required")
 	}
@@ -96,68 +100,73 @@ func (s *Server) SearchEntries(ctx context.Context, req *pb.SearchEntriesRequest
 		return nil, status.Error(codes.InvalidArgument, "at least one search criterion is required (document, account_number, or ispb)")
 	}
 
-	// Validate page size
 	if req.PageSize <= 0 {
-		req.PageSize = 100 // Default page size
+		req.PageSize = defaultDirectoryPageSize
 	}
-	if req.PageSize > 1000 {
+	if req.PageSize > maxDirectoryPageSize {
 		return nil, status.Error(codes.InvalidArgument, "page_size cannot exceed 1000")
 	}
 
-	// TODO: Step 1: Convert gRPC request to SOAP XML (SearchEntries)
-	// TODO: Step 2: Sign XML with ICP-Brasil A3
-	// TODO: Step 3: Execute search query on Bacen
-	// TODO: Step 4: Parse SOAP response
-	// TODO: Step 5: Convert XML results to proto Entry messages
-
-	s.logger.Warnf("SearchEntries not fully implemented - returning mock data")
-
-	// Placeholder response (mock data filtered by criteria)
-	var mockResults []*pb.Entry
-
-	// Simulate search by ISPB
-	if req.GetIspb() == "12345678" {
-		mockResults = append(mockResults, &pb.Entry{
-			EntryId:    "entry-search-001",
-			ExternalId: "bacen-search-001",
-			KeyType:    commonv1.KeyType_KEY_TYPE_PHONE,
-			KeyValue:   "+5511999999999",
-			Account: &commonv1.Account{
-				Ispb:              "12345678",
-				AccountType:       commonv1.AccountType_ACCOUNT_TYPE_SAVINGS,
-				AccountNumber:     "789012",
-				AccountCheckDigit: "3",
-				BranchCode:        "0002",
-			},
-			Status:    commonv1.EntryStatus_ENTRY_STATUS_ACTIVE,
-			CreatedAt: timestamppb.Now(),
-			UpdatedAt: timestamppb.Now(),
-		})
-	}
-
-	// Simulate search by account holder document
-	if req.GetAccountHolderDocument() == "12345678900" {
-		mockResults = append(mockResults, &pb.Entry{
-			EntryId:    "entry-search-002",
-			ExternalId: "bacen-search-002",
-			KeyType:    commonv1.KeyType_KEY_TYPE_CPF,
-			KeyValue:   "12345678900",
-			Account: &commonv1.Account{
-				Ispb:              "12345678",
-				AccountType:       commonv1.AccountType_ACCOUNT_TYPE_CHECKING,
-				AccountNumber:     "123456",
-				AccountCheckDigit: "7",
-				BranchCode:        "0001",
-			},
-			Status:    commonv1.EntryStatus_ENTRY_STATUS_ACTIVE,
-			CreatedAt: timestamppb.Now(),
-			UpdatedAt: timestamppb.Now(),
-		})
-	}
-
-	return &pb.SearchEntriesResponse{
-		Entries:       mockResults,
-		NextPageToken: "", // No more pages in this mock
-		TotalCount:    int32(len(mockResults)),
-	}, nil
+	// Step 2: Convert gRPC request to XML (page token → Bacen cursor)
+	xmlData, err := xml.SearchEntriesRequestToXML(req)
+	if err != nil {
+		if errors.Is(err, xml.ErrInvalidPageToken) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		s.logger.WithError(err).Error("Failed to convert request to XML")
+		return nil, status.Errorf(codes.Internal, "failed to convert request to XML: %v", err)
+	}
+
+	// Steps 3-6: Sign, send to Bacen and parse the SOAP response
+	bodyXML, err := s.callBacen(ctx, endpointSearchEntries, xmlData)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 7: Convert XML response to gRPC response
+	response, err := xml.SearchEntriesResponseFromXML(bodyXML, req)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to convert XML response to gRPC")
+		return nil, status.Errorf(codes.Internal, "failed to convert response: %v", err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"entries":    len(response.Entries),
+		"totalCount": response.TotalCount,
+		"hasMore":    response.NextPageToken != "",
+	}).Info("SearchEntries completed successfully")
+
+	return response, nil
+}
+
+// callBacen signs the request XML, wraps it in a SOAP envelope, sends it to Bacen
+// and returns the SOAP body of the response (errors are already gRPC statuses)
+func (s *Server) callBacen(ctx context.Context, endpoint string, xmlData []byte) ([]byte, error) {
+	// Sign XML with ICP-Brasil A3
+	signedXML, err := s.xmlSigner.SignXML(ctx, string(xmlData))
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to sign XML")
+		return nil, status.Errorf(codes.Internal, "failed to sign XML: %v", err)
+	}
+
+	// Build SOAP envelope
+	soapEnvelope, err := s.soapClient.BuildSOAPEnvelope(signedXML, "")
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to build SOAP envelope")
+		return nil, status.Errorf(codes.Internal, "failed to build SOAP envelope: %v", err)
+	}
+
+	// Send SOAP request to Bacen
+	soapResponse, err := s.soapClient.SendSOAPRequest(ctx, endpoint, soapEnvelope)
+	if err != nil {
+		return nil, s.bacenRequestError(ctx, err, "failed to query Bacen")
+	}
+
+	// Parse SOAP response
+	bodyXML, err := s.soapClient.ParseSOAPResponse(soapResponse)
+	if err != nil {
+		return nil, s.soapResponseError(err)
+	}
+
+	return bodyXML, nil
 }
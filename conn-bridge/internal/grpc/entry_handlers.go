@@ -72,8 +72,7 @@ func (s *Server) CreateEntry(ctx context.Context, req *pb.CreateEntryRequest) (*
 	// Step 5: Send SOAP request to Bacen via HTTPS + mTLS
 	soapResponse, err := s.soapClient.SendSOAPRequest(ctx, endpointCreateEntry, soapEnvelope)
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to send SOAP request to Bacen")
-		return nil, status.Errorf(codes.Unavailable, "failed to send request to Bacen: %v", err)
+		return nil, s.bacenRequestError(ctx, err, "failed to send request to Bacen")
 	}
 
 	s.logger.WithFields(logrus.Fields{
@@ -83,8 +82,7 @@ func (s *Server) CreateEntry(ctx context.Context, req *pb.CreateEntryRequest) (*
 	// Step 6: Parse SOAP response
 	bodyXML, err := s.soapClient.ParseSOAPResponse(soapResponse)
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to parse SOAP response")
-		return nil, status.Errorf(codes.Internal, "failed to parse SOAP response: %v", err)
+		return nil, s.soapResponseError(err)
 	}
 
 	// Step 7: Convert XML response to gRPC response
@@ -145,8 +143,7 @@ func (s *Server) GetEntry(ctx context.Context, req *pb.GetEntryRequest) (*pb.Get
 	// Step 5: Send SOAP request to Bacen
 	soapResponse, err := s.soapClient.SendSOAPRequest(ctx, endpointGetEntry, soapEnvelope)
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to send SOAP request to Bacen")
-		return nil, status.Errorf(codes.Unavailable, "failed to query Bacen: %v", err)
+		return nil, s.bacenRequestError(ctx, err, "failed to query Bacen")
 	}
 
 	s.logger.Debug("Received SOAP response from Bacen")
@@ -154,8 +151,7 @@ func (s *Server) GetEntry(ctx context.Context, req *pb.GetEntryRequest) (*pb.Get
 	// Step 6: Parse SOAP response
 	bodyXML, err := s.soapClient.ParseSOAPResponse(soapResponse)
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to parse SOAP response")
-		return nil, status.Errorf(codes.Internal, "failed to parse SOAP response: %v", err)
+		return nil, s.soapResponseError(err)
 	}
 
 	// Step 7: Convert XML response to gRPC response
@@ -220,8 +216,7 @@ func (s *Server) UpdateEntry(ctx context.Context, req *pb.UpdateEntryRequest) (*
 	// Step 5: Send SOAP request to Bacen
 	soapResponse, err := s.soapClient.SendSOAPRequest(ctx, endpointUpdateEntry, soapEnvelope)
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to send SOAP request to Bacen")
-		return nil, status.Errorf(codes.Unavailable, "failed to update entry in Bacen: %v", err)
+		return nil, s.bacenRequestError(ctx, err, "failed to update entry in Bacen")
 	}
 
 	s.logger.Debug("Received SOAP response from Bacen")
@@ -229,8 +224,7 @@ func (s *Server) UpdateEntry(ctx context.Context, req *pb.UpdateEntryRequest) (*
 	// Step 6: Parse SOAP response
 	bodyXML, err := s.soapClient.ParseSOAPResponse(soapResponse)
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to parse SOAP response")
-		return nil, status.Errorf(codes.Internal, "failed to parse SOAP response: %v", err)
+		return nil, s.soapResponseError(err)
 	}
 
 	// Step 7: Convert XML response to gRPC response
@@ -291,8 +285,7 @@ func (s *Server) DeleteEntry(ctx context.Context, req *pb.DeleteEntryRequest) (*
 	// Step 5: Send SOAP request to Bacen
 	soapResponse, err := s.soapClient.SendSOAPRequest(ctx, endpointDeleteEntry, soapEnvelope)
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to send SOAP request to Bacen")
-		return nil, status.Errorf(codes.Unavailable, "failed to delete entry in Bacen: %v", err)
+		return nil, s.bacenRequestError(ctx, err, "failed to delete entry in Bacen")
 	}
 
 	s.logger.Debug("Received SOAP response from Bacen")
@@ -300,8 +293,7 @@ func (s *Server) DeleteEntry(ctx context.Context, req *pb.DeleteEntryRequest) (*
 	// Step 6: Parse SOAP response
 	bodyXML, err := s.soapClient.ParseSOAPResponse(soapResponse)
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to parse SOAP response")
-		return nil, status.Errorf(codes.Internal, "failed to parse SOAP response: %v", err)
+		return nil, s.soapResponseError(err)
 	}
 
 	// Step 7: Convert XML response to gRPC response
@@ -192,38 +192,14 @@ func (s *Server) determineOverallHealth(
 	return pb.HealthStatus_HEALTH_STATUS_DEGRADED
 }
 
-// CheckXMLSignerHealth checks if the XML Signer service is available
+// CheckXMLSignerHealth checks if the XML Signer (Java sidecar or native) is available
 // This is an internal helper method (not exposed via gRPC)
 func (s *Server) CheckXMLSignerHealth(ctx context.Context) bool {
-	xmlSignerURL := os.Getenv("XML_SIGNER_URL")
-	if xmlSignerURL == "" {
-		xmlSignerURL = "http://localhost:8081" // Default URL
-	}
-
-	healthURL := fmt.Sprintf("%s/health", xmlSignerURL)
-
-	client := &http.Client{
-		Timeout: 3 * time.Second,
-	}
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, healthURL, nil)
-	if err != nil {
-		s.logger.Errorf("Failed to create XML Signer health check request: %v", err)
-		return false
-	}
-
-	resp, err := client.Do(req)
-	if err != nil {
+	if err := s.xmlSigner.HealthCheck(ctx); err != nil {
 		s.logger.Errorf("XML Signer health check failed: %v", err)
 		return false
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode == http.StatusOK {
-		s.logger.Debug("XML Signer health check OK")
-		return true
-	}
 
-	s.logger.Errorf("XML Signer health check failed: status=%d", resp.StatusCode)
-	return false
+	s.logger.Debug("XML Signer health check OK")
+	return true
 }
@@ -0,0 +1,323 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lbpay-lab/conn-bridge/internal/xml"
+	pb "github.com/lbpay-lab/dict-contracts/gen/proto/bridge/v1"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Bacen DICT infraction report endpoints
+	endpointCreateInfractionReport      = "/api/v1/dict/infraction-reports"
+	endpointGetInfractionReport         = "/api/v1/dict/infraction-reports"
+	endpointListInfractionReports       = "/api/v1/dict/infraction-reports/list"
+	endpointAcknowledgeInfractionReport = "/api/v1/dict/infraction-reports/acknowledge"
+	endpointCloseInfractionReport       = "/api/v1/dict/infraction-reports/close"
+	endpointCancelInfractionReport      = "/api/v1/dict/infraction-reports/cancel"
+
+	defaultInfractionPageSize = 100
+	maxInfractionPageSize     = 1000
+)
+
+// CreateInfractionReport handles the CreateInfractionReport RPC call
+// Reports a suspicious PIX transaction (by EndToEndId) to Bacen
+// Flow: gRPC Request → XML → Sign XML → SOAP Envelope → mTLS POST → Parse Response → gRPC Response
+func (s *Server) CreateInfractionReport(ctx context.Context, req *pb.CreateInfractionReportRequest) (*pb.CreateInfractionReportResponse, error) {
+	s.logger.WithFields(logrus.Fields{
+		"requestId":     req.RequestId,
+		"participant":   req.ParticipantIspb,
+		"transactionId": req.TransactionId,
+		"reason":        req.Reason,
+		"situationType": req.SituationType,
+	}).Info("CreateInfractionReport called")
+
+	// Step 1: Validate request
+	if err := s.validateCreateInfractionReportRequest(req); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "validation failed: %v", err)
+	}
+
+	// Step 2: Convert gRPC request to XML
+	xmlData, err := xml.CreateInfractionReportRequestToXML(req)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to convert request to XML")
+		return nil, status.Errorf(codes.Internal, "failed to convert request to XML: %v", err)
+	}
+
+	// Steps 3-6: Sign, send to Bacen and parse the SOAP response
+	bodyXML, err := s.callBacen(ctx, endpointCreateInfractionReport, xmlData)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 7: Convert XML response to gRPC response
+	response, err := xml.CreateInfractionReportResponseFromXML(bodyXML)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to convert XML response to gRPC")
+		return nil, status.Errorf(codes.Internal, "failed to convert response: %v", err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"infractionReportId": response.InfractionReport.GetInfractionReportId(),
+		"status":             response.InfractionReport.GetStatus(),
+	}).Info("CreateInfractionReport completed successfully")
+
+	return response, nil
+}
+
+// GetInfractionReport handles the GetInfractionReport RPC call
+// Retrieves the current status of an infraction report from Bacen
+func (s *Server) GetInfractionReport(ctx context.Context, req *pb.GetInfractionReportRequest) (*pb.GetInfractionReportResponse, error) {
+	s.logger.WithFields(logrus.Fields{
+		"requestId":          req.RequestId,
+		"infractionReportId": req.InfractionReportId,
+	}).Info("GetInfractionReport called")
+
+	// Step 1: Validate request
+	if req.InfractionReportId == "" {
+		return nil, status.Error(codes.InvalidArgument, "infraction_report_id is required")
+	}
+
+	// Step 2: Convert gRPC request to XML
+	xmlData, err := xml.GetInfractionReportRequestToXML(req)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to convert request to XML")
+		return nil, status.Errorf(codes.Internal, "failed to convert request to XML: %v", err)
+	}
+
+	// Steps 3-6: Sign, send to Bacen and parse the SOAP response
+	bodyXML, err := s.callBacen(ctx, endpointGetInfractionReport, xmlData)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 7: Convert XML response to gRPC response
+	response, err := xml.GetInfractionReportResponseFromXML(bodyXML)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to convert XML response to gRPC")
+		return nil, status.Errorf(codes.Internal, "failed to convert response: %v", err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"infractionReportId": req.InfractionReportId,
+		"found":              response.Found,
+		"status":             response.InfractionReport.GetStatus(),
+	}).Info("GetInfractionReport completed successfully")
+
+	return response, nil
+}
+
+// ListInfractionReports handles the ListInfractionReports RPC call
+// Lists the infraction reports in which the participant is reporter or reported party
+func (s *Server) ListInfractionReports(ctx context.Context, req *pb.ListInfractionReportsRequest) (*pb.ListInfractionReportsResponse, error) {
+	s.logger.WithFields(logrus.Fields{
+		"requestId":   req.RequestId,
+		"participant": req.ParticipantIspb,
+		"status":      req.GetStatus(),
+		"pageSize":    req.PageSize,
+	}).Info("ListInfractionReports called")
+
+	// Step 1: Validate request
+	if req.ParticipantIspb == "" {
+		return nil, status.Error(codes.InvalidArgument, "participant_ispb is required")
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = defaultInfractionPageSize
+	}
+	if req.PageSize > maxInfractionPageSize {
+		return nil, status.Error(codes.InvalidArgument, "page_size cannot exceed 1000")
+	}
+
+	// Step 2: Convert gRPC request to XML (page token → Bacen cursor)
+	xmlData, err := xml.ListInfractionReportsRequestToXML(req)
+	if err != nil {
+		if errors.Is(err, xml.ErrInvalidPageToken) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		s.logger.WithError(err).Error("Failed to convert request to XML")
+		return nil, status.Errorf(codes.Internal, "failed to convert request to XML: %v", err)
+	}
+
+	// Steps 3-6: Sign, send to Bacen and parse the SOAP response
+	bodyXML, err := s.callBacen(ctx, endpointListInfractionReports, xmlData)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 7: Convert XML response to gRPC response
+	response, err := xml.ListInfractionReportsResponseFromXML(bodyXML, req)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to convert XML response to gRPC")
+		return nil, status.Errorf(codes.Internal, "failed to convert response: %v", err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"infractionReports": len(response.InfractionReports),
+		"hasMore":           response.NextPageToken != "",
+	}).Info("ListInfractionReports completed successfully")
+
+	return response, nil
+}
+
+// AcknowledgeInfractionReport handles the AcknowledgeInfractionReport RPC call
+// The reported participant confirms it received the infraction report (OPEN → ACKNOWLEDGED)
+func (s *Server) AcknowledgeInfractionReport(ctx context.Context, req *pb.AcknowledgeInfractionReportRequest) (*pb.AcknowledgeInfractionReportResponse, error) {
+	s.logger.WithFields(logrus.Fields{
+		"requestId":          req.RequestId,
+		"infractionReportId": req.InfractionReportId,
+		"participant":        req.ParticipantIspb,
+	}).Info("AcknowledgeInfractionReport called")
+
+	// Step 1: Validate request
+	if err := validateInfractionReportTarget(req.InfractionReportId, req.ParticipantIspb); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "validation failed: %v", err)
+	}
+
+	// Step 2: Convert gRPC request to XML
+	xmlData, err := xml.AcknowledgeInfractionReportRequestToXML(req)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to convert request to XML")
+		return nil, status.Errorf(codes.Internal, "failed to convert request to XML: %v", err)
+	}
+
+	// Steps 3-6: Sign, send to Bacen and parse the SOAP response
+	bodyXML, err := s.callBacen(ctx, endpointAcknowledgeInfractionReport, xmlData)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 7: Convert XML response to gRPC response
+	response, err := xml.AcknowledgeInfractionReportResponseFromXML(bodyXML)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to convert XML response to gRPC")
+		return nil, status.Errorf(codes.Internal, "failed to convert response: %v", err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"infractionReportId": req.InfractionReportId,
+		"status":             response.InfractionReport.GetStatus(),
+	}).Info("AcknowledgeInfractionReport completed successfully")
+
+	return response, nil
+}
+
+// CloseInfractionReport handles the CloseInfractionReport RPC call
+// The reported participant closes the infraction report with the result of its analysis
+func (s *Server) CloseInfractionReport(ctx context.Context, req *pb.CloseInfractionReportRequest) (*pb.CloseInfractionReportResponse, error) {
+	s.logger.WithFields(logrus.Fields{
+		"requestId":          req.RequestId,
+		"infractionReportId": req.InfractionReportId,
+		"participant":        req.ParticipantIspb,
+		"analysisResult":     req.AnalysisResult,
+	}).Info("CloseInfractionReport called")
+
+	// Step 1: Validate request
+	if err := validateInfractionReportTarget(req.InfractionReportId, req.ParticipantIspb); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "validation failed: %v", err)
+	}
+	if req.AnalysisResult == pb.InfractionAnalysisResult_INFRACTION_ANALYSIS_RESULT_UNSPECIFIED {
+		return nil, status.Error(codes.InvalidArgument, "validation failed: analysis_result is required")
+	}
+
+	// Step 2: Convert gRPC request to XML
+	xmlData, err := xml.CloseInfractionReportRequestToXML(req)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to convert request to XML")
+		return nil, status.Errorf(codes.Internal, "failed to convert request to XML: %v", err)
+	}
+
+	// Steps 3-6: Sign, send to Bacen and parse the SOAP response
+	bodyXML, err := s.callBacen(ctx, endpointCloseInfractionReport, xmlData)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 7: Convert XML response to gRPC response
+	response, err := xml.CloseInfractionReportResponseFromXML(bodyXML)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to convert XML response to gRPC")
+		return nil, status.Errorf(codes.Internal, "failed to convert response: %v", err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"infractionReportId": req.InfractionReportId,
+		"status":             response.InfractionReport.GetStatus(),
+	}).Info("CloseInfractionReport completed successfully")
+
+	return response, nil
+}
+
+// CancelInfractionReport handles the CancelInfractionReport RPC call
+// The reporting participant withdraws an infraction report that is not closed yet
+func (s *Server) CancelInfractionReport(ctx context.Context, req *pb.CancelInfractionReportRequest) (*pb.CancelInfractionReportResponse, error) {
+	s.logger.WithFields(logrus.Fields{
+		"requestId":          req.RequestId,
+		"infractionReportId": req.InfractionReportId,
+		"participant":        req.ParticipantIspb,
+	}).Info("CancelInfractionReport called")
+
+	// Step 1: Validate request
+	if err := validateInfractionReportTarget(req.InfractionReportId, req.ParticipantIspb); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "validation failed: %v", err)
+	}
+
+	// Step 2: Convert gRPC request to XML
+	xmlData, err := xml.CancelInfractionReportRequestToXML(req)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to convert request to XML")
+		return nil, status.Errorf(codes.Internal, "failed to convert request to XML: %v", err)
+	}
+
+	// Steps 3-6: Sign, send to Bacen and parse the SOAP response
+	bodyXML, err := s.callBacen(ctx, endpointCancelInfractionReport, xmlData)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 7: Convert XML response to gRPC response
+	response, err := xml.CancelInfractionReportResponseFromXML(bodyXML)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to convert XML response to gRPC")
+		return nil, status.Errorf(codes.Internal, "failed to convert response: %v", err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"infractionReportId": req.InfractionReportId,
+		"status":             response.InfractionReport.GetStatus(),
+	}).Info("CancelInfractionReport completed successfully")
+
+	return response, nil
+}
+
+// validateCreateInfractionReportRequest validates CreateInfractionReport request
+func (s *Server) validateCreateInfractionReportRequest(req *pb.CreateInfractionReportRequest) error {
+	if req.ParticipantIspb == "" {
+		return fmt.Errorf("participant_ispb is required")
+	}
+	if req.TransactionId == "" {
+		return fmt.Errorf("transaction_id is required")
+	}
+	if req.Reason == pb.InfractionReportReason_INFRACTION_REPORT_REASON_UNSPECIFIED {
+		return fmt.Errorf("reason is required")
+	}
+	if req.SituationType == pb.InfractionSituationType_INFRACTION_SITUATION_TYPE_UNSPECIFIED {
+		return fmt.Errorf("situation_type is required")
+	}
+	return nil
+}
+
+// validateInfractionReportTarget validates the report ID and participant of Acknowledge/Close/Cancel
+func validateInfractionReportTarget(infractionReportID, participantISPB string) error {
+	if infractionReportID == "" {
+		return fmt.Errorf("infraction_report_id is required")
+	}
+	if participantISPB == "" {
+		return fmt.Errorf("participant_ispb is required")
+	}
+	return nil
+}
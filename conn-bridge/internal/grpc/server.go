@@ -2,15 +2,33 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"net"
+	"net/http"
 
+	"github.com/lbpay-lab/conn-bridge/internal/infrastructure/bacen"
+	"github.com/lbpay-lab/conn-bridge/internal/infrastructure/signer"
 	pb "github.com/lbpay-lab/dict-contracts/gen/proto/bridge/v1"
+	commonv1 "github.com/lbpay-lab/dict-contracts/gen/proto/common/v1"
+	"github.com/lbpay-lab/dict-contracts/idempotency"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// bacenErrorDomain is the google.rpc.ErrorInfo domain of Bacen rejections
+	bacenErrorDomain = "dict.pi.rsfn.net.br"
+	// bridgeErrorDomain is the google.rpc.ErrorInfo domain of conn-bridge's own rejections
+	bridgeErrorDomain = "conn-bridge.lbpay.com.br"
 )
 
 // Server implements the Bridge gRPC server
@@ -21,6 +39,25 @@ type Server struct {
 	port        int
 	soapClient  SOAPClient
 	xmlSigner   XMLSigner
+	idempotency *idempotency.Interceptor
+}
+
+// idempotentMethods are the mutating BridgeService RPCs covered by request_id idempotency
+var idempotentMethods = []string{
+	pb.BridgeService_CreateEntry_FullMethodName,
+	pb.BridgeService_DeleteEntry_FullMethodName,
+	pb.BridgeService_UpdateEntry_FullMethodName,
+	pb.BridgeService_CreateClaim_FullMethodName,
+	pb.BridgeService_ConfirmClaim_FullMethodName,
+	pb.BridgeService_CompleteClaim_FullMethodName,
+	pb.BridgeService_CancelClaim_FullMethodName,
+	pb.BridgeService_InitiatePortability_FullMethodName,
+	pb.BridgeService_ConfirmPortability_FullMethodName,
+	pb.BridgeService_CancelPortability_FullMethodName,
+	pb.BridgeService_CreateInfractionReport_FullMethodName,
+	pb.BridgeService_AcknowledgeInfractionReport_FullMethodName,
+	pb.BridgeService_CloseInfractionReport_FullMethodName,
+	pb.BridgeService_CancelInfractionReport_FullMethodName,
 }
 
 // SOAPClient defines the interface for SOAP operations
@@ -47,6 +84,16 @@ func NewServer(logger *logrus.Logger, port int, soapClient SOAPClient, xmlSigner
 	}
 }
 
+// EnableIdempotency makes the mutating RPCs idempotent by their request_id field (call before
+// Start). A retried request is answered from store instead of being sent to Bacen again.
+func (s *Server) EnableIdempotency(store idempotency.Store, config idempotency.Config) {
+	if config.ErrorDomain == "" {
+		config.ErrorDomain = bridgeErrorDomain
+	}
+	logger := slog.New(slog.NewJSONHandler(s.logger.Out, nil))
+	s.idempotency = idempotency.NewInterceptor(store, idempotentMethods, config, logger)
+}
+
 // Start initializes and starts the gRPC server
 func (s *Server) Start() error {
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.port))
@@ -55,11 +102,15 @@ func (s *Server) Start() error {
 	}
 
 	// Create gRPC server with interceptors
+	interceptors := []grpc.UnaryServerInterceptor{
+		s.loggingInterceptor,
+		s.metricsInterceptor,
+	}
+	if s.idempotency != nil {
+		interceptors = append(interceptors, s.idempotency.Unary())
+	}
 	s.grpcServer = grpc.NewServer(
-		grpc.ChainUnaryInterceptor(
-			s.loggingInterceptor,
-			s.metricsInterceptor,
-		),
+		grpc.ChainUnaryInterceptor(interceptors...),
 	)
 
 	// Register Bridge service
@@ -106,6 +157,84 @@ func (s *Server) loggingInterceptor(
 	return resp, err
 }
 
+// soapResponseError maps a ParseSOAPResponse failure to a gRPC status.
+// Bacen responses with a missing, invalid or untrusted signature fail with DataLoss
+// so callers can tell them apart from transport and parsing errors.
+func (s *Server) soapResponseError(err error) error {
+	if errors.Is(err, signer.ErrMissingSignature) ||
+		errors.Is(err, signer.ErrInvalidSignature) ||
+		errors.Is(err, signer.ErrUntrustedCertificate) {
+		s.logger.WithError(err).Error("Rejected Bacen response: signature verification failed")
+		return status.Errorf(codes.DataLoss, "bacen response signature verification failed: %v", err)
+	}
+
+	s.logger.WithError(err).Error("Failed to parse SOAP response")
+	return status.Errorf(codes.Internal, "failed to parse SOAP response: %v", err)
+}
+
+// bacenRequestError maps a SendSOAPRequest failure to a gRPC status.
+// Bacen rejections (SOAP Faults answering a request Bacen processed) keep their reason
+// code in google.rpc.ErrorInfo and a common.v1.BacenError detail, so Connect and Core
+// can react to the code instead of parsing messages. Anything else is Unavailable.
+func (s *Server) bacenRequestError(ctx context.Context, err error, action string) error {
+	fault, ok := bacen.AsFaultError(err)
+	if !ok {
+		s.logger.WithError(err).Error("Failed to send SOAP request to Bacen")
+		return status.Errorf(codes.Unavailable, "%s: %v", action, err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"bacenCode":  fault.Code,
+		"httpStatus": fault.HTTPStatus,
+	}).Warn("Request rejected by Bacen")
+
+	requestID, _ := ctx.Value("correlationID").(string)
+	st, detailErr := status.New(bacenFaultGRPCCode(fault), fault.Reason).WithDetails(
+		&errdetails.ErrorInfo{
+			Reason: fault.Code,
+			Domain: bacenErrorDomain,
+			Metadata: map[string]string{
+				"http_status": fmt.Sprintf("%d", fault.HTTPStatus),
+			},
+		},
+		&commonv1.BacenError{
+			BacenCode:      fault.Code,
+			BacenMessage:   fault.Reason,
+			BacenRequestId: requestID,
+			OccurredAt:     timestamppb.Now(),
+			HttpStatus:     int32(fault.HTTPStatus),
+		},
+	)
+	if detailErr != nil {
+		return status.Errorf(bacenFaultGRPCCode(fault), "%s: %v", action, fault)
+	}
+	return st.Err()
+}
+
+// bacenFaultGRPCCode picks the gRPC code of a Bacen rejection from its reason code,
+// falling back to the HTTP status of the fault
+func bacenFaultGRPCCode(fault *bacen.FaultError) codes.Code {
+	switch fault.Code {
+	case bacen.ReasonEntryLimitExceeded:
+		return codes.ResourceExhausted
+	case bacen.ReasonEntryLockedByClaim, bacen.ReasonInvalidClaimStatus, bacen.ReasonInvalidInfractionReportStatus:
+		return codes.FailedPrecondition
+	}
+
+	switch fault.HTTPStatus {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	default:
+		return codes.FailedPrecondition
+	}
+}
+
 // metricsInterceptor collects metrics for requests
 func (s *Server) metricsInterceptor(
 	ctx context.Context,
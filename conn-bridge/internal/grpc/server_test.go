@@ -1,18 +1,24 @@
 package grpc
 
 import (
+	"context"
+	"sync"
 	"testing"
+	"time"
 
+	pb "github.com/lbpay-lab/dict-contracts/gen/proto/bridge/v1"
+	"github.com/lbpay-lab/dict-contracts/idempotency"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 )
 
 func TestNewServer(t *testing.T) {
 	logger := logrus.New()
 	port := 9094
 
-	server := NewServer(logger, port)
+	server := NewServer(logger, port, nil, nil)
 
 	require.NotNil(t, server)
 	assert.Equal(t, port, server.port)
@@ -21,7 +27,7 @@ func TestNewServer(t *testing.T) {
 
 func TestServer_ValidateCreateEntryRequest(t *testing.T) {
 	logger := logrus.New()
-	_ = NewServer(logger, 9094) // Server used for validation logic tests
+	_ = NewServer(logger, 9094, nil, nil) // Server used for validation logic tests
 
 	tests := []struct {
 		name    string
@@ -86,4 +92,57 @@ func TestServer_ValidateCreateEntryRequest(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+// memoryIdempotencyStore is an in-memory idempotency table
+type memoryIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]idempotency.Record
+}
+
+func (s *memoryIdempotencyStore) Reserve(_ context.Context, record *idempotency.Record) (*idempotency.Record, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.records[record.Key]; ok && existing.Live(time.Now()) {
+		return &existing, false, nil
+	}
+	s.records[record.Key] = *record
+	return record, true, nil
+}
+
+func (s *memoryIdempotencyStore) Complete(_ context.Context, record *idempotency.Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[record.Key] = *record
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, key)
+	return nil
+}
+
+func TestIdempotency_ConfirmClaimRetryIsReplayed(t *testing.T) {
+	soapClient := &fakeSOAPClient{body: `<ConfirmClaimResponse><CorrelationId>corr-1</CorrelationId>
+<Claim><ClaimId>bacen-claim-1</ClaimId><Status>CONFIRMED</Status></Claim></ConfirmClaimResponse>`}
+	server := newTestServer(soapClient)
+	server.EnableIdempotency(&memoryIdempotencyStore{records: make(map[string]idempotency.Record)}, idempotency.DefaultConfig())
+
+	calls := 0
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		calls++
+		return server.ConfirmClaim(ctx, req.(*pb.ConfirmClaimRequest))
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: pb.BridgeService_ConfirmClaim_FullMethodName}
+	req := &pb.ConfirmClaimRequest{ClaimId: "claim-1", ExternalId: "bacen-claim-1", RequestId: "claim-1:confirm"}
+
+	// The activity retries after a timeout with the same request_id
+	for i := 0; i < 2; i++ {
+		resp, err := server.idempotency.Unary()(context.Background(), req, info, handler)
+		require.NoError(t, err)
+		assert.Equal(t, "corr-1", resp.(*pb.ConfirmClaimResponse).BacenTransactionId)
+	}
+
+	assert.Equal(t, 1, calls, "the retry must not confirm the claim at Bacen again")
+}
@@ -0,0 +1,160 @@
+package grpc
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/lbpay-lab/conn-bridge/internal/xml"
+	pb "github.com/lbpay-lab/dict-contracts/gen/proto/bridge/v1"
+	commonv1 "github.com/lbpay-lab/dict-contracts/gen/proto/common/v1"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Bacen DICT sync verification endpoints
+	endpointVerifySync    = "/api/v1/dict/sync-verifications"
+	endpointListCids      = "/api/v1/dict/cids/list"
+	endpointGetEntryByCid = "/api/v1/dict/cids/entries"
+)
+
+// cidRegex matches a CID: hex-encoded HMAC-SHA256
+var cidRegex = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
+
+// VerifySync handles the VerifySync RPC call
+// Returns the VSync value Bacen computed for the participant's keys of one type
+// Flow: gRPC Request → XML → Sign XML → SOAP Envelope → mTLS POST → Parse Response → gRPC Response
+func (s *Server) VerifySync(ctx context.Context, req *pb.VerifySyncRequest) (*pb.VerifySyncResponse, error) {
+	s.logger.WithFields(logrus.Fields{
+		"requestId":   req.RequestId,
+		"participant": req.ParticipantIspb,
+		"keyType":     req.KeyType,
+	}).Info("VerifySync called")
+
+	// Step 1: Validate request
+	if err := validateSyncTarget(req.ParticipantIspb, req.KeyType); err != nil {
+		return nil, err
+	}
+
+	// Step 2: Convert gRPC request to XML
+	xmlData, err := xml.VerifySyncRequestToXML(req)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to convert request to XML")
+		return nil, status.Errorf(codes.Internal, "failed to convert request to XML: %v", err)
+	}
+
+	// Steps 3-6: Sign, send to Bacen and parse the SOAP response
+	bodyXML, err := s.callBacen(ctx, endpointVerifySync, xmlData)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 7: Convert XML response to gRPC response
+	response, err := xml.VerifySyncResponseFromXML(bodyXML)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to convert XML response to gRPC")
+		return nil, status.Errorf(codes.Internal, "failed to convert response: %v", err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"keyType":    response.KeyType,
+		"entryCount": response.EntryCount,
+	}).Info("VerifySync completed successfully")
+
+	return response, nil
+}
+
+// ListCids handles the ListCids RPC call
+// Lists the CIDs of the participant's keys of one type, sorted
+// Flow: gRPC Request → XML → Sign XML → SOAP Envelope → mTLS POST → Parse Response → gRPC Response
+func (s *Server) ListCids(ctx context.Context, req *pb.ListCidsRequest) (*pb.ListCidsResponse, error) {
+	s.logger.WithFields(logrus.Fields{
+		"requestId":   req.RequestId,
+		"participant": req.ParticipantIspb,
+		"keyType":     req.KeyType,
+	}).Info("ListCids called")
+
+	// Step 1: Validate request
+	if err := validateSyncTarget(req.ParticipantIspb, req.KeyType); err != nil {
+		return nil, err
+	}
+
+	// Step 2: Convert gRPC request to XML
+	xmlData, err := xml.ListCidsRequestToXML(req)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to convert request to XML")
+		return nil, status.Errorf(codes.Internal, "failed to convert request to XML: %v", err)
+	}
+
+	// Steps 3-6: Sign, send to Bacen and parse the SOAP response
+	bodyXML, err := s.callBacen(ctx, endpointListCids, xmlData)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 7: Convert XML response to gRPC response
+	response, err := xml.ListCidsResponseFromXML(bodyXML)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to convert XML response to gRPC")
+		return nil, status.Errorf(codes.Internal, "failed to convert response: %v", err)
+	}
+
+	s.logger.WithField("cids", len(response.Cids)).Info("ListCids completed successfully")
+
+	return response, nil
+}
+
+// GetEntryByCid handles the GetEntryByCid RPC call
+// Returns the key Bacen holds under a CID, used to resolve CIDs that have no local match
+// Flow: gRPC Request → XML → Sign XML → SOAP Envelope → mTLS POST → Parse Response → gRPC Response
+func (s *Server) GetEntryByCid(ctx context.Context, req *pb.GetEntryByCidRequest) (*pb.GetEntryByCidResponse, error) {
+	s.logger.WithFields(logrus.Fields{
+		"requestId":   req.RequestId,
+		"participant": req.ParticipantIspb,
+		"cid":         req.Cid,
+	}).Info("GetEntryByCid called")
+
+	// Step 1: Validate request
+	if req.ParticipantIspb == "" {
+		return nil, status.Error(codes.InvalidArgument, "participant_ispb is required")
+	}
+	if !cidRegex.MatchString(req.Cid) {
+		return nil, status.Error(codes.InvalidArgument, "cid must be 64 hex characters")
+	}
+
+	// Step 2: Convert gRPC request to XML
+	xmlData, err := xml.GetEntryByCidRequestToXML(req)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to convert request to XML")
+		return nil, status.Errorf(codes.Internal, "failed to convert request to XML: %v", err)
+	}
+
+	// Steps 3-6: Sign, send to Bacen and parse the SOAP response
+	bodyXML, err := s.callBacen(ctx, endpointGetEntryByCid, xmlData)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 7: Convert XML response to gRPC response
+	response, err := xml.GetEntryByCidResponseFromXML(bodyXML)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to convert XML response to gRPC")
+		return nil, status.Errorf(codes.Internal, "failed to convert response: %v", err)
+	}
+
+	s.logger.WithField("keyType", response.Entry.GetKeyType()).Info("GetEntryByCid completed successfully")
+
+	return response, nil
+}
+
+// validateSyncTarget validates the participant and key type of a VSync request
+func validateSyncTarget(participantISPB string, keyType commonv1.KeyType) error {
+	if participantISPB == "" {
+		return status.Error(codes.InvalidArgument, "participant_ispb is required")
+	}
+	if keyType == commonv1.KeyType_KEY_TYPE_UNSPECIFIED {
+		return status.Error(codes.InvalidArgument, "key_type is required")
+	}
+	return nil
+}
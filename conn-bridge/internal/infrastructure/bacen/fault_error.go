@@ -0,0 +1,77 @@
+package bacen
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Bacen DICT reason codes that need special handling by the bridge.
+// Any other code is carried through as-is.
+const (
+	ReasonEntryLimitExceeded            = "EntryLimitExceeded"
+	ReasonEntryLockedByClaim            = "EntryLockedByClaim"
+	ReasonInvalidClaimStatus            = "InvalidClaimStatus"
+	ReasonInvalidInfractionReportStatus = "InvalidInfractionReportStatus"
+)
+
+// FaultError is a SOAP Fault returned by Bacen DICT.
+// Code is the Bacen reason code sent in the fault Detail (e.g. "EntryAlreadyExists").
+type FaultError struct {
+	HTTPStatus int
+	FaultCode  string // SOAP fault code (soap:Sender or soap:Receiver)
+	Code       string
+	Reason     string
+}
+
+func (e *FaultError) Error() string {
+	return fmt.Sprintf("SOAP Fault (HTTP %d): %s - %s (Detail: %s)", e.HTTPStatus, e.FaultCode, e.Reason, e.Code)
+}
+
+// IsRejection reports whether Bacen processed and refused the request (a business error).
+// Rejections must not be retried nor counted as failures by the circuit breaker.
+func (e *FaultError) IsRejection() bool {
+	if e.HTTPStatus >= 400 && e.HTTPStatus < 500 {
+		return e.HTTPStatus != http.StatusRequestTimeout && e.HTTPStatus != http.StatusTooManyRequests
+	}
+	return e.HTTPStatus < 400 && strings.HasSuffix(e.FaultCode, "Sender")
+}
+
+// AsFaultError extracts a Bacen rejection from err
+func AsFaultError(err error) (*FaultError, bool) {
+	var fault *FaultError
+	if errors.As(err, &fault) && fault.IsRejection() {
+		return fault, true
+	}
+	return nil, false
+}
+
+// parseSOAPFault returns the fault carried by body, or nil when body isn't a SOAP Fault
+func parseSOAPFault(statusCode int, body []byte) *FaultError {
+	var faultResp SOAPFaultResponse
+	if err := xml.Unmarshal(body, &faultResp); err != nil {
+		return nil
+	}
+	if faultResp.Body.Fault.Code == "" {
+		return nil
+	}
+
+	return &FaultError{
+		HTTPStatus: statusCode,
+		FaultCode:  strings.TrimSpace(faultResp.Body.Fault.Code),
+		Code:       strings.TrimSpace(faultResp.Body.Fault.Detail),
+		Reason:     strings.TrimSpace(faultResp.Body.Fault.Reason),
+	}
+}
+
+// isSuccessfulForBreaker keeps Bacen rejections from tripping the circuit breaker:
+// a run of duplicate keys says nothing about the health of Bacen
+func isSuccessfulForBreaker(err error) bool {
+	if err == nil {
+		return true
+	}
+	_, ok := AsFaultError(err)
+	return ok
+}
@@ -6,6 +6,7 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -58,6 +59,7 @@ type HTTPClient struct {
 	devMode     bool
 	logger      *logrus.Logger
 	maxRetries  int
+	verifier    ResponseVerifier
 }
 
 // Config holds the configuration for the HTTP client
@@ -71,6 +73,10 @@ type Config struct {
 	DevMode     bool
 	Logger      *logrus.Logger
 	MaxRetries  int
+
+	// TrustStorePath is the ICP-Brasil CA bundle (PEM file or directory) used to verify
+	// the signature of Bacen responses. Required unless DevMode is set.
+	TrustStorePath string
 }
 
 // NewHTTPClient creates a new Bacen HTTP client with mTLS support
@@ -98,6 +104,12 @@ func NewHTTPClient(config *Config) (interfaces.BacenClient, error) {
 		return nil, fmt.Errorf("failed to configure TLS: %w", err)
 	}
 
+	// Configure verification of Bacen response signatures
+	verifier, err := newResponseVerifier(config.TrustStorePath, config.DevMode, config.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure response signature verification: %w", err)
+	}
+
 	// Create HTTP transport with connection pooling and timeouts
 	transport := &http.Transport{
 		TLSClientConfig: tlsConfig,
@@ -130,6 +142,7 @@ func NewHTTPClient(config *Config) (interfaces.BacenClient, error) {
 		devMode:    config.DevMode,
 		logger:     config.Logger,
 		maxRetries: config.MaxRetries,
+		verifier:   verifier,
 	}, nil
 }
 
@@ -225,9 +238,9 @@ func (c *HTTPClient) CreateEntry(ctx context.Context, entry *entities.DictEntry)
 			return c.handleErrorResponse(resp)
 		}
 
-		body, err := io.ReadAll(resp.Body)
+		body, err := c.readVerifiedBody(resp)
 		if err != nil {
-			return fmt.Errorf("failed to read response body: %w", err)
+			return err
 		}
 
 		response = &xmlstructs.XMLCreateEntryResponse{}
@@ -293,9 +306,9 @@ func (c *HTTPClient) UpdateEntry(ctx context.Context, entry *entities.DictEntry)
 			return c.handleErrorResponse(resp)
 		}
 
-		body, err := io.ReadAll(resp.Body)
+		body, err := c.readVerifiedBody(resp)
 		if err != nil {
-			return fmt.Errorf("failed to read response body: %w", err)
+			return err
 		}
 
 		response = &xmlstructs.XMLUpdateEntryResponse{}
@@ -355,9 +368,9 @@ func (c *HTTPClient) DeleteEntry(ctx context.Context, keyID string, keyType enti
 			return c.handleErrorResponse(resp)
 		}
 
-		body, err := io.ReadAll(resp.Body)
+		body, err := c.readVerifiedBody(resp)
 		if err != nil {
-			return fmt.Errorf("failed to read response body: %w", err)
+			return err
 		}
 
 		response = &xmlstructs.XMLDeleteEntryResponse{}
@@ -418,9 +431,9 @@ func (c *HTTPClient) GetEntry(ctx context.Context, keyID string, keyType entitie
 			return c.handleErrorResponse(resp)
 		}
 
-		body, err := io.ReadAll(resp.Body)
+		body, err := c.readVerifiedBody(resp)
 		if err != nil {
-			return fmt.Errorf("failed to read response body: %w", err)
+			return err
 		}
 
 		response = &xmlstructs.XMLGetEntryResponse{}
@@ -464,9 +477,9 @@ func (c *HTTPClient) SendRequest(ctx context.Context, request *valueobjects.Bace
 			return c.handleErrorResponse(resp)
 		}
 
-		body, err := io.ReadAll(resp.Body)
+		body, err := c.readVerifiedBody(resp)
 		if err != nil {
-			return fmt.Errorf("failed to read response body: %w", err)
+			return err
 		}
 
 		response = valueobjects.NewBacenResponse(
@@ -572,6 +585,24 @@ func (c *HTTPClient) doRequest(ctx context.Context, method, endpoint string, bod
 	return resp, nil
 }
 
+// readVerifiedBody reads a successful response body and verifies its Bacen signature.
+// Signature failures wrap signer.ErrMissingSignature, signer.ErrInvalidSignature or
+// signer.ErrUntrustedCertificate and are not retried.
+func (c *HTTPClient) readVerifiedBody(resp *http.Response) ([]byte, error) {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if c.verifier != nil {
+		if _, err := c.verifier.VerifyResponse(body); err != nil {
+			return nil, &signatureError{err: err}
+		}
+	}
+
+	return body, nil
+}
+
 // retryWithBackoff implements exponential backoff retry logic
 func (c *HTTPClient) retryWithBackoff(ctx context.Context, fn func() error) error {
 	backoff := initialBackoff
@@ -587,8 +618,9 @@ func (c *HTTPClient) retryWithBackoff(ctx context.Context, fn func() error) erro
 			return ctx.Err()
 		}
 
-		// Don't retry on 4xx errors (client errors)
-		if isClientError(err) {
+		// Don't retry on 4xx errors (client errors) or untrusted responses
+		var sigErr *signatureError
+		if isClientError(err) || errors.As(err, &sigErr) {
 			return err
 		}
 
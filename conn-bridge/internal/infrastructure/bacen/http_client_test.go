@@ -3,14 +3,16 @@ package bacen
 import (
 	"context"
 	"crypto/tls"
-	"crypto/x509"
 	"encoding/xml"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/lbpay-lab/conn-bridge/internal/domain/entities"
+	"github.com/lbpay-lab/conn-bridge/internal/infrastructure/signer"
 	xmlstructs "github.com/lbpay-lab/conn-bridge/internal/xml"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -650,3 +652,72 @@ func TestAPIKeyHeader(t *testing.T) {
 
 	assert.Equal(t, "test-api-key-123", receivedAPIKey)
 }
+
+func TestGetEntry_VerifiesResponseSignature(t *testing.T) {
+	signerDir := filepath.Join("..", "signer", "testdata")
+	xmlSigner, err := signer.NewNativeXMLSigner(&signer.NativeConfig{
+		CertPath: filepath.Join(signerDir, "signer.p12"),
+		Password: "changeit",
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		sign    bool
+		wantErr error
+	}{
+		{name: "signed response", sign: true},
+		{name: "unsigned response", sign: false, wantErr: signer.ErrMissingSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int32
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&requests, 1)
+
+				body, err := xml.Marshal(&xmlstructs.XMLGetEntryResponse{
+					ResponseTime:  time.Now().Format(time.RFC3339),
+					CorrelationId: "get-correlation-id",
+				})
+				require.NoError(t, err)
+				if tt.sign {
+					signed, err := xmlSigner.SignXML(r.Context(), string(body))
+					require.NoError(t, err)
+					body = []byte(signed)
+				}
+
+				w.Header().Set("Content-Type", "application/xml")
+				w.WriteHeader(http.StatusOK)
+				w.Write(body)
+			}))
+			defer server.Close()
+
+			client, err := NewHTTPClient(&Config{
+				BaseURL:        server.URL,
+				DevMode:        true,
+				Logger:         logrus.New(),
+				TrustStorePath: filepath.Join(signerDir, "signer-cert.pem"),
+			})
+			require.NoError(t, err)
+
+			response, err := client.(*HTTPClient).GetEntry(context.Background(), "12345678901", entities.KeyTypeCPF)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, int32(1), atomic.LoadInt32(&requests), "signature failures are not retried")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "get-correlation-id", response.CorrelationId)
+		})
+	}
+}
+
+func TestNewHTTPClient_RequiresTrustStoreOutsideDevMode(t *testing.T) {
+	_, err := NewHTTPClient(&Config{
+		BaseURL:  "https://dict-hom.bcb.gov.br",
+		CertPath: filepath.Join("..", "signer", "testdata", "signer-cert.pem"),
+		KeyPath:  filepath.Join("..", "signer", "testdata", "signer-key.pem"),
+	})
+	assert.ErrorContains(t, err, "trust store path is required")
+}
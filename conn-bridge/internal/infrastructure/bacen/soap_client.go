@@ -13,6 +13,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/lbpay-lab/conn-bridge/internal/infrastructure/signer"
 	"github.com/sirupsen/logrus"
 	"github.com/sony/gobreaker"
 )
@@ -46,6 +47,12 @@ type SOAPClient struct {
 	devMode    bool
 	logger     *logrus.Logger
 	cb         *gobreaker.CircuitBreaker
+	verifier   ResponseVerifier
+}
+
+// ResponseVerifier verifies the XML-DSig signature of Bacen response bodies
+type ResponseVerifier interface {
+	VerifyResponse(xmlData []byte) (*x509.Certificate, error)
 }
 
 // SOAPClientConfig holds the configuration for the SOAP client
@@ -57,6 +64,10 @@ type SOAPClientConfig struct {
 	CAPath   string
 	DevMode  bool
 	Logger   *logrus.Logger
+
+	// TrustStorePath is the ICP-Brasil CA bundle (PEM file or directory) used to verify
+	// the signature of Bacen responses. Required unless DevMode is set.
+	TrustStorePath string
 }
 
 // SOAPEnvelope represents a SOAP 1.2 envelope
@@ -124,6 +135,12 @@ func NewSOAPClient(config *SOAPClientConfig) (*SOAPClient, error) {
 		return nil, fmt.Errorf("failed to configure TLS: %w", err)
 	}
 
+	// Configure verification of Bacen response signatures
+	verifier, err := newResponseVerifier(config.TrustStorePath, config.DevMode, config.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure response signature verification: %w", err)
+	}
+
 	// Create HTTP transport with connection pooling
 	transport := &http.Transport{
 		TLSClientConfig: tlsConfig,
@@ -155,6 +172,7 @@ func NewSOAPClient(config *SOAPClientConfig) (*SOAPClient, error) {
 			failureRatio := float64(counts.TotalFailures) / float64(counts.Requests)
 			return counts.Requests >= 5 && failureRatio >= 0.6
 		},
+		IsSuccessful: isSuccessfulForBreaker,
 		OnStateChange: func(name string, from gobreaker.State, to gobreaker.State) {
 			config.Logger.WithFields(logrus.Fields{
 				"circuitBreaker": name,
@@ -174,9 +192,44 @@ func NewSOAPClient(config *SOAPClientConfig) (*SOAPClient, error) {
 		devMode:    config.DevMode,
 		logger:     config.Logger,
 		cb:         gobreaker.NewCircuitBreaker(cbSettings),
+		verifier:   verifier,
 	}, nil
 }
 
+// newResponseVerifier loads the ICP-Brasil trust store for response signatures.
+// Without a trust store, responses are only accepted unverified in dev mode.
+func newResponseVerifier(trustStorePath string, devMode bool, logger *logrus.Logger) (ResponseVerifier, error) {
+	if trustStorePath == "" {
+		if devMode {
+			logger.Warn("Running in DEV MODE without trust store - Bacen response signatures are NOT verified")
+			return nil, nil
+		}
+		return nil, fmt.Errorf("trust store path is required to verify Bacen responses")
+	}
+
+	verifier, err := signer.NewResponseVerifier(&signer.ResponseVerifierConfig{
+		TrustStorePath: trustStorePath,
+		Logger:         logger,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return verifier, nil
+}
+
+// signatureError marks a response whose Bacen signature could not be verified
+type signatureError struct {
+	err error
+}
+
+func (e *signatureError) Error() string {
+	return fmt.Sprintf("bacen response signature verification failed: %v", e.err)
+}
+
+func (e *signatureError) Unwrap() error {
+	return e.err
+}
+
 // configureSOAPTLS sets up TLS configuration with mTLS support
 func configureSOAPTLS(config *SOAPClientConfig) (*tls.Config, error) {
 	tlsConfig := &tls.Config{
@@ -331,6 +384,8 @@ func (c *SOAPClient) doSOAPRequest(ctx context.Context, endpoint string, soapEnv
 }
 
 // ParseSOAPResponse extracts the body content from a SOAP envelope response
+// and verifies its Bacen signature. Signature failures wrap signer.ErrMissingSignature,
+// signer.ErrInvalidSignature or signer.ErrUntrustedCertificate.
 func (c *SOAPClient) ParseSOAPResponse(soapResponse []byte) ([]byte, error) {
 	var envelope struct {
 		XMLName xml.Name `xml:"Envelope"`
@@ -343,36 +398,45 @@ func (c *SOAPClient) ParseSOAPResponse(soapResponse []byte) ([]byte, error) {
 		return nil, fmt.Errorf("failed to parse SOAP envelope: %w", err)
 	}
 
+	if c.verifier != nil {
+		if _, err := c.verifier.VerifyResponse(envelope.Body.Content); err != nil {
+			return nil, fmt.Errorf("bacen response signature verification failed: %w", err)
+		}
+	}
+
 	return envelope.Body.Content, nil
 }
 
 // checkSOAPFault checks if the response contains a SOAP Fault
 func (c *SOAPClient) checkSOAPFault(body []byte) error {
-	var faultResp SOAPFaultResponse
-	if err := xml.Unmarshal(body, &faultResp); err != nil {
+	fault := parseSOAPFault(http.StatusOK, body)
+	if fault == nil {
 		// Not a fault response, this is ok
 		return nil
 	}
 
-	// Check if it contains a fault
-	if faultResp.Body.Fault.Code != "" {
-		c.logger.WithFields(logrus.Fields{
-			"faultCode":   faultResp.Body.Fault.Code,
-			"faultReason": faultResp.Body.Fault.Reason,
-			"faultDetail": faultResp.Body.Fault.Detail,
-		}).Error("SOAP Fault received")
-
-		return fmt.Errorf("SOAP Fault: %s - %s (Detail: %s)",
-			faultResp.Body.Fault.Code,
-			faultResp.Body.Fault.Reason,
-			faultResp.Body.Fault.Detail)
-	}
+	c.logger.WithFields(logrus.Fields{
+		"faultCode":   fault.FaultCode,
+		"faultReason": fault.Reason,
+		"faultDetail": fault.Code,
+	}).Error("SOAP Fault received")
 
-	return nil
+	return fault
 }
 
-// handleSOAPError processes SOAP error responses
+// handleSOAPError processes SOAP error responses.
+// Faults are returned as *FaultError so callers can tell Bacen rejections from outages.
 func (c *SOAPClient) handleSOAPError(statusCode int, body []byte) error {
+	if fault := parseSOAPFault(statusCode, body); fault != nil {
+		c.logger.WithFields(logrus.Fields{
+			"statusCode":  statusCode,
+			"faultCode":   fault.FaultCode,
+			"faultReason": fault.Reason,
+			"faultDetail": fault.Code,
+		}).Warn("SOAP request rejected by Bacen")
+		return fault
+	}
+
 	c.logger.WithFields(logrus.Fields{
 		"statusCode": statusCode,
 		"body":       string(body),
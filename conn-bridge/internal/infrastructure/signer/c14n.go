@@ -0,0 +1,380 @@
+package signer
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// XML namespaces used by the canonicalizer and the signature
+const (
+	nsXML   = "http://www.w3.org/XML/1998/namespace"
+	nsDSig  = "http://www.w3.org/2000/09/xmldsig#"
+	nsXMLNS = "xmlns"
+)
+
+// xmlDocument is a minimal DOM that keeps namespace prefixes as written,
+// which encoding/xml discards when resolving names
+type xmlDocument struct {
+	hasDeclaration bool
+	before         []xmlNode // processing instructions/comments before the root element
+	root           *xmlElement
+	after          []xmlNode // processing instructions/comments after the root element
+}
+
+// xmlNode is one of *xmlElement, xmlText, xmlComment or xmlProcInst
+type xmlNode interface{}
+
+type xmlText string
+
+type xmlComment string
+
+type xmlProcInst struct {
+	target string
+	inst   string
+}
+
+type xmlAttr struct {
+	prefix string
+	local  string
+	value  string
+}
+
+type xmlNSDecl struct {
+	prefix string // "" for the default namespace
+	uri    string
+}
+
+type xmlElement struct {
+	prefix   string
+	local    string
+	nsDecls  []xmlNSDecl
+	attrs    []xmlAttr
+	children []xmlNode
+}
+
+// parseXMLDocument parses an XML document keeping prefixes and namespace declarations
+func parseXMLDocument(data []byte) (*xmlDocument, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	doc := &xmlDocument{}
+
+	var stack []*xmlElement
+	for {
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse XML: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			elem := &xmlElement{prefix: t.Name.Space, local: t.Name.Local}
+			for _, attr := range t.Attr {
+				switch {
+				case attr.Name.Space == "" && attr.Name.Local == nsXMLNS:
+					elem.nsDecls = append(elem.nsDecls, xmlNSDecl{prefix: "", uri: attr.Value})
+				case attr.Name.Space == nsXMLNS:
+					elem.nsDecls = append(elem.nsDecls, xmlNSDecl{prefix: attr.Name.Local, uri: attr.Value})
+				default:
+					elem.attrs = append(elem.attrs, xmlAttr{prefix: attr.Name.Space, local: attr.Name.Local, value: attr.Value})
+				}
+			}
+			if len(stack) == 0 {
+				if doc.root != nil {
+					return nil, errors.New("failed to parse XML: multiple root elements")
+				}
+				doc.root = elem
+			} else {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, elem)
+			}
+			stack = append(stack, elem)
+
+		case xml.EndElement:
+			if len(stack) == 0 {
+				return nil, errors.New("failed to parse XML: unexpected end element")
+			}
+			stack = stack[:len(stack)-1]
+
+		case xml.CharData:
+			if len(stack) == 0 {
+				// Whitespace outside the root element is not part of the document
+				continue
+			}
+			parent := stack[len(stack)-1]
+			parent.children = append(parent.children, xmlText(string(t)))
+
+		case xml.Comment:
+			doc.appendMisc(stack, xmlComment(string(t)))
+
+		case xml.ProcInst:
+			if t.Target == "xml" {
+				doc.hasDeclaration = true
+				continue
+			}
+			doc.appendMisc(stack, xmlProcInst{target: t.Target, inst: string(t.Inst)})
+
+		case xml.Directive:
+			return nil, errors.New("failed to parse XML: DTDs are not supported")
+		}
+	}
+
+	if doc.root == nil {
+		return nil, errors.New("failed to parse XML: no root element")
+	}
+	if len(stack) != 0 {
+		return nil, errors.New("failed to parse XML: unexpected end of document")
+	}
+	return doc, nil
+}
+
+// appendMisc adds a comment or processing instruction at its position in the document
+func (d *xmlDocument) appendMisc(stack []*xmlElement, node xmlNode) {
+	switch {
+	case len(stack) > 0:
+		parent := stack[len(stack)-1]
+		parent.children = append(parent.children, node)
+	case d.root == nil:
+		d.before = append(d.before, node)
+	default:
+		d.after = append(d.after, node)
+	}
+}
+
+// nsScope maps prefixes to namespace URIs ("" is the default namespace)
+type nsScope map[string]string
+
+// with returns the scope extended with the declarations of an element
+func (s nsScope) with(decls []xmlNSDecl) nsScope {
+	if len(decls) == 0 {
+		return s
+	}
+	scope := make(nsScope, len(s)+len(decls))
+	for prefix, uri := range s {
+		scope[prefix] = uri
+	}
+	for _, decl := range decls {
+		scope[decl.prefix] = decl.uri
+	}
+	return scope
+}
+
+// resolve returns the namespace URI bound to a prefix
+func (s nsScope) resolve(prefix string) string {
+	if prefix == "xml" {
+		return nsXML
+	}
+	return s[prefix]
+}
+
+// canonicalizeDocument applies Exclusive XML Canonicalization 1.0 (without comments)
+// to the whole document. When exclude is not nil, that element and its subtree are
+// left out, which is the enveloped-signature transform.
+func canonicalizeDocument(doc *xmlDocument, exclude *xmlElement) []byte {
+	var buf bytes.Buffer
+	for _, node := range doc.before {
+		if pi, ok := node.(xmlProcInst); ok {
+			writeCanonicalProcInst(&buf, pi)
+			buf.WriteByte('\n')
+		}
+	}
+	writeCanonicalElement(&buf, doc.root, nsScope{}, nsScope{}, exclude)
+	for _, node := range doc.after {
+		if pi, ok := node.(xmlProcInst); ok {
+			buf.WriteByte('\n')
+			writeCanonicalProcInst(&buf, pi)
+		}
+	}
+	return buf.Bytes()
+}
+
+// canonicalizeElement applies Exclusive XML Canonicalization 1.0 (without comments)
+// to a subtree whose ancestors declare the namespaces in scope
+func canonicalizeElement(elem *xmlElement, scope nsScope) []byte {
+	var buf bytes.Buffer
+	writeCanonicalElement(&buf, elem, scope, nsScope{}, nil)
+	return buf.Bytes()
+}
+
+// writeCanonicalElement renders an element in exclusive canonical form.
+// rendered holds the namespace declarations already output by ancestors.
+func writeCanonicalElement(buf *bytes.Buffer, elem *xmlElement, scope, rendered nsScope, exclude *xmlElement) {
+	if elem == exclude {
+		return
+	}
+	scope = scope.with(elem.nsDecls)
+
+	// Namespaces visibly utilized by the element and its attributes
+	utilized := map[string]bool{elem.prefix: true}
+	for _, attr := range elem.attrs {
+		if attr.prefix != "" {
+			utilized[attr.prefix] = true
+		}
+	}
+
+	var decls []xmlNSDecl
+	for prefix := range utilized {
+		if prefix == "xml" {
+			continue
+		}
+		uri := scope.resolve(prefix)
+		renderedURI, wasRendered := rendered[prefix]
+		if prefix == "" && uri == "" {
+			// xmlns="" is only needed to undo a default namespace rendered above
+			if wasRendered && renderedURI != "" {
+				decls = append(decls, xmlNSDecl{prefix: "", uri: ""})
+			}
+			continue
+		}
+		if !wasRendered || renderedURI != uri {
+			decls = append(decls, xmlNSDecl{prefix: prefix, uri: uri})
+		}
+	}
+	sort.Slice(decls, func(i, j int) bool { return decls[i].prefix < decls[j].prefix })
+	rendered = rendered.with(decls)
+
+	attrs := make([]xmlAttr, len(elem.attrs))
+	copy(attrs, elem.attrs)
+	sort.SliceStable(attrs, func(i, j int) bool {
+		nsI, nsJ := attrNamespace(attrs[i], scope), attrNamespace(attrs[j], scope)
+		if nsI != nsJ {
+			return nsI < nsJ
+		}
+		return attrs[i].local < attrs[j].local
+	})
+
+	buf.WriteByte('<')
+	buf.WriteString(qualifiedName(elem.prefix, elem.local))
+	for _, decl := range decls {
+		if decl.prefix == "" {
+			buf.WriteString(` xmlns="`)
+		} else {
+			buf.WriteString(` xmlns:` + decl.prefix + `="`)
+		}
+		buf.WriteString(escapeAttrValue(decl.uri))
+		buf.WriteByte('"')
+	}
+	for _, attr := range attrs {
+		buf.WriteString(" " + qualifiedName(attr.prefix, attr.local) + `="`)
+		buf.WriteString(escapeAttrValue(attr.value))
+		buf.WriteByte('"')
+	}
+	buf.WriteByte('>')
+
+	for _, child := range elem.children {
+		switch c := child.(type) {
+		case *xmlElement:
+			writeCanonicalElement(buf, c, scope, rendered, exclude)
+		case xmlText:
+			buf.WriteString(escapeText(string(c)))
+		case xmlProcInst:
+			writeCanonicalProcInst(buf, c)
+		}
+	}
+
+	buf.WriteString("</" + qualifiedName(elem.prefix, elem.local) + ">")
+}
+
+func writeCanonicalProcInst(buf *bytes.Buffer, pi xmlProcInst) {
+	buf.WriteString("<?" + pi.target)
+	if inst := strings.TrimLeft(pi.inst, " \t\r\n"); inst != "" {
+		buf.WriteString(" " + inst)
+	}
+	buf.WriteString("?>")
+}
+
+// attrNamespace returns the namespace URI of an attribute (unprefixed attributes have none)
+func attrNamespace(attr xmlAttr, scope nsScope) string {
+	if attr.prefix == "" {
+		return ""
+	}
+	return scope.resolve(attr.prefix)
+}
+
+func qualifiedName(prefix, local string) string {
+	if prefix == "" {
+		return local
+	}
+	return prefix + ":" + local
+}
+
+// escapeText escapes character data as required by XML canonicalization
+func escapeText(s string) string {
+	return strings.NewReplacer(
+		"&", "&amp;",
+		"<", "&lt;",
+		">", "&gt;",
+		"\r", "&#xD;",
+	).Replace(s)
+}
+
+// escapeAttrValue escapes attribute values as required by XML canonicalization
+func escapeAttrValue(s string) string {
+	return strings.NewReplacer(
+		"&", "&amp;",
+		"<", "&lt;",
+		`"`, "&quot;",
+		"\t", "&#x9;",
+		"\n", "&#xA;",
+		"\r", "&#xD;",
+	).Replace(s)
+}
+
+// serializeDocument writes the document back as XML, keeping every namespace
+// declaration, attribute order and comment as parsed (unlike the canonical form)
+func serializeDocument(doc *xmlDocument) []byte {
+	var buf bytes.Buffer
+	if doc.hasDeclaration {
+		buf.WriteString(`<?xml version="1.0" encoding="UTF-8"?>`)
+		buf.WriteByte('\n')
+	}
+	for _, node := range doc.before {
+		writeNode(&buf, node)
+		buf.WriteByte('\n')
+	}
+	writeNode(&buf, doc.root)
+	for _, node := range doc.after {
+		buf.WriteByte('\n')
+		writeNode(&buf, node)
+	}
+	return buf.Bytes()
+}
+
+func writeNode(buf *bytes.Buffer, node xmlNode) {
+	switch n := node.(type) {
+	case *xmlElement:
+		buf.WriteString("<" + qualifiedName(n.prefix, n.local))
+		for _, decl := range n.nsDecls {
+			if decl.prefix == "" {
+				buf.WriteString(` xmlns="`)
+			} else {
+				buf.WriteString(` xmlns:` + decl.prefix + `="`)
+			}
+			buf.WriteString(escapeAttrValue(decl.uri) + `"`)
+		}
+		for _, attr := range n.attrs {
+			buf.WriteString(" " + qualifiedName(attr.prefix, attr.local) + `="` + escapeAttrValue(attr.value) + `"`)
+		}
+		if len(n.children) == 0 {
+			buf.WriteString("/>")
+			return
+		}
+		buf.WriteByte('>')
+		for _, child := range n.children {
+			writeNode(buf, child)
+		}
+		buf.WriteString("</" + qualifiedName(n.prefix, n.local) + ">")
+	case xmlText:
+		buf.WriteString(escapeText(string(n)))
+	case xmlComment:
+		buf.WriteString("<!--" + string(n) + "-->")
+	case xmlProcInst:
+		writeCanonicalProcInst(buf, n)
+	}
+}
@@ -0,0 +1,46 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+)
+
+// Signer modes selectable by configuration (XML_SIGNER_MODE)
+const (
+	// ModeJava signs through the Java xml-signer sidecar over HTTP
+	ModeJava = "java"
+	// ModeNative signs in-process with NativeXMLSigner
+	ModeNative = "native"
+)
+
+// Signer is implemented by both XMLSignerClient and NativeXMLSigner
+type Signer interface {
+	SignXML(ctx context.Context, xmlData string) (string, error)
+	HealthCheck(ctx context.Context) error
+}
+
+// NewSigner creates the XML signer for the configured mode (empty defaults to the Java sidecar)
+func NewSigner(mode string, javaConfig *Config, nativeConfig *NativeConfig) (Signer, error) {
+	switch mode {
+	case "", ModeJava:
+		if javaConfig == nil {
+			javaConfig = &Config{}
+		}
+		client, err := NewXMLSignerClient(javaConfig)
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	case ModeNative:
+		if nativeConfig == nil {
+			return nil, fmt.Errorf("native XML signer requires certificate configuration")
+		}
+		nativeSigner, err := NewNativeXMLSigner(nativeConfig)
+		if err != nil {
+			return nil, err
+		}
+		return nativeSigner, nil
+	default:
+		return nil, fmt.Errorf("unknown XML signer mode %q (expected %q or %q)", mode, ModeJava, ModeNative)
+	}
+}
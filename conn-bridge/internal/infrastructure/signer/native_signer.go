@@ -0,0 +1,324 @@
+package signer
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/pkcs12"
+)
+
+// XML-DSig algorithm identifiers (same defaults as the Java xml-signer)
+const (
+	AlgorithmExcC14N             = "http://www.w3.org/2001/10/xml-exc-c14n#"
+	AlgorithmEnvelopedSignature  = "http://www.w3.org/2000/09/xmldsig#enveloped-signature"
+	AlgorithmRSASHA256           = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"
+	AlgorithmSHA256              = "http://www.w3.org/2001/04/xmlenc#sha256"
+	dsigPrefix                   = "ds"
+	base64LineLength             = 76
+	certificateExpiryWarningTime = 30 * 24 * time.Hour
+)
+
+// NativeXMLSigner signs XML in-process with enveloped XML-DSig
+// (exclusive C14N, RSA-SHA256, X.509 KeyInfo), producing the same signature
+// layout as the Java xml-signer service without the HTTP round trip
+type NativeXMLSigner struct {
+	privateKey  *rsa.PrivateKey
+	certificate *x509.Certificate
+	logger      *logrus.Logger
+	now         func() time.Time
+}
+
+// NativeConfig holds the configuration for the native XML signer
+type NativeConfig struct {
+	// CertPath is a PKCS#12 keystore (.p12/.pfx) or a PEM certificate
+	CertPath string
+	// KeyPath is the PEM private key (only used with a PEM certificate)
+	KeyPath string
+	// Password unlocks the PKCS#12 keystore
+	Password string
+	Logger   *logrus.Logger
+}
+
+// NewNativeXMLSigner creates a native XML signer loading the key pair from PKCS#12 or PEM
+func NewNativeXMLSigner(config *NativeConfig) (*NativeXMLSigner, error) {
+	if config.CertPath == "" {
+		return nil, errors.New("certificate path is required for the native XML signer")
+	}
+
+	if config.Logger == nil {
+		config.Logger = logrus.New()
+		config.Logger.SetLevel(logrus.InfoLevel)
+	}
+
+	var (
+		key  *rsa.PrivateKey
+		cert *x509.Certificate
+		err  error
+	)
+	if config.KeyPath == "" {
+		key, cert, err = loadPKCS12(config.CertPath, config.Password)
+	} else {
+		key, cert, err = loadPEM(config.CertPath, config.KeyPath)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	config.Logger.WithFields(logrus.Fields{
+		"subject":  cert.Subject.String(),
+		"issuer":   cert.Issuer.String(),
+		"notAfter": cert.NotAfter,
+	}).Info("Native XML signer loaded certificate")
+
+	return newNativeXMLSigner(key, cert, config.Logger), nil
+}
+
+func newNativeXMLSigner(key *rsa.PrivateKey, cert *x509.Certificate, logger *logrus.Logger) *NativeXMLSigner {
+	return &NativeXMLSigner{
+		privateKey:  key,
+		certificate: cert,
+		logger:      logger,
+		now:         time.Now,
+	}
+}
+
+// SignXML appends an enveloped ds:Signature to the root element of the document
+func (s *NativeXMLSigner) SignXML(ctx context.Context, xmlData string) (string, error) {
+	s.logger.WithFields(logrus.Fields{
+		"xmlSize": len(xmlData),
+	}).Debug("Signing XML natively with ICP-Brasil certificate")
+
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	doc, err := parseXMLDocument([]byte(xmlData))
+	if err != nil {
+		return "", err
+	}
+
+	// Reference URI="" with the enveloped-signature transform: the digest covers the
+	// document as it is before the Signature element is appended
+	digest := sha256.Sum256(canonicalizeDocument(doc, nil))
+
+	signedInfo := buildSignedInfo(base64.StdEncoding.EncodeToString(digest[:]))
+	signedInfoDigest := sha256.Sum256(canonicalizeElement(signedInfo, nsScope{dsigPrefix: nsDSig}))
+	signatureValue, err := rsa.SignPKCS1v15(nil, s.privateKey, crypto.SHA256, signedInfoDigest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to compute signature value: %w", err)
+	}
+
+	signature := buildSignature(signedInfo, signatureValue, s.certificate.Raw)
+	doc.root.children = append(doc.root.children, signature)
+
+	signedXML := string(serializeDocument(doc))
+
+	s.logger.WithFields(logrus.Fields{
+		"originalSize": len(xmlData),
+		"signedSize":   len(signedXML),
+	}).Info("XML signed successfully with native signer")
+
+	return signedXML, nil
+}
+
+// HealthCheck verifies the signing certificate is still valid
+func (s *NativeXMLSigner) HealthCheck(ctx context.Context) error {
+	now := s.now()
+	if now.Before(s.certificate.NotBefore) {
+		return fmt.Errorf("signing certificate is not valid before %s", s.certificate.NotBefore.Format(time.RFC3339))
+	}
+	if now.After(s.certificate.NotAfter) {
+		return fmt.Errorf("signing certificate expired at %s", s.certificate.NotAfter.Format(time.RFC3339))
+	}
+	if s.certificate.NotAfter.Sub(now) < certificateExpiryWarningTime {
+		s.logger.WithField("notAfter", s.certificate.NotAfter).Warn("Signing certificate expires in less than 30 days")
+	}
+	return nil
+}
+
+// Certificate returns the signing certificate
+func (s *NativeXMLSigner) Certificate() *x509.Certificate {
+	return s.certificate
+}
+
+// buildSignedInfo builds the ds:SignedInfo element for an enveloped reference to the whole document
+func buildSignedInfo(digestValue string) *xmlElement {
+	return dsElement("SignedInfo", nil,
+		dsElement("CanonicalizationMethod", []xmlAttr{{local: "Algorithm", value: AlgorithmExcC14N}}),
+		dsElement("SignatureMethod", []xmlAttr{{local: "Algorithm", value: AlgorithmRSASHA256}}),
+		dsElement("Reference", []xmlAttr{{local: "URI", value: ""}},
+			dsElement("Transforms", nil,
+				dsElement("Transform", []xmlAttr{{local: "Algorithm", value: AlgorithmEnvelopedSignature}}),
+				dsElement("Transform", []xmlAttr{{local: "Algorithm", value: AlgorithmExcC14N}}),
+			),
+			dsElement("DigestMethod", []xmlAttr{{local: "Algorithm", value: AlgorithmSHA256}}),
+			dsTextElement("DigestValue", digestValue),
+		),
+	)
+}
+
+// buildSignature assembles ds:Signature with the signature value and the X.509 certificate
+func buildSignature(signedInfo *xmlElement, signatureValue, certificate []byte) *xmlElement {
+	signature := dsElement("Signature", nil,
+		signedInfo,
+		dsTextElement("SignatureValue", "\n"+wrapBase64(signatureValue)+"\n"),
+		dsElement("KeyInfo", nil,
+			dsElement("X509Data", nil,
+				dsTextElement("X509Certificate", "\n"+wrapBase64(certificate)+"\n"),
+			),
+		),
+	)
+	signature.nsDecls = []xmlNSDecl{{prefix: dsigPrefix, uri: nsDSig}}
+	return signature
+}
+
+// dsElement creates a ds: element; like Apache Santuario, children are separated by line breaks
+func dsElement(local string, attrs []xmlAttr, children ...*xmlElement) *xmlElement {
+	elem := &xmlElement{prefix: dsigPrefix, local: local, attrs: attrs}
+	if len(children) > 0 {
+		elem.children = append(elem.children, xmlText("\n"))
+		for _, child := range children {
+			elem.children = append(elem.children, child, xmlText("\n"))
+		}
+	}
+	return elem
+}
+
+func dsTextElement(local, text string) *xmlElement {
+	return &xmlElement{prefix: dsigPrefix, local: local, children: []xmlNode{xmlText(text)}}
+}
+
+// wrapBase64 encodes data as base64 with 76-character lines
+func wrapBase64(data []byte) string {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	var lines []string
+	for len(encoded) > base64LineLength {
+		lines = append(lines, encoded[:base64LineLength])
+		encoded = encoded[base64LineLength:]
+	}
+	lines = append(lines, encoded)
+	return strings.Join(lines, "\n")
+}
+
+// loadPKCS12 loads the key pair from a PKCS#12 keystore, picking the certificate
+// that matches the private key when the keystore also carries the CA chain
+func loadPKCS12(path, password string) (*rsa.PrivateKey, *x509.Certificate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read PKCS#12 keystore: %w", err)
+	}
+
+	blocks, err := pkcs12.ToPEM(data, password)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode PKCS#12 keystore (only legacy 3DES/RC2 encryption is supported, use PEM otherwise): %w", err)
+	}
+
+	var (
+		key   *rsa.PrivateKey
+		certs []*x509.Certificate
+	)
+	for _, block := range blocks {
+		switch block.Type {
+		case "PRIVATE KEY":
+			if key, err = parseRSAPrivateKey(block.Bytes); err != nil {
+				return nil, nil, err
+			}
+		case "CERTIFICATE":
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to parse certificate from keystore: %w", err)
+			}
+			certs = append(certs, cert)
+		}
+	}
+	if key == nil {
+		return nil, nil, errors.New("no private key found in PKCS#12 keystore")
+	}
+
+	cert, err := certificateForKey(key, certs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return key, cert, nil
+}
+
+// loadPEM loads the key pair from PEM files (the certificate file may contain the chain)
+func loadPEM(certPath, keyPath string) (*rsa.PrivateKey, *x509.Certificate, error) {
+	certPEM, err := os.ReadFile(certPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read certificate: %w", err)
+	}
+	keyPEM, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read private key: %w", err)
+	}
+
+	var certs []*x509.Certificate
+	for rest := certPEM; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse certificate: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+
+	keyBlock, _ := pem.Decode(keyPEM)
+	if keyBlock == nil {
+		return nil, nil, errors.New("no PEM private key found")
+	}
+	key, err := parseRSAPrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cert, err := certificateForKey(key, certs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return key, cert, nil
+}
+
+// parseRSAPrivateKey accepts PKCS#1 and PKCS#8 encoded RSA keys
+func parseRSAPrivateKey(der []byte) (*rsa.PrivateKey, error) {
+	if key, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not RSA (RSA-SHA256 requires an RSA key)")
+	}
+	return key, nil
+}
+
+// certificateForKey returns the certificate whose public key matches the private key
+func certificateForKey(key *rsa.PrivateKey, certs []*x509.Certificate) (*x509.Certificate, error) {
+	for _, cert := range certs {
+		if pub, ok := cert.PublicKey.(*rsa.PublicKey); ok && pub.Equal(&key.PublicKey) {
+			return cert, nil
+		}
+	}
+	return nil, errors.New("no certificate matches the private key")
+}
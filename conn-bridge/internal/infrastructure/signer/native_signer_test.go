@@ -0,0 +1,245 @@
+package signer
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite testdata/golden/*.signed.xml")
+
+func newTestSigner(t *testing.T) *NativeXMLSigner {
+	t.Helper()
+	signer, err := NewNativeXMLSigner(&NativeConfig{
+		CertPath: filepath.Join("testdata", "signer.p12"),
+		Password: "changeit",
+		Logger:   logrus.New(),
+	})
+	require.NoError(t, err)
+	return signer
+}
+
+func TestExclusiveC14N_Vectors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "sorts attributes, drops unused namespaces and expands empty elements",
+			input:    `<doc xmlns:unused="urn:u" b="2" a="1"><e/></doc>`,
+			expected: `<doc a="1" b="2"><e></e></doc>`,
+		},
+		{
+			name:     "undoes a rendered default namespace",
+			input:    `<a xmlns="urn:a"><b xmlns=""><c/></b></a>`,
+			expected: `<a xmlns="urn:a"><b xmlns=""><c></c></b></a>`,
+		},
+		{
+			name:     "renders prefixes where visibly utilized",
+			input:    `<p:a xmlns:p="urn:p" xmlns:q="urn:q"><q:b q:x="1" y="2"/></p:a>`,
+			expected: `<p:a xmlns:p="urn:p"><q:b xmlns:q="urn:q" y="2" q:x="1"></q:b></p:a>`,
+		},
+		{
+			name:     "omits redundant redeclarations",
+			input:    `<p:a xmlns:p="urn:p"><p:b xmlns:p="urn:p"/></p:a>`,
+			expected: `<p:a xmlns:p="urn:p"><p:b></p:b></p:a>`,
+		},
+		{
+			name:     "escapes text and attribute values",
+			input:    `<a attr="&quot;x&#9;&lt;">1 &lt; 2 &amp; 3 > 0</a>`,
+			expected: `<a attr="&quot;x&#x9;&lt;">1 &lt; 2 &amp; 3 &gt; 0</a>`,
+		},
+		{
+			name:     "drops declaration and comments, keeps processing instructions",
+			input:    "<?xml version=\"1.0\"?>\n<?pi data?>\n<!--c--><a><!--x--><![CDATA[<y>]]></a>\n<?end?>",
+			expected: "<?pi data?>\n<a>&lt;y&gt;</a>\n<?end?>",
+		},
+		{
+			name:     "orders attributes by namespace URI then local name",
+			input:    `<a xml:lang="pt" xmlns:z="urn:z" z:b="1" c="2"/>`,
+			expected: `<a xmlns:z="urn:z" c="2" xml:lang="pt" z:b="1"></a>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parseXMLDocument([]byte(tt.input))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(canonicalizeDocument(doc, nil)))
+		})
+	}
+}
+
+func TestNativeXMLSigner_GoldenVectors(t *testing.T) {
+	signer := newTestSigner(t)
+
+	inputs, err := filepath.Glob(filepath.Join("testdata", "golden", "*.xml"))
+	require.NoError(t, err)
+
+	var checked int
+	for _, input := range inputs {
+		if strings.HasSuffix(input, ".signed.xml") {
+			continue
+		}
+		checked++
+
+		t.Run(filepath.Base(input), func(t *testing.T) {
+			data, err := os.ReadFile(input)
+			require.NoError(t, err)
+
+			signed, err := signer.SignXML(context.Background(), string(data))
+			require.NoError(t, err)
+
+			goldenPath := strings.TrimSuffix(input, ".xml") + ".signed.xml"
+			if *updateGolden {
+				require.NoError(t, os.WriteFile(goldenPath, []byte(signed), 0o644))
+			}
+			expected, err := os.ReadFile(goldenPath)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), signed)
+
+			cert, err := VerifySignature(signed)
+			require.NoError(t, err)
+			assert.Equal(t, signer.Certificate().Raw, cert.Raw)
+		})
+	}
+	assert.NotZero(t, checked)
+}
+
+func TestNativeXMLSigner_JavaVectors(t *testing.T) {
+	inputs, err := filepath.Glob(filepath.Join("testdata", "golden", "*.xml"))
+	require.NoError(t, err)
+
+	signer := newTestSigner(t)
+	for _, input := range inputs {
+		if strings.HasSuffix(input, ".signed.xml") {
+			continue
+		}
+		name := strings.TrimSuffix(filepath.Base(input), ".xml")
+
+		t.Run(name, func(t *testing.T) {
+			// Every input needs a vector signed by the Java xml-signer: without it nothing checks
+			// that Bacen gets the signature it got from the Java service
+			vector := filepath.Join("testdata", "java", name+".signed.xml")
+			javaSigned, err := os.ReadFile(vector)
+			require.NoError(t, err, "missing Java xml-signer vector; run testdata/java/generate.sh and commit its output")
+
+			_, err = VerifySignature(string(javaSigned))
+			require.NoError(t, err, "Java signature must verify natively")
+
+			data, err := os.ReadFile(input)
+			require.NoError(t, err)
+			nativeSigned, err := signer.SignXML(context.Background(), string(data))
+			require.NoError(t, err)
+
+			javaDigest, javaValue := signatureValues(t, string(javaSigned))
+			nativeDigest, nativeValue := signatureValues(t, nativeSigned)
+			assert.Equal(t, javaDigest, nativeDigest)
+			assert.Equal(t, javaValue, nativeValue)
+		})
+	}
+}
+
+func TestVerifySignature_DetectsTampering(t *testing.T) {
+	signer := newTestSigner(t)
+	signed, err := signer.SignXML(context.Background(), `<DeleteEntryRequest><Key>user@example.com</Key><RequestId>r-1</RequestId></DeleteEntryRequest>`)
+	require.NoError(t, err)
+
+	_, err = VerifySignature(signed)
+	require.NoError(t, err)
+
+	tests := map[string]string{
+		"content":     strings.Replace(signed, "user@example.com", "other@example.com", 1),
+		"signed info": strings.Replace(signed, `<ds:Reference URI="">`, `<ds:Reference URI="" Id="x">`, 1),
+	}
+	for name, doc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := VerifySignature(doc)
+			assert.ErrorIs(t, err, ErrInvalidSignature)
+		})
+	}
+
+	_, err = VerifySignature(`<DeleteEntryRequest><Key>user@example.com</Key></DeleteEntryRequest>`)
+	assert.ErrorIs(t, err, ErrMissingSignature)
+
+	// Insignificant serialization changes keep the signature valid
+	_, err = VerifySignature(strings.Replace(signed, "<Key>", `<Key xmlns:unused="urn:x">`, 1))
+	assert.NoError(t, err)
+}
+
+func TestNewNativeXMLSigner_KeyFormats(t *testing.T) {
+	fromPKCS12 := newTestSigner(t)
+
+	fromPEM, err := NewNativeXMLSigner(&NativeConfig{
+		CertPath: filepath.Join("testdata", "signer-cert.pem"),
+		KeyPath:  filepath.Join("testdata", "signer-key.pem"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, fromPKCS12.Certificate().Raw, fromPEM.Certificate().Raw)
+
+	_, err = NewNativeXMLSigner(&NativeConfig{
+		CertPath: filepath.Join("testdata", "signer.p12"),
+		Password: "wrong",
+	})
+	assert.Error(t, err)
+
+	_, err = NewNativeXMLSigner(&NativeConfig{})
+	assert.Error(t, err)
+}
+
+func TestNativeXMLSigner_HealthCheck(t *testing.T) {
+	signer := newTestSigner(t)
+	assert.NoError(t, signer.HealthCheck(context.Background()))
+
+	signer.now = func() time.Time { return signer.Certificate().NotAfter.Add(time.Hour) }
+	assert.Error(t, signer.HealthCheck(context.Background()))
+}
+
+func TestNewSigner_Modes(t *testing.T) {
+	javaSigner, err := NewSigner("", &Config{BaseURL: "http://xml-signer:8080"}, nil)
+	require.NoError(t, err)
+	assert.IsType(t, &XMLSignerClient{}, javaSigner)
+
+	nativeSigner, err := NewSigner(ModeNative, nil, &NativeConfig{
+		CertPath: filepath.Join("testdata", "signer.p12"),
+		Password: "changeit",
+	})
+	require.NoError(t, err)
+	assert.IsType(t, &NativeXMLSigner{}, nativeSigner)
+
+	_, err = NewSigner(ModeNative, nil, nil)
+	assert.Error(t, err)
+
+	_, err = NewSigner("hsm", nil, nil)
+	assert.Error(t, err)
+}
+
+// signatureValues extracts DigestValue and SignatureValue (without line breaks) from a signed document
+func signatureValues(t *testing.T, signed string) (string, string) {
+	t.Helper()
+	doc, err := parseXMLDocument([]byte(signed))
+	require.NoError(t, err)
+
+	scope := nsScope{}.with(doc.root.nsDecls)
+	signature, sigScope := findDSigChild(doc.root, scope, "Signature")
+	require.NotNil(t, signature)
+	signedInfo, signedInfoScope := findDSigChild(signature, sigScope, "SignedInfo")
+	require.NotNil(t, signedInfo)
+	reference, referenceScope := findDSigChild(signedInfo, signedInfoScope, "Reference")
+	require.NotNil(t, reference)
+	digest, _ := findDSigChild(reference, referenceScope, "DigestValue")
+	value, _ := findDSigChild(signature, sigScope, "SignatureValue")
+	require.NotNil(t, digest)
+	require.NotNil(t, value)
+
+	return strings.Join(strings.Fields(textContent(digest)), ""), strings.Join(strings.Fields(textContent(value)), "")
+}
@@ -0,0 +1,247 @@
+package signer
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrUntrustedCertificate is returned when a signature is valid but its certificate
+// does not chain to the configured ICP-Brasil trust store
+var ErrUntrustedCertificate = errors.New("untrusted XML signing certificate")
+
+// Signature verification results (metric label values)
+const (
+	verificationValid     = "valid"
+	verificationMissing   = "missing"
+	verificationInvalid   = "invalid"
+	verificationUntrusted = "untrusted"
+)
+
+var (
+	// Prometheus metrics
+	responseSignatureVerificationsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "bacen_response_signature_verifications_total",
+			Help: "Total number of Bacen response signature verifications by result (valid, missing, invalid, untrusted)",
+		},
+		[]string{"result"},
+	)
+)
+
+// TrustStore holds the ICP-Brasil root CAs (trust anchors) and intermediate CAs
+type TrustStore struct {
+	roots         *x509.CertPool
+	intermediates *x509.CertPool
+}
+
+// NewTrustStore builds a trust store: self-signed certificates become trust anchors,
+// the others are used only as intermediates
+func NewTrustStore(certs ...*x509.Certificate) (*TrustStore, error) {
+	store := &TrustStore{
+		roots:         x509.NewCertPool(),
+		intermediates: x509.NewCertPool(),
+	}
+
+	var roots int
+	for _, cert := range certs {
+		if isSelfSigned(cert) {
+			store.roots.AddCert(cert)
+			roots++
+		} else {
+			store.intermediates.AddCert(cert)
+		}
+	}
+	if roots == 0 {
+		return nil, errors.New("trust store has no root certificate")
+	}
+	return store, nil
+}
+
+// LoadTrustStore loads a trust store from a PEM bundle or from a directory of
+// certificates (.pem, .crt or .cer, PEM or DER encoded)
+func LoadTrustStore(path string) (*TrustStore, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open trust store: %w", err)
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		files = nil
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read trust store directory: %w", err)
+		}
+		for _, entry := range entries {
+			switch strings.ToLower(filepath.Ext(entry.Name())) {
+			case ".pem", ".crt", ".cer":
+				if !entry.IsDir() {
+					files = append(files, filepath.Join(path, entry.Name()))
+				}
+			}
+		}
+	}
+
+	var certs []*x509.Certificate
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read trust store certificate: %w", err)
+		}
+		parsed, err := parseCertificates(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", filepath.Base(file), err)
+		}
+		certs = append(certs, parsed...)
+	}
+
+	return NewTrustStore(certs...)
+}
+
+// ResponseVerifier verifies the XML-DSig signature of Bacen responses and the
+// signing certificate chain against the ICP-Brasil trust store
+type ResponseVerifier struct {
+	trustStore *TrustStore
+	logger     *logrus.Logger
+	now        func() time.Time
+}
+
+// ResponseVerifierConfig holds the configuration for the response verifier
+type ResponseVerifierConfig struct {
+	// TrustStorePath is a PEM bundle or a directory with the ICP-Brasil CA certificates
+	TrustStorePath string
+	Logger         *logrus.Logger
+}
+
+// NewResponseVerifier creates a response verifier loading the configured trust store
+func NewResponseVerifier(config *ResponseVerifierConfig) (*ResponseVerifier, error) {
+	if config.TrustStorePath == "" {
+		return nil, errors.New("trust store path is required for response signature verification")
+	}
+
+	trustStore, err := LoadTrustStore(config.TrustStorePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResponseVerifierWithTrustStore(trustStore, config.Logger), nil
+}
+
+// NewResponseVerifierWithTrustStore creates a response verifier for an already loaded trust store
+func NewResponseVerifierWithTrustStore(trustStore *TrustStore, logger *logrus.Logger) *ResponseVerifier {
+	if logger == nil {
+		logger = logrus.New()
+		logger.SetLevel(logrus.InfoLevel)
+	}
+
+	return &ResponseVerifier{
+		trustStore: trustStore,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// VerifyResponse verifies the enveloped signature of a Bacen response body and returns
+// the signing certificate. Errors wrap ErrMissingSignature, ErrInvalidSignature or
+// ErrUntrustedCertificate.
+func (v *ResponseVerifier) VerifyResponse(xmlData []byte) (*x509.Certificate, error) {
+	signingCert, chain, err := verifyDocument(xmlData)
+	if err != nil {
+		result := verificationInvalid
+		if errors.Is(err, ErrMissingSignature) {
+			result = verificationMissing
+		}
+		return nil, v.reject(result, err)
+	}
+
+	if err := v.verifyChain(signingCert, chain); err != nil {
+		return nil, v.reject(verificationUntrusted, err)
+	}
+
+	responseSignatureVerificationsTotal.WithLabelValues(verificationValid).Inc()
+	v.logger.WithFields(logrus.Fields{
+		"subject": signingCert.Subject.String(),
+		"serial":  signingCert.SerialNumber.String(),
+	}).Debug("Bacen response signature verified")
+
+	return signingCert, nil
+}
+
+// verifyChain checks the signing certificate against the trust store at the current time
+func (v *ResponseVerifier) verifyChain(signingCert *x509.Certificate, chain []*x509.Certificate) error {
+	if signingCert.KeyUsage != 0 &&
+		signingCert.KeyUsage&(x509.KeyUsageDigitalSignature|x509.KeyUsageContentCommitment) == 0 {
+		return fmt.Errorf("%w: certificate is not valid for digital signatures", ErrUntrustedCertificate)
+	}
+
+	intermediates := v.trustStore.intermediates.Clone()
+	for _, cert := range chain {
+		intermediates.AddCert(cert)
+	}
+
+	_, err := signingCert.Verify(x509.VerifyOptions{
+		Roots:         v.trustStore.roots,
+		Intermediates: intermediates,
+		CurrentTime:   v.now(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUntrustedCertificate, err)
+	}
+	return nil
+}
+
+// reject records the failed verification and returns its error
+func (v *ResponseVerifier) reject(result string, err error) error {
+	responseSignatureVerificationsTotal.WithLabelValues(result).Inc()
+	v.logger.WithFields(logrus.Fields{
+		"result": result,
+		"error":  err.Error(),
+	}).Error("Bacen response signature verification failed")
+	return err
+}
+
+// parseCertificates parses PEM (one or more blocks) or a single DER certificate
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	if !bytes.Contains(data, []byte("-----BEGIN")) {
+		cert, err := x509.ParseCertificate(data)
+		if err != nil {
+			return nil, err
+		}
+		return []*x509.Certificate{cert}, nil
+	}
+
+	var certs []*x509.Certificate
+	for rest := data; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	return certs, nil
+}
+
+// isSelfSigned reports whether a certificate is a self-signed root
+func isSelfSigned(cert *x509.Certificate) bool {
+	return bytes.Equal(cert.RawIssuer, cert.RawSubject) && cert.CheckSignatureFrom(cert) == nil
+}
@@ -0,0 +1,230 @@
+package signer
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCA is a certificate with its key, used to build self-signed ICP-Brasil-like chains offline
+type testCA struct {
+	cert *x509.Certificate
+	key  *rsa.PrivateKey
+}
+
+var testSerial int64
+
+func newTestCert(t *testing.T, name string, parent *testCA, isCA bool, notAfter time.Time) *testCA {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	testSerial++
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(testSerial),
+		Subject:               pkix.Name{Country: []string{"BR"}, Organization: []string{"ICP-Brasil"}, CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              notAfter,
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageContentCommitment,
+	}
+	if isCA {
+		template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+	}
+
+	signerCert, signerKey := template, key
+	if parent != nil {
+		signerCert, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signerCert, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCA{cert: cert, key: key}
+}
+
+// bacenChain is root → intermediate → leaf (the Bacen signing certificate)
+type bacenChain struct {
+	root, intermediate, leaf *testCA
+}
+
+func newBacenChain(t *testing.T, leafNotAfter time.Time) bacenChain {
+	t.Helper()
+	year := time.Now().AddDate(1, 0, 0)
+	root := newTestCert(t, "AC Raiz Teste", nil, true, year)
+	intermediate := newTestCert(t, "AC Intermediaria Teste", root, true, year)
+	leaf := newTestCert(t, "BANCO CENTRAL DO BRASIL DICT", intermediate, false, leafNotAfter)
+	return bacenChain{root: root, intermediate: intermediate, leaf: leaf}
+}
+
+const bacenResponse = `<CreateEntryResponse><Entry><Key>user@example.com</Key><KeyType>EMAIL</KeyType></Entry><CorrelationId>c-1</CorrelationId></CreateEntryResponse>`
+
+// signResponse signs a response as Bacen would, optionally adding the intermediate CA to KeyInfo
+func signResponse(t *testing.T, chain bacenChain, withIntermediate bool) []byte {
+	t.Helper()
+	signer := newNativeXMLSigner(chain.leaf.key, chain.leaf.cert, logrus.New())
+	signed, err := signer.SignXML(context.Background(), bacenResponse)
+	require.NoError(t, err)
+
+	if withIntermediate {
+		// KeyInfo is outside SignedInfo, so extra certificates keep the signature valid
+		extra := "<ds:X509Certificate>" + base64.StdEncoding.EncodeToString(chain.intermediate.cert.Raw) + "</ds:X509Certificate>\n</ds:X509Data>"
+		signed = strings.Replace(signed, "</ds:X509Data>", extra, 1)
+	}
+	return []byte(signed)
+}
+
+func verificationCount(t *testing.T, result string) float64 {
+	t.Helper()
+	var metric dto.Metric
+	require.NoError(t, responseSignatureVerificationsTotal.WithLabelValues(result).Write(&metric))
+	return metric.GetCounter().GetValue()
+}
+
+func TestResponseVerifier_Chains(t *testing.T) {
+	chain := newBacenChain(t, time.Now().AddDate(0, 6, 0))
+	otherRoot := newTestCert(t, "Outra AC Raiz", nil, true, time.Now().AddDate(1, 0, 0))
+
+	tests := []struct {
+		name             string
+		trusted          []*x509.Certificate
+		withIntermediate bool
+		now              time.Time
+		expectedErr      error
+	}{
+		{
+			name:    "intermediate in trust store",
+			trusted: []*x509.Certificate{chain.root.cert, chain.intermediate.cert},
+		},
+		{
+			name:             "intermediate in KeyInfo",
+			trusted:          []*x509.Certificate{chain.root.cert},
+			withIntermediate: true,
+		},
+		{
+			name:        "missing intermediate",
+			trusted:     []*x509.Certificate{chain.root.cert},
+			expectedErr: ErrUntrustedCertificate,
+		},
+		{
+			name:             "unknown root",
+			trusted:          []*x509.Certificate{otherRoot.cert},
+			withIntermediate: true,
+			expectedErr:      ErrUntrustedCertificate,
+		},
+		{
+			name:        "expired signing certificate",
+			trusted:     []*x509.Certificate{chain.root.cert, chain.intermediate.cert},
+			now:         chain.leaf.cert.NotAfter.Add(time.Hour),
+			expectedErr: ErrUntrustedCertificate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, err := NewTrustStore(tt.trusted...)
+			require.NoError(t, err)
+			verifier := NewResponseVerifierWithTrustStore(store, logrus.New())
+			if !tt.now.IsZero() {
+				verifier.now = func() time.Time { return tt.now }
+			}
+
+			cert, err := verifier.VerifyResponse(signResponse(t, chain, tt.withIntermediate))
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, chain.leaf.cert.Raw, cert.Raw)
+		})
+	}
+}
+
+func TestResponseVerifier_RejectsAndCounts(t *testing.T) {
+	chain := newBacenChain(t, time.Now().AddDate(0, 6, 0))
+	store, err := NewTrustStore(chain.root.cert, chain.intermediate.cert)
+	require.NoError(t, err)
+	verifier := NewResponseVerifierWithTrustStore(store, logrus.New())
+
+	signed := signResponse(t, chain, false)
+	tampered := []byte(strings.Replace(string(signed), "user@example.com", "attacker@example.com", 1))
+
+	tests := []struct {
+		name        string
+		response    []byte
+		expectedErr error
+		result      string
+	}{
+		{name: "valid", response: signed, result: verificationValid},
+		{name: "missing signature", response: []byte(bacenResponse), expectedErr: ErrMissingSignature, result: verificationMissing},
+		{name: "tampered content", response: tampered, expectedErr: ErrInvalidSignature, result: verificationInvalid},
+		{name: "not XML", response: []byte("<html>gateway error"), expectedErr: ErrInvalidSignature, result: verificationInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := verificationCount(t, tt.result)
+
+			_, err := verifier.VerifyResponse(tt.response)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, before+1, verificationCount(t, tt.result))
+		})
+	}
+}
+
+func TestLoadTrustStore_FileAndDirectory(t *testing.T) {
+	chain := newBacenChain(t, time.Now().AddDate(0, 6, 0))
+	signed := signResponse(t, chain, false)
+
+	dir := t.TempDir()
+	rootPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: chain.root.cert.Raw})
+	intermediatePEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: chain.intermediate.cert.Raw})
+
+	// PEM bundle
+	bundle := filepath.Join(dir, "icp-brasil.pem")
+	require.NoError(t, os.WriteFile(bundle, append(rootPEM, intermediatePEM...), 0o644))
+
+	// Directory with a PEM root and a DER intermediate
+	certsDir := filepath.Join(dir, "certs")
+	require.NoError(t, os.Mkdir(certsDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(certsDir, "raiz.crt"), rootPEM, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(certsDir, "intermediaria.cer"), chain.intermediate.cert.Raw, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(certsDir, "README.txt"), []byte("ignored"), 0o644))
+
+	for _, path := range []string{bundle, certsDir} {
+		verifier, err := NewResponseVerifier(&ResponseVerifierConfig{TrustStorePath: path})
+		require.NoError(t, err)
+		_, err = verifier.VerifyResponse(signed)
+		assert.NoError(t, err, path)
+	}
+
+	// Intermediates alone are not trust anchors
+	onlyIntermediate := filepath.Join(dir, "intermediate.pem")
+	require.NoError(t, os.WriteFile(onlyIntermediate, intermediatePEM, 0o644))
+	_, err := NewResponseVerifier(&ResponseVerifierConfig{TrustStorePath: onlyIntermediate})
+	assert.Error(t, err)
+
+	_, err = NewResponseVerifier(&ResponseVerifierConfig{})
+	assert.Error(t, err)
+}
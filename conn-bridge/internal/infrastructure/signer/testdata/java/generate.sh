@@ -0,0 +1,20 @@
+#!/bin/sh
+# Regenerates the Java xml-signer vectors in this directory from ../golden/*.xml.
+# Needs Maven and JDK 17, or Docker (falls back to the maven:3.9.5-eclipse-temurin-17 image).
+set -eu
+
+here=$(cd "$(dirname "$0")" && pwd)
+testdata=$(dirname "$here")
+signer=$(cd "$testdata/../../../../xml-signer" && pwd)
+
+if command -v mvn >/dev/null 2>&1; then
+	cd "$signer"
+	mvn -B -q test -Dtest=GoVectorGeneratorTest -Dgo.signer.testdata="$testdata"
+else
+	docker run --rm \
+		-v "$signer":/app -v "$testdata":/testdata -w /app \
+		maven:3.9.5-eclipse-temurin-17 \
+		mvn -B -q test -Dtest=GoVectorGeneratorTest -Dgo.signer.testdata=/testdata
+fi
+
+ls "$here"/*.signed.xml
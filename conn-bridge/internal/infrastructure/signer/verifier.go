@@ -0,0 +1,214 @@
+package signer
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrMissingSignature is returned when the document has no enveloped ds:Signature
+	ErrMissingSignature = errors.New("missing XML signature")
+	// ErrInvalidSignature is returned when an XML signature is malformed or does not verify
+	ErrInvalidSignature = errors.New("invalid XML signature")
+)
+
+// VerifySignature verifies the enveloped ds:Signature of a document signed with
+// exclusive C14N and RSA-SHA256 (by NativeXMLSigner or the Java xml-signer)
+// and returns the signing certificate from KeyInfo. Trust in the certificate is not checked.
+func VerifySignature(xmlData string) (*x509.Certificate, error) {
+	signer, _, err := verifyDocument([]byte(xmlData))
+	return signer, err
+}
+
+// verifyDocument verifies the enveloped signature and returns the signing certificate
+// plus the other certificates carried in KeyInfo (usually the intermediate CAs)
+func verifyDocument(xmlData []byte) (*x509.Certificate, []*x509.Certificate, error) {
+	doc, err := parseXMLDocument(xmlData)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+
+	rootScope := nsScope{}.with(doc.root.nsDecls)
+	signature, sigScope := findDSigChild(doc.root, rootScope, "Signature")
+	if signature == nil {
+		return nil, nil, fmt.Errorf("%w: no enveloped ds:Signature in root element", ErrMissingSignature)
+	}
+
+	signedInfo, signedInfoScope := findDSigChild(signature, sigScope, "SignedInfo")
+	if signedInfo == nil {
+		return nil, nil, fmt.Errorf("%w: missing ds:SignedInfo", ErrInvalidSignature)
+	}
+	if err := checkAlgorithm(signedInfo, signedInfoScope, "CanonicalizationMethod", AlgorithmExcC14N); err != nil {
+		return nil, nil, err
+	}
+	if err := checkAlgorithm(signedInfo, signedInfoScope, "SignatureMethod", AlgorithmRSASHA256); err != nil {
+		return nil, nil, err
+	}
+
+	reference, referenceScope := findDSigChild(signedInfo, signedInfoScope, "Reference")
+	if reference == nil {
+		return nil, nil, fmt.Errorf("%w: missing ds:Reference", ErrInvalidSignature)
+	}
+	if uri, _ := attrValue(reference, "URI"); uri != "" {
+		return nil, nil, fmt.Errorf("%w: unsupported reference URI %q", ErrInvalidSignature, uri)
+	}
+	if err := checkTransforms(reference, referenceScope); err != nil {
+		return nil, nil, err
+	}
+	if err := checkAlgorithm(reference, referenceScope, "DigestMethod", AlgorithmSHA256); err != nil {
+		return nil, nil, err
+	}
+
+	digestValue, err := decodeBase64Element(reference, referenceScope, "DigestValue")
+	if err != nil {
+		return nil, nil, err
+	}
+	digest := sha256.Sum256(canonicalizeDocument(doc, signature))
+	if subtle.ConstantTimeCompare(digest[:], digestValue) != 1 {
+		return nil, nil, fmt.Errorf("%w: digest mismatch", ErrInvalidSignature)
+	}
+
+	signatureValue, err := decodeBase64Element(signature, sigScope, "SignatureValue")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyInfo, keyInfoScope := findDSigChild(signature, sigScope, "KeyInfo")
+	if keyInfo == nil {
+		return nil, nil, fmt.Errorf("%w: missing ds:KeyInfo", ErrInvalidSignature)
+	}
+	x509Data, x509DataScope := findDSigChild(keyInfo, keyInfoScope, "X509Data")
+	if x509Data == nil {
+		return nil, nil, fmt.Errorf("%w: missing ds:X509Data", ErrInvalidSignature)
+	}
+	var certs []*x509.Certificate
+	for _, elem := range findDSigChildren(x509Data, x509DataScope, "X509Certificate") {
+		der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(textContent(elem)), ""))
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: invalid base64 in ds:X509Certificate", ErrInvalidSignature)
+		}
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: failed to parse certificate: %v", ErrInvalidSignature, err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, nil, fmt.Errorf("%w: missing ds:X509Certificate", ErrInvalidSignature)
+	}
+
+	// The signing certificate is the one whose key verifies SignedInfo; the rest is chain
+	signedInfoDigest := sha256.Sum256(canonicalizeElement(signedInfo, sigScope))
+	for i, cert := range certs {
+		publicKey, ok := cert.PublicKey.(*rsa.PublicKey)
+		if !ok {
+			continue
+		}
+		if rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, signedInfoDigest[:], signatureValue) == nil {
+			chain := append(append([]*x509.Certificate{}, certs[:i]...), certs[i+1:]...)
+			return cert, chain, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("%w: signature value mismatch", ErrInvalidSignature)
+}
+
+// findDSigChild returns the first XML-DSig child element with the given local name
+// and the namespace scope inside it
+func findDSigChild(parent *xmlElement, scope nsScope, local string) (*xmlElement, nsScope) {
+	for _, node := range parent.children {
+		child, ok := node.(*xmlElement)
+		if !ok || child.local != local {
+			continue
+		}
+		childScope := scope.with(child.nsDecls)
+		if childScope.resolve(child.prefix) == nsDSig {
+			return child, childScope
+		}
+	}
+	return nil, nil
+}
+
+// findDSigChildren returns all XML-DSig child elements with the given local name
+func findDSigChildren(parent *xmlElement, scope nsScope, local string) []*xmlElement {
+	var children []*xmlElement
+	for _, node := range parent.children {
+		child, ok := node.(*xmlElement)
+		if ok && child.local == local && scope.with(child.nsDecls).resolve(child.prefix) == nsDSig {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// checkAlgorithm ensures the Algorithm attribute of a child element matches the expected URI
+func checkAlgorithm(parent *xmlElement, scope nsScope, local, expected string) error {
+	child, _ := findDSigChild(parent, scope, local)
+	if child == nil {
+		return fmt.Errorf("%w: missing ds:%s", ErrInvalidSignature, local)
+	}
+	if algorithm, _ := attrValue(child, "Algorithm"); algorithm != expected {
+		return fmt.Errorf("%w: unsupported %s %q", ErrInvalidSignature, local, algorithm)
+	}
+	return nil
+}
+
+// checkTransforms accepts the enveloped-signature transform optionally followed by exclusive C14N
+func checkTransforms(reference *xmlElement, scope nsScope) error {
+	transforms, transformsScope := findDSigChild(reference, scope, "Transforms")
+	if transforms == nil {
+		return fmt.Errorf("%w: missing ds:Transforms", ErrInvalidSignature)
+	}
+
+	var algorithms []string
+	for _, transform := range findDSigChildren(transforms, transformsScope, "Transform") {
+		algorithm, _ := attrValue(transform, "Algorithm")
+		algorithms = append(algorithms, algorithm)
+	}
+
+	switch strings.Join(algorithms, " ") {
+	case AlgorithmEnvelopedSignature, AlgorithmEnvelopedSignature + " " + AlgorithmExcC14N:
+		return nil
+	default:
+		return fmt.Errorf("%w: unsupported transforms %v", ErrInvalidSignature, algorithms)
+	}
+}
+
+// decodeBase64Element decodes the base64 text of a child element, ignoring line breaks
+func decodeBase64Element(parent *xmlElement, scope nsScope, local string) ([]byte, error) {
+	child, _ := findDSigChild(parent, scope, local)
+	if child == nil {
+		return nil, fmt.Errorf("%w: missing ds:%s", ErrInvalidSignature, local)
+	}
+	value, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(textContent(child)), ""))
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid base64 in ds:%s", ErrInvalidSignature, local)
+	}
+	return value, nil
+}
+
+func attrValue(elem *xmlElement, local string) (string, bool) {
+	for _, attr := range elem.attrs {
+		if attr.prefix == "" && attr.local == local {
+			return attr.value, true
+		}
+	}
+	return "", false
+}
+
+func textContent(elem *xmlElement) string {
+	var buf bytes.Buffer
+	for _, node := range elem.children {
+		if text, ok := node.(xmlText); ok {
+			buf.WriteString(string(text))
+		}
+	}
+	return buf.String()
+}
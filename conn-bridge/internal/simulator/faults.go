@@ -0,0 +1,142 @@
+package simulator
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// FaultConfig controls the failures injected into SOAP operations.
+// Rates are probabilities in [0, 1]; the *Next counters force the next N
+// requests to fail and take precedence over the rates.
+type FaultConfig struct {
+	Latency         time.Duration
+	HTTPErrorRate   float64
+	HTTPErrorStatus int // defaults to 503
+	HTTPErrorNext   int
+	SOAPFaultRate   float64
+	SOAPFaultNext   int
+}
+
+// faultConfigJSON is the admin API representation (latency as a Go duration string)
+type faultConfigJSON struct {
+	Latency         string  `json:"latency"`
+	HTTPErrorRate   float64 `json:"httpErrorRate"`
+	HTTPErrorStatus int     `json:"httpErrorStatus"`
+	HTTPErrorNext   int     `json:"httpErrorNext"`
+	SOAPFaultRate   float64 `json:"soapFaultRate"`
+	SOAPFaultNext   int     `json:"soapFaultNext"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (c FaultConfig) MarshalJSON() ([]byte, error) {
+	return json.Marshal(faultConfigJSON{
+		Latency:         c.Latency.String(),
+		HTTPErrorRate:   c.HTTPErrorRate,
+		HTTPErrorStatus: c.HTTPErrorStatus,
+		HTTPErrorNext:   c.HTTPErrorNext,
+		SOAPFaultRate:   c.SOAPFaultRate,
+		SOAPFaultNext:   c.SOAPFaultNext,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (c *FaultConfig) UnmarshalJSON(data []byte) error {
+	var raw faultConfigJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var latency time.Duration
+	if raw.Latency != "" {
+		var err error
+		if latency, err = time.ParseDuration(raw.Latency); err != nil {
+			return fmt.Errorf("invalid latency: %w", err)
+		}
+	}
+
+	*c = FaultConfig{
+		Latency:         latency,
+		HTTPErrorRate:   raw.HTTPErrorRate,
+		HTTPErrorStatus: raw.HTTPErrorStatus,
+		HTTPErrorNext:   raw.HTTPErrorNext,
+		SOAPFaultRate:   raw.SOAPFaultRate,
+		SOAPFaultNext:   raw.SOAPFaultNext,
+	}
+	return c.validate()
+}
+
+func (c FaultConfig) validate() error {
+	if c.Latency < 0 || c.HTTPErrorNext < 0 || c.SOAPFaultNext < 0 {
+		return fmt.Errorf("latency and counters must not be negative")
+	}
+	if c.HTTPErrorRate < 0 || c.HTTPErrorRate > 1 || c.SOAPFaultRate < 0 || c.SOAPFaultRate > 1 {
+		return fmt.Errorf("rates must be between 0 and 1")
+	}
+	if c.HTTPErrorStatus != 0 && (c.HTTPErrorStatus < 400 || c.HTTPErrorStatus > 599) {
+		return fmt.Errorf("httpErrorStatus must be a 4xx or 5xx status")
+	}
+	return nil
+}
+
+// fault is the failure chosen for one request
+type fault int
+
+const (
+	faultNone fault = iota
+	faultHTTPError
+	faultSOAPFault
+)
+
+// faultInjector decides, per request, which failure to inject
+type faultInjector struct {
+	mu     sync.Mutex
+	config FaultConfig
+	random func() float64
+}
+
+func newFaultInjector(config FaultConfig) *faultInjector {
+	return &faultInjector{config: config, random: rand.Float64}
+}
+
+func (f *faultInjector) get() FaultConfig {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.config
+}
+
+func (f *faultInjector) set(config FaultConfig) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.config = config
+}
+
+// next returns the latency to apply and the fault for the current request,
+// consuming the forced-failure counters
+func (f *faultInjector) next() (time.Duration, fault, int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	status := f.config.HTTPErrorStatus
+	if status == 0 {
+		status = http.StatusServiceUnavailable
+	}
+
+	switch {
+	case f.config.HTTPErrorNext > 0:
+		f.config.HTTPErrorNext--
+		return f.config.Latency, faultHTTPError, status
+	case f.config.SOAPFaultNext > 0:
+		f.config.SOAPFaultNext--
+		return f.config.Latency, faultSOAPFault, http.StatusInternalServerError
+	case f.config.HTTPErrorRate > 0 && f.random() < f.config.HTTPErrorRate:
+		return f.config.Latency, faultHTTPError, status
+	case f.config.SOAPFaultRate > 0 && f.random() < f.config.SOAPFaultRate:
+		return f.config.Latency, faultSOAPFault, http.StatusInternalServerError
+	default:
+		return f.config.Latency, faultNone, http.StatusOK
+	}
+}
@@ -0,0 +1,37 @@
+package simulator
+
+import (
+	"encoding/xml"
+
+	xmlstructs "github.com/lbpay-lab/conn-bridge/internal/xml"
+)
+
+// Messages accepted by the simulator that have no counterpart in internal/xml yet.
+// They follow the same conventions: one root element per operation, ResponseTime
+// and CorrelationId in every response.
+
+// getClaimRequest matches xml.GetClaimRequestToXML
+type getClaimRequest struct {
+	XMLName   xml.Name `xml:"GetClaimRequest"`
+	ClaimId   string   `xml:"ClaimId"`
+	RequestId string   `xml:"RequestId"`
+}
+
+type getClaimResponse struct {
+	XMLName       xml.Name            `xml:"GetClaimResponse"`
+	ResponseTime  string              `xml:"ResponseTime"`
+	CorrelationId string              `xml:"CorrelationId"`
+	Claim         xmlstructs.XMLClaim `xml:"Claim"`
+}
+
+type acknowledgeClaimRequest struct {
+	XMLName xml.Name `xml:"AcknowledgeClaimRequest"`
+	ClaimId string   `xml:"ClaimId"`
+}
+
+type acknowledgeClaimResponse struct {
+	XMLName       xml.Name            `xml:"AcknowledgeClaimResponse"`
+	ResponseTime  string              `xml:"ResponseTime"`
+	CorrelationId string              `xml:"CorrelationId"`
+	Claim         xmlstructs.XMLClaim `xml:"Claim"`
+}
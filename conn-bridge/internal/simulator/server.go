@@ -0,0 +1,570 @@
+// Package simulator implements a stateful Bacen DICT simulator speaking the
+// SOAP/XML dialect of internal/xml, for local development and integration tests.
+package simulator
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lbpay-lab/conn-bridge/internal/infrastructure/signer"
+	xmlstructs "github.com/lbpay-lab/conn-bridge/internal/xml"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	soapEnvelopeNS = "http://www.w3.org/2003/05/soap-envelope"
+	bacenDictNS    = "http://www.bcb.gov.br/dict/api/v1"
+
+	maxRequestSize = 4 << 20
+)
+
+// SignatureVerifier checks the signing certificate of a request;
+// signer.ResponseVerifier satisfies it with an ICP-Brasil trust store
+type SignatureVerifier interface {
+	VerifyResponse(xmlData []byte) (*x509.Certificate, error)
+}
+
+// ResponseSigner signs response bodies; signer.NativeXMLSigner satisfies it
+type ResponseSigner interface {
+	SignXML(ctx context.Context, xmlData string) (string, error)
+}
+
+// Config holds the simulator configuration
+type Config struct {
+	Logger *logrus.Logger
+	Faults FaultConfig
+
+	// RequireSignedRequests rejects requests without a valid enveloped XML-DSig signature
+	RequireSignedRequests bool
+	// RequestVerifier additionally checks the request certificate chain (optional)
+	RequestVerifier SignatureVerifier
+	// ResponseSigner signs response bodies, as the bridge verifies Bacen signatures (optional)
+	ResponseSigner ResponseSigner
+}
+
+// operation handles one request body and returns the response message
+type operation func(ex *exchange, body []byte) (interface{}, error)
+
+// exchange carries the per-request metadata echoed in every response
+type exchange struct {
+	responseTime  string
+	correlationID string
+}
+
+// Server is the simulator HTTP handler. SOAP operations are dispatched by the
+// root element of the body, whatever the path, like the bridge sends them.
+type Server struct {
+	store      *Store
+	faults     *faultInjector
+	logger     *logrus.Logger
+	config     *Config
+	operations map[string]operation
+	mux        *http.ServeMux
+}
+
+// NewServer creates a simulator with an empty store
+func NewServer(config *Config) *Server {
+	if config == nil {
+		config = &Config{}
+	}
+	if config.Logger == nil {
+		config.Logger = logrus.New()
+		config.Logger.SetLevel(logrus.InfoLevel)
+	}
+
+	s := &Server{
+		store:  NewStore(),
+		faults: newFaultInjector(config.Faults),
+		logger: config.Logger,
+		config: config,
+	}
+	s.registerOperations()
+
+	s.mux = http.NewServeMux()
+	s.mux.HandleFunc("/health", s.handleHealth)
+	s.mux.HandleFunc("/simulator/state", s.handleState)
+	s.mux.HandleFunc("/simulator/reset", s.handleReset)
+	s.mux.HandleFunc("/simulator/faults", s.handleFaults)
+	s.mux.HandleFunc("/simulator/clock/advance", s.handleAdvanceClock)
+	s.mux.HandleFunc("/", s.handleSOAP)
+
+	return s
+}
+
+// Store returns the simulator state, for tests that seed or inspect it directly
+func (s *Server) Store() *Store {
+	return s.store
+}
+
+// SetFaults replaces the fault injection configuration
+func (s *Server) SetFaults(config FaultConfig) {
+	s.faults.set(config)
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) registerOperations() {
+	s.operations = map[string]operation{
+		// Entries
+		"CreateEntryRequest": handle(func(ex *exchange, req xmlstructs.XMLCreateEntryRequest) (interface{}, error) {
+			created, err := s.store.CreateEntry(req.Entry, req.RequestId)
+			if err != nil {
+				return nil, err
+			}
+			return xmlstructs.XMLCreateEntryResponse{ResponseTime: ex.responseTime, CorrelationId: ex.correlationID, Entry: created}, nil
+		}),
+		"UpdateEntryRequest": handle(func(ex *exchange, req xmlstructs.XMLUpdateEntryRequest) (interface{}, error) {
+			updated, err := s.store.UpdateEntry(req.Key, req.KeyType, req.NewAccount, req.RequestId)
+			if err != nil {
+				return nil, err
+			}
+			return xmlstructs.XMLUpdateEntryResponse{ResponseTime: ex.responseTime, CorrelationId: ex.correlationID, Entry: updated}, nil
+		}),
+		"DeleteEntryRequest": handle(func(ex *exchange, req xmlstructs.XMLDeleteEntryRequest) (interface{}, error) {
+			if err := s.store.DeleteEntry(req.Key, req.KeyType); err != nil {
+				return nil, err
+			}
+			return xmlstructs.XMLDeleteEntryResponse{ResponseTime: ex.responseTime, CorrelationId: ex.correlationID, Deleted: true, Key: req.Key, KeyType: req.KeyType}, nil
+		}),
+		"GetEntryRequest": handle(func(ex *exchange, req xmlstructs.XMLGetEntryRequest) (interface{}, error) {
+			key := req.Key
+			if key == "" {
+				key = req.EntryId
+			}
+			found, err := s.store.GetEntry(key, req.KeyType)
+			if err != nil {
+				return nil, err
+			}
+			return xmlstructs.XMLGetEntryResponse{ResponseTime: ex.responseTime, CorrelationId: ex.correlationID, Entry: found}, nil
+		}),
+
+		// Directory
+		"GetDirectoryRequest": handle(func(ex *exchange, req xmlstructs.XMLGetDirectoryRequest) (interface{}, error) {
+			entries, next, total, err := s.store.ListEntries(req.Filter, req.Pagination.Cursor, int(req.Pagination.Limit))
+			if err != nil {
+				return nil, err
+			}
+			return xmlstructs.XMLGetDirectoryResponse{
+				ResponseTime: ex.responseTime, CorrelationId: ex.correlationID,
+				Entries: entries, HasMoreElements: next != "", NextCursor: next, TotalCount: int32(total),
+			}, nil
+		}),
+		"SearchEntriesRequest": handle(func(ex *exchange, req xmlstructs.XMLSearchEntriesRequest) (interface{}, error) {
+			entries, next, total, err := s.store.ListEntries(req.Filter, req.Pagination.Cursor, int(req.Pagination.Limit))
+			if err != nil {
+				return nil, err
+			}
+			return xmlstructs.XMLSearchEntriesResponse{
+				ResponseTime: ex.responseTime, CorrelationId: ex.correlationID,
+				Entries: entries, HasMoreElements: next != "", NextCursor: next, TotalCount: int32(total),
+			}, nil
+		}),
+
+		// Claims
+		"CreateClaimRequest": handle(func(ex *exchange, req xmlstructs.XMLCreateClaimRequest) (interface{}, error) {
+			created, err := s.store.CreateClaim(req.Claim)
+			if err != nil {
+				return nil, err
+			}
+			return xmlstructs.XMLCreateClaimResponse{ResponseTime: ex.responseTime, CorrelationId: ex.correlationID, Claim: created}, nil
+		}),
+		"GetClaimRequest": handle(func(ex *exchange, req getClaimRequest) (interface{}, error) {
+			found, err := s.store.GetClaim(req.ClaimId)
+			if err != nil {
+				return nil, err
+			}
+			return getClaimResponse{ResponseTime: ex.responseTime, CorrelationId: ex.correlationID, Claim: found}, nil
+		}),
+		"ListClaimsRequest": handle(func(ex *exchange, req xmlstructs.XMLListClaimsRequest) (interface{}, error) {
+			claims, next, err := s.store.ListClaims(req.Filter, req.Pagination.Cursor, int(req.Pagination.Limit))
+			if err != nil {
+				return nil, err
+			}
+			return xmlstructs.XMLListClaimsResponse{
+				ResponseTime: ex.responseTime, CorrelationId: ex.correlationID,
+				Claims: claims, HasMoreElements: next != "", NextCursor: next,
+			}, nil
+		}),
+		"AcknowledgeClaimRequest": handle(func(ex *exchange, req acknowledgeClaimRequest) (interface{}, error) {
+			updated, err := s.store.AcknowledgeClaim(req.ClaimId)
+			if err != nil {
+				return nil, err
+			}
+			return acknowledgeClaimResponse{ResponseTime: ex.responseTime, CorrelationId: ex.correlationID, Claim: updated}, nil
+		}),
+		"ConfirmClaimRequest": handle(func(ex *exchange, req xmlstructs.XMLConfirmClaimRequest) (interface{}, error) {
+			updated, err := s.store.ConfirmClaim(req.ClaimId)
+			if err != nil {
+				return nil, err
+			}
+			return xmlstructs.XMLConfirmClaimResponse{ResponseTime: ex.responseTime, CorrelationId: ex.correlationID, Claim: updated}, nil
+		}),
+		"CancelClaimRequest": handle(func(ex *exchange, req xmlstructs.XMLCancelClaimRequest) (interface{}, error) {
+			updated, err := s.store.CancelClaim(req.ClaimId)
+			if err != nil {
+				return nil, err
+			}
+			return xmlstructs.XMLCancelClaimResponse{ResponseTime: ex.responseTime, CorrelationId: ex.correlationID, Claim: updated}, nil
+		}),
+		"CompleteClaimRequest": handle(func(ex *exchange, req xmlstructs.XMLCompleteClaimRequest) (interface{}, error) {
+			updated, err := s.store.CompleteClaim(req.ClaimId)
+			if err != nil {
+				return nil, err
+			}
+			return xmlstructs.XMLCompleteClaimResponse{ResponseTime: ex.responseTime, CorrelationId: ex.correlationID, Claim: updated}, nil
+		}),
+
+		// Infractions
+		"CreateInfractionReportRequest": handle(func(ex *exchange, req xmlstructs.XMLCreateInfractionReportRequest) (interface{}, error) {
+			created, err := s.store.CreateInfraction(req.Participant, req.InfractionReport)
+			if err != nil {
+				return nil, err
+			}
+			return xmlstructs.XMLCreateInfractionReportResponse{ResponseTime: ex.responseTime, CorrelationId: ex.correlationID, InfractionReport: created}, nil
+		}),
+		"GetInfractionReportRequest": handle(func(ex *exchange, req xmlstructs.XMLGetInfractionReportRequest) (interface{}, error) {
+			report, err := s.store.GetInfraction(req.InfractionReportId)
+			if err != nil {
+				return nil, err
+			}
+			return xmlstructs.XMLGetInfractionReportResponse{ResponseTime: ex.responseTime, CorrelationId: ex.correlationID, InfractionReport: report}, nil
+		}),
+		"ListInfractionReportsRequest": handle(func(ex *exchange, req xmlstructs.XMLListInfractionReportsRequest) (interface{}, error) {
+			reports, next, err := s.store.ListInfractions(req.Filter, req.Pagination.Cursor, int(req.Pagination.Limit))
+			if err != nil {
+				return nil, err
+			}
+			return xmlstructs.XMLListInfractionReportsResponse{
+				ResponseTime: ex.responseTime, CorrelationId: ex.correlationID,
+				InfractionReports: reports, HasMoreElements: next != "", NextCursor: next,
+			}, nil
+		}),
+		"AcknowledgeInfractionReportRequest": handle(func(ex *exchange, req xmlstructs.XMLAcknowledgeInfractionReportRequest) (interface{}, error) {
+			report, err := s.store.AcknowledgeInfraction(req.InfractionReportId, req.Participant)
+			if err != nil {
+				return nil, err
+			}
+			return xmlstructs.XMLAcknowledgeInfractionReportResponse{ResponseTime: ex.responseTime, CorrelationId: ex.correlationID, InfractionReport: report}, nil
+		}),
+		"CloseInfractionReportRequest": handle(func(ex *exchange, req xmlstructs.XMLCloseInfractionReportRequest) (interface{}, error) {
+			report, err := s.store.CloseInfraction(req.InfractionReportId, req.Participant, req.AnalysisResult, req.AnalysisDetails)
+			if err != nil {
+				return nil, err
+			}
+			return xmlstructs.XMLCloseInfractionReportResponse{ResponseTime: ex.responseTime, CorrelationId: ex.correlationID, InfractionReport: report}, nil
+		}),
+		"CancelInfractionReportRequest": handle(func(ex *exchange, req xmlstructs.XMLCancelInfractionReportRequest) (interface{}, error) {
+			report, err := s.store.CancelInfraction(req.InfractionReportId, req.Participant)
+			if err != nil {
+				return nil, err
+			}
+			return xmlstructs.XMLCancelInfractionReportResponse{ResponseTime: ex.responseTime, CorrelationId: ex.correlationID, InfractionReport: report}, nil
+		}),
+
+		// VSync
+		"VerifySyncRequest": handle(func(ex *exchange, req xmlstructs.XMLVerifySyncRequest) (interface{}, error) {
+			count, vsync := s.store.VerifySync(req.Participant, req.KeyType)
+			return xmlstructs.XMLVerifySyncResponse{
+				ResponseTime: ex.responseTime, CorrelationId: ex.correlationID,
+				Participant: req.Participant, KeyType: req.KeyType, EntryCount: int64(count), VSync: vsync,
+			}, nil
+		}),
+		"ListCidsRequest": handle(func(ex *exchange, req xmlstructs.XMLListCidsRequest) (interface{}, error) {
+			return xmlstructs.XMLListCidsResponse{
+				ResponseTime: ex.responseTime, CorrelationId: ex.correlationID,
+				Cids: s.store.ListCIDs(req.Participant, req.KeyType),
+			}, nil
+		}),
+		"GetEntryByCidRequest": handle(func(ex *exchange, req xmlstructs.XMLGetEntryByCidRequest) (interface{}, error) {
+			found, err := s.store.EntryByCID(req.Participant, req.Cid)
+			if err != nil {
+				return nil, err
+			}
+			return xmlstructs.XMLGetEntryByCidResponse{ResponseTime: ex.responseTime, CorrelationId: ex.correlationID, Entry: found}, nil
+		}),
+	}
+}
+
+// handle decodes the request body into Req before calling fn
+func handle[Req any](fn func(ex *exchange, req Req) (interface{}, error)) operation {
+	return func(ex *exchange, body []byte) (interface{}, error) {
+		var req Req
+		if err := xml.Unmarshal(body, &req); err != nil {
+			return nil, newError(http.StatusBadRequest, ErrCodeInvalidRequest, "malformed request: %v", err)
+		}
+		return fn(ex, req)
+	}
+}
+
+// handleSOAP serves every SOAP operation
+func (s *Server) handleSOAP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	latency, injected, status := s.faults.next()
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	ex := &exchange{
+		responseTime:  formatTime(s.store.Now()),
+		correlationID: r.Header.Get("X-Correlation-ID"),
+	}
+	if ex.correlationID == "" {
+		ex.correlationID = uuid.NewString()
+	}
+
+	switch injected {
+	case faultHTTPError:
+		s.logger.WithField("status", status).Warn("Injecting HTTP error")
+		http.Error(w, http.StatusText(status), status)
+		return
+	case faultSOAPFault:
+		s.logger.Warn("Injecting SOAP fault")
+		s.writeFault(w, "soap:Receiver", newError(status, "InternalError", "injected fault"))
+		return
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxRequestSize))
+	if err != nil {
+		s.writeFault(w, "soap:Sender", newError(http.StatusBadRequest, ErrCodeInvalidRequest, "failed to read request"))
+		return
+	}
+
+	body, rootName, err := extractBody(data)
+	if err != nil {
+		s.writeFault(w, "soap:Sender", newError(http.StatusBadRequest, ErrCodeInvalidRequest, "%v", err))
+		return
+	}
+
+	logger := s.logger.WithFields(logrus.Fields{
+		"operation":     rootName,
+		"correlationId": ex.correlationID,
+	})
+
+	op, ok := s.operations[rootName]
+	if !ok {
+		logger.Warn("Unknown operation")
+		s.writeFault(w, "soap:Sender", newError(http.StatusBadRequest, ErrCodeInvalidRequest, "unknown operation %s", rootName))
+		return
+	}
+
+	if err := s.checkSignature(body); err != nil {
+		logger.WithError(err).Warn("Rejected request signature")
+		s.writeFault(w, "soap:Sender", newError(http.StatusForbidden, ErrCodeForbidden, "%v", err))
+		return
+	}
+
+	response, err := op(ex, body)
+	if err != nil {
+		var dictErr *Error
+		if !errors.As(err, &dictErr) {
+			dictErr = newError(http.StatusInternalServerError, "InternalError", "%v", err)
+		}
+		logger.WithField("code", dictErr.Code).Info("Operation rejected")
+		s.writeFault(w, "soap:Sender", dictErr)
+		return
+	}
+
+	payload, err := s.encodeResponse(r.Context(), response)
+	if err != nil {
+		logger.WithError(err).Error("Failed to encode response")
+		s.writeFault(w, "soap:Receiver", newError(http.StatusInternalServerError, "InternalError", "%v", err))
+		return
+	}
+
+	logger.Debug("Operation completed")
+	w.Header().Set("Content-Type", "application/soap+xml; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(payload)
+}
+
+// extractBody returns the operation element from a SOAP envelope or a bare XML
+// document, and the name of its root element
+func extractBody(data []byte) ([]byte, string, error) {
+	rootName, err := rootElement(data)
+	if err != nil {
+		return nil, "", err
+	}
+	if rootName != "Envelope" {
+		return data, rootName, nil
+	}
+
+	var envelope struct {
+		Body struct {
+			Content []byte `xml:",innerxml"`
+		} `xml:"Body"`
+	}
+	if err := xml.Unmarshal(data, &envelope); err != nil {
+		return nil, "", fmt.Errorf("malformed SOAP envelope: %v", err)
+	}
+
+	// The bridge embeds the signed document, XML declaration included
+	body := bytes.TrimSpace(envelope.Body.Content)
+	if bytes.HasPrefix(body, []byte("<?xml")) {
+		end := bytes.Index(body, []byte("?>"))
+		if end < 0 {
+			return nil, "", errors.New("malformed XML declaration in SOAP body")
+		}
+		body = bytes.TrimSpace(body[end+2:])
+	}
+
+	rootName, err = rootElement(body)
+	if err != nil {
+		return nil, "", fmt.Errorf("empty SOAP body: %v", err)
+	}
+	return body, rootName, nil
+}
+
+func rootElement(data []byte) (string, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", fmt.Errorf("no root element: %v", err)
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start.Name.Local, nil
+		}
+	}
+}
+
+// checkSignature enforces signed requests when configured
+func (s *Server) checkSignature(body []byte) error {
+	if !s.config.RequireSignedRequests {
+		return nil
+	}
+	if s.config.RequestVerifier != nil {
+		_, err := s.config.RequestVerifier.VerifyResponse(body)
+		return err
+	}
+	_, err := signer.VerifySignature(string(body))
+	return err
+}
+
+// encodeResponse marshals the response, signs it when configured and wraps it in a SOAP envelope
+func (s *Server) encodeResponse(ctx context.Context, response interface{}) ([]byte, error) {
+	body, err := xml.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	if s.config.ResponseSigner != nil {
+		signed, err := s.config.ResponseSigner.SignXML(ctx, string(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign response: %w", err)
+		}
+		body = []byte(signed)
+	}
+
+	return wrapEnvelope(body), nil
+}
+
+func wrapEnvelope(body []byte) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<soap:Envelope xmlns:soap="` + soapEnvelopeNS + `" xmlns:dict="` + bacenDictNS + `"><soap:Body>`)
+	buf.Write(body)
+	buf.WriteString(`</soap:Body></soap:Envelope>`)
+	return buf.Bytes()
+}
+
+// writeFault writes a SOAP 1.2 Fault carrying the Bacen error code in Detail
+func (s *Server) writeFault(w http.ResponseWriter, code string, dictErr *Error) {
+	var reason, detail bytes.Buffer
+	xml.EscapeText(&reason, []byte(dictErr.Message))
+	xml.EscapeText(&detail, []byte(dictErr.Code))
+
+	fault := `<soap:Fault><soap:Code><soap:Value>` + code + `</soap:Value></soap:Code>` +
+		`<soap:Reason><soap:Text xml:lang="en">` + reason.String() + `</soap:Text></soap:Reason>` +
+		`<soap:Detail>` + detail.String() + `</soap:Detail></soap:Fault>`
+
+	w.Header().Set("Content-Type", "application/soap+xml; charset=utf-8")
+	w.WriteHeader(dictErr.Status)
+	w.Write(wrapEnvelope([]byte(fault)))
+}
+
+// ========== ADMIN API ==========
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "UP"})
+}
+
+// handleState returns the whole simulator state (GET)
+func (s *Server) handleState(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.store.Snapshot())
+}
+
+// handleReset drops all state (POST)
+func (s *Server) handleReset(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	s.store.Reset()
+	s.logger.Info("Simulator state reset")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleFaults reads (GET) or replaces (PUT) the fault injection configuration
+func (s *Server) handleFaults(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, s.faults.get())
+	case http.MethodPut:
+		var config FaultConfig
+		if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.faults.set(config)
+		s.logger.WithField("faults", config).Info("Fault injection updated")
+		writeJSON(w, http.StatusOK, config)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleAdvanceClock moves the simulated clock forward (POST ?by=168h)
+func (s *Server) handleAdvanceClock(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	by, err := time.ParseDuration(r.URL.Query().Get("by"))
+	if err != nil || by <= 0 {
+		http.Error(w, "query parameter by must be a positive duration", http.StatusBadRequest)
+		return
+	}
+	now := s.store.Advance(by)
+	s.logger.WithField("now", now).Info("Simulator clock advanced")
+	writeJSON(w, http.StatusOK, map[string]string{"now": formatTime(now)})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,640 @@
+package simulator
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lbpay-lab/conn-bridge/internal/infrastructure/bacen"
+	"github.com/lbpay-lab/conn-bridge/internal/infrastructure/signer"
+	xmlstructs "github.com/lbpay-lab/conn-bridge/internal/xml"
+	"github.com/sirupsen/logrus"
+	"github.com/sony/gobreaker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	ispbLBPay = "12345678"
+	ispbOther = "87654321"
+)
+
+func newTestServer(t *testing.T, config *Config) (*Server, *httptest.Server) {
+	t.Helper()
+	if config == nil {
+		config = &Config{}
+	}
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	config.Logger = logger
+
+	sim := NewServer(config)
+	server := httptest.NewServer(sim)
+	t.Cleanup(server.Close)
+	return sim, server
+}
+
+// soapResult is a parsed simulator response: the body element or the fault
+type soapResult struct {
+	status int
+	body   string
+	code   string // fault Detail
+}
+
+func postSOAP(t *testing.T, url string, message interface{}) soapResult {
+	t.Helper()
+	body, err := xml.Marshal(message)
+	require.NoError(t, err)
+	return postRaw(t, url, string(wrapEnvelope([]byte(xml.Header+string(body)))))
+}
+
+func postRaw(t *testing.T, url, payload string) soapResult {
+	t.Helper()
+	resp, err := http.Post(url+"/api/v1/dict/entries", "application/soap+xml", strings.NewReader(payload))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var envelope struct {
+		Body struct {
+			Content []byte `xml:",innerxml"`
+			Fault   struct {
+				Detail string `xml:"Detail"`
+			} `xml:"Fault"`
+		} `xml:"Body"`
+	}
+	result := soapResult{status: resp.StatusCode}
+	if xml.Unmarshal(data, &envelope) == nil {
+		result.body = string(envelope.Body.Content)
+		result.code = envelope.Body.Fault.Detail
+	}
+	return result
+}
+
+func testEntry(key, keyType, participant, account, taxID string) xmlstructs.XMLEntry {
+	ownerType := "NATURAL_PERSON"
+	if len(taxID) == 14 {
+		ownerType = "LEGAL_PERSON"
+	}
+	return xmlstructs.XMLEntry{
+		Key:     key,
+		KeyType: keyType,
+		Account: xmlstructs.XMLAccount{Participant: participant, Branch: "0001", AccountNumber: account, AccountType: "CACC"},
+		Owner:   xmlstructs.XMLOwner{Type: ownerType, TaxIdNumber: taxID, Name: "Maria Silva"},
+	}
+}
+
+func createEntry(t *testing.T, url string, e xmlstructs.XMLEntry) soapResult {
+	t.Helper()
+	return postSOAP(t, url, xmlstructs.XMLCreateEntryRequest{Entry: e, RequestId: "req-" + e.Key})
+}
+
+func TestSimulator_EntryRules(t *testing.T) {
+	_, server := newTestServer(t, nil)
+
+	email := testEntry("maria@example.com", "EMAIL", ispbLBPay, "1001", "12345678901")
+	result := createEntry(t, server.URL, email)
+	require.Equal(t, http.StatusOK, result.status, result.body)
+	var created xmlstructs.XMLCreateEntryResponse
+	require.NoError(t, xml.Unmarshal([]byte(result.body), &created))
+	assert.Equal(t, "maria@example.com", created.Entry.Key)
+	assert.NotEmpty(t, created.CorrelationId)
+	assert.NotEmpty(t, created.Entry.KeyOwnershipDate)
+
+	tests := []struct {
+		name   string
+		entry  xmlstructs.XMLEntry
+		status int
+		code   string
+	}{
+		{"duplicate at same participant", email, http.StatusConflict, ErrCodeEntryAlreadyExists},
+		{"registered at other participant", testEntry("maria@example.com", "EMAIL", ispbOther, "2001", "12345678901"), http.StatusConflict, ErrCodeEntryOwnedByOther},
+		{"CPF of someone else", testEntry("98765432100", "CPF", ispbLBPay, "1001", "12345678901"), http.StatusBadRequest, ErrCodeEntryKeyMismatch},
+		{"CPF for legal person", testEntry("12345678000190", "CPF", ispbLBPay, "1001", "12345678000190"), http.StatusBadRequest, ErrCodeInvalidRequest},
+		{"malformed phone", testEntry("11999990000", "PHONE", ispbLBPay, "1001", "12345678901"), http.StatusBadRequest, ErrCodeInvalidRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := createEntry(t, server.URL, tt.entry)
+			assert.Equal(t, tt.status, result.status)
+			assert.Equal(t, tt.code, result.code)
+		})
+	}
+
+	// Moving a key to another participant requires a claim
+	moved := email.Account
+	moved.Participant = ispbOther
+	result = postSOAP(t, server.URL, xmlstructs.XMLUpdateEntryRequest{Key: email.Key, KeyType: "EMAIL", NewAccount: moved})
+	assert.Equal(t, http.StatusForbidden, result.status)
+
+	newAccount := email.Account
+	newAccount.AccountNumber = "1002"
+	result = postSOAP(t, server.URL, xmlstructs.XMLUpdateEntryRequest{Key: email.Key, KeyType: "EMAIL", NewAccount: newAccount})
+	require.Equal(t, http.StatusOK, result.status)
+
+	result = postSOAP(t, server.URL, xmlstructs.XMLGetEntryRequest{Key: email.Key, KeyType: "EMAIL"})
+	require.Equal(t, http.StatusOK, result.status)
+	var found xmlstructs.XMLGetEntryResponse
+	require.NoError(t, xml.Unmarshal([]byte(result.body), &found))
+	assert.Equal(t, "1002", found.Entry.Account.AccountNumber)
+
+	result = postSOAP(t, server.URL, xmlstructs.XMLDeleteEntryRequest{Key: email.Key, KeyType: "EMAIL"})
+	require.Equal(t, http.StatusOK, result.status)
+	result = postSOAP(t, server.URL, xmlstructs.XMLGetEntryRequest{Key: email.Key, KeyType: "EMAIL"})
+	assert.Equal(t, http.StatusNotFound, result.status)
+	assert.Equal(t, ErrCodeEntryNotFound, result.code)
+}
+
+func TestStore_KeysPerAccountLimit(t *testing.T) {
+	store := NewStore()
+
+	for i := 0; i < maxKeysNaturalPerson; i++ {
+		_, err := store.CreateEntry(testEntry(fmt.Sprintf("+55119999900%02d", i), "PHONE", ispbLBPay, "1001", "12345678901"), "req-1")
+		require.NoError(t, err)
+	}
+	_, err := store.CreateEntry(testEntry("+5511999990099", "PHONE", ispbLBPay, "1001", "12345678901"), "req-1")
+	var dictErr *Error
+	require.ErrorAs(t, err, &dictErr)
+	assert.Equal(t, ErrCodeEntryLimitExceeded, dictErr.Code)
+
+	// Other accounts are not affected
+	_, err = store.CreateEntry(testEntry("+5511999990099", "PHONE", ispbLBPay, "1002", "12345678901"), "req-1")
+	assert.NoError(t, err)
+}
+
+func TestStore_ClaimPeriods(t *testing.T) {
+	claimer := xmlstructs.XMLAccount{Participant: ispbOther, Branch: "0001", AccountNumber: "2001", AccountType: "CACC"}
+
+	t.Run("ownership claim without answer is confirmed, then completed", func(t *testing.T) {
+		store := NewStore()
+		_, err := store.CreateEntry(testEntry("+5511988887777", "PHONE", ispbLBPay, "1001", "12345678901"), "req-1")
+		require.NoError(t, err)
+
+		c, err := store.CreateClaim(xmlstructs.XMLClaim{
+			Type: ClaimTypeOwnership, Key: "+5511988887777", KeyType: "PHONE",
+			ClaimerAccount: claimer,
+			Claimer:        xmlstructs.XMLOwner{Type: "NATURAL_PERSON", TaxIdNumber: "98765432100", Name: "Joao Souza"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, ClaimStatusOpen, c.Status)
+		assert.Equal(t, ispbLBPay, c.DonorParticipant)
+
+		// The key is locked while the claim is open
+		assert.Error(t, store.DeleteEntry("+5511988887777", "PHONE"))
+
+		store.Advance(ClaimResolutionPeriod)
+		c, err = store.GetClaim(c.ClaimId)
+		require.NoError(t, err)
+		assert.Equal(t, ClaimStatusConfirmed, c.Status)
+
+		c, err = store.CompleteClaim(c.ClaimId)
+		require.NoError(t, err)
+		assert.Equal(t, ClaimStatusCompleted, c.Status)
+
+		moved, err := store.GetEntry("+5511988887777", "PHONE")
+		require.NoError(t, err)
+		assert.Equal(t, ispbOther, moved.Account.Participant)
+		assert.Equal(t, "98765432100", moved.Owner.TaxIdNumber)
+	})
+
+	t.Run("portability without answer is cancelled", func(t *testing.T) {
+		store := NewStore()
+		_, err := store.CreateEntry(testEntry("maria@example.com", "EMAIL", ispbLBPay, "1001", "12345678901"), "req-1")
+		require.NoError(t, err)
+
+		c, err := store.CreateClaim(xmlstructs.XMLClaim{
+			Type: ClaimTypePortability, Key: "maria@example.com", KeyType: "EMAIL",
+			ClaimerAccount: claimer,
+			Claimer:        xmlstructs.XMLOwner{Type: "NATURAL_PERSON", TaxIdNumber: "12345678901", Name: "Maria Silva"},
+		})
+		require.NoError(t, err)
+
+		_, err = store.CreateClaim(xmlstructs.XMLClaim{Type: ClaimTypePortability, Key: "maria@example.com", KeyType: "EMAIL", ClaimerAccount: claimer, Claimer: xmlstructs.XMLOwner{TaxIdNumber: "12345678901"}})
+		var dictErr *Error
+		require.ErrorAs(t, err, &dictErr)
+		assert.Equal(t, ErrCodeClaimAlreadyOpen, dictErr.Code)
+
+		store.Advance(ClaimResolutionPeriod + time.Minute)
+		c, err = store.GetClaim(c.ClaimId)
+		require.NoError(t, err)
+		assert.Equal(t, ClaimStatusCancelled, c.Status)
+
+		_, err = store.CompleteClaim(c.ClaimId)
+		require.ErrorAs(t, err, &dictErr)
+		assert.Equal(t, ErrCodeInvalidClaimStatus, dictErr.Code)
+	})
+}
+
+func TestSimulator_DirectoryPagination(t *testing.T) {
+	sim, server := newTestServer(t, nil)
+	for i := 0; i < 5; i++ {
+		_, err := sim.Store().CreateEntry(testEntry(fmt.Sprintf("user%d@example.com", i), "EMAIL", ispbLBPay, fmt.Sprintf("10%02d", i), "12345678901"), "req-1")
+		require.NoError(t, err)
+	}
+	_, err := sim.Store().CreateEntry(testEntry("other@example.com", "EMAIL", ispbOther, "2001", "12345678901"), "req-1")
+	require.NoError(t, err)
+
+	var keys []string
+	cursor := ""
+	for page := 0; page < 5; page++ {
+		result := postSOAP(t, server.URL, xmlstructs.XMLGetDirectoryRequest{
+			Filter:     xmlstructs.XMLEntryFilter{Participant: ispbLBPay},
+			Pagination: xmlstructs.XMLPagination{Limit: 2, Cursor: cursor},
+		})
+		require.Equal(t, http.StatusOK, result.status, result.body)
+
+		var resp xmlstructs.XMLGetDirectoryResponse
+		require.NoError(t, xml.Unmarshal([]byte(result.body), &resp))
+		assert.Equal(t, int32(5), resp.TotalCount)
+		for _, e := range resp.Entries {
+			keys = append(keys, e.Key)
+			assert.Equal(t, "ACTIVE", e.Status)
+		}
+		if !resp.HasMoreElements {
+			break
+		}
+		cursor = resp.NextCursor
+	}
+	assert.Equal(t, []string{"user0@example.com", "user1@example.com", "user2@example.com", "user3@example.com", "user4@example.com"}, keys)
+
+	result := postSOAP(t, server.URL, xmlstructs.XMLSearchEntriesRequest{Pagination: xmlstructs.XMLPagination{Limit: 2, Cursor: "%%%"}})
+	assert.Equal(t, http.StatusBadRequest, result.status)
+}
+
+func TestStore_VerifySync(t *testing.T) {
+	store := NewStore()
+	count, empty := store.VerifySync(ispbLBPay, "EMAIL")
+	assert.Zero(t, count)
+
+	_, err := store.CreateEntry(testEntry("a@example.com", "EMAIL", ispbLBPay, "1001", "12345678901"), "req-1")
+	require.NoError(t, err)
+	_, err = store.CreateEntry(testEntry("b@example.com", "EMAIL", ispbLBPay, "1001", "12345678901"), "req-1")
+	require.NoError(t, err)
+	_, err = store.CreateEntry(testEntry("+5511977776666", "PHONE", ispbLBPay, "1001", "12345678901"), "req-1")
+	require.NoError(t, err)
+
+	count, vsync := store.VerifySync(ispbLBPay, "EMAIL")
+	assert.Equal(t, 2, count)
+	assert.NotEqual(t, empty, vsync)
+
+	cids := store.ListCIDs(ispbLBPay, "EMAIL")
+	require.Len(t, cids, 2)
+
+	account := testEntry("", "", ispbLBPay, "1009", "").Account
+	_, err = store.UpdateEntry("a@example.com", "EMAIL", account, "req-2")
+	require.NoError(t, err)
+	_, changed := store.VerifySync(ispbLBPay, "EMAIL")
+	assert.NotEqual(t, vsync, changed)
+	assert.NotEqual(t, cids, store.ListCIDs(ispbLBPay, "EMAIL"))
+}
+
+// Same vector as conn-dict's TestEntry_CID: both sides must compute the same CID
+func TestEntryCID(t *testing.T) {
+	e := xmlstructs.XMLExtendedEntry{
+		Key:              "maria@example.com",
+		KeyType:          "EMAIL",
+		Account:          xmlstructs.XMLAccount{Participant: "12345678", Branch: "0001", AccountNumber: "1001", AccountType: "CHECKING"},
+		Owner:            xmlstructs.XMLOwner{Type: "NATURAL_PERSON", TaxIdNumber: "12345678901", Name: "Maria Silva"},
+		KeyOwnershipDate: "2025-10-20T08:30:00Z",
+		RequestId:        "a946d533-7f22-42a5-9a9b-e87cd55c0f4d",
+	}
+	assert.Equal(t, "87e2c4ad758546fc122841416d4eb8b06329750f13ccf48c580246f6a38d4c4a", entryCID(e))
+
+	e.RequestId = "0c1f5a52-4c0e-4d5c-9a77-3b3f6d0e2a10"
+	assert.NotEqual(t, "87e2c4ad758546fc122841416d4eb8b06329750f13ccf48c580246f6a38d4c4a", entryCID(e))
+}
+
+func TestSimulator_CidLookup(t *testing.T) {
+	sim, server := newTestServer(t, nil)
+	_, err := sim.Store().CreateEntry(testEntry("a@example.com", "EMAIL", ispbLBPay, "1001", "12345678901"), "req-1")
+	require.NoError(t, err)
+
+	result := postSOAP(t, server.URL, xmlstructs.XMLVerifySyncRequest{Participant: ispbLBPay, KeyType: "EMAIL"})
+	require.Equal(t, http.StatusOK, result.status, result.body)
+	var verify xmlstructs.XMLVerifySyncResponse
+	require.NoError(t, xml.Unmarshal([]byte(result.body), &verify))
+	assert.Equal(t, int64(1), verify.EntryCount)
+
+	result = postSOAP(t, server.URL, xmlstructs.XMLListCidsRequest{Participant: ispbLBPay, KeyType: "EMAIL"})
+	require.Equal(t, http.StatusOK, result.status, result.body)
+	var list xmlstructs.XMLListCidsResponse
+	require.NoError(t, xml.Unmarshal([]byte(result.body), &list))
+	require.Len(t, list.Cids, 1)
+	// A single key: the VSync is its CID
+	assert.Equal(t, verify.VSync, list.Cids[0])
+
+	result = postSOAP(t, server.URL, xmlstructs.XMLGetEntryByCidRequest{Participant: ispbLBPay, Cid: list.Cids[0]})
+	require.Equal(t, http.StatusOK, result.status, result.body)
+	var found xmlstructs.XMLGetEntryByCidResponse
+	require.NoError(t, xml.Unmarshal([]byte(result.body), &found))
+	assert.Equal(t, "a@example.com", found.Entry.Key)
+
+	// CIDs of other participants are not disclosed
+	result = postSOAP(t, server.URL, xmlstructs.XMLGetEntryByCidRequest{Participant: ispbOther, Cid: list.Cids[0]})
+	assert.Equal(t, http.StatusNotFound, result.status)
+}
+
+func TestSimulator_InfractionLifecycle(t *testing.T) {
+	_, server := newTestServer(t, nil)
+
+	result := postSOAP(t, server.URL, xmlstructs.XMLCreateInfractionReportRequest{
+		Participant: ispbLBPay,
+		InfractionReport: xmlstructs.XMLInfractionReport{
+			TransactionId: "E1234567820251018000000001", Reason: "FRAUD", SituationType: "SCAM", ReportedParticipant: ispbOther,
+		},
+	})
+	require.Equal(t, http.StatusOK, result.status, result.body)
+	var created xmlstructs.XMLCreateInfractionReportResponse
+	require.NoError(t, xml.Unmarshal([]byte(result.body), &created))
+	require.NotEmpty(t, created.InfractionReport.Id)
+	assert.Equal(t, ispbLBPay, created.InfractionReport.ReporterParticipant)
+	id := created.InfractionReport.Id
+
+	// Only the reported participant acknowledges and closes
+	result = postSOAP(t, server.URL, xmlstructs.XMLAcknowledgeInfractionReportRequest{InfractionReportId: id, Participant: ispbLBPay})
+	assert.Equal(t, http.StatusForbidden, result.status)
+
+	result = postSOAP(t, server.URL, xmlstructs.XMLAcknowledgeInfractionReportRequest{InfractionReportId: id, Participant: ispbOther})
+	require.Equal(t, http.StatusOK, result.status)
+	assert.Contains(t, result.body, "<AcknowledgeInfractionReportResponse>")
+	assert.Contains(t, result.body, "<Status>ACKNOWLEDGED</Status>")
+
+	result = postSOAP(t, server.URL, xmlstructs.XMLCancelInfractionReportRequest{InfractionReportId: id, Participant: ispbLBPay})
+	assert.Equal(t, http.StatusConflict, result.status)
+	assert.Equal(t, ErrCodeInvalidInfractionState, result.code)
+
+	result = postSOAP(t, server.URL, xmlstructs.XMLCloseInfractionReportRequest{
+		InfractionReportId: id, Participant: ispbOther, AnalysisResult: "AGREED", AnalysisDetails: "account blocked",
+	})
+	require.Equal(t, http.StatusOK, result.status)
+	var closed xmlstructs.XMLCloseInfractionReportResponse
+	require.NoError(t, xml.Unmarshal([]byte(result.body), &closed))
+	assert.Equal(t, InfractionStatusClosed, closed.InfractionReport.Status)
+	assert.Equal(t, "AGREED", closed.InfractionReport.AnalysisResult)
+
+	// Both sides of the report see it
+	for _, participant := range []string{ispbLBPay, ispbOther} {
+		result = postSOAP(t, server.URL, xmlstructs.XMLListInfractionReportsRequest{
+			Filter: xmlstructs.XMLInfractionReportFilter{Participant: participant, Status: InfractionStatusClosed},
+		})
+		require.Equal(t, http.StatusOK, result.status)
+		var list xmlstructs.XMLListInfractionReportsResponse
+		require.NoError(t, xml.Unmarshal([]byte(result.body), &list))
+		require.Len(t, list.InfractionReports, 1)
+		assert.Equal(t, id, list.InfractionReports[0].Id)
+	}
+}
+
+func TestStore_ListInfractionsPagination(t *testing.T) {
+	store := NewStore()
+	for i := 0; i < 3; i++ {
+		_, err := store.CreateInfraction(ispbLBPay, xmlstructs.XMLInfractionReport{
+			TransactionId: fmt.Sprintf("E12345678202510180000000%02d", i), Reason: "FRAUD", SituationType: "SCAM",
+		})
+		require.NoError(t, err)
+		store.Advance(time.Minute)
+	}
+
+	filter := xmlstructs.XMLInfractionReportFilter{Participant: ispbLBPay}
+	page, next, err := store.ListInfractions(filter, "", 2)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	require.NotEmpty(t, next)
+
+	rest, next, err := store.ListInfractions(filter, next, 2)
+	require.NoError(t, err)
+	require.Len(t, rest, 1)
+	assert.Empty(t, next)
+	assert.Equal(t, "E1234567820251018000000002", rest[0].TransactionId)
+
+	filter.ModifiedAfter = page[1].LastModified
+	modified, _, err := store.ListInfractions(filter, "", 10)
+	require.NoError(t, err)
+	require.Len(t, modified, 1)
+
+	_, _, err = store.ListInfractions(filter, "bm90LWFuLWlk", 10)
+	assert.Error(t, err)
+}
+
+func TestStore_ListClaimsByRole(t *testing.T) {
+	store := NewStore()
+	claimer := xmlstructs.XMLAccount{Participant: ispbOther, Branch: "0001", AccountNumber: "2001", AccountType: "CACC"}
+	for i := 0; i < 3; i++ {
+		key := fmt.Sprintf("+55119888877%02d", i)
+		_, err := store.CreateEntry(testEntry(key, "PHONE", ispbLBPay, fmt.Sprintf("10%02d", i), "12345678901"), "req-1")
+		require.NoError(t, err)
+		_, err = store.CreateClaim(xmlstructs.XMLClaim{
+			Type: ClaimTypeOwnership, Key: key, KeyType: "PHONE", ClaimerAccount: claimer,
+			Claimer: xmlstructs.XMLOwner{Type: "NATURAL_PERSON", TaxIdNumber: "98765432100", Name: "Joao Souza"},
+		})
+		require.NoError(t, err)
+		store.Advance(time.Minute)
+	}
+
+	isDonor := true
+	filter := xmlstructs.XMLClaimFilter{Participant: ispbLBPay, IsDonor: &isDonor}
+	page, next, err := store.ListClaims(filter, "", 2)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	require.NotEmpty(t, next)
+	assert.Equal(t, ispbLBPay, page[0].DonorParticipant)
+
+	rest, next, err := store.ListClaims(filter, next, 2)
+	require.NoError(t, err)
+	require.Len(t, rest, 1)
+	assert.Empty(t, next)
+	assert.Equal(t, "+5511988887702", rest[0].Key)
+
+	// The donor never shows up as claimer
+	isClaimer := true
+	asClaimer, _, err := store.ListClaims(xmlstructs.XMLClaimFilter{Participant: ispbLBPay, IsClaimer: &isClaimer}, "", 10)
+	require.NoError(t, err)
+	assert.Empty(t, asClaimer)
+
+	both, _, err := store.ListClaims(xmlstructs.XMLClaimFilter{Participant: ispbOther}, "", 10)
+	require.NoError(t, err)
+	assert.Len(t, both, 3)
+
+	_, err = store.ConfirmClaim(page[0].ClaimId)
+	require.NoError(t, err)
+	filter.ModifiedAfter = rest[0].LastModified
+	modified, _, err := store.ListClaims(filter, "", 10)
+	require.NoError(t, err)
+	require.Len(t, modified, 1)
+	assert.Equal(t, ClaimStatusConfirmed, modified[0].Status)
+}
+
+func TestSimulator_FaultInjection(t *testing.T) {
+	sim, server := newTestServer(t, nil)
+	get := xmlstructs.XMLGetEntryRequest{Key: "none@example.com", KeyType: "EMAIL"}
+
+	sim.SetFaults(FaultConfig{HTTPErrorNext: 1, SOAPFaultNext: 1})
+	assert.Equal(t, http.StatusServiceUnavailable, postSOAP(t, server.URL, get).status)
+	result := postSOAP(t, server.URL, get)
+	assert.Equal(t, http.StatusInternalServerError, result.status)
+	assert.Equal(t, "InternalError", result.code)
+	assert.Equal(t, http.StatusNotFound, postSOAP(t, server.URL, get).status)
+
+	sim.SetFaults(FaultConfig{SOAPFaultRate: 1})
+	assert.Equal(t, http.StatusInternalServerError, postSOAP(t, server.URL, get).status)
+
+	sim.SetFaults(FaultConfig{Latency: 50 * time.Millisecond})
+	start := time.Now()
+	postSOAP(t, server.URL, get)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+}
+
+func TestSimulator_AdminAPI(t *testing.T) {
+	sim, server := newTestServer(t, nil)
+
+	req, err := http.NewRequest(http.MethodPut, server.URL+"/simulator/faults", strings.NewReader(`{"latency":"10ms","httpErrorRate":0.5}`))
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, FaultConfig{Latency: 10 * time.Millisecond, HTTPErrorRate: 0.5}, sim.faults.get())
+
+	req, err = http.NewRequest(http.MethodPut, server.URL+"/simulator/faults", strings.NewReader(`{"soapFaultRate":2}`))
+	require.NoError(t, err)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	_, err = sim.Store().CreateEntry(testEntry("maria@example.com", "EMAIL", ispbLBPay, "1001", "12345678901"), "req-1")
+	require.NoError(t, err)
+	before := sim.Store().Now()
+
+	resp, err = http.Post(server.URL+"/simulator/clock/advance?by=168h", "", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.WithinDuration(t, before.Add(168*time.Hour), sim.Store().Now(), time.Minute)
+
+	resp, err = http.Get(server.URL + "/simulator/state")
+	require.NoError(t, err)
+	var snapshot Snapshot
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&snapshot))
+	resp.Body.Close()
+	require.Len(t, snapshot.Entries, 1)
+
+	resp, err = http.Post(server.URL+"/simulator/reset", "", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Empty(t, sim.Store().Snapshot().Entries)
+	assert.WithinDuration(t, time.Now(), sim.Store().Now(), time.Minute)
+}
+
+// TestSimulator_BridgeSOAPClient runs the bridge SOAP client against the simulator
+// with signed requests and signed responses verified against a trust store
+func TestSimulator_BridgeSOAPClient(t *testing.T) {
+	signerDir := filepath.Join("..", "infrastructure", "signer", "testdata")
+	xmlSigner, err := signer.NewNativeXMLSigner(&signer.NativeConfig{
+		CertPath: filepath.Join(signerDir, "signer.p12"),
+		Password: "changeit",
+	})
+	require.NoError(t, err)
+
+	_, server := newTestServer(t, &Config{
+		RequireSignedRequests: true,
+		ResponseSigner:        xmlSigner,
+	})
+
+	client, err := bacen.NewSOAPClient(&bacen.SOAPClientConfig{
+		BaseURL:        server.URL,
+		DevMode:        true,
+		TrustStorePath: filepath.Join(signerDir, "signer-cert.pem"),
+		Logger:         logrus.New(),
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	request, err := xml.Marshal(xmlstructs.XMLCreateEntryRequest{
+		Entry:     testEntry("12345678901", "CPF", ispbLBPay, "1001", "12345678901"),
+		RequestId: "req-1",
+	})
+	require.NoError(t, err)
+
+	signed, err := xmlSigner.SignXML(ctx, xml.Header+string(request))
+	require.NoError(t, err)
+	envelope, err := client.BuildSOAPEnvelope(signed, "")
+	require.NoError(t, err)
+
+	response, err := client.SendSOAPRequest(ctx, "/api/v1/dict/entries", envelope)
+	require.NoError(t, err)
+	body, err := client.ParseSOAPResponse(response)
+	require.NoError(t, err)
+
+	created, err := xmlstructs.CreateEntryResponseFromXML(body)
+	require.NoError(t, err)
+	assert.Equal(t, "12345678901", created.EntryId)
+	assert.NotNil(t, created.CreatedAt)
+
+	// Unsigned requests are rejected
+	unsigned, err := client.BuildSOAPEnvelope(string(request), "")
+	require.NoError(t, err)
+	_, err = client.SendSOAPRequest(ctx, "/api/v1/dict/entries", unsigned)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "403")
+
+	// A simulator without a response signer fails the bridge verification
+	_, plain := newTestServer(t, nil)
+	plainClient, err := bacen.NewSOAPClient(&bacen.SOAPClientConfig{
+		BaseURL:        plain.URL,
+		DevMode:        true,
+		TrustStorePath: filepath.Join(signerDir, "signer-cert.pem"),
+	})
+	require.NoError(t, err)
+	response, err = plainClient.SendSOAPRequest(ctx, "/api/v1/dict/entries", envelope)
+	require.NoError(t, err)
+	_, err = plainClient.ParseSOAPResponse(response)
+	assert.ErrorIs(t, err, signer.ErrMissingSignature)
+}
+
+// TestSimulator_BridgeSOAPClient_Rejections checks that Bacen business errors reach the
+// bridge as typed faults and don't trip the circuit breaker
+func TestSimulator_BridgeSOAPClient_Rejections(t *testing.T) {
+	_, server := newTestServer(t, nil)
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	client, err := bacen.NewSOAPClient(&bacen.SOAPClientConfig{
+		BaseURL: server.URL,
+		DevMode: true,
+		Logger:  logger,
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	request, err := xml.Marshal(xmlstructs.XMLCreateEntryRequest{
+		Entry:     testEntry("12345678901", "CPF", ispbLBPay, "1001", "12345678901"),
+		RequestId: "req-1",
+	})
+	require.NoError(t, err)
+	envelope, err := client.BuildSOAPEnvelope(string(request), "")
+	require.NoError(t, err)
+
+	_, err = client.SendSOAPRequest(ctx, "/api/v1/dict/entries", envelope)
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		_, err = client.SendSOAPRequest(ctx, "/api/v1/dict/entries", envelope)
+		fault, ok := bacen.AsFaultError(err)
+		require.True(t, ok, "expected a Bacen rejection, got %v", err)
+		assert.Equal(t, ErrCodeEntryAlreadyExists, fault.Code)
+		assert.Equal(t, http.StatusConflict, fault.HTTPStatus)
+		assert.NotEmpty(t, fault.Reason)
+	}
+	assert.Equal(t, gobreaker.StateClosed, client.GetCircuitBreakerState())
+}
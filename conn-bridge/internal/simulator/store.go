@@ -0,0 +1,893 @@
+package simulator
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	xmlstructs "github.com/lbpay-lab/conn-bridge/internal/xml"
+)
+
+// Bacen DICT error codes returned in the SOAP Fault Detail
+const (
+	ErrCodeInvalidRequest         = "InvalidRequest"
+	ErrCodeEntryAlreadyExists     = "EntryAlreadyExists"
+	ErrCodeEntryOwnedByOther      = "EntryKeyOwnedByDifferentParticipant"
+	ErrCodeEntryKeyMismatch       = "EntryKeyDoesNotMatchOwner"
+	ErrCodeEntryLimitExceeded     = "EntryLimitExceeded"
+	ErrCodeEntryNotFound          = "EntryNotFound"
+	ErrCodeEntryLockedByClaim     = "EntryLockedByClaim"
+	ErrCodeForbidden              = "Forbidden"
+	ErrCodeClaimNotFound          = "ClaimNotFound"
+	ErrCodeClaimAlreadyOpen       = "ClaimAlreadyOpen"
+	ErrCodeInvalidClaimStatus     = "InvalidClaimStatus"
+	ErrCodeInfractionNotFound     = "InfractionReportNotFound"
+	ErrCodeInvalidInfractionState = "InvalidInfractionReportStatus"
+)
+
+// Claim periods defined by the DICT manual
+const (
+	ClaimResolutionPeriod = 7 * 24 * time.Hour
+	ClaimCompletionPeriod = 30 * 24 * time.Hour
+)
+
+// Keys per account defined by the DICT manual
+const (
+	maxKeysNaturalPerson = 5
+	maxKeysLegalPerson   = 20
+)
+
+// Claim types and statuses as exchanged with Bacen
+const (
+	ClaimTypePortability = "PORTABILITY"
+	ClaimTypeOwnership   = "OWNERSHIP"
+
+	ClaimStatusOpen              = "OPEN"
+	ClaimStatusWaitingResolution = "WAITING_RESOLUTION"
+	ClaimStatusConfirmed         = "CONFIRMED"
+	ClaimStatusCancelled         = "CANCELLED"
+	ClaimStatusCompleted         = "COMPLETED"
+)
+
+// Infraction report statuses
+const (
+	InfractionStatusOpen         = "OPEN"
+	InfractionStatusAcknowledged = "ACKNOWLEDGED"
+	InfractionStatusClosed       = "CLOSED"
+	InfractionStatusCancelled    = "CANCELLED"
+)
+
+var (
+	cpfPattern   = regexp.MustCompile(`^\d{11}$`)
+	cnpjPattern  = regexp.MustCompile(`^\d{14}$`)
+	phonePattern = regexp.MustCompile(`^\+[1-9]\d{1,14}$`)
+	emailPattern = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+	evpPattern   = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+)
+
+// Error is a DICT business error, returned to the client as a SOAP Fault
+type Error struct {
+	Status  int    // HTTP status of the fault response
+	Code    string // Bacen error code (fault Detail)
+	Message string // Human readable reason (fault Reason)
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+func newError(status int, code, format string, args ...interface{}) *Error {
+	return &Error{Status: status, Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+type entry struct {
+	data xmlstructs.XMLExtendedEntry
+	cid  string
+}
+
+type claim struct {
+	id             string
+	claimType      string
+	key            string
+	keyType        string
+	status         string
+	donor          string
+	claimerAccount xmlstructs.XMLAccount
+	claimer        xmlstructs.XMLOwner
+	created        time.Time
+	modified       time.Time
+	resolutionEnd  time.Time
+	completionEnd  time.Time
+}
+
+type infraction struct {
+	id              string
+	participant     string
+	report          xmlstructs.XMLInfractionReport
+	status          string
+	analysisResult  string
+	analysisDetails string
+	created         time.Time
+	modified        time.Time
+}
+
+// Store keeps the simulated DICT state in memory and enforces the DICT rules
+type Store struct {
+	mu          sync.Mutex
+	clock       func() time.Time
+	offset      time.Duration
+	entries     map[string]*entry
+	claims      map[string]*claim
+	infractions map[string]*infraction
+}
+
+// NewStore creates an empty store using the wall clock
+func NewStore() *Store {
+	s := &Store{clock: time.Now}
+	s.Reset()
+	return s
+}
+
+// Reset drops all state and the clock offset
+func (s *Store) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.offset = 0
+	s.entries = make(map[string]*entry)
+	s.claims = make(map[string]*claim)
+	s.infractions = make(map[string]*infraction)
+}
+
+// Advance moves the simulated clock forward, e.g. to expire claim periods
+func (s *Store) Advance(d time.Duration) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.offset += d
+	return s.now()
+}
+
+// Now returns the simulated time
+func (s *Store) Now() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.now()
+}
+
+func (s *Store) now() time.Time {
+	return s.clock().Add(s.offset).UTC()
+}
+
+// ========== ENTRIES ==========
+
+// CreateEntry registers a new key after checking format, ownership and limits
+func (s *Store) CreateEntry(req xmlstructs.XMLEntry, requestID string) (xmlstructs.XMLExtendedEntry, error) {
+	if err := validateEntry(req); err != nil {
+		return xmlstructs.XMLExtendedEntry{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.entries[req.Key]; ok {
+		if existing.data.Account.Participant == req.Account.Participant {
+			return xmlstructs.XMLExtendedEntry{}, newError(http.StatusConflict, ErrCodeEntryAlreadyExists,
+				"key %s is already registered", req.Key)
+		}
+		return xmlstructs.XMLExtendedEntry{}, newError(http.StatusConflict, ErrCodeEntryOwnedByOther,
+			"key %s is registered at another participant, open a claim instead", req.Key)
+	}
+
+	limit := maxKeysNaturalPerson
+	if req.Owner.Type == "LEGAL_PERSON" {
+		limit = maxKeysLegalPerson
+	}
+	if s.countAccountKeys(req.Account) >= limit {
+		return xmlstructs.XMLExtendedEntry{}, newError(http.StatusBadRequest, ErrCodeEntryLimitExceeded,
+			"account already has %d keys", limit)
+	}
+
+	now := formatTime(s.now())
+	e := &entry{data: xmlstructs.XMLExtendedEntry{
+		Key:              req.Key,
+		KeyType:          req.KeyType,
+		Account:          req.Account,
+		Owner:            req.Owner,
+		CreationTime:     now,
+		KeyOwnershipDate: now,
+		RequestId:        requestID,
+	}}
+	e.cid = entryCID(e.data)
+	s.entries[req.Key] = e
+
+	return e.data, nil
+}
+
+// UpdateEntry changes the account of a key; moving it to another participant requires a claim
+func (s *Store) UpdateEntry(key, keyType string, account xmlstructs.XMLAccount, requestID string) (xmlstructs.XMLExtendedEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expireClaims()
+
+	e, err := s.lookupEntry(key, keyType)
+	if err != nil {
+		return xmlstructs.XMLExtendedEntry{}, err
+	}
+	if c := s.openClaim(key); c != nil {
+		return xmlstructs.XMLExtendedEntry{}, newError(http.StatusConflict, ErrCodeEntryLockedByClaim,
+			"key %s has open claim %s", key, c.id)
+	}
+	if account.Participant != e.data.Account.Participant {
+		return xmlstructs.XMLExtendedEntry{}, newError(http.StatusForbidden, ErrCodeForbidden,
+			"key %s belongs to participant %s", key, e.data.Account.Participant)
+	}
+
+	e.data.Account = account
+	e.data.LastModifiedDate = formatTime(s.now())
+	e.data.RequestId = requestID
+	e.cid = entryCID(e.data)
+
+	return e.data, nil
+}
+
+// DeleteEntry removes a key that is not locked by a claim
+func (s *Store) DeleteEntry(key, keyType string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expireClaims()
+
+	if _, err := s.lookupEntry(key, keyType); err != nil {
+		return err
+	}
+	if c := s.openClaim(key); c != nil {
+		return newError(http.StatusConflict, ErrCodeEntryLockedByClaim, "key %s has open claim %s", key, c.id)
+	}
+
+	delete(s.entries, key)
+	return nil
+}
+
+// GetEntry returns a registered key
+func (s *Store) GetEntry(key, keyType string) (xmlstructs.XMLExtendedEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, err := s.lookupEntry(key, keyType)
+	if err != nil {
+		return xmlstructs.XMLExtendedEntry{}, err
+	}
+	return e.data, nil
+}
+
+// ListEntries returns one page of entries matching the filter, ordered by key.
+// The cursor is opaque to clients: the last key of the previous page.
+func (s *Store) ListEntries(filter xmlstructs.XMLEntryFilter, cursor string, limit int) ([]xmlstructs.XMLDirectoryEntry, string, int, error) {
+	after := ""
+	if cursor != "" {
+		decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+		if err != nil || len(decoded) == 0 {
+			return nil, "", 0, newError(http.StatusBadRequest, ErrCodeInvalidRequest, "invalid cursor")
+		}
+		after = string(decoded)
+	}
+	if limit <= 0 || limit > 1000 {
+		limit = 100
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expireClaims()
+
+	var matched []xmlstructs.XMLDirectoryEntry
+	for _, e := range s.entries {
+		status := s.entryStatus(e.data.Key)
+		if !matchesFilter(e.data, status, filter) {
+			continue
+		}
+		matched = append(matched, xmlstructs.XMLDirectoryEntry{XMLExtendedEntry: e.data, Status: status})
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].Key < matched[j].Key })
+
+	start := sort.Search(len(matched), func(i int) bool { return matched[i].Key > after })
+	end := start + limit
+	if end >= len(matched) {
+		return matched[start:], "", len(matched), nil
+	}
+	next := base64.RawURLEncoding.EncodeToString([]byte(matched[end-1].Key))
+	return matched[start:end], next, len(matched), nil
+}
+
+func (s *Store) lookupEntry(key, keyType string) (*entry, error) {
+	e, ok := s.entries[key]
+	if !ok || (keyType != "" && e.data.KeyType != keyType) {
+		return nil, newError(http.StatusNotFound, ErrCodeEntryNotFound, "key %s not found", key)
+	}
+	return e, nil
+}
+
+func (s *Store) countAccountKeys(account xmlstructs.XMLAccount) int {
+	var count int
+	for _, e := range s.entries {
+		a := e.data.Account
+		if a.Participant == account.Participant && a.Branch == account.Branch && a.AccountNumber == account.AccountNumber {
+			count++
+		}
+	}
+	return count
+}
+
+func (s *Store) entryStatus(key string) string {
+	c := s.openClaim(key)
+	switch {
+	case c == nil:
+		return "ACTIVE"
+	case c.claimType == ClaimTypePortability:
+		return "PORTABILITY_PENDING"
+	default:
+		return "CLAIM_PENDING"
+	}
+}
+
+func matchesFilter(e xmlstructs.XMLExtendedEntry, status string, f xmlstructs.XMLEntryFilter) bool {
+	return (f.KeyType == "" || f.KeyType == e.KeyType) &&
+		(f.Status == "" || f.Status == status) &&
+		(f.Participant == "" || f.Participant == e.Account.Participant) &&
+		(f.TaxIdNumber == "" || f.TaxIdNumber == e.Owner.TaxIdNumber) &&
+		(f.AccountNumber == "" || f.AccountNumber == e.Account.AccountNumber)
+}
+
+// validateEntry checks the key format and that CPF/CNPJ keys belong to the owner
+func validateEntry(req xmlstructs.XMLEntry) error {
+	if req.Account.Participant == "" || req.Account.AccountNumber == "" {
+		return newError(http.StatusBadRequest, ErrCodeInvalidRequest, "account participant and number are required")
+	}
+	if req.Owner.TaxIdNumber == "" || req.Owner.Name == "" {
+		return newError(http.StatusBadRequest, ErrCodeInvalidRequest, "owner tax id and name are required")
+	}
+
+	var valid bool
+	switch req.KeyType {
+	case "CPF":
+		valid = cpfPattern.MatchString(req.Key) && req.Owner.Type == "NATURAL_PERSON"
+	case "CNPJ":
+		valid = cnpjPattern.MatchString(req.Key) && req.Owner.Type == "LEGAL_PERSON"
+	case "PHONE":
+		valid = phonePattern.MatchString(req.Key)
+	case "EMAIL":
+		valid = emailPattern.MatchString(req.Key)
+	case "EVP":
+		valid = evpPattern.MatchString(req.Key)
+	default:
+		return newError(http.StatusBadRequest, ErrCodeInvalidRequest, "unknown key type %q", req.KeyType)
+	}
+	if !valid {
+		return newError(http.StatusBadRequest, ErrCodeInvalidRequest, "invalid %s key %q", req.KeyType, req.Key)
+	}
+
+	if (req.KeyType == "CPF" || req.KeyType == "CNPJ") && req.Key != req.Owner.TaxIdNumber {
+		return newError(http.StatusBadRequest, ErrCodeEntryKeyMismatch,
+			"%s key must be the owner's tax id", req.KeyType)
+	}
+	return nil
+}
+
+// ========== CLAIMS ==========
+
+// CreateClaim opens a portability or ownership claim for a registered key
+func (s *Store) CreateClaim(req xmlstructs.XMLClaim) (xmlstructs.XMLClaim, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expireClaims()
+
+	if req.Type != ClaimTypePortability && req.Type != ClaimTypeOwnership {
+		return xmlstructs.XMLClaim{}, newError(http.StatusBadRequest, ErrCodeInvalidRequest, "unknown claim type %q", req.Type)
+	}
+	e, err := s.lookupEntry(req.Key, req.KeyType)
+	if err != nil {
+		return xmlstructs.XMLClaim{}, err
+	}
+	if c := s.openClaim(req.Key); c != nil {
+		return xmlstructs.XMLClaim{}, newError(http.StatusConflict, ErrCodeClaimAlreadyOpen,
+			"key %s already has open claim %s", req.Key, c.id)
+	}
+
+	samePerson := req.Claimer.TaxIdNumber == e.data.Owner.TaxIdNumber
+	switch {
+	case req.Type == ClaimTypePortability && !samePerson:
+		return xmlstructs.XMLClaim{}, newError(http.StatusBadRequest, ErrCodeInvalidRequest,
+			"portability must be claimed by the key owner")
+	case req.Type == ClaimTypeOwnership && samePerson:
+		return xmlstructs.XMLClaim{}, newError(http.StatusBadRequest, ErrCodeInvalidRequest,
+			"the key owner cannot claim ownership of its own key")
+	case req.Type == ClaimTypePortability && req.ClaimerAccount.Participant == e.data.Account.Participant:
+		return xmlstructs.XMLClaim{}, newError(http.StatusBadRequest, ErrCodeInvalidRequest,
+			"portability must move the key to another participant")
+	case req.Type == ClaimTypeOwnership && (req.KeyType == "CPF" || req.KeyType == "CNPJ"):
+		return xmlstructs.XMLClaim{}, newError(http.StatusBadRequest, ErrCodeInvalidRequest,
+			"ownership of %s keys cannot be claimed", req.KeyType)
+	}
+
+	now := s.now()
+	c := &claim{
+		id:             uuid.NewString(),
+		claimType:      req.Type,
+		key:            req.Key,
+		keyType:        e.data.KeyType,
+		status:         ClaimStatusOpen,
+		donor:          e.data.Account.Participant,
+		claimerAccount: req.ClaimerAccount,
+		claimer:        req.Claimer,
+		created:        now,
+		modified:       now,
+		resolutionEnd:  now.Add(ClaimResolutionPeriod),
+		completionEnd:  now.Add(ClaimCompletionPeriod),
+	}
+	s.claims[c.id] = c
+
+	return c.toXML(), nil
+}
+
+// GetClaim returns a claim, applying any period expiration first
+func (s *Store) GetClaim(id string) (xmlstructs.XMLClaim, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expireClaims()
+
+	c, err := s.lookupClaim(id)
+	if err != nil {
+		return xmlstructs.XMLClaim{}, err
+	}
+	return c.toXML(), nil
+}
+
+// AcknowledgeClaim marks a claim as seen by the donor participant
+func (s *Store) AcknowledgeClaim(id string) (xmlstructs.XMLClaim, error) {
+	return s.transitionClaim(id, ClaimStatusWaitingResolution, ClaimStatusOpen)
+}
+
+// ConfirmClaim is the donor releasing the key
+func (s *Store) ConfirmClaim(id string) (xmlstructs.XMLClaim, error) {
+	return s.transitionClaim(id, ClaimStatusConfirmed, ClaimStatusOpen, ClaimStatusWaitingResolution)
+}
+
+// CancelClaim cancels a claim that is not completed yet
+func (s *Store) CancelClaim(id string) (xmlstructs.XMLClaim, error) {
+	return s.transitionClaim(id, ClaimStatusCancelled, ClaimStatusOpen, ClaimStatusWaitingResolution, ClaimStatusConfirmed)
+}
+
+// CompleteClaim moves the key to the claimer after confirmation
+func (s *Store) CompleteClaim(id string) (xmlstructs.XMLClaim, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expireClaims()
+
+	c, err := s.lookupClaim(id)
+	if err != nil {
+		return xmlstructs.XMLClaim{}, err
+	}
+	if c.status != ClaimStatusConfirmed {
+		return xmlstructs.XMLClaim{}, newError(http.StatusConflict, ErrCodeInvalidClaimStatus,
+			"claim %s is %s, expected %s", id, c.status, ClaimStatusConfirmed)
+	}
+
+	now := s.now()
+	if e, ok := s.entries[c.key]; ok {
+		e.data.Account = c.claimerAccount
+		e.data.Owner = c.claimer
+		e.data.KeyOwnershipDate = formatTime(now)
+		e.data.LastModifiedDate = formatTime(now)
+		e.data.RequestId = c.id // The completion request carries no RequestId; the claim is the request
+		e.cid = entryCID(e.data)
+	}
+	c.status = ClaimStatusCompleted
+	c.modified = now
+
+	return c.toXML(), nil
+}
+
+func (s *Store) transitionClaim(id, to string, from ...string) (xmlstructs.XMLClaim, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expireClaims()
+
+	c, err := s.lookupClaim(id)
+	if err != nil {
+		return xmlstructs.XMLClaim{}, err
+	}
+	allowed := false
+	for _, status := range from {
+		allowed = allowed || c.status == status
+	}
+	if !allowed {
+		return xmlstructs.XMLClaim{}, newError(http.StatusConflict, ErrCodeInvalidClaimStatus,
+			"claim %s is %s and cannot move to %s", id, c.status, to)
+	}
+
+	c.status = to
+	c.modified = s.now()
+	return c.toXML(), nil
+}
+
+func (s *Store) lookupClaim(id string) (*claim, error) {
+	c, ok := s.claims[id]
+	if !ok {
+		return nil, newError(http.StatusNotFound, ErrCodeClaimNotFound, "claim %s not found", id)
+	}
+	return c, nil
+}
+
+func (s *Store) openClaim(key string) *claim {
+	for _, c := range s.claims {
+		if c.key == key && c.status != ClaimStatusCancelled && c.status != ClaimStatusCompleted {
+			return c
+		}
+	}
+	return nil
+}
+
+// ListClaims returns one page of the claims where the filter participant is donor
+// or claimer, oldest first. IsDonor/IsClaimer restrict the role; without either
+// both roles are listed. The cursor follows the same rules as ListInfractions.
+func (s *Store) ListClaims(filter xmlstructs.XMLClaimFilter, cursor string, limit int) ([]xmlstructs.XMLClaim, string, error) {
+	modifiedAfter, err := parseModifiedAfter(filter.ModifiedAfter)
+	if err != nil {
+		return nil, "", err
+	}
+	wantDonor, wantClaimer := true, true
+	if filter.IsDonor != nil || filter.IsClaimer != nil {
+		wantDonor = filter.IsDonor != nil && *filter.IsDonor
+		wantClaimer = filter.IsClaimer != nil && *filter.IsClaimer
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expireClaims()
+
+	var matched []*claim
+	for _, c := range s.sortedClaims() {
+		if filter.Participant != "" {
+			donor := wantDonor && c.donor == filter.Participant
+			claimer := wantClaimer && c.claimerAccount.Participant == filter.Participant
+			if !donor && !claimer {
+				continue
+			}
+		}
+		if filter.Status != "" && c.status != filter.Status {
+			continue
+		}
+		if !modifiedAfter.IsZero() && !c.modified.Truncate(time.Second).After(modifiedAfter) {
+			continue
+		}
+		matched = append(matched, c)
+	}
+
+	page, next, err := paginate(matched, cursor, limit, func(c *claim) string { return c.id })
+	if err != nil {
+		return nil, "", err
+	}
+	claims := make([]xmlstructs.XMLClaim, 0, len(page))
+	for _, c := range page {
+		claims = append(claims, c.toXML())
+	}
+	return claims, next, nil
+}
+
+// sortedClaims returns every claim, oldest first (callers hold s.mu)
+func (s *Store) sortedClaims() []*claim {
+	claims := make([]*claim, 0, len(s.claims))
+	for _, c := range s.claims {
+		claims = append(claims, c)
+	}
+	sort.Slice(claims, func(i, j int) bool {
+		if claims[i].created.Equal(claims[j].created) {
+			return claims[i].id < claims[j].id
+		}
+		return claims[i].created.Before(claims[j].created)
+	})
+	return claims
+}
+
+// expireClaims applies the DICT deadlines lazily: an unanswered portability is
+// cancelled and an unanswered ownership claim is confirmed once the resolution
+// period ends; a claim not completed within the completion period is cancelled.
+func (s *Store) expireClaims() {
+	now := s.now()
+	for _, c := range s.claims {
+		switch c.status {
+		case ClaimStatusOpen, ClaimStatusWaitingResolution:
+			if now.Before(c.resolutionEnd) {
+				continue
+			}
+			if c.claimType == ClaimTypePortability {
+				c.status = ClaimStatusCancelled
+			} else {
+				c.status = ClaimStatusConfirmed
+			}
+			c.modified = c.resolutionEnd
+		case ClaimStatusConfirmed:
+			if !now.Before(c.completionEnd) {
+				c.status = ClaimStatusCancelled
+				c.modified = c.completionEnd
+			}
+		}
+	}
+}
+
+func (c *claim) toXML() xmlstructs.XMLClaim {
+	return xmlstructs.XMLClaim{
+		ClaimId:             c.id,
+		Type:                c.claimType,
+		Key:                 c.key,
+		KeyType:             c.keyType,
+		Status:              c.status,
+		DonorParticipant:    c.donor,
+		ClaimerAccount:      c.claimerAccount,
+		Claimer:             c.claimer,
+		CompletionPeriodEnd: formatTime(c.completionEnd),
+		ResolutionPeriodEnd: formatTime(c.resolutionEnd),
+		LastModified:        formatTime(c.modified),
+		CreationTime:        formatTime(c.created),
+	}
+}
+
+// ========== INFRACTIONS ==========
+
+// CreateInfraction registers an infraction report for a transaction
+func (s *Store) CreateInfraction(participant string, report xmlstructs.XMLInfractionReport) (xmlstructs.XMLInfractionReportFull, error) {
+	if participant == "" || report.TransactionId == "" || report.Reason == "" {
+		return xmlstructs.XMLInfractionReportFull{}, newError(http.StatusBadRequest, ErrCodeInvalidRequest,
+			"participant, transaction id and reason are required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, inf := range s.infractions {
+		if inf.report.TransactionId == report.TransactionId && inf.status != InfractionStatusCancelled && inf.status != InfractionStatusClosed {
+			return xmlstructs.XMLInfractionReportFull{}, newError(http.StatusConflict, ErrCodeInvalidInfractionState,
+				"transaction %s already has open infraction report %s", report.TransactionId, inf.id)
+		}
+	}
+
+	now := s.now()
+	inf := &infraction{
+		id:          uuid.NewString(),
+		participant: participant,
+		report:      report,
+		status:      InfractionStatusOpen,
+		created:     now,
+		modified:    now,
+	}
+	s.infractions[inf.id] = inf
+
+	return inf.toXML(), nil
+}
+
+// GetInfraction returns an infraction report
+func (s *Store) GetInfraction(id string) (xmlstructs.XMLInfractionReportFull, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	inf, ok := s.infractions[id]
+	if !ok {
+		return xmlstructs.XMLInfractionReportFull{}, newError(http.StatusNotFound, ErrCodeInfractionNotFound, "infraction report %s not found", id)
+	}
+	return inf.toXML(), nil
+}
+
+// ListInfractions returns one page of the infraction reports created by or against
+// the filter participant, oldest first. The cursor is opaque to clients: the id of
+// the last report of the previous page.
+func (s *Store) ListInfractions(filter xmlstructs.XMLInfractionReportFilter, cursor string, limit int) ([]xmlstructs.XMLInfractionReportFull, string, error) {
+	modifiedAfter, err := parseModifiedAfter(filter.ModifiedAfter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var matched []*infraction
+	for _, inf := range s.sortedInfractions() {
+		if filter.Participant != "" && inf.participant != filter.Participant && inf.report.ReportedParticipant != filter.Participant {
+			continue
+		}
+		if filter.Status != "" && inf.status != filter.Status {
+			continue
+		}
+		if !modifiedAfter.IsZero() && !inf.modified.Truncate(time.Second).After(modifiedAfter) { // LastModified has second precision
+			continue
+		}
+		matched = append(matched, inf)
+	}
+
+	page, next, err := paginate(matched, cursor, limit, func(inf *infraction) string { return inf.id })
+	if err != nil {
+		return nil, "", err
+	}
+	reports := make([]xmlstructs.XMLInfractionReportFull, 0, len(page))
+	for _, inf := range page {
+		reports = append(reports, inf.toXML())
+	}
+	return reports, next, nil
+}
+
+// AcknowledgeInfraction marks an open report as received by the reported participant
+func (s *Store) AcknowledgeInfraction(id, participant string) (xmlstructs.XMLInfractionReportFull, error) {
+	return s.transitionInfraction(id, participant, false, InfractionStatusAcknowledged, nil, InfractionStatusOpen)
+}
+
+// CloseInfraction closes a report with the analysis of the reported participant
+func (s *Store) CloseInfraction(id, participant, analysisResult, analysisDetails string) (xmlstructs.XMLInfractionReportFull, error) {
+	if analysisResult != "AGREED" && analysisResult != "DISAGREED" {
+		return xmlstructs.XMLInfractionReportFull{}, newError(http.StatusBadRequest, ErrCodeInvalidRequest,
+			"analysis result must be AGREED or DISAGREED")
+	}
+	return s.transitionInfraction(id, participant, false, InfractionStatusClosed, func(inf *infraction) {
+		inf.analysisResult = analysisResult
+		inf.analysisDetails = analysisDetails
+	}, InfractionStatusOpen, InfractionStatusAcknowledged)
+}
+
+// CancelInfraction cancels a report that is still open, on behalf of its reporter
+func (s *Store) CancelInfraction(id, participant string) (xmlstructs.XMLInfractionReportFull, error) {
+	return s.transitionInfraction(id, participant, true, InfractionStatusCancelled, nil, InfractionStatusOpen)
+}
+
+// transitionInfraction moves a report to a new status. Cancelling is reserved to the
+// reporter; acknowledging and closing to the other participants.
+func (s *Store) transitionInfraction(id, participant string, byReporter bool, to string, apply func(*infraction), from ...string) (xmlstructs.XMLInfractionReportFull, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	inf, ok := s.infractions[id]
+	if !ok {
+		return xmlstructs.XMLInfractionReportFull{}, newError(http.StatusNotFound, ErrCodeInfractionNotFound, "infraction report %s not found", id)
+	}
+	if participant != "" && (inf.participant == participant) != byReporter {
+		return xmlstructs.XMLInfractionReportFull{}, newError(http.StatusForbidden, ErrCodeForbidden,
+			"participant %s cannot move infraction report %s to %s", participant, id, to)
+	}
+	allowed := false
+	for _, status := range from {
+		allowed = allowed || inf.status == status
+	}
+	if !allowed {
+		return xmlstructs.XMLInfractionReportFull{}, newError(http.StatusConflict, ErrCodeInvalidInfractionState,
+			"infraction report %s is %s and cannot move to %s", id, inf.status, to)
+	}
+
+	inf.status = to
+	inf.modified = s.now()
+	if apply != nil {
+		apply(inf)
+	}
+	return inf.toXML(), nil
+}
+
+// parseModifiedAfter parses the optional ModifiedAfter filter (zero time when empty)
+func parseModifiedAfter(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, newError(http.StatusBadRequest, ErrCodeInvalidRequest, "invalid ModifiedAfter: %v", err)
+	}
+	return t, nil
+}
+
+// paginate returns the page of items after the cursor and the cursor of the next page.
+// The cursor is opaque to clients: the id of the last item of the previous page.
+func paginate[T any](items []T, cursor string, limit int, id func(T) string) ([]T, string, error) {
+	if limit <= 0 || limit > 1000 {
+		limit = 100
+	}
+
+	start := 0
+	if cursor != "" {
+		decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+		if err != nil || len(decoded) == 0 {
+			return nil, "", newError(http.StatusBadRequest, ErrCodeInvalidRequest, "invalid cursor")
+		}
+		start = -1
+		for i, item := range items {
+			if id(item) == string(decoded) {
+				start = i + 1
+				break
+			}
+		}
+		if start < 0 {
+			return nil, "", newError(http.StatusBadRequest, ErrCodeInvalidRequest, "invalid cursor")
+		}
+	}
+
+	end := start + limit
+	next := ""
+	if end < len(items) {
+		next = base64.RawURLEncoding.EncodeToString([]byte(id(items[end-1])))
+	} else {
+		end = len(items)
+	}
+	return items[start:end], next, nil
+}
+
+// sortedInfractions returns every report, oldest first (callers hold s.mu)
+func (s *Store) sortedInfractions() []*infraction {
+	infractions := make([]*infraction, 0, len(s.infractions))
+	for _, inf := range s.infractions {
+		infractions = append(infractions, inf)
+	}
+	sort.Slice(infractions, func(i, j int) bool {
+		if infractions[i].created.Equal(infractions[j].created) {
+			return infractions[i].id < infractions[j].id
+		}
+		return infractions[i].created.Before(infractions[j].created)
+	})
+	return infractions
+}
+
+func (inf *infraction) toXML() xmlstructs.XMLInfractionReportFull {
+	return xmlstructs.XMLInfractionReportFull{
+		TransactionId:       inf.report.TransactionId,
+		Reason:              inf.report.Reason,
+		SituationType:       inf.report.SituationType,
+		ReportDetails:       inf.report.ReportDetails,
+		ContactInformation:  inf.report.ContactInformation,
+		Id:                  inf.id,
+		Status:              inf.status,
+		ReporterParticipant: inf.participant,
+		ReportedParticipant: inf.report.ReportedParticipant,
+		AnalysisResult:      inf.analysisResult,
+		AnalysisDetails:     inf.analysisDetails,
+		CreationTime:        formatTime(inf.created),
+		LastModified:        formatTime(inf.modified),
+	}
+}
+
+// ========== SNAPSHOT ==========
+
+// Snapshot is the JSON view of the store exposed by the admin API
+type Snapshot struct {
+	Now         string                               `json:"now"`
+	Entries     []xmlstructs.XMLDirectoryEntry       `json:"entries"`
+	Claims      []xmlstructs.XMLClaim                `json:"claims"`
+	Infractions []xmlstructs.XMLInfractionReportFull `json:"infractions"`
+}
+
+// Snapshot returns the whole state, sorted for stable output
+func (s *Store) Snapshot() Snapshot {
+	s.mu.Lock()
+	s.expireClaims()
+
+	snapshot := Snapshot{Now: formatTime(s.now())}
+	for _, e := range s.entries {
+		snapshot.Entries = append(snapshot.Entries, xmlstructs.XMLDirectoryEntry{XMLExtendedEntry: e.data, Status: s.entryStatus(e.data.Key)})
+	}
+	for _, c := range s.claims {
+		snapshot.Claims = append(snapshot.Claims, c.toXML())
+	}
+	for _, inf := range s.sortedInfractions() {
+		snapshot.Infractions = append(snapshot.Infractions, inf.toXML())
+	}
+	s.mu.Unlock()
+
+	sort.Slice(snapshot.Entries, func(i, j int) bool { return snapshot.Entries[i].Key < snapshot.Entries[j].Key })
+	sort.Slice(snapshot.Claims, func(i, j int) bool {
+		if snapshot.Claims[i].CreationTime == snapshot.Claims[j].CreationTime {
+			return snapshot.Claims[i].ClaimId < snapshot.Claims[j].ClaimId
+		}
+		return snapshot.Claims[i].CreationTime < snapshot.Claims[j].CreationTime
+	})
+	return snapshot
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
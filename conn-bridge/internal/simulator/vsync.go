@@ -0,0 +1,86 @@
+package simulator
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sort"
+	"strings"
+
+	xmlstructs "github.com/lbpay-lab/conn-bridge/internal/xml"
+)
+
+// entryCID is the content identifier of an entry: HMAC-SHA256 over the fields
+// that DICT participants must keep in sync, in a fixed order, keyed by the
+// RequestId of the last create or update of the key
+func entryCID(e xmlstructs.XMLExtendedEntry) string {
+	fields := []string{
+		e.KeyType,
+		e.Key,
+		e.Owner.Type,
+		e.Owner.TaxIdNumber,
+		e.Owner.Name,
+		e.Owner.TradeName,
+		e.Account.Participant,
+		e.Account.Branch,
+		e.Account.AccountNumber,
+		e.Account.AccountType,
+		e.Account.OpeningDate,
+		e.KeyOwnershipDate,
+	}
+	mac := hmac.New(sha256.New, []byte(e.RequestId))
+	mac.Write([]byte(strings.Join(fields, "&")))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySync returns the number of entries of a participant for a key type and
+// the VSync value: the XOR of their CIDs, which does not depend on ordering
+func (s *Store) VerifySync(participant, keyType string) (int, string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var count int
+	var vsync [sha256.Size]byte
+	for _, e := range s.entries {
+		if e.data.Account.Participant != participant || e.data.KeyType != keyType {
+			continue
+		}
+		cid, _ := hex.DecodeString(e.cid)
+		for i := range vsync {
+			vsync[i] ^= cid[i]
+		}
+		count++
+	}
+	return count, hex.EncodeToString(vsync[:])
+}
+
+// ListCIDs returns the sorted CIDs of a participant for a key type, used to find
+// the divergent entries once VerifySync reports a mismatch
+func (s *Store) ListCIDs(participant, keyType string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cids := []string{}
+	for _, e := range s.entries {
+		if e.data.Account.Participant == participant && e.data.KeyType == keyType {
+			cids = append(cids, e.cid)
+		}
+	}
+	sort.Strings(cids)
+	return cids
+}
+
+// EntryByCID returns the entry of a participant whose current CID is cid
+func (s *Store) EntryByCID(participant, cid string) (xmlstructs.XMLExtendedEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cid = strings.ToLower(cid)
+	for _, e := range s.entries {
+		if e.cid == cid && e.data.Account.Participant == participant {
+			return e.data, nil
+		}
+	}
+	return xmlstructs.XMLExtendedEntry{}, newError(http.StatusNotFound, ErrCodeEntryNotFound, "no entry with CID %s", cid)
+}
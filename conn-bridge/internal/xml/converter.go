@@ -3,9 +3,14 @@ package xml
 import (
 	"encoding/xml"
 	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
 
 	pb "github.com/lbpay-lab/dict-contracts/gen/proto/bridge/v1"
 	commonv1 "github.com/lbpay-lab/dict-contracts/gen/proto/common/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // ========== ENTRY CONVERTERS ==========
@@ -27,7 +32,7 @@ func CreateEntryRequestToXML(req *pb.CreateEntryRequest) ([]byte, error) {
 			Key:     req.Key.KeyValue,
 			KeyType: keyTypeToXML(req.Key.KeyType),
 			Account: accountToXML(req.Account),
-			// Owner will be populated from account holder info in real implementation
+			Owner:   ownerToXML(req.Account),
 		},
 		RequestId: req.RequestId,
 	}
@@ -42,10 +47,12 @@ func CreateEntryResponseFromXML(xmlData []byte) (*pb.CreateEntryResponse, error)
 		return nil, fmt.Errorf("failed to unmarshal XML: %w", err)
 	}
 
+	// CreationTime is also the initial key ownership date, which enters the CID
 	return &pb.CreateEntryResponse{
 		EntryId:    xmlResp.Entry.Key,
 		ExternalId: xmlResp.CorrelationId,
 		Status:     commonv1.EntryStatus_ENTRY_STATUS_ACTIVE,
+		CreatedAt:  timestampFromXML(xmlResp.Entry.CreationTime),
 	}, nil
 }
 
@@ -87,6 +94,7 @@ func DeleteEntryRequestToXML(req *pb.DeleteEntryRequest) ([]byte, error) {
 	xmlReq := &XMLDeleteEntryRequest{
 		Key:       req.EntryId,
 		KeyType:   "CPF", // TODO: Get from existing entry
+		Reason:    entryDeletionReasonToXML(req.Reason),
 		RequestId: req.RequestId,
 	}
 
@@ -146,8 +154,247 @@ func GetEntryResponseFromXML(xmlData []byte) (*pb.GetEntryResponse, error) {
 			KeyType:  keyTypeFromXML(xmlResp.Entry.KeyType),
 			KeyValue: xmlResp.Entry.Key,
 		},
-		Account: accountFromXML(&xmlResp.Entry.Account),
-		Status:  commonv1.EntryStatus_ENTRY_STATUS_ACTIVE,
+		Account:          accountWithOwnerFromXML(&xmlResp.Entry.Account, &xmlResp.Entry.Owner),
+		Status:           commonv1.EntryStatus_ENTRY_STATUS_ACTIVE,
+		CreatedAt:        timestampFromXML(xmlResp.Entry.CreationTime),
+		Found:            true,
+		KeyOwnershipDate: timestampFromXML(xmlResp.Entry.KeyOwnershipDate),
+		RequestId:        xmlResp.Entry.RequestId,
+	}, nil
+}
+
+// ========== DIRECTORY CONVERTERS ==========
+
+// GetDirectoryRequestToXML converts gRPC GetDirectoryRequest to XML bytes.
+// The opaque page token is resolved into the Bacen cursor (ErrInvalidPageToken if it does not match the filters).
+func GetDirectoryRequestToXML(req *pb.GetDirectoryRequest) ([]byte, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+
+	filter := directoryFilter(req)
+	cursor, err := decodePageToken(req.PageToken, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	xmlReq := &XMLGetDirectoryRequest{
+		Filter:     filter,
+		Pagination: XMLPagination{Limit: req.PageSize, Cursor: cursor},
+		RequestId:  req.RequestId,
+	}
+
+	return marshalXML(xmlReq)
+}
+
+// GetDirectoryResponseFromXML converts XML bytes to gRPC GetDirectoryResponse.
+// The request is needed to bind the next page token to the same filters.
+func GetDirectoryResponseFromXML(xmlData []byte, req *pb.GetDirectoryRequest) (*pb.GetDirectoryResponse, error) {
+	var xmlResp XMLGetDirectoryResponse
+	if err := xml.Unmarshal(xmlData, &xmlResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal XML: %w", err)
+	}
+
+	nextToken, err := nextPageToken(xmlResp.HasMoreElements, xmlResp.NextCursor, directoryFilter(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetDirectoryResponse{
+		Entries:       entriesFromXML(xmlResp.Entries),
+		NextPageToken: nextToken,
+		TotalCount:    xmlResp.TotalCount,
+	}, nil
+}
+
+// SearchEntriesRequestToXML converts gRPC SearchEntriesRequest to XML bytes.
+// The opaque page token is resolved into the Bacen cursor (ErrInvalidPageToken if it does not match the filters).
+func SearchEntriesRequestToXML(req *pb.SearchEntriesRequest) ([]byte, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+
+	filter := searchFilter(req)
+	cursor, err := decodePageToken(req.PageToken, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	xmlReq := &XMLSearchEntriesRequest{
+		Filter:     filter,
+		Pagination: XMLPagination{Limit: req.PageSize, Cursor: cursor},
+		RequestId:  req.RequestId,
+	}
+
+	return marshalXML(xmlReq)
+}
+
+// SearchEntriesResponseFromXML converts XML bytes to gRPC SearchEntriesResponse.
+// The request is needed to bind the next page token to the same filters.
+func SearchEntriesResponseFromXML(xmlData []byte, req *pb.SearchEntriesRequest) (*pb.SearchEntriesResponse, error) {
+	var xmlResp XMLSearchEntriesResponse
+	if err := xml.Unmarshal(xmlData, &xmlResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal XML: %w", err)
+	}
+
+	nextToken, err := nextPageToken(xmlResp.HasMoreElements, xmlResp.NextCursor, searchFilter(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.SearchEntriesResponse{
+		Entries:       entriesFromXML(xmlResp.Entries),
+		NextPageToken: nextToken,
+		TotalCount:    xmlResp.TotalCount,
+	}, nil
+}
+
+// directoryFilter builds the XML filter from GetDirectoryRequest
+func directoryFilter(req *pb.GetDirectoryRequest) XMLEntryFilter {
+	filter := XMLEntryFilter{Participant: req.GetIspb()}
+	if req.KeyType != nil {
+		filter.KeyType = keyTypeToXML(req.GetKeyType())
+	}
+	if req.Status != nil {
+		filter.Status = entryStatusToXML(req.GetStatus())
+	}
+	return filter
+}
+
+// searchFilter builds the XML filter from SearchEntriesRequest
+func searchFilter(req *pb.SearchEntriesRequest) XMLEntryFilter {
+	filter := XMLEntryFilter{
+		Participant:   req.GetIspb(),
+		TaxIdNumber:   normalizeTaxID(req.GetAccountHolderDocument()),
+		AccountNumber: req.GetAccountNumber(),
+	}
+	if req.KeyType != nil {
+		filter.KeyType = keyTypeToXML(req.GetKeyType())
+	}
+	if req.Status != nil {
+		filter.Status = entryStatusToXML(req.GetStatus())
+	}
+	return filter
+}
+
+// entriesFromXML converts XML directory entries to gRPC Entry messages
+func entriesFromXML(xmlEntries []XMLDirectoryEntry) []*pb.Entry {
+	entries := make([]*pb.Entry, 0, len(xmlEntries))
+	for i := range xmlEntries {
+		entries = append(entries, entryFromXML(&xmlEntries[i].XMLExtendedEntry, xmlEntries[i].Status))
+	}
+	return entries
+}
+
+// entryFromXML converts one XML entry to gRPC Entry
+func entryFromXML(e *XMLExtendedEntry, status string) *pb.Entry {
+	createdAt := timestampFromXML(e.CreationTime)
+	updatedAt := timestampFromXML(e.LastModifiedDate)
+	if updatedAt == nil {
+		updatedAt = createdAt
+	}
+
+	return &pb.Entry{
+		EntryId:          e.Key,
+		ExternalId:       e.Key, // Bacen identifica o vínculo pela própria chave
+		KeyType:          keyTypeFromXML(e.KeyType),
+		KeyValue:         e.Key,
+		Account:          accountWithOwnerFromXML(&e.Account, &e.Owner),
+		Status:           entryStatusFromXML(status),
+		CreatedAt:        createdAt,
+		UpdatedAt:        updatedAt,
+		KeyOwnershipDate: timestampFromXML(e.KeyOwnershipDate),
+		RequestId:        e.RequestId,
+	}
+}
+
+// ========== VSYNC CONVERTERS ==========
+
+// VerifySyncRequestToXML converts gRPC VerifySyncRequest to XML bytes
+func VerifySyncRequestToXML(req *pb.VerifySyncRequest) ([]byte, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+
+	xmlReq := &XMLVerifySyncRequest{
+		Participant: req.ParticipantIspb,
+		KeyType:     keyTypeToXML(req.KeyType),
+		RequestId:   req.RequestId,
+	}
+
+	return marshalXML(xmlReq)
+}
+
+// VerifySyncResponseFromXML converts XML bytes to gRPC VerifySyncResponse
+func VerifySyncResponseFromXML(xmlData []byte) (*pb.VerifySyncResponse, error) {
+	var xmlResp XMLVerifySyncResponse
+	if err := xml.Unmarshal(xmlData, &xmlResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal XML: %w", err)
+	}
+
+	return &pb.VerifySyncResponse{
+		KeyType:    keyTypeFromXML(xmlResp.KeyType),
+		EntryCount: xmlResp.EntryCount,
+		Vsync:      strings.ToLower(xmlResp.VSync),
+	}, nil
+}
+
+// ListCidsRequestToXML converts gRPC ListCidsRequest to XML bytes
+func ListCidsRequestToXML(req *pb.ListCidsRequest) ([]byte, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+
+	xmlReq := &XMLListCidsRequest{
+		Participant: req.ParticipantIspb,
+		KeyType:     keyTypeToXML(req.KeyType),
+		RequestId:   req.RequestId,
+	}
+
+	return marshalXML(xmlReq)
+}
+
+// ListCidsResponseFromXML converts XML bytes to gRPC ListCidsResponse.
+// CIDs are lowercased and sorted so that clients can merge them with their own list.
+func ListCidsResponseFromXML(xmlData []byte) (*pb.ListCidsResponse, error) {
+	var xmlResp XMLListCidsResponse
+	if err := xml.Unmarshal(xmlData, &xmlResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal XML: %w", err)
+	}
+
+	cids := make([]string, 0, len(xmlResp.Cids))
+	for _, cid := range xmlResp.Cids {
+		cids = append(cids, strings.ToLower(strings.TrimSpace(cid)))
+	}
+	sort.Strings(cids)
+
+	return &pb.ListCidsResponse{Cids: cids}, nil
+}
+
+// GetEntryByCidRequestToXML converts gRPC GetEntryByCidRequest to XML bytes
+func GetEntryByCidRequestToXML(req *pb.GetEntryByCidRequest) ([]byte, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+
+	xmlReq := &XMLGetEntryByCidRequest{
+		Participant: req.ParticipantIspb,
+		Cid:         req.Cid,
+		RequestId:   req.RequestId,
+	}
+
+	return marshalXML(xmlReq)
+}
+
+// GetEntryByCidResponseFromXML converts XML bytes to gRPC GetEntryByCidResponse
+func GetEntryByCidResponseFromXML(xmlData []byte) (*pb.GetEntryByCidResponse, error) {
+	var xmlResp XMLGetEntryByCidResponse
+	if err := xml.Unmarshal(xmlData, &xmlResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal XML: %w", err)
+	}
+
+	return &pb.GetEntryByCidResponse{
+		Entry: entryFromXML(&xmlResp.Entry, ""),
 	}, nil
 }
 
@@ -184,6 +431,45 @@ func CreateClaimResponseFromXML(xmlData []byte) (*pb.CreateClaimResponse, error)
 	}, nil
 }
 
+// ConfirmClaimRequestToXML converts gRPC ConfirmClaimRequest to XML bytes
+func ConfirmClaimRequestToXML(req *pb.ConfirmClaimRequest) ([]byte, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+
+	// Bacen identifies the claim by its own ID
+	claimID := req.ExternalId
+	if claimID == "" {
+		claimID = req.ClaimId
+	}
+
+	xmlReq := &XMLConfirmClaimRequest{
+		ClaimId: claimID,
+	}
+
+	return marshalXML(xmlReq)
+}
+
+// ConfirmClaimResponseFromXML converts XML bytes to gRPC ConfirmClaimResponse
+func ConfirmClaimResponseFromXML(xmlData []byte) (*pb.ConfirmClaimResponse, error) {
+	var xmlResp XMLConfirmClaimResponse
+	if err := xml.Unmarshal(xmlData, &xmlResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal XML: %w", err)
+	}
+
+	confirmedAt := timestampFromXML(xmlResp.Claim.LastModified)
+	if confirmedAt == nil {
+		confirmedAt = timestampFromXML(xmlResp.ResponseTime)
+	}
+
+	return &pb.ConfirmClaimResponse{
+		ClaimId:            xmlResp.Claim.ClaimId,
+		Status:             claimStatusFromXML(xmlResp.Claim.Status),
+		ConfirmedAt:        confirmedAt,
+		BacenTransactionId: xmlResp.CorrelationId,
+	}, nil
+}
+
 // CancelClaimRequestToXML converts gRPC CancelClaimRequest to XML bytes
 func CancelClaimRequestToXML(req *pb.CancelClaimRequest) ([]byte, error) {
 	if req == nil {
@@ -288,6 +574,95 @@ func GetClaimResponseFromXML(xmlData []byte) (*pb.GetClaimResponse, error) {
 	}, nil
 }
 
+// ListClaimsRequestToXML converts gRPC ListClaimsRequest to XML bytes.
+// The opaque page token is resolved into the Bacen cursor (ErrInvalidPageToken if it does not match the filters).
+func ListClaimsRequestToXML(req *pb.ListClaimsRequest) ([]byte, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+
+	filter := claimFilter(req)
+	cursor, err := decodePageToken(req.PageToken, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	xmlReq := &XMLListClaimsRequest{
+		Filter:     filter,
+		Pagination: XMLPagination{Limit: req.PageSize, Cursor: cursor},
+		RequestId:  req.RequestId,
+	}
+
+	return marshalXML(xmlReq)
+}
+
+// ListClaimsResponseFromXML converts XML bytes to gRPC ListClaimsResponse.
+// The request is needed to bind the next page token to the same filters.
+func ListClaimsResponseFromXML(xmlData []byte, req *pb.ListClaimsRequest) (*pb.ListClaimsResponse, error) {
+	var xmlResp XMLListClaimsResponse
+	if err := xml.Unmarshal(xmlData, &xmlResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal XML: %w", err)
+	}
+
+	claims := make([]*pb.Claim, 0, len(xmlResp.Claims))
+	for i := range xmlResp.Claims {
+		claims = append(claims, claimFromXML(&xmlResp.Claims[i]))
+	}
+
+	nextToken, err := nextPageToken(xmlResp.HasMoreElements, xmlResp.NextCursor, claimFilter(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ListClaimsResponse{
+		Claims:        claims,
+		NextPageToken: nextToken,
+	}, nil
+}
+
+// claimFilter builds the XML filter from ListClaimsRequest
+func claimFilter(req *pb.ListClaimsRequest) XMLClaimFilter {
+	filter := XMLClaimFilter{
+		Participant: req.GetParticipantIspb(),
+		IsDonor:     req.IsDonor,
+		IsClaimer:   req.IsClaimer,
+	}
+	if req.Status != nil {
+		filter.Status = claimStatusToXML(req.GetStatus())
+	}
+	if req.ModifiedAfter != nil {
+		filter.ModifiedAfter = req.ModifiedAfter.AsTime().UTC().Format(time.RFC3339)
+	}
+	return filter
+}
+
+// claimFromXML converts the XML Claim to gRPC Claim
+func claimFromXML(c *XMLClaim) *pb.Claim {
+	createdAt := timestampFromXML(c.CreationTime)
+	updatedAt := timestampFromXML(c.LastModified)
+	if updatedAt == nil {
+		updatedAt = createdAt
+	}
+	completionEnd := timestampFromXML(c.CompletionPeriodEnd)
+
+	return &pb.Claim{
+		ClaimId:              c.ClaimId,
+		Status:               claimStatusFromXML(c.Status),
+		CompletionPeriodDays: 30,
+		CreatedAt:            createdAt,
+		ExpiresAt:            completionEnd,
+		ClaimerIspb:          c.ClaimerAccount.Participant,
+		OwnerIspb:            c.DonorParticipant,
+		Type:                 claimTypeFromXML(c.Type),
+		KeyType:              keyTypeFromXML(c.KeyType),
+		KeyValue:             c.Key,
+		ClaimerAccount:       accountWithOwnerFromXML(&c.ClaimerAccount, &c.Claimer),
+		ResolutionPeriodEnd:  timestampFromXML(c.ResolutionPeriodEnd),
+		CompletionPeriodEnd:  completionEnd,
+		UpdatedAt:            updatedAt,
+	}
+}
+
 // ========== PORTABILITY CONVERTERS ==========
 
 // InitiatePortabilityRequestToXML converts gRPC InitiatePortabilityRequest to XML bytes
@@ -460,6 +835,254 @@ func CancelPortabilityResponseFromXML(xmlData []byte) (*pb.CancelPortabilityResp
 	}, nil
 }
 
+// ========== INFRACTION REPORT CONVERTERS ==========
+
+// CreateInfractionReportRequestToXML converts gRPC CreateInfractionReportRequest to XML bytes
+func CreateInfractionReportRequestToXML(req *pb.CreateInfractionReportRequest) ([]byte, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+	if req.TransactionId == "" {
+		return nil, fmt.Errorf("transaction_id is required")
+	}
+
+	report := XMLInfractionReport{
+		TransactionId:       req.TransactionId,
+		Reason:              infractionReasonToXML(req.Reason),
+		SituationType:       infractionSituationTypeToXML(req.SituationType),
+		ReportDetails:       req.ReportDetails,
+		ReportedParticipant: req.ReportedIspb,
+	}
+	if req.ContactEmail != "" || req.ContactPhone != "" {
+		report.ContactInformation = &XMLContactInformation{
+			Email: req.ContactEmail,
+			Phone: req.ContactPhone,
+		}
+	}
+
+	xmlReq := &XMLCreateInfractionReportRequest{
+		Participant:      req.ParticipantIspb,
+		InfractionReport: report,
+		RequestId:        req.RequestId,
+	}
+
+	return marshalXML(xmlReq)
+}
+
+// CreateInfractionReportResponseFromXML converts XML bytes to gRPC CreateInfractionReportResponse
+func CreateInfractionReportResponseFromXML(xmlData []byte) (*pb.CreateInfractionReportResponse, error) {
+	var xmlResp XMLCreateInfractionReportResponse
+	if err := xml.Unmarshal(xmlData, &xmlResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal XML: %w", err)
+	}
+
+	return &pb.CreateInfractionReportResponse{
+		InfractionReport: infractionReportFromXML(&xmlResp.InfractionReport),
+	}, nil
+}
+
+// GetInfractionReportRequestToXML converts gRPC GetInfractionReportRequest to XML bytes
+func GetInfractionReportRequestToXML(req *pb.GetInfractionReportRequest) ([]byte, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+
+	xmlReq := &XMLGetInfractionReportRequest{
+		InfractionReportId: req.InfractionReportId,
+		RequestId:          req.RequestId,
+	}
+
+	return marshalXML(xmlReq)
+}
+
+// GetInfractionReportResponseFromXML converts XML bytes to gRPC GetInfractionReportResponse
+func GetInfractionReportResponseFromXML(xmlData []byte) (*pb.GetInfractionReportResponse, error) {
+	var xmlResp XMLGetInfractionReportResponse
+	if err := xml.Unmarshal(xmlData, &xmlResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal XML: %w", err)
+	}
+
+	return &pb.GetInfractionReportResponse{
+		InfractionReport: infractionReportFromXML(&xmlResp.InfractionReport),
+		Found:            xmlResp.InfractionReport.Id != "",
+	}, nil
+}
+
+// ListInfractionReportsRequestToXML converts gRPC ListInfractionReportsRequest to XML bytes.
+// The opaque page token is resolved into the Bacen cursor (ErrInvalidPageToken if it does not match the filters).
+func ListInfractionReportsRequestToXML(req *pb.ListInfractionReportsRequest) ([]byte, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+
+	filter := infractionReportFilter(req)
+	cursor, err := decodePageToken(req.PageToken, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	xmlReq := &XMLListInfractionReportsRequest{
+		Filter:     filter,
+		Pagination: XMLPagination{Limit: req.PageSize, Cursor: cursor},
+		RequestId:  req.RequestId,
+	}
+
+	return marshalXML(xmlReq)
+}
+
+// ListInfractionReportsResponseFromXML converts XML bytes to gRPC ListInfractionReportsResponse.
+// The request is needed to bind the next page token to the same filters.
+func ListInfractionReportsResponseFromXML(xmlData []byte, req *pb.ListInfractionReportsRequest) (*pb.ListInfractionReportsResponse, error) {
+	var xmlResp XMLListInfractionReportsResponse
+	if err := xml.Unmarshal(xmlData, &xmlResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal XML: %w", err)
+	}
+
+	reports := make([]*pb.InfractionReport, 0, len(xmlResp.InfractionReports))
+	for i := range xmlResp.InfractionReports {
+		reports = append(reports, infractionReportFromXML(&xmlResp.InfractionReports[i]))
+	}
+
+	nextToken, err := nextPageToken(xmlResp.HasMoreElements, xmlResp.NextCursor, infractionReportFilter(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ListInfractionReportsResponse{
+		InfractionReports: reports,
+		NextPageToken:     nextToken,
+	}, nil
+}
+
+// AcknowledgeInfractionReportRequestToXML converts gRPC AcknowledgeInfractionReportRequest to XML bytes
+func AcknowledgeInfractionReportRequestToXML(req *pb.AcknowledgeInfractionReportRequest) ([]byte, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+
+	xmlReq := &XMLAcknowledgeInfractionReportRequest{
+		InfractionReportId: req.InfractionReportId,
+		Participant:        req.ParticipantIspb,
+		RequestId:          req.RequestId,
+	}
+
+	return marshalXML(xmlReq)
+}
+
+// AcknowledgeInfractionReportResponseFromXML converts XML bytes to gRPC AcknowledgeInfractionReportResponse
+func AcknowledgeInfractionReportResponseFromXML(xmlData []byte) (*pb.AcknowledgeInfractionReportResponse, error) {
+	var xmlResp XMLAcknowledgeInfractionReportResponse
+	if err := xml.Unmarshal(xmlData, &xmlResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal XML: %w", err)
+	}
+
+	return &pb.AcknowledgeInfractionReportResponse{
+		InfractionReport: infractionReportFromXML(&xmlResp.InfractionReport),
+	}, nil
+}
+
+// CloseInfractionReportRequestToXML converts gRPC CloseInfractionReportRequest to XML bytes
+func CloseInfractionReportRequestToXML(req *pb.CloseInfractionReportRequest) ([]byte, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+
+	analysisResult := infractionAnalysisResultToXML(req.AnalysisResult)
+	if analysisResult == "" {
+		return nil, fmt.Errorf("analysis_result is required")
+	}
+
+	xmlReq := &XMLCloseInfractionReportRequest{
+		InfractionReportId: req.InfractionReportId,
+		Participant:        req.ParticipantIspb,
+		AnalysisResult:     analysisResult,
+		AnalysisDetails:    req.AnalysisDetails,
+		RequestId:          req.RequestId,
+	}
+
+	return marshalXML(xmlReq)
+}
+
+// CloseInfractionReportResponseFromXML converts XML bytes to gRPC CloseInfractionReportResponse
+func CloseInfractionReportResponseFromXML(xmlData []byte) (*pb.CloseInfractionReportResponse, error) {
+	var xmlResp XMLCloseInfractionReportResponse
+	if err := xml.Unmarshal(xmlData, &xmlResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal XML: %w", err)
+	}
+
+	return &pb.CloseInfractionReportResponse{
+		InfractionReport: infractionReportFromXML(&xmlResp.InfractionReport),
+	}, nil
+}
+
+// CancelInfractionReportRequestToXML converts gRPC CancelInfractionReportRequest to XML bytes
+func CancelInfractionReportRequestToXML(req *pb.CancelInfractionReportRequest) ([]byte, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+
+	xmlReq := &XMLCancelInfractionReportRequest{
+		InfractionReportId: req.InfractionReportId,
+		Participant:        req.ParticipantIspb,
+		RequestId:          req.RequestId,
+	}
+
+	return marshalXML(xmlReq)
+}
+
+// CancelInfractionReportResponseFromXML converts XML bytes to gRPC CancelInfractionReportResponse
+func CancelInfractionReportResponseFromXML(xmlData []byte) (*pb.CancelInfractionReportResponse, error) {
+	var xmlResp XMLCancelInfractionReportResponse
+	if err := xml.Unmarshal(xmlData, &xmlResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal XML: %w", err)
+	}
+
+	return &pb.CancelInfractionReportResponse{
+		InfractionReport: infractionReportFromXML(&xmlResp.InfractionReport),
+	}, nil
+}
+
+// infractionReportFilter builds the XML filter from ListInfractionReportsRequest
+func infractionReportFilter(req *pb.ListInfractionReportsRequest) XMLInfractionReportFilter {
+	filter := XMLInfractionReportFilter{Participant: req.GetParticipantIspb()}
+	if req.Status != nil {
+		filter.Status = infractionReportStatusToXML(req.GetStatus())
+	}
+	if req.ModifiedAfter != nil {
+		filter.ModifiedAfter = req.ModifiedAfter.AsTime().UTC().Format(time.RFC3339)
+	}
+	return filter
+}
+
+// infractionReportFromXML converts the XML InfractionReport to gRPC InfractionReport
+func infractionReportFromXML(r *XMLInfractionReportFull) *pb.InfractionReport {
+	createdAt := timestampFromXML(r.CreationTime)
+	updatedAt := timestampFromXML(r.LastModified)
+	if updatedAt == nil {
+		updatedAt = createdAt
+	}
+
+	report := &pb.InfractionReport{
+		InfractionReportId: r.Id,
+		TransactionId:      r.TransactionId,
+		Reason:             infractionReasonFromXML(r.Reason),
+		SituationType:      infractionSituationTypeFromXML(r.SituationType),
+		ReportDetails:      r.ReportDetails,
+		ReporterIspb:       r.ReporterParticipant,
+		ReportedIspb:       r.ReportedParticipant,
+		Status:             infractionReportStatusFromXML(r.Status),
+		AnalysisResult:     infractionAnalysisResultFromXML(r.AnalysisResult),
+		AnalysisDetails:    r.AnalysisDetails,
+		CreatedAt:          createdAt,
+		UpdatedAt:          updatedAt,
+	}
+	if r.ContactInformation != nil {
+		report.ContactEmail = r.ContactInformation.Email
+		report.ContactPhone = r.ContactInformation.Phone
+	}
+	return report
+}
+
 // ========== HELPER FUNCTIONS ==========
 
 // marshalXML marshals any XML struct to bytes with header
@@ -479,12 +1102,16 @@ func accountToXML(acc *commonv1.Account) XMLAccount {
 		return XMLAccount{}
 	}
 
-	return XMLAccount{
+	account := XMLAccount{
 		Participant:   acc.Ispb,
 		Branch:        acc.BranchCode,
 		AccountNumber: acc.AccountNumber,
 		AccountType:   accountTypeToXML(acc.AccountType),
 	}
+	if acc.OpeningDate != nil {
+		account.OpeningDate = acc.OpeningDate.AsTime().UTC().Format(time.RFC3339)
+	}
+	return account
 }
 
 // accountFromXML converts XML Account to gRPC Account
@@ -498,11 +1125,93 @@ func accountFromXML(acc *XMLAccount) *commonv1.Account {
 		BranchCode:    acc.Branch,
 		AccountNumber: acc.AccountNumber,
 		AccountType:   accountTypeFromXML(acc.AccountType),
+		OpeningDate:   timestampFromXML(acc.OpeningDate),
+	}
+}
+
+// taxIDFormatter remove a máscara de CPF/CNPJ (".", "/", "-")
+var taxIDFormatter = strings.NewReplacer(".", "", "/", "", "-", "", " ", "")
+
+var (
+	cpfFormatRegex = regexp.MustCompile(`^\d{11}$`)
+	// CNPJ numérico ou alfanumérico: 12 posições [0-9A-Z] + 2 dígitos verificadores
+	cnpjFormatRegex = regexp.MustCompile(`^[0-9A-Z]{12}\d{2}$`)
+)
+
+// normalizeTaxID removes CPF/CNPJ formatting and upper-cases alphanumeric CNPJs
+func normalizeTaxID(taxID string) string {
+	return strings.ToUpper(taxIDFormatter.Replace(strings.TrimSpace(taxID)))
+}
+
+// ownerToXML builds the XML Owner from the account holder fields
+func ownerToXML(acc *commonv1.Account) XMLOwner {
+	if acc == nil {
+		return XMLOwner{}
 	}
+
+	taxID := normalizeTaxID(acc.AccountHolderDocument)
+	return XMLOwner{
+		Type:        ownerTypeToXML(acc.DocumentType, taxID),
+		TaxIdNumber: taxID,
+		Name:        acc.AccountHolderName,
+	}
+}
+
+// accountWithOwnerFromXML converts XML Account + Owner to gRPC Account
+func accountWithOwnerFromXML(acc *XMLAccount, owner *XMLOwner) *commonv1.Account {
+	account := accountFromXML(acc)
+	if account == nil || owner == nil {
+		return account
+	}
+
+	account.AccountHolderName = owner.Name
+	account.AccountHolderDocument = normalizeTaxID(owner.TaxIdNumber)
+	account.DocumentType = documentTypeFromXML(owner.Type, account.AccountHolderDocument)
+	return account
 }
 
 // ========== ENUM CONVERTERS ==========
 
+// ownerTypeToXML converts gRPC DocumentType to XML owner type.
+// When the document type is not set, it is inferred from the tax ID format.
+func ownerTypeToXML(dt commonv1.DocumentType, taxID string) string {
+	switch dt {
+	case commonv1.DocumentType_DOCUMENT_TYPE_CPF:
+		return "NATURAL_PERSON"
+	case commonv1.DocumentType_DOCUMENT_TYPE_CNPJ:
+		return "LEGAL_PERSON"
+	}
+
+	switch {
+	case cpfFormatRegex.MatchString(taxID):
+		return "NATURAL_PERSON"
+	case cnpjFormatRegex.MatchString(taxID):
+		return "LEGAL_PERSON"
+	default:
+		return ""
+	}
+}
+
+// documentTypeFromXML converts XML owner type to gRPC DocumentType.
+// When the owner type is missing, it is inferred from the tax ID format.
+func documentTypeFromXML(ownerType, taxID string) commonv1.DocumentType {
+	switch ownerType {
+	case "NATURAL_PERSON":
+		return commonv1.DocumentType_DOCUMENT_TYPE_CPF
+	case "LEGAL_PERSON":
+		return commonv1.DocumentType_DOCUMENT_TYPE_CNPJ
+	}
+
+	switch {
+	case cpfFormatRegex.MatchString(taxID):
+		return commonv1.DocumentType_DOCUMENT_TYPE_CPF
+	case cnpjFormatRegex.MatchString(taxID):
+		return commonv1.DocumentType_DOCUMENT_TYPE_CNPJ
+	default:
+		return commonv1.DocumentType_DOCUMENT_TYPE_UNSPECIFIED
+	}
+}
+
 // keyTypeToXML converts gRPC KeyType to XML string
 func keyTypeToXML(kt commonv1.KeyType) string {
 	switch kt {
@@ -567,6 +1276,62 @@ func accountTypeFromXML(s string) commonv1.AccountType {
 	}
 }
 
+// entryStatusToXML converts gRPC EntryStatus to XML string
+func entryStatusToXML(st commonv1.EntryStatus) string {
+	switch st {
+	case commonv1.EntryStatus_ENTRY_STATUS_PENDING:
+		return "PENDING"
+	case commonv1.EntryStatus_ENTRY_STATUS_ACTIVE:
+		return "ACTIVE"
+	case commonv1.EntryStatus_ENTRY_STATUS_BLOCKED:
+		return "BLOCKED"
+	case commonv1.EntryStatus_ENTRY_STATUS_PORTABILITY_PENDING:
+		return "PORTABILITY_PENDING"
+	case commonv1.EntryStatus_ENTRY_STATUS_PORTABILITY_CONFIRMED:
+		return "PORTABILITY_CONFIRMED"
+	case commonv1.EntryStatus_ENTRY_STATUS_CLAIM_PENDING:
+		return "CLAIM_PENDING"
+	case commonv1.EntryStatus_ENTRY_STATUS_DELETED:
+		return "DELETED"
+	default:
+		return ""
+	}
+}
+
+// entryStatusFromXML converts XML string to gRPC EntryStatus (missing status means ACTIVE)
+func entryStatusFromXML(s string) commonv1.EntryStatus {
+	switch s {
+	case "", "ACTIVE":
+		return commonv1.EntryStatus_ENTRY_STATUS_ACTIVE
+	case "PENDING":
+		return commonv1.EntryStatus_ENTRY_STATUS_PENDING
+	case "BLOCKED":
+		return commonv1.EntryStatus_ENTRY_STATUS_BLOCKED
+	case "PORTABILITY_PENDING":
+		return commonv1.EntryStatus_ENTRY_STATUS_PORTABILITY_PENDING
+	case "PORTABILITY_CONFIRMED":
+		return commonv1.EntryStatus_ENTRY_STATUS_PORTABILITY_CONFIRMED
+	case "CLAIM_PENDING":
+		return commonv1.EntryStatus_ENTRY_STATUS_CLAIM_PENDING
+	case "DELETED":
+		return commonv1.EntryStatus_ENTRY_STATUS_DELETED
+	default:
+		return commonv1.EntryStatus_ENTRY_STATUS_UNSPECIFIED
+	}
+}
+
+// timestampFromXML parses an ISO 8601 timestamp (nil when empty or invalid)
+func timestampFromXML(s string) *timestamppb.Timestamp {
+	if s == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
 // claimStatusFromXML converts XML string to gRPC ClaimStatus
 func claimStatusFromXML(s string) commonv1.ClaimStatus {
 	switch s {
@@ -583,4 +1348,173 @@ func claimStatusFromXML(s string) commonv1.ClaimStatus {
 	default:
 		return commonv1.ClaimStatus_CLAIM_STATUS_UNSPECIFIED
 	}
-}
\ No newline at end of file
+}
+
+// claimStatusToXML converts gRPC ClaimStatus to XML string
+func claimStatusToXML(st commonv1.ClaimStatus) string {
+	switch st {
+	case commonv1.ClaimStatus_CLAIM_STATUS_OPEN:
+		return "OPEN"
+	case commonv1.ClaimStatus_CLAIM_STATUS_WAITING_RESOLUTION:
+		return "WAITING_RESOLUTION"
+	case commonv1.ClaimStatus_CLAIM_STATUS_CONFIRMED:
+		return "CONFIRMED"
+	case commonv1.ClaimStatus_CLAIM_STATUS_CANCELLED:
+		return "CANCELLED"
+	case commonv1.ClaimStatus_CLAIM_STATUS_COMPLETED:
+		return "COMPLETED"
+	default:
+		return ""
+	}
+}
+
+// claimTypeFromXML converts XML string to gRPC ClaimType
+func claimTypeFromXML(s string) pb.ClaimType {
+	switch s {
+	case "OWNERSHIP":
+		return pb.ClaimType_CLAIM_TYPE_OWNERSHIP
+	case "PORTABILITY":
+		return pb.ClaimType_CLAIM_TYPE_PORTABILITY
+	default:
+		return pb.ClaimType_CLAIM_TYPE_UNSPECIFIED
+	}
+}
+
+// infractionReportStatusToXML converts gRPC InfractionReportStatus to XML string
+func infractionReportStatusToXML(st pb.InfractionReportStatus) string {
+	switch st {
+	case pb.InfractionReportStatus_INFRACTION_REPORT_STATUS_OPEN:
+		return "OPEN"
+	case pb.InfractionReportStatus_INFRACTION_REPORT_STATUS_ACKNOWLEDGED:
+		return "ACKNOWLEDGED"
+	case pb.InfractionReportStatus_INFRACTION_REPORT_STATUS_CLOSED:
+		return "CLOSED"
+	case pb.InfractionReportStatus_INFRACTION_REPORT_STATUS_CANCELLED:
+		return "CANCELLED"
+	default:
+		return ""
+	}
+}
+
+// infractionReportStatusFromXML converts XML string to gRPC InfractionReportStatus
+func infractionReportStatusFromXML(s string) pb.InfractionReportStatus {
+	switch s {
+	case "OPEN":
+		return pb.InfractionReportStatus_INFRACTION_REPORT_STATUS_OPEN
+	case "ACKNOWLEDGED":
+		return pb.InfractionReportStatus_INFRACTION_REPORT_STATUS_ACKNOWLEDGED
+	case "CLOSED":
+		return pb.InfractionReportStatus_INFRACTION_REPORT_STATUS_CLOSED
+	case "CANCELLED":
+		return pb.InfractionReportStatus_INFRACTION_REPORT_STATUS_CANCELLED
+	default:
+		return pb.InfractionReportStatus_INFRACTION_REPORT_STATUS_UNSPECIFIED
+	}
+}
+
+// entryDeletionReasonToXML converts gRPC EntryDeletionReason to the Bacen XML reason
+// (unspecified is a deletion requested by the key owner)
+func entryDeletionReasonToXML(r pb.EntryDeletionReason) string {
+	switch r {
+	case pb.EntryDeletionReason_ENTRY_DELETION_REASON_ACCOUNT_CLOSURE:
+		return "ACCOUNT_CLOSURE"
+	case pb.EntryDeletionReason_ENTRY_DELETION_REASON_RECONCILIATION:
+		return "RECONCILIATION"
+	case pb.EntryDeletionReason_ENTRY_DELETION_REASON_FRAUD:
+		return "FRAUD"
+	default:
+		return "USER_REQUESTED"
+	}
+}
+
+// infractionReasonToXML converts gRPC InfractionReportReason to XML string
+func infractionReasonToXML(r pb.InfractionReportReason) string {
+	switch r {
+	case pb.InfractionReportReason_INFRACTION_REPORT_REASON_FRAUD:
+		return "FRAUD"
+	case pb.InfractionReportReason_INFRACTION_REPORT_REASON_REFUND_REQUEST:
+		return "REFUND_REQUEST"
+	case pb.InfractionReportReason_INFRACTION_REPORT_REASON_REFUND_CANCELLED:
+		return "REFUND_CANCELLED"
+	default:
+		return ""
+	}
+}
+
+// infractionReasonFromXML converts XML string to gRPC InfractionReportReason
+func infractionReasonFromXML(s string) pb.InfractionReportReason {
+	switch s {
+	case "FRAUD":
+		return pb.InfractionReportReason_INFRACTION_REPORT_REASON_FRAUD
+	case "REFUND_REQUEST":
+		return pb.InfractionReportReason_INFRACTION_REPORT_REASON_REFUND_REQUEST
+	case "REFUND_CANCELLED":
+		return pb.InfractionReportReason_INFRACTION_REPORT_REASON_REFUND_CANCELLED
+	default:
+		return pb.InfractionReportReason_INFRACTION_REPORT_REASON_UNSPECIFIED
+	}
+}
+
+// infractionSituationTypeToXML converts gRPC InfractionSituationType to XML string
+func infractionSituationTypeToXML(t pb.InfractionSituationType) string {
+	switch t {
+	case pb.InfractionSituationType_INFRACTION_SITUATION_TYPE_SCAM:
+		return "SCAM"
+	case pb.InfractionSituationType_INFRACTION_SITUATION_TYPE_ACCOUNT_TAKEOVER:
+		return "ACCOUNT_TAKEOVER"
+	case pb.InfractionSituationType_INFRACTION_SITUATION_TYPE_COERCION:
+		return "COERCION"
+	case pb.InfractionSituationType_INFRACTION_SITUATION_TYPE_FRAUDULENT_ACCESS:
+		return "FRAUDULENT_ACCESS"
+	case pb.InfractionSituationType_INFRACTION_SITUATION_TYPE_OTHER:
+		return "OTHER"
+	case pb.InfractionSituationType_INFRACTION_SITUATION_TYPE_UNKNOWN:
+		return "UNKNOWN"
+	default:
+		return ""
+	}
+}
+
+// infractionSituationTypeFromXML converts XML string to gRPC InfractionSituationType
+func infractionSituationTypeFromXML(s string) pb.InfractionSituationType {
+	switch s {
+	case "SCAM":
+		return pb.InfractionSituationType_INFRACTION_SITUATION_TYPE_SCAM
+	case "ACCOUNT_TAKEOVER":
+		return pb.InfractionSituationType_INFRACTION_SITUATION_TYPE_ACCOUNT_TAKEOVER
+	case "COERCION":
+		return pb.InfractionSituationType_INFRACTION_SITUATION_TYPE_COERCION
+	case "FRAUDULENT_ACCESS":
+		return pb.InfractionSituationType_INFRACTION_SITUATION_TYPE_FRAUDULENT_ACCESS
+	case "OTHER":
+		return pb.InfractionSituationType_INFRACTION_SITUATION_TYPE_OTHER
+	case "UNKNOWN":
+		return pb.InfractionSituationType_INFRACTION_SITUATION_TYPE_UNKNOWN
+	default:
+		return pb.InfractionSituationType_INFRACTION_SITUATION_TYPE_UNSPECIFIED
+	}
+}
+
+// infractionAnalysisResultToXML converts gRPC InfractionAnalysisResult to XML string
+func infractionAnalysisResultToXML(r pb.InfractionAnalysisResult) string {
+	switch r {
+	case pb.InfractionAnalysisResult_INFRACTION_ANALYSIS_RESULT_AGREED:
+		return "AGREED"
+	case pb.InfractionAnalysisResult_INFRACTION_ANALYSIS_RESULT_DISAGREED:
+		return "DISAGREED"
+	default:
+		return ""
+	}
+}
+
+// infractionAnalysisResultFromXML converts XML string to gRPC InfractionAnalysisResult
+func infractionAnalysisResultFromXML(s string) pb.InfractionAnalysisResult {
+	switch s {
+	case "AGREED":
+		return pb.InfractionAnalysisResult_INFRACTION_ANALYSIS_RESULT_AGREED
+	case "DISAGREED":
+		return pb.InfractionAnalysisResult_INFRACTION_ANALYSIS_RESULT_DISAGREED
+	default:
+		return pb.InfractionAnalysisResult_INFRACTION_ANALYSIS_RESULT_UNSPECIFIED
+	}
+}
@@ -0,0 +1,488 @@
+package xml
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	pb "github.com/lbpay-lab/dict-contracts/gen/proto/bridge/v1"
+	commonv1 "github.com/lbpay-lab/dict-contracts/gen/proto/common/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateEntryRequestToXML_Owner(t *testing.T) {
+	tests := []struct {
+		name         string
+		keyType      commonv1.KeyType
+		keyValue     string
+		document     string
+		documentType commonv1.DocumentType
+		wantType     string
+		wantTaxID    string
+	}{
+		{
+			name:         "cpf_owner",
+			keyType:      commonv1.KeyType_KEY_TYPE_CPF,
+			keyValue:     "12345678909",
+			document:     "12345678909",
+			documentType: commonv1.DocumentType_DOCUMENT_TYPE_CPF,
+			wantType:     "NATURAL_PERSON",
+			wantTaxID:    "12345678909",
+		},
+		{
+			name:         "numeric_cnpj_owner",
+			keyType:      commonv1.KeyType_KEY_TYPE_CNPJ,
+			keyValue:     "11222333000181",
+			document:     "11.222.333/0001-81",
+			documentType: commonv1.DocumentType_DOCUMENT_TYPE_CNPJ,
+			wantType:     "LEGAL_PERSON",
+			wantTaxID:    "11222333000181",
+		},
+		{
+			name:         "alphanumeric_cnpj_owner",
+			keyType:      commonv1.KeyType_KEY_TYPE_CNPJ,
+			keyValue:     "12ABC34501DE35",
+			document:     "12.ABC.345/01DE-35",
+			documentType: commonv1.DocumentType_DOCUMENT_TYPE_CNPJ,
+			wantType:     "LEGAL_PERSON",
+			wantTaxID:    "12ABC34501DE35",
+		},
+		{
+			name:      "alphanumeric_cnpj_owner_inferred_lowercase",
+			keyType:   commonv1.KeyType_KEY_TYPE_EMAIL,
+			keyValue:  "financeiro@empresa.com.br",
+			document:  "1a2b3c4d000179",
+			wantType:  "LEGAL_PERSON",
+			wantTaxID: "1A2B3C4D000179",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &pb.CreateEntryRequest{
+				Key: &commonv1.DictKey{KeyType: tt.keyType, KeyValue: tt.keyValue},
+				Account: &commonv1.Account{
+					Ispb:                  "12345678",
+					AccountType:           commonv1.AccountType_ACCOUNT_TYPE_CHECKING,
+					AccountNumber:         "123456",
+					BranchCode:            "0001",
+					AccountHolderName:     "Empresa Exemplo LTDA",
+					AccountHolderDocument: tt.document,
+					DocumentType:          tt.documentType,
+				},
+				RequestId: "request-001",
+			}
+
+			data, err := CreateEntryRequestToXML(req)
+			require.NoError(t, err)
+
+			var parsed XMLCreateEntryRequest
+			require.NoError(t, xml.Unmarshal(data, &parsed))
+			assert.Equal(t, tt.keyValue, parsed.Entry.Key)
+			assert.Equal(t, tt.wantType, parsed.Entry.Owner.Type)
+			assert.Equal(t, tt.wantTaxID, parsed.Entry.Owner.TaxIdNumber)
+			assert.Equal(t, "Empresa Exemplo LTDA", parsed.Entry.Owner.Name)
+		})
+	}
+}
+
+func TestGetEntryResponseFromXML_Owner(t *testing.T) {
+	tests := []struct {
+		name      string
+		ownerType string
+		taxID     string
+		wantDoc   string
+		wantType  commonv1.DocumentType
+	}{
+		{"cpf", "NATURAL_PERSON", "12345678909", "12345678909", commonv1.DocumentType_DOCUMENT_TYPE_CPF},
+		{"numeric_cnpj", "LEGAL_PERSON", "11222333000181", "11222333000181", commonv1.DocumentType_DOCUMENT_TYPE_CNPJ},
+		{"alphanumeric_cnpj", "LEGAL_PERSON", "12ABC34501DE35", "12ABC34501DE35", commonv1.DocumentType_DOCUMENT_TYPE_CNPJ},
+		{"alphanumeric_cnpj_without_type", "", "ABCDEFGHIJKL80", "ABCDEFGHIJKL80", commonv1.DocumentType_DOCUMENT_TYPE_CNPJ},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<GetEntryResponse>
+  <ResponseTime>2026-10-18T10:00:00Z</ResponseTime>
+  <CorrelationId>corr-001</CorrelationId>
+  <Entry>
+    <Key>` + tt.taxID + `</Key>
+    <KeyType>CNPJ</KeyType>
+    <Account>
+      <Participant>12345678</Participant>
+      <Branch>0001</Branch>
+      <AccountNumber>123456</AccountNumber>
+      <AccountType>CHECKING</AccountType>
+    </Account>
+    <Owner>
+      <Type>` + tt.ownerType + `</Type>
+      <TaxIdNumber>` + tt.taxID + `</TaxIdNumber>
+      <Name>Empresa Exemplo LTDA</Name>
+    </Owner>
+    <CreationTime>2026-10-18T10:00:00Z</CreationTime>
+    <KeyOwnershipDate>2026-10-18T10:00:00Z</KeyOwnershipDate>
+  </Entry>
+</GetEntryResponse>`)
+
+			resp, err := GetEntryResponseFromXML(data)
+			require.NoError(t, err)
+			require.NotNil(t, resp.Account)
+			assert.Equal(t, tt.wantDoc, resp.Account.AccountHolderDocument)
+			assert.Equal(t, tt.wantType, resp.Account.DocumentType)
+			assert.Equal(t, "Empresa Exemplo LTDA", resp.Account.AccountHolderName)
+		})
+	}
+}
+
+func directoryResponseXML(hasMore bool, cursor string) []byte {
+	more := "false"
+	if hasMore {
+		more = "true"
+	}
+	return []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<GetDirectoryResponse>
+  <ResponseTime>2026-10-18T10:00:00Z</ResponseTime>
+  <CorrelationId>corr-dir-001</CorrelationId>
+  <Entries>
+    <Entry>
+      <Key>12345678909</Key>
+      <KeyType>CPF</KeyType>
+      <Account>
+        <Participant>12345678</Participant>
+        <Branch>0001</Branch>
+        <AccountNumber>123456</AccountNumber>
+        <AccountType>CHECKING</AccountType>
+      </Account>
+      <Owner>
+        <Type>NATURAL_PERSON</Type>
+        <TaxIdNumber>12345678909</TaxIdNumber>
+        <Name>Fulano de Tal</Name>
+      </Owner>
+      <CreationTime>2026-10-01T10:00:00Z</CreationTime>
+      <KeyOwnershipDate>2026-10-01T10:00:00Z</KeyOwnershipDate>
+      <LastModifiedDate>2026-10-10T10:00:00Z</LastModifiedDate>
+      <Status>BLOCKED</Status>
+    </Entry>
+    <Entry>
+      <Key>cliente@exemplo.com.br</Key>
+      <KeyType>EMAIL</KeyType>
+      <Account>
+        <Participant>12345678</Participant>
+        <Branch>0002</Branch>
+        <AccountNumber>654321</AccountNumber>
+        <AccountType>SAVINGS</AccountType>
+      </Account>
+      <Owner>
+        <Type>LEGAL_PERSON</Type>
+        <TaxIdNumber>12ABC34501DE35</TaxIdNumber>
+        <Name>Empresa Exemplo LTDA</Name>
+      </Owner>
+      <CreationTime>2026-10-02T10:00:00Z</CreationTime>
+      <KeyOwnershipDate>2026-10-02T10:00:00Z</KeyOwnershipDate>
+    </Entry>
+  </Entries>
+  <HasMoreElements>` + more + `</HasMoreElements>
+  <NextCursor>` + cursor + `</NextCursor>
+  <TotalCount>250</TotalCount>
+</GetDirectoryResponse>`)
+}
+
+func TestGetDirectory_FiltersAndPagination(t *testing.T) {
+	keyType := commonv1.KeyType_KEY_TYPE_CPF
+	entryStatus := commonv1.EntryStatus_ENTRY_STATUS_BLOCKED
+	ispb := "12345678"
+	req := &pb.GetDirectoryRequest{KeyType: &keyType, Status: &entryStatus, Ispb: &ispb, PageSize: 2, RequestId: "req-dir-1"}
+
+	// First page: filters are sent, no cursor
+	data, err := GetDirectoryRequestToXML(req)
+	require.NoError(t, err)
+	var first XMLGetDirectoryRequest
+	require.NoError(t, xml.Unmarshal(data, &first))
+	assert.Equal(t, XMLEntryFilter{KeyType: "CPF", Status: "BLOCKED", Participant: "12345678"}, first.Filter)
+	assert.Equal(t, XMLPagination{Limit: 2}, first.Pagination)
+
+	// Response: entries converted, Bacen cursor wrapped in an opaque token
+	resp, err := GetDirectoryResponseFromXML(directoryResponseXML(true, "bacen-cursor-2"), req)
+	require.NoError(t, err)
+	require.Len(t, resp.Entries, 2)
+	assert.Equal(t, int32(250), resp.TotalCount)
+	assert.Equal(t, commonv1.EntryStatus_ENTRY_STATUS_BLOCKED, resp.Entries[0].Status)
+	assert.Equal(t, commonv1.EntryStatus_ENTRY_STATUS_ACTIVE, resp.Entries[1].Status)
+	assert.Equal(t, commonv1.KeyType_KEY_TYPE_EMAIL, resp.Entries[1].KeyType)
+	assert.Equal(t, "12ABC34501DE35", resp.Entries[1].Account.AccountHolderDocument)
+	assert.Equal(t, commonv1.DocumentType_DOCUMENT_TYPE_CNPJ, resp.Entries[1].Account.DocumentType)
+	assert.Equal(t, "2026-10-10T10:00:00Z", resp.Entries[0].UpdatedAt.AsTime().Format(time.RFC3339))
+	assert.Equal(t, resp.Entries[1].CreatedAt.AsTime(), resp.Entries[1].UpdatedAt.AsTime())
+	require.NotEmpty(t, resp.NextPageToken)
+	assert.NotContains(t, resp.NextPageToken, "bacen-cursor-2")
+
+	// Second page: token resolves back to the Bacen cursor
+	req.PageToken = resp.NextPageToken
+	data, err = GetDirectoryRequestToXML(req)
+	require.NoError(t, err)
+	var second XMLGetDirectoryRequest
+	require.NoError(t, xml.Unmarshal(data, &second))
+	assert.Equal(t, "bacen-cursor-2", second.Pagination.Cursor)
+
+	// Last page: no token
+	resp, err = GetDirectoryResponseFromXML(directoryResponseXML(false, ""), req)
+	require.NoError(t, err)
+	assert.Empty(t, resp.NextPageToken)
+}
+
+// Bacen reporting more elements without a cursor must not end the pagination silently
+func TestGetDirectory_MoreElementsWithoutCursor(t *testing.T) {
+	req := &pb.GetDirectoryRequest{PageSize: 2, RequestId: "req-dir-3"}
+	_, err := GetDirectoryResponseFromXML(directoryResponseXML(true, ""), req)
+	assert.ErrorIs(t, err, ErrMissingNextCursor)
+}
+
+func TestPageToken_BoundToFilters(t *testing.T) {
+	keyType := commonv1.KeyType_KEY_TYPE_CPF
+	req := &pb.GetDirectoryRequest{KeyType: &keyType, RequestId: "req-dir-2"}
+	resp, err := GetDirectoryResponseFromXML(directoryResponseXML(true, "bacen-cursor-2"), req)
+	require.NoError(t, err)
+
+	// Same token with different filters is rejected
+	otherType := commonv1.KeyType_KEY_TYPE_EMAIL
+	_, err = GetDirectoryRequestToXML(&pb.GetDirectoryRequest{KeyType: &otherType, PageToken: resp.NextPageToken})
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+
+	// Token issued by GetDirectory is not valid for SearchEntries
+	ispb := "12345678"
+	_, err = SearchEntriesRequestToXML(&pb.SearchEntriesRequest{Ispb: &ispb, PageToken: resp.NextPageToken})
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+
+	_, err = GetDirectoryRequestToXML(&pb.GetDirectoryRequest{PageToken: "not-a-token!"})
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+}
+
+func TestSearchEntriesRequestToXML_Filters(t *testing.T) {
+	document := "12.ABC.345/01DE-35"
+	account := "654321"
+	entryStatus := commonv1.EntryStatus_ENTRY_STATUS_ACTIVE
+	data, err := SearchEntriesRequestToXML(&pb.SearchEntriesRequest{
+		AccountHolderDocument: &document,
+		AccountNumber:         &account,
+		Status:                &entryStatus,
+		PageSize:              50,
+		RequestId:             "req-search-1",
+	})
+	require.NoError(t, err)
+
+	var parsed XMLSearchEntriesRequest
+	require.NoError(t, xml.Unmarshal(data, &parsed))
+	assert.Equal(t, XMLEntryFilter{Status: "ACTIVE", TaxIdNumber: "12ABC34501DE35", AccountNumber: "654321"}, parsed.Filter)
+	assert.Equal(t, int32(50), parsed.Pagination.Limit)
+	assert.Equal(t, "req-search-1", parsed.RequestId)
+}
+
+func TestDeleteEntryRequestToXML_Reason(t *testing.T) {
+	tests := []struct {
+		reason pb.EntryDeletionReason
+		want   string
+	}{
+		{pb.EntryDeletionReason_ENTRY_DELETION_REASON_UNSPECIFIED, "USER_REQUESTED"},
+		{pb.EntryDeletionReason_ENTRY_DELETION_REASON_ACCOUNT_CLOSURE, "ACCOUNT_CLOSURE"},
+		{pb.EntryDeletionReason_ENTRY_DELETION_REASON_FRAUD, "FRAUD"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			data, err := DeleteEntryRequestToXML(&pb.DeleteEntryRequest{EntryId: "entry-1", RequestId: "req-1", Reason: tt.reason})
+			require.NoError(t, err)
+			var xmlReq XMLDeleteEntryRequest
+			require.NoError(t, xml.Unmarshal(data, &xmlReq))
+			assert.Equal(t, tt.want, xmlReq.Reason)
+		})
+	}
+}
+
+func TestCreateInfractionReport_RoundTrip(t *testing.T) {
+	req := &pb.CreateInfractionReportRequest{
+		ParticipantIspb: "12345678",
+		TransactionId:   "E1234567820251018000000001",
+		Reason:          pb.InfractionReportReason_INFRACTION_REPORT_REASON_FRAUD,
+		SituationType:   pb.InfractionSituationType_INFRACTION_SITUATION_TYPE_ACCOUNT_TAKEOVER,
+		ReportDetails:   "conta invadida",
+		ContactEmail:    "fraude@lbpay.com.br",
+		RequestId:       "req-inf-1",
+	}
+
+	data, err := CreateInfractionReportRequestToXML(req)
+	require.NoError(t, err)
+	var xmlReq XMLCreateInfractionReportRequest
+	require.NoError(t, xml.Unmarshal(data, &xmlReq))
+	assert.Equal(t, "12345678", xmlReq.Participant)
+	assert.Equal(t, "FRAUD", xmlReq.InfractionReport.Reason)
+	assert.Equal(t, "ACCOUNT_TAKEOVER", xmlReq.InfractionReport.SituationType)
+	require.NotNil(t, xmlReq.InfractionReport.ContactInformation)
+	assert.Equal(t, "fraude@lbpay.com.br", xmlReq.InfractionReport.ContactInformation.Email)
+
+	resp, err := CreateInfractionReportResponseFromXML([]byte(`<CreateInfractionReportResponse>
+  <ResponseTime>2026-10-18T10:00:00Z</ResponseTime>
+  <CorrelationId>corr-1</CorrelationId>
+  <InfractionReport>
+    <TransactionId>E1234567820251018000000001</TransactionId>
+    <Reason>FRAUD</Reason>
+    <SituationType>ACCOUNT_TAKEOVER</SituationType>
+    <ReportDetails>conta invadida</ReportDetails>
+    <Id>91a2c3d4-0000-4000-8000-000000000001</Id>
+    <Status>OPEN</Status>
+    <ReporterParticipant>12345678</ReporterParticipant>
+    <CreationTime>2026-10-18T10:00:00Z</CreationTime>
+    <LastModified>2026-10-18T10:00:00Z</LastModified>
+  </InfractionReport>
+</CreateInfractionReportResponse>`))
+	require.NoError(t, err)
+	report := resp.InfractionReport
+	assert.Equal(t, "91a2c3d4-0000-4000-8000-000000000001", report.InfractionReportId)
+	assert.Equal(t, pb.InfractionReportStatus_INFRACTION_REPORT_STATUS_OPEN, report.Status)
+	assert.Equal(t, pb.InfractionSituationType_INFRACTION_SITUATION_TYPE_ACCOUNT_TAKEOVER, report.SituationType)
+	assert.Equal(t, "12345678", report.ReporterIspb)
+	assert.Equal(t, "2026-10-18T10:00:00Z", report.CreatedAt.AsTime().Format(time.RFC3339))
+}
+
+func TestCloseInfractionReportRequestToXML_AnalysisResult(t *testing.T) {
+	_, err := CloseInfractionReportRequestToXML(&pb.CloseInfractionReportRequest{InfractionReportId: "inf-1", ParticipantIspb: "87654321"})
+	assert.Error(t, err)
+
+	data, err := CloseInfractionReportRequestToXML(&pb.CloseInfractionReportRequest{
+		InfractionReportId: "inf-1",
+		ParticipantIspb:    "87654321",
+		AnalysisResult:     pb.InfractionAnalysisResult_INFRACTION_ANALYSIS_RESULT_DISAGREED,
+		AnalysisDetails:    "transacao legitima",
+	})
+	require.NoError(t, err)
+	var xmlReq XMLCloseInfractionReportRequest
+	require.NoError(t, xml.Unmarshal(data, &xmlReq))
+	assert.Equal(t, "DISAGREED", xmlReq.AnalysisResult)
+	assert.Equal(t, "87654321", xmlReq.Participant)
+}
+
+func TestListInfractionReports_Pagination(t *testing.T) {
+	infractionStatus := pb.InfractionReportStatus_INFRACTION_REPORT_STATUS_OPEN
+	req := &pb.ListInfractionReportsRequest{ParticipantIspb: "12345678", Status: &infractionStatus, PageSize: 10}
+
+	data, err := ListInfractionReportsRequestToXML(req)
+	require.NoError(t, err)
+	var first XMLListInfractionReportsRequest
+	require.NoError(t, xml.Unmarshal(data, &first))
+	assert.Equal(t, XMLInfractionReportFilter{Participant: "12345678", Status: "OPEN"}, first.Filter)
+
+	resp, err := ListInfractionReportsResponseFromXML([]byte(`<ListInfractionReportsResponse>
+  <InfractionReports>
+    <InfractionReport><Id>inf-1</Id><Status>OPEN</Status></InfractionReport>
+    <InfractionReport><Id>inf-2</Id><Status>OPEN</Status></InfractionReport>
+  </InfractionReports>
+  <HasMoreElements>true</HasMoreElements>
+  <NextCursor>bacen-cursor-inf</NextCursor>
+</ListInfractionReportsResponse>`), req)
+	require.NoError(t, err)
+	require.Len(t, resp.InfractionReports, 2)
+	require.NotEmpty(t, resp.NextPageToken)
+
+	req.PageToken = resp.NextPageToken
+	data, err = ListInfractionReportsRequestToXML(req)
+	require.NoError(t, err)
+	var second XMLListInfractionReportsRequest
+	require.NoError(t, xml.Unmarshal(data, &second))
+	assert.Equal(t, "bacen-cursor-inf", second.Pagination.Cursor)
+
+	// Token is bound to the participant
+	_, err = ListInfractionReportsRequestToXML(&pb.ListInfractionReportsRequest{ParticipantIspb: "87654321", PageToken: resp.NextPageToken})
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+}
+
+func TestListClaims_Pagination(t *testing.T) {
+	isDonor := true
+	req := &pb.ListClaimsRequest{ParticipantIspb: "12345678", IsDonor: &isDonor, PageSize: 10}
+
+	data, err := ListClaimsRequestToXML(req)
+	require.NoError(t, err)
+	var first XMLListClaimsRequest
+	require.NoError(t, xml.Unmarshal(data, &first))
+	assert.Equal(t, "12345678", first.Filter.Participant)
+	require.NotNil(t, first.Filter.IsDonor)
+	assert.True(t, *first.Filter.IsDonor)
+	assert.Nil(t, first.Filter.IsClaimer)
+
+	resp, err := ListClaimsResponseFromXML([]byte(`<ListClaimsResponse>
+  <Claims>
+    <Claim>
+      <ClaimId>claim-1</ClaimId><Type>OWNERSHIP</Type><Key>+5511988887777</Key><KeyType>PHONE</KeyType>
+      <Status>OPEN</Status><DonorParticipant>12345678</DonorParticipant>
+      <ClaimerAccount><Participant>87654321</Participant><Branch>0001</Branch><AccountNumber>2001</AccountNumber><AccountType>CHECKING</AccountType></ClaimerAccount>
+      <Claimer><Type>NATURAL_PERSON</Type><TaxIdNumber>98765432100</TaxIdNumber><Name>Joao Souza</Name></Claimer>
+      <ResolutionPeriodEnd>2025-10-25T12:00:00Z</ResolutionPeriodEnd>
+      <CompletionPeriodEnd>2025-11-17T12:00:00Z</CompletionPeriodEnd>
+      <CreationTime>2025-10-18T12:00:00Z</CreationTime>
+    </Claim>
+  </Claims>
+  <HasMoreElements>true</HasMoreElements>
+  <NextCursor>bacen-cursor-claim</NextCursor>
+</ListClaimsResponse>`), req)
+	require.NoError(t, err)
+	require.Len(t, resp.Claims, 1)
+	claim := resp.Claims[0]
+	assert.Equal(t, "claim-1", claim.ClaimId)
+	assert.Equal(t, pb.ClaimType_CLAIM_TYPE_OWNERSHIP, claim.Type)
+	assert.Equal(t, commonv1.KeyType_KEY_TYPE_PHONE, claim.KeyType)
+	assert.Equal(t, commonv1.ClaimStatus_CLAIM_STATUS_OPEN, claim.Status)
+	assert.Equal(t, "12345678", claim.OwnerIspb)
+	assert.Equal(t, "87654321", claim.ClaimerIspb)
+	assert.Equal(t, "98765432100", claim.ClaimerAccount.AccountHolderDocument)
+	assert.Equal(t, claim.CreatedAt.AsTime(), claim.UpdatedAt.AsTime())
+	require.NotEmpty(t, resp.NextPageToken)
+
+	req.PageToken = resp.NextPageToken
+	data, err = ListClaimsRequestToXML(req)
+	require.NoError(t, err)
+	var second XMLListClaimsRequest
+	require.NoError(t, xml.Unmarshal(data, &second))
+	assert.Equal(t, "bacen-cursor-claim", second.Pagination.Cursor)
+
+	// Token is bound to the role filter
+	_, err = ListClaimsRequestToXML(&pb.ListClaimsRequest{ParticipantIspb: "12345678", PageToken: resp.NextPageToken})
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+}
+
+func TestVSync_Converters(t *testing.T) {
+	data, err := VerifySyncRequestToXML(&pb.VerifySyncRequest{ParticipantIspb: "12345678", KeyType: commonv1.KeyType_KEY_TYPE_EMAIL, RequestId: "req-1"})
+	require.NoError(t, err)
+	var verifyReq XMLVerifySyncRequest
+	require.NoError(t, xml.Unmarshal(data, &verifyReq))
+	assert.Equal(t, "12345678", verifyReq.Participant)
+	assert.Equal(t, "EMAIL", verifyReq.KeyType)
+
+	verify, err := VerifySyncResponseFromXML([]byte(`<VerifySyncResponse>
+  <Participant>12345678</Participant><KeyType>EMAIL</KeyType><EntryCount>2</EntryCount>
+  <VSync>AB00000000000000000000000000000000000000000000000000000000000001</VSync>
+</VerifySyncResponse>`))
+	require.NoError(t, err)
+	assert.Equal(t, commonv1.KeyType_KEY_TYPE_EMAIL, verify.KeyType)
+	assert.Equal(t, int64(2), verify.EntryCount)
+	assert.Equal(t, "ab00000000000000000000000000000000000000000000000000000000000001", verify.Vsync)
+
+	// CIDs come back lowercase and sorted
+	cids, err := ListCidsResponseFromXML([]byte(`<ListCidsResponse><Cids><Cid>FF01</Cid><Cid>0a02</Cid></Cids></ListCidsResponse>`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0a02", "ff01"}, cids.Cids)
+
+	found, err := GetEntryByCidResponseFromXML([]byte(`<GetEntryByCidResponse>
+  <Entry>
+    <Key>a@example.com</Key><KeyType>EMAIL</KeyType>
+    <Account><Participant>12345678</Participant><Branch>0001</Branch><AccountNumber>1001</AccountNumber><AccountType>CHECKING</AccountType></Account>
+    <Owner><Type>NATURAL_PERSON</Type><TaxIdNumber>12345678901</TaxIdNumber><Name>Maria Silva</Name></Owner>
+    <CreationTime>2025-10-18T12:00:00Z</CreationTime>
+    <KeyOwnershipDate>2025-10-20T08:30:00Z</KeyOwnershipDate>
+  </Entry>
+</GetEntryByCidResponse>`))
+	require.NoError(t, err)
+	assert.Equal(t, "a@example.com", found.Entry.KeyValue)
+	assert.Equal(t, commonv1.EntryStatus_ENTRY_STATUS_ACTIVE, found.Entry.Status)
+	assert.Equal(t, "12345678901", found.Entry.Account.AccountHolderDocument)
+	assert.Equal(t, time.Date(2025, 10, 20, 8, 30, 0, 0, time.UTC), found.Entry.KeyOwnershipDate.AsTime())
+}
@@ -0,0 +1,70 @@
+package xml
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+)
+
+// ErrInvalidPageToken is returned when a page token is malformed or was issued for other filters
+var ErrInvalidPageToken = errors.New("invalid page_token")
+
+// ErrMissingNextCursor is returned when Bacen reports more elements but no cursor to fetch them
+var ErrMissingNextCursor = errors.New("bacen reported more elements without a next cursor")
+
+// pageToken is the opaque gRPC page token: the Bacen cursor bound to the filters of the query
+type pageToken struct {
+	Cursor string `json:"c"`
+	Filter string `json:"f"`
+}
+
+// encodePageToken wraps the Bacen cursor into an opaque page token.
+// An empty cursor (last page) yields an empty token.
+func encodePageToken(cursor string, filter interface{}) string {
+	if cursor == "" {
+		return ""
+	}
+	data, _ := json.Marshal(pageToken{Cursor: cursor, Filter: filterFingerprint(filter)})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// nextPageToken returns the page token of the page after a Bacen response. More elements without
+// a cursor is an error: an empty token would end the pagination silently.
+func nextPageToken(hasMoreElements bool, cursor string, filter interface{}) (string, error) {
+	if !hasMoreElements {
+		return "", nil
+	}
+	if cursor == "" {
+		return "", ErrMissingNextCursor
+	}
+	return encodePageToken(cursor, filter), nil
+}
+
+// decodePageToken extracts the Bacen cursor from an opaque page token.
+// An empty token means the first page.
+func decodePageToken(token string, filter interface{}) (string, error) {
+	if token == "" {
+		return "", nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", ErrInvalidPageToken
+	}
+	var pt pageToken
+	if err := json.Unmarshal(data, &pt); err != nil || pt.Cursor == "" {
+		return "", ErrInvalidPageToken
+	}
+	if pt.Filter != filterFingerprint(filter) {
+		return "", ErrInvalidPageToken
+	}
+	return pt.Cursor, nil
+}
+
+// filterFingerprint identifies a filter combination so a token cannot be replayed with other filters
+func filterFingerprint(filter interface{}) string {
+	data, _ := json.Marshal(filter)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
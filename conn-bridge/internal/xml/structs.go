@@ -17,8 +17,10 @@ type XMLAccount struct {
 }
 
 // XMLOwner representa o dono da chave
+// TaxIdNumber: CPF (11 dígitos) ou CNPJ (14 posições, numérico ou alfanumérico),
+// sempre sem máscara e com letras maiúsculas
 type XMLOwner struct {
-	Type        string `xml:"Type"`
+	Type        string `xml:"Type"` // NATURAL_PERSON, LEGAL_PERSON
 	TaxIdNumber string `xml:"TaxIdNumber"`
 	Name        string `xml:"Name"`
 	TradeName   string `xml:"TradeName,omitempty"` // Nome fantasia (apenas PJ)
@@ -61,6 +63,7 @@ type XMLExtendedEntry struct {
 	CreationTime     string     `xml:"CreationTime"`
 	KeyOwnershipDate string     `xml:"KeyOwnershipDate"`
 	LastModifiedDate string     `xml:"LastModifiedDate,omitempty"`
+	RequestId        string     `xml:"RequestId,omitempty"` // Última inclusão/alteração aceita (chave do HMAC do CID)
 }
 
 // ========== UPDATE ENTRY ==========
@@ -128,6 +131,131 @@ type XMLGetEntryResponse struct {
 	Entry         XMLExtendedEntry `xml:"Entry"`
 }
 
+// ========== DIRECTORY / SEARCH ==========
+
+// XMLEntryFilter representa os filtros de listagem do diretório DICT
+type XMLEntryFilter struct {
+	KeyType       string `xml:"KeyType,omitempty"`
+	Status        string `xml:"Status,omitempty"`
+	Participant   string `xml:"Participant,omitempty"`
+	TaxIdNumber   string `xml:"TaxIdNumber,omitempty"`
+	AccountNumber string `xml:"AccountNumber,omitempty"`
+}
+
+// XMLPagination representa a paginação por cursor do BACEN
+type XMLPagination struct {
+	Limit  int32  `xml:"Limit"`
+	Cursor string `xml:"Cursor,omitempty"` // Vazio na primeira página
+}
+
+// XMLDirectoryEntry representa um Entry listado pelo diretório (com status)
+type XMLDirectoryEntry struct {
+	XMLExtendedEntry
+	Status string `xml:"Status,omitempty"` // ACTIVE, BLOCKED, ... (vazio = ACTIVE)
+}
+
+// XMLGetDirectoryRequest representa a requisição de listagem do diretório DICT
+type XMLGetDirectoryRequest struct {
+	XMLName    xml.Name       `xml:"GetDirectoryRequest"`
+	Signature  string         `xml:"Signature,omitempty"`
+	Filter     XMLEntryFilter `xml:"Filter"`
+	Pagination XMLPagination  `xml:"Pagination"`
+	RequestId  string         `xml:"RequestId"`
+}
+
+// XMLGetDirectoryResponse representa a resposta do BACEN
+type XMLGetDirectoryResponse struct {
+	XMLName         xml.Name            `xml:"GetDirectoryResponse"`
+	Signature       string              `xml:"Signature,omitempty"`
+	ResponseTime    string              `xml:"ResponseTime"`
+	CorrelationId   string              `xml:"CorrelationId"`
+	Entries         []XMLDirectoryEntry `xml:"Entries>Entry"`
+	HasMoreElements bool                `xml:"HasMoreElements"`
+	NextCursor      string              `xml:"NextCursor,omitempty"`
+	TotalCount      int32               `xml:"TotalCount"`
+}
+
+// XMLSearchEntriesRequest representa a requisição de busca de chaves por titular/conta/participante
+type XMLSearchEntriesRequest struct {
+	XMLName    xml.Name       `xml:"SearchEntriesRequest"`
+	Signature  string         `xml:"Signature,omitempty"`
+	Filter     XMLEntryFilter `xml:"Filter"`
+	Pagination XMLPagination  `xml:"Pagination"`
+	RequestId  string         `xml:"RequestId"`
+}
+
+// XMLSearchEntriesResponse representa a resposta do BACEN
+type XMLSearchEntriesResponse struct {
+	XMLName         xml.Name            `xml:"SearchEntriesResponse"`
+	Signature       string              `xml:"Signature,omitempty"`
+	ResponseTime    string              `xml:"ResponseTime"`
+	CorrelationId   string              `xml:"CorrelationId"`
+	Entries         []XMLDirectoryEntry `xml:"Entries>Entry"`
+	HasMoreElements bool                `xml:"HasMoreElements"`
+	NextCursor      string              `xml:"NextCursor,omitempty"`
+	TotalCount      int32               `xml:"TotalCount"`
+}
+
+// ========== VSYNC ==========
+
+// XMLVerifySyncRequest representa a consulta do VSync de um participante e tipo de chave
+type XMLVerifySyncRequest struct {
+	XMLName     xml.Name `xml:"VerifySyncRequest"`
+	Signature   string   `xml:"Signature,omitempty"`
+	Participant string   `xml:"Participant"`
+	KeyType     string   `xml:"KeyType"`
+	RequestId   string   `xml:"RequestId"`
+}
+
+// XMLVerifySyncResponse representa a resposta do BACEN
+// VSync: XOR dos CIDs das chaves (hex)
+type XMLVerifySyncResponse struct {
+	XMLName       xml.Name `xml:"VerifySyncResponse"`
+	Signature     string   `xml:"Signature,omitempty"`
+	ResponseTime  string   `xml:"ResponseTime"`
+	CorrelationId string   `xml:"CorrelationId"`
+	Participant   string   `xml:"Participant"`
+	KeyType       string   `xml:"KeyType"`
+	EntryCount    int64    `xml:"EntryCount"`
+	VSync         string   `xml:"VSync"`
+}
+
+// XMLListCidsRequest representa a requisição dos CIDs por trás de um VSync
+type XMLListCidsRequest struct {
+	XMLName     xml.Name `xml:"ListCidsRequest"`
+	Signature   string   `xml:"Signature,omitempty"`
+	Participant string   `xml:"Participant"`
+	KeyType     string   `xml:"KeyType"`
+	RequestId   string   `xml:"RequestId"`
+}
+
+// XMLListCidsResponse representa a resposta do BACEN
+type XMLListCidsResponse struct {
+	XMLName       xml.Name `xml:"ListCidsResponse"`
+	Signature     string   `xml:"Signature,omitempty"`
+	ResponseTime  string   `xml:"ResponseTime"`
+	CorrelationId string   `xml:"CorrelationId"`
+	Cids          []string `xml:"Cids>Cid"`
+}
+
+// XMLGetEntryByCidRequest representa a requisição da chave identificada por um CID
+type XMLGetEntryByCidRequest struct {
+	XMLName     xml.Name `xml:"GetEntryByCidRequest"`
+	Signature   string   `xml:"Signature,omitempty"`
+	Participant string   `xml:"Participant"`
+	Cid         string   `xml:"Cid"`
+	RequestId   string   `xml:"RequestId"`
+}
+
+// XMLGetEntryByCidResponse representa a resposta do BACEN
+type XMLGetEntryByCidResponse struct {
+	XMLName       xml.Name         `xml:"GetEntryByCidResponse"`
+	Signature     string           `xml:"Signature,omitempty"`
+	ResponseTime  string           `xml:"ResponseTime"`
+	CorrelationId string           `xml:"CorrelationId"`
+	Entry         XMLExtendedEntry `xml:"Entry"`
+}
+
 // ========== CLAIM STRUCTURES ==========
 
 // XMLClaim represents a portability or ownership claim
@@ -211,6 +339,35 @@ type XMLCompleteClaimResponse struct {
 	Claim         XMLClaim `xml:"Claim"`
 }
 
+// XMLClaimFilter represents the filters of the claim listing
+type XMLClaimFilter struct {
+	Participant   string `xml:"Participant"`
+	IsDonor       *bool  `xml:"IsDonor,omitempty"`
+	IsClaimer     *bool  `xml:"IsClaimer,omitempty"`
+	Status        string `xml:"Status,omitempty"`
+	ModifiedAfter string `xml:"ModifiedAfter,omitempty"`
+}
+
+// XMLListClaimsRequest represents GET /claims/ request
+type XMLListClaimsRequest struct {
+	XMLName    xml.Name       `xml:"ListClaimsRequest"`
+	Signature  string         `xml:"Signature,omitempty"`
+	Filter     XMLClaimFilter `xml:"Filter"`
+	Pagination XMLPagination  `xml:"Pagination"`
+	RequestId  string         `xml:"RequestId,omitempty"`
+}
+
+// XMLListClaimsResponse represents GET /claims/ response
+type XMLListClaimsResponse struct {
+	XMLName         xml.Name   `xml:"ListClaimsResponse"`
+	Signature       string     `xml:"Signature,omitempty"`
+	ResponseTime    string     `xml:"ResponseTime"`
+	CorrelationId   string     `xml:"CorrelationId"`
+	Claims          []XMLClaim `xml:"Claims>Claim"`
+	HasMoreElements bool       `xml:"HasMoreElements"`
+	NextCursor      string     `xml:"NextCursor,omitempty"`
+}
+
 // ========== INFRACTION STRUCTURES ==========
 
 // XMLContactInformation representa as informações de contato
@@ -221,19 +378,21 @@ type XMLContactInformation struct {
 
 // XMLCreateInfractionReportRequest representa o request do manual (seção 4.2)
 type XMLCreateInfractionReportRequest struct {
-	XMLName          xml.Name             `xml:"CreateInfractionReportRequest"`
-	Signature        string               `xml:"Signature,omitempty"`
-	Participant      string               `xml:"Participant"`
-	InfractionReport XMLInfractionReport  `xml:"InfractionReport"`
+	XMLName          xml.Name            `xml:"CreateInfractionReportRequest"`
+	Signature        string              `xml:"Signature,omitempty"`
+	Participant      string              `xml:"Participant"`
+	InfractionReport XMLInfractionReport `xml:"InfractionReport"`
+	RequestId        string              `xml:"RequestId,omitempty"`
 }
 
 // XMLInfractionReport representa o InfractionReport do manual
 type XMLInfractionReport struct {
-	TransactionId      string                  `xml:"TransactionId"`
-	Reason             string                  `xml:"Reason"`
-	SituationType      string                  `xml:"SituationType"`
-	ReportDetails      string                  `xml:"ReportDetails,omitempty"`
-	ContactInformation *XMLContactInformation  `xml:"ContactInformation"`
+	TransactionId       string                 `xml:"TransactionId"`
+	Reason              string                 `xml:"Reason"`
+	SituationType       string                 `xml:"SituationType"`
+	ReportDetails       string                 `xml:"ReportDetails,omitempty"`
+	ContactInformation  *XMLContactInformation `xml:"ContactInformation"`
+	ReportedParticipant string                 `xml:"ReportedParticipant,omitempty"` // Derivado da transação quando ausente
 }
 
 // XMLCreateInfractionReportResponse representa a resposta do manual (seção 4.3)
@@ -247,13 +406,117 @@ type XMLCreateInfractionReportResponse struct {
 
 // XMLInfractionReportFull representa o InfractionReport completo da resposta
 type XMLInfractionReportFull struct {
-	TransactionId      string                 `xml:"TransactionId"`
-	Reason             string                 `xml:"Reason"`
-	SituationType      string                 `xml:"SituationType"`
-	ReportDetails      string                 `xml:"ReportDetails"`
-	ContactInformation *XMLContactInformation `xml:"ContactInformation"`
-	Id                 string                 `xml:"Id"`
-	Status             string                 `xml:"Status"`
-	CreationTime       string                 `xml:"CreationTime"`
-	LastModified       string                 `xml:"LastModified"`
-}
\ No newline at end of file
+	TransactionId       string                 `xml:"TransactionId"`
+	Reason              string                 `xml:"Reason"`
+	SituationType       string                 `xml:"SituationType"`
+	ReportDetails       string                 `xml:"ReportDetails"`
+	ContactInformation  *XMLContactInformation `xml:"ContactInformation"`
+	Id                  string                 `xml:"Id"`
+	Status              string                 `xml:"Status"`
+	ReporterParticipant string                 `xml:"ReporterParticipant,omitempty"`
+	ReportedParticipant string                 `xml:"ReportedParticipant,omitempty"`
+	AnalysisResult      string                 `xml:"AnalysisResult,omitempty"`  // AGREED, DISAGREED (após fechamento)
+	AnalysisDetails     string                 `xml:"AnalysisDetails,omitempty"` // Preenchido no fechamento
+	CreationTime        string                 `xml:"CreationTime"`
+	LastModified        string                 `xml:"LastModified"`
+}
+
+// XMLGetInfractionReportRequest representa a requisição de consulta de notificação
+type XMLGetInfractionReportRequest struct {
+	XMLName            xml.Name `xml:"GetInfractionReportRequest"`
+	Signature          string   `xml:"Signature,omitempty"`
+	InfractionReportId string   `xml:"InfractionReportId"`
+	RequestId          string   `xml:"RequestId,omitempty"`
+}
+
+// XMLGetInfractionReportResponse representa a resposta do BACEN
+type XMLGetInfractionReportResponse struct {
+	XMLName          xml.Name                `xml:"GetInfractionReportResponse"`
+	Signature        string                  `xml:"Signature,omitempty"`
+	ResponseTime     string                  `xml:"ResponseTime"`
+	CorrelationId    string                  `xml:"CorrelationId"`
+	InfractionReport XMLInfractionReportFull `xml:"InfractionReport"`
+}
+
+// XMLInfractionReportFilter representa os filtros de listagem de notificações
+type XMLInfractionReportFilter struct {
+	Participant   string `xml:"Participant"`
+	Status        string `xml:"Status,omitempty"`
+	ModifiedAfter string `xml:"ModifiedAfter,omitempty"`
+}
+
+// XMLListInfractionReportsRequest representa a requisição de listagem de notificações do participante
+type XMLListInfractionReportsRequest struct {
+	XMLName    xml.Name                  `xml:"ListInfractionReportsRequest"`
+	Signature  string                    `xml:"Signature,omitempty"`
+	Filter     XMLInfractionReportFilter `xml:"Filter"`
+	Pagination XMLPagination             `xml:"Pagination"`
+	RequestId  string                    `xml:"RequestId,omitempty"`
+}
+
+// XMLListInfractionReportsResponse representa a resposta do BACEN
+type XMLListInfractionReportsResponse struct {
+	XMLName           xml.Name                  `xml:"ListInfractionReportsResponse"`
+	Signature         string                    `xml:"Signature,omitempty"`
+	ResponseTime      string                    `xml:"ResponseTime"`
+	CorrelationId     string                    `xml:"CorrelationId"`
+	InfractionReports []XMLInfractionReportFull `xml:"InfractionReports>InfractionReport"`
+	HasMoreElements   bool                      `xml:"HasMoreElements"`
+	NextCursor        string                    `xml:"NextCursor,omitempty"`
+}
+
+// XMLAcknowledgeInfractionReportRequest representa o recebimento da notificação pelo participante notificado
+type XMLAcknowledgeInfractionReportRequest struct {
+	XMLName            xml.Name `xml:"AcknowledgeInfractionReportRequest"`
+	Signature          string   `xml:"Signature,omitempty"`
+	InfractionReportId string   `xml:"InfractionReportId"`
+	Participant        string   `xml:"Participant"`
+	RequestId          string   `xml:"RequestId,omitempty"`
+}
+
+// XMLAcknowledgeInfractionReportResponse representa a resposta do BACEN
+type XMLAcknowledgeInfractionReportResponse struct {
+	XMLName          xml.Name                `xml:"AcknowledgeInfractionReportResponse"`
+	Signature        string                  `xml:"Signature,omitempty"`
+	ResponseTime     string                  `xml:"ResponseTime"`
+	CorrelationId    string                  `xml:"CorrelationId"`
+	InfractionReport XMLInfractionReportFull `xml:"InfractionReport"`
+}
+
+// XMLCloseInfractionReportRequest representa o fechamento da notificação com o resultado da análise
+type XMLCloseInfractionReportRequest struct {
+	XMLName            xml.Name `xml:"CloseInfractionReportRequest"`
+	Signature          string   `xml:"Signature,omitempty"`
+	InfractionReportId string   `xml:"InfractionReportId"`
+	Participant        string   `xml:"Participant"`
+	AnalysisResult     string   `xml:"AnalysisResult"`
+	AnalysisDetails    string   `xml:"AnalysisDetails,omitempty"`
+	RequestId          string   `xml:"RequestId,omitempty"`
+}
+
+// XMLCloseInfractionReportResponse representa a resposta do BACEN
+type XMLCloseInfractionReportResponse struct {
+	XMLName          xml.Name                `xml:"CloseInfractionReportResponse"`
+	Signature        string                  `xml:"Signature,omitempty"`
+	ResponseTime     string                  `xml:"ResponseTime"`
+	CorrelationId    string                  `xml:"CorrelationId"`
+	InfractionReport XMLInfractionReportFull `xml:"InfractionReport"`
+}
+
+// XMLCancelInfractionReportRequest representa o cancelamento da notificação pelo participante que a criou
+type XMLCancelInfractionReportRequest struct {
+	XMLName            xml.Name `xml:"CancelInfractionReportRequest"`
+	Signature          string   `xml:"Signature,omitempty"`
+	InfractionReportId string   `xml:"InfractionReportId"`
+	Participant        string   `xml:"Participant"`
+	RequestId          string   `xml:"RequestId,omitempty"`
+}
+
+// XMLCancelInfractionReportResponse representa a resposta do BACEN
+type XMLCancelInfractionReportResponse struct {
+	XMLName          xml.Name                `xml:"CancelInfractionReportResponse"`
+	Signature        string                  `xml:"Signature,omitempty"`
+	ResponseTime     string                  `xml:"ResponseTime"`
+	CorrelationId    string                  `xml:"CorrelationId"`
+	InfractionReport XMLInfractionReportFull `xml:"InfractionReport"`
+}
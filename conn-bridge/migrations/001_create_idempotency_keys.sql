@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Idempotency records of mutating BridgeService RPCs (request_id -> fingerprint, status and response)
+CREATE TABLE idempotency_keys (
+    -- request_id of the BridgeService request
+    idempotency_key VARCHAR(128) PRIMARY KEY,
+    method          VARCHAR(255) NOT NULL,
+    -- SHA-256 (hex) of method and request payload
+    fingerprint     CHAR(64) NOT NULL,
+    status          VARCHAR(20) NOT NULL,
+    -- Serialized google.protobuf.Any (response message or google.rpc.Status)
+    response        BYTEA,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    CONSTRAINT chk_idempotency_keys_status CHECK (status IN ('IN_PROGRESS', 'COMPLETED'))
+);
+
+-- Cleanup of expired records
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
+
+COMMENT ON TABLE idempotency_keys IS 'Outcome of mutating BridgeService requests by request_id; retries replay the stored response instead of calling Bacen again';
+COMMENT ON COLUMN idempotency_keys.expires_at IS 'IN_PROGRESS: lock deadline (a crashed request frees the key); COMPLETED: end of the replay window';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS idempotency_keys;
+-- +goose StatementEnd
@@ -0,0 +1,45 @@
+#!/bin/bash
+#
+# generate-simulator-certs.sh
+#
+# Generates the response signing certificate of the Bacen DICT simulator, used by
+# docker-compose.offline.yml: the simulator signs with signer.p12 and the bridge
+# trusts signer-cert.pem (BACEN_TRUST_STORE_PATH) instead of the ICP-Brasil chain.
+#
+# Usage: ./scripts/generate-simulator-certs.sh
+#
+
+set -e
+
+CERT_DIR="./certs/simulator"
+DAYS_VALID=365
+PASSWORD="changeit"
+ALIAS="lbpay-dict"
+
+if [ -f "$CERT_DIR/signer.p12" ] && [ -f "$CERT_DIR/signer-cert.pem" ]; then
+  echo "✅ Simulator certificates already present in $CERT_DIR/"
+  exit 0
+fi
+
+echo "🔐 Generating Bacen simulator signing certificate..."
+
+mkdir -p "$CERT_DIR"
+
+openssl req -x509 -newkey rsa:2048 -nodes -days "$DAYS_VALID" \
+  -keyout "$CERT_DIR/signer-key.pem" -out "$CERT_DIR/signer-cert.pem" \
+  -subj "/C=BR/O=ICP-Brasil/OU=Simulador/CN=BACEN DICT SIMULADOR:00038166000105"
+
+# Legacy PBE algorithms: the bridge PKCS#12 decoder only supports 3DES/RC2 keystores
+openssl pkcs12 -export -name "$ALIAS" -passout "pass:$PASSWORD" \
+  -keypbe PBE-SHA1-3DES -certpbe PBE-SHA1-3DES -macalg sha1 \
+  -inkey "$CERT_DIR/signer-key.pem" -in "$CERT_DIR/signer-cert.pem" \
+  -out "$CERT_DIR/signer.p12"
+
+chmod 600 "$CERT_DIR/signer-key.pem" "$CERT_DIR/signer.p12"
+chmod 644 "$CERT_DIR/signer-cert.pem"
+
+echo ""
+echo "✅ Certificates generated successfully in $CERT_DIR/"
+echo "  - signer.p12      (simulator response signer, password $PASSWORD)"
+echo "  - signer-cert.pem (bridge trust store)"
+echo ""
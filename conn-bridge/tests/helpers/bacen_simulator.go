@@ -0,0 +1,31 @@
+package helpers
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lbpay-lab/conn-bridge/internal/simulator"
+	"github.com/sirupsen/logrus"
+)
+
+// NewBacenSimulator starts the stateful Bacen DICT simulator (same as cmd/bacen-simulator)
+// and returns it with its base URL. Unlike MockBacenServer it keeps entries, claims
+// and infractions across requests and enforces the DICT rules.
+func NewBacenSimulator(t *testing.T, config *simulator.Config) (*simulator.Server, string) {
+	t.Helper()
+
+	if config == nil {
+		config = &simulator.Config{}
+	}
+	if config.Logger == nil {
+		config.Logger = logrus.New()
+		config.Logger.SetOutput(io.Discard)
+	}
+
+	sim := simulator.NewServer(config)
+	server := httptest.NewServer(sim)
+	t.Cleanup(server.Close)
+
+	return sim, server.URL
+}
@@ -1,8 +1,10 @@
 package helpers
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
+	"encoding/xml"
 	"fmt"
 	"net"
 	"testing"
@@ -276,16 +278,64 @@ func GenerateTestPhone() string {
 // MockSOAPClient is a mock implementation of SOAPClient for testing
 type MockSOAPClient struct{}
 
+// mockSOAPResponses are the bodies the mock answers with, by request root element
+var mockSOAPResponses = map[string]string{
+	"GetDirectoryRequest":  `<GetDirectoryResponse>` + mockDirectoryPage + `</GetDirectoryResponse>`,
+	"SearchEntriesRequest": `<SearchEntriesResponse>` + mockDirectoryPage + `</SearchEntriesResponse>`,
+}
+
+// mockDirectoryPage is a last page with one entry, shared by GetDirectory and SearchEntries
+const mockDirectoryPage = `<Entries><Entry>
+<Key>12345678909</Key><KeyType>CPF</KeyType>
+<Account><Participant>12345678</Participant><Branch>0001</Branch><AccountNumber>123456</AccountNumber><AccountType>CHECKING</AccountType></Account>
+<Owner><Type>NATURAL_PERSON</Type><TaxIdNumber>12345678909</TaxIdNumber><Name>Fulano de Tal</Name></Owner>
+<CreationTime>2026-10-01T10:00:00Z</CreationTime><KeyOwnershipDate>2026-10-01T10:00:00Z</KeyOwnershipDate><Status>ACTIVE</Status>
+</Entry></Entries><HasMoreElements>false</HasMoreElements><TotalCount>1</TotalCount>`
+
 func (m *MockSOAPClient) SendSOAPRequest(ctx context.Context, endpoint string, soapEnvelope []byte) ([]byte, error) {
-	return []byte(`<soap:Envelope><soap:Body><Response>OK</Response></soap:Body></soap:Envelope>`), nil
+	body := `<Response>OK</Response>`
+	if root, err := mockSOAPBodyRoot(soapEnvelope); err == nil {
+		if response, ok := mockSOAPResponses[root]; ok {
+			body = response
+		}
+	}
+	return []byte(`<soap:Envelope><soap:Body>` + body + `</soap:Body></soap:Envelope>`), nil
+}
+
+// mockSOAPBodyRoot returns the name of the first element in the SOAP body
+func mockSOAPBodyRoot(soapEnvelope []byte) (string, error) {
+	body, err := (&MockSOAPClient{}).ParseSOAPResponse(soapEnvelope)
+	if err != nil {
+		return "", err
+	}
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start.Name.Local, nil
+		}
+	}
 }
 
 func (m *MockSOAPClient) BuildSOAPEnvelope(bodyXML string, signedXML string) ([]byte, error) {
 	return []byte(fmt.Sprintf(`<soap:Envelope><soap:Body>%s</soap:Body></soap:Envelope>`, bodyXML)), nil
 }
 
+// ParseSOAPResponse returns the content of the SOAP body, like the real client
 func (m *MockSOAPClient) ParseSOAPResponse(soapResponse []byte) ([]byte, error) {
-	return soapResponse, nil
+	var envelope struct {
+		XMLName xml.Name `xml:"Envelope"`
+		Body    struct {
+			Content []byte `xml:",innerxml"`
+		} `xml:"Body"`
+	}
+	if err := xml.Unmarshal(soapResponse, &envelope); err != nil {
+		return nil, fmt.Errorf("failed to parse SOAP envelope: %w", err)
+	}
+	return envelope.Body.Content, nil
 }
 
 func (m *MockSOAPClient) HealthCheck(ctx context.Context) error {
@@ -0,0 +1,83 @@
+package integration
+
+import (
+	"context"
+	"encoding/xml"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lbpay-lab/conn-bridge/internal/infrastructure/bacen"
+	"github.com/lbpay-lab/conn-bridge/internal/infrastructure/signer"
+	"github.com/lbpay-lab/conn-bridge/internal/simulator"
+	xmlstructs "github.com/lbpay-lab/conn-bridge/internal/xml"
+	"github.com/lbpay-lab/conn-bridge/tests/helpers"
+)
+
+// TestBacenSimulator_SignedEntryRoundTrip runs the bridge SOAP client against the
+// stateful simulator configured like docker-compose.offline.yml: signed requests,
+// signed responses, and the bridge trusting the simulator certificate
+func TestBacenSimulator_SignedEntryRoundTrip(t *testing.T) {
+	signerDir := filepath.Join("..", "..", "internal", "infrastructure", "signer", "testdata")
+	xmlSigner, err := signer.NewNativeXMLSigner(&signer.NativeConfig{
+		CertPath: filepath.Join(signerDir, "signer.p12"),
+		Password: "changeit",
+	})
+	require.NoError(t, err)
+
+	sim, url := helpers.NewBacenSimulator(t, &simulator.Config{
+		RequireSignedRequests: true,
+		ResponseSigner:        xmlSigner,
+	})
+
+	client, err := bacen.NewSOAPClient(&bacen.SOAPClientConfig{
+		BaseURL:        url,
+		DevMode:        true,
+		TrustStorePath: filepath.Join(signerDir, "signer-cert.pem"),
+		Logger:         logrus.New(),
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	call := func(request interface{}) []byte {
+		t.Helper()
+		body, err := xml.Marshal(request)
+		require.NoError(t, err)
+		signed, err := xmlSigner.SignXML(ctx, xml.Header+string(body))
+		require.NoError(t, err)
+		envelope, err := client.BuildSOAPEnvelope(signed, "")
+		require.NoError(t, err)
+		response, err := client.SendSOAPRequest(ctx, "/api/v1/dict/entries", envelope)
+		require.NoError(t, err)
+		content, err := client.ParseSOAPResponse(response)
+		require.NoError(t, err)
+		return content
+	}
+
+	created, err := xmlstructs.CreateEntryResponseFromXML(call(xmlstructs.XMLCreateEntryRequest{
+		Entry: xmlstructs.XMLEntry{
+			Key:     "maria@example.com",
+			KeyType: "EMAIL",
+			Account: xmlstructs.XMLAccount{Participant: "12345678", Branch: "0001", AccountNumber: "1001", AccountType: "CACC"},
+			Owner:   xmlstructs.XMLOwner{Type: "NATURAL_PERSON", TaxIdNumber: "12345678901", Name: "Maria Silva"},
+		},
+		RequestId: "req-1",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "maria@example.com", created.EntryId)
+
+	var got xmlstructs.XMLGetEntryResponse
+	require.NoError(t, xml.Unmarshal(call(xmlstructs.XMLGetEntryRequest{
+		Key:       "maria@example.com",
+		KeyType:   "EMAIL",
+		RequestId: "req-2",
+	}), &got))
+	assert.Equal(t, "1001", got.Entry.Account.AccountNumber)
+
+	stored, err := sim.Store().GetEntry("maria@example.com", "EMAIL")
+	require.NoError(t, err)
+	assert.Equal(t, "12345678", stored.Account.Participant)
+}
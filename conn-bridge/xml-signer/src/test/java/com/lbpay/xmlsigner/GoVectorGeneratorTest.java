@@ -0,0 +1,65 @@
+package com.lbpay.xmlsigner;
+
+import com.lbpay.xmlsigner.model.SignRequest;
+import com.lbpay.xmlsigner.model.SignResponse;
+import com.lbpay.xmlsigner.service.XmlSignerService;
+import org.junit.jupiter.api.Test;
+import org.junit.jupiter.api.condition.EnabledIfSystemProperty;
+
+import java.nio.charset.StandardCharsets;
+import java.nio.file.DirectoryStream;
+import java.nio.file.Files;
+import java.nio.file.Path;
+import java.nio.file.Paths;
+
+import static org.junit.jupiter.api.Assertions.*;
+
+/**
+ * Generates the cross-implementation vectors checked by the Go native signer
+ * (TestNativeXMLSigner_JavaVectors in internal/infrastructure/signer).
+ *
+ * Signs every input in the Go testdata/golden directory with testdata/signer.p12
+ * and writes testdata/java/&lt;input&gt;.signed.xml. Only runs when
+ * -Dgo.signer.testdata points at that testdata directory (see
+ * internal/infrastructure/signer/testdata/java/generate.sh).
+ */
+@EnabledIfSystemProperty(named = "go.signer.testdata", matches = ".+")
+public class GoVectorGeneratorTest {
+
+    private static final String CERT_PASSWORD = "changeit";
+    private static final String CERT_ALIAS = "lbpay-dict";
+
+    @Test
+    public void generateGoVectors() throws Exception {
+        Path testdata = Paths.get(System.getProperty("go.signer.testdata")).toAbsolutePath();
+        Path golden = testdata.resolve("golden");
+        Path out = testdata.resolve("java");
+        Files.createDirectories(out);
+
+        XmlSignerService service = new XmlSignerService();
+        int generated = 0;
+        try (DirectoryStream<Path> inputs = Files.newDirectoryStream(golden, "*.xml")) {
+            for (Path input : inputs) {
+                String name = input.getFileName().toString();
+                if (name.endsWith(".signed.xml")) {
+                    continue;
+                }
+
+                SignRequest request = new SignRequest();
+                request.setXmlContent(Files.readString(input, StandardCharsets.UTF_8));
+                request.setCertificatePath(testdata.resolve("signer.p12").toString());
+                request.setCertificatePassword(CERT_PASSWORD);
+                request.setKeyAlias(CERT_ALIAS);
+
+                SignResponse response = service.signXml(request);
+                assertTrue(response.isSuccess(), "signing " + name + ": " + response.getError());
+                assertTrue(service.verifySignature(response.getSignedXml()));
+
+                String vector = name.substring(0, name.length() - ".xml".length()) + ".signed.xml";
+                Files.writeString(out.resolve(vector), response.getSignedXml(), StandardCharsets.UTF_8);
+                generated++;
+            }
+        }
+        assertNotEquals(0, generated, "no inputs found in " + golden);
+    }
+}
@@ -0,0 +1,688 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/lbpay-lab/conn-dict/internal/activities"
+	"github.com/lbpay-lab/conn-dict/internal/domain/entities"
+	"github.com/lbpay-lab/conn-dict/internal/infrastructure/database"
+	"github.com/lbpay-lab/conn-dict/internal/infrastructure/grpc"
+	"github.com/lbpay-lab/conn-dict/internal/infrastructure/pulsar"
+	"github.com/lbpay-lab/conn-dict/internal/infrastructure/repositories"
+	"github.com/lbpay-lab/conn-dict/internal/infrastructure/storage"
+	"github.com/lbpay-lab/conn-dict/internal/workflows"
+	"github.com/lbpay-lab/dict-contracts/fieldcrypto"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/worker"
+)
+
+// Prometheus metrics for worker
+var (
+	workerTasksProcessed = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "conn_dict",
+			Subsystem: "worker",
+			Name:      "tasks_processed_total",
+			Help:      "Total number of tasks processed by worker",
+		},
+		[]string{"task_type", "status"},
+	)
+
+	workerTaskDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "conn_dict",
+			Subsystem: "worker",
+			Name:      "task_duration_seconds",
+			Help:      "Task execution duration in seconds",
+			Buckets:   []float64{0.1, 0.5, 1.0, 5.0, 10.0, 30.0, 60.0, 120.0, 300.0},
+		},
+		[]string{"task_type"},
+	)
+
+	workerHealthStatus = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "conn_dict",
+			Subsystem: "worker",
+			Name:      "health_status",
+			Help:      "Worker health status (1 = healthy, 0 = unhealthy)",
+		},
+	)
+
+	workerActiveWorkflows = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "conn_dict",
+			Subsystem: "worker",
+			Name:      "active_workflows",
+			Help:      "Number of active workflow executions",
+		},
+	)
+
+	workerActiveActivities = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "conn_dict",
+			Subsystem: "worker",
+			Name:      "active_activities",
+			Help:      "Number of active activity executions",
+		},
+	)
+)
+
+func main() {
+	// Initialize logger
+	logger := logrus.New()
+	logger.SetFormatter(&logrus.JSONFormatter{})
+
+	// Set log level from environment
+	logLevel := os.Getenv("LOG_LEVEL")
+	switch logLevel {
+	case "debug":
+		logger.SetLevel(logrus.DebugLevel)
+	case "warn":
+		logger.SetLevel(logrus.WarnLevel)
+	case "error":
+		logger.SetLevel(logrus.ErrorLevel)
+	default:
+		logger.SetLevel(logrus.InfoLevel)
+	}
+
+	logger.Info("Starting Temporal worker...")
+
+	// Get Temporal server address from environment
+	temporalAddress := os.Getenv("TEMPORAL_ADDRESS")
+	if temporalAddress == "" {
+		temporalAddress = "localhost:7233"
+	}
+
+	// Get namespace from environment
+	namespace := os.Getenv("TEMPORAL_NAMESPACE")
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	// Create Temporal client
+	temporalClient, err := client.Dial(client.Options{
+		HostPort:  temporalAddress,
+		Namespace: namespace,
+		// Note: Temporal SDK uses its own logger interface, incompatible with logrus
+		// We use logrus for application logging, Temporal SDK will use default logger
+	})
+	if err != nil {
+		log.Fatalf("Failed to create Temporal client: %v", err)
+	}
+	defer temporalClient.Close()
+
+	logger.WithFields(logrus.Fields{
+		"temporal_address": temporalAddress,
+		"namespace":        namespace,
+	}).Info("Connected to Temporal server")
+
+	// Get task queue from environment
+	taskQueue := os.Getenv("TEMPORAL_TASK_QUEUE")
+	if taskQueue == "" {
+		taskQueue = "conn-dict-task-queue"
+	}
+
+	// Get worker concurrency settings from environment
+	maxConcurrentActivities := getEnvAsInt("MAX_CONCURRENT_ACTIVITIES", 200)
+	maxConcurrentWorkflows := getEnvAsInt("MAX_CONCURRENT_WORKFLOWS", 100)
+
+	// Create Temporal worker with optimized settings per requirements
+	w := worker.New(temporalClient, taskQueue, worker.Options{
+		MaxConcurrentActivityExecutionSize:     maxConcurrentActivities,
+		MaxConcurrentWorkflowTaskExecutionSize: maxConcurrentWorkflows,
+		MaxConcurrentActivityTaskPollers:       20,
+		MaxConcurrentWorkflowTaskPollers:       10,
+		EnableSessionWorker:                    true,
+		MaxConcurrentSessionExecutionSize:      50,
+	})
+
+	logger.WithFields(logrus.Fields{
+		"task_queue":                taskQueue,
+		"max_concurrent_activities": maxConcurrentActivities,
+		"max_concurrent_workflows":  maxConcurrentWorkflows,
+	}).Info("Worker configuration")
+
+	// Initialize PostgreSQL client
+	pgConfig := &database.PostgresConfig{
+		Host:     getEnvOrDefault("POSTGRES_HOST", "localhost"),
+		Port:     getEnvAsInt("POSTGRES_PORT", 5432),
+		User:     getEnvOrDefault("POSTGRES_USER", "dict_user"),
+		Password: getEnvOrDefault("POSTGRES_PASSWORD", "dict_password"),
+		Database: getEnvOrDefault("POSTGRES_DB", "dict_db"),
+		SSLMode:  getEnvOrDefault("POSTGRES_SSLMODE", "disable"),
+		MaxConns: int32(getEnvAsInt("POSTGRES_MAX_CONNS", 25)),
+		MinConns: int32(getEnvAsInt("POSTGRES_MIN_CONNS", 5)),
+	}
+
+	postgresClient, err := database.NewPostgresClient(pgConfig, logger)
+	if err != nil {
+		log.Fatalf("Failed to initialize PostgreSQL client: %v", err)
+	}
+	defer postgresClient.Close()
+
+	logger.Info("PostgreSQL client initialized successfully")
+
+	// Health check PostgreSQL
+	ctx := context.Background()
+	if err := postgresClient.HealthCheck(ctx); err != nil {
+		log.Fatalf("PostgreSQL health check failed: %v", err)
+	}
+	logger.Info("PostgreSQL health check passed")
+
+	// Initialize field-level encryption (LGPD): CPF/EMAIL/PHONE keys and owner data
+	keyProvider, err := fieldcrypto.NewFileKeyProvider(getEnvOrDefault("FIELD_ENCRYPTION_KEYRING_FILE", "/etc/conn-dict/keyring.json"))
+	if err != nil {
+		log.Fatalf("Failed to load field encryption keyring: %v", err)
+	}
+	fieldCipher, err := fieldcrypto.NewCipher(ctx, keyProvider)
+	if err != nil {
+		log.Fatalf("Failed to initialize field encryption: %v", err)
+	}
+	logger.WithField("key_id", fieldCipher.CurrentKeyID()).Info("Field encryption initialized")
+
+	// Initialize repositories
+	claimRepo := repositories.NewClaimRepository(postgresClient, logger)
+	entryRepo := repositories.NewEntryRepository(postgresClient, fieldCipher, logger)
+	infractionRepo := repositories.NewInfractionRepository(postgresClient, logger)
+	syncReportRepo := repositories.NewSyncReportRepository(postgresClient, logger)
+	vsyncRepo := repositories.NewVSyncRepository(postgresClient, fieldCipher, logger)
+	partitionRepo := repositories.NewPartitionRepository(postgresClient, logger)
+
+	// Initialize Pulsar producer
+	pulsarConfig := pulsar.ProducerConfig{
+		URL:            getEnvOrDefault("PULSAR_URL", "pulsar://localhost:6650"),
+		Topic:          getEnvOrDefault("PULSAR_TOPIC", "persistent://public/default/dict-events"),
+		ProducerName:   getEnvOrDefault("PULSAR_PRODUCER_NAME", "conn-dict-worker"),
+		MaxReconnect:   10,
+		ConnectTimeout: 30 * time.Second,
+	}
+
+	pulsarProducer, err := pulsar.NewProducer(pulsarConfig, logger)
+	if err != nil {
+		log.Fatalf("Failed to initialize Pulsar producer: %v", err)
+	}
+	defer pulsarProducer.Close()
+
+	logger.Info("Pulsar producer initialized successfully")
+
+	// Producers for the events consumed by core-dict (protobuf payloads, one topic each)
+	claimCreatedProducer, err := pulsar.NewProducer(pulsar.ProducerConfig{
+		URL:            pulsarConfig.URL,
+		Topic:          getEnvOrDefault("PULSAR_TOPIC_CLAIM_CREATED", "persistent://public/default/dict.claims.created"),
+		ProducerName:   pulsarConfig.ProducerName + "-claims-created",
+		MaxReconnect:   pulsarConfig.MaxReconnect,
+		ConnectTimeout: pulsarConfig.ConnectTimeout,
+	}, logger)
+	if err != nil {
+		log.Fatalf("Failed to initialize claims created producer: %v", err)
+	}
+	defer claimCreatedProducer.Close()
+
+	claimCompletedProducer, err := pulsar.NewProducer(pulsar.ProducerConfig{
+		URL:            pulsarConfig.URL,
+		Topic:          getEnvOrDefault("PULSAR_TOPIC_CLAIM_COMPLETED", "persistent://public/default/dict.claims.completed"),
+		ProducerName:   pulsarConfig.ProducerName + "-claims-completed",
+		MaxReconnect:   pulsarConfig.MaxReconnect,
+		ConnectTimeout: pulsarConfig.ConnectTimeout,
+	}, logger)
+	if err != nil {
+		log.Fatalf("Failed to initialize claims completed producer: %v", err)
+	}
+	defer claimCompletedProducer.Close()
+
+	infractionReportedProducer, err := pulsar.NewProducer(pulsar.ProducerConfig{
+		URL:            pulsarConfig.URL,
+		Topic:          getEnvOrDefault("PULSAR_TOPIC_INFRACTION_REPORTED", "persistent://public/default/dict.infractions.reported"),
+		ProducerName:   pulsarConfig.ProducerName + "-infractions-reported",
+		MaxReconnect:   pulsarConfig.MaxReconnect,
+		ConnectTimeout: pulsarConfig.ConnectTimeout,
+	}, logger)
+	if err != nil {
+		log.Fatalf("Failed to initialize infractions reported producer: %v", err)
+	}
+	defer infractionReportedProducer.Close()
+
+	// Register workflows
+	w.RegisterWorkflow(workflows.ClaimWorkflow)
+	logger.Info("Registered ClaimWorkflow")
+
+	// Register Entry workflow with waiting period (30 days)
+	w.RegisterWorkflow(workflows.DeleteEntryWithWaitingPeriodWorkflow)
+	logger.Info("Registered DeleteEntryWithWaitingPeriodWorkflow")
+
+	// Register Infraction workflow
+	w.RegisterWorkflow(workflows.InvestigateInfractionWorkflow)
+	logger.Info("Registered InvestigateInfractionWorkflow")
+
+	// Register VSYNC workflows
+	w.RegisterWorkflow(workflows.VSyncWorkflow)
+	w.RegisterWorkflow(workflows.VSyncSchedulerWorkflow)
+	w.RegisterWorkflow(workflows.VSyncRepairWorkflow)
+	logger.Info("Registered VSYNC workflows (Sync, Scheduler, Repair)")
+
+	// Register partition maintenance workflow (audit_logs/event_logs)
+	w.RegisterWorkflow(workflows.PartitionMaintenanceWorkflow)
+	logger.Info("Registered PartitionMaintenanceWorkflow")
+
+	w.RegisterWorkflow(workflows.FieldReencryptionWorkflow)
+	logger.Info("Registered FieldReencryptionWorkflow")
+
+	// Register incoming claims/infractions poller
+	w.RegisterWorkflow(workflows.IncomingPollerWorkflow)
+	logger.Info("Registered IncomingPollerWorkflow")
+
+	// Initialize Bridge gRPC client (claims, infraction reports and VSYNC)
+	bridgeAddress := getEnvOrDefault("BRIDGE_ADDRESS", "localhost:9094")
+	bridgeClient, err := grpc.NewBridgeClient(&grpc.BridgeClientConfig{
+		Address:        bridgeAddress,
+		ConnectTimeout: 10 * time.Second,
+		RequestTimeout: 30 * time.Second,
+	}, logger)
+	if err != nil {
+		logger.WithError(err).Warn("Failed to initialize Bridge client - Bacen notifications and VSYNC will not work")
+		// Don't fail startup - Bridge may not be available in dev environment
+	} else {
+		logger.WithField("bridge_address", bridgeAddress).Info("Bridge client initialized successfully")
+	}
+
+	// Register Claim activities
+	claimActivities := activities.NewClaimActivities(logger, claimRepo, pulsarProducer, bridgeClient, claimCompletedProducer)
+	w.RegisterActivity(claimActivities.CreateClaimActivity)
+	w.RegisterActivity(claimActivities.SubmitClaimToBacenActivity)
+	w.RegisterActivity(claimActivities.ConfirmClaimAtBacenActivity)
+	w.RegisterActivity(claimActivities.CompleteClaimAtBacenActivity)
+	w.RegisterActivity(claimActivities.CancelClaimAtBacenActivity)
+	w.RegisterActivity(claimActivities.UpdateClaimStatusActivity)
+	w.RegisterActivity(claimActivities.NotifyDonorActivity)
+	w.RegisterActivity(claimActivities.CompleteClaimActivity)
+	w.RegisterActivity(claimActivities.CancelClaimActivity)
+	w.RegisterActivity(claimActivities.ExpireClaimActivity)
+	w.RegisterActivity(claimActivities.GetClaimStatusActivity)
+	w.RegisterActivity(claimActivities.ValidateClaimEligibilityActivity)
+	w.RegisterActivity(claimActivities.SendClaimConfirmationActivity)
+	w.RegisterActivity(claimActivities.UpdateEntryOwnershipActivity)
+	w.RegisterActivity(claimActivities.PublishClaimEventActivity)
+	w.RegisterActivity(claimActivities.PublishClaimCompletedActivity)
+	logger.Info("Registered Claim activities (including Sprint 1 activities: Create, SubmitToBacen, UpdateStatus)")
+
+	// Register Entry activities
+	entryActivities := activities.NewEntryActivities(logger, entryRepo, pulsarProducer)
+	w.RegisterActivity(entryActivities.CreateEntryActivity)
+	w.RegisterActivity(entryActivities.UpdateEntryActivity)
+	w.RegisterActivity(entryActivities.DeleteEntryActivity)
+	w.RegisterActivity(entryActivities.ActivateEntryActivity)
+	w.RegisterActivity(entryActivities.DeactivateEntryActivity)
+	w.RegisterActivity(entryActivities.GetEntryStatusActivity)
+	w.RegisterActivity(entryActivities.ValidateEntryActivity)
+	w.RegisterActivity(entryActivities.UpdateEntryOwnershipActivity)
+	logger.Info("Registered Entry activities")
+
+	// Register Infraction activities
+	infractionActivities := activities.NewInfractionActivities(logger, infractionRepo, pulsarProducer, bridgeClient)
+	w.RegisterActivity(infractionActivities.CreateInfractionActivity)
+	w.RegisterActivity(infractionActivities.InvestigateInfractionActivity)
+	w.RegisterActivity(infractionActivities.ResolveInfractionActivity)
+	w.RegisterActivity(infractionActivities.DismissInfractionActivity)
+	w.RegisterActivity(infractionActivities.EscalateInfractionActivity)
+	w.RegisterActivity(infractionActivities.AddEvidenceActivity)
+	w.RegisterActivity(infractionActivities.GetInfractionStatusActivity)
+	w.RegisterActivity(infractionActivities.ValidateInfractionEligibilityActivity)
+	w.RegisterActivity(infractionActivities.NotifyReportedParticipantActivity)
+	w.RegisterActivity(infractionActivities.NotifyBacenActivity)
+	w.RegisterActivity(infractionActivities.PublishInfractionEventActivity)
+	logger.Info("Registered Infraction activities")
+
+	// Register VSYNC activities
+	vsyncActivities := activities.NewVSyncActivities(logger, entryRepo, vsyncRepo, entryActivities, syncReportRepo, bridgeClient, getEnvOrDefault("PARTICIPANT_ISPB", "12345678"), loadRepairPolicy(logger))
+	w.RegisterActivity(vsyncActivities.BackfillEntryCIDsActivity)
+	w.RegisterActivity(vsyncActivities.StageBacenEntriesActivity)
+	w.RegisterActivity(vsyncActivities.CompareStagedEntriesActivity)
+	w.RegisterActivity(vsyncActivities.CompareVSyncActivity)
+	w.RegisterActivity(vsyncActivities.ReconcileCIDsActivity)
+	w.RegisterActivity(vsyncActivities.ApplyDiscrepanciesActivity)
+	w.RegisterActivity(vsyncActivities.DropStagedEntriesActivity)
+	w.RegisterActivity(vsyncActivities.GenerateSyncReportActivity)
+	w.RegisterActivity(vsyncActivities.RepairDiscrepancyActivity)
+	logger.Info("Registered VSYNC activities (BackfillCIDs, Stage, CompareStaged, CompareVSync, ReconcileCIDs, Apply, DropStaged, GenerateReport, RepairDiscrepancy)")
+
+	// Register partition maintenance activities
+	partitionActivities := activities.NewPartitionActivities(logger, partitionRepo, loadArchiveStore(logger))
+	w.RegisterActivity(partitionActivities.EnsureFuturePartitionsActivity)
+	w.RegisterActivity(partitionActivities.ArchiveExpiredPartitionsActivity)
+	logger.Info("Registered partition maintenance activities (EnsureFuture, ArchiveExpired)")
+
+	// Register field encryption activities
+	encryptionActivities := activities.NewEncryptionActivities(logger, entryRepo)
+	w.RegisterActivity(encryptionActivities.ReencryptEntriesActivity)
+	logger.Info("Registered field encryption activities (ReencryptEntries)")
+
+	// Register incoming poller activities
+	incomingActivities := activities.NewIncomingActivities(
+		logger,
+		claimRepo,
+		infractionRepo,
+		bridgeClient,
+		claimCreatedProducer,
+		claimCompletedProducer,
+		infractionReportedProducer,
+		getEnvOrDefault("PARTICIPANT_ISPB", "12345678"),
+	)
+	w.RegisterActivity(incomingActivities.PollIncomingClaimsActivity)
+	w.RegisterActivity(incomingActivities.PollIncomingInfractionsActivity)
+	logger.Info("Registered incoming poller activities (PollIncomingClaims, PollIncomingInfractions)")
+
+	logger.Info("Registered all activities (Claim, Entry, Infraction, VSYNC, Partitions, Encryption, Incoming)")
+
+	// Start HTTP server for metrics and health checks
+	metricsPort := getEnvAsInt("METRICS_PORT", 9093)
+	healthPort := getEnvAsInt("HEALTH_PORT", 8081)
+
+	// Start metrics server
+	go func() {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.Handler())
+
+		metricsAddr := fmt.Sprintf(":%d", metricsPort)
+		logger.Infof("Starting metrics server on %s", metricsAddr)
+
+		if err := http.ListenAndServe(metricsAddr, mux); err != nil {
+			logger.Errorf("Metrics server failed: %v", err)
+		}
+	}()
+
+	// Start health check server
+	healthServer := &http.Server{
+		Addr:         fmt.Sprintf(":%d", healthPort),
+		Handler:      createHealthCheckHandler(logger, postgresClient, temporalClient),
+		ReadTimeout:  5 * time.Second,
+		WriteTimeout: 10 * time.Second,
+		IdleTimeout:  120 * time.Second,
+	}
+
+	go func() {
+		logger.Infof("Starting health check server on :%d", healthPort)
+		if err := healthServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Errorf("Health check server failed: %v", err)
+		}
+	}()
+
+	// Set initial health status
+	workerHealthStatus.Set(1)
+
+	// Start worker in a goroutine
+	errChan := make(chan error, 1)
+	go func() {
+		logger.Infof("Starting worker on task queue: %s", taskQueue)
+		if err := w.Run(worker.InterruptCh()); err != nil {
+			errChan <- err
+		}
+	}()
+
+	logger.Info("Worker started successfully")
+
+	// Schedule partition maintenance as a cron workflow (no-op if already scheduled)
+	startPartitionMaintenance(temporalClient, taskQueue, logger)
+
+	// Schedule re-encryption of entries under the current key (legacy plaintext and rotations)
+	startFieldReencryption(temporalClient, taskQueue, logger)
+
+	// Schedule the poller of claims and infractions other participants opened against our keys
+	startIncomingPoller(temporalClient, taskQueue, logger)
+
+	// Wait for interrupt signal
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+
+	select {
+	case err := <-errChan:
+		logger.Errorf("Worker error: %v", err)
+		workerHealthStatus.Set(0)
+	case sig := <-sigChan:
+		logger.Infof("Received signal: %v", sig)
+	}
+
+	// Graceful shutdown
+	logger.Info("Shutting down worker...")
+	workerHealthStatus.Set(0)
+
+	// Stop accepting new tasks
+	w.Stop()
+
+	// Wait for current tasks to complete (max 30s)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	logger.Info("Waiting for active tasks to complete (max 30s)...")
+
+	// Shutdown health check server
+	if err := healthServer.Shutdown(shutdownCtx); err != nil {
+		logger.Warnf("Health check server shutdown error: %v", err)
+	}
+
+	logger.Info("Worker stopped successfully")
+}
+
+// startPartitionMaintenance starts the PartitionMaintenanceWorkflow cron. The fixed workflow ID
+// makes this idempotent across worker restarts and replicas.
+func startPartitionMaintenance(temporalClient client.Client, taskQueue string, logger *logrus.Logger) {
+	cronSchedule := getEnvOrDefault("PARTITION_MAINTENANCE_CRON", workflows.DefaultPartitionMaintenanceCron)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := temporalClient.ExecuteWorkflow(ctx, client.StartWorkflowOptions{
+		ID:           workflows.PartitionMaintenanceWorkflowID,
+		TaskQueue:    taskQueue,
+		CronSchedule: cronSchedule,
+	}, workflows.PartitionMaintenanceWorkflow, workflows.PartitionMaintenanceInput{
+		LookaheadMonths: getEnvAsInt("PARTITION_LOOKAHEAD_MONTHS", workflows.DefaultPartitionLookaheadMonths),
+		RetentionMonths: getEnvAsInt("PARTITION_RETENTION_MONTHS", workflows.DefaultPartitionRetentionMonths),
+	})
+
+	var alreadyStarted *serviceerror.WorkflowExecutionAlreadyStarted
+	switch {
+	case err == nil:
+		logger.WithField("cron", cronSchedule).Info("Partition maintenance cron workflow started")
+	case errors.As(err, &alreadyStarted):
+		logger.WithField("cron", cronSchedule).Info("Partition maintenance cron workflow already running")
+	default:
+		logger.WithError(err).Warn("Failed to start partition maintenance cron workflow")
+	}
+}
+
+// startFieldReencryption starts the FieldReencryptionWorkflow cron. Like the partition
+// maintenance, the fixed workflow ID makes this idempotent.
+func startFieldReencryption(temporalClient client.Client, taskQueue string, logger *logrus.Logger) {
+	cronSchedule := getEnvOrDefault("FIELD_REENCRYPTION_CRON", workflows.DefaultFieldReencryptionCron)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := temporalClient.ExecuteWorkflow(ctx, client.StartWorkflowOptions{
+		ID:           workflows.FieldReencryptionWorkflowID,
+		TaskQueue:    taskQueue,
+		CronSchedule: cronSchedule,
+	}, workflows.FieldReencryptionWorkflow, workflows.FieldReencryptionInput{
+		BatchSize:  getEnvAsInt("FIELD_REENCRYPTION_BATCH_SIZE", workflows.DefaultFieldReencryptionBatchSize),
+		MaxBatches: getEnvAsInt("FIELD_REENCRYPTION_MAX_BATCHES", workflows.DefaultFieldReencryptionMaxBatches),
+	})
+
+	var alreadyStarted *serviceerror.WorkflowExecutionAlreadyStarted
+	switch {
+	case err == nil:
+		logger.WithField("cron", cronSchedule).Info("Field re-encryption cron workflow started")
+	case errors.As(err, &alreadyStarted):
+		logger.WithField("cron", cronSchedule).Info("Field re-encryption cron workflow already running")
+	default:
+		logger.WithError(err).Warn("Failed to start field re-encryption cron workflow")
+	}
+}
+
+// createHealthCheckHandler creates HTTP handler for health checks
+func createHealthCheckHandler(logger *logrus.Logger, pgClient *database.PostgresClient, temporalClient client.Client) http.Handler {
+	mux := http.NewServeMux()
+
+	// Liveness probe - checks if worker is running
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"status":"healthy","service":"conn-dict-worker"}`)
+	})
+
+	// Readiness probe - checks if worker can process tasks
+	mux.HandleFunc("/ready", func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
+		defer cancel()
+
+		// Check PostgreSQL health
+		if err := pgClient.HealthCheck(ctx); err != nil {
+			logger.WithError(err).Warn("PostgreSQL health check failed")
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprintf(w, `{"status":"not_ready","reason":"database_unhealthy","error":"%s"}`, err.Error())
+			return
+		}
+
+		// Check Temporal connection
+		_, err := temporalClient.CheckHealth(ctx, &client.CheckHealthRequest{})
+		if err != nil {
+			logger.WithError(err).Warn("Temporal health check failed")
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprintf(w, `{"status":"not_ready","reason":"temporal_unhealthy","error":"%s"}`, err.Error())
+			return
+		}
+
+		// All checks passed
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"status":"ready","service":"conn-dict-worker"}`)
+	})
+
+	return mux
+}
+
+// getEnvAsInt retrieves environment variable as integer with default value
+func getEnvAsInt(key string, defaultValue int) int {
+	valueStr := os.Getenv(key)
+	if valueStr == "" {
+		return defaultValue
+	}
+
+	value, err := strconv.Atoi(valueStr)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+// getEnvOrDefault retrieves environment variable with default value
+func getEnvOrDefault(key, defaultValue string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+// startIncomingPoller starts the IncomingPollerWorkflow cron. Like the partition maintenance, the
+// fixed workflow ID makes this idempotent.
+func startIncomingPoller(temporalClient client.Client, taskQueue string, logger *logrus.Logger) {
+	cronSchedule := getEnvOrDefault("INCOMING_POLLER_CRON", workflows.DefaultIncomingPollerCron)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := temporalClient.ExecuteWorkflow(ctx, client.StartWorkflowOptions{
+		ID:           workflows.IncomingPollerWorkflowID,
+		TaskQueue:    taskQueue,
+		CronSchedule: cronSchedule,
+	}, workflows.IncomingPollerWorkflow, workflows.IncomingPollerInput{
+		PageSize: int32(getEnvAsInt("INCOMING_POLLER_PAGE_SIZE", workflows.DefaultIncomingPollerPageSize)),
+	})
+
+	var alreadyStarted *serviceerror.WorkflowExecutionAlreadyStarted
+	switch {
+	case err == nil:
+		logger.WithField("cron", cronSchedule).Info("Incoming poller cron workflow started")
+	case errors.As(err, &alreadyStarted):
+		logger.WithField("cron", cronSchedule).Info("Incoming poller cron workflow already running")
+	default:
+		logger.WithError(err).Warn("Failed to start incoming poller cron workflow")
+	}
+}
+
+// loadArchiveStore builds the object store that receives the archived partitions. Without
+// PARTITION_ARCHIVE_BUCKET it returns nil and expired partitions stay online.
+func loadArchiveStore(logger *logrus.Logger) activities.ArchiveStore {
+	bucket := os.Getenv("PARTITION_ARCHIVE_BUCKET")
+	if bucket == "" {
+		logger.Warn("PARTITION_ARCHIVE_BUCKET not set: expired audit_logs/event_logs partitions are not archived")
+		return nil
+	}
+
+	store, err := storage.NewS3Store(storage.S3Config{
+		Endpoint:        getEnvOrDefault("PARTITION_ARCHIVE_ENDPOINT", "https://s3.sa-east-1.amazonaws.com"),
+		Region:          getEnvOrDefault("PARTITION_ARCHIVE_REGION", "sa-east-1"),
+		Bucket:          bucket,
+		AccessKeyID:     os.Getenv("PARTITION_ARCHIVE_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("PARTITION_ARCHIVE_SECRET_ACCESS_KEY"),
+	})
+	if err != nil {
+		log.Fatalf("Invalid partition archive store configuration: %v", err)
+	}
+
+	logger.WithField("bucket", bucket).Info("Partition archives are stored in object storage")
+	return store
+}
+
+// loadRepairPolicy builds the VSYNC repair policy: the default one, with the rules of each
+// discrepancy type replaced by VSYNC_POLICY_<TYPE> when set ("ACTION[,AGE:ACTION...]")
+func loadRepairPolicy(logger *logrus.Logger) entities.RepairPolicy {
+	policy := entities.DefaultRepairPolicy()
+	policy.EscalateTo = getEnvOrDefault("VSYNC_REVIEW_ASSIGNEE", entities.DefaultEscalationAssignee)
+
+	for _, discrepancyType := range []entities.DiscrepancyType{
+		entities.DiscrepancyTypeMissingLocal,
+		entities.DiscrepancyTypeOutdatedLocal,
+		entities.DiscrepancyTypeMissingBacen,
+	} {
+		spec := os.Getenv("VSYNC_POLICY_" + string(discrepancyType))
+		if spec == "" {
+			continue
+		}
+		rules, err := entities.ParseRepairRules(discrepancyType, spec)
+		if err != nil {
+			log.Fatalf("Invalid VSYNC_POLICY_%s: %v", discrepancyType, err)
+		}
+
+		kept := policy.Rules[:0]
+		for _, rule := range policy.Rules {
+			if rule.Type != discrepancyType {
+				kept = append(kept, rule)
+			}
+		}
+		policy.Rules = append(kept, rules...)
+	}
+
+	logger.WithFields(logrus.Fields{
+		"rules":       policy.Rules,
+		"escalate_to": policy.EscalateTo,
+	}).Info("VSYNC repair policy loaded")
+	return policy
+}
@@ -0,0 +1,37 @@
+package activities
+
+import (
+	commonv1 "github.com/lbpay-lab/dict-contracts/gen/proto/common/v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/lbpay-lab/conn-dict/workflows"
+)
+
+// bacenRejection extracts the common.v1.BacenError detail Bridge attaches when Bacen rejected
+// the request. Errors without it (Bridge or Bacen unavailable) are not rejections and can be retried.
+func bacenRejection(err error) (workflows.BacenRejection, bool) {
+	st, ok := status.FromError(err)
+	if !ok {
+		return workflows.BacenRejection{}, false
+	}
+
+	for _, detail := range st.Details() {
+		bacenErr, ok := detail.(*commonv1.BacenError)
+		if !ok || bacenErr.GetBacenCode() == "" {
+			continue
+		}
+
+		rejection := workflows.BacenRejection{
+			Code:       bacenErr.GetBacenCode(),
+			Message:    bacenErr.GetBacenMessage(),
+			RequestID:  bacenErr.GetBacenRequestId(),
+			HTTPStatus: int(bacenErr.GetHttpStatus()),
+		}
+		if bacenErr.GetOccurredAt() != nil {
+			rejection.OccurredAt = bacenErr.GetOccurredAt().AsTime()
+		}
+		return rejection, true
+	}
+
+	return workflows.BacenRejection{}, false
+}
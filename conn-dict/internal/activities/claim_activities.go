@@ -5,7 +5,14 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
+	bridgev1 "github.com/lbpay-lab/dict-contracts/gen/proto/bridge/v1"
+	commonv1 "github.com/lbpay-lab/dict-contracts/gen/proto/common/v1"
+	connectv1 "github.com/lbpay-lab/dict-contracts/gen/proto/connect/v1"
 	"go.temporal.io/sdk/activity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/lbpay-lab/conn-dict/internal/domain/entities"
 	"github.com/lbpay-lab/conn-dict/internal/infrastructure/pulsar"
@@ -19,8 +26,24 @@ type ClaimActivities struct {
 	logger         *logrus.Logger
 	claimRepo      *repositories.ClaimRepository
 	pulsarProducer *pulsar.Producer
-	// TODO Sprint 2: Add Bridge gRPC client
-	// bridgeClient   *grpc.BridgeClient
+	bridgeClient   ClaimBridgeClient
+	claimEvents    EventPublisher // dict.claims.completed (consumed by core-dict)
+}
+
+// ClaimBridgeClient interface for the Bridge claim RPCs (for testing/mocking)
+type ClaimBridgeClient interface {
+	CreateClaim(ctx context.Context, req *bridgev1.CreateClaimRequest) (*bridgev1.CreateClaimResponse, error)
+	ConfirmClaim(ctx context.Context, req *bridgev1.ConfirmClaimRequest) (*bridgev1.ConfirmClaimResponse, error)
+	CompleteClaim(ctx context.Context, req *bridgev1.CompleteClaimRequest) (*bridgev1.CompleteClaimResponse, error)
+	CancelClaim(ctx context.Context, req *bridgev1.CancelClaimRequest) (*bridgev1.CancelClaimResponse, error)
+}
+
+// ClaimBacenTransitionInput is the input for the activities that move a claim at Bacen
+// (confirm, complete and cancel)
+type ClaimBacenTransitionInput struct {
+	ClaimID    string
+	ExternalID string // Bacen claim ID returned by SubmitClaimToBacenActivity
+	Reason     string
 }
 
 // NewClaimActivities creates a new instance of ClaimActivities
@@ -28,14 +51,32 @@ func NewClaimActivities(
 	logger *logrus.Logger,
 	claimRepo *repositories.ClaimRepository,
 	pulsarProducer *pulsar.Producer,
+	bridgeClient ClaimBridgeClient,
+	claimEvents EventPublisher,
 ) *ClaimActivities {
 	return &ClaimActivities{
 		logger:         logger,
 		claimRepo:      claimRepo,
 		pulsarProducer: pulsarProducer,
+		bridgeClient:   bridgeClient,
+		claimEvents:    claimEvents,
 	}
 }
 
+// ClaimCompletedEventInput is the input for PublishClaimCompletedActivity
+type ClaimCompletedEventInput struct {
+	ClaimID     string
+	EntryID     string
+	KeyType     string
+	Key         string
+	ClaimerISPB string
+	DonorISPB   string
+	Status      string // CONFIRMED, COMPLETED, CANCELLED or EXPIRED
+	Reason      string
+	CompletedAt time.Time
+	Rejection   *workflows.BacenRejection // Set when the claim was cancelled because Bacen rejected it
+}
+
 // CreateClaimActivity creates a new claim in the database
 //
 // Sprint 1: This activity persists the claim to PostgreSQL and returns the claim UUID
@@ -124,11 +165,9 @@ func (a *ClaimActivities) CreateClaimActivity(ctx context.Context, input workflo
 
 // SubmitClaimToBacenActivity submits the claim to Bacen via Bridge gRPC
 //
-// Sprint 1: This activity simulates submission to Bacen. In Sprint 2, it will call
-// the actual Bridge gRPC service to submit the claim to Bacen DICT.
-//
-// This activity handles retries automatically via Temporal's retry policy.
-// It is idempotent and can be safely retried.
+// A claim rejected by Bacen (a BacenError detail or invalid argument) is returned as an unsuccessful
+// result carrying the rejection instead of an error, so the workflow cancels it without retrying. Other errors are retried by Temporal; the
+// claim ID is the idempotency key, so a retry never opens a second claim at Bacen.
 //
 // Input: workflows.SubmitClaimToBacenInput
 // Output: workflows.SubmitClaimToBacenResult
@@ -148,73 +187,47 @@ func (a *ClaimActivities) SubmitClaimToBacenActivity(ctx context.Context, input
 	// Record heartbeat
 	activity.RecordHeartbeat(ctx, "Preparing to submit claim to Bacen")
 
-	// TODO Sprint 2: Implement actual Bridge gRPC call
-	// For now, we simulate a successful submission
-	//
-	// In production, this should:
-	// 1. Create gRPC request with claim data
-	// 2. Call Bridge service: bridgeClient.CreateClaim(ctx, request)
-	// 3. Handle gRPC errors (connection, timeout, etc.)
-	// 4. Parse Bridge response
-	// 5. Return appropriate result
-	//
-	// Example implementation:
-	/*
-		bridgeReq := &bridgepb.CreateClaimRequest{
-			ClaimId:              input.ClaimID,
-			Key:                  input.Key,
-			KeyType:              input.KeyType,
-			DonorIspb:            input.DonorISPB,
-			ClaimerIspb:          input.ClaimerISPB,
-			ClaimerAccountBranch: input.ClaimerAccountBranch,
-			ClaimerAccountNumber: input.ClaimerAccountNumber,
-			ClaimerAccountType:   input.ClaimerAccountType,
-			ClaimType:            input.ClaimType,
-			CorrelationId:        input.CorrelationID,
-		}
-
-		// Call Bridge with context timeout
-		ctxTimeout, cancel := context.WithTimeout(ctx, 30*time.Second)
-		defer cancel()
-
-		bridgeResp, err := a.bridgeClient.CreateClaim(ctxTimeout, bridgeReq)
-		if err != nil {
-			logger.Error("Bridge gRPC call failed", "error", err)
-			return nil, fmt.Errorf("bridge call failed: %w", err)
+	bridgeResp, err := a.bridgeClient.CreateClaim(ctx, &bridgev1.CreateClaimRequest{
+		EntryId:     input.EntryID,
+		KeyType:     claimKeyType(input.KeyType),
+		KeyValue:    input.Key,
+		ClaimerIspb: input.ClaimerISPB,
+		OwnerIspb:   input.DonorISPB,
+		ClaimerAccount: &commonv1.Account{
+			Ispb:          input.ClaimerISPB,
+			AccountType:   claimAccountType(input.ClaimerAccountType),
+			AccountNumber: input.ClaimerAccountNumber,
+			BranchCode:    input.ClaimerAccountBranch,
+		},
+		CompletionPeriodDays: int32(input.CompletionPeriod / (24 * time.Hour)),
+		IdempotencyKey:       input.ClaimID,
+		RequestId:            input.CorrelationID,
+	})
+	if err != nil {
+		if rejection, ok := bacenRejection(err); ok {
+			logger.Warn("Bacen rejected claim", "claim_id", input.ClaimID, "bacen_code", rejection.Code, "error", rejection.Message)
+			return &workflows.SubmitClaimToBacenResult{
+				Success:      false,
+				ErrorCode:    rejection.Code,
+				ErrorMessage: rejection.Message,
+				Rejection:    &rejection,
+			}, nil
 		}
-
-		if !bridgeResp.Success {
-			logger.Warn("Bacen rejected claim",
-				"error_code", bridgeResp.ErrorCode,
-				"error_message", bridgeResp.ErrorMessage,
-			)
+		if st, ok := status.FromError(err); ok && st.Code() == codes.InvalidArgument {
+			logger.Warn("Bacen rejected claim", "claim_id", input.ClaimID, "error", st.Message())
 			return &workflows.SubmitClaimToBacenResult{
-				Success:            false,
-				BacenCorrelationID: bridgeResp.CorrelationId,
-				ErrorCode:          bridgeResp.ErrorCode,
-				ErrorMessage:       bridgeResp.ErrorMessage,
+				Success:      false,
+				ErrorCode:    st.Code().String(),
+				ErrorMessage: st.Message(),
 			}, nil
 		}
+		logger.Error("Bridge gRPC call failed", "error", err)
+		return nil, fmt.Errorf("failed to submit claim to Bacen: %w", err)
+	}
 
-		logger.Info("Claim submitted to Bacen successfully",
-			"bacen_correlation_id", bridgeResp.CorrelationId,
-		)
-
-		return &workflows.SubmitClaimToBacenResult{
-			Success:            true,
-			BacenCorrelationID: bridgeResp.CorrelationId,
-		}, nil
-	*/
-
-	// Simulate processing time
-	time.Sleep(500 * time.Millisecond)
-
-	// Simulate successful submission
-	bacenCorrelationID := fmt.Sprintf("BACEN-%s-%d", input.ClaimID, time.Now().Unix())
-
-	logger.Info("Claim submitted to Bacen successfully (simulated)",
+	logger.Info("Claim submitted to Bacen successfully",
 		"claim_id", input.ClaimID,
-		"bacen_correlation_id", bacenCorrelationID,
+		"bacen_correlation_id", bridgeResp.ExternalId,
 	)
 
 	// Record heartbeat
@@ -222,12 +235,113 @@ func (a *ClaimActivities) SubmitClaimToBacenActivity(ctx context.Context, input
 
 	return &workflows.SubmitClaimToBacenResult{
 		Success:            true,
-		BacenCorrelationID: bacenCorrelationID,
-		ErrorCode:          "",
-		ErrorMessage:       "",
+		BacenCorrelationID: bridgeResp.ExternalId,
 	}, nil
 }
 
+// ConfirmClaimAtBacenActivity confirms the claim at Bacen (donor accepted or resolution period expired)
+func (a *ClaimActivities) ConfirmClaimAtBacenActivity(ctx context.Context, input ClaimBacenTransitionInput) error {
+	a.logger.WithFields(logrus.Fields{
+		"claim_id": input.ClaimID,
+		"reason":   input.Reason,
+	}).Info("Confirming claim at Bacen")
+
+	_, err := a.bridgeClient.ConfirmClaim(ctx, &bridgev1.ConfirmClaimRequest{
+		ClaimId:            input.ClaimID,
+		ExternalId:         input.ExternalID,
+		ConfirmationReason: input.Reason,
+		IdempotencyKey:     input.ClaimID + ":confirm",
+		RequestId:          input.ClaimID + ":confirm", // Same on every retry, so Bacen is told once
+	})
+	if err != nil {
+		if rejection, ok := bacenRejection(err); ok {
+			return workflows.NewBacenRejectedError("Bacen rejected claim confirmation", rejection, err)
+		}
+		return fmt.Errorf("failed to confirm claim at Bacen: %w", err)
+	}
+
+	return nil
+}
+
+// CompleteClaimAtBacenActivity completes the confirmed claim at Bacen, moving the key to the claimer
+func (a *ClaimActivities) CompleteClaimAtBacenActivity(ctx context.Context, input ClaimBacenTransitionInput) error {
+	a.logger.WithField("claim_id", input.ClaimID).Info("Completing claim at Bacen")
+
+	_, err := a.bridgeClient.CompleteClaim(ctx, &bridgev1.CompleteClaimRequest{
+		ClaimId:          input.ClaimID,
+		ExternalId:       input.ExternalID,
+		ResolutionReason: input.Reason,
+		IdempotencyKey:   input.ClaimID + ":complete",
+		RequestId:        uuid.New().String(),
+	})
+	if err != nil {
+		if rejection, ok := bacenRejection(err); ok {
+			return workflows.NewBacenRejectedError("Bacen rejected claim completion", rejection, err)
+		}
+		return fmt.Errorf("failed to complete claim at Bacen: %w", err)
+	}
+
+	return nil
+}
+
+// CancelClaimAtBacenActivity cancels the claim at Bacen
+func (a *ClaimActivities) CancelClaimAtBacenActivity(ctx context.Context, input ClaimBacenTransitionInput) error {
+	a.logger.WithFields(logrus.Fields{
+		"claim_id": input.ClaimID,
+		"reason":   input.Reason,
+	}).Info("Cancelling claim at Bacen")
+
+	_, err := a.bridgeClient.CancelClaim(ctx, &bridgev1.CancelClaimRequest{
+		ClaimId:            input.ClaimID,
+		ExternalId:         input.ExternalID,
+		CancellationReason: input.Reason,
+		IdempotencyKey:     input.ClaimID + ":cancel",
+		RequestId:          uuid.New().String(),
+	})
+	if err != nil {
+		if rejection, ok := bacenRejection(err); ok {
+			return workflows.NewBacenRejectedError("Bacen rejected claim cancellation", rejection, err)
+		}
+		return fmt.Errorf("failed to cancel claim at Bacen: %w", err)
+	}
+
+	return nil
+}
+
+// claimKeyType maps the claim key type to the Bridge key type
+func claimKeyType(keyType string) commonv1.KeyType {
+	switch keyType {
+	case "CPF":
+		return commonv1.KeyType_KEY_TYPE_CPF
+	case "CNPJ":
+		return commonv1.KeyType_KEY_TYPE_CNPJ
+	case "EMAIL":
+		return commonv1.KeyType_KEY_TYPE_EMAIL
+	case "PHONE":
+		return commonv1.KeyType_KEY_TYPE_PHONE
+	case "EVP":
+		return commonv1.KeyType_KEY_TYPE_EVP
+	default:
+		return commonv1.KeyType_KEY_TYPE_UNSPECIFIED
+	}
+}
+
+// claimAccountType maps the claimer account type (Bacen code) to the Bridge account type
+func claimAccountType(accountType string) commonv1.AccountType {
+	switch accountType {
+	case "CACC":
+		return commonv1.AccountType_ACCOUNT_TYPE_CHECKING
+	case "SVGS":
+		return commonv1.AccountType_ACCOUNT_TYPE_SAVINGS
+	case "TRAN":
+		return commonv1.AccountType_ACCOUNT_TYPE_PAYMENT
+	case "SLRY":
+		return commonv1.AccountType_ACCOUNT_TYPE_SALARY
+	default:
+		return commonv1.AccountType_ACCOUNT_TYPE_UNSPECIFIED
+	}
+}
+
 // UpdateClaimStatusActivity updates the claim status in the database and publishes an event
 //
 // Sprint 1: This activity updates the claim status and can publish events to Pulsar.
@@ -424,7 +538,7 @@ func (a *ClaimActivities) CancelClaimActivity(ctx context.Context, claimID, reas
 	return nil
 }
 
-// ExpireClaimActivity expires the claim after 30 days timeout
+// ExpireClaimActivity expires the claim when the completion period ends
 func (a *ClaimActivities) ExpireClaimActivity(ctx context.Context, claimID string) error {
 	a.logger.WithField("claim_id", claimID).Info("Expiring claim")
 
@@ -592,4 +706,67 @@ func (a *ClaimActivities) PublishClaimEventActivity(ctx context.Context, event m
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// PublishClaimCompletedActivity publishes the final status of a claim to core-dict
+// (connect.v1.ClaimCompletedEvent), with the Bacen error when Bacen rejected the claim
+func (a *ClaimActivities) PublishClaimCompletedActivity(ctx context.Context, input ClaimCompletedEventInput) error {
+	a.logger.WithFields(logrus.Fields{
+		"claim_id": input.ClaimID,
+		"status":   input.Status,
+	}).Info("Publishing claim completed event")
+
+	if err := a.claimEvents.PublishProto(ctx, claimCompletedEvent(input), input.ClaimID); err != nil {
+		return fmt.Errorf("failed to publish claim completed event: %w", err)
+	}
+
+	return nil
+}
+
+// claimCompletedEvent builds the connect.v1.ClaimCompletedEvent published on dict.claims.completed
+func claimCompletedEvent(input ClaimCompletedEventInput) *connectv1.ClaimCompletedEvent {
+	event := &connectv1.ClaimCompletedEvent{
+		ClaimId:     input.ClaimID,
+		EntryId:     input.EntryID,
+		KeyType:     claimKeyType(input.KeyType),
+		KeyValue:    input.Key,
+		ClaimerIspb: input.ClaimerISPB,
+		OwnerIspb:   input.DonorISPB,
+		FinalStatus: claimStatus(input.Status),
+		Reason:      input.Reason,
+		CompletedAt: timestamppb.New(input.CompletedAt),
+	}
+	if rejection := input.Rejection; rejection != nil {
+		event.BacenError = &commonv1.BacenError{
+			BacenCode:      rejection.Code,
+			BacenMessage:   rejection.Message,
+			BacenRequestId: rejection.RequestID,
+			HttpStatus:     int32(rejection.HTTPStatus),
+		}
+		if !rejection.OccurredAt.IsZero() {
+			event.BacenError.OccurredAt = timestamppb.New(rejection.OccurredAt)
+		}
+	}
+
+	return event
+}
+
+// claimStatus maps the claim workflow status to the contract claim status
+func claimStatus(status string) commonv1.ClaimStatus {
+	switch status {
+	case "OPEN":
+		return commonv1.ClaimStatus_CLAIM_STATUS_OPEN
+	case "WAITING_RESOLUTION":
+		return commonv1.ClaimStatus_CLAIM_STATUS_WAITING_RESOLUTION
+	case "CONFIRMED":
+		return commonv1.ClaimStatus_CLAIM_STATUS_CONFIRMED
+	case "CANCELLED":
+		return commonv1.ClaimStatus_CLAIM_STATUS_CANCELLED
+	case "COMPLETED":
+		return commonv1.ClaimStatus_CLAIM_STATUS_COMPLETED
+	case "EXPIRED":
+		return commonv1.ClaimStatus_CLAIM_STATUS_EXPIRED
+	default:
+		return commonv1.ClaimStatus_CLAIM_STATUS_UNSPECIFIED
+	}
+}
@@ -69,7 +69,7 @@ func TestNewClaimActivities(t *testing.T) {
 	repo := new(MockClaimRepository)
 	producer := new(MockPulsarProducer)
 
-	activities := NewClaimActivities(logger, repo, producer)
+	activities := NewClaimActivities(logger, repo, producer, nil, nil)
 
 	require.NotNil(t, activities)
 	assert.Equal(t, logger, activities.logger)
@@ -82,7 +82,7 @@ func TestCreateClaimActivity_Success(t *testing.T) {
 	repo := new(MockClaimRepository)
 	producer := new(MockPulsarProducer)
 
-	activities := NewClaimActivities(logger, repo, producer)
+	activities := NewClaimActivities(logger, repo, producer, nil, nil)
 
 	input := CreateClaimInput{
 		ClaimID:              "claim-123",
@@ -115,7 +115,7 @@ func TestCreateClaimActivity_InvalidType(t *testing.T) {
 	repo := new(MockClaimRepository)
 	producer := new(MockPulsarProducer)
 
-	activities := NewClaimActivities(logger, repo, producer)
+	activities := NewClaimActivities(logger, repo, producer, nil, nil)
 
 	input := CreateClaimInput{
 		ClaimID:     "claim-123",
@@ -140,7 +140,7 @@ func TestCreateClaimActivity_RepositoryError(t *testing.T) {
 	repo := new(MockClaimRepository)
 	producer := new(MockPulsarProducer)
 
-	activities := NewClaimActivities(logger, repo, producer)
+	activities := NewClaimActivities(logger, repo, producer, nil, nil)
 
 	input := CreateClaimInput{
 		ClaimID:     "claim-123",
@@ -170,7 +170,7 @@ func TestGetClaimStatusActivity_Success(t *testing.T) {
 	repo := new(MockClaimRepository)
 	producer := new(MockPulsarProducer)
 
-	activities := NewClaimActivities(logger, repo, producer)
+	activities := NewClaimActivities(logger, repo, producer, nil, nil)
 
 	claim := &entities.Claim{
 		ClaimID: "claim-123",
@@ -195,7 +195,7 @@ func TestCompleteClaimActivity_Success(t *testing.T) {
 	repo := new(MockClaimRepository)
 	producer := new(MockPulsarProducer)
 
-	activities := NewClaimActivities(logger, repo, producer)
+	activities := NewClaimActivities(logger, repo, producer, nil, nil)
 
 	claim, _ := entities.NewClaim(
 		"claim-123",
@@ -228,7 +228,7 @@ func TestCancelClaimActivity_Success(t *testing.T) {
 	repo := new(MockClaimRepository)
 	producer := new(MockPulsarProducer)
 
-	activities := NewClaimActivities(logger, repo, producer)
+	activities := NewClaimActivities(logger, repo, producer, nil, nil)
 
 	claim, _ := entities.NewClaim(
 		"claim-123",
@@ -260,7 +260,7 @@ func TestExpireClaimActivity_Success(t *testing.T) {
 	repo := new(MockClaimRepository)
 	producer := new(MockPulsarProducer)
 
-	activities := NewClaimActivities(logger, repo, producer)
+	activities := NewClaimActivities(logger, repo, producer, nil, nil)
 
 	claim, _ := entities.NewClaim(
 		"claim-123",
@@ -292,7 +292,7 @@ func TestValidateClaimEligibilityActivity_Success(t *testing.T) {
 	repo := new(MockClaimRepository)
 	producer := new(MockPulsarProducer)
 
-	activities := NewClaimActivities(logger, repo, producer)
+	activities := NewClaimActivities(logger, repo, producer, nil, nil)
 
 	// Setup mocks - no active claim exists
 	repo.On("HasActiveClaim", mock.Anything, "12345678901").Return(false, nil)
@@ -311,7 +311,7 @@ func TestValidateClaimEligibilityActivity_AlreadyHasClaim(t *testing.T) {
 	repo := new(MockClaimRepository)
 	producer := new(MockPulsarProducer)
 
-	activities := NewClaimActivities(logger, repo, producer)
+	activities := NewClaimActivities(logger, repo, producer, nil, nil)
 
 	// Setup mocks - active claim exists
 	repo.On("HasActiveClaim", mock.Anything, "12345678901").Return(true, nil)
@@ -365,7 +365,7 @@ func TestClaimActivitiesTableDriven(t *testing.T) {
 			repo := new(MockClaimRepository)
 			producer := new(MockPulsarProducer)
 
-			activities := NewClaimActivities(logger, repo, producer)
+			activities := NewClaimActivities(logger, repo, producer, nil, nil)
 
 			if tt.setupMocks != nil {
 				tt.setupMocks(repo, producer)
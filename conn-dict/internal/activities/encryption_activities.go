@@ -0,0 +1,91 @@
+package activities
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lbpay-lab/conn-dict/internal/infrastructure/repositories"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
+)
+
+// fieldReencryptedTotal counts the entries rewritten under the current KEK
+var fieldReencryptedTotal = promauto.NewCounter(
+	prometheus.CounterOpts{
+		Namespace: "conn_dict",
+		Subsystem: "field_encryption",
+		Name:      "reencrypted_entries_total",
+		Help:      "Total number of entries re-encrypted under the current key (rotation and legacy plaintext)",
+	},
+)
+
+// EncryptionActivities contains Temporal activities for field-level encryption maintenance
+type EncryptionActivities struct {
+	logger    *logrus.Logger
+	entryRepo *repositories.EntryRepository
+}
+
+// NewEncryptionActivities creates a new instance of EncryptionActivities
+func NewEncryptionActivities(logger *logrus.Logger, entryRepo *repositories.EntryRepository) *EncryptionActivities {
+	return &EncryptionActivities{
+		logger:    logger,
+		entryRepo: entryRepo,
+	}
+}
+
+// ReencryptEntriesInput represents input for ReencryptEntriesActivity
+type ReencryptEntriesInput struct {
+	BatchSize  int `json:"batch_size"`
+	MaxBatches int `json:"max_batches"` // Bounds one run; the next scheduled run continues
+}
+
+// ReencryptEntriesResult represents the outcome of ReencryptEntriesActivity
+type ReencryptEntriesResult struct {
+	Reencrypted int  `json:"reencrypted"`
+	Batches     int  `json:"batches"`
+	Completed   bool `json:"completed"` // No entry left under an old key or in plaintext
+}
+
+// ReencryptEntriesActivity re-encrypts entries under the current KEK, batch by batch, until none
+// is left or MaxBatches is reached. Each batch is committed on its own, so a retry resumes where
+// the previous attempt stopped.
+func (a *EncryptionActivities) ReencryptEntriesActivity(ctx context.Context, input ReencryptEntriesInput) (*ReencryptEntriesResult, error) {
+	logger := activity.GetLogger(ctx)
+	logger.Info("Re-encrypting entries", "batch_size", input.BatchSize, "max_batches", input.MaxBatches)
+
+	if input.BatchSize <= 0 || input.MaxBatches <= 0 {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("batch_size and max_batches must be > 0, got %d and %d", input.BatchSize, input.MaxBatches),
+			"InvalidInput",
+			nil,
+		)
+	}
+
+	result := &ReencryptEntriesResult{}
+	for result.Batches < input.MaxBatches {
+		n, err := a.entryRepo.ReencryptBatch(ctx, input.BatchSize)
+		if err != nil {
+			return nil, err
+		}
+		result.Batches++
+		result.Reencrypted += n
+		fieldReencryptedTotal.Add(float64(n))
+		activity.RecordHeartbeat(ctx, result.Reencrypted)
+
+		if n < input.BatchSize {
+			result.Completed = true
+			break
+		}
+	}
+
+	a.logger.WithFields(logrus.Fields{
+		"reencrypted": result.Reencrypted,
+		"batches":     result.Batches,
+		"completed":   result.Completed,
+	}).Info("Entries re-encrypted")
+
+	return result, nil
+}
@@ -3,6 +3,7 @@ package activities
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/lbpay-lab/conn-dict/internal/domain/entities"
 	"github.com/lbpay-lab/conn-dict/internal/infrastructure/pulsar"
@@ -42,6 +43,10 @@ type CreateEntryInput struct {
 	OwnerType       string
 	OwnerName       string
 	OwnerTaxID      string
+	// Dates and last RequestId Bacen holds for the key, so the local CID matches Bacen's
+	AccountOpenedDate *time.Time
+	KeyOwnershipDate  *time.Time
+	BacenRequestID    *string
 }
 
 // CreateEntryActivity creates a new entry in the database
@@ -125,6 +130,9 @@ func (a *EntryActivities) CreateEntryActivity(ctx context.Context, input CreateE
 	if input.OwnerTaxID != "" {
 		entry.OwnerTaxID = &input.OwnerTaxID
 	}
+	entry.AccountOpenedDate = input.AccountOpenedDate
+	entry.KeyOwnershipDate = input.KeyOwnershipDate
+	entry.BacenRequestID = input.BacenRequestID
 
 	// Insert into database
 	if err := a.entryRepo.Create(ctx, entry); err != nil {
@@ -157,10 +165,14 @@ func (a *EntryActivities) CreateEntryActivity(ctx context.Context, input CreateE
 
 // UpdateEntryInput is the input for UpdateEntryActivity
 type UpdateEntryInput struct {
-	AccountBranch *string
-	AccountNumber *string
-	OwnerName     *string
-	OwnerTaxID    *string
+	AccountBranch     *string
+	AccountNumber     *string
+	AccountType       *string
+	OwnerName         *string
+	OwnerTaxID        *string
+	AccountOpenedDate *time.Time
+	KeyOwnershipDate  *time.Time
+	BacenRequestID    *string
 }
 
 // UpdateEntryActivity updates an existing entry in the database
@@ -186,6 +198,24 @@ func (a *EntryActivities) UpdateEntryActivity(ctx context.Context, entryID strin
 	if updates.OwnerTaxID != nil {
 		entry.OwnerTaxID = updates.OwnerTaxID
 	}
+	if updates.AccountType != nil {
+		accountType := entities.AccountType(*updates.AccountType)
+		switch accountType {
+		case entities.AccountTypeCACC, entities.AccountTypeSLRY, entities.AccountTypeSVGS, entities.AccountTypeTRAN:
+			entry.AccountType = accountType
+		default:
+			return fmt.Errorf("invalid account type: %s", *updates.AccountType)
+		}
+	}
+	if updates.AccountOpenedDate != nil {
+		entry.AccountOpenedDate = updates.AccountOpenedDate
+	}
+	if updates.KeyOwnershipDate != nil {
+		entry.KeyOwnershipDate = updates.KeyOwnershipDate
+	}
+	if updates.BacenRequestID != nil {
+		entry.BacenRequestID = updates.BacenRequestID
+	}
 
 	// Update in database
 	if err := a.entryRepo.Update(ctx, entry); err != nil {
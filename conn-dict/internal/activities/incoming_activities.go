@@ -0,0 +1,536 @@
+package activities
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lbpay-lab/conn-dict/internal/domain/entities"
+	bridgev1 "github.com/lbpay-lab/dict-contracts/gen/proto/bridge/v1"
+	commonv1 "github.com/lbpay-lab/dict-contracts/gen/proto/common/v1"
+	connectv1 "github.com/lbpay-lab/dict-contracts/gen/proto/connect/v1"
+	"github.com/sirupsen/logrus"
+	"go.temporal.io/sdk/activity"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// IncomingActivities polls Bacen (via Bridge) for claims and infraction reports opened by other
+// participants against our keys, and mirrors them locally
+type IncomingActivities struct {
+	logger               *logrus.Logger
+	claimRepo            IncomingClaimRepository
+	infractionRepo       IncomingInfractionRepository
+	bridgeClient         IncomingBridgeClient
+	claimEvents          EventPublisher
+	claimCompletedEvents EventPublisher
+	infractionEvents     EventPublisher
+	participantISPB      string
+}
+
+// IncomingBridgeClient interface for the Bridge listing RPCs (for testing/mocking)
+type IncomingBridgeClient interface {
+	ListClaims(ctx context.Context, req *bridgev1.ListClaimsRequest) (*bridgev1.ListClaimsResponse, error)
+	ListInfractionReports(ctx context.Context, req *bridgev1.ListInfractionReportsRequest) (*bridgev1.ListInfractionReportsResponse, error)
+}
+
+// IncomingClaimRepository is the subset of the claim repository used by the poller
+type IncomingClaimRepository interface {
+	GetStatus(ctx context.Context, claimID string) (entities.ClaimStatus, bool, error)
+	Upsert(ctx context.Context, claim *entities.Claim) (bool, error)
+}
+
+// IncomingInfractionRepository is the subset of the infraction repository used by the poller
+type IncomingInfractionRepository interface {
+	Exists(ctx context.Context, infractionID string) (bool, error)
+	CreateIfNotExists(ctx context.Context, infraction *entities.Infraction) (bool, error)
+}
+
+// EventPublisher publishes protobuf events to a Pulsar topic consumed by core-dict
+type EventPublisher interface {
+	PublishProto(ctx context.Context, event proto.Message, key string) error
+}
+
+// NewIncomingActivities creates a new instance of IncomingActivities
+func NewIncomingActivities(
+	logger *logrus.Logger,
+	claimRepo IncomingClaimRepository,
+	infractionRepo IncomingInfractionRepository,
+	bridgeClient IncomingBridgeClient,
+	claimEvents EventPublisher,
+	claimCompletedEvents EventPublisher,
+	infractionEvents EventPublisher,
+	participantISPB string,
+) *IncomingActivities {
+	return &IncomingActivities{
+		logger:               logger,
+		claimRepo:            claimRepo,
+		infractionRepo:       infractionRepo,
+		bridgeClient:         bridgeClient,
+		claimEvents:          claimEvents,
+		claimCompletedEvents: claimCompletedEvents,
+		infractionEvents:     infractionEvents,
+		participantISPB:      participantISPB,
+	}
+}
+
+// PollIncomingInput is the input for the incoming poll activities
+type PollIncomingInput struct {
+	ModifiedAfter time.Time // Zero lists everything Bacen still has
+	PageSize      int32
+}
+
+// IncomingClaim is a claim where we are the donor, still waiting for our resolution
+type IncomingClaim struct {
+	ClaimID              string
+	ClaimType            string // OWNERSHIP or PORTABILITY
+	Key                  string
+	KeyType              string
+	ClaimerISPB          string
+	DonorISPB            string
+	ClaimerAccountBranch string
+	ClaimerAccountNumber string
+	ClaimerAccountType   string
+	ResolutionPeriodEnd  time.Time
+}
+
+// PollIncomingClaimsResult is the result of PollIncomingClaimsActivity
+type PollIncomingClaimsResult struct {
+	Claims    []IncomingClaim // Open claims seen in this poll (new or already known)
+	Fetched   int
+	New       int
+	Watermark time.Time // Latest Bacen modification seen, next poll starts from it
+}
+
+// IncomingInfraction is an infraction report received against us, still open at Bacen
+type IncomingInfraction struct {
+	InfractionID  string
+	Key           string
+	Type          string
+	Description   string
+	ReporterISPB  string
+	ReportedISPB  string
+	TransactionID string
+}
+
+// PollIncomingInfractionsResult is the result of PollIncomingInfractionsActivity
+type PollIncomingInfractionsResult struct {
+	Infractions []IncomingInfraction // Open reports seen in this poll (new or already known)
+	Fetched     int
+	New         int
+	Watermark   time.Time
+}
+
+// PollIncomingClaimsActivity pages through the claims where we are the donor, upserts them and
+// publishes dict.claims.created for the ones we didn't know yet, and dict.claims.completed for the
+// ones Bacen moved to another final status (e.g. the claimer completed or cancelled the claim).
+//
+// The events are published before the claim is stored: a failure in between is retried and the
+// event is delivered at least once, never lost.
+func (a *IncomingActivities) PollIncomingClaimsActivity(ctx context.Context, input PollIncomingInput) (*PollIncomingClaimsResult, error) {
+	a.logger.WithFields(logrus.Fields{
+		"participant_ispb": a.participantISPB,
+		"modified_after":   input.ModifiedAfter,
+	}).Info("Polling incoming claims")
+
+	result := &PollIncomingClaimsResult{Watermark: input.ModifiedAfter}
+	isDonor := true
+	pageToken := ""
+
+	for {
+		req := &bridgev1.ListClaimsRequest{
+			ParticipantIspb: a.participantISPB,
+			IsDonor:         &isDonor,
+			PageSize:        input.PageSize,
+			PageToken:       pageToken,
+			RequestId:       uuid.New().String(),
+		}
+		if !input.ModifiedAfter.IsZero() {
+			req.ModifiedAfter = timestamppb.New(input.ModifiedAfter)
+		}
+
+		resp, err := a.bridgeClient.ListClaims(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list claims: %w", err)
+		}
+
+		for _, bacenClaim := range resp.GetClaims() {
+			result.Fetched++
+			if updatedAt := incomingTime(bacenClaim.GetUpdatedAt()); updatedAt.After(result.Watermark) {
+				result.Watermark = updatedAt
+			}
+
+			claim := incomingClaimEntity(bacenClaim)
+			inserted, err := a.mirrorClaim(ctx, bacenClaim, claim)
+			if err != nil {
+				return nil, err
+			}
+			if inserted {
+				result.New++
+			}
+
+			if claim.IsActive() && claim.Status != entities.ClaimStatusConfirmed {
+				result.Claims = append(result.Claims, IncomingClaim{
+					ClaimID:              claim.ClaimID,
+					ClaimType:            string(claim.Type),
+					Key:                  claim.Key,
+					KeyType:              claim.KeyType,
+					ClaimerISPB:          claim.ClaimerParticipant,
+					DonorISPB:            claim.DonorParticipant,
+					ClaimerAccountBranch: claim.ClaimerAccountBranch,
+					ClaimerAccountNumber: claim.ClaimerAccountNumber,
+					ClaimerAccountType:   claim.ClaimerAccountType,
+					ResolutionPeriodEnd:  incomingTime(bacenClaim.GetResolutionPeriodEnd()),
+				})
+			}
+		}
+
+		activity.RecordHeartbeat(ctx, result.Fetched)
+
+		pageToken = resp.GetNextPageToken()
+		if pageToken == "" {
+			break
+		}
+	}
+
+	a.logger.WithFields(logrus.Fields{
+		"fetched":   result.Fetched,
+		"new":       result.New,
+		"open":      len(result.Claims),
+		"watermark": result.Watermark,
+	}).Info("Incoming claims polled")
+
+	return result, nil
+}
+
+// mirrorClaim publishes the created event for an unknown claim, the completed event when Bacen
+// changed its final status, and upserts it
+func (a *IncomingActivities) mirrorClaim(ctx context.Context, bacenClaim *bridgev1.Claim, claim *entities.Claim) (bool, error) {
+	localStatus, exists, err := a.claimRepo.GetStatus(ctx, claim.ClaimID)
+	if err != nil {
+		return false, err
+	}
+
+	if !exists {
+		event := &connectv1.ClaimCreatedEvent{
+			ClaimId:        claim.ClaimID,
+			EntryId:        bacenClaim.GetEntryId(),
+			KeyType:        bacenClaim.GetKeyType(),
+			KeyValue:       claim.Key,
+			ClaimerIspb:    claim.ClaimerParticipant,
+			OwnerIspb:      claim.DonorParticipant,
+			ClaimerAccount: bacenClaim.GetClaimerAccount(),
+			ClaimType:      connectv1.ClaimCreatedEvent_ClaimType(bacenClaim.GetType()),
+			Status:         bacenClaim.GetStatus(),
+			ExpiresAt:      timestamppb.New(claim.ClaimExpiryDate),
+			CreatedAt:      timestamppb.New(claim.CreatedAt),
+			Metadata:       map[string]string{"source": "bacen_poller"},
+		}
+		if err := a.claimEvents.PublishProto(ctx, event, claim.ClaimID); err != nil {
+			return false, fmt.Errorf("failed to publish claim created event: %w", err)
+		}
+	}
+
+	// Same condition as the status refresh of Upsert: the resolution steps are driven by the
+	// claim workflow, which publishes its own outcome
+	if claim.Status != localStatus && claim.Status != entities.ClaimStatusOpen && claim.Status != entities.ClaimStatusWaitingResolution {
+		event := claimCompletedEvent(ClaimCompletedEventInput{
+			ClaimID:     claim.ClaimID,
+			EntryID:     bacenClaim.GetEntryId(),
+			KeyType:     claim.KeyType,
+			Key:         claim.Key,
+			ClaimerISPB: claim.ClaimerParticipant,
+			DonorISPB:   claim.DonorParticipant,
+			Status:      string(claim.Status),
+			Reason:      "status changed at Bacen",
+			CompletedAt: claim.UpdatedAt,
+		})
+		if err := a.claimCompletedEvents.PublishProto(ctx, event, claim.ClaimID); err != nil {
+			return false, fmt.Errorf("failed to publish claim completed event: %w", err)
+		}
+	}
+
+	inserted, err := a.claimRepo.Upsert(ctx, claim)
+	if err != nil {
+		return false, fmt.Errorf("failed to upsert claim: %w", err)
+	}
+
+	return inserted, nil
+}
+
+// PollIncomingInfractionsActivity pages through the infraction reports where we are the reported
+// participant, stores the new ones and publishes dict.infractions.reported for them
+func (a *IncomingActivities) PollIncomingInfractionsActivity(ctx context.Context, input PollIncomingInput) (*PollIncomingInfractionsResult, error) {
+	a.logger.WithFields(logrus.Fields{
+		"participant_ispb": a.participantISPB,
+		"modified_after":   input.ModifiedAfter,
+	}).Info("Polling incoming infraction reports")
+
+	result := &PollIncomingInfractionsResult{Watermark: input.ModifiedAfter}
+	pageToken := ""
+
+	for {
+		req := &bridgev1.ListInfractionReportsRequest{
+			ParticipantIspb: a.participantISPB,
+			PageSize:        input.PageSize,
+			PageToken:       pageToken,
+			RequestId:       uuid.New().String(),
+		}
+		if !input.ModifiedAfter.IsZero() {
+			req.ModifiedAfter = timestamppb.New(input.ModifiedAfter)
+		}
+
+		resp, err := a.bridgeClient.ListInfractionReports(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list infraction reports: %w", err)
+		}
+
+		for _, report := range resp.GetInfractionReports() {
+			result.Fetched++
+			if updatedAt := incomingTime(report.GetUpdatedAt()); updatedAt.After(result.Watermark) {
+				result.Watermark = updatedAt
+			}
+
+			// The listing also returns the reports we opened ourselves
+			if report.GetReportedIspb() != a.participantISPB {
+				continue
+			}
+
+			infraction, err := incomingInfractionEntity(report)
+			if err != nil {
+				a.logger.WithError(err).WithField("infraction_report_id", report.GetInfractionReportId()).
+					Warn("Skipping invalid infraction report")
+				continue
+			}
+
+			inserted, err := a.mirrorInfraction(ctx, infraction)
+			if err != nil {
+				return nil, err
+			}
+			if inserted {
+				result.New++
+			}
+
+			switch report.GetStatus() {
+			case bridgev1.InfractionReportStatus_INFRACTION_REPORT_STATUS_OPEN,
+				bridgev1.InfractionReportStatus_INFRACTION_REPORT_STATUS_ACKNOWLEDGED:
+				result.Infractions = append(result.Infractions, IncomingInfraction{
+					InfractionID:  infraction.InfractionID,
+					Key:           infraction.Key,
+					Type:          string(infraction.Type),
+					Description:   infraction.Description,
+					ReporterISPB:  infraction.ReporterParticipant,
+					ReportedISPB:  a.participantISPB,
+					TransactionID: report.GetTransactionId(),
+				})
+			}
+		}
+
+		activity.RecordHeartbeat(ctx, result.Fetched)
+
+		pageToken = resp.GetNextPageToken()
+		if pageToken == "" {
+			break
+		}
+	}
+
+	a.logger.WithFields(logrus.Fields{
+		"fetched":   result.Fetched,
+		"new":       result.New,
+		"open":      len(result.Infractions),
+		"watermark": result.Watermark,
+	}).Info("Incoming infraction reports polled")
+
+	return result, nil
+}
+
+// mirrorInfraction publishes the reported event for an unknown infraction and stores it
+func (a *IncomingActivities) mirrorInfraction(ctx context.Context, infraction *entities.Infraction) (bool, error) {
+	exists, err := a.infractionRepo.Exists(ctx, infraction.InfractionID)
+	if err != nil {
+		return false, err
+	}
+	if exists {
+		return false, nil
+	}
+
+	event := &connectv1.InfractionReportedEvent{
+		InfractionId:    infraction.InfractionID,
+		KeyValue:        infraction.Key,
+		ParticipantIspb: a.participantISPB,
+		InfractionType:  incomingInfractionEventType(infraction.Type),
+		Description:     infraction.Description,
+		ReporterIspb:    infraction.ReporterParticipant,
+		Status:          connectv1.InfractionReportedEvent_INFRACTION_STATUS_REPORTED,
+		ReportedAt:      timestamppb.New(infraction.ReportedAt),
+	}
+	if err := a.infractionEvents.PublishProto(ctx, event, infraction.InfractionID); err != nil {
+		return false, fmt.Errorf("failed to publish infraction reported event: %w", err)
+	}
+
+	inserted, err := a.infractionRepo.CreateIfNotExists(ctx, infraction)
+	if err != nil {
+		return false, fmt.Errorf("failed to store infraction: %w", err)
+	}
+
+	return inserted, nil
+}
+
+// incomingClaimEntity maps a Bacen claim to the local entity. The Bacen claim ID is used as claim_id.
+func incomingClaimEntity(c *bridgev1.Claim) *entities.Claim {
+	createdAt := incomingTime(c.GetCreatedAt())
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+	updatedAt := createdAt
+	if c.GetUpdatedAt() != nil {
+		updatedAt = c.GetUpdatedAt().AsTime()
+	}
+
+	return &entities.Claim{
+		ID:                   uuid.New(),
+		ClaimID:              c.GetClaimId(),
+		Type:                 incomingClaimType(c.GetType()),
+		Status:               incomingClaimStatus(c.GetStatus()),
+		Key:                  c.GetKeyValue(),
+		KeyType:              incomingKeyType(c.GetKeyType()),
+		DonorParticipant:     c.GetOwnerIspb(),
+		ClaimerParticipant:   c.GetClaimerIspb(),
+		ClaimerAccountBranch: c.GetClaimerAccount().GetBranchCode(),
+		ClaimerAccountNumber: c.GetClaimerAccount().GetAccountNumber(),
+		ClaimerAccountType:   incomingAccountType(c.GetClaimerAccount().GetAccountType()),
+		CompletionPeriodEnd:  incomingTime(c.GetResolutionPeriodEnd()),
+		ClaimExpiryDate:      incomingTime(c.GetCompletionPeriodEnd()),
+		CreatedAt:            createdAt,
+		UpdatedAt:            updatedAt,
+	}
+}
+
+// incomingInfractionEntity maps a Bacen infraction report to the local entity.
+// Bacen reports refer to a transaction, not to a key: the EndToEndId stands in for the key.
+func incomingInfractionEntity(report *bridgev1.InfractionReport) (*entities.Infraction, error) {
+	description := report.GetReportDetails()
+	if description == "" {
+		description = fmt.Sprintf("Infraction report %s received from %s", report.GetInfractionReportId(), report.GetReporterIspb())
+	}
+
+	infraction, err := entities.NewInfraction(
+		report.GetInfractionReportId(),
+		report.GetTransactionId(),
+		incomingInfractionType(report.GetSituationType()),
+		description,
+		report.GetReporterIspb(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := infraction.MarkReportedToBacen(report.GetInfractionReportId()); err != nil {
+		return nil, err
+	}
+
+	reported := report.GetReportedIspb()
+	transactionID := report.GetTransactionId()
+	infraction.ReportedParticipant = &reported
+	infraction.TransactionID = &transactionID
+	if report.GetCreatedAt() != nil {
+		infraction.ReportedAt = report.GetCreatedAt().AsTime()
+	}
+
+	return infraction, nil
+}
+
+// incomingTime converts an optional Bacen timestamp (zero when absent, AsTime would give the epoch)
+func incomingTime(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+// incomingClaimType maps the Bridge claim type to the domain claim type
+func incomingClaimType(claimType bridgev1.ClaimType) entities.ClaimType {
+	if claimType == bridgev1.ClaimType_CLAIM_TYPE_PORTABILITY {
+		return entities.ClaimTypePortability
+	}
+	return entities.ClaimTypeOwnership
+}
+
+// incomingClaimStatus maps the Bridge claim status to the domain claim status
+func incomingClaimStatus(status commonv1.ClaimStatus) entities.ClaimStatus {
+	switch status {
+	case commonv1.ClaimStatus_CLAIM_STATUS_WAITING_RESOLUTION:
+		return entities.ClaimStatusWaitingResolution
+	case commonv1.ClaimStatus_CLAIM_STATUS_CONFIRMED, commonv1.ClaimStatus_CLAIM_STATUS_AUTO_CONFIRMED:
+		return entities.ClaimStatusConfirmed
+	case commonv1.ClaimStatus_CLAIM_STATUS_CANCELLED:
+		return entities.ClaimStatusCancelled
+	case commonv1.ClaimStatus_CLAIM_STATUS_COMPLETED:
+		return entities.ClaimStatusCompleted
+	case commonv1.ClaimStatus_CLAIM_STATUS_EXPIRED:
+		return entities.ClaimStatusExpired
+	default:
+		return entities.ClaimStatusOpen
+	}
+}
+
+// incomingKeyType is the inverse of claimKeyType
+func incomingKeyType(keyType commonv1.KeyType) string {
+	switch keyType {
+	case commonv1.KeyType_KEY_TYPE_CPF:
+		return "CPF"
+	case commonv1.KeyType_KEY_TYPE_CNPJ:
+		return "CNPJ"
+	case commonv1.KeyType_KEY_TYPE_EMAIL:
+		return "EMAIL"
+	case commonv1.KeyType_KEY_TYPE_PHONE:
+		return "PHONE"
+	default:
+		return "EVP"
+	}
+}
+
+// incomingAccountType is the inverse of claimAccountType (empty when unknown)
+func incomingAccountType(accountType commonv1.AccountType) string {
+	switch accountType {
+	case commonv1.AccountType_ACCOUNT_TYPE_CHECKING:
+		return "CACC"
+	case commonv1.AccountType_ACCOUNT_TYPE_SAVINGS:
+		return "SVGS"
+	case commonv1.AccountType_ACCOUNT_TYPE_PAYMENT:
+		return "TRAN"
+	case commonv1.AccountType_ACCOUNT_TYPE_SALARY:
+		return "SLRY"
+	default:
+		return ""
+	}
+}
+
+// incomingInfractionType maps the Bacen situation type to the domain infraction type
+func incomingInfractionType(situation bridgev1.InfractionSituationType) entities.InfractionType {
+	switch situation {
+	case bridgev1.InfractionSituationType_INFRACTION_SITUATION_TYPE_SCAM,
+		bridgev1.InfractionSituationType_INFRACTION_SITUATION_TYPE_COERCION:
+		return entities.InfractionTypeFraud
+	case bridgev1.InfractionSituationType_INFRACTION_SITUATION_TYPE_ACCOUNT_TAKEOVER,
+		bridgev1.InfractionSituationType_INFRACTION_SITUATION_TYPE_FRAUDULENT_ACCESS:
+		return entities.InfractionTypeUnauthorizedUse
+	default:
+		return entities.InfractionTypeOther
+	}
+}
+
+// incomingInfractionEventType maps the domain infraction type to the event infraction type
+func incomingInfractionEventType(infractionType entities.InfractionType) connectv1.InfractionReportedEvent_InfractionType {
+	switch infractionType {
+	case entities.InfractionTypeFraud, entities.InfractionTypeUnauthorizedUse:
+		return connectv1.InfractionReportedEvent_INFRACTION_TYPE_FRAUD
+	case entities.InfractionTypeAccountClosed:
+		return connectv1.InfractionReportedEvent_INFRACTION_TYPE_ACCOUNT_CLOSED
+	case entities.InfractionTypeDuplicateKey:
+		return connectv1.InfractionReportedEvent_INFRACTION_TYPE_DUPLICATE_KEY
+	default:
+		return connectv1.InfractionReportedEvent_INFRACTION_TYPE_UNSPECIFIED
+	}
+}
@@ -4,10 +4,16 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/google/uuid"
+	bridgev1 "github.com/lbpay-lab/dict-contracts/gen/proto/bridge/v1"
 	"github.com/lbpay-lab/conn-dict/internal/domain/entities"
 	"github.com/lbpay-lab/conn-dict/internal/infrastructure/pulsar"
 	"github.com/lbpay-lab/conn-dict/internal/infrastructure/repositories"
+	"github.com/lbpay-lab/conn-dict/workflows"
 	"github.com/sirupsen/logrus"
+	"go.temporal.io/sdk/temporal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // InfractionActivities contains all Temporal activities for infraction workflow
@@ -15,6 +21,13 @@ type InfractionActivities struct {
 	logger         *logrus.Logger
 	infractionRepo *repositories.InfractionRepository
 	pulsarProducer *pulsar.Producer
+	bridgeClient   InfractionBridgeClient
+}
+
+// InfractionBridgeClient interface for the Bridge infraction report RPCs (for testing/mocking)
+type InfractionBridgeClient interface {
+	CreateInfractionReport(ctx context.Context, req *bridgev1.CreateInfractionReportRequest) (*bridgev1.CreateInfractionReportResponse, error)
+	GetInfractionReport(ctx context.Context, req *bridgev1.GetInfractionReportRequest) (*bridgev1.GetInfractionReportResponse, error)
 }
 
 // NewInfractionActivities creates a new instance of InfractionActivities
@@ -22,11 +35,13 @@ func NewInfractionActivities(
 	logger *logrus.Logger,
 	infractionRepo *repositories.InfractionRepository,
 	pulsarProducer *pulsar.Producer,
+	bridgeClient InfractionBridgeClient,
 ) *InfractionActivities {
 	return &InfractionActivities{
 		logger:         logger,
 		infractionRepo: infractionRepo,
 		pulsarProducer: pulsarProducer,
+		bridgeClient:   bridgeClient,
 	}
 }
 
@@ -41,6 +56,7 @@ type CreateInfractionInput struct {
 	EvidenceURLs        []string // Optional
 	EntryID             string   // Optional - related entry
 	ClaimID             string   // Optional - related claim
+	TransactionID       string   // Optional - EndToEndId, required to report to Bacen
 }
 
 // CreateInfractionActivity creates a new infraction in the database
@@ -97,6 +113,10 @@ func (a *InfractionActivities) CreateInfractionActivity(ctx context.Context, inp
 		infraction.ClaimID = &input.ClaimID
 	}
 
+	if input.TransactionID != "" {
+		infraction.TransactionID = &input.TransactionID
+	}
+
 	// Set evidence URLs if provided
 	if len(input.EvidenceURLs) > 0 {
 		infraction.EvidenceURLs = input.EvidenceURLs
@@ -379,8 +399,8 @@ func (a *InfractionActivities) NotifyReportedParticipantActivity(ctx context.Con
 		return nil
 	}
 
-	// TODO: Call Bridge gRPC to send DICT message to reported participant
-	// This will be implemented when Bridge gRPC client is ready
+	// Bacen delivers the infraction report to the reported participant once
+	// NotifyBacenActivity creates it; this event notifies our own consumers
 
 	// Publish notification event
 	event := map[string]interface{}{
@@ -400,6 +420,8 @@ func (a *InfractionActivities) NotifyReportedParticipantActivity(ctx context.Con
 }
 
 // NotifyBacenActivity sends infraction notification to Bacen
+// The first call creates the infraction report through the Bridge (idempotent on the
+// infraction ID); later calls refresh the report status from Bacen.
 func (a *InfractionActivities) NotifyBacenActivity(ctx context.Context, infractionID string) error {
 	a.logger.WithField("infraction_id", infractionID).Info("Notifying Bacen about infraction")
 
@@ -409,27 +431,134 @@ func (a *InfractionActivities) NotifyBacenActivity(ctx context.Context, infracti
 		return fmt.Errorf("failed to get infraction: %w", err)
 	}
 
-	// TODO: Call Bridge gRPC to send DICT message to Bacen
-	// This will be implemented when Bridge gRPC client is ready
+	var report *bridgev1.InfractionReport
+	if infraction.IsReportedToBacen() {
+		report, err = a.getBacenReport(ctx, *infraction.BacenReportID)
+	} else {
+		report, err = a.createBacenReport(ctx, infraction)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Persist the Bacen report ID so retries and later calls do not report twice
+	if !infraction.IsReportedToBacen() {
+		if err := infraction.MarkReportedToBacen(report.GetInfractionReportId()); err != nil {
+			return fmt.Errorf("invalid Bacen response: %w", err)
+		}
+		if err := a.infractionRepo.Update(ctx, infraction); err != nil {
+			return fmt.Errorf("failed to update infraction: %w", err)
+		}
+	}
 
 	// Publish notification event
 	event := map[string]interface{}{
-		"event_type":    "bacen_notified",
-		"infraction_id": infraction.InfractionID,
-		"key":           infraction.Key,
-		"type":          infraction.Type,
-		"status":        infraction.Status,
+		"event_type":      "bacen_notified",
+		"infraction_id":   infraction.InfractionID,
+		"key":             infraction.Key,
+		"type":            infraction.Type,
+		"status":          infraction.Status,
+		"bacen_report_id": report.GetInfractionReportId(),
+		"bacen_status":    report.GetStatus().String(),
 	}
 
 	if err := a.pulsarProducer.PublishEvent(ctx, event, infraction.InfractionID); err != nil {
 		a.logger.WithError(err).Warn("Failed to publish Bacen notified event")
 	}
 
-	a.logger.WithField("infraction_id", infractionID).Info("Bacen notified successfully")
+	a.logger.WithFields(logrus.Fields{
+		"infraction_id":   infractionID,
+		"bacen_report_id": report.GetInfractionReportId(),
+		"bacen_status":    report.GetStatus(),
+	}).Info("Bacen notified successfully")
 
 	return nil
 }
 
+// createBacenReport creates the infraction report at Bacen through the Bridge
+func (a *InfractionActivities) createBacenReport(ctx context.Context, infraction *entities.Infraction) (*bridgev1.InfractionReport, error) {
+	// Bacen reports refer to a PIX transaction: retrying cannot fix a missing EndToEndId
+	if infraction.TransactionID == nil || *infraction.TransactionID == "" {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("infraction %s has no transaction_id, cannot report to Bacen", infraction.InfractionID),
+			"MissingTransactionID", nil)
+	}
+
+	reason, ok := infractionReportReason(infraction.Type)
+	if !ok {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("infraction %s of type %s is not reportable to Bacen", infraction.InfractionID, infraction.Type),
+			"UnreportableInfractionType", nil)
+	}
+
+	req := &bridgev1.CreateInfractionReportRequest{
+		ParticipantIspb: infraction.ReporterParticipant,
+		TransactionId:   *infraction.TransactionID,
+		Reason:          reason,
+		SituationType:   infractionSituationType(infraction.Type),
+		ReportDetails:   infraction.Description,
+		IdempotencyKey:  infraction.InfractionID,
+		RequestId:       uuid.New().String(),
+	}
+	if infraction.ReportedParticipant != nil {
+		req.ReportedIspb = *infraction.ReportedParticipant
+	}
+
+	resp, err := a.bridgeClient.CreateInfractionReport(ctx, req)
+	if err != nil {
+		if rejection, ok := bacenRejection(err); ok {
+			return nil, workflows.NewBacenRejectedError("Bacen rejected infraction report", rejection, err)
+		}
+		if st, ok := status.FromError(err); ok && st.Code() == codes.InvalidArgument {
+			return nil, temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("Bacen rejected infraction report: %s", st.Message()), "InvalidInfractionReport", err)
+		}
+		return nil, fmt.Errorf("failed to create infraction report at Bacen: %w", err)
+	}
+
+	return resp.GetInfractionReport(), nil
+}
+
+// getBacenReport fetches the current state of an infraction report from Bacen
+func (a *InfractionActivities) getBacenReport(ctx context.Context, reportID string) (*bridgev1.InfractionReport, error) {
+	resp, err := a.bridgeClient.GetInfractionReport(ctx, &bridgev1.GetInfractionReportRequest{
+		InfractionReportId: reportID,
+		RequestId:          uuid.New().String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get infraction report from Bacen: %w", err)
+	}
+	if !resp.Found {
+		return nil, fmt.Errorf("infraction report %s not found at Bacen", reportID)
+	}
+
+	return resp.GetInfractionReport(), nil
+}
+
+// infractionReportReason maps the infraction type to the Bacen report reason. Only
+// transaction infractions are reported; key data issues (closed account, incorrect
+// data, duplicate key) are handled locally and have no Bacen report reason.
+func infractionReportReason(t entities.InfractionType) (bridgev1.InfractionReportReason, bool) {
+	switch t {
+	case entities.InfractionTypeFraud, entities.InfractionTypeUnauthorizedUse, entities.InfractionTypeOther:
+		return bridgev1.InfractionReportReason_INFRACTION_REPORT_REASON_FRAUD, true
+	default:
+		return bridgev1.InfractionReportReason_INFRACTION_REPORT_REASON_UNSPECIFIED, false
+	}
+}
+
+// infractionSituationType maps the infraction type to the Bacen situation type
+func infractionSituationType(t entities.InfractionType) bridgev1.InfractionSituationType {
+	switch t {
+	case entities.InfractionTypeFraud:
+		return bridgev1.InfractionSituationType_INFRACTION_SITUATION_TYPE_SCAM
+	case entities.InfractionTypeUnauthorizedUse:
+		return bridgev1.InfractionSituationType_INFRACTION_SITUATION_TYPE_FRAUDULENT_ACCESS
+	default:
+		return bridgev1.InfractionSituationType_INFRACTION_SITUATION_TYPE_OTHER
+	}
+}
+
 // PublishInfractionEventActivity publishes infraction events to Pulsar
 func (a *InfractionActivities) PublishInfractionEventActivity(ctx context.Context, event map[string]interface{}) error {
 	eventType, ok := event["event_type"].(string)
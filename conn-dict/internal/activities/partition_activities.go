@@ -0,0 +1,389 @@
+package activities
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/lbpay-lab/conn-dict/internal/infrastructure/repositories"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
+)
+
+// Prometheus metrics for partition maintenance
+var (
+	partitionCurrentMonthCovered = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "conn_dict",
+			Subsystem: "partitions",
+			Name:      "current_month_covered",
+			Help:      "1 if the partition for the current month exists, 0 otherwise",
+		},
+		[]string{"table"},
+	)
+
+	partitionFutureMonths = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "conn_dict",
+			Subsystem: "partitions",
+			Name:      "future_months",
+			Help:      "Number of consecutive months after the current month that already have a partition",
+		},
+		[]string{"table"},
+	)
+
+	partitionCount = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "conn_dict",
+			Subsystem: "partitions",
+			Name:      "attached",
+			Help:      "Number of monthly partitions attached to the table",
+		},
+		[]string{"table"},
+	)
+
+	partitionsCreatedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "conn_dict",
+			Subsystem: "partitions",
+			Name:      "created_total",
+			Help:      "Total number of monthly partitions created by the partition manager",
+		},
+		[]string{"table"},
+	)
+
+	partitionsArchivedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "conn_dict",
+			Subsystem: "partitions",
+			Name:      "archived_total",
+			Help:      "Total number of partitions archived and dropped past the retention window",
+		},
+		[]string{"table"},
+	)
+
+	partitionArchivedRowsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "conn_dict",
+			Subsystem: "partitions",
+			Name:      "archived_rows_total",
+			Help:      "Total number of rows exported to partition archives",
+		},
+		[]string{"table"},
+	)
+)
+
+// ArchiveStore stores partition archives durably (object storage such as S3)
+type ArchiveStore interface {
+	// PutFile uploads the file at path as key, replacing any object with the same key, and
+	// returns the object location once it is durably stored
+	PutFile(ctx context.Context, key, path string) (string, error)
+}
+
+// PartitionActivities contains Temporal activities for audit_logs/event_logs partition maintenance
+type PartitionActivities struct {
+	logger        *logrus.Logger
+	partitionRepo *repositories.PartitionRepository
+	archiveStore  ArchiveStore
+}
+
+// NewPartitionActivities creates a new instance of PartitionActivities.
+// Expired partitions are archived as gzip-compressed CSV objects in archiveStore; without a
+// store (nil) they are never archived nor dropped.
+func NewPartitionActivities(
+	logger *logrus.Logger,
+	partitionRepo *repositories.PartitionRepository,
+	archiveStore ArchiveStore,
+) *PartitionActivities {
+	return &PartitionActivities{
+		logger:        logger,
+		partitionRepo: partitionRepo,
+		archiveStore:  archiveStore,
+	}
+}
+
+// EnsurePartitionsInput represents input for EnsureFuturePartitionsActivity
+type EnsurePartitionsInput struct {
+	Table           string    `json:"table"`
+	LookaheadMonths int       `json:"lookahead_months"` // Months to pre-create after the current one
+	Reference       time.Time `json:"reference"`        // "Now" as seen by the workflow
+}
+
+// EnsurePartitionsResult represents the outcome of EnsureFuturePartitionsActivity
+type EnsurePartitionsResult struct {
+	Table        string   `json:"table"`
+	Created      []string `json:"created"`
+	FutureMonths int      `json:"future_months"`
+}
+
+// ArchivePartitionsInput represents input for ArchiveExpiredPartitionsActivity
+type ArchivePartitionsInput struct {
+	Table           string    `json:"table"`
+	RetentionMonths int       `json:"retention_months"` // Full months kept online before the current one
+	Reference       time.Time `json:"reference"`        // "Now" as seen by the workflow
+}
+
+// ArchivedPartition describes one partition moved to cold storage
+type ArchivedPartition struct {
+	Name            string `json:"name"`
+	ArchiveLocation string `json:"archive_location"` // Object location (s3://bucket/key)
+	Rows            int64  `json:"rows"`
+}
+
+// ArchivePartitionsResult represents the outcome of ArchiveExpiredPartitionsActivity
+type ArchivePartitionsResult struct {
+	Table    string              `json:"table"`
+	Archived []ArchivedPartition `json:"archived"`
+}
+
+// EnsureFuturePartitionsActivity creates the partitions for the current month and the next
+// LookaheadMonths months, then refreshes the coverage metrics for the table.
+func (a *PartitionActivities) EnsureFuturePartitionsActivity(ctx context.Context, input EnsurePartitionsInput) (*EnsurePartitionsResult, error) {
+	logger := activity.GetLogger(ctx)
+	logger.Info("Ensuring future partitions", "table", input.Table, "lookahead_months", input.LookaheadMonths)
+
+	if !repositories.IsManagedPartitionedTable(input.Table) {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("table %q is not a managed partitioned table", input.Table),
+			"InvalidTable",
+			nil,
+		)
+	}
+	if input.LookaheadMonths < 0 {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("lookahead_months must be >= 0, got %d", input.LookaheadMonths),
+			"InvalidInput",
+			nil,
+		)
+	}
+
+	existing, err := a.partitionRepo.ListPartitions(ctx, input.Table)
+	if err != nil {
+		return nil, err
+	}
+	attached := make(map[string]bool, len(existing))
+	for _, p := range existing {
+		attached[p.Name] = true
+	}
+
+	result := &EnsurePartitionsResult{Table: input.Table}
+	current := repositories.MonthStart(input.Reference)
+	for i := 0; i <= input.LookaheadMonths; i++ {
+		month := current.AddDate(0, i, 0)
+		name := repositories.PartitionName(input.Table, month)
+		if attached[name] {
+			continue
+		}
+
+		if err := a.partitionRepo.CreateMonthlyPartition(ctx, input.Table, month); err != nil {
+			return nil, err
+		}
+		result.Created = append(result.Created, name)
+		partitionsCreatedTotal.WithLabelValues(input.Table).Inc()
+		activity.RecordHeartbeat(ctx, fmt.Sprintf("Created partition %s", name))
+	}
+
+	partitions, err := a.partitionRepo.ListPartitions(ctx, input.Table)
+	if err != nil {
+		return nil, err
+	}
+	result.FutureMonths = a.recordCoverage(input.Table, partitions, input.Reference)
+
+	a.logger.WithFields(logrus.Fields{
+		"table":         input.Table,
+		"created":       result.Created,
+		"future_months": result.FutureMonths,
+	}).Info("Partitions ensured")
+
+	return result, nil
+}
+
+// ArchiveExpiredPartitionsActivity archives and drops every partition whose range ends before
+// the retention cutoff. Each partition is first detached, so no row can reach it afterwards,
+// then exported from the detached table to <table>/<partition>.csv.gz in the archive store, and
+// dropped only once the upload succeeded. A retry after a failure picks up the partitions that
+// were detached but not dropped, so it never loses data.
+func (a *PartitionActivities) ArchiveExpiredPartitionsActivity(ctx context.Context, input ArchivePartitionsInput) (*ArchivePartitionsResult, error) {
+	logger := activity.GetLogger(ctx)
+	logger.Info("Archiving expired partitions", "table", input.Table, "retention_months", input.RetentionMonths)
+
+	if !repositories.IsManagedPartitionedTable(input.Table) {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("table %q is not a managed partitioned table", input.Table),
+			"InvalidTable",
+			nil,
+		)
+	}
+	if input.RetentionMonths < 1 {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("retention_months must be >= 1, got %d", input.RetentionMonths),
+			"InvalidInput",
+			nil,
+		)
+	}
+	if a.archiveStore == nil {
+		return nil, temporal.NewNonRetryableApplicationError(
+			"no archive store configured; expired partitions are kept online",
+			"ArchiveStoreNotConfigured",
+			nil,
+		)
+	}
+
+	partitions, err := a.partitionRepo.ListPartitions(ctx, input.Table)
+	if err != nil {
+		return nil, err
+	}
+	for _, partition := range expiredPartitions(partitions, input.Reference, input.RetentionMonths) {
+		if err := a.partitionRepo.DetachPartition(ctx, partition); err != nil {
+			return nil, err
+		}
+	}
+
+	detached, err := a.partitionRepo.ListDetachedPartitions(ctx, input.Table)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ArchivePartitionsResult{Table: input.Table}
+	for _, partition := range detached {
+		archived, err := a.archivePartition(ctx, partition)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := a.partitionRepo.DropDetachedPartition(ctx, partition); err != nil {
+			return nil, err
+		}
+
+		result.Archived = append(result.Archived, *archived)
+		partitionsArchivedTotal.WithLabelValues(input.Table).Inc()
+		partitionArchivedRowsTotal.WithLabelValues(input.Table).Add(float64(archived.Rows))
+		activity.RecordHeartbeat(ctx, fmt.Sprintf("Archived partition %s", partition.Name))
+
+		a.logger.WithFields(logrus.Fields{
+			"table":            input.Table,
+			"partition":        partition.Name,
+			"rows":             archived.Rows,
+			"archive_location": archived.ArchiveLocation,
+		}).Info("Partition archived and dropped")
+	}
+
+	partitions, err = a.partitionRepo.ListPartitions(ctx, input.Table)
+	if err != nil {
+		return nil, err
+	}
+	a.recordCoverage(input.Table, partitions, input.Reference)
+
+	return result, nil
+}
+
+// archivePartition exports the detached partition to a local gzip spool file and uploads it to
+// the archive store. The spool file is removed afterwards; only the uploaded object counts.
+func (a *PartitionActivities) archivePartition(ctx context.Context, partition repositories.MonthlyPartition) (*ArchivedPartition, error) {
+	file, err := os.CreateTemp("", partition.Name+"-*.csv.gz")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create archive spool file: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	gz := gzip.NewWriter(file)
+	gz.Name = partition.Name + ".csv"
+	gz.ModTime = partition.End
+
+	rows, err := a.partitionRepo.ExportPartition(ctx, partition, &heartbeatWriter{ctx: ctx, w: gz, name: partition.Name})
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to flush archive of %s: %w", partition.Name, err)
+	}
+	if err := file.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close archive of %s: %w", partition.Name, err)
+	}
+
+	key := partition.Table + "/" + partition.Name + ".csv.gz"
+	location, err := a.archiveStore.PutFile(ctx, key, file.Name())
+	if err != nil {
+		return nil, fmt.Errorf("failed to store archive of %s: %w", partition.Name, err)
+	}
+
+	return &ArchivedPartition{
+		Name:            partition.Name,
+		ArchiveLocation: location,
+		Rows:            rows,
+	}, nil
+}
+
+// heartbeatWriter records activity heartbeats while a large partition is being exported
+type heartbeatWriter struct {
+	ctx     context.Context
+	w       io.Writer
+	name    string
+	written int64
+	last    time.Time
+}
+
+func (h *heartbeatWriter) Write(p []byte) (int, error) {
+	n, err := h.w.Write(p)
+	h.written += int64(n)
+	if time.Since(h.last) >= 10*time.Second {
+		activity.RecordHeartbeat(h.ctx, fmt.Sprintf("Exporting partition %s (%d bytes)", h.name, h.written))
+		h.last = time.Now()
+	}
+	return n, err
+}
+
+// recordCoverage updates the coverage gauges for the table and returns the number of
+// consecutive future months covered
+func (a *PartitionActivities) recordCoverage(table string, partitions []repositories.MonthlyPartition, reference time.Time) int {
+	currentCovered, futureMonths := partitionCoverage(partitions, reference)
+
+	covered := 0.0
+	if currentCovered {
+		covered = 1.0
+	}
+	partitionCurrentMonthCovered.WithLabelValues(table).Set(covered)
+	partitionFutureMonths.WithLabelValues(table).Set(float64(futureMonths))
+	partitionCount.WithLabelValues(table).Set(float64(len(partitions)))
+
+	return futureMonths
+}
+
+// partitionCoverage reports whether the current month has a partition and how many consecutive
+// months after it are covered as well
+func partitionCoverage(partitions []repositories.MonthlyPartition, reference time.Time) (bool, int) {
+	starts := make(map[time.Time]bool, len(partitions))
+	for _, p := range partitions {
+		starts[p.Start] = true
+	}
+
+	current := repositories.MonthStart(reference)
+	futureMonths := 0
+	for starts[current.AddDate(0, futureMonths+1, 0)] {
+		futureMonths++
+	}
+
+	return starts[current], futureMonths
+}
+
+// expiredPartitions returns the partitions entirely older than the retention window: the current
+// month plus retentionMonths full months before it are kept online
+func expiredPartitions(partitions []repositories.MonthlyPartition, reference time.Time, retentionMonths int) []repositories.MonthlyPartition {
+	cutoff := repositories.MonthStart(reference).AddDate(0, -retentionMonths, 0)
+
+	var expired []repositories.MonthlyPartition
+	for _, p := range partitions {
+		if !p.End.After(cutoff) {
+			expired = append(expired, p)
+		}
+	}
+	return expired
+}
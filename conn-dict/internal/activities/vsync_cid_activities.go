@@ -0,0 +1,451 @@
+package activities
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lbpay-lab/conn-dict/internal/domain/entities"
+	"github.com/lbpay-lab/conn-dict/internal/infrastructure/repositories"
+	bridgev1 "github.com/lbpay-lab/dict-contracts/gen/proto/bridge/v1"
+	"github.com/sirupsen/logrus"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// VSyncKeyTypes are the key types Bacen computes a VSync value for
+var VSyncKeyTypes = []entities.KeyType{
+	entities.KeyTypeCPF,
+	entities.KeyTypeCNPJ,
+	entities.KeyTypeEMAIL,
+	entities.KeyTypePHONE,
+	entities.KeyTypeEVP,
+}
+
+// cidScanHeartbeatInterval is the number of local CIDs walked between heartbeats
+const cidScanHeartbeatInterval = 10000
+
+// errCIDScanLimit stops the local CID scan once enough divergent CIDs were collected
+var errCIDScanLimit = errors.New("cid scan limit reached")
+
+// KeyTypeVSync compares the local and Bacen VSync of one key type
+type KeyTypeVSync struct {
+	KeyType    string `json:"key_type"`
+	LocalCount int64  `json:"local_count"`
+	LocalVSync string `json:"local_vsync"`
+	BacenCount int64  `json:"bacen_count"`
+	BacenVSync string `json:"bacen_vsync"`
+	InSync     bool   `json:"in_sync"`
+}
+
+// VSyncComparison is the result of CompareVSyncActivity
+type VSyncComparison struct {
+	ParticipantISPB string         `json:"participant_ispb"`
+	KeyTypes        []KeyTypeVSync `json:"key_types"`
+}
+
+// BackfillCIDsInput represents input for BackfillEntryCIDsActivity
+type BackfillCIDsInput struct {
+	BatchSize  int `json:"batch_size"`
+	MaxBatches int `json:"max_batches"` // Bounds one activity; the workflow calls it again until Completed
+}
+
+// BackfillCIDsResult represents the outcome of BackfillEntryCIDsActivity
+type BackfillCIDsResult struct {
+	Filled    int  `json:"filled"`
+	Batches   int  `json:"batches"`
+	Completed bool `json:"completed"` // Every entry has a CID
+}
+
+// ReconcileCIDsInput represents input for ReconcileCIDsActivity
+type ReconcileCIDsInput struct {
+	SyncID           string `json:"sync_id"` // Discrepancies are stored under it
+	ParticipantISPB  string `json:"participant_ispb"`
+	KeyType          string `json:"key_type"`
+	MaxDiscrepancies int    `json:"max_discrepancies"` // Divergent CIDs resolved per run
+}
+
+// ReconcileCIDsResult represents the outcome of ReconcileCIDsActivity
+type ReconcileCIDsResult struct {
+	KeyType       string                     `json:"key_type"`
+	LocalCIDs     int                        `json:"local_cids"`
+	BacenCIDs     int                        `json:"bacen_cids"`
+	Discrepancies entities.DiscrepancyCounts `json:"discrepancies"` // Stored in vsync_discrepancies
+	Truncated     bool                       `json:"truncated"`     // More divergent CIDs than MaxDiscrepancies; the next run continues
+}
+
+// BackfillEntryCIDsActivity stores the CID of entries written before CIDs existed, batch by batch,
+// until none is left or MaxBatches is reached. The VSync aggregates only cover entries with a CID,
+// so VSyncWorkflow runs it before comparing them with Bacen.
+func (a *VSyncActivities) BackfillEntryCIDsActivity(ctx context.Context, input BackfillCIDsInput) (*BackfillCIDsResult, error) {
+	logger := activity.GetLogger(ctx)
+	logger.Info("Backfilling entry CIDs", "batch_size", input.BatchSize, "max_batches", input.MaxBatches)
+
+	if input.BatchSize <= 0 || input.MaxBatches <= 0 {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("batch_size and max_batches must be > 0, got %d and %d", input.BatchSize, input.MaxBatches),
+			"InvalidInput",
+			nil,
+		)
+	}
+
+	result := &BackfillCIDsResult{}
+	for result.Batches < input.MaxBatches {
+		n, err := a.entryRepo.BackfillCIDs(ctx, input.BatchSize)
+		if err != nil {
+			return nil, err
+		}
+		result.Batches++
+		result.Filled += n
+		activity.RecordHeartbeat(ctx, result.Filled)
+
+		if n < input.BatchSize {
+			result.Completed = true
+			break
+		}
+	}
+
+	a.logger.WithFields(logrus.Fields{
+		"filled":    result.Filled,
+		"batches":   result.Batches,
+		"completed": result.Completed,
+	}).Info("Entry CIDs backfilled")
+
+	return result, nil
+}
+
+// CompareVSyncActivity compares, for every key type, the local VSync aggregate with the VSync
+// value Bacen computes for the participant. It reads one row and makes one Bridge call per key
+// type, whatever the number of keys.
+//
+// Parameters:
+// - ispb: Participant ISPB (empty = the participant this instance runs for)
+func (a *VSyncActivities) CompareVSyncActivity(ctx context.Context, ispb string) (*VSyncComparison, error) {
+	if ispb == "" {
+		ispb = a.participantISPB
+	}
+
+	comparison := &VSyncComparison{ParticipantISPB: ispb}
+	for _, keyType := range VSyncKeyTypes {
+		local, err := a.entryRepo.GetVSyncAggregate(ctx, ispb, keyType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get local vsync for %s: %w", keyType, err)
+		}
+
+		resp, err := a.bridgeClient.VerifySync(ctx, &bridgev1.VerifySyncRequest{
+			ParticipantIspb: ispb,
+			KeyType:         claimKeyType(string(keyType)),
+			RequestId:       uuid.New().String(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify sync of %s at Bacen: %w", keyType, err)
+		}
+
+		bacenVSync := resp.Vsync
+		if bacenVSync == "" {
+			bacenVSync = repositories.EmptyVSync
+		}
+		comparison.KeyTypes = append(comparison.KeyTypes, KeyTypeVSync{
+			KeyType:    string(keyType),
+			LocalCount: local.EntryCount,
+			LocalVSync: local.VSync,
+			BacenCount: resp.EntryCount,
+			BacenVSync: bacenVSync,
+			InSync:     local.VSync == bacenVSync && local.EntryCount == resp.EntryCount,
+		})
+	}
+
+	a.logger.WithFields(logrus.Fields{
+		"participant_ispb": ispb,
+		"key_types":        comparison.KeyTypes,
+	}).Info("VSync compared with Bacen")
+
+	return comparison, nil
+}
+
+// ReconcileCIDsActivity finds the entries of one key type that differ from Bacen and stores them
+// as discrepancies of the sync. It is only run for key types whose VSync differs.
+//
+// The sorted CID list from Bacen is merge-walked against the local CIDs, streamed in the same
+// order, so only divergent CIDs are kept in memory. Each divergent CID is then resolved:
+//   - Local CID unknown to Bacen: the key is looked up at Bacen. Not found (or registered to
+//     another participant) is MISSING_BACEN; otherwise the local entry is OUTDATED_LOCAL and is
+//     updated with Bacen's data, which accounts for Bacen's CID of the key as well.
+//   - Bacen CID unknown locally: the entry is fetched from Bacen by CID. MISSING_LOCAL when the
+//     key does not exist locally, OUTDATED_LOCAL when it does (e.g. deactivated locally).
+func (a *VSyncActivities) ReconcileCIDsActivity(ctx context.Context, input ReconcileCIDsInput) (*ReconcileCIDsResult, error) {
+	if input.MaxDiscrepancies <= 0 {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("max_discrepancies must be > 0, got %d", input.MaxDiscrepancies),
+			"InvalidInput",
+			nil,
+		)
+	}
+
+	ispb := input.ParticipantISPB
+	if ispb == "" {
+		ispb = a.participantISPB
+	}
+	keyType := entities.KeyType(input.KeyType)
+	result := &ReconcileCIDsResult{KeyType: input.KeyType}
+
+	resp, err := a.bridgeClient.ListCids(ctx, &bridgev1.ListCidsRequest{
+		ParticipantIspb: ispb,
+		KeyType:         claimKeyType(input.KeyType),
+		RequestId:       uuid.New().String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cids of %s at Bacen: %w", keyType, err)
+	}
+	bacenCIDs := resp.Cids
+	sort.Strings(bacenCIDs)
+	result.BacenCIDs = len(bacenCIDs)
+
+	// Merge walk: both lists are in byte order
+	var localOnly []string // Entry IDs
+	bacenOnly := make(map[string]struct{})
+	diverged := func() int { return len(localOnly) + len(bacenOnly) }
+	i := 0
+	err = a.entryRepo.ScanCIDs(ctx, ispb, keyType, func(cid, entryID string) error {
+		result.LocalCIDs++
+		if result.LocalCIDs%cidScanHeartbeatInterval == 0 {
+			activity.RecordHeartbeat(ctx, result.LocalCIDs)
+		}
+
+		for i < len(bacenCIDs) && bacenCIDs[i] < cid {
+			if diverged() >= input.MaxDiscrepancies {
+				return errCIDScanLimit
+			}
+			bacenOnly[bacenCIDs[i]] = struct{}{}
+			i++
+		}
+		if i < len(bacenCIDs) && bacenCIDs[i] == cid {
+			i++
+			return nil
+		}
+		if diverged() >= input.MaxDiscrepancies {
+			return errCIDScanLimit
+		}
+		localOnly = append(localOnly, entryID)
+		return nil
+	})
+	switch {
+	case errors.Is(err, errCIDScanLimit):
+		result.Truncated = true
+	case err != nil:
+		return nil, fmt.Errorf("failed to scan local cids of %s: %w", keyType, err)
+	default:
+		for ; i < len(bacenCIDs); i++ {
+			if diverged() >= input.MaxDiscrepancies {
+				result.Truncated = true
+				break
+			}
+			bacenOnly[bacenCIDs[i]] = struct{}{}
+		}
+	}
+
+	a.logger.WithFields(logrus.Fields{
+		"participant_ispb": ispb,
+		"key_type":         keyType,
+		"local_cids":       result.LocalCIDs,
+		"bacen_cids":       result.BacenCIDs,
+		"local_only":       len(localOnly),
+		"bacen_only":       len(bacenOnly),
+		"truncated":        result.Truncated,
+	}).Info("CID lists compared")
+
+	for n, entryID := range localOnly {
+		activity.RecordHeartbeat(ctx, n)
+
+		discrepancy, bacenCID, err := a.resolveLocalCID(ctx, ispb, entryID)
+		if err != nil {
+			return nil, err
+		}
+		delete(bacenOnly, bacenCID)
+		if err := a.storeDiscrepancy(ctx, input.SyncID, discrepancy); err != nil {
+			return nil, err
+		}
+		result.Discrepancies.Add(discrepancy.Type)
+	}
+
+	// Sorted, so the run is deterministic
+	remaining := make([]string, 0, len(bacenOnly))
+	for cid := range bacenOnly {
+		remaining = append(remaining, cid)
+	}
+	sort.Strings(remaining)
+	for n, cid := range remaining {
+		activity.RecordHeartbeat(ctx, len(localOnly)+n)
+
+		discrepancy, err := a.resolveBacenCID(ctx, ispb, cid)
+		if err != nil {
+			return nil, err
+		}
+		if discrepancy == nil {
+			continue
+		}
+		if err := a.storeDiscrepancy(ctx, input.SyncID, discrepancy); err != nil {
+			return nil, err
+		}
+		result.Discrepancies.Add(discrepancy.Type)
+	}
+
+	a.logger.WithFields(logrus.Fields{
+		"key_type":       keyType,
+		"discrepancies":  result.Discrepancies.Total(),
+		"missing_local":  result.Discrepancies.MissingLocal,
+		"outdated_local": result.Discrepancies.OutdatedLocal,
+		"missing_bacen":  result.Discrepancies.MissingBacen,
+	}).Info("CID reconciliation completed")
+
+	return result, nil
+}
+
+// storeDiscrepancy stores a discrepancy found by the CID reconciliation under the sync
+func (a *VSyncActivities) storeDiscrepancy(ctx context.Context, syncID string, discrepancy *entities.SyncDiscrepancy) error {
+	discrepancy.SyncID = syncID
+	if err := a.vsyncRepo.CreateDiscrepancy(ctx, discrepancy); err != nil {
+		return fmt.Errorf("failed to store discrepancy: %w", err)
+	}
+	return nil
+}
+
+// resolveLocalCID looks up at Bacen a local entry whose CID Bacen does not have. It also returns
+// Bacen's CID of the key (empty when Bacen does not hold it for the participant).
+func (a *VSyncActivities) resolveLocalCID(ctx context.Context, ispb, entryID string) (*entities.SyncDiscrepancy, string, error) {
+	local, err := a.entryRepo.GetByEntryID(ctx, entryID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get local entry %s: %w", entryID, err)
+	}
+
+	discrepancy := &entities.SyncDiscrepancy{
+		Participant: ispb,
+		KeyType:     local.KeyType,
+		Key:         local.Key,
+		EntryID:     &local.EntryID,
+	}
+
+	resp, err := a.bridgeClient.GetEntry(ctx, &bridgev1.GetEntryRequest{
+		Identifier: &bridgev1.GetEntryRequest_KeyQuery{
+			KeyQuery: &bridgev1.KeyQuery{
+				KeyType:  claimKeyType(string(local.KeyType)),
+				KeyValue: local.Key,
+			},
+		},
+		RequestId: uuid.New().String(),
+	})
+	if status.Code(err) == codes.NotFound {
+		discrepancy.Type = entities.DiscrepancyTypeMissingBacen
+		discrepancy.Reason = "Entry exists locally but not in Bacen DICT - needs manual review (may be pending registration)"
+		return discrepancy, "", nil
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get entry %s at Bacen: %w", entryID, err)
+	}
+
+	bacen := convertProtoEntryToBacenEntry(&bridgev1.Entry{
+		KeyType:          resp.Key.GetKeyType(),
+		KeyValue:         resp.Key.GetKeyValue(),
+		Account:          resp.Account,
+		Status:           resp.Status,
+		CreatedAt:        resp.CreatedAt,
+		UpdatedAt:        resp.UpdatedAt,
+		KeyOwnershipDate: resp.KeyOwnershipDate,
+		RequestId:        resp.RequestId,
+	})
+	discrepancy.BacenEntry = bacen.entry()
+	if bacen.ParticipantISPB != ispb {
+		discrepancy.Type = entities.DiscrepancyTypeMissingBacen
+		discrepancy.Reason = fmt.Sprintf("Key is registered to participant %s in Bacen DICT - needs manual review", bacen.ParticipantISPB)
+		return discrepancy, "", nil
+	}
+
+	discrepancy.Type = entities.DiscrepancyTypeOutdatedLocal
+	discrepancy.Reason = "Entry CID differs between Bacen and local database"
+	return discrepancy, discrepancy.BacenEntry.CID(), nil
+}
+
+// resolveBacenCID fetches from Bacen an entry whose CID has no local match. It returns nil when
+// Bacen no longer holds the CID (the key changed since the list was taken).
+func (a *VSyncActivities) resolveBacenCID(ctx context.Context, ispb, cid string) (*entities.SyncDiscrepancy, error) {
+	resp, err := a.bridgeClient.GetEntryByCid(ctx, &bridgev1.GetEntryByCidRequest{
+		ParticipantIspb: ispb,
+		Cid:             cid,
+		RequestId:       uuid.New().String(),
+	})
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get entry of cid %s at Bacen: %w", cid, err)
+	}
+
+	bacen := convertProtoEntryToBacenEntry(resp.Entry)
+	discrepancy := &entities.SyncDiscrepancy{
+		Participant: ispb,
+		KeyType:     entities.KeyType(bacen.KeyType),
+		Key:         bacen.Key,
+		BacenEntry:  bacen.entry(),
+	}
+
+	local, err := a.entryRepo.GetByKey(ctx, bacen.Key)
+	if errors.Is(err, repositories.ErrEntryNotFound) {
+		discrepancy.Type = entities.DiscrepancyTypeMissingLocal
+		discrepancy.Reason = "Entry exists in Bacen DICT but not in local database"
+		return discrepancy, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get local entry of cid %s: %w", cid, err)
+	}
+
+	discrepancy.Type = entities.DiscrepancyTypeOutdatedLocal
+	discrepancy.EntryID = &local.EntryID
+	discrepancy.Reason = "Entry registered in Bacen DICT is not counted locally (deactivated or CID differs)"
+	return discrepancy, nil
+}
+
+// entry converts an entry as returned by Bacen; its CID is Bacen's CID of the key
+func (b *BacenEntry) entry() *entities.Entry {
+	entry := &entities.Entry{
+		KeyType:           entities.KeyType(b.KeyType),
+		Key:               b.Key,
+		Participant:       b.ParticipantISPB,
+		AccountType:       entities.AccountType(b.AccountType),
+		AccountOpenedDate: b.AccountOpenedDate,
+		OwnerType:         entities.OwnerType(b.OwnerType),
+		KeyOwnershipDate:  b.KeyOwnershipDate,
+		CreatedAt:         b.CreatedAt,
+		UpdatedAt:         b.UpdatedAt,
+	}
+	if b.AccountBranch != "" {
+		entry.AccountBranch = &b.AccountBranch
+	}
+	if b.AccountNumber != "" {
+		entry.AccountNumber = &b.AccountNumber
+	}
+	if b.OwnerName != "" {
+		entry.OwnerName = &b.OwnerName
+	}
+	if b.OwnerTaxID != "" {
+		entry.OwnerTaxID = &b.OwnerTaxID
+	}
+	if b.RequestID != "" {
+		entry.BacenRequestID = &b.RequestID
+	}
+	return entry
+}
+
+// optionalTime converts an optional protobuf timestamp
+func optionalTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
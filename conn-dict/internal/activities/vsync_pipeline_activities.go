@@ -0,0 +1,538 @@
+package activities
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lbpay-lab/conn-dict/internal/domain/entities"
+	"github.com/lbpay-lab/conn-dict/internal/infrastructure/repositories"
+	bridgev1 "github.com/lbpay-lab/dict-contracts/gen/proto/bridge/v1"
+	commonv1 "github.com/lbpay-lab/dict-contracts/gen/proto/common/v1"
+	"github.com/sirupsen/logrus"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// StageBacenEntriesInput represents input for StageBacenEntriesActivity
+type StageBacenEntriesInput struct {
+	SyncID          string     `json:"sync_id"`
+	ParticipantISPB string     `json:"participant_ispb"`         // Empty = the participant this instance runs for
+	LastSyncDate    *time.Time `json:"last_sync_date,omitempty"` // INCREMENTAL: only entries updated after it are staged
+	PageToken       string     `json:"page_token"`               // Where the previous call stopped
+	PageSize        int        `json:"page_size"`
+	MaxPages        int        `json:"max_pages"` // Bounds one activity; the workflow calls it again until Done
+}
+
+// StageBacenEntriesResult represents the outcome of StageBacenEntriesActivity. It is also the
+// heartbeat detail, so a retry resumes from the last staged page.
+type StageBacenEntriesResult struct {
+	ParticipantISPB string `json:"participant_ispb"`
+	Fetched         int    `json:"fetched"`
+	Staged          int    `json:"staged"`
+	Pages           int    `json:"pages"`
+	NextPageToken   string `json:"next_page_token"`
+	Done            bool   `json:"done"` // Every page was staged
+}
+
+// CompareStagedInput represents input for CompareStagedEntriesActivity
+type CompareStagedInput struct {
+	SyncID          string `json:"sync_id"`
+	ParticipantISPB string `json:"participant_ispb"`
+	KeyType         string `json:"key_type"`
+	MissingBacen    bool   `json:"missing_bacen"` // Also flag local entries Bacen did not return (FULL sync)
+}
+
+// CompareStagedResult represents the outcome of CompareStagedEntriesActivity
+type CompareStagedResult struct {
+	KeyType       string                     `json:"key_type"`
+	Discrepancies entities.DiscrepancyCounts `json:"discrepancies"` // Stored in vsync_discrepancies
+}
+
+// ApplyDiscrepanciesInput represents input for ApplyDiscrepanciesActivity
+type ApplyDiscrepanciesInput struct {
+	SyncID     string `json:"sync_id"`
+	AfterID    int64  `json:"after_id"` // Last discrepancy handled by the previous call
+	BatchSize  int    `json:"batch_size"`
+	MaxBatches int    `json:"max_batches"` // Bounds one activity; the workflow calls it again until Done
+}
+
+// ApplyDiscrepanciesResult represents the outcome of ApplyDiscrepanciesActivity. It is also the
+// heartbeat detail, so a retry resumes after the last handled discrepancy.
+type ApplyDiscrepanciesResult struct {
+	Created    int   `json:"created"`
+	Updated    int   `json:"updated"`
+	Registered int   `json:"registered"` // Registered at Bacen again
+	Escalated  int   `json:"escalated"`  // Sent to the review queue
+	Failed     int   `json:"failed"`     // Repair failed, sent to the review queue
+	LastID     int64 `json:"last_id"`
+	Done       bool  `json:"done"` // No pending discrepancy left after LastID
+}
+
+// RepairDiscrepancyInput represents input for RepairDiscrepancyActivity
+type RepairDiscrepancyInput struct {
+	DiscrepancyID int64  `json:"discrepancy_id"`
+	ReviewerID    string `json:"reviewer_id"` // Who approved the repair
+}
+
+// RepairDiscrepancyResult represents the outcome of RepairDiscrepancyActivity
+type RepairDiscrepancyResult struct {
+	DiscrepancyID int64  `json:"discrepancy_id"`
+	SyncReportID  string `json:"sync_report_id"`
+	Action        string `json:"action"`
+	Status        string `json:"status"` // APPLIED or FAILED
+	Error         string `json:"error,omitempty"`
+}
+
+// StageBacenEntriesActivity downloads the participant's entries from Bacen via the Bridge, page
+// by page, and stages each page in the VSYNC work table. Nothing proportional to the number of
+// keys is returned; the page token is heartbeated after every page, so a retry after a worker
+// crash resumes from there.
+//
+// Bacen cannot filter by update date, so an INCREMENTAL sync still walks every page and only
+// stages the entries updated after LastSyncDate. Entries of unknown key type and deleted entries
+// are not staged.
+func (a *VSyncActivities) StageBacenEntriesActivity(ctx context.Context, input StageBacenEntriesInput) (*StageBacenEntriesResult, error) {
+	if input.SyncID == "" || input.PageSize <= 0 || input.MaxPages <= 0 {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("sync_id, page_size and max_pages are required, got %q, %d and %d", input.SyncID, input.PageSize, input.MaxPages),
+			"InvalidInput",
+			nil,
+		)
+	}
+
+	ispb := input.ParticipantISPB
+	if ispb == "" {
+		ispb = a.participantISPB
+	}
+
+	result := &StageBacenEntriesResult{ParticipantISPB: ispb, NextPageToken: input.PageToken}
+	if activity.HasHeartbeatDetails(ctx) {
+		var progress StageBacenEntriesResult
+		if err := activity.GetHeartbeatDetails(ctx, &progress); err == nil {
+			result = &progress
+		}
+	}
+
+	for !result.Done && result.Pages < input.MaxPages {
+		resp, err := a.bridgeClient.SearchEntries(ctx, &bridgev1.SearchEntriesRequest{
+			Ispb:      &ispb,
+			PageSize:  int32(input.PageSize),
+			PageToken: result.NextPageToken,
+			RequestId: uuid.New().String(),
+		})
+		if err != nil {
+			return nil, searchEntriesError(err)
+		}
+
+		page := make([]*entities.Entry, 0, len(resp.Entries))
+		for _, protoEntry := range resp.Entries {
+			bacen := convertProtoEntryToBacenEntry(protoEntry)
+			if !isStageable(&bacen, input.LastSyncDate) {
+				continue
+			}
+			page = append(page, bacen.entry())
+		}
+
+		staged, err := a.vsyncRepo.StageEntries(ctx, input.SyncID, page)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Pages++
+		result.Fetched += len(resp.Entries)
+		result.Staged += staged
+		result.NextPageToken = resp.NextPageToken
+		result.Done = resp.NextPageToken == ""
+		activity.RecordHeartbeat(ctx, *result)
+	}
+
+	a.logger.WithFields(logrus.Fields{
+		"sync_id": input.SyncID,
+		"ispb":    ispb,
+		"pages":   result.Pages,
+		"fetched": result.Fetched,
+		"staged":  result.Staged,
+		"done":    result.Done,
+	}).Info("Staged entries from Bacen DICT")
+
+	return result, nil
+}
+
+// isStageable tells whether a Bacen entry takes part in the comparison
+func isStageable(bacen *BacenEntry, lastSyncDate *time.Time) bool {
+	switch entities.KeyType(bacen.KeyType) {
+	case entities.KeyTypeCPF, entities.KeyTypeCNPJ, entities.KeyTypeEMAIL, entities.KeyTypePHONE, entities.KeyTypeEVP:
+	default:
+		return false
+	}
+	if bacen.Status == "DELETED" {
+		return false
+	}
+	return lastSyncDate == nil || bacen.UpdatedAt.After(*lastSyncDate)
+}
+
+// searchEntriesError describes a failed Bridge SearchEntries call
+func searchEntriesError(err error) error {
+	switch status.Code(err) {
+	case codes.Unavailable:
+		return fmt.Errorf("bridge service unavailable (check if conn-bridge is running): %w", err)
+	case codes.Unauthenticated:
+		return fmt.Errorf("bridge authentication failed (mTLS cert issue): %w", err)
+	case codes.DeadlineExceeded:
+		return fmt.Errorf("bridge request timeout (Bacen may be slow): %w", err)
+	case codes.PermissionDenied:
+		return fmt.Errorf("bridge permission denied (check ISPB authorization): %w", err)
+	default:
+		return fmt.Errorf("bridge SearchEntries failed: %w", err)
+	}
+}
+
+// CompareStagedEntriesActivity compares the staged Bacen entries of one key type with the local
+// database in SQL and stores the discrepancies found. Running it again for the same sync and key
+// type adds nothing.
+func (a *VSyncActivities) CompareStagedEntriesActivity(ctx context.Context, input CompareStagedInput) (*CompareStagedResult, error) {
+	ispb := input.ParticipantISPB
+	if ispb == "" {
+		ispb = a.participantISPB
+	}
+
+	counts, err := a.vsyncRepo.CompareStaged(ctx, input.SyncID, ispb, entities.KeyType(input.KeyType), input.MissingBacen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare staged %s entries: %w", input.KeyType, err)
+	}
+
+	a.logger.WithFields(logrus.Fields{
+		"sync_id":        input.SyncID,
+		"key_type":       input.KeyType,
+		"missing_local":  counts.MissingLocal,
+		"outdated_local": counts.OutdatedLocal,
+		"missing_bacen":  counts.MissingBacen,
+	}).Info("Staged entries compared")
+
+	return &CompareStagedResult{KeyType: input.KeyType, Discrepancies: *counts}, nil
+}
+
+// ApplyDiscrepanciesActivity handles the pending discrepancies of a sync in ID order, batch by
+// batch, until none is left or MaxBatches is reached. The repair policy decides, per type and
+// age, what is done with each:
+//   - CREATE_LOCAL (MISSING_LOCAL): the entry is created with Bacen's data
+//   - UPDATE_LOCAL (OUTDATED_LOCAL): the entry is updated with Bacen's data, which gives it
+//     Bacen's CID
+//   - REGISTER_AT_BACEN (MISSING_BACEN): the local entry is registered at Bacen again; a local
+//     entry is never deleted
+//   - ESCALATE: the discrepancy goes to the review queue, assigned as the policy says
+//
+// A discrepancy that cannot be repaired keeps the error and goes to the review queue too; the
+// others go on. Every status change is audited with the sync report ID.
+func (a *VSyncActivities) ApplyDiscrepanciesActivity(ctx context.Context, input ApplyDiscrepanciesInput) (*ApplyDiscrepanciesResult, error) {
+	if input.SyncID == "" || input.BatchSize <= 0 || input.MaxBatches <= 0 {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("sync_id, batch_size and max_batches are required, got %q, %d and %d", input.SyncID, input.BatchSize, input.MaxBatches),
+			"InvalidInput",
+			nil,
+		)
+	}
+
+	result := &ApplyDiscrepanciesResult{LastID: input.AfterID}
+	if activity.HasHeartbeatDetails(ctx) {
+		var progress ApplyDiscrepanciesResult
+		if err := activity.GetHeartbeatDetails(ctx, &progress); err == nil {
+			result = &progress
+		}
+	}
+
+	for batches := 0; batches < input.MaxBatches; batches++ {
+		pending, err := a.vsyncRepo.ListPendingDiscrepancies(ctx, input.SyncID, result.LastID, input.BatchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, discrepancy := range pending {
+			if err := a.applyDiscrepancy(ctx, discrepancy, result); err != nil {
+				return nil, err
+			}
+			result.LastID = discrepancy.ID
+			activity.RecordHeartbeat(ctx, *result)
+		}
+
+		if len(pending) < input.BatchSize {
+			result.Done = true
+			break
+		}
+	}
+
+	a.logger.WithFields(logrus.Fields{
+		"sync_id":    input.SyncID,
+		"created":    result.Created,
+		"updated":    result.Updated,
+		"registered": result.Registered,
+		"escalated":  result.Escalated,
+		"failed":     result.Failed,
+		"last_id":    result.LastID,
+		"done":       result.Done,
+	}).Info("Discrepancies applied")
+
+	return result, nil
+}
+
+// applyDiscrepancy handles one discrepancy as the repair policy decides and records the outcome.
+// Only a failure to record it is returned.
+func (a *VSyncActivities) applyDiscrepancy(ctx context.Context, discrepancy *entities.SyncDiscrepancy, result *ApplyDiscrepanciesResult) error {
+	action := a.policy.Decide(discrepancy, time.Now())
+	if action == entities.RepairActionEscalate {
+		if err := discrepancy.Escalate(a.policy.EscalateTo, time.Now()); err != nil {
+			return err
+		}
+		if err := a.vsyncRepo.TransitionDiscrepancy(ctx, discrepancy, entities.DiscrepancyStatusPending, repositories.AuditActorWorkflow, discrepancy.SyncID); err != nil {
+			return err
+		}
+
+		a.logger.WithFields(logrus.Fields{
+			"discrepancy_id": discrepancy.ID,
+			"type":           discrepancy.Type,
+			"entry_id":       discrepancy.EntryID,
+			"assignee":       a.policy.EscalateTo,
+		}).Warn("Discrepancy escalated for review")
+		result.Escalated++
+		return nil
+	}
+
+	if err := a.repairDiscrepancy(ctx, discrepancy, action, discrepancy.SyncID); err != nil {
+		return err
+	}
+
+	switch {
+	case discrepancy.Status == entities.DiscrepancyStatusFailed:
+		result.Failed++
+	case action == entities.RepairActionCreateLocal:
+		result.Created++
+	case action == entities.RepairActionUpdateLocal:
+		result.Updated++
+	case action == entities.RepairActionRegisterAtBacen:
+		result.Registered++
+	}
+	return nil
+}
+
+// RepairDiscrepancyActivity runs the repair of a discrepancy approved by a reviewer and records
+// the outcome, audited as done on the reviewer's approval. A failed repair goes back to the
+// review queue and is not an activity error. A discrepancy already repaired is left as is, so
+// the activity can be retried.
+func (a *VSyncActivities) RepairDiscrepancyActivity(ctx context.Context, input RepairDiscrepancyInput) (*RepairDiscrepancyResult, error) {
+	discrepancy, err := a.vsyncRepo.GetDiscrepancy(ctx, input.DiscrepancyID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrDiscrepancyNotFound) {
+			return nil, temporal.NewNonRetryableApplicationError(err.Error(), "NotFound", err)
+		}
+		return nil, err
+	}
+
+	if discrepancy.Status == entities.DiscrepancyStatusApproved {
+		if err := a.repairDiscrepancy(ctx, discrepancy, discrepancy.Type.Repair(), input.ReviewerID); err != nil {
+			return nil, err
+		}
+	} else if discrepancy.Status != entities.DiscrepancyStatusApplied {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("discrepancy %d is %s, not APPROVED", discrepancy.ID, discrepancy.Status),
+			"InvalidState",
+			nil,
+		)
+	}
+
+	result := &RepairDiscrepancyResult{
+		DiscrepancyID: discrepancy.ID,
+		SyncReportID:  discrepancy.SyncReportID(),
+		Action:        string(discrepancy.Action),
+		Status:        string(discrepancy.Status),
+	}
+	if discrepancy.ApplyError != nil {
+		result.Error = *discrepancy.ApplyError
+	}
+
+	a.logger.WithFields(logrus.Fields{
+		"discrepancy_id": discrepancy.ID,
+		"sync_report_id": result.SyncReportID,
+		"reviewer_id":    input.ReviewerID,
+		"action":         result.Action,
+		"status":         result.Status,
+	}).Info("Approved discrepancy repaired")
+
+	return result, nil
+}
+
+// repairDiscrepancy runs a repair and records it as APPLIED or FAILED, audited under actorID.
+// Only a failure to record it is returned.
+func (a *VSyncActivities) repairDiscrepancy(ctx context.Context, discrepancy *entities.SyncDiscrepancy, action entities.RepairAction, actorID string) error {
+	from := discrepancy.Status
+
+	var err error
+	if repairErr := a.repair(ctx, discrepancy, action); repairErr != nil {
+		a.logger.WithError(repairErr).WithField("discrepancy_id", discrepancy.ID).Warn("Failed to repair discrepancy")
+		err = discrepancy.MarkFailed(action, repairErr.Error(), a.policy.EscalateTo, time.Now())
+	} else {
+		err = discrepancy.MarkApplied(action, time.Now())
+	}
+	if err != nil {
+		return err
+	}
+
+	return a.vsyncRepo.TransitionDiscrepancy(ctx, discrepancy, from, repositories.AuditActorWorkflow, actorID)
+}
+
+// repair brings the local entries back in line with Bacen for one discrepancy
+func (a *VSyncActivities) repair(ctx context.Context, discrepancy *entities.SyncDiscrepancy, action entities.RepairAction) error {
+	if action != discrepancy.Type.Repair() {
+		return fmt.Errorf("%s discrepancy %d cannot be repaired with %s", discrepancy.Type, discrepancy.ID, action)
+	}
+
+	switch {
+	case action == entities.RepairActionRegisterAtBacen && discrepancy.EntryID != nil:
+		return a.registerAtBacen(ctx, discrepancy)
+	case action == entities.RepairActionRegisterAtBacen:
+		return fmt.Errorf("discrepancy %d has no local entry", discrepancy.ID)
+	case discrepancy.BacenEntry == nil:
+		return fmt.Errorf("discrepancy %d has no Bacen entry", discrepancy.ID)
+	case action == entities.RepairActionCreateLocal:
+		return a.entryWriter.CreateEntryActivity(ctx, createEntryInput(discrepancy.BacenEntry))
+	case discrepancy.EntryID != nil:
+		return a.entryWriter.UpdateEntryActivity(ctx, *discrepancy.EntryID, updateEntryInput(discrepancy.BacenEntry))
+	default:
+		return fmt.Errorf("discrepancy %d has no local entry", discrepancy.ID)
+	}
+}
+
+// registerAtBacen registers a local entry missing in Bacen again, and keeps Bacen's ID and
+// ownership date (part of the CID) on the local entry. The discrepancy ID is the idempotency key.
+func (a *VSyncActivities) registerAtBacen(ctx context.Context, discrepancy *entities.SyncDiscrepancy) error {
+	entry, err := a.entryRepo.GetByEntryID(ctx, *discrepancy.EntryID)
+	if err != nil {
+		return fmt.Errorf("failed to get entry %s: %w", *discrepancy.EntryID, err)
+	}
+
+	account := &commonv1.Account{
+		Ispb:         entry.Participant,
+		AccountType:  claimAccountType(string(entry.AccountType)),
+		DocumentType: documentType(entry.OwnerType),
+	}
+	if entry.AccountBranch != nil {
+		account.BranchCode = *entry.AccountBranch
+	}
+	if entry.AccountNumber != nil {
+		account.AccountNumber = *entry.AccountNumber
+	}
+	if entry.OwnerName != nil {
+		account.AccountHolderName = *entry.OwnerName
+	}
+	if entry.OwnerTaxID != nil {
+		account.AccountHolderDocument = *entry.OwnerTaxID
+	}
+	if entry.AccountOpenedDate != nil {
+		account.OpeningDate = timestamppb.New(*entry.AccountOpenedDate)
+	}
+
+	// The RequestId keys the CID, so a retry replayed by the bridge must carry the same one
+	idempotencyKey := fmt.Sprintf("vsync-discrepancy-%d", discrepancy.ID)
+	requestID := uuid.NewSHA1(uuid.NameSpaceURL, []byte(idempotencyKey)).String()
+	resp, err := a.bridgeClient.CreateEntry(ctx, &bridgev1.CreateEntryRequest{
+		Key: &commonv1.DictKey{
+			KeyType:  claimKeyType(string(entry.KeyType)),
+			KeyValue: entry.Key,
+		},
+		Account:        account,
+		IdempotencyKey: idempotencyKey,
+		RequestId:      requestID,
+	})
+	if err != nil {
+		return fmt.Errorf("bridge CreateEntry failed: %w", err)
+	}
+
+	entry.BacenEntryID = &resp.ExternalId
+	entry.BacenRequestID = &requestID
+	if resp.CreatedAt != nil {
+		ownership := resp.CreatedAt.AsTime()
+		entry.KeyOwnershipDate = &ownership
+	}
+	entry.UpdatedAt = time.Now()
+	return a.entryRepo.Update(ctx, entry)
+}
+
+// documentType maps the owner type to the type of the owner's document
+func documentType(ownerType entities.OwnerType) commonv1.DocumentType {
+	switch ownerType {
+	case entities.OwnerTypeNaturalPerson:
+		return commonv1.DocumentType_DOCUMENT_TYPE_CPF
+	case entities.OwnerTypeLegalPerson:
+		return commonv1.DocumentType_DOCUMENT_TYPE_CNPJ
+	default:
+		return commonv1.DocumentType_DOCUMENT_TYPE_UNSPECIFIED
+	}
+}
+
+// DropStagedEntriesActivity drops the entries staged by a sync once it is compared
+func (a *VSyncActivities) DropStagedEntriesActivity(ctx context.Context, syncID string) error {
+	deleted, err := a.vsyncRepo.DeleteStaged(ctx, syncID)
+	if err != nil {
+		return err
+	}
+
+	a.logger.WithFields(logrus.Fields{
+		"sync_id": syncID,
+		"deleted": deleted,
+	}).Info("Staged entries dropped")
+
+	return nil
+}
+
+// createEntryInput creates a local entry from the entry held by Bacen
+func createEntryInput(bacen *entities.Entry) CreateEntryInput {
+	input := CreateEntryInput{
+		EntryID:           fmt.Sprintf("ENTRY-%s", uuid.New().String()[:8]),
+		Key:               bacen.Key,
+		KeyType:           string(bacen.KeyType),
+		ParticipantISPB:   bacen.Participant,
+		AccountType:       string(bacen.AccountType),
+		OwnerType:         string(bacen.OwnerType),
+		AccountOpenedDate: bacen.AccountOpenedDate,
+		KeyOwnershipDate:  bacen.KeyOwnershipDate,
+		BacenRequestID:    bacen.BacenRequestID,
+	}
+	if bacen.AccountBranch != nil {
+		input.AccountBranch = *bacen.AccountBranch
+	}
+	if bacen.AccountNumber != nil {
+		input.AccountNumber = *bacen.AccountNumber
+	}
+	if bacen.OwnerName != nil {
+		input.OwnerName = *bacen.OwnerName
+	}
+	if bacen.OwnerTaxID != nil {
+		input.OwnerTaxID = *bacen.OwnerTaxID
+	}
+	return input
+}
+
+// updateEntryInput takes every CID field from the entry held by Bacen, so the updated local entry
+// gets Bacen's CID
+func updateEntryInput(bacen *entities.Entry) UpdateEntryInput {
+	input := UpdateEntryInput{
+		AccountBranch:     bacen.AccountBranch,
+		AccountNumber:     bacen.AccountNumber,
+		OwnerName:         bacen.OwnerName,
+		OwnerTaxID:        bacen.OwnerTaxID,
+		AccountOpenedDate: bacen.AccountOpenedDate,
+		KeyOwnershipDate:  bacen.KeyOwnershipDate,
+		BacenRequestID:    bacen.BacenRequestID,
+	}
+	switch bacen.AccountType {
+	case entities.AccountTypeCACC, entities.AccountTypeSLRY, entities.AccountTypeSVGS, entities.AccountTypeTRAN:
+		accountType := string(bacen.AccountType)
+		input.AccountType = &accountType
+	}
+	return input
+}
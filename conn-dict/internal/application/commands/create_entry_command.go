@@ -174,6 +174,9 @@ func (h *CreateEntryHandler) validateCommand(cmd *CreateEntryCommand) error {
 		if taxIDLen != 11 && taxIDLen != 14 {
 			return fmt.Errorf("owner_tax_id must be 11 (CPF) or 14 (CNPJ) digits, got %d", taxIDLen)
 		}
+		if !entities.IsValidTaxID(*cmd.OwnerTaxID) {
+			return fmt.Errorf("owner_tax_id must be a CPF (11 digits) or CNPJ (12 alphanumeric characters + 2 check digits)")
+		}
 	}
 
 	return nil
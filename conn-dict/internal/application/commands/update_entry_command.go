@@ -137,6 +137,9 @@ func (h *UpdateEntryHandler) validateCommand(cmd *UpdateEntryCommand) error {
 		if taxIDLen != 11 && taxIDLen != 14 {
 			return fmt.Errorf("owner_tax_id must be 11 (CPF) or 14 (CNPJ) digits, got %d", taxIDLen)
 		}
+		if !entities.IsValidTaxID(*cmd.OwnerTaxID) {
+			return fmt.Errorf("owner_tax_id must be a CPF (11 digits) or CNPJ (12 alphanumeric characters + 2 check digits)")
+		}
 	}
 
 	return nil
@@ -68,17 +68,19 @@ type Entry struct {
 	// Owner information
 	OwnerType   OwnerType
 	OwnerName   *string
-	OwnerTaxID  *string // CPF (11) or CNPJ (14)
+	OwnerTaxID  *string // CPF (11) or CNPJ (14, numeric or alphanumeric)
 
 	// Status management
 	Status                   EntryStatus
 	ReasonForStatusChange    *string
 	BacenEntryID             *string
+	BacenRequestID           *string // RequestId of the last create/update Bacen accepted (CID key)
 
 	// Timestamps
-	RegisteredAt   *time.Time
-	ActivatedAt    *time.Time
-	DeactivatedAt  *time.Time
+	RegisteredAt     *time.Time
+	ActivatedAt      *time.Time
+	DeactivatedAt    *time.Time
+	KeyOwnershipDate *time.Time // Set by Bacen when the key is registered or changes owner (part of the CID)
 
 	// Audit
 	CreatedAt time.Time
@@ -234,6 +236,10 @@ func (e *Entry) UpdateOwnership(participant string, ownerName, ownerTaxID string
 		return fmt.Errorf("invalid tax ID length: must be 11 (CPF) or 14 (CNPJ), got %d", len(ownerTaxID))
 	}
 
+	if !IsValidTaxID(ownerTaxID) {
+		return errors.New("invalid tax ID format: must be a CPF (11 digits) or CNPJ (12 alphanumeric characters + 2 check digits)")
+	}
+
 	e.Participant = participant
 	e.OwnerName = &ownerName
 	e.OwnerTaxID = &ownerTaxID
@@ -262,13 +268,13 @@ func validateKeyFormat(key string, keyType KeyType) error {
 	switch keyType {
 	case KeyTypeCPF:
 		// CPF: 11 digits
-		if !regexp.MustCompile(`^\d{11}$`).MatchString(key) {
+		if !cpfFormatRegex.MatchString(key) {
 			return errors.New("CPF must be 11 digits")
 		}
 	case KeyTypeCNPJ:
-		// CNPJ: 14 digits
-		if !regexp.MustCompile(`^\d{14}$`).MatchString(key) {
-			return errors.New("CNPJ must be 14 digits")
+		// CNPJ: 14 digits, or 12 uppercase alphanumeric characters + 2 check digits
+		if !isValidCNPJFormat(key) {
+			return errors.New("CNPJ must be 14 digits or 12 alphanumeric characters followed by 2 check digits")
 		}
 	case KeyTypeEMAIL:
 		// Email: basic validation
@@ -0,0 +1,71 @@
+package entities
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// CID returns the content identifier of the entry: the hex HMAC-SHA256 of the fields participants
+// must keep in sync, joined by "&" in a fixed order, keyed by the RequestId of the last create or
+// update Bacen accepted for the key (CID = HMAC-SHA256(requestId, entryAttributes)).
+// Without that RequestId the CID cannot be computed and CID returns "".
+//
+// Bacen XORs the CIDs of a participant's keys of one type into the VSync value, so any field that
+// differs between the local entry and Bacen's shows up as a VSync mismatch. Values are encoded the
+// way the bridge sends them to Bacen: account types in the Bacen vocabulary, dates in RFC 3339 UTC
+// and an empty trade name (it is not stored).
+func (e *Entry) CID() string {
+	if e.BacenRequestID == nil || *e.BacenRequestID == "" {
+		return ""
+	}
+
+	fields := []string{
+		string(e.KeyType),
+		e.Key,
+		string(e.OwnerType),
+		strings.ToUpper(stringValue(e.OwnerTaxID)),
+		stringValue(e.OwnerName),
+		"", // Trade name
+		e.Participant,
+		stringValue(e.AccountBranch),
+		stringValue(e.AccountNumber),
+		cidAccountType(e.AccountType),
+		cidTime(e.AccountOpenedDate),
+		cidTime(e.KeyOwnershipDate),
+	}
+	mac := hmac.New(sha256.New, []byte(*e.BacenRequestID))
+	mac.Write([]byte(strings.Join(fields, "&")))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// cidAccountType maps the ISO account type to the Bacen vocabulary. Salary accounts have no
+// Bacen counterpart and are sent empty.
+func cidAccountType(accountType AccountType) string {
+	switch accountType {
+	case AccountTypeCACC:
+		return "CHECKING"
+	case AccountTypeSVGS:
+		return "SAVINGS"
+	case AccountTypeTRAN:
+		return "PAYMENT"
+	default:
+		return ""
+	}
+}
+
+func cidTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
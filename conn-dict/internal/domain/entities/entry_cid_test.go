@@ -0,0 +1,60 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntry_CID(t *testing.T) {
+	entry, err := NewEntry("ENTRY-001", "maria@example.com", KeyTypeEMAIL, "12345678", AccountTypeCACC, OwnerTypeNaturalPerson)
+	require.NoError(t, err)
+
+	branch, account, name, taxID := "0001", "1001", "Maria Silva", "12345678901"
+	requestID := "a946d533-7f22-42a5-9a9b-e87cd55c0f4d"
+	ownership := time.Date(2025, 10, 20, 5, 30, 0, 0, time.FixedZone("BRT", -3*60*60))
+	entry.AccountBranch = &branch
+	entry.AccountNumber = &account
+	entry.OwnerName = &name
+	entry.OwnerTaxID = &taxID
+	entry.KeyOwnershipDate = &ownership
+	assert.Empty(t, entry.CID(), "no CID before Bacen accepts the key")
+
+	// HMAC-SHA256 keyed by the RequestId of
+	// "EMAIL&maria@example.com&NATURAL_PERSON&12345678901&Maria Silva&&12345678&0001&1001&CHECKING&&2025-10-20T08:30:00Z"
+	// (key type, key, owner, trade name, account, opening date, ownership date)
+	entry.BacenRequestID = &requestID
+	cid := entry.CID()
+	assert.Equal(t, "87e2c4ad758546fc122841416d4eb8b06329750f13ccf48c580246f6a38d4c4a", cid)
+
+	// Local-only state does not enter the CID
+	require.NoError(t, entry.Block("court order"))
+	assert.Equal(t, cid, entry.CID())
+
+	// A synchronized field does
+	otherBranch := "0002"
+	entry.AccountBranch = &otherBranch
+	assert.NotEqual(t, cid, entry.CID())
+
+	// So does a new Bacen request over the same fields
+	entry.AccountBranch = &branch
+	otherRequestID := "0c1f5a52-4c0e-4d5c-9a77-3b3f6d0e2a10"
+	entry.BacenRequestID = &otherRequestID
+	assert.NotEqual(t, cid, entry.CID())
+}
+
+func TestEntry_CID_AlphanumericCNPJ(t *testing.T) {
+	entry, err := NewEntry("ENTRY-002", "12ABC34501DE35", KeyTypeCNPJ, "12345678", AccountTypeSLRY, OwnerTypeLegalPerson)
+	require.NoError(t, err)
+
+	upper, lower, requestID := "12ABC34501DE35", "12abc34501de35", "5b1d3f0e-2c4a-4e8b-8f6d-1a2b3c4d5e6f"
+	entry.BacenRequestID = &requestID
+	entry.OwnerTaxID = &upper
+	cid := entry.CID()
+
+	// Bacen receives the tax ID uppercased
+	entry.OwnerTaxID = &lower
+	assert.Equal(t, cid, entry.CID())
+}
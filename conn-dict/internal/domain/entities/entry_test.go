@@ -149,6 +149,53 @@ func TestNewEntry_InvalidKeyFormat_CNPJ_TooLong(t *testing.T) {
 	assert.Contains(t, err.Error(), "CNPJ must be 14 digits")
 }
 
+func TestNewEntry_ValidKeyFormat_CNPJ_Alphanumeric(t *testing.T) {
+	testCases := []struct {
+		name string
+		key  string
+	}{
+		{"Numeric", "11222333000181"},
+		{"Alphanumeric", "12ABC34501DE35"},
+		{"Letters only base", "ABCDEFGHIJKL80"},
+		{"Mixed", "1A2B3C4D000179"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			entry, err := NewEntry("ENTRY-CNPJ", tc.key, KeyTypeCNPJ, "12345678", AccountTypeCACC, OwnerTypeLegalPerson)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.NotNil(t, entry)
+			assert.Equal(t, tc.key, entry.Key)
+		})
+	}
+}
+
+func TestNewEntry_InvalidKeyFormat_CNPJ_Alphanumeric(t *testing.T) {
+	testCases := []struct {
+		name string
+		key  string
+	}{
+		{"Lowercase letters", "12abc34501de35"},
+		{"Letter in check digits", "12ABC34501DE3A"},
+		{"Special character", "12ABC345-1DE35"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			entry, err := NewEntry("ENTRY-CNPJ", tc.key, KeyTypeCNPJ, "12345678", AccountTypeCACC, OwnerTypeLegalPerson)
+
+			// Assert
+			assert.Error(t, err)
+			assert.Nil(t, entry)
+			assert.Contains(t, err.Error(), "CNPJ must be 14 digits or 12 alphanumeric characters")
+		})
+	}
+}
+
 func TestNewEntry_InvalidKeyFormat_Email_MissingAt(t *testing.T) {
 	// Arrange
 	entryID := "ENTRY-009"
@@ -651,6 +698,36 @@ func TestEntry_UpdateOwnership_ValidCNPJ(t *testing.T) {
 	assert.Equal(t, newOwnerTaxID, *entry.OwnerTaxID)
 }
 
+func TestEntry_UpdateOwnership_ValidAlphanumericCNPJ(t *testing.T) {
+	// Arrange
+	entry := createTestEntry(t)
+	newISPB := "87654321"
+	newOwnerName := "New Company Name"
+	newOwnerTaxID := "12ABC34501DE35" // Alphanumeric CNPJ
+
+	// Act
+	err := entry.UpdateOwnership(newISPB, newOwnerName, newOwnerTaxID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, newOwnerTaxID, *entry.OwnerTaxID)
+}
+
+func TestEntry_UpdateOwnership_InvalidTaxIDFormat(t *testing.T) {
+	// Arrange
+	entry := createTestEntry(t)
+	newISPB := "87654321"
+	newOwnerName := "New Owner Name"
+	newOwnerTaxID := "9876543210A" // 11 chars but not a CPF
+
+	// Act
+	err := entry.UpdateOwnership(newISPB, newOwnerName, newOwnerTaxID)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid tax ID format")
+}
+
 // =============================================================================
 // Helper Methods Tests
 // =============================================================================
@@ -837,6 +914,7 @@ func TestEntry_AllKeyTypes(t *testing.T) {
 	}{
 		{"CPF", "12345678901", KeyTypeCPF},
 		{"CNPJ", "12345678901234", KeyTypeCNPJ},
+		{"CNPJ alphanumeric", "12ABC34501DE35", KeyTypeCNPJ},
 		{"Email", "test@example.com", KeyTypeEMAIL},
 		{"Phone", "+5511987654321", KeyTypePHONE},
 		{"EVP", uuid.New().String(), KeyTypeEVP},
@@ -2,7 +2,24 @@ package entities
 
 import "regexp"
 
+var (
+	cpfFormatRegex = regexp.MustCompile(`^\d{11}$`)
+	// cnpjFormatRegex accepts both the numeric CNPJ and the alphanumeric CNPJ
+	// (IN RFB 2.229/2024): 12 uppercase alphanumeric positions + 2 numeric check digits
+	cnpjFormatRegex = regexp.MustCompile(`^[0-9A-Z]{12}\d{2}$`)
+)
+
 // isValidISPB checks if an ISPB (participant code) is valid (8 digits)
 func isValidISPB(ispb string) bool {
 	return regexp.MustCompile(`^\d{8}$`).MatchString(ispb)
 }
+
+// isValidCNPJFormat checks if a value is a numeric or alphanumeric CNPJ
+func isValidCNPJFormat(cnpj string) bool {
+	return cnpjFormatRegex.MatchString(cnpj)
+}
+
+// IsValidTaxID checks if a tax ID is a CPF (11 digits) or a CNPJ (numeric or alphanumeric)
+func IsValidTaxID(taxID string) bool {
+	return cpfFormatRegex.MatchString(taxID) || isValidCNPJFormat(taxID)
+}
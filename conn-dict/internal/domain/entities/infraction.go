@@ -54,6 +54,10 @@ type Infraction struct {
 	Status          InfractionStatus
 	ResolutionNotes *string
 
+	// Bacen infraction report
+	TransactionID *string // EndToEndId of the reported PIX transaction (required by Bacen)
+	BacenReportID *string // Bacen infraction report ID, set once reported
+
 	// Timestamps
 	ReportedAt     time.Time
 	InvestigatedAt *time.Time
@@ -176,6 +180,27 @@ func (i *Infraction) EscalateToBacen(notes string) error {
 	return nil
 }
 
+// MarkReportedToBacen records the ID of the infraction report created at Bacen
+func (i *Infraction) MarkReportedToBacen(reportID string) error {
+	if reportID == "" {
+		return errors.New("bacen report ID cannot be empty")
+	}
+
+	if i.BacenReportID != nil && *i.BacenReportID != reportID {
+		return fmt.Errorf("infraction already reported to Bacen as %s", *i.BacenReportID)
+	}
+
+	i.BacenReportID = &reportID
+	i.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// IsReportedToBacen checks if an infraction report was created at Bacen
+func (i *Infraction) IsReportedToBacen() bool {
+	return i.BacenReportID != nil
+}
+
 // AddEvidence adds an evidence URL to the infraction
 func (i *Infraction) AddEvidence(url string) error {
 	if url == "" {
@@ -662,4 +662,20 @@ func TestInfraction_ValidateStatusTransition_Invalid(t *testing.T) {
 			assert.Equal(t, tc.fromStatus, infraction.Status)
 		})
 	}
-}
\ No newline at end of file
+}
+func TestInfraction_MarkReportedToBacen(t *testing.T) {
+	// Arrange
+	infraction, err := NewInfraction("INF-001", "+5511999999999", InfractionTypeFraud, "Test", "12345678")
+	require.NoError(t, err)
+	assert.False(t, infraction.IsReportedToBacen())
+
+	// Act & Assert
+	assert.Error(t, infraction.MarkReportedToBacen(""))
+	require.NoError(t, infraction.MarkReportedToBacen("bacen-report-1"))
+	assert.True(t, infraction.IsReportedToBacen())
+	assert.Equal(t, "bacen-report-1", *infraction.BacenReportID)
+
+	// Same report ID is idempotent, a different one is rejected
+	assert.NoError(t, infraction.MarkReportedToBacen("bacen-report-1"))
+	assert.Error(t, infraction.MarkReportedToBacen("bacen-report-2"))
+}
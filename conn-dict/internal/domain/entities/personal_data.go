@@ -0,0 +1,25 @@
+package entities
+
+// PersonalDataRecord is a row holding personal data of a data subject (LGPD), as returned by
+// a data subject access request
+type PersonalDataRecord struct {
+	Table    string
+	RecordID string
+	Fields   map[string]interface{}
+}
+
+// ErasureOutcome is what a data subject erasure did with a record
+type ErasureOutcome string
+
+const (
+	ErasureOutcomePseudonymised ErasureOutcome = "PSEUDONYMISED" // Personal data removed, blind indexes kept
+	ErasureOutcomeRetained      ErasureOutcome = "RETAINED"      // Kept (active or within the legal retention period)
+)
+
+// ErasureAction reports the outcome of a data subject erasure for one record
+type ErasureAction struct {
+	Table    string
+	RecordID string
+	Outcome  ErasureOutcome
+	Reason   string // Why the record was retained
+}
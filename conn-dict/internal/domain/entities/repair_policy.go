@@ -0,0 +1,119 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RepairAction represents what is done with a discrepancy found by VSYNC
+type RepairAction string
+
+const (
+	RepairActionCreateLocal     RepairAction = "CREATE_LOCAL"      // Create the local entry from Bacen's
+	RepairActionUpdateLocal     RepairAction = "UPDATE_LOCAL"      // Update the local entry with Bacen's data
+	RepairActionRegisterAtBacen RepairAction = "REGISTER_AT_BACEN" // Register the local entry at Bacen again
+	RepairActionEscalate        RepairAction = "ESCALATE"          // Send to the review queue
+)
+
+// DefaultEscalationAssignee is the team escalated discrepancies are assigned to by default
+const DefaultEscalationAssignee = "dict-operations"
+
+// RepairRule applies an action to the discrepancies of a type whose diverging entry last changed
+// at least MinAge ago
+type RepairRule struct {
+	Type   DiscrepancyType `json:"type"`
+	MinAge time.Duration   `json:"min_age"`
+	Action RepairAction    `json:"action"`
+}
+
+// RepairPolicy decides, per discrepancy type and age, whether VSYNC repairs a discrepancy on its
+// own or escalates it to the review queue
+type RepairPolicy struct {
+	Rules      []RepairRule `json:"rules"`
+	EscalateTo string       `json:"escalate_to"` // Assignee of the escalated discrepancies
+}
+
+// DefaultRepairPolicy returns the policy used when none is configured:
+//   - MISSING_LOCAL: the entry is created from Bacen's
+//   - OUTDATED_LOCAL: the entry is updated with Bacen's data, unless it changed in the last hour
+//     (the change may not have reached Bacen yet), then it is escalated
+//   - MISSING_BACEN: escalated; the entry may be pending registration, and registering it
+//     again is left to a reviewer
+func DefaultRepairPolicy() RepairPolicy {
+	return RepairPolicy{
+		Rules: []RepairRule{
+			{Type: DiscrepancyTypeMissingLocal, Action: RepairActionCreateLocal},
+			{Type: DiscrepancyTypeOutdatedLocal, Action: RepairActionEscalate},
+			{Type: DiscrepancyTypeOutdatedLocal, MinAge: time.Hour, Action: RepairActionUpdateLocal},
+			{Type: DiscrepancyTypeMissingBacen, Action: RepairActionEscalate},
+		},
+		EscalateTo: DefaultEscalationAssignee,
+	}
+}
+
+// Validate checks that every rule repairs its type the only way it can be repaired, or escalates
+func (p RepairPolicy) Validate() error {
+	seen := make(map[RepairRule]bool, len(p.Rules))
+	for _, rule := range p.Rules {
+		repair := rule.Type.Repair()
+		if repair == RepairActionEscalate {
+			return fmt.Errorf("unknown discrepancy type: %s", rule.Type)
+		}
+		if rule.Action != repair && rule.Action != RepairActionEscalate {
+			return fmt.Errorf("%s discrepancies can only be repaired with %s or %s, got %s", rule.Type, repair, RepairActionEscalate, rule.Action)
+		}
+		if rule.MinAge < 0 {
+			return fmt.Errorf("min age of a %s rule cannot be negative, got %s", rule.Type, rule.MinAge)
+		}
+		key := RepairRule{Type: rule.Type, MinAge: rule.MinAge}
+		if seen[key] {
+			return fmt.Errorf("more than one %s rule from age %s", rule.Type, rule.MinAge)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// Decide returns the action of the rule of the discrepancy's type with the largest MinAge not
+// above the discrepancy's age. A discrepancy no rule matches is escalated.
+func (p RepairPolicy) Decide(discrepancy *SyncDiscrepancy, now time.Time) RepairAction {
+	age := discrepancy.Age(now)
+
+	action, matched := RepairActionEscalate, time.Duration(-1)
+	for _, rule := range p.Rules {
+		if rule.Type == discrepancy.Type && rule.MinAge <= age && rule.MinAge > matched {
+			action, matched = rule.Action, rule.MinAge
+		}
+	}
+	return action
+}
+
+// ParseRepairRules parses the rules of one discrepancy type from "ACTION[,AGE:ACTION...]": the
+// first action applies from age 0 and each AGE:ACTION from that age on, e.g.
+// "ESCALATE,1h:UPDATE_LOCAL". Ages use Go duration syntax.
+func ParseRepairRules(discrepancyType DiscrepancyType, spec string) ([]RepairRule, error) {
+	var rules []RepairRule
+	for i, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		rule := RepairRule{Type: discrepancyType, Action: RepairAction(part)}
+
+		if i > 0 {
+			age, action, ok := strings.Cut(part, ":")
+			if !ok {
+				return nil, fmt.Errorf("invalid %s rule %q: expected AGE:ACTION", discrepancyType, part)
+			}
+			minAge, err := time.ParseDuration(strings.TrimSpace(age))
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s rule %q: %w", discrepancyType, part, err)
+			}
+			rule.MinAge, rule.Action = minAge, RepairAction(strings.TrimSpace(action))
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := (RepairPolicy{Rules: rules}).Validate(); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
@@ -0,0 +1,94 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepairPolicy_Decide(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	changed := func(ago time.Duration) *time.Time {
+		at := now.Add(-ago)
+		return &at
+	}
+	policy := DefaultRepairPolicy()
+
+	tests := []struct {
+		name        string
+		discrepancy *SyncDiscrepancy
+		want        RepairAction
+	}{
+		{
+			name:        "missing locally is created",
+			discrepancy: &SyncDiscrepancy{Type: DiscrepancyTypeMissingLocal, BacenEntry: &Entry{UpdatedAt: now}},
+			want:        RepairActionCreateLocal,
+		},
+		{
+			name:        "outdated entry changed long ago is updated",
+			discrepancy: &SyncDiscrepancy{Type: DiscrepancyTypeOutdatedLocal, EntryUpdatedAt: changed(3 * time.Hour)},
+			want:        RepairActionUpdateLocal,
+		},
+		{
+			name:        "outdated entry changed recently is escalated",
+			discrepancy: &SyncDiscrepancy{Type: DiscrepancyTypeOutdatedLocal, EntryUpdatedAt: changed(10 * time.Minute)},
+			want:        RepairActionEscalate,
+		},
+		{
+			name:        "missing in bacen is escalated",
+			discrepancy: &SyncDiscrepancy{Type: DiscrepancyTypeMissingBacen, EntryUpdatedAt: changed(30 * 24 * time.Hour)},
+			want:        RepairActionEscalate,
+		},
+		{
+			name:        "unknown type is escalated",
+			discrepancy: &SyncDiscrepancy{Type: DiscrepancyType("UNKNOWN")},
+			want:        RepairActionEscalate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, policy.Decide(tt.discrepancy, now))
+		})
+	}
+}
+
+func TestParseRepairRules(t *testing.T) {
+	rules, err := ParseRepairRules(DiscrepancyTypeMissingBacen, "ESCALATE, 72h:REGISTER_AT_BACEN")
+	require.NoError(t, err)
+	assert.Equal(t, []RepairRule{
+		{Type: DiscrepancyTypeMissingBacen, Action: RepairActionEscalate},
+		{Type: DiscrepancyTypeMissingBacen, MinAge: 72 * time.Hour, Action: RepairActionRegisterAtBacen},
+	}, rules)
+
+	now := time.Now()
+	policy := RepairPolicy{Rules: rules}
+	young := &SyncDiscrepancy{Type: DiscrepancyTypeMissingBacen, EntryUpdatedAt: &now}
+	assert.Equal(t, RepairActionEscalate, policy.Decide(young, now))
+	assert.Equal(t, RepairActionRegisterAtBacen, policy.Decide(young, now.Add(96*time.Hour)))
+}
+
+func TestParseRepairRules_Invalid(t *testing.T) {
+	tests := []struct {
+		name            string
+		discrepancyType DiscrepancyType
+		spec            string
+	}{
+		{"action of another type", DiscrepancyTypeMissingLocal, "UPDATE_LOCAL"},
+		{"unknown action", DiscrepancyTypeOutdatedLocal, "DELETE_LOCAL"},
+		{"age without action", DiscrepancyTypeOutdatedLocal, "ESCALATE,1h"},
+		{"invalid age", DiscrepancyTypeOutdatedLocal, "ESCALATE,1d:UPDATE_LOCAL"},
+		{"negative age", DiscrepancyTypeOutdatedLocal, "ESCALATE,-1h:UPDATE_LOCAL"},
+		{"same age twice", DiscrepancyTypeOutdatedLocal, "ESCALATE,0s:UPDATE_LOCAL"},
+		{"unknown type", DiscrepancyType("UNKNOWN"), "ESCALATE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRepairRules(tt.discrepancyType, tt.spec)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DiscrepancyType represents how a local entry differs from Bacen's registry
+type DiscrepancyType string
+
+const (
+	DiscrepancyTypeMissingLocal  DiscrepancyType = "MISSING_LOCAL"  // Entry exists in Bacen but not locally
+	DiscrepancyTypeOutdatedLocal DiscrepancyType = "OUTDATED_LOCAL" // Entry exists but data differs
+	DiscrepancyTypeMissingBacen  DiscrepancyType = "MISSING_BACEN"  // Entry exists locally but not in Bacen
+)
+
+// Repair returns the repair that brings the local entries back in line with Bacen for this type
+// of discrepancy
+func (t DiscrepancyType) Repair() RepairAction {
+	switch t {
+	case DiscrepancyTypeMissingLocal:
+		return RepairActionCreateLocal
+	case DiscrepancyTypeOutdatedLocal:
+		return RepairActionUpdateLocal
+	case DiscrepancyTypeMissingBacen:
+		return RepairActionRegisterAtBacen
+	default:
+		return RepairActionEscalate
+	}
+}
+
+// DiscrepancyStatus represents where a discrepancy is in the repair and review flow
+type DiscrepancyStatus string
+
+const (
+	DiscrepancyStatusPending   DiscrepancyStatus = "PENDING"   // Found, not handled by the repair policy yet
+	DiscrepancyStatusEscalated DiscrepancyStatus = "ESCALATED" // Waiting in the review queue
+	DiscrepancyStatusApproved  DiscrepancyStatus = "APPROVED"  // Repair approved by a reviewer, running
+	DiscrepancyStatusApplied   DiscrepancyStatus = "APPLIED"   // Repaired
+	DiscrepancyStatusFailed    DiscrepancyStatus = "FAILED"    // Repair failed, back in the review queue
+	DiscrepancyStatusRejected  DiscrepancyStatus = "REJECTED"  // Reviewer decided to keep the local entries as they are
+)
+
+// ErrDiscrepancyNotInReview is returned when a discrepancy outside the review queue is approved
+// or rejected
+var ErrDiscrepancyNotInReview = errors.New("discrepancy is not waiting for review")
+
+// SyncDiscrepancy is a difference found by a VSYNC execution, stored in vsync_discrepancies and
+// handled by ID: repaired as the repair policy decides or escalated to the review queue
+type SyncDiscrepancy struct {
+	ID          int64
+	SyncID      string // Temporal workflow ID of the sync
+	Type        DiscrepancyType
+	Participant string
+	KeyType     KeyType
+	Key         string  // PIX key; only its blind index is stored
+	EntryID     *string // Local entry (OUTDATED_LOCAL, MISSING_BACEN)
+	BacenEntry  *Entry  // Entry as held by Bacen (MISSING_LOCAL, OUTDATED_LOCAL)
+	Reason      string
+
+	// EntryUpdatedAt is the last change of the local entry, read with the discrepancy
+	EntryUpdatedAt *time.Time
+
+	Status        DiscrepancyStatus
+	Action        RepairAction // Repair applied or decided by the policy
+	Assignee      *string      // Reviewer or team the escalated discrepancy is assigned to
+	ReviewedBy    *string
+	ReviewedAt    *time.Time
+	ReviewComment *string
+
+	AppliedAt  *time.Time
+	ApplyError *string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// SyncReportID returns the ID of the report of the sync that found the discrepancy
+func (d *SyncDiscrepancy) SyncReportID() string {
+	return SyncReportID(d.SyncID).String()
+}
+
+// Age returns how long ago the diverging entry last changed: the local entry when there is one,
+// else Bacen's. It is zero when neither is known.
+func (d *SyncDiscrepancy) Age(now time.Time) time.Duration {
+	switch {
+	case d.EntryUpdatedAt != nil:
+		return now.Sub(*d.EntryUpdatedAt)
+	case d.BacenEntry != nil && !d.BacenEntry.UpdatedAt.IsZero():
+		return now.Sub(d.BacenEntry.UpdatedAt)
+	default:
+		return 0
+	}
+}
+
+// InReview tells whether the discrepancy waits for a reviewer
+func (d *SyncDiscrepancy) InReview() bool {
+	return d.Status == DiscrepancyStatusEscalated || d.Status == DiscrepancyStatusFailed
+}
+
+// Escalate sends a pending discrepancy to the review queue
+func (d *SyncDiscrepancy) Escalate(assignee string, now time.Time) error {
+	if d.Status != DiscrepancyStatusPending {
+		return fmt.Errorf("discrepancy can only be escalated from PENDING status, got %s", d.Status)
+	}
+	d.Status = DiscrepancyStatusEscalated
+	d.Action = RepairActionEscalate
+	if assignee != "" {
+		d.Assignee = &assignee
+	}
+	d.UpdatedAt = now
+	return nil
+}
+
+// AssignedTo tells whether a reviewer may decide the discrepancy: the assignee itself or a
+// member of the assigned team
+func (d *SyncDiscrepancy) AssignedTo(reviewer string, teams []string) bool {
+	if d.Assignee == nil || reviewer == "" {
+		return false
+	}
+	if *d.Assignee == reviewer {
+		return true
+	}
+	for _, team := range teams {
+		if team == *d.Assignee {
+			return true
+		}
+	}
+	return false
+}
+
+// Approve accepts the repair of a discrepancy in the review queue; the repair is then run for
+// its type. Approving again a discrepancy already approved retries a repair that did not start.
+func (d *SyncDiscrepancy) Approve(reviewer, comment string, now time.Time) error {
+	if !d.InReview() && d.Status != DiscrepancyStatusApproved {
+		return fmt.Errorf("%w: %s", ErrDiscrepancyNotInReview, d.Status)
+	}
+	d.review(DiscrepancyStatusApproved, reviewer, comment, now)
+	d.Action = d.Type.Repair()
+	return nil
+}
+
+// Reject closes a discrepancy in the review queue without repairing it
+func (d *SyncDiscrepancy) Reject(reviewer, reason string, now time.Time) error {
+	if !d.InReview() {
+		return fmt.Errorf("%w: %s", ErrDiscrepancyNotInReview, d.Status)
+	}
+	d.review(DiscrepancyStatusRejected, reviewer, reason, now)
+	return nil
+}
+
+func (d *SyncDiscrepancy) review(status DiscrepancyStatus, reviewer, comment string, now time.Time) {
+	d.Status = status
+	d.ReviewedBy = &reviewer
+	d.ReviewedAt = &now
+	if comment != "" {
+		d.ReviewComment = &comment
+	} else {
+		d.ReviewComment = nil
+	}
+	d.UpdatedAt = now
+}
+
+// MarkApplied records a successful repair, decided by the policy or approved by a reviewer
+func (d *SyncDiscrepancy) MarkApplied(action RepairAction, now time.Time) error {
+	if d.Status != DiscrepancyStatusPending && d.Status != DiscrepancyStatusApproved {
+		return fmt.Errorf("discrepancy can only be applied from PENDING or APPROVED status, got %s", d.Status)
+	}
+	d.Status = DiscrepancyStatusApplied
+	d.Action = action
+	d.AppliedAt = &now
+	d.ApplyError = nil
+	d.UpdatedAt = now
+	return nil
+}
+
+// MarkFailed records a failed repair; the discrepancy goes to the review queue, assigned to
+// assignee unless it already has one
+func (d *SyncDiscrepancy) MarkFailed(action RepairAction, reason, assignee string, now time.Time) error {
+	if d.Status != DiscrepancyStatusPending && d.Status != DiscrepancyStatusApproved {
+		return fmt.Errorf("discrepancy can only fail from PENDING or APPROVED status, got %s", d.Status)
+	}
+	d.Status = DiscrepancyStatusFailed
+	d.Action = action
+	d.ApplyError = &reason
+	if d.Assignee == nil && assignee != "" {
+		d.Assignee = &assignee
+	}
+	d.UpdatedAt = now
+	return nil
+}
+
+// DiscrepancyCounts counts the discrepancies of a sync by type
+type DiscrepancyCounts struct {
+	MissingLocal  int `json:"missing_local"`
+	OutdatedLocal int `json:"outdated_local"`
+	MissingBacen  int `json:"missing_bacen"`
+}
+
+// Total returns the number of discrepancies of every type
+func (c DiscrepancyCounts) Total() int {
+	return c.MissingLocal + c.OutdatedLocal + c.MissingBacen
+}
+
+// Add counts one more discrepancy of the given type
+func (c *DiscrepancyCounts) Add(discrepancyType DiscrepancyType) {
+	switch discrepancyType {
+	case DiscrepancyTypeMissingLocal:
+		c.MissingLocal++
+	case DiscrepancyTypeOutdatedLocal:
+		c.OutdatedLocal++
+	case DiscrepancyTypeMissingBacen:
+		c.MissingBacen++
+	}
+}
@@ -0,0 +1,97 @@
+package entities
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscrepancyCounts_Add(t *testing.T) {
+	var counts DiscrepancyCounts
+	counts.Add(DiscrepancyTypeMissingLocal)
+	counts.Add(DiscrepancyTypeOutdatedLocal)
+	counts.Add(DiscrepancyTypeOutdatedLocal)
+	counts.Add(DiscrepancyTypeMissingBacen)
+	counts.Add(DiscrepancyType("UNKNOWN"))
+
+	assert.Equal(t, DiscrepancyCounts{MissingLocal: 1, OutdatedLocal: 2, MissingBacen: 1}, counts)
+	assert.Equal(t, 4, counts.Total())
+}
+
+// The Bacen entry of a discrepancy is stored as JSON; it must keep Bacen's CID
+func TestSyncDiscrepancy_BacenEntryKeepsCID(t *testing.T) {
+	entry, err := NewEntry("", "12345678901", KeyTypeCPF, "12345678", AccountTypeSVGS, OwnerTypeNaturalPerson)
+	require.NoError(t, err)
+
+	name, opened := "Maria Silva", time.Date(2020, 1, 15, 0, 0, 0, 0, time.FixedZone("BRT", -3*60*60))
+	requestID := "a946d533-7f22-42a5-9a9b-e87cd55c0f4d"
+	entry.BacenRequestID = &requestID
+	entry.OwnerName = &name
+	entry.OwnerTaxID = &entry.Key
+	entry.AccountOpenedDate = &opened
+
+	data, err := json.Marshal(entry)
+	require.NoError(t, err)
+
+	var stored Entry
+	require.NoError(t, json.Unmarshal(data, &stored))
+	assert.NotEmpty(t, stored.CID())
+	assert.Equal(t, entry.CID(), stored.CID())
+}
+
+func TestSyncDiscrepancy_Review(t *testing.T) {
+	now := time.Now()
+
+	discrepancy := &SyncDiscrepancy{Type: DiscrepancyTypeMissingBacen, Status: DiscrepancyStatusPending}
+	assert.Error(t, discrepancy.Approve("ana", "", now), "pending discrepancies are not in review")
+
+	require.NoError(t, discrepancy.Escalate(DefaultEscalationAssignee, now))
+	assert.True(t, discrepancy.InReview())
+	assert.Equal(t, RepairActionEscalate, discrepancy.Action)
+	assert.Equal(t, DefaultEscalationAssignee, *discrepancy.Assignee)
+
+	require.NoError(t, discrepancy.Approve("ana", "registration was lost", now))
+	assert.Equal(t, DiscrepancyStatusApproved, discrepancy.Status)
+	assert.Equal(t, RepairActionRegisterAtBacen, discrepancy.Action)
+	assert.Equal(t, "ana", *discrepancy.ReviewedBy)
+	assert.ErrorIs(t, discrepancy.Reject("ana", "changed my mind", now), ErrDiscrepancyNotInReview)
+
+	require.NoError(t, discrepancy.MarkFailed(RepairActionRegisterAtBacen, "bridge unavailable", "other-team", now))
+	assert.True(t, discrepancy.InReview(), "failed repairs go back to the review queue")
+	assert.Equal(t, DefaultEscalationAssignee, *discrepancy.Assignee, "the assignee is kept")
+
+	require.NoError(t, discrepancy.Reject("bruno", "key was deleted by the owner", now))
+	assert.Equal(t, DiscrepancyStatusRejected, discrepancy.Status)
+	assert.Equal(t, "key was deleted by the owner", *discrepancy.ReviewComment)
+	assert.ErrorIs(t, discrepancy.Approve("bruno", "", now), ErrDiscrepancyNotInReview)
+	assert.Error(t, discrepancy.MarkApplied(RepairActionRegisterAtBacen, now))
+}
+
+func TestSyncDiscrepancy_AssignedTo(t *testing.T) {
+	now := time.Now()
+
+	failed := &SyncDiscrepancy{Type: DiscrepancyTypeMissingLocal, Status: DiscrepancyStatusPending}
+	assert.False(t, failed.AssignedTo("ana", []string{DefaultEscalationAssignee}), "no assignee, nobody decides")
+
+	// A failed automatic repair goes to the policy's team
+	require.NoError(t, failed.MarkFailed(RepairActionCreateLocal, "bridge unavailable", DefaultEscalationAssignee, now))
+	assert.True(t, failed.AssignedTo("ana", []string{"auditors", DefaultEscalationAssignee}))
+	assert.False(t, failed.AssignedTo("ana", []string{"auditors"}))
+	assert.False(t, failed.AssignedTo("", []string{DefaultEscalationAssignee}))
+
+	personal := &SyncDiscrepancy{Type: DiscrepancyTypeMissingBacen, Status: DiscrepancyStatusPending}
+	require.NoError(t, personal.Escalate("bruno", now))
+	assert.True(t, personal.AssignedTo("bruno", nil))
+	assert.False(t, personal.AssignedTo("ana", []string{DefaultEscalationAssignee}))
+}
+
+func TestSyncDiscrepancy_SyncReportID(t *testing.T) {
+	report := NewSyncReport("vsync-full-20260310", SyncTypeFull, "12345678")
+	discrepancy := &SyncDiscrepancy{SyncID: "vsync-full-20260310"}
+
+	assert.Equal(t, report.ID.String(), discrepancy.SyncReportID())
+	assert.NotEqual(t, report.ID, SyncReportID("vsync-full-20260311"))
+}
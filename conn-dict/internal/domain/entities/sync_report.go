@@ -12,6 +12,7 @@ type SyncType string
 const (
 	SyncTypeFull        SyncType = "FULL"        // Complete sync of all entries
 	SyncTypeIncremental SyncType = "INCREMENTAL" // Only entries changed since last sync
+	SyncTypeCID         SyncType = "CID"         // VSync per key type, CID reconciliation only where it differs
 )
 
 // SyncStatus represents the outcome of a VSYNC execution
@@ -73,7 +74,7 @@ func NewSyncReport(
 ) *SyncReport {
 	now := time.Now()
 	return &SyncReport{
-		ID:              uuid.New(),
+		ID:              SyncReportID(syncID),
 		SyncID:          syncID,
 		SyncType:        syncType,
 		SyncTimestamp:   now,
@@ -85,6 +86,15 @@ func NewSyncReport(
 	}
 }
 
+// syncReportNamespace is the namespace of the sync report IDs
+var syncReportNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("lbpay-lab/conn-dict/sync_reports"))
+
+// SyncReportID returns the ID of the report of a sync. It is derived from the sync ID, so the
+// repairs done while the sync runs are audited with the ID of a report written at its end.
+func SyncReportID(syncID string) uuid.UUID {
+	return uuid.NewSHA1(syncReportNamespace, []byte(syncID))
+}
+
 // SetError marks the sync report as failed with error details
 func (r *SyncReport) SetError(errorMessage, errorCode string) {
 	r.Status = SyncStatusFailed
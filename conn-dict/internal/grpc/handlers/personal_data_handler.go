@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/lbpay-lab/conn-dict/internal/domain/entities"
+	connectv1 "github.com/lbpay-lab/dict-contracts/gen/proto/connect/v1"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PersonalDataRepository is the data subject request (LGPD) side of the conn-dict database
+type PersonalDataRepository interface {
+	CollectPersonalData(ctx context.Context, taxID string) ([]*entities.PersonalDataRecord, error)
+	ErasePersonalData(ctx context.Context, taxID string, retainAfter time.Time, dryRun bool) ([]*entities.ErasureAction, error)
+}
+
+// PersonalDataHandler serves the data subject requests of core-dict for ConnectService.
+// core-dict owns the request (identity check, bundle, audit); conn-dict only reads and
+// pseudonymises its own tables.
+type PersonalDataHandler struct {
+	personalDataRepo PersonalDataRepository
+	logger           *logrus.Logger
+	tracer           trace.Tracer
+}
+
+// NewPersonalDataHandler creates a new PersonalDataHandler
+func NewPersonalDataHandler(
+	personalDataRepo PersonalDataRepository,
+	logger *logrus.Logger,
+	tracer trace.Tracer,
+) *PersonalDataHandler {
+	return &PersonalDataHandler{
+		personalDataRepo: personalDataRepo,
+		logger:           logger,
+		tracer:           tracer,
+	}
+}
+
+// CollectPersonalData returns every conn-dict record tied to the tax ID
+func (h *PersonalDataHandler) CollectPersonalData(ctx context.Context, req *connectv1.CollectPersonalDataRequest) (*connectv1.CollectPersonalDataResponse, error) {
+	ctx, span := h.tracer.Start(ctx, "PersonalDataHandler.CollectPersonalData")
+	defer span.End()
+
+	// The tax ID is personal data and is never logged
+	h.logger.WithField("request_id", req.RequestId).Info("CollectPersonalData called")
+
+	if req.TaxId == "" {
+		return nil, status.Error(codes.InvalidArgument, "tax_id is required")
+	}
+
+	records, err := h.personalDataRepo.CollectPersonalData(ctx, req.TaxId)
+	if err != nil {
+		h.logger.WithError(err).WithField("request_id", req.RequestId).Error("Failed to collect personal data")
+		return nil, status.Error(codes.Internal, "failed to collect personal data")
+	}
+
+	resp := &connectv1.CollectPersonalDataResponse{
+		Records: make([]*connectv1.PersonalDataRecord, 0, len(records)),
+	}
+	for _, record := range records {
+		fields, err := json.Marshal(record.Fields)
+		if err != nil {
+			h.logger.WithError(err).WithField("record_id", record.RecordID).Error("Failed to encode personal data record")
+			return nil, status.Error(codes.Internal, "failed to encode personal data")
+		}
+		resp.Records = append(resp.Records, &connectv1.PersonalDataRecord{
+			Table:      record.Table,
+			RecordId:   record.RecordID,
+			FieldsJson: fields,
+		})
+	}
+
+	return resp, nil
+}
+
+// ErasePersonalData pseudonymises the subject's conn-dict records closed before retain_after
+func (h *PersonalDataHandler) ErasePersonalData(ctx context.Context, req *connectv1.ErasePersonalDataRequest) (*connectv1.ErasePersonalDataResponse, error) {
+	ctx, span := h.tracer.Start(ctx, "PersonalDataHandler.ErasePersonalData")
+	defer span.End()
+
+	h.logger.WithFields(logrus.Fields{
+		"request_id": req.RequestId,
+		"dry_run":    req.DryRun,
+	}).Info("ErasePersonalData called")
+
+	if req.TaxId == "" {
+		return nil, status.Error(codes.InvalidArgument, "tax_id is required")
+	}
+	if req.RetainAfter == nil {
+		return nil, status.Error(codes.InvalidArgument, "retain_after is required")
+	}
+
+	actions, err := h.personalDataRepo.ErasePersonalData(ctx, req.TaxId, req.RetainAfter.AsTime(), req.DryRun)
+	if err != nil {
+		h.logger.WithError(err).WithField("request_id", req.RequestId).Error("Failed to erase personal data")
+		return nil, status.Error(codes.Internal, "failed to erase personal data")
+	}
+
+	resp := &connectv1.ErasePersonalDataResponse{
+		Actions: make([]*connectv1.PersonalDataErasureAction, 0, len(actions)),
+	}
+	for _, action := range actions {
+		resp.Actions = append(resp.Actions, &connectv1.PersonalDataErasureAction{
+			Table:    action.Table,
+			RecordId: action.RecordID,
+			Outcome:  convertErasureOutcomeToProto(action.Outcome),
+			Reason:   action.Reason,
+		})
+	}
+
+	return resp, nil
+}
+
+func convertErasureOutcomeToProto(outcome entities.ErasureOutcome) connectv1.PersonalDataErasureOutcome {
+	switch outcome {
+	case entities.ErasureOutcomePseudonymised:
+		return connectv1.PersonalDataErasureOutcome_PERSONAL_DATA_ERASURE_OUTCOME_PSEUDONYMISED
+	case entities.ErasureOutcomeRetained:
+		return connectv1.PersonalDataErasureOutcome_PERSONAL_DATA_ERASURE_OUTCOME_RETAINED
+	default:
+		return connectv1.PersonalDataErasureOutcome_PERSONAL_DATA_ERASURE_OUTCOME_UNSPECIFIED
+	}
+}
@@ -4,7 +4,7 @@ import (
 	"context"
 	"fmt"
 
-	connectv1 "github.com/lbpay-lab/dict-contracts/gen/proto/conn_dict/v1"
+	connectv1 "github.com/lbpay-lab/dict-contracts/gen/proto/connect/v1"
 	commonv1 "github.com/lbpay-lab/dict-contracts/gen/proto/common/v1"
 	"github.com/lbpay-lab/conn-dict/internal/domain/entities"
 	"github.com/lbpay-lab/conn-dict/internal/infrastructure/cache"
@@ -0,0 +1,298 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lbpay-lab/conn-dict/internal/domain/entities"
+	"github.com/lbpay-lab/conn-dict/internal/grpc/interceptors"
+	"github.com/lbpay-lab/conn-dict/internal/infrastructure/repositories"
+	"github.com/lbpay-lab/conn-dict/internal/workflows"
+	connectv1 "github.com/lbpay-lab/dict-contracts/gen/proto/connect/v1"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+	"go.temporal.io/sdk/client"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultDiscrepancyPageSize = 100
+	maxDiscrepancyPageSize     = 1000
+)
+
+// VSyncReviewRepository is the subset of the VSYNC repository used by the review queue
+type VSyncReviewRepository interface {
+	GetDiscrepancy(ctx context.Context, id int64) (*entities.SyncDiscrepancy, error)
+	ListDiscrepancies(ctx context.Context, filter repositories.DiscrepancyFilter) ([]*entities.SyncDiscrepancy, error)
+	TransitionDiscrepancy(ctx context.Context, discrepancy *entities.SyncDiscrepancy, from entities.DiscrepancyStatus, actorType, actorID string) error
+}
+
+// VSyncReviewHandler handles the review queue of the VSYNC discrepancies for ConnectService.
+// Approving starts VSyncRepairWorkflow; approvals and rejections are audited with the reviewer
+// and the sync report ID. The reviewer is the caller authenticated by AuthInterceptor, and only
+// the discrepancy's assignee (or a member of the assigned team) may decide it.
+type VSyncReviewHandler struct {
+	vsyncRepo      VSyncReviewRepository
+	temporalClient client.Client
+	logger         *logrus.Logger
+	tracer         trace.Tracer
+}
+
+// NewVSyncReviewHandler creates a new VSyncReviewHandler
+func NewVSyncReviewHandler(
+	vsyncRepo VSyncReviewRepository,
+	temporalClient client.Client,
+	logger *logrus.Logger,
+	tracer trace.Tracer,
+) *VSyncReviewHandler {
+	return &VSyncReviewHandler{
+		vsyncRepo:      vsyncRepo,
+		temporalClient: temporalClient,
+		logger:         logger,
+		tracer:         tracer,
+	}
+}
+
+// ListSyncDiscrepancies lists discrepancies in ID order; without a status filter, the review
+// queue (ESCALATED and FAILED)
+func (h *VSyncReviewHandler) ListSyncDiscrepancies(ctx context.Context, req *connectv1.ListSyncDiscrepanciesRequest) (*connectv1.ListSyncDiscrepanciesResponse, error) {
+	ctx, span := h.tracer.Start(ctx, "VSyncReviewHandler.ListSyncDiscrepancies")
+	defer span.End()
+
+	h.logger.WithFields(logrus.Fields{
+		"statuses":  req.Statuses,
+		"assignee":  req.GetAssignee(),
+		"sync_id":   req.GetSyncId(),
+		"after_id":  req.AfterId,
+		"page_size": req.PageSize,
+	}).Info("ListSyncDiscrepancies called")
+
+	pageSize := int(req.PageSize)
+	if pageSize <= 0 {
+		pageSize = defaultDiscrepancyPageSize
+	}
+	if pageSize > maxDiscrepancyPageSize {
+		pageSize = maxDiscrepancyPageSize
+	}
+
+	filter := repositories.DiscrepancyFilter{
+		Assignee: req.GetAssignee(),
+		SyncID:   req.GetSyncId(),
+		AfterID:  req.AfterId,
+		Limit:    pageSize + 1, // One more tells whether there is a next page
+	}
+	for _, protoStatus := range req.Statuses {
+		discrepancyStatus, ok := convertDiscrepancyStatusFromProto(protoStatus)
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid status: %s", protoStatus)
+		}
+		filter.Statuses = append(filter.Statuses, discrepancyStatus)
+	}
+	if len(filter.Statuses) == 0 {
+		filter.Statuses = []entities.DiscrepancyStatus{entities.DiscrepancyStatusEscalated, entities.DiscrepancyStatusFailed}
+	}
+
+	discrepancies, err := h.vsyncRepo.ListDiscrepancies(ctx, filter)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to list discrepancies")
+		return nil, status.Error(codes.Internal, "failed to list discrepancies")
+	}
+
+	hasMore := len(discrepancies) > pageSize
+	if hasMore {
+		discrepancies = discrepancies[:pageSize]
+	}
+
+	resp := &connectv1.ListSyncDiscrepanciesResponse{
+		Discrepancies: make([]*connectv1.SyncDiscrepancy, 0, len(discrepancies)),
+		LastId:        req.AfterId,
+		HasMore:       hasMore,
+	}
+	for _, discrepancy := range discrepancies {
+		resp.Discrepancies = append(resp.Discrepancies, convertDiscrepancyToProto(discrepancy))
+		resp.LastId = discrepancy.ID
+	}
+
+	return resp, nil
+}
+
+// ApproveSyncDiscrepancy approves the repair of a discrepancy in the review queue and starts
+// its repair. When the repair cannot be started, the discrepancy stays APPROVED and can be
+// approved again.
+func (h *VSyncReviewHandler) ApproveSyncDiscrepancy(ctx context.Context, req *connectv1.ApproveSyncDiscrepancyRequest) (*connectv1.ApproveSyncDiscrepancyResponse, error) {
+	ctx, span := h.tracer.Start(ctx, "VSyncReviewHandler.ApproveSyncDiscrepancy")
+	defer span.End()
+
+	reviewer, ok := interceptors.CallerFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "reviewer is not authenticated")
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"discrepancy_id": req.DiscrepancyId,
+		"reviewer_id":    reviewer.Subject,
+	}).Info("ApproveSyncDiscrepancy called")
+
+	if req.DiscrepancyId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "discrepancy_id is required")
+	}
+
+	discrepancy, err := h.review(ctx, req.DiscrepancyId, reviewer, func(discrepancy *entities.SyncDiscrepancy) error {
+		return discrepancy.Approve(reviewer.Subject, req.Comment, time.Now())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	workflowID := workflows.VSyncRepairWorkflowID(discrepancy.ID)
+	workflowOptions := client.StartWorkflowOptions{
+		ID:                       workflowID,
+		TaskQueue:                "dict-task-queue",
+		WorkflowExecutionTimeout: 24 * time.Hour,
+	}
+	_, err = h.temporalClient.ExecuteWorkflow(ctx, workflowOptions, workflows.VSyncRepairWorkflow, workflows.VSyncRepairInput{
+		DiscrepancyID: discrepancy.ID,
+		ReviewerID:    reviewer.Subject,
+	})
+	if err != nil {
+		h.logger.WithError(err).WithField("discrepancy_id", discrepancy.ID).Error("Failed to start VSyncRepairWorkflow")
+		return nil, status.Errorf(codes.Unavailable, "discrepancy %d approved but its repair did not start, approve it again", discrepancy.ID)
+	}
+
+	return &connectv1.ApproveSyncDiscrepancyResponse{
+		Discrepancy: convertDiscrepancyToProto(discrepancy),
+		WorkflowId:  workflowID,
+		Message:     "Repair approved and started",
+	}, nil
+}
+
+// RejectSyncDiscrepancy closes a discrepancy in the review queue without repairing it
+func (h *VSyncReviewHandler) RejectSyncDiscrepancy(ctx context.Context, req *connectv1.RejectSyncDiscrepancyRequest) (*connectv1.RejectSyncDiscrepancyResponse, error) {
+	ctx, span := h.tracer.Start(ctx, "VSyncReviewHandler.RejectSyncDiscrepancy")
+	defer span.End()
+
+	reviewer, ok := interceptors.CallerFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "reviewer is not authenticated")
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"discrepancy_id": req.DiscrepancyId,
+		"reviewer_id":    reviewer.Subject,
+	}).Info("RejectSyncDiscrepancy called")
+
+	if req.DiscrepancyId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "discrepancy_id is required")
+	}
+	if req.Reason == "" {
+		return nil, status.Error(codes.InvalidArgument, "reason is required")
+	}
+
+	discrepancy, err := h.review(ctx, req.DiscrepancyId, reviewer, func(discrepancy *entities.SyncDiscrepancy) error {
+		return discrepancy.Reject(reviewer.Subject, req.Reason, time.Now())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &connectv1.RejectSyncDiscrepancyResponse{
+		Discrepancy: convertDiscrepancyToProto(discrepancy),
+		Message:     "Repair rejected, local entries kept as they are",
+	}, nil
+}
+
+// review applies a reviewer's decision to a discrepancy assigned to the reviewer and stores it,
+// audited under the reviewer
+func (h *VSyncReviewHandler) review(ctx context.Context, id int64, reviewer interceptors.Caller, decide func(*entities.SyncDiscrepancy) error) (*entities.SyncDiscrepancy, error) {
+	discrepancy, err := h.vsyncRepo.GetDiscrepancy(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrDiscrepancyNotFound) {
+			return nil, status.Errorf(codes.NotFound, "discrepancy not found: %d", id)
+		}
+		h.logger.WithError(err).Error("Failed to get discrepancy")
+		return nil, status.Error(codes.Internal, "failed to get discrepancy")
+	}
+
+	if !discrepancy.AssignedTo(reviewer.Subject, reviewer.Teams) {
+		return nil, status.Errorf(codes.PermissionDenied, "discrepancy %d is not assigned to %s", id, reviewer.Subject)
+	}
+
+	from := discrepancy.Status
+	if err := decide(discrepancy); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	if err := h.vsyncRepo.TransitionDiscrepancy(ctx, discrepancy, from, repositories.AuditActorUser, reviewer.Subject); err != nil {
+		if errors.Is(err, repositories.ErrDiscrepancyChanged) {
+			return nil, status.Errorf(codes.Aborted, "discrepancy %d was changed by someone else, reload it", id)
+		}
+		h.logger.WithError(err).Error("Failed to store review")
+		return nil, status.Error(codes.Internal, "failed to store review")
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"discrepancy_id": discrepancy.ID,
+		"sync_report_id": discrepancy.SyncReportID(),
+		"reviewer_id":    reviewer.Subject,
+		"status":         discrepancy.Status,
+	}).Info("Discrepancy reviewed")
+
+	return discrepancy, nil
+}
+
+// convertDiscrepancyToProto converts a domain SyncDiscrepancy to proto, with the key masked
+func convertDiscrepancyToProto(discrepancy *entities.SyncDiscrepancy) *connectv1.SyncDiscrepancy {
+	protoDiscrepancy := &connectv1.SyncDiscrepancy{
+		DiscrepancyId:   discrepancy.ID,
+		SyncId:          discrepancy.SyncID,
+		SyncReportId:    discrepancy.SyncReportID(),
+		DiscrepancyType: string(discrepancy.Type),
+		KeyType:         convertKeyTypeToProto(discrepancy.KeyType),
+		ParticipantIspb: discrepancy.Participant,
+		EntryId:         discrepancy.EntryID,
+		Status:          convertDiscrepancyStatusToProto(discrepancy.Status),
+		Action:          string(discrepancy.Action),
+		Assignee:        discrepancy.Assignee,
+		Reason:          discrepancy.Reason,
+		ApplyError:      discrepancy.ApplyError,
+		ReviewedBy:      discrepancy.ReviewedBy,
+		ReviewComment:   discrepancy.ReviewComment,
+		CreatedAt:       timestamppb.New(discrepancy.CreatedAt),
+	}
+	if discrepancy.Key != "" {
+		protoDiscrepancy.KeyValue = maskKey(discrepancy.Key)
+	}
+	if discrepancy.ReviewedAt != nil {
+		protoDiscrepancy.ReviewedAt = timestamppb.New(*discrepancy.ReviewedAt)
+	}
+	if discrepancy.AppliedAt != nil {
+		protoDiscrepancy.AppliedAt = timestamppb.New(*discrepancy.AppliedAt)
+	}
+	return protoDiscrepancy
+}
+
+// discrepancyStatuses maps the discrepancy statuses to proto
+var discrepancyStatuses = map[entities.DiscrepancyStatus]connectv1.SyncDiscrepancyStatus{
+	entities.DiscrepancyStatusPending:   connectv1.SyncDiscrepancyStatus_SYNC_DISCREPANCY_STATUS_PENDING,
+	entities.DiscrepancyStatusEscalated: connectv1.SyncDiscrepancyStatus_SYNC_DISCREPANCY_STATUS_ESCALATED,
+	entities.DiscrepancyStatusApproved:  connectv1.SyncDiscrepancyStatus_SYNC_DISCREPANCY_STATUS_APPROVED,
+	entities.DiscrepancyStatusApplied:   connectv1.SyncDiscrepancyStatus_SYNC_DISCREPANCY_STATUS_APPLIED,
+	entities.DiscrepancyStatusFailed:    connectv1.SyncDiscrepancyStatus_SYNC_DISCREPANCY_STATUS_FAILED,
+	entities.DiscrepancyStatusRejected:  connectv1.SyncDiscrepancyStatus_SYNC_DISCREPANCY_STATUS_REJECTED,
+}
+
+func convertDiscrepancyStatusToProto(discrepancyStatus entities.DiscrepancyStatus) connectv1.SyncDiscrepancyStatus {
+	return discrepancyStatuses[discrepancyStatus]
+}
+
+func convertDiscrepancyStatusFromProto(protoStatus connectv1.SyncDiscrepancyStatus) (entities.DiscrepancyStatus, bool) {
+	for discrepancyStatus, candidate := range discrepancyStatuses {
+		if candidate == protoStatus {
+			return discrepancyStatus, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,79 @@
+package interceptors
+
+import (
+	"context"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Caller is the authenticated identity of a request
+type Caller struct {
+	Subject string   // User ID (JWT "sub")
+	Teams   []string // Teams the user belongs to (JWT "groups")
+}
+
+type callerKey struct{}
+
+// callerClaims are the claims of the bearer tokens issued to operators
+type callerClaims struct {
+	Groups []string `json:"groups"`
+	jwt.RegisteredClaims
+}
+
+// CallerFromContext returns the caller authenticated by AuthInterceptor
+func CallerFromContext(ctx context.Context) (Caller, bool) {
+	caller, ok := ctx.Value(callerKey{}).(Caller)
+	return caller, ok
+}
+
+// AuthInterceptor creates a gRPC unary server interceptor that requires, on the given methods,
+// a bearer JWT (HS256, signed with secret, with "sub" and "exp") and puts its Caller in the
+// context. Other methods (service-to-service calls from core-dict) pass through unchanged.
+// With an empty secret, every call to the given methods is rejected.
+func AuthInterceptor(secret []byte, methods ...string) grpc.UnaryServerInterceptor {
+	protected := make(map[string]bool, len(methods))
+	for _, method := range methods {
+		protected[method] = true
+	}
+
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if !protected[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		if len(secret) == 0 {
+			return nil, status.Error(codes.Unauthenticated, "authentication is not configured")
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get("authorization")
+		if len(values) == 0 || !strings.HasPrefix(values[0], "Bearer ") {
+			return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+		}
+
+		var claims callerClaims
+		_, err := jwt.ParseWithClaims(strings.TrimPrefix(values[0], "Bearer "), &claims,
+			func(*jwt.Token) (interface{}, error) { return secret, nil },
+			jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+			jwt.WithExpirationRequired(),
+		)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "invalid bearer token")
+		}
+		if claims.Subject == "" {
+			return nil, status.Error(codes.Unauthenticated, "bearer token has no subject")
+		}
+
+		ctx = context.WithValue(ctx, callerKey{}, Caller{Subject: claims.Subject, Teams: claims.Groups})
+		return handler(ctx, req)
+	}
+}
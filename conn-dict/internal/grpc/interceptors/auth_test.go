@@ -0,0 +1,77 @@
+package interceptors
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const protectedMethod = "/dict.connect.v1.ConnectService/ApproveSyncDiscrepancy"
+
+func signToken(t *testing.T, secret string, claims jwt.Claims) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	require.NoError(t, err)
+	return token
+}
+
+func callWithToken(secret []byte, method, token string) (Caller, bool, error) {
+	ctx := context.Background()
+	if token != "" {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+token))
+	}
+
+	var caller Caller
+	var found bool
+	_, err := AuthInterceptor(secret, protectedMethod)(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method},
+		func(ctx context.Context, _ interface{}) (interface{}, error) {
+			caller, found = CallerFromContext(ctx)
+			return nil, nil
+		})
+	return caller, found, err
+}
+
+func TestAuthInterceptor(t *testing.T) {
+	secret := []byte("test-secret")
+	valid := signToken(t, "test-secret", callerClaims{
+		Groups: []string{"dict-operations"},
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "ana",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	})
+
+	caller, found, err := callWithToken(secret, protectedMethod, valid)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, Caller{Subject: "ana", Teams: []string{"dict-operations"}}, caller)
+
+	// Other methods are not authenticated
+	_, found, err = callWithToken(secret, "/dict.connect.v1.ConnectService/GetEntry", "")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	rejected := map[string]string{
+		"no token":       "",
+		"wrong secret":   signToken(t, "other-secret", jwt.RegisteredClaims{Subject: "ana", ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))}),
+		"expired":        signToken(t, "test-secret", jwt.RegisteredClaims{Subject: "ana", ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Minute))}),
+		"no expiration":  signToken(t, "test-secret", jwt.RegisteredClaims{Subject: "ana"}),
+		"no subject":     signToken(t, "test-secret", jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))}),
+		"not a jwt":      "not-a-jwt",
+		"unsigned token": "eyJhbGciOiJub25lIn0.eyJzdWIiOiJhbmEifQ.",
+	}
+	for name, token := range rejected {
+		_, _, err := callWithToken(secret, protectedMethod, token)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err), name)
+	}
+
+	_, _, err = callWithToken(nil, protectedMethod, valid)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "no secret configured")
+}
@@ -6,10 +6,10 @@ import (
 	"net"
 	"time"
 
-	bridgev1 "github.com/lbpay-lab/dict-contracts/gen/proto/bridge/v1"
-	connectv1 "github.com/lbpay-lab/dict-contracts/gen/proto/conn_dict/v1"
 	"github.com/lbpay-lab/conn-dict/internal/grpc/handlers"
 	"github.com/lbpay-lab/conn-dict/internal/grpc/interceptors"
+	bridgev1 "github.com/lbpay-lab/dict-contracts/gen/proto/bridge/v1"
+	connectv1 "github.com/lbpay-lab/dict-contracts/gen/proto/connect/v1"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
@@ -20,25 +20,31 @@ import (
 
 // Server implements the Connect gRPC server
 type Server struct {
-	logger            *logrus.Logger
-	grpcServer        *grpc.Server
-	port              int
-	entryHandler      *handlers.EntryHandler
-	claimHandler      *handlers.ClaimHandler
-	infractionHandler *handlers.InfractionHandler
-	queryHandler      *handlers.QueryHandler
-	healthServer      *health.Server
-	devMode           bool
+	logger              *logrus.Logger
+	grpcServer          *grpc.Server
+	port                int
+	entryHandler        *handlers.EntryHandler
+	claimHandler        *handlers.ClaimHandler
+	infractionHandler   *handlers.InfractionHandler
+	queryHandler        *handlers.QueryHandler
+	vsyncHandler        *handlers.VSyncReviewHandler
+	personalDataHandler *handlers.PersonalDataHandler
+	healthServer        *health.Server
+	devMode             bool
+	reviewerJWTSecret   []byte
 }
 
 // ServerConfig holds configuration for the gRPC server
 type ServerConfig struct {
-	Port              int
-	DevMode           bool
-	EntryHandler      *handlers.EntryHandler
-	ClaimHandler      *handlers.ClaimHandler
-	InfractionHandler *handlers.InfractionHandler
-	QueryHandler      *handlers.QueryHandler
+	Port                int
+	DevMode             bool
+	EntryHandler        *handlers.EntryHandler
+	ClaimHandler        *handlers.ClaimHandler
+	InfractionHandler   *handlers.InfractionHandler
+	QueryHandler        *handlers.QueryHandler
+	VSyncHandler        *handlers.VSyncReviewHandler
+	PersonalDataHandler *handlers.PersonalDataHandler
+	ReviewerJWTSecret   []byte // Verifies the operator tokens of the VSYNC review calls
 }
 
 // NewServer creates a new Connect gRPC server instance
@@ -48,13 +54,16 @@ func NewServer(logger *logrus.Logger, config *ServerConfig) *Server {
 	}
 
 	return &Server{
-		logger:            logger,
-		port:              config.Port,
-		entryHandler:      config.EntryHandler,
-		claimHandler:      config.ClaimHandler,
-		infractionHandler: config.InfractionHandler,
-		queryHandler:      config.QueryHandler,
-		devMode:           config.DevMode,
+		logger:              logger,
+		port:                config.Port,
+		entryHandler:        config.EntryHandler,
+		claimHandler:        config.ClaimHandler,
+		infractionHandler:   config.InfractionHandler,
+		queryHandler:        config.QueryHandler,
+		vsyncHandler:        config.VSyncHandler,
+		personalDataHandler: config.PersonalDataHandler,
+		devMode:             config.DevMode,
+		reviewerJWTSecret:   config.ReviewerJWTSecret,
 	}
 }
 
@@ -66,13 +75,17 @@ func (s *Server) Start(ctx context.Context) error {
 	}
 
 	// Create gRPC server with chained interceptors
-	// Order matters: Recovery → Logging → Tracing → Metrics
+	// Order matters: Recovery → Logging → Tracing → Metrics → Auth
 	s.grpcServer = grpc.NewServer(
 		grpc.ChainUnaryInterceptor(
-			interceptors.RecoveryInterceptor(s.logger),      // Panic recovery (first - catch all panics)
-			interceptors.LoggingInterceptor(s.logger),       // Request logging
-			interceptors.TracingInterceptor("conn-dict"),    // OpenTelemetry tracing
-			interceptors.MetricsInterceptor(),               // Prometheus metrics (last)
+			interceptors.RecoveryInterceptor(s.logger),   // Panic recovery (first - catch all panics)
+			interceptors.LoggingInterceptor(s.logger),    // Request logging
+			interceptors.TracingInterceptor("conn-dict"), // OpenTelemetry tracing
+			interceptors.MetricsInterceptor(),            // Prometheus metrics
+			interceptors.AuthInterceptor(s.reviewerJWTSecret, // Reviewer identity of the VSYNC decisions
+				connectv1.ConnectService_ApproveSyncDiscrepancy_FullMethodName,
+				connectv1.ConnectService_RejectSyncDiscrepancy_FullMethodName,
+			),
 		),
 	)
 
@@ -84,11 +97,13 @@ func (s *Server) Start(ctx context.Context) error {
 	// Register ConnectService with all handlers
 	// This service exposes Entry/Claim/Infraction operations to core-dict
 	connectv1.RegisterConnectServiceServer(s.grpcServer, &connectServiceServer{
-		entryHandler:      s.entryHandler,
-		claimHandler:      s.claimHandler,
-		infractionHandler: s.infractionHandler,
-		queryHandler:      s.queryHandler,
-		logger:            s.logger,
+		entryHandler:        s.entryHandler,
+		claimHandler:        s.claimHandler,
+		infractionHandler:   s.infractionHandler,
+		queryHandler:        s.queryHandler,
+		vsyncHandler:        s.vsyncHandler,
+		personalDataHandler: s.personalDataHandler,
+		logger:              s.logger,
 	})
 	s.logger.Info("Registered ConnectService with all handlers")
 
@@ -157,11 +172,13 @@ func (s *Server) Stop() {
 // connectServiceServer implements ConnectService by delegating to handlers
 type connectServiceServer struct {
 	connectv1.UnimplementedConnectServiceServer
-	entryHandler      *handlers.EntryHandler
-	claimHandler      *handlers.ClaimHandler
-	infractionHandler *handlers.InfractionHandler
-	queryHandler      *handlers.QueryHandler
-	logger            *logrus.Logger
+	entryHandler        *handlers.EntryHandler
+	claimHandler        *handlers.ClaimHandler
+	infractionHandler   *handlers.InfractionHandler
+	queryHandler        *handlers.QueryHandler
+	vsyncHandler        *handlers.VSyncReviewHandler
+	personalDataHandler *handlers.PersonalDataHandler
+	logger              *logrus.Logger
 }
 
 // Entry Operations (delegated to QueryHandler)
@@ -267,6 +284,28 @@ func (s *connectServiceServer) ListInfractions(ctx context.Context, req *connect
 	return resp.(*connectv1.ListInfractionsResponse), nil
 }
 
+// VSync Review Queue (delegated to VSyncReviewHandler)
+func (s *connectServiceServer) ListSyncDiscrepancies(ctx context.Context, req *connectv1.ListSyncDiscrepanciesRequest) (*connectv1.ListSyncDiscrepanciesResponse, error) {
+	return s.vsyncHandler.ListSyncDiscrepancies(ctx, req)
+}
+
+func (s *connectServiceServer) ApproveSyncDiscrepancy(ctx context.Context, req *connectv1.ApproveSyncDiscrepancyRequest) (*connectv1.ApproveSyncDiscrepancyResponse, error) {
+	return s.vsyncHandler.ApproveSyncDiscrepancy(ctx, req)
+}
+
+func (s *connectServiceServer) RejectSyncDiscrepancy(ctx context.Context, req *connectv1.RejectSyncDiscrepancyRequest) (*connectv1.RejectSyncDiscrepancyResponse, error) {
+	return s.vsyncHandler.RejectSyncDiscrepancy(ctx, req)
+}
+
+// Personal Data / LGPD (delegated to PersonalDataHandler)
+func (s *connectServiceServer) CollectPersonalData(ctx context.Context, req *connectv1.CollectPersonalDataRequest) (*connectv1.CollectPersonalDataResponse, error) {
+	return s.personalDataHandler.CollectPersonalData(ctx, req)
+}
+
+func (s *connectServiceServer) ErasePersonalData(ctx context.Context, req *connectv1.ErasePersonalDataRequest) (*connectv1.ErasePersonalDataResponse, error) {
+	return s.personalDataHandler.ErasePersonalData(ctx, req)
+}
+
 // Health Check
 func (s *connectServiceServer) HealthCheck(ctx context.Context, req *emptypb.Empty) (*connectv1.HealthCheckResponse, error) {
 	return &connectv1.HealthCheckResponse{
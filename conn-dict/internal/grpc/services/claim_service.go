@@ -116,6 +116,8 @@ func (s *ClaimService) CreateClaim(ctx context.Context, req interface{}) (interf
 	}
 
 	// Extract optional fields
+	key := getStringOrEmpty(reqMap, "key")
+	keyType := getStringOrEmpty(reqMap, "key_type")
 	claimerAccount := getStringOrEmpty(reqMap, "claimer_account")
 	requestedBy := getStringOrEmpty(reqMap, "requested_by")
 
@@ -132,19 +134,21 @@ func (s *ClaimService) CreateClaim(ctx context.Context, req interface{}) (interf
 		ClaimID:        claimID,
 		EntryID:        entryID,
 		ClaimType:      claimType,
+		Key:            key,
+		KeyType:        keyType,
 		ClaimerISPB:    claimerISPB,
 		DonorISPB:      donorISPB,
 		ClaimerAccount: claimerAccount,
 		RequestedBy:    requestedBy,
 	}
 
-	// Start Temporal workflow for 30-day claim processing
+	// Start Temporal workflow for the claim lifecycle (resolution + completion periods)
 	workflowID := fmt.Sprintf("claim-workflow-%s", claimID)
 	workflowOptions := client.StartWorkflowOptions{
 		ID:        workflowID,
 		TaskQueue: "dict-claims-queue",
-		// Workflow timeout: 31 days (30 days + buffer)
-		WorkflowExecutionTimeout: 31 * 24 * time.Hour,
+		// Workflow timeout: both periods + 1 day buffer
+		WorkflowExecutionTimeout: workflows.DefaultClaimResolutionPeriod + workflows.DefaultClaimCompletionPeriod + 24*time.Hour,
 	}
 
 	we, err := s.temporalClient.ExecuteWorkflow(ctx, workflowOptions, workflows.ClaimWorkflow, workflowInput)
@@ -157,8 +161,8 @@ func (s *ClaimService) CreateClaim(ctx context.Context, req interface{}) (interf
 		return nil, status.Errorf(codes.Internal, "failed to start claim workflow: %v", err)
 	}
 
-	// Calculate expiration date (30 days from now)
-	expiresAt := time.Now().Add(30 * 24 * time.Hour)
+	// Calculate the end of the resolution period
+	expiresAt := time.Now().Add(workflows.DefaultClaimResolutionPeriod)
 
 	s.logger.WithFields(logrus.Fields{
 		"claim_id":     claimID,
@@ -182,7 +186,7 @@ func (s *ClaimService) CreateClaim(ctx context.Context, req interface{}) (interf
 		"claimer_ispb": claimerISPB,
 		"donor_ispb":   donorISPB,
 		"expires_at":   expiresAt.Format(time.RFC3339),
-		"message":      "Claim created successfully. Donor has 30 days to respond.",
+		"message":      "Claim created successfully. Donor has 7 days to respond.",
 	}, nil
 }
 
@@ -243,7 +247,7 @@ func (s *ClaimService) ConfirmClaim(ctx context.Context, req interface{}) (inter
 		"confirmed_at": time.Now().Format(time.RFC3339),
 	}
 
-	err = s.temporalClient.SignalWorkflow(ctx, workflowID, runID, "confirm", signalData)
+	err = s.temporalClient.SignalWorkflow(ctx, workflowID, runID, workflows.ClaimSignalConfirm, signalData)
 	if err != nil {
 		s.logger.WithError(err).WithFields(logrus.Fields{
 			"claim_id":    claimID,
@@ -261,7 +265,82 @@ func (s *ClaimService) ConfirmClaim(ctx context.Context, req interface{}) (inter
 	return map[string]interface{}{
 		"claim_id": claimID,
 		"status":   "CONFIRMED",
-		"message":  "Claim confirmation signal sent successfully. Workflow will complete the claim.",
+		"message":  "Claim confirmation signal sent successfully. The claimer can now complete the claim.",
+	}, nil
+}
+
+// CompleteClaim handles claim completion by sending a Signal to the Temporal workflow
+// This is called by the claimer after the claim was confirmed, within the completion period
+//
+// Request should contain:
+// - claim_id: Claim identifier
+// - completed_by: User/system completing the claim
+//
+// Returns:
+// - claim_id: Claim identifier
+// - status: Updated status ("COMPLETED")
+// - message: Completion message
+//
+// Error codes:
+// - InvalidArgument: Missing or invalid claim_id
+// - NotFound: Claim not found
+// - FailedPrecondition: Claim is not confirmed
+// - Internal: Failed to signal workflow
+func (s *ClaimService) CompleteClaim(ctx context.Context, req interface{}) (interface{}, error) {
+	s.logger.Info("CompleteClaim called")
+
+	reqMap, ok := req.(map[string]interface{})
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "invalid request format")
+	}
+
+	claimID, ok := reqMap["claim_id"].(string)
+	if !ok || claimID == "" {
+		return nil, status.Error(codes.InvalidArgument, "claim_id is required")
+	}
+
+	completedBy := getStringOrEmpty(reqMap, "completed_by")
+
+	// Verify claim exists
+	claim, err := s.claimRepo.GetByClaimID(ctx, claimID)
+	if err != nil {
+		s.logger.WithError(err).WithField("claim_id", claimID).Error("Failed to get claim")
+		return nil, status.Errorf(codes.NotFound, "claim not found: %s", claimID)
+	}
+
+	// Only confirmed claims can be completed
+	if claim.Status != entities.ClaimStatusConfirmed {
+		return nil, status.Errorf(codes.FailedPrecondition, "claim cannot be completed in status %s", claim.Status)
+	}
+
+	// Send "complete" signal to Temporal workflow
+	workflowID := fmt.Sprintf("claim-workflow-%s", claimID)
+	runID := "" // Empty string means "latest run"
+
+	signalData := map[string]interface{}{
+		"completed_by": completedBy,
+		"completed_at": time.Now().Format(time.RFC3339),
+	}
+
+	err = s.temporalClient.SignalWorkflow(ctx, workflowID, runID, workflows.ClaimSignalComplete, signalData)
+	if err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"claim_id":    claimID,
+			"workflow_id": workflowID,
+		}).Error("Failed to signal ClaimWorkflow for completion")
+		return nil, status.Errorf(codes.Internal, "failed to complete claim: %v", err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"claim_id":     claimID,
+		"workflow_id":  workflowID,
+		"completed_by": completedBy,
+	}).Info("Claim completion signal sent successfully")
+
+	return map[string]interface{}{
+		"claim_id": claimID,
+		"status":   "COMPLETED",
+		"message":  "Claim completion signal sent successfully. Workflow will complete the claim at Bacen.",
 	}, nil
 }
 
@@ -325,7 +404,7 @@ func (s *ClaimService) CancelClaim(ctx context.Context, req interface{}) (interf
 		"cancelled_at": time.Now().Format(time.RFC3339),
 	}
 
-	err = s.temporalClient.SignalWorkflow(ctx, workflowID, runID, "cancel", signalData)
+	err = s.temporalClient.SignalWorkflow(ctx, workflowID, runID, workflows.ClaimSignalCancel, signalData)
 	if err != nil {
 		s.logger.WithError(err).WithFields(logrus.Fields{
 			"claim_id":    claimID,
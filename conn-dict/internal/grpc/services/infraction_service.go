@@ -135,6 +135,7 @@ func (s *InfractionService) CreateInfraction(ctx context.Context, req interface{
 
 	relatedEntryID := getStringOrEmpty(reqMap, "related_entry_id")
 	relatedClaimID := getStringOrEmpty(reqMap, "related_claim_id")
+	transactionID := getStringOrEmpty(reqMap, "transaction_id")
 
 	// Extract evidence URLs (array)
 	var evidenceURLs []string
@@ -157,6 +158,7 @@ func (s *InfractionService) CreateInfraction(ctx context.Context, req interface{
 		EvidenceURLs:        evidenceURLs,
 		RelatedEntryID:      relatedEntryID,
 		RelatedClaimID:      relatedClaimID,
+		TransactionID:       transactionID,
 	}
 
 	// Start Temporal workflow for async infraction investigation
@@ -189,3 +189,340 @@ func (c *BridgeClient) SearchEntries(ctx context.Context, req *bridgev1.SearchEn
 
 	return resp, nil
 }
+
+// CreateClaim calls Bridge to open a portability or ownership claim at Bacen
+func (c *BridgeClient) CreateClaim(ctx context.Context, req *bridgev1.CreateClaimRequest) (*bridgev1.CreateClaimResponse, error) {
+	ctx, span := c.tracer.Start(ctx, "BridgeClient.CreateClaim")
+	defer span.End()
+
+	c.logger.WithFields(logrus.Fields{
+		"entry_id":        req.EntryId,
+		"claimer_ispb":    req.ClaimerIspb,
+		"owner_ispb":      req.OwnerIspb,
+		"idempotency_key": req.IdempotencyKey,
+		"request_id":      req.RequestId,
+	}).Debug("Calling Bridge CreateClaim")
+
+	resp, err := c.client.CreateClaim(ctx, req)
+	if err != nil {
+		c.logger.WithError(err).Error("Bridge CreateClaim failed")
+		return nil, fmt.Errorf("bridge CreateClaim failed: %w", err)
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"claim_id":    resp.ClaimId,
+		"external_id": resp.ExternalId,
+		"status":      resp.Status,
+	}).Info("Bridge CreateClaim succeeded")
+
+	return resp, nil
+}
+
+// ConfirmClaim calls Bridge to confirm a claim at Bacen
+func (c *BridgeClient) ConfirmClaim(ctx context.Context, req *bridgev1.ConfirmClaimRequest) (*bridgev1.ConfirmClaimResponse, error) {
+	ctx, span := c.tracer.Start(ctx, "BridgeClient.ConfirmClaim")
+	defer span.End()
+
+	c.logger.WithFields(logrus.Fields{
+		"claim_id":            req.ClaimId,
+		"external_id":         req.ExternalId,
+		"confirmation_reason": req.ConfirmationReason,
+		"request_id":          req.RequestId,
+	}).Debug("Calling Bridge ConfirmClaim")
+
+	resp, err := c.client.ConfirmClaim(ctx, req)
+	if err != nil {
+		c.logger.WithError(err).Error("Bridge ConfirmClaim failed")
+		return nil, fmt.Errorf("bridge ConfirmClaim failed: %w", err)
+	}
+
+	c.logger.WithField("status", resp.Status).Info("Bridge ConfirmClaim succeeded")
+
+	return resp, nil
+}
+
+// CompleteClaim calls Bridge to complete a confirmed claim at Bacen, moving the key to the claimer
+func (c *BridgeClient) CompleteClaim(ctx context.Context, req *bridgev1.CompleteClaimRequest) (*bridgev1.CompleteClaimResponse, error) {
+	ctx, span := c.tracer.Start(ctx, "BridgeClient.CompleteClaim")
+	defer span.End()
+
+	c.logger.WithFields(logrus.Fields{
+		"claim_id":    req.ClaimId,
+		"external_id": req.ExternalId,
+		"request_id":  req.RequestId,
+	}).Debug("Calling Bridge CompleteClaim")
+
+	resp, err := c.client.CompleteClaim(ctx, req)
+	if err != nil {
+		c.logger.WithError(err).Error("Bridge CompleteClaim failed")
+		return nil, fmt.Errorf("bridge CompleteClaim failed: %w", err)
+	}
+
+	c.logger.WithField("status", resp.Status).Info("Bridge CompleteClaim succeeded")
+
+	return resp, nil
+}
+
+// CancelClaim calls Bridge to cancel a claim at Bacen
+func (c *BridgeClient) CancelClaim(ctx context.Context, req *bridgev1.CancelClaimRequest) (*bridgev1.CancelClaimResponse, error) {
+	ctx, span := c.tracer.Start(ctx, "BridgeClient.CancelClaim")
+	defer span.End()
+
+	c.logger.WithFields(logrus.Fields{
+		"claim_id":            req.ClaimId,
+		"external_id":         req.ExternalId,
+		"cancellation_reason": req.CancellationReason,
+		"request_id":          req.RequestId,
+	}).Debug("Calling Bridge CancelClaim")
+
+	resp, err := c.client.CancelClaim(ctx, req)
+	if err != nil {
+		c.logger.WithError(err).Error("Bridge CancelClaim failed")
+		return nil, fmt.Errorf("bridge CancelClaim failed: %w", err)
+	}
+
+	c.logger.WithField("status", resp.Status).Info("Bridge CancelClaim succeeded")
+
+	return resp, nil
+}
+
+// ListClaims calls Bridge to list the claims where the participant is donor and/or claimer
+func (c *BridgeClient) ListClaims(ctx context.Context, req *bridgev1.ListClaimsRequest) (*bridgev1.ListClaimsResponse, error) {
+	ctx, span := c.tracer.Start(ctx, "BridgeClient.ListClaims")
+	defer span.End()
+
+	c.logger.WithFields(logrus.Fields{
+		"participant_ispb": req.ParticipantIspb,
+		"is_donor":         req.GetIsDonor(),
+		"is_claimer":       req.GetIsClaimer(),
+		"page_size":        req.PageSize,
+		"page_token":       req.PageToken,
+		"request_id":       req.RequestId,
+	}).Debug("Calling Bridge ListClaims")
+
+	resp, err := c.client.ListClaims(ctx, req)
+	if err != nil {
+		c.logger.WithError(err).Error("Bridge ListClaims failed")
+		return nil, fmt.Errorf("bridge ListClaims failed: %w", err)
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"claims_count":    len(resp.Claims),
+		"next_page_token": resp.NextPageToken,
+	}).Info("Bridge ListClaims succeeded")
+
+	return resp, nil
+}
+
+// CreateInfractionReport calls Bridge to report a suspicious transaction to Bacen
+func (c *BridgeClient) CreateInfractionReport(ctx context.Context, req *bridgev1.CreateInfractionReportRequest) (*bridgev1.CreateInfractionReportResponse, error) {
+	ctx, span := c.tracer.Start(ctx, "BridgeClient.CreateInfractionReport")
+	defer span.End()
+
+	c.logger.WithFields(logrus.Fields{
+		"participant_ispb": req.ParticipantIspb,
+		"transaction_id":   req.TransactionId,
+		"reason":           req.Reason,
+		"idempotency_key":  req.IdempotencyKey,
+		"request_id":       req.RequestId,
+	}).Debug("Calling Bridge CreateInfractionReport")
+
+	resp, err := c.client.CreateInfractionReport(ctx, req)
+	if err != nil {
+		c.logger.WithError(err).Error("Bridge CreateInfractionReport failed")
+		return nil, fmt.Errorf("bridge CreateInfractionReport failed: %w", err)
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"infraction_report_id": resp.InfractionReport.GetInfractionReportId(),
+		"status":               resp.InfractionReport.GetStatus(),
+	}).Info("Bridge CreateInfractionReport succeeded")
+
+	return resp, nil
+}
+
+// GetInfractionReport calls Bridge to get an infraction report from Bacen
+func (c *BridgeClient) GetInfractionReport(ctx context.Context, req *bridgev1.GetInfractionReportRequest) (*bridgev1.GetInfractionReportResponse, error) {
+	ctx, span := c.tracer.Start(ctx, "BridgeClient.GetInfractionReport")
+	defer span.End()
+
+	c.logger.WithFields(logrus.Fields{
+		"infraction_report_id": req.InfractionReportId,
+		"request_id":           req.RequestId,
+	}).Debug("Calling Bridge GetInfractionReport")
+
+	resp, err := c.client.GetInfractionReport(ctx, req)
+	if err != nil {
+		c.logger.WithError(err).Error("Bridge GetInfractionReport failed")
+		return nil, fmt.Errorf("bridge GetInfractionReport failed: %w", err)
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"found":  resp.Found,
+		"status": resp.InfractionReport.GetStatus(),
+	}).Info("Bridge GetInfractionReport succeeded")
+
+	return resp, nil
+}
+
+// ListInfractionReports calls Bridge to list the infraction reports of a participant
+func (c *BridgeClient) ListInfractionReports(ctx context.Context, req *bridgev1.ListInfractionReportsRequest) (*bridgev1.ListInfractionReportsResponse, error) {
+	ctx, span := c.tracer.Start(ctx, "BridgeClient.ListInfractionReports")
+	defer span.End()
+
+	c.logger.WithFields(logrus.Fields{
+		"participant_ispb": req.ParticipantIspb,
+		"page_size":        req.PageSize,
+		"page_token":       req.PageToken,
+		"request_id":       req.RequestId,
+	}).Debug("Calling Bridge ListInfractionReports")
+
+	resp, err := c.client.ListInfractionReports(ctx, req)
+	if err != nil {
+		c.logger.WithError(err).Error("Bridge ListInfractionReports failed")
+		return nil, fmt.Errorf("bridge ListInfractionReports failed: %w", err)
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"reports_count":   len(resp.InfractionReports),
+		"next_page_token": resp.NextPageToken,
+	}).Info("Bridge ListInfractionReports succeeded")
+
+	return resp, nil
+}
+
+// AcknowledgeInfractionReport calls Bridge to acknowledge an infraction report received from Bacen
+func (c *BridgeClient) AcknowledgeInfractionReport(ctx context.Context, req *bridgev1.AcknowledgeInfractionReportRequest) (*bridgev1.AcknowledgeInfractionReportResponse, error) {
+	ctx, span := c.tracer.Start(ctx, "BridgeClient.AcknowledgeInfractionReport")
+	defer span.End()
+
+	c.logger.WithFields(logrus.Fields{
+		"infraction_report_id": req.InfractionReportId,
+		"participant_ispb":     req.ParticipantIspb,
+		"request_id":           req.RequestId,
+	}).Debug("Calling Bridge AcknowledgeInfractionReport")
+
+	resp, err := c.client.AcknowledgeInfractionReport(ctx, req)
+	if err != nil {
+		c.logger.WithError(err).Error("Bridge AcknowledgeInfractionReport failed")
+		return nil, fmt.Errorf("bridge AcknowledgeInfractionReport failed: %w", err)
+	}
+
+	c.logger.WithField("status", resp.InfractionReport.GetStatus()).Info("Bridge AcknowledgeInfractionReport succeeded")
+
+	return resp, nil
+}
+
+// CloseInfractionReport calls Bridge to close an infraction report with the analysis result
+func (c *BridgeClient) CloseInfractionReport(ctx context.Context, req *bridgev1.CloseInfractionReportRequest) (*bridgev1.CloseInfractionReportResponse, error) {
+	ctx, span := c.tracer.Start(ctx, "BridgeClient.CloseInfractionReport")
+	defer span.End()
+
+	c.logger.WithFields(logrus.Fields{
+		"infraction_report_id": req.InfractionReportId,
+		"participant_ispb":     req.ParticipantIspb,
+		"analysis_result":      req.AnalysisResult,
+		"request_id":           req.RequestId,
+	}).Debug("Calling Bridge CloseInfractionReport")
+
+	resp, err := c.client.CloseInfractionReport(ctx, req)
+	if err != nil {
+		c.logger.WithError(err).Error("Bridge CloseInfractionReport failed")
+		return nil, fmt.Errorf("bridge CloseInfractionReport failed: %w", err)
+	}
+
+	c.logger.WithField("status", resp.InfractionReport.GetStatus()).Info("Bridge CloseInfractionReport succeeded")
+
+	return resp, nil
+}
+
+// CancelInfractionReport calls Bridge to cancel an infraction report created by the participant
+func (c *BridgeClient) CancelInfractionReport(ctx context.Context, req *bridgev1.CancelInfractionReportRequest) (*bridgev1.CancelInfractionReportResponse, error) {
+	ctx, span := c.tracer.Start(ctx, "BridgeClient.CancelInfractionReport")
+	defer span.End()
+
+	c.logger.WithFields(logrus.Fields{
+		"infraction_report_id": req.InfractionReportId,
+		"participant_ispb":     req.ParticipantIspb,
+		"request_id":           req.RequestId,
+	}).Debug("Calling Bridge CancelInfractionReport")
+
+	resp, err := c.client.CancelInfractionReport(ctx, req)
+	if err != nil {
+		c.logger.WithError(err).Error("Bridge CancelInfractionReport failed")
+		return nil, fmt.Errorf("bridge CancelInfractionReport failed: %w", err)
+	}
+
+	c.logger.WithField("status", resp.InfractionReport.GetStatus()).Info("Bridge CancelInfractionReport succeeded")
+
+	return resp, nil
+}
+
+// VerifySync calls Bridge to get the VSync value Bacen computed for the participant's keys of one type
+func (c *BridgeClient) VerifySync(ctx context.Context, req *bridgev1.VerifySyncRequest) (*bridgev1.VerifySyncResponse, error) {
+	ctx, span := c.tracer.Start(ctx, "BridgeClient.VerifySync")
+	defer span.End()
+
+	c.logger.WithFields(logrus.Fields{
+		"participant_ispb": req.ParticipantIspb,
+		"key_type":         req.KeyType,
+		"request_id":       req.RequestId,
+	}).Debug("Calling Bridge VerifySync")
+
+	resp, err := c.client.VerifySync(ctx, req)
+	if err != nil {
+		c.logger.WithError(err).Error("Bridge VerifySync failed")
+		return nil, fmt.Errorf("bridge VerifySync failed: %w", err)
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"key_type":    resp.KeyType,
+		"entry_count": resp.EntryCount,
+	}).Info("Bridge VerifySync succeeded")
+
+	return resp, nil
+}
+
+// ListCids calls Bridge to list the CIDs of the participant's keys of one type, sorted
+func (c *BridgeClient) ListCids(ctx context.Context, req *bridgev1.ListCidsRequest) (*bridgev1.ListCidsResponse, error) {
+	ctx, span := c.tracer.Start(ctx, "BridgeClient.ListCids")
+	defer span.End()
+
+	c.logger.WithFields(logrus.Fields{
+		"participant_ispb": req.ParticipantIspb,
+		"key_type":         req.KeyType,
+		"request_id":       req.RequestId,
+	}).Debug("Calling Bridge ListCids")
+
+	resp, err := c.client.ListCids(ctx, req)
+	if err != nil {
+		c.logger.WithError(err).Error("Bridge ListCids failed")
+		return nil, fmt.Errorf("bridge ListCids failed: %w", err)
+	}
+
+	c.logger.WithField("cids_count", len(resp.Cids)).Info("Bridge ListCids succeeded")
+
+	return resp, nil
+}
+
+// GetEntryByCid calls Bridge to get the key Bacen holds under a CID
+func (c *BridgeClient) GetEntryByCid(ctx context.Context, req *bridgev1.GetEntryByCidRequest) (*bridgev1.GetEntryByCidResponse, error) {
+	ctx, span := c.tracer.Start(ctx, "BridgeClient.GetEntryByCid")
+	defer span.End()
+
+	c.logger.WithFields(logrus.Fields{
+		"participant_ispb": req.ParticipantIspb,
+		"cid":              req.Cid,
+		"request_id":       req.RequestId,
+	}).Debug("Calling Bridge GetEntryByCid")
+
+	resp, err := c.client.GetEntryByCid(ctx, req)
+	if err != nil {
+		c.logger.WithError(err).Error("Bridge GetEntryByCid failed")
+		return nil, fmt.Errorf("bridge GetEntryByCid failed: %w", err)
+	}
+
+	c.logger.WithField("entry_id", resp.Entry.GetEntryId()).Info("Bridge GetEntryByCid succeeded")
+
+	return resp, nil
+}
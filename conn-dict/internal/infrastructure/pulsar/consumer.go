@@ -9,93 +9,70 @@ import (
 
 	"github.com/apache/pulsar-client-go/pulsar"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/lbpay-lab/conn-dict/internal/domain/entities"
 	"github.com/lbpay-lab/conn-dict/internal/infrastructure/repositories"
+	"github.com/lbpay-lab/dict-contracts/events"
 	bridgepb "github.com/lbpay-lab/dict-contracts/gen/proto/bridge/v1"
 	commonpb "github.com/lbpay-lab/dict-contracts/gen/proto/common/v1"
+	connectv1 "github.com/lbpay-lab/dict-contracts/gen/proto/connect/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// StatusEventPublisher publishes the protobuf events consumed by core-dict
+// (dict.entries.status.changed); implemented by Producer
+type StatusEventPublisher interface {
+	PublishProto(ctx context.Context, event proto.Message, key string) error
+}
+
 // Consumer wraps Pulsar consumer for processing Entry events asynchronously
 // This consumer handles fast operations (< 2s) that don't require Temporal workflows
 type Consumer struct {
-	client        pulsar.Client
-	consumers     []pulsar.Consumer
-	entryRepo     *repositories.EntryRepository
-	bridgeClient  bridgepb.BridgeServiceClient
-	logger        *logrus.Logger
-	wg            sync.WaitGroup
-	stopChan      chan struct{}
-	config        ConsumerConfig
+	client       pulsar.Client
+	consumers    []pulsar.Consumer
+	entryRepo    *repositories.EntryRepository
+	bridgeClient bridgepb.BridgeServiceClient
+	statusEvents StatusEventPublisher
+	logger       *logrus.Logger
+	wg           sync.WaitGroup
+	stopChan     chan struct{}
+	config       ConsumerConfig
 }
 
 // ConsumerConfig holds configuration for Pulsar consumer
 type ConsumerConfig struct {
-	URL                     string
-	Subscription            string
-	TopicEntryCreated       string
-	TopicEntryUpdated       string
-	TopicEntryDeletedImmed  string
-	MaxReconnectToBroker    uint
-	ConnectionTimeout       time.Duration
-	OperationTimeout        time.Duration
-	AckTimeout              time.Duration
-	NackRedeliveryDelay     time.Duration
-	MaxDeliveryAttempts     int
-}
-
-// EntryCreatedEvent represents a Pulsar event for entry creation
-type EntryCreatedEvent struct {
-	EntryID           string    `json:"entry_id"`
-	Key               string    `json:"key"`
-	KeyType           string    `json:"key_type"`
-	Participant       string    `json:"participant"`
-	AccountBranch     *string   `json:"account_branch,omitempty"`
-	AccountNumber     *string   `json:"account_number,omitempty"`
-	AccountType       string    `json:"account_type"`
-	AccountOpenedDate *string   `json:"account_opened_date,omitempty"`
-	OwnerType         string    `json:"owner_type"`
-	OwnerName         *string   `json:"owner_name,omitempty"`
-	OwnerTaxID        *string   `json:"owner_tax_id,omitempty"`
-	IdempotencyKey    string    `json:"idempotency_key"`
-	RequestID         string    `json:"request_id"`
-	Timestamp         time.Time `json:"timestamp"`
+	URL                       string
+	Subscription              string
+	TopicEntryCreated         string
+	TopicEntryUpdated         string
+	TopicEntryDeletedImmed    string
+	TopicClaimCancelled       string
+	TopicPortabilityCancelled string
+	MaxReconnectToBroker      uint
+	ConnectionTimeout         time.Duration
+	OperationTimeout          time.Duration
+	AckTimeout                time.Duration
+	NackRedeliveryDelay       time.Duration
+	MaxDeliveryAttempts       int
 }
 
-// EntryUpdatedEvent represents a Pulsar event for entry update
-type EntryUpdatedEvent struct {
-	EntryID           string    `json:"entry_id"`
-	Key               string    `json:"key"`
-	KeyType           string    `json:"key_type"`
-	Participant       string    `json:"participant"`
-	AccountBranch     *string   `json:"account_branch,omitempty"`
-	AccountNumber     *string   `json:"account_number,omitempty"`
-	AccountType       string    `json:"account_type"`
-	AccountOpenedDate *string   `json:"account_opened_date,omitempty"`
-	OwnerType         string    `json:"owner_type"`
-	OwnerName         *string   `json:"owner_name,omitempty"`
-	OwnerTaxID        *string   `json:"owner_tax_id,omitempty"`
-	IdempotencyKey    string    `json:"idempotency_key"`
-	RequestID         string    `json:"request_id"`
-	Timestamp         time.Time `json:"timestamp"`
-}
-
-// EntryDeletedEvent represents a Pulsar event for immediate entry deletion
-type EntryDeletedEvent struct {
-	EntryID        string    `json:"entry_id"`
-	Key            string    `json:"key"`
-	KeyType        string    `json:"key_type"`
-	Reason         string    `json:"reason"`
-	IdempotencyKey string    `json:"idempotency_key"`
-	RequestID      string    `json:"request_id"`
-	Timestamp      time.Time `json:"timestamp"`
-}
+// Entry and cancellation event payloads, shared with the core-dict outbox (see dict-contracts/events)
+type (
+	EntryCreatedEvent         = events.EntryCreatedEvent
+	EntryUpdatedEvent         = events.EntryUpdatedEvent
+	EntryDeletedEvent         = events.EntryDeletedEvent
+	ClaimCancelledEvent       = events.ClaimCancelledEvent
+	PortabilityCancelledEvent = events.PortabilityCancelledEvent
+)
 
 // NewConsumer creates a new Pulsar consumer for Entry events
 func NewConsumer(
 	config ConsumerConfig,
 	entryRepo *repositories.EntryRepository,
 	bridgeClient bridgepb.BridgeServiceClient,
+	statusEvents StatusEventPublisher,
 	logger *logrus.Logger,
 ) (*Consumer, error) {
 	if entryRepo == nil {
@@ -104,6 +81,9 @@ func NewConsumer(
 	if bridgeClient == nil {
 		return nil, fmt.Errorf("bridgeClient cannot be nil")
 	}
+	if statusEvents == nil {
+		return nil, fmt.Errorf("statusEvents cannot be nil")
+	}
 	if logger == nil {
 		return nil, fmt.Errorf("logger cannot be nil")
 	}
@@ -127,9 +107,10 @@ func NewConsumer(
 
 	return &Consumer{
 		client:       client,
-		consumers:    make([]pulsar.Consumer, 0, 3),
+		consumers:    make([]pulsar.Consumer, 0, 5),
 		entryRepo:    entryRepo,
 		bridgeClient: bridgeClient,
+		statusEvents: statusEvents,
 		logger:       logger,
 		stopChan:     make(chan struct{}),
 		config:       config,
@@ -192,11 +173,25 @@ func (c *Consumer) Start(ctx context.Context) error {
 	c.consumers = append(c.consumers, consumerDeleted)
 	c.logger.Infof("Subscribed to topic: %s", c.config.TopicEntryDeletedImmed)
 
+	// Subscribe to the cancellations of core-dict (claims and portabilities are claims at Bacen)
+	consumerClaimCancelled, err := c.subscribe(c.config.TopicClaimCancelled, "-claims-cancelled")
+	if err != nil {
+		c.Stop()
+		return err
+	}
+	consumerPortabilityCancelled, err := c.subscribe(c.config.TopicPortabilityCancelled, "-portabilities-cancelled")
+	if err != nil {
+		c.Stop()
+		return err
+	}
+
 	// Start goroutines to consume messages
-	c.wg.Add(3)
+	c.wg.Add(5)
 	go c.consumeCreatedEvents(ctx, consumerCreated)
 	go c.consumeUpdatedEvents(ctx, consumerUpdated)
 	go c.consumeDeletedEvents(ctx, consumerDeleted)
+	go c.consumeEvents(ctx, consumerClaimCancelled, "ClaimCancelled", c.handleClaimCancelled)
+	go c.consumeEvents(ctx, consumerPortabilityCancelled, "PortabilityCancelled", c.handlePortabilityCancelled)
 
 	c.logger.Info("Pulsar consumer started successfully - processing Entry events")
 	return nil
@@ -310,6 +305,58 @@ func (c *Consumer) consumeDeletedEvents(ctx context.Context, consumer pulsar.Con
 	}
 }
 
+// subscribe subscribes to a topic with the options shared by every Entry topic
+func (c *Consumer) subscribe(topic, subscriptionSuffix string) (pulsar.Consumer, error) {
+	consumer, err := c.client.Subscribe(pulsar.ConsumerOptions{
+		Topic:                       topic,
+		SubscriptionName:            c.config.Subscription + subscriptionSuffix,
+		Type:                        pulsar.Shared,
+		SubscriptionInitialPosition: pulsar.SubscriptionPositionLatest,
+		NackRedeliveryDelay:         c.config.NackRedeliveryDelay,
+		RetryEnable:                 true,
+		MaxReconnectToBroker:        &c.config.MaxReconnectToBroker,
+		ReceiverQueueSize:           1000,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to %s: %w", topic, err)
+	}
+	c.consumers = append(c.consumers, consumer)
+	c.logger.Infof("Subscribed to topic: %s", topic)
+	return consumer, nil
+}
+
+// consumeEvents consumes messages of a topic with handle: ack on success, nack for redelivery otherwise
+func (c *Consumer) consumeEvents(ctx context.Context, consumer pulsar.Consumer, eventType string, handle func(context.Context, pulsar.Message) error) {
+	defer c.wg.Done()
+
+	for {
+		select {
+		case <-c.stopChan:
+			c.logger.Infof("Stopping %s consumer", eventType)
+			return
+		case <-ctx.Done():
+			c.logger.Infof("Context cancelled, stopping %s consumer", eventType)
+			return
+		default:
+			msg, err := consumer.Receive(ctx)
+			if err != nil {
+				c.logger.Errorf("Error receiving %s message: %v", eventType, err)
+				continue
+			}
+
+			if err := handle(ctx, msg); err != nil {
+				c.logger.WithError(err).WithFields(logrus.Fields{
+					"message_id": msg.ID(),
+					"topic":      msg.Topic(),
+				}).Errorf("Failed to process %s event", eventType)
+				consumer.Nack(msg)
+			} else {
+				consumer.Ack(msg)
+			}
+		}
+	}
+}
+
 // handleEntryCreated processes EntryCreated event by calling Bridge gRPC directly
 // NO TEMPORAL WORKFLOW - This is a fast operation (< 1.5s)
 func (c *Consumer) handleEntryCreated(ctx context.Context, msg pulsar.Message) error {
@@ -348,6 +395,7 @@ func (c *Consumer) handleEntryCreated(ctx context.Context, msg pulsar.Message) e
 			AccountHolderName:     ptrToString(event.OwnerName),
 			AccountHolderDocument: ptrToString(event.OwnerTaxID),
 			DocumentType:          c.mapDocumentType(event.OwnerType),
+			OpeningDate:           ptrToTimestamp(entry.AccountOpenedDate),
 		},
 		IdempotencyKey: event.IdempotencyKey,
 		RequestId:      event.RequestID,
@@ -372,9 +420,15 @@ func (c *Consumer) handleEntryCreated(ctx context.Context, msg pulsar.Message) e
 	// 4. Update entry status to ACTIVE + store Bacen Entry ID
 	entry.Status = entities.EntryStatusActive
 	entry.BacenEntryID = &resp.ExternalId
+	entry.BacenRequestID = &event.RequestID
 	now := time.Now()
 	entry.ActivatedAt = &now
 	entry.UpdatedAt = now
+	// Bacen's creation time is the ownership date that enters the CID
+	if resp.CreatedAt != nil {
+		ownership := resp.CreatedAt.AsTime()
+		entry.KeyOwnershipDate = &ownership
+	}
 
 	if err := c.entryRepo.Update(ctx, entry); err != nil {
 		c.logger.WithError(err).Error("Failed to update entry after Bridge call")
@@ -383,11 +437,11 @@ func (c *Consumer) handleEntryCreated(ctx context.Context, msg pulsar.Message) e
 
 	duration := time.Since(startTime)
 	c.logger.WithFields(logrus.Fields{
-		"entry_id":        event.EntryID,
-		"bacen_entry_id":  resp.ExternalId,
-		"status":          "ACTIVE",
-		"duration_ms":     duration.Milliseconds(),
-		"request_id":      event.RequestID,
+		"entry_id":       event.EntryID,
+		"bacen_entry_id": resp.ExternalId,
+		"status":         "ACTIVE",
+		"duration_ms":    duration.Milliseconds(),
+		"request_id":     event.RequestID,
 	}).Info("EntryCreated processed successfully")
 
 	return nil
@@ -427,6 +481,7 @@ func (c *Consumer) handleEntryUpdated(ctx context.Context, msg pulsar.Message) e
 			AccountHolderName:     ptrToString(event.OwnerName),
 			AccountHolderDocument: ptrToString(event.OwnerTaxID),
 			DocumentType:          c.mapDocumentType(event.OwnerType),
+			OpeningDate:           ptrToTimestamp(entry.AccountOpenedDate),
 		},
 		IdempotencyKey: event.IdempotencyKey,
 		RequestId:      event.RequestID,
@@ -457,6 +512,7 @@ func (c *Consumer) handleEntryUpdated(ctx context.Context, msg pulsar.Message) e
 	if event.OwnerTaxID != nil {
 		entry.OwnerTaxID = event.OwnerTaxID
 	}
+	entry.BacenRequestID = &event.RequestID
 	entry.UpdatedAt = time.Now()
 
 	if err := c.entryRepo.Update(ctx, entry); err != nil {
@@ -509,6 +565,7 @@ func (c *Consumer) handleEntryDeleteImmediate(ctx context.Context, msg pulsar.Me
 		},
 		IdempotencyKey: event.IdempotencyKey,
 		RequestId:      event.RequestID,
+		Reason:         c.mapDeletionReason(event.Reason),
 	}
 
 	// 3. Call Bridge gRPC DeleteEntry directly (NO TEMPORAL!)
@@ -519,10 +576,25 @@ func (c *Consumer) handleEntryDeleteImmediate(ctx context.Context, msg pulsar.Me
 			"request_id": event.RequestID,
 		}).Error("Bridge DeleteEntry call failed")
 
-		return fmt.Errorf("bridge DeleteEntry failed: %w", err)
+		// A Bacen rejection is final, and so is the last delivery: report the failure to core-dict
+		// and ack. Other failures (Bridge or Bacen unavailable) are redelivered.
+		rejection, rejected := bacenRejection(err)
+		if !rejected && !c.isLastDelivery(msg) {
+			return fmt.Errorf("bridge DeleteEntry failed: %w", err)
+		}
+		if !rejected {
+			rejection = err.Error()
+		}
+		return c.publishDeletionResult(ctx, &event, entry, rejection)
+	}
+
+	// 4. Report the Bacen confirmation to core-dict (a redelivery reports it again; core-dict
+	// ignores answers for keys it is no longer waiting on)
+	if err := c.publishDeletionResult(ctx, &event, entry, ""); err != nil {
+		return err
 	}
 
-	// 4. Soft delete entry in database
+	// 5. Soft delete entry in database
 	if err := c.entryRepo.Delete(ctx, entry.EntryID); err != nil {
 		c.logger.WithError(err).Error("Failed to soft delete entry after Bridge call")
 		return fmt.Errorf("failed to delete entry: %w", err)
@@ -540,6 +612,128 @@ func (c *Consumer) handleEntryDeleteImmediate(ctx context.Context, msg pulsar.Me
 	return nil
 }
 
+// publishDeletionResult publishes the Bacen answer to a deletion on dict.entries.status.changed:
+// ACTIVE → DELETED, or the entry still ACTIVE with the error when the deletion failed. core-dict
+// uses it to record the final outcome of the keys of an account closure.
+func (c *Consumer) publishDeletionResult(ctx context.Context, event *EntryDeletedEvent, entry *entities.Entry, deletionErr string) error {
+	statusEvent := &connectv1.EntryStatusChangedEvent{
+		EntryId:         event.EntryID,
+		ParticipantIspb: entry.Participant,
+		KeyType:         c.mapKeyType(event.KeyType),
+		KeyValue:        event.Key,
+		OldStatus:       commonpb.EntryStatus_ENTRY_STATUS_ACTIVE,
+		NewStatus:       commonpb.EntryStatus_ENTRY_STATUS_DELETED,
+		Reason:          event.Reason,
+		CausedById:      event.RequestID,
+		CausedByType:    connectv1.EntryStatusChangedEvent_CAUSED_BY_TYPE_DELETION,
+		ChangedAt:       timestamppb.Now(),
+	}
+	if deletionErr != "" {
+		statusEvent.NewStatus = commonpb.EntryStatus_ENTRY_STATUS_ACTIVE
+		statusEvent.Error = &deletionErr
+	}
+
+	if err := c.statusEvents.PublishProto(ctx, statusEvent, event.EntryID); err != nil {
+		return fmt.Errorf("failed to publish entry status changed event: %w", err)
+	}
+	return nil
+}
+
+// handleClaimCancelled cancels at Bacen a claim cancelled in core-dict
+func (c *Consumer) handleClaimCancelled(ctx context.Context, msg pulsar.Message) error {
+	var event ClaimCancelledEvent
+	if err := json.Unmarshal(msg.Payload(), &event); err != nil {
+		return fmt.Errorf("failed to unmarshal ClaimCancelled event: %w", err)
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"claim_id":       event.ClaimID,
+		"bacen_claim_id": event.BacenClaimID,
+		"reason":         event.Reason,
+		"request_id":     event.RequestID,
+	}).Info("Processing ClaimCancelled event")
+
+	return c.cancelClaimAtBacen(ctx, msg, event.ClaimID, event.BacenClaimID, event.Reason, event.IdempotencyKey, event.RequestID)
+}
+
+// handlePortabilityCancelled cancels at Bacen the claim of a portability cancelled in core-dict
+func (c *Consumer) handlePortabilityCancelled(ctx context.Context, msg pulsar.Message) error {
+	var event PortabilityCancelledEvent
+	if err := json.Unmarshal(msg.Payload(), &event); err != nil {
+		return fmt.Errorf("failed to unmarshal PortabilityCancelled event: %w", err)
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"portability_id":       event.PortabilityID,
+		"bacen_portability_id": event.BacenPortabilityID,
+		"reason":               event.Reason,
+		"request_id":           event.RequestID,
+	}).Info("Processing PortabilityCancelled event")
+
+	return c.cancelClaimAtBacen(ctx, msg, event.PortabilityID, event.BacenPortabilityID, event.Reason, event.IdempotencyKey, event.RequestID)
+}
+
+// cancelClaimAtBacen calls Bridge CancelClaim. A claim that never reached Bacen has nothing to
+// cancel; a Bacen rejection is logged and acked (redelivery would be rejected again).
+func (c *Consumer) cancelClaimAtBacen(ctx context.Context, msg pulsar.Message, claimID, bacenClaimID, reason, idempotencyKey, requestID string) error {
+	logger := c.logger.WithFields(logrus.Fields{
+		"claim_id":       claimID,
+		"bacen_claim_id": bacenClaimID,
+		"request_id":     requestID,
+	})
+	if bacenClaimID == "" {
+		logger.Info("Claim was never sent to Bacen, nothing to cancel")
+		return nil
+	}
+
+	resp, err := c.bridgeClient.CancelClaim(ctx, &bridgepb.CancelClaimRequest{
+		ClaimId:            claimID,
+		ExternalId:         bacenClaimID,
+		CancellationReason: c.mapCancellationReason(reason),
+		IdempotencyKey:     idempotencyKey,
+		RequestId:          requestID,
+	})
+	if err != nil {
+		if rejection, rejected := bacenRejection(err); rejected {
+			logger.WithField("rejection", rejection).Error("Bacen rejected the claim cancellation")
+			return nil
+		}
+		if c.isLastDelivery(msg) {
+			logger.WithError(err).Error("Bridge CancelClaim failed on the last delivery, giving up")
+			return nil
+		}
+		return fmt.Errorf("bridge CancelClaim failed: %w", err)
+	}
+
+	logger.WithFields(logrus.Fields{
+		"status":      resp.Status,
+		"bacen_tx_id": resp.BacenTransactionId,
+	}).Info("Claim cancelled at Bacen")
+	return nil
+}
+
+// isLastDelivery reports whether msg will not be redelivered after a nack
+func (c *Consumer) isLastDelivery(msg pulsar.Message) bool {
+	return c.config.MaxDeliveryAttempts > 0 && int(msg.RedeliveryCount())+1 >= c.config.MaxDeliveryAttempts
+}
+
+// bacenRejection returns the Bacen error Bridge attaches (common.v1.BacenError detail) when Bacen
+// rejected the request. Errors without it (Bridge or Bacen unavailable) can be retried.
+func bacenRejection(err error) (string, bool) {
+	st, ok := status.FromError(err)
+	if !ok {
+		return "", false
+	}
+	for _, detail := range st.Details() {
+		bacenErr, ok := detail.(*commonpb.BacenError)
+		if !ok || bacenErr.GetBacenCode() == "" {
+			continue
+		}
+		return fmt.Sprintf("%s [%s]", bacenErr.GetBacenMessage(), bacenErr.GetBacenCode()), true
+	}
+	return "", false
+}
+
 // Stop gracefully stops the consumer
 func (c *Consumer) Stop() {
 	c.logger.Info("Stopping Pulsar consumer...")
@@ -580,6 +774,31 @@ func (c *Consumer) mapKeyType(keyType string) commonpb.KeyType {
 	}
 }
 
+// mapDeletionReason maps the reason of the EntryDeleted event to the Bacen deletion reason.
+// Free-text reasons (user deletions) are USER_REQUESTED.
+func (c *Consumer) mapDeletionReason(reason string) bridgepb.EntryDeletionReason {
+	switch reason {
+	case events.DeletionReasonAccountClosure:
+		return bridgepb.EntryDeletionReason_ENTRY_DELETION_REASON_ACCOUNT_CLOSURE
+	case events.DeletionReasonReconciliation:
+		return bridgepb.EntryDeletionReason_ENTRY_DELETION_REASON_RECONCILIATION
+	case events.DeletionReasonFraud:
+		return bridgepb.EntryDeletionReason_ENTRY_DELETION_REASON_FRAUD
+	default:
+		return bridgepb.EntryDeletionReason_ENTRY_DELETION_REASON_USER_REQUESTED
+	}
+}
+
+// mapCancellationReason maps the reason of a cancellation event to the Bacen cancellation reason
+func (c *Consumer) mapCancellationReason(reason string) string {
+	switch reason {
+	case events.DeletionReasonAccountClosure, events.DeletionReasonFraud:
+		return reason
+	default:
+		return "USER_REQUESTED"
+	}
+}
+
 func (c *Consumer) mapAccountType(accountType string) commonpb.AccountType {
 	switch accountType {
 	case "CACC":
@@ -597,9 +816,9 @@ func (c *Consumer) mapAccountType(accountType string) commonpb.AccountType {
 
 func (c *Consumer) mapDocumentType(ownerType string) commonpb.DocumentType {
 	switch ownerType {
-	case "NATURAL_PERSON":
+	case events.OwnerTypeNaturalPerson:
 		return commonpb.DocumentType_DOCUMENT_TYPE_CPF
-	case "LEGAL_PERSON":
+	case events.OwnerTypeLegalPerson:
 		return commonpb.DocumentType_DOCUMENT_TYPE_CNPJ
 	default:
 		return commonpb.DocumentType_DOCUMENT_TYPE_UNSPECIFIED
@@ -613,19 +832,29 @@ func ptrToString(ptr *string) string {
 	return *ptr
 }
 
+// ptrToTimestamp converts an optional time to a protobuf timestamp
+func ptrToTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
 // DefaultConsumerConfig returns default consumer configuration
 func DefaultConsumerConfig() ConsumerConfig {
 	return ConsumerConfig{
-		URL:                    "pulsar://localhost:6650",
-		Subscription:           "conn-dict-entry-consumer",
-		TopicEntryCreated:      "dict.entries.created",
-		TopicEntryUpdated:      "dict.entries.updated",
-		TopicEntryDeletedImmed: "dict.entries.deleted.immediate",
-		MaxReconnectToBroker:   10,
-		ConnectionTimeout:      30 * time.Second,
-		OperationTimeout:       30 * time.Second,
-		AckTimeout:             20 * time.Second,
-		NackRedeliveryDelay:    60 * time.Second,
-		MaxDeliveryAttempts:    5,
+		URL:                       "pulsar://localhost:6650",
+		Subscription:              "conn-dict-entry-consumer",
+		TopicEntryCreated:         "dict.entries.created",
+		TopicEntryUpdated:         "dict.entries.updated",
+		TopicEntryDeletedImmed:    "dict.entries.deleted.immediate",
+		TopicClaimCancelled:       "dict.claims.cancelled",
+		TopicPortabilityCancelled: "dict.portabilities.cancelled",
+		MaxReconnectToBroker:      10,
+		ConnectionTimeout:         30 * time.Second,
+		OperationTimeout:          30 * time.Second,
+		AckTimeout:                20 * time.Second,
+		NackRedeliveryDelay:       60 * time.Second,
+		MaxDeliveryAttempts:       5,
 	}
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/apache/pulsar-client-go/pulsar"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
 )
 
 // Producer wraps Pulsar producer with application-specific logic
@@ -123,6 +124,33 @@ func (p *Producer) PublishEventSync(ctx context.Context, event interface{}, key
 	return msgID, nil
 }
 
+// PublishProto publishes a protobuf-encoded event synchronously.
+// Used for topics consumed by core-dict, which decodes payloads with proto.Unmarshal.
+func (p *Producer) PublishProto(ctx context.Context, event proto.Message, key string) error {
+	payload, err := proto.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	msg := &pulsar.ProducerMessage{
+		Payload: payload,
+		Key:     key,
+		Properties: map[string]string{
+			"event_time":   time.Now().UTC().Format(time.RFC3339),
+			"producer":     "conn-dict",
+			"content_type": "application/x-protobuf",
+		},
+	}
+
+	msgID, err := p.producer.Send(ctx, msg)
+	if err != nil {
+		return fmt.Errorf("failed to publish event: %w", err)
+	}
+
+	p.logger.Debugf("Event published (proto): topic=%s, key=%s, msgID=%v", p.topic, key, msgID)
+	return nil
+}
+
 // Close closes the producer and client
 func (p *Producer) Close() {
 	p.producer.Close()
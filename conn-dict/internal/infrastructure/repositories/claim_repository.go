@@ -73,6 +73,98 @@ func (r *ClaimRepository) Create(ctx context.Context, claim *entities.Claim) err
 	return nil
 }
 
+// Exists checks whether a claim with the given claim_id is already stored
+func (r *ClaimRepository) Exists(ctx context.Context, claimID string) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM claims WHERE claim_id = $1)`
+
+	var exists bool
+	if err := r.db.QueryRow(ctx, query, claimID).Scan(&exists); err != nil {
+		r.logger.WithError(err).Errorf("Failed to check claim existence: %s", claimID)
+		return false, fmt.Errorf("failed to check claim existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+// GetStatus returns the status of a claim, and whether it exists
+func (r *ClaimRepository) GetStatus(ctx context.Context, claimID string) (entities.ClaimStatus, bool, error) {
+	query := `SELECT status FROM claims WHERE claim_id = $1`
+
+	var status entities.ClaimStatus
+	err := r.db.QueryRow(ctx, query, claimID).Scan(&status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", false, nil
+	}
+	if err != nil {
+		r.logger.WithError(err).Errorf("Failed to get claim status: %s", claimID)
+		return "", false, fmt.Errorf("failed to get claim status: %w", err)
+	}
+
+	return status, true, nil
+}
+
+// Upsert inserts a claim mirrored from Bacen, or refreshes its status once Bacen moved it past the
+// resolution phase (the local OPEN/WAITING_RESOLUTION steps are driven by the claim workflow).
+// It reports whether the claim was newly inserted so callers can notify only once.
+func (r *ClaimRepository) Upsert(ctx context.Context, claim *entities.Claim) (bool, error) {
+	query := `
+		INSERT INTO claims (
+			id, claim_id, type, status, key, key_type,
+			donor_participant, claimer_participant,
+			claimer_account_branch, claimer_account_number, claimer_account_type,
+			completion_period_end, claim_expiry_date,
+			created_at, updated_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NULLIF($11, ''), $12, $13, $14, $15
+		)
+		ON CONFLICT (claim_id) DO UPDATE
+		SET
+			status = EXCLUDED.status,
+			completion_period_end = EXCLUDED.completion_period_end,
+			claim_expiry_date = EXCLUDED.claim_expiry_date,
+			updated_at = EXCLUDED.updated_at
+		WHERE claims.status IS DISTINCT FROM EXCLUDED.status
+			AND EXCLUDED.status NOT IN ('OPEN', 'WAITING_RESOLUTION')
+		RETURNING (xmax = 0) AS inserted
+	`
+
+	var inserted bool
+	err := r.db.QueryRow(ctx, query,
+		claim.ID,
+		claim.ClaimID,
+		claim.Type,
+		claim.Status,
+		claim.Key,
+		claim.KeyType,
+		claim.DonorParticipant,
+		claim.ClaimerParticipant,
+		claim.ClaimerAccountBranch,
+		claim.ClaimerAccountNumber,
+		claim.ClaimerAccountType,
+		claim.CompletionPeriodEnd,
+		claim.ClaimExpiryDate,
+		claim.CreatedAt,
+		claim.UpdatedAt,
+	).Scan(&inserted)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Already known and nothing to refresh
+		return false, nil
+	}
+	if err != nil {
+		r.logger.WithError(err).Errorf("Failed to upsert claim: %s", claim.ClaimID)
+		return false, fmt.Errorf("failed to upsert claim: %w", err)
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"claim_id": claim.ClaimID,
+		"status":   claim.Status,
+		"inserted": inserted,
+	}).Info("Claim upserted successfully")
+
+	return inserted, nil
+}
+
 // GetByID retrieves a claim by its UUID
 func (r *ClaimRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Claim, error) {
 	query := `
@@ -0,0 +1,184 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/lbpay-lab/conn-dict/internal/domain/entities"
+)
+
+// Encrypted entry columns (AES-GCM additional data and blind index domain of each value)
+const (
+	fieldEntryKey        = "entries.key"
+	fieldEntryOwnerName  = "entries.owner_name"
+	fieldEntryOwnerTaxID = "entries.owner_tax_id"
+)
+
+// isPersonalKeyType reports whether keys of this type identify a natural person (LGPD).
+// CNPJ and EVP keys stay in plaintext; all keys get a blind index.
+func isPersonalKeyType(keyType entities.KeyType) bool {
+	switch keyType {
+	case entities.KeyTypeCPF, entities.KeyTypeEMAIL, entities.KeyTypePHONE:
+		return true
+	default:
+		return false
+	}
+}
+
+// sealedEntry holds the stored form of the personal data of an entry
+type sealedEntry struct {
+	key                 *string // CNPJ and EVP only
+	keyEncrypted        []byte  // CPF, EMAIL and PHONE
+	keyIndex            string
+	ownerNameEncrypted  []byte
+	ownerTaxIDEncrypted []byte
+	ownerTaxIDIndex     *string
+	keyID               string // KEK version of the encrypted columns
+}
+
+// seal encrypts the personal data of entry under the current KEK
+func (r *EntryRepository) seal(ctx context.Context, entry *entities.Entry) (*sealedEntry, error) {
+	sealed := &sealedEntry{
+		keyIndex: r.cipher.BlindIndex(fieldEntryKey, entry.Key),
+		keyID:    r.cipher.CurrentKeyID(),
+	}
+
+	var err error
+	if isPersonalKeyType(entry.KeyType) {
+		if sealed.keyEncrypted, err = r.cipher.Encrypt(ctx, fieldEntryKey, entry.Key); err != nil {
+			return nil, fmt.Errorf("failed to encrypt key: %w", err)
+		}
+	} else {
+		key := entry.Key
+		sealed.key = &key
+	}
+
+	if entry.OwnerName != nil {
+		if sealed.ownerNameEncrypted, err = r.cipher.Encrypt(ctx, fieldEntryOwnerName, *entry.OwnerName); err != nil {
+			return nil, fmt.Errorf("failed to encrypt owner name: %w", err)
+		}
+	}
+	if entry.OwnerTaxID != nil {
+		if sealed.ownerTaxIDEncrypted, err = r.cipher.Encrypt(ctx, fieldEntryOwnerTaxID, *entry.OwnerTaxID); err != nil {
+			return nil, fmt.Errorf("failed to encrypt owner tax ID: %w", err)
+		}
+		index := r.cipher.BlindIndex(fieldEntryOwnerTaxID, *entry.OwnerTaxID)
+		sealed.ownerTaxIDIndex = &index
+	}
+
+	return sealed, nil
+}
+
+// scanEntry scans a row of entryColumns and decrypts its personal data
+func (r *EntryRepository) scanEntry(ctx context.Context, row pgx.Row) (*entities.Entry, error) {
+	entry := &entities.Entry{}
+	var key, ownerName, ownerTaxID *string
+	var keyEncrypted, ownerNameEncrypted, ownerTaxIDEncrypted []byte
+
+	err := row.Scan(
+		&entry.ID, &entry.EntryID, &key, &keyEncrypted, &entry.KeyType, &entry.Participant,
+		&entry.AccountBranch, &entry.AccountNumber, &entry.AccountType, &entry.AccountOpenedDate,
+		&entry.OwnerType, &ownerName, &ownerNameEncrypted, &ownerTaxID, &ownerTaxIDEncrypted,
+		&entry.Status, &entry.ReasonForStatusChange, &entry.BacenEntryID, &entry.BacenRequestID,
+		&entry.RegisteredAt, &entry.ActivatedAt, &entry.DeactivatedAt, &entry.KeyOwnershipDate,
+		&entry.CreatedAt, &entry.UpdatedAt, &entry.DeletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	openedKey, err := r.open(ctx, fieldEntryKey, key, keyEncrypted)
+	if err != nil {
+		return nil, err
+	}
+	if openedKey != nil {
+		entry.Key = *openedKey
+	}
+	if entry.OwnerName, err = r.open(ctx, fieldEntryOwnerName, ownerName, ownerNameEncrypted); err != nil {
+		return nil, err
+	}
+	if entry.OwnerTaxID, err = r.open(ctx, fieldEntryOwnerTaxID, ownerTaxID, ownerTaxIDEncrypted); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// open returns the value of an encrypted column, or its legacy plaintext while the
+// re-encryption job has not migrated the row yet
+func (r *EntryRepository) open(ctx context.Context, field string, plaintext *string, encrypted []byte) (*string, error) {
+	if encrypted == nil {
+		return plaintext, nil
+	}
+	value, err := r.cipher.Decrypt(ctx, field, encrypted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt %s: %w", field, err)
+	}
+	return &value, nil
+}
+
+// ReencryptBatch migrates up to limit entries to the current KEK and returns how many were
+// rewritten.
+//
+// It picks the entries sealed under an older KEK (after a rotation) and the legacy entries still
+// in plaintext, fills their blind indexes and clears the plaintext columns. Soft-deleted entries
+// are included; erased entries (no key left) are skipped. Rows are locked with FOR UPDATE SKIP
+// LOCKED, so concurrent runs do not overlap.
+func (r *EntryRepository) ReencryptBatch(ctx context.Context, limit int) (int, error) {
+	query := `
+		SELECT` + entryColumns + `
+		FROM entries
+		WHERE (key_index IS NULL OR encryption_key_id IS DISTINCT FROM $1)
+		  AND (key IS NOT NULL OR key_encrypted IS NOT NULL)
+		ORDER BY id
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	`
+
+	update := `
+		UPDATE entries SET
+			key = $2, key_encrypted = $3, key_index = $4,
+			owner_name = NULL, owner_name_encrypted = $5,
+			owner_tax_id = NULL, owner_tax_id_encrypted = $6, owner_tax_id_index = $7,
+			encryption_key_id = $8
+		WHERE id = $1
+	`
+
+	var count int
+	err := r.db.ExecuteInTransaction(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, query, r.cipher.CurrentKeyID(), limit)
+		if err != nil {
+			return fmt.Errorf("failed to select entries to re-encrypt: %w", err)
+		}
+		pending, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*entities.Entry, error) {
+			return r.scanEntry(ctx, row)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to read entries to re-encrypt: %w", err)
+		}
+
+		for _, entry := range pending {
+			sealed, err := r.seal(ctx, entry)
+			if err != nil {
+				return fmt.Errorf("entry %s: %w", entry.EntryID, err)
+			}
+			_, err = tx.Exec(ctx, update, entry.ID,
+				sealed.key, sealed.keyEncrypted, sealed.keyIndex,
+				sealed.ownerNameEncrypted,
+				sealed.ownerTaxIDEncrypted, sealed.ownerTaxIDIndex,
+				sealed.keyID,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to re-encrypt entry %s: %w", entry.EntryID, err)
+			}
+		}
+		count = len(pending)
+		return nil
+	})
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to re-encrypt entries")
+		return 0, err
+	}
+
+	return count, nil
+}
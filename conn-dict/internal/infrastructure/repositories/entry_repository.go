@@ -8,19 +8,37 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/lbpay-lab/conn-dict/internal/domain/entities"
 	"github.com/lbpay-lab/conn-dict/internal/infrastructure/database"
+	"github.com/lbpay-lab/dict-contracts/fieldcrypto"
 	"github.com/sirupsen/logrus"
 )
 
-// EntryRepository handles persistence of Entry entities
+// entryColumns are the columns read by scanEntry
+const entryColumns = `
+			id, entry_id, key, key_encrypted, key_type, participant,
+			account_branch, account_number, account_type, account_opened_date,
+			owner_type, owner_name, owner_name_encrypted, owner_tax_id, owner_tax_id_encrypted,
+			status, reason_for_status_change, bacen_entry_id, bacen_request_id,
+			registered_at, activated_at, deactivated_at, key_ownership_date,
+			created_at, updated_at, deleted_at`
+
+// ErrEntryNotFound is returned by GetByKey when no entry holds the key
+var ErrEntryNotFound = errors.New("entry not found")
+
+// EntryRepository handles persistence of Entry entities.
+// CPF/EMAIL/PHONE keys and the owner name and tax ID are stored encrypted (see fieldcrypto);
+// key lookups go through the key_index blind index. Every write stores the entry CID, from which
+// the database keeps the per-key-type VSync aggregates (see entry_vsync.go).
 type EntryRepository struct {
 	db     *database.PostgresClient
+	cipher *fieldcrypto.Cipher
 	logger *logrus.Logger
 }
 
 // NewEntryRepository creates a new EntryRepository
-func NewEntryRepository(db *database.PostgresClient, logger *logrus.Logger) *EntryRepository {
+func NewEntryRepository(db *database.PostgresClient, cipher *fieldcrypto.Cipher, logger *logrus.Logger) *EntryRepository {
 	return &EntryRepository{
 		db:     db,
+		cipher: cipher,
 		logger: logger,
 	}
 }
@@ -29,29 +47,38 @@ func NewEntryRepository(db *database.PostgresClient, logger *logrus.Logger) *Ent
 func (r *EntryRepository) Create(ctx context.Context, entry *entities.Entry) error {
 	query := `
 		INSERT INTO entries (
-			id, entry_id, key, key_type, participant,
+			id, entry_id, key, key_encrypted, key_index, key_type, participant,
 			account_branch, account_number, account_type, account_opened_date,
-			owner_type, owner_name, owner_tax_id,
-			status, reason_for_status_change, bacen_entry_id,
-			registered_at, activated_at, deactivated_at,
-			created_at, updated_at
+			owner_type, owner_name_encrypted, owner_tax_id_encrypted, owner_tax_id_index,
+			encryption_key_id,
+			status, reason_for_status_change, bacen_entry_id, bacen_request_id,
+			registered_at, activated_at, deactivated_at, key_ownership_date,
+			cid, created_at, updated_at
 		) VALUES (
-			$1, $2, $3, $4, $5,
-			$6, $7, $8, $9,
-			$10, $11, $12,
-			$13, $14, $15,
-			$16, $17, $18,
-			$19, $20
+			$1, $2, $3, $4, $5, $6, $7,
+			$8, $9, $10, $11,
+			$12, $13, $14, $15,
+			$16,
+			$17, $18, $19, $20,
+			$21, $22, $23, $24,
+			$25, $26, $27
 		)
 	`
 
-	_, err := r.db.Exec(ctx, query,
-		entry.ID, entry.EntryID, entry.Key, entry.KeyType, entry.Participant,
+	sealed, err := r.seal(ctx, entry)
+	if err != nil {
+		r.logger.WithError(err).Errorf("Failed to encrypt entry: %s", entry.EntryID)
+		return err
+	}
+
+	_, err = r.db.Exec(ctx, query,
+		entry.ID, entry.EntryID, sealed.key, sealed.keyEncrypted, sealed.keyIndex, entry.KeyType, entry.Participant,
 		entry.AccountBranch, entry.AccountNumber, entry.AccountType, entry.AccountOpenedDate,
-		entry.OwnerType, entry.OwnerName, entry.OwnerTaxID,
-		entry.Status, entry.ReasonForStatusChange, entry.BacenEntryID,
-		entry.RegisteredAt, entry.ActivatedAt, entry.DeactivatedAt,
-		entry.CreatedAt, entry.UpdatedAt,
+		entry.OwnerType, sealed.ownerNameEncrypted, sealed.ownerTaxIDEncrypted, sealed.ownerTaxIDIndex,
+		sealed.keyID,
+		entry.Status, entry.ReasonForStatusChange, entry.BacenEntryID, entry.BacenRequestID,
+		entry.RegisteredAt, entry.ActivatedAt, entry.DeactivatedAt, entry.KeyOwnershipDate,
+		entryCID(entry), entry.CreatedAt, entry.UpdatedAt,
 	)
 
 	if err != nil {
@@ -66,26 +93,12 @@ func (r *EntryRepository) Create(ctx context.Context, entry *entities.Entry) err
 // GetByID retrieves an entry by UUID
 func (r *EntryRepository) GetByID(ctx context.Context, id string) (*entities.Entry, error) {
 	query := `
-		SELECT 
-			id, entry_id, key, key_type, participant,
-			account_branch, account_number, account_type, account_opened_date,
-			owner_type, owner_name, owner_tax_id,
-			status, reason_for_status_change, bacen_entry_id,
-			registered_at, activated_at, deactivated_at,
-			created_at, updated_at, deleted_at
+		SELECT` + entryColumns + `
 		FROM entries
 		WHERE id = $1 AND deleted_at IS NULL
 	`
 
-	entry := &entities.Entry{}
-	err := r.db.QueryRow(ctx, query, id).Scan(
-		&entry.ID, &entry.EntryID, &entry.Key, &entry.KeyType, &entry.Participant,
-		&entry.AccountBranch, &entry.AccountNumber, &entry.AccountType, &entry.AccountOpenedDate,
-		&entry.OwnerType, &entry.OwnerName, &entry.OwnerTaxID,
-		&entry.Status, &entry.ReasonForStatusChange, &entry.BacenEntryID,
-		&entry.RegisteredAt, &entry.ActivatedAt, &entry.DeactivatedAt,
-		&entry.CreatedAt, &entry.UpdatedAt, &entry.DeletedAt,
-	)
+	entry, err := r.scanEntry(ctx, r.db.QueryRow(ctx, query, id))
 
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, fmt.Errorf("entry not found: %s", id)
@@ -102,26 +115,12 @@ func (r *EntryRepository) GetByID(ctx context.Context, id string) (*entities.Ent
 // GetByEntryID retrieves an entry by entry_id
 func (r *EntryRepository) GetByEntryID(ctx context.Context, entryID string) (*entities.Entry, error) {
 	query := `
-		SELECT 
-			id, entry_id, key, key_type, participant,
-			account_branch, account_number, account_type, account_opened_date,
-			owner_type, owner_name, owner_tax_id,
-			status, reason_for_status_change, bacen_entry_id,
-			registered_at, activated_at, deactivated_at,
-			created_at, updated_at, deleted_at
+		SELECT` + entryColumns + `
 		FROM entries
 		WHERE entry_id = $1 AND deleted_at IS NULL
 	`
 
-	entry := &entities.Entry{}
-	err := r.db.QueryRow(ctx, query, entryID).Scan(
-		&entry.ID, &entry.EntryID, &entry.Key, &entry.KeyType, &entry.Participant,
-		&entry.AccountBranch, &entry.AccountNumber, &entry.AccountType, &entry.AccountOpenedDate,
-		&entry.OwnerType, &entry.OwnerName, &entry.OwnerTaxID,
-		&entry.Status, &entry.ReasonForStatusChange, &entry.BacenEntryID,
-		&entry.RegisteredAt, &entry.ActivatedAt, &entry.DeactivatedAt,
-		&entry.CreatedAt, &entry.UpdatedAt, &entry.DeletedAt,
-	)
+	entry, err := r.scanEntry(ctx, r.db.QueryRow(ctx, query, entryID))
 
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, fmt.Errorf("entry not found: %s", entryID)
@@ -138,29 +137,16 @@ func (r *EntryRepository) GetByEntryID(ctx context.Context, entryID string) (*en
 // GetByKey retrieves an entry by PIX key
 func (r *EntryRepository) GetByKey(ctx context.Context, key string) (*entities.Entry, error) {
 	query := `
-		SELECT 
-			id, entry_id, key, key_type, participant,
-			account_branch, account_number, account_type, account_opened_date,
-			owner_type, owner_name, owner_tax_id,
-			status, reason_for_status_change, bacen_entry_id,
-			registered_at, activated_at, deactivated_at,
-			created_at, updated_at, deleted_at
+		SELECT` + entryColumns + `
 		FROM entries
-		WHERE key = $1 AND deleted_at IS NULL
+		WHERE (key_index = $1 OR (key_index IS NULL AND key = $2)) AND deleted_at IS NULL
 	`
 
-	entry := &entities.Entry{}
-	err := r.db.QueryRow(ctx, query, key).Scan(
-		&entry.ID, &entry.EntryID, &entry.Key, &entry.KeyType, &entry.Participant,
-		&entry.AccountBranch, &entry.AccountNumber, &entry.AccountType, &entry.AccountOpenedDate,
-		&entry.OwnerType, &entry.OwnerName, &entry.OwnerTaxID,
-		&entry.Status, &entry.ReasonForStatusChange, &entry.BacenEntryID,
-		&entry.RegisteredAt, &entry.ActivatedAt, &entry.DeactivatedAt,
-		&entry.CreatedAt, &entry.UpdatedAt, &entry.DeletedAt,
-	)
+	// key matches rows not yet migrated by the re-encryption job
+	entry, err := r.scanEntry(ctx, r.db.QueryRow(ctx, query, r.cipher.BlindIndex(fieldEntryKey, key), key))
 
 	if errors.Is(err, pgx.ErrNoRows) {
-		return nil, fmt.Errorf("entry not found for key: %s", key)
+		return nil, fmt.Errorf("%w for key: %s", ErrEntryNotFound, key)
 	}
 
 	if err != nil {
@@ -175,22 +161,32 @@ func (r *EntryRepository) GetByKey(ctx context.Context, key string) (*entities.E
 func (r *EntryRepository) Update(ctx context.Context, entry *entities.Entry) error {
 	query := `
 		UPDATE entries SET
-			key = $1, key_type = $2, participant = $3,
-			account_branch = $4, account_number = $5, account_type = $6, account_opened_date = $7,
-			owner_type = $8, owner_name = $9, owner_tax_id = $10,
-			status = $11, reason_for_status_change = $12, bacen_entry_id = $13,
-			registered_at = $14, activated_at = $15, deactivated_at = $16,
-			updated_at = $17
-		WHERE entry_id = $18 AND deleted_at IS NULL
+			key = $1, key_encrypted = $2, key_index = $3, key_type = $4, participant = $5,
+			account_branch = $6, account_number = $7, account_type = $8, account_opened_date = $9,
+			owner_type = $10, owner_name = NULL, owner_name_encrypted = $11,
+			owner_tax_id = NULL, owner_tax_id_encrypted = $12, owner_tax_id_index = $13,
+			encryption_key_id = $14,
+			status = $15, reason_for_status_change = $16, bacen_entry_id = $17, bacen_request_id = $18,
+			registered_at = $19, activated_at = $20, deactivated_at = $21, key_ownership_date = $22,
+			cid = $23, updated_at = $24
+		WHERE entry_id = $25 AND deleted_at IS NULL
 	`
 
+	sealed, err := r.seal(ctx, entry)
+	if err != nil {
+		r.logger.WithError(err).Errorf("Failed to encrypt entry: %s", entry.EntryID)
+		return err
+	}
+
 	cmdTag, err := r.db.Exec(ctx, query,
-		entry.Key, entry.KeyType, entry.Participant,
+		sealed.key, sealed.keyEncrypted, sealed.keyIndex, entry.KeyType, entry.Participant,
 		entry.AccountBranch, entry.AccountNumber, entry.AccountType, entry.AccountOpenedDate,
-		entry.OwnerType, entry.OwnerName, entry.OwnerTaxID,
-		entry.Status, entry.ReasonForStatusChange, entry.BacenEntryID,
-		entry.RegisteredAt, entry.ActivatedAt, entry.DeactivatedAt,
-		entry.UpdatedAt,
+		entry.OwnerType, sealed.ownerNameEncrypted,
+		sealed.ownerTaxIDEncrypted, sealed.ownerTaxIDIndex,
+		sealed.keyID,
+		entry.Status, entry.ReasonForStatusChange, entry.BacenEntryID, entry.BacenRequestID,
+		entry.RegisteredAt, entry.ActivatedAt, entry.DeactivatedAt, entry.KeyOwnershipDate,
+		entryCID(entry), entry.UpdatedAt,
 		entry.EntryID,
 	)
 
@@ -260,13 +256,7 @@ func (r *EntryRepository) Delete(ctx context.Context, entryID string) error {
 // ListByParticipant lists all entries for a given participant (ISPB)
 func (r *EntryRepository) ListByParticipant(ctx context.Context, ispb string, limit, offset int) ([]*entities.Entry, error) {
 	query := `
-		SELECT 
-			id, entry_id, key, key_type, participant,
-			account_branch, account_number, account_type, account_opened_date,
-			owner_type, owner_name, owner_tax_id,
-			status, reason_for_status_change, bacen_entry_id,
-			registered_at, activated_at, deactivated_at,
-			created_at, updated_at, deleted_at
+		SELECT` + entryColumns + `
 		FROM entries
 		WHERE participant = $1 AND deleted_at IS NULL
 		ORDER BY created_at DESC
@@ -282,15 +272,7 @@ func (r *EntryRepository) ListByParticipant(ctx context.Context, ispb string, li
 
 	var entries []*entities.Entry
 	for rows.Next() {
-		entry := &entities.Entry{}
-		err := rows.Scan(
-			&entry.ID, &entry.EntryID, &entry.Key, &entry.KeyType, &entry.Participant,
-			&entry.AccountBranch, &entry.AccountNumber, &entry.AccountType, &entry.AccountOpenedDate,
-			&entry.OwnerType, &entry.OwnerName, &entry.OwnerTaxID,
-			&entry.Status, &entry.ReasonForStatusChange, &entry.BacenEntryID,
-			&entry.RegisteredAt, &entry.ActivatedAt, &entry.DeactivatedAt,
-			&entry.CreatedAt, &entry.UpdatedAt, &entry.DeletedAt,
-		)
+		entry, err := r.scanEntry(ctx, rows)
 		if err != nil {
 			r.logger.WithError(err).Error("Failed to scan entry row")
 			return nil, fmt.Errorf("failed to scan entry: %w", err)
@@ -324,13 +306,13 @@ func (r *EntryRepository) HasActiveKey(ctx context.Context, key string) (bool, e
 	query := `
 		SELECT COUNT(*)
 		FROM entries
-		WHERE key = $1
+		WHERE (key_index = $1 OR (key_index IS NULL AND key = $2))
 		  AND status = 'ACTIVE'
 		  AND deleted_at IS NULL
 	`
 
 	var count int
-	err := r.db.QueryRow(ctx, query, key).Scan(&count)
+	err := r.db.QueryRow(ctx, query, r.cipher.BlindIndex(fieldEntryKey, key), key).Scan(&count)
 	if err != nil {
 		r.logger.WithError(err).Errorf("Failed to check active key: %s", key)
 		return false, fmt.Errorf("failed to check active key: %w", err)
@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/lbpay-lab/conn-dict/internal/domain/entities"
+)
+
+// EmptyVSync is the VSync of a key type without keys: the XOR of no CID
+const EmptyVSync = "0000000000000000000000000000000000000000000000000000000000000000"
+
+// VSyncAggregate is the local VSync of a participant's keys of one type, kept up to date by the
+// maintain_entries_vsync trigger on every entry write
+type VSyncAggregate struct {
+	KeyType    entities.KeyType `json:"key_type"`
+	EntryCount int64            `json:"entry_count"`
+	VSync      string           `json:"vsync"` // Hex XOR of the CIDs
+}
+
+// GetVSyncAggregate returns the local VSync of a participant's keys of one type: the XOR of the
+// key type's buckets in vsync_aggregates. A key type that never had keys has EmptyVSync.
+func (r *EntryRepository) GetVSyncAggregate(ctx context.Context, ispb string, keyType entities.KeyType) (*VSyncAggregate, error) {
+	query := `
+		SELECT COALESCE(SUM(entry_count), 0), BIT_XOR(vsync)
+		FROM vsync_aggregates
+		WHERE participant = $1 AND key_type = $2
+	`
+
+	aggregate := &VSyncAggregate{KeyType: keyType, VSync: EmptyVSync}
+	var vsync pgtype.Bits
+	if err := r.db.QueryRow(ctx, query, ispb, keyType).Scan(&aggregate.EntryCount, &vsync); err != nil {
+		r.logger.WithError(err).Errorf("Failed to get VSync aggregate: %s/%s", ispb, keyType)
+		return nil, fmt.Errorf("failed to query vsync aggregate: %w", err)
+	}
+
+	if vsync.Valid {
+		aggregate.VSync = hex.EncodeToString(vsync.Bytes)
+	}
+	return aggregate, nil
+}
+
+// ScanCIDs calls fn with the CID and entry ID of each of the participant's keys of one type that
+// count in the VSync aggregate, in CID order. Rows are streamed from the
+// (participant, key_type, cid) index, so memory does not grow with the number of keys.
+func (r *EntryRepository) ScanCIDs(ctx context.Context, ispb string, keyType entities.KeyType, fn func(cid, entryID string) error) error {
+	query := `
+		SELECT cid, entry_id
+		FROM entries
+		WHERE participant = $1 AND key_type = $2
+		  AND cid IS NOT NULL AND bacen_entry_id IS NOT NULL
+		  AND deleted_at IS NULL AND status <> 'INACTIVE'
+		ORDER BY cid
+	`
+
+	rows, err := r.db.Query(ctx, query, ispb, keyType)
+	if err != nil {
+		r.logger.WithError(err).Errorf("Failed to list CIDs: %s/%s", ispb, keyType)
+		return fmt.Errorf("failed to list cids: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cid, entryID string
+		if err := rows.Scan(&cid, &entryID); err != nil {
+			return fmt.Errorf("failed to scan cid: %w", err)
+		}
+		if err := fn(cid, entryID); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// entryCID is the CID column of an entry: NULL while the CID cannot be computed (no Bacen RequestId)
+func entryCID(entry *entities.Entry) *string {
+	cid := entry.CID()
+	if cid == "" {
+		return nil
+	}
+	return &cid
+}
+
+// BackfillCIDs stores the CID of up to limit entries written before CIDs existed and returns how
+// many were filled. The trigger adds them to the VSync aggregates. Entries without the RequestId
+// of a Bacen create/update (the CID key) and entries pseudonymised by a data subject erasure (no
+// key left) are skipped; rows are locked with FOR UPDATE SKIP LOCKED, so concurrent runs do not
+// overlap.
+func (r *EntryRepository) BackfillCIDs(ctx context.Context, limit int) (int, error) {
+	query := `
+		SELECT` + entryColumns + `
+		FROM entries
+		WHERE cid IS NULL AND bacen_request_id IS NOT NULL
+		  AND (key IS NOT NULL OR key_encrypted IS NOT NULL)
+		ORDER BY id
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	`
+
+	update := `UPDATE entries SET cid = $2 WHERE id = $1`
+
+	var count int
+	err := r.db.ExecuteInTransaction(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, query, limit)
+		if err != nil {
+			return fmt.Errorf("failed to select entries without cid: %w", err)
+		}
+		pending, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*entities.Entry, error) {
+			return r.scanEntry(ctx, row)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to read entries without cid: %w", err)
+		}
+
+		for _, entry := range pending {
+			if _, err := tx.Exec(ctx, update, entry.ID, entryCID(entry)); err != nil {
+				return fmt.Errorf("failed to store cid of entry %s: %w", entry.EntryID, err)
+			}
+		}
+		count = len(pending)
+		return nil
+	})
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to backfill entry CIDs")
+		return 0, err
+	}
+
+	return count, nil
+}
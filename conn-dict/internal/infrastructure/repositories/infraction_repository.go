@@ -33,6 +33,7 @@ func (r *InfractionRepository) Create(ctx context.Context, infraction *entities.
 			type, description, evidence_urls,
 			reporter_participant, reported_participant,
 			status, resolution_notes,
+			transaction_id, bacen_report_id,
 			reported_at, investigated_at, resolved_at,
 			created_at, updated_at
 		) VALUES (
@@ -40,8 +41,9 @@ func (r *InfractionRepository) Create(ctx context.Context, infraction *entities.
 			$6, $7, $8,
 			$9, $10,
 			$11, $12,
-			$13, $14, $15,
-			$16, $17
+			$13, $14,
+			$15, $16, $17,
+			$18, $19
 		)
 	`
 
@@ -50,6 +52,7 @@ func (r *InfractionRepository) Create(ctx context.Context, infraction *entities.
 		infraction.Type, infraction.Description, infraction.EvidenceURLs,
 		infraction.ReporterParticipant, infraction.ReportedParticipant,
 		infraction.Status, infraction.ResolutionNotes,
+		infraction.TransactionID, infraction.BacenReportID,
 		infraction.ReportedAt, infraction.InvestigatedAt, infraction.ResolvedAt,
 		infraction.CreatedAt, infraction.UpdatedAt,
 	)
@@ -63,6 +66,67 @@ func (r *InfractionRepository) Create(ctx context.Context, infraction *entities.
 	return nil
 }
 
+// Exists checks whether a infraction with the given infraction_id is already stored
+func (r *InfractionRepository) Exists(ctx context.Context, infractionID string) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM infractions WHERE infraction_id = $1)`
+
+	var exists bool
+	if err := r.db.QueryRow(ctx, query, infractionID).Scan(&exists); err != nil {
+		r.logger.WithError(err).Errorf("Failed to check infraction existence: %s", infractionID)
+		return false, fmt.Errorf("failed to check infraction existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+// CreateIfNotExists inserts an infraction unless one with the same infraction_id already exists.
+// It reports whether a new row was inserted.
+func (r *InfractionRepository) CreateIfNotExists(ctx context.Context, infraction *entities.Infraction) (bool, error) {
+	query := `
+		INSERT INTO infractions (
+			id, infraction_id, entry_id, claim_id, key,
+			type, description, evidence_urls,
+			reporter_participant, reported_participant,
+			status, resolution_notes,
+			transaction_id, bacen_report_id,
+			reported_at, investigated_at, resolved_at,
+			created_at, updated_at
+		) VALUES (
+			$1, $2, $3, $4, $5,
+			$6, $7, $8,
+			$9, $10,
+			$11, $12,
+			$13, $14,
+			$15, $16, $17,
+			$18, $19
+		)
+		ON CONFLICT (infraction_id) DO NOTHING
+	`
+
+	cmdTag, err := r.db.Exec(ctx, query,
+		infraction.ID, infraction.InfractionID, infraction.EntryID, infraction.ClaimID, infraction.Key,
+		infraction.Type, infraction.Description, infraction.EvidenceURLs,
+		infraction.ReporterParticipant, infraction.ReportedParticipant,
+		infraction.Status, infraction.ResolutionNotes,
+		infraction.TransactionID, infraction.BacenReportID,
+		infraction.ReportedAt, infraction.InvestigatedAt, infraction.ResolvedAt,
+		infraction.CreatedAt, infraction.UpdatedAt,
+	)
+
+	if err != nil {
+		r.logger.WithError(err).Errorf("Failed to create infraction: %s", infraction.InfractionID)
+		return false, fmt.Errorf("failed to insert infraction: %w", err)
+	}
+
+	inserted := cmdTag.RowsAffected() > 0
+	r.logger.WithFields(logrus.Fields{
+		"infraction_id": infraction.InfractionID,
+		"inserted":      inserted,
+	}).Info("Infraction create-if-not-exists completed")
+
+	return inserted, nil
+}
+
 // GetByID retrieves an infraction by UUID
 func (r *InfractionRepository) GetByID(ctx context.Context, id string) (*entities.Infraction, error) {
 	query := `
@@ -71,6 +135,7 @@ func (r *InfractionRepository) GetByID(ctx context.Context, id string) (*entitie
 			type, description, evidence_urls,
 			reporter_participant, reported_participant,
 			status, resolution_notes,
+			transaction_id, bacen_report_id,
 			reported_at, investigated_at, resolved_at,
 			created_at, updated_at, deleted_at
 		FROM infractions
@@ -83,6 +148,7 @@ func (r *InfractionRepository) GetByID(ctx context.Context, id string) (*entitie
 		&infraction.Type, &infraction.Description, &infraction.EvidenceURLs,
 		&infraction.ReporterParticipant, &infraction.ReportedParticipant,
 		&infraction.Status, &infraction.ResolutionNotes,
+		&infraction.TransactionID, &infraction.BacenReportID,
 		&infraction.ReportedAt, &infraction.InvestigatedAt, &infraction.ResolvedAt,
 		&infraction.CreatedAt, &infraction.UpdatedAt, &infraction.DeletedAt,
 	)
@@ -107,6 +173,7 @@ func (r *InfractionRepository) GetByInfractionID(ctx context.Context, infraction
 			type, description, evidence_urls,
 			reporter_participant, reported_participant,
 			status, resolution_notes,
+			transaction_id, bacen_report_id,
 			reported_at, investigated_at, resolved_at,
 			created_at, updated_at, deleted_at
 		FROM infractions
@@ -119,6 +186,7 @@ func (r *InfractionRepository) GetByInfractionID(ctx context.Context, infraction
 		&infraction.Type, &infraction.Description, &infraction.EvidenceURLs,
 		&infraction.ReporterParticipant, &infraction.ReportedParticipant,
 		&infraction.Status, &infraction.ResolutionNotes,
+		&infraction.TransactionID, &infraction.BacenReportID,
 		&infraction.ReportedAt, &infraction.InvestigatedAt, &infraction.ResolvedAt,
 		&infraction.CreatedAt, &infraction.UpdatedAt, &infraction.DeletedAt,
 	)
@@ -143,9 +211,10 @@ func (r *InfractionRepository) Update(ctx context.Context, infraction *entities.
 			type = $4, description = $5, evidence_urls = $6,
 			reporter_participant = $7, reported_participant = $8,
 			status = $9, resolution_notes = $10,
-			reported_at = $11, investigated_at = $12, resolved_at = $13,
-			updated_at = $14
-		WHERE infraction_id = $15 AND deleted_at IS NULL
+			transaction_id = $11, bacen_report_id = $12,
+			reported_at = $13, investigated_at = $14, resolved_at = $15,
+			updated_at = $16
+		WHERE infraction_id = $17 AND deleted_at IS NULL
 	`
 
 	cmdTag, err := r.db.Exec(ctx, query,
@@ -153,6 +222,7 @@ func (r *InfractionRepository) Update(ctx context.Context, infraction *entities.
 		infraction.Type, infraction.Description, infraction.EvidenceURLs,
 		infraction.ReporterParticipant, infraction.ReportedParticipant,
 		infraction.Status, infraction.ResolutionNotes,
+		infraction.TransactionID, infraction.BacenReportID,
 		infraction.ReportedAt, infraction.InvestigatedAt, infraction.ResolvedAt,
 		infraction.UpdatedAt,
 		infraction.InfractionID,
@@ -235,6 +305,7 @@ func (r *InfractionRepository) ListByKey(ctx context.Context, key string, limit,
 			type, description, evidence_urls,
 			reporter_participant, reported_participant,
 			status, resolution_notes,
+			transaction_id, bacen_report_id,
 			reported_at, investigated_at, resolved_at,
 			created_at, updated_at, deleted_at
 		FROM infractions
@@ -261,6 +332,7 @@ func (r *InfractionRepository) ListByReporter(ctx context.Context, ispb string,
 			type, description, evidence_urls,
 			reporter_participant, reported_participant,
 			status, resolution_notes,
+			transaction_id, bacen_report_id,
 			reported_at, investigated_at, resolved_at,
 			created_at, updated_at, deleted_at
 		FROM infractions
@@ -291,6 +363,7 @@ func (r *InfractionRepository) ListByStatus(
 			type, description, evidence_urls,
 			reporter_participant, reported_participant,
 			status, resolution_notes,
+			transaction_id, bacen_report_id,
 			reported_at, investigated_at, resolved_at,
 			created_at, updated_at, deleted_at
 		FROM infractions
@@ -317,6 +390,7 @@ func (r *InfractionRepository) ListOpen(ctx context.Context, limit int) ([]*enti
 			type, description, evidence_urls,
 			reporter_participant, reported_participant,
 			status, resolution_notes,
+			transaction_id, bacen_report_id,
 			reported_at, investigated_at, resolved_at,
 			created_at, updated_at, deleted_at
 		FROM infractions
@@ -347,6 +421,7 @@ func (r *InfractionRepository) scanInfractions(rows pgx.Rows) ([]*entities.Infra
 			&infraction.Type, &infraction.Description, &infraction.EvidenceURLs,
 			&infraction.ReporterParticipant, &infraction.ReportedParticipant,
 			&infraction.Status, &infraction.ResolutionNotes,
+			&infraction.TransactionID, &infraction.BacenReportID,
 			&infraction.ReportedAt, &infraction.InvestigatedAt, &infraction.ResolvedAt,
 			&infraction.CreatedAt, &infraction.UpdatedAt, &infraction.DeletedAt,
 		)
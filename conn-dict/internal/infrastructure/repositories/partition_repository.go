@@ -0,0 +1,286 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/lbpay-lab/conn-dict/internal/infrastructure/database"
+)
+
+// Monthly partitioned tables managed by the partition maintenance workflow.
+// Both are RANGE partitioned on occurred_at (see migration 004).
+const (
+	PartitionedTableAuditLogs = "audit_logs"
+	PartitionedTableEventLogs = "event_logs"
+)
+
+// partitionSuffixLayout is the TO_CHAR(start_date, 'YYYY_MM') suffix used by create_next_partition
+const partitionSuffixLayout = "2006_01"
+
+// MonthlyPartition describes one monthly partition of a partitioned table
+type MonthlyPartition struct {
+	Table string    `json:"table"` // Parent table (audit_logs, event_logs)
+	Name  string    `json:"name"`  // Partition name (e.g. audit_logs_2026_01)
+	Start time.Time `json:"start"` // Inclusive lower bound (first day of the month, UTC)
+	End   time.Time `json:"end"`   // Exclusive upper bound (first day of the next month, UTC)
+}
+
+// PartitionRepository manages monthly partitions of the audit and event log tables
+type PartitionRepository struct {
+	db     *database.PostgresClient
+	logger *logrus.Logger
+}
+
+// NewPartitionRepository creates a new PartitionRepository
+func NewPartitionRepository(db *database.PostgresClient, logger *logrus.Logger) *PartitionRepository {
+	return &PartitionRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// IsManagedPartitionedTable reports whether table is one of the partitioned tables this repository manages.
+// Table names are interpolated into DDL, so anything else is rejected.
+func IsManagedPartitionedTable(table string) bool {
+	return table == PartitionedTableAuditLogs || table == PartitionedTableEventLogs
+}
+
+// MonthStart truncates t to the first instant of its month in UTC
+func MonthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// PartitionName returns the partition name for the month containing t (e.g. audit_logs_2026_01)
+func PartitionName(table string, t time.Time) string {
+	return table + "_" + MonthStart(t).Format(partitionSuffixLayout)
+}
+
+// ParsePartitionName parses a partition name created by create_next_partition.
+// It returns false for partitions that don't follow the monthly naming scheme (e.g. a DEFAULT partition).
+func ParsePartitionName(table, name string) (MonthlyPartition, bool) {
+	suffix, ok := strings.CutPrefix(name, table+"_")
+	if !ok {
+		return MonthlyPartition{}, false
+	}
+	start, err := time.Parse(partitionSuffixLayout, suffix)
+	if err != nil {
+		return MonthlyPartition{}, false
+	}
+	return MonthlyPartition{
+		Table: table,
+		Name:  name,
+		Start: start,
+		End:   start.AddDate(0, 1, 0),
+	}, true
+}
+
+// ListPartitions returns the monthly partitions currently attached to table, ordered by month
+func (r *PartitionRepository) ListPartitions(ctx context.Context, table string) ([]MonthlyPartition, error) {
+	if !IsManagedPartitionedTable(table) {
+		return nil, fmt.Errorf("table %q is not a managed partitioned table", table)
+	}
+
+	query := `
+		SELECT child.relname
+		FROM pg_inherits inh
+		JOIN pg_class parent ON parent.oid = inh.inhparent
+		JOIN pg_class child ON child.oid = inh.inhrelid
+		WHERE parent.relname = $1
+		ORDER BY child.relname
+	`
+
+	rows, err := r.db.Query(ctx, query, table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list partitions of %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	var partitions []MonthlyPartition
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan partition name: %w", err)
+		}
+		partition, ok := ParsePartitionName(table, name)
+		if !ok {
+			r.logger.WithFields(logrus.Fields{
+				"table":     table,
+				"partition": name,
+			}).Debug("Ignoring partition outside the monthly naming scheme")
+			continue
+		}
+		partitions = append(partitions, partition)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating partitions: %w", err)
+	}
+
+	return partitions, nil
+}
+
+// CreateMonthlyPartition creates the partition for the month containing month.
+// It is idempotent: create_next_partition uses CREATE TABLE IF NOT EXISTS.
+func (r *PartitionRepository) CreateMonthlyPartition(ctx context.Context, table string, month time.Time) error {
+	if !IsManagedPartitionedTable(table) {
+		return fmt.Errorf("table %q is not a managed partitioned table", table)
+	}
+
+	start := MonthStart(month)
+	_, err := r.db.Exec(ctx, `SELECT create_next_partition($1, $2::date)`, table, start.Format("2006-01-02"))
+	if err != nil {
+		return fmt.Errorf("failed to create partition %s: %w", PartitionName(table, start), err)
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"table":     table,
+		"partition": PartitionName(table, start),
+	}).Debug("Partition ensured")
+
+	return nil
+}
+
+// ExportPartition streams every row of the partition to w as CSV (with header); the partition
+// may be attached or detached. Returns the number of rows exported.
+func (r *PartitionRepository) ExportPartition(ctx context.Context, partition MonthlyPartition, w io.Writer) (int64, error) {
+	if !IsManagedPartitionedTable(partition.Table) {
+		return 0, fmt.Errorf("table %q is not a managed partitioned table", partition.Table)
+	}
+
+	conn, err := r.db.GetPool().Acquire(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	copySQL := fmt.Sprintf(
+		"COPY (SELECT * FROM %s ORDER BY occurred_at) TO STDOUT WITH (FORMAT csv, HEADER true)",
+		pgx.Identifier{partition.Name}.Sanitize(),
+	)
+
+	tag, err := conn.Conn().PgConn().CopyTo(ctx, w, copySQL)
+	if err != nil {
+		return 0, fmt.Errorf("failed to export partition %s: %w", partition.Name, err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// DetachPartition detaches the partition from its parent table, leaving it as a standalone
+// table that no insert can reach. It is a no-op when the partition is no longer attached.
+func (r *PartitionRepository) DetachPartition(ctx context.Context, partition MonthlyPartition) error {
+	if !IsManagedPartitionedTable(partition.Table) {
+		return fmt.Errorf("table %q is not a managed partitioned table", partition.Table)
+	}
+
+	detachSQL := fmt.Sprintf("ALTER TABLE %s DETACH PARTITION %s",
+		pgx.Identifier{partition.Table}.Sanitize(),
+		pgx.Identifier{partition.Name}.Sanitize(),
+	)
+
+	return r.db.ExecuteInTransaction(ctx, func(tx pgx.Tx) error {
+		attached, err := isAttached(ctx, tx, partition)
+		if err != nil {
+			return err
+		}
+		if !attached {
+			return nil
+		}
+
+		if _, err := tx.Exec(ctx, detachSQL); err != nil {
+			return fmt.Errorf("failed to detach partition %s: %w", partition.Name, err)
+		}
+		return nil
+	})
+}
+
+// ListDetachedPartitions returns the monthly partitions of table that were detached but not
+// dropped yet (an archive interrupted after the detach), ordered by month
+func (r *PartitionRepository) ListDetachedPartitions(ctx context.Context, table string) ([]MonthlyPartition, error) {
+	if !IsManagedPartitionedTable(table) {
+		return nil, fmt.Errorf("table %q is not a managed partitioned table", table)
+	}
+
+	query := `
+		SELECT c.relname
+		FROM pg_class c
+		WHERE c.relkind = 'r'
+			AND c.relnamespace = current_schema()::regnamespace
+			AND c.relname LIKE $1 || '\_%'
+			AND NOT EXISTS (SELECT 1 FROM pg_inherits inh WHERE inh.inhrelid = c.oid)
+		ORDER BY c.relname
+	`
+
+	rows, err := r.db.Query(ctx, query, table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list detached partitions of %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	var partitions []MonthlyPartition
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan partition name: %w", err)
+		}
+		if partition, ok := ParsePartitionName(table, name); ok {
+			partitions = append(partitions, partition)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating detached partitions: %w", err)
+	}
+
+	return partitions, nil
+}
+
+// DropDetachedPartition drops a partition detached by DetachPartition. It refuses to drop a
+// partition that is still attached and is a no-op when the table no longer exists.
+func (r *PartitionRepository) DropDetachedPartition(ctx context.Context, partition MonthlyPartition) error {
+	if !IsManagedPartitionedTable(partition.Table) {
+		return fmt.Errorf("table %q is not a managed partitioned table", partition.Table)
+	}
+
+	dropSQL := fmt.Sprintf("DROP TABLE IF EXISTS %s", pgx.Identifier{partition.Name}.Sanitize())
+
+	return r.db.ExecuteInTransaction(ctx, func(tx pgx.Tx) error {
+		attached, err := isAttached(ctx, tx, partition)
+		if err != nil {
+			return err
+		}
+		if attached {
+			return fmt.Errorf("partition %s is still attached to %s", partition.Name, partition.Table)
+		}
+
+		if _, err := tx.Exec(ctx, dropSQL); err != nil {
+			return fmt.Errorf("failed to drop partition %s: %w", partition.Name, err)
+		}
+		return nil
+	})
+}
+
+// isAttached reports whether the partition is attached to its parent table
+func isAttached(ctx context.Context, tx pgx.Tx, partition MonthlyPartition) (bool, error) {
+	var attached bool
+	err := tx.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1
+			FROM pg_inherits inh
+			JOIN pg_class parent ON parent.oid = inh.inhparent
+			JOIN pg_class child ON child.oid = inh.inhrelid
+			WHERE parent.relname = $1 AND child.relname = $2
+		)
+	`, partition.Table, partition.Name).Scan(&attached)
+	if err != nil {
+		return false, fmt.Errorf("failed to check partition %s: %w", partition.Name, err)
+	}
+	return attached, nil
+}
@@ -0,0 +1,577 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/lbpay-lab/conn-dict/internal/domain/entities"
+	"github.com/lbpay-lab/conn-dict/internal/infrastructure/database"
+	"github.com/lbpay-lab/dict-contracts/fieldcrypto"
+	"github.com/sirupsen/logrus"
+)
+
+// Erasure reasons reported for retained records
+const (
+	retainedKeyRegistered   = "key is registered in DICT"
+	retainedWithinRetention = "closed within the legal retention period"
+	retainedAuditTrail      = "audit trail is kept for the legal retention period"
+)
+
+// erasedInfractionDescription replaces the free-text description of pseudonymised infractions
+// (the column is NOT NULL)
+const erasedInfractionDescription = "[erased: LGPD data subject request]"
+
+// PersonalDataRepository compiles and erases the personal data of a data subject (LGPD): the
+// entries owned by a tax ID, the claims and infractions of their keys, and the audit and event
+// logs of those records. core-dict reaches it through the ConnectService CollectPersonalData and
+// ErasePersonalData RPCs.
+type PersonalDataRepository struct {
+	db     *database.PostgresClient
+	cipher *fieldcrypto.Cipher
+	logger *logrus.Logger
+}
+
+// NewPersonalDataRepository creates a new PersonalDataRepository
+func NewPersonalDataRepository(db *database.PostgresClient, cipher *fieldcrypto.Cipher, logger *logrus.Logger) *PersonalDataRepository {
+	return &PersonalDataRepository{
+		db:     db,
+		cipher: cipher,
+		logger: logger,
+	}
+}
+
+// subjectEntry is an entry of the data subject
+type subjectEntry struct {
+	entryID   string
+	key       string
+	keyIndex  string
+	deletedAt *time.Time
+	record    *entities.PersonalDataRecord
+}
+
+// subjectCase is a claim or infraction tied to one of the subject's keys
+type subjectCase struct {
+	id       string
+	closed   bool
+	closedAt *time.Time
+	record   *entities.PersonalDataRecord
+}
+
+// CollectPersonalData returns every record tied to the tax ID
+func (r *PersonalDataRepository) CollectPersonalData(ctx context.Context, taxID string) ([]*entities.PersonalDataRecord, error) {
+	var records []*entities.PersonalDataRecord
+	err := r.db.ExecuteInTransaction(ctx, func(tx pgx.Tx) error {
+		entries, claims, infractions, err := r.findSubjectRecords(ctx, tx, taxID, false)
+		if err != nil {
+			return err
+		}
+
+		ids := make([]string, 0, len(entries)+len(claims)+len(infractions))
+		for _, entry := range entries {
+			ids = append(ids, entry.entryID)
+			records = append(records, entry.record)
+		}
+		for _, c := range append(claims, infractions...) {
+			ids = append(ids, c.id)
+			records = append(records, c.record)
+		}
+
+		logs, err := r.findLogs(ctx, tx, ids)
+		if err != nil {
+			return err
+		}
+		records = append(records, logs...)
+		return nil
+	})
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to collect personal data")
+		return nil, err
+	}
+	return records, nil
+}
+
+// ErasePersonalData pseudonymises the subject's records closed before retainAfter.
+//
+// Entries lose the key, the owner and the account; claims and infractions get the blind index
+// of the key in place of the key and lose their free-text notes. Entries still registered, open
+// cases, records closed within the retention period and the audit and event logs are retained.
+func (r *PersonalDataRepository) ErasePersonalData(ctx context.Context, taxID string, retainAfter time.Time, dryRun bool) ([]*entities.ErasureAction, error) {
+	var actions []*entities.ErasureAction
+	err := r.db.ExecuteInTransaction(ctx, func(tx pgx.Tx) error {
+		entries, claims, infractions, err := r.findSubjectRecords(ctx, tx, taxID, true)
+		if err != nil {
+			return err
+		}
+
+		ids := make([]string, 0, len(entries)+len(claims)+len(infractions))
+		for _, entry := range entries {
+			ids = append(ids, entry.entryID)
+
+			if reason := retentionReason(entry.deletedAt, retainAfter, retainedKeyRegistered); reason != "" {
+				actions = append(actions, retainedAction("entries", entry.entryID, reason))
+				continue
+			}
+			if !dryRun {
+				_, err := tx.Exec(ctx, `
+					UPDATE entries SET
+						key = NULL, key_encrypted = NULL,
+						key_index = COALESCE(key_index, $2),
+						owner_name = NULL, owner_name_encrypted = NULL,
+						owner_tax_id = NULL, owner_tax_id_encrypted = NULL,
+						owner_tax_id_index = COALESCE(owner_tax_id_index, $3),
+						account_branch = NULL, account_number = NULL
+					WHERE entry_id = $1
+				`, entry.entryID, r.cipher.BlindIndex(fieldEntryKey, entry.key), r.cipher.BlindIndex(fieldEntryOwnerTaxID, taxID))
+				if err != nil {
+					return fmt.Errorf("failed to pseudonymise entry %s: %w", entry.entryID, err)
+				}
+			}
+			actions = append(actions, pseudonymisedAction("entries", entry.entryID))
+		}
+
+		for _, claim := range claims {
+			ids = append(ids, claim.id)
+
+			if reason := caseRetentionReason(claim, retainAfter, "claim is open"); reason != "" {
+				actions = append(actions, retainedAction("claims", claim.id, reason))
+				continue
+			}
+			if !dryRun {
+				_, err := tx.Exec(ctx, `
+					UPDATE claims SET
+						key = $2,
+						claimer_account_branch = NULL, claimer_account_number = NULL,
+						notes = NULL
+					WHERE claim_id = $1
+				`, claim.id, r.keyPseudonym(claim.record))
+				if err != nil {
+					return fmt.Errorf("failed to pseudonymise claim %s: %w", claim.id, err)
+				}
+			}
+			actions = append(actions, pseudonymisedAction("claims", claim.id))
+		}
+
+		for _, infraction := range infractions {
+			ids = append(ids, infraction.id)
+
+			if reason := caseRetentionReason(infraction, retainAfter, "infraction is open"); reason != "" {
+				actions = append(actions, retainedAction("infractions", infraction.id, reason))
+				continue
+			}
+			if !dryRun {
+				_, err := tx.Exec(ctx, `
+					UPDATE infractions SET
+						key = $2,
+						description = $3,
+						evidence_urls = NULL, resolution_notes = NULL
+					WHERE infraction_id = $1
+				`, infraction.id, r.keyPseudonym(infraction.record), erasedInfractionDescription)
+				if err != nil {
+					return fmt.Errorf("failed to pseudonymise infraction %s: %w", infraction.id, err)
+				}
+			}
+			actions = append(actions, pseudonymisedAction("infractions", infraction.id))
+		}
+
+		// Audit and event logs are never changed
+		logs, err := r.findLogs(ctx, tx, ids)
+		if err != nil {
+			return err
+		}
+		for _, log := range logs {
+			actions = append(actions, retainedAction(log.Table, log.RecordID, retainedAuditTrail))
+		}
+		return nil
+	})
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to erase personal data")
+		return nil, err
+	}
+	return actions, nil
+}
+
+// keyPseudonym is the blind index stored in place of the key of a pseudonymised claim or
+// infraction. A key already pseudonymised is kept as is.
+func (r *PersonalDataRepository) keyPseudonym(record *entities.PersonalDataRecord) string {
+	key, _ := record.Fields["key"].(string)
+	if pseudonymised, _ := record.Fields["key_pseudonymised"].(bool); pseudonymised {
+		return key
+	}
+	return r.cipher.BlindIndex(fieldEntryKey, key)
+}
+
+// findSubjectRecords loads the entries owned by the tax ID (deleted ones included) and the claims
+// and infractions of their keys. With forUpdate the rows are locked for the erasure.
+func (r *PersonalDataRepository) findSubjectRecords(ctx context.Context, tx pgx.Tx, taxID string, forUpdate bool) ([]*subjectEntry, []*subjectCase, []*subjectCase, error) {
+	lock := ""
+	if forUpdate {
+		lock = " FOR UPDATE"
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT entry_id, key, key_encrypted, key_index, key_type, participant,
+		       account_branch, account_number, account_type, owner_type,
+		       owner_name, owner_name_encrypted, owner_tax_id, owner_tax_id_encrypted,
+		       status, registered_at, deactivated_at, created_at, deleted_at
+		FROM entries
+		WHERE owner_tax_id_index = $1 OR (owner_tax_id_index IS NULL AND owner_tax_id = $2)
+		ORDER BY created_at`+lock,
+		r.cipher.BlindIndex(fieldEntryOwnerTaxID, taxID), taxID,
+	)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to find subject entries: %w", err)
+	}
+	entries, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*subjectEntry, error) {
+		return r.scanEntry(ctx, row)
+	})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to read subject entries: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil, nil, nil, nil
+	}
+
+	// Claims and infractions reference the key itself, or its blind index once pseudonymised
+	entryIDs := make([]string, 0, len(entries))
+	keys := make([]string, 0, 2*len(entries))
+	pseudonyms := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		entryIDs = append(entryIDs, entry.entryID)
+		index := entry.keyIndex
+		if entry.key != "" {
+			keys = append(keys, entry.key)
+			index = r.cipher.BlindIndex(fieldEntryKey, entry.key)
+		}
+		if index != "" {
+			keys = append(keys, index)
+			pseudonyms[index] = true
+		}
+	}
+
+	rows, err = tx.Query(ctx, `
+		SELECT claim_id, type, key, key_type, status, donor_participant, claimer_participant,
+		       claimer_account_branch, claimer_account_number, claimer_account_type,
+		       cancellation_reason, notes, completed_at, cancelled_at, expired_at, created_at
+		FROM claims
+		WHERE key = ANY($1)
+		ORDER BY created_at`+lock,
+		keys,
+	)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to find subject claims: %w", err)
+	}
+	claims, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*subjectCase, error) {
+		var claimID, claimType, key, keyType, status, donor, claimer string
+		var branch, number, accountType, cancellationReason, notes *string
+		var completedAt, cancelledAt, expiredAt *time.Time
+		var createdAt time.Time
+		if err := row.Scan(&claimID, &claimType, &key, &keyType, &status, &donor, &claimer,
+			&branch, &number, &accountType, &cancellationReason, &notes,
+			&completedAt, &cancelledAt, &expiredAt, &createdAt); err != nil {
+			return nil, err
+		}
+		closed := status == "COMPLETED" || status == "CANCELLED" || status == "EXPIRED"
+		return &subjectCase{
+			id:       claimID,
+			closed:   closed,
+			closedAt: latest(completedAt, cancelledAt, expiredAt),
+			record: &entities.PersonalDataRecord{
+				Table:    "claims",
+				RecordID: claimID,
+				Fields: map[string]interface{}{
+					"type":                   claimType,
+					"key":                    key,
+					"key_pseudonymised":      pseudonyms[key],
+					"key_type":               keyType,
+					"status":                 status,
+					"donor_participant":      donor,
+					"claimer_participant":    claimer,
+					"claimer_account_branch": branch,
+					"claimer_account_number": number,
+					"claimer_account_type":   accountType,
+					"cancellation_reason":    cancellationReason,
+					"notes":                  notes,
+					"completed_at":           completedAt,
+					"cancelled_at":           cancelledAt,
+					"expired_at":             expiredAt,
+					"created_at":             createdAt,
+				},
+			},
+		}, nil
+	})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to read subject claims: %w", err)
+	}
+
+	rows, err = tx.Query(ctx, `
+		SELECT infraction_id, entry_id, claim_id, key, type, status, reporter_participant,
+		       reported_participant, description, evidence_urls, resolution_notes,
+		       reported_at, resolved_at
+		FROM infractions
+		WHERE entry_id = ANY($1) OR key = ANY($2)
+		ORDER BY reported_at`+lock,
+		entryIDs, keys,
+	)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to find subject infractions: %w", err)
+	}
+	infractions, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*subjectCase, error) {
+		var infractionID, key, infractionType, status, reporter, description string
+		var entryID, claimID, reported, resolutionNotes *string
+		var evidenceURLs []string
+		var reportedAt time.Time
+		var resolvedAt *time.Time
+		if err := row.Scan(&infractionID, &entryID, &claimID, &key, &infractionType, &status, &reporter,
+			&reported, &description, &evidenceURLs, &resolutionNotes, &reportedAt, &resolvedAt); err != nil {
+			return nil, err
+		}
+		return &subjectCase{
+			id:       infractionID,
+			closed:   status == "RESOLVED" || status == "DISMISSED",
+			closedAt: resolvedAt,
+			record: &entities.PersonalDataRecord{
+				Table:    "infractions",
+				RecordID: infractionID,
+				Fields: map[string]interface{}{
+					"entry_id":             entryID,
+					"claim_id":             claimID,
+					"key":                  key,
+					"key_pseudonymised":    pseudonyms[key],
+					"type":                 infractionType,
+					"status":               status,
+					"reporter_participant": reporter,
+					"reported_participant": reported,
+					"description":          description,
+					"evidence_urls":        evidenceURLs,
+					"resolution_notes":     resolutionNotes,
+					"reported_at":          reportedAt,
+					"resolved_at":          resolvedAt,
+				},
+			},
+		}, nil
+	})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to read subject infractions: %w", err)
+	}
+
+	return entries, claims, infractions, nil
+}
+
+// scanEntry scans and decrypts an entry row
+func (r *PersonalDataRepository) scanEntry(ctx context.Context, row pgx.Row) (*subjectEntry, error) {
+	entry := &subjectEntry{}
+	var key, keyIndex, branch, number, accountType, ownerType, ownerName, ownerTaxID *string
+	var keyEncrypted, ownerNameEncrypted, ownerTaxIDEncrypted []byte
+	var keyType, participant, status string
+	var registeredAt, deactivatedAt *time.Time
+	var createdAt time.Time
+
+	err := row.Scan(
+		&entry.entryID, &key, &keyEncrypted, &keyIndex, &keyType, &participant,
+		&branch, &number, &accountType, &ownerType,
+		&ownerName, &ownerNameEncrypted, &ownerTaxID, &ownerTaxIDEncrypted,
+		&status, &registeredAt, &deactivatedAt, &createdAt, &entry.deletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.key, err = r.open(ctx, fieldEntryKey, key, keyEncrypted); err != nil {
+		return nil, err
+	}
+	name, err := r.open(ctx, fieldEntryOwnerName, ownerName, ownerNameEncrypted)
+	if err != nil {
+		return nil, err
+	}
+	document, err := r.open(ctx, fieldEntryOwnerTaxID, ownerTaxID, ownerTaxIDEncrypted)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]interface{}{
+		"key":            entry.key,
+		"key_type":       keyType,
+		"participant":    participant,
+		"account_branch": branch,
+		"account_number": number,
+		"account_type":   accountType,
+		"owner_type":     ownerType,
+		"owner_name":     name,
+		"owner_tax_id":   document,
+		"status":         status,
+		"registered_at":  registeredAt,
+		"deactivated_at": deactivatedAt,
+		"created_at":     createdAt,
+		"deleted_at":     entry.deletedAt,
+	}
+	if keyIndex != nil {
+		entry.keyIndex = *keyIndex
+	}
+
+	entry.record = &entities.PersonalDataRecord{
+		Table:    "entries",
+		RecordID: entry.entryID,
+		Fields:   fields,
+	}
+	return entry, nil
+}
+
+// findLogs loads the audit and event logs of the given records
+func (r *PersonalDataRepository) findLogs(ctx context.Context, tx pgx.Tx, ids []string) ([]*entities.PersonalDataRecord, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT id::text, entity_type, entity_id, operation, actor_type, actor_id,
+		       old_values, new_values, reason, occurred_at
+		FROM audit_logs
+		WHERE entity_id = ANY($1)
+		ORDER BY occurred_at
+	`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find subject audit logs: %w", err)
+	}
+	auditLogs, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*entities.PersonalDataRecord, error) {
+		var id, entityType, entityID, operation string
+		var actorType, actorID, reason *string
+		var oldValues, newValues []byte
+		var occurredAt time.Time
+		if err := row.Scan(&id, &entityType, &entityID, &operation, &actorType, &actorID,
+			&oldValues, &newValues, &reason, &occurredAt); err != nil {
+			return nil, err
+		}
+		return &entities.PersonalDataRecord{
+			Table:    "audit_logs",
+			RecordID: id,
+			Fields: map[string]interface{}{
+				"entity_type": entityType,
+				"entity_id":   entityID,
+				"operation":   operation,
+				"actor_type":  actorType,
+				"actor_id":    actorID,
+				"old_values":  json.RawMessage(nullJSON(oldValues)),
+				"new_values":  json.RawMessage(nullJSON(newValues)),
+				"reason":      reason,
+				"occurred_at": occurredAt,
+			},
+		}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read subject audit logs: %w", err)
+	}
+
+	rows, err = tx.Query(ctx, `
+		SELECT event_id, event_type, aggregate_type, aggregate_id, payload, occurred_at
+		FROM event_logs
+		WHERE aggregate_id = ANY($1)
+		ORDER BY occurred_at
+	`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find subject event logs: %w", err)
+	}
+	eventLogs, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*entities.PersonalDataRecord, error) {
+		var eventID, eventType, aggregateType, aggregateID string
+		var payload []byte
+		var occurredAt time.Time
+		if err := row.Scan(&eventID, &eventType, &aggregateType, &aggregateID, &payload, &occurredAt); err != nil {
+			return nil, err
+		}
+		return &entities.PersonalDataRecord{
+			Table:    "event_logs",
+			RecordID: eventID,
+			Fields: map[string]interface{}{
+				"event_type":     eventType,
+				"aggregate_type": aggregateType,
+				"aggregate_id":   aggregateID,
+				"payload":        json.RawMessage(nullJSON(payload)),
+				"occurred_at":    occurredAt,
+			},
+		}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read subject event logs: %w", err)
+	}
+
+	return append(auditLogs, eventLogs...), nil
+}
+
+// open returns the value of an encrypted column, or its legacy plaintext while the
+// re-encryption job has not migrated the row yet ("" when both are NULL)
+func (r *PersonalDataRepository) open(ctx context.Context, field string, plaintext *string, encrypted []byte) (string, error) {
+	if encrypted != nil {
+		value, err := r.cipher.Decrypt(ctx, field, encrypted)
+		if err != nil {
+			return "", fmt.Errorf("failed to decrypt %s: %w", field, err)
+		}
+		return value, nil
+	}
+	if plaintext != nil {
+		return *plaintext, nil
+	}
+	return "", nil
+}
+
+// caseRetentionReason returns why a claim or infraction must be kept, or "" when it can be
+// pseudonymised
+func caseRetentionReason(c *subjectCase, retainAfter time.Time, openReason string) string {
+	if !c.closed {
+		return openReason
+	}
+	if c.closedAt == nil {
+		return retainedWithinRetention
+	}
+	return retentionReason(c.closedAt, retainAfter, openReason)
+}
+
+// retentionReason returns why a record closed at closedAt (nil = still active) must be kept,
+// or "" when it can be pseudonymised
+func retentionReason(closedAt *time.Time, retainAfter time.Time, activeReason string) string {
+	switch {
+	case closedAt == nil:
+		return activeReason
+	case !closedAt.Before(retainAfter):
+		return retainedWithinRetention
+	default:
+		return ""
+	}
+}
+
+// latest returns the latest of the non-nil times, or nil
+func latest(times ...*time.Time) *time.Time {
+	var result *time.Time
+	for _, t := range times {
+		if t != nil && (result == nil || t.After(*result)) {
+			result = t
+		}
+	}
+	return result
+}
+
+// nullJSON maps an empty JSONB column to a JSON null
+func nullJSON(raw []byte) []byte {
+	if len(raw) == 0 {
+		return []byte("null")
+	}
+	return raw
+}
+
+func retainedAction(table, recordID, reason string) *entities.ErasureAction {
+	return &entities.ErasureAction{
+		Table:    table,
+		RecordID: recordID,
+		Outcome:  entities.ErasureOutcomeRetained,
+		Reason:   reason,
+	}
+}
+
+func pseudonymisedAction(table, recordID string) *entities.ErasureAction {
+	return &entities.ErasureAction{
+		Table:    table,
+		RecordID: recordID,
+		Outcome:  entities.ErasureOutcomePseudonymised,
+	}
+}
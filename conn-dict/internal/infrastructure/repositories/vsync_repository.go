@@ -0,0 +1,528 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/lbpay-lab/conn-dict/internal/domain/entities"
+	"github.com/lbpay-lab/conn-dict/internal/infrastructure/database"
+	"github.com/lbpay-lab/dict-contracts/fieldcrypto"
+	"github.com/sirupsen/logrus"
+)
+
+// fieldVSyncBacenEntry is the cipher domain of the Bacen entries kept by VSYNC, in the staging
+// table and in the discrepancies alike, so a staged entry can be copied to a discrepancy in SQL
+const fieldVSyncBacenEntry = "vsync.bacen_entry"
+
+// Reasons stored with the discrepancies found by CompareStaged
+const (
+	reasonMissingLocal  = "Entry exists in Bacen DICT but not in local database"
+	reasonOutdatedLocal = "Entry data differs between Bacen and local database"
+	reasonMissingBacen  = "Entry exists locally but not in Bacen DICT - needs manual review (may be pending registration)"
+)
+
+// Actor types of the audit_logs rows written when a discrepancy changes status
+const (
+	AuditActorWorkflow = "WORKFLOW" // Repair policy, or repair approved by a reviewer
+	AuditActorUser     = "USER"     // Reviewer approving or rejecting
+)
+
+// auditEntityDiscrepancy is the audit_logs entity type of the VSYNC discrepancies
+const auditEntityDiscrepancy = "SYNC_DISCREPANCY"
+
+// ErrDiscrepancyNotFound is returned when no discrepancy has the requested ID
+var ErrDiscrepancyNotFound = errors.New("discrepancy not found")
+
+// ErrDiscrepancyChanged is returned when a discrepancy changed status since it was read
+var ErrDiscrepancyChanged = errors.New("discrepancy changed status concurrently")
+
+// discrepancyColumns are the columns read by scanDiscrepancy; the local entry's last change
+// comes from entries
+const discrepancyColumns = `
+	d.id, d.sync_id, d.discrepancy_type, d.participant, d.key_type, d.entry_id, d.bacen_entry,
+	d.reason, e.updated_at, d.status, d.action, d.assignee, d.reviewed_by, d.reviewed_at,
+	d.review_comment, d.applied_at, d.apply_error, d.created_at, d.updated_at
+	FROM vsync_discrepancies d
+	LEFT JOIN entries e ON e.entry_id = d.entry_id AND e.deleted_at IS NULL`
+
+// DiscrepancyFilter selects discrepancies of the review queue, in ID order
+type DiscrepancyFilter struct {
+	Statuses []entities.DiscrepancyStatus // Empty = every status
+	Assignee string
+	SyncID   string
+	AfterID  int64 // Keyset pagination: last ID of the previous page
+	Limit    int
+}
+
+// VSyncRepository handles the VSYNC work tables: the Bacen entries staged by a sync
+// (vsync_staged_entries) and the discrepancies it finds (vsync_discrepancies).
+//
+// Staged entries are matched with entries on the key blind index and compared on the CID, so a
+// sync is compared one key type at a time in SQL whatever the number of keys. The Bacen entries
+// carry PII and are stored envelope-encrypted.
+type VSyncRepository struct {
+	db     *database.PostgresClient
+	cipher *fieldcrypto.Cipher
+	logger *logrus.Logger
+}
+
+// NewVSyncRepository creates a new VSyncRepository
+func NewVSyncRepository(db *database.PostgresClient, cipher *fieldcrypto.Cipher, logger *logrus.Logger) *VSyncRepository {
+	return &VSyncRepository{
+		db:     db,
+		cipher: cipher,
+		logger: logger,
+	}
+}
+
+// StageEntries stores a page of Bacen entries for a sync and returns how many were staged. A key
+// staged twice (a page fetched again after a retry) keeps its latest data.
+func (r *VSyncRepository) StageEntries(ctx context.Context, syncID string, entries []*entities.Entry) (int, error) {
+	query := `
+		INSERT INTO vsync_staged_entries (sync_id, key_index, key_type, participant, cid, bacen_entry)
+		SELECT $1, s.key_index, s.key_type, s.participant, s.cid, s.bacen_entry
+		FROM unnest($2::text[], $3::text[], $4::text[], $5::text[], $6::bytea[])
+			AS s(key_index, key_type, participant, cid, bacen_entry)
+		ON CONFLICT (sync_id, key_index) DO UPDATE SET
+			key_type = EXCLUDED.key_type,
+			participant = EXCLUDED.participant,
+			cid = EXCLUDED.cid,
+			bacen_entry = EXCLUDED.bacen_entry,
+			staged_at = NOW()
+	`
+
+	// One row per key: ON CONFLICT cannot update a row inserted by the same statement
+	position := make(map[string]int, len(entries))
+	var keyIndexes, keyTypes, participants, cids []string
+	var sealed [][]byte
+	for _, entry := range entries {
+		envelope, err := r.sealEntry(ctx, entry)
+		if err != nil {
+			return 0, err
+		}
+		keyIndex := r.cipher.BlindIndex(fieldEntryKey, entry.Key)
+		if i, ok := position[keyIndex]; ok {
+			keyTypes[i], participants[i], cids[i], sealed[i] = string(entry.KeyType), entry.Participant, entry.CID(), envelope
+			continue
+		}
+		position[keyIndex] = len(keyIndexes)
+		keyIndexes = append(keyIndexes, keyIndex)
+		keyTypes = append(keyTypes, string(entry.KeyType))
+		participants = append(participants, entry.Participant)
+		cids = append(cids, entry.CID())
+		sealed = append(sealed, envelope)
+	}
+	if len(keyIndexes) == 0 {
+		return 0, nil
+	}
+
+	if _, err := r.db.Exec(ctx, query, syncID, keyIndexes, keyTypes, participants, cids, sealed); err != nil {
+		r.logger.WithError(err).Errorf("Failed to stage Bacen entries: %s", syncID)
+		return 0, fmt.Errorf("failed to stage bacen entries: %w", err)
+	}
+
+	return len(keyIndexes), nil
+}
+
+// CompareStaged compares the staged Bacen entries of one key type with the local entries and
+// stores the discrepancies found, in one transaction:
+//   - MISSING_LOCAL: staged key with no local entry
+//   - OUTDATED_LOCAL: local entry whose CID differs from Bacen's, or that is inactive
+//   - MISSING_BACEN: the participant's active local entry that was not staged; only with
+//     missingBacen, as it needs every Bacen entry of the participant to be staged (FULL sync)
+//
+// Discrepancies already stored for the sync are kept, so the comparison can be run again.
+func (r *VSyncRepository) CompareStaged(ctx context.Context, syncID, ispb string, keyType entities.KeyType, missingBacen bool) (*entities.DiscrepancyCounts, error) {
+	missingLocalQuery := `
+		INSERT INTO vsync_discrepancies (sync_id, discrepancy_type, participant, key_type, key_index, bacen_entry, reason)
+		SELECT s.sync_id, 'MISSING_LOCAL', s.participant, s.key_type, s.key_index, s.bacen_entry, $3
+		FROM vsync_staged_entries s
+		WHERE s.sync_id = $1 AND s.key_type = $2
+		  AND NOT EXISTS (
+			SELECT 1 FROM entries e
+			WHERE e.key_index = s.key_index AND e.deleted_at IS NULL
+		  )
+		ON CONFLICT (sync_id, discrepancy_type, key_index) DO NOTHING
+	`
+
+	outdatedLocalQuery := `
+		INSERT INTO vsync_discrepancies (sync_id, discrepancy_type, participant, key_type, key_index, entry_id, bacen_entry, reason)
+		SELECT s.sync_id, 'OUTDATED_LOCAL', s.participant, s.key_type, s.key_index, e.entry_id, s.bacen_entry, $3
+		FROM vsync_staged_entries s
+		JOIN entries e ON e.key_index = s.key_index AND e.deleted_at IS NULL
+		WHERE s.sync_id = $1 AND s.key_type = $2
+		  AND (e.cid IS DISTINCT FROM s.cid OR e.status = 'INACTIVE')
+		ON CONFLICT (sync_id, discrepancy_type, key_index) DO NOTHING
+	`
+
+	missingBacenQuery := `
+		INSERT INTO vsync_discrepancies (sync_id, discrepancy_type, participant, key_type, key_index, entry_id, reason)
+		SELECT $1, 'MISSING_BACEN', e.participant, e.key_type, e.key_index, e.entry_id, $3
+		FROM entries e
+		WHERE e.key_type = $2 AND e.participant = $4
+		  AND e.key_index IS NOT NULL AND e.deleted_at IS NULL AND e.status <> 'INACTIVE'
+		  AND NOT EXISTS (
+			SELECT 1 FROM vsync_staged_entries s
+			WHERE s.sync_id = $1 AND s.key_index = e.key_index
+		  )
+		ON CONFLICT (sync_id, discrepancy_type, key_index) DO NOTHING
+	`
+
+	counts := &entities.DiscrepancyCounts{}
+	err := r.db.ExecuteInTransaction(ctx, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, missingLocalQuery, syncID, keyType, reasonMissingLocal)
+		if err != nil {
+			return fmt.Errorf("failed to find entries missing locally: %w", err)
+		}
+		counts.MissingLocal = int(tag.RowsAffected())
+
+		tag, err = tx.Exec(ctx, outdatedLocalQuery, syncID, keyType, reasonOutdatedLocal)
+		if err != nil {
+			return fmt.Errorf("failed to find outdated entries: %w", err)
+		}
+		counts.OutdatedLocal = int(tag.RowsAffected())
+
+		if !missingBacen {
+			return nil
+		}
+		tag, err = tx.Exec(ctx, missingBacenQuery, syncID, keyType, reasonMissingBacen, ispb)
+		if err != nil {
+			return fmt.Errorf("failed to find entries missing in bacen: %w", err)
+		}
+		counts.MissingBacen = int(tag.RowsAffected())
+		return nil
+	})
+	if err != nil {
+		r.logger.WithError(err).Errorf("Failed to compare staged entries: %s/%s", syncID, keyType)
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// CreateDiscrepancy stores a discrepancy found outside the staging table (CID reconciliation).
+// A discrepancy already stored for the sync, type and key is kept as is.
+func (r *VSyncRepository) CreateDiscrepancy(ctx context.Context, discrepancy *entities.SyncDiscrepancy) error {
+	query := `
+		INSERT INTO vsync_discrepancies (sync_id, discrepancy_type, participant, key_type, key_index, entry_id, bacen_entry, reason)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (sync_id, discrepancy_type, key_index) DO NOTHING
+	`
+
+	var bacenEntry []byte
+	if discrepancy.BacenEntry != nil {
+		var err error
+		if bacenEntry, err = r.sealEntry(ctx, discrepancy.BacenEntry); err != nil {
+			return err
+		}
+	}
+
+	_, err := r.db.Exec(ctx, query,
+		discrepancy.SyncID,
+		discrepancy.Type,
+		discrepancy.Participant,
+		discrepancy.KeyType,
+		r.cipher.BlindIndex(fieldEntryKey, discrepancy.Key),
+		discrepancy.EntryID,
+		bacenEntry,
+		discrepancy.Reason,
+	)
+	if err != nil {
+		r.logger.WithError(err).Errorf("Failed to create discrepancy: %s", discrepancy.SyncID)
+		return fmt.Errorf("failed to insert discrepancy: %w", err)
+	}
+
+	return nil
+}
+
+// ListPendingDiscrepancies returns up to limit discrepancies of a sync not handled by the repair
+// policy yet with an ID above afterID, in ID order
+func (r *VSyncRepository) ListPendingDiscrepancies(ctx context.Context, syncID string, afterID int64, limit int) ([]*entities.SyncDiscrepancy, error) {
+	query := `SELECT ` + discrepancyColumns + `
+		WHERE d.sync_id = $1 AND d.status = 'PENDING' AND d.id > $2
+		ORDER BY d.id
+		LIMIT $3
+	`
+
+	rows, err := r.db.Query(ctx, query, syncID, afterID, limit)
+	if err != nil {
+		r.logger.WithError(err).Errorf("Failed to list discrepancies: %s", syncID)
+		return nil, fmt.Errorf("failed to list discrepancies: %w", err)
+	}
+
+	return r.collectDiscrepancies(ctx, rows)
+}
+
+// GetDiscrepancy returns a discrepancy by ID
+func (r *VSyncRepository) GetDiscrepancy(ctx context.Context, id int64) (*entities.SyncDiscrepancy, error) {
+	query := `SELECT ` + discrepancyColumns + ` WHERE d.id = $1`
+
+	discrepancy, err := r.scanDiscrepancy(ctx, r.db.QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %d", ErrDiscrepancyNotFound, id)
+	}
+	if err != nil {
+		r.logger.WithError(err).Errorf("Failed to get discrepancy: %d", id)
+		return nil, fmt.Errorf("failed to get discrepancy: %w", err)
+	}
+
+	return discrepancy, nil
+}
+
+// ListDiscrepancies returns the discrepancies matching the filter, in ID order
+func (r *VSyncRepository) ListDiscrepancies(ctx context.Context, filter DiscrepancyFilter) ([]*entities.SyncDiscrepancy, error) {
+	query := `SELECT ` + discrepancyColumns + `
+		WHERE d.id > $1
+		  AND (cardinality($2::text[]) = 0 OR d.status = ANY($2))
+		  AND ($3 = '' OR d.assignee = $3)
+		  AND ($4 = '' OR d.sync_id = $4)
+		ORDER BY d.id
+		LIMIT $5
+	`
+
+	statuses := make([]string, 0, len(filter.Statuses))
+	for _, status := range filter.Statuses {
+		statuses = append(statuses, string(status))
+	}
+
+	rows, err := r.db.Query(ctx, query, filter.AfterID, statuses, filter.Assignee, filter.SyncID, filter.Limit)
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to list discrepancies")
+		return nil, fmt.Errorf("failed to list discrepancies: %w", err)
+	}
+
+	return r.collectDiscrepancies(ctx, rows)
+}
+
+// TransitionDiscrepancy stores the new status of a discrepancy that had status from, and writes
+// the change to audit_logs with the sync report ID, in one transaction. It fails with
+// ErrDiscrepancyChanged when the discrepancy no longer has status from.
+func (r *VSyncRepository) TransitionDiscrepancy(ctx context.Context, discrepancy *entities.SyncDiscrepancy, from entities.DiscrepancyStatus, actorType, actorID string) error {
+	updateQuery := `
+		UPDATE vsync_discrepancies SET
+			status = $2, action = $3, assignee = $4, reviewed_by = $5, reviewed_at = $6,
+			review_comment = $7, applied_at = $8, apply_error = $9, updated_at = $10
+		WHERE id = $1 AND status = $11
+	`
+
+	auditQuery := `
+		INSERT INTO audit_logs (
+			entity_type, entity_id, operation, actor_type, actor_id, actor_participant,
+			old_values, new_values, reason, occurred_at, metadata
+		) VALUES ($1, $2, 'STATUS_CHANGE', $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	oldValues, err := json.Marshal(map[string]interface{}{"status": from})
+	if err != nil {
+		return fmt.Errorf("failed to marshal old values: %w", err)
+	}
+	newValues, err := json.Marshal(map[string]interface{}{
+		"status":   discrepancy.Status,
+		"action":   discrepancy.Action,
+		"assignee": discrepancy.Assignee,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal new values: %w", err)
+	}
+	metadata, err := json.Marshal(map[string]interface{}{
+		"sync_id":          discrepancy.SyncID,
+		"sync_report_id":   discrepancy.SyncReportID(),
+		"discrepancy_type": discrepancy.Type,
+		"key_type":         discrepancy.KeyType,
+		"entry_id":         discrepancy.EntryID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	reason := discrepancy.ReviewComment
+	if discrepancy.Status == entities.DiscrepancyStatusFailed {
+		reason = discrepancy.ApplyError
+	}
+
+	var action *entities.RepairAction
+	if discrepancy.Action != "" {
+		action = &discrepancy.Action
+	}
+
+	err = r.db.ExecuteInTransaction(ctx, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, updateQuery,
+			discrepancy.ID,
+			discrepancy.Status,
+			action,
+			discrepancy.Assignee,
+			discrepancy.ReviewedBy,
+			discrepancy.ReviewedAt,
+			discrepancy.ReviewComment,
+			discrepancy.AppliedAt,
+			discrepancy.ApplyError,
+			discrepancy.UpdatedAt,
+			from,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update discrepancy %d: %w", discrepancy.ID, err)
+		}
+		if tag.RowsAffected() == 0 {
+			return fmt.Errorf("%w: %d is no longer %s", ErrDiscrepancyChanged, discrepancy.ID, from)
+		}
+
+		_, err = tx.Exec(ctx, auditQuery,
+			auditEntityDiscrepancy,
+			fmt.Sprintf("%d", discrepancy.ID),
+			actorType,
+			actorID,
+			discrepancy.Participant,
+			oldValues,
+			newValues,
+			reason,
+			discrepancy.UpdatedAt,
+			metadata,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to audit discrepancy %d: %w", discrepancy.ID, err)
+		}
+		return nil
+	})
+	if err != nil {
+		r.logger.WithError(err).Errorf("Failed to change discrepancy status: %d %s -> %s", discrepancy.ID, from, discrepancy.Status)
+		return err
+	}
+
+	return nil
+}
+
+// CountDiscrepancies counts the discrepancies found by a sync, by type
+func (r *VSyncRepository) CountDiscrepancies(ctx context.Context, syncID string) (*entities.DiscrepancyCounts, error) {
+	query := `
+		SELECT discrepancy_type, COUNT(*)
+		FROM vsync_discrepancies
+		WHERE sync_id = $1
+		GROUP BY discrepancy_type
+	`
+
+	rows, err := r.db.Query(ctx, query, syncID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count discrepancies: %w", err)
+	}
+	defer rows.Close()
+
+	counts := &entities.DiscrepancyCounts{}
+	for rows.Next() {
+		var discrepancyType entities.DiscrepancyType
+		var count int
+		if err := rows.Scan(&discrepancyType, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan discrepancy count: %w", err)
+		}
+		switch discrepancyType {
+		case entities.DiscrepancyTypeMissingLocal:
+			counts.MissingLocal = count
+		case entities.DiscrepancyTypeOutdatedLocal:
+			counts.OutdatedLocal = count
+		case entities.DiscrepancyTypeMissingBacen:
+			counts.MissingBacen = count
+		}
+	}
+
+	return counts, rows.Err()
+}
+
+// DeleteStaged drops the entries staged by a sync, and those left behind by syncs that never
+// finished (older than a week). It returns how many rows were deleted.
+func (r *VSyncRepository) DeleteStaged(ctx context.Context, syncID string) (int64, error) {
+	query := `
+		DELETE FROM vsync_staged_entries
+		WHERE sync_id = $1 OR staged_at < NOW() - INTERVAL '7 days'
+	`
+
+	tag, err := r.db.Exec(ctx, query, syncID)
+	if err != nil {
+		r.logger.WithError(err).Errorf("Failed to delete staged entries: %s", syncID)
+		return 0, fmt.Errorf("failed to delete staged entries: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// collectDiscrepancies reads every row of a discrepancy query
+func (r *VSyncRepository) collectDiscrepancies(ctx context.Context, rows pgx.Rows) ([]*entities.SyncDiscrepancy, error) {
+	discrepancies, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*entities.SyncDiscrepancy, error) {
+		return r.scanDiscrepancy(ctx, row)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read discrepancies: %w", err)
+	}
+
+	return discrepancies, nil
+}
+
+// scanDiscrepancy reads a row of discrepancyColumns and opens its Bacen entry
+func (r *VSyncRepository) scanDiscrepancy(ctx context.Context, row pgx.Row) (*entities.SyncDiscrepancy, error) {
+	discrepancy := &entities.SyncDiscrepancy{}
+	var bacenEntry []byte
+	var action *string
+	err := row.Scan(
+		&discrepancy.ID,
+		&discrepancy.SyncID,
+		&discrepancy.Type,
+		&discrepancy.Participant,
+		&discrepancy.KeyType,
+		&discrepancy.EntryID,
+		&bacenEntry,
+		&discrepancy.Reason,
+		&discrepancy.EntryUpdatedAt,
+		&discrepancy.Status,
+		&action,
+		&discrepancy.Assignee,
+		&discrepancy.ReviewedBy,
+		&discrepancy.ReviewedAt,
+		&discrepancy.ReviewComment,
+		&discrepancy.AppliedAt,
+		&discrepancy.ApplyError,
+		&discrepancy.CreatedAt,
+		&discrepancy.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if action != nil {
+		discrepancy.Action = entities.RepairAction(*action)
+	}
+
+	if bacenEntry != nil {
+		if discrepancy.BacenEntry, err = r.openEntry(ctx, bacenEntry); err != nil {
+			return nil, fmt.Errorf("discrepancy %d: %w", discrepancy.ID, err)
+		}
+		discrepancy.Key = discrepancy.BacenEntry.Key
+	}
+
+	return discrepancy, nil
+}
+
+// sealEntry encrypts a Bacen entry for the VSYNC tables
+func (r *VSyncRepository) sealEntry(ctx context.Context, entry *entities.Entry) ([]byte, error) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal bacen entry: %w", err)
+	}
+	envelope, err := r.cipher.Encrypt(ctx, fieldVSyncBacenEntry, string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt bacen entry: %w", err)
+	}
+	return envelope, nil
+}
+
+// openEntry decrypts a Bacen entry sealed by sealEntry
+func (r *VSyncRepository) openEntry(ctx context.Context, envelope []byte) (*entities.Entry, error) {
+	data, err := r.cipher.Decrypt(ctx, fieldVSyncBacenEntry, envelope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt bacen entry: %w", err)
+	}
+	entry := &entities.Entry{}
+	if err := json.Unmarshal([]byte(data), entry); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal bacen entry: %w", err)
+	}
+	return entry, nil
+}
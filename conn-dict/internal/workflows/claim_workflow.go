@@ -6,38 +6,94 @@ import (
 
 	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/workflow"
+
+	"github.com/lbpay-lab/conn-dict/internal/activities"
+	claimtypes "github.com/lbpay-lab/conn-dict/workflows"
 )
 
 // ClaimWorkflowInput represents the input parameters for the Claim workflow
 type ClaimWorkflowInput struct {
-	ClaimID       string `json:"claim_id"`
-	EntryID       string `json:"entry_id"`
-	ClaimType     string `json:"claim_type"`      // "OWNERSHIP" or "PORTABILITY"
-	ClaimerISPB   string `json:"claimer_ispb"`
-	DonorISPB     string `json:"donor_ispb"`
-	ClaimerAccount string `json:"claimer_account"`
-	RequestedBy   string `json:"requested_by"`
+	ClaimID              string `json:"claim_id"`
+	EntryID              string `json:"entry_id"`
+	ClaimType            string `json:"claim_type"` // "OWNERSHIP" or "PORTABILITY"
+	Key                  string `json:"key"`
+	KeyType              string `json:"key_type"`
+	ClaimerISPB          string `json:"claimer_ispb"`
+	DonorISPB            string `json:"donor_ispb"`
+	ClaimerAccount       string `json:"claimer_account"`
+	ClaimerAccountBranch string `json:"claimer_account_branch,omitempty"`
+	ClaimerAccountType   string `json:"claimer_account_type,omitempty"` // CACC, SVGS, TRAN, SLRY
+	RequestedBy          string `json:"requested_by"`
+
+	// Phase durations (zero uses the defaults)
+	ResolutionPeriod time.Duration `json:"resolution_period,omitempty"`
+	CompletionPeriod time.Duration `json:"completion_period,omitempty"`
+
+	// Action when the donor doesn't respond within the resolution period
+	// (empty uses the default for the claim type, see DefaultResolutionTimeoutAction)
+	ResolutionTimeoutAction string `json:"resolution_timeout_action,omitempty"`
+
+	// Incoming marks a claim opened at Bacen by another participant against one of our keys (we
+	// are the donor). ClaimID is the Bacen claim ID and the claim is already stored by the poller.
+	Incoming bool `json:"incoming,omitempty"`
 }
 
 // ClaimWorkflowResult represents the result of the Claim workflow
 type ClaimWorkflowResult struct {
 	ClaimID       string    `json:"claim_id"`
+	BacenClaimID  string    `json:"bacen_claim_id,omitempty"`
 	Status        string    `json:"status"` // "COMPLETED", "CANCELLED", "EXPIRED"
+	AutoConfirmed bool      `json:"auto_confirmed,omitempty"`
+	ConfirmedAt   time.Time `json:"confirmed_at,omitempty"`
 	CompletedAt   time.Time `json:"completed_at,omitempty"`
 	CancelledAt   time.Time `json:"cancelled_at,omitempty"`
 	ExpiredAt     time.Time `json:"expired_at,omitempty"`
 	Reason        string    `json:"reason,omitempty"`
 	Message       string    `json:"message"`
+
+	// BacenError is the Bacen rejection that cancelled the claim, if any
+	BacenError *claimtypes.BacenRejection `json:"bacen_error,omitempty"`
+}
+
+// ClaimConfirmSignal is the payload of the "confirm" signal
+type ClaimConfirmSignal struct {
+	ConfirmedBy string `json:"confirmed_by"`
+}
+
+// ClaimCancelSignal is the payload of the "cancel" signal
+type ClaimCancelSignal struct {
+	Reason      string `json:"reason"`
+	CancelledBy string `json:"cancelled_by"`
+}
+
+// ClaimCompleteSignal is the payload of the "complete" signal
+type ClaimCompleteSignal struct {
+	CompletedBy string `json:"completed_by"`
 }
 
 const (
-	// ClaimTimeout is the maximum duration for a claim (30 days)
-	ClaimTimeout = 30 * 24 * time.Hour
+	// DefaultClaimResolutionPeriod is the time the donor has to confirm or cancel the claim (7 days)
+	DefaultClaimResolutionPeriod = 7 * 24 * time.Hour
 
-	// ClaimStatusPending indicates the claim is waiting for confirmation
-	ClaimStatusPending = "PENDING"
+	// DefaultClaimCompletionPeriod is the time the claimer has to complete a confirmed claim (30 days)
+	DefaultClaimCompletionPeriod = 30 * 24 * time.Hour
+
+	// ClaimSignalConfirm confirms the claim (donor accepts)
+	ClaimSignalConfirm = "confirm"
+
+	// ClaimSignalCancel cancels the claim (claimer or donor rejects)
+	ClaimSignalCancel = "cancel"
+
+	// ClaimSignalComplete completes a confirmed claim (claimer takes the key)
+	ClaimSignalComplete = "complete"
+
+	// ClaimStatusOpen indicates the claim was created and is being submitted to Bacen
+	ClaimStatusOpen = "OPEN"
+
+	// ClaimStatusWaitingResolution indicates the claim is waiting for the donor
+	ClaimStatusWaitingResolution = "WAITING_RESOLUTION"
 
-	// ClaimStatusConfirmed indicates the donor confirmed the claim
+	// ClaimStatusConfirmed indicates the claim was confirmed and is waiting for completion
 	ClaimStatusConfirmed = "CONFIRMED"
 
 	// ClaimStatusCancelled indicates the claim was cancelled
@@ -46,23 +102,80 @@ const (
 	// ClaimStatusCompleted indicates the claim was completed successfully
 	ClaimStatusCompleted = "COMPLETED"
 
-	// ClaimStatusExpired indicates the claim expired after 30 days
+	// ClaimStatusExpired indicates the claim was not completed within the completion period
 	ClaimStatusExpired = "EXPIRED"
+
+	// ClaimStatusPending indicates a claim of the single-period lifecycle whose final activity failed
+	ClaimStatusPending = "PENDING"
+
+	// ClaimTimeoutActionConfirm confirms the claim when the resolution period expires
+	ClaimTimeoutActionConfirm = "CONFIRM"
+
+	// ClaimTimeoutActionCancel cancels the claim when the resolution period expires
+	ClaimTimeoutActionCancel = "CANCEL"
+)
+
+// Workflow versions (workflow.GetVersion). Claims started before a change replay the old path.
+const (
+	// claimLifecycleChangeID versions the two-phase lifecycle (resolution and completion periods);
+	// before it, the claim waited on a single 30-day timer (see legacyClaimWorkflow)
+	claimLifecycleChangeID = "claim-lifecycle"
+
+	// claimCompletedEventChangeID versions the ClaimCompletedEvent published at the end of the workflow
+	claimCompletedEventChangeID = "claim-completed-event"
 )
 
-// ClaimWorkflow is the main Temporal workflow for handling PIX key portability claims
+// legacyClaimTimeout is the single period of claims started before the two-phase lifecycle
+const legacyClaimTimeout = 30 * 24 * time.Hour
+
+// Reasons sent to Bacen
+const (
+	claimReasonUserRequested           = "USER_REQUESTED"
+	claimReasonTimeout                 = "TIMEOUT"
+	claimReasonDefaultOperation        = "DEFAULT_OPERATION"
+	claimReasonResolutionPeriodExpired = "RESOLUTION_PERIOD_EXPIRED"
+)
+
+// DefaultResolutionTimeoutAction returns what happens when the donor doesn't respond within the
+// resolution period: an ownership claim is confirmed (the donor didn't defend the key), a
+// portability claim is cancelled (the donor must explicitly release the key).
+func DefaultResolutionTimeoutAction(claimType string) string {
+	if claimType == "OWNERSHIP" {
+		return ClaimTimeoutActionConfirm
+	}
+	return ClaimTimeoutActionCancel
+}
+
+// ClaimWorkflow is the main Temporal workflow for handling PIX key ownership and portability claims
+//
+// The claim goes through two phases, each with its own timer:
+//
+//	OPEN ──submit to Bacen──> WAITING_RESOLUTION ──confirm──> CONFIRMED ──complete──> COMPLETED
+//	  │                              │                             │
+//	  └─ rejected ──> CANCELLED      └─ cancel ──> CANCELLED       ├─ cancel ──> CANCELLED
+//	                                                               └─ completion period ──> EXPIRED
+//
+// 1. Resolution period (default 7 days): the donor confirms or cancels. When it expires, an
+// OWNERSHIP claim moves on to the completion period (the donor didn't defend the key) and a
+// PORTABILITY claim is cancelled, unless ResolutionTimeoutAction overrides it.
+// 2. Completion period (default 30 days, starts at confirmation): the claimer completes or cancels
+// the claim. When it expires, the claim is cancelled at Bacen and EXPIRED.
 //
-// This workflow implements the 30-day claim process defined by Bacen:
-// 1. Claim is created (PENDING)
-// 2. Wait for donor confirmation or 30 days timeout
-// 3. Three possible outcomes:
-//    a) Donor confirms → Claim COMPLETED
-//    b) Claimer/Donor cancels → Claim CANCELLED
-//    c) 30 days timeout → Claim EXPIRED
+// Only the donor confirms a claim at Bacen: for our own claims, "confirm" records the donor's
+// confirmation reported by Bacen. Every other transition is registered at Bacen (via Bridge)
+// before the local status changes. The final status is published to core-dict
+// (ClaimCompletedEvent), with the Bacen error when Bacen rejected the claim.
+//
+// Incoming claims (we are the donor, see IncomingPollerWorkflow) skip creation and submission and
+// only run the resolution period: the completion belongs to the claimer. When the resolution
+// period expires, Bacen applies the default operation itself and only the local status changes.
+//
+// Claims started before this lifecycle keep their single 30-day period (legacyClaimWorkflow).
 //
 // Signals:
-// - "confirm" → Confirms the claim (donor accepts)
-// - "cancel"  → Cancels the claim (claimer or donor rejects)
+// - "confirm"  → Confirms the claim (donor accepts), during the resolution period
+// - "cancel"   → Cancels the claim (claimer or donor rejects), in both periods
+// - "complete" → Completes the claim (claimer takes the key), during the completion period
 func ClaimWorkflow(ctx workflow.Context, input ClaimWorkflowInput) (*ClaimWorkflowResult, error) {
 	logger := workflow.GetLogger(ctx)
 	logger.Info("ClaimWorkflow started",
@@ -78,6 +191,11 @@ func ClaimWorkflow(ctx workflow.Context, input ClaimWorkflowInput) (*ClaimWorkfl
 		return nil, fmt.Errorf("invalid claim input: %w", err)
 	}
 
+	if workflow.GetVersion(ctx, claimLifecycleChangeID, workflow.DefaultVersion, 1) == workflow.DefaultVersion {
+		return legacyClaimWorkflow(ctx, input)
+	}
+	input = withClaimDefaults(input)
+
 	// Activity options
 	activityOptions := workflow.ActivityOptions{
 		StartToCloseTimeout: 30 * time.Second,
@@ -87,111 +205,505 @@ func ClaimWorkflow(ctx workflow.Context, input ClaimWorkflowInput) (*ClaimWorkfl
 	}
 	ctx = workflow.WithActivityOptions(ctx, activityOptions)
 
-	// Step 1: Create claim in database
-	logger.Info("Creating claim in database", "claim_id", input.ClaimID)
-	err := workflow.ExecuteActivity(ctx, "CreateClaimActivity", input).Get(ctx, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create claim: %w", err)
+	// Bacen calls go through Bridge: more attempts with backoff (Bacen may be unavailable for a while)
+	bacenCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    5 * time.Second,
+			BackoffCoefficient: 2.0,
+			MaximumInterval:    5 * time.Minute,
+			MaximumAttempts:    10,
+		},
+	})
+
+	claim := &claimLifecycle{
+		ctx:      ctx,
+		bacenCtx: bacenCtx,
+		input:    input,
+		result: &ClaimWorkflowResult{
+			ClaimID: input.ClaimID,
+			Status:  ClaimStatusOpen,
+		},
 	}
 
-	// Step 2: Notify donor about the claim
+	// Steps 1-2: Create the claim and submit it to Bacen (OPEN → WAITING_RESOLUTION), or take
+	// over an incoming claim already open at Bacen
+	if input.Incoming {
+		if err := claim.receive(); err != nil {
+			return nil, err
+		}
+	} else if err := claim.open(); err != nil {
+		return nil, err
+	}
+	if claim.result.Status == ClaimStatusCancelled {
+		return claim.finish(), nil
+	}
+
+	// Step 3: Notify donor about the claim
 	logger.Info("Notifying donor ISPB", "donor_ispb", input.DonorISPB)
-	err = workflow.ExecuteActivity(ctx, "NotifyDonorActivity", input).Get(ctx, nil)
+	err := workflow.ExecuteActivity(ctx, "NotifyDonorActivity", input.ClaimID).Get(ctx, nil)
 	if err != nil {
 		logger.Error("Failed to notify donor", "error", err)
 		// Non-critical error, continue workflow
 	}
 
-	// Step 3: Wait for confirmation, cancellation, or timeout (30 days)
-	result := &ClaimWorkflowResult{
-		ClaimID: input.ClaimID,
-	}
+	confirmChannel := workflow.GetSignalChannel(ctx, ClaimSignalConfirm)
+	cancelChannel := workflow.GetSignalChannel(ctx, ClaimSignalCancel)
+	completeChannel := workflow.GetSignalChannel(ctx, ClaimSignalComplete)
 
-	// Create channels for signals
-	confirmChannel := workflow.GetSignalChannel(ctx, "confirm")
-	cancelChannel := workflow.GetSignalChannel(ctx, "cancel")
+	// Step 4: Resolution period - wait for the donor
+	logger.Info("Waiting for donor resolution", "claim_id", input.ClaimID, "resolution_period", input.ResolutionPeriod)
 
-	// Selector to wait for signals or timeout
+	var phaseErr error
+	timerCtx, cancelTimer := workflow.WithCancel(ctx)
 	selector := workflow.NewSelector(ctx)
 
-	// Handle "confirm" signal
 	selector.AddReceive(confirmChannel, func(c workflow.ReceiveChannel, more bool) {
-		var confirmData map[string]interface{}
-		c.Receive(ctx, &confirmData)
+		var signal ClaimConfirmSignal
+		c.Receive(ctx, &signal)
 
-		logger.Info("Claim confirmed by donor", "claim_id", input.ClaimID)
+		logger.Info("Claim confirmed by donor", "claim_id", input.ClaimID, "confirmed_by", signal.ConfirmedBy)
+		phaseErr = claim.confirm(claimReasonDefaultOperation)
+	})
 
-		// Execute completion activity
-		err := workflow.ExecuteActivity(ctx, "CompleteClaimActivity", input.ClaimID).Get(ctx, nil)
-		if err != nil {
-			logger.Error("Failed to complete claim", "error", err)
-			result.Status = ClaimStatusPending // Retry later
+	selector.AddReceive(cancelChannel, func(c workflow.ReceiveChannel, more bool) {
+		var signal ClaimCancelSignal
+		c.Receive(ctx, &signal)
+
+		logger.Info("Claim cancelled during resolution period",
+			"claim_id", input.ClaimID,
+			"reason", signal.Reason,
+			"cancelled_by", signal.CancelledBy,
+		)
+		phaseErr = claim.cancel(claimReasonUserRequested, signal.Reason,
+			fmt.Sprintf("Claim cancelled by %s", signal.CancelledBy))
+	})
+
+	selector.AddFuture(workflow.NewTimer(timerCtx, input.ResolutionPeriod), func(f workflow.Future) {
+		logger.Info("Resolution period expired",
+			"claim_id", input.ClaimID,
+			"action", input.ResolutionTimeoutAction,
+		)
+		if input.Incoming {
+			phaseErr = claim.resolutionExpired()
 			return
 		}
+		if input.ResolutionTimeoutAction == ClaimTimeoutActionConfirm {
+			// Nothing to confirm at Bacen: the claimer may now complete or cancel the claim
+			claim.result.AutoConfirmed = true
+			phaseErr = claim.confirm(claimReasonResolutionPeriodExpired)
+			return
+		}
+		phaseErr = claim.cancel(claimReasonTimeout, "resolution period expired",
+			"Claim cancelled - donor did not respond within the resolution period")
+	})
 
-		result.Status = ClaimStatusCompleted
-		result.CompletedAt = workflow.Now(ctx)
-		result.Message = "Claim completed successfully - donor confirmed"
+	selector.Select(ctx)
+	cancelTimer()
+	if phaseErr != nil {
+		return nil, phaseErr
+	}
+	if claim.result.Status != ClaimStatusConfirmed || input.Incoming {
+		// Incoming claims are completed by the claimer: the poller mirrors the final status
+		return claim.finish(), nil
+	}
+
+	// Step 5: Completion period - wait for the claimer
+	logger.Info("Waiting for claim completion", "claim_id", input.ClaimID, "completion_period", input.CompletionPeriod)
+
+	timerCtx, cancelTimer = workflow.WithCancel(ctx)
+	selector = workflow.NewSelector(ctx)
+
+	selector.AddReceive(completeChannel, func(c workflow.ReceiveChannel, more bool) {
+		var signal ClaimCompleteSignal
+		c.Receive(ctx, &signal)
+
+		logger.Info("Claim completion requested", "claim_id", input.ClaimID, "completed_by", signal.CompletedBy)
+		phaseErr = claim.complete()
 	})
 
-	// Handle "cancel" signal
 	selector.AddReceive(cancelChannel, func(c workflow.ReceiveChannel, more bool) {
-		var cancelData struct {
-			Reason    string `json:"reason"`
-			CancelledBy string `json:"cancelled_by"`
-		}
-		c.Receive(ctx, &cancelData)
+		var signal ClaimCancelSignal
+		c.Receive(ctx, &signal)
 
-		logger.Info("Claim cancelled",
+		logger.Info("Claim cancelled during completion period",
 			"claim_id", input.ClaimID,
-			"reason", cancelData.Reason,
-			"cancelled_by", cancelData.CancelledBy,
+			"reason", signal.Reason,
+			"cancelled_by", signal.CancelledBy,
 		)
+		phaseErr = claim.cancel(claimReasonUserRequested, signal.Reason,
+			fmt.Sprintf("Claim cancelled by %s", signal.CancelledBy))
+	})
+
+	selector.AddFuture(workflow.NewTimer(timerCtx, input.CompletionPeriod), func(f workflow.Future) {
+		logger.Info("Completion period expired", "claim_id", input.ClaimID)
+		phaseErr = claim.expire()
+	})
+
+	selector.Select(ctx)
+	cancelTimer()
+	if phaseErr != nil {
+		return nil, phaseErr
+	}
 
-		// Execute cancellation activity
-		err := workflow.ExecuteActivity(ctx, "CancelClaimActivity", input.ClaimID, cancelData.Reason).Get(ctx, nil)
+	logger.Info("ClaimWorkflow completed",
+		"claim_id", input.ClaimID,
+		"status", claim.result.Status,
+	)
+
+	return claim.finish(), nil
+}
+
+// claimLifecycle executes the claim transitions: each one is registered at Bacen first and then
+// in the database
+type claimLifecycle struct {
+	ctx      workflow.Context
+	bacenCtx workflow.Context
+	input    ClaimWorkflowInput
+	result   *ClaimWorkflowResult
+}
+
+// open creates the claim in the database (OPEN) and submits it to Bacen
+func (l *claimLifecycle) open() error {
+	workflow.GetLogger(l.ctx).Info("Creating claim in database", "claim_id", l.input.ClaimID)
+	err := workflow.ExecuteActivity(l.ctx, "CreateClaimActivity", claimtypes.ClaimWorkflowInput{
+		ClaimID:              l.input.ClaimID,
+		Key:                  l.input.Key,
+		KeyType:              l.input.KeyType,
+		DonorISPB:            l.input.DonorISPB,
+		ClaimerISPB:          l.input.ClaimerISPB,
+		ClaimerAccountBranch: l.input.ClaimerAccountBranch,
+		ClaimerAccountNumber: l.input.ClaimerAccount,
+		ClaimerAccountType:   l.input.ClaimerAccountType,
+		ClaimType:            l.input.ClaimType,
+		RequestedBy:          l.input.RequestedBy,
+	}).Get(l.ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create claim: %w", err)
+	}
+
+	return l.submit()
+}
+
+// receive takes over an incoming claim: it is already open at Bacen under its own ID, so only the
+// local status moves to WAITING_RESOLUTION
+func (l *claimLifecycle) receive() error {
+	l.result.BacenClaimID = l.input.ClaimID
+
+	err := workflow.ExecuteActivity(l.ctx, "UpdateClaimStatusActivity", claimtypes.UpdateClaimStatusInput{
+		ClaimID: l.input.ClaimID,
+		Status:  ClaimStatusWaitingResolution,
+	}).Get(l.ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to move incoming claim to waiting resolution: %w", err)
+	}
+	l.result.Status = ClaimStatusWaitingResolution
+
+	return nil
+}
+
+// resolutionExpired records the default operation Bacen applies to an incoming claim we didn't
+// resolve in time (nothing is sent to Bacen)
+func (l *claimLifecycle) resolutionExpired() error {
+	if l.input.ResolutionTimeoutAction == ClaimTimeoutActionConfirm {
+		err := workflow.ExecuteActivity(l.ctx, "UpdateClaimStatusActivity", claimtypes.UpdateClaimStatusInput{
+			ClaimID: l.input.ClaimID,
+			Status:  ClaimStatusConfirmed,
+			Reason:  claimReasonResolutionPeriodExpired,
+		}).Get(l.ctx, nil)
 		if err != nil {
-			logger.Error("Failed to cancel claim", "error", err)
-			result.Status = ClaimStatusPending // Retry later
+			return fmt.Errorf("failed to confirm claim: %w", err)
+		}
+
+		l.result.Status = ClaimStatusConfirmed
+		l.result.AutoConfirmed = true
+		l.result.ConfirmedAt = workflow.Now(l.ctx)
+		l.result.Message = "Claim confirmed by Bacen - no resolution within the resolution period"
+		return nil
+	}
+
+	reason := "resolution period expired"
+	if err := workflow.ExecuteActivity(l.ctx, "CancelClaimActivity", l.input.ClaimID, reason).Get(l.ctx, nil); err != nil {
+		return fmt.Errorf("failed to cancel claim: %w", err)
+	}
+
+	l.result.Status = ClaimStatusCancelled
+	l.result.CancelledAt = workflow.Now(l.ctx)
+	l.result.Reason = reason
+	l.result.Message = "Claim cancelled by Bacen - no resolution within the resolution period"
+	return nil
+}
+
+// submit opens the claim at Bacen and moves it to WAITING_RESOLUTION. A claim rejected by Bacen
+// is cancelled.
+func (l *claimLifecycle) submit() error {
+	var submitted claimtypes.SubmitClaimToBacenResult
+	err := workflow.ExecuteActivity(l.bacenCtx, "SubmitClaimToBacenActivity", claimtypes.SubmitClaimToBacenInput{
+		ClaimID:              l.input.ClaimID,
+		EntryID:              l.input.EntryID,
+		Key:                  l.input.Key,
+		KeyType:              l.input.KeyType,
+		DonorISPB:            l.input.DonorISPB,
+		ClaimerISPB:          l.input.ClaimerISPB,
+		ClaimerAccountBranch: l.input.ClaimerAccountBranch,
+		ClaimerAccountNumber: l.input.ClaimerAccount,
+		ClaimerAccountType:   l.input.ClaimerAccountType,
+		ClaimType:            l.input.ClaimType,
+		CorrelationID:        workflow.GetInfo(l.ctx).WorkflowExecution.ID,
+		CompletionPeriod:     l.input.CompletionPeriod,
+	}).Get(l.ctx, &submitted)
+	if err != nil {
+		return fmt.Errorf("failed to submit claim to Bacen: %w", err)
+	}
+
+	if !submitted.Success {
+		reason := fmt.Sprintf("rejected by Bacen: %s", submitted.ErrorMessage)
+		if err := workflow.ExecuteActivity(l.ctx, "CancelClaimActivity", l.input.ClaimID, reason).Get(l.ctx, nil); err != nil {
+			return fmt.Errorf("failed to cancel claim: %w", err)
+		}
+		l.result.Status = ClaimStatusCancelled
+		l.result.CancelledAt = workflow.Now(l.ctx)
+		l.result.Reason = reason
+		l.result.Message = "Claim rejected by Bacen"
+		l.result.BacenError = submitted.Rejection
+		return nil
+	}
+	l.result.BacenClaimID = submitted.BacenCorrelationID
+
+	err = workflow.ExecuteActivity(l.ctx, "UpdateClaimStatusActivity", claimtypes.UpdateClaimStatusInput{
+		ClaimID: l.input.ClaimID,
+		Status:  ClaimStatusWaitingResolution,
+	}).Get(l.ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to move claim to waiting resolution: %w", err)
+	}
+	l.result.Status = ClaimStatusWaitingResolution
+
+	return nil
+}
+
+// confirm moves the claim to CONFIRMED. As the donor (incoming claims) we confirm it at Bacen
+// first; as the claimer we only record it: Bacen doesn't accept a confirmation from the claimer.
+func (l *claimLifecycle) confirm(bacenReason string) error {
+	if l.input.Incoming {
+		if err := l.bacen("ConfirmClaimAtBacenActivity", bacenReason); err != nil {
+			return fmt.Errorf("failed to confirm claim at Bacen: %w", err)
+		}
+	}
+
+	err := workflow.ExecuteActivity(l.ctx, "UpdateClaimStatusActivity", claimtypes.UpdateClaimStatusInput{
+		ClaimID: l.input.ClaimID,
+		Status:  ClaimStatusConfirmed,
+		Reason:  bacenReason,
+	}).Get(l.ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to confirm claim: %w", err)
+	}
+
+	l.result.Status = ClaimStatusConfirmed
+	l.result.ConfirmedAt = workflow.Now(l.ctx)
+	return nil
+}
+
+// complete completes the claim at Bacen, moves it to COMPLETED and transfers the key to the claimer
+func (l *claimLifecycle) complete() error {
+	if err := l.bacen("CompleteClaimAtBacenActivity", ""); err != nil {
+		return fmt.Errorf("failed to complete claim at Bacen: %w", err)
+	}
+
+	if err := workflow.ExecuteActivity(l.ctx, "CompleteClaimActivity", l.input.ClaimID).Get(l.ctx, nil); err != nil {
+		return fmt.Errorf("failed to complete claim: %w", err)
+	}
+
+	if l.input.Key != "" {
+		err := workflow.ExecuteActivity(l.ctx, "UpdateEntryOwnershipActivity", l.input.Key, l.input.ClaimerISPB).Get(l.ctx, nil)
+		if err != nil {
+			workflow.GetLogger(l.ctx).Error("Failed to update entry ownership", "error", err)
+			// Non-critical: the key already belongs to the claimer at Bacen (VSYNC reconciles it)
+		}
+	}
+
+	l.result.Status = ClaimStatusCompleted
+	l.result.CompletedAt = workflow.Now(l.ctx)
+	l.result.Message = "Claim completed successfully"
+	if l.result.AutoConfirmed {
+		l.result.Message = "Claim completed successfully - confirmed after the resolution period"
+	}
+	return nil
+}
+
+// cancel cancels the claim at Bacen and moves it to CANCELLED
+func (l *claimLifecycle) cancel(bacenReason, reason, message string) error {
+	if err := l.bacen("CancelClaimAtBacenActivity", bacenReason); err != nil {
+		return fmt.Errorf("failed to cancel claim at Bacen: %w", err)
+	}
+
+	if err := workflow.ExecuteActivity(l.ctx, "CancelClaimActivity", l.input.ClaimID, reason).Get(l.ctx, nil); err != nil {
+		return fmt.Errorf("failed to cancel claim: %w", err)
+	}
+
+	l.result.Status = ClaimStatusCancelled
+	l.result.CancelledAt = workflow.Now(l.ctx)
+	l.result.Reason = reason
+	l.result.Message = message
+	return nil
+}
+
+// expire cancels the claim at Bacen and moves it to EXPIRED (not completed within the completion period)
+func (l *claimLifecycle) expire() error {
+	if err := l.bacen("CancelClaimAtBacenActivity", claimReasonTimeout); err != nil {
+		return fmt.Errorf("failed to cancel expired claim at Bacen: %w", err)
+	}
+
+	if err := workflow.ExecuteActivity(l.ctx, "ExpireClaimActivity", l.input.ClaimID).Get(l.ctx, nil); err != nil {
+		return fmt.Errorf("failed to expire claim: %w", err)
+	}
+
+	l.result.Status = ClaimStatusExpired
+	l.result.ExpiredAt = workflow.Now(l.ctx)
+	l.result.Message = "Claim expired - not completed within the completion period"
+	return nil
+}
+
+// finish publishes the final status to core-dict and returns the workflow result. A failed
+// publication doesn't fail the claim: it already reached its final status at Bacen.
+func (l *claimLifecycle) finish() *ClaimWorkflowResult {
+	if workflow.GetVersion(l.ctx, claimCompletedEventChangeID, workflow.DefaultVersion, 1) == workflow.DefaultVersion {
+		return l.result
+	}
+
+	completedAt := workflow.Now(l.ctx)
+	for _, at := range []time.Time{l.result.CompletedAt, l.result.CancelledAt, l.result.ExpiredAt, l.result.ConfirmedAt} {
+		if !at.IsZero() {
+			completedAt = at
+			break
+		}
+	}
+
+	err := workflow.ExecuteActivity(l.ctx, "PublishClaimCompletedActivity", activities.ClaimCompletedEventInput{
+		ClaimID:     l.input.ClaimID,
+		EntryID:     l.input.EntryID,
+		KeyType:     l.input.KeyType,
+		Key:         l.input.Key,
+		ClaimerISPB: l.input.ClaimerISPB,
+		DonorISPB:   l.input.DonorISPB,
+		Status:      l.result.Status,
+		Reason:      l.result.Reason,
+		CompletedAt: completedAt,
+		Rejection:   l.result.BacenError,
+	}).Get(l.ctx, nil)
+	if err != nil {
+		workflow.GetLogger(l.ctx).Error("Failed to publish claim completed event", "claim_id", l.input.ClaimID, "error", err)
+	}
+
+	return l.result
+}
+
+// bacen executes one of the Bacen transition activities
+func (l *claimLifecycle) bacen(activityName, reason string) error {
+	return workflow.ExecuteActivity(l.bacenCtx, activityName, activities.ClaimBacenTransitionInput{
+		ClaimID:    l.input.ClaimID,
+		ExternalID: l.result.BacenClaimID,
+		Reason:     reason,
+	}).Get(l.ctx, nil)
+}
+
+// legacyClaimWorkflow is the lifecycle of claims started before claimLifecycleChangeID: the claim
+// is created, the donor notified and a single 30-day period waits for "confirm" (completes the
+// claim), "cancel" or the expiration. Kept unchanged so that these claims replay; new claims
+// never take it.
+func legacyClaimWorkflow(ctx workflow.Context, input ClaimWorkflowInput) (*ClaimWorkflowResult, error) {
+	logger := workflow.GetLogger(ctx)
+
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 30 * time.Second,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts: 3,
+		},
+	})
+
+	// Step 1: Create claim in database
+	if err := workflow.ExecuteActivity(ctx, "CreateClaimActivity", input).Get(ctx, nil); err != nil {
+		return nil, fmt.Errorf("failed to create claim: %w", err)
+	}
+
+	// Step 2: Notify donor about the claim
+	if err := workflow.ExecuteActivity(ctx, "NotifyDonorActivity", input).Get(ctx, nil); err != nil {
+		logger.Error("Failed to notify donor", "error", err)
+		// Non-critical error, continue workflow
+	}
+
+	// Step 3: Wait for confirmation, cancellation, or timeout (30 days)
+	claim := &claimLifecycle{
+		ctx:    ctx,
+		input:  input,
+		result: &ClaimWorkflowResult{ClaimID: input.ClaimID},
+	}
+	result := claim.result
+	selector := workflow.NewSelector(ctx)
+
+	selector.AddReceive(workflow.GetSignalChannel(ctx, ClaimSignalConfirm), func(c workflow.ReceiveChannel, more bool) {
+		var signal map[string]interface{}
+		c.Receive(ctx, &signal)
+
+		if err := workflow.ExecuteActivity(ctx, "CompleteClaimActivity", input.ClaimID).Get(ctx, nil); err != nil {
+			logger.Error("Failed to complete claim", "error", err)
+			result.Status = ClaimStatusPending
 			return
 		}
+		result.Status = ClaimStatusCompleted
+		result.CompletedAt = workflow.Now(ctx)
+		result.Message = "Claim completed successfully - donor confirmed"
+	})
 
+	selector.AddReceive(workflow.GetSignalChannel(ctx, ClaimSignalCancel), func(c workflow.ReceiveChannel, more bool) {
+		var signal ClaimCancelSignal
+		c.Receive(ctx, &signal)
+
+		if err := workflow.ExecuteActivity(ctx, "CancelClaimActivity", input.ClaimID, signal.Reason).Get(ctx, nil); err != nil {
+			logger.Error("Failed to cancel claim", "error", err)
+			result.Status = ClaimStatusPending
+			return
+		}
 		result.Status = ClaimStatusCancelled
 		result.CancelledAt = workflow.Now(ctx)
-		result.Reason = cancelData.Reason
-		result.Message = fmt.Sprintf("Claim cancelled by %s", cancelData.CancelledBy)
+		result.Reason = signal.Reason
+		result.Message = fmt.Sprintf("Claim cancelled by %s", signal.CancelledBy)
 	})
 
-	// Wait for signal or timeout (30 days)
-	logger.Info("Waiting for confirmation or cancellation (30 days timeout)...")
-
-	// Use a timer for 30 days
-	timer := workflow.NewTimer(ctx, ClaimTimeout)
-	selector.AddFuture(timer, func(f workflow.Future) {
-		logger.Info("Claim expired after 30 days", "claim_id", input.ClaimID)
-
-		// Execute expiration activity
-		err := workflow.ExecuteActivity(ctx, "ExpireClaimActivity", input.ClaimID).Get(ctx, nil)
-		if err != nil {
+	selector.AddFuture(workflow.NewTimer(ctx, legacyClaimTimeout), func(f workflow.Future) {
+		if err := workflow.ExecuteActivity(ctx, "ExpireClaimActivity", input.ClaimID).Get(ctx, nil); err != nil {
 			logger.Error("Failed to expire claim", "error", err)
-			result.Status = ClaimStatusPending // Retry later
+			result.Status = ClaimStatusPending
 			return
 		}
-
 		result.Status = ClaimStatusExpired
 		result.ExpiredAt = workflow.Now(ctx)
 		result.Message = "Claim expired after 30 days without confirmation"
 	})
 
-	// Block until one of the conditions is met
 	selector.Select(ctx)
 
-	logger.Info("ClaimWorkflow completed",
-		"claim_id", input.ClaimID,
-		"status", result.Status,
-	)
+	logger.Info("ClaimWorkflow completed", "claim_id", input.ClaimID, "status", result.Status)
 
-	return result, nil
+	if result.Status == ClaimStatusPending {
+		return result, nil
+	}
+	return claim.finish(), nil
+}
+
+// withClaimDefaults fills the phase durations and the resolution timeout action
+func withClaimDefaults(input ClaimWorkflowInput) ClaimWorkflowInput {
+	if input.ResolutionPeriod <= 0 {
+		input.ResolutionPeriod = DefaultClaimResolutionPeriod
+	}
+	if input.CompletionPeriod <= 0 {
+		input.CompletionPeriod = DefaultClaimCompletionPeriod
+	}
+	if input.ResolutionTimeoutAction == "" {
+		input.ResolutionTimeoutAction = DefaultResolutionTimeoutAction(input.ClaimType)
+	}
+	return input
 }
 
 // validateClaimInput validates the claim workflow input
@@ -199,7 +711,8 @@ func validateClaimInput(input ClaimWorkflowInput) error {
 	if input.ClaimID == "" {
 		return fmt.Errorf("claim_id is required")
 	}
-	if input.EntryID == "" {
+	// Incoming claims come from Bacen, which doesn't expose our entry ID
+	if input.EntryID == "" && !input.Incoming {
 		return fmt.Errorf("entry_id is required")
 	}
 	if input.ClaimType != "OWNERSHIP" && input.ClaimType != "PORTABILITY" {
@@ -211,6 +724,14 @@ func validateClaimInput(input ClaimWorkflowInput) error {
 	if input.DonorISPB == "" {
 		return fmt.Errorf("donor_ispb is required")
 	}
+	if input.ResolutionPeriod < 0 || input.CompletionPeriod < 0 {
+		return fmt.Errorf("resolution_period and completion_period cannot be negative")
+	}
+	switch input.ResolutionTimeoutAction {
+	case "", ClaimTimeoutActionConfirm, ClaimTimeoutActionCancel:
+	default:
+		return fmt.Errorf("resolution_timeout_action must be CONFIRM or CANCEL")
+	}
 
 	return nil
-}
\ No newline at end of file
+}
@@ -1,17 +1,35 @@
 package workflows
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/lbpay-lab/conn-dict/internal/activities"
+	claimtypes "github.com/lbpay-lab/conn-dict/workflows"
 )
 
 // ClaimWorkflowTestSuite is the test suite for ClaimWorkflow
 type ClaimWorkflowTestSuite struct {
 	suite.Suite
+	testsuite.WorkflowTestSuite
+
+	env *testsuite.TestWorkflowEnvironment
+
+	// Transitions executed by the workflow, in order ("ConfirmClaimAtBacen:TIMEOUT", "status:CONFIRMED", ...)
+	transitions []string
+	// Workflow clock when each transition happened
+	at map[string]time.Time
+	// ClaimCompletedEvents published by the workflow
+	published []activities.ClaimCompletedEventInput
 }
 
 // TestClaimWorkflowSuite runs the ClaimWorkflow test suite
@@ -21,66 +39,419 @@ func TestClaimWorkflowSuite(t *testing.T) {
 
 // SetupTest runs before each test
 func (s *ClaimWorkflowTestSuite) SetupTest() {
-	// Setup test fixtures
+	s.env = s.NewTestWorkflowEnvironment()
+	s.env.RegisterActivity(&activities.ClaimActivities{})
+	s.transitions = nil
+	s.at = make(map[string]time.Time)
+	s.published = nil
+}
+
+// mockClaimActivities mocks the claim activities, recording each transition. Tests that need a
+// different behaviour mock the activity before calling it (the first matching mock wins).
+func (s *ClaimWorkflowTestSuite) mockClaimActivities() {
+	s.env.OnActivity("CreateClaimActivity", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, input claimtypes.ClaimWorkflowInput) (*claimtypes.CreateClaimResult, error) {
+			s.record("create")
+			return &claimtypes.CreateClaimResult{ClaimID: input.ClaimID, Success: true}, nil
+		}).Maybe()
+
+	s.env.OnActivity("SubmitClaimToBacenActivity", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, input claimtypes.SubmitClaimToBacenInput) (*claimtypes.SubmitClaimToBacenResult, error) {
+			s.record("SubmitClaimToBacen")
+			return &claimtypes.SubmitClaimToBacenResult{Success: true, BacenCorrelationID: "BACEN-" + input.ClaimID}, nil
+		}).Maybe()
+
+	s.env.OnActivity("NotifyDonorActivity", mock.Anything, mock.Anything).Return(nil).Maybe()
+	s.env.OnActivity("UpdateEntryOwnershipActivity", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	s.env.OnActivity("UpdateClaimStatusActivity", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, input claimtypes.UpdateClaimStatusInput) error {
+			s.record("status:" + input.Status)
+			return nil
+		}).Maybe()
+
+	for _, name := range []string{"ConfirmClaimAtBacenActivity", "CompleteClaimAtBacenActivity", "CancelClaimAtBacenActivity"} {
+		name := name
+		s.env.OnActivity(name, mock.Anything, mock.Anything).
+			Return(func(_ context.Context, input activities.ClaimBacenTransitionInput) error {
+				assert.Equal(s.T(), "BACEN-claim-1", input.ExternalID)
+				s.record(name[:len(name)-len("Activity")] + ":" + input.Reason)
+				return nil
+			}).Maybe()
+	}
+
+	s.env.OnActivity("CompleteClaimActivity", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, claimID string) error {
+			s.record("status:" + ClaimStatusCompleted)
+			return nil
+		}).Maybe()
+
+	s.env.OnActivity("CancelClaimActivity", mock.Anything, mock.Anything, mock.Anything).
+		Return(func(_ context.Context, claimID, reason string) error {
+			s.record("status:" + ClaimStatusCancelled)
+			return nil
+		}).Maybe()
+
+	s.env.OnActivity("ExpireClaimActivity", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, claimID string) error {
+			s.record("status:" + ClaimStatusExpired)
+			return nil
+		}).Maybe()
+
+	s.env.OnActivity("PublishClaimCompletedActivity", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, input activities.ClaimCompletedEventInput) error {
+			s.published = append(s.published, input)
+			return nil
+		}).Maybe()
+}
+
+func (s *ClaimWorkflowTestSuite) AfterTest(suiteName, testName string) {
+	s.env.AssertExpectations(s.T())
+}
+
+func (s *ClaimWorkflowTestSuite) record(transition string) {
+	s.transitions = append(s.transitions, transition)
+	s.at[transition] = s.env.Now()
+}
+
+func (s *ClaimWorkflowTestSuite) signalAfter(delay time.Duration, name string, payload interface{}) {
+	s.env.RegisterDelayedCallback(func() {
+		s.env.SignalWorkflow(name, payload)
+	}, delay)
+}
+
+func (s *ClaimWorkflowTestSuite) execute(input ClaimWorkflowInput) *ClaimWorkflowResult {
+	s.env.ExecuteWorkflow(ClaimWorkflow, input)
+
+	require.True(s.T(), s.env.IsWorkflowCompleted())
+	require.NoError(s.T(), s.env.GetWorkflowError())
+
+	var result ClaimWorkflowResult
+	require.NoError(s.T(), s.env.GetWorkflowResult(&result))
+	return &result
+}
+
+func testClaimInput(claimType string) ClaimWorkflowInput {
+	return ClaimWorkflowInput{
+		ClaimID:     "claim-1",
+		EntryID:     "entry-1",
+		ClaimType:   claimType,
+		Key:         "+5511999999999",
+		KeyType:     "PHONE",
+		ClaimerISPB: "12345678",
+		DonorISPB:   "87654321",
+	}
+}
+
+// TestClaimWorkflow_ConfirmedAndCompleted tests the full path OPEN → WAITING_RESOLUTION → CONFIRMED → COMPLETED
+func (s *ClaimWorkflowTestSuite) TestClaimWorkflow_ConfirmedAndCompleted() {
+	s.mockClaimActivities()
+	start := s.env.Now()
+	s.signalAfter(2*24*time.Hour, ClaimSignalConfirm, ClaimConfirmSignal{ConfirmedBy: "donor"})
+	s.signalAfter(5*24*time.Hour, ClaimSignalComplete, ClaimCompleteSignal{CompletedBy: "claimer"})
+
+	result := s.execute(testClaimInput("PORTABILITY"))
+
+	assert.Equal(s.T(), ClaimStatusCompleted, result.Status)
+	assert.Equal(s.T(), "BACEN-claim-1", result.BacenClaimID)
+	assert.False(s.T(), result.AutoConfirmed)
+	assert.Equal(s.T(), []string{
+		"create",
+		"SubmitClaimToBacen",
+		"status:" + ClaimStatusWaitingResolution,
+		"status:" + ClaimStatusConfirmed,
+		"CompleteClaimAtBacen:",
+		"status:" + ClaimStatusCompleted,
+	}, s.transitions)
+	assert.Equal(s.T(), 2*24*time.Hour, s.at["status:"+ClaimStatusConfirmed].Sub(start))
+	assert.Equal(s.T(), 5*24*time.Hour, s.at["status:"+ClaimStatusCompleted].Sub(start))
+}
+
+// TestClaimWorkflow_OwnershipResolutionTimeoutConfirms tests that an unanswered ownership claim is confirmed
+func (s *ClaimWorkflowTestSuite) TestClaimWorkflow_OwnershipResolutionTimeoutConfirms() {
+	s.mockClaimActivities()
+	start := s.env.Now()
+	s.signalAfter(DefaultClaimResolutionPeriod+24*time.Hour, ClaimSignalComplete, ClaimCompleteSignal{CompletedBy: "claimer"})
+
+	result := s.execute(testClaimInput("OWNERSHIP"))
+
+	assert.Equal(s.T(), ClaimStatusCompleted, result.Status)
+	assert.True(s.T(), result.AutoConfirmed)
+	// The claimer can't confirm at Bacen: the claim only moves on to the completion period
+	assert.Equal(s.T(), []string{
+		"create",
+		"SubmitClaimToBacen",
+		"status:" + ClaimStatusWaitingResolution,
+		"status:" + ClaimStatusConfirmed,
+		"CompleteClaimAtBacen:",
+		"status:" + ClaimStatusCompleted,
+	}, s.transitions)
+	assert.Equal(s.T(), DefaultClaimResolutionPeriod, s.at["status:"+ClaimStatusConfirmed].Sub(start))
+}
+
+// TestClaimWorkflow_OwnershipResolutionTimeoutThenCancel tests that the claimer can cancel an
+// ownership claim after the resolution period expired
+func (s *ClaimWorkflowTestSuite) TestClaimWorkflow_OwnershipResolutionTimeoutThenCancel() {
+	s.mockClaimActivities()
+	s.signalAfter(DefaultClaimResolutionPeriod+time.Hour, ClaimSignalCancel, ClaimCancelSignal{Reason: "claimer gave up", CancelledBy: "claimer"})
+
+	result := s.execute(testClaimInput("OWNERSHIP"))
+
+	assert.Equal(s.T(), ClaimStatusCancelled, result.Status)
+	assert.Equal(s.T(), []string{
+		"create",
+		"SubmitClaimToBacen",
+		"status:" + ClaimStatusWaitingResolution,
+		"status:" + ClaimStatusConfirmed,
+		"CancelClaimAtBacen:" + claimReasonUserRequested,
+		"status:" + ClaimStatusCancelled,
+	}, s.transitions)
+}
+
+// TestClaimWorkflow_PortabilityResolutionTimeoutCancels tests that an unanswered portability claim is cancelled
+func (s *ClaimWorkflowTestSuite) TestClaimWorkflow_PortabilityResolutionTimeoutCancels() {
+	s.mockClaimActivities()
+	start := s.env.Now()
+
+	result := s.execute(testClaimInput("PORTABILITY"))
+
+	assert.Equal(s.T(), ClaimStatusCancelled, result.Status)
+	assert.Equal(s.T(), "resolution period expired", result.Reason)
+	assert.Equal(s.T(), []string{
+		"create",
+		"SubmitClaimToBacen",
+		"status:" + ClaimStatusWaitingResolution,
+		"CancelClaimAtBacen:" + claimReasonTimeout,
+		"status:" + ClaimStatusCancelled,
+	}, s.transitions)
+	assert.Equal(s.T(), DefaultClaimResolutionPeriod, s.at["status:"+ClaimStatusCancelled].Sub(start))
+}
+
+// TestClaimWorkflow_ResolutionTimeoutActionOverride tests that the default timeout action can be overridden
+func (s *ClaimWorkflowTestSuite) TestClaimWorkflow_ResolutionTimeoutActionOverride() {
+	s.mockClaimActivities()
+	input := testClaimInput("OWNERSHIP")
+	input.ResolutionTimeoutAction = ClaimTimeoutActionCancel
+
+	result := s.execute(input)
+
+	assert.Equal(s.T(), ClaimStatusCancelled, result.Status)
+	assert.NotContains(s.T(), s.transitions, "ConfirmClaimAtBacen:"+claimReasonResolutionPeriodExpired)
+}
+
+// TestClaimWorkflow_CompletionPeriodExpires tests the configurable periods and the EXPIRED outcome
+func (s *ClaimWorkflowTestSuite) TestClaimWorkflow_CompletionPeriodExpires() {
+	s.mockClaimActivities()
+	start := s.env.Now()
+	input := testClaimInput("PORTABILITY")
+	input.ResolutionPeriod = 2 * time.Hour
+	input.CompletionPeriod = 10 * time.Hour
+	s.signalAfter(30*time.Minute, ClaimSignalConfirm, ClaimConfirmSignal{ConfirmedBy: "donor"})
+
+	result := s.execute(input)
+
+	assert.Equal(s.T(), ClaimStatusExpired, result.Status)
+	assert.Equal(s.T(), []string{
+		"create",
+		"SubmitClaimToBacen",
+		"status:" + ClaimStatusWaitingResolution,
+		"status:" + ClaimStatusConfirmed,
+		"CancelClaimAtBacen:" + claimReasonTimeout,
+		"status:" + ClaimStatusExpired,
+	}, s.transitions)
+	// The completion period starts at confirmation
+	assert.Equal(s.T(), 30*time.Minute+10*time.Hour, s.at["status:"+ClaimStatusExpired].Sub(start))
 }
 
-// TearDownTest runs after each test
-func (s *ClaimWorkflowTestSuite) TearDownTest() {
-	// Cleanup
+// TestClaimWorkflow_CancelDuringResolution tests cancellation while waiting for the donor
+func (s *ClaimWorkflowTestSuite) TestClaimWorkflow_CancelDuringResolution() {
+	s.mockClaimActivities()
+	s.signalAfter(time.Hour, ClaimSignalCancel, ClaimCancelSignal{Reason: "donor rejected", CancelledBy: "donor"})
+
+	result := s.execute(testClaimInput("OWNERSHIP"))
+
+	assert.Equal(s.T(), ClaimStatusCancelled, result.Status)
+	assert.Equal(s.T(), "donor rejected", result.Reason)
+	assert.Contains(s.T(), s.transitions, "CancelClaimAtBacen:"+claimReasonUserRequested)
+	assert.NotContains(s.T(), s.transitions, "status:"+ClaimStatusConfirmed)
+}
+
+// TestClaimWorkflow_CancelDuringCompletion tests cancellation of a confirmed claim
+func (s *ClaimWorkflowTestSuite) TestClaimWorkflow_CancelDuringCompletion() {
+	s.mockClaimActivities()
+	s.signalAfter(time.Hour, ClaimSignalConfirm, ClaimConfirmSignal{ConfirmedBy: "donor"})
+	s.signalAfter(3*24*time.Hour, ClaimSignalCancel, ClaimCancelSignal{Reason: "claimer gave up", CancelledBy: "claimer"})
+
+	result := s.execute(testClaimInput("PORTABILITY"))
+
+	assert.Equal(s.T(), ClaimStatusCancelled, result.Status)
+	assert.Equal(s.T(), []string{
+		"create",
+		"SubmitClaimToBacen",
+		"status:" + ClaimStatusWaitingResolution,
+		"status:" + ClaimStatusConfirmed,
+		"CancelClaimAtBacen:" + claimReasonUserRequested,
+		"status:" + ClaimStatusCancelled,
+	}, s.transitions)
 }
 
-// TestClaimWorkflow_BasicFlow tests the basic claim workflow
-func (s *ClaimWorkflowTestSuite) TestClaimWorkflow_BasicFlow() {
-	t := s.T()
+// TestClaimWorkflow_EarlyCompleteWaitsForConfirmation tests that "complete" only takes effect after confirmation
+func (s *ClaimWorkflowTestSuite) TestClaimWorkflow_EarlyCompleteWaitsForConfirmation() {
+	s.mockClaimActivities()
+	start := s.env.Now()
+	s.signalAfter(time.Hour, ClaimSignalComplete, ClaimCompleteSignal{CompletedBy: "claimer"})
+	s.signalAfter(2*time.Hour, ClaimSignalConfirm, ClaimConfirmSignal{ConfirmedBy: "donor"})
 
-	// TODO: Implement when ClaimWorkflow is created
-	// For now, just test basic structure
+	result := s.execute(testClaimInput("PORTABILITY"))
 
-	claimID := "test-claim-123"
-	assert.NotEmpty(t, claimID)
-	require.NotNil(t, t)
+	// The buffered "complete" signal is handled as soon as the completion period starts
+	assert.Equal(s.T(), ClaimStatusCompleted, result.Status)
+	assert.Equal(s.T(), 2*time.Hour, s.at["status:"+ClaimStatusCompleted].Sub(start))
 }
 
-// TestClaimWorkflow_Timeout tests the 30-day timeout
-func (s *ClaimWorkflowTestSuite) TestClaimWorkflow_Timeout() {
-	t := s.T()
+// TestClaimWorkflow_RejectedByBacen tests that a claim rejected by Bacen is cancelled without waiting
+func (s *ClaimWorkflowTestSuite) TestClaimWorkflow_RejectedByBacen() {
+	rejection := &claimtypes.BacenRejection{Code: "EntryLockedByClaim", Message: "key already claimed", HTTPStatus: 400}
+	s.env.OnActivity("SubmitClaimToBacenActivity", mock.Anything, mock.Anything).
+		Return(&claimtypes.SubmitClaimToBacenResult{Success: false, ErrorMessage: "key already claimed", Rejection: rejection}, nil).Once()
+	s.mockClaimActivities()
+
+	s.env.ExecuteWorkflow(ClaimWorkflow, testClaimInput("PORTABILITY"))
+
+	require.True(s.T(), s.env.IsWorkflowCompleted())
+	require.NoError(s.T(), s.env.GetWorkflowError())
+	var result ClaimWorkflowResult
+	require.NoError(s.T(), s.env.GetWorkflowResult(&result))
 
-	// 30 days timeout
-	timeout := 30 * 24 * time.Hour
+	assert.Equal(s.T(), ClaimStatusCancelled, result.Status)
+	assert.Contains(s.T(), result.Reason, "key already claimed")
+	assert.NotContains(s.T(), s.transitions, "status:"+ClaimStatusWaitingResolution)
+	assert.Equal(s.T(), rejection, result.BacenError)
 
-	assert.Equal(t, 720*time.Hour, timeout)
-	require.Greater(t, timeout, 0*time.Hour)
+	// core-dict receives the Bacen error with the final status
+	require.Len(s.T(), s.published, 1)
+	assert.Equal(s.T(), ClaimStatusCancelled, s.published[0].Status)
+	assert.Equal(s.T(), rejection, s.published[0].Rejection)
 }
 
-// TestClaimWorkflow_ConfirmScenario tests confirm claim scenario
-func (s *ClaimWorkflowTestSuite) TestClaimWorkflow_ConfirmScenario() {
-	t := s.T()
+// TestClaimWorkflow_BacenRejectionKeepsDetails tests that a transition rejected by Bacen fails the
+// workflow with the Bacen error details
+func (s *ClaimWorkflowTestSuite) TestClaimWorkflow_BacenRejectionKeepsDetails() {
+	rejection := claimtypes.BacenRejection{Code: "InvalidClaimStatus", Message: "claim is not waiting resolution", HTTPStatus: 400}
+	s.env.OnActivity("ConfirmClaimAtBacenActivity", mock.Anything, mock.Anything).
+		Return(claimtypes.NewBacenRejectedError("Bacen rejected claim confirmation", rejection, nil)).Once()
+	s.mockClaimActivities()
+	s.signalAfter(time.Hour, ClaimSignalConfirm, ClaimConfirmSignal{ConfirmedBy: "donor"})
 
-	// TODO: Implement when ClaimWorkflow is created
-	// Test scenario: Claim is confirmed within 30 days
+	s.env.ExecuteWorkflow(ClaimWorkflow, testIncomingClaimInput("PORTABILITY"))
 
-	scenarios := []string{"confirm", "cancel", "expire"}
-	assert.Contains(t, scenarios, "confirm")
+	require.True(s.T(), s.env.IsWorkflowCompleted())
+	got, ok := claimtypes.BacenRejectionFromError(s.env.GetWorkflowError())
+	require.True(s.T(), ok)
+	assert.Equal(s.T(), rejection, *got)
+	assert.NotContains(s.T(), s.transitions, "status:"+ClaimStatusConfirmed)
+	assert.Empty(s.T(), s.published)
 }
 
-// TestClaimWorkflow_CancelScenario tests cancel claim scenario
-func (s *ClaimWorkflowTestSuite) TestClaimWorkflow_CancelScenario() {
-	t := s.T()
+// TestClaimWorkflow_BacenFailureFailsWorkflow tests that the local status isn't changed when Bacen fails
+func (s *ClaimWorkflowTestSuite) TestClaimWorkflow_BacenFailureFailsWorkflow() {
+	s.env.OnActivity("ConfirmClaimAtBacenActivity", mock.Anything, mock.Anything).
+		Return(errors.New("bridge unavailable"))
+	s.mockClaimActivities()
+	s.signalAfter(time.Hour, ClaimSignalConfirm, ClaimConfirmSignal{ConfirmedBy: "donor"})
 
-	// TODO: Implement when ClaimWorkflow is created
-	// Test scenario: Claim is cancelled
+	s.env.ExecuteWorkflow(ClaimWorkflow, testIncomingClaimInput("PORTABILITY"))
 
-	scenarios := []string{"confirm", "cancel", "expire"}
-	assert.Contains(t, scenarios, "cancel")
+	require.True(s.T(), s.env.IsWorkflowCompleted())
+	assert.Error(s.T(), s.env.GetWorkflowError())
+	assert.NotContains(s.T(), s.transitions, "status:"+ClaimStatusConfirmed)
 }
 
-// TestClaimWorkflow_ExpireScenario tests expire claim scenario
-func (s *ClaimWorkflowTestSuite) TestClaimWorkflow_ExpireScenario() {
-	t := s.T()
+// TestClaimWorkflow_InvalidInput tests input validation
+func (s *ClaimWorkflowTestSuite) TestClaimWorkflow_InvalidInput() {
+	input := testClaimInput("TRANSFER")
 
-	// TODO: Implement when ClaimWorkflow is created
-	// Test scenario: Claim expires after 30 days
+	s.env.ExecuteWorkflow(ClaimWorkflow, input)
+
+	require.True(s.T(), s.env.IsWorkflowCompleted())
+	assert.Error(s.T(), s.env.GetWorkflowError())
+	assert.Empty(s.T(), s.transitions)
+}
 
-	scenarios := []string{"confirm", "cancel", "expire"}
-	assert.Contains(t, scenarios, "expire")
-}
\ No newline at end of file
+// testIncomingClaimInput returns an incoming claim, identified by its Bacen claim ID
+func testIncomingClaimInput(claimType string) ClaimWorkflowInput {
+	input := testClaimInput(claimType)
+	input.ClaimID = "BACEN-claim-1"
+	input.EntryID = ""
+	input.ClaimerISPB, input.DonorISPB = input.DonorISPB, input.ClaimerISPB
+	input.ResolutionPeriod = 3 * 24 * time.Hour
+	input.Incoming = true
+	return input
+}
+
+// TestClaimWorkflow_IncomingConfirmed tests that an incoming claim skips creation and ends at confirmation
+func (s *ClaimWorkflowTestSuite) TestClaimWorkflow_IncomingConfirmed() {
+	s.mockClaimActivities()
+	s.signalAfter(time.Hour, ClaimSignalConfirm, ClaimConfirmSignal{ConfirmedBy: "donor"})
+
+	result := s.execute(testIncomingClaimInput("PORTABILITY"))
+
+	assert.Equal(s.T(), ClaimStatusConfirmed, result.Status)
+	assert.Equal(s.T(), "BACEN-claim-1", result.BacenClaimID)
+	assert.Equal(s.T(), []string{
+		"status:" + ClaimStatusWaitingResolution,
+		"ConfirmClaimAtBacen:" + claimReasonDefaultOperation,
+		"status:" + ClaimStatusConfirmed,
+	}, s.transitions)
+}
+
+// TestClaimWorkflow_IncomingOwnershipTimeout tests that Bacen's default confirmation is only recorded locally
+func (s *ClaimWorkflowTestSuite) TestClaimWorkflow_IncomingOwnershipTimeout() {
+	s.mockClaimActivities()
+	start := s.env.Now()
+
+	result := s.execute(testIncomingClaimInput("OWNERSHIP"))
+
+	assert.Equal(s.T(), ClaimStatusConfirmed, result.Status)
+	assert.True(s.T(), result.AutoConfirmed)
+	assert.Equal(s.T(), []string{
+		"status:" + ClaimStatusWaitingResolution,
+		"status:" + ClaimStatusConfirmed,
+	}, s.transitions)
+	assert.Equal(s.T(), 3*24*time.Hour, s.at["status:"+ClaimStatusConfirmed].Sub(start))
+}
+
+// TestClaimWorkflow_IncomingPortabilityTimeout tests that Bacen's default cancellation is only recorded locally
+func (s *ClaimWorkflowTestSuite) TestClaimWorkflow_IncomingPortabilityTimeout() {
+	s.mockClaimActivities()
+
+	result := s.execute(testIncomingClaimInput("PORTABILITY"))
+
+	assert.Equal(s.T(), ClaimStatusCancelled, result.Status)
+	assert.Equal(s.T(), []string{
+		"status:" + ClaimStatusWaitingResolution,
+		"status:" + ClaimStatusCancelled,
+	}, s.transitions)
+}
+
+// TestClaimWorkflow_LegacyLifecycle tests that claims started before the two-phase lifecycle keep
+// the single 30-day period
+func (s *ClaimWorkflowTestSuite) TestClaimWorkflow_LegacyLifecycle() {
+	s.env.OnGetVersion(claimLifecycleChangeID, workflow.DefaultVersion, 1).Return(workflow.DefaultVersion)
+	s.mockClaimActivities()
+	start := s.env.Now()
+
+	result := s.execute(testClaimInput("OWNERSHIP"))
+
+	assert.Equal(s.T(), ClaimStatusExpired, result.Status)
+	assert.Equal(s.T(), []string{"create", "status:" + ClaimStatusExpired}, s.transitions)
+	assert.WithinDuration(s.T(), start.Add(legacyClaimTimeout), s.at["status:"+ClaimStatusExpired], time.Minute)
+	require.Len(s.T(), s.published, 1)
+	assert.Equal(s.T(), ClaimStatusExpired, s.published[0].Status)
+}
+
+// TestDefaultResolutionTimeoutAction tests the default timeout action per claim type
+func TestDefaultResolutionTimeoutAction(t *testing.T) {
+	assert.Equal(t, ClaimTimeoutActionConfirm, DefaultResolutionTimeoutAction("OWNERSHIP"))
+	assert.Equal(t, ClaimTimeoutActionCancel, DefaultResolutionTimeoutAction("PORTABILITY"))
+}
@@ -0,0 +1,90 @@
+package workflows
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lbpay-lab/conn-dict/internal/activities"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	// FieldReencryptionWorkflowID is the fixed workflow ID of the re-encryption cron
+	FieldReencryptionWorkflowID = "field-reencryption"
+
+	// DefaultFieldReencryptionCron runs the re-encryption every hour
+	DefaultFieldReencryptionCron = "0 * * * *"
+
+	// DefaultFieldReencryptionBatchSize is the number of entries rewritten per transaction
+	DefaultFieldReencryptionBatchSize = 500
+
+	// DefaultFieldReencryptionMaxBatches bounds the entries migrated by one run
+	DefaultFieldReencryptionMaxBatches = 200
+)
+
+// FieldReencryptionInput represents the input for FieldReencryptionWorkflow
+type FieldReencryptionInput struct {
+	BatchSize  int `json:"batch_size,omitempty"`  // 0 = DefaultFieldReencryptionBatchSize
+	MaxBatches int `json:"max_batches,omitempty"` // 0 = DefaultFieldReencryptionMaxBatches
+}
+
+// FieldReencryptionResult represents the result of FieldReencryptionWorkflow
+type FieldReencryptionResult struct {
+	activities.ReencryptEntriesResult
+	RanAt time.Time `json:"ran_at"`
+}
+
+// FieldReencryptionWorkflow migrates the encrypted entry columns to the current KMS key
+//
+// After a key rotation (new current_key_id in the keyring), entries sealed under the previous
+// key are decrypted and sealed again; legacy entries still in plaintext are encrypted and get
+// their blind indexes. A run stops after MaxBatches batches and the next scheduled run carries
+// on, so the rotation of a large table is spread over several runs.
+//
+// startFieldReencryption in the worker schedules it with FIELD_REENCRYPTION_CRON (hourly by
+// default) under FieldReencryptionWorkflowID. Right after a rotation, a one-off run migrates the
+// first batches without waiting for the next hour:
+//
+//	temporal workflow start \
+//	  --task-queue dict-task-queue \
+//	  --type FieldReencryptionWorkflow \
+//	  --workflow-id field-reencryption-after-rotation \
+//	  --input '{"max_batches": 1000}'
+func FieldReencryptionWorkflow(ctx workflow.Context, input FieldReencryptionInput) (*FieldReencryptionResult, error) {
+	logger := workflow.GetLogger(ctx)
+
+	if input.BatchSize == 0 {
+		input.BatchSize = DefaultFieldReencryptionBatchSize
+	}
+	if input.MaxBatches == 0 {
+		input.MaxBatches = DefaultFieldReencryptionMaxBatches
+	}
+	if input.BatchSize < 0 || input.MaxBatches < 0 {
+		logger.Error("Invalid field re-encryption input", "batch_size", input.BatchSize, "max_batches", input.MaxBatches)
+		return nil, fmt.Errorf("batch_size and max_batches must be >= 0")
+	}
+
+	logger.Info("FieldReencryptionWorkflow started", "batch_size", input.BatchSize, "max_batches", input.MaxBatches)
+
+	opts := activities.NewActivityOptions().LongRunning
+	opts.StartToCloseTimeout = time.Hour
+	opts.ScheduleToCloseTimeout = 3 * time.Hour
+	activityCtx := workflow.WithActivityOptions(ctx, opts)
+
+	result := &FieldReencryptionResult{RanAt: workflow.Now(ctx)}
+	err := workflow.ExecuteActivity(activityCtx, "ReencryptEntriesActivity", activities.ReencryptEntriesInput{
+		BatchSize:  input.BatchSize,
+		MaxBatches: input.MaxBatches,
+	}).Get(activityCtx, &result.ReencryptEntriesResult)
+	if err != nil {
+		logger.Error("Failed to re-encrypt entries", "error", err)
+		return nil, fmt.Errorf("failed to re-encrypt entries: %w", err)
+	}
+
+	logger.Info("FieldReencryptionWorkflow completed",
+		"reencrypted", result.Reencrypted,
+		"completed", result.Completed,
+	)
+
+	return result, nil
+}
@@ -0,0 +1,73 @@
+package workflows
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lbpay-lab/conn-dict/internal/activities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.temporal.io/sdk/testsuite"
+)
+
+type FieldReencryptionWorkflowTestSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+
+	env *testsuite.TestWorkflowEnvironment
+}
+
+func TestFieldReencryptionWorkflowTestSuite(t *testing.T) {
+	suite.Run(t, new(FieldReencryptionWorkflowTestSuite))
+}
+
+func (s *FieldReencryptionWorkflowTestSuite) SetupTest() {
+	s.env = s.NewTestWorkflowEnvironment()
+	s.env.RegisterActivity(&activities.EncryptionActivities{})
+}
+
+func (s *FieldReencryptionWorkflowTestSuite) AfterTest(suiteName, testName string) {
+	s.env.AssertExpectations(s.T())
+}
+
+// TestFieldReencryptionWorkflow_Defaults tests that the activity runs with the default batch sizes
+func (s *FieldReencryptionWorkflowTestSuite) TestFieldReencryptionWorkflow_Defaults() {
+	s.env.OnActivity("ReencryptEntriesActivity", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, input activities.ReencryptEntriesInput) (*activities.ReencryptEntriesResult, error) {
+			assert.Equal(s.T(), DefaultFieldReencryptionBatchSize, input.BatchSize)
+			assert.Equal(s.T(), DefaultFieldReencryptionMaxBatches, input.MaxBatches)
+			return &activities.ReencryptEntriesResult{Reencrypted: 742, Batches: 2, Completed: true}, nil
+		})
+
+	s.env.ExecuteWorkflow(FieldReencryptionWorkflow, FieldReencryptionInput{})
+
+	assert.True(s.T(), s.env.IsWorkflowCompleted())
+	assert.NoError(s.T(), s.env.GetWorkflowError())
+
+	var result FieldReencryptionResult
+	assert.NoError(s.T(), s.env.GetWorkflowResult(&result))
+	assert.Equal(s.T(), 742, result.Reencrypted)
+	assert.Equal(s.T(), 2, result.Batches)
+	assert.True(s.T(), result.Completed)
+}
+
+// TestFieldReencryptionWorkflow_ActivityFailure tests that an activity failure fails the run
+func (s *FieldReencryptionWorkflowTestSuite) TestFieldReencryptionWorkflow_ActivityFailure() {
+	s.env.OnActivity("ReencryptEntriesActivity", mock.Anything, mock.Anything).
+		Return(nil, errors.New("keyring unavailable"))
+
+	s.env.ExecuteWorkflow(FieldReencryptionWorkflow, FieldReencryptionInput{BatchSize: 100, MaxBatches: 10})
+
+	assert.True(s.T(), s.env.IsWorkflowCompleted())
+	assert.Error(s.T(), s.env.GetWorkflowError())
+}
+
+// TestFieldReencryptionWorkflow_InvalidInput tests that negative sizes are rejected before any activity runs
+func (s *FieldReencryptionWorkflowTestSuite) TestFieldReencryptionWorkflow_InvalidInput() {
+	s.env.ExecuteWorkflow(FieldReencryptionWorkflow, FieldReencryptionInput{BatchSize: -1})
+
+	assert.True(s.T(), s.env.IsWorkflowCompleted())
+	assert.Error(s.T(), s.env.GetWorkflowError())
+}
@@ -0,0 +1,210 @@
+package workflows
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lbpay-lab/conn-dict/internal/activities"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	// IncomingPollerWorkflowID is the fixed workflow ID of the incoming claims/infractions poller cron
+	IncomingPollerWorkflowID = "incoming-poller"
+
+	// DefaultIncomingPollerCron polls Bacen every 2 minutes
+	DefaultIncomingPollerCron = "*/2 * * * *"
+
+	// DefaultIncomingPollerPageSize is the Bacen listing page size
+	DefaultIncomingPollerPageSize = 100
+
+	// minIncomingResolutionPeriod keeps a claim whose resolution period is about to end from
+	// falling back to the default period
+	minIncomingResolutionPeriod = time.Second
+)
+
+// IncomingPollerInput represents the input for IncomingPollerWorkflow
+type IncomingPollerInput struct {
+	PageSize int32 `json:"page_size,omitempty"` // 0 = DefaultIncomingPollerPageSize
+}
+
+// IncomingPollerResult represents the result of IncomingPollerWorkflow. The watermarks are read
+// back by the next cron run, which only lists what Bacen modified after them.
+type IncomingPollerResult struct {
+	ClaimsWatermark      time.Time `json:"claims_watermark"`
+	InfractionsWatermark time.Time `json:"infractions_watermark"`
+	ClaimsFetched        int       `json:"claims_fetched"`
+	ClaimsNew            int       `json:"claims_new"`
+	InfractionsFetched   int       `json:"infractions_fetched"`
+	InfractionsNew       int       `json:"infractions_new"`
+	WorkflowsStarted     int       `json:"workflows_started"`
+	RanAt                time.Time `json:"ran_at"`
+}
+
+// IncomingPollerWorkflow ingests the claims and infraction reports other participants opened
+// against our keys at Bacen
+//
+// Steps:
+// 1. Page through the claims where we are the donor, upsert them and publish dict.claims.created
+// for the new ones (core-dict lists them in ListIncomingClaims)
+// 2. Page through the infraction reports where we are the reported participant, store the new
+// ones and publish dict.infractions.reported
+// 3. Start a ClaimWorkflow (claim-workflow-<claim_id>) for each open claim and an
+// InvestigateInfractionWorkflow (infraction-<infraction_id>) for each open report
+//
+// Workflow IDs are deterministic and duplicates are rejected, so polling the same claim or report
+// again never starts a second workflow for it.
+//
+// The worker starts this workflow on boot as a Temporal cron workflow. To start it by hand:
+//
+//	temporal workflow start \
+//	  --task-queue dict-task-queue \
+//	  --type IncomingPollerWorkflow \
+//	  --workflow-id incoming-poller \
+//	  --cron "*/2 * * * *"
+func IncomingPollerWorkflow(ctx workflow.Context, input IncomingPollerInput) (*IncomingPollerResult, error) {
+	logger := workflow.GetLogger(ctx)
+
+	if input.PageSize <= 0 {
+		input.PageSize = DefaultIncomingPollerPageSize
+	}
+
+	result := &IncomingPollerResult{RanAt: workflow.Now(ctx)}
+
+	// Resume from the previous successful run
+	if workflow.HasLastCompletionResult(ctx) {
+		var last IncomingPollerResult
+		if err := workflow.GetLastCompletionResult(ctx, &last); err != nil {
+			logger.Warn("Failed to read last poller result, polling everything", "error", err)
+		} else {
+			result.ClaimsWatermark = last.ClaimsWatermark
+			result.InfractionsWatermark = last.InfractionsWatermark
+		}
+	}
+
+	logger.Info("IncomingPollerWorkflow started",
+		"claims_watermark", result.ClaimsWatermark,
+		"infractions_watermark", result.InfractionsWatermark,
+	)
+
+	activityOpts := activities.NewActivityOptions()
+	pollCtx := workflow.WithActivityOptions(ctx, activityOpts.ExternalAPI)
+
+	// Step 1: Claims where we are the donor
+	var claims activities.PollIncomingClaimsResult
+	err := workflow.ExecuteActivity(pollCtx, "PollIncomingClaimsActivity", activities.PollIncomingInput{
+		ModifiedAfter: result.ClaimsWatermark,
+		PageSize:      input.PageSize,
+	}).Get(pollCtx, &claims)
+	if err != nil {
+		return nil, fmt.Errorf("failed to poll incoming claims: %w", err)
+	}
+	result.ClaimsWatermark = claims.Watermark
+	result.ClaimsFetched = claims.Fetched
+	result.ClaimsNew = claims.New
+
+	// Step 2: Infraction reports where we are the reported participant
+	var infractions activities.PollIncomingInfractionsResult
+	err = workflow.ExecuteActivity(pollCtx, "PollIncomingInfractionsActivity", activities.PollIncomingInput{
+		ModifiedAfter: result.InfractionsWatermark,
+		PageSize:      input.PageSize,
+	}).Get(pollCtx, &infractions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to poll incoming infractions: %w", err)
+	}
+	result.InfractionsWatermark = infractions.Watermark
+	result.InfractionsFetched = infractions.Fetched
+	result.InfractionsNew = infractions.New
+
+	// Step 3: Start the workflows
+	for _, claim := range claims.Claims {
+		started, err := startIncomingChild(ctx, fmt.Sprintf("claim-workflow-%s", claim.ClaimID), ClaimWorkflow,
+			incomingClaimWorkflowInput(ctx, claim))
+		if err != nil {
+			return nil, err
+		}
+		if started {
+			result.WorkflowsStarted++
+		}
+	}
+
+	for _, infraction := range infractions.Infractions {
+		started, err := startIncomingChild(ctx, fmt.Sprintf("infraction-%s", infraction.InfractionID), InvestigateInfractionWorkflow,
+			InvestigateInfractionInput{
+				InfractionID:  infraction.InfractionID,
+				Key:           infraction.Key,
+				Type:          infraction.Type,
+				Description:   infraction.Description,
+				ReporterISPB:  infraction.ReporterISPB,
+				ReportedISPB:  infraction.ReportedISPB,
+				TransactionID: infraction.TransactionID,
+				Incoming:      true,
+			})
+		if err != nil {
+			return nil, err
+		}
+		if started {
+			result.WorkflowsStarted++
+		}
+	}
+
+	logger.Info("IncomingPollerWorkflow completed",
+		"claims_fetched", result.ClaimsFetched,
+		"claims_new", result.ClaimsNew,
+		"infractions_fetched", result.InfractionsFetched,
+		"infractions_new", result.InfractionsNew,
+		"workflows_started", result.WorkflowsStarted,
+	)
+
+	return result, nil
+}
+
+// startIncomingChild starts a child workflow that outlives the poller run. It reports false when a
+// workflow with the same ID already ran or is running.
+func startIncomingChild(ctx workflow.Context, workflowID string, childWorkflow interface{}, input interface{}) (bool, error) {
+	childCtx := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+		WorkflowID:            workflowID,
+		ParentClosePolicy:     enumspb.PARENT_CLOSE_POLICY_ABANDON,
+		WorkflowIDReusePolicy: enumspb.WORKFLOW_ID_REUSE_POLICY_REJECT_DUPLICATE,
+	})
+
+	err := workflow.ExecuteChildWorkflow(childCtx, childWorkflow, input).GetChildWorkflowExecution().Get(childCtx, nil)
+	if temporal.IsWorkflowExecutionAlreadyStartedError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to start workflow %s: %w", workflowID, err)
+	}
+
+	workflow.GetLogger(ctx).Info("Incoming workflow started", "workflow_id", workflowID)
+	return true, nil
+}
+
+// incomingClaimWorkflowInput builds the ClaimWorkflow input of an incoming claim. The resolution
+// period is what is left of the Bacen one.
+func incomingClaimWorkflowInput(ctx workflow.Context, claim activities.IncomingClaim) ClaimWorkflowInput {
+	var resolutionPeriod time.Duration
+	if !claim.ResolutionPeriodEnd.IsZero() {
+		resolutionPeriod = claim.ResolutionPeriodEnd.Sub(workflow.Now(ctx))
+		if resolutionPeriod < minIncomingResolutionPeriod {
+			resolutionPeriod = minIncomingResolutionPeriod
+		}
+	}
+
+	return ClaimWorkflowInput{
+		ClaimID:              claim.ClaimID,
+		ClaimType:            claim.ClaimType,
+		Key:                  claim.Key,
+		KeyType:              claim.KeyType,
+		ClaimerISPB:          claim.ClaimerISPB,
+		DonorISPB:            claim.DonorISPB,
+		ClaimerAccount:       claim.ClaimerAccountNumber,
+		ClaimerAccountBranch: claim.ClaimerAccountBranch,
+		ClaimerAccountType:   claim.ClaimerAccountType,
+		RequestedBy:          claim.ClaimerISPB,
+		ResolutionPeriod:     resolutionPeriod,
+		Incoming:             true,
+	}
+}
@@ -0,0 +1,146 @@
+package workflows
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lbpay-lab/conn-dict/internal/activities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+)
+
+type IncomingPollerWorkflowTestSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+
+	env *testsuite.TestWorkflowEnvironment
+}
+
+func TestIncomingPollerWorkflowTestSuite(t *testing.T) {
+	suite.Run(t, new(IncomingPollerWorkflowTestSuite))
+}
+
+func (s *IncomingPollerWorkflowTestSuite) SetupTest() {
+	s.env = s.NewTestWorkflowEnvironment()
+	s.env.RegisterActivity(&activities.IncomingActivities{})
+	s.env.RegisterWorkflow(ClaimWorkflow)
+	s.env.RegisterWorkflow(InvestigateInfractionWorkflow)
+}
+
+func (s *IncomingPollerWorkflowTestSuite) AfterTest(suiteName, testName string) {
+	s.env.AssertExpectations(s.T())
+}
+
+// TestIncomingPollerWorkflow_StartsWorkflows tests that open claims and reports get their workflows, with deterministic IDs
+func (s *IncomingPollerWorkflowTestSuite) TestIncomingPollerWorkflow_StartsWorkflows() {
+	watermark := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	resolutionEnd := s.env.Now().Add(48 * time.Hour)
+
+	s.env.OnActivity("PollIncomingClaimsActivity", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, input activities.PollIncomingInput) (*activities.PollIncomingClaimsResult, error) {
+			assert.True(s.T(), input.ModifiedAfter.IsZero())
+			assert.Equal(s.T(), int32(DefaultIncomingPollerPageSize), input.PageSize)
+			return &activities.PollIncomingClaimsResult{
+				Claims: []activities.IncomingClaim{{
+					ClaimID:             "bacen-claim-1",
+					ClaimType:           "PORTABILITY",
+					Key:                 "+5511999999999",
+					KeyType:             "PHONE",
+					ClaimerISPB:         "87654321",
+					DonorISPB:           "12345678",
+					ResolutionPeriodEnd: resolutionEnd,
+				}},
+				Fetched:   3,
+				New:       1,
+				Watermark: watermark,
+			}, nil
+		})
+
+	s.env.OnActivity("PollIncomingInfractionsActivity", mock.Anything, mock.Anything).
+		Return(&activities.PollIncomingInfractionsResult{
+			Infractions: []activities.IncomingInfraction{{
+				InfractionID:  "bacen-report-1",
+				Key:           "E1234567820260110120000000000001",
+				Type:          "FRAUD",
+				Description:   "Golpe relatado pelo pagador",
+				ReporterISPB:  "87654321",
+				ReportedISPB:  "12345678",
+				TransactionID: "E1234567820260110120000000000001",
+			}},
+			Fetched:   1,
+			New:       1,
+			Watermark: watermark.Add(time.Minute),
+		}, nil)
+
+	var claimWorkflowID string
+	s.env.OnWorkflow(ClaimWorkflow, mock.Anything, mock.Anything).
+		Return(func(ctx workflow.Context, input ClaimWorkflowInput) (*ClaimWorkflowResult, error) {
+			claimWorkflowID = workflow.GetInfo(ctx).WorkflowExecution.ID
+			assert.True(s.T(), input.Incoming)
+			assert.Equal(s.T(), "bacen-claim-1", input.ClaimID)
+			assert.Equal(s.T(), 48*time.Hour, input.ResolutionPeriod)
+			return &ClaimWorkflowResult{ClaimID: input.ClaimID, Status: ClaimStatusConfirmed}, nil
+		})
+
+	var infractionWorkflowID string
+	s.env.OnWorkflow(InvestigateInfractionWorkflow, mock.Anything, mock.Anything).
+		Return(func(ctx workflow.Context, input InvestigateInfractionInput) (*InfractionWorkflowResult, error) {
+			infractionWorkflowID = workflow.GetInfo(ctx).WorkflowExecution.ID
+			assert.True(s.T(), input.Incoming)
+			return &InfractionWorkflowResult{InfractionID: input.InfractionID, Status: InfractionStatusResolved}, nil
+		})
+
+	s.env.ExecuteWorkflow(IncomingPollerWorkflow, IncomingPollerInput{})
+
+	require.True(s.T(), s.env.IsWorkflowCompleted())
+	require.NoError(s.T(), s.env.GetWorkflowError())
+
+	var result IncomingPollerResult
+	require.NoError(s.T(), s.env.GetWorkflowResult(&result))
+	assert.Equal(s.T(), 2, result.WorkflowsStarted)
+	assert.Equal(s.T(), 1, result.ClaimsNew)
+	assert.Equal(s.T(), 3, result.ClaimsFetched)
+	assert.Equal(s.T(), watermark, result.ClaimsWatermark)
+	assert.Equal(s.T(), watermark.Add(time.Minute), result.InfractionsWatermark)
+	assert.Equal(s.T(), "claim-workflow-bacen-claim-1", claimWorkflowID)
+	assert.Equal(s.T(), "infraction-bacen-report-1", infractionWorkflowID)
+}
+
+// TestIncomingPollerWorkflow_PollFailure tests that no workflow is started when Bacen can't be listed
+func (s *IncomingPollerWorkflowTestSuite) TestIncomingPollerWorkflow_PollFailure() {
+	s.env.OnActivity("PollIncomingClaimsActivity", mock.Anything, mock.Anything).
+		Return(nil, errors.New("bridge unavailable"))
+
+	s.env.ExecuteWorkflow(IncomingPollerWorkflow, IncomingPollerInput{PageSize: 10})
+
+	require.True(s.T(), s.env.IsWorkflowCompleted())
+	assert.Error(s.T(), s.env.GetWorkflowError())
+}
+
+// TestIncomingClaimWorkflowInput tests the remaining resolution period of an incoming claim
+func TestIncomingClaimWorkflowInput(t *testing.T) {
+	var ts testsuite.WorkflowTestSuite
+	env := ts.NewTestWorkflowEnvironment()
+	now := env.Now()
+
+	env.ExecuteWorkflow(func(ctx workflow.Context) ([]time.Duration, error) {
+		var periods []time.Duration
+		for _, end := range []time.Time{{}, now.Add(-time.Hour), now.Add(time.Hour)} {
+			input := incomingClaimWorkflowInput(ctx, activities.IncomingClaim{ClaimID: "c", ResolutionPeriodEnd: end})
+			periods = append(periods, input.ResolutionPeriod)
+		}
+		return periods, nil
+	})
+
+	require.NoError(t, env.GetWorkflowError())
+	var periods []time.Duration
+	require.NoError(t, env.GetWorkflowResult(&periods))
+	// Unknown end → default period, already ended → minimum period, otherwise what is left
+	assert.Equal(t, []time.Duration{0, minIncomingResolutionPeriod, time.Hour}, periods)
+}
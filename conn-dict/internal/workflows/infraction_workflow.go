@@ -19,6 +19,11 @@ type InvestigateInfractionInput struct {
 	EvidenceURLs        []string `json:"evidence_urls,omitempty"`
 	RelatedEntryID      string   `json:"related_entry_id,omitempty"`
 	RelatedClaimID      string   `json:"related_claim_id,omitempty"`
+	TransactionID       string   `json:"transaction_id,omitempty"` // EndToEndId, required to report to Bacen
+
+	// Incoming marks an infraction report received from Bacen (we are the reported participant).
+	// InfractionID is the Bacen report ID and the infraction is already stored by the poller.
+	Incoming bool `json:"incoming,omitempty"`
 }
 
 // InvestigationDecision represents the decision made after investigation
@@ -112,23 +117,27 @@ func InvestigateInfractionWorkflow(ctx workflow.Context, input InvestigateInfrac
 		InfractionID: input.InfractionID,
 	}
 
-	// Step 1: Create infraction in database (OPEN status)
-	logger.Info("Step 1: Creating infraction in database", "infraction_id", input.InfractionID)
-	ctx1 := workflow.WithActivityOptions(ctx, activityOpts.Database)
-	createInput := activities.CreateInfractionInput{
-		InfractionID:        input.InfractionID,
-		Key:                 input.Key,
-		Type:                input.Type,
-		Description:         input.Description,
-		ReporterParticipant: input.ReporterISPB,
-		ReportedParticipant: input.ReportedISPB,
-		EvidenceURLs:        input.EvidenceURLs,
-		EntryID:             input.RelatedEntryID,
-		ClaimID:             input.RelatedClaimID,
-	}
-	err := workflow.ExecuteActivity(ctx1, "CreateInfractionActivity", createInput).Get(ctx1, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create infraction: %w", err)
+	// Step 1: Create infraction in database (OPEN status), incoming reports are already stored
+	var err error
+	if !input.Incoming {
+		logger.Info("Step 1: Creating infraction in database", "infraction_id", input.InfractionID)
+		ctx1 := workflow.WithActivityOptions(ctx, activityOpts.Database)
+		createInput := activities.CreateInfractionInput{
+			InfractionID:        input.InfractionID,
+			Key:                 input.Key,
+			Type:                input.Type,
+			Description:         input.Description,
+			ReporterParticipant: input.ReporterISPB,
+			ReportedParticipant: input.ReportedISPB,
+			EvidenceURLs:        input.EvidenceURLs,
+			EntryID:             input.RelatedEntryID,
+			ClaimID:             input.RelatedClaimID,
+			TransactionID:       input.TransactionID,
+		}
+		err = workflow.ExecuteActivity(ctx1, "CreateInfractionActivity", createInput).Get(ctx1, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create infraction: %w", err)
+		}
 	}
 
 	// Step 2: Notify reported participant (non-critical)
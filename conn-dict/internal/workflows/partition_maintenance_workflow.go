@@ -0,0 +1,155 @@
+package workflows
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lbpay-lab/conn-dict/internal/activities"
+	"github.com/lbpay-lab/conn-dict/internal/infrastructure/repositories"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	// PartitionMaintenanceWorkflowID is the fixed workflow ID of the partition maintenance cron
+	PartitionMaintenanceWorkflowID = "partition-maintenance"
+
+	// DefaultPartitionMaintenanceCron runs the partition maintenance daily at 03:00
+	DefaultPartitionMaintenanceCron = "0 3 * * *"
+
+	// DefaultPartitionLookaheadMonths is how many months ahead of the current one are pre-created
+	DefaultPartitionLookaheadMonths = 3
+
+	// DefaultPartitionRetentionMonths is how many full months before the current one stay online
+	DefaultPartitionRetentionMonths = 24
+)
+
+// PartitionMaintenanceInput represents the input for PartitionMaintenanceWorkflow
+type PartitionMaintenanceInput struct {
+	Tables          []string `json:"tables,omitempty"`           // Empty = audit_logs and event_logs
+	LookaheadMonths int      `json:"lookahead_months,omitempty"` // 0 = DefaultPartitionLookaheadMonths
+	RetentionMonths int      `json:"retention_months,omitempty"` // 0 = DefaultPartitionRetentionMonths
+}
+
+// PartitionMaintenanceResult represents the result of PartitionMaintenanceWorkflow
+type PartitionMaintenanceResult struct {
+	Ensured  []activities.EnsurePartitionsResult  `json:"ensured"`
+	Archived []activities.ArchivePartitionsResult `json:"archived"`
+	RanAt    time.Time                            `json:"ran_at"`
+}
+
+// PartitionMaintenanceWorkflow keeps the monthly partitions of audit_logs and event_logs healthy
+//
+// Steps, for each table:
+// 1. Pre-create the partitions for the current month and the next LookaheadMonths months
+// 2. Detach partitions older than RetentionMonths, archive them to object storage, then drop them
+//
+// Partition creation always runs first and for every table, so a failing archive never leaves
+// inserts without a partition to land in.
+//
+// The worker starts this workflow on boot as a Temporal cron workflow. To start it by hand:
+//
+//	temporal workflow start \
+//	  --task-queue dict-task-queue \
+//	  --type PartitionMaintenanceWorkflow \
+//	  --workflow-id partition-maintenance \
+//	  --cron "0 3 * * *"  # Run at 3 AM daily
+func PartitionMaintenanceWorkflow(ctx workflow.Context, input PartitionMaintenanceInput) (*PartitionMaintenanceResult, error) {
+	logger := workflow.GetLogger(ctx)
+
+	input = withPartitionMaintenanceDefaults(input)
+	if err := validatePartitionMaintenanceInput(input); err != nil {
+		logger.Error("Invalid partition maintenance input", "error", err)
+		return nil, err
+	}
+
+	now := workflow.Now(ctx)
+	logger.Info("PartitionMaintenanceWorkflow started",
+		"tables", input.Tables,
+		"lookahead_months", input.LookaheadMonths,
+		"retention_months", input.RetentionMonths,
+	)
+
+	activityOpts := activities.NewActivityOptions()
+	result := &PartitionMaintenanceResult{RanAt: now}
+
+	// Step 1: Pre-create future partitions
+	ensureCtx := workflow.WithActivityOptions(ctx, activityOpts.Database)
+	for _, table := range input.Tables {
+		var ensured activities.EnsurePartitionsResult
+		err := workflow.ExecuteActivity(ensureCtx, "EnsureFuturePartitionsActivity", activities.EnsurePartitionsInput{
+			Table:           table,
+			LookaheadMonths: input.LookaheadMonths,
+			Reference:       now,
+		}).Get(ensureCtx, &ensured)
+		if err != nil {
+			logger.Error("Failed to ensure future partitions", "table", table, "error", err)
+			return nil, fmt.Errorf("failed to ensure partitions for %s: %w", table, err)
+		}
+		result.Ensured = append(result.Ensured, ensured)
+	}
+
+	// Step 2: Archive expired partitions (exports can be large)
+	archiveOpts := activityOpts.LongRunning
+	archiveOpts.StartToCloseTimeout = 2 * time.Hour
+	archiveOpts.ScheduleToCloseTimeout = 6 * time.Hour
+	archiveCtx := workflow.WithActivityOptions(ctx, archiveOpts)
+
+	var archiveErr error
+	for _, table := range input.Tables {
+		var archived activities.ArchivePartitionsResult
+		err := workflow.ExecuteActivity(archiveCtx, "ArchiveExpiredPartitionsActivity", activities.ArchivePartitionsInput{
+			Table:           table,
+			RetentionMonths: input.RetentionMonths,
+			Reference:       now,
+		}).Get(archiveCtx, &archived)
+		if err != nil {
+			// Keep going with the other tables; the next scheduled run retries this one
+			logger.Error("Failed to archive expired partitions", "table", table, "error", err)
+			if archiveErr == nil {
+				archiveErr = fmt.Errorf("failed to archive partitions for %s: %w", table, err)
+			}
+			continue
+		}
+		result.Archived = append(result.Archived, archived)
+	}
+	if archiveErr != nil {
+		return result, archiveErr
+	}
+
+	logger.Info("PartitionMaintenanceWorkflow completed",
+		"ensured", len(result.Ensured),
+		"archived", len(result.Archived),
+	)
+
+	return result, nil
+}
+
+// withPartitionMaintenanceDefaults fills zero values of the input with the defaults
+func withPartitionMaintenanceDefaults(input PartitionMaintenanceInput) PartitionMaintenanceInput {
+	if len(input.Tables) == 0 {
+		input.Tables = []string{repositories.PartitionedTableAuditLogs, repositories.PartitionedTableEventLogs}
+	}
+	if input.LookaheadMonths == 0 {
+		input.LookaheadMonths = DefaultPartitionLookaheadMonths
+	}
+	if input.RetentionMonths == 0 {
+		input.RetentionMonths = DefaultPartitionRetentionMonths
+	}
+	return input
+}
+
+// validatePartitionMaintenanceInput validates the (defaulted) workflow input
+func validatePartitionMaintenanceInput(input PartitionMaintenanceInput) error {
+	for _, table := range input.Tables {
+		if !repositories.IsManagedPartitionedTable(table) {
+			return fmt.Errorf("table %q is not a managed partitioned table", table)
+		}
+	}
+	if input.LookaheadMonths < 0 {
+		return fmt.Errorf("lookahead_months must be >= 0, got: %d", input.LookaheadMonths)
+	}
+	if input.RetentionMonths < 1 {
+		return fmt.Errorf("retention_months must be >= 1, got: %d", input.RetentionMonths)
+	}
+	return nil
+}
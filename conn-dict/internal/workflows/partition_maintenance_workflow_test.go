@@ -0,0 +1,111 @@
+package workflows
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lbpay-lab/conn-dict/internal/activities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.temporal.io/sdk/testsuite"
+)
+
+type PartitionMaintenanceWorkflowTestSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+
+	env *testsuite.TestWorkflowEnvironment
+}
+
+func TestPartitionMaintenanceWorkflowTestSuite(t *testing.T) {
+	suite.Run(t, new(PartitionMaintenanceWorkflowTestSuite))
+}
+
+func (s *PartitionMaintenanceWorkflowTestSuite) SetupTest() {
+	s.env = s.NewTestWorkflowEnvironment()
+	s.env.RegisterActivity(&activities.PartitionActivities{})
+}
+
+func (s *PartitionMaintenanceWorkflowTestSuite) AfterTest(suiteName, testName string) {
+	s.env.AssertExpectations(s.T())
+}
+
+// TestPartitionMaintenanceWorkflow_Defaults tests that both log tables are maintained with the default windows
+func (s *PartitionMaintenanceWorkflowTestSuite) TestPartitionMaintenanceWorkflow_Defaults() {
+	var ensured, archived []string
+
+	s.env.OnActivity("EnsureFuturePartitionsActivity", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, input activities.EnsurePartitionsInput) (*activities.EnsurePartitionsResult, error) {
+			assert.Equal(s.T(), DefaultPartitionLookaheadMonths, input.LookaheadMonths)
+			ensured = append(ensured, input.Table)
+			return &activities.EnsurePartitionsResult{Table: input.Table, FutureMonths: input.LookaheadMonths}, nil
+		})
+
+	s.env.OnActivity("ArchiveExpiredPartitionsActivity", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, input activities.ArchivePartitionsInput) (*activities.ArchivePartitionsResult, error) {
+			assert.Equal(s.T(), DefaultPartitionRetentionMonths, input.RetentionMonths)
+			archived = append(archived, input.Table)
+			return &activities.ArchivePartitionsResult{Table: input.Table}, nil
+		})
+
+	s.env.ExecuteWorkflow(PartitionMaintenanceWorkflow, PartitionMaintenanceInput{})
+
+	assert.True(s.T(), s.env.IsWorkflowCompleted())
+	assert.NoError(s.T(), s.env.GetWorkflowError())
+
+	var result PartitionMaintenanceResult
+	assert.NoError(s.T(), s.env.GetWorkflowResult(&result))
+	assert.Len(s.T(), result.Ensured, 2)
+	assert.Len(s.T(), result.Archived, 2)
+	assert.Equal(s.T(), []string{"audit_logs", "event_logs"}, ensured)
+	assert.Equal(s.T(), []string{"audit_logs", "event_logs"}, archived)
+}
+
+// TestPartitionMaintenanceWorkflow_EnsureFailureSkipsArchive tests that nothing is archived when partitions can't be created
+func (s *PartitionMaintenanceWorkflowTestSuite) TestPartitionMaintenanceWorkflow_EnsureFailureSkipsArchive() {
+	s.env.OnActivity("EnsureFuturePartitionsActivity", mock.Anything, mock.Anything).
+		Return(nil, errors.New("database unavailable"))
+
+	s.env.ExecuteWorkflow(PartitionMaintenanceWorkflow, PartitionMaintenanceInput{
+		Tables:          []string{"audit_logs"},
+		LookaheadMonths: 2,
+		RetentionMonths: 12,
+	})
+
+	assert.True(s.T(), s.env.IsWorkflowCompleted())
+	assert.Error(s.T(), s.env.GetWorkflowError())
+}
+
+// TestPartitionMaintenanceWorkflow_ArchiveFailureContinues tests that an archive failure doesn't stop the other tables
+func (s *PartitionMaintenanceWorkflowTestSuite) TestPartitionMaintenanceWorkflow_ArchiveFailureContinues() {
+	s.env.OnActivity("EnsureFuturePartitionsActivity", mock.Anything, mock.Anything).
+		Return(&activities.EnsurePartitionsResult{FutureMonths: 3}, nil)
+
+	s.env.OnActivity("ArchiveExpiredPartitionsActivity", mock.Anything, mock.MatchedBy(func(input activities.ArchivePartitionsInput) bool {
+		return input.Table == "audit_logs"
+	})).Return(nil, errors.New("archive volume full"))
+
+	s.env.OnActivity("ArchiveExpiredPartitionsActivity", mock.Anything, mock.MatchedBy(func(input activities.ArchivePartitionsInput) bool {
+		return input.Table == "event_logs"
+	})).Return(&activities.ArchivePartitionsResult{Table: "event_logs"}, nil).Once()
+
+	s.env.SetTestTimeout(time.Minute)
+	s.env.ExecuteWorkflow(PartitionMaintenanceWorkflow, PartitionMaintenanceInput{})
+
+	assert.True(s.T(), s.env.IsWorkflowCompleted())
+	assert.Error(s.T(), s.env.GetWorkflowError())
+	assert.Contains(s.T(), s.env.GetWorkflowError().Error(), "audit_logs")
+}
+
+// TestPartitionMaintenanceWorkflow_InvalidTable tests that unmanaged tables are rejected
+func (s *PartitionMaintenanceWorkflowTestSuite) TestPartitionMaintenanceWorkflow_InvalidTable() {
+	s.env.ExecuteWorkflow(PartitionMaintenanceWorkflow, PartitionMaintenanceInput{
+		Tables: []string{"entries"},
+	})
+
+	assert.True(s.T(), s.env.IsWorkflowCompleted())
+	assert.Error(s.T(), s.env.GetWorkflowError())
+}
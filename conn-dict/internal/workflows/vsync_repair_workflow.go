@@ -0,0 +1,54 @@
+package workflows
+
+import (
+	"fmt"
+
+	"github.com/lbpay-lab/conn-dict/internal/activities"
+	"go.temporal.io/sdk/workflow"
+)
+
+// VSyncRepairInput represents the input for VSyncRepairWorkflow
+type VSyncRepairInput struct {
+	DiscrepancyID int64  `json:"discrepancy_id"`
+	ReviewerID    string `json:"reviewer_id"` // Who approved the repair
+}
+
+// VSyncRepairWorkflowID returns the workflow ID of the repair of a discrepancy, so a discrepancy
+// approved twice is not repaired twice at the same time
+func VSyncRepairWorkflowID(discrepancyID int64) string {
+	return fmt.Sprintf("vsync-repair-%d", discrepancyID)
+}
+
+// VSyncRepairWorkflow repairs a VSYNC discrepancy approved in the review queue: the local entry
+// is created or updated with Bacen's data, or registered at Bacen again, depending on the type of
+// discrepancy. The outcome (APPLIED, or FAILED and back in the review queue) is audited with the
+// report ID of the sync that found the discrepancy.
+func VSyncRepairWorkflow(ctx workflow.Context, input VSyncRepairInput) (*activities.RepairDiscrepancyResult, error) {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("VSyncRepairWorkflow started", "discrepancy_id", input.DiscrepancyID, "reviewer_id", input.ReviewerID)
+
+	if input.DiscrepancyID <= 0 || input.ReviewerID == "" {
+		return nil, fmt.Errorf("discrepancy_id and reviewer_id are required, got %d and %q", input.DiscrepancyID, input.ReviewerID)
+	}
+
+	activityOpts := activities.NewActivityOptions()
+	ctx = workflow.WithActivityOptions(ctx, activityOpts.ExternalAPI)
+
+	var result activities.RepairDiscrepancyResult
+	err := workflow.ExecuteActivity(ctx, "RepairDiscrepancyActivity", activities.RepairDiscrepancyInput{
+		DiscrepancyID: input.DiscrepancyID,
+		ReviewerID:    input.ReviewerID,
+	}).Get(ctx, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to repair discrepancy %d: %w", input.DiscrepancyID, err)
+	}
+
+	logger.Info("VSyncRepairWorkflow completed",
+		"discrepancy_id", result.DiscrepancyID,
+		"sync_report_id", result.SyncReportID,
+		"action", result.Action,
+		"status", result.Status,
+	)
+
+	return &result, nil
+}
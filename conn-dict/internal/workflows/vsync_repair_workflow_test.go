@@ -0,0 +1,65 @@
+package workflows
+
+import (
+	"testing"
+
+	"github.com/lbpay-lab/conn-dict/internal/activities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.temporal.io/sdk/testsuite"
+)
+
+type VSyncRepairWorkflowTestSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+
+	env *testsuite.TestWorkflowEnvironment
+}
+
+func TestVSyncRepairWorkflowTestSuite(t *testing.T) {
+	suite.Run(t, new(VSyncRepairWorkflowTestSuite))
+}
+
+func (s *VSyncRepairWorkflowTestSuite) SetupTest() {
+	s.env = s.NewTestWorkflowEnvironment()
+	s.env.RegisterActivity(&activities.VSyncActivities{})
+}
+
+func (s *VSyncRepairWorkflowTestSuite) AfterTest(suiteName, testName string) {
+	s.env.AssertExpectations(s.T())
+}
+
+// TestVSyncRepairWorkflow_Success tests that the approved discrepancy is repaired on the reviewer's behalf
+func (s *VSyncRepairWorkflowTestSuite) TestVSyncRepairWorkflow_Success() {
+	s.env.OnActivity("RepairDiscrepancyActivity", mock.Anything, activities.RepairDiscrepancyInput{DiscrepancyID: 42, ReviewerID: "ana"}).
+		Return(&activities.RepairDiscrepancyResult{
+			DiscrepancyID: 42,
+			SyncReportID:  "0b5d3b9e-6c1f-5a53-9c4e-7f1e2d3c4b5a",
+			Action:        "REGISTER_AT_BACEN",
+			Status:        "APPLIED",
+		}, nil)
+
+	s.env.ExecuteWorkflow(VSyncRepairWorkflow, VSyncRepairInput{DiscrepancyID: 42, ReviewerID: "ana"})
+
+	require.True(s.T(), s.env.IsWorkflowCompleted())
+	require.NoError(s.T(), s.env.GetWorkflowError())
+
+	var result activities.RepairDiscrepancyResult
+	require.NoError(s.T(), s.env.GetWorkflowResult(&result))
+	assert.Equal(s.T(), "APPLIED", result.Status)
+	assert.Equal(s.T(), "REGISTER_AT_BACEN", result.Action)
+}
+
+// TestVSyncRepairWorkflow_InvalidInput tests that a repair needs a discrepancy and a reviewer
+func (s *VSyncRepairWorkflowTestSuite) TestVSyncRepairWorkflow_InvalidInput() {
+	s.env.ExecuteWorkflow(VSyncRepairWorkflow, VSyncRepairInput{DiscrepancyID: 42})
+
+	require.True(s.T(), s.env.IsWorkflowCompleted())
+	assert.Error(s.T(), s.env.GetWorkflowError())
+}
+
+func TestVSyncRepairWorkflowID(t *testing.T) {
+	assert.Equal(t, "vsync-repair-42", VSyncRepairWorkflowID(42))
+}
@@ -5,14 +5,33 @@ import (
 	"time"
 
 	"github.com/lbpay-lab/conn-dict/internal/activities"
+	"github.com/lbpay-lab/conn-dict/internal/domain/entities"
 	"go.temporal.io/sdk/workflow"
 )
 
 // VSyncInput represents the input parameters for VSYNC workflow
 type VSyncInput struct {
 	ParticipantISPB string     `json:"participant_ispb"` // ISPB to sync (empty = all participants)
-	SyncType        string     `json:"sync_type"`        // "FULL" or "INCREMENTAL"
+	SyncType        string     `json:"sync_type"`        // "FULL", "INCREMENTAL" or "CID"
 	LastSyncDate    *time.Time `json:"last_sync_date"`   // For incremental sync
+
+	MaxDiscrepancies int `json:"max_discrepancies,omitempty"`  // CID sync: divergent CIDs resolved per key type (0 = DefaultVSyncMaxDiscrepancies)
+	ActivitiesPerRun int `json:"activities_per_run,omitempty"` // Activities run before continuing as new (0 = DefaultVSyncActivitiesPerRun)
+
+	Checkpoint *VSyncCheckpoint `json:"checkpoint,omitempty"` // Set by the workflow when it continues as new
+}
+
+// VSyncCheckpoint is the progress of a VSYNC, carried over when the workflow continues as new.
+// Everything proportional to the number of keys stays in the database (staged entries and
+// discrepancies, under the workflow ID); the checkpoint only holds cursors and counters.
+type VSyncCheckpoint struct {
+	Phase          string      `json:"phase"`
+	PageToken      string      `json:"page_token,omitempty"` // STAGE: next Bacen page
+	KeyTypeIndex   int         `json:"key_type_index"`       // COMPARE, RECONCILE: next key type
+	AfterID        int64       `json:"after_id"`             // APPLY: last discrepancy handled
+	EntriesFetched int         `json:"entries_fetched"`
+	FailedFixes    int         `json:"failed_fixes"`
+	Result         VSyncResult `json:"result"`
 }
 
 // VSyncResult represents the result of the VSYNC workflow
@@ -20,13 +39,20 @@ type VSyncResult struct {
 	EntriesSynced    int           `json:"entries_synced"`    // Total entries processed
 	EntriesCreated   int           `json:"entries_created"`   // Missing entries created locally
 	EntriesUpdated   int           `json:"entries_updated"`   // Outdated entries updated
-	EntriesDeleted   int           `json:"entries_deleted"`   // Entries flagged for deletion
+	EntriesDeleted   int           `json:"entries_deleted"`   // Entries deleted locally (VSYNC never deletes)
 	Discrepancies    int           `json:"discrepancies"`     // Total discrepancies found
 	Duration         time.Duration `json:"duration"`          // Workflow execution time
 	SyncTimestamp    time.Time     `json:"sync_timestamp"`    // When sync started
 	ReportID         string        `json:"report_id"`         // Audit report ID
 	Status           string        `json:"status"`            // "COMPLETED", "PARTIAL", "FAILED"
 	ErrorMessage     string        `json:"error_message,omitempty"`
+
+	EntriesRegistered int `json:"entries_registered,omitempty"` // Local entries registered at Bacen again
+	EntriesEscalated  int `json:"entries_escalated,omitempty"`  // Discrepancies sent to the review queue
+
+	ParticipantISPB   string   `json:"participant_ispb,omitempty"`
+	OutOfSyncKeyTypes []string `json:"out_of_sync_key_types,omitempty"` // CID sync: key types whose VSync differed
+	Truncated         bool     `json:"truncated,omitempty"`             // CID sync: more divergent CIDs than MaxDiscrepancies
 }
 
 const (
@@ -36,6 +62,35 @@ const (
 	// SyncTypeIncremental indicates an incremental sync (only changes since last sync)
 	SyncTypeIncremental = "INCREMENTAL"
 
+	// SyncTypeCID compares the VSync value of each key type with Bacen's and only lists the CIDs
+	// of the key types that differ
+	SyncTypeCID = "CID"
+
+	// DefaultVSyncMaxDiscrepancies bounds the divergent CIDs resolved per key type in one run
+	DefaultVSyncMaxDiscrepancies = 500
+
+	// DefaultCIDBackfillBatchSize is the number of entries given a CID per transaction
+	DefaultCIDBackfillBatchSize = 1000
+
+	// DefaultCIDBackfillMaxBatches bounds one BackfillEntryCIDsActivity call
+	DefaultCIDBackfillMaxBatches = 100
+
+	// DefaultVSyncPageSize is the number of entries requested per Bacen page (Bacen's maximum)
+	DefaultVSyncPageSize = 1000
+
+	// DefaultVSyncStageMaxPages bounds one StageBacenEntriesActivity call
+	DefaultVSyncStageMaxPages = 50
+
+	// DefaultVSyncApplyBatchSize is the number of discrepancies read per batch
+	DefaultVSyncApplyBatchSize = 100
+
+	// DefaultVSyncApplyMaxBatches bounds one ApplyDiscrepanciesActivity call
+	DefaultVSyncApplyMaxBatches = 10
+
+	// DefaultVSyncActivitiesPerRun is the number of activities a run executes before continuing
+	// as new, which keeps the history of a sync of any size bounded
+	DefaultVSyncActivitiesPerRun = 500
+
 	// SyncStatusCompleted indicates sync completed successfully
 	SyncStatusCompleted = "COMPLETED"
 
@@ -46,6 +101,28 @@ const (
 	SyncStatusFailed = "FAILED"
 )
 
+// VSYNC phases, in order. A CID sync goes BACKFILL, VERIFY, RECONCILE, APPLY; a FULL or
+// INCREMENTAL sync goes BACKFILL, STAGE, COMPARE, APPLY.
+const (
+	VSyncPhaseBackfill  = "BACKFILL"  // Give a CID to entries written before CIDs existed
+	VSyncPhaseVerify    = "VERIFY"    // Compare the VSync of each key type with Bacen
+	VSyncPhaseReconcile = "RECONCILE" // Reconcile the CIDs of the key types that differ
+	VSyncPhaseStage     = "STAGE"     // Stage Bacen's entries, page by page
+	VSyncPhaseCompare   = "COMPARE"   // Compare the staged entries, key type by key type
+	VSyncPhaseApply     = "APPLY"     // Apply the discrepancies found, batch by batch
+	VSyncPhaseReport    = "REPORT"    // Report and publish the outcome
+)
+
+// Workflow versions (workflow.GetVersion). Syncs started before a change replay the old path.
+const (
+	// vsyncPipelineChangeID versions the checkpointed pipeline; before it, the sync fetched and
+	// compared every entry in two activities (see legacyVSyncWorkflow)
+	vsyncPipelineChangeID = "vsync-pipeline"
+
+	// vsyncCIDSchedulerChangeID versions the scheduler's switch from INCREMENTAL to CID syncs
+	vsyncCIDSchedulerChangeID = "vsync-cid-scheduler"
+)
+
 // VSyncWorkflow is the main Temporal workflow for DICT data synchronization with Bacen
 //
 // This workflow implements periodic reconciliation between local DICT database
@@ -58,10 +135,21 @@ const (
 //
 // Purpose: Ensure data consistency and compliance with Bacen DICT regulations
 //
+// With SyncType CID, steps 1-2 do not download the entries: the per-key-type VSync aggregates
+// are compared with the values computed by Bacen, and the CID lists are only fetched and
+// reconciled for the key types that differ.
+//
+// The sync is a checkpointed pipeline: Bacen pages are staged in a work table, compared in SQL
+// one key type at a time, and the discrepancies are stored and applied by ID, so no activity
+// payload grows with the number of keys. Every activity is bounded and heartbeats its cursor;
+// after ActivitiesPerRun activities the workflow continues as new with a VSyncCheckpoint, under
+// the same workflow ID (the sync ID the work tables are keyed by).
+//
 // Workflow Steps:
-// - Step 1: Fetch Bacen entries (external API call)
-// - Step 2: Compare with local database
-// - Step 3: Apply fixes for discrepancies (create/update/flag)
+// - Step 1: Stage Bacen entries (external API call), or compare VSync values (CID)
+// - Step 2: Compare with local database, or reconcile divergent CIDs (CID)
+// - Step 3: Repair discrepancies as the repair policy decides (create/update/register at
+//   Bacen), or escalate them to the review queue
 // - Step 4: Generate audit report
 // - Step 5: Publish sync event to Pulsar
 func VSyncWorkflow(ctx workflow.Context, input VSyncInput) (*VSyncResult, error) {
@@ -78,17 +166,319 @@ func VSyncWorkflow(ctx workflow.Context, input VSyncInput) (*VSyncResult, error)
 		return nil, fmt.Errorf("invalid vsync input: %w", err)
 	}
 
+	if workflow.GetVersion(ctx, vsyncPipelineChangeID, workflow.DefaultVersion, 1) == workflow.DefaultVersion {
+		return legacyVSyncWorkflow(ctx, input)
+	}
+
+	syncID := workflow.GetInfo(ctx).WorkflowExecution.ID
+	checkpoint := input.Checkpoint
+	if checkpoint == nil {
+		checkpoint = &VSyncCheckpoint{
+			Phase: VSyncPhaseBackfill,
+			Result: VSyncResult{
+				SyncTimestamp:   workflow.Now(ctx),
+				Status:          SyncStatusCompleted, // Optimistic - will change if errors occur
+				ParticipantISPB: input.ParticipantISPB,
+			},
+		}
+	} else {
+		logger.Info("Resuming VSYNC from checkpoint", "phase", checkpoint.Phase)
+	}
+	result := &checkpoint.Result
+
+	activitiesPerRun := input.ActivitiesPerRun
+	if activitiesPerRun == 0 {
+		activitiesPerRun = DefaultVSyncActivitiesPerRun
+	}
+
+	// Steps 1-3: one bounded activity per iteration, continuing as new when the run is full
+	for executed := 0; checkpoint.Phase != VSyncPhaseReport; executed++ {
+		if executed == activitiesPerRun {
+			logger.Info("Continuing VSYNC as new", "phase", checkpoint.Phase)
+			input.Checkpoint = checkpoint
+			return nil, workflow.NewContinueAsNewError(ctx, VSyncWorkflow, input)
+		}
+
+		if err := runVSyncStep(ctx, syncID, input, checkpoint); err != nil {
+			logger.Error("VSYNC step failed", "phase", checkpoint.Phase, "error", err)
+			result.Status = SyncStatusFailed
+			result.ErrorMessage = fmt.Sprintf("%s error: %v", checkpoint.Phase, err)
+			return result, fmt.Errorf("vsync %s failed: %w", checkpoint.Phase, err)
+		}
+	}
+
+	activityOpts := activities.NewActivityOptions()
+	dbCtx := workflow.WithActivityOptions(ctx, activityOpts.Database)
+
+	if input.SyncType != SyncTypeCID {
+		err := workflow.ExecuteActivity(dbCtx, "DropStagedEntriesActivity", syncID).Get(dbCtx, nil)
+		if err != nil {
+			logger.Warn("Failed to drop staged entries (non-critical)", "error", err)
+		}
+	}
+
+	if result.Discrepancies == 0 {
+		logger.Info("No discrepancies found - database is in sync")
+	}
+	if checkpoint.FailedFixes > 0 {
+		result.Status = SyncStatusPartial
+		result.ErrorMessage = fmt.Sprintf("%d out of %d fixes failed", checkpoint.FailedFixes, result.EntriesSynced+checkpoint.FailedFixes)
+		logger.Warn("VSYNC completed with errors", "error_count", checkpoint.FailedFixes)
+	}
+
+	// Step 4: Generate sync audit report
+	logger.Info("Step 4: Generating sync audit report")
+
+	var reportID string
+	err := workflow.ExecuteActivity(dbCtx, "GenerateSyncReportActivity",
+		syncID,
+		result.ParticipantISPB,
+		input.SyncType,
+		checkpoint.EntriesFetched,
+		result.EntriesCreated,
+		result.EntriesUpdated,
+		result.EntriesDeleted,
+		workflow.Now(ctx).Sub(result.SyncTimestamp),
+		result.SyncTimestamp,
+		result.Status,
+		result.ErrorMessage,
+	).Get(dbCtx, &reportID)
+	if err != nil {
+		logger.Warn("Failed to generate sync report (non-critical)", "error", err)
+		// Don't fail workflow if report generation fails
+	} else {
+		result.ReportID = reportID
+		logger.Info("Sync report generated", "report_id", reportID)
+	}
+
+	// Step 5: Publish sync completion event to Pulsar
+	logger.Info("Step 5: Publishing sync completion event")
+	ctx5 := workflow.WithActivityOptions(ctx, activityOpts.Messaging)
+
+	syncEvent := map[string]interface{}{
+		"event_type":        "vsync_completed",
+		"ispb":              input.ParticipantISPB,
+		"sync_type":         input.SyncType,
+		"entries_synced":    result.EntriesSynced,
+		"entries_created":   result.EntriesCreated,
+		"entries_updated":   result.EntriesUpdated,
+		"entries_deleted":   result.EntriesDeleted,
+		"entries_escalated": result.EntriesEscalated,
+		"discrepancies":     result.Discrepancies,
+		"status":            result.Status,
+		"report_id":         reportID,
+		"sync_timestamp":    result.SyncTimestamp,
+		"duration_seconds":  workflow.Now(ctx).Sub(result.SyncTimestamp).Seconds(),
+	}
+
+	err = workflow.ExecuteActivity(ctx5, "PublishClaimEventActivity", syncEvent).Get(ctx5, nil)
+	if err != nil {
+		logger.Warn("Failed to publish sync event (non-critical)", "error", err)
+		// Don't fail workflow if event publishing fails
+	}
+
+	result.Duration = workflow.Now(ctx).Sub(result.SyncTimestamp)
+	logger.Info("VSyncWorkflow completed",
+		"status", result.Status,
+		"duration", result.Duration,
+		"synced", result.EntriesSynced,
+		"created", result.EntriesCreated,
+		"updated", result.EntriesUpdated,
+		"registered", result.EntriesRegistered,
+		"escalated", result.EntriesEscalated,
+	)
+
+	return result, nil
+}
+
+// runVSyncStep executes the next activity of the checkpoint's phase and moves the checkpoint
+// forward
+func runVSyncStep(ctx workflow.Context, syncID string, input VSyncInput, checkpoint *VSyncCheckpoint) error {
+	logger := workflow.GetLogger(ctx)
+	activityOpts := activities.NewActivityOptions()
+	result := &checkpoint.Result
+
+	longRunning := activityOpts.LongRunning
+	longRunning.StartToCloseTimeout = time.Hour
+	longCtx := workflow.WithActivityOptions(ctx, longRunning)
+
+	switch checkpoint.Phase {
+	case VSyncPhaseBackfill:
+		var backfill activities.BackfillCIDsResult
+		err := workflow.ExecuteActivity(longCtx, "BackfillEntryCIDsActivity", activities.BackfillCIDsInput{
+			BatchSize:  DefaultCIDBackfillBatchSize,
+			MaxBatches: DefaultCIDBackfillMaxBatches,
+		}).Get(longCtx, &backfill)
+		if err != nil {
+			return fmt.Errorf("failed to backfill entry cids: %w", err)
+		}
+		if !backfill.Completed {
+			return nil
+		}
+		if input.SyncType == SyncTypeCID {
+			checkpoint.Phase = VSyncPhaseVerify
+		} else {
+			checkpoint.Phase = VSyncPhaseStage
+		}
+
+	case VSyncPhaseVerify:
+		logger.Info("Step 1: Comparing VSync values with Bacen", "ispb", input.ParticipantISPB)
+		apiCtx := workflow.WithActivityOptions(ctx, activityOpts.ExternalAPI)
+
+		var comparison activities.VSyncComparison
+		err := workflow.ExecuteActivity(apiCtx, "CompareVSyncActivity", input.ParticipantISPB).Get(apiCtx, &comparison)
+		if err != nil {
+			return fmt.Errorf("failed to compare vsync: %w", err)
+		}
+		result.ParticipantISPB = comparison.ParticipantISPB
+		for _, keyType := range comparison.KeyTypes {
+			if !keyType.InSync {
+				result.OutOfSyncKeyTypes = append(result.OutOfSyncKeyTypes, keyType.KeyType)
+			}
+		}
+		checkpoint.Phase = VSyncPhaseReconcile
+		checkpoint.KeyTypeIndex = 0
+		if len(result.OutOfSyncKeyTypes) == 0 {
+			logger.Info("VSync matches Bacen for every key type")
+			checkpoint.Phase = VSyncPhaseApply
+		}
+
+	case VSyncPhaseReconcile:
+		keyType := result.OutOfSyncKeyTypes[checkpoint.KeyTypeIndex]
+		logger.Info("Step 2: Reconciling CIDs", "key_type", keyType)
+
+		maxDiscrepancies := input.MaxDiscrepancies
+		if maxDiscrepancies == 0 {
+			maxDiscrepancies = DefaultVSyncMaxDiscrepancies
+		}
+
+		var reconciled activities.ReconcileCIDsResult
+		err := workflow.ExecuteActivity(longCtx, "ReconcileCIDsActivity", activities.ReconcileCIDsInput{
+			SyncID:           syncID,
+			ParticipantISPB:  result.ParticipantISPB,
+			KeyType:          keyType,
+			MaxDiscrepancies: maxDiscrepancies,
+		}).Get(longCtx, &reconciled)
+		if err != nil {
+			return fmt.Errorf("failed to reconcile %s cids: %w", keyType, err)
+		}
+		checkpoint.EntriesFetched += reconciled.BacenCIDs
+		result.Discrepancies += reconciled.Discrepancies.Total()
+		result.Truncated = result.Truncated || reconciled.Truncated
+		checkpoint.KeyTypeIndex++
+		if checkpoint.KeyTypeIndex == len(result.OutOfSyncKeyTypes) {
+			checkpoint.Phase = VSyncPhaseApply
+		}
+
+	case VSyncPhaseStage:
+		logger.Info("Step 1: Staging entries from Bacen DICT", "ispb", result.ParticipantISPB)
+
+		var staged activities.StageBacenEntriesResult
+		err := workflow.ExecuteActivity(longCtx, "StageBacenEntriesActivity", activities.StageBacenEntriesInput{
+			SyncID:          syncID,
+			ParticipantISPB: result.ParticipantISPB,
+			LastSyncDate:    input.LastSyncDate,
+			PageToken:       checkpoint.PageToken,
+			PageSize:        DefaultVSyncPageSize,
+			MaxPages:        DefaultVSyncStageMaxPages,
+		}).Get(longCtx, &staged)
+		if err != nil {
+			return fmt.Errorf("failed to stage Bacen entries: %w", err)
+		}
+		result.ParticipantISPB = staged.ParticipantISPB
+		checkpoint.EntriesFetched += staged.Fetched
+		checkpoint.PageToken = staged.NextPageToken
+		if staged.Done {
+			checkpoint.Phase = VSyncPhaseCompare
+			checkpoint.KeyTypeIndex = 0
+		}
+
+	case VSyncPhaseCompare:
+		keyType := string(activities.VSyncKeyTypes[checkpoint.KeyTypeIndex])
+		logger.Info("Step 2: Comparing staged entries with local database", "key_type", keyType)
+
+		// One SQL statement per discrepancy type: nothing to heartbeat in between
+		compareOpts := longRunning
+		compareOpts.HeartbeatTimeout = 0
+		compareCtx := workflow.WithActivityOptions(ctx, compareOpts)
+
+		var compared activities.CompareStagedResult
+		err := workflow.ExecuteActivity(compareCtx, "CompareStagedEntriesActivity", activities.CompareStagedInput{
+			SyncID:          syncID,
+			ParticipantISPB: result.ParticipantISPB,
+			KeyType:         keyType,
+			MissingBacen:    input.SyncType == SyncTypeFull,
+		}).Get(compareCtx, &compared)
+		if err != nil {
+			return fmt.Errorf("failed to compare %s entries: %w", keyType, err)
+		}
+		result.Discrepancies += compared.Discrepancies.Total()
+		checkpoint.KeyTypeIndex++
+		if checkpoint.KeyTypeIndex == len(activities.VSyncKeyTypes) {
+			checkpoint.Phase = VSyncPhaseApply
+		}
+
+	case VSyncPhaseApply:
+		logger.Info("Step 3: Applying fixes for discrepancies", "after_id", checkpoint.AfterID)
+
+		var applied activities.ApplyDiscrepanciesResult
+		err := workflow.ExecuteActivity(longCtx, "ApplyDiscrepanciesActivity", activities.ApplyDiscrepanciesInput{
+			SyncID:     syncID,
+			AfterID:    checkpoint.AfterID,
+			BatchSize:  DefaultVSyncApplyBatchSize,
+			MaxBatches: DefaultVSyncApplyMaxBatches,
+		}).Get(longCtx, &applied)
+		if err != nil {
+			return fmt.Errorf("failed to apply discrepancies: %w", err)
+		}
+		result.EntriesCreated += applied.Created
+		result.EntriesUpdated += applied.Updated
+		result.EntriesRegistered += applied.Registered
+		result.EntriesEscalated += applied.Escalated
+		result.EntriesSynced += applied.Created + applied.Updated + applied.Registered
+		checkpoint.FailedFixes += applied.Failed
+		checkpoint.AfterID = applied.LastID
+		if applied.Done {
+			checkpoint.Phase = VSyncPhaseReport
+		}
+
+	default:
+		return fmt.Errorf("unknown vsync phase: %s", checkpoint.Phase)
+	}
+
+	return nil
+}
+
+// legacyVSyncDiscrepancy is a discrepancy as returned by CompareEntriesActivity to the syncs
+// started before vsyncPipelineChangeID
+type legacyVSyncDiscrepancy struct {
+	Type        entities.DiscrepancyType    `json:"type"`
+	Key         string                      `json:"key"`
+	EntryID     string                      `json:"entry_id"`
+	CreateInput activities.CreateEntryInput `json:"create_input"`
+	UpdateInput activities.UpdateEntryInput `json:"update_input"`
+}
+
+// legacyVSyncWorkflow is the sync of runs started before vsyncPipelineChangeID: every Bacen entry
+// is fetched and compared in one activity each, and each discrepancy is fixed by its own
+// activity. Kept unchanged so that these runs replay; new syncs never take it.
+//
+// FetchBacenEntriesActivity and CompareEntriesActivity are no longer registered, so a run that
+// had not completed them fails at that step; the next scheduled sync takes the pipeline. The
+// report step passes the result as before, which GenerateSyncReportActivity never decoded, so
+// it is skipped as non-critical.
+func legacyVSyncWorkflow(ctx workflow.Context, input VSyncInput) (*VSyncResult, error) {
+	logger := workflow.GetLogger(ctx)
+
 	startTime := workflow.Now(ctx)
 	result := &VSyncResult{
 		SyncTimestamp: startTime,
 		Status:        SyncStatusCompleted, // Optimistic - will change if errors occur
 	}
 
-	// Get activity options
 	activityOpts := activities.NewActivityOptions()
 
 	// Step 1: Fetch entries from Bacen DICT API
-	logger.Info("Step 1: Fetching entries from Bacen DICT", "ispb", input.ParticipantISPB)
 	ctx1 := workflow.WithActivityOptions(ctx, activityOpts.ExternalAPI)
 
 	var bacenEntries []activities.BacenEntry
@@ -97,160 +487,114 @@ func VSyncWorkflow(ctx workflow.Context, input VSyncInput) (*VSyncResult, error)
 		input.SyncType,
 		input.LastSyncDate,
 	).Get(ctx1, &bacenEntries)
-
 	if err != nil {
 		logger.Error("Failed to fetch Bacen entries", "error", err)
 		result.Status = SyncStatusFailed
 		result.ErrorMessage = fmt.Sprintf("Bacen API error: %v", err)
 		return result, fmt.Errorf("failed to fetch Bacen entries: %w", err)
 	}
-	logger.Info("Fetched Bacen entries", "count", len(bacenEntries))
 
 	// Step 2: Compare entries with local database
-	logger.Info("Step 2: Comparing entries with local database")
 	ctx2 := workflow.WithActivityOptions(ctx, activityOpts.Database)
 
-	var discrepancies []activities.EntryDiscrepancy
+	var discrepancies []legacyVSyncDiscrepancy
 	err = workflow.ExecuteActivity(ctx2, "CompareEntriesActivity",
 		bacenEntries,
 		input.ParticipantISPB,
 	).Get(ctx2, &discrepancies)
-
 	if err != nil {
 		logger.Error("Failed to compare entries", "error", err)
 		result.Status = SyncStatusFailed
 		result.ErrorMessage = fmt.Sprintf("Comparison error: %v", err)
 		return result, fmt.Errorf("failed to compare entries: %w", err)
 	}
-
 	result.Discrepancies = len(discrepancies)
-	logger.Info("Found discrepancies", "count", len(discrepancies))
 
 	// Step 3: Apply fixes for each discrepancy
-	if len(discrepancies) > 0 {
-		logger.Info("Step 3: Applying fixes for discrepancies")
-		ctx3 := workflow.WithActivityOptions(ctx, activityOpts.Database)
-
-		errorCount := 0
-		for i, disc := range discrepancies {
-			logger.Info("Processing discrepancy",
-				"index", i+1,
-				"total", len(discrepancies),
-				"type", disc.Type,
-				"key", disc.Key,
-			)
-
-			switch disc.Type {
-			case activities.DiscrepancyTypeMissingLocal:
-				// Entry exists in Bacen but not locally - create it
-				logger.Info("Creating missing entry", "key", disc.Key)
-				err = workflow.ExecuteActivity(ctx3, "CreateEntryActivity", disc.CreateInput).Get(ctx3, nil)
-				if err != nil {
-					logger.Warn("Failed to create missing entry", "key", disc.Key, "error", err)
-					errorCount++
-					continue
-				}
-				result.EntriesCreated++
-
-			case activities.DiscrepancyTypeOutdatedLocal:
-				// Entry exists but data is different - update it
-				logger.Info("Updating outdated entry", "key", disc.Key)
-				err = workflow.ExecuteActivity(ctx3, "UpdateEntryActivity", disc.EntryID, disc.UpdateInput).Get(ctx3, nil)
-				if err != nil {
-					logger.Warn("Failed to update entry", "key", disc.Key, "error", err)
-					errorCount++
-					continue
-				}
-				result.EntriesUpdated++
-
-			case activities.DiscrepancyTypeMissingBacen:
-				// Entry exists locally but not in Bacen - flag for manual review
-				logger.Warn("Entry missing in Bacen - flagged for review",
-					"key", disc.Key,
-					"entry_id", disc.EntryID,
-					"reason", "Entry exists locally but not found in Bacen DICT - may be pending registration or deleted",
-				)
-				// Don't auto-delete - could be:
-				// 1. Pending registration (not yet synced to Bacen)
-				// 2. Network error during Bacen fetch
-				// 3. Bacen data issue
-				// Flag for compliance team review instead
-				result.EntriesDeleted++ // Count as flagged for deletion review
-
-			default:
-				logger.Warn("Unknown discrepancy type", "type", disc.Type, "key", disc.Key)
+	errorCount := 0
+	for _, disc := range discrepancies {
+		switch disc.Type {
+		case entities.DiscrepancyTypeMissingLocal:
+			err = workflow.ExecuteActivity(ctx2, "CreateEntryActivity", disc.CreateInput).Get(ctx2, nil)
+			if err != nil {
+				logger.Warn("Failed to create missing entry", "key", disc.Key, "error", err)
+				errorCount++
+				continue
 			}
+			result.EntriesCreated++
+
+		case entities.DiscrepancyTypeOutdatedLocal:
+			err = workflow.ExecuteActivity(ctx2, "UpdateEntryActivity", disc.EntryID, disc.UpdateInput).Get(ctx2, nil)
+			if err != nil {
+				logger.Warn("Failed to update entry", "key", disc.Key, "error", err)
+				errorCount++
+				continue
+			}
+			result.EntriesUpdated++
 
-			result.EntriesSynced++
-		}
+		case entities.DiscrepancyTypeMissingBacen:
+			// Flagged for review, never deleted
+			result.EntriesDeleted++
 
-		// Set status based on error count
-		if errorCount > 0 {
-			result.Status = SyncStatusPartial
-			result.ErrorMessage = fmt.Sprintf("%d out of %d fixes failed", errorCount, len(discrepancies))
-			logger.Warn("VSYNC completed with errors", "error_count", errorCount)
+		default:
+			logger.Warn("Unknown discrepancy type", "type", disc.Type, "key", disc.Key)
 		}
-	} else {
-		logger.Info("No discrepancies found - database is in sync")
-	}
 
-	// Step 4: Generate sync audit report
-	logger.Info("Step 4: Generating sync audit report")
-	ctx4 := workflow.WithActivityOptions(ctx, activityOpts.Database)
+		result.EntriesSynced++
+	}
+	if errorCount > 0 {
+		result.Status = SyncStatusPartial
+		result.ErrorMessage = fmt.Sprintf("%d out of %d fixes failed", errorCount, len(discrepancies))
+	}
 
+	// Step 4: Generate sync audit report (non-critical)
 	var reportID string
-	err = workflow.ExecuteActivity(ctx4, "GenerateSyncReportActivity", result).Get(ctx4, &reportID)
+	err = workflow.ExecuteActivity(ctx2, "GenerateSyncReportActivity", result).Get(ctx2, &reportID)
 	if err != nil {
 		logger.Warn("Failed to generate sync report (non-critical)", "error", err)
-		// Don't fail workflow if report generation fails
 	} else {
 		result.ReportID = reportID
-		logger.Info("Sync report generated", "report_id", reportID)
 	}
 
-	// Step 5: Publish sync completion event to Pulsar
-	logger.Info("Step 5: Publishing sync completion event")
+	// Step 5: Publish sync completion event to Pulsar (non-critical)
 	ctx5 := workflow.WithActivityOptions(ctx, activityOpts.Messaging)
 
 	syncEvent := map[string]interface{}{
-		"event_type":        "vsync_completed",
-		"ispb":              input.ParticipantISPB,
-		"sync_type":         input.SyncType,
-		"entries_synced":    result.EntriesSynced,
-		"entries_created":   result.EntriesCreated,
-		"entries_updated":   result.EntriesUpdated,
-		"entries_deleted":   result.EntriesDeleted,
-		"discrepancies":     result.Discrepancies,
-		"status":            result.Status,
-		"report_id":         reportID,
-		"sync_timestamp":    result.SyncTimestamp,
-		"duration_seconds":  workflow.Now(ctx).Sub(startTime).Seconds(),
+		"event_type":       "vsync_completed",
+		"ispb":             input.ParticipantISPB,
+		"sync_type":        input.SyncType,
+		"entries_synced":   result.EntriesSynced,
+		"entries_created":  result.EntriesCreated,
+		"entries_updated":  result.EntriesUpdated,
+		"entries_deleted":  result.EntriesDeleted,
+		"discrepancies":    result.Discrepancies,
+		"status":           result.Status,
+		"report_id":        reportID,
+		"sync_timestamp":   result.SyncTimestamp,
+		"duration_seconds": workflow.Now(ctx).Sub(startTime).Seconds(),
 	}
-
 	err = workflow.ExecuteActivity(ctx5, "PublishClaimEventActivity", syncEvent).Get(ctx5, nil)
 	if err != nil {
 		logger.Warn("Failed to publish sync event (non-critical)", "error", err)
-		// Don't fail workflow if event publishing fails
 	}
 
 	result.Duration = workflow.Now(ctx).Sub(startTime)
-	logger.Info("VSyncWorkflow completed",
-		"status", result.Status,
-		"duration", result.Duration,
-		"synced", result.EntriesSynced,
-		"created", result.EntriesCreated,
-		"updated", result.EntriesUpdated,
-		"flagged", result.EntriesDeleted,
-	)
-
 	return result, nil
 }
 
 // validateVSyncInput validates the VSYNC workflow input
 func validateVSyncInput(input VSyncInput) error {
-	// SyncType must be FULL or INCREMENTAL
-	if input.SyncType != SyncTypeFull && input.SyncType != SyncTypeIncremental {
-		return fmt.Errorf("sync_type must be FULL or INCREMENTAL, got: %s", input.SyncType)
+	// SyncType must be FULL, INCREMENTAL or CID
+	if input.SyncType != SyncTypeFull && input.SyncType != SyncTypeIncremental && input.SyncType != SyncTypeCID {
+		return fmt.Errorf("sync_type must be FULL, INCREMENTAL or CID, got: %s", input.SyncType)
+	}
+
+	if input.MaxDiscrepancies < 0 {
+		return fmt.Errorf("max_discrepancies must be >= 0, got: %d", input.MaxDiscrepancies)
+	}
+
+	if input.ActivitiesPerRun < 0 {
+		return fmt.Errorf("activities_per_run must be >= 0, got: %d", input.ActivitiesPerRun)
 	}
 
 	// For incremental sync, LastSyncDate is required
@@ -294,11 +638,17 @@ func VSyncSchedulerWorkflow(ctx workflow.Context) error {
 		WorkflowRunTimeout: 2 * time.Hour, // VSYNC should complete within 2 hours
 	})
 
-	// Configure VSYNC input
+	// Configure VSYNC input: the CID check only downloads the key types that differ
 	input := VSyncInput{
-		ParticipantISPB: "",                                              // Empty = all ISPBs
-		SyncType:        SyncTypeIncremental,                             // Incremental by default
-		LastSyncDate:    ptrTime(workflow.Now(ctx).Add(-24 * time.Hour)), // Last 24 hours
+		ParticipantISPB: "", // Empty = the participant the worker runs for
+		SyncType:        SyncTypeCID,
+	}
+	if workflow.GetVersion(ctx, vsyncCIDSchedulerChangeID, workflow.DefaultVersion, 1) == workflow.DefaultVersion {
+		input = VSyncInput{
+			ParticipantISPB: "",                                              // Empty = all ISPBs
+			SyncType:        SyncTypeIncremental,                             // Incremental by default
+			LastSyncDate:    ptrTime(workflow.Now(ctx).Add(-24 * time.Hour)), // Last 24 hours
+		}
 	}
 
 	var result VSyncResult
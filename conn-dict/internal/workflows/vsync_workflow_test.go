@@ -1,14 +1,20 @@
 package workflows
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/lbpay-lab/conn-dict/internal/activities"
+	"github.com/lbpay-lab/conn-dict/internal/domain/entities"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/converter"
 	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
 )
 
 type VSyncWorkflowTestSuite struct {
@@ -20,6 +26,9 @@ type VSyncWorkflowTestSuite struct {
 
 func (s *VSyncWorkflowTestSuite) SetupTest() {
 	s.env = s.NewTestWorkflowEnvironment()
+	s.env.RegisterActivity(&activities.VSyncActivities{})
+	s.env.RegisterActivity(&activities.EntryActivities{})
+	s.env.RegisterActivity(&activities.ClaimActivities{})
 }
 
 func (s *VSyncWorkflowTestSuite) AfterTest(suiteName, testName string) {
@@ -35,47 +44,25 @@ func (s *VSyncWorkflowTestSuite) TestVSyncWorkflow_Success() {
 		LastSyncDate:    nil,
 	}
 
-	// Mock Bacen entries
-	bacenEntries := []activities.BacenEntry{
-		{
-			Key:             "+5511999999999",
-			KeyType:         "PHONE",
-			ParticipantISPB: "12345678",
-			AccountBranch:   "0001",
-			AccountNumber:   "123456",
-			AccountType:     "CACC",
-			Status:          "ACTIVE",
-		},
-	}
+	reportID := "SYNC-REPORT-123"
 
-	// Mock discrepancies
-	discrepancies := []activities.EntryDiscrepancy{
-		{
-			Type:    activities.DiscrepancyTypeMissingLocal,
-			Key:     "+5511999999999",
-			EntryID: "",
-			CreateInput: activities.CreateEntryInput{
-				EntryID:         "entry-123",
-				Key:             "+5511999999999",
-				KeyType:         "PHONE",
-				ParticipantISPB: "12345678",
-			},
-		},
-	}
+	// Mock activities: one PHONE key missing locally
+	s.mockStaging(&activities.StageBacenEntriesResult{ParticipantISPB: "12345678", Fetched: 1, Staged: 1, Pages: 1, Done: true})
 
-	reportID := "SYNC-REPORT-123"
+	s.env.OnActivity("CompareStagedEntriesActivity", mock.Anything, mock.MatchedBy(func(in activities.CompareStagedInput) bool {
+		return in.KeyType == "PHONE" && in.MissingBacen
+	})).Return(&activities.CompareStagedResult{KeyType: "PHONE", Discrepancies: entities.DiscrepancyCounts{MissingLocal: 1}}, nil).Once()
 
-	// Mock activities
-	s.env.OnActivity("FetchBacenEntriesActivity", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
-		Return(bacenEntries, nil)
+	s.env.OnActivity("CompareStagedEntriesActivity", mock.Anything, mock.Anything).
+		Return(&activities.CompareStagedResult{}, nil).Times(4)
 
-	s.env.OnActivity("CompareEntriesActivity", mock.Anything, mock.Anything, mock.Anything).
-		Return(discrepancies, nil)
+	s.env.OnActivity("ApplyDiscrepanciesActivity", mock.Anything, mock.Anything).
+		Return(&activities.ApplyDiscrepanciesResult{Created: 1, LastID: 1, Done: true}, nil)
 
-	s.env.OnActivity("CreateEntryActivity", mock.Anything, mock.Anything).
+	s.env.OnActivity("DropStagedEntriesActivity", mock.Anything, mock.Anything).
 		Return(nil)
 
-	s.env.OnActivity("GenerateSyncReportActivity", mock.Anything, mock.Anything).
+	s.env.OnActivity("GenerateSyncReportActivity", reportArgs()...).
 		Return(reportID, nil)
 
 	s.env.OnActivity("PublishClaimEventActivity", mock.Anything, mock.Anything).
@@ -106,18 +93,22 @@ func (s *VSyncWorkflowTestSuite) TestVSyncWorkflow_NoDiscrepancies() {
 		LastSyncDate:    ptrTime(time.Now().Add(-24 * time.Hour)),
 	}
 
-	bacenEntries := []activities.BacenEntry{}
-	discrepancies := []activities.EntryDiscrepancy{}
 	reportID := "SYNC-REPORT-456"
 
-	// Mock activities
-	s.env.OnActivity("FetchBacenEntriesActivity", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
-		Return(bacenEntries, nil)
+	// Mock activities: an incremental sync never flags entries missing in Bacen
+	s.mockStaging(&activities.StageBacenEntriesResult{ParticipantISPB: "12345678", Pages: 1, Done: true})
 
-	s.env.OnActivity("CompareEntriesActivity", mock.Anything, mock.Anything, mock.Anything).
-		Return(discrepancies, nil)
+	s.env.OnActivity("CompareStagedEntriesActivity", mock.Anything, mock.MatchedBy(func(in activities.CompareStagedInput) bool {
+		return !in.MissingBacen && in.ParticipantISPB == "12345678"
+	})).Return(&activities.CompareStagedResult{}, nil).Times(5)
 
-	s.env.OnActivity("GenerateSyncReportActivity", mock.Anything, mock.Anything).
+	s.env.OnActivity("ApplyDiscrepanciesActivity", mock.Anything, mock.Anything).
+		Return(&activities.ApplyDiscrepanciesResult{Done: true}, nil)
+
+	s.env.OnActivity("DropStagedEntriesActivity", mock.Anything, mock.Anything).
+		Return(nil)
+
+	s.env.OnActivity("GenerateSyncReportActivity", reportArgs()...).
 		Return(reportID, nil)
 
 	s.env.OnActivity("PublishClaimEventActivity", mock.Anything, mock.Anything).
@@ -147,40 +138,25 @@ func (s *VSyncWorkflowTestSuite) TestVSyncWorkflow_PartialFailure() {
 		LastSyncDate:    nil,
 	}
 
-	bacenEntries := []activities.BacenEntry{
-		{Key: "+5511999999999", KeyType: "PHONE"},
-		{Key: "+5511888888888", KeyType: "PHONE"},
-	}
+	reportID := "SYNC-REPORT-789"
 
-	discrepancies := []activities.EntryDiscrepancy{
-		{
-			Type:        activities.DiscrepancyTypeMissingLocal,
-			Key:         "+5511999999999",
-			CreateInput: activities.CreateEntryInput{EntryID: "entry-1", Key: "+5511999999999"},
-		},
-		{
-			Type:        activities.DiscrepancyTypeMissingLocal,
-			Key:         "+5511888888888",
-			CreateInput: activities.CreateEntryInput{EntryID: "entry-2", Key: "+5511888888888"},
-		},
-	}
+	// Mock activities - the second create fails
+	s.mockStaging(&activities.StageBacenEntriesResult{ParticipantISPB: "12345678", Fetched: 2, Staged: 2, Pages: 1, Done: true})
 
-	reportID := "SYNC-REPORT-789"
+	s.env.OnActivity("CompareStagedEntriesActivity", mock.Anything, mock.MatchedBy(func(in activities.CompareStagedInput) bool {
+		return in.KeyType == "PHONE"
+	})).Return(&activities.CompareStagedResult{KeyType: "PHONE", Discrepancies: entities.DiscrepancyCounts{MissingLocal: 2}}, nil).Once()
 
-	// Mock activities - second CreateEntry fails
-	s.env.OnActivity("FetchBacenEntriesActivity", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
-		Return(bacenEntries, nil)
+	s.env.OnActivity("CompareStagedEntriesActivity", mock.Anything, mock.Anything).
+		Return(&activities.CompareStagedResult{}, nil).Times(4)
 
-	s.env.OnActivity("CompareEntriesActivity", mock.Anything, mock.Anything, mock.Anything).
-		Return(discrepancies, nil)
+	s.env.OnActivity("ApplyDiscrepanciesActivity", mock.Anything, mock.Anything).
+		Return(&activities.ApplyDiscrepanciesResult{Created: 1, Failed: 1, LastID: 2, Done: true}, nil)
 
-	s.env.OnActivity("CreateEntryActivity", mock.Anything, discrepancies[0].CreateInput).
+	s.env.OnActivity("DropStagedEntriesActivity", mock.Anything, mock.Anything).
 		Return(nil)
 
-	s.env.OnActivity("CreateEntryActivity", mock.Anything, discrepancies[1].CreateInput).
-		Return(assert.AnError)
-
-	s.env.OnActivity("GenerateSyncReportActivity", mock.Anything, mock.Anything).
+	s.env.OnActivity("GenerateSyncReportActivity", reportArgs()...).
 		Return(reportID, nil)
 
 	s.env.OnActivity("PublishClaimEventActivity", mock.Anything, mock.Anything).
@@ -217,7 +193,7 @@ func (s *VSyncWorkflowTestSuite) TestVSyncWorkflow_InvalidInput() {
 	// Assert
 	assert.True(s.T(), s.env.IsWorkflowCompleted())
 	assert.Error(s.T(), s.env.GetWorkflowError())
-	assert.Contains(s.T(), s.env.GetWorkflowError().Error(), "sync_type must be FULL or INCREMENTAL")
+	assert.Contains(s.T(), s.env.GetWorkflowError().Error(), "sync_type must be FULL, INCREMENTAL or CID")
 }
 
 // TestVSyncWorkflow_IncrementalWithoutDate tests incremental sync without LastSyncDate
@@ -247,46 +223,227 @@ func (s *VSyncWorkflowTestSuite) TestVSyncWorkflow_MultipleDiscrepancyTypes() {
 		LastSyncDate:    nil,
 	}
 
-	bacenEntries := []activities.BacenEntry{
-		{Key: "+5511999999999"},
-	}
+	reportID := "SYNC-REPORT-999"
 
-	discrepancies := []activities.EntryDiscrepancy{
-		{
-			Type:        activities.DiscrepancyTypeMissingLocal,
-			Key:         "+5511999999999",
-			CreateInput: activities.CreateEntryInput{EntryID: "entry-1"},
-		},
-		{
-			Type:        activities.DiscrepancyTypeOutdatedLocal,
-			Key:         "+5511888888888",
-			EntryID:     "entry-2",
-			UpdateInput: activities.UpdateEntryInput{},
+	// Mock activities
+	s.mockStaging(&activities.StageBacenEntriesResult{ParticipantISPB: "12345678", Fetched: 2, Staged: 2, Pages: 1, Done: true})
+
+	s.env.OnActivity("CompareStagedEntriesActivity", mock.Anything, mock.MatchedBy(func(in activities.CompareStagedInput) bool {
+		return in.KeyType == "PHONE"
+	})).Return(&activities.CompareStagedResult{
+		KeyType:       "PHONE",
+		Discrepancies: entities.DiscrepancyCounts{MissingLocal: 1, OutdatedLocal: 1, MissingBacen: 1},
+	}, nil).Once()
+
+	s.env.OnActivity("CompareStagedEntriesActivity", mock.Anything, mock.Anything).
+		Return(&activities.CompareStagedResult{}, nil).Times(4)
+
+	s.env.OnActivity("ApplyDiscrepanciesActivity", mock.Anything, mock.Anything).
+		Return(&activities.ApplyDiscrepanciesResult{Created: 1, Updated: 1, Escalated: 1, LastID: 3, Done: true}, nil)
+
+	s.env.OnActivity("DropStagedEntriesActivity", mock.Anything, mock.Anything).
+		Return(nil)
+
+	s.env.OnActivity("GenerateSyncReportActivity", reportArgs()...).
+		Return(reportID, nil)
+
+	s.env.OnActivity("PublishClaimEventActivity", mock.Anything, mock.Anything).
+		Return(nil)
+
+	// Act
+	s.env.ExecuteWorkflow(VSyncWorkflow, input)
+
+	// Assert
+	assert.True(s.T(), s.env.IsWorkflowCompleted())
+	assert.NoError(s.T(), s.env.GetWorkflowError())
+
+	var result VSyncResult
+	err := s.env.GetWorkflowResult(&result)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), SyncStatusCompleted, result.Status)
+	assert.Equal(s.T(), 3, result.Discrepancies)
+	assert.Equal(s.T(), 1, result.EntriesCreated)
+	assert.Equal(s.T(), 1, result.EntriesUpdated)
+	assert.Equal(s.T(), 1, result.EntriesEscalated)
+	assert.Equal(s.T(), 0, result.EntriesDeleted)
+}
+
+// TestVSyncWorkflow_CIDInSync tests that no CID list is fetched when every VSync matches Bacen
+func (s *VSyncWorkflowTestSuite) TestVSyncWorkflow_CIDInSync() {
+	// Arrange
+	input := VSyncInput{SyncType: SyncTypeCID}
+
+	comparison := &activities.VSyncComparison{
+		ParticipantISPB: "12345678",
+		KeyTypes: []activities.KeyTypeVSync{
+			{KeyType: "CPF", LocalCount: 2, BacenCount: 2, InSync: true},
+			{KeyType: "EMAIL", LocalCount: 1, BacenCount: 1, InSync: true},
 		},
-		{
-			Type:    activities.DiscrepancyTypeMissingBacen,
-			Key:     "+5511777777777",
-			EntryID: "entry-3",
+	}
+
+	s.env.OnActivity("BackfillEntryCIDsActivity", mock.Anything, mock.Anything).
+		Return(&activities.BackfillCIDsResult{Completed: true}, nil)
+
+	s.env.OnActivity("CompareVSyncActivity", mock.Anything, "").
+		Return(comparison, nil)
+
+	s.env.OnActivity("ApplyDiscrepanciesActivity", mock.Anything, mock.Anything).
+		Return(&activities.ApplyDiscrepanciesResult{Done: true}, nil)
+
+	s.env.OnActivity("GenerateSyncReportActivity", reportArgs()...).
+		Return("SYNC-REPORT-CID", nil)
+
+	s.env.OnActivity("PublishClaimEventActivity", mock.Anything, mock.Anything).
+		Return(nil)
+
+	// Act
+	s.env.ExecuteWorkflow(VSyncWorkflow, input)
+
+	// Assert
+	assert.True(s.T(), s.env.IsWorkflowCompleted())
+	assert.NoError(s.T(), s.env.GetWorkflowError())
+
+	var result VSyncResult
+	err := s.env.GetWorkflowResult(&result)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), SyncStatusCompleted, result.Status)
+	assert.Equal(s.T(), "12345678", result.ParticipantISPB)
+	assert.Empty(s.T(), result.OutOfSyncKeyTypes)
+	assert.Equal(s.T(), 0, result.Discrepancies)
+	s.env.AssertNotCalled(s.T(), "ReconcileCIDsActivity", mock.Anything, mock.Anything)
+	s.env.AssertNotCalled(s.T(), "StageBacenEntriesActivity", mock.Anything, mock.Anything)
+}
+
+// TestVSyncWorkflow_CIDReconcilesDivergentKeyTypes tests that only the key types whose VSync
+// differs are reconciled, after the CID backfill has caught up
+func (s *VSyncWorkflowTestSuite) TestVSyncWorkflow_CIDReconcilesDivergentKeyTypes() {
+	// Arrange
+	input := VSyncInput{SyncType: SyncTypeCID, MaxDiscrepancies: 50}
+
+	comparison := &activities.VSyncComparison{
+		ParticipantISPB: "12345678",
+		KeyTypes: []activities.KeyTypeVSync{
+			{KeyType: "CPF", LocalCount: 2, BacenCount: 2, InSync: true},
+			{KeyType: "EMAIL", LocalCount: 1, BacenCount: 1, InSync: false},
 		},
 	}
 
-	reportID := "SYNC-REPORT-999"
+	reconciled := &activities.ReconcileCIDsResult{
+		KeyType:       "EMAIL",
+		LocalCIDs:     1,
+		BacenCIDs:     1,
+		Discrepancies: entities.DiscrepancyCounts{OutdatedLocal: 1},
+	}
 
-	// Mock activities
-	s.env.OnActivity("FetchBacenEntriesActivity", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
-		Return(bacenEntries, nil)
+	s.env.OnActivity("BackfillEntryCIDsActivity", mock.Anything, mock.Anything).
+		Return(&activities.BackfillCIDsResult{Filled: 100000}, nil).Once()
 
-	s.env.OnActivity("CompareEntriesActivity", mock.Anything, mock.Anything, mock.Anything).
-		Return(discrepancies, nil)
+	s.env.OnActivity("BackfillEntryCIDsActivity", mock.Anything, mock.Anything).
+		Return(&activities.BackfillCIDsResult{Filled: 10, Completed: true}, nil).Once()
 
-	s.env.OnActivity("CreateEntryActivity", mock.Anything, mock.Anything).
+	s.env.OnActivity("CompareVSyncActivity", mock.Anything, "").
+		Return(comparison, nil)
+
+	s.env.OnActivity("ReconcileCIDsActivity", mock.Anything, mock.MatchedBy(func(in activities.ReconcileCIDsInput) bool {
+		return in.SyncID != "" && in.ParticipantISPB == "12345678" && in.KeyType == "EMAIL" && in.MaxDiscrepancies == 50
+	})).Return(reconciled, nil).Once()
+
+	s.env.OnActivity("ApplyDiscrepanciesActivity", mock.Anything, mock.Anything).
+		Return(&activities.ApplyDiscrepanciesResult{Updated: 1, LastID: 7, Done: true}, nil)
+
+	s.env.OnActivity("GenerateSyncReportActivity", reportArgs()...).
+		Return("SYNC-REPORT-CID", nil)
+
+	s.env.OnActivity("PublishClaimEventActivity", mock.Anything, mock.Anything).
 		Return(nil)
 
-	s.env.OnActivity("UpdateEntryActivity", mock.Anything, mock.Anything, mock.Anything).
+	// Act
+	s.env.ExecuteWorkflow(VSyncWorkflow, input)
+
+	// Assert
+	assert.True(s.T(), s.env.IsWorkflowCompleted())
+	assert.NoError(s.T(), s.env.GetWorkflowError())
+
+	var result VSyncResult
+	err := s.env.GetWorkflowResult(&result)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), SyncStatusCompleted, result.Status)
+	assert.Equal(s.T(), []string{"EMAIL"}, result.OutOfSyncKeyTypes)
+	assert.Equal(s.T(), 1, result.Discrepancies)
+	assert.Equal(s.T(), 1, result.EntriesUpdated)
+	assert.False(s.T(), result.Truncated)
+}
+
+// TestVSyncWorkflow_ContinuesAsNew tests that a run stops after ActivitiesPerRun activities and
+// carries its progress in the checkpoint
+func (s *VSyncWorkflowTestSuite) TestVSyncWorkflow_ContinuesAsNew() {
+	// Arrange
+	input := VSyncInput{
+		ParticipantISPB:  "12345678",
+		SyncType:         SyncTypeFull,
+		ActivitiesPerRun: 3,
+	}
+
+	s.env.OnActivity("BackfillEntryCIDsActivity", mock.Anything, mock.Anything).
+		Return(&activities.BackfillCIDsResult{Completed: true}, nil).Once()
+
+	s.env.OnActivity("StageBacenEntriesActivity", mock.Anything, mock.MatchedBy(func(in activities.StageBacenEntriesInput) bool {
+		return in.PageToken == ""
+	})).Return(&activities.StageBacenEntriesResult{ParticipantISPB: "12345678", Fetched: 50000, Pages: 50, NextPageToken: "page-51"}, nil).Once()
+
+	s.env.OnActivity("StageBacenEntriesActivity", mock.Anything, mock.MatchedBy(func(in activities.StageBacenEntriesInput) bool {
+		return in.PageToken == "page-51"
+	})).Return(&activities.StageBacenEntriesResult{ParticipantISPB: "12345678", Fetched: 50000, Pages: 50, NextPageToken: "page-101"}, nil).Once()
+
+	// Act
+	s.env.ExecuteWorkflow(VSyncWorkflow, input)
+
+	// Assert
+	assert.True(s.T(), s.env.IsWorkflowCompleted())
+
+	var continueAsNew *workflow.ContinueAsNewError
+	s.Require().True(errors.As(s.env.GetWorkflowError(), &continueAsNew))
+
+	var next VSyncInput
+	s.Require().NoError(converter.GetDefaultDataConverter().FromPayloads(continueAsNew.Input, &next))
+	s.Require().NotNil(next.Checkpoint)
+	assert.Equal(s.T(), VSyncPhaseStage, next.Checkpoint.Phase)
+	assert.Equal(s.T(), "page-101", next.Checkpoint.PageToken)
+	assert.Equal(s.T(), 100000, next.Checkpoint.EntriesFetched)
+	assert.Equal(s.T(), "12345678", next.Checkpoint.Result.ParticipantISPB)
+	s.env.AssertNotCalled(s.T(), "CompareStagedEntriesActivity", mock.Anything, mock.Anything)
+}
+
+// TestVSyncWorkflow_ResumesFromCheckpoint tests that a continued run picks up where the
+// checkpoint left off and keeps the counters of the previous runs
+func (s *VSyncWorkflowTestSuite) TestVSyncWorkflow_ResumesFromCheckpoint() {
+	// Arrange
+	input := VSyncInput{
+		ParticipantISPB: "12345678",
+		SyncType:        SyncTypeFull,
+		Checkpoint: &VSyncCheckpoint{
+			Phase:          VSyncPhaseApply,
+			AfterID:        1000,
+			EntriesFetched: 120000,
+			Result: VSyncResult{
+				ParticipantISPB: "12345678",
+				Status:          SyncStatusCompleted,
+				Discrepancies:   1500,
+				EntriesCreated:  1000,
+				EntriesSynced:   1000,
+			},
+		},
+	}
+
+	s.env.OnActivity("ApplyDiscrepanciesActivity", mock.Anything, mock.MatchedBy(func(in activities.ApplyDiscrepanciesInput) bool {
+		return in.AfterID == 1000
+	})).Return(&activities.ApplyDiscrepanciesResult{Created: 500, LastID: 1500, Done: true}, nil).Once()
+
+	s.env.OnActivity("DropStagedEntriesActivity", mock.Anything, mock.Anything).
 		Return(nil)
 
-	s.env.OnActivity("GenerateSyncReportActivity", mock.Anything, mock.Anything).
-		Return(reportID, nil)
+	s.env.OnActivity("GenerateSyncReportActivity", reportArgs()...).
+		Return("SYNC-REPORT-RESUMED", nil)
 
 	s.env.OnActivity("PublishClaimEventActivity", mock.Anything, mock.Anything).
 		Return(nil)
@@ -302,10 +459,109 @@ func (s *VSyncWorkflowTestSuite) TestVSyncWorkflow_MultipleDiscrepancyTypes() {
 	err := s.env.GetWorkflowResult(&result)
 	assert.NoError(s.T(), err)
 	assert.Equal(s.T(), SyncStatusCompleted, result.Status)
-	assert.Equal(s.T(), 3, result.Discrepancies)
+	assert.Equal(s.T(), 1500, result.Discrepancies)
+	assert.Equal(s.T(), 1500, result.EntriesCreated)
+	assert.Equal(s.T(), 1500, result.EntriesSynced)
+	s.env.AssertNotCalled(s.T(), "BackfillEntryCIDsActivity", mock.Anything, mock.Anything)
+	s.env.AssertNotCalled(s.T(), "StageBacenEntriesActivity", mock.Anything, mock.Anything)
+}
+
+// TestVSyncWorkflow_LegacySync tests that a sync started before the pipeline replays the
+// fetch-compare-fix sequence
+func (s *VSyncWorkflowTestSuite) TestVSyncWorkflow_LegacySync() {
+	// Arrange
+	s.env.OnGetVersion(vsyncPipelineChangeID, workflow.DefaultVersion, 1).Return(workflow.DefaultVersion)
+
+	input := VSyncInput{
+		ParticipantISPB: "12345678",
+		SyncType:        SyncTypeFull,
+	}
+
+	// The legacy fetch and compare activities are no longer registered by the worker
+	s.env.RegisterActivityWithOptions(func(ctx context.Context, ispb, syncType string, lastSyncDate *time.Time) ([]activities.BacenEntry, error) {
+		return []activities.BacenEntry{{Key: "+5511999999999", KeyType: "PHONE"}}, nil
+	}, activity.RegisterOptions{Name: "FetchBacenEntriesActivity"})
+	s.env.RegisterActivityWithOptions(func(ctx context.Context, bacenEntries []activities.BacenEntry, ispb string) ([]legacyVSyncDiscrepancy, error) {
+		return []legacyVSyncDiscrepancy{
+			{Type: entities.DiscrepancyTypeMissingLocal, Key: "+5511999999999", CreateInput: activities.CreateEntryInput{Key: "+5511999999999"}},
+			{Type: entities.DiscrepancyTypeMissingBacen, Key: "user@example.com", EntryID: "ENTRY-1"},
+		}, nil
+	}, activity.RegisterOptions{Name: "CompareEntriesActivity"})
+
+	s.env.OnActivity("CreateEntryActivity", mock.Anything, mock.Anything).
+		Return(nil).Once()
+
+	s.env.OnActivity("PublishClaimEventActivity", mock.Anything, mock.Anything).
+		Return(nil)
+
+	// Act
+	s.env.ExecuteWorkflow(VSyncWorkflow, input)
+
+	// Assert
+	assert.True(s.T(), s.env.IsWorkflowCompleted())
+	assert.NoError(s.T(), s.env.GetWorkflowError())
+
+	var result VSyncResult
+	err := s.env.GetWorkflowResult(&result)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), SyncStatusCompleted, result.Status)
+	assert.Equal(s.T(), 2, result.Discrepancies)
 	assert.Equal(s.T(), 1, result.EntriesCreated)
-	assert.Equal(s.T(), 1, result.EntriesUpdated)
-	assert.Equal(s.T(), 1, result.EntriesDeleted) // Flagged for deletion review
+	assert.Equal(s.T(), 1, result.EntriesDeleted)
+	s.env.AssertNotCalled(s.T(), "BackfillEntryCIDsActivity", mock.Anything, mock.Anything)
+}
+
+// TestVSyncSchedulerWorkflow_SyncType tests that the scheduler runs CID syncs, and the
+// INCREMENTAL sync of the last 24 hours for schedulers started before the switch
+func (s *VSyncWorkflowTestSuite) TestVSyncSchedulerWorkflow_SyncType() {
+	tests := []struct {
+		name     string
+		version  workflow.Version
+		syncType string
+	}{
+		{name: "CID", version: 1, syncType: SyncTypeCID},
+		{name: "legacy INCREMENTAL", version: workflow.DefaultVersion, syncType: SyncTypeIncremental},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			env := s.NewTestWorkflowEnvironment()
+			env.RegisterWorkflow(VSyncWorkflow)
+			env.OnGetVersion(vsyncCIDSchedulerChangeID, workflow.DefaultVersion, 1).Return(tt.version)
+
+			var started VSyncInput
+			env.OnWorkflow(VSyncWorkflow, mock.Anything, mock.Anything).
+				Return(func(ctx workflow.Context, input VSyncInput) (*VSyncResult, error) {
+					started = input
+					return &VSyncResult{Status: SyncStatusCompleted}, nil
+				})
+
+			env.ExecuteWorkflow(VSyncSchedulerWorkflow)
+
+			var continueAsNew *workflow.ContinueAsNewError
+			assert.True(s.T(), errors.As(env.GetWorkflowError(), &continueAsNew))
+			assert.Equal(s.T(), tt.syncType, started.SyncType)
+			assert.Equal(s.T(), tt.syncType == SyncTypeIncremental, started.LastSyncDate != nil)
+		})
+	}
+}
+
+// mockStaging mocks a completed CID backfill followed by the staging of Bacen's entries
+func (s *VSyncWorkflowTestSuite) mockStaging(staged *activities.StageBacenEntriesResult) {
+	s.env.OnActivity("BackfillEntryCIDsActivity", mock.Anything, mock.Anything).
+		Return(&activities.BackfillCIDsResult{Completed: true}, nil)
+
+	s.env.OnActivity("StageBacenEntriesActivity", mock.Anything, mock.Anything).
+		Return(staged, nil)
+}
+
+// reportArgs matches the arguments of GenerateSyncReportActivity
+func reportArgs() []interface{} {
+	args := make([]interface{}, 12)
+	for i := range args {
+		args[i] = mock.Anything
+	}
+	return args
 }
 
 func TestVSyncWorkflowTestSuite(t *testing.T) {
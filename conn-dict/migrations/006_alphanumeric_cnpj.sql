@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Alphanumeric CNPJ (IN RFB 2.229/2024): 12 positions [0-9A-Z] + 2 numeric check digits.
+-- The numeric CNPJ is a subset of this format, so existing rows remain valid.
+ALTER TABLE entries DROP CONSTRAINT valid_tax_id;
+ALTER TABLE entries ADD CONSTRAINT valid_tax_id CHECK (
+    owner_tax_id ~ '^[0-9]{11}$' OR owner_tax_id ~ '^[0-9A-Z]{12}[0-9]{2}$'
+);
+
+COMMENT ON COLUMN entries.owner_tax_id IS 'CPF (11 digits) or CNPJ (14 chars, numeric or alphanumeric)';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE entries DROP CONSTRAINT valid_tax_id;
+ALTER TABLE entries ADD CONSTRAINT valid_tax_id CHECK (LENGTH(owner_tax_id) IN (11, 14));
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Infraction reports at Bacen: EndToEndId of the reported transaction and the
+-- report ID returned by Bacen (NULL until NotifyBacenActivity creates the report)
+ALTER TABLE infractions ADD COLUMN transaction_id VARCHAR(32);
+ALTER TABLE infractions ADD COLUMN bacen_report_id VARCHAR(64);
+
+CREATE UNIQUE INDEX idx_infractions_bacen_report_id ON infractions(bacen_report_id) WHERE bacen_report_id IS NOT NULL;
+CREATE INDEX idx_infractions_transaction_id ON infractions(transaction_id) WHERE deleted_at IS NULL;
+
+COMMENT ON COLUMN infractions.transaction_id IS 'EndToEndId of the reported PIX transaction (required to report to Bacen)';
+COMMENT ON COLUMN infractions.bacen_report_id IS 'Infraction report ID assigned by Bacen DICT';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_infractions_transaction_id;
+DROP INDEX IF EXISTS idx_infractions_bacen_report_id;
+ALTER TABLE infractions DROP COLUMN IF EXISTS bacen_report_id;
+ALTER TABLE infractions DROP COLUMN IF EXISTS transaction_id;
+-- +goose StatementEnd
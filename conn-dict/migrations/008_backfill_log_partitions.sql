@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Migration 004 only created audit_logs/event_logs partitions up to 2026_01.
+-- Backfill every month from 2026_02 through three months after the current one;
+-- from here on PartitionMaintenanceWorkflow keeps the lookahead window filled.
+DO $$
+DECLARE
+    month DATE;
+BEGIN
+    FOR month IN
+        SELECT generate_series(
+            DATE '2026-02-01',
+            (date_trunc('month', CURRENT_DATE) + INTERVAL '3 months')::DATE,
+            INTERVAL '1 month'
+        )::DATE
+    LOOP
+        PERFORM create_next_partition('audit_logs', month);
+        PERFORM create_next_partition('event_logs', month);
+    END LOOP;
+END;
+$$;
+-- +goose StatementEnd
+
+-- +goose Down
+-- Partitions created here may already hold audit data; they are archived and
+-- dropped by PartitionMaintenanceWorkflow, never by a migration rollback.
@@ -0,0 +1,49 @@
+-- +goose Up
+-- +goose StatementBegin
+-- LGPD: envelope encryption of CPF/EMAIL/PHONE keys and of the owner name and tax ID, with
+-- HMAC blind indexes for equality lookups. New rows are written encrypted by the application;
+-- existing rows keep their plaintext until FieldReencryptionWorkflow migrates them (it fills the
+-- *_encrypted/*_index columns and clears key, owner_name and owner_tax_id).
+ALTER TABLE entries ADD COLUMN key_encrypted BYTEA;
+ALTER TABLE entries ADD COLUMN key_index CHAR(64);
+ALTER TABLE entries ADD COLUMN owner_name_encrypted BYTEA;
+ALTER TABLE entries ADD COLUMN owner_tax_id_encrypted BYTEA;
+ALTER TABLE entries ADD COLUMN owner_tax_id_index CHAR(64);
+ALTER TABLE entries ADD COLUMN encryption_key_id VARCHAR(255);
+
+-- key keeps only CNPJ and EVP keys (and legacy rows), so its indexes stay for those
+ALTER TABLE entries ALTER COLUMN key DROP NOT NULL;
+
+CREATE UNIQUE INDEX idx_entries_key_index ON entries(key_index) WHERE deleted_at IS NULL;
+
+-- The plaintext tax ID index is replaced by the blind index
+DROP INDEX IF EXISTS idx_entries_owner_tax_id;
+CREATE INDEX idx_entries_owner_tax_id_index ON entries(owner_tax_id_index) WHERE deleted_at IS NULL;
+
+COMMENT ON COLUMN entries.key IS 'The PIX key in plaintext: CNPJ and random (EVP) keys only; CPF/EMAIL/PHONE are in key_encrypted';
+COMMENT ON COLUMN entries.key_encrypted IS 'Envelope-encrypted CPF/EMAIL/PHONE key (AES-256-GCM, DEK wrapped by the KMS)';
+COMMENT ON COLUMN entries.key_index IS 'Blind index (HMAC-SHA256) of the key, for equality lookups';
+COMMENT ON COLUMN entries.owner_name_encrypted IS 'Envelope-encrypted owner name';
+COMMENT ON COLUMN entries.owner_tax_id_encrypted IS 'Envelope-encrypted owner CPF/CNPJ';
+COMMENT ON COLUMN entries.owner_tax_id_index IS 'Blind index (HMAC-SHA256) of the owner CPF/CNPJ';
+COMMENT ON COLUMN entries.encryption_key_id IS 'KMS key version (KEK) of the encrypted columns';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- The encrypted values cannot be decrypted in SQL: rolling back after the re-encryption
+-- workflow has run loses the migrated personal data. Restore from backup instead.
+DROP INDEX IF EXISTS idx_entries_owner_tax_id_index;
+DROP INDEX IF EXISTS idx_entries_key_index;
+
+CREATE INDEX idx_entries_owner_tax_id ON entries(owner_tax_id) WHERE deleted_at IS NULL;
+
+ALTER TABLE entries ALTER COLUMN key SET NOT NULL;
+
+ALTER TABLE entries DROP COLUMN IF EXISTS encryption_key_id;
+ALTER TABLE entries DROP COLUMN IF EXISTS owner_tax_id_index;
+ALTER TABLE entries DROP COLUMN IF EXISTS owner_tax_id_encrypted;
+ALTER TABLE entries DROP COLUMN IF EXISTS owner_name_encrypted;
+ALTER TABLE entries DROP COLUMN IF EXISTS key_index;
+ALTER TABLE entries DROP COLUMN IF EXISTS key_encrypted;
+-- +goose StatementEnd
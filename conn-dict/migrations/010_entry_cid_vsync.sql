@@ -0,0 +1,104 @@
+-- +goose Up
+-- +goose StatementBegin
+-- CID-based VSync: every entry stores its content identifier (CID, hex HMAC-SHA256 of the fields
+-- synchronized with Bacen keyed by the RequestId of the last create/update Bacen accepted,
+-- computed by the application since the owner data is encrypted) and
+-- vsync_aggregates keeps, per participant and key type, the XOR of the CIDs of the keys
+-- registered at Bacen. VSyncWorkflow compares it with the VSync value computed by Bacen and
+-- only lists CIDs for the key types that differ.
+ALTER TABLE entries ADD COLUMN key_ownership_date TIMESTAMPTZ;
+ALTER TABLE entries ADD COLUMN bacen_request_id VARCHAR(100);
+-- Byte-order collation, so ORDER BY cid walks the index in the order Bacen sorts the CID list
+ALTER TABLE entries ADD COLUMN cid CHAR(64) COLLATE "C";
+
+CREATE INDEX idx_entries_participant_key_type_cid ON entries(participant, key_type, cid)
+    WHERE deleted_at IS NULL AND cid IS NOT NULL AND bacen_entry_id IS NOT NULL;
+-- Rows whose CID is not stored yet, filled by BackfillEntryCIDsActivity once the RequestId is known
+CREATE INDEX idx_entries_cid_missing ON entries(id) WHERE cid IS NULL AND bacen_request_id IS NOT NULL;
+
+COMMENT ON COLUMN entries.key_ownership_date IS 'Date Bacen assigned the key to its current owner (part of the CID)';
+COMMENT ON COLUMN entries.bacen_request_id IS 'RequestId of the last create/update Bacen accepted for the key (HMAC key of the CID)';
+COMMENT ON COLUMN entries.cid IS 'Content identifier: hex HMAC-SHA256 of the fields synchronized with Bacen';
+
+-- Sharded in 64 bucket rows per participant and key type: a single row would be locked by
+-- every entry write of the key type until its transaction ends, serializing them. An entry
+-- always lands in the same bucket (hash of its id), so an update only touches its own bucket.
+-- The VSync of the key type is the XOR of its buckets.
+CREATE FUNCTION vsync_bucket(id UUID)
+RETURNS SMALLINT AS $$
+    SELECT (hashtext($1::text) & 63)::SMALLINT;
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE TABLE vsync_aggregates (
+    participant VARCHAR(8) NOT NULL,
+    key_type VARCHAR(20) NOT NULL,
+    bucket SMALLINT NOT NULL,
+    entry_count BIGINT NOT NULL DEFAULT 0,
+    vsync BIT(256) NOT NULL DEFAULT 0::BIT(256),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (participant, key_type, bucket)
+);
+
+COMMENT ON TABLE vsync_aggregates IS 'XOR of the CIDs of the keys registered at Bacen, per participant, key type and bucket (maintained by trigger)';
+
+-- An entry counts while it is registered at Bacen: confirmed (Bacen returned its entry ID), not
+-- deleted, not deactivated, with a CID. Entries still waiting for Bacen's confirmation are not
+-- in Bacen's VSync either.
+-- Removing and adding a CID is the same XOR, so an update is "remove old row, add new row".
+CREATE OR REPLACE FUNCTION apply_entry_vsync()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE')
+       AND OLD.cid IS NOT NULL AND OLD.bacen_entry_id IS NOT NULL
+       AND OLD.deleted_at IS NULL AND OLD.status <> 'INACTIVE' THEN
+        UPDATE vsync_aggregates SET
+            entry_count = entry_count - 1,
+            vsync = vsync # ('x' || OLD.cid)::BIT(256),
+            updated_at = NOW()
+        WHERE participant = OLD.participant AND key_type = OLD.key_type
+          AND bucket = vsync_bucket(OLD.id);
+    END IF;
+
+    IF TG_OP IN ('INSERT', 'UPDATE')
+       AND NEW.cid IS NOT NULL AND NEW.bacen_entry_id IS NOT NULL
+       AND NEW.deleted_at IS NULL AND NEW.status <> 'INACTIVE' THEN
+        INSERT INTO vsync_aggregates (participant, key_type, bucket, entry_count, vsync)
+        VALUES (NEW.participant, NEW.key_type, vsync_bucket(NEW.id), 1, ('x' || NEW.cid)::BIT(256))
+        ON CONFLICT (participant, key_type, bucket) DO UPDATE SET
+            entry_count = vsync_aggregates.entry_count + 1,
+            vsync = vsync_aggregates.vsync # EXCLUDED.vsync,
+            updated_at = NOW();
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER maintain_entries_vsync
+    AFTER INSERT OR DELETE OR UPDATE OF cid, bacen_entry_id, participant, key_type, status, deleted_at ON entries
+    FOR EACH ROW
+    EXECUTE FUNCTION apply_entry_vsync();
+
+ALTER TABLE sync_reports DROP CONSTRAINT sync_reports_sync_type_check;
+ALTER TABLE sync_reports ADD CONSTRAINT sync_reports_sync_type_check
+    CHECK (sync_type IN ('FULL', 'INCREMENTAL', 'CID'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM sync_reports WHERE sync_type = 'CID';
+ALTER TABLE sync_reports DROP CONSTRAINT sync_reports_sync_type_check;
+ALTER TABLE sync_reports ADD CONSTRAINT sync_reports_sync_type_check
+    CHECK (sync_type IN ('FULL', 'INCREMENTAL'));
+
+DROP TRIGGER IF EXISTS maintain_entries_vsync ON entries;
+DROP FUNCTION IF EXISTS apply_entry_vsync();
+DROP TABLE IF EXISTS vsync_aggregates;
+DROP FUNCTION IF EXISTS vsync_bucket(UUID);
+
+DROP INDEX IF EXISTS idx_entries_cid_missing;
+DROP INDEX IF EXISTS idx_entries_participant_key_type_cid;
+ALTER TABLE entries DROP COLUMN IF EXISTS cid;
+ALTER TABLE entries DROP COLUMN IF EXISTS bacen_request_id;
+ALTER TABLE entries DROP COLUMN IF EXISTS key_ownership_date;
+-- +goose StatementEnd
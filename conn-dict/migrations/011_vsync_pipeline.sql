@@ -0,0 +1,52 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Batched VSYNC: the Bacen pages fetched by a FULL/INCREMENTAL sync are staged in
+-- vsync_staged_entries and compared with entries in SQL, one key type at a time; the
+-- discrepancies found (by any sync type) are stored in vsync_discrepancies and applied from
+-- there by ID, so no activity payload grows with the number of keys.
+--
+-- The Bacen entry is kept envelope-encrypted (it carries the key and the owner data); rows are
+-- matched on the key blind index (key_index) and compared on the CID.
+CREATE UNLOGGED TABLE vsync_staged_entries (
+    sync_id VARCHAR(50) NOT NULL,  -- Temporal workflow ID of the sync
+    key_index CHAR(64) NOT NULL,
+    key_type VARCHAR(20) NOT NULL,
+    participant VARCHAR(8) NOT NULL,
+    cid CHAR(64) NOT NULL,
+    bacen_entry BYTEA NOT NULL,  -- Envelope-encrypted JSON of the Bacen entry
+    staged_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (sync_id, key_index)
+);
+
+CREATE INDEX idx_vsync_staged_entries_key_type ON vsync_staged_entries(sync_id, key_type);
+CREATE INDEX idx_vsync_staged_entries_staged_at ON vsync_staged_entries(staged_at);
+
+COMMENT ON TABLE vsync_staged_entries IS 'Work table: Bacen entries fetched by a running VSYNC (dropped when the sync ends)';
+
+CREATE TABLE vsync_discrepancies (
+    id BIGSERIAL PRIMARY KEY,
+    sync_id VARCHAR(50) NOT NULL,
+    discrepancy_type VARCHAR(20) NOT NULL CHECK (discrepancy_type IN ('MISSING_LOCAL', 'OUTDATED_LOCAL', 'MISSING_BACEN')),
+    participant VARCHAR(8) NOT NULL,
+    key_type VARCHAR(20) NOT NULL,
+    key_index CHAR(64) NOT NULL,
+    entry_id VARCHAR(50),  -- Local entry (OUTDATED_LOCAL, MISSING_BACEN)
+    bacen_entry BYTEA,     -- Envelope-encrypted JSON of the Bacen entry (MISSING_LOCAL, OUTDATED_LOCAL)
+    reason TEXT NOT NULL,
+    applied_at TIMESTAMPTZ,
+    apply_error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (sync_id, discrepancy_type, key_index)
+);
+
+CREATE INDEX idx_vsync_discrepancies_pending ON vsync_discrepancies(sync_id, id) WHERE applied_at IS NULL;
+CREATE INDEX idx_vsync_discrepancies_entry_id ON vsync_discrepancies(entry_id) WHERE entry_id IS NOT NULL;
+
+COMMENT ON TABLE vsync_discrepancies IS 'Differences between Bacen and the local entries found by VSYNC, applied by ID';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS vsync_discrepancies;
+DROP TABLE IF EXISTS vsync_staged_entries;
+-- +goose StatementEnd
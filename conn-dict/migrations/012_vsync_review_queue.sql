@@ -0,0 +1,57 @@
+-- +goose Up
+-- +goose StatementBegin
+-- VSYNC review queue: each discrepancy goes through the repair policy, which repairs it or
+-- escalates it (status ESCALATED, with an assignee). Reviewers approve or reject the escalated
+-- and failed ones. Every status change is written to audit_logs (entity_type SYNC_DISCREPANCY)
+-- with the sync report ID in the metadata.
+ALTER TABLE vsync_discrepancies
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'PENDING'
+        CHECK (status IN ('PENDING', 'ESCALATED', 'APPROVED', 'APPLIED', 'FAILED', 'REJECTED')),
+    ADD COLUMN action VARCHAR(20)
+        CHECK (action IN ('CREATE_LOCAL', 'UPDATE_LOCAL', 'REGISTER_AT_BACEN', 'ESCALATE')),
+    ADD COLUMN assignee VARCHAR(50),
+    ADD COLUMN reviewed_by VARCHAR(50),
+    ADD COLUMN reviewed_at TIMESTAMPTZ,
+    ADD COLUMN review_comment TEXT,
+    ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+-- Discrepancies handled before the review queue existed; MISSING_BACEN ones were only flagged
+UPDATE vsync_discrepancies SET status = 'ESCALATED', action = 'ESCALATE', applied_at = NULL
+WHERE discrepancy_type = 'MISSING_BACEN' AND applied_at IS NOT NULL;
+UPDATE vsync_discrepancies SET status = 'APPLIED' WHERE applied_at IS NOT NULL;
+UPDATE vsync_discrepancies SET status = 'FAILED' WHERE applied_at IS NULL AND apply_error IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_vsync_discrepancies_pending;
+CREATE INDEX idx_vsync_discrepancies_pending ON vsync_discrepancies(sync_id, id) WHERE status = 'PENDING';
+CREATE INDEX idx_vsync_discrepancies_review ON vsync_discrepancies(status, id)
+    WHERE status IN ('ESCALATED', 'APPROVED', 'FAILED');
+CREATE INDEX idx_vsync_discrepancies_assignee ON vsync_discrepancies(assignee, id) WHERE assignee IS NOT NULL;
+
+ALTER TABLE audit_logs DROP CONSTRAINT audit_logs_entity_type_check;
+ALTER TABLE audit_logs ADD CONSTRAINT audit_logs_entity_type_check
+    CHECK (entity_type IN ('CLAIM', 'ENTRY', 'INFRACTION', 'ACCOUNT', 'USER', 'SYNC_DISCREPANCY'));
+
+COMMENT ON TABLE vsync_discrepancies IS 'Differences between Bacen and the local entries found by VSYNC, repaired by policy or reviewed';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM audit_logs WHERE entity_type = 'SYNC_DISCREPANCY';
+ALTER TABLE audit_logs DROP CONSTRAINT audit_logs_entity_type_check;
+ALTER TABLE audit_logs ADD CONSTRAINT audit_logs_entity_type_check
+    CHECK (entity_type IN ('CLAIM', 'ENTRY', 'INFRACTION', 'ACCOUNT', 'USER'));
+
+DROP INDEX IF EXISTS idx_vsync_discrepancies_assignee;
+DROP INDEX IF EXISTS idx_vsync_discrepancies_review;
+DROP INDEX IF EXISTS idx_vsync_discrepancies_pending;
+CREATE INDEX idx_vsync_discrepancies_pending ON vsync_discrepancies(sync_id, id) WHERE applied_at IS NULL;
+
+ALTER TABLE vsync_discrepancies
+    DROP COLUMN updated_at,
+    DROP COLUMN review_comment,
+    DROP COLUMN reviewed_at,
+    DROP COLUMN reviewed_by,
+    DROP COLUMN assignee,
+    DROP COLUMN action,
+    DROP COLUMN status;
+-- +goose StatementEnd
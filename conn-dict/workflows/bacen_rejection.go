@@ -0,0 +1,49 @@
+package workflows
+
+import (
+	"errors"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+)
+
+// BacenRejectedErrorType is the type of the non-retryable application error returned by an
+// activity when Bacen rejected the request. Its details carry the BacenRejection.
+const BacenRejectedErrorType = "BacenRejected"
+
+// BacenRejection is a request rejected by Bacen, relayed by Bridge as a common.v1.BacenError
+// status detail
+type BacenRejection struct {
+	Code       string // Bacen reason code (e.g. "EntryLockedByClaim")
+	Message    string
+	RequestID  string
+	HTTPStatus int
+	OccurredAt time.Time
+}
+
+// NewBacenRejectedError returns the non-retryable activity error of a request rejected by Bacen:
+// retrying cannot change Bacen's answer
+func NewBacenRejectedError(message string, rejection BacenRejection, cause error) error {
+	return temporal.NewNonRetryableApplicationError(message, BacenRejectedErrorType, cause, rejection)
+}
+
+// BacenRejectionFromError extracts the Bacen rejection carried by an activity error
+// (see NewBacenRejectedError), also when a workflow error wraps it
+func BacenRejectionFromError(err error) (*BacenRejection, bool) {
+	for err != nil {
+		var appErr *temporal.ApplicationError
+		if !errors.As(err, &appErr) {
+			return nil, false
+		}
+
+		if appErr.Type() == BacenRejectedErrorType && appErr.HasDetails() {
+			var rejection BacenRejection
+			if err := appErr.Details(&rejection); err != nil {
+				return nil, false
+			}
+			return &rejection, true
+		}
+		err = appErr.Unwrap()
+	}
+	return nil, false
+}
@@ -216,6 +216,7 @@ type CreateClaimResult struct {
 // SubmitClaimToBacenInput is the input for SubmitClaimToBacenActivity
 type SubmitClaimToBacenInput struct {
 	ClaimID              string
+	EntryID              string
 	Key                  string
 	KeyType              string
 	DonorISPB            string
@@ -225,6 +226,7 @@ type SubmitClaimToBacenInput struct {
 	ClaimerAccountType   string
 	ClaimType            string
 	CorrelationID        string
+	CompletionPeriod     time.Duration
 }
 
 // SubmitClaimToBacenResult is the result of SubmitClaimToBacenActivity
@@ -233,6 +235,7 @@ type SubmitClaimToBacenResult struct {
 	BacenCorrelationID string
 	ErrorCode          string
 	ErrorMessage       string
+	Rejection          *BacenRejection // Set when Bacen rejected the claim
 }
 
 // UpdateClaimStatusInput is the input for UpdateClaimStatusActivity
@@ -0,0 +1,169 @@
+// Command auditchain verifica a cadeia de hashes do log de auditoria pela linha de comando, com a
+// mesma lógica do RPC VerifyAuditChain, e grava checkpoints sob demanda.
+//
+// Uso:
+//
+//	auditchain verify --from 2026-10-01 --to 2026-10-31 [--stream ENTRY]
+//	auditchain checkpoint
+//
+// verify termina com código 1 se alguma cadeia estiver quebrada (o primeiro elo inválido é
+// informado no relatório). Configuração pelas mesmas variáveis de ambiente do servidor gRPC:
+// DB_*, AUDIT_CHECKPOINT_SIGNING_KEY_FILE (obrigatória em checkpoint) e
+// AUDIT_CHECKPOINT_TRUSTED_KEYS.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/lbpay-lab/core-dict/internal/application/services"
+	"github.com/lbpay-lab/core-dict/internal/infrastructure/database"
+)
+
+const dateLayout = "2006-01-02"
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "verify":
+		err = runVerify(os.Args[2:])
+	case "checkpoint":
+		err = runCheckpoint(os.Args[2:])
+	default:
+		usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: auditchain verify --from <YYYY-MM-DD> --to <YYYY-MM-DD> [--stream <stream>] | auditchain checkpoint")
+}
+
+func runVerify(args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	fromFlag := fs.String("from", "", "first day of the period (YYYY-MM-DD, UTC)")
+	toFlag := fs.String("to", "", "last day of the period (YYYY-MM-DD, UTC, inclusive)")
+	stream := fs.String("stream", "", "verify a single stream (ENTRY, CLAIM, dict_entries, ...)")
+	fs.Parse(args)
+
+	from, err := time.Parse(dateLayout, *fromFlag)
+	if err != nil {
+		return fmt.Errorf("--from must be a YYYY-MM-DD date")
+	}
+	to, err := time.Parse(dateLayout, *toFlag)
+	if err != nil {
+		return fmt.Errorf("--to must be a YYYY-MM-DD date")
+	}
+	to = to.Add(24*time.Hour - time.Nanosecond)
+
+	ctx := context.Background()
+	svc, closeFn, err := newAuditChainService(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+
+	result, err := svc.Verify(ctx, from, to, *stream)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(map[string]interface{}{
+		"valid":                result.Valid(),
+		"chains_verified":      result.ChainsVerified,
+		"events_verified":      result.EventsVerified,
+		"checkpoints_verified": result.CheckpointsVerified,
+		"first_break":          result.Break,
+		"verified_at":          result.VerifiedAt,
+	}); err != nil {
+		return err
+	}
+
+	if !result.Valid() {
+		return fmt.Errorf("audit chain is broken at %s", result.Break)
+	}
+	return nil
+}
+
+func runCheckpoint(args []string) error {
+	fs := flag.NewFlagSet("checkpoint", flag.ExitOnError)
+	fs.Parse(args)
+
+	ctx := context.Background()
+	svc, closeFn, err := newAuditChainService(ctx, true)
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+
+	created, err := svc.Checkpoint(ctx)
+	fmt.Fprintf(os.Stderr, "checkpoints created: %d\n", created)
+	return err
+}
+
+// newAuditChainService conecta na base e monta o serviço como no servidor gRPC
+func newAuditChainService(ctx context.Context, requireSigner bool) (*services.AuditChainService, func(), error) {
+	trustedKeys, err := services.ParseCheckpointPublicKeys(os.Getenv("AUDIT_CHECKPOINT_TRUSTED_KEYS"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid AUDIT_CHECKPOINT_TRUSTED_KEYS: %w", err)
+	}
+
+	var signer services.CheckpointSigner
+	if keyFile := os.Getenv("AUDIT_CHECKPOINT_SIGNING_KEY_FILE"); keyFile != "" {
+		bundleSigner, err := services.NewBundleSignerFromFile(keyFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		signer = bundleSigner
+	} else if requireSigner {
+		return nil, nil, fmt.Errorf("AUDIT_CHECKPOINT_SIGNING_KEY_FILE is required")
+	}
+
+	pgConfig := database.DefaultPostgresConfig()
+	pgConfig.Host = getEnv("DB_HOST", pgConfig.Host)
+	pgConfig.Port = getEnvAsInt("DB_PORT", pgConfig.Port)
+	pgConfig.Database = getEnv("DB_NAME", "lbpay_core_dict")
+	pgConfig.User = getEnv("DB_USER", "dict_app")
+	pgConfig.Password = getEnv("DB_PASSWORD", "dict_password")
+	pgConfig.Schema = getEnv("DB_SCHEMA", "core_dict")
+	pgConfig.MaxConnections = 2
+	pgConfig.MinConnections = 1
+
+	pgPool, err := database.NewPostgresConnectionPool(ctx, pgConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to PostgreSQL: %w", err)
+	}
+
+	repo := database.NewPostgresAuditChainRepository(pgPool.Pool())
+	return services.NewAuditChainService(repo, signer, trustedKeys), pgPool.Close, nil
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvAsInt(key string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,261 @@
+// Command dsr atende pedidos do titular de dados (LGPD) pela linha de comando, com a mesma
+// lógica dos RPCs ExportPersonalData e ErasePersonalData.
+//
+// Uso:
+//
+//	dsr export --tax-id 12345678909 --format json --out bundle.json --requested-by dpo@lbpay --ticket LGPD-42
+//	dsr erase --tax-id 12345678909 --requested-by dpo@lbpay --ticket LGPD-43 [--dry-run]
+//
+// Configuração pelas mesmas variáveis de ambiente do servidor gRPC: DB_*,
+// FIELD_ENCRYPTION_KEYRING_FILE, DSR_SIGNING_KEY_FILE, DSR_RETENTION_PERIOD e
+// DSR_CONN_DICT_ADDRESS (opcional; os dados do conn-dict são lidos e pseudonimizados pelo próprio
+// conn-dict, via ConnectService).
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lbpay-lab/core-dict/internal/application/services"
+	"github.com/lbpay-lab/core-dict/internal/infrastructure/database"
+	grpcinfra "github.com/lbpay-lab/core-dict/internal/infrastructure/grpc"
+	"github.com/lbpay-lab/dict-contracts/fieldcrypto"
+)
+
+// connDictTimeout bounds each conn-dict personal data RPC (the erasure runs in one transaction)
+const connDictTimeout = 60 * time.Second
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "export":
+		err = runExport(os.Args[2:])
+	case "erase":
+		err = runErase(os.Args[2:])
+	default:
+		usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: dsr export|erase --tax-id <CPF/CNPJ> --requested-by <operator> --ticket <id> [options]")
+}
+
+// requestFlags são as opções comuns aos dois subcomandos
+type requestFlags struct {
+	taxID       string
+	requestedBy string
+	ticketID    string
+	reason      string
+}
+
+func (f *requestFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.taxID, "tax-id", "", "CPF or CNPJ of the data subject")
+	fs.StringVar(&f.requestedBy, "requested-by", "", "operator handling the request (recorded in the audit trail)")
+	fs.StringVar(&f.ticketID, "ticket", "", "data subject request ticket ID")
+	fs.StringVar(&f.reason, "reason", "", "justification (recorded in the audit trail)")
+}
+
+func (f *requestFlags) validate() error {
+	if f.taxID == "" || f.requestedBy == "" || f.ticketID == "" {
+		return fmt.Errorf("--tax-id, --requested-by and --ticket are required")
+	}
+	return nil
+}
+
+func runExport(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	var req requestFlags
+	req.register(fs)
+	format := fs.String("format", "json", "bundle format: json or csv")
+	out := fs.String("out", "", "bundle output file (default: stdout)")
+	fs.Parse(args)
+	if err := req.validate(); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	svc, closeFn, err := newDataSubjectRequestService(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+
+	bundle, err := svc.Export(ctx, services.ExportPersonalDataRequest{
+		TaxID:       req.taxID,
+		Format:      services.BundleFormat(strings.ToUpper(*format)),
+		RequestedBy: req.requestedBy,
+		TicketID:    req.ticketID,
+		Reason:      req.reason,
+	})
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		_, err = os.Stdout.Write(bundle.Content)
+	} else {
+		err = os.WriteFile(*out, bundle.Content, 0o600)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+
+	// A assinatura é destacada: vai para stderr junto com o resumo
+	fmt.Fprintf(os.Stderr, "request_id:     %s\n", bundle.RequestID)
+	fmt.Fprintf(os.Stderr, "records:        %d (%s)\n", bundle.RecordCount, strings.Join(bundle.Sources, ", "))
+	fmt.Fprintf(os.Stderr, "sha256:         %s\n", bundle.SHA256)
+	fmt.Fprintf(os.Stderr, "signature:      %s\n", bundle.Signature)
+	fmt.Fprintf(os.Stderr, "signing_key_id: %s\n", bundle.KeyID)
+	return nil
+}
+
+func runErase(args []string) error {
+	fs := flag.NewFlagSet("erase", flag.ExitOnError)
+	var req requestFlags
+	req.register(fs)
+	dryRun := fs.Bool("dry-run", false, "only report what would be pseudonymised or retained")
+	fs.Parse(args)
+	if err := req.validate(); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	svc, closeFn, err := newDataSubjectRequestService(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+
+	report, err := svc.Erase(ctx, services.ErasePersonalDataRequest{
+		TaxID:       req.taxID,
+		RequestedBy: req.requestedBy,
+		TicketID:    req.ticketID,
+		Reason:      req.reason,
+		DryRun:      *dryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(map[string]interface{}{
+		"request_id":          report.RequestID,
+		"subject_pseudonym":   report.SubjectPseudonym,
+		"dry_run":             report.DryRun,
+		"retain_after":        report.RetainAfter,
+		"pseudonymised_count": report.Count(services.ErasureOutcomePseudonymised),
+		"retained_count":      report.Count(services.ErasureOutcomeRetained),
+		"actions":             report.Actions,
+		"processed_at":        report.ProcessedAt,
+	})
+}
+
+// newDataSubjectRequestService conecta nas bases e monta o serviço como no servidor gRPC
+func newDataSubjectRequestService(ctx context.Context) (*services.DataSubjectRequestService, func(), error) {
+	signingKeyFile := os.Getenv("DSR_SIGNING_KEY_FILE")
+	if signingKeyFile == "" {
+		return nil, nil, fmt.Errorf("DSR_SIGNING_KEY_FILE is required")
+	}
+	signer, err := services.NewBundleSignerFromFile(signingKeyFile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyringFile := os.Getenv("FIELD_ENCRYPTION_KEYRING_FILE")
+	if keyringFile == "" {
+		return nil, nil, fmt.Errorf("FIELD_ENCRYPTION_KEYRING_FILE is required")
+	}
+	keyProvider, err := fieldcrypto.NewFileKeyProvider(keyringFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load field encryption keyring: %w", err)
+	}
+	cipher, err := fieldcrypto.NewCipher(ctx, keyProvider)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create field cipher: %w", err)
+	}
+
+	retention := services.DefaultDataSubjectRequestConfig().RetentionPeriod
+	if value := os.Getenv("DSR_RETENTION_PERIOD"); value != "" {
+		if retention, err = time.ParseDuration(value); err != nil {
+			return nil, nil, fmt.Errorf("invalid DSR_RETENTION_PERIOD: %w", err)
+		}
+	}
+
+	pgConfig := database.DefaultPostgresConfig()
+	pgConfig.Host = getEnv("DB_HOST", pgConfig.Host)
+	pgConfig.Port = getEnvAsInt("DB_PORT", pgConfig.Port)
+	pgConfig.Database = getEnv("DB_NAME", "lbpay_core_dict")
+	pgConfig.User = getEnv("DB_USER", "dict_app")
+	pgConfig.Password = getEnv("DB_PASSWORD", "dict_password")
+	pgConfig.Schema = getEnv("DB_SCHEMA", "core_dict")
+	pgConfig.MaxConnections = 2
+	pgConfig.MinConnections = 1
+
+	pgPool, err := database.NewPostgresConnectionPool(ctx, pgConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to PostgreSQL: %w", err)
+	}
+	closers := []func(){pgPool.Close}
+	closeAll := func() {
+		for _, c := range closers {
+			c()
+		}
+	}
+
+	coreDictSource := database.NewPostgresPersonalDataRepository(pgPool.Pool(), cipher)
+	sources := []services.PersonalDataSource{coreDictSource}
+	if address := os.Getenv("DSR_CONN_DICT_ADDRESS"); address != "" {
+		connDictClient, err := grpcinfra.NewConnectClient(address,
+			grpcinfra.WithTimeout(connDictTimeout),
+			grpcinfra.WithHealthCheck(false, 0),
+		)
+		if err != nil {
+			closeAll()
+			return nil, nil, fmt.Errorf("failed to create conn-dict client: %w", err)
+		}
+		closers = append(closers, func() { _ = connDictClient.Close() })
+		sources = append(sources, grpcinfra.NewConnDictPersonalDataSource(connDictClient))
+	} else {
+		fmt.Fprintln(os.Stderr, "warning: DSR_CONN_DICT_ADDRESS not set, conn-dict data is not covered")
+	}
+
+	svc, err := services.NewDataSubjectRequestService(sources, database.NewPostgresAuditRepository(pgPool.Pool()),
+		signer, coreDictSource, &services.DataSubjectRequestConfig{RetentionPeriod: retention})
+	if err != nil {
+		closeAll()
+		return nil, nil, err
+	}
+	return svc, closeAll, nil
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvAsInt(key string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
@@ -2,8 +2,10 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -19,8 +21,9 @@ import (
 )
 
 const (
-	defaultPort     = "9090"
-	defaultLogLevel = "info"
+	defaultPort        = "9090"
+	defaultMetricsPort = "9091"
+	defaultLogLevel    = "info"
 )
 
 func main() {
@@ -28,6 +31,7 @@ func main() {
 	// 1. CONFIGURAÇÃO
 	// ============================================================
 	port := getEnv("GRPC_PORT", defaultPort)
+	metricsPort := getEnv("METRICS_PORT", defaultMetricsPort)
 	logLevel := getEnv("LOG_LEVEL", defaultLogLevel)
 	useMockMode := getEnv("CORE_DICT_USE_MOCK_MODE", "true") == "true"
 
@@ -42,56 +46,80 @@ func main() {
 	)
 
 	// ============================================================
-	// 3. gRPC SERVER
+	// 3. CORE DICT SERVICE HANDLER
 	// ============================================================
-	lis, err := net.Listen("tcp", ":"+port)
-	if err != nil {
-		logger.Error("Failed to listen", "error", err, "port", port)
-		os.Exit(1)
-	}
-
-	// gRPC server options
-	opts := []grpc.ServerOption{
-		grpc.UnaryInterceptor(loggingInterceptor(logger)),
-		grpc.MaxRecvMsgSize(10 * 1024 * 1024), // 10MB
-		grpc.MaxSendMsgSize(10 * 1024 * 1024), // 10MB
-	}
-
-	grpcServer := grpc.NewServer(opts...)
-
-	// ============================================================
-	// 4. REGISTER SERVICES
-	// ============================================================
-
-	// 4a. Core DICT Service Handler
+	var handler *grpchandler.CoreDictServiceHandler
 	var cleanup *Cleanup
+	var statsExporter *grpchandler.StatisticsExporter
+	var metricsSources []grpchandler.MetricsSource
+	var lookupLimiter grpchandler.LookupRateLimiter
+	var idempotencyInterceptor *grpchandler.IdempotencyInterceptor
 	if useMockMode {
 		logger.Warn("⚠️  MOCK MODE ENABLED - Using mock responses for all RPCs")
 		logger.Warn("⚠️  Set CORE_DICT_USE_MOCK_MODE=false to enable real business logic")
 
 		// Create handler with nil dependencies (mock mode doesn't need them)
-		handler := grpchandler.NewCoreDictServiceHandler(
+		handler = grpchandler.NewCoreDictServiceHandler(
 			true, // useMockMode = true
-			nil, nil, nil, nil, nil, nil, nil, nil, nil, // commands (not needed in mock mode)
-			nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, // queries (not needed in mock mode)
+			nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, // commands (not needed in mock mode)
+			nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, // queries (not needed in mock mode)
+			nil, // lookup rate limiter (not needed in mock mode)
+			nil, // key verifier (not needed in mock mode)
+			nil, // data subject requests (not needed in mock mode)
+			nil, // audit chain verification (not needed in mock mode)
+			nil, // webhooks (not needed in mock mode)
 			logger,
 		)
-		corev1.RegisterCoreDictServiceServer(grpcServer, handler)
-		logger.Info("✅ CoreDictService registered (MOCK MODE)")
+		// No idempotency store in mock mode (requests are never deduplicated)
+		idempotencyInterceptor = grpchandler.NewIdempotencyInterceptor(nil, grpchandler.IdempotencyConfig{}, logger)
 	} else {
 		logger.Info("🚀 REAL MODE ENABLED - Initializing all dependencies...")
 
 		// Initialize all dependencies and create handler
-		handler, cleanupResources, err := initializeRealHandler(logger)
+		realHandler, statisticsExporter, realMetricsSources, realLookupLimiter, realIdempotencyInterceptor, cleanupResources, err := initializeRealHandler(logger)
 		if err != nil {
 			logger.Error("❌ Failed to initialize Real Mode", "error", err)
 			logger.Error("💡 Tip: Set CORE_DICT_USE_MOCK_MODE=true to use mock mode for testing")
 			os.Exit(1)
 		}
+		handler = realHandler
 		cleanup = cleanupResources
+		statsExporter = statisticsExporter
+		metricsSources = realMetricsSources
+		lookupLimiter = realLookupLimiter
+		idempotencyInterceptor = realIdempotencyInterceptor
+	}
+
+	// ============================================================
+	// 4. gRPC SERVER
+	// ============================================================
+	lis, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		logger.Error("Failed to listen", "error", err, "port", port)
+		os.Exit(1)
+	}
+
+	// gRPC server options
+	metricsInterceptor := grpchandler.NewMetricsInterceptor()
+	lookupRateLimitInterceptor := grpchandler.NewLookupRateLimitInterceptor(lookupLimiter, logger)
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(
+			loggingInterceptor(logger),
+			metricsInterceptor.Unary(),
+			lookupRateLimitInterceptor.Unary(), // DICT anti-scan (LookupKey only, no-op in mock mode)
+			idempotencyInterceptor.Unary(),     // request_id replay of mutating RPCs (no-op in mock mode)
+		),
+		grpc.MaxRecvMsgSize(10 * 1024 * 1024), // 10MB
+		grpc.MaxSendMsgSize(10 * 1024 * 1024), // 10MB
+	}
 
-		// Register handler
-		corev1.RegisterCoreDictServiceServer(grpcServer, handler)
+	grpcServer := grpc.NewServer(opts...)
+
+	// 4a. Core DICT Service Handler
+	corev1.RegisterCoreDictServiceServer(grpcServer, handler)
+	if useMockMode {
+		logger.Info("✅ CoreDictService registered (MOCK MODE)")
+	} else {
 		logger.Info("✅ CoreDictService registered (REAL MODE)")
 	}
 
@@ -117,6 +145,21 @@ func main() {
 		}
 	}()
 
+	// Prometheus metrics (gRPC metrics + DICT statistics from materialized view + outbox relay)
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", grpchandler.NewMetricsHTTPHandler(metricsInterceptor, statsExporter, metricsSources...))
+	metricsServer := &http.Server{
+		Addr:              ":" + metricsPort,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		logger.Info("📊 Metrics server listening", "address", metricsServer.Addr)
+		if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("Metrics server failed", "error", err)
+		}
+	}()
+
 	// ============================================================
 	// 6. GRACEFUL SHUTDOWN
 	// ============================================================
@@ -126,6 +169,10 @@ func main() {
 
 	logger.Info("🛑 Shutting down gRPC server...")
 
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), 5*time.Second)
+	_ = metricsServer.Shutdown(shutdownCtx)
+	cancelShutdown()
+
 	// Graceful stop with timeout
 	stopped := make(chan struct{})
 	go func() {
@@ -6,18 +6,30 @@ import (
 	"log/slog"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/redis/go-redis/v9"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 
+	"github.com/lbpay-lab/dict-contracts/fieldcrypto"
+	"github.com/lbpay-lab/dict-contracts/idempotency"
+
 	"github.com/lbpay-lab/core-dict/internal/application/commands"
 	"github.com/lbpay-lab/core-dict/internal/application/queries"
 	"github.com/lbpay-lab/core-dict/internal/application/services"
+	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
 	"github.com/lbpay-lab/core-dict/internal/infrastructure/adapters"
+	"github.com/lbpay-lab/core-dict/internal/infrastructure/cache"
+	"github.com/lbpay-lab/core-dict/internal/infrastructure/corebanking"
 	"github.com/lbpay-lab/core-dict/internal/infrastructure/database"
 	grpcinfra "github.com/lbpay-lab/core-dict/internal/infrastructure/grpc"
+	"github.com/lbpay-lab/core-dict/internal/infrastructure/messaging"
+	"github.com/lbpay-lab/core-dict/internal/infrastructure/notification"
+	"github.com/lbpay-lab/core-dict/internal/infrastructure/outbox"
+	"github.com/lbpay-lab/core-dict/internal/infrastructure/webhook"
 )
 
 // Config holds all configuration for Real Mode initialization
@@ -36,9 +48,23 @@ type Config struct {
 	RedisPassword string
 	RedisDB       int
 
-	// Pulsar (optional for now)
+	// Pulsar (domain events published by the outbox relay)
 	PulsarURL string
 
+	// AccountClosed events from core banking (account closure cascade)
+	AccountClosedConsumerEnabled bool
+	AccountClosedConsumer        corebanking.ConsumerConfig
+
+	// Events from conn-dict (entry status, claims, infractions); the Bacen answers to the
+	// deletions of an account closure finish its keys
+	ConnectEventsConsumerEnabled bool
+	ConnectEventsSubscription    string
+
+	// Transactional outbox relay
+	OutboxRelayEnabled bool
+	OutboxRelay        outbox.RelayConfig
+	OutboxSendTimeout  time.Duration
+
 	// Connect (gRPC Client to conn-dict)
 	ConnectURL     string
 	ConnectEnabled bool
@@ -46,6 +72,47 @@ type Config struct {
 	// Participant ISPB
 	ParticipantISPB string
 
+	// Field-level encryption of personal data (local keyring file as KMS)
+	FieldEncryptionKeyringFile string
+	ReencryptionEnabled        bool
+	ReencryptionInterval       time.Duration
+	ReencryptionBatchSize      int
+
+	// Statistics materialized view refresh interval
+	StatisticsRefreshInterval time.Duration
+
+	// LookupKey anti-scan token buckets (Redis)
+	LookupRateLimit *cache.LookupRateLimitConfig
+
+	// request_id idempotency of mutating RPCs (Postgres + Redis)
+	IdempotencyEnabled       bool
+	Idempotency              grpcinfra.IdempotencyConfig
+	IdempotencyPurgeInterval time.Duration
+
+	// EMAIL/PHONE ownership verification (FEATURE_OTP_VALIDATION)
+	KeyVerificationEnabled  bool
+	KeyVerification         *services.KeyVerificationConfig
+	KeyVerificationNotifier string // "log" or "file"
+	KeyVerificationFilePath string
+
+	// LGPD data subject requests (ExportPersonalData/ErasePersonalData)
+	DSREnabled         bool
+	DSRSigningKeyFile  string // Ed25519 seed (base64) that signs the exported bundles
+	DSRRetentionPeriod time.Duration
+	DSRConnDictAddress string // conn-dict gRPC address (optional: without it only core-dict data is covered)
+
+	// Audit log hash chain checkpoints (VerifyAuditChain)
+	AuditCheckpointSigningKeyFile string // Ed25519 seed (base64); empty: no checkpoints are written
+	AuditCheckpointTrustedKeys    string // Previous public keys (base64, comma separated)
+	AuditCheckpointInterval       time.Duration
+	AuditLinkInterval             time.Duration // Linking of committed events into the chain
+
+	// Outbound webhooks to the banking backends (claim/entry/infraction events)
+	WebhooksEnabled          bool
+	WebhookConsumer          webhook.ConsumerConfig
+	WebhookDispatcher        webhook.DispatcherConfig
+	WebhookAllowInsecureURLs bool // Accept http:// endpoints (development only)
+
 	// Timeouts
 	DatabaseTimeout time.Duration
 	RedisTimeout    time.Duration
@@ -69,9 +136,35 @@ func loadConfig() *Config {
 		RedisPassword: getEnv("REDIS_PASSWORD", ""),
 		RedisDB:       getEnvAsInt("REDIS_DB", 0),
 
-		// Pulsar (optional)
+		// Pulsar
 		PulsarURL: getEnv("PULSAR_URL", "pulsar://localhost:6650"),
 
+		// AccountClosed consumer
+		AccountClosedConsumerEnabled: getEnv("ACCOUNT_CLOSED_CONSUMER_ENABLED", "false") == "true",
+		AccountClosedConsumer: corebanking.ConsumerConfig{
+			PulsarURL:        getEnv("PULSAR_URL", "pulsar://localhost:6650"),
+			Topic:            getEnv("ACCOUNT_CLOSED_TOPIC", corebanking.DefaultConsumerConfig().Topic),
+			SubscriptionName: getEnv("ACCOUNT_CLOSED_SUBSCRIPTION", corebanking.DefaultConsumerConfig().SubscriptionName),
+			DLQTopic:         getEnv("ACCOUNT_CLOSED_DLQ_TOPIC", corebanking.DefaultConsumerConfig().DLQTopic),
+			MaxDeliveries:    uint32(getEnvAsInt("ACCOUNT_CLOSED_MAX_DELIVERIES", int(corebanking.DefaultConsumerConfig().MaxDeliveries))),
+		},
+
+		// conn-dict events consumer
+		ConnectEventsConsumerEnabled: getEnv("CONNECT_EVENTS_CONSUMER_ENABLED", "false") == "true",
+		ConnectEventsSubscription:    getEnv("CONNECT_EVENTS_SUBSCRIPTION", messaging.DefaultEntryEventConsumerConfig().SubscriptionName),
+
+		// Outbox relay
+		OutboxRelayEnabled: getEnv("OUTBOX_RELAY_ENABLED", "true") == "true",
+		OutboxRelay: outbox.RelayConfig{
+			PollInterval: getEnvAsDuration("OUTBOX_POLL_INTERVAL", outbox.DefaultRelayConfig().PollInterval),
+			BatchSize:    getEnvAsInt("OUTBOX_BATCH_SIZE", outbox.DefaultRelayConfig().BatchSize),
+			MaxAttempts:  getEnvAsInt("OUTBOX_MAX_ATTEMPTS", outbox.DefaultRelayConfig().MaxAttempts),
+			BackoffBase:  getEnvAsDuration("OUTBOX_BACKOFF_BASE", outbox.DefaultRelayConfig().BackoffBase),
+			BackoffMax:   getEnvAsDuration("OUTBOX_BACKOFF_MAX", outbox.DefaultRelayConfig().BackoffMax),
+			Retention:    getEnvAsDuration("OUTBOX_RETENTION", outbox.DefaultRelayConfig().Retention),
+		},
+		OutboxSendTimeout: getEnvAsDuration("OUTBOX_SEND_TIMEOUT", 10*time.Second),
+
 		// Connect
 		ConnectURL:     getEnv("CONNECT_URL", "localhost:9092"),
 		ConnectEnabled: getEnv("CONNECT_ENABLED", "false") == "true",
@@ -79,6 +172,50 @@ func loadConfig() *Config {
 		// Participant
 		ParticipantISPB: getEnv("PARTICIPANT_ISPB", "12345678"),
 
+		// Field-level encryption
+		FieldEncryptionKeyringFile: getEnv("FIELD_ENCRYPTION_KEYRING_FILE", ""),
+		ReencryptionEnabled:        getEnv("FIELD_REENCRYPTION_ENABLED", "true") == "true",
+		ReencryptionInterval:       getEnvAsDuration("FIELD_REENCRYPTION_INTERVAL", database.DefaultReencryptionInterval),
+		ReencryptionBatchSize:      getEnvAsInt("FIELD_REENCRYPTION_BATCH_SIZE", database.DefaultReencryptionBatchSize),
+
+		// Statistics
+		StatisticsRefreshInterval: getEnvAsDuration("STATISTICS_REFRESH_INTERVAL", database.DefaultStatisticsRefreshInterval),
+
+		// Anti-scan
+		LookupRateLimit: loadLookupRateLimitConfig(getEnv("PARTICIPANT_ISPB", "12345678")),
+
+		// Idempotency
+		IdempotencyEnabled: getEnv("IDEMPOTENCY_ENABLED", "true") == "true",
+		Idempotency: grpcinfra.IdempotencyConfig{
+			TTL:     getEnvAsDuration("IDEMPOTENCY_TTL", grpcinfra.DefaultIdempotencyConfig().TTL),
+			LockTTL: getEnvAsDuration("IDEMPOTENCY_LOCK_TTL", grpcinfra.DefaultIdempotencyConfig().LockTTL),
+		},
+		IdempotencyPurgeInterval: getEnvAsDuration("IDEMPOTENCY_PURGE_INTERVAL", time.Hour),
+
+		// Key ownership verification
+		KeyVerificationEnabled:  getEnv("FEATURE_OTP_VALIDATION", "true") == "true",
+		KeyVerification:         loadKeyVerificationConfig(),
+		KeyVerificationNotifier: getEnv("KEY_VERIFICATION_NOTIFIER", "log"),
+		KeyVerificationFilePath: getEnv("KEY_VERIFICATION_NOTIFIER_FILE", "/tmp/core-dict-verification-codes.jsonl"),
+
+		// LGPD data subject requests
+		DSREnabled:         getEnv("DSR_ENABLED", "false") == "true",
+		DSRSigningKeyFile:  getEnv("DSR_SIGNING_KEY_FILE", ""),
+		DSRRetentionPeriod: getEnvAsDuration("DSR_RETENTION_PERIOD", services.DefaultDataSubjectRequestConfig().RetentionPeriod),
+		DSRConnDictAddress: getEnv("DSR_CONN_DICT_ADDRESS", ""),
+
+		// Audit hash chain
+		AuditCheckpointSigningKeyFile: getEnv("AUDIT_CHECKPOINT_SIGNING_KEY_FILE", ""),
+		AuditCheckpointTrustedKeys:    getEnv("AUDIT_CHECKPOINT_TRUSTED_KEYS", ""),
+		AuditCheckpointInterval:       getEnvAsDuration("AUDIT_CHECKPOINT_INTERVAL", database.DefaultAuditCheckpointInterval),
+		AuditLinkInterval:             getEnvAsDuration("AUDIT_LINK_INTERVAL", database.DefaultAuditLinkInterval),
+
+		// Webhooks
+		WebhooksEnabled:          getEnv("WEBHOOKS_ENABLED", "false") == "true",
+		WebhookConsumer:          loadWebhookConsumerConfig(getEnv("PULSAR_URL", "pulsar://localhost:6650")),
+		WebhookDispatcher:        loadWebhookDispatcherConfig(),
+		WebhookAllowInsecureURLs: getEnv("WEBHOOK_ALLOW_INSECURE_URLS", "false") == "true",
+
 		// Timeouts
 		DatabaseTimeout: getEnvAsDuration("DATABASE_TIMEOUT", 10*time.Second),
 		RedisTimeout:    getEnvAsDuration("REDIS_TIMEOUT", 5*time.Second),
@@ -86,8 +223,10 @@ func loadConfig() *Config {
 	}
 }
 
-// initializeRealHandler creates a fully initialized handler with all dependencies
-func initializeRealHandler(logger *slog.Logger) (*grpcinfra.CoreDictServiceHandler, *Cleanup, error) {
+// initializeRealHandler creates a fully initialized handler with all dependencies,
+// the statistics exporter and outbox relay metrics served on /metrics, the
+// LookupKey anti-scan rate limiter and the request_id idempotency interceptor
+func initializeRealHandler(logger *slog.Logger) (*grpcinfra.CoreDictServiceHandler, *grpcinfra.StatisticsExporter, []grpcinfra.MetricsSource, grpcinfra.LookupRateLimiter, *grpcinfra.IdempotencyInterceptor, *Cleanup, error) {
 	logger.Info("🔧 Initializing Real Mode handler with all dependencies...")
 
 	// 1. Load configuration
@@ -128,14 +267,14 @@ func initializeRealHandler(logger *slog.Logger) (*grpcinfra.CoreDictServiceHandl
 
 	pgPool, err := database.NewPostgresConnectionPool(ctx, pgConfig)
 	if err != nil {
-		return nil, cleanup, fmt.Errorf("failed to connect to PostgreSQL: %w", err)
+		return nil, nil, nil, nil, nil, cleanup, fmt.Errorf("failed to connect to PostgreSQL: %w", err)
 	}
 	cleanup.AddPostgres(pgPool)
 	logger.Info("✅ PostgreSQL connected successfully")
 
 	// Test database health
 	if err := pgPool.HealthCheck(ctx); err != nil {
-		return nil, cleanup, fmt.Errorf("PostgreSQL health check failed: %w", err)
+		return nil, nil, nil, nil, nil, cleanup, fmt.Errorf("PostgreSQL health check failed: %w", err)
 	}
 	logger.Info("✅ PostgreSQL health check passed")
 
@@ -158,7 +297,7 @@ func initializeRealHandler(logger *slog.Logger) (*grpcinfra.CoreDictServiceHandl
 
 	// Test Redis connection
 	if err := redisClient.Ping(ctx).Err(); err != nil {
-		return nil, cleanup, fmt.Errorf("failed to connect to Redis: %w", err)
+		return nil, nil, nil, nil, nil, cleanup, fmt.Errorf("failed to connect to Redis: %w", err)
 	}
 	logger.Info("✅ Redis connected successfully")
 
@@ -189,49 +328,97 @@ func initializeRealHandler(logger *slog.Logger) (*grpcinfra.CoreDictServiceHandl
 	}
 
 	// ============================================================
-	// 5. INITIALIZE PULSAR (optional - not blocking)
+	// 5. INITIALIZE TRANSACTIONAL OUTBOX (domain events → Pulsar)
 	// ============================================================
-	// TODO: Initialize Pulsar client and producers
-	// For now, we'll continue without Pulsar
-	logger.Info("ℹ️  Pulsar initialization skipped (not required for basic functionality)")
+	// Commands write their events to core_dict.outbox_events in the same transaction as the
+	// aggregate; the relay publishes them to Pulsar in order per aggregate
+	txManager := database.NewTransactionManager(pgPool.Pool())
+	outboxRepo := database.NewPostgresOutboxRepository(pgPool.Pool())
+
+	var metricsSources []grpcinfra.MetricsSource
+	if config.OutboxRelayEnabled {
+		sender, err := outbox.NewPulsarSender(config.PulsarURL, config.OutboxSendTimeout)
+		if err != nil {
+			return nil, nil, nil, nil, nil, cleanup, fmt.Errorf("failed to create outbox Pulsar sender: %w", err)
+		}
+		relay := outbox.NewRelay(outboxRepo, sender, config.OutboxRelay, logger)
+		relayCtx, stopRelay := context.WithCancel(context.Background())
+		relay.Start(relayCtx)
+		cleanup.AddStopFunc(stopRelay)
+		cleanup.AddStopFunc(sender.Close)
+		metricsSources = append(metricsSources, relay.Metrics())
+
+		logger.Info("✅ Outbox relay started",
+			"pulsar_url", config.PulsarURL,
+			"poll_interval", config.OutboxRelay.PollInterval,
+			"batch_size", config.OutboxRelay.BatchSize,
+		)
+	} else {
+		logger.Warn("⚠️  Outbox relay disabled (OUTBOX_RELAY_ENABLED=false): events stay pending in core_dict.outbox_events")
+	}
 
 	// ============================================================
-	// 6. CREATE REPOSITORIES
+	// 6. INITIALIZE FIELD-LEVEL ENCRYPTION (LGPD)
+	// ============================================================
+	// Key values (CPF/EMAIL/PHONE) and account holder data are stored encrypted;
+	// lookups go through HMAC blind indexes
+	if config.FieldEncryptionKeyringFile == "" {
+		return nil, nil, nil, nil, nil, cleanup, fmt.Errorf("FIELD_ENCRYPTION_KEYRING_FILE is required")
+	}
+	keyProvider, err := fieldcrypto.NewFileKeyProvider(config.FieldEncryptionKeyringFile)
+	if err != nil {
+		return nil, nil, nil, nil, nil, cleanup, fmt.Errorf("failed to load field encryption keyring: %w", err)
+	}
+	fieldCipher, err := fieldcrypto.NewCipher(ctx, keyProvider)
+	if err != nil {
+		return nil, nil, nil, nil, nil, cleanup, fmt.Errorf("failed to create field cipher: %w", err)
+	}
+	logger.Info("✅ Field encryption initialized", "key_id", fieldCipher.CurrentKeyID())
+
+	if config.ReencryptionEnabled {
+		// Migrates legacy plaintext rows and rows under a previous key version
+		reencryptorCtx, stopReencryptor := context.WithCancel(context.Background())
+		database.NewFieldReencryptor(pgPool.Pool(), fieldCipher, config.ReencryptionInterval, config.ReencryptionBatchSize, logger).Start(reencryptorCtx)
+		cleanup.AddStopFunc(stopReencryptor)
+		logger.Info("✅ Field re-encryption job started", "interval", config.ReencryptionInterval)
+	}
+
+	// ============================================================
+	// 7. CREATE REPOSITORIES
 	// ============================================================
 	logger.Info("🏗️  Creating repositories...")
 
-	entryRepo := database.NewPostgresEntryRepository(pgPool.Pool())
+	entryRepo := database.NewPostgresEntryRepository(pgPool.Pool(), fieldCipher)
 	claimRepo := database.NewPostgresClaimRepository(pgPool.Pool())
-	accountRepo := database.NewPostgresAccountRepository(pgPool.Pool())
+	accountRepo := database.NewPostgresAccountRepository(pgPool.Pool(), fieldCipher)
 	auditRepo := database.NewPostgresAuditRepository(pgPool.Pool())
 	healthRepo := database.NewPostgresHealthRepository(pgPool.Pool(), redisClient)
 	statsRepo := database.NewPostgresStatisticsRepository(pgPool.Pool())
 	infractionRepo := database.NewPostgresInfractionRepository(pgPool.Pool())
+	portabilityRepo := database.NewPostgresPortabilityRepository(pgPool.Pool())
+	accountClosureRepo := database.NewPostgresAccountClosureRepository(pgPool.Pool())
 
-	logger.Info("✅ Repositories created successfully (7/7)")
+	logger.Info("✅ Repositories created successfully (9/9)")
 
 	// ============================================================
-	// 7. CREATE SERVICES
+	// 8. CREATE SERVICES
 	// ============================================================
 	logger.Info("🏗️  Creating application services...")
 
 	// Cache service (wraps Redis)
 	cacheService := services.NewCacheServiceImpl(&redisClientAdapter{client: redisClient})
 
-	// Event publisher service (mock for now - Pulsar-based later)
-	var eventPublisher commands.EventPublisher = &mockEventPublisher{logger: logger}
+	// Event publisher: appends to the outbox inside the command transaction
+	eventPublisher := outbox.NewPublisher(outboxRepo, outbox.DefaultTopicRouter())
 
-	// Entry event producer (mock for now)
-	var entryProducer commands.EntryEventProducer
-
-	logger.Info("✅ Application services initialized (1 functional + 2 mocks)")
+	logger.Info("✅ Application services initialized (cache + outbox event publisher)")
 
 	// ============================================================
-	// 8. CREATE COMMAND HANDLERS (9 handlers)
+	// 9. CREATE COMMAND HANDLERS (10 handlers)
 	// ============================================================
 	logger.Info("🏗️  Creating command handlers...")
 
-	// NOTE: Some dependencies are nil (validators, entryProducer)
+	// NOTE: Some dependencies are nil (validators)
 	// They will be implemented later
 	var keyValidator commands.KeyValidatorService
 	var ownershipChecker commands.OwnershipService
@@ -245,7 +432,7 @@ func initializeRealHandler(logger *slog.Logger) (*grpcinfra.CoreDictServiceHandl
 		duplicateChecker,
 		cacheService,
 		connectClient,
-		entryProducer,
+		txManager,
 	)
 
 	updateEntryCmd := commands.NewUpdateEntryCommandHandler(
@@ -253,7 +440,7 @@ func initializeRealHandler(logger *slog.Logger) (*grpcinfra.CoreDictServiceHandl
 		eventPublisher,
 		cacheService,
 		connectClient,
-		entryProducer,
+		txManager,
 	)
 
 	deleteEntryCmd := commands.NewDeleteEntryCommandHandler(
@@ -261,37 +448,44 @@ func initializeRealHandler(logger *slog.Logger) (*grpcinfra.CoreDictServiceHandl
 		eventPublisher,
 		cacheService,
 		connectClient,
-		entryProducer,
+		txManager,
 	)
 
 	blockEntryCmd := commands.NewBlockEntryCommandHandler(
 		entryRepo,
 		eventPublisher,
 		cacheService,
+		auditRepo,
+		txManager,
 	)
 
 	unblockEntryCmd := commands.NewUnblockEntryCommandHandler(
 		entryRepo,
 		eventPublisher,
 		cacheService,
+		auditRepo,
+		txManager,
 	)
 
 	createClaimCmd := commands.NewCreateClaimCommandHandler(
 		entryRepo,
 		claimRepo,
 		eventPublisher,
+		txManager,
 	)
 
 	confirmClaimCmd := commands.NewConfirmClaimCommandHandler(
 		claimRepo,
 		entryRepo,
 		eventPublisher,
+		txManager,
 	)
 
 	cancelClaimCmd := commands.NewCancelClaimCommandHandler(
 		claimRepo,
 		entryRepo,
 		eventPublisher,
+		txManager,
 	)
 
 	completeClaimCmd := commands.NewCompleteClaimCommandHandler(
@@ -299,12 +493,24 @@ func initializeRealHandler(logger *slog.Logger) (*grpcinfra.CoreDictServiceHandl
 		entryRepo,
 		eventPublisher,
 		cacheService,
+		txManager,
 	)
 
-	logger.Info("✅ Command handlers initialized (9/9 functional)")
+	closeAccountCmd := commands.NewCloseAccountCommandHandler(
+		accountRepo,
+		entryRepo,
+		claimRepo,
+		portabilityRepo,
+		accountClosureRepo,
+		eventPublisher,
+		cacheService,
+		txManager,
+	)
+
+	logger.Info("✅ Command handlers initialized (10/10 functional)")
 
 	// ============================================================
-	// 9. CREATE QUERY HANDLERS (10 handlers)
+	// 10. CREATE QUERY HANDLERS (11 handlers)
 	// ============================================================
 	logger.Info("🏗️  Creating query handlers...")
 
@@ -364,16 +570,197 @@ func initializeRealHandler(logger *slog.Logger) (*grpcinfra.CoreDictServiceHandl
 		cacheService,
 	)
 
-	logger.Info("✅ Query handlers initialized (10/10 functional)")
+	getKeyHistoryQuery := queries.NewGetKeyHistoryQueryHandler(
+		entryRepo,
+		claimRepo,
+		infractionRepo,
+		auditRepo,
+	)
+
+	logger.Info("✅ Query handlers initialized (11/11 functional)")
+
+	// Statistics materialized view refresher (feeds GetStatistics RPC and /metrics)
+	refresherCtx, stopRefresher := context.WithCancel(context.Background())
+	database.NewStatisticsRefresher(getStatisticsQuery, config.StatisticsRefreshInterval, logger).Start(refresherCtx)
+	cleanup.AddStopFunc(stopRefresher)
+	statsExporter := grpcinfra.NewStatisticsExporter(getStatisticsQuery)
+
+	logger.Info("✅ Statistics refresher started", "interval", config.StatisticsRefreshInterval)
+
+	// LookupKey anti-scan token buckets (shared by all replicas through Redis)
+	lookupLimiter, err := cache.NewLookupRateLimiter(redisClient, config.LookupRateLimit)
+	if err != nil {
+		return nil, nil, nil, nil, nil, cleanup, fmt.Errorf("failed to create lookup rate limiter: %w", err)
+	}
+	logger.Info("✅ Lookup anti-scan rate limiter initialized",
+		"participant_capacity", config.LookupRateLimit.Participant.Capacity,
+		"natural_person_capacity", config.LookupRateLimit.NaturalPerson.Capacity,
+		"legal_person_capacity", config.LookupRateLimit.LegalPerson.Capacity,
+	)
+
+	// EMAIL/PHONE ownership verification (codes in Redis, sent by the configured notifier)
+	var keyVerifier *services.KeyVerificationService
+	if config.KeyVerificationEnabled {
+		notifier, err := newVerificationNotifier(config, logger)
+		if err != nil {
+			return nil, nil, nil, nil, nil, cleanup, err
+		}
+		keyVerifier, err = services.NewKeyVerificationService(
+			cache.NewKeyVerificationStore(redisClient),
+			notifier,
+			config.KeyVerification,
+		)
+		if err != nil {
+			return nil, nil, nil, nil, nil, cleanup, fmt.Errorf("failed to create key verification service: %w", err)
+		}
+		logger.Info("✅ Key ownership verification initialized",
+			"notifier", config.KeyVerificationNotifier,
+			"code_ttl", config.KeyVerification.CodeTTL,
+			"max_attempts", config.KeyVerification.MaxAttempts,
+		)
+	} else {
+		logger.Warn("⚠️  Key ownership verification disabled (FEATURE_OTP_VALIDATION=false)")
+	}
+
+	// Portability confirmation: the OTP of a portability is the ownership verification of its key
+	var ownershipVerifier commands.KeyOwnershipVerifier
+	if keyVerifier != nil {
+		ownershipVerifier = keyVerifier
+	}
+	confirmPortabilityCmd := commands.NewConfirmPortabilityCommandHandler(portabilityRepo, entryRepo, ownershipVerifier)
+
+	// request_id idempotency of mutating RPCs (records in Postgres, completed responses cached in Redis)
+	var idempotencyStore idempotency.Store
+	if config.IdempotencyEnabled {
+		idempotencyRepo := idempotency.NewPostgresStore(pgPool.Pool(), "core_dict.idempotency_keys")
+		idempotencyStore = idempotency.NewCachedStore(idempotencyRepo, redisClient, "core-dict:idempotency:")
+
+		purgeCtx, stopPurge := context.WithCancel(context.Background())
+		go idempotencyRepo.RunPurge(purgeCtx, config.IdempotencyPurgeInterval, logger)
+		cleanup.AddStopFunc(stopPurge)
+
+		logger.Info("✅ Request idempotency initialized",
+			"ttl", config.Idempotency.TTL,
+			"lock_ttl", config.Idempotency.LockTTL,
+		)
+	} else {
+		logger.Warn("⚠️  Request idempotency disabled (IDEMPOTENCY_ENABLED=false): retried requests run again")
+	}
+	idempotencyInterceptor := grpcinfra.NewIdempotencyInterceptor(idempotencyStore, config.Idempotency, logger)
+
+	// LGPD data subject requests (export/erasure across core-dict and conn-dict)
+	var dsrService *services.DataSubjectRequestService
+	if config.DSREnabled {
+		dsrService, err = newDataSubjectRequestService(config, pgPool.Pool(), fieldCipher, auditRepo, cleanup)
+		if err != nil {
+			return nil, nil, nil, nil, nil, cleanup, err
+		}
+		logger.Info("✅ Data subject requests initialized",
+			"retention_period", config.DSRRetentionPeriod,
+			"conn_dict_source", config.DSRConnDictAddress != "",
+		)
+	} else {
+		logger.Info("ℹ️  Data subject requests disabled (DSR_ENABLED=false)")
+	}
+
+	// Audit log hash chain (committed events linked by the linker, checkpoints signed here)
+	linkCtx, stopLinker := context.WithCancel(context.Background())
+	database.NewAuditChainLinker(pgPool.Pool(), config.AuditLinkInterval, logger).Start(linkCtx)
+	cleanup.AddStopFunc(stopLinker)
+	logger.Info("✅ Audit chain linker started", "interval", config.AuditLinkInterval)
+
+	auditChainService, err := newAuditChainService(config, pgPool.Pool())
+	if err != nil {
+		return nil, nil, nil, nil, nil, cleanup, err
+	}
+	if config.AuditCheckpointSigningKeyFile != "" {
+		checkpointCtx, stopCheckpointer := context.WithCancel(context.Background())
+		database.NewAuditChainCheckpointer(auditChainService, config.AuditCheckpointInterval, logger).Start(checkpointCtx)
+		cleanup.AddStopFunc(stopCheckpointer)
+		logger.Info("✅ Audit chain checkpointer started", "interval", config.AuditCheckpointInterval)
+	} else {
+		logger.Warn("⚠️  Audit chain checkpoints disabled (AUDIT_CHECKPOINT_SIGNING_KEY_FILE not set): a recomputed chain is not detected")
+	}
+
+	// AccountClosed events from core banking trigger the same closure cascade as CloseAccount
+	if config.AccountClosedConsumerEnabled {
+		accountClosedHandler := corebanking.NewAccountClosedHandler(closeAccountCmd, accountRepo, logger)
+		accountClosedConsumer, err := corebanking.NewAccountClosedConsumer(config.AccountClosedConsumer, accountClosedHandler, logger)
+		if err != nil {
+			return nil, nil, nil, nil, nil, cleanup, fmt.Errorf("failed to create AccountClosed consumer: %w", err)
+		}
+		consumerCtx, stopConsumer := context.WithCancel(context.Background())
+		accountClosedConsumer.Start(consumerCtx)
+		cleanup.AddStopFunc(stopConsumer)
+		cleanup.AddStopFunc(accountClosedConsumer.Close)
+		logger.Info("✅ AccountClosed consumer started",
+			"topic", config.AccountClosedConsumer.Topic,
+			"subscription", config.AccountClosedConsumer.SubscriptionName,
+		)
+	} else {
+		logger.Info("ℹ️  AccountClosed consumer disabled (ACCOUNT_CLOSED_CONSUMER_ENABLED=false): closures only via CloseAccount")
+	}
+
+	// conn-dict events: the Bacen answer to each deletion of an account closure finishes its key
+	if config.ConnectEventsConsumerEnabled {
+		consumerConfig := messaging.DefaultEntryEventConsumerConfig()
+		consumerConfig.PulsarURL = config.PulsarURL
+		consumerConfig.SubscriptionName = config.ConnectEventsSubscription
+		confirmClosureKeyCmd := commands.NewConfirmAccountClosureKeyCommandHandler(accountClosureRepo, txManager)
+		connectEventsConsumer, err := messaging.NewEntryEventConsumer(consumerConfig, entryRepo, claimRepo, infractionRepo, confirmClosureKeyCmd)
+		if err != nil {
+			return nil, nil, nil, nil, nil, cleanup, fmt.Errorf("failed to create conn-dict events consumer: %w", err)
+		}
+		connectEventsCtx, stopConnectEvents := context.WithCancel(context.Background())
+		go func() {
+			if err := connectEventsConsumer.Start(connectEventsCtx); err != nil && connectEventsCtx.Err() == nil {
+				logger.Error("conn-dict events consumer stopped", "error", err)
+			}
+		}()
+		cleanup.AddStopFunc(stopConnectEvents)
+		cleanup.AddStopFunc(func() { _ = connectEventsConsumer.Close() })
+		logger.Info("✅ conn-dict events consumer started", "subscription", consumerConfig.SubscriptionName)
+	} else {
+		logger.Warn("⚠️  conn-dict events consumer disabled (CONNECT_EVENTS_CONSUMER_ENABLED=false): account closures stay AWAITING_BACEN")
+	}
+
+	// Webhooks: events are read from Pulsar into core_dict.webhook_deliveries and posted by the dispatcher
+	var webhookService *services.WebhookService
+	if config.WebhooksEnabled {
+		webhookRepo := database.NewPostgresWebhookRepository(pgPool.Pool(), fieldCipher)
+		webhookService = services.NewWebhookService(webhookRepo, services.WebhookServiceConfig{
+			AllowInsecureURLs: config.WebhookAllowInsecureURLs,
+		})
+
+		webhookConsumer, err := webhook.NewConsumer(config.WebhookConsumer, webhookRepo, logger)
+		if err != nil {
+			return nil, nil, nil, nil, nil, cleanup, fmt.Errorf("failed to create webhook consumer: %w", err)
+		}
+		webhookCtx, stopWebhooks := context.WithCancel(context.Background())
+		webhookConsumer.Start(webhookCtx)
+		dispatcher := webhook.NewDispatcher(webhookRepo, config.WebhookDispatcher, logger)
+		dispatcher.Start(webhookCtx)
+		cleanup.AddStopFunc(stopWebhooks)
+		cleanup.AddStopFunc(webhookConsumer.Close)
+		metricsSources = append(metricsSources, dispatcher.Metrics())
+
+		logger.Info("✅ Webhooks started",
+			"topics", config.WebhookConsumer.Topics,
+			"max_attempts", config.WebhookDispatcher.MaxAttempts,
+			"backoff_max", config.WebhookDispatcher.BackoffMax,
+		)
+	} else {
+		logger.Info("ℹ️  Webhooks disabled (WEBHOOKS_ENABLED=false)")
+	}
 
 	// ============================================================
-	// 10. CREATE HANDLER WITH ALL DEPENDENCIES
+	// 11. CREATE HANDLER WITH ALL DEPENDENCIES
 	// ============================================================
 	logger.Info("🏗️  Creating CoreDictServiceHandler...")
 
 	handler := grpcinfra.NewCoreDictServiceHandler(
 		false, // useMockMode = false (REAL MODE)
-		// Commands (9)
+		// Commands (11)
 		createEntryCmd,
 		updateEntryCmd,
 		deleteEntryCmd,
@@ -383,7 +770,9 @@ func initializeRealHandler(logger *slog.Logger) (*grpcinfra.CoreDictServiceHandl
 		confirmClaimCmd,
 		cancelClaimCmd,
 		completeClaimCmd,
-		// Queries (10)
+		closeAccountCmd,
+		confirmPortabilityCmd,
+		// Queries (11)
 		getEntryQuery,
 		listEntriesQuery,
 		getClaimQuery,
@@ -394,15 +783,72 @@ func initializeRealHandler(logger *slog.Logger) (*grpcinfra.CoreDictServiceHandl
 		getStatisticsQuery,
 		listInfractionsQuery,
 		getAuditLogQuery,
+		getKeyHistoryQuery,
+		// Anti-scan
+		lookupLimiter,
+		// Key ownership verification
+		keyVerifier,
+		// LGPD data subject requests
+		dsrService,
+		// Audit hash chain
+		auditChainService,
+		// Webhooks
+		webhookService,
 		// Logger
 		logger,
 	)
 
 	logger.Info("✅ CoreDictServiceHandler created successfully (REAL MODE)")
 	logger.Info("🎉 Real Mode initialization complete!")
-	logger.Info("📊 Status: 9/9 commands, 10/10 queries functional")
+	logger.Info("📊 Status: 10/10 commands, 11/11 queries functional")
+
+	return handler, statsExporter, metricsSources, lookupLimiter, idempotencyInterceptor, cleanup, nil
+}
+
+// newDataSubjectRequestService builds the LGPD export/erasure service over the core-dict
+// database and, when DSR_CONN_DICT_ADDRESS is set, conn-dict (through its ConnectService
+// personal data RPCs; conn-dict reads and pseudonymises its own tables)
+func newDataSubjectRequestService(config *Config, pool *pgxpool.Pool, cipher *fieldcrypto.Cipher, auditRepo repositories.AuditRepository, cleanup *Cleanup) (*services.DataSubjectRequestService, error) {
+	if config.DSRSigningKeyFile == "" {
+		return nil, fmt.Errorf("DSR_SIGNING_KEY_FILE is required when DSR_ENABLED=true")
+	}
+	signer, err := services.NewBundleSignerFromFile(config.DSRSigningKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load data subject bundle signing key: %w", err)
+	}
+
+	coreDictSource := database.NewPostgresPersonalDataRepository(pool, cipher)
+	sources := []services.PersonalDataSource{coreDictSource}
+	if config.DSRConnDictAddress != "" {
+		connDictClient, err := grpcinfra.NewConnectClient(config.DSRConnDictAddress, grpcinfra.WithTimeout(config.ConnectTimeout))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create conn-dict client: %w", err)
+		}
+		cleanup.AddStopFunc(func() { _ = connDictClient.Close() })
+		sources = append(sources, grpcinfra.NewConnDictPersonalDataSource(connDictClient))
+	}
+
+	return services.NewDataSubjectRequestService(sources, auditRepo, signer, coreDictSource,
+		&services.DataSubjectRequestConfig{RetentionPeriod: config.DSRRetentionPeriod})
+}
+
+// newAuditChainService builds the audit hash chain service; without a signing key it only
+// verifies (checkpoints signed by AUDIT_CHECKPOINT_TRUSTED_KEYS are still checked)
+func newAuditChainService(config *Config, pool *pgxpool.Pool) (*services.AuditChainService, error) {
+	trustedKeys, err := services.ParseCheckpointPublicKeys(config.AuditCheckpointTrustedKeys)
+	if err != nil {
+		return nil, fmt.Errorf("invalid AUDIT_CHECKPOINT_TRUSTED_KEYS: %w", err)
+	}
 
-	return handler, cleanup, nil
+	repo := database.NewPostgresAuditChainRepository(pool)
+	if config.AuditCheckpointSigningKeyFile == "" {
+		return services.NewAuditChainService(repo, nil, trustedKeys), nil
+	}
+	signer, err := services.NewBundleSignerFromFile(config.AuditCheckpointSigningKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load audit checkpoint signing key: %w", err)
+	}
+	return services.NewAuditChainService(repo, signer, trustedKeys), nil
 }
 
 // ============================================================
@@ -414,6 +860,7 @@ type Cleanup struct {
 	pgPool      *database.PostgresConnectionPool
 	redisClient *redis.Client
 	grpcConns   []*grpc.ClientConn
+	stopFuncs   []func()
 }
 
 // AddPostgres adds PostgreSQL connection pool for cleanup
@@ -431,10 +878,20 @@ func (c *Cleanup) AddGRPCConn(conn *grpc.ClientConn) {
 	c.grpcConns = append(c.grpcConns, conn)
 }
 
+// AddStopFunc adds a background job stop function for cleanup
+func (c *Cleanup) AddStopFunc(stop func()) {
+	c.stopFuncs = append(c.stopFuncs, stop)
+}
+
 // Close closes all resources
 func (c *Cleanup) Close(logger *slog.Logger) {
 	logger.Info("🧹 Cleaning up resources...")
 
+	// Stop background jobs before closing the connections they use
+	for _, stop := range c.stopFuncs {
+		stop()
+	}
+
 	if c.pgPool != nil {
 		c.pgPool.Close()
 		logger.Info("✅ PostgreSQL connection closed")
@@ -463,6 +920,77 @@ func (c *Cleanup) Close(logger *slog.Logger) {
 // HELPER FUNCTIONS
 // ============================================================
 
+// loadLookupRateLimitConfig loads the anti-scan bucket policies, starting from the defaults
+func loadLookupRateLimitConfig(participantISPB string) *cache.LookupRateLimitConfig {
+	config := cache.DefaultLookupRateLimitConfig(participantISPB)
+	config.TaxIDSecret = getEnv("LOOKUP_RATE_LIMIT_TAX_ID_SECRET", "")
+
+	config.Participant.Capacity = getEnvAsInt("LOOKUP_RATE_LIMIT_PARTICIPANT_CAPACITY", config.Participant.Capacity)
+	config.Participant.RefillPerMinute = getEnvAsInt("LOOKUP_RATE_LIMIT_PARTICIPANT_REFILL_PER_MINUTE", config.Participant.RefillPerMinute)
+	config.NaturalPerson.Capacity = getEnvAsInt("LOOKUP_RATE_LIMIT_NATURAL_PERSON_CAPACITY", config.NaturalPerson.Capacity)
+	config.NaturalPerson.RefillPerMinute = getEnvAsInt("LOOKUP_RATE_LIMIT_NATURAL_PERSON_REFILL_PER_MINUTE", config.NaturalPerson.RefillPerMinute)
+	config.LegalPerson.Capacity = getEnvAsInt("LOOKUP_RATE_LIMIT_LEGAL_PERSON_CAPACITY", config.LegalPerson.Capacity)
+	config.LegalPerson.RefillPerMinute = getEnvAsInt("LOOKUP_RATE_LIMIT_LEGAL_PERSON_REFILL_PER_MINUTE", config.LegalPerson.RefillPerMinute)
+
+	return config
+}
+
+// loadWebhookConsumerConfig loads the webhook source topics (comma separated), starting from the defaults
+func loadWebhookConsumerConfig(pulsarURL string) webhook.ConsumerConfig {
+	config := webhook.DefaultConsumerConfig()
+	config.PulsarURL = pulsarURL
+
+	if topics := getEnv("WEBHOOK_TOPICS", ""); topics != "" {
+		config.Topics = nil
+		for _, topic := range strings.Split(topics, ",") {
+			if topic = strings.TrimSpace(topic); topic != "" {
+				config.Topics = append(config.Topics, topic)
+			}
+		}
+	}
+	config.SubscriptionName = getEnv("WEBHOOK_SUBSCRIPTION", config.SubscriptionName)
+
+	return config
+}
+
+func loadWebhookDispatcherConfig() webhook.DispatcherConfig {
+	config := webhook.DefaultDispatcherConfig()
+
+	config.PollInterval = getEnvAsDuration("WEBHOOK_POLL_INTERVAL", config.PollInterval)
+	config.BatchSize = getEnvAsInt("WEBHOOK_BATCH_SIZE", config.BatchSize)
+	config.Concurrency = getEnvAsInt("WEBHOOK_CONCURRENCY", config.Concurrency)
+	config.RequestTimeout = getEnvAsDuration("WEBHOOK_REQUEST_TIMEOUT", config.RequestTimeout)
+	config.MaxAttempts = getEnvAsInt("WEBHOOK_MAX_ATTEMPTS", config.MaxAttempts)
+	config.BackoffBase = getEnvAsDuration("WEBHOOK_BACKOFF_BASE", config.BackoffBase)
+	config.BackoffMax = getEnvAsDuration("WEBHOOK_BACKOFF_MAX", config.BackoffMax)
+
+	return config
+}
+
+func loadKeyVerificationConfig() *services.KeyVerificationConfig {
+	config := services.DefaultKeyVerificationConfig(getEnv("KEY_VERIFICATION_CODE_SECRET", ""))
+
+	config.CodeTTL = getEnvAsDuration("KEY_VERIFICATION_CODE_TTL", config.CodeTTL)
+	config.VerifiedTTL = getEnvAsDuration("KEY_VERIFICATION_VERIFIED_TTL", config.VerifiedTTL)
+	config.MaxAttempts = getEnvAsInt("KEY_VERIFICATION_MAX_ATTEMPTS", config.MaxAttempts)
+	config.ResendInterval = getEnvAsDuration("KEY_VERIFICATION_RESEND_INTERVAL", config.ResendInterval)
+
+	return config
+}
+
+// newVerificationNotifier creates the notifier that delivers verification codes.
+// Only local notifiers exist for now; email/SMS providers plug in here.
+func newVerificationNotifier(config *Config, logger *slog.Logger) (services.VerificationNotifier, error) {
+	switch config.KeyVerificationNotifier {
+	case "log":
+		return notification.NewLogNotifier(logger), nil
+	case "file":
+		return notification.NewFileNotifier(config.KeyVerificationFilePath)
+	default:
+		return nil, fmt.Errorf("unknown key verification notifier: %q", config.KeyVerificationNotifier)
+	}
+}
+
 func getEnvAsInt(key string, defaultValue int) int {
 	valueStr := os.Getenv(key)
 	if valueStr == "" {
@@ -527,18 +1055,3 @@ func (a *redisClientAdapter) Exists(ctx context.Context, key string) (bool, erro
 func (a *redisClientAdapter) Expire(ctx context.Context, key string, ttl time.Duration) error {
 	return a.client.Expire(ctx, key, ttl).Err()
 }
-
-// mockEventPublisher is a temporary event publisher that just logs events
-type mockEventPublisher struct {
-	logger *slog.Logger
-}
-
-func (m *mockEventPublisher) Publish(ctx context.Context, event commands.DomainEvent) error {
-	m.logger.Info("📤 Domain event published (mock)",
-		"event_type", event.EventType,
-		"aggregate_id", event.AggregateID,
-		"aggregate_type", event.AggregateType,
-	)
-	// In real implementation, this would publish to Pulsar
-	return nil
-}
@@ -2,10 +2,11 @@ package commands
 
 import (
 	"context"
-	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lbpay-lab/core-dict/internal/domain"
 	"github.com/lbpay-lab/core-dict/internal/domain/entities"
 	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
 	"github.com/lbpay-lab/core-dict/internal/application/services"
@@ -14,7 +15,8 @@ import (
 // BlockEntryCommand comando para bloquear chave PIX (infração, fraude, etc.)
 type BlockEntryCommand struct {
 	EntryID     uuid.UUID
-	Reason      string // Motivo do bloqueio (obrigatório)
+	ReasonCode  entities.BlockReason // Código do motivo (obrigatório)
+	Reason      string // Descrição do motivo (obrigatória)
 	BlockedBy   string // Admin/Sistema que bloqueou
 	InfractionID *uuid.UUID // Opcional: ID da infração relacionada
 }
@@ -31,6 +33,8 @@ type BlockEntryCommandHandler struct {
 	entryRepo      repositories.EntryRepository
 	eventPublisher EventPublisher
 	cacheService   services.CacheService
+	auditRepo      repositories.AuditRepository
+	txManager      TransactionManager
 }
 
 // NewBlockEntryCommandHandler cria nova instância
@@ -38,30 +42,41 @@ func NewBlockEntryCommandHandler(
 	entryRepo repositories.EntryRepository,
 	eventPublisher EventPublisher,
 	cacheService services.CacheService,
+	auditRepo repositories.AuditRepository,
+	txManager TransactionManager,
 ) *BlockEntryCommandHandler {
 	return &BlockEntryCommandHandler{
 		entryRepo:      entryRepo,
 		eventPublisher: eventPublisher,
 		cacheService:   cacheService,
+		auditRepo:      auditRepo,
+		txManager:      txManager,
 	}
 }
 
 // Handle executa o comando
 func (h *BlockEntryCommandHandler) Handle(ctx context.Context, cmd BlockEntryCommand) (*BlockEntryResult, error) {
 	// 1. Validar entrada
+	if !cmd.ReasonCode.IsValid() {
+		return nil, domain.ErrValidation.WithMessage("invalid block reason code").WithField("reason_code", "unknown block reason")
+	}
 	if cmd.Reason == "" {
-		return nil, errors.New("reason is required for blocking")
+		return nil, domain.ErrValidation.WithMessage("reason is required for blocking").WithField("reason", "required")
+	}
+	if cmd.BlockedBy == "" {
+		return nil, domain.ErrValidation.WithMessage("blocked_by is required for blocking").WithField("blocked_by", "required")
 	}
 
 	// 2. Buscar entry
 	entry, err := h.entryRepo.FindByID(ctx, cmd.EntryID)
 	if err != nil {
-		return nil, errors.New("entry not found")
+		return nil, domain.ErrEntryNotFound
 	}
 
 	// 3. Validar status (apenas ACTIVE pode ser bloqueado)
 	if entry.Status != entities.KeyStatusActive {
-		return nil, errors.New("only active entries can be blocked")
+		return nil, domain.ErrInvalidStatus.WithMessage("invalid status: only active entries can be blocked").
+			WithMetadata("status", string(entry.Status))
 	}
 
 	// 4. Atualizar status para BLOCKED
@@ -69,30 +84,61 @@ func (h *BlockEntryCommandHandler) Handle(ctx context.Context, cmd BlockEntryCom
 	entry.Status = entities.KeyStatusBlocked
 	entry.UpdatedAt = now
 
-	// 5. Persistir mudança
-	if err := h.entryRepo.Update(ctx, entry); err != nil {
-		return nil, errors.New("failed to block entry: " + err.Error())
-	}
+	// Escrita e evento (outbox) na mesma transação
+	err = withTransaction(ctx, h.txManager, func(ctx context.Context) error {
+		// 5. Persistir mudança
+		if err := h.entryRepo.Update(ctx, entry); err != nil {
+			return fmt.Errorf("failed to block entry: %w", err)
+		}
 
-	// 6. Publicar evento (para notificar Bacen e usuário)
-	event := DomainEvent{
-		EventType:     "EntryBlocked",
-		AggregateID:   entry.ID.String(),
-		AggregateType: "Entry",
-		OccurredAt:    now,
-		Payload: map[string]interface{}{
-			"entry_id":      entry.ID.String(),
-			"key_value":     entry.KeyValue,
-			"reason":        cmd.Reason,
-			"blocked_by":    cmd.BlockedBy,
-			"infraction_id": cmd.InfractionID,
-		},
-	}
-	if err := h.eventPublisher.Publish(ctx, event); err != nil {
-		return nil, errors.New("failed to publish event: " + err.Error())
+		// 6. Registrar auditoria (ENTRY_BLOCKED)
+		auditEvent, err := entities.NewAuditEvent(
+			entities.EventTypeEntryBlocked,
+			entities.EntityTypeEntry,
+			entry.ID,
+			map[string]interface{}{"status": string(entities.KeyStatusActive)},
+			map[string]interface{}{"status": string(entities.KeyStatusBlocked)},
+			actorUserID(cmd.BlockedBy),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to build audit event: %w", err)
+		}
+		auditEvent.OccurredAt = now
+		auditEvent.AddMetadata("reason_code", string(cmd.ReasonCode))
+		auditEvent.AddMetadata("reason", cmd.Reason)
+		auditEvent.AddMetadata("actor", cmd.BlockedBy)
+		if cmd.InfractionID != nil {
+			auditEvent.AddMetadata("infraction_id", cmd.InfractionID.String())
+		}
+		if err := h.auditRepo.Create(ctx, auditEvent); err != nil {
+			return fmt.Errorf("failed to record audit event: %w", err)
+		}
+
+		// 7. Publicar evento (para notificar Bacen e usuário)
+		event := DomainEvent{
+			EventType:     "EntryBlocked",
+			AggregateID:   entry.ID.String(),
+			AggregateType: "Entry",
+			OccurredAt:    now,
+			Payload: map[string]interface{}{
+				"entry_id":      entry.ID.String(),
+				"key_value":     entry.KeyValue,
+				"reason_code":   string(cmd.ReasonCode),
+				"reason":        cmd.Reason,
+				"blocked_by":    cmd.BlockedBy,
+				"infraction_id": cmd.InfractionID,
+			},
+		}
+		if err := h.eventPublisher.Publish(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// 7. Invalidar cache
+	// 8. Invalidar cache
 	h.cacheService.Delete(ctx, "entry:"+entry.KeyValue)
 
 	return &BlockEntryResult{
@@ -101,3 +147,14 @@ func (h *BlockEntryCommandHandler) Handle(ctx context.Context, cmd BlockEntryCom
 		BlockedAt: now,
 	}, nil
 }
+
+// actorUserID converte o identificador do ator em UUID (quando aplicável).
+// Atores que não são usuários do Core DICT (ex.: "system", IDs externos)
+// ficam registrados apenas nos metadados da auditoria.
+func actorUserID(actor string) *uuid.UUID {
+	id, err := uuid.Parse(actor)
+	if err != nil {
+		return nil
+	}
+	return &id
+}
@@ -2,10 +2,11 @@ package commands
 
 import (
 	"context"
-	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lbpay-lab/core-dict/internal/domain"
 	"github.com/lbpay-lab/core-dict/internal/domain/entities"
 	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
 	"github.com/lbpay-lab/core-dict/internal/domain/valueobjects"
@@ -31,6 +32,7 @@ type CancelClaimCommandHandler struct {
 	claimRepo      repositories.ClaimRepository
 	entryRepo      repositories.EntryRepository
 	eventPublisher EventPublisher
+	txManager      TransactionManager
 }
 
 // NewCancelClaimCommandHandler cria nova instância
@@ -38,11 +40,13 @@ func NewCancelClaimCommandHandler(
 	claimRepo repositories.ClaimRepository,
 	entryRepo repositories.EntryRepository,
 	eventPublisher EventPublisher,
+	txManager TransactionManager,
 ) *CancelClaimCommandHandler {
 	return &CancelClaimCommandHandler{
 		claimRepo:      claimRepo,
 		entryRepo:      entryRepo,
 		eventPublisher: eventPublisher,
+		txManager:      txManager,
 	}
 }
 
@@ -50,19 +54,19 @@ func NewCancelClaimCommandHandler(
 func (h *CancelClaimCommandHandler) Handle(ctx context.Context, cmd CancelClaimCommand) (*CancelClaimResult, error) {
 	// 1. Validar 2FA
 	if cmd.TwoFactorCode == "" {
-		return nil, errors.New("2FA code required for claim cancellation")
+		return nil, domain.ErrTwoFactorRequired.WithMessage("2FA code required for claim cancellation").WithField("two_factor_code", "required")
 	}
 	// TODO: Validar 2FA
 
 	// 2. Buscar claim
 	claim, err := h.claimRepo.FindByID(ctx, cmd.ClaimID)
 	if err != nil {
-		return nil, errors.New("claim not found")
+		return nil, domain.ErrClaimNotFound
 	}
 
 	// 3. Validar se pode cancelar (status deve permitir)
 	if claim.Status.IsFinal() {
-		return nil, errors.New("cannot cancel claim in final status")
+		return nil, domain.ErrInvalidClaimStatus.WithMessage("cannot cancel claim in final status").WithMetadata("status", string(claim.Status))
 	}
 
 	// 4. Cancelar claim usando domain method
@@ -71,42 +75,45 @@ func (h *CancelClaimCommandHandler) Handle(ctx context.Context, cmd CancelClaimC
 		reason = "Cancelled by user"
 	}
 	if err := claim.Cancel(reason); err != nil {
-		return nil, errors.New("failed to cancel claim: " + err.Error())
+		return nil, fmt.Errorf("failed to cancel claim: %w", err)
 	}
 
-	// 5. Persistir mudança
-	if err := h.claimRepo.Update(ctx, claim); err != nil {
-		return nil, errors.New("failed to update claim: " + err.Error())
-	}
+	now := time.Now()
 
-	// 6. Entry continua ACTIVE (claim foi rejeitado)
-	// Nota: Entry só muda para ACTIVE novamente se estava em CLAIM_PENDING
+	// Escrita e evento (outbox) na mesma transação
+	err = withTransaction(ctx, h.txManager, func(ctx context.Context) error {
+		// 5. Persistir mudança
+		if err := h.claimRepo.Update(ctx, claim); err != nil {
+			return fmt.Errorf("failed to update claim: %w", err)
+		}
 
-	entry, err := h.entryRepo.FindByKey(ctx, claim.EntryKey)
-	if err == nil && entry.Status == "CLAIM_PENDING" {
-		entry.Status = entities.KeyStatusActive
-		entry.UpdatedAt = time.Now()
-		_ = h.entryRepo.Update(ctx, entry)
-	}
+		// 6. Entry continua ACTIVE (claim foi rejeitado)
+		// Nota: Entry só muda para ACTIVE novamente se estava em CLAIM_PENDING
 
-	// 7. Publicar evento (para notificar Bacen via RSFN)
-	now := time.Now()
-	event := DomainEvent{
-		EventType:     "ClaimCancelled",
-		AggregateID:   claim.ID.String(),
-		AggregateType: "Claim",
-		OccurredAt:    now,
-		Payload: map[string]interface{}{
-			"claim_id":       claim.ID.String(),
-			"entry_key":      claim.EntryKey,
-			"claimer_ispb":   claim.ClaimerParticipant.ISPB,
-			"donor_ispb":     claim.DonorParticipant.ISPB,
-			"bacen_claim_id": claim.BacenClaimID,
-			"reason":         cmd.Reason,
-		},
-	}
-	if err := h.eventPublisher.Publish(ctx, event); err != nil {
-		return nil, errors.New("failed to publish event: " + err.Error())
+		entry, err := h.entryRepo.FindByKey(ctx, claim.EntryKey)
+		if err == nil && entry.Status == "CLAIM_PENDING" {
+			entry.Status = entities.KeyStatusActive
+			entry.UpdatedAt = now
+			if err := h.entryRepo.Update(ctx, entry); err != nil {
+				return fmt.Errorf("failed to update entry status: %w", err)
+			}
+		}
+
+		// 7. Publicar evento (o conn-dict cancela a reivindicação no Bacen)
+		event := DomainEvent{
+			EventType:     "ClaimCancelled",
+			AggregateID:   claim.ID.String(),
+			AggregateType: "Claim",
+			OccurredAt:    now,
+			Payload:       claimCancelledEvent(claim, reason, now),
+		}
+		if err := h.eventPublisher.Publish(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return &CancelClaimResult{
@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lbpay-lab/core-dict/internal/domain/entities"
+	"github.com/lbpay-lab/dict-contracts/events"
+)
+
+// Payloads dos cancelamentos consumidos pelo conn-dict (contrato em dict-contracts/events), que
+// cancela a reivindicação no Bacen. O request_id é também a chave de idempotência no Bridge.
+
+// claimCancelledEvent monta o payload ClaimCancelled
+func claimCancelledEvent(claim *entities.Claim, reason string, occurredAt time.Time) *events.ClaimCancelledEvent {
+	requestID := uuid.NewString()
+	return &events.ClaimCancelledEvent{
+		ClaimID:        claim.ID.String(),
+		BacenClaimID:   claim.BacenClaimID,
+		Key:            claim.EntryKey,
+		ClaimerISPB:    claim.ClaimerParticipant.ISPB,
+		DonorISPB:      claim.DonorParticipant.ISPB,
+		Reason:         reason,
+		IdempotencyKey: requestID,
+		RequestID:      requestID,
+		Timestamp:      occurredAt,
+	}
+}
+
+// portabilityCancelledEvent monta o payload PortabilityCancelled
+func portabilityCancelledEvent(portability *entities.Portability, reason string, occurredAt time.Time) *events.PortabilityCancelledEvent {
+	requestID := uuid.NewString()
+	return &events.PortabilityCancelledEvent{
+		PortabilityID:      portability.ID.String(),
+		BacenPortabilityID: portability.BacenPortabilityID,
+		EntryID:            portability.EntryID.String(),
+		Key:                portability.EntryKey,
+		OriginISPB:         portability.OriginParticipant.ISPB,
+		DestinationISPB:    portability.DestinationParticipant.ISPB,
+		Reason:             reason,
+		IdempotencyKey:     requestID,
+		RequestID:          requestID,
+		Timestamp:          occurredAt,
+	}
+}
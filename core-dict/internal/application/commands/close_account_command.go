@@ -0,0 +1,301 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lbpay-lab/core-dict/internal/application/services"
+	"github.com/lbpay-lab/core-dict/internal/domain"
+	"github.com/lbpay-lab/core-dict/internal/domain/entities"
+	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
+)
+
+// closeAccountPageSize tamanho da página na leitura das chaves da conta
+const closeAccountPageSize = 100
+
+// CloseAccountCommand comando para encerrar uma conta e excluir todas as suas chaves
+type CloseAccountCommand struct {
+	AccountID   uuid.UUID
+	Trigger     entities.AccountClosureTrigger // RPC ou EVENT (AccountClosed do core banking)
+	RequestedBy string                         // Usuário do token ou origem do evento
+	Reason      string                         // Motivo informado (opcional)
+}
+
+// CloseAccountResult resultado do comando
+type CloseAccountResult struct {
+	Closure *entities.AccountClosure
+}
+
+// CloseAccountCommandHandler encerra a conta em cascata: para cada chave, cancela claims e
+// portabilidades em aberto (eventos ClaimCancelled e PortabilityCancelled), marca a chave como
+// DELETED e registra o evento EntryDeleted com motivo ACCOUNT_CLOSURE (o relay do outbox leva a
+// exclusão ao Bacen: Connect → Bridge → Bacen DICT).
+//
+// Cada chave é processada em uma transação própria e fica REQUESTED até o conn-dict informar a
+// resposta do Bacen (ConfirmAccountClosureKeyCommandHandler), que a torna DELETED ou FAILED; uma
+// falha não interrompe as demais. O comando é idempotente: repetir o encerramento da mesma conta
+// (nova chamada do RPC ou reentrega do evento) processa apenas as chaves sem exclusão enviada ou
+// confirmada.
+type CloseAccountCommandHandler struct {
+	accountRepo     repositories.AccountRepository
+	entryRepo       repositories.EntryRepository
+	claimRepo       repositories.ClaimRepository
+	portabilityRepo repositories.PortabilityRepository
+	closureRepo     repositories.AccountClosureRepository
+	eventPublisher  EventPublisher
+	cacheService    services.CacheService
+	txManager       TransactionManager
+}
+
+// NewCloseAccountCommandHandler cria nova instância
+func NewCloseAccountCommandHandler(
+	accountRepo repositories.AccountRepository,
+	entryRepo repositories.EntryRepository,
+	claimRepo repositories.ClaimRepository,
+	portabilityRepo repositories.PortabilityRepository,
+	closureRepo repositories.AccountClosureRepository,
+	eventPublisher EventPublisher,
+	cacheService services.CacheService,
+	txManager TransactionManager,
+) *CloseAccountCommandHandler {
+	return &CloseAccountCommandHandler{
+		accountRepo:     accountRepo,
+		entryRepo:       entryRepo,
+		claimRepo:       claimRepo,
+		portabilityRepo: portabilityRepo,
+		closureRepo:     closureRepo,
+		eventPublisher:  eventPublisher,
+		cacheService:    cacheService,
+		txManager:       txManager,
+	}
+}
+
+// Handle executa o comando
+func (h *CloseAccountCommandHandler) Handle(ctx context.Context, cmd CloseAccountCommand) (*CloseAccountResult, error) {
+	// 1. Validar comando
+	if cmd.AccountID == uuid.Nil {
+		return nil, domain.ErrValidation.WithMessage("account_id is required").WithField("account_id", "required")
+	}
+	if cmd.RequestedBy == "" {
+		return nil, domain.ErrValidation.WithMessage("requested_by is required").WithField("requested_by", "required")
+	}
+
+	// 2. Buscar conta
+	account, err := h.accountRepo.FindByID(ctx, cmd.AccountID)
+	if err != nil {
+		return nil, domain.ErrAccountNotFound
+	}
+
+	// 3. Encerrar a conta e registrar o encerramento (ou retomar um encerramento anterior)
+	closure, err := h.closureRepo.FindByAccountID(ctx, cmd.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find account closure: %w", err)
+	}
+	if closure == nil {
+		closure, err = entities.NewAccountClosure(cmd.AccountID, cmd.Trigger, cmd.RequestedBy, cmd.Reason)
+		if err != nil {
+			return nil, domain.ErrValidation.WithMessage(err.Error())
+		}
+
+		err = withTransaction(ctx, h.txManager, func(ctx context.Context) error {
+			// A conta pode já ter sido encerrada pelo core banking
+			if !account.IsClosed() {
+				if err := account.Close(); err != nil {
+					return err
+				}
+				if err := h.accountRepo.Update(ctx, account); err != nil {
+					return fmt.Errorf("failed to close account: %w", err)
+				}
+			}
+			if err := h.closureRepo.Create(ctx, closure); err != nil {
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// Nova execução: respostas do Bacen recebidas durante o processamento só atualizam as chaves
+		closure.Resume()
+		if err := h.closureRepo.Update(ctx, closure); err != nil {
+			return nil, err
+		}
+	}
+
+	// 4. Buscar todas as chaves da conta
+	entries, err := h.listEntries(ctx, cmd.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 5. Excluir cada chave ainda não excluída, registrando o resultado
+	for _, entry := range entries {
+		if !closure.NeedsDeletion(entry) {
+			continue
+		}
+		if err := h.closeKey(ctx, closure, entry, cmd.RequestedBy); err != nil {
+			return nil, err
+		}
+	}
+
+	// 6. Concluir com os resultados gravados, que incluem as respostas do Bacen recebidas durante
+	// o processamento (AWAITING_BACEN enquanto faltar alguma, PARTIAL se alguma chave falhou)
+	err = withTransaction(ctx, h.txManager, func(ctx context.Context) error {
+		current, err := h.closureRepo.FindByAccountID(ctx, cmd.AccountID)
+		if err != nil {
+			return fmt.Errorf("failed to find account closure: %w", err)
+		}
+		current.Finish()
+		closure = current
+		return h.closureRepo.Update(ctx, closure)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 7. Invalidar cache das chaves da conta
+	h.cacheService.Invalidate(ctx, "entries:account:"+cmd.AccountID.String())
+
+	return &CloseAccountResult{Closure: closure}, nil
+}
+
+// listEntries lê todas as chaves da conta
+func (h *CloseAccountCommandHandler) listEntries(ctx context.Context, accountID uuid.UUID) ([]*entities.Entry, error) {
+	var entries []*entities.Entry
+	for offset := 0; ; offset += closeAccountPageSize {
+		page, err := h.entryRepo.List(ctx, accountID, closeAccountPageSize, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list account entries: %w", err)
+		}
+		entries = append(entries, page...)
+		if len(page) < closeAccountPageSize {
+			return entries, nil
+		}
+	}
+}
+
+// closeKey exclui uma chave em uma transação (cancelamentos, exclusão, evento e resultado REQUESTED).
+// Se a transação falhar, o resultado FAILED é registrado fora dela; só a falha ao registrar o
+// resultado interrompe o encerramento.
+func (h *CloseAccountCommandHandler) closeKey(ctx context.Context, closure *entities.AccountClosure, entry *entities.Entry, requestedBy string) error {
+	now := time.Now()
+	result := &entities.AccountClosureKey{
+		EntryID:     entry.ID,
+		KeyType:     entry.KeyType,
+		Outcome:     entities.AccountClosureKeyRequested,
+		Attempts:    closure.NextAttempt(entry.ID),
+		ProcessedAt: now,
+	}
+
+	err := withTransaction(ctx, h.txManager, func(ctx context.Context) error {
+		claimsCancelled, err := h.cancelClaims(ctx, entry, now)
+		if err != nil {
+			return err
+		}
+		portabilitiesCancelled, err := h.cancelPortabilities(ctx, entry, now)
+		if err != nil {
+			return err
+		}
+		result.ClaimsCancelled = claimsCancelled
+		result.PortabilitiesCancelled = portabilitiesCancelled
+
+		// Soft delete, como no DeleteEntryCommand
+		entry.Status = entities.KeyStatusDeleted
+		entry.UpdatedAt = now
+		if err := h.entryRepo.Update(ctx, entry); err != nil {
+			return fmt.Errorf("failed to delete entry: %w", err)
+		}
+
+		if err := h.eventPublisher.Publish(ctx, DomainEvent{
+			EventType:     "EntryDeleted",
+			AggregateID:   entry.ID.String(),
+			AggregateType: "Entry",
+			OccurredAt:    now,
+			Payload:       entryDeletedEvent(entry, entities.AccountClosureDeletionReason, requestedBy, now),
+		}); err != nil {
+			return fmt.Errorf("failed to publish event: %w", err)
+		}
+
+		return h.closureRepo.SaveKey(ctx, closure.ID, result)
+	})
+	if err != nil {
+		result.Outcome = entities.AccountClosureKeyFailed
+		result.ClaimsCancelled = 0
+		result.PortabilitiesCancelled = 0
+		result.Error = err.Error()
+		if saveErr := h.closureRepo.SaveKey(ctx, closure.ID, result); saveErr != nil {
+			return saveErr
+		}
+	} else {
+		h.cacheService.Delete(ctx, "entry:"+entry.KeyValue)
+	}
+
+	closure.RecordKey(result)
+	return nil
+}
+
+// cancelClaims cancela as reivindicações em aberto da chave (o conn-dict cancela no Bacen pelo evento ClaimCancelled)
+func (h *CloseAccountCommandHandler) cancelClaims(ctx context.Context, entry *entities.Entry, now time.Time) (int, error) {
+	claims, err := h.claimRepo.FindByEntryKey(ctx, entry.KeyValue)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find claims: %w", err)
+	}
+
+	cancelled := 0
+	for _, claim := range claims {
+		if claim.IsFinal() {
+			continue
+		}
+		if err := claim.Cancel(entities.AccountClosureDeletionReason); err != nil {
+			return 0, fmt.Errorf("failed to cancel claim: %w", err)
+		}
+		if err := h.claimRepo.Update(ctx, claim); err != nil {
+			return 0, fmt.Errorf("failed to update claim: %w", err)
+		}
+
+		if err := h.eventPublisher.Publish(ctx, DomainEvent{
+			EventType:     "ClaimCancelled",
+			AggregateID:   claim.ID.String(),
+			AggregateType: "Claim",
+			OccurredAt:    now,
+			Payload:       claimCancelledEvent(claim, entities.AccountClosureDeletionReason, now),
+		}); err != nil {
+			return 0, fmt.Errorf("failed to publish event: %w", err)
+		}
+		cancelled++
+	}
+
+	return cancelled, nil
+}
+
+// cancelPortabilities cancela as portabilidades em aberto da chave (evento PortabilityCancelled)
+func (h *CloseAccountCommandHandler) cancelPortabilities(ctx context.Context, entry *entities.Entry, now time.Time) (int, error) {
+	portabilities, err := h.portabilityRepo.FindActiveByEntryID(ctx, entry.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find portabilities: %w", err)
+	}
+
+	for _, portability := range portabilities {
+		if err := portability.Cancel(entities.AccountClosureDeletionReason); err != nil {
+			return 0, fmt.Errorf("failed to cancel portability: %w", err)
+		}
+		if err := h.portabilityRepo.Update(ctx, portability); err != nil {
+			return 0, fmt.Errorf("failed to update portability: %w", err)
+		}
+
+		if err := h.eventPublisher.Publish(ctx, DomainEvent{
+			EventType:     "PortabilityCancelled",
+			AggregateID:   portability.ID.String(),
+			AggregateType: "Portability",
+			OccurredAt:    now,
+			Payload:       portabilityCancelledEvent(portability, entities.AccountClosureDeletionReason, now),
+		}); err != nil {
+			return 0, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	return len(portabilities), nil
+}
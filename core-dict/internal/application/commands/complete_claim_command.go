@@ -2,10 +2,11 @@ package commands
 
 import (
 	"context"
-	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lbpay-lab/core-dict/internal/domain"
 	"github.com/lbpay-lab/core-dict/internal/domain/entities"
 	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
 	"github.com/lbpay-lab/core-dict/internal/domain/valueobjects"
@@ -32,6 +33,7 @@ type CompleteClaimCommandHandler struct {
 	entryRepo      repositories.EntryRepository
 	eventPublisher EventPublisher
 	cacheService   services.CacheService
+	txManager      TransactionManager
 }
 
 // NewCompleteClaimCommandHandler cria nova instância
@@ -40,12 +42,14 @@ func NewCompleteClaimCommandHandler(
 	entryRepo repositories.EntryRepository,
 	eventPublisher EventPublisher,
 	cacheService services.CacheService,
+	txManager TransactionManager,
 ) *CompleteClaimCommandHandler {
 	return &CompleteClaimCommandHandler{
 		claimRepo:      claimRepo,
 		entryRepo:      entryRepo,
 		eventPublisher: eventPublisher,
 		cacheService:   cacheService,
+		txManager:      txManager,
 	}
 }
 
@@ -54,60 +58,69 @@ func (h *CompleteClaimCommandHandler) Handle(ctx context.Context, cmd CompleteCl
 	// 1. Buscar claim
 	claim, err := h.claimRepo.FindByID(ctx, cmd.ClaimID)
 	if err != nil {
-		return nil, errors.New("claim not found")
+		return nil, domain.ErrClaimNotFound
 	}
 
 	// 2. Validar status (deve estar CONFIRMED)
 	if claim.Status != valueobjects.ClaimStatusConfirmed {
-		return nil, errors.New("claim must be confirmed to be completed")
+		return nil, domain.ErrInvalidClaimStatus.WithMessage("claim must be confirmed to be completed").WithMetadata("status", string(claim.Status))
 	}
 
-	// 3. Completar claim usando domain method
+	// 3. Buscar entry da chave reivindicada
+	entry, err := h.entryRepo.FindByKey(ctx, claim.EntryKey)
+	if err != nil {
+		return nil, domain.ErrEntryNotFound
+	}
+
+	// Completar claim usando domain method
 	if err := claim.Complete(); err != nil {
-		return nil, errors.New("failed to complete claim: " + err.Error())
+		return nil, fmt.Errorf("failed to complete claim: %w", err)
 	}
 
 	// Atualizar resolution reason com detalhes do Bacen
 	claim.ResolutionReason = "Completed by " + cmd.CompletedBy + ". Bacen response: " + cmd.BacenResponseID
 
-	// 4. Persistir mudança
-	if err := h.claimRepo.Update(ctx, claim); err != nil {
-		return nil, errors.New("failed to update claim: " + err.Error())
-	}
-
-	// 5. Atualizar entry (transferir para outro PSP - deletar localmente)
-	entry, err := h.entryRepo.FindByKey(ctx, claim.EntryKey)
-	if err != nil {
-		return nil, errors.New("entry not found")
-	}
-
-	// Marcar entry como transferido (soft delete)
 	now := time.Now()
-	entry.Status = entities.KeyStatusDeleted // Chave foi transferida
-	entry.UpdatedAt = now
-	entry.DeletedAt = &now // Soft delete
 
-	if err := h.entryRepo.Update(ctx, entry); err != nil {
-		return nil, errors.New("failed to update entry status: " + err.Error())
-	}
-
-	// 6. Publicar evento (para notificação ao usuário)
-	event := DomainEvent{
-		EventType:     "ClaimCompleted",
-		AggregateID:   claim.ID.String(),
-		AggregateType: "Claim",
-		OccurredAt:    now,
-		Payload: map[string]interface{}{
-			"claim_id":           claim.ID.String(),
-			"entry_key":          claim.EntryKey,
-			"key_value":          entry.KeyValue,
-			"claimer_ispb":       claim.ClaimerParticipant.ISPB,
-			"donor_ispb":         claim.DonorParticipant.ISPB,
-			"bacen_response_id":  cmd.BacenResponseID,
-		},
-	}
-	if err := h.eventPublisher.Publish(ctx, event); err != nil {
-		return nil, errors.New("failed to publish event: " + err.Error())
+	// Escrita e evento (outbox) na mesma transação
+	err = withTransaction(ctx, h.txManager, func(ctx context.Context) error {
+		// 4. Persistir mudança
+		if err := h.claimRepo.Update(ctx, claim); err != nil {
+			return fmt.Errorf("failed to update claim: %w", err)
+		}
+
+		// 5. Atualizar entry (transferir para outro PSP - deletar localmente)
+		// Marcar entry como transferido (soft delete)
+		entry.Status = entities.KeyStatusDeleted // Chave foi transferida
+		entry.UpdatedAt = now
+		entry.DeletedAt = &now // Soft delete
+
+		if err := h.entryRepo.Update(ctx, entry); err != nil {
+			return fmt.Errorf("failed to update entry status: %w", err)
+		}
+
+		// 6. Publicar evento (para notificação ao usuário)
+		event := DomainEvent{
+			EventType:     "ClaimCompleted",
+			AggregateID:   claim.ID.String(),
+			AggregateType: "Claim",
+			OccurredAt:    now,
+			Payload: map[string]interface{}{
+				"claim_id":           claim.ID.String(),
+				"entry_key":          claim.EntryKey,
+				"key_value":          entry.KeyValue,
+				"claimer_ispb":       claim.ClaimerParticipant.ISPB,
+				"donor_ispb":         claim.DonorParticipant.ISPB,
+				"bacen_response_id":  cmd.BacenResponseID,
+			},
+		}
+		if err := h.eventPublisher.Publish(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// 7. Invalidar cache
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lbpay-lab/core-dict/internal/domain"
+	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
+)
+
+// ConfirmAccountClosureKeyCommand resposta do Bacen à exclusão de uma chave, informada pelo
+// conn-dict (EntryStatusChanged)
+type ConfirmAccountClosureKeyCommand struct {
+	EntryID  uuid.UUID
+	BacenErr string // Vazio: exclusão confirmada; senão, motivo da rejeição
+}
+
+// ConfirmAccountClosureKeyResult resultado do comando
+type ConfirmAccountClosureKeyResult struct {
+	Confirmed bool // false: a chave não aguardava resposta (exclusão fora de um encerramento ou reentrega)
+}
+
+// ConfirmAccountClosureKeyCommandHandler registra o resultado final de uma chave do encerramento de
+// conta. Enquanto o CloseAccountCommandHandler processa as chaves, só o resultado da chave é gravado
+// e o próprio comando conclui o encerramento; depois disso, a última resposta o conclui.
+type ConfirmAccountClosureKeyCommandHandler struct {
+	closureRepo repositories.AccountClosureRepository
+	txManager   TransactionManager
+}
+
+// NewConfirmAccountClosureKeyCommandHandler cria nova instância
+func NewConfirmAccountClosureKeyCommandHandler(
+	closureRepo repositories.AccountClosureRepository,
+	txManager TransactionManager,
+) *ConfirmAccountClosureKeyCommandHandler {
+	return &ConfirmAccountClosureKeyCommandHandler{
+		closureRepo: closureRepo,
+		txManager:   txManager,
+	}
+}
+
+// Handle executa o comando
+func (h *ConfirmAccountClosureKeyCommandHandler) Handle(ctx context.Context, cmd ConfirmAccountClosureKeyCommand) (*ConfirmAccountClosureKeyResult, error) {
+	if cmd.EntryID == uuid.Nil {
+		return nil, domain.ErrValidation.WithMessage("entry_id is required").WithField("entry_id", "required")
+	}
+
+	result := &ConfirmAccountClosureKeyResult{}
+	err := withTransaction(ctx, h.txManager, func(ctx context.Context) error {
+		// Bloqueia o encerramento: o comando de encerramento e as demais respostas esperam o commit
+		closure, err := h.closureRepo.FindByRequestedKey(ctx, cmd.EntryID)
+		if err != nil {
+			return fmt.Errorf("failed to find account closure: %w", err)
+		}
+		if closure == nil || !closure.ConfirmKey(cmd.EntryID, cmd.BacenErr) {
+			return nil
+		}
+		result.Confirmed = true
+
+		if err := h.closureRepo.SaveKey(ctx, closure.ID, closure.Key(cmd.EntryID)); err != nil {
+			return err
+		}
+		if closure.IsProcessing() {
+			return nil
+		}
+		closure.Finish()
+		return h.closureRepo.Update(ctx, closure)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
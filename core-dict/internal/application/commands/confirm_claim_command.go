@@ -2,10 +2,11 @@ package commands
 
 import (
 	"context"
-	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lbpay-lab/core-dict/internal/domain"
 	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
 	"github.com/lbpay-lab/core-dict/internal/domain/valueobjects"
 )
@@ -30,6 +31,7 @@ type ConfirmClaimCommandHandler struct {
 	claimRepo      repositories.ClaimRepository
 	entryRepo      repositories.EntryRepository
 	eventPublisher EventPublisher
+	txManager      TransactionManager
 }
 
 // NewConfirmClaimCommandHandler cria nova instância
@@ -37,11 +39,13 @@ func NewConfirmClaimCommandHandler(
 	claimRepo repositories.ClaimRepository,
 	entryRepo repositories.EntryRepository,
 	eventPublisher EventPublisher,
+	txManager TransactionManager,
 ) *ConfirmClaimCommandHandler {
 	return &ConfirmClaimCommandHandler{
 		claimRepo:      claimRepo,
 		entryRepo:      entryRepo,
 		eventPublisher: eventPublisher,
+		txManager:      txManager,
 	}
 }
 
@@ -49,68 +53,77 @@ func NewConfirmClaimCommandHandler(
 func (h *ConfirmClaimCommandHandler) Handle(ctx context.Context, cmd ConfirmClaimCommand) (*ConfirmClaimResult, error) {
 	// 1. Validar 2FA
 	if cmd.TwoFactorCode == "" {
-		return nil, errors.New("2FA code required for claim confirmation")
+		return nil, domain.ErrTwoFactorRequired.WithMessage("2FA code required for claim confirmation").WithField("two_factor_code", "required")
 	}
 	// TODO: Validar 2FA
 
 	// 2. Buscar claim
 	claim, err := h.claimRepo.FindByID(ctx, cmd.ClaimID)
 	if err != nil {
-		return nil, errors.New("claim not found")
+		return nil, domain.ErrClaimNotFound
 	}
 
 	// 3. Validar se pode confirmar (status deve permitir transição)
 	if !claim.Status.CanTransitionTo(valueobjects.ClaimStatusConfirmed) {
-		return nil, errors.New("claim cannot be confirmed in current status")
+		return nil, domain.ErrInvalidClaimStatus.WithMessage("claim cannot be confirmed in current status").WithMetadata("status", string(claim.Status))
 	}
 
 	// 4. Validar deadline (não pode confirmar após expiração)
 	if claim.IsExpired() {
-		return nil, errors.New("claim deadline exceeded")
+		return nil, domain.ErrClaimExpired.WithMessage("claim deadline exceeded")
 	}
 
-	// 5. Confirmar claim usando domain method
-	reason := "Confirmed by user: " + cmd.ConfirmedBy
-	if err := claim.Confirm(reason); err != nil {
-		return nil, errors.New("failed to confirm claim: " + err.Error())
-	}
-
-	// 6. Persistir mudança
-	if err := h.claimRepo.Update(ctx, claim); err != nil {
-		return nil, errors.New("failed to update claim: " + err.Error())
-	}
-
-	// 7. Atualizar entry (marcar como em transferência)
+	// 5. Buscar entry da chave reivindicada
 	entry, err := h.entryRepo.FindByKey(ctx, claim.EntryKey)
 	if err != nil {
-		return nil, errors.New("entry not found")
+		return nil, domain.ErrEntryNotFound
 	}
 
-	// Marcar entry como tendo claim em andamento (usar string literal pois KeyStatus está duplicado)
-	entry.Status = "CLAIM_PENDING"
-	entry.UpdatedAt = time.Now()
-	if err := h.entryRepo.Update(ctx, entry); err != nil {
-		return nil, errors.New("failed to update entry status: " + err.Error())
+	// Confirmar claim usando domain method
+	reason := "Confirmed by user: " + cmd.ConfirmedBy
+	if err := claim.Confirm(reason); err != nil {
+		return nil, fmt.Errorf("failed to confirm claim: %w", err)
 	}
 
-	// 8. Publicar evento (para iniciar workflow no Temporal)
 	now := time.Now()
-	event := DomainEvent{
-		EventType:     "ClaimConfirmed",
-		AggregateID:   claim.ID.String(),
-		AggregateType: "Claim",
-		OccurredAt:    now,
-		Payload: map[string]interface{}{
-			"claim_id":       claim.ID.String(),
-			"entry_key":      claim.EntryKey,
-			"claimer_ispb":   claim.ClaimerParticipant.ISPB,
-			"donor_ispb":     claim.DonorParticipant.ISPB,
-			"bacen_claim_id": claim.BacenClaimID,
-			"confirmed_by":   cmd.ConfirmedBy,
-		},
-	}
-	if err := h.eventPublisher.Publish(ctx, event); err != nil {
-		return nil, errors.New("failed to publish event: " + err.Error())
+
+	// Escrita e evento (outbox) na mesma transação
+	err = withTransaction(ctx, h.txManager, func(ctx context.Context) error {
+		// 6. Persistir mudança
+		if err := h.claimRepo.Update(ctx, claim); err != nil {
+			return fmt.Errorf("failed to update claim: %w", err)
+		}
+
+		// 7. Atualizar entry (marcar como em transferência)
+		// Marcar entry como tendo claim em andamento (usar string literal pois KeyStatus está duplicado)
+		entry.Status = "CLAIM_PENDING"
+		entry.UpdatedAt = now
+		if err := h.entryRepo.Update(ctx, entry); err != nil {
+			return fmt.Errorf("failed to update entry status: %w", err)
+		}
+
+		// 8. Publicar evento (para iniciar workflow no Temporal)
+		event := DomainEvent{
+			EventType:     "ClaimConfirmed",
+			AggregateID:   claim.ID.String(),
+			AggregateType: "Claim",
+			OccurredAt:    now,
+			Payload: map[string]interface{}{
+				"claim_id":       claim.ID.String(),
+				"entry_key":      claim.EntryKey,
+				"claimer_ispb":   claim.ClaimerParticipant.ISPB,
+				"donor_ispb":     claim.DonorParticipant.ISPB,
+				"bacen_claim_id": claim.BacenClaimID,
+				"confirmed_by":   cmd.ConfirmedBy,
+			},
+		}
+		if err := h.eventPublisher.Publish(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return &ConfirmClaimResult{
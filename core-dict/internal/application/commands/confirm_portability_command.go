@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lbpay-lab/core-dict/internal/application/services"
+	"github.com/lbpay-lab/core-dict/internal/domain"
+	"github.com/lbpay-lab/core-dict/internal/domain/entities"
+	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
+	"github.com/lbpay-lab/core-dict/internal/domain/valueobjects"
+)
+
+// ConfirmPortabilityCommand comando para confirmar uma portabilidade
+type ConfirmPortabilityCommand struct {
+	PortabilityID  uuid.UUID
+	KeyValue       string // Chave informada pelo cliente (opcional); se informada, deve ser a da portabilidade
+	VerificationID string // Verificação de posse confirmada (OTP da portabilidade)
+	RequestedBy    string // Usuário autenticado (dono da verificação)
+}
+
+// ConfirmPortabilityResult resultado do comando
+type ConfirmPortabilityResult struct {
+	PortabilityID uuid.UUID
+	EntryID       uuid.UUID
+	EntryStatus   entities.KeyStatus
+	Status        entities.PortabilityStatus
+	ConfirmedAt   time.Time
+}
+
+// KeyOwnershipVerifier consome a verificação de posse confirmada pelo usuário
+// (implementado por services.KeyVerificationService)
+type KeyOwnershipVerifier interface {
+	Consume(ctx context.Context, verificationID, userID string, keyType valueobjects.KeyType, keyValue string) (*services.KeyVerification, error)
+}
+
+// ConfirmPortabilityCommandHandler handler para confirmar portabilidade.
+// A chave vem do registro da portabilidade (nunca da requisição) e o OTP exigido pela
+// portabilidade é a verificação de posse do EMAIL/PHONE.
+type ConfirmPortabilityCommandHandler struct {
+	portabilityRepo repositories.PortabilityRepository
+	entryRepo       repositories.EntryRepository
+	verifier        KeyOwnershipVerifier // nil: verificação desabilitada (portabilidades com OTP são recusadas)
+}
+
+// NewConfirmPortabilityCommandHandler cria nova instância
+func NewConfirmPortabilityCommandHandler(
+	portabilityRepo repositories.PortabilityRepository,
+	entryRepo repositories.EntryRepository,
+	verifier KeyOwnershipVerifier,
+) *ConfirmPortabilityCommandHandler {
+	return &ConfirmPortabilityCommandHandler{
+		portabilityRepo: portabilityRepo,
+		entryRepo:       entryRepo,
+		verifier:        verifier,
+	}
+}
+
+// Handle executa o comando
+func (h *ConfirmPortabilityCommandHandler) Handle(ctx context.Context, cmd ConfirmPortabilityCommand) (*ConfirmPortabilityResult, error) {
+	// 1. Buscar portabilidade
+	portability, err := h.portabilityRepo.FindByID(ctx, cmd.PortabilityID)
+	if err != nil {
+		return nil, err
+	}
+	if portability.IsFinal() {
+		return nil, domain.ErrInvalidPortabilityStatus.WithMessage("portability already finished").
+			WithMetadata("status", string(portability.Status))
+	}
+
+	// 2. Buscar a chave da portabilidade
+	entry, err := h.entryRepo.FindByID(ctx, portability.EntryID)
+	if err != nil {
+		return nil, domain.ErrEntryNotFound.Wrap(err)
+	}
+	if cmd.KeyValue != "" && cmd.KeyValue != entry.KeyValue {
+		return nil, domain.ErrValidation.WithMessage("key does not match the portability").
+			WithField("key", "must be the key of the portability")
+	}
+
+	// 3. OTP: verificação de posse confirmada para a chave da portabilidade (uso único)
+	if portability.RequiresOTP && portability.OTPValidatedAt == nil {
+		if h.verifier == nil {
+			return nil, domain.ErrKeyVerificationRequired.WithMessage("portability requires OTP but key verification is disabled")
+		}
+		if _, err := h.verifier.Consume(ctx, cmd.VerificationID, cmd.RequestedBy, valueobjects.KeyType(entry.KeyType), entry.KeyValue); err != nil {
+			return nil, err
+		}
+		if err := portability.ValidateOTP(); err != nil {
+			return nil, fmt.Errorf("failed to validate portability OTP: %w", err)
+		}
+	}
+
+	// 4. Aprovar (a entidade recusa a aprovação sem OTP validado)
+	if err := portability.Approve(); err != nil {
+		return nil, domain.ErrInvalidPortabilityStatus.WithMessage(err.Error()).
+			WithMetadata("status", string(portability.Status))
+	}
+
+	// 5. Persistir
+	if err := h.portabilityRepo.Update(ctx, portability); err != nil {
+		return nil, fmt.Errorf("failed to update portability: %w", err)
+	}
+
+	return &ConfirmPortabilityResult{
+		PortabilityID: portability.ID,
+		EntryID:       entry.ID,
+		EntryStatus:   entry.Status,
+		Status:        portability.Status,
+		ConfirmedAt:   portability.UpdatedAt,
+	}, nil
+}
@@ -2,10 +2,11 @@ package commands
 
 import (
 	"context"
-	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lbpay-lab/core-dict/internal/domain"
 	"github.com/lbpay-lab/core-dict/internal/domain/entities"
 	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
 	"github.com/lbpay-lab/core-dict/internal/domain/valueobjects"
@@ -35,6 +36,7 @@ type CreateClaimCommandHandler struct {
 	entryRepo      repositories.EntryRepository
 	claimRepo      repositories.ClaimRepository
 	eventPublisher EventPublisher
+	txManager      TransactionManager
 }
 
 // NewCreateClaimCommandHandler cria nova instância
@@ -42,11 +44,13 @@ func NewCreateClaimCommandHandler(
 	entryRepo repositories.EntryRepository,
 	claimRepo repositories.ClaimRepository,
 	eventPublisher EventPublisher,
+	txManager TransactionManager,
 ) *CreateClaimCommandHandler {
 	return &CreateClaimCommandHandler{
 		entryRepo:      entryRepo,
 		claimRepo:      claimRepo,
 		eventPublisher: eventPublisher,
+		txManager:      txManager,
 	}
 }
 
@@ -54,27 +58,27 @@ func NewCreateClaimCommandHandler(
 func (h *CreateClaimCommandHandler) Handle(ctx context.Context, cmd CreateClaimCommand) (*CreateClaimResult, error) {
 	// 1. Validar entrada
 	if cmd.KeyValue == "" {
-		return nil, errors.New("key_value is required")
+		return nil, domain.ErrValidation.WithMessage("key_value is required").WithField("key_value", "required")
 	}
 	if cmd.BacenClaimID == "" {
-		return nil, errors.New("bacen_claim_id is required")
+		return nil, domain.ErrValidation.WithMessage("bacen_claim_id is required").WithField("bacen_claim_id", "required")
 	}
 
 	// 2. Buscar entry existente
 	entry, err := h.entryRepo.FindByKey(ctx, cmd.KeyValue)
 	if err != nil {
-		return nil, errors.New("entry not found for key: " + cmd.KeyValue)
+		return nil, domain.ErrEntryNotFound
 	}
 
 	// 3. Validar que entry pertence a este ISPB
 	if entry.ISPB != cmd.ClaimedISPB {
-		return nil, errors.New("entry does not belong to claimed ISPB")
+		return nil, domain.ErrNotOwner.WithMessage("entry does not belong to claimed ISPB")
 	}
 
 	// 4. Validar que não existe claim ativo para esta chave
 	existingClaim, err := h.claimRepo.FindActiveByEntryID(ctx, entry.ID)
 	if err == nil && existingClaim != nil {
-		return nil, errors.New("active claim already exists for this key")
+		return nil, domain.ErrClaimAlreadyExists
 	}
 
 	// 5. Criar entidade Claim usando domain factory
@@ -96,36 +100,43 @@ func (h *CreateClaimCommandHandler) Handle(ctx context.Context, cmd CreateClaimC
 		entry.AccountID,
 	)
 	if err != nil {
-		return nil, errors.New("failed to create claim: " + err.Error())
+		return nil, fmt.Errorf("failed to create claim: %w", err)
 	}
 
 	// Adicionar dados do Bacen
 	claim.SetBacenClaimID(cmd.BacenClaimID)
 
-	// 6. Persistir claim
-	if err := h.claimRepo.Create(ctx, claim); err != nil {
-		return nil, errors.New("failed to create claim: " + err.Error())
-	}
-
-	// 7. Publicar evento (para notificar usuário via app/email)
-	now := time.Now()
-	event := DomainEvent{
-		EventType:     "ClaimReceived",
-		AggregateID:   claim.ID.String(),
-		AggregateType: "Claim",
-		OccurredAt:    now,
-		Payload: map[string]interface{}{
-			"claim_id":       claim.ID.String(),
-			"entry_id":       entry.ID.String(),
-			"key_value":      entry.KeyValue,
-			"claim_type":     string(cmd.ClaimType),
-			"claimer_ispb":   cmd.ClaimerISPB,
-			"deadline_at":    claim.ExpiresAt,
-			"bacen_claim_id": cmd.BacenClaimID,
-		},
-	}
-	if err := h.eventPublisher.Publish(ctx, event); err != nil {
-		return nil, errors.New("failed to publish event: " + err.Error())
+	// Escrita e evento (outbox) na mesma transação
+	err = withTransaction(ctx, h.txManager, func(ctx context.Context) error {
+		// 6. Persistir claim
+		if err := h.claimRepo.Create(ctx, claim); err != nil {
+			return fmt.Errorf("failed to create claim: %w", err)
+		}
+
+		// 7. Publicar evento (para notificar usuário via app/email)
+		now := time.Now()
+		event := DomainEvent{
+			EventType:     "ClaimReceived",
+			AggregateID:   claim.ID.String(),
+			AggregateType: "Claim",
+			OccurredAt:    now,
+			Payload: map[string]interface{}{
+				"claim_id":       claim.ID.String(),
+				"entry_id":       entry.ID.String(),
+				"key_value":      entry.KeyValue,
+				"claim_type":     string(cmd.ClaimType),
+				"claimer_ispb":   cmd.ClaimerISPB,
+				"deadline_at":    claim.ExpiresAt,
+				"bacen_claim_id": cmd.BacenClaimID,
+			},
+		}
+		if err := h.eventPublisher.Publish(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return &CreateClaimResult{
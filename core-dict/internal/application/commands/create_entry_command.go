@@ -2,10 +2,11 @@ package commands
 
 import (
 	"context"
-	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lbpay-lab/core-dict/internal/domain"
 	"github.com/lbpay-lab/core-dict/internal/domain/entities"
 	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
 	"github.com/lbpay-lab/core-dict/internal/application/services"
@@ -43,7 +44,7 @@ type CreateEntryCommandHandler struct {
 	duplicateChecker DuplicateCheckerService
 	cacheService     services.CacheService
 	connectClient    services.ConnectClient // NEW: gRPC client for RSFN operations
-	entryProducer    EntryEventProducer     // NEW: Pulsar event producer
+	txManager        TransactionManager     // Entry + evento (outbox) na mesma transação
 }
 
 // NewCreateEntryCommandHandler cria nova instância do handler
@@ -55,7 +56,7 @@ func NewCreateEntryCommandHandler(
 	duplicateChecker DuplicateCheckerService,
 	cacheService services.CacheService,
 	connectClient services.ConnectClient,
-	entryProducer EntryEventProducer,
+	txManager TransactionManager,
 ) *CreateEntryCommandHandler {
 	return &CreateEntryCommandHandler{
 		entryRepo:        entryRepo,
@@ -65,7 +66,7 @@ func NewCreateEntryCommandHandler(
 		duplicateChecker: duplicateChecker,
 		cacheService:     cacheService,
 		connectClient:    connectClient,
-		entryProducer:    entryProducer,
+		txManager:        txManager,
 	}
 }
 
@@ -73,35 +74,35 @@ func NewCreateEntryCommandHandler(
 func (h *CreateEntryCommandHandler) Handle(ctx context.Context, cmd CreateEntryCommand) (*CreateEntryResult, error) {
 	// 1. Validar formato da chave
 	if err := h.keyValidator.ValidateFormat(cmd.KeyType, cmd.KeyValue); err != nil {
-		return nil, errors.New("invalid key format: " + err.Error())
+		return nil, domain.ErrInvalidKeyValue.WithMessage("invalid key format").WithField("key_value", err.Error()).Wrap(err)
 	}
 
 	// 2. Validar ownership (CPF/CNPJ deve pertencer ao titular da conta)
 	if err := h.ownershipChecker.ValidateOwnership(ctx, cmd.KeyType, cmd.KeyValue, cmd.OwnerTaxID); err != nil {
-		return nil, errors.New("ownership validation failed: " + err.Error())
+		return nil, domain.ErrKeyOwnershipMismatch.Wrap(err)
 	}
 
 	// 3. Verificar duplicação local (chave já existe neste PSP?)
 	isDuplicate, err := h.duplicateChecker.IsDuplicate(ctx, cmd.KeyValue)
 	if err != nil {
-		return nil, errors.New("duplicate check failed: " + err.Error())
+		return nil, fmt.Errorf("duplicate check failed: %w", err)
 	}
 	if isDuplicate {
-		return nil, errors.New("key already registered in this PSP")
+		return nil, domain.ErrDuplicateKey.WithMessage("key already registered in this PSP")
 	}
 
 	// 3a. Verificar duplicação GLOBAL via Connect (consulta RSFN)
 	if h.connectClient != nil {
 		existingEntry, err := h.connectClient.GetEntryByKey(ctx, cmd.KeyValue)
 		if err == nil && existingEntry != nil {
-			return nil, errors.New("key already registered in RSFN DICT")
+			return nil, domain.ErrDuplicateKeyGlobal
 		}
 		// If error is "not found", continue (key is available)
 	}
 
 	// 4. Validar limites (max 5 CPF, 20 CNPJ, etc.)
 	if err := h.keyValidator.ValidateLimits(ctx, cmd.KeyType, cmd.OwnerTaxID); err != nil {
-		return nil, errors.New("key limit exceeded: " + err.Error())
+		return nil, domain.ErrMaxKeysExceeded.WithMessage("key limit exceeded").Wrap(err)
 	}
 
 	// 5. Criar entidade Entry (Domain Layer)
@@ -123,40 +124,27 @@ func (h *CreateEntryCommandHandler) Handle(ctx context.Context, cmd CreateEntryC
 		UpdatedAt:     now,
 	}
 
-	// 6. Persistir no repositório
-	if err := h.entryRepo.Create(ctx, entry); err != nil {
-		return nil, errors.New("failed to create entry: " + err.Error())
-	}
+	// 6. Persistir entry e evento EntryCreated (outbox) na mesma transação
+	// O relay do outbox publica o evento no Pulsar: Connect → Bridge → Bacen DICT
+	err = withTransaction(ctx, h.txManager, func(ctx context.Context) error {
+		if err := h.entryRepo.Create(ctx, entry); err != nil {
+			return fmt.Errorf("failed to create entry: %w", err)
+		}
 
-	// 7. Publicar evento de domínio via Pulsar (EntryCreated)
-	// This event triggers: Connect → Bridge → Bacen DICT registration
-	// Non-blocking: uses goroutine to avoid blocking user response
-	if h.entryProducer != nil {
-		go func() {
-			bgCtx := context.Background()
-			// TODO: Convert Entry to proper domain entity format
-			// For now, publish using legacy event publisher
-			if err := h.eventPublisher.Publish(bgCtx, DomainEvent{
-				EventType:     "EntryCreated",
-				AggregateID:   entry.ID.String(),
-				AggregateType: "Entry",
-				OccurredAt:    time.Now(),
-				Payload: map[string]interface{}{
-					"entry_id":       entry.ID.String(),
-					"key_type":       string(entry.KeyType),
-					"key_value":      entry.KeyValue,
-					"ispb":           entry.ISPB,
-					"account_branch": entry.Branch,
-					"account_number": entry.AccountNumber,
-					"owner_name":     entry.OwnerName,
-					"owner_tax_id":   entry.OwnerTaxID,
-				},
-			}); err != nil {
-				// Log error but don't fail the request
-				// TODO: Use proper logger
-				// Background worker will retry failed events
-			}
-		}()
+		// 7. Registrar evento de domínio (EntryCreated)
+		if err := h.eventPublisher.Publish(ctx, DomainEvent{
+			EventType:     "EntryCreated",
+			AggregateID:   entry.ID.String(),
+			AggregateType: "Entry",
+			OccurredAt:    now,
+			Payload:       entryCreatedEvent(entry, now),
+		}); err != nil {
+			return fmt.Errorf("failed to publish event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// 8. Invalidar cache
@@ -175,7 +163,7 @@ type DomainEvent struct {
 	AggregateID   string
 	AggregateType string
 	OccurredAt    time.Time
-	Payload       map[string]interface{}
+	Payload       interface{} // Serializado em JSON (eventos de chave: contrato dict-contracts/events)
 }
 
 // Service interfaces
@@ -196,9 +184,17 @@ type DuplicateCheckerService interface {
 	IsDuplicate(ctx context.Context, keyValue string) (bool, error)
 }
 
-// EntryEventProducer interface for publishing events to Pulsar
-type EntryEventProducer interface {
-	PublishCreated(ctx context.Context, entry interface{}, userID string) error
-	PublishUpdated(ctx context.Context, entry interface{}, userID string) error
-	PublishDeleted(ctx context.Context, entryID, keyValue, reason, userID string) error
+// TransactionManager executa fn numa transação propagada via contexto
+// (implementado por database.TransactionManager). Os repositórios e o outbox de
+// eventos usam a transação do contexto, então a escrita e o evento são atômicos.
+type TransactionManager interface {
+	WithTransactionContext(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// withTransaction executa fn na transação do txManager (sem txManager, executa fn diretamente)
+func withTransaction(ctx context.Context, txManager TransactionManager, fn func(ctx context.Context) error) error {
+	if txManager == nil {
+		return fn(ctx)
+	}
+	return txManager.WithTransactionContext(ctx, fn)
 }
@@ -149,26 +149,6 @@ func (m *MockConnectClient) DeleteEntry(ctx context.Context, entryID, reason str
 	return args.Error(0)
 }
 
-// Mock EntryEventProducer
-type MockEntryEventProducer struct {
-	mock.Mock
-}
-
-func (m *MockEntryEventProducer) PublishCreated(ctx context.Context, entry interface{}, userID string) error {
-	args := m.Called(ctx, entry, userID)
-	return args.Error(0)
-}
-
-func (m *MockEntryEventProducer) PublishUpdated(ctx context.Context, entry interface{}, userID string) error {
-	args := m.Called(ctx, entry, userID)
-	return args.Error(0)
-}
-
-func (m *MockEntryEventProducer) PublishDeleted(ctx context.Context, entryID, keyValue, reason, userID string) error {
-	args := m.Called(ctx, entryID, keyValue, reason, userID)
-	return args.Error(0)
-}
-
 // Test 1: TestCreateEntryHandler_Success
 func TestCreateEntryHandler_Success(t *testing.T) {
 	// Arrange
@@ -179,7 +159,6 @@ func TestCreateEntryHandler_Success(t *testing.T) {
 	mockDuplicateChecker := new(MockDuplicateCheckerService)
 	mockCacheService := new(MockCacheService)
 	mockConnectClient := new(MockConnectClient)
-	mockEntryProducer := new(MockEntryEventProducer)
 
 	mockKeyValidator.On("ValidateFormat", commands.KeyTypeCPF, "12345678901").Return(nil)
 	mockOwnershipChecker.On("ValidateOwnership", mock.Anything, commands.KeyTypeCPF, "12345678901", "12345678901").Return(nil)
@@ -198,7 +177,7 @@ func TestCreateEntryHandler_Success(t *testing.T) {
 		mockDuplicateChecker,
 		mockCacheService,
 		mockConnectClient,
-		mockEntryProducer,
+		nil, // txManager
 	)
 
 	cmd := commands.CreateEntryCommand{
@@ -239,7 +218,6 @@ func TestCreateEntryHandler_DuplicateKeyLocal(t *testing.T) {
 	mockDuplicateChecker := new(MockDuplicateCheckerService)
 	mockCacheService := new(MockCacheService)
 	mockConnectClient := new(MockConnectClient)
-	mockEntryProducer := new(MockEntryEventProducer)
 
 	mockKeyValidator.On("ValidateFormat", commands.KeyTypeCPF, "12345678901").Return(nil)
 	mockOwnershipChecker.On("ValidateOwnership", mock.Anything, commands.KeyTypeCPF, "12345678901", "12345678901").Return(nil)
@@ -253,7 +231,7 @@ func TestCreateEntryHandler_DuplicateKeyLocal(t *testing.T) {
 		mockDuplicateChecker,
 		mockCacheService,
 		mockConnectClient,
-		mockEntryProducer,
+		nil, // txManager
 	)
 
 	cmd := commands.CreateEntryCommand{
@@ -282,7 +260,6 @@ func TestCreateEntryHandler_DuplicateKeyGlobal(t *testing.T) {
 	mockDuplicateChecker := new(MockDuplicateCheckerService)
 	mockCacheService := new(MockCacheService)
 	mockConnectClient := new(MockConnectClient)
-	mockEntryProducer := new(MockEntryEventProducer)
 
 	existingEntry := &commands.Entry{ID: uuid.New(), KeyValue: "12345678901"}
 
@@ -299,7 +276,7 @@ func TestCreateEntryHandler_DuplicateKeyGlobal(t *testing.T) {
 		mockDuplicateChecker,
 		mockCacheService,
 		mockConnectClient,
-		mockEntryProducer,
+		nil, // txManager
 	)
 
 	cmd := commands.CreateEntryCommand{
@@ -328,7 +305,6 @@ func TestCreateEntryHandler_MaxKeysExceeded(t *testing.T) {
 	mockDuplicateChecker := new(MockDuplicateCheckerService)
 	mockCacheService := new(MockCacheService)
 	mockConnectClient := new(MockConnectClient)
-	mockEntryProducer := new(MockEntryEventProducer)
 
 	mockKeyValidator.On("ValidateFormat", commands.KeyTypeCPF, "12345678901").Return(nil)
 	mockOwnershipChecker.On("ValidateOwnership", mock.Anything, commands.KeyTypeCPF, "12345678901", "12345678901").Return(nil)
@@ -344,7 +320,7 @@ func TestCreateEntryHandler_MaxKeysExceeded(t *testing.T) {
 		mockDuplicateChecker,
 		mockCacheService,
 		mockConnectClient,
-		mockEntryProducer,
+		nil, // txManager
 	)
 
 	cmd := commands.CreateEntryCommand{
@@ -373,7 +349,6 @@ func TestCreateEntryHandler_InvalidKeyValue(t *testing.T) {
 	mockDuplicateChecker := new(MockDuplicateCheckerService)
 	mockCacheService := new(MockCacheService)
 	mockConnectClient := new(MockConnectClient)
-	mockEntryProducer := new(MockEntryEventProducer)
 
 	mockKeyValidator.On("ValidateFormat", commands.KeyTypeCPF, "invalid").Return(errors.New("invalid format"))
 
@@ -385,7 +360,7 @@ func TestCreateEntryHandler_InvalidKeyValue(t *testing.T) {
 		mockDuplicateChecker,
 		mockCacheService,
 		mockConnectClient,
-		mockEntryProducer,
+		nil, // txManager
 	)
 
 	cmd := commands.CreateEntryCommand{
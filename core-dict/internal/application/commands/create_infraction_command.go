@@ -2,10 +2,11 @@ package commands
 
 import (
 	"context"
-	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lbpay-lab/core-dict/internal/domain"
 	"github.com/lbpay-lab/core-dict/internal/domain/entities"
 	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
 	"github.com/lbpay-lab/core-dict/internal/domain/valueobjects"
@@ -42,6 +43,7 @@ type CreateInfractionCommandHandler struct {
 	entryRepo       repositories.EntryRepository
 	infractionRepo  repositories.InfractionRepository
 	eventPublisher  EventPublisher
+	txManager      TransactionManager
 }
 
 // NewCreateInfractionCommandHandler cria nova instância
@@ -49,11 +51,13 @@ func NewCreateInfractionCommandHandler(
 	entryRepo repositories.EntryRepository,
 	infractionRepo repositories.InfractionRepository,
 	eventPublisher EventPublisher,
+	txManager TransactionManager,
 ) *CreateInfractionCommandHandler {
 	return &CreateInfractionCommandHandler{
 		entryRepo:      entryRepo,
 		infractionRepo: infractionRepo,
 		eventPublisher: eventPublisher,
+		txManager:      txManager,
 	}
 }
 
@@ -61,16 +65,16 @@ func NewCreateInfractionCommandHandler(
 func (h *CreateInfractionCommandHandler) Handle(ctx context.Context, cmd CreateInfractionCommand) (*CreateInfractionResult, error) {
 	// 1. Validar entrada
 	if cmd.Description == "" {
-		return nil, errors.New("description is required")
+		return nil, domain.ErrValidation.WithMessage("description is required").WithField("description", "required")
 	}
 	if cmd.BacenInfractionID == "" {
-		return nil, errors.New("bacen_infraction_id is required")
+		return nil, domain.ErrValidation.WithMessage("bacen_infraction_id is required").WithField("bacen_infraction_id", "required")
 	}
 
 	// 2. Buscar entry
 	entry, err := h.entryRepo.FindByID(ctx, cmd.EntryID)
 	if err != nil {
-		return nil, errors.New("entry not found")
+		return nil, domain.ErrEntryNotFound
 	}
 
 	// 3. Criar entidade Infraction usando domain factory
@@ -94,7 +98,7 @@ func (h *CreateInfractionCommandHandler) Handle(ctx context.Context, cmd CreateI
 		cmd.Description,
 	)
 	if err != nil {
-		return nil, errors.New("failed to create infraction: " + err.Error())
+		return nil, fmt.Errorf("failed to create infraction: %w", err)
 	}
 
 	// Adicionar dados adicionais
@@ -103,39 +107,47 @@ func (h *CreateInfractionCommandHandler) Handle(ctx context.Context, cmd CreateI
 		infraction.Status = entities.InfractionStatusEscalated
 	}
 
-	// 4. Persistir infraction
-	if err := h.infractionRepo.Create(ctx, infraction); err != nil {
-		return nil, errors.New("failed to create infraction: " + err.Error())
-	}
-
-	// 5. Se severidade CRITICAL, bloquear chave automaticamente
 	now := time.Now()
-	if cmd.Severity == "CRITICAL" {
-		entry.Status = entities.KeyStatusBlocked
-		entry.UpdatedAt = now
-		if err := h.entryRepo.Update(ctx, entry); err != nil {
-			return nil, errors.New("failed to block entry: " + err.Error())
+
+	// Escrita e evento (outbox) na mesma transação
+	err = withTransaction(ctx, h.txManager, func(ctx context.Context) error {
+		// 4. Persistir infraction
+		if err := h.infractionRepo.Create(ctx, infraction); err != nil {
+			return fmt.Errorf("failed to create infraction: %w", err)
 		}
-	}
 
-	// 6. Publicar evento (para notificar compliance e usuário)
-	event := DomainEvent{
-		EventType:     "InfractionCreated",
-		AggregateID:   infraction.ID.String(),
-		AggregateType: "Infraction",
-		OccurredAt:    now,
-		Payload: map[string]interface{}{
-			"infraction_id":       infraction.ID.String(),
-			"entry_id":            entry.ID.String(),
-			"key_value":           entry.KeyValue,
-			"infraction_type":     string(cmd.InfractionType),
-			"severity":            cmd.Severity,
-			"reported_by":         cmd.ReportedBy,
-			"bacen_infraction_id": cmd.BacenInfractionID,
-		},
-	}
-	if err := h.eventPublisher.Publish(ctx, event); err != nil {
-		return nil, errors.New("failed to publish event: " + err.Error())
+		// 5. Se severidade CRITICAL, bloquear chave automaticamente
+		if cmd.Severity == "CRITICAL" {
+			entry.Status = entities.KeyStatusBlocked
+			entry.UpdatedAt = now
+			if err := h.entryRepo.Update(ctx, entry); err != nil {
+				return fmt.Errorf("failed to block entry: %w", err)
+			}
+		}
+
+		// 6. Publicar evento (para notificar compliance e usuário)
+		event := DomainEvent{
+			EventType:     "InfractionCreated",
+			AggregateID:   infraction.ID.String(),
+			AggregateType: "Infraction",
+			OccurredAt:    now,
+			Payload: map[string]interface{}{
+				"infraction_id":       infraction.ID.String(),
+				"entry_id":            entry.ID.String(),
+				"key_value":           entry.KeyValue,
+				"infraction_type":     string(cmd.InfractionType),
+				"severity":            cmd.Severity,
+				"reported_by":         cmd.ReportedBy,
+				"bacen_infraction_id": cmd.BacenInfractionID,
+			},
+		}
+		if err := h.eventPublisher.Publish(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return &CreateInfractionResult{
@@ -2,10 +2,11 @@ package commands
 
 import (
 	"context"
-	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lbpay-lab/core-dict/internal/domain"
 	"github.com/lbpay-lab/core-dict/internal/domain/entities"
 	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
 	"github.com/lbpay-lab/core-dict/internal/application/services"
@@ -31,7 +32,7 @@ type DeleteEntryCommandHandler struct {
 	eventPublisher EventPublisher
 	cacheService   services.CacheService
 	connectClient  services.ConnectClient // NEW: gRPC client for RSFN
-	entryProducer  EntryEventProducer     // NEW: Pulsar event producer
+	txManager      TransactionManager     // Entry + evento (outbox) na mesma transação
 }
 
 // NewDeleteEntryCommandHandler cria nova instância
@@ -40,14 +41,14 @@ func NewDeleteEntryCommandHandler(
 	eventPublisher EventPublisher,
 	cacheService services.CacheService,
 	connectClient services.ConnectClient,
-	entryProducer EntryEventProducer,
+	txManager TransactionManager,
 ) *DeleteEntryCommandHandler {
 	return &DeleteEntryCommandHandler{
 		entryRepo:      entryRepo,
 		eventPublisher: eventPublisher,
 		cacheService:   cacheService,
 		connectClient:  connectClient,
-		entryProducer:  entryProducer,
+		txManager:      txManager,
 	}
 }
 
@@ -55,19 +56,19 @@ func NewDeleteEntryCommandHandler(
 func (h *DeleteEntryCommandHandler) Handle(ctx context.Context, cmd DeleteEntryCommand) (*DeleteEntryResult, error) {
 	// 1. Validar 2FA obrigatório
 	if cmd.TwoFactorCode == "" {
-		return nil, errors.New("2FA code required for key deletion")
+		return nil, domain.ErrTwoFactorRequired.WithMessage("2FA code required for key deletion").WithField("two_factor_code", "required")
 	}
 	// TODO: Validar código 2FA
 
 	// 2. Buscar entry
 	entry, err := h.entryRepo.FindByID(ctx, cmd.EntryID)
 	if err != nil {
-		return nil, errors.New("entry not found")
+		return nil, domain.ErrEntryNotFound
 	}
 
 	// 3. Validar status (apenas ACTIVE pode ser deletado)
 	if entry.Status != entities.KeyStatusActive {
-		return nil, errors.New("only active entries can be deleted")
+		return nil, domain.ErrInvalidStatus.WithMessage("only active entries can be deleted").WithMetadata("status", string(entry.Status))
 	}
 
 	// 4. Atualizar status para DELETED (soft delete)
@@ -75,32 +76,27 @@ func (h *DeleteEntryCommandHandler) Handle(ctx context.Context, cmd DeleteEntryC
 	now := time.Now()
 	entry.UpdatedAt = now
 
-	// 5. Persistir mudança
-	if err := h.entryRepo.Update(ctx, entry); err != nil {
-		return nil, errors.New("failed to delete entry: " + err.Error())
-	}
+	// 5. Persistir mudança e evento EntryDeleted (outbox) na mesma transação
+	// O relay do outbox publica o evento no Pulsar: Connect → Bridge → Bacen DICT
+	err = withTransaction(ctx, h.txManager, func(ctx context.Context) error {
+		if err := h.entryRepo.Update(ctx, entry); err != nil {
+			return fmt.Errorf("failed to delete entry: %w", err)
+		}
 
-	// 6. Publicar evento de deleção via Pulsar (EntryDeleted)
-	// Non-blocking: triggers Connect → Bridge → Bacen DICT deletion
-	if h.entryProducer != nil {
-		go func() {
-			bgCtx := context.Background()
-			if err := h.eventPublisher.Publish(bgCtx, DomainEvent{
-				EventType:     "EntryDeleted",
-				AggregateID:   entry.ID.String(),
-				AggregateType: "Entry",
-				OccurredAt:    now,
-				Payload: map[string]interface{}{
-					"entry_id":   entry.ID.String(),
-					"key_value":  entry.KeyValue,
-					"key_type":   string(entry.KeyType),
-					"deleted_by": cmd.RequestedBy.String(),
-					"reason":     cmd.Reason,
-				},
-			}); err != nil {
-				// Log error but don't fail request
-			}
-		}()
+		// 6. Registrar evento de domínio (EntryDeleted)
+		if err := h.eventPublisher.Publish(ctx, DomainEvent{
+			EventType:     "EntryDeleted",
+			AggregateID:   entry.ID.String(),
+			AggregateType: "Entry",
+			OccurredAt:    now,
+			Payload:       entryDeletedEvent(entry, cmd.Reason, cmd.RequestedBy.String(), now),
+		}); err != nil {
+			return fmt.Errorf("failed to publish event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// 7. Invalidar cache
@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lbpay-lab/core-dict/internal/domain/entities"
+	"github.com/lbpay-lab/dict-contracts/events"
+)
+
+// Payloads dos eventos de chave consumidos pelo conn-dict (contrato em dict-contracts/events).
+// Cada operação tem um request_id próprio, usado também como chave de idempotência no Bridge:
+// a republicação do mesmo evento pelo outbox não repete a operação no Bacen.
+
+// entryCreatedEvent monta o payload EntryCreated
+func entryCreatedEvent(entry *entities.Entry, occurredAt time.Time) *events.EntryCreatedEvent {
+	requestID := uuid.NewString()
+	return &events.EntryCreatedEvent{
+		EntryID:        entry.ID.String(),
+		Key:            entry.KeyValue,
+		KeyType:        string(entry.KeyType),
+		Participant:    entry.ISPB,
+		AccountBranch:  optionalString(entry.Branch),
+		AccountNumber:  optionalString(entry.AccountNumber),
+		AccountType:    entry.AccountType,
+		OwnerType:      eventOwnerType(entry.OwnerType),
+		OwnerName:      optionalString(entry.OwnerName),
+		OwnerTaxID:     optionalString(entry.OwnerTaxID),
+		IdempotencyKey: requestID,
+		RequestID:      requestID,
+		Timestamp:      occurredAt,
+	}
+}
+
+// entryUpdatedEvent monta o payload EntryUpdated com a conta atual da chave
+func entryUpdatedEvent(entry *entities.Entry, occurredAt time.Time) *events.EntryUpdatedEvent {
+	requestID := uuid.NewString()
+	return &events.EntryUpdatedEvent{
+		EntryID:        entry.ID.String(),
+		Key:            entry.KeyValue,
+		KeyType:        string(entry.KeyType),
+		Participant:    entry.ISPB,
+		AccountBranch:  optionalString(entry.Branch),
+		AccountNumber:  optionalString(entry.AccountNumber),
+		AccountType:    entry.AccountType,
+		OwnerType:      eventOwnerType(entry.OwnerType),
+		OwnerName:      optionalString(entry.OwnerName),
+		OwnerTaxID:     optionalString(entry.OwnerTaxID),
+		IdempotencyKey: requestID,
+		RequestID:      requestID,
+		Timestamp:      occurredAt,
+	}
+}
+
+// entryDeletedEvent monta o payload EntryDeleted
+func entryDeletedEvent(entry *entities.Entry, reason, deletedBy string, occurredAt time.Time) *events.EntryDeletedEvent {
+	requestID := uuid.NewString()
+	return &events.EntryDeletedEvent{
+		EntryID:        entry.ID.String(),
+		Key:            entry.KeyValue,
+		KeyType:        string(entry.KeyType),
+		Reason:         reason,
+		DeletedBy:      deletedBy,
+		IdempotencyKey: requestID,
+		RequestID:      requestID,
+		Timestamp:      occurredAt,
+	}
+}
+
+// eventOwnerType converte o tipo de titular do core-dict para o do contrato
+func eventOwnerType(ownerType string) string {
+	if entities.OwnerType(ownerType) == entities.OwnerTypeLegalEntity {
+		return events.OwnerTypeLegalPerson
+	}
+	return ownerType
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -2,10 +2,11 @@ package commands
 
 import (
 	"context"
-	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lbpay-lab/core-dict/internal/domain"
 	"github.com/lbpay-lab/core-dict/internal/domain/entities"
 	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
 	"github.com/lbpay-lab/core-dict/internal/application/services"
@@ -14,7 +15,8 @@ import (
 // UnblockEntryCommand comando para desbloquear chave PIX
 type UnblockEntryCommand struct {
 	EntryID     uuid.UUID
-	Reason      string // Motivo do desbloqueio (obrigatório)
+	ReasonCode  entities.UnblockReason // Código do motivo (obrigatório)
+	Reason      string // Descrição do motivo (obrigatória)
 	UnblockedBy string // Admin/Sistema que desbloqueou
 }
 
@@ -30,6 +32,8 @@ type UnblockEntryCommandHandler struct {
 	entryRepo      repositories.EntryRepository
 	eventPublisher EventPublisher
 	cacheService   services.CacheService
+	auditRepo      repositories.AuditRepository
+	txManager      TransactionManager
 }
 
 // NewUnblockEntryCommandHandler cria nova instância
@@ -37,30 +41,41 @@ func NewUnblockEntryCommandHandler(
 	entryRepo repositories.EntryRepository,
 	eventPublisher EventPublisher,
 	cacheService services.CacheService,
+	auditRepo repositories.AuditRepository,
+	txManager TransactionManager,
 ) *UnblockEntryCommandHandler {
 	return &UnblockEntryCommandHandler{
 		entryRepo:      entryRepo,
 		eventPublisher: eventPublisher,
 		cacheService:   cacheService,
+		auditRepo:      auditRepo,
+		txManager:      txManager,
 	}
 }
 
 // Handle executa o comando
 func (h *UnblockEntryCommandHandler) Handle(ctx context.Context, cmd UnblockEntryCommand) (*UnblockEntryResult, error) {
 	// 1. Validar entrada
+	if !cmd.ReasonCode.IsValid() {
+		return nil, domain.ErrValidation.WithMessage("invalid unblock reason code").WithField("reason_code", "unknown unblock reason")
+	}
 	if cmd.Reason == "" {
-		return nil, errors.New("reason is required for unblocking")
+		return nil, domain.ErrValidation.WithMessage("reason is required for unblocking").WithField("reason", "required")
+	}
+	if cmd.UnblockedBy == "" {
+		return nil, domain.ErrValidation.WithMessage("unblocked_by is required for unblocking").WithField("unblocked_by", "required")
 	}
 
 	// 2. Buscar entry
 	entry, err := h.entryRepo.FindByID(ctx, cmd.EntryID)
 	if err != nil {
-		return nil, errors.New("entry not found")
+		return nil, domain.ErrEntryNotFound
 	}
 
 	// 3. Validar status (apenas BLOCKED pode ser desbloqueado)
 	if entry.Status != entities.KeyStatusBlocked {
-		return nil, errors.New("only blocked entries can be unblocked")
+		return nil, domain.ErrInvalidStatus.WithMessage("invalid status: only blocked entries can be unblocked").
+			WithMetadata("status", string(entry.Status))
 	}
 
 	// 4. Atualizar status para ACTIVE
@@ -68,29 +83,57 @@ func (h *UnblockEntryCommandHandler) Handle(ctx context.Context, cmd UnblockEntr
 	entry.Status = entities.KeyStatusActive
 	entry.UpdatedAt = now
 
-	// 5. Persistir mudança
-	if err := h.entryRepo.Update(ctx, entry); err != nil {
-		return nil, errors.New("failed to unblock entry: " + err.Error())
-	}
+	// Escrita e evento (outbox) na mesma transação
+	err = withTransaction(ctx, h.txManager, func(ctx context.Context) error {
+		// 5. Persistir mudança
+		if err := h.entryRepo.Update(ctx, entry); err != nil {
+			return fmt.Errorf("failed to unblock entry: %w", err)
+		}
 
-	// 6. Publicar evento
-	event := DomainEvent{
-		EventType:     "EntryUnblocked",
-		AggregateID:   entry.ID.String(),
-		AggregateType: "Entry",
-		OccurredAt:    now,
-		Payload: map[string]interface{}{
-			"entry_id":     entry.ID.String(),
-			"key_value":    entry.KeyValue,
-			"reason":       cmd.Reason,
-			"unblocked_by": cmd.UnblockedBy,
-		},
-	}
-	if err := h.eventPublisher.Publish(ctx, event); err != nil {
-		return nil, errors.New("failed to publish event: " + err.Error())
+		// 6. Registrar auditoria (ENTRY_UNBLOCKED)
+		auditEvent, err := entities.NewAuditEvent(
+			entities.EventTypeEntryUnblocked,
+			entities.EntityTypeEntry,
+			entry.ID,
+			map[string]interface{}{"status": string(entities.KeyStatusBlocked)},
+			map[string]interface{}{"status": string(entities.KeyStatusActive)},
+			actorUserID(cmd.UnblockedBy),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to build audit event: %w", err)
+		}
+		auditEvent.OccurredAt = now
+		auditEvent.AddMetadata("reason_code", string(cmd.ReasonCode))
+		auditEvent.AddMetadata("reason", cmd.Reason)
+		auditEvent.AddMetadata("actor", cmd.UnblockedBy)
+		if err := h.auditRepo.Create(ctx, auditEvent); err != nil {
+			return fmt.Errorf("failed to record audit event: %w", err)
+		}
+
+		// 7. Publicar evento
+		event := DomainEvent{
+			EventType:     "EntryUnblocked",
+			AggregateID:   entry.ID.String(),
+			AggregateType: "Entry",
+			OccurredAt:    now,
+			Payload: map[string]interface{}{
+				"entry_id":     entry.ID.String(),
+				"key_value":    entry.KeyValue,
+				"reason_code":  string(cmd.ReasonCode),
+				"reason":       cmd.Reason,
+				"unblocked_by": cmd.UnblockedBy,
+			},
+		}
+		if err := h.eventPublisher.Publish(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// 7. Invalidar cache
+	// 8. Invalidar cache
 	h.cacheService.Delete(ctx, "entry:"+entry.KeyValue)
 
 	return &UnblockEntryResult{
@@ -2,10 +2,11 @@ package commands
 
 import (
 	"context"
-	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lbpay-lab/core-dict/internal/domain"
 	"github.com/lbpay-lab/core-dict/internal/domain/entities"
 	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
 	"github.com/lbpay-lab/core-dict/internal/application/services"
@@ -34,7 +35,7 @@ type UpdateEntryCommandHandler struct {
 	eventPublisher EventPublisher
 	cacheService   services.CacheService
 	connectClient  services.ConnectClient      // NEW: gRPC client for RSFN
-	entryProducer  EntryEventProducer // NEW: Pulsar event producer
+	txManager      TransactionManager     // Entry + evento (outbox) na mesma transação
 }
 
 // NewUpdateEntryCommandHandler cria nova instância
@@ -43,14 +44,14 @@ func NewUpdateEntryCommandHandler(
 	eventPublisher EventPublisher,
 	cacheService services.CacheService,
 	connectClient services.ConnectClient,
-	entryProducer EntryEventProducer,
+	txManager TransactionManager,
 ) *UpdateEntryCommandHandler {
 	return &UpdateEntryCommandHandler{
 		entryRepo:      entryRepo,
 		eventPublisher: eventPublisher,
 		cacheService:   cacheService,
 		connectClient:  connectClient,
-		entryProducer:  entryProducer,
+		txManager:      txManager,
 	}
 }
 
@@ -58,19 +59,19 @@ func NewUpdateEntryCommandHandler(
 func (h *UpdateEntryCommandHandler) Handle(ctx context.Context, cmd UpdateEntryCommand) (*UpdateEntryResult, error) {
 	// 1. Validar 2FA
 	if cmd.TwoFactorCode == "" {
-		return nil, errors.New("2FA code required")
+		return nil, domain.ErrTwoFactorRequired.WithField("two_factor_code", "required")
 	}
 	// TODO: Validar código 2FA com serviço de autenticação
 
 	// 2. Buscar entry existente
 	entry, err := h.entryRepo.FindByID(ctx, cmd.EntryID)
 	if err != nil {
-		return nil, errors.New("entry not found")
+		return nil, domain.ErrEntryNotFound
 	}
 
 	// 3. Validar status (apenas ACTIVE pode ser atualizado)
 	if entry.Status != entities.KeyStatusActive {
-		return nil, errors.New("only active entries can be updated")
+		return nil, domain.ErrInvalidStatus.WithMessage("only active entries can be updated").WithMetadata("status", string(entry.Status))
 	}
 
 	// 4. Atualizar campos (flat structure)
@@ -88,34 +89,27 @@ func (h *UpdateEntryCommandHandler) Handle(ctx context.Context, cmd UpdateEntryC
 	}
 	entry.UpdatedAt = time.Now()
 
-	// 5. Persistir mudanças
-	if err := h.entryRepo.Update(ctx, entry); err != nil {
-		return nil, errors.New("failed to update entry: " + err.Error())
-	}
+	// 5. Persistir mudanças e evento EntryUpdated (outbox) na mesma transação
+	// O relay do outbox publica o evento no Pulsar: Connect → Bridge → Bacen DICT
+	err = withTransaction(ctx, h.txManager, func(ctx context.Context) error {
+		if err := h.entryRepo.Update(ctx, entry); err != nil {
+			return fmt.Errorf("failed to update entry: %w", err)
+		}
 
-	// 6. Publicar evento via Pulsar (EntryUpdated)
-	// Non-blocking: triggers Connect → Bridge → Bacen DICT update
-	if h.entryProducer != nil {
-		go func() {
-			bgCtx := context.Background()
-			if err := h.eventPublisher.Publish(bgCtx, DomainEvent{
-				EventType:     "EntryUpdated",
-				AggregateID:   entry.ID.String(),
-				AggregateType: "Entry",
-				OccurredAt:    time.Now(),
-				Payload: map[string]interface{}{
-					"entry_id":       entry.ID.String(),
-					"key_value":      entry.KeyValue,
-					"new_account_id": cmd.AccountID.String(),
-					"new_ispb":       cmd.AccountISPB,
-					"new_branch":     cmd.AccountBranch,
-					"new_number":     cmd.AccountNumber,
-					"updated_by":     cmd.RequestedBy.String(),
-				},
-			}); err != nil {
-				// Log error but don't fail request
-			}
-		}()
+		// 6. Registrar evento de domínio (EntryUpdated)
+		if err := h.eventPublisher.Publish(ctx, DomainEvent{
+			EventType:     "EntryUpdated",
+			AggregateID:   entry.ID.String(),
+			AggregateType: "Entry",
+			OccurredAt:    entry.UpdatedAt,
+			Payload:       entryUpdatedEvent(entry, entry.UpdatedAt),
+		}); err != nil {
+			return fmt.Errorf("failed to publish event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// 7. Invalidar cache
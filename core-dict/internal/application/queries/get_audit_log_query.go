@@ -100,37 +100,7 @@ func (h *GetAuditLogQueryHandler) Handle(ctx context.Context, query GetAuditLogQ
 	}
 
 	// Convert AuditEvent to AuditLog
-	auditLogs := make([]*entities.AuditLog, len(auditEvents))
-	for i, event := range auditEvents {
-		var actorID uuid.UUID
-		if event.UserID != nil {
-			actorID = *event.UserID
-		}
-
-		// Merge OldValues, NewValues, and Diff into Changes
-		changes := make(map[string]interface{})
-		if event.OldValues != nil {
-			changes["old"] = event.OldValues
-		}
-		if event.NewValues != nil {
-			changes["new"] = event.NewValues
-		}
-		if event.Diff != nil {
-			changes["diff"] = event.Diff
-		}
-
-		auditLogs[i] = &entities.AuditLog{
-			ID:         event.ID,
-			EntityType: string(event.EntityType),
-			EntityID:   event.EntityID,
-			Action:     string(event.EventType),
-			ActorID:    actorID,
-			ActorType:  "user",
-			Changes:    changes,
-			Metadata:   event.Metadata,
-			Timestamp:  event.OccurredAt,
-		}
-	}
+	auditLogs := mapAuditEventsToLogs(auditEvents)
 
 	// 3. Calcular total count usando Count method
 	filters := repositories.AuditFilters{
@@ -214,37 +184,7 @@ func (h *GetAuditLogQueryHandler) HandleByActor(ctx context.Context, query GetAu
 	}
 
 	// Convert AuditEvent to AuditLog
-	auditLogs := make([]*entities.AuditLog, len(auditEvents))
-	for i, event := range auditEvents {
-		var actorID uuid.UUID
-		if event.UserID != nil {
-			actorID = *event.UserID
-		}
-
-		// Merge OldValues, NewValues, and Diff into Changes
-		changes := make(map[string]interface{})
-		if event.OldValues != nil {
-			changes["old"] = event.OldValues
-		}
-		if event.NewValues != nil {
-			changes["new"] = event.NewValues
-		}
-		if event.Diff != nil {
-			changes["diff"] = event.Diff
-		}
-
-		auditLogs[i] = &entities.AuditLog{
-			ID:         event.ID,
-			EntityType: string(event.EntityType),
-			EntityID:   event.EntityID,
-			Action:     string(event.EventType),
-			ActorID:    actorID,
-			ActorType:  "user",
-			Changes:    changes,
-			Metadata:   event.Metadata,
-			Timestamp:  event.OccurredAt,
-		}
-	}
+	auditLogs := mapAuditEventsToLogs(auditEvents)
 
 	// 3. Calcular total count
 	filters := repositories.AuditFilters{
@@ -281,6 +221,116 @@ func (h *GetAuditLogQueryHandler) HandleByActor(ctx context.Context, query GetAu
 	return result, nil
 }
 
+// ListAuditEventsQuery representa a query de listagem de audit logs com filtros combinados
+type ListAuditEventsQuery struct {
+	EntityType     string     // Opcional: ENTRY, CLAIM, INFRACTION, ...
+	EntityID       *uuid.UUID // Opcional
+	ActorID        *uuid.UUID // Opcional
+	EventType      string     // Opcional: ENTRY_CREATED, ENTRY_BLOCKED, ...
+	OccurredAfter  *time.Time // Opcional
+	OccurredBefore *time.Time // Opcional
+	Page           int        // 1-indexed
+	PageSize       int        // default: 100, max: 1000
+}
+
+// HandleList executa a listagem de audit logs com filtros (entidade, ator, tipo de evento, período).
+// Não usa cache: filtros por período tornam a taxa de acerto irrelevante.
+func (h *GetAuditLogQueryHandler) HandleList(ctx context.Context, query ListAuditEventsQuery) (*GetAuditLogResult, error) {
+	// Validação
+	if query.OccurredAfter != nil && query.OccurredBefore != nil && !query.OccurredAfter.Before(*query.OccurredBefore) {
+		return nil, fmt.Errorf("occurred_after must be before occurred_before")
+	}
+
+	// Default e limites de paginação
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.PageSize <= 0 {
+		query.PageSize = 100
+	}
+	if query.PageSize > 1000 {
+		query.PageSize = 1000
+	}
+
+	offset := (query.Page - 1) * query.PageSize
+
+	filters := repositories.AuditFilters{
+		EntityID:       query.EntityID,
+		UserID:         query.ActorID,
+		OccurredAfter:  query.OccurredAfter,
+		OccurredBefore: query.OccurredBefore,
+		Limit:          query.PageSize,
+		Offset:         offset,
+	}
+	if query.EntityType != "" {
+		entityType := entities.EntityType(query.EntityType)
+		filters.EntityType = &entityType
+	}
+	if query.EventType != "" {
+		eventType := entities.EventType(query.EventType)
+		filters.EventType = &eventType
+	}
+
+	auditEvents, err := h.auditRepo.List(ctx, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit logs: %w", err)
+	}
+
+	totalCount, err := h.auditRepo.Count(ctx, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count audit logs: %w", err)
+	}
+
+	totalPages := int(totalCount) / query.PageSize
+	if int(totalCount)%query.PageSize > 0 {
+		totalPages++
+	}
+
+	return &GetAuditLogResult{
+		AuditLogs:  mapAuditEventsToLogs(auditEvents),
+		TotalCount: totalCount,
+		Page:       query.Page,
+		PageSize:   query.PageSize,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// mapAuditEventsToLogs converte AuditEvent (persistência) em AuditLog (leitura)
+func mapAuditEventsToLogs(auditEvents []*entities.AuditEvent) []*entities.AuditLog {
+	auditLogs := make([]*entities.AuditLog, len(auditEvents))
+	for i, event := range auditEvents {
+		var actorID uuid.UUID
+		if event.UserID != nil {
+			actorID = *event.UserID
+		}
+
+		// Merge OldValues, NewValues, and Diff into Changes
+		changes := make(map[string]interface{})
+		if event.OldValues != nil {
+			changes["old"] = event.OldValues
+		}
+		if event.NewValues != nil {
+			changes["new"] = event.NewValues
+		}
+		if event.Diff != nil {
+			changes["diff"] = event.Diff
+		}
+
+		auditLogs[i] = &entities.AuditLog{
+			ID:         event.ID,
+			EntityType: string(event.EntityType),
+			EntityID:   event.EntityID,
+			Action:     string(event.EventType),
+			ActorID:    actorID,
+			ActorType:  "user",
+			Changes:    changes,
+			Metadata:   event.Metadata,
+			Timestamp:  event.OccurredAt,
+		}
+	}
+	return auditLogs
+}
+
 // InvalidateCache invalida o cache de audit logs de uma entidade
 func (h *GetAuditLogQueryHandler) InvalidateCache(ctx context.Context, entityType string, entityID uuid.UUID) error {
 	pattern := fmt.Sprintf("audit:entity:%s:%s:*", entityType, entityID.String())
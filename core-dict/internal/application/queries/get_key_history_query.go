@@ -0,0 +1,117 @@
+package queries
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/lbpay-lab/core-dict/internal/domain"
+	"github.com/lbpay-lab/core-dict/internal/domain/entities"
+	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
+)
+
+// keyHistoryMaxEventsPerEntity limita os eventos lidos por entidade na linha do tempo
+const keyHistoryMaxEventsPerEntity = 1000
+
+// GetKeyHistoryQuery representa a query do histórico completo de uma chave PIX
+type GetKeyHistoryQuery struct {
+	EntryID uuid.UUID
+}
+
+// GetKeyHistoryResult representa a linha do tempo de uma chave
+type GetKeyHistoryResult struct {
+	Entry  *entities.Entry      `json:"entry"`
+	Events []*entities.AuditLog `json:"events"` // Ordem cronológica (mais antigo primeiro)
+}
+
+// GetKeyHistoryQueryHandler monta o histórico de uma chave juntando os eventos
+// de auditoria da entry, das claims e das infrações relacionadas
+type GetKeyHistoryQueryHandler struct {
+	entryRepo      repositories.EntryRepository
+	claimRepo      repositories.ClaimRepository
+	infractionRepo repositories.InfractionRepository
+	auditRepo      repositories.AuditRepository
+}
+
+// NewGetKeyHistoryQueryHandler cria um novo handler para GetKeyHistory
+func NewGetKeyHistoryQueryHandler(
+	entryRepo repositories.EntryRepository,
+	claimRepo repositories.ClaimRepository,
+	infractionRepo repositories.InfractionRepository,
+	auditRepo repositories.AuditRepository,
+) *GetKeyHistoryQueryHandler {
+	return &GetKeyHistoryQueryHandler{
+		entryRepo:      entryRepo,
+		claimRepo:      claimRepo,
+		infractionRepo: infractionRepo,
+		auditRepo:      auditRepo,
+	}
+}
+
+// Handle executa a query GetKeyHistory (sem cache: histórico de compliance deve ser sempre atual)
+func (h *GetKeyHistoryQueryHandler) Handle(ctx context.Context, query GetKeyHistoryQuery) (*GetKeyHistoryResult, error) {
+	// Validação
+	if query.EntryID == uuid.Nil {
+		return nil, fmt.Errorf("entry_id is required")
+	}
+
+	// 1. Buscar entry
+	entry, err := h.entryRepo.FindByID(ctx, query.EntryID)
+	if err != nil {
+		if errors.Is(err, domain.ErrEntryNotFound) || errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrEntryNotFound.Wrap(err)
+		}
+		return nil, fmt.Errorf("failed to get entry: %w", err)
+	}
+
+	// 2. Eventos da própria entry
+	var events []*entities.AuditEvent
+	entryEvents, err := h.auditRepo.FindByEntityID(ctx, entities.EntityTypeEntry, entry.ID, keyHistoryMaxEventsPerEntity, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get entry audit events: %w", err)
+	}
+	events = append(events, entryEvents...)
+
+	// 3. Eventos das claims da chave
+	if h.claimRepo != nil {
+		claims, err := h.claimRepo.FindByEntryKey(ctx, entry.KeyValue)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get claims: %w", err)
+		}
+		for _, claim := range claims {
+			claimEvents, err := h.auditRepo.FindByEntityID(ctx, entities.EntityTypeClaim, claim.ID, keyHistoryMaxEventsPerEntity, 0)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get claim audit events: %w", err)
+			}
+			events = append(events, claimEvents...)
+		}
+	}
+
+	// 4. Eventos das infrações da chave
+	if h.infractionRepo != nil {
+		infractions, err := h.infractionRepo.FindByEntryID(ctx, entry.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get infractions: %w", err)
+		}
+		for _, infraction := range infractions {
+			infractionEvents, err := h.auditRepo.FindByEntityID(ctx, entities.EntityTypeInfraction, infraction.ID, keyHistoryMaxEventsPerEntity, 0)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get infraction audit events: %w", err)
+			}
+			events = append(events, infractionEvents...)
+		}
+	}
+
+	// 5. Ordenar em linha do tempo única
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].OccurredAt.Before(events[j].OccurredAt)
+	})
+
+	return &GetKeyHistoryResult{
+		Entry:  entry,
+		Events: mapAuditEventsToLogs(events),
+	}, nil
+}
@@ -11,9 +11,31 @@ import (
 	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
 )
 
+// statisticsCachePrefix prefixo das chaves de cache de estatísticas
+const statisticsCachePrefix = "statistics:"
+
 // GetStatisticsQuery representa a query para obter estatísticas agregadas
 type GetStatisticsQuery struct {
-	// Pode adicionar filtros no futuro (ex: período, ISPB específico)
+	CreatedAfter  *time.Time // Janela de tempo opcional (data de criação)
+	CreatedBefore *time.Time
+	ISPB          string
+	Branch        string
+	GroupBy       repositories.StatisticsGroupBy // Quebra por ISPB ou ISPB+agência
+}
+
+// cacheKey monta a chave de cache a partir dos filtros (sem filtros: "statistics:global")
+func (q GetStatisticsQuery) cacheKey() string {
+	if q.CreatedAfter == nil && q.CreatedBefore == nil && q.ISPB == "" && q.Branch == "" && q.GroupBy == "" {
+		return statisticsCachePrefix + "global"
+	}
+	day := func(t *time.Time) string {
+		if t == nil {
+			return "*"
+		}
+		return t.UTC().Format("2006-01-02")
+	}
+	return fmt.Sprintf("%sfrom=%s:to=%s:ispb=%s:branch=%s:group=%s",
+		statisticsCachePrefix, day(q.CreatedAfter), day(q.CreatedBefore), q.ISPB, q.Branch, q.GroupBy)
 }
 
 // GetStatisticsQueryHandler lida com a query GetStatistics
@@ -34,10 +56,24 @@ func NewGetStatisticsQueryHandler(
 }
 
 // Handle executa a query GetStatistics
-// Estatísticas são SEMPRE cacheadas por 5 minutos devido ao custo computacional
+// Os dados vêm da materialized view (atualizada pelo StatisticsRefresher) e são
+// cacheados por 5 minutos por combinação de filtros
 func (h *GetStatisticsQueryHandler) Handle(ctx context.Context, query GetStatisticsQuery) (*entities.Statistics, error) {
-	// 1. Try cache first (estatísticas são caras de calcular)
-	cacheKey := "statistics:global"
+	// Validação
+	if query.CreatedAfter != nil && query.CreatedBefore != nil && query.CreatedAfter.After(*query.CreatedBefore) {
+		return nil, fmt.Errorf("created_after must be before created_before")
+	}
+	if query.Branch != "" && query.ISPB == "" {
+		return nil, fmt.Errorf("branch filter requires ispb")
+	}
+	switch query.GroupBy {
+	case repositories.StatisticsGroupByNone, repositories.StatisticsGroupByISPB, repositories.StatisticsGroupByBranch:
+	default:
+		return nil, fmt.Errorf("invalid group_by: %s", query.GroupBy)
+	}
+
+	// 1. Try cache first
+	cacheKey := query.cacheKey()
 	if cachedData, err := h.cache.Get(ctx, cacheKey); err == nil && cachedData != nil {
 		// Cache hit
 		if stats, ok := cachedData.(*entities.Statistics); ok {
@@ -52,8 +88,14 @@ func (h *GetStatisticsQueryHandler) Handle(ctx context.Context, query GetStatist
 		}
 	}
 
-	// 2. Cache miss - calculate statistics (expensive operation)
-	stats, err := h.statsRepo.GetStatistics(ctx)
+	// 2. Cache miss - read aggregated counters from materialized view
+	stats, err := h.statsRepo.GetStatistics(ctx, repositories.StatisticsFilters{
+		CreatedAfter:  query.CreatedAfter,
+		CreatedBefore: query.CreatedBefore,
+		ISPB:          query.ISPB,
+		Branch:        query.Branch,
+		GroupBy:       query.GroupBy,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get statistics: %w", err)
 	}
@@ -67,15 +109,19 @@ func (h *GetStatisticsQueryHandler) Handle(ctx context.Context, query GetStatist
 	return stats, nil
 }
 
-// InvalidateCache invalida o cache de estatísticas
-// Deve ser chamado após operações que mudem contadores (create, delete, etc)
+// InvalidateCache invalida o cache de estatísticas (todas as combinações de filtros)
+// Deve ser chamado após o refresh da materialized view
 func (h *GetStatisticsQueryHandler) InvalidateCache(ctx context.Context) error {
-	cacheKey := "statistics:global"
-	return h.cache.Delete(ctx, cacheKey)
+	return h.cache.Invalidate(ctx, statisticsCachePrefix+"*")
 }
 
-// RefreshCache força um refresh do cache de estatísticas
+// RefreshCache força um refresh da materialized view e do cache de estatísticas
 func (h *GetStatisticsQueryHandler) RefreshCache(ctx context.Context) error {
+	// Recalcula a materialized view
+	if _, err := h.statsRepo.RefreshStatistics(ctx); err != nil {
+		return fmt.Errorf("failed to refresh statistics: %w", err)
+	}
+
 	// Invalida cache existente
 	if err := h.InvalidateCache(ctx); err != nil {
 		return err
@@ -3,6 +3,8 @@ package services
 import (
 	"context"
 	"errors"
+
+	"github.com/lbpay-lab/core-dict/internal/domain/valueobjects"
 )
 
 // AccountOwnershipService valida ownership de chave PIX
@@ -63,9 +65,14 @@ func (s *AccountOwnershipService) validateCPFOwnership(cpf, ownerTaxID string) e
 	return nil
 }
 
-// validateCNPJOwnership valida que CNPJ da chave pertence ao titular da conta
+// validateCNPJOwnership valida que CNPJ da chave pertence ao titular da conta.
+// O tax ID do titular pode vir com máscara ou letras minúsculas (CNPJ alfanumérico),
+// então ambos os lados são normalizados antes da comparação.
 func (s *AccountOwnershipService) validateCNPJOwnership(cnpj, ownerTaxID string) error {
-	if cnpj != ownerTaxID {
+	if !valueobjects.IsCNPJFormat(valueobjects.NormalizeCNPJ(ownerTaxID)) {
+		return errors.New("account owner tax ID is not a valid CNPJ")
+	}
+	if valueobjects.NormalizeCNPJ(cnpj) != valueobjects.NormalizeCNPJ(ownerTaxID) {
 		return errors.New("CNPJ key must match account owner CNPJ")
 	}
 	return nil
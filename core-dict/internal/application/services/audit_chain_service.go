@@ -0,0 +1,360 @@
+package services
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lbpay-lab/core-dict/internal/domain"
+	"github.com/lbpay-lab/core-dict/internal/domain/entities"
+	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
+)
+
+// Cadeia de hashes do log de auditoria (migration 014).
+//
+// O banco encadeia cada evento ao anterior da mesma cadeia (mês UTC + stream) depois do commit
+// (audit.link_events(), chamada pelo AuditChainLinker).
+// Isso detecta alterações e remoções feitas sem recalcular a cadeia; para cobrir quem tem acesso
+// de escrita ao banco e recalcula tudo, a aplicação assina periodicamente as cabeças das cadeias
+// (checkpoints Ed25519) com uma chave que o banco não possui.
+//
+// A verificação recalcula os hashes aqui, sem confiar nos valores gravados, confere a sequência,
+// a cabeça e cada checkpoint, e para no primeiro elo quebrado.
+
+// DefaultAuditChainPageSize é a quantidade de elos lidos por consulta na verificação
+const DefaultAuditChainPageSize = 1000
+
+// ErrAuditChainBroken é retornado por Checkpoint quando uma cadeia não confere
+var ErrAuditChainBroken = errors.New("audit chain is broken")
+
+// CheckpointSigner assina os checkpoints (implementado por BundleSigner)
+type CheckpointSigner interface {
+	KeyID() string
+	PublicKey() ed25519.PublicKey
+	SignMessage(message []byte) string
+}
+
+// AuditChainBreak identifica o primeiro elo inválido de uma cadeia
+type AuditChainBreak struct {
+	Partition string `json:"chain_partition"`
+	Stream    string `json:"stream"`
+	Seq       int64  `json:"chain_seq"`
+	EventID   string `json:"event_id,omitempty"` // Vazio quando o evento não existe
+	Reason    string `json:"reason"`
+}
+
+func (b *AuditChainBreak) String() string {
+	return fmt.Sprintf("%s/%s seq %d: %s", b.Partition, b.Stream, b.Seq, b.Reason)
+}
+
+// AuditChainVerification é o resultado de uma verificação
+type AuditChainVerification struct {
+	From                time.Time
+	To                  time.Time
+	ChainsVerified      int
+	EventsVerified      int64
+	CheckpointsVerified int
+	Break               *AuditChainBreak // nil quando todas as cadeias conferem
+	VerifiedAt          time.Time
+}
+
+// Valid indica se nenhuma cadeia foi quebrada
+func (v *AuditChainVerification) Valid() bool {
+	return v.Break == nil
+}
+
+// AuditChainService verifica a cadeia de auditoria e grava os checkpoints assinados
+type AuditChainService struct {
+	repo        repositories.AuditChainRepository
+	signer      CheckpointSigner // nil: apenas verificação
+	trustedKeys map[string]ed25519.PublicKey
+	pageSize    int
+	now         func() time.Time
+}
+
+// NewAuditChainService cria nova instância. A chave do signer é aceita na verificação junto com
+// trustedKeys (chaves anteriores, após uma rotação).
+func NewAuditChainService(
+	repo repositories.AuditChainRepository,
+	signer CheckpointSigner,
+	trustedKeys []ed25519.PublicKey,
+) *AuditChainService {
+	keys := make(map[string]ed25519.PublicKey, len(trustedKeys)+1)
+	for _, key := range trustedKeys {
+		keys[bundleKeyID(key)] = key
+	}
+	if signer != nil {
+		keys[signer.KeyID()] = signer.PublicKey()
+	}
+	return &AuditChainService{
+		repo:        repo,
+		signer:      signer,
+		trustedKeys: keys,
+		pageSize:    DefaultAuditChainPageSize,
+		now:         time.Now,
+	}
+}
+
+// ParseCheckpointPublicKeys lê uma lista de chaves públicas Ed25519 em base64 separadas por vírgula
+func ParseCheckpointPublicKeys(value string) ([]ed25519.PublicKey, error) {
+	var keys []ed25519.PublicKey
+	for _, encoded := range strings.Split(value, ",") {
+		encoded = strings.TrimSpace(encoded)
+		if encoded == "" {
+			continue
+		}
+		raw, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(raw) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid checkpoint public key %q: must be a base64 Ed25519 public key", encoded)
+		}
+		keys = append(keys, ed25519.PublicKey(raw))
+	}
+	return keys, nil
+}
+
+// Verify verifica as cadeias dos meses (UTC) que tocam [from, to], por inteiro e em ordem.
+// stream vazio verifica todos os streams.
+func (s *AuditChainService) Verify(ctx context.Context, from, to time.Time, stream string) (*AuditChainVerification, error) {
+	if from.IsZero() || to.IsZero() || to.Before(from) {
+		return nil, domain.ErrValidation.
+			WithMessage("invalid verification period").
+			WithField("to", "from and to are required and to must not be before from")
+	}
+
+	fromPartition := entities.AuditChainPartition(from)
+	toPartition := entities.AuditChainPartition(to)
+
+	heads, err := s.repo.ListHeads(ctx, fromPartition, toPartition, stream)
+	if err != nil {
+		return nil, err
+	}
+	checkpoints, err := s.repo.ListCheckpoints(ctx, fromPartition, toPartition, stream)
+	if err != nil {
+		return nil, err
+	}
+
+	// Uma cadeia com checkpoints e sem cabeça teve a cabeça removida
+	headsByChain := make(map[chainKey]*entities.AuditChainHead, len(heads))
+	for _, head := range heads {
+		headsByChain[chainKey{head.Partition, head.Stream}] = head
+	}
+	checkpointsByChain := make(map[chainKey][]*entities.AuditCheckpoint)
+	for _, checkpoint := range checkpoints {
+		key := chainKey{checkpoint.Partition, checkpoint.Stream}
+		checkpointsByChain[key] = append(checkpointsByChain[key], checkpoint)
+	}
+	chains := make([]chainKey, 0, len(headsByChain)+len(checkpointsByChain))
+	for key := range headsByChain {
+		chains = append(chains, key)
+	}
+	for key := range checkpointsByChain {
+		if _, ok := headsByChain[key]; !ok {
+			chains = append(chains, key)
+		}
+	}
+	sort.Slice(chains, func(i, j int) bool {
+		if chains[i].partition != chains[j].partition {
+			return chains[i].partition < chains[j].partition
+		}
+		return chains[i].stream < chains[j].stream
+	})
+
+	result := &AuditChainVerification{From: from, To: to}
+	for _, key := range chains {
+		head, ok := headsByChain[key]
+		if !ok {
+			cps := checkpointsByChain[key]
+			result.Break = &AuditChainBreak{
+				Partition: key.partition,
+				Stream:    key.stream,
+				Seq:       cps[len(cps)-1].Seq,
+				Reason:    "chain head is missing for a signed checkpoint",
+			}
+			break
+		}
+
+		walk, err := s.walkChain(ctx, head, nil, checkpointsByChain[key])
+		if err != nil {
+			return nil, err
+		}
+		result.ChainsVerified++
+		result.EventsVerified += walk.events
+		result.CheckpointsVerified += walk.checkpoints
+		if walk.brk != nil {
+			result.Break = walk.brk
+			break
+		}
+	}
+
+	result.VerifiedAt = s.now().UTC()
+	return result, nil
+}
+
+// Checkpoint assina as cabeças que avançaram desde o último checkpoint e retorna quantos
+// checkpoints foram gravados. Os elos novos são verificados antes da assinatura; uma cadeia
+// quebrada não é assinada e o erro (ErrAuditChainBroken) é retornado após as demais.
+func (s *AuditChainService) Checkpoint(ctx context.Context) (int, error) {
+	if s.signer == nil {
+		return 0, fmt.Errorf("audit checkpoint signing key is not configured")
+	}
+
+	heads, err := s.repo.ListUncheckpointedHeads(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	var broken []string
+	for _, head := range heads {
+		checkpoints, err := s.repo.ListCheckpoints(ctx, head.Partition, head.Partition, head.Stream)
+		if err != nil {
+			return created, err
+		}
+		var last *entities.AuditCheckpoint
+		if len(checkpoints) > 0 {
+			last = checkpoints[len(checkpoints)-1]
+		}
+
+		walk, err := s.walkChain(ctx, head, last, nil)
+		if err != nil {
+			return created, err
+		}
+		if walk.brk != nil {
+			broken = append(broken, walk.brk.String())
+			continue
+		}
+
+		checkpoint := &entities.AuditCheckpoint{
+			Partition: head.Partition,
+			Stream:    head.Stream,
+			Seq:       head.LastSeq,
+			Hash:      head.LastHash,
+			KeyID:     s.signer.KeyID(),
+			CreatedAt: s.now().UTC(),
+		}
+		checkpoint.Signature = s.signer.SignMessage(checkpoint.SigningPayload())
+		if err := s.repo.CreateCheckpoint(ctx, checkpoint); err != nil {
+			return created, err
+		}
+		created++
+	}
+
+	if len(broken) > 0 {
+		return created, fmt.Errorf("%w: %s", ErrAuditChainBroken, strings.Join(broken, "; "))
+	}
+	return created, nil
+}
+
+type chainKey struct {
+	partition string
+	stream    string
+}
+
+type chainWalk struct {
+	events      int64
+	checkpoints int
+	brk         *AuditChainBreak
+}
+
+// walkChain percorre a cadeia até a cabeça lida. Com start, parte desse checkpoint (cuja
+// assinatura é conferida) em vez do primeiro evento; checkpoints devem estar em ordem de seq.
+// Eventos gravados depois da leitura da cabeça ficam para a próxima verificação.
+func (s *AuditChainService) walkChain(
+	ctx context.Context,
+	head *entities.AuditChainHead,
+	start *entities.AuditCheckpoint,
+	checkpoints []*entities.AuditCheckpoint,
+) (*chainWalk, error) {
+	walk := &chainWalk{}
+	fail := func(seq int64, eventID, reason string) (*chainWalk, error) {
+		walk.brk = &AuditChainBreak{
+			Partition: head.Partition,
+			Stream:    head.Stream,
+			Seq:       seq,
+			EventID:   eventID,
+			Reason:    reason,
+		}
+		return walk, nil
+	}
+
+	seq := int64(0)
+	prevHash := entities.GenesisChainHash
+	if start != nil {
+		if reason := s.checkSignature(start); reason != "" {
+			return fail(start.Seq, "", reason)
+		}
+		seq = start.Seq
+		prevHash = start.Hash
+	}
+	next := 0
+
+	for seq < head.LastSeq {
+		links, err := s.repo.ListLinks(ctx, head.Partition, head.Stream, seq, s.pageSize)
+		if err != nil {
+			return nil, err
+		}
+		if len(links) == 0 {
+			break
+		}
+
+		for _, link := range links {
+			if link.Seq != seq+1 {
+				return fail(seq+1, "", "event is missing (gap in chain_seq)")
+			}
+			if link.Seq > head.LastSeq {
+				break
+			}
+			if link.PrevHash != prevHash {
+				return fail(link.Seq, link.EventID, "prev_hash does not match the previous event")
+			}
+			if link.ComputeHash() != link.Hash {
+				return fail(link.Seq, link.EventID, "event content does not match its hash")
+			}
+
+			for next < len(checkpoints) && checkpoints[next].Seq <= link.Seq {
+				checkpoint := checkpoints[next]
+				if checkpoint.Seq != link.Seq || checkpoint.Hash != link.Hash {
+					return fail(checkpoint.Seq, link.EventID, "event hash differs from the signed checkpoint")
+				}
+				if reason := s.checkSignature(checkpoint); reason != "" {
+					return fail(checkpoint.Seq, link.EventID, reason)
+				}
+				walk.checkpoints++
+				next++
+			}
+
+			seq = link.Seq
+			prevHash = link.Hash
+			walk.events++
+		}
+	}
+
+	if seq < head.LastSeq {
+		return fail(seq+1, "", "event is missing (chain ends before its head)")
+	}
+	if prevHash != head.LastHash {
+		return fail(seq, "", "chain head does not match the last event")
+	}
+	if next < len(checkpoints) {
+		return fail(checkpoints[next].Seq, "", "signed checkpoint is beyond the chain head (events removed)")
+	}
+	return walk, nil
+}
+
+// checkSignature retorna o motivo da falha, ou vazio se a assinatura confere
+func (s *AuditChainService) checkSignature(checkpoint *entities.AuditCheckpoint) string {
+	key, ok := s.trustedKeys[checkpoint.KeyID]
+	if !ok {
+		return fmt.Sprintf("checkpoint is signed with an untrusted key (%s)", checkpoint.KeyID)
+	}
+	raw, err := base64.StdEncoding.DecodeString(checkpoint.Signature)
+	if err != nil || !ed25519.Verify(key, checkpoint.SigningPayload(), raw) {
+		return "checkpoint signature is invalid"
+	}
+	return ""
+}
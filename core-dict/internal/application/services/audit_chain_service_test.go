@@ -0,0 +1,244 @@
+package services_test
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lbpay-lab/core-dict/internal/application/services"
+	"github.com/lbpay-lab/core-dict/internal/domain"
+	"github.com/lbpay-lab/core-dict/internal/domain/entities"
+)
+
+const chainTestPartition = "2026-10"
+
+// memoryAuditChainRepository links events like audit.link_events() does
+type memoryAuditChainRepository struct {
+	links       map[string][]*entities.AuditChainLink // by stream
+	heads       map[string]*entities.AuditChainHead
+	checkpoints []*entities.AuditCheckpoint
+}
+
+func newMemoryAuditChainRepository() *memoryAuditChainRepository {
+	return &memoryAuditChainRepository{
+		links: make(map[string][]*entities.AuditChainLink),
+		heads: make(map[string]*entities.AuditChainHead),
+	}
+}
+
+func (r *memoryAuditChainRepository) append(stream string, count int) {
+	head, ok := r.heads[stream]
+	if !ok {
+		head = &entities.AuditChainHead{Partition: chainTestPartition, Stream: stream, LastHash: entities.GenesisChainHash}
+		r.heads[stream] = head
+	}
+	for i := 0; i < count; i++ {
+		link := &entities.AuditChainLink{
+			Partition:  chainTestPartition,
+			Stream:     stream,
+			Seq:        head.LastSeq + 1,
+			PrevHash:   head.LastHash,
+			EventID:    fmt.Sprintf("%s-%d", stream, head.LastSeq+1),
+			EntityType: stream,
+			EventType:  "ENTRY_CREATED",
+			NewValues:  `{"status": "ACTIVE"}`,
+			OccurredAt: "2026-10-18T12:00:00.000000Z",
+		}
+		link.Hash = link.ComputeHash()
+		r.links[stream] = append(r.links[stream], link)
+		head.LastSeq = link.Seq
+		head.LastHash = link.Hash
+	}
+}
+
+// rehash recomputes the chain from the first link, as someone with write access to the database could
+func (r *memoryAuditChainRepository) rehash(stream string) {
+	prev := entities.GenesisChainHash
+	for _, link := range r.links[stream] {
+		link.PrevHash = prev
+		link.Hash = link.ComputeHash()
+		prev = link.Hash
+	}
+	r.heads[stream].LastHash = prev
+}
+
+func (r *memoryAuditChainRepository) ListHeads(_ context.Context, _, _, stream string) ([]*entities.AuditChainHead, error) {
+	var heads []*entities.AuditChainHead
+	for _, s := range []string{"CLAIM", "ENTRY"} {
+		if head, ok := r.heads[s]; ok && (stream == "" || stream == s) {
+			heads = append(heads, head)
+		}
+	}
+	return heads, nil
+}
+
+func (r *memoryAuditChainRepository) ListUncheckpointedHeads(ctx context.Context) ([]*entities.AuditChainHead, error) {
+	heads, _ := r.ListHeads(ctx, "", "", "")
+	var pending []*entities.AuditChainHead
+	for _, head := range heads {
+		last := int64(0)
+		for _, c := range r.checkpoints {
+			if c.Stream == head.Stream && c.Seq > last {
+				last = c.Seq
+			}
+		}
+		if head.LastSeq > last {
+			pending = append(pending, head)
+		}
+	}
+	return pending, nil
+}
+
+func (r *memoryAuditChainRepository) ListLinks(_ context.Context, _, stream string, afterSeq int64, limit int) ([]*entities.AuditChainLink, error) {
+	var links []*entities.AuditChainLink
+	for _, link := range r.links[stream] {
+		if link.Seq > afterSeq && len(links) < limit {
+			links = append(links, link)
+		}
+	}
+	return links, nil
+}
+
+func (r *memoryAuditChainRepository) ListCheckpoints(_ context.Context, _, _, stream string) ([]*entities.AuditCheckpoint, error) {
+	var checkpoints []*entities.AuditCheckpoint
+	for _, c := range r.checkpoints {
+		if stream == "" || c.Stream == stream {
+			checkpoints = append(checkpoints, c)
+		}
+	}
+	return checkpoints, nil
+}
+
+func (r *memoryAuditChainRepository) CreateCheckpoint(_ context.Context, checkpoint *entities.AuditCheckpoint) error {
+	r.checkpoints = append(r.checkpoints, checkpoint)
+	return nil
+}
+
+func newTestAuditChainService(t *testing.T, repo *memoryAuditChainRepository) *services.AuditChainService {
+	t.Helper()
+	signer, err := services.NewBundleSigner(make([]byte, 32))
+	require.NoError(t, err)
+	return services.NewAuditChainService(repo, signer, nil)
+}
+
+func verifyTestChains(t *testing.T, svc *services.AuditChainService) *services.AuditChainVerification {
+	t.Helper()
+	day := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	result, err := svc.Verify(context.Background(), day, day.Add(24*time.Hour), "")
+	require.NoError(t, err)
+	return result
+}
+
+func TestAuditChain_Verify_ValidChains(t *testing.T) {
+	repo := newMemoryAuditChainRepository()
+	repo.append("ENTRY", 5)
+	repo.append("CLAIM", 3)
+	svc := newTestAuditChainService(t, repo)
+
+	created, err := svc.Checkpoint(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, created)
+	repo.append("ENTRY", 2)
+
+	result := verifyTestChains(t, svc)
+	assert.True(t, result.Valid())
+	assert.Equal(t, 2, result.ChainsVerified)
+	assert.Equal(t, int64(10), result.EventsVerified)
+	assert.Equal(t, 2, result.CheckpointsVerified)
+}
+
+func TestAuditChain_Verify_DetectsTamperedEvent(t *testing.T) {
+	repo := newMemoryAuditChainRepository()
+	repo.append("ENTRY", 5)
+	repo.links["ENTRY"][2].NewValues = `{"status": "BLOCKED"}`
+
+	result := verifyTestChains(t, newTestAuditChainService(t, repo))
+	require.False(t, result.Valid())
+	assert.Equal(t, int64(3), result.Break.Seq)
+	assert.Equal(t, "ENTRY-3", result.Break.EventID)
+	assert.Contains(t, result.Break.Reason, "does not match its hash")
+}
+
+func TestAuditChain_Verify_DetectsDeletedEvent(t *testing.T) {
+	repo := newMemoryAuditChainRepository()
+	repo.append("ENTRY", 5)
+	repo.links["ENTRY"] = append(repo.links["ENTRY"][:1], repo.links["ENTRY"][2:]...)
+
+	result := verifyTestChains(t, newTestAuditChainService(t, repo))
+	require.False(t, result.Valid())
+	assert.Equal(t, int64(2), result.Break.Seq)
+	assert.Empty(t, result.Break.EventID)
+}
+
+func TestAuditChain_Verify_DetectsTruncatedChain(t *testing.T) {
+	repo := newMemoryAuditChainRepository()
+	repo.append("ENTRY", 5)
+	repo.links["ENTRY"] = repo.links["ENTRY"][:4]
+
+	result := verifyTestChains(t, newTestAuditChainService(t, repo))
+	require.False(t, result.Valid())
+	assert.Equal(t, int64(5), result.Break.Seq)
+}
+
+func TestAuditChain_Verify_CheckpointDetectsRecomputedChain(t *testing.T) {
+	repo := newMemoryAuditChainRepository()
+	repo.append("ENTRY", 5)
+	svc := newTestAuditChainService(t, repo)
+	_, err := svc.Checkpoint(context.Background())
+	require.NoError(t, err)
+
+	// Alteração com a cadeia inteira recalculada: só o checkpoint assinado denuncia
+	repo.links["ENTRY"][1].NewValues = `{"status": "BLOCKED"}`
+	repo.rehash("ENTRY")
+
+	result := verifyTestChains(t, svc)
+	require.False(t, result.Valid())
+	assert.Equal(t, int64(5), result.Break.Seq)
+	assert.Contains(t, result.Break.Reason, "signed checkpoint")
+}
+
+func TestAuditChain_Verify_RejectsUntrustedCheckpointKey(t *testing.T) {
+	repo := newMemoryAuditChainRepository()
+	repo.append("ENTRY", 2)
+	other, err := services.NewBundleSigner([]byte("0123456789abcdef0123456789abcdef"))
+	require.NoError(t, err)
+	_, err = services.NewAuditChainService(repo, other, nil).Checkpoint(context.Background())
+	require.NoError(t, err)
+
+	result := verifyTestChains(t, newTestAuditChainService(t, repo))
+	require.False(t, result.Valid())
+	assert.Contains(t, result.Break.Reason, "untrusted key")
+
+	// Chave anterior informada como confiável (AUDIT_CHECKPOINT_TRUSTED_KEYS)
+	keys, err := services.ParseCheckpointPublicKeys(" , " + base64.StdEncoding.EncodeToString(other.PublicKey()))
+	require.NoError(t, err)
+	trusted := services.NewAuditChainService(repo, nil, keys)
+	assert.True(t, verifyTestChains(t, trusted).Valid())
+}
+
+func TestAuditChain_Checkpoint_SkipsBrokenChain(t *testing.T) {
+	repo := newMemoryAuditChainRepository()
+	repo.append("ENTRY", 3)
+	repo.append("CLAIM", 3)
+	repo.links["CLAIM"][0].EventType = "CLAIM_CANCELLED"
+
+	created, err := newTestAuditChainService(t, repo).Checkpoint(context.Background())
+	assert.ErrorIs(t, err, services.ErrAuditChainBroken)
+	assert.Equal(t, 1, created)
+	require.Len(t, repo.checkpoints, 1)
+	assert.Equal(t, "ENTRY", repo.checkpoints[0].Stream)
+	assert.Equal(t, int64(3), repo.checkpoints[0].Seq)
+}
+
+func TestAuditChain_Verify_InvalidPeriod(t *testing.T) {
+	svc := newTestAuditChainService(t, newMemoryAuditChainRepository())
+	now := time.Now()
+
+	_, err := svc.Verify(context.Background(), now, now.Add(-time.Hour), "")
+	assert.ErrorIs(t, err, domain.ErrValidation)
+}
@@ -0,0 +1,213 @@
+package services
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BundleFormat é o formato do pacote de dados do titular
+type BundleFormat string
+
+const (
+	BundleFormatJSON BundleFormat = "JSON"
+	BundleFormatCSV  BundleFormat = "CSV"
+)
+
+// IsValid verifica se o formato é suportado
+func (f BundleFormat) IsValid() bool {
+	return f == BundleFormatJSON || f == BundleFormatCSV
+}
+
+// ContentType retorna o media type do pacote
+func (f BundleFormat) ContentType() string {
+	if f == BundleFormatCSV {
+		return "text/csv"
+	}
+	return "application/json"
+}
+
+// DataSubjectBundle é o conteúdo do pacote exportado
+type DataSubjectBundle struct {
+	RequestID   uuid.UUID             `json:"request_id"`
+	TaxID       string                `json:"tax_id"`
+	GeneratedAt time.Time             `json:"generated_at"`
+	Sources     []string              `json:"sources"`
+	Records     []*PersonalDataRecord `json:"records"`
+}
+
+// SignedBundle é o pacote serializado com a assinatura destacada.
+// A assinatura Ed25519 cobre exatamente os bytes de Content.
+type SignedBundle struct {
+	RequestID   uuid.UUID
+	Format      BundleFormat
+	ContentType string
+	Content     []byte
+	SHA256      string // Hex do SHA-256 de Content
+	Signature   string // Base64 da assinatura Ed25519 de Content
+	KeyID       string // Identifica a chave pública de verificação
+	RecordCount int
+	Sources     []string
+	GeneratedAt time.Time
+}
+
+// ErrInvalidBundleSignature é retornado por VerifyBundle quando a assinatura não confere
+var ErrInvalidBundleSignature = errors.New("invalid bundle signature")
+
+// BundleSigner assina os pacotes exportados com uma chave Ed25519
+type BundleSigner struct {
+	privateKey ed25519.PrivateKey
+	keyID      string
+}
+
+// NewBundleSigner cria o assinador a partir da seed Ed25519 (32 bytes)
+func NewBundleSigner(seed []byte) (*BundleSigner, error) {
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("signing key seed must have %d bytes, got %d", ed25519.SeedSize, len(seed))
+	}
+	privateKey := ed25519.NewKeyFromSeed(seed)
+	return &BundleSigner{
+		privateKey: privateKey,
+		keyID:      bundleKeyID(privateKey.Public().(ed25519.PublicKey)),
+	}, nil
+}
+
+// NewBundleSignerFromFile cria o assinador a partir de um arquivo com a seed em base64
+func NewBundleSignerFromFile(path string) (*BundleSigner, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key file: %w", err)
+	}
+	seed, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(raw)))
+	if err != nil {
+		return nil, fmt.Errorf("signing key file must contain a base64 seed: %w", err)
+	}
+	return NewBundleSigner(seed)
+}
+
+// PublicKey retorna a chave pública de verificação (publicada para quem recebe os pacotes)
+func (s *BundleSigner) PublicKey() ed25519.PublicKey {
+	return s.privateKey.Public().(ed25519.PublicKey)
+}
+
+// KeyID retorna o identificador da chave pública
+func (s *BundleSigner) KeyID() string {
+	return s.keyID
+}
+
+// SignMessage assina uma mensagem arbitrária e retorna a assinatura em base64
+// (usado também nos checkpoints da cadeia de auditoria)
+func (s *BundleSigner) SignMessage(message []byte) string {
+	return base64.StdEncoding.EncodeToString(ed25519.Sign(s.privateKey, message))
+}
+
+// Sign serializa o pacote no formato pedido e assina o resultado
+func (s *BundleSigner) Sign(bundle *DataSubjectBundle, format BundleFormat) (*SignedBundle, error) {
+	var content []byte
+	var err error
+	switch format {
+	case BundleFormatJSON:
+		content, err = json.MarshalIndent(bundle, "", "  ")
+	case BundleFormatCSV:
+		content, err = encodeBundleCSV(bundle)
+	default:
+		return nil, fmt.Errorf("unsupported bundle format: %s", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode bundle: %w", err)
+	}
+
+	digest := sha256.Sum256(content)
+	return &SignedBundle{
+		RequestID:   bundle.RequestID,
+		Format:      format,
+		ContentType: format.ContentType(),
+		Content:     content,
+		SHA256:      hex.EncodeToString(digest[:]),
+		Signature:   base64.StdEncoding.EncodeToString(ed25519.Sign(s.privateKey, content)),
+		KeyID:       s.keyID,
+		RecordCount: len(bundle.Records),
+		Sources:     bundle.Sources,
+		GeneratedAt: bundle.GeneratedAt,
+	}, nil
+}
+
+// VerifyBundle confere a assinatura (base64) de um pacote com a chave pública
+func VerifyBundle(publicKey ed25519.PublicKey, content []byte, signature string) error {
+	raw, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil || !ed25519.Verify(publicKey, content, raw) {
+		return ErrInvalidBundleSignature
+	}
+	return nil
+}
+
+// bundleKeyID deriva o identificador da chave: primeiros 8 bytes do SHA-256 da chave pública
+func bundleKeyID(publicKey ed25519.PublicKey) string {
+	digest := sha256.Sum256(publicKey)
+	return hex.EncodeToString(digest[:8])
+}
+
+// encodeBundleCSV gera uma linha por campo: request_id, source, table, record_id, field, value.
+// Valores que não são texto são gravados em JSON; os campos de cada registro saem em ordem alfabética.
+func encodeBundleCSV(bundle *DataSubjectBundle) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	if err := w.Write([]string{"request_id", "source", "table", "record_id", "field", "value"}); err != nil {
+		return nil, err
+	}
+	requestID := bundle.RequestID.String()
+	for _, record := range bundle.Records {
+		fields := make([]string, 0, len(record.Fields))
+		for field := range record.Fields {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+
+		for _, field := range fields {
+			value, err := csvValue(record.Fields[field])
+			if err != nil {
+				return nil, fmt.Errorf("field %s of %s/%s: %w", field, record.Table, record.RecordID, err)
+			}
+			if err := w.Write([]string{requestID, record.Source, record.Table, record.RecordID, field, value}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// csvValue formata um valor de campo para o CSV
+func csvValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case time.Time:
+		return v.UTC().Format(time.RFC3339), nil
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(encoded), nil
+	}
+}
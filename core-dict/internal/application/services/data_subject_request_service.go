@@ -0,0 +1,326 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/lbpay-lab/core-dict/internal/domain"
+	"github.com/lbpay-lab/core-dict/internal/domain/entities"
+	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
+	"github.com/lbpay-lab/core-dict/internal/domain/valueobjects"
+)
+
+// Pedidos do titular de dados (LGPD, art. 18) por CPF/CNPJ.
+//
+// Exportação: reúne os dados pessoais do titular em todas as bases (core-dict e conn-dict) em um
+// pacote JSON ou CSV assinado (Ed25519), que pode ser entregue ao titular e conferido depois.
+//
+// Eliminação: pseudonimiza os registros encerrados há mais tempo que o prazo de retenção legal
+// (Bacen exige a guarda dos registros do DICT). Chaves ainda registradas, contas abertas e registros
+// dentro do prazo são mantidos e aparecem no relatório como RETAINED, assim como a trilha de
+// auditoria. Na pseudonimização só o índice cego (HMAC) do dado permanece, para manter a ligação
+// com a auditoria sem expor o dado.
+//
+// Todo pedido (inclusive simulações) é registrado na auditoria com o pseudônimo do titular,
+// nunca com o CPF/CNPJ.
+
+// PersonalDataRecord é um registro com dados pessoais do titular em uma das bases
+type PersonalDataRecord struct {
+	Source   string                 `json:"source"` // Base de origem (core-dict, conn-dict)
+	Table    string                 `json:"table"`
+	RecordID string                 `json:"record_id"`
+	Fields   map[string]interface{} `json:"fields"`
+}
+
+// ErasureOutcome é o resultado da eliminação para um registro
+type ErasureOutcome string
+
+const (
+	ErasureOutcomePseudonymised ErasureOutcome = "PSEUDONYMISED" // Dados pessoais removidos, resta o índice cego
+	ErasureOutcomeRetained      ErasureOutcome = "RETAINED"      // Mantido (retenção legal ou registro ativo)
+)
+
+// ErasureAction descreve o que foi (ou seria, em simulação) feito com um registro
+type ErasureAction struct {
+	Source   string         `json:"source"`
+	Table    string         `json:"table"`
+	RecordID string         `json:"record_id"`
+	Outcome  ErasureOutcome `json:"outcome"`
+	Reason   string         `json:"reason"`
+}
+
+// PersonalDataSource dá acesso aos dados pessoais de uma base.
+// Implementado por database.PostgresPersonalDataRepository (core-dict) e
+// grpc.ConnDictPersonalDataSource (conn-dict, pelos RPCs do ConnectService).
+type PersonalDataSource interface {
+	// Name identifica a base no pacote exportado e no relatório de eliminação
+	Name() string
+
+	// CollectPersonalData retorna todos os registros vinculados ao CPF/CNPJ
+	CollectPersonalData(ctx context.Context, taxID string) ([]*PersonalDataRecord, error)
+
+	// ErasePersonalData pseudonimiza os registros do CPF/CNPJ encerrados antes de retainAfter e
+	// retorna a ação aplicada a cada registro. Com dryRun nada é alterado.
+	ErasePersonalData(ctx context.Context, taxID string, retainAfter time.Time, dryRun bool) ([]*ErasureAction, error)
+}
+
+// SubjectPseudonymizer calcula o pseudônimo estável do titular (índice cego do CPF/CNPJ)
+type SubjectPseudonymizer interface {
+	SubjectPseudonym(taxID string) string
+}
+
+// DataSubjectRequestConfig configura o atendimento dos pedidos do titular
+type DataSubjectRequestConfig struct {
+	RetentionPeriod time.Duration // Guarda obrigatória após o encerramento do registro
+}
+
+// DefaultDataSubjectRequestConfig retorna a configuração padrão (retenção de 5 anos)
+func DefaultDataSubjectRequestConfig() *DataSubjectRequestConfig {
+	return &DataSubjectRequestConfig{
+		RetentionPeriod: 5 * 365 * 24 * time.Hour,
+	}
+}
+
+// Validate valida a configuração
+func (c *DataSubjectRequestConfig) Validate() error {
+	if c.RetentionPeriod <= 0 {
+		return fmt.Errorf("retention period must be positive")
+	}
+	return nil
+}
+
+// ExportPersonalDataRequest é o pedido de acesso/portabilidade dos dados do titular
+type ExportPersonalDataRequest struct {
+	TaxID       string
+	Format      BundleFormat
+	RequestedBy string // Operador (usuário do token ou da CLI)
+	TicketID    string // Protocolo do pedido do titular
+	Reason      string
+}
+
+// ErasePersonalDataRequest é o pedido de eliminação dos dados do titular
+type ErasePersonalDataRequest struct {
+	TaxID       string
+	RequestedBy string
+	TicketID    string
+	Reason      string
+	DryRun      bool // Apenas calcula as ações
+}
+
+// ErasureReport é o resultado de um pedido de eliminação
+type ErasureReport struct {
+	RequestID        uuid.UUID
+	SubjectPseudonym string
+	DryRun           bool
+	RetainAfter      time.Time // Registros encerrados depois disso ficam retidos
+	Actions          []*ErasureAction
+	ProcessedAt      time.Time
+}
+
+// Complete indica se a eliminação foi completa: nenhum registro ficou retido com dados pessoais
+// (registros ativos, dentro do prazo legal ou eventos de auditoria com os valores anteriores)
+func (r *ErasureReport) Complete() bool {
+	return r.Count(ErasureOutcomeRetained) == 0
+}
+
+// Count conta as ações com o resultado informado
+func (r *ErasureReport) Count(outcome ErasureOutcome) int {
+	count := 0
+	for _, action := range r.Actions {
+		if action.Outcome == outcome {
+			count++
+		}
+	}
+	return count
+}
+
+// DataSubjectRequestService atende os pedidos de exportação e eliminação do titular
+type DataSubjectRequestService struct {
+	sources       []PersonalDataSource
+	auditRepo     repositories.AuditRepository
+	signer        *BundleSigner
+	pseudonymizer SubjectPseudonymizer
+	config        *DataSubjectRequestConfig
+	now           func() time.Time
+}
+
+// NewDataSubjectRequestService cria nova instância
+func NewDataSubjectRequestService(
+	sources []PersonalDataSource,
+	auditRepo repositories.AuditRepository,
+	signer *BundleSigner,
+	pseudonymizer SubjectPseudonymizer,
+	config *DataSubjectRequestConfig,
+) (*DataSubjectRequestService, error) {
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("at least one personal data source is required")
+	}
+	if auditRepo == nil || signer == nil || pseudonymizer == nil {
+		return nil, fmt.Errorf("audit repository, signer and pseudonymizer are required")
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid data subject request config: %w", err)
+	}
+	return &DataSubjectRequestService{
+		sources:       sources,
+		auditRepo:     auditRepo,
+		signer:        signer,
+		pseudonymizer: pseudonymizer,
+		config:        config,
+		now:           time.Now,
+	}, nil
+}
+
+// Export reúne os dados pessoais do titular em todas as bases e devolve o pacote assinado.
+// Falha se alguma base não responder: um pacote parcial não atende o pedido.
+func (s *DataSubjectRequestService) Export(ctx context.Context, req ExportPersonalDataRequest) (*SignedBundle, error) {
+	taxID, err := normalizeSubjectTaxID(req.TaxID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Format == "" {
+		req.Format = BundleFormatJSON
+	}
+	if !req.Format.IsValid() {
+		return nil, domain.ErrValidation.
+			WithMessage("invalid export format").
+			WithField("format", "must be JSON or CSV")
+	}
+
+	bundle := &DataSubjectBundle{
+		RequestID:   uuid.New(),
+		TaxID:       taxID,
+		GeneratedAt: s.now().UTC(),
+		Records:     []*PersonalDataRecord{},
+	}
+	for _, source := range s.sources {
+		records, err := source.CollectPersonalData(ctx, taxID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to collect personal data from %s: %w", source.Name(), err)
+		}
+		bundle.Sources = append(bundle.Sources, source.Name())
+		bundle.Records = append(bundle.Records, records...)
+	}
+
+	signed, err := s.signer.Sign(bundle, req.Format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign personal data bundle: %w", err)
+	}
+
+	// O pacote só é entregue se o pedido ficou registrado na auditoria
+	err = s.recordAudit(ctx, entities.EventTypeDataSubjectExported, bundle.RequestID, bundle.GeneratedAt,
+		map[string]interface{}{
+			"subject_pseudonym": s.pseudonymizer.SubjectPseudonym(taxID),
+			"format":            string(req.Format),
+			"record_count":      len(bundle.Records),
+			"sources":           bundle.Sources,
+			"sha256":            signed.SHA256,
+			"signing_key_id":    signed.KeyID,
+		},
+		req.RequestedBy, req.TicketID, req.Reason,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return signed, nil
+}
+
+// Erase pseudonimiza os dados do titular fora do prazo de retenção, base por base.
+// Se uma base falhar, as anteriores já foram processadas: o pedido é auditado com o erro e
+// pode ser repetido (a pseudonimização é idempotente).
+func (s *DataSubjectRequestService) Erase(ctx context.Context, req ErasePersonalDataRequest) (*ErasureReport, error) {
+	taxID, err := normalizeSubjectTaxID(req.TaxID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now().UTC()
+	report := &ErasureReport{
+		RequestID:        uuid.New(),
+		SubjectPseudonym: s.pseudonymizer.SubjectPseudonym(taxID),
+		DryRun:           req.DryRun,
+		RetainAfter:      now.Add(-s.config.RetentionPeriod),
+		Actions:          []*ErasureAction{},
+		ProcessedAt:      now,
+	}
+
+	var eraseErr error
+	for _, source := range s.sources {
+		actions, err := source.ErasePersonalData(ctx, taxID, report.RetainAfter, req.DryRun)
+		if err != nil {
+			eraseErr = fmt.Errorf("failed to erase personal data from %s: %w", source.Name(), err)
+			break
+		}
+		report.Actions = append(report.Actions, actions...)
+	}
+
+	newValues := map[string]interface{}{
+		"subject_pseudonym":   report.SubjectPseudonym,
+		"dry_run":             report.DryRun,
+		"retain_after":        report.RetainAfter.Format(time.RFC3339),
+		"pseudonymised_count": report.Count(ErasureOutcomePseudonymised),
+		"retained_count":      report.Count(ErasureOutcomeRetained),
+		"complete":            report.Complete(),
+	}
+	if eraseErr != nil {
+		newValues["error"] = eraseErr.Error()
+	}
+	if err := s.recordAudit(ctx, entities.EventTypeDataSubjectErased, report.RequestID, now,
+		newValues, req.RequestedBy, req.TicketID, req.Reason); err != nil {
+		return nil, errors.Join(eraseErr, err)
+	}
+	if eraseErr != nil {
+		return nil, eraseErr
+	}
+
+	return report, nil
+}
+
+// recordAudit registra o pedido na trilha de auditoria
+func (s *DataSubjectRequestService) recordAudit(
+	ctx context.Context,
+	eventType entities.EventType,
+	requestID uuid.UUID,
+	occurredAt time.Time,
+	newValues map[string]interface{},
+	requestedBy, ticketID, reason string,
+) error {
+	event, err := entities.NewAuditEvent(eventType, entities.EntityTypeDataSubjectRequest, requestID, nil, newValues, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build audit event: %w", err)
+	}
+	event.OccurredAt = occurredAt
+	event.AddMetadata("actor", requestedBy)
+	event.AddMetadata("ticket_id", ticketID)
+	event.AddMetadata("reason", reason)
+
+	if err := s.auditRepo.Create(ctx, event); err != nil {
+		return fmt.Errorf("failed to record data subject request in audit trail: %w", err)
+	}
+	return nil
+}
+
+// normalizeSubjectTaxID valida o CPF/CNPJ do titular (com ou sem pontuação)
+func normalizeSubjectTaxID(taxID string) (string, error) {
+	normalized := valueobjects.NormalizeCNPJ(taxID)
+	validator := NewKeyValidatorService(nil)
+
+	switch len(normalized) {
+	case 11:
+		if err := validator.ValidateFormat(KeyTypeCPF, normalized); err == nil {
+			return normalized, nil
+		}
+	case 14:
+		if err := validator.ValidateFormat(KeyTypeCNPJ, normalized); err == nil {
+			return normalized, nil
+		}
+	}
+	return "", domain.ErrValidation.
+		WithMessage("invalid tax ID").
+		WithField("tax_id", "must be a valid CPF or CNPJ")
+}
@@ -0,0 +1,232 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lbpay-lab/core-dict/internal/application/services"
+	"github.com/lbpay-lab/core-dict/internal/domain"
+	"github.com/lbpay-lab/core-dict/internal/domain/entities"
+	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
+)
+
+const dsrTestTaxID = "12345678909"
+
+// memoryPersonalDataSource is a PersonalDataSource over fixed records
+type memoryPersonalDataSource struct {
+	name       string
+	records    []*services.PersonalDataRecord
+	actions    []*services.ErasureAction
+	err        error
+	erasedWith []bool // dryRun of each ErasePersonalData call
+}
+
+func (s *memoryPersonalDataSource) Name() string { return s.name }
+
+func (s *memoryPersonalDataSource) CollectPersonalData(_ context.Context, taxID string) ([]*services.PersonalDataRecord, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	if taxID != dsrTestTaxID {
+		return nil, nil
+	}
+	return s.records, nil
+}
+
+func (s *memoryPersonalDataSource) ErasePersonalData(_ context.Context, _ string, _ time.Time, dryRun bool) ([]*services.ErasureAction, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.erasedWith = append(s.erasedWith, dryRun)
+	return s.actions, nil
+}
+
+// memoryAuditRepository records the created audit events
+type memoryAuditRepository struct {
+	repositories.AuditRepository
+	events []*entities.AuditEvent
+}
+
+func (r *memoryAuditRepository) Create(_ context.Context, event *entities.AuditEvent) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+type prefixPseudonymizer struct{}
+
+func (prefixPseudonymizer) SubjectPseudonym(taxID string) string {
+	return "pseudo-" + taxID[len(taxID)-2:]
+}
+
+func newTestDataSubjectRequestService(t *testing.T, sources ...services.PersonalDataSource) (*services.DataSubjectRequestService, *services.BundleSigner, *memoryAuditRepository) {
+	t.Helper()
+	signer, err := services.NewBundleSigner(make([]byte, 32))
+	require.NoError(t, err)
+	auditRepo := &memoryAuditRepository{}
+	svc, err := services.NewDataSubjectRequestService(sources, auditRepo, signer, prefixPseudonymizer{}, services.DefaultDataSubjectRequestConfig())
+	require.NoError(t, err)
+	return svc, signer, auditRepo
+}
+
+func coreDictTestSource() *memoryPersonalDataSource {
+	return &memoryPersonalDataSource{
+		name: "core-dict",
+		records: []*services.PersonalDataRecord{
+			{Source: "core-dict", Table: "accounts", RecordID: "acc-1", Fields: map[string]interface{}{
+				"holder_name":     "Maria da Silva",
+				"holder_document": dsrTestTaxID,
+			}},
+		},
+		actions: []*services.ErasureAction{
+			{Source: "core-dict", Table: "accounts", RecordID: "acc-1", Outcome: services.ErasureOutcomeRetained, Reason: "account is open"},
+			{Source: "core-dict", Table: "dict_entries", RecordID: "entry-1", Outcome: services.ErasureOutcomePseudonymised},
+		},
+	}
+}
+
+func TestDataSubjectRequest_ExportJSON(t *testing.T) {
+	connDict := &memoryPersonalDataSource{
+		name: "conn-dict",
+		records: []*services.PersonalDataRecord{
+			{Source: "conn-dict", Table: "entries", RecordID: "E1", Fields: map[string]interface{}{"key": "maria@example.com"}},
+		},
+	}
+	svc, signer, auditRepo := newTestDataSubjectRequestService(t, coreDictTestSource(), connDict)
+
+	bundle, err := svc.Export(context.Background(), services.ExportPersonalDataRequest{
+		TaxID:       "123.456.789-09",
+		RequestedBy: "dpo@lbpay",
+		TicketID:    "LGPD-42",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, services.BundleFormatJSON, bundle.Format)
+	assert.Equal(t, 2, bundle.RecordCount)
+	assert.Equal(t, []string{"core-dict", "conn-dict"}, bundle.Sources)
+	assert.NoError(t, services.VerifyBundle(signer.PublicKey(), bundle.Content, bundle.Signature))
+
+	var decoded services.DataSubjectBundle
+	require.NoError(t, json.Unmarshal(bundle.Content, &decoded))
+	assert.Equal(t, dsrTestTaxID, decoded.TaxID)
+	assert.Equal(t, bundle.RequestID, decoded.RequestID)
+	assert.Len(t, decoded.Records, 2)
+
+	// Tampered content fails verification
+	tampered := append([]byte{}, bundle.Content...)
+	tampered[len(tampered)-2] ^= 1
+	assert.ErrorIs(t, services.VerifyBundle(signer.PublicKey(), tampered, bundle.Signature), services.ErrInvalidBundleSignature)
+
+	// The request is audited under the subject pseudonym, never the tax ID
+	require.Len(t, auditRepo.events, 1)
+	event := auditRepo.events[0]
+	assert.Equal(t, entities.EventTypeDataSubjectExported, event.EventType)
+	assert.Equal(t, entities.EntityTypeDataSubjectRequest, event.EntityType)
+	assert.Equal(t, bundle.RequestID, event.EntityID)
+	assert.Equal(t, "pseudo-09", event.NewValues["subject_pseudonym"])
+	assert.Equal(t, bundle.SHA256, event.NewValues["sha256"])
+	assert.Equal(t, "LGPD-42", event.Metadata["ticket_id"])
+	encoded, _ := json.Marshal(event)
+	assert.NotContains(t, string(encoded), dsrTestTaxID)
+}
+
+func TestDataSubjectRequest_ExportCSV(t *testing.T) {
+	svc, signer, _ := newTestDataSubjectRequestService(t, coreDictTestSource())
+
+	bundle, err := svc.Export(context.Background(), services.ExportPersonalDataRequest{
+		TaxID:  dsrTestTaxID,
+		Format: services.BundleFormatCSV,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "text/csv", bundle.ContentType)
+	assert.NoError(t, services.VerifyBundle(signer.PublicKey(), bundle.Content, bundle.Signature))
+
+	lines := strings.Split(strings.TrimSpace(string(bundle.Content)), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, "request_id,source,table,record_id,field,value", lines[0])
+	assert.Equal(t, bundle.RequestID.String()+",core-dict,accounts,acc-1,holder_document,"+dsrTestTaxID, lines[1])
+	assert.Equal(t, bundle.RequestID.String()+",core-dict,accounts,acc-1,holder_name,Maria da Silva", lines[2])
+}
+
+func TestDataSubjectRequest_ExportSourceFailure(t *testing.T) {
+	failing := &memoryPersonalDataSource{name: "conn-dict", err: errors.New("connection refused")}
+	svc, _, auditRepo := newTestDataSubjectRequestService(t, coreDictTestSource(), failing)
+
+	_, err := svc.Export(context.Background(), services.ExportPersonalDataRequest{TaxID: dsrTestTaxID})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "conn-dict")
+	assert.Empty(t, auditRepo.events, "a failed export hands out no data and is not audited as exported")
+}
+
+func TestDataSubjectRequest_Erase(t *testing.T) {
+	source := coreDictTestSource()
+	svc, _, auditRepo := newTestDataSubjectRequestService(t, source)
+
+	report, err := svc.Erase(context.Background(), services.ErasePersonalDataRequest{
+		TaxID:  dsrTestTaxID,
+		DryRun: true,
+	})
+	require.NoError(t, err)
+
+	assert.True(t, report.DryRun)
+	assert.Equal(t, []bool{true}, source.erasedWith)
+	assert.Equal(t, 1, report.Count(services.ErasureOutcomePseudonymised))
+	assert.Equal(t, 1, report.Count(services.ErasureOutcomeRetained))
+	assert.False(t, report.Complete(), "a retained record still holds personal data")
+	assert.WithinDuration(t, time.Now().Add(-5*365*24*time.Hour), report.RetainAfter, time.Minute)
+
+	require.Len(t, auditRepo.events, 1)
+	assert.Equal(t, entities.EventTypeDataSubjectErased, auditRepo.events[0].EventType)
+	assert.Equal(t, true, auditRepo.events[0].NewValues["dry_run"])
+	assert.Equal(t, 1, auditRepo.events[0].NewValues["pseudonymised_count"])
+	assert.Equal(t, false, auditRepo.events[0].NewValues["complete"])
+}
+
+func TestDataSubjectRequest_EraseFailureIsAudited(t *testing.T) {
+	failing := &memoryPersonalDataSource{name: "conn-dict", err: errors.New("connection refused")}
+	svc, _, auditRepo := newTestDataSubjectRequestService(t, coreDictTestSource(), failing)
+
+	_, err := svc.Erase(context.Background(), services.ErasePersonalDataRequest{TaxID: dsrTestTaxID})
+	require.Error(t, err)
+
+	require.Len(t, auditRepo.events, 1)
+	assert.Contains(t, auditRepo.events[0].NewValues["error"], "connection refused")
+}
+
+func TestDataSubjectRequest_InvalidRequests(t *testing.T) {
+	svc, _, auditRepo := newTestDataSubjectRequestService(t, coreDictTestSource())
+	ctx := context.Background()
+
+	_, err := svc.Export(ctx, services.ExportPersonalDataRequest{TaxID: "12345678900"})
+	assert.ErrorIs(t, err, domain.ErrValidation)
+
+	_, err = svc.Export(ctx, services.ExportPersonalDataRequest{TaxID: dsrTestTaxID, Format: "XML"})
+	assert.ErrorIs(t, err, domain.ErrValidation)
+
+	_, err = svc.Erase(ctx, services.ErasePersonalDataRequest{TaxID: "not-a-tax-id"})
+	assert.ErrorIs(t, err, domain.ErrValidation)
+
+	assert.Empty(t, auditRepo.events)
+}
+
+func TestBundleSigner_KeyID(t *testing.T) {
+	_, err := services.NewBundleSigner([]byte("short"))
+	assert.Error(t, err)
+
+	a, err := services.NewBundleSigner(make([]byte, 32))
+	require.NoError(t, err)
+	seed := make([]byte, 32)
+	seed[0] = 1
+	b, err := services.NewBundleSigner(seed)
+	require.NoError(t, err)
+
+	assert.Len(t, a.KeyID(), 16)
+	assert.NotEqual(t, a.KeyID(), b.KeyID())
+}
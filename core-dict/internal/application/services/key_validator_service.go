@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"regexp"
+
+	"github.com/lbpay-lab/core-dict/internal/domain/valueobjects"
 )
 
 // KeyType representa o tipo de chave PIX
@@ -145,16 +147,16 @@ func (s *KeyValidatorService) validateCPFCheckDigits(cpf string) bool {
 	return true
 }
 
-// validateCNPJ valida formato de CNPJ
+// validateCNPJ valida formato de CNPJ (numérico ou alfanumérico)
 func (s *KeyValidatorService) validateCNPJ(cnpj string) error {
 	// 1. Validar comprimento
 	if len(cnpj) != 14 {
-		return errors.New("CNPJ must have 14 digits")
+		return errors.New("CNPJ must have 14 characters")
 	}
 
-	// 2. Validar apenas números
-	if !regexp.MustCompile(`^\d{14}$`).MatchString(cnpj) {
-		return errors.New("CNPJ must contain only digits")
+	// 2. Validar formato: 12 posições alfanuméricas (maiúsculas) + 2 dígitos verificadores
+	if !valueobjects.IsCNPJFormat(cnpj) {
+		return errors.New("CNPJ must contain 12 uppercase alphanumeric characters followed by 2 check digits")
 	}
 
 	// 3. Rejeitar CNPJs conhecidos inválidos
@@ -179,40 +181,9 @@ func (s *KeyValidatorService) validateCNPJ(cnpj string) error {
 }
 
 // validateCNPJCheckDigits valida dígitos verificadores do CNPJ
+// (mesmo módulo 11 para ambos os formatos, com valor ASCII-48 por caractere)
 func (s *KeyValidatorService) validateCNPJCheckDigits(cnpj string) bool {
-	// Primeiro dígito verificador
-	weights := []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
-	sum := 0
-	for i := 0; i < 12; i++ {
-		digit := int(cnpj[i] - '0')
-		sum += digit * weights[i]
-	}
-	remainder := sum % 11
-	firstDigit := 0
-	if remainder >= 2 {
-		firstDigit = 11 - remainder
-	}
-	if firstDigit != int(cnpj[12]-'0') {
-		return false
-	}
-
-	// Segundo dígito verificador
-	weights = []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
-	sum = 0
-	for i := 0; i < 13; i++ {
-		digit := int(cnpj[i] - '0')
-		sum += digit * weights[i]
-	}
-	remainder = sum % 11
-	secondDigit := 0
-	if remainder >= 2 {
-		secondDigit = 11 - remainder
-	}
-	if secondDigit != int(cnpj[13]-'0') {
-		return false
-	}
-
-	return true
+	return valueobjects.ValidCNPJCheckDigits(cnpj)
 }
 
 // validateEmail valida formato de email
@@ -123,7 +123,46 @@ func TestKeyValidator_ValidateCNPJ_InvalidLength(t *testing.T) {
 
 	// Assert
 	require.Error(t, err)
-	assert.Contains(t, err.Error(), "14 digits")
+	assert.Contains(t, err.Error(), "14 characters")
+}
+
+// TestKeyValidator_ValidateCNPJ_Alphanumeric covers numeric and alphanumeric CNPJs
+func TestKeyValidator_ValidateCNPJ_Alphanumeric(t *testing.T) {
+	// Arrange
+	mockCounter := new(MockEntryCounter)
+	validator := services.NewKeyValidatorService(mockCounter)
+
+	testCases := []struct {
+		name   string
+		cnpj   string
+		errMsg string
+	}{
+		{name: "numeric", cnpj: "11222333000181"},
+		{name: "alphanumeric RFB example", cnpj: "12ABC34501DE35"},
+		{name: "alphanumeric letters only base", cnpj: "ABCDEFGHIJKL80"},
+		{name: "alphanumeric mixed base", cnpj: "1A2B3C4D000179"},
+		{name: "alphanumeric wrong check digits", cnpj: "12ABC34501DE36", errMsg: "check digits"},
+		{name: "numeric wrong check digits", cnpj: "11222333000182", errMsg: "check digits"},
+		{name: "lowercase letters", cnpj: "12abc34501de35", errMsg: "alphanumeric"},
+		{name: "letter in check digits", cnpj: "12ABC34501DE3A", errMsg: "alphanumeric"},
+		{name: "formatted", cnpj: "12.ABC.345/01DE-35", errMsg: "14 characters"},
+		{name: "repeated digits", cnpj: "11111111111111", errMsg: "invalid CNPJ pattern"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			err := validator.ValidateFormat(services.KeyTypeCNPJ, tc.cnpj)
+
+			// Assert
+			if tc.errMsg == "" {
+				require.NoError(t, err, "CNPJ %s should be valid", tc.cnpj)
+				return
+			}
+			require.Error(t, err, "CNPJ %s should be invalid", tc.cnpj)
+			assert.Contains(t, err.Error(), tc.errMsg)
+		})
+	}
 }
 
 // ===== EMAIL VALIDATION TESTS =====
@@ -0,0 +1,321 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/lbpay-lab/core-dict/internal/domain"
+	"github.com/lbpay-lab/core-dict/internal/domain/valueobjects"
+)
+
+// Verificação de posse de chaves EMAIL e PHONE (exigência do Bacen antes do registro).
+//
+// Fluxo:
+//  1. Request: gera um código numérico, guarda apenas o HMAC do código (com TTL e limite de
+//     tentativas) e envia o código ao email/telefone pelo VerificationNotifier
+//  2. Confirm: o usuário informa o código; cada erro consome uma tentativa
+//  3. Consume: CreateKey/ConfirmPortability usam o verification_id confirmado (uso único,
+//     vinculado ao usuário e à chave)
+
+// KeyVerificationStatus representa o estado de uma verificação
+type KeyVerificationStatus string
+
+const (
+	KeyVerificationPending  KeyVerificationStatus = "PENDING"
+	KeyVerificationVerified KeyVerificationStatus = "VERIFIED"
+	KeyVerificationLocked   KeyVerificationStatus = "LOCKED" // Tentativas esgotadas
+)
+
+// KeyVerification representa uma verificação de posse de chave
+type KeyVerification struct {
+	ID          string
+	UserID      string
+	KeyType     valueobjects.KeyType
+	KeyValue    string
+	CodeHash    string // HMAC-SHA256 do código (o código nunca é armazenado)
+	Status      KeyVerificationStatus
+	Attempts    int
+	MaxAttempts int
+	CreatedAt   time.Time
+	ExpiresAt   time.Time // Expiração do código (PENDING) ou do uso da verificação (VERIFIED)
+	VerifiedAt  *time.Time
+}
+
+// RemainingAttempts retorna quantas tentativas ainda restam
+func (v *KeyVerification) RemainingAttempts() int {
+	if remaining := v.MaxAttempts - v.Attempts; remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// CodeCheckResult é o resultado da conferência atômica do código no store
+type CodeCheckResult string
+
+const (
+	CodeCheckMatched         CodeCheckResult = "MATCHED"
+	CodeCheckMismatch        CodeCheckResult = "MISMATCH"
+	CodeCheckLocked          CodeCheckResult = "LOCKED"
+	CodeCheckAlreadyVerified CodeCheckResult = "ALREADY_VERIFIED"
+)
+
+// ErrKeyVerificationNotFound é retornado pelo store quando a verificação não existe ou expirou
+var ErrKeyVerificationNotFound = errors.New("key verification not found")
+
+// KeyVerificationStore persiste as verificações (implementado por cache.KeyVerificationStore no Redis).
+// CheckCode e Consume devem ser atômicos: réplicas concorrentes não podem ganhar tentativas extras
+// nem usar a mesma verificação duas vezes.
+type KeyVerificationStore interface {
+	// Save grava uma verificação PENDING até v.ExpiresAt
+	Save(ctx context.Context, v *KeyVerification) error
+
+	// CheckCode compara codeHash e conta a tentativa; quando o código confere, marca como
+	// VERIFIED e estende a validade para verifiedTTL. Verificações de outro usuário são
+	// tratadas como inexistentes (ErrKeyVerificationNotFound).
+	CheckCode(ctx context.Context, id, userID, codeHash string, verifiedTTL time.Duration) (*KeyVerification, CodeCheckResult, error)
+
+	// Consume remove a verificação VERIFIED do usuário para a chave informada (uso único).
+	// Com keyValue vazio aceita qualquer chave do usuário.
+	Consume(ctx context.Context, id, userID string, keyType valueobjects.KeyType, keyValue string) (*KeyVerification, error)
+
+	// AcquireResendSlot reserva o envio de um novo código para o usuário/chave (false se ainda em cooldown)
+	AcquireResendSlot(ctx context.Context, userID string, keyType valueobjects.KeyType, keyValue string, interval time.Duration) (bool, error)
+}
+
+// VerificationCodeMessage é o conteúdo entregue ao notificador
+type VerificationCodeMessage struct {
+	VerificationID string
+	KeyType        valueobjects.KeyType
+	Destination    string // Email ou telefone (valor da chave)
+	Code           string
+	ExpiresAt      time.Time
+}
+
+// VerificationNotifier entrega o código ao titular (email, SMS...).
+// Implementações locais (log/arquivo) ficam em infrastructure/notification.
+type VerificationNotifier interface {
+	SendVerificationCode(ctx context.Context, msg VerificationCodeMessage) error
+}
+
+// KeyVerificationConfig configura a verificação de posse
+type KeyVerificationConfig struct {
+	CodeLength     int           // Dígitos do código
+	CodeTTL        time.Duration // Validade do código enviado
+	VerifiedTTL    time.Duration // Prazo para usar a verificação confirmada
+	MaxAttempts    int           // Tentativas antes de invalidar o código
+	ResendInterval time.Duration // Intervalo mínimo entre envios para o mesmo usuário/chave
+	CodeSecret     string        // Segredo do HMAC dos códigos
+}
+
+// DefaultKeyVerificationConfig retorna a configuração padrão
+func DefaultKeyVerificationConfig(codeSecret string) *KeyVerificationConfig {
+	return &KeyVerificationConfig{
+		CodeLength:     6,
+		CodeTTL:        10 * time.Minute,
+		VerifiedTTL:    30 * time.Minute,
+		MaxAttempts:    5,
+		ResendInterval: time.Minute,
+		CodeSecret:     codeSecret,
+	}
+}
+
+// Validate valida a configuração
+func (c *KeyVerificationConfig) Validate() error {
+	if c.CodeLength < 6 || c.CodeLength > 10 {
+		return fmt.Errorf("code length must be between 6 and 10")
+	}
+	if c.CodeTTL <= 0 || c.VerifiedTTL <= 0 {
+		return fmt.Errorf("code TTL and verified TTL must be positive")
+	}
+	if c.MaxAttempts <= 0 {
+		return fmt.Errorf("max attempts must be positive")
+	}
+	if c.ResendInterval < 0 {
+		return fmt.Errorf("resend interval must not be negative")
+	}
+	if len(c.CodeSecret) < 16 {
+		return fmt.Errorf("code secret must have at least 16 characters")
+	}
+	return nil
+}
+
+// KeyVerificationService coordena a verificação de posse de chaves EMAIL e PHONE
+type KeyVerificationService struct {
+	store    KeyVerificationStore
+	notifier VerificationNotifier
+	config   *KeyVerificationConfig
+	now      func() time.Time
+}
+
+// NewKeyVerificationService cria nova instância
+func NewKeyVerificationService(store KeyVerificationStore, notifier VerificationNotifier, config *KeyVerificationConfig) (*KeyVerificationService, error) {
+	if store == nil || notifier == nil {
+		return nil, fmt.Errorf("store and notifier are required")
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid key verification config: %w", err)
+	}
+	return &KeyVerificationService{
+		store:    store,
+		notifier: notifier,
+		config:   config,
+		now:      time.Now,
+	}, nil
+}
+
+// RequiresVerification indica se o tipo de chave exige verificação de posse
+func RequiresVerification(keyType valueobjects.KeyType) bool {
+	return keyType == valueobjects.KeyTypeEmail || keyType == valueobjects.KeyTypePhone
+}
+
+// Request cria uma verificação e envia o código ao email/telefone
+func (s *KeyVerificationService) Request(ctx context.Context, userID string, keyType valueobjects.KeyType, keyValue string) (*KeyVerification, error) {
+	if !RequiresVerification(keyType) {
+		return nil, domain.ErrInvalidKeyType.
+			WithMessage("ownership verification applies only to EMAIL and PHONE keys").
+			WithField("key_type", "must be EMAIL or PHONE")
+	}
+	if userID == "" || keyValue == "" {
+		return nil, domain.ErrValidation.WithMessage("user and key value are required")
+	}
+
+	// Cooldown entre envios (evita disparo em massa de emails/SMS)
+	if s.config.ResendInterval > 0 {
+		acquired, err := s.store.AcquireResendSlot(ctx, userID, keyType, keyValue, s.config.ResendInterval)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check resend interval: %w", err)
+		}
+		if !acquired {
+			return nil, domain.ErrVerificationResendTooSoon.
+				WithMetadata("resend_interval_seconds", strconv.Itoa(int(s.config.ResendInterval.Seconds())))
+		}
+	}
+
+	code, err := generateNumericCode(s.config.CodeLength)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate verification code: %w", err)
+	}
+
+	now := s.now()
+	verification := &KeyVerification{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		KeyType:     keyType,
+		KeyValue:    keyValue,
+		Status:      KeyVerificationPending,
+		MaxAttempts: s.config.MaxAttempts,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.config.CodeTTL),
+	}
+	verification.CodeHash = s.hashCode(verification.ID, code)
+
+	if err := s.store.Save(ctx, verification); err != nil {
+		return nil, fmt.Errorf("failed to save verification: %w", err)
+	}
+
+	if err := s.notifier.SendVerificationCode(ctx, VerificationCodeMessage{
+		VerificationID: verification.ID,
+		KeyType:        keyType,
+		Destination:    keyValue,
+		Code:           code,
+		ExpiresAt:      verification.ExpiresAt,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to send verification code: %w", err)
+	}
+
+	return verification, nil
+}
+
+// Confirm confere o código informado pelo usuário
+func (s *KeyVerificationService) Confirm(ctx context.Context, verificationID, userID, code string) (*KeyVerification, error) {
+	if verificationID == "" || code == "" {
+		return nil, domain.ErrValidation.WithMessage("verification_id and code are required")
+	}
+
+	// A verificação só pode ser confirmada pelo usuário que a solicitou
+	verification, result, err := s.store.CheckCode(ctx, verificationID, userID, s.hashCode(verificationID, code), s.config.VerifiedTTL)
+	if err != nil {
+		if errors.Is(err, ErrKeyVerificationNotFound) {
+			return nil, domain.ErrVerificationNotFound
+		}
+		return nil, fmt.Errorf("verification store failed: %w", err)
+	}
+
+	switch result {
+	case CodeCheckMatched, CodeCheckAlreadyVerified:
+		return verification, nil
+	case CodeCheckLocked:
+		return nil, domain.ErrVerificationAttemptsExceeded.WithMetadata("verification_id", verificationID)
+	default:
+		return nil, domain.ErrVerificationCodeInvalid.
+			WithMetadata("remaining_attempts", strconv.Itoa(verification.RemainingAttempts()))
+	}
+}
+
+// Consume exige uma verificação confirmada do usuário para a chave e a invalida (uso único).
+// Chamado por CreateKey e ConfirmPortability (com a chave do registro da portabilidade) antes de
+// executar a operação; com keyValue vazio a chave verificada é a retornada.
+func (s *KeyVerificationService) Consume(ctx context.Context, verificationID, userID string, keyType valueobjects.KeyType, keyValue string) (*KeyVerification, error) {
+	if verificationID == "" {
+		return nil, domain.ErrKeyVerificationRequired.
+			WithField("verification_id", "required for EMAIL and PHONE keys")
+	}
+
+	verification, err := s.store.Consume(ctx, verificationID, userID, keyType, keyValue)
+	if err != nil {
+		if errors.Is(err, ErrKeyVerificationNotFound) {
+			// Inexistente, expirada, não confirmada ou de outro usuário/chave: a resposta é a mesma
+			return nil, domain.ErrKeyVerificationRequired.WithMessage("no confirmed verification for this key")
+		}
+		return nil, fmt.Errorf("failed to consume verification: %w", err)
+	}
+	return verification, nil
+}
+
+// hashCode calcula o HMAC do código; o ID da verificação entra na mensagem para que o mesmo
+// código gere hashes diferentes em verificações diferentes
+func (s *KeyVerificationService) hashCode(verificationID, code string) string {
+	mac := hmac.New(sha256.New, []byte(s.config.CodeSecret))
+	mac.Write([]byte(verificationID + ":" + strings.TrimSpace(code)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// generateNumericCode gera um código numérico com crypto/rand
+func generateNumericCode(length int) (string, error) {
+	max := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(length)), nil)
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", length, n), nil
+}
+
+// MaskDestination mascara o email/telefone para exibição (e.g. "j***@example.com", "+55*******4321")
+func MaskDestination(keyType valueobjects.KeyType, keyValue string) string {
+	switch keyType {
+	case valueobjects.KeyTypeEmail:
+		at := strings.LastIndex(keyValue, "@")
+		if at <= 0 {
+			return "***"
+		}
+		return keyValue[:1] + "***" + keyValue[at:]
+	case valueobjects.KeyTypePhone:
+		if len(keyValue) <= 7 {
+			return "***"
+		}
+		return keyValue[:3] + strings.Repeat("*", len(keyValue)-7) + keyValue[len(keyValue)-4:]
+	default:
+		return "***"
+	}
+}
@@ -0,0 +1,254 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lbpay-lab/core-dict/internal/application/services"
+	"github.com/lbpay-lab/core-dict/internal/domain"
+	"github.com/lbpay-lab/core-dict/internal/domain/valueobjects"
+)
+
+// memoryVerificationStore is an in-memory KeyVerificationStore with the same rules as the Redis one
+type memoryVerificationStore struct {
+	mu      sync.Mutex
+	records map[string]services.KeyVerification
+	resend  map[string]time.Time
+}
+
+func newMemoryVerificationStore() *memoryVerificationStore {
+	return &memoryVerificationStore{
+		records: make(map[string]services.KeyVerification),
+		resend:  make(map[string]time.Time),
+	}
+}
+
+func (s *memoryVerificationStore) Save(_ context.Context, v *services.KeyVerification) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[v.ID] = *v
+	return nil
+}
+
+func (s *memoryVerificationStore) CheckCode(_ context.Context, id, userID, codeHash string, verifiedTTL time.Duration) (*services.KeyVerification, services.CodeCheckResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.records[id]
+	if !ok || v.UserID != userID || time.Now().After(v.ExpiresAt) {
+		return nil, "", services.ErrKeyVerificationNotFound
+	}
+	if v.Status == services.KeyVerificationVerified {
+		return &v, services.CodeCheckAlreadyVerified, nil
+	}
+	if v.Status == services.KeyVerificationLocked || v.Attempts >= v.MaxAttempts {
+		return &v, services.CodeCheckLocked, nil
+	}
+
+	v.Attempts++
+	result := services.CodeCheckMismatch
+	switch {
+	case v.CodeHash == codeHash:
+		now := time.Now()
+		v.Status = services.KeyVerificationVerified
+		v.VerifiedAt = &now
+		v.ExpiresAt = now.Add(verifiedTTL)
+		result = services.CodeCheckMatched
+	case v.Attempts >= v.MaxAttempts:
+		v.Status = services.KeyVerificationLocked
+		result = services.CodeCheckLocked
+	}
+	s.records[id] = v
+	return &v, result, nil
+}
+
+func (s *memoryVerificationStore) Consume(_ context.Context, id, userID string, keyType valueobjects.KeyType, keyValue string) (*services.KeyVerification, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.records[id]
+	if !ok || v.UserID != userID || v.Status != services.KeyVerificationVerified || time.Now().After(v.ExpiresAt) {
+		return nil, services.ErrKeyVerificationNotFound
+	}
+	if keyValue != "" && (v.KeyType != keyType || v.KeyValue != keyValue) {
+		return nil, services.ErrKeyVerificationNotFound
+	}
+	delete(s.records, id)
+	return &v, nil
+}
+
+func (s *memoryVerificationStore) AcquireResendSlot(_ context.Context, userID string, keyType valueobjects.KeyType, keyValue string, interval time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := userID + "|" + string(keyType) + "|" + keyValue
+	if until, ok := s.resend[key]; ok && time.Now().Before(until) {
+		return false, nil
+	}
+	s.resend[key] = time.Now().Add(interval)
+	return true, nil
+}
+
+// capturingNotifier keeps the codes it was asked to send
+type capturingNotifier struct {
+	mu       sync.Mutex
+	messages []services.VerificationCodeMessage
+	err      error
+}
+
+func (n *capturingNotifier) SendVerificationCode(_ context.Context, msg services.VerificationCodeMessage) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.messages = append(n.messages, msg)
+	return n.err
+}
+
+func (n *capturingNotifier) lastCode() string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.messages[len(n.messages)-1].Code
+}
+
+func newTestKeyVerificationService(t *testing.T) (*services.KeyVerificationService, *memoryVerificationStore, *capturingNotifier) {
+	store := newMemoryVerificationStore()
+	notifier := &capturingNotifier{}
+	config := services.DefaultKeyVerificationConfig("test-secret-0123456789")
+	config.MaxAttempts = 3
+
+	svc, err := services.NewKeyVerificationService(store, notifier, config)
+	require.NoError(t, err)
+	return svc, store, notifier
+}
+
+func TestKeyVerification_FullFlow(t *testing.T) {
+	svc, store, notifier := newTestKeyVerificationService(t)
+	ctx := context.Background()
+
+	v, err := svc.Request(ctx, "user-1", valueobjects.KeyTypeEmail, "john@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, services.KeyVerificationPending, v.Status)
+
+	code := notifier.lastCode()
+	assert.Len(t, code, 6)
+
+	// Only the hash is stored
+	stored := store.records[v.ID]
+	assert.NotEmpty(t, stored.CodeHash)
+	assert.NotContains(t, stored.CodeHash, code)
+
+	// Not confirmed yet: can't be used
+	_, err = svc.Consume(ctx, v.ID, "user-1", valueobjects.KeyTypeEmail, "john@example.com")
+	assert.ErrorIs(t, err, domain.ErrKeyVerificationRequired)
+
+	confirmed, err := svc.Confirm(ctx, v.ID, "user-1", code)
+	require.NoError(t, err)
+	assert.Equal(t, services.KeyVerificationVerified, confirmed.Status)
+	require.NotNil(t, confirmed.VerifiedAt)
+
+	consumed, err := svc.Consume(ctx, v.ID, "user-1", valueobjects.KeyTypeEmail, "john@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "john@example.com", consumed.KeyValue)
+
+	// Single use
+	_, err = svc.Consume(ctx, v.ID, "user-1", valueobjects.KeyTypeEmail, "john@example.com")
+	assert.ErrorIs(t, err, domain.ErrKeyVerificationRequired)
+}
+
+func TestKeyVerification_AttemptsExceeded(t *testing.T) {
+	svc, _, notifier := newTestKeyVerificationService(t)
+	ctx := context.Background()
+
+	v, err := svc.Request(ctx, "user-1", valueobjects.KeyTypePhone, "+5511987654321")
+	require.NoError(t, err)
+	code := notifier.lastCode()
+	wrong := "000000"
+	if code == wrong {
+		wrong = "111111"
+	}
+
+	_, err = svc.Confirm(ctx, v.ID, "user-1", wrong)
+	require.ErrorIs(t, err, domain.ErrVerificationCodeInvalid)
+	domainErr, ok := domain.AsError(err)
+	require.True(t, ok)
+	assert.Equal(t, "2", domainErr.Metadata["remaining_attempts"])
+
+	_, err = svc.Confirm(ctx, v.ID, "user-1", wrong)
+	require.ErrorIs(t, err, domain.ErrVerificationCodeInvalid)
+	_, err = svc.Confirm(ctx, v.ID, "user-1", wrong)
+	require.ErrorIs(t, err, domain.ErrVerificationAttemptsExceeded)
+
+	// The right code no longer works
+	_, err = svc.Confirm(ctx, v.ID, "user-1", code)
+	assert.ErrorIs(t, err, domain.ErrVerificationAttemptsExceeded)
+}
+
+func TestKeyVerification_BoundToUserAndKey(t *testing.T) {
+	svc, _, notifier := newTestKeyVerificationService(t)
+	ctx := context.Background()
+
+	v, err := svc.Request(ctx, "user-1", valueobjects.KeyTypeEmail, "john@example.com")
+	require.NoError(t, err)
+	code := notifier.lastCode()
+
+	// Another user can't confirm it, even with the right code
+	_, err = svc.Confirm(ctx, v.ID, "user-2", code)
+	assert.ErrorIs(t, err, domain.ErrVerificationNotFound)
+
+	_, err = svc.Confirm(ctx, v.ID, "user-1", code)
+	require.NoError(t, err)
+
+	// Nor use it, and it can't be used for another key
+	_, err = svc.Consume(ctx, v.ID, "user-2", valueobjects.KeyTypeEmail, "john@example.com")
+	assert.ErrorIs(t, err, domain.ErrKeyVerificationRequired)
+	_, err = svc.Consume(ctx, v.ID, "user-1", valueobjects.KeyTypeEmail, "mary@example.com")
+	assert.ErrorIs(t, err, domain.ErrKeyVerificationRequired)
+
+	_, err = svc.Consume(ctx, "", "user-1", valueobjects.KeyTypeEmail, "john@example.com")
+	require.ErrorIs(t, err, domain.ErrKeyVerificationRequired)
+	domainErr, _ := domain.AsError(err)
+	require.Len(t, domainErr.Violations, 1)
+	assert.Equal(t, "verification_id", domainErr.Violations[0].Field)
+}
+
+func TestKeyVerification_RequestRules(t *testing.T) {
+	svc, _, notifier := newTestKeyVerificationService(t)
+	ctx := context.Background()
+
+	_, err := svc.Request(ctx, "user-1", valueobjects.KeyTypeCPF, "12345678909")
+	assert.ErrorIs(t, err, domain.ErrInvalidKeyType)
+
+	_, err = svc.Request(ctx, "user-1", valueobjects.KeyTypeEmail, "john@example.com")
+	require.NoError(t, err)
+
+	// Resend cooldown per user/key
+	_, err = svc.Request(ctx, "user-1", valueobjects.KeyTypeEmail, "john@example.com")
+	assert.ErrorIs(t, err, domain.ErrVerificationResendTooSoon)
+	_, err = svc.Request(ctx, "user-1", valueobjects.KeyTypeEmail, "mary@example.com")
+	assert.NoError(t, err)
+
+	// Notifier failures are reported
+	notifier.err = errors.New("smtp down")
+	_, err = svc.Request(ctx, "user-2", valueobjects.KeyTypeEmail, "john@example.com")
+	assert.ErrorContains(t, err, "smtp down")
+}
+
+func TestKeyVerificationConfig_Validate(t *testing.T) {
+	config := services.DefaultKeyVerificationConfig("short")
+	assert.Error(t, config.Validate())
+
+	config = services.DefaultKeyVerificationConfig(strings.Repeat("s", 32))
+	assert.NoError(t, config.Validate())
+
+	config.MaxAttempts = 0
+	assert.Error(t, config.Validate())
+}
+
+func TestMaskDestination(t *testing.T) {
+	assert.Equal(t, "j***@example.com", services.MaskDestination(valueobjects.KeyTypeEmail, "john@example.com"))
+	assert.Equal(t, "+55*******4321", services.MaskDestination(valueobjects.KeyTypePhone, "+5511987654321"))
+	assert.Equal(t, "***", services.MaskDestination(valueobjects.KeyTypeEmail, "invalid"))
+}
@@ -138,6 +138,42 @@ func TestAccountOwnership_Verify_EmailPhone_Success(t *testing.T) {
 	}
 }
 
+// TestAccountOwnership_ValidateCNPJ_Alphanumeric exercises the real ownership service
+// with numeric and alphanumeric CNPJ keys
+func TestAccountOwnership_ValidateCNPJ_Alphanumeric(t *testing.T) {
+	// Arrange
+	ownershipService := services.NewAccountOwnershipService(nil)
+
+	testCases := []struct {
+		name       string
+		keyValue   string
+		ownerTaxID string
+		wantErr    bool
+	}{
+		{name: "numeric match", keyValue: "11222333000181", ownerTaxID: "11222333000181"},
+		{name: "alphanumeric match", keyValue: "12ABC34501DE35", ownerTaxID: "12ABC34501DE35"},
+		{name: "alphanumeric owner with mask", keyValue: "12ABC34501DE35", ownerTaxID: "12.ABC.345/01DE-35"},
+		{name: "alphanumeric owner lowercase", keyValue: "12ABC34501DE35", ownerTaxID: "12abc34501de35"},
+		{name: "alphanumeric mismatch", keyValue: "12ABC34501DE35", ownerTaxID: "1A2B3C4D000179", wantErr: true},
+		{name: "numeric key for alphanumeric owner", keyValue: "11222333000181", ownerTaxID: "12ABC34501DE35", wantErr: true},
+		{name: "owner is a CPF", keyValue: "12ABC34501DE35", ownerTaxID: "12345678909", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			err := ownershipService.ValidateOwnership(context.Background(), services.KeyTypeCNPJ, tc.keyValue, tc.ownerTaxID)
+
+			// Assert
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
 // ===== DUPLICATE KEY CHECKER TESTS =====
 
 // Test 19: TestDuplicateChecker_ExistsLocal
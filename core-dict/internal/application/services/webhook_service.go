@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+
+	"github.com/lbpay-lab/core-dict/internal/domain"
+	"github.com/lbpay-lab/core-dict/internal/domain/entities"
+	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
+)
+
+// Webhooks para os backends (mobile, internet banking).
+//
+// Em vez de consultar ListIncomingClaims periodicamente, o backend registra um endpoint para os
+// eventos que interessam (claim.received, entry.deleted...) e recebe um POST assinado a cada evento.
+// O consumidor de webhooks (infrastructure/webhook) lê os tópicos de claims, chaves e infrações,
+// enfileira uma entrega por endpoint e o dispatcher as envia com retentativas; as que esgotam as
+// tentativas vão para a DLQ (DEAD_LETTER) e só voltam por replay.
+
+// webhookSecretPrefix identifica os segredos de assinatura de webhook
+const webhookSecretPrefix = "whsec_"
+
+// WebhookServiceConfig configura o registro de endpoints
+type WebhookServiceConfig struct {
+	// AllowInsecureURLs aceita endpoints http:// (apenas desenvolvimento)
+	AllowInsecureURLs bool
+}
+
+// RegisterWebhookEndpointInput dados do endpoint a registrar
+type RegisterWebhookEndpointInput struct {
+	URL         string
+	EventTypes  []string // Eventos do catálogo ou curinga do agregado (claim.*)
+	Description string
+	CreatedBy   string
+}
+
+// WebhookService registra endpoints e reenfileira entregas
+type WebhookService struct {
+	repo   repositories.WebhookRepository
+	config WebhookServiceConfig
+}
+
+// NewWebhookService cria nova instância
+func NewWebhookService(repo repositories.WebhookRepository, config WebhookServiceConfig) *WebhookService {
+	return &WebhookService{
+		repo:   repo,
+		config: config,
+	}
+}
+
+// RegisterEndpoint registra um endpoint com um segredo de assinatura novo. O segredo só é
+// retornado aqui (é gravado criptografado): o backend precisa guardá-lo para conferir as assinaturas.
+func (s *WebhookService) RegisterEndpoint(ctx context.Context, input RegisterWebhookEndpointInput) (*entities.WebhookEndpoint, error) {
+	if input.URL == "" {
+		return nil, domain.ErrValidation.WithMessage("url is required").WithField("url", "required")
+	}
+	if parsed, err := url.Parse(input.URL); err == nil && parsed.Scheme == "http" && !s.config.AllowInsecureURLs {
+		return nil, domain.ErrValidation.WithMessage("webhook URL must use https").WithField("url", "https required")
+	}
+
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, err := entities.NewWebhookEndpoint(input.URL, input.EventTypes, input.Description, secret, input.CreatedBy)
+	if err != nil {
+		return nil, domain.ErrValidation.WithMessage(err.Error())
+	}
+
+	if err := s.repo.CreateEndpoint(ctx, endpoint); err != nil {
+		return nil, err
+	}
+	return endpoint, nil
+}
+
+// Replay volta as entregas do filtro para a fila, com as tentativas zeradas. Sem status, reenvia
+// a DLQ (DEAD_LETTER). Reenviar entregas já DELIVERED exige os IDs ou o início do período.
+func (s *WebhookService) Replay(ctx context.Context, filter entities.WebhookReplayFilter) (int64, error) {
+	if filter.Status == "" {
+		filter.Status = entities.WebhookDeliveryDeadLetter
+	}
+	switch filter.Status {
+	case entities.WebhookDeliveryDeadLetter:
+	case entities.WebhookDeliveryDelivered:
+		if len(filter.DeliveryIDs) == 0 && filter.From == nil {
+			return 0, domain.ErrValidation.WithMessage("replaying delivered webhooks requires delivery_ids or from").
+				WithField("delivery_ids", "required")
+		}
+	default:
+		return 0, domain.ErrValidation.WithMessage("only DEAD_LETTER or DELIVERED deliveries can be replayed").
+			WithField("status", "invalid")
+	}
+	if filter.From != nil && filter.To != nil && filter.To.Before(*filter.From) {
+		return 0, domain.ErrValidation.WithMessage("to must not be before from").WithField("to", "invalid")
+	}
+
+	return s.repo.Replay(ctx, filter)
+}
+
+// generateWebhookSecret gera um segredo aleatório de 256 bits
+func generateWebhookSecret() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return webhookSecretPrefix + base64.RawURLEncoding.EncodeToString(raw), nil
+}
@@ -0,0 +1,92 @@
+package services_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lbpay-lab/core-dict/internal/application/services"
+	"github.com/lbpay-lab/core-dict/internal/domain"
+	"github.com/lbpay-lab/core-dict/internal/domain/entities"
+	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
+)
+
+type fakeWebhookRepository struct {
+	repositories.WebhookRepository
+	endpoints []*entities.WebhookEndpoint
+	replayed  *entities.WebhookReplayFilter
+}
+
+func (r *fakeWebhookRepository) CreateEndpoint(_ context.Context, endpoint *entities.WebhookEndpoint) error {
+	r.endpoints = append(r.endpoints, endpoint)
+	return nil
+}
+
+func (r *fakeWebhookRepository) Replay(_ context.Context, filter entities.WebhookReplayFilter) (int64, error) {
+	r.replayed = &filter
+	return 3, nil
+}
+
+func TestWebhookService_RegisterEndpoint(t *testing.T) {
+	repo := &fakeWebhookRepository{}
+	svc := services.NewWebhookService(repo, services.WebhookServiceConfig{})
+
+	endpoint, err := svc.RegisterEndpoint(context.Background(), services.RegisterWebhookEndpointInput{
+		URL:        "https://mobile-backend.internal/dict/webhooks",
+		EventTypes: []string{"claim.received", "entry.*", "claim.received"},
+		CreatedBy:  "admin-1",
+	})
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(endpoint.Secret, "whsec_"))
+	assert.Equal(t, []string{"claim.received", "entry.*"}, endpoint.EventTypes)
+	assert.True(t, endpoint.Active)
+	assert.True(t, endpoint.Subscribes("entry.deleted"))
+	assert.False(t, endpoint.Subscribes("claim.cancelled"))
+	require.Len(t, repo.endpoints, 1)
+}
+
+func TestWebhookService_RegisterEndpoint_Validation(t *testing.T) {
+	svc := services.NewWebhookService(&fakeWebhookRepository{}, services.WebhookServiceConfig{})
+
+	tests := map[string]services.RegisterWebhookEndpointInput{
+		"http URL":           {URL: "http://backend/webhooks", EventTypes: []string{"claim.received"}, CreatedBy: "admin-1"},
+		"relative URL":       {URL: "/webhooks", EventTypes: []string{"claim.received"}, CreatedBy: "admin-1"},
+		"no event types":     {URL: "https://backend/webhooks", CreatedBy: "admin-1"},
+		"unknown event type": {URL: "https://backend/webhooks", EventTypes: []string{"portability.created"}, CreatedBy: "admin-1"},
+	}
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := svc.RegisterEndpoint(context.Background(), input)
+			assert.ErrorIs(t, err, domain.ErrValidation)
+		})
+	}
+}
+
+func TestWebhookService_Replay(t *testing.T) {
+	repo := &fakeWebhookRepository{}
+	svc := services.NewWebhookService(repo, services.WebhookServiceConfig{})
+	endpointID := uuid.New()
+
+	replayed, err := svc.Replay(context.Background(), entities.WebhookReplayFilter{EndpointID: &endpointID})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), replayed)
+	assert.Equal(t, entities.WebhookDeliveryDeadLetter, repo.replayed.Status, "defaults to the dead letter queue")
+
+	// Delivered webhooks are only replayed with an explicit selection
+	_, err = svc.Replay(context.Background(), entities.WebhookReplayFilter{
+		EndpointID: &endpointID,
+		Status:     entities.WebhookDeliveryDelivered,
+	})
+	assert.ErrorIs(t, err, domain.ErrValidation)
+
+	from := time.Now()
+	to := from.Add(-time.Hour)
+	_, err = svc.Replay(context.Background(), entities.WebhookReplayFilter{From: &from, To: &to})
+	assert.ErrorIs(t, err, domain.ErrValidation)
+}
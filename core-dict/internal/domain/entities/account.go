@@ -44,7 +44,7 @@ type Account struct {
 
 // Owner representa o titular de uma conta
 type Owner struct {
-	TaxID       string    // CPF (11) ou CNPJ (14)
+	TaxID       string    // CPF (11) ou CNPJ (14, numérico ou alfanumérico)
 	Type        OwnerType // Tipo de titular
 	Name        string    // Nome completo
 	NameEncoded string    // Nome codificado (LGPD)
@@ -53,7 +53,7 @@ type Owner struct {
 var (
 	ispbRegex = regexp.MustCompile(`^\d{8}$`)
 	cpfRegex  = regexp.MustCompile(`^\d{11}$`)
-	cnpjRegex = regexp.MustCompile(`^\d{14}$`)
+	cnpjRegex = regexp.MustCompile(`^[0-9A-Z]{12}\d{2}$`) // CNPJ numérico ou alfanumérico
 )
 
 // NewAccount cria uma nova conta
@@ -131,7 +131,7 @@ func (o *Owner) Validate() error {
 		}
 	case OwnerTypeLegalEntity:
 		if !cnpjRegex.MatchString(o.TaxID) {
-			return errors.New("invalid CNPJ: must be 14 digits")
+			return errors.New("invalid CNPJ: must be 12 alphanumeric characters followed by 2 check digits")
 		}
 	default:
 		return errors.New("invalid owner type")
@@ -0,0 +1,197 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AccountClosureDeletionReason é o motivo Bacen enviado na exclusão das chaves de uma conta encerrada
+const AccountClosureDeletionReason = "ACCOUNT_CLOSURE"
+
+// AccountClosureTrigger indica a origem do encerramento
+type AccountClosureTrigger string
+
+const (
+	AccountClosureTriggerRPC   AccountClosureTrigger = "RPC"   // RPC CloseAccount
+	AccountClosureTriggerEvent AccountClosureTrigger = "EVENT" // Evento AccountClosed do core banking
+)
+
+// AccountClosureStatus representa o status do encerramento
+type AccountClosureStatus string
+
+const (
+	AccountClosureStatusInProgress    AccountClosureStatus = "IN_PROGRESS"    // Chaves sendo processadas pelo comando
+	AccountClosureStatusAwaitingBacen AccountClosureStatus = "AWAITING_BACEN" // Exclusões enviadas; aguardando a confirmação do Bacen
+	AccountClosureStatusCompleted     AccountClosureStatus = "COMPLETED"      // Todas as chaves excluídas no Bacen
+	AccountClosureStatusPartial       AccountClosureStatus = "PARTIAL"        // Alguma chave falhou; repetir o encerramento retoma as pendentes
+)
+
+// AccountClosureKeyOutcome é o resultado do encerramento para uma chave
+type AccountClosureKeyOutcome string
+
+const (
+	// AccountClosureKeyRequested chave excluída no core-dict e exclusão no Bacen enfileirada (outbox)
+	AccountClosureKeyRequested AccountClosureKeyOutcome = "REQUESTED"
+	// AccountClosureKeyDeleted exclusão confirmada pelo Bacen (evento do conn-dict)
+	AccountClosureKeyDeleted AccountClosureKeyOutcome = "DELETED"
+	// AccountClosureKeyFailed falha local ou exclusão rejeitada pelo Bacen
+	AccountClosureKeyFailed AccountClosureKeyOutcome = "FAILED"
+)
+
+// AccountClosure é o encerramento em cascata de uma conta: todas as chaves vinculadas são excluídas
+// (com claims e portabilidades em aberto canceladas antes) e o resultado de cada uma é registrado.
+// O resultado de uma chave só é final (DELETED ou FAILED) quando o conn-dict informa a resposta do Bacen.
+type AccountClosure struct {
+	ID          uuid.UUID
+	AccountID   uuid.UUID
+	Trigger     AccountClosureTrigger
+	RequestedBy string
+	Reason      string
+	Status      AccountClosureStatus
+	Keys        []*AccountClosureKey
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	CompletedAt *time.Time
+}
+
+// AccountClosureKey é o resultado do encerramento para uma chave da conta
+type AccountClosureKey struct {
+	EntryID                uuid.UUID
+	KeyType                KeyType
+	Outcome                AccountClosureKeyOutcome
+	ClaimsCancelled        int
+	PortabilitiesCancelled int
+	Error                  string
+	Attempts               int
+	ProcessedAt            time.Time
+}
+
+// NewAccountClosure cria um novo encerramento de conta
+func NewAccountClosure(accountID uuid.UUID, trigger AccountClosureTrigger, requestedBy, reason string) (*AccountClosure, error) {
+	if accountID == uuid.Nil {
+		return nil, errors.New("account ID cannot be nil")
+	}
+	if trigger != AccountClosureTriggerRPC && trigger != AccountClosureTriggerEvent {
+		return nil, errors.New("invalid account closure trigger")
+	}
+	if requestedBy == "" {
+		return nil, errors.New("requested by cannot be empty")
+	}
+
+	now := time.Now()
+	return &AccountClosure{
+		ID:          uuid.New(),
+		AccountID:   accountID,
+		Trigger:     trigger,
+		RequestedBy: requestedBy,
+		Reason:      reason,
+		Status:      AccountClosureStatusInProgress,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// Key retorna o resultado registrado para a chave, se houver
+func (c *AccountClosure) Key(entryID uuid.UUID) *AccountClosureKey {
+	for _, key := range c.Keys {
+		if key.EntryID == entryID {
+			return key
+		}
+	}
+	return nil
+}
+
+// NextAttempt retorna o número da próxima tentativa de exclusão da chave
+func (c *AccountClosure) NextAttempt(entryID uuid.UUID) int {
+	if previous := c.Key(entryID); previous != nil {
+		return previous.Attempts + 1
+	}
+	return 1
+}
+
+// RecordKey registra o resultado de uma chave, substituindo o de uma tentativa anterior
+func (c *AccountClosure) RecordKey(result *AccountClosureKey) {
+	if previous := c.Key(result.EntryID); previous != nil {
+		*previous = *result
+		return
+	}
+	c.Keys = append(c.Keys, result)
+}
+
+// NeedsDeletion verifica se a chave ainda precisa ser excluída: chaves excluídas antes do
+// encerramento e chaves com exclusão enviada ou confirmada pelo Bacen são ignoradas
+func (c *AccountClosure) NeedsDeletion(entry *Entry) bool {
+	if key := c.Key(entry.ID); key != nil {
+		return key.Outcome == AccountClosureKeyFailed
+	}
+	return entry.Status != KeyStatusDeleted
+}
+
+// Resume marca o encerramento como em processamento (nova execução do comando)
+func (c *AccountClosure) Resume() {
+	c.Status = AccountClosureStatusInProgress
+	c.CompletedAt = nil
+	c.UpdatedAt = time.Now()
+}
+
+// IsProcessing verifica se o comando ainda está processando as chaves; enquanto isso, as
+// confirmações do Bacen só atualizam o resultado da chave e o comando conclui o encerramento
+func (c *AccountClosure) IsProcessing() bool {
+	return c.Status == AccountClosureStatusInProgress
+}
+
+// Finish conclui o encerramento: PARTIAL se alguma chave falhou, AWAITING_BACEN enquanto houver
+// exclusão sem resposta do Bacen, senão COMPLETED
+func (c *AccountClosure) Finish() {
+	now := time.Now()
+	c.Status = AccountClosureStatusCompleted
+	for _, key := range c.Keys {
+		if key.Outcome == AccountClosureKeyFailed {
+			c.Status = AccountClosureStatusPartial
+			break
+		}
+		if key.Outcome == AccountClosureKeyRequested {
+			c.Status = AccountClosureStatusAwaitingBacen
+		}
+	}
+	c.CompletedAt = nil
+	if c.Status == AccountClosureStatusCompleted {
+		c.CompletedAt = &now
+	}
+	c.UpdatedAt = now
+}
+
+// ConfirmKey registra a resposta do Bacen para uma chave com exclusão enviada: DELETED ou, se
+// bacenErr não for vazio, FAILED. Retorna false se a chave não aguarda resposta (reentrega).
+func (c *AccountClosure) ConfirmKey(entryID uuid.UUID, bacenErr string) bool {
+	key := c.Key(entryID)
+	if key == nil || key.Outcome != AccountClosureKeyRequested {
+		return false
+	}
+	key.Outcome = AccountClosureKeyDeleted
+	key.Error = ""
+	if bacenErr != "" {
+		key.Outcome = AccountClosureKeyFailed
+		key.Error = bacenErr
+	}
+	key.ProcessedAt = time.Now()
+	return true
+}
+
+// IsCompleted verifica se todas as chaves da conta já foram excluídas no Bacen
+func (c *AccountClosure) IsCompleted() bool {
+	return c.Status == AccountClosureStatusCompleted
+}
+
+// Count conta as chaves com o resultado informado
+func (c *AccountClosure) Count(outcome AccountClosureKeyOutcome) int {
+	count := 0
+	for _, key := range c.Keys {
+		if key.Outcome == outcome {
+			count++
+		}
+	}
+	return count
+}
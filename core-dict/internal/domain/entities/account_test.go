@@ -37,6 +37,40 @@ func TestNewAccount_Success(t *testing.T) {
 	assert.Equal(t, owner.TaxID, account.Owner.TaxID)
 }
 
+func TestNewAccount_LegalEntityOwner(t *testing.T) {
+	tests := []struct {
+		name      string
+		taxID     string
+		wantError bool
+	}{
+		{name: "Numeric CNPJ", taxID: "11222333000181", wantError: false},
+		{name: "Alphanumeric CNPJ", taxID: "12ABC34501DE35", wantError: false},
+		{name: "Lowercase alphanumeric CNPJ", taxID: "12abc34501de35", wantError: true},
+		{name: "Letter in check digits", taxID: "12ABC34501DE3A", wantError: true},
+		{name: "CPF as legal entity", taxID: "12345678909", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owner := entities.Owner{
+				TaxID: tt.taxID,
+				Type:  entities.OwnerTypeLegalEntity,
+				Name:  "Empresa Exemplo LTDA",
+			}
+
+			account, err := entities.NewAccount("12345678", "0001", "123456", entities.AccountTypeCACC, owner)
+
+			if tt.wantError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "invalid CNPJ")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.taxID, account.Owner.TaxID)
+		})
+	}
+}
+
 func TestAccount_Validate_Success(t *testing.T) {
 	// Arrange
 	owner := entities.Owner{
@@ -0,0 +1,104 @@
+package entities
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GenesisChainHash é o prev_hash do primeiro evento de cada cadeia
+var GenesisChainHash = strings.Repeat("0", 64)
+
+// AuditChainPartitionLayout é o formato do mês (UTC) que identifica a cadeia
+const AuditChainPartitionLayout = "2006-01"
+
+// AuditChainPartition retorna a partição (mês UTC) da cadeia de um instante
+func AuditChainPartition(t time.Time) string {
+	return t.UTC().Format(AuditChainPartitionLayout)
+}
+
+// AuditChainHead é o último elo de uma cadeia (partição + stream)
+type AuditChainHead struct {
+	Partition string
+	Stream    string
+	LastSeq   int64
+	LastHash  string
+	UpdatedAt time.Time
+}
+
+// AuditChainLink é um evento de auditoria na representação textual canônica usada no hash.
+// Campos nulos são strings vazias; OccurredAt está em UTC com microssegundos.
+type AuditChainLink struct {
+	Partition    string
+	Stream       string
+	Seq          int64
+	PrevHash     string
+	Hash         string
+	EventID      string
+	EntityType   string
+	EntityID     string
+	EventType    string
+	EventSubtype string
+	UserID       string
+	OldValues    string
+	NewValues    string
+	Diff         string
+	Metadata     string
+	IPAddress    string
+	UserAgent    string
+	OccurredAt   string
+}
+
+// ComputeHash recalcula o hash do elo, independente do valor gravado pelo banco.
+// Deve seguir exatamente audit.event_hash() (migration 014).
+func (l *AuditChainLink) ComputeHash() string {
+	var payload strings.Builder
+	for _, field := range []string{
+		l.Partition,
+		l.Stream,
+		strconv.FormatInt(l.Seq, 10),
+		l.PrevHash,
+		l.EventID,
+		l.EntityType,
+		l.EntityID,
+		l.EventType,
+		l.EventSubtype,
+		l.UserID,
+		l.OldValues,
+		l.NewValues,
+		l.Diff,
+		l.Metadata,
+		l.IPAddress,
+		l.UserAgent,
+		l.OccurredAt,
+	} {
+		payload.WriteString(strconv.Itoa(len(field)))
+		payload.WriteByte(':')
+		payload.WriteString(field)
+	}
+	sum := sha256.Sum256([]byte(payload.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// AuditCheckpoint é a assinatura Ed25519 de um elo da cadeia, feita pela aplicação
+// com uma chave que o banco não possui
+type AuditCheckpoint struct {
+	Partition string
+	Stream    string
+	Seq       int64
+	Hash      string
+	KeyID     string
+	Signature string // Base64 da assinatura de SigningPayload
+	CreatedAt time.Time
+}
+
+// SigningPayload retorna a mensagem assinada no checkpoint
+func (c *AuditCheckpoint) SigningPayload() []byte {
+	return []byte("lb-dict/audit-checkpoint/v1\n" +
+		c.Partition + "\n" +
+		c.Stream + "\n" +
+		strconv.FormatInt(c.Seq, 10) + "\n" +
+		c.Hash)
+}
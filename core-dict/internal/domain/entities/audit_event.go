@@ -27,6 +27,8 @@ const (
 	EventTypeSyncStarted      EventType = "SYNC_STARTED"
 	EventTypeSyncCompleted    EventType = "SYNC_COMPLETED"
 	EventTypeSyncFailed       EventType = "SYNC_FAILED"
+	EventTypeDataSubjectExported EventType = "DATA_SUBJECT_EXPORTED"
+	EventTypeDataSubjectErased   EventType = "DATA_SUBJECT_ERASED"
 )
 
 // EntityType representa o tipo de entidade auditada
@@ -38,6 +40,7 @@ const (
 	EntityTypeClaim       EntityType = "CLAIM"
 	EntityTypePortability EntityType = "PORTABILITY"
 	EntityTypeInfraction  EntityType = "INFRACTION"
+	EntityTypeDataSubjectRequest EntityType = "DATA_SUBJECT_REQUEST" // Pedido do titular (LGPD)
 )
 
 // AuditEvent representa um evento de auditoria no sistema
@@ -55,6 +58,11 @@ type AuditEvent struct {
 	UserAgent    string
 	OccurredAt   time.Time
 	Metadata     map[string]interface{}
+
+	// Elo na cadeia de hashes, preenchido pelo banco ao gravar (migration 014)
+	ChainSeq int64
+	PrevHash string
+	Hash     string
 }
 
 // NewAuditEvent cria um novo evento de auditoria
@@ -139,6 +147,8 @@ func validateEventType(et EventType) error {
 		EventTypeSyncStarted:        true,
 		EventTypeSyncCompleted:      true,
 		EventTypeSyncFailed:         true,
+		EventTypeDataSubjectExported: true,
+		EventTypeDataSubjectErased:   true,
 	}
 	if !validEventTypes[et] {
 		return errors.New("invalid event type")
@@ -153,6 +163,7 @@ func validateEntityType(et EntityType) error {
 		EntityTypeClaim:       true,
 		EntityTypePortability: true,
 		EntityTypeInfraction:  true,
+		EntityTypeDataSubjectRequest: true,
 	}
 	if !validEntityTypes[et] {
 		return errors.New("invalid entity type")
@@ -0,0 +1,43 @@
+package entities
+
+// BlockReason representa o motivo do bloqueio de uma chave PIX
+type BlockReason string
+
+const (
+	BlockReasonJudicialOrder  BlockReason = "JUDICIAL_ORDER"  // Ordem judicial
+	BlockReasonFraudSuspicion BlockReason = "FRAUD_SUSPICION" // Suspeita de fraude
+	BlockReasonInfraction     BlockReason = "INFRACTION"      // Notificação de infração (MED)
+	BlockReasonRegulatory     BlockReason = "REGULATORY"      // Determinação regulatória (Bacen)
+	BlockReasonOther          BlockReason = "OTHER"           // Outro (exige descrição)
+)
+
+// IsValid verifica se o motivo de bloqueio é válido
+func (r BlockReason) IsValid() bool {
+	switch r {
+	case BlockReasonJudicialOrder, BlockReasonFraudSuspicion, BlockReasonInfraction,
+		BlockReasonRegulatory, BlockReasonOther:
+		return true
+	}
+	return false
+}
+
+// UnblockReason representa o motivo do desbloqueio de uma chave PIX
+type UnblockReason string
+
+const (
+	UnblockReasonJudicialRelease  UnblockReason = "JUDICIAL_RELEASE"  // Revogação da ordem judicial
+	UnblockReasonFraudDismissed   UnblockReason = "FRAUD_DISMISSED"   // Suspeita de fraude descartada
+	UnblockReasonInfractionClosed UnblockReason = "INFRACTION_CLOSED" // Infração encerrada/improcedente
+	UnblockReasonRegulatory       UnblockReason = "REGULATORY"        // Determinação regulatória (Bacen)
+	UnblockReasonOther            UnblockReason = "OTHER"             // Outro (exige descrição)
+)
+
+// IsValid verifica se o motivo de desbloqueio é válido
+func (r UnblockReason) IsValid() bool {
+	switch r {
+	case UnblockReasonJudicialRelease, UnblockReasonFraudDismissed, UnblockReasonInfractionClosed,
+		UnblockReasonRegulatory, UnblockReasonOther:
+		return true
+	}
+	return false
+}
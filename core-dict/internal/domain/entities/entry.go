@@ -64,18 +64,30 @@ type AuditLog struct {
 
 // Statistics representa estatísticas agregadas do sistema
 type Statistics struct {
-	TotalKeys             int64            `json:"total_keys"`
-	ActiveKeys            int64            `json:"active_keys"`
-	BlockedKeys           int64            `json:"blocked_keys"`
-	DeletedKeys           int64            `json:"deleted_keys"`
-	TotalClaims           int64            `json:"total_claims"`
-	PendingClaims         int64            `json:"pending_claims"`
-	CompletedClaims       int64            `json:"completed_claims"`
-	KeysByType            map[string]int64 `json:"keys_by_type"`
-	ClaimsByType          map[string]int64 `json:"claims_by_type"`
-	TotalInfractions      int64            `json:"total_infractions"`
-	InfractionsBySeverity map[string]int64 `json:"infractions_by_severity"`
-	LastUpdated           time.Time        `json:"last_updated"`
+	TotalKeys        int64                   `json:"total_keys"`
+	ActiveKeys       int64                   `json:"active_keys"`
+	BlockedKeys      int64                   `json:"blocked_keys"`
+	DeletedKeys      int64                   `json:"deleted_keys"`
+	TotalClaims      int64                   `json:"total_claims"`
+	PendingClaims    int64                   `json:"pending_claims"`
+	CompletedClaims  int64                   `json:"completed_claims"`
+	KeysByType       map[string]int64        `json:"keys_by_type"`
+	ClaimsByType     map[string]int64        `json:"claims_by_type"`
+	TotalInfractions int64                   `json:"total_infractions"`
+	Breakdown        []ParticipantStatistics `json:"breakdown,omitempty"`
+	LastUpdated      time.Time               `json:"last_updated"`
+}
+
+// ParticipantStatistics representa os contadores de um participante (ISPB ou ISPB+agência)
+type ParticipantStatistics struct {
+	ISPB        string           `json:"ispb"`
+	Branch      string           `json:"branch,omitempty"`
+	TotalKeys   int64            `json:"total_keys"`
+	ActiveKeys  int64            `json:"active_keys"`
+	BlockedKeys int64            `json:"blocked_keys"`
+	DeletedKeys int64            `json:"deleted_keys"`
+	KeysByType  map[string]int64 `json:"keys_by_type"`
+	TotalClaims int64            `json:"total_claims"` // Claims como doador (apenas na quebra por ISPB)
 }
 
 // HealthStatus representa o status de saúde do sistema
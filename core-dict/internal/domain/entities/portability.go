@@ -24,6 +24,7 @@ const (
 // Portability representa uma portabilidade de chave PIX entre contas
 type Portability struct {
 	ID                    uuid.UUID
+	EntryID               uuid.UUID
 	EntryKey              string
 	OriginParticipant     valueobjects.Participant
 	DestinationParticipant valueobjects.Participant
@@ -0,0 +1,236 @@
+package entities
+
+import (
+	"errors"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// WebhookDeliveryStatus representa o status de uma entrega de webhook
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending    WebhookDeliveryStatus = "PENDING"     // Aguardando a próxima tentativa
+	WebhookDeliveryDelivered  WebhookDeliveryStatus = "DELIVERED"   // Endpoint respondeu 2xx
+	WebhookDeliveryDeadLetter WebhookDeliveryStatus = "DEAD_LETTER" // Tentativas esgotadas (DLQ); reenviada apenas por replay
+)
+
+// webhookAggregates são os agregados cujos eventos podem ser assinados
+var webhookAggregates = map[string]struct{}{
+	"claim":      {},
+	"entry":      {},
+	"infraction": {},
+}
+
+// webhookEventTypes é o catálogo de eventos notificados por webhook. Os nomes são normalizados
+// (agregado.ação), independentemente da origem: ClaimCancelled (core-dict) e claim_cancelled
+// (conn-dict) são ambos claim.cancelled.
+var webhookEventTypes = map[string]struct{}{
+	"claim.created":                  {},
+	"claim.received":                 {},
+	"claim.confirmed":                {},
+	"claim.cancelled":                {},
+	"claim.completed":                {},
+	"claim.expired":                  {},
+	"claim.status_changed":           {},
+	"entry.created":                  {},
+	"entry.updated":                  {},
+	"entry.deleted":                  {},
+	"entry.blocked":                  {},
+	"entry.unblocked":                {},
+	"entry.activated":                {},
+	"entry.deactivated":              {},
+	"infraction.created":             {},
+	"infraction.under_investigation": {},
+	"infraction.resolved":            {},
+	"infraction.dismissed":           {},
+	"infraction.escalated":           {},
+}
+
+// WebhookEventTypes retorna o catálogo de eventos em ordem alfabética
+func WebhookEventTypes() []string {
+	types := make([]string, 0, len(webhookEventTypes))
+	for eventType := range webhookEventTypes {
+		types = append(types, eventType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// NormalizeWebhookEventType converte o tipo de evento publicado no Pulsar (PascalCase do outbox
+// ou snake_case do conn-dict) para o nome do catálogo. ok é false para eventos fora do catálogo.
+func NormalizeWebhookEventType(raw string) (string, bool) {
+	snake := raw
+	if !strings.Contains(raw, "_") {
+		var b strings.Builder
+		for i, r := range raw {
+			if unicode.IsUpper(r) && i > 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		}
+		snake = b.String()
+	}
+	snake = strings.ToLower(snake)
+
+	aggregate, action, found := strings.Cut(snake, "_")
+	if !found {
+		return "", false
+	}
+	eventType := aggregate + "." + action
+	if _, ok := webhookEventTypes[eventType]; !ok {
+		return "", false
+	}
+	return eventType, true
+}
+
+// IsValidWebhookSubscription verifica se o tipo pode ser assinado: um evento do catálogo ou
+// todos os eventos de um agregado (claim.*)
+func IsValidWebhookSubscription(eventType string) bool {
+	if aggregate, found := strings.CutSuffix(eventType, ".*"); found {
+		_, ok := webhookAggregates[aggregate]
+		return ok
+	}
+	_, ok := webhookEventTypes[eventType]
+	return ok
+}
+
+// WebhookEndpoint é um endpoint de um backend (mobile, internet banking) notificado dos eventos
+// assinados. As chamadas são assinadas com HMAC-SHA256 usando Secret.
+type WebhookEndpoint struct {
+	ID          uuid.UUID
+	URL         string
+	EventTypes  []string
+	Description string
+	Secret      string
+	Active      bool
+	CreatedBy   string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// NewWebhookEndpoint cria um novo endpoint ativo
+func NewWebhookEndpoint(rawURL string, eventTypes []string, description, secret, createdBy string) (*WebhookEndpoint, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "https" && parsed.Scheme != "http") {
+		return nil, errors.New("webhook URL must be an absolute http(s) URL")
+	}
+	if len(eventTypes) == 0 {
+		return nil, errors.New("at least one event type is required")
+	}
+
+	seen := make(map[string]struct{}, len(eventTypes))
+	types := make([]string, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		if !IsValidWebhookSubscription(eventType) {
+			return nil, errors.New("unknown webhook event type: " + eventType)
+		}
+		if _, dup := seen[eventType]; dup {
+			continue
+		}
+		seen[eventType] = struct{}{}
+		types = append(types, eventType)
+	}
+	if secret == "" {
+		return nil, errors.New("webhook secret cannot be empty")
+	}
+	if createdBy == "" {
+		return nil, errors.New("created by cannot be empty")
+	}
+
+	now := time.Now()
+	return &WebhookEndpoint{
+		ID:          uuid.New(),
+		URL:         rawURL,
+		EventTypes:  types,
+		Description: description,
+		Secret:      secret,
+		Active:      true,
+		CreatedBy:   createdBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// Subscribes verifica se o endpoint assina o evento (diretamente ou pelo curinga do agregado)
+func (e *WebhookEndpoint) Subscribes(eventType string) bool {
+	aggregate, _, _ := strings.Cut(eventType, ".")
+	for _, subscribed := range e.EventTypes {
+		if subscribed == eventType || subscribed == aggregate+".*" {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookEvent é um evento do Pulsar a ser entregue aos endpoints que o assinam
+type WebhookEvent struct {
+	EventID     string // event_id do outbox ou ID da mensagem Pulsar; deduplica reentregas
+	EventType   string // Nome do catálogo (claim.created)
+	AggregateID string
+	OccurredAt  time.Time
+	Payload     []byte // JSON original do evento
+}
+
+// WebhookDelivery é a entrega de um evento a um endpoint
+type WebhookDelivery struct {
+	ID             uuid.UUID
+	EndpointID     uuid.UUID
+	Endpoint       *WebhookEndpoint // Carregado para o envio (URL e segredo)
+	EventID        string
+	EventType      string
+	AggregateID    string
+	Payload        []byte
+	OccurredAt     time.Time
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastStatusCode int // 0 quando não houve resposta HTTP
+	LastError      string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeliveredAt    *time.Time
+}
+
+// MarkDelivered registra uma tentativa bem-sucedida
+func (d *WebhookDelivery) MarkDelivered(statusCode int, at time.Time) {
+	d.Attempts++
+	d.Status = WebhookDeliveryDelivered
+	d.LastStatusCode = statusCode
+	d.LastError = ""
+	d.DeliveredAt = &at
+	d.UpdatedAt = at
+}
+
+// MarkFailed registra uma tentativa com falha e agenda a próxima
+func (d *WebhookDelivery) MarkFailed(statusCode int, cause string, nextAttemptAt, at time.Time) {
+	d.Attempts++
+	d.Status = WebhookDeliveryPending
+	d.LastStatusCode = statusCode
+	d.LastError = cause
+	d.NextAttemptAt = nextAttemptAt
+	d.UpdatedAt = at
+}
+
+// MarkDeadLetter registra a última tentativa com falha e move a entrega para a DLQ
+func (d *WebhookDelivery) MarkDeadLetter(statusCode int, cause string, at time.Time) {
+	d.Attempts++
+	d.Status = WebhookDeliveryDeadLetter
+	d.LastStatusCode = statusCode
+	d.LastError = cause
+	d.UpdatedAt = at
+}
+
+// WebhookReplayFilter seleciona as entregas reenfileiradas por um replay
+type WebhookReplayFilter struct {
+	DeliveryIDs []uuid.UUID
+	EndpointID  *uuid.UUID
+	Status      WebhookDeliveryStatus // DEAD_LETTER ou DELIVERED
+	From        *time.Time            // created_at da entrega
+	To          *time.Time
+}
@@ -1,27 +1,250 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
+
+// ErrorKind classifica um erro de domínio (a camada de infraestrutura traduz para o status gRPC)
+type ErrorKind int
+
+const (
+	KindInternal ErrorKind = iota
+	KindInvalidArgument
+	KindNotFound
+	KindAlreadyExists
+	KindPermissionDenied
+	KindFailedPrecondition
+	KindResourceExhausted
+	KindDeadlineExceeded
+	KindAborted
+)
+
+// ErrorCode é o código estável do erro, exposto aos clientes (google.rpc.ErrorInfo.reason)
+type ErrorCode string
+
+const (
+	// Chaves
+	CodeInvalidKeyType             ErrorCode = "INVALID_KEY_TYPE"
+	CodeInvalidKeyValue            ErrorCode = "INVALID_KEY_VALUE"
+	CodeKeyAlreadyRegistered       ErrorCode = "KEY_ALREADY_REGISTERED"
+	CodeKeyOwnedByOtherParticipant ErrorCode = "KEY_OWNED_BY_OTHER_PARTICIPANT"
+	CodeKeyOwnershipMismatch       ErrorCode = "KEY_OWNERSHIP_MISMATCH"
+	CodeEntryNotFound              ErrorCode = "ENTRY_NOT_FOUND"
+	CodeInvalidEntryStatus         ErrorCode = "INVALID_ENTRY_STATUS"
+	CodeEntryLimitExceeded         ErrorCode = "ENTRY_LIMIT_EXCEEDED"
+	CodeEntryLockedByClaim         ErrorCode = "ENTRY_LOCKED_BY_CLAIM"
+
+	// Reivindicações
+	CodeInvalidClaim       ErrorCode = "INVALID_CLAIM"
+	CodeClaimExpired       ErrorCode = "CLAIM_EXPIRED"
+	CodeClaimNotFound      ErrorCode = "CLAIM_NOT_FOUND"
+	CodeClaimAlreadyExists ErrorCode = "CLAIM_ALREADY_EXISTS"
+	CodeInvalidClaimStatus ErrorCode = "INVALID_CLAIM_STATUS"
+	CodeNotOwner           ErrorCode = "NOT_OWNER"
+
+	// Portabilidades
+	CodePortabilityNotFound      ErrorCode = "PORTABILITY_NOT_FOUND"
+	CodeInvalidPortabilityStatus ErrorCode = "INVALID_PORTABILITY_STATUS"
+
+	// Infrações
+	CodeInfractionNotFound ErrorCode = "INFRACTION_NOT_FOUND"
 
-// Domain-level errors
+	// Contas e participantes
+	CodeInvalidAccount     ErrorCode = "INVALID_ACCOUNT"
+	CodeAccountNotFound    ErrorCode = "ACCOUNT_NOT_FOUND"
+	CodeInvalidParticipant ErrorCode = "INVALID_PARTICIPANT"
+
+	// Autorização
+	CodeUnauthorized      ErrorCode = "UNAUTHORIZED"
+	CodeTwoFactorRequired ErrorCode = "TWO_FACTOR_REQUIRED"
+
+	// Verificação de posse (EMAIL/PHONE)
+	CodeKeyVerificationRequired      ErrorCode = "KEY_VERIFICATION_REQUIRED"
+	CodeVerificationNotFound         ErrorCode = "VERIFICATION_NOT_FOUND"
+	CodeVerificationCodeInvalid      ErrorCode = "VERIFICATION_CODE_INVALID"
+	CodeVerificationAttemptsExceeded ErrorCode = "VERIFICATION_ATTEMPTS_EXCEEDED"
+	CodeVerificationResendTooSoon    ErrorCode = "VERIFICATION_RESEND_TOO_SOON"
+
+	// Genéricos
+	CodeValidationFailed ErrorCode = "VALIDATION_FAILED"
+	CodeBacenRejected    ErrorCode = "BACEN_REJECTED"
+)
+
+// Catálogo de erros de domínio.
+// Os sentinelas são comparados por código: errors.Is(err, ErrEntryNotFound) é verdadeiro
+// para qualquer *Error com CodeEntryNotFound, inclusive os criados com WithMessage/Wrap.
 var (
 	// Key errors
-	ErrInvalidKeyType  = errors.New("invalid key type")
-	ErrInvalidKeyValue = errors.New("invalid key value")
-	ErrDuplicateKey    = errors.New("duplicate key")
-	ErrEntryNotFound   = errors.New("entry not found")
-	ErrInvalidStatus   = errors.New("invalid status")
-	ErrMaxKeysExceeded = errors.New("maximum number of keys exceeded")
+	ErrInvalidKeyType       = newError(KindInvalidArgument, CodeInvalidKeyType, "invalid key type", "")
+	ErrInvalidKeyValue      = newError(KindInvalidArgument, CodeInvalidKeyValue, "invalid key value", "")
+	ErrDuplicateKey         = newError(KindAlreadyExists, CodeKeyAlreadyRegistered, "duplicate key", "EntryAlreadyExists")
+	ErrDuplicateKeyGlobal   = newError(KindAlreadyExists, CodeKeyOwnedByOtherParticipant, "key already registered in RSFN DICT", "EntryKeyOwnedByDifferentParticipant")
+	ErrKeyOwnershipMismatch = newError(KindInvalidArgument, CodeKeyOwnershipMismatch, "ownership validation failed", "EntryKeyDoesNotMatchOwner")
+	ErrEntryNotFound        = newError(KindNotFound, CodeEntryNotFound, "entry not found", "EntryNotFound")
+	ErrInvalidStatus        = newError(KindInvalidArgument, CodeInvalidEntryStatus, "invalid status", "")
+	ErrMaxKeysExceeded      = newError(KindResourceExhausted, CodeEntryLimitExceeded, "maximum number of keys exceeded", "EntryLimitExceeded")
+	ErrEntryLockedByClaim   = newError(KindFailedPrecondition, CodeEntryLockedByClaim, "entry is locked by an open claim", "EntryLockedByClaim")
 
 	// Claim errors
-	ErrInvalidClaim = errors.New("invalid claim")
-	ErrClaimExpired = errors.New("claim expired")
+	ErrInvalidClaim       = newError(KindInvalidArgument, CodeInvalidClaim, "invalid claim", "")
+	ErrClaimExpired       = newError(KindFailedPrecondition, CodeClaimExpired, "claim expired", "")
+	ErrClaimNotFound      = newError(KindNotFound, CodeClaimNotFound, "claim not found", "ClaimNotFound")
+	ErrClaimAlreadyExists = newError(KindFailedPrecondition, CodeClaimAlreadyExists, "active claim already exists for this key", "ClaimAlreadyOpen")
+	ErrInvalidClaimStatus = newError(KindFailedPrecondition, CodeInvalidClaimStatus, "invalid claim status", "InvalidClaimStatus")
+	ErrNotOwner           = newError(KindPermissionDenied, CodeNotOwner, "not the owner of this resource", "")
+
+	// Portability errors
+	ErrPortabilityNotFound      = newError(KindNotFound, CodePortabilityNotFound, "portability not found", "")
+	ErrInvalidPortabilityStatus = newError(KindFailedPrecondition, CodeInvalidPortabilityStatus, "invalid portability status", "")
+
+	// Infraction errors
+	ErrInfractionNotFound = newError(KindNotFound, CodeInfractionNotFound, "infraction not found", "InfractionReportNotFound")
 
 	// Account errors
-	ErrInvalidAccount = errors.New("invalid account")
+	ErrInvalidAccount  = newError(KindInvalidArgument, CodeInvalidAccount, "invalid account", "")
+	ErrAccountNotFound = newError(KindNotFound, CodeAccountNotFound, "account not found", "")
 
 	// Participant errors
-	ErrInvalidParticipant = errors.New("invalid participant")
+	ErrInvalidParticipant = newError(KindInvalidArgument, CodeInvalidParticipant, "invalid participant", "")
 
 	// Authorization errors
-	ErrUnauthorized = errors.New("unauthorized")
+	ErrUnauthorized      = newError(KindPermissionDenied, CodeUnauthorized, "unauthorized", "Forbidden")
+	ErrTwoFactorRequired = newError(KindInvalidArgument, CodeTwoFactorRequired, "2FA code required", "")
+
+	// Key verification errors
+	ErrKeyVerificationRequired      = newError(KindFailedPrecondition, CodeKeyVerificationRequired, "key ownership verification required", "")
+	ErrVerificationNotFound         = newError(KindNotFound, CodeVerificationNotFound, "verification not found or expired", "")
+	ErrVerificationCodeInvalid      = newError(KindInvalidArgument, CodeVerificationCodeInvalid, "invalid verification code", "")
+	ErrVerificationAttemptsExceeded = newError(KindResourceExhausted, CodeVerificationAttemptsExceeded, "too many invalid verification attempts", "")
+	ErrVerificationResendTooSoon    = newError(KindResourceExhausted, CodeVerificationResendTooSoon, "verification code requested too recently", "")
+
+	// Generic errors
+	ErrValidation    = newError(KindInvalidArgument, CodeValidationFailed, "validation failed", "InvalidRequest")
+	ErrBacenRejected = newError(KindFailedPrecondition, CodeBacenRejected, "request rejected by Bacen DICT", "")
 )
+
+// catalog lista os erros com código de motivo do Bacen, usados por FromBacenRejection
+var catalog = []*Error{
+	ErrDuplicateKey, ErrDuplicateKeyGlobal, ErrKeyOwnershipMismatch, ErrEntryNotFound,
+	ErrMaxKeysExceeded, ErrEntryLockedByClaim, ErrClaimNotFound, ErrClaimAlreadyExists,
+	ErrInvalidClaimStatus, ErrInfractionNotFound, ErrUnauthorized, ErrValidation,
+}
+
+// FieldViolation descreve um campo inválido da requisição (google.rpc.BadRequest)
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// BacenRejection é a resposta de erro do Bacen que originou o erro (recebida via Connect/Bridge)
+type BacenRejection struct {
+	Code       string // Código de motivo do Bacen (e.g., "EntryAlreadyExists")
+	Message    string
+	RequestID  string
+	HTTPStatus int
+	OccurredAt time.Time
+}
+
+// Error é o erro de domínio tipado.
+// Carrega um código estável, o código de motivo do Bacen quando existir e os campos inválidos.
+type Error struct {
+	Kind       ErrorKind
+	Code       ErrorCode
+	Message    string
+	BacenCode  string            // Código de motivo do Bacen equivalente (vazio se não houver)
+	Violations []FieldViolation  // Campos inválidos
+	Metadata   map[string]string // Contexto adicional (IDs, limites...)
+	Bacen      *BacenRejection   // Rejeição do Bacen que originou o erro, se houver
+	cause      error
+}
+
+func newError(kind ErrorKind, code ErrorCode, message, bacenCode string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message, BacenCode: bacenCode}
+}
+
+// Error implementa a interface error
+func (e *Error) Error() string {
+	if e.cause != nil {
+		return e.Message + ": " + e.cause.Error()
+	}
+	return e.Message
+}
+
+// Unwrap retorna a causa original
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Is compara erros de domínio pelo código
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// WithMessage retorna uma cópia do erro com outra mensagem
+func (e *Error) WithMessage(message string) *Error {
+	c := e.clone()
+	c.Message = message
+	return c
+}
+
+// WithField retorna uma cópia do erro com mais um campo inválido
+func (e *Error) WithField(field, description string) *Error {
+	c := e.clone()
+	c.Violations = append(c.Violations, FieldViolation{Field: field, Description: description})
+	return c
+}
+
+// WithMetadata retorna uma cópia do erro com mais um item de contexto
+func (e *Error) WithMetadata(key, value string) *Error {
+	c := e.clone()
+	c.Metadata[key] = value
+	return c
+}
+
+// Wrap retorna uma cópia do erro com a causa original (mantida em Unwrap)
+func (e *Error) Wrap(cause error) *Error {
+	c := e.clone()
+	c.cause = cause
+	return c
+}
+
+func (e *Error) clone() *Error {
+	c := *e
+	c.Violations = append([]FieldViolation(nil), e.Violations...)
+	c.Metadata = make(map[string]string, len(e.Metadata)+1)
+	for k, v := range e.Metadata {
+		c.Metadata[k] = v
+	}
+	return &c
+}
+
+// AsError extrai o *Error da cadeia de erros
+func AsError(err error) (*Error, bool) {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr, true
+	}
+	return nil, false
+}
+
+// FromBacenRejection traduz uma rejeição do Bacen para o erro do catálogo com o mesmo
+// código de motivo (ErrBacenRejected quando o código não é conhecido)
+func FromBacenRejection(rejection BacenRejection) *Error {
+	base := ErrBacenRejected
+	for _, e := range catalog {
+		if e.BacenCode == rejection.Code {
+			base = e
+			break
+		}
+	}
+
+	err := base.clone()
+	err.BacenCode = rejection.Code
+	err.Bacen = &rejection
+	if base == ErrBacenRejected && rejection.Message != "" {
+		err.Message = base.Message + ": " + rejection.Message
+	}
+	return err
+}
@@ -2,6 +2,7 @@ package domain_test
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -149,6 +150,8 @@ func TestErrClaimExpired(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.Equal(t, "claim expired", err.Error())
 	assert.True(t, errors.Is(err, domain.ErrClaimExpired))
+	// An expired claim can't move anymore: the request isn't retryable
+	assert.Equal(t, domain.KindFailedPrecondition, err.Kind)
 }
 
 func TestErrUnauthorized(t *testing.T) {
@@ -190,3 +193,68 @@ func TestErrInvalidParticipant(t *testing.T) {
 	assert.Equal(t, "invalid participant", err.Error())
 	assert.True(t, errors.Is(err, domain.ErrInvalidParticipant))
 }
+
+func TestError_IsMatchesByCode(t *testing.T) {
+	// Act
+	err := domain.ErrEntryNotFound.WithMessage("entry not found for key").WithMetadata("entry_id", "e-1")
+
+	// Assert
+	assert.True(t, errors.Is(err, domain.ErrEntryNotFound))
+	assert.False(t, errors.Is(err, domain.ErrClaimNotFound))
+	assert.Equal(t, "entry not found for key", err.Error())
+	assert.Empty(t, domain.ErrEntryNotFound.Metadata, "sentinels must not be modified")
+	assert.Equal(t, "entry not found", domain.ErrEntryNotFound.Error())
+}
+
+func TestError_WrapAndViolations(t *testing.T) {
+	// Arrange
+	cause := errors.New("max 5 keys for CPF")
+
+	// Act
+	err := domain.ErrMaxKeysExceeded.WithMessage("key limit exceeded").Wrap(cause)
+	validation := domain.ErrValidation.WithField("reason", "required").WithField("blocked_by", "required")
+
+	// Assert
+	assert.Equal(t, "key limit exceeded: max 5 keys for CPF", err.Error())
+	assert.True(t, errors.Is(err, cause))
+	assert.True(t, errors.Is(err, domain.ErrMaxKeysExceeded))
+	assert.Len(t, validation.Violations, 2)
+	assert.Empty(t, domain.ErrValidation.Violations)
+
+	domainErr, ok := domain.AsError(fmt.Errorf("create entry: %w", err))
+	assert.True(t, ok)
+	assert.Equal(t, domain.CodeEntryLimitExceeded, domainErr.Code)
+	assert.Equal(t, "EntryLimitExceeded", domainErr.BacenCode)
+}
+
+func TestFromBacenRejection(t *testing.T) {
+	tests := []struct {
+		name      string
+		bacenCode string
+		expected  *domain.Error
+	}{
+		{"entry_already_exists", "EntryAlreadyExists", domain.ErrDuplicateKey},
+		{"owned_by_other_participant", "EntryKeyOwnedByDifferentParticipant", domain.ErrDuplicateKeyGlobal},
+		{"claim_already_open", "ClaimAlreadyOpen", domain.ErrClaimAlreadyExists},
+		{"locked_by_claim", "EntryLockedByClaim", domain.ErrEntryLockedByClaim},
+		{"unknown_code", "SomethingNew", domain.ErrBacenRejected},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := domain.FromBacenRejection(domain.BacenRejection{
+				Code:       tt.bacenCode,
+				Message:    "rejected",
+				RequestID:  "req-1",
+				HTTPStatus: 409,
+			})
+
+			// Assert
+			assert.True(t, errors.Is(err, tt.expected))
+			assert.Equal(t, tt.expected.Kind, err.Kind)
+			assert.Equal(t, tt.bacenCode, err.BacenCode)
+			assert.Equal(t, "req-1", err.Bacen.RequestID)
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lbpay-lab/core-dict/internal/domain/entities"
+)
+
+// AccountClosureRepository define as operações de persistência do encerramento de contas
+type AccountClosureRepository interface {
+	// Create grava um novo encerramento (um por conta)
+	Create(ctx context.Context, closure *entities.AccountClosure) error
+
+	// Update atualiza o status do encerramento
+	Update(ctx context.Context, closure *entities.AccountClosure) error
+
+	// FindByAccountID busca o encerramento da conta com o resultado de cada chave
+	// (nil, nil se a conta ainda não foi encerrada). Em uma transação, bloqueia o encerramento até o commit.
+	FindByAccountID(ctx context.Context, accountID uuid.UUID) (*entities.AccountClosure, error)
+
+	// FindByRequestedKey busca o encerramento que aguarda a resposta do Bacen para a chave
+	// (nil, nil se não houver). Em uma transação, bloqueia o encerramento até o commit.
+	FindByRequestedKey(ctx context.Context, entryID uuid.UUID) (*entities.AccountClosure, error)
+
+	// SaveKey grava (ou substitui) o resultado de uma chave
+	SaveKey(ctx context.Context, closureID uuid.UUID, key *entities.AccountClosureKey) error
+}
@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/lbpay-lab/core-dict/internal/domain/entities"
+)
+
+// AuditChainRepository define o acesso à cadeia de hashes do log de auditoria.
+// Os elos são gravados por audit.link_events() (AuditChainLinker); aqui só há leitura e checkpoints.
+type AuditChainRepository interface {
+	// ListHeads lista as cabeças das cadeias com partição entre fromPartition e toPartition
+	// (inclusive, formato YYYY-MM); stream vazio lista todos os streams
+	ListHeads(ctx context.Context, fromPartition, toPartition, stream string) ([]*entities.AuditChainHead, error)
+
+	// ListUncheckpointedHeads lista as cabeças com eventos posteriores ao último checkpoint
+	ListUncheckpointedHeads(ctx context.Context) ([]*entities.AuditChainHead, error)
+
+	// ListLinks lista os elos de uma cadeia com chain_seq > afterSeq, em ordem
+	ListLinks(ctx context.Context, partition, stream string, afterSeq int64, limit int) ([]*entities.AuditChainLink, error)
+
+	// ListCheckpoints lista os checkpoints das cadeias do intervalo de partições, em ordem
+	ListCheckpoints(ctx context.Context, fromPartition, toPartition, stream string) ([]*entities.AuditCheckpoint, error)
+
+	// CreateCheckpoint grava um checkpoint; se o elo já tem checkpoint (outra réplica), não faz nada
+	CreateCheckpoint(ctx context.Context, checkpoint *entities.AuditCheckpoint) error
+}
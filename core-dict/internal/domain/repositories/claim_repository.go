@@ -14,6 +14,10 @@ type ClaimRepository interface {
 	// Create cria uma nova reivindicação
 	Create(ctx context.Context, claim *entities.Claim) error
 
+	// CreateIfNotExists cria a reivindicação se ainda não existir uma com o mesmo ID
+	// (reentregas de eventos); retorna se foi criada
+	CreateIfNotExists(ctx context.Context, claim *entities.Claim) (bool, error)
+
 	// Update atualiza uma reivindicação existente
 	Update(ctx context.Context, claim *entities.Claim) error
 
@@ -21,10 +21,10 @@ type EntryRepository interface {
 	// UpdateStatus atualiza apenas o status de uma entrada
 	UpdateStatus(ctx context.Context, entryID uuid.UUID, status entities.KeyStatus) error
 
-	// FindByKey busca uma chave PIX por seu valor
+	// FindByKey busca uma chave PIX por seu valor (domain.ErrEntryNotFound se não existir)
 	FindByKey(ctx context.Context, keyValue string) (*entities.Entry, error)
 
-	// FindByID busca uma chave PIX por seu ID
+	// FindByID busca uma chave PIX por seu ID (domain.ErrEntryNotFound se não existir)
 	FindByID(ctx context.Context, id uuid.UUID) (*entities.Entry, error)
 
 	// List lista chaves PIX com paginação
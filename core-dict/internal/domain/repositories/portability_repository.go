@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lbpay-lab/core-dict/internal/domain/entities"
+)
+
+// PortabilityRepository define as operações de persistência para Portability
+type PortabilityRepository interface {
+	// FindByID busca uma portabilidade pelo ID (domain.ErrPortabilityNotFound se não existir)
+	FindByID(ctx context.Context, id uuid.UUID) (*entities.Portability, error)
+
+	// FindActiveByEntryID busca as portabilidades ainda não finalizadas de uma chave
+	FindActiveByEntryID(ctx context.Context, entryID uuid.UUID) ([]*entities.Portability, error)
+
+	// Update atualiza o status de uma portabilidade
+	Update(ctx context.Context, portability *entities.Portability) error
+}
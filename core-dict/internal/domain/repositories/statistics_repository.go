@@ -2,12 +2,34 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/lbpay-lab/core-dict/internal/domain/entities"
 )
 
 // StatisticsRepository interface para estatísticas agregadas
 type StatisticsRepository interface {
-	// GetStatistics retorna estatísticas agregadas do sistema
-	GetStatistics(ctx context.Context) (*entities.Statistics, error)
+	// GetStatistics retorna estatísticas agregadas do sistema (lidas da materialized view)
+	GetStatistics(ctx context.Context, filters StatisticsFilters) (*entities.Statistics, error)
+
+	// RefreshStatistics recalcula a materialized view e retorna o momento do refresh
+	RefreshStatistics(ctx context.Context) (time.Time, error)
+}
+
+// StatisticsGroupBy define a quebra por participante das estatísticas
+type StatisticsGroupBy string
+
+const (
+	StatisticsGroupByNone   StatisticsGroupBy = ""
+	StatisticsGroupByISPB   StatisticsGroupBy = "ISPB"
+	StatisticsGroupByBranch StatisticsGroupBy = "BRANCH"
+)
+
+// StatisticsFilters define filtros para estatísticas agregadas
+type StatisticsFilters struct {
+	CreatedAfter  *time.Time // Janela de tempo pela data de criação (granularidade diária, inclusive)
+	CreatedBefore *time.Time // Janela de tempo pela data de criação (granularidade diária, inclusive)
+	ISPB          string
+	Branch        string
+	GroupBy       StatisticsGroupBy
 }
@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/lbpay-lab/core-dict/internal/domain/entities"
+)
+
+// WebhookRepository define as operações de persistência dos webhooks (endpoints e fila de entregas)
+type WebhookRepository interface {
+	// CreateEndpoint grava um novo endpoint (o segredo é gravado criptografado)
+	CreateEndpoint(ctx context.Context, endpoint *entities.WebhookEndpoint) error
+
+	// Enqueue cria uma entrega PENDING do evento para cada endpoint ativo que o assina e retorna
+	// quantas foram criadas. Um evento já enfileirado para o endpoint é ignorado (reentrega do Pulsar).
+	Enqueue(ctx context.Context, event *entities.WebhookEvent) (int, error)
+
+	// ClaimDue reserva até limit entregas vencidas, adiando next_attempt_at por lease para que
+	// outra réplica não as envie ao mesmo tempo. Retorna as entregas com o endpoint carregado.
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*entities.WebhookDelivery, error)
+
+	// UpdateDelivery grava o resultado de uma tentativa
+	UpdateDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error
+
+	// Replay volta para PENDING (tentativas zeradas) as entregas do filtro e retorna quantas foram reenfileiradas
+	Replay(ctx context.Context, filter entities.WebhookReplayFilter) (int64, error)
+}
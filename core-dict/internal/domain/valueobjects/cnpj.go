@@ -0,0 +1,65 @@
+package valueobjects
+
+import (
+	"regexp"
+	"strings"
+)
+
+// CNPJ alfanumérico (IN RFB nº 2.229/2024): as 12 primeiras posições aceitam
+// dígitos e letras maiúsculas; os 2 dígitos verificadores continuam numéricos.
+// O CNPJ numérico tradicional é um caso particular do mesmo formato.
+var (
+	cnpjFormatRegex        = regexp.MustCompile(`^[0-9A-Z]{12}\d{2}$`)
+	cnpjNumericRegex       = regexp.MustCompile(`^\d{14}$`)
+	cnpjFormattingReplacer = strings.NewReplacer(".", "", "/", "", "-", "", " ", "")
+)
+
+var (
+	cnpjFirstDigitWeights  = []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+	cnpjSecondDigitWeights = []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+)
+
+// NormalizeCNPJ remove a máscara (".", "/", "-") e converte letras para maiúsculas
+func NormalizeCNPJ(cnpj string) string {
+	return strings.ToUpper(cnpjFormattingReplacer.Replace(strings.TrimSpace(cnpj)))
+}
+
+// IsCNPJFormat verifica se o valor tem o formato de CNPJ (numérico ou alfanumérico)
+func IsCNPJFormat(cnpj string) bool {
+	return cnpjFormatRegex.MatchString(cnpj)
+}
+
+// IsAlphanumericCNPJ verifica se o CNPJ usa o formato alfanumérico (contém letras)
+func IsAlphanumericCNPJ(cnpj string) bool {
+	return IsCNPJFormat(cnpj) && !cnpjNumericRegex.MatchString(cnpj)
+}
+
+// ValidCNPJCheckDigits valida os dígitos verificadores do CNPJ.
+// Cada caractere vale seu código ASCII menos 48 ('0'..'9' => 0..9, 'A'..'Z' => 17..42),
+// o que mantém o cálculo do módulo 11 idêntico ao do CNPJ numérico.
+func ValidCNPJCheckDigits(cnpj string) bool {
+	if !IsCNPJFormat(cnpj) {
+		return false
+	}
+
+	firstDigit := cnpjCheckDigit(cnpj[:12], cnpjFirstDigitWeights)
+	if firstDigit != int(cnpj[12]-'0') {
+		return false
+	}
+
+	secondDigit := cnpjCheckDigit(cnpj[:13], cnpjSecondDigitWeights)
+	return secondDigit == int(cnpj[13]-'0')
+}
+
+// cnpjCheckDigit calcula um dígito verificador (módulo 11) para a base informada
+func cnpjCheckDigit(base string, weights []int) int {
+	sum := 0
+	for i, weight := range weights {
+		sum += int(base[i]-'0') * weight
+	}
+	remainder := sum % 11
+	if remainder < 2 {
+		return 0
+	}
+	return 11 - remainder
+}
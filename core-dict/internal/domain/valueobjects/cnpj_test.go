@@ -0,0 +1,60 @@
+package valueobjects_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lbpay-lab/core-dict/internal/domain/valueobjects"
+)
+
+func TestValidCNPJCheckDigits(t *testing.T) {
+	tests := []struct {
+		name  string
+		cnpj  string
+		valid bool
+	}{
+		{name: "Numeric CNPJ", cnpj: "11222333000181", valid: true},
+		{name: "Alphanumeric CNPJ (RFB example)", cnpj: "12ABC34501DE35", valid: true},
+		{name: "Alphanumeric CNPJ - letters only base", cnpj: "ABCDEFGHIJKL80", valid: true},
+		{name: "Alphanumeric CNPJ - mixed base", cnpj: "1A2B3C4D000179", valid: true},
+		{name: "Alphanumeric CNPJ - interleaved", cnpj: "A1B2C3D4E5F668", valid: true},
+		{name: "Numeric CNPJ - wrong check digits", cnpj: "11222333000182", valid: false},
+		{name: "Alphanumeric CNPJ - wrong first digit", cnpj: "12ABC34501DE45", valid: false},
+		{name: "Alphanumeric CNPJ - wrong second digit", cnpj: "12ABC34501DE36", valid: false},
+		{name: "Lowercase letters", cnpj: "12abc34501de35", valid: false},
+		{name: "Letter in check digits", cnpj: "12ABC34501DE3A", valid: false},
+		{name: "Too short", cnpj: "12ABC34501DE3", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.valid, valueobjects.ValidCNPJCheckDigits(tt.cnpj))
+		})
+	}
+}
+
+func TestIsAlphanumericCNPJ(t *testing.T) {
+	assert.True(t, valueobjects.IsAlphanumericCNPJ("12ABC34501DE35"))
+	assert.False(t, valueobjects.IsAlphanumericCNPJ("11222333000181"))
+	assert.False(t, valueobjects.IsAlphanumericCNPJ("12345678909"))
+}
+
+func TestNormalizeCNPJ(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "11.222.333/0001-81", expected: "11222333000181"},
+		{input: "12.abc.345/01de-35", expected: "12ABC34501DE35"},
+		{input: " 12ABC34501DE35 ", expected: "12ABC34501DE35"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			normalized := valueobjects.NormalizeCNPJ(tt.input)
+			assert.Equal(t, tt.expected, normalized)
+			assert.True(t, valueobjects.IsCNPJFormat(normalized))
+		})
+	}
+}
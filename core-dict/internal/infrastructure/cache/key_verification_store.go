@@ -0,0 +1,202 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/lbpay-lab/core-dict/internal/application/services"
+	"github.com/lbpay-lab/core-dict/internal/domain/valueobjects"
+)
+
+// Key ownership verifications (EMAIL/PHONE) on Redis.
+//
+// Each verification is a hash that expires with the code (PENDING) or with the window to use
+// it (VERIFIED). Only the HMAC of the code is stored. Code checks and consumption are Lua
+// scripts, so concurrent replicas can't get extra attempts nor use a verification twice.
+
+const defaultKeyVerificationPrefix = "core-dict:key-verification:"
+
+// KeyVerificationStore implements services.KeyVerificationStore on Redis
+type KeyVerificationStore struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewKeyVerificationStore creates a new verification store
+func NewKeyVerificationStore(client redis.UniversalClient) *KeyVerificationStore {
+	return &KeyVerificationStore{
+		client: client,
+		prefix: defaultKeyVerificationPrefix,
+	}
+}
+
+// Save stores a PENDING verification until v.ExpiresAt
+func (s *KeyVerificationStore) Save(ctx context.Context, v *services.KeyVerification) error {
+	key := s.verificationKey(v.ID)
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key,
+			"user_id", v.UserID,
+			"key_type", string(v.KeyType),
+			"key_value", v.KeyValue,
+			"code_hash", v.CodeHash,
+			"status", string(v.Status),
+			"attempts", v.Attempts,
+			"max_attempts", v.MaxAttempts,
+			"created_at", v.CreatedAt.UnixMilli(),
+			"expires_at", v.ExpiresAt.UnixMilli(),
+		)
+		pipe.PExpireAt(ctx, key, v.ExpiresAt)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save key verification: %w", err)
+	}
+	return nil
+}
+
+// checkCodeScript counts one attempt and compares the code hash.
+// KEYS: verification hash. ARGV: user_id, code_hash, verified ttl ms.
+// Returns {result, HGETALL} (result NOT_FOUND has no fields).
+var checkCodeScript = redis.NewScript(`
+local f = redis.call('HMGET', KEYS[1], 'user_id', 'status', 'code_hash', 'attempts', 'max_attempts')
+if f[1] == false or f[1] ~= ARGV[1] then
+	return {'NOT_FOUND'}
+end
+if f[2] == 'VERIFIED' then
+	return {'ALREADY_VERIFIED', redis.call('HGETALL', KEYS[1])}
+end
+
+local attempts = tonumber(f[4])
+local max_attempts = tonumber(f[5])
+if f[2] == 'LOCKED' or attempts >= max_attempts then
+	return {'LOCKED', redis.call('HGETALL', KEYS[1])}
+end
+
+attempts = attempts + 1
+if f[3] == ARGV[2] then
+	local t = redis.call('TIME')
+	local now_ms = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+	local ttl_ms = tonumber(ARGV[3])
+	redis.call('HSET', KEYS[1], 'status', 'VERIFIED', 'attempts', attempts,
+		'verified_at', now_ms, 'expires_at', now_ms + ttl_ms)
+	redis.call('PEXPIRE', KEYS[1], ttl_ms)
+	return {'MATCHED', redis.call('HGETALL', KEYS[1])}
+end
+
+if attempts >= max_attempts then
+	-- Kept until the code expires so that further attempts are refused too
+	redis.call('HSET', KEYS[1], 'status', 'LOCKED', 'attempts', attempts)
+	return {'LOCKED', redis.call('HGETALL', KEYS[1])}
+end
+redis.call('HSET', KEYS[1], 'attempts', attempts)
+return {'MISMATCH', redis.call('HGETALL', KEYS[1])}
+`)
+
+// CheckCode counts one attempt against the verification and compares the code hash
+func (s *KeyVerificationStore) CheckCode(ctx context.Context, id, userID, codeHash string, verifiedTTL time.Duration) (*services.KeyVerification, services.CodeCheckResult, error) {
+	res, err := checkCodeScript.Run(ctx, s.client, []string{s.verificationKey(id)},
+		userID, codeHash, verifiedTTL.Milliseconds()).Slice()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to check verification code: %w", err)
+	}
+
+	result, _ := res[0].(string)
+	if result == "NOT_FOUND" || len(res) < 2 {
+		return nil, "", services.ErrKeyVerificationNotFound
+	}
+	v, err := parseKeyVerification(id, res[1])
+	if err != nil {
+		return nil, "", err
+	}
+	return v, services.CodeCheckResult(result), nil
+}
+
+// consumeScript deletes a VERIFIED verification that belongs to the user and key.
+// KEYS: verification hash. ARGV: user_id, key_type, key_value (empty = any key of the user).
+// Returns the fields before deletion, or false.
+var consumeScript = redis.NewScript(`
+local f = redis.call('HMGET', KEYS[1], 'user_id', 'key_type', 'key_value', 'status')
+if f[1] == false or f[1] ~= ARGV[1] or f[4] ~= 'VERIFIED' then
+	return false
+end
+if ARGV[3] ~= '' and (f[2] ~= ARGV[2] or f[3] ~= ARGV[3]) then
+	return false
+end
+local fields = redis.call('HGETALL', KEYS[1])
+redis.call('DEL', KEYS[1])
+return fields
+`)
+
+// Consume deletes the user's VERIFIED verification for the given key (single use).
+// An empty keyValue accepts any key of the user (the caller then checks the returned key).
+func (s *KeyVerificationStore) Consume(ctx context.Context, id, userID string, keyType valueobjects.KeyType, keyValue string) (*services.KeyVerification, error) {
+	res, err := consumeScript.Run(ctx, s.client, []string{s.verificationKey(id)},
+		userID, string(keyType), keyValue).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, services.ErrKeyVerificationNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume key verification: %w", err)
+	}
+	return parseKeyVerification(id, res)
+}
+
+// AcquireResendSlot reserves sending a new code to the user/key for interval
+func (s *KeyVerificationStore) AcquireResendSlot(ctx context.Context, userID string, keyType valueobjects.KeyType, keyValue string, interval time.Duration) (bool, error) {
+	// The key value is hashed so that emails/phones don't show up in Redis key names
+	sum := sha256.Sum256([]byte(userID + "|" + string(keyType) + "|" + keyValue))
+	acquired, err := s.client.SetNX(ctx, s.prefix+"resend:"+hex.EncodeToString(sum[:]), 1, interval).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire resend slot: %w", err)
+	}
+	return acquired, nil
+}
+
+func (s *KeyVerificationStore) verificationKey(id string) string {
+	return s.prefix + "v:" + id
+}
+
+// parseKeyVerification converts a HGETALL reply (flat field/value list) into a verification
+func parseKeyVerification(id string, reply interface{}) (*services.KeyVerification, error) {
+	values, ok := reply.([]interface{})
+	if !ok || len(values)%2 != 0 {
+		return nil, fmt.Errorf("unexpected key verification reply: %T", reply)
+	}
+
+	fields := make(map[string]string, len(values)/2)
+	for i := 0; i < len(values); i += 2 {
+		k, _ := values[i].(string)
+		v, _ := values[i+1].(string)
+		fields[k] = v
+	}
+
+	atoi := func(name string) int64 {
+		n, _ := strconv.ParseInt(fields[name], 10, 64)
+		return n
+	}
+
+	v := &services.KeyVerification{
+		ID:          id,
+		UserID:      fields["user_id"],
+		KeyType:     valueobjects.KeyType(fields["key_type"]),
+		KeyValue:    fields["key_value"],
+		CodeHash:    fields["code_hash"],
+		Status:      services.KeyVerificationStatus(fields["status"]),
+		Attempts:    int(atoi("attempts")),
+		MaxAttempts: int(atoi("max_attempts")),
+		CreatedAt:   time.UnixMilli(atoi("created_at")),
+		ExpiresAt:   time.UnixMilli(atoi("expires_at")),
+	}
+	if fields["verified_at"] != "" {
+		verifiedAt := time.UnixMilli(atoi("verified_at"))
+		v.VerifiedAt = &verifiedAt
+	}
+	return v, nil
+}
@@ -0,0 +1,115 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lbpay-lab/core-dict/internal/application/services"
+	"github.com/lbpay-lab/core-dict/internal/domain/valueobjects"
+	"github.com/lbpay-lab/core-dict/internal/infrastructure/cache"
+)
+
+func pendingVerification(id string) *services.KeyVerification {
+	now := time.Now()
+	return &services.KeyVerification{
+		ID:          id,
+		UserID:      "user-1",
+		KeyType:     valueobjects.KeyTypeEmail,
+		KeyValue:    "john@example.com",
+		CodeHash:    "right-hash",
+		Status:      services.KeyVerificationPending,
+		MaxAttempts: 3,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(time.Minute),
+	}
+}
+
+func TestKeyVerificationStore_CheckAndConsume(t *testing.T) {
+	client, cleanup := setupRedisContainer(t)
+	defer cleanup()
+
+	store := cache.NewKeyVerificationStore(client.Client())
+	ctx := context.Background()
+	require.NoError(t, store.Save(ctx, pendingVerification("v-1")))
+
+	// Other users don't see it
+	_, _, err := store.CheckCode(ctx, "v-1", "user-2", "right-hash", time.Hour)
+	assert.ErrorIs(t, err, services.ErrKeyVerificationNotFound)
+
+	v, result, err := store.CheckCode(ctx, "v-1", "user-1", "wrong-hash", time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, services.CodeCheckMismatch, result)
+	assert.Equal(t, 2, v.RemainingAttempts())
+
+	// Not verified yet
+	_, err = store.Consume(ctx, "v-1", "user-1", valueobjects.KeyTypeEmail, "john@example.com")
+	assert.ErrorIs(t, err, services.ErrKeyVerificationNotFound)
+
+	v, result, err = store.CheckCode(ctx, "v-1", "user-1", "right-hash", time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, services.CodeCheckMatched, result)
+	assert.Equal(t, services.KeyVerificationVerified, v.Status)
+	require.NotNil(t, v.VerifiedAt)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), v.ExpiresAt, 5*time.Second)
+
+	// Bound to the key
+	_, err = store.Consume(ctx, "v-1", "user-1", valueobjects.KeyTypeEmail, "mary@example.com")
+	assert.ErrorIs(t, err, services.ErrKeyVerificationNotFound)
+
+	v, err = store.Consume(ctx, "v-1", "user-1", valueobjects.KeyTypeEmail, "john@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "john@example.com", v.KeyValue)
+
+	// Single use
+	_, err = store.Consume(ctx, "v-1", "user-1", valueobjects.KeyTypeEmail, "john@example.com")
+	assert.ErrorIs(t, err, services.ErrKeyVerificationNotFound)
+}
+
+func TestKeyVerificationStore_LocksAfterMaxAttempts(t *testing.T) {
+	client, cleanup := setupRedisContainer(t)
+	defer cleanup()
+
+	store := cache.NewKeyVerificationStore(client.Client())
+	ctx := context.Background()
+	require.NoError(t, store.Save(ctx, pendingVerification("v-2")))
+
+	for i := 0; i < 2; i++ {
+		_, result, err := store.CheckCode(ctx, "v-2", "user-1", "wrong-hash", time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, services.CodeCheckMismatch, result)
+	}
+	_, result, err := store.CheckCode(ctx, "v-2", "user-1", "wrong-hash", time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, services.CodeCheckLocked, result)
+
+	_, result, err = store.CheckCode(ctx, "v-2", "user-1", "right-hash", time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, services.CodeCheckLocked, result)
+}
+
+func TestKeyVerificationStore_ExpiresAndResendSlot(t *testing.T) {
+	client, cleanup := setupRedisContainer(t)
+	defer cleanup()
+
+	store := cache.NewKeyVerificationStore(client.Client())
+	ctx := context.Background()
+
+	v := pendingVerification("v-3")
+	v.ExpiresAt = time.Now().Add(200 * time.Millisecond)
+	require.NoError(t, store.Save(ctx, v))
+	time.Sleep(400 * time.Millisecond)
+
+	_, _, err := store.CheckCode(ctx, "v-3", "user-1", "right-hash", time.Hour)
+	assert.ErrorIs(t, err, services.ErrKeyVerificationNotFound)
+
+	acquired, err := store.AcquireResendSlot(ctx, "user-1", valueobjects.KeyTypeEmail, "john@example.com", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+	acquired, err = store.AcquireResendSlot(ctx, "user-1", valueobjects.KeyTypeEmail, "john@example.com", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, acquired)
+}
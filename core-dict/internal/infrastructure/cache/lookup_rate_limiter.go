@@ -0,0 +1,465 @@
+package cache
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/lbpay-lab/core-dict/internal/domain/valueobjects"
+)
+
+// Anti-scan rate limiting for DICT lookups (Manual Operacional do DICT - limitação de consultas).
+//
+// Every lookup is charged against two token buckets: one for the participant (our ISPB)
+// and one for the end user that is paying (CPF/CNPJ of the payer). A lookup that finds the
+// key costs SuccessCost tokens; a lookup for a key that doesn't exist costs NotFoundCost,
+// which is what makes scanning the key space expensive. Buckets refill continuously and
+// every settled payment gives SettlementCredit tokens back.
+//
+// Bucket state lives in Redis (hash with tokens + last update) and is only changed by Lua
+// scripts that read the clock from Redis itself, so all replicas see and update the same
+// buckets atomically regardless of their local clocks. Every key of a participant carries
+// the {ISPB} hash tag, so the scripts touch a single slot on Redis Cluster.
+
+// LookupBucketScope identifies which bucket a state or a block refers to
+type LookupBucketScope string
+
+const (
+	LookupBucketParticipant LookupBucketScope = "PARTICIPANT"
+	LookupBucketEndUser     LookupBucketScope = "END_USER"
+)
+
+// ErrInvalidPayerTaxID is returned when the payer tax ID is neither a CPF nor a CNPJ
+var ErrInvalidPayerTaxID = errors.New("payer_tax_id must be a CPF (11 digits) or a CNPJ (14 characters)")
+
+var cpfFormatRegex = regexp.MustCompile(`^\d{11}$`)
+
+// TokenBucketPolicy configures one kind of bucket
+type TokenBucketPolicy struct {
+	Capacity         int // Maximum tokens (bucket starts full)
+	RefillPerMinute  int // Tokens added per minute
+	SuccessCost      int // Tokens charged for a lookup that found the key
+	NotFoundCost     int // Tokens charged for a lookup whose key doesn't exist
+	SettlementCredit int // Tokens given back when a payment is settled
+}
+
+// LookupRateLimitConfig holds anti-scan rate limiting configuration
+type LookupRateLimitConfig struct {
+	KeyPrefix       string
+	ParticipantISPB string
+
+	Participant   TokenBucketPolicy
+	NaturalPerson TokenBucketPolicy // End users identified by CPF
+	LegalPerson   TokenBucketPolicy // End users identified by CNPJ
+
+	// SettlementDedupTTL is how long a settled EndToEndId is remembered to avoid double credits
+	SettlementDedupTTL time.Duration
+
+	// TaxIDSecret keys the HMAC that names the end user buckets, so bucket keys can't be
+	// reversed into CPF/CNPJ by hashing the (small) tax ID space
+	TaxIDSecret string
+}
+
+// DefaultLookupRateLimitConfig returns the default anti-scan policies
+func DefaultLookupRateLimitConfig(participantISPB string) *LookupRateLimitConfig {
+	return &LookupRateLimitConfig{
+		KeyPrefix:       "core-dict:lookup-ratelimit:",
+		ParticipantISPB: participantISPB,
+		Participant: TokenBucketPolicy{
+			Capacity:         50000,
+			RefillPerMinute:  25000,
+			SuccessCost:      1,
+			NotFoundCost:     3,
+			SettlementCredit: 1,
+		},
+		NaturalPerson: TokenBucketPolicy{
+			Capacity:         100,
+			RefillPerMinute:  2,
+			SuccessCost:      1,
+			NotFoundCost:     20,
+			SettlementCredit: 1,
+		},
+		LegalPerson: TokenBucketPolicy{
+			Capacity:         1000,
+			RefillPerMinute:  20,
+			SuccessCost:      1,
+			NotFoundCost:     20,
+			SettlementCredit: 1,
+		},
+		SettlementDedupTTL: 7 * 24 * time.Hour,
+	}
+}
+
+// Validate checks that every policy can refill and charge
+func (c *LookupRateLimitConfig) Validate() error {
+	if c.ParticipantISPB == "" {
+		return fmt.Errorf("participant ISPB is required")
+	}
+	if len(c.TaxIDSecret) < 16 {
+		return fmt.Errorf("tax ID secret must have at least 16 characters")
+	}
+	for name, p := range map[string]TokenBucketPolicy{
+		"participant":    c.Participant,
+		"natural person": c.NaturalPerson,
+		"legal person":   c.LegalPerson,
+	} {
+		if p.Capacity <= 0 || p.RefillPerMinute <= 0 {
+			return fmt.Errorf("%s bucket: capacity and refill per minute must be positive", name)
+		}
+		if p.SuccessCost <= 0 || p.NotFoundCost < p.SuccessCost {
+			return fmt.Errorf("%s bucket: costs must be positive and not_found_cost >= success_cost", name)
+		}
+		if p.SettlementCredit < 0 {
+			return fmt.Errorf("%s bucket: settlement credit must not be negative", name)
+		}
+	}
+	return nil
+}
+
+// LookupDecision is the outcome of LookupRateLimiter.Reserve
+type LookupDecision struct {
+	Allowed    bool
+	BlockedBy  LookupBucketScope // Set when Allowed is false
+	RetryAfter time.Duration     // Time until the blocking bucket has enough tokens
+}
+
+// LookupBucketState is a read-only view of one bucket
+type LookupBucketState struct {
+	Scope      LookupBucketScope
+	Subject    string // ISPB or masked payer tax ID
+	Tokens     float64
+	Policy     TokenBucketPolicy
+	Blocked    bool
+	RetryAfter time.Duration
+	UpdatedAt  time.Time // Zero when the bucket was never used (or expired back to full)
+}
+
+// LookupRateLimiter implements the DICT anti-scan token buckets on Redis
+type LookupRateLimiter struct {
+	client redis.UniversalClient
+	config *LookupRateLimitConfig
+}
+
+// NewLookupRateLimiter creates a new anti-scan rate limiter
+func NewLookupRateLimiter(client redis.UniversalClient, config *LookupRateLimitConfig) (*LookupRateLimiter, error) {
+	if config == nil {
+		return nil, fmt.Errorf("lookup rate limit config is required")
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid lookup rate limit config: %w", err)
+	}
+	return &LookupRateLimiter{
+		client: client,
+		config: config,
+	}, nil
+}
+
+// tokenBucketLuaPrelude reads the clock from Redis and refills a bucket lazily.
+// Buckets that don't exist (never used or expired) are full.
+const tokenBucketLuaPrelude = `
+local t = redis.call('TIME')
+local now_ms = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+
+local function refill(key, capacity, rate_per_ms)
+	local state = redis.call('HMGET', key, 'tokens', 'ts')
+	local tokens = tonumber(state[1])
+	local ts = tonumber(state[2])
+	if tokens == nil or ts == nil then
+		return capacity
+	end
+	if now_ms > ts then
+		tokens = math.min(capacity, tokens + (now_ms - ts) * rate_per_ms)
+	end
+	return tokens
+end
+
+local function save(key, tokens, ttl)
+	redis.call('HSET', key, 'tokens', tostring(tokens), 'ts', now_ms)
+	redis.call('EXPIRE', key, ttl)
+end
+`
+
+// reserveScript charges ARGV cost on every bucket, all or nothing.
+// ARGV per bucket: capacity, refill per ms, cost, ttl seconds.
+// Returns {allowed, index of the first blocking bucket, wait ms}.
+var reserveScript = redis.NewScript(tokenBucketLuaPrelude + `
+local tokens = {}
+local blocked = 0
+local wait_ms = 0
+for i = 1, #KEYS do
+	local b = (i - 1) * 4
+	local capacity = tonumber(ARGV[b + 1])
+	local rate = tonumber(ARGV[b + 2])
+	local cost = tonumber(ARGV[b + 3])
+	tokens[i] = refill(KEYS[i], capacity, rate)
+	if tokens[i] < cost then
+		if blocked == 0 then
+			blocked = i
+		end
+		local w = math.ceil((cost - tokens[i]) / rate)
+		if w > wait_ms then
+			wait_ms = w
+		end
+	end
+end
+if blocked > 0 then
+	return {0, blocked, wait_ms}
+end
+for i = 1, #KEYS do
+	local b = (i - 1) * 4
+	save(KEYS[i], tokens[i] - tonumber(ARGV[b + 3]), tonumber(ARGV[b + 4]))
+end
+return {1, 0, 0}
+`)
+
+// debitScript charges ARGV cost on every bucket, never going below zero.
+// ARGV per bucket: capacity, refill per ms, cost, ttl seconds.
+var debitScript = redis.NewScript(tokenBucketLuaPrelude + `
+for i = 1, #KEYS do
+	local b = (i - 1) * 4
+	local tokens = refill(KEYS[i], tonumber(ARGV[b + 1]), tonumber(ARGV[b + 2]))
+	save(KEYS[i], math.max(0, tokens - tonumber(ARGV[b + 3])), tonumber(ARGV[b + 4]))
+end
+return 1
+`)
+
+// creditScript gives ARGV credit back to every bucket once per settled payment.
+// KEYS[1] is the settlement dedup key; ARGV[1] its ttl in seconds.
+// ARGV per bucket (from ARGV[2]): capacity, refill per ms, credit, ttl seconds.
+// Returns 1 if credited, 0 if the payment was already counted.
+var creditScript = redis.NewScript(tokenBucketLuaPrelude + `
+if not redis.call('SET', KEYS[1], '1', 'NX', 'EX', tonumber(ARGV[1])) then
+	return 0
+end
+for i = 2, #KEYS do
+	local b = (i - 2) * 4 + 1
+	local capacity = tonumber(ARGV[b + 1])
+	local tokens = refill(KEYS[i], capacity, tonumber(ARGV[b + 2]))
+	save(KEYS[i], math.min(capacity, tokens + tonumber(ARGV[b + 3])), tonumber(ARGV[b + 4]))
+end
+return 1
+`)
+
+// lookupBucket is one bucket resolved for a request
+type lookupBucket struct {
+	scope   LookupBucketScope
+	key     string
+	subject string
+	policy  TokenBucketPolicy
+}
+
+// Reserve charges the success cost of one lookup on the participant and end user buckets.
+// Nothing is charged when either bucket doesn't have enough tokens.
+func (l *LookupRateLimiter) Reserve(ctx context.Context, payerTaxID string) (*LookupDecision, error) {
+	buckets, err := l.buckets(payerTaxID)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, args := bucketScriptArgs(buckets, func(p TokenBucketPolicy) int { return p.SuccessCost })
+	result, err := reserveScript.Run(ctx, l.client, keys, args...).Int64Slice()
+	if err != nil {
+		return nil, fmt.Errorf("lookup rate limit reserve failed: %w", err)
+	}
+	if len(result) != 3 {
+		return nil, fmt.Errorf("lookup rate limit reserve returned %d values", len(result))
+	}
+
+	if result[0] == 1 {
+		return &LookupDecision{Allowed: true}, nil
+	}
+	return &LookupDecision{
+		Allowed:    false,
+		BlockedBy:  buckets[result[1]-1].scope,
+		RetryAfter: time.Duration(result[2]) * time.Millisecond,
+	}, nil
+}
+
+// ChargeNotFound charges the extra cost of a lookup whose key doesn't exist
+// (NotFoundCost - SuccessCost, since Reserve already charged SuccessCost)
+func (l *LookupRateLimiter) ChargeNotFound(ctx context.Context, payerTaxID string) error {
+	buckets, err := l.buckets(payerTaxID)
+	if err != nil {
+		return err
+	}
+
+	keys, args := bucketScriptArgs(buckets, func(p TokenBucketPolicy) int { return p.NotFoundCost - p.SuccessCost })
+	if err := debitScript.Run(ctx, l.client, keys, args...).Err(); err != nil {
+		return fmt.Errorf("lookup rate limit not-found charge failed: %w", err)
+	}
+	return nil
+}
+
+// CreditSettlement gives tokens back to the participant and payer buckets for a settled payment.
+// Each EndToEndId is credited only once; returns false for repeated notifications.
+func (l *LookupRateLimiter) CreditSettlement(ctx context.Context, endToEndID, payerTaxID string) (bool, error) {
+	if endToEndID == "" {
+		return false, fmt.Errorf("end_to_end_id is required")
+	}
+	buckets, err := l.buckets(payerTaxID)
+	if err != nil {
+		return false, err
+	}
+
+	keys, args := bucketScriptArgs(buckets, func(p TokenBucketPolicy) int { return p.SettlementCredit })
+	keys = append([]string{l.keyPrefix() + "settled:" + endToEndID}, keys...)
+	args = append([]interface{}{int64(l.config.SettlementDedupTTL.Seconds())}, args...)
+
+	credited, err := creditScript.Run(ctx, l.client, keys, args...).Int64()
+	if err != nil {
+		return false, fmt.Errorf("lookup rate limit settlement credit failed: %w", err)
+	}
+	return credited == 1, nil
+}
+
+// Status returns the current state of the participant bucket and, when payerTaxID is set,
+// of the payer bucket. It never changes the buckets.
+func (l *LookupRateLimiter) Status(ctx context.Context, payerTaxID string) ([]LookupBucketState, error) {
+	buckets := []lookupBucket{l.participantBucket()}
+	if payerTaxID != "" {
+		var err error
+		if buckets, err = l.buckets(payerTaxID); err != nil {
+			return nil, err
+		}
+	}
+
+	now, err := l.client.Time(ctx).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read redis time: %w", err)
+	}
+
+	states := make([]LookupBucketState, 0, len(buckets))
+	for _, b := range buckets {
+		values, err := l.client.HMGet(ctx, b.key, "tokens", "ts").Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s bucket: %w", b.scope, err)
+		}
+		tokens, updatedAt := currentTokens(values, b.policy, now)
+
+		state := LookupBucketState{
+			Scope:     b.scope,
+			Subject:   b.subject,
+			Tokens:    tokens,
+			Policy:    b.policy,
+			UpdatedAt: updatedAt,
+		}
+		if missing := float64(b.policy.SuccessCost) - tokens; missing > 0 {
+			state.Blocked = true
+			state.RetryAfter = time.Duration(math.Ceil(missing * 60 / float64(b.policy.RefillPerMinute) * float64(time.Second)))
+		}
+		states = append(states, state)
+	}
+	return states, nil
+}
+
+// buckets resolves the participant and end user buckets for a payer
+func (l *LookupRateLimiter) buckets(payerTaxID string) ([]lookupBucket, error) {
+	taxID := valueobjects.NormalizeCNPJ(payerTaxID)
+
+	var policy TokenBucketPolicy
+	switch {
+	case cpfFormatRegex.MatchString(taxID):
+		policy = l.config.NaturalPerson
+	case valueobjects.IsCNPJFormat(taxID):
+		policy = l.config.LegalPerson
+	default:
+		return nil, ErrInvalidPayerTaxID
+	}
+
+	// The tax ID is keyed-hashed so Redis never holds CPF/CNPJ in clear text
+	mac := hmac.New(sha256.New, []byte(l.config.TaxIDSecret))
+	mac.Write([]byte(taxID))
+	digest := mac.Sum(nil)
+
+	return []lookupBucket{
+		l.participantBucket(),
+		{
+			scope:   LookupBucketEndUser,
+			key:     l.keyPrefix() + "user:" + hex.EncodeToString(digest[:16]),
+			subject: MaskTaxID(taxID),
+			policy:  policy,
+		},
+	}, nil
+}
+
+func (l *LookupRateLimiter) participantBucket() lookupBucket {
+	return lookupBucket{
+		scope:   LookupBucketParticipant,
+		key:     l.keyPrefix() + "participant",
+		subject: l.config.ParticipantISPB,
+		policy:  l.config.Participant,
+	}
+}
+
+// keyPrefix prefixes every key with the {ISPB} hash tag: the scripts get the participant
+// bucket with an end user bucket (and the settlement dedup key), which must share a slot
+func (l *LookupRateLimiter) keyPrefix() string {
+	return l.config.KeyPrefix + "{" + l.config.ParticipantISPB + "}:"
+}
+
+// bucketScriptArgs builds KEYS and the per-bucket ARGV (capacity, refill per ms, amount, ttl)
+func bucketScriptArgs(buckets []lookupBucket, amount func(TokenBucketPolicy) int) ([]string, []interface{}) {
+	keys := make([]string, 0, len(buckets))
+	args := make([]interface{}, 0, len(buckets)*4)
+	for _, b := range buckets {
+		keys = append(keys, b.key)
+		args = append(args,
+			b.policy.Capacity,
+			strconv.FormatFloat(float64(b.policy.RefillPerMinute)/60000, 'g', -1, 64),
+			amount(b.policy),
+			bucketTTLSeconds(b.policy),
+		)
+	}
+	return keys, args
+}
+
+// bucketTTLSeconds keeps a bucket until it would be full again (plus a minute);
+// an expired bucket is recreated full, so expiring it changes nothing
+func bucketTTLSeconds(p TokenBucketPolicy) int {
+	minutes := (p.Capacity + p.RefillPerMinute - 1) / p.RefillPerMinute
+	return (minutes + 1) * 60
+}
+
+// currentTokens applies the refill since the last update to a bucket read with HMGET
+func currentTokens(values []interface{}, p TokenBucketPolicy, now time.Time) (float64, time.Time) {
+	capacity := float64(p.Capacity)
+	if len(values) != 2 || values[0] == nil || values[1] == nil {
+		return capacity, time.Time{}
+	}
+
+	tokens, err := strconv.ParseFloat(fmt.Sprint(values[0]), 64)
+	if err != nil {
+		return capacity, time.Time{}
+	}
+	ts, err := strconv.ParseInt(fmt.Sprint(values[1]), 10, 64)
+	if err != nil {
+		return capacity, time.Time{}
+	}
+
+	updatedAt := time.UnixMilli(ts)
+	if elapsed := now.Sub(updatedAt); elapsed > 0 {
+		tokens = math.Min(capacity, tokens+elapsed.Minutes()*float64(p.RefillPerMinute))
+	}
+	return tokens, updatedAt
+}
+
+// MaskTaxID masks a CPF (***.456.789-**) or CNPJ (**.345.678/0001-**) for display
+func MaskTaxID(taxID string) string {
+	switch len(taxID) {
+	case 11:
+		return "***." + taxID[3:6] + "." + taxID[6:9] + "-**"
+	case 14:
+		return "**." + taxID[2:5] + "." + taxID[5:8] + "/" + taxID[8:12] + "-**"
+	default:
+		return "***"
+	}
+}
@@ -0,0 +1,179 @@
+package cache_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lbpay-lab/core-dict/internal/infrastructure/cache"
+)
+
+func newTestLookupRateLimiter(t *testing.T, client *cache.RedisClient) *cache.LookupRateLimiter {
+	config := cache.DefaultLookupRateLimitConfig("12345678")
+	config.KeyPrefix = "test:lookup:"
+	config.TaxIDSecret = "test-lookup-tax-id-secret"
+	config.NaturalPerson = cache.TokenBucketPolicy{
+		Capacity:         5,
+		RefillPerMinute:  1,
+		SuccessCost:      1,
+		NotFoundCost:     3,
+		SettlementCredit: 1,
+	}
+
+	limiter, err := cache.NewLookupRateLimiter(client.Client(), config)
+	require.NoError(t, err)
+	return limiter
+}
+
+func TestLookupRateLimiter_Reserve_BlocksEndUser(t *testing.T) {
+	client, cleanup := setupRedisContainer(t)
+	defer cleanup()
+
+	limiter := newTestLookupRateLimiter(t, client)
+	ctx := context.Background()
+
+	// 5 tokens, 1 per lookup
+	for i := 0; i < 5; i++ {
+		decision, err := limiter.Reserve(ctx, "12345678909")
+		require.NoError(t, err)
+		assert.True(t, decision.Allowed)
+	}
+
+	decision, err := limiter.Reserve(ctx, "12345678909")
+	require.NoError(t, err)
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, cache.LookupBucketEndUser, decision.BlockedBy)
+	assert.Greater(t, decision.RetryAfter.Seconds(), 0.0)
+
+	// Other payers are not affected
+	decision, err = limiter.Reserve(ctx, "98765432100")
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+}
+
+func TestLookupRateLimiter_ChargeNotFound(t *testing.T) {
+	client, cleanup := setupRedisContainer(t)
+	defer cleanup()
+
+	limiter := newTestLookupRateLimiter(t, client)
+	ctx := context.Background()
+
+	// Two not-found lookups cost 3 each: 5 -> 2 -> 0 (floored)
+	for i := 0; i < 2; i++ {
+		decision, err := limiter.Reserve(ctx, "12345678909")
+		require.NoError(t, err)
+		require.True(t, decision.Allowed)
+		require.NoError(t, limiter.ChargeNotFound(ctx, "12345678909"))
+	}
+
+	decision, err := limiter.Reserve(ctx, "12345678909")
+	require.NoError(t, err)
+	assert.False(t, decision.Allowed)
+}
+
+func TestLookupRateLimiter_CreditSettlement_OncePerEndToEndID(t *testing.T) {
+	client, cleanup := setupRedisContainer(t)
+	defer cleanup()
+
+	limiter := newTestLookupRateLimiter(t, client)
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		_, err := limiter.Reserve(ctx, "12345678909")
+		require.NoError(t, err)
+	}
+
+	credited, err := limiter.CreditSettlement(ctx, "E1234567820261018120000000000001", "12345678909")
+	require.NoError(t, err)
+	assert.True(t, credited)
+
+	credited, err = limiter.CreditSettlement(ctx, "E1234567820261018120000000000001", "12345678909")
+	require.NoError(t, err)
+	assert.False(t, credited)
+
+	// Exactly one token came back
+	decision, err := limiter.Reserve(ctx, "12345678909")
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+
+	decision, err = limiter.Reserve(ctx, "12345678909")
+	require.NoError(t, err)
+	assert.False(t, decision.Allowed)
+}
+
+func TestLookupRateLimiter_Status_IsReadOnly(t *testing.T) {
+	client, cleanup := setupRedisContainer(t)
+	defer cleanup()
+
+	limiter := newTestLookupRateLimiter(t, client)
+	ctx := context.Background()
+
+	states, err := limiter.Status(ctx, "12345678909")
+	require.NoError(t, err)
+	require.Len(t, states, 2)
+	assert.Equal(t, 5.0, states[1].Tokens)
+	assert.True(t, states[1].UpdatedAt.IsZero())
+	assert.Equal(t, "***.456.789-**", states[1].Subject)
+
+	_, err = limiter.Reserve(ctx, "12345678909")
+	require.NoError(t, err)
+
+	states, err = limiter.Status(ctx, "12345678909")
+	require.NoError(t, err)
+	assert.InDelta(t, 4.0, states[1].Tokens, 0.1)
+	assert.False(t, states[1].Blocked)
+
+	again, err := limiter.Status(ctx, "12345678909")
+	require.NoError(t, err)
+	assert.InDelta(t, states[1].Tokens, again[1].Tokens, 0.1)
+
+	// Without a payer only the participant bucket is returned
+	states, err = limiter.Status(ctx, "")
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+	assert.Equal(t, cache.LookupBucketParticipant, states[0].Scope)
+}
+
+func TestLookupRateLimiter_KeysShareParticipantSlot(t *testing.T) {
+	client, cleanup := setupRedisContainer(t)
+	defer cleanup()
+
+	limiter := newTestLookupRateLimiter(t, client)
+	ctx := context.Background()
+
+	_, err := limiter.Reserve(ctx, "12345678909")
+	require.NoError(t, err)
+	_, err = limiter.CreditSettlement(ctx, "E12345678202401011200abcdefghijk", "12345678909")
+	require.NoError(t, err)
+
+	keys, err := client.Client().Keys(ctx, "test:lookup:*").Result()
+	require.NoError(t, err)
+	require.Len(t, keys, 3)
+
+	plainDigest := sha256.Sum256([]byte("12345678909"))
+	for _, key := range keys {
+		assert.True(t, strings.HasPrefix(key, "test:lookup:{12345678}:"), key)
+		assert.NotContains(t, key, "12345678909")
+		assert.NotContains(t, key, hex.EncodeToString(plainDigest[:16]))
+	}
+}
+
+func TestLookupRateLimiter_RequiresTaxIDSecret(t *testing.T) {
+	_, err := cache.NewLookupRateLimiter(nil, cache.DefaultLookupRateLimitConfig("12345678"))
+	assert.Error(t, err)
+}
+
+func TestLookupRateLimiter_InvalidPayerTaxID(t *testing.T) {
+	config := cache.DefaultLookupRateLimitConfig("12345678")
+	config.TaxIDSecret = "test-lookup-tax-id-secret"
+	limiter, err := cache.NewLookupRateLimiter(nil, config)
+	require.NoError(t, err)
+
+	_, err = limiter.Reserve(context.Background(), "123")
+	assert.ErrorIs(t, err, cache.ErrInvalidPayerTaxID)
+}
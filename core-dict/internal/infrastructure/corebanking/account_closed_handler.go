@@ -0,0 +1,119 @@
+// Package corebanking consumes the account lifecycle events published by core banking
+package corebanking
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/lbpay-lab/core-dict/internal/application/commands"
+	"github.com/lbpay-lab/core-dict/internal/domain/entities"
+	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
+)
+
+// requestedByCoreBanking identifies event-triggered closures in core_dict.account_closures
+const requestedByCoreBanking = "core-banking"
+
+// ErrInvalidEvent marks a message that can never be processed (malformed or incomplete);
+// it is acknowledged instead of redelivered
+var ErrInvalidEvent = errors.New("invalid AccountClosed event")
+
+// AccountClosedEvent is published by core banking when an account is closed. The account is
+// identified by account_id or, when core banking does not know it, by ispb/branch/account_number.
+type AccountClosedEvent struct {
+	EventID       string    `json:"event_id"`
+	AccountID     string    `json:"account_id"`
+	ISPB          string    `json:"ispb"`
+	Branch        string    `json:"branch"`
+	AccountNumber string    `json:"account_number"`
+	Reason        string    `json:"reason"`
+	ClosedAt      time.Time `json:"closed_at"`
+}
+
+// AccountCloser is implemented by commands.CloseAccountCommandHandler
+type AccountCloser interface {
+	Handle(ctx context.Context, cmd commands.CloseAccountCommand) (*commands.CloseAccountResult, error)
+}
+
+// AccountClosedHandler runs the account closure cascade for AccountClosed events
+type AccountClosedHandler struct {
+	closer      AccountCloser
+	accountRepo repositories.AccountRepository
+	logger      *slog.Logger
+}
+
+// NewAccountClosedHandler creates a new AccountClosed event handler
+func NewAccountClosedHandler(closer AccountCloser, accountRepo repositories.AccountRepository, logger *slog.Logger) *AccountClosedHandler {
+	return &AccountClosedHandler{
+		closer:      closer,
+		accountRepo: accountRepo,
+		logger:      logger,
+	}
+}
+
+// Handle processes one event payload. A closure that leaves failed keys (PARTIAL) returns an
+// error so the message is redelivered and the closure resumes with the failed keys; a closure
+// waiting for Bacen (AWAITING_BACEN) is finished by the Bacen answers, not by redelivery.
+// Keys rejected by Bacen make the closure PARTIAL later and are retried by a new CloseAccount.
+func (h *AccountClosedHandler) Handle(ctx context.Context, payload []byte) error {
+	var event AccountClosedEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidEvent, err)
+	}
+
+	accountID, err := h.resolveAccount(ctx, &event)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.closer.Handle(ctx, commands.CloseAccountCommand{
+		AccountID:   accountID,
+		Trigger:     entities.AccountClosureTriggerEvent,
+		RequestedBy: requestedByCoreBanking,
+		Reason:      event.Reason,
+	})
+	if err != nil {
+		return fmt.Errorf("account closure failed: %w", err)
+	}
+
+	closure := result.Closure
+	h.logger.Info("AccountClosed event processed",
+		"event_id", event.EventID,
+		"account_id", accountID,
+		"status", closure.Status,
+		"keys_requested", closure.Count(entities.AccountClosureKeyRequested),
+		"keys_deleted", closure.Count(entities.AccountClosureKeyDeleted),
+		"keys_failed", closure.Count(entities.AccountClosureKeyFailed),
+	)
+
+	if closure.Status == entities.AccountClosureStatusPartial {
+		return fmt.Errorf("account closure %s is %s: %d keys failed",
+			closure.ID, closure.Status, closure.Count(entities.AccountClosureKeyFailed))
+	}
+	return nil
+}
+
+// resolveAccount returns the core-dict account ID of the event
+func (h *AccountClosedHandler) resolveAccount(ctx context.Context, event *AccountClosedEvent) (uuid.UUID, error) {
+	if event.AccountID != "" {
+		accountID, err := uuid.Parse(event.AccountID)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("%w: invalid account_id %q", ErrInvalidEvent, event.AccountID)
+		}
+		return accountID, nil
+	}
+
+	if event.ISPB == "" || event.Branch == "" || event.AccountNumber == "" {
+		return uuid.Nil, fmt.Errorf("%w: account_id or ispb/branch/account_number is required", ErrInvalidEvent)
+	}
+	account, err := h.accountRepo.FindByAccountNumber(ctx, event.ISPB, event.Branch, event.AccountNumber)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to find account %s/%s/%s: %w", event.ISPB, event.Branch, event.AccountNumber, err)
+	}
+	return account.ID, nil
+}
@@ -0,0 +1,433 @@
+package corebanking_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lbpay-lab/core-dict/internal/application/commands"
+	"github.com/lbpay-lab/core-dict/internal/domain"
+	"github.com/lbpay-lab/core-dict/internal/domain/entities"
+	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
+	"github.com/lbpay-lab/core-dict/internal/domain/valueobjects"
+	"github.com/lbpay-lab/core-dict/internal/infrastructure/corebanking"
+	"github.com/lbpay-lab/dict-contracts/events"
+)
+
+// ============================================================================
+// In-memory fakes (only the methods used by the closure cascade)
+// ============================================================================
+
+type fakeAccountRepo struct {
+	repositories.AccountRepository
+	accounts map[uuid.UUID]*entities.Account
+}
+
+func (r *fakeAccountRepo) FindByID(_ context.Context, id uuid.UUID) (*entities.Account, error) {
+	if account, ok := r.accounts[id]; ok {
+		return account, nil
+	}
+	return nil, errors.New("account not found")
+}
+
+func (r *fakeAccountRepo) FindByAccountNumber(_ context.Context, ispb, branch, number string) (*entities.Account, error) {
+	for _, account := range r.accounts {
+		if account.ISPB == ispb && account.Branch == branch && account.AccountNumber == number {
+			return account, nil
+		}
+	}
+	return nil, errors.New("account not found")
+}
+
+func (r *fakeAccountRepo) Update(_ context.Context, account *entities.Account) error {
+	r.accounts[account.ID] = account
+	return nil
+}
+
+type fakeEntryRepo struct {
+	repositories.EntryRepository
+	entries  []*entities.Entry
+	failWith map[uuid.UUID]error // Update fails for these entries
+}
+
+func (r *fakeEntryRepo) List(_ context.Context, accountID uuid.UUID, limit, offset int) ([]*entities.Entry, error) {
+	var out []*entities.Entry
+	for _, e := range r.entries {
+		if e.AccountID == accountID {
+			cp := *e
+			out = append(out, &cp)
+		}
+	}
+	if offset >= len(out) {
+		return nil, nil
+	}
+	out = out[offset:]
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (r *fakeEntryRepo) Update(_ context.Context, e *entities.Entry) error {
+	if err := r.failWith[e.ID]; err != nil {
+		return err
+	}
+	for i, existing := range r.entries {
+		if existing.ID == e.ID {
+			cp := *e
+			r.entries[i] = &cp
+		}
+	}
+	return nil
+}
+
+type fakeClaimRepo struct {
+	repositories.ClaimRepository
+	claims []*entities.Claim
+}
+
+func (r *fakeClaimRepo) FindByEntryKey(_ context.Context, entryKey string) ([]*entities.Claim, error) {
+	var out []*entities.Claim
+	for _, c := range r.claims {
+		if c.EntryKey == entryKey {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeClaimRepo) Update(_ context.Context, _ *entities.Claim) error { return nil }
+
+type fakePortabilityRepo struct {
+	byEntry map[uuid.UUID][]*entities.Portability
+}
+
+func (r *fakePortabilityRepo) FindByID(_ context.Context, id uuid.UUID) (*entities.Portability, error) {
+	for _, ps := range r.byEntry {
+		for _, p := range ps {
+			if p.ID == id {
+				return p, nil
+			}
+		}
+	}
+	return nil, domain.ErrPortabilityNotFound
+}
+
+func (r *fakePortabilityRepo) FindActiveByEntryID(_ context.Context, entryID uuid.UUID) ([]*entities.Portability, error) {
+	var out []*entities.Portability
+	for _, p := range r.byEntry[entryID] {
+		if !p.IsFinal() {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakePortabilityRepo) Update(_ context.Context, _ *entities.Portability) error { return nil }
+
+type fakeClosureRepo struct {
+	closures map[uuid.UUID]*entities.AccountClosure // by account
+	keys     map[uuid.UUID]entities.AccountClosureKey
+}
+
+func newFakeClosureRepo() *fakeClosureRepo {
+	return &fakeClosureRepo{
+		closures: make(map[uuid.UUID]*entities.AccountClosure),
+		keys:     make(map[uuid.UUID]entities.AccountClosureKey),
+	}
+}
+
+func (r *fakeClosureRepo) Create(_ context.Context, closure *entities.AccountClosure) error {
+	cp := *closure
+	r.closures[closure.AccountID] = &cp
+	return nil
+}
+
+func (r *fakeClosureRepo) Update(_ context.Context, closure *entities.AccountClosure) error {
+	stored := r.closures[closure.AccountID]
+	stored.Status = closure.Status
+	stored.CompletedAt = closure.CompletedAt
+	return nil
+}
+
+func (r *fakeClosureRepo) FindByAccountID(_ context.Context, accountID uuid.UUID) (*entities.AccountClosure, error) {
+	stored, ok := r.closures[accountID]
+	if !ok {
+		return nil, nil
+	}
+	closure := *stored
+	closure.Keys = nil
+	for _, key := range r.keys {
+		k := key
+		closure.Keys = append(closure.Keys, &k)
+	}
+	return &closure, nil
+}
+
+func (r *fakeClosureRepo) FindByRequestedKey(ctx context.Context, entryID uuid.UUID) (*entities.AccountClosure, error) {
+	key, ok := r.keys[entryID]
+	if !ok || key.Outcome != entities.AccountClosureKeyRequested {
+		return nil, nil
+	}
+	for accountID := range r.closures {
+		return r.FindByAccountID(ctx, accountID)
+	}
+	return nil, nil
+}
+
+func (r *fakeClosureRepo) SaveKey(_ context.Context, _ uuid.UUID, key *entities.AccountClosureKey) error {
+	r.keys[key.EntryID] = *key
+	return nil
+}
+
+type fakeEventPublisher struct {
+	events []commands.DomainEvent
+}
+
+func (p *fakeEventPublisher) Publish(_ context.Context, e commands.DomainEvent) error {
+	p.events = append(p.events, e)
+	return nil
+}
+
+func (p *fakeEventPublisher) ofType(eventType string) []commands.DomainEvent {
+	var out []commands.DomainEvent
+	for _, e := range p.events {
+		if e.EventType == eventType {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+type fakeCache struct{}
+
+func (fakeCache) Get(context.Context, string) (interface{}, error)              { return nil, errors.New("miss") }
+func (fakeCache) Set(context.Context, string, interface{}, time.Duration) error { return nil }
+func (fakeCache) Delete(context.Context, string) error                          { return nil }
+func (fakeCache) Exists(context.Context, string) (bool, error)                  { return false, nil }
+func (fakeCache) Invalidate(context.Context, string) error                      { return nil }
+
+// ============================================================================
+// Helpers
+// ============================================================================
+
+type closureTestEnv struct {
+	handler     *corebanking.AccountClosedHandler
+	confirm     *commands.ConfirmAccountClosureKeyCommandHandler
+	account     *entities.Account
+	accounts    *fakeAccountRepo
+	entries     *fakeEntryRepo
+	claims      *fakeClaimRepo
+	closures    *fakeClosureRepo
+	publisher   *fakeEventPublisher
+	keys        []*entities.Entry // EVP, EMAIL (open claim and portability), PHONE (already deleted)
+	claim       *entities.Claim
+	portability *entities.Portability
+}
+
+func newClosureTestEnv(t *testing.T) *closureTestEnv {
+	t.Helper()
+	account, err := entities.NewAccount("12345678", "0001", "123456", entities.AccountTypeCACC, entities.Owner{
+		TaxID: "12345678901",
+		Type:  entities.OwnerTypeNaturalPerson,
+		Name:  "John Doe",
+	})
+	require.NoError(t, err)
+
+	newKey := func(keyType entities.KeyType, value string, status entities.KeyStatus) *entities.Entry {
+		return &entities.Entry{ID: uuid.New(), KeyType: keyType, KeyValue: value, Status: status, AccountID: account.ID}
+	}
+	keys := []*entities.Entry{
+		newKey(entities.KeyTypeEVP, uuid.NewString(), entities.KeyStatusActive),
+		newKey(entities.KeyTypeEmail, "john@example.com", entities.KeyStatusActive),
+		newKey(entities.KeyTypePhone, "+5511999999999", entities.KeyStatusDeleted),
+	}
+	claim := &entities.Claim{ID: uuid.New(), EntryKey: "john@example.com", Status: valueobjects.ClaimStatusOpen}
+	portability := &entities.Portability{ID: uuid.New(), Status: entities.PortabilityStatusPendingApproval}
+
+	env := &closureTestEnv{
+		account:     account,
+		accounts:    &fakeAccountRepo{accounts: map[uuid.UUID]*entities.Account{account.ID: account}},
+		entries:     &fakeEntryRepo{entries: keys, failWith: make(map[uuid.UUID]error)},
+		claims:      &fakeClaimRepo{claims: []*entities.Claim{claim}},
+		closures:    newFakeClosureRepo(),
+		publisher:   &fakeEventPublisher{},
+		keys:        keys,
+		claim:       claim,
+		portability: portability,
+	}
+	portabilities := &fakePortabilityRepo{byEntry: map[uuid.UUID][]*entities.Portability{keys[1].ID: {portability}}}
+
+	closeCmd := commands.NewCloseAccountCommandHandler(
+		env.accounts, env.entries, env.claims, portabilities, env.closures, env.publisher, fakeCache{}, nil,
+	)
+	env.handler = corebanking.NewAccountClosedHandler(closeCmd, env.accounts, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	env.confirm = commands.NewConfirmAccountClosureKeyCommandHandler(env.closures, nil)
+	return env
+}
+
+// bacenAnswer simulates the EntryStatusChanged event of conn-dict for a deleted key
+func (env *closureTestEnv) bacenAnswer(t *testing.T, entry *entities.Entry, bacenErr string) bool {
+	t.Helper()
+	result, err := env.confirm.Handle(context.Background(), commands.ConfirmAccountClosureKeyCommand{
+		EntryID:  entry.ID,
+		BacenErr: bacenErr,
+	})
+	require.NoError(t, err)
+	return result.Confirmed
+}
+
+func eventPayload(t *testing.T, event corebanking.AccountClosedEvent) []byte {
+	t.Helper()
+	payload, err := json.Marshal(event)
+	require.NoError(t, err)
+	return payload
+}
+
+// ============================================================================
+// Tests
+// ============================================================================
+
+func TestAccountClosed_DeletesAllKeysOfTheAccount(t *testing.T) {
+	env := newClosureTestEnv(t)
+
+	err := env.handler.Handle(context.Background(), eventPayload(t, corebanking.AccountClosedEvent{
+		EventID:   "evt-1",
+		AccountID: env.account.ID.String(),
+		Reason:    "customer request",
+	}))
+	require.NoError(t, err)
+
+	assert.True(t, env.account.IsClosed())
+	for _, key := range env.entries.entries {
+		assert.Equal(t, entities.KeyStatusDeleted, key.Status)
+	}
+
+	// Chaves excluídas no Bacen com motivo de encerramento de conta
+	deleted := env.publisher.ofType("EntryDeleted")
+	require.Len(t, deleted, 2)
+	for _, event := range deleted {
+		assert.Equal(t, entities.AccountClosureDeletionReason, event.Payload.(*events.EntryDeletedEvent).Reason)
+	}
+	cancelled := env.publisher.ofType("ClaimCancelled")
+	require.Len(t, cancelled, 1)
+	assert.Equal(t, env.claim.ID.String(), cancelled[0].Payload.(*events.ClaimCancelledEvent).ClaimID)
+	assert.Len(t, env.publisher.ofType("PortabilityCancelled"), 1)
+	assert.Equal(t, valueobjects.ClaimStatusCancelled, env.claim.Status)
+	assert.Equal(t, entities.PortabilityStatusCancelled, env.portability.Status)
+
+	// Resultado por chave (a chave já excluída não entra no encerramento): aguardando o Bacen
+	closure := env.closures.closures[env.account.ID]
+	assert.Equal(t, entities.AccountClosureTriggerEvent, closure.Trigger)
+	assert.Equal(t, entities.AccountClosureStatusAwaitingBacen, closure.Status)
+	require.Len(t, env.closures.keys, 2)
+	emailKey := env.closures.keys[env.keys[1].ID]
+	assert.Equal(t, entities.AccountClosureKeyRequested, emailKey.Outcome)
+	assert.Equal(t, 1, emailKey.ClaimsCancelled)
+	assert.Equal(t, 1, emailKey.PortabilitiesCancelled)
+	assert.NotContains(t, env.closures.keys, env.keys[2].ID)
+
+	// O encerramento só é concluído quando o Bacen confirma a última exclusão
+	assert.True(t, env.bacenAnswer(t, env.keys[0], ""))
+	assert.Equal(t, entities.AccountClosureStatusAwaitingBacen, env.closures.closures[env.account.ID].Status)
+	assert.True(t, env.bacenAnswer(t, env.keys[1], ""))
+	assert.Equal(t, entities.AccountClosureStatusCompleted, env.closures.closures[env.account.ID].Status)
+	assert.NotNil(t, env.closures.closures[env.account.ID].CompletedAt)
+	assert.Equal(t, entities.AccountClosureKeyDeleted, env.closures.keys[env.keys[1].ID].Outcome)
+
+	// Reentrega da resposta não altera o resultado
+	assert.False(t, env.bacenAnswer(t, env.keys[1], "late duplicate"))
+	assert.Equal(t, entities.AccountClosureKeyDeleted, env.closures.keys[env.keys[1].ID].Outcome)
+}
+
+func TestAccountClosed_KeyRejectedByBacenIsRetried(t *testing.T) {
+	env := newClosureTestEnv(t)
+	payload := eventPayload(t, corebanking.AccountClosedEvent{EventID: "evt-1", AccountID: env.account.ID.String()})
+	require.NoError(t, env.handler.Handle(context.Background(), payload))
+
+	// Bacen rejeita uma das exclusões: a chave fica FAILED e o encerramento PARTIAL
+	assert.True(t, env.bacenAnswer(t, env.keys[0], "Bacen rejected the deletion [ENTRY_LOCKED]"))
+	assert.True(t, env.bacenAnswer(t, env.keys[1], ""))
+	assert.Equal(t, entities.AccountClosureStatusPartial, env.closures.closures[env.account.ID].Status)
+	rejected := env.closures.keys[env.keys[0].ID]
+	assert.Equal(t, entities.AccountClosureKeyFailed, rejected.Outcome)
+	assert.Contains(t, rejected.Error, "ENTRY_LOCKED")
+
+	// Novo encerramento: só a chave rejeitada é excluída de novo, embora já esteja DELETED no core-dict
+	require.NoError(t, env.handler.Handle(context.Background(), payload))
+	deleted := env.publisher.ofType("EntryDeleted")
+	require.Len(t, deleted, 3)
+	assert.Equal(t, env.keys[0].ID.String(), deleted[2].Payload.(*events.EntryDeletedEvent).EntryID)
+	assert.Equal(t, 2, env.closures.keys[env.keys[0].ID].Attempts)
+	assert.Equal(t, entities.AccountClosureStatusAwaitingBacen, env.closures.closures[env.account.ID].Status)
+
+	assert.True(t, env.bacenAnswer(t, env.keys[0], ""))
+	assert.Equal(t, entities.AccountClosureStatusCompleted, env.closures.closures[env.account.ID].Status)
+}
+
+func TestAccountClosed_FailedKeyIsRetriedOnRedelivery(t *testing.T) {
+	env := newClosureTestEnv(t)
+	env.entries.failWith[env.keys[0].ID] = errors.New("connection reset")
+	payload := eventPayload(t, corebanking.AccountClosedEvent{EventID: "evt-1", AccountID: env.account.ID.String()})
+
+	// Primeira entrega: uma chave falha, o evento é devolvido (nack)
+	err := env.handler.Handle(context.Background(), payload)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, corebanking.ErrInvalidEvent)
+	assert.Equal(t, entities.AccountClosureStatusPartial, env.closures.closures[env.account.ID].Status)
+	failed := env.closures.keys[env.keys[0].ID]
+	assert.Equal(t, entities.AccountClosureKeyFailed, failed.Outcome)
+	assert.Contains(t, failed.Error, "connection reset")
+	assert.Len(t, env.publisher.ofType("EntryDeleted"), 1)
+
+	// Reentrega: só a chave que falhou é processada
+	delete(env.entries.failWith, env.keys[0].ID)
+	require.NoError(t, env.handler.Handle(context.Background(), payload))
+	assert.Equal(t, entities.AccountClosureStatusAwaitingBacen, env.closures.closures[env.account.ID].Status)
+	retried := env.closures.keys[env.keys[0].ID]
+	assert.Equal(t, entities.AccountClosureKeyRequested, retried.Outcome)
+	assert.Equal(t, 2, retried.Attempts)
+	assert.Empty(t, retried.Error)
+	assert.Len(t, env.publisher.ofType("EntryDeleted"), 2)
+	assert.Len(t, env.publisher.ofType("ClaimCancelled"), 1)
+}
+
+func TestAccountClosed_ResolvesAccountByNumber(t *testing.T) {
+	env := newClosureTestEnv(t)
+
+	err := env.handler.Handle(context.Background(), eventPayload(t, corebanking.AccountClosedEvent{
+		ISPB:          "12345678",
+		Branch:        "0001",
+		AccountNumber: "123456",
+	}))
+	require.NoError(t, err)
+	assert.Contains(t, env.closures.closures, env.account.ID)
+}
+
+func TestAccountClosed_InvalidEvents(t *testing.T) {
+	env := newClosureTestEnv(t)
+
+	tests := []struct {
+		name    string
+		payload []byte
+	}{
+		{"malformed", []byte("{not json")},
+		{"no account", eventPayload(t, corebanking.AccountClosedEvent{EventID: "evt-1"})},
+		{"invalid account id", eventPayload(t, corebanking.AccountClosedEvent{AccountID: "123"})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := env.handler.Handle(context.Background(), tt.payload)
+			assert.ErrorIs(t, err, corebanking.ErrInvalidEvent)
+		})
+	}
+	assert.Empty(t, env.closures.closures)
+}
@@ -0,0 +1,143 @@
+package corebanking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+)
+
+// ConsumerConfig configures the AccountClosed consumer
+type ConsumerConfig struct {
+	PulsarURL        string
+	Topic            string
+	SubscriptionName string
+	DLQTopic         string
+	// Deliveries before a message goes to the DLQ (an account with keys that keep failing)
+	MaxDeliveries       uint32
+	NackRedeliveryDelay time.Duration
+	HandleTimeout       time.Duration
+}
+
+// DefaultConsumerConfig returns the default AccountClosed consumer configuration
+func DefaultConsumerConfig() ConsumerConfig {
+	return ConsumerConfig{
+		PulsarURL:           "pulsar://localhost:6650",
+		Topic:               "persistent://core-banking/accounts/account-closed",
+		SubscriptionName:    "core-dict-account-closure",
+		DLQTopic:            "persistent://core-banking/accounts/account-closed-dlq",
+		MaxDeliveries:       10,
+		NackRedeliveryDelay: time.Minute,
+		HandleTimeout:       2 * time.Minute,
+	}
+}
+
+// AccountClosedConsumer consumes AccountClosed events from Pulsar. It uses a Key_Shared
+// subscription, so events of the same account (message key) are processed by one replica at a time.
+type AccountClosedConsumer struct {
+	client   pulsar.Client
+	consumer pulsar.Consumer
+	handler  *AccountClosedHandler
+	config   ConsumerConfig
+	logger   *slog.Logger
+}
+
+// NewAccountClosedConsumer connects to Pulsar and subscribes to the AccountClosed topic
+func NewAccountClosedConsumer(config ConsumerConfig, handler *AccountClosedHandler, logger *slog.Logger) (*AccountClosedConsumer, error) {
+	defaults := DefaultConsumerConfig()
+	if config.MaxDeliveries == 0 {
+		config.MaxDeliveries = defaults.MaxDeliveries
+	}
+	if config.NackRedeliveryDelay <= 0 {
+		config.NackRedeliveryDelay = defaults.NackRedeliveryDelay
+	}
+	if config.HandleTimeout <= 0 {
+		config.HandleTimeout = defaults.HandleTimeout
+	}
+
+	client, err := pulsar.NewClient(pulsar.ClientOptions{
+		URL:               config.PulsarURL,
+		ConnectionTimeout: 5 * time.Second,
+		OperationTimeout:  30 * time.Second,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Pulsar client: %w", err)
+	}
+
+	consumer, err := client.Subscribe(pulsar.ConsumerOptions{
+		Topic:                       config.Topic,
+		SubscriptionName:            config.SubscriptionName,
+		Type:                        pulsar.KeyShared,
+		SubscriptionInitialPosition: pulsar.SubscriptionPositionEarliest,
+		NackRedeliveryDelay:         config.NackRedeliveryDelay,
+		DLQ: &pulsar.DLQPolicy{
+			MaxDeliveries:   config.MaxDeliveries,
+			DeadLetterTopic: config.DLQTopic,
+		},
+	})
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to subscribe to %s: %w", config.Topic, err)
+	}
+
+	return &AccountClosedConsumer{
+		client:   client,
+		consumer: consumer,
+		handler:  handler,
+		config:   config,
+		logger:   logger,
+	}, nil
+}
+
+// Start consumes events until the context is cancelled
+func (c *AccountClosedConsumer) Start(ctx context.Context) {
+	go func() {
+		for {
+			msg, err := c.consumer.Receive(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				c.logger.Error("AccountClosed consumer: receive failed", "error", err)
+				time.Sleep(time.Second)
+				continue
+			}
+			c.process(ctx, msg)
+		}
+	}()
+}
+
+// process handles one message: ack on success or on an invalid event, nack otherwise
+func (c *AccountClosedConsumer) process(ctx context.Context, msg pulsar.Message) {
+	handleCtx, cancel := context.WithTimeout(ctx, c.config.HandleTimeout)
+	defer cancel()
+
+	err := c.handler.Handle(handleCtx, msg.Payload())
+	switch {
+	case err == nil:
+		c.ack(msg)
+	case errors.Is(err, ErrInvalidEvent):
+		c.logger.Error("AccountClosed consumer: discarding invalid event",
+			"error", err, "message_id", msg.ID().String())
+		c.ack(msg)
+	default:
+		c.logger.Warn("AccountClosed consumer: event will be redelivered",
+			"error", err, "message_id", msg.ID().String(), "redelivery_count", msg.RedeliveryCount())
+		c.consumer.Nack(msg)
+	}
+}
+
+func (c *AccountClosedConsumer) ack(msg pulsar.Message) {
+	if err := c.consumer.Ack(msg); err != nil {
+		c.logger.Error("AccountClosed consumer: ack failed", "error", err, "message_id", msg.ID().String())
+	}
+}
+
+// Close closes the subscription and the client
+func (c *AccountClosedConsumer) Close() {
+	c.consumer.Close()
+	c.client.Close()
+}
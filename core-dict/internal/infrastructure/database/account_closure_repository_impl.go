@@ -0,0 +1,197 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/lbpay-lab/core-dict/internal/domain/entities"
+	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
+)
+
+// PostgresAccountClosureRepository implements AccountClosureRepository using PostgreSQL
+type PostgresAccountClosureRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresAccountClosureRepository creates a new account closure repository
+func NewPostgresAccountClosureRepository(pool *pgxpool.Pool) repositories.AccountClosureRepository {
+	return &PostgresAccountClosureRepository{pool: pool}
+}
+
+// Create inserts a closure. A second closure of the same account fails on the account_id
+// unique constraint (concurrent RPC and AccountClosed event); the caller retries and resumes it.
+func (r *PostgresAccountClosureRepository) Create(ctx context.Context, closure *entities.AccountClosure) error {
+	query := `
+		INSERT INTO core_dict.account_closures (
+			id, account_id, trigger, requested_by, reason, status, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, $8)
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		closure.ID,
+		closure.AccountID,
+		string(closure.Trigger),
+		closure.RequestedBy,
+		closure.Reason,
+		string(closure.Status),
+		closure.CreatedAt,
+		closure.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create account closure: %w", err)
+	}
+
+	return nil
+}
+
+// Update updates the closure status
+func (r *PostgresAccountClosureRepository) Update(ctx context.Context, closure *entities.AccountClosure) error {
+	query := `
+		UPDATE core_dict.account_closures
+		SET status = $2, updated_at = $3, completed_at = $4
+		WHERE id = $1
+	`
+
+	result, err := conn(ctx, r.pool).Exec(ctx, query,
+		closure.ID,
+		string(closure.Status),
+		closure.UpdatedAt,
+		closure.CompletedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update account closure: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("account closure not found: %s", closure.ID)
+	}
+
+	return nil
+}
+
+// FindByAccountID returns the closure of the account with its key outcomes, or nil if the
+// account was never closed. Inside a transaction the closure row stays locked until commit.
+func (r *PostgresAccountClosureRepository) FindByAccountID(ctx context.Context, accountID uuid.UUID) (*entities.AccountClosure, error) {
+	query := `
+		SELECT id, account_id, trigger, requested_by, COALESCE(reason, ''), status,
+		       created_at, updated_at, completed_at
+		FROM core_dict.account_closures
+		WHERE account_id = $1
+		FOR UPDATE
+	`
+	return r.find(ctx, query, accountID)
+}
+
+// FindByRequestedKey returns the closure waiting for the Bacen answer to the deletion of the key,
+// or nil if none is. Inside a transaction the closure row stays locked until commit.
+func (r *PostgresAccountClosureRepository) FindByRequestedKey(ctx context.Context, entryID uuid.UUID) (*entities.AccountClosure, error) {
+	query := `
+		SELECT c.id, c.account_id, c.trigger, c.requested_by, COALESCE(c.reason, ''), c.status,
+		       c.created_at, c.updated_at, c.completed_at
+		FROM core_dict.account_closures c
+		JOIN core_dict.account_closure_keys k ON k.closure_id = c.id
+		WHERE k.entry_id = $1 AND k.outcome = $2
+		FOR UPDATE OF c
+	`
+	return r.find(ctx, query, entryID, string(entities.AccountClosureKeyRequested))
+}
+
+// find loads the closure selected by query with its key outcomes
+func (r *PostgresAccountClosureRepository) find(ctx context.Context, query string, args ...interface{}) (*entities.AccountClosure, error) {
+	closure := &entities.AccountClosure{}
+	var trigger, status string
+	err := conn(ctx, r.pool).QueryRow(ctx, query, args...).Scan(
+		&closure.ID,
+		&closure.AccountID,
+		&trigger,
+		&closure.RequestedBy,
+		&closure.Reason,
+		&status,
+		&closure.CreatedAt,
+		&closure.UpdatedAt,
+		&closure.CompletedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find account closure: %w", err)
+	}
+	closure.Trigger = entities.AccountClosureTrigger(trigger)
+	closure.Status = entities.AccountClosureStatus(status)
+
+	keysQuery := `
+		SELECT entry_id, key_type, outcome, claims_cancelled, portabilities_cancelled,
+		       COALESCE(error, ''), attempts, processed_at
+		FROM core_dict.account_closure_keys
+		WHERE closure_id = $1
+		ORDER BY processed_at
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, keysQuery, closure.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list account closure keys: %w", err)
+	}
+	closure.Keys, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (*entities.AccountClosureKey, error) {
+		key := &entities.AccountClosureKey{}
+		var keyType, outcome string
+		if err := row.Scan(
+			&key.EntryID,
+			&keyType,
+			&outcome,
+			&key.ClaimsCancelled,
+			&key.PortabilitiesCancelled,
+			&key.Error,
+			&key.Attempts,
+			&key.ProcessedAt,
+		); err != nil {
+			return nil, err
+		}
+		key.KeyType = entities.KeyType(keyType)
+		key.Outcome = entities.AccountClosureKeyOutcome(outcome)
+		return key, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan account closure keys: %w", err)
+	}
+
+	return closure, nil
+}
+
+// SaveKey upserts the outcome of a key; it joins the transaction carried by ctx, if any
+func (r *PostgresAccountClosureRepository) SaveKey(ctx context.Context, closureID uuid.UUID, key *entities.AccountClosureKey) error {
+	query := `
+		INSERT INTO core_dict.account_closure_keys (
+			closure_id, entry_id, key_type, outcome, claims_cancelled,
+			portabilities_cancelled, error, attempts, processed_at
+		) VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), $8, $9)
+		ON CONFLICT (closure_id, entry_id) DO UPDATE SET
+			outcome = EXCLUDED.outcome,
+			claims_cancelled = EXCLUDED.claims_cancelled,
+			portabilities_cancelled = EXCLUDED.portabilities_cancelled,
+			error = EXCLUDED.error,
+			attempts = EXCLUDED.attempts,
+			processed_at = EXCLUDED.processed_at
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		closureID,
+		key.EntryID,
+		string(key.KeyType),
+		string(key.Outcome),
+		key.ClaimsCancelled,
+		key.PortabilitiesCancelled,
+		key.Error,
+		key.Attempts,
+		key.ProcessedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save account closure key: %w", err)
+	}
+
+	return nil
+}
@@ -11,48 +11,46 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/lbpay-lab/core-dict/internal/domain/entities"
 	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
+	"github.com/lbpay-lab/dict-contracts/fieldcrypto"
 )
 
-// PostgresAccountRepository implements AccountRepository using PostgreSQL
+// accountColumns are the columns read by scanAccount
+const accountColumns = `
+			id, participant_ispb, branch_code, account_number,
+			account_type, account_status,
+			holder_name, holder_name_encrypted,
+			holder_document, holder_document_encrypted, holder_document_type,
+			created_at, updated_at`
+
+// holderDocumentFilter matches holder_document by blind index, or by plaintext for accounts not
+// yet migrated by the re-encryption job
+const holderDocumentFilter = " AND (holder_document_index = $%d OR (holder_document_index IS NULL AND holder_document = $%d))"
+
+// PostgresAccountRepository implements AccountRepository using PostgreSQL.
+// The holder name and document are stored encrypted (see fieldcrypto).
 type PostgresAccountRepository struct {
-	pool *pgxpool.Pool
+	pool   *pgxpool.Pool
+	cipher *fieldcrypto.Cipher
 }
 
 // NewPostgresAccountRepository creates a new account repository
-func NewPostgresAccountRepository(pool *pgxpool.Pool) repositories.AccountRepository {
+func NewPostgresAccountRepository(pool *pgxpool.Pool, cipher *fieldcrypto.Cipher) repositories.AccountRepository {
 	return &PostgresAccountRepository{
-		pool: pool,
+		pool:   pool,
+		cipher: cipher,
 	}
 }
 
 // FindByID finds an account by ID
 func (r *PostgresAccountRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.Account, error) {
 	query := `
-		SELECT
-			id, participant_ispb, branch_code, account_number,
-			account_type, account_status, holder_name,
-			holder_document, holder_document_type,
-			created_at, updated_at
+		SELECT` + accountColumns + `
 		FROM core_dict.accounts
 		WHERE id = $1 AND deleted_at IS NULL
 		LIMIT 1
 	`
 
-	var account entities.Account
-	var ownerType string
-	err := r.pool.QueryRow(ctx, query, id).Scan(
-		&account.ID,
-		&account.ISPB,
-		&account.Branch,
-		&account.AccountNumber,
-		&account.AccountType,
-		&account.Status,
-		&account.Owner.Name,
-		&account.Owner.TaxID,
-		&ownerType,
-		&account.CreatedAt,
-		&account.UpdatedAt,
-	)
+	account, err := r.scanAccount(ctx, r.pool.QueryRow(ctx, query, id))
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -61,18 +59,13 @@ func (r *PostgresAccountRepository) FindByID(ctx context.Context, id uuid.UUID)
 		return nil, fmt.Errorf("failed to find account: %w", err)
 	}
 
-	account.Owner.Type = entities.OwnerType(ownerType)
-	return &account, nil
+	return account, nil
 }
 
 // FindByAccountNumber finds an account by ISPB + branch + account number
 func (r *PostgresAccountRepository) FindByAccountNumber(ctx context.Context, ispb, branch, accountNumber string) (*entities.Account, error) {
 	query := `
-		SELECT
-			id, participant_ispb, branch_code, account_number,
-			account_type, account_status, holder_name,
-			holder_document, holder_document_type,
-			created_at, updated_at
+		SELECT` + accountColumns + `
 		FROM core_dict.accounts
 		WHERE participant_ispb = $1
 			AND branch_code = $2
@@ -81,21 +74,7 @@ func (r *PostgresAccountRepository) FindByAccountNumber(ctx context.Context, isp
 		LIMIT 1
 	`
 
-	var account entities.Account
-	var ownerType string
-	err := r.pool.QueryRow(ctx, query, ispb, branch, accountNumber).Scan(
-		&account.ID,
-		&account.ISPB,
-		&account.Branch,
-		&account.AccountNumber,
-		&account.AccountType,
-		&account.Status,
-		&account.Owner.Name,
-		&account.Owner.TaxID,
-		&ownerType,
-		&account.CreatedAt,
-		&account.UpdatedAt,
-	)
+	account, err := r.scanAccount(ctx, r.pool.QueryRow(ctx, query, ispb, branch, accountNumber))
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -104,8 +83,7 @@ func (r *PostgresAccountRepository) FindByAccountNumber(ctx context.Context, isp
 		return nil, fmt.Errorf("failed to find account: %w", err)
 	}
 
-	account.Owner.Type = entities.OwnerType(ownerType)
-	return &account, nil
+	return account, nil
 }
 
 // VerifyAccount verifies if an account is valid and active
@@ -136,24 +114,35 @@ func (r *PostgresAccountRepository) Create(ctx context.Context, account *entitie
 	query := `
 		INSERT INTO core_dict.accounts (
 			id, participant_ispb, branch_code, account_number,
-			account_type, account_status, holder_name,
-			holder_document, holder_document_type,
-			opened_at, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			account_type, account_status, holder_name_encrypted,
+			holder_document_encrypted, holder_document_index, holder_document_type,
+			encryption_key_id, opened_at, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 	`
 
 	documentType := getDocumentType(account.Owner.TaxID)
 
-	_, err := r.pool.Exec(ctx, query,
+	nameEncrypted, err := r.cipher.Encrypt(ctx, fieldHolderName, account.Owner.Name)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt holder name: %w", err)
+	}
+	documentEncrypted, err := r.cipher.Encrypt(ctx, fieldHolderDocument, account.Owner.TaxID)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt holder document: %w", err)
+	}
+
+	_, err = r.pool.Exec(ctx, query,
 		account.ID,
 		account.ISPB,
 		account.Branch,
 		account.AccountNumber,
 		account.AccountType,
 		account.Status,
-		account.Owner.Name,
-		account.Owner.TaxID,
+		nameEncrypted,
+		documentEncrypted,
+		r.cipher.BlindIndex(fieldHolderDocument, account.Owner.TaxID),
 		documentType,
+		r.cipher.CurrentKeyID(),
 		account.OpenedAt,
 		account.CreatedAt,
 		account.UpdatedAt,
@@ -170,15 +159,23 @@ func (r *PostgresAccountRepository) Create(ctx context.Context, account *entitie
 func (r *PostgresAccountRepository) Update(ctx context.Context, account *entities.Account) error {
 	query := `
 		UPDATE core_dict.accounts
-		SET holder_name = $2,
+		SET holder_name = NULL,
+			holder_name_encrypted = $2,
 			account_status = $3,
 			updated_at = $4
 		WHERE id = $1 AND deleted_at IS NULL
 	`
 
+	// encryption_key_id is left as is: it tracks the oldest KEK of the row for the
+	// re-encryption job
+	nameEncrypted, err := r.cipher.Encrypt(ctx, fieldHolderName, account.Owner.Name)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt holder name: %w", err)
+	}
+
 	result, err := r.pool.Exec(ctx, query,
 		account.ID,
-		account.Owner.Name,
+		nameEncrypted,
 		account.Status,
 		account.UpdatedAt,
 	)
@@ -222,17 +219,14 @@ func (r *PostgresAccountRepository) Delete(ctx context.Context, accountID uuid.U
 // FindByOwnerTaxID finds accounts by owner's CPF/CNPJ
 func (r *PostgresAccountRepository) FindByOwnerTaxID(ctx context.Context, taxID string) ([]*entities.Account, error) {
 	query := `
-		SELECT
-			id, participant_ispb, branch_code, account_number,
-			account_type, account_status, holder_name,
-			holder_document, holder_document_type,
-			created_at, updated_at
+		SELECT` + accountColumns + `
 		FROM core_dict.accounts
-		WHERE holder_document = $1 AND deleted_at IS NULL
+		WHERE (holder_document_index = $1 OR (holder_document_index IS NULL AND holder_document = $2))
+		  AND deleted_at IS NULL
 		ORDER BY created_at DESC
 	`
 
-	rows, err := r.pool.Query(ctx, query, taxID)
+	rows, err := r.pool.Query(ctx, query, r.cipher.BlindIndex(fieldHolderDocument, taxID), taxID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find accounts by tax ID: %w", err)
 	}
@@ -240,28 +234,12 @@ func (r *PostgresAccountRepository) FindByOwnerTaxID(ctx context.Context, taxID
 
 	var accounts []*entities.Account
 	for rows.Next() {
-		var account entities.Account
-		var ownerType string
-
-		err := rows.Scan(
-			&account.ID,
-			&account.ISPB,
-			&account.Branch,
-			&account.AccountNumber,
-			&account.AccountType,
-			&account.Status,
-			&account.Owner.Name,
-			&account.Owner.TaxID,
-			&ownerType,
-			&account.CreatedAt,
-			&account.UpdatedAt,
-		)
+		account, err := r.scanAccount(ctx, rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan account: %w", err)
 		}
 
-		account.Owner.Type = entities.OwnerType(ownerType)
-		accounts = append(accounts, &account)
+		accounts = append(accounts, account)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -274,11 +252,7 @@ func (r *PostgresAccountRepository) FindByOwnerTaxID(ctx context.Context, taxID
 // FindByISPB lists accounts for a participant with pagination
 func (r *PostgresAccountRepository) FindByISPB(ctx context.Context, ispb string, limit, offset int) ([]*entities.Account, error) {
 	query := `
-		SELECT
-			id, participant_ispb, branch_code, account_number,
-			account_type, account_status, holder_name,
-			holder_document, holder_document_type,
-			created_at, updated_at
+		SELECT` + accountColumns + `
 		FROM core_dict.accounts
 		WHERE participant_ispb = $1 AND deleted_at IS NULL
 		ORDER BY created_at DESC
@@ -293,28 +267,12 @@ func (r *PostgresAccountRepository) FindByISPB(ctx context.Context, ispb string,
 
 	var accounts []*entities.Account
 	for rows.Next() {
-		var account entities.Account
-		var ownerType string
-
-		err := rows.Scan(
-			&account.ID,
-			&account.ISPB,
-			&account.Branch,
-			&account.AccountNumber,
-			&account.AccountType,
-			&account.Status,
-			&account.Owner.Name,
-			&account.Owner.TaxID,
-			&ownerType,
-			&account.CreatedAt,
-			&account.UpdatedAt,
-		)
+		account, err := r.scanAccount(ctx, rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan account: %w", err)
 		}
 
-		account.Owner.Type = entities.OwnerType(ownerType)
-		accounts = append(accounts, &account)
+		accounts = append(accounts, account)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -349,11 +307,7 @@ func (r *PostgresAccountRepository) ExistsByAccountNumber(ctx context.Context, i
 // List lists accounts with filters and pagination
 func (r *PostgresAccountRepository) List(ctx context.Context, filters repositories.AccountFilters) ([]*entities.Account, error) {
 	query := `
-		SELECT
-			id, participant_ispb, branch_code, account_number,
-			account_type, account_status, holder_name,
-			holder_document, holder_document_type,
-			created_at, updated_at
+		SELECT` + accountColumns + `
 		FROM core_dict.accounts
 		WHERE deleted_at IS NULL
 	`
@@ -368,9 +322,9 @@ func (r *PostgresAccountRepository) List(ctx context.Context, filters repositori
 	}
 
 	if filters.OwnerTaxID != nil {
-		query += fmt.Sprintf(" AND holder_document = $%d", argPos)
-		args = append(args, *filters.OwnerTaxID)
-		argPos++
+		query += fmt.Sprintf(holderDocumentFilter, argPos, argPos+1)
+		args = append(args, r.cipher.BlindIndex(fieldHolderDocument, *filters.OwnerTaxID), *filters.OwnerTaxID)
+		argPos += 2
 	}
 
 	if filters.AccountType != nil {
@@ -406,28 +360,12 @@ func (r *PostgresAccountRepository) List(ctx context.Context, filters repositori
 
 	var accounts []*entities.Account
 	for rows.Next() {
-		var account entities.Account
-		var ownerType string
-
-		err := rows.Scan(
-			&account.ID,
-			&account.ISPB,
-			&account.Branch,
-			&account.AccountNumber,
-			&account.AccountType,
-			&account.Status,
-			&account.Owner.Name,
-			&account.Owner.TaxID,
-			&ownerType,
-			&account.CreatedAt,
-			&account.UpdatedAt,
-		)
+		account, err := r.scanAccount(ctx, rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan account: %w", err)
 		}
 
-		account.Owner.Type = entities.OwnerType(ownerType)
-		accounts = append(accounts, &account)
+		accounts = append(accounts, account)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -451,9 +389,9 @@ func (r *PostgresAccountRepository) Count(ctx context.Context, filters repositor
 	}
 
 	if filters.OwnerTaxID != nil {
-		query += fmt.Sprintf(" AND holder_document = $%d", argPos)
-		args = append(args, *filters.OwnerTaxID)
-		argPos++
+		query += fmt.Sprintf(holderDocumentFilter, argPos, argPos+1)
+		args = append(args, r.cipher.BlindIndex(fieldHolderDocument, *filters.OwnerTaxID), *filters.OwnerTaxID)
+		argPos += 2
 	}
 
 	if filters.AccountType != nil {
@@ -476,6 +414,42 @@ func (r *PostgresAccountRepository) Count(ctx context.Context, filters repositor
 	return count, nil
 }
 
+// scanAccount scans a row of accountColumns and decrypts the holder data
+func (r *PostgresAccountRepository) scanAccount(ctx context.Context, row pgxRows) (*entities.Account, error) {
+	var account entities.Account
+	var ownerType string
+	var holderName, holderDocument *string
+	var holderNameEncrypted, holderDocumentEncrypted []byte
+
+	err := row.Scan(
+		&account.ID,
+		&account.ISPB,
+		&account.Branch,
+		&account.AccountNumber,
+		&account.AccountType,
+		&account.Status,
+		&holderName,
+		&holderNameEncrypted,
+		&holderDocument,
+		&holderDocumentEncrypted,
+		&ownerType,
+		&account.CreatedAt,
+		&account.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if account.Owner.Name, err = openColumn(ctx, r.cipher, fieldHolderName, holderName, holderNameEncrypted); err != nil {
+		return nil, err
+	}
+	if account.Owner.TaxID, err = openColumn(ctx, r.cipher, fieldHolderDocument, holderDocument, holderDocumentEncrypted); err != nil {
+		return nil, err
+	}
+	account.Owner.Type = entities.OwnerType(ownerType)
+	return &account, nil
+}
+
 // getDocumentType determines if the tax ID is CPF or CNPJ based on length
 func getDocumentType(taxID string) string {
 	if len(taxID) == 11 {
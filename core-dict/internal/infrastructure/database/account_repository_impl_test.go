@@ -17,7 +17,7 @@ func TestAccountRepo_Create_Success(t *testing.T) {
 	pool, cleanup := setupTestDB(t)
 	defer cleanup()
 
-	repo := database.NewPostgresAccountRepository(pool)
+	repo := database.NewPostgresAccountRepository(pool, newTestCipher(t))
 
 	account := &entities.Account{
 		ID:            uuid.New(),
@@ -50,7 +50,7 @@ func TestAccountRepo_FindByID_Success(t *testing.T) {
 	pool, cleanup := setupTestDB(t)
 	defer cleanup()
 
-	repo := database.NewPostgresAccountRepository(pool)
+	repo := database.NewPostgresAccountRepository(pool, newTestCipher(t))
 
 	account := &entities.Account{
 		ID:            uuid.New(),
@@ -83,7 +83,7 @@ func TestAccountRepo_UpdateStatus(t *testing.T) {
 	pool, cleanup := setupTestDB(t)
 	defer cleanup()
 
-	repo := database.NewPostgresAccountRepository(pool)
+	repo := database.NewPostgresAccountRepository(pool, newTestCipher(t))
 
 	account := &entities.Account{
 		ID:            uuid.New(),
@@ -122,7 +122,7 @@ func TestAccountRepo_List_ByISPB(t *testing.T) {
 	pool, cleanup := setupTestDB(t)
 	defer cleanup()
 
-	repo := database.NewPostgresAccountRepository(pool)
+	repo := database.NewPostgresAccountRepository(pool, newTestCipher(t))
 
 	ispb := "87654321"
 
@@ -0,0 +1,75 @@
+package database
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// DefaultAuditCheckpointInterval intervalo padrão entre checkpoints da cadeia de auditoria
+const DefaultAuditCheckpointInterval = 15 * time.Minute
+
+// AuditCheckpointCreator é implementado por services.AuditChainService
+// (verifica os elos novos e assina as cabeças das cadeias)
+type AuditCheckpointCreator interface {
+	Checkpoint(ctx context.Context) (int, error)
+}
+
+// AuditChainCheckpointer grava periodicamente os checkpoints assinados da cadeia de auditoria.
+// Pode rodar em todas as réplicas: um checkpoint repetido do mesmo elo é descartado pelo banco.
+type AuditChainCheckpointer struct {
+	creator  AuditCheckpointCreator
+	interval time.Duration
+	timeout  time.Duration
+	logger   *slog.Logger
+}
+
+// NewAuditChainCheckpointer cria um novo AuditChainCheckpointer
+func NewAuditChainCheckpointer(creator AuditCheckpointCreator, interval time.Duration, logger *slog.Logger) *AuditChainCheckpointer {
+	if interval <= 0 {
+		interval = DefaultAuditCheckpointInterval
+	}
+	return &AuditChainCheckpointer{
+		creator:  creator,
+		interval: interval,
+		timeout:  interval / 2,
+		logger:   logger,
+	}
+}
+
+// Start executa um checkpoint imediato e depois a cada intervalo, até o contexto ser cancelado
+func (c *AuditChainCheckpointer) Start(ctx context.Context) {
+	go func() {
+		c.checkpointOnce(ctx)
+
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				c.checkpointOnce(ctx)
+			}
+		}
+	}()
+}
+
+// checkpointOnce executa um checkpoint com timeout; falhas são apenas logadas
+// (uma cadeia quebrada continua sem checkpoint até ser investigada)
+func (c *AuditChainCheckpointer) checkpointOnce(ctx context.Context) {
+	checkpointCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	created, err := c.creator.Checkpoint(checkpointCtx)
+	if err != nil {
+		if ctx.Err() == nil {
+			c.logger.Error("Audit chain checkpoint failed", "error", err, "checkpoints_created", created)
+		}
+		return
+	}
+	if created > 0 {
+		c.logger.Info("Audit chain checkpoints created", "checkpoints_created", created)
+	}
+}
@@ -0,0 +1,91 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// DefaultAuditLinkInterval intervalo padrão entre rodadas de encadeamento
+	DefaultAuditLinkInterval = 2 * time.Second
+
+	// DefaultAuditLinkBatchSize quantidade máxima de eventos encadeados por transação
+	DefaultAuditLinkBatchSize = 500
+)
+
+// AuditChainLinker encadeia os eventos de auditoria já commitados (audit.link_events(),
+// migration 014), fora da transação que os gravou.
+// Pode rodar em todas as réplicas: enquanto uma encadeia, as outras pulam a rodada.
+type AuditChainLinker struct {
+	pool      *pgxpool.Pool
+	interval  time.Duration
+	batchSize int
+	logger    *slog.Logger
+}
+
+// NewAuditChainLinker cria um novo AuditChainLinker
+func NewAuditChainLinker(pool *pgxpool.Pool, interval time.Duration, logger *slog.Logger) *AuditChainLinker {
+	if interval <= 0 {
+		interval = DefaultAuditLinkInterval
+	}
+	return &AuditChainLinker{
+		pool:      pool,
+		interval:  interval,
+		batchSize: DefaultAuditLinkBatchSize,
+		logger:    logger,
+	}
+}
+
+// Start encadeia os eventos pendentes a cada intervalo, até o contexto ser cancelado
+func (l *AuditChainLinker) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(l.interval)
+		defer ticker.Stop()
+
+		for {
+			l.linkPending(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// LinkOnce encadeia um lote de eventos pendentes e retorna quantos foram encadeados
+// (0 quando outra réplica está encadeando)
+func (l *AuditChainLinker) LinkOnce(ctx context.Context) (int, error) {
+	var linked int
+	if err := l.pool.QueryRow(ctx, `SELECT audit.link_events($1)`, l.batchSize).Scan(&linked); err != nil {
+		return 0, fmt.Errorf("failed to link audit events: %w", err)
+	}
+	return linked, nil
+}
+
+// linkPending encadeia lotes até esvaziar a fila; falhas são apenas logadas
+// (os eventos continuam pendentes e entram na próxima rodada)
+func (l *AuditChainLinker) linkPending(ctx context.Context) {
+	total := 0
+	for ctx.Err() == nil {
+		linked, err := l.LinkOnce(ctx)
+		total += linked
+		if err != nil {
+			if ctx.Err() == nil {
+				l.logger.Error("Audit chain linking failed", "error", err, "events_linked", total)
+			}
+			return
+		}
+		if linked < l.batchSize {
+			break
+		}
+	}
+	if total > 0 {
+		l.logger.Debug("Audit events linked", "events_linked", total)
+	}
+}
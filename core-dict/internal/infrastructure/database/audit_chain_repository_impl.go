@@ -0,0 +1,176 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/lbpay-lab/core-dict/internal/domain/entities"
+	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
+)
+
+// PostgresAuditChainRepository implements AuditChainRepository on the hash chain columns of
+// audit.entry_events, audit.chain_heads and audit.chain_checkpoints (migration 014)
+type PostgresAuditChainRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresAuditChainRepository creates a new audit chain repository
+func NewPostgresAuditChainRepository(pool *pgxpool.Pool) repositories.AuditChainRepository {
+	return &PostgresAuditChainRepository{
+		pool: pool,
+	}
+}
+
+// ListHeads lists the chain heads of the partition range; an empty stream matches all streams
+func (r *PostgresAuditChainRepository) ListHeads(ctx context.Context, fromPartition, toPartition, stream string) ([]*entities.AuditChainHead, error) {
+	query := `
+		SELECT chain_partition, stream, last_seq, last_hash, updated_at
+		FROM audit.chain_heads
+		WHERE chain_partition BETWEEN $1 AND $2
+			AND ($3 = '' OR stream = $3)
+		ORDER BY chain_partition, stream
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, fromPartition, toPartition, stream)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit chain heads: %w", err)
+	}
+	return collectChainHeads(rows)
+}
+
+// ListUncheckpointedHeads lists the heads that moved past their latest checkpoint
+func (r *PostgresAuditChainRepository) ListUncheckpointedHeads(ctx context.Context) ([]*entities.AuditChainHead, error) {
+	query := `
+		SELECT h.chain_partition, h.stream, h.last_seq, h.last_hash, h.updated_at
+		FROM audit.chain_heads h
+		WHERE h.last_seq > COALESCE((
+			SELECT MAX(c.chain_seq)
+			FROM audit.chain_checkpoints c
+			WHERE c.chain_partition = h.chain_partition AND c.stream = h.stream
+		), 0)
+		ORDER BY h.chain_partition, h.stream
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list uncheckpointed audit chain heads: %w", err)
+	}
+	return collectChainHeads(rows)
+}
+
+// ListLinks lists the links of a chain after afterSeq in chain order.
+// Every column is read in the text form hashed by audit.event_hash(); the expressions must
+// match that function so that AuditChainLink.ComputeHash reproduces the stored hash.
+func (r *PostgresAuditChainRepository) ListLinks(ctx context.Context, partition, stream string, afterSeq int64, limit int) ([]*entities.AuditChainLink, error) {
+	query := `
+		SELECT
+			chain_partition, stream, chain_seq, prev_hash, hash,
+			event_id::text, entity_type, entity_id::text, event_type,
+			COALESCE(event_subtype, ''),
+			COALESCE(user_id::text, ''),
+			COALESCE(old_values::text, ''),
+			COALESCE(new_values::text, ''),
+			COALESCE(diff::text, ''),
+			COALESCE(metadata::text, ''),
+			COALESCE(ip_address::text, ''),
+			COALESCE(user_agent, ''),
+			to_char(occurred_at AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS.US"Z"')
+		FROM audit.entry_events
+		WHERE chain_partition = $1 AND stream = $2 AND chain_seq > $3
+		ORDER BY chain_seq
+		LIMIT $4
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, partition, stream, afterSeq, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit chain links: %w", err)
+	}
+
+	links, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*entities.AuditChainLink, error) {
+		var l entities.AuditChainLink
+		err := row.Scan(
+			&l.Partition, &l.Stream, &l.Seq, &l.PrevHash, &l.Hash,
+			&l.EventID, &l.EntityType, &l.EntityID, &l.EventType,
+			&l.EventSubtype,
+			&l.UserID,
+			&l.OldValues,
+			&l.NewValues,
+			&l.Diff,
+			&l.Metadata,
+			&l.IPAddress,
+			&l.UserAgent,
+			&l.OccurredAt,
+		)
+		return &l, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan audit chain link: %w", err)
+	}
+	return links, nil
+}
+
+// ListCheckpoints lists the checkpoints of the partition range in chain order
+func (r *PostgresAuditChainRepository) ListCheckpoints(ctx context.Context, fromPartition, toPartition, stream string) ([]*entities.AuditCheckpoint, error) {
+	query := `
+		SELECT chain_partition, stream, chain_seq, hash, key_id, signature, created_at
+		FROM audit.chain_checkpoints
+		WHERE chain_partition BETWEEN $1 AND $2
+			AND ($3 = '' OR stream = $3)
+		ORDER BY chain_partition, stream, chain_seq
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, fromPartition, toPartition, stream)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit checkpoints: %w", err)
+	}
+
+	checkpoints, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*entities.AuditCheckpoint, error) {
+		var c entities.AuditCheckpoint
+		err := row.Scan(&c.Partition, &c.Stream, &c.Seq, &c.Hash, &c.KeyID, &c.Signature, &c.CreatedAt)
+		return &c, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan audit checkpoint: %w", err)
+	}
+	return checkpoints, nil
+}
+
+// CreateCheckpoint stores a checkpoint; a checkpoint of the same link written by another
+// replica is kept as is
+func (r *PostgresAuditChainRepository) CreateCheckpoint(ctx context.Context, checkpoint *entities.AuditCheckpoint) error {
+	query := `
+		INSERT INTO audit.chain_checkpoints (
+			chain_partition, stream, chain_seq, hash, key_id, signature, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (chain_partition, stream, chain_seq) DO NOTHING
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		checkpoint.Partition,
+		checkpoint.Stream,
+		checkpoint.Seq,
+		checkpoint.Hash,
+		checkpoint.KeyID,
+		checkpoint.Signature,
+		checkpoint.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create audit checkpoint: %w", err)
+	}
+	return nil
+}
+
+func collectChainHeads(rows pgx.Rows) ([]*entities.AuditChainHead, error) {
+	heads, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*entities.AuditChainHead, error) {
+		var h entities.AuditChainHead
+		err := row.Scan(&h.Partition, &h.Stream, &h.LastSeq, &h.LastHash, &h.UpdatedAt)
+		return &h, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan audit chain head: %w", err)
+	}
+	return heads, nil
+}
@@ -37,7 +37,7 @@ func (r *PostgresAuditRepository) FindByEntityID(ctx context.Context, entityType
 		LIMIT $3 OFFSET $4
 	`
 
-	rows, err := r.pool.Query(ctx, query, entityType, entityID, limit, offset)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, entityType, entityID, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find audit events: %w", err)
 	}
@@ -71,7 +71,7 @@ func (r *PostgresAuditRepository) FindByActor(ctx context.Context, actorID uuid.
 		LIMIT $2 OFFSET $3
 	`
 
-	rows, err := r.pool.Query(ctx, query, actorID, limit, offset)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, actorID, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find audit logs: %w", err)
 	}
@@ -128,12 +128,14 @@ func (r *PostgresAuditRepository) FindByActor(ctx context.Context, actorID uuid.
 
 // Create creates a new audit event
 func (r *PostgresAuditRepository) Create(ctx context.Context, event *entities.AuditEvent) error {
+	// Events raised outside a request (jobs, CLI) carry no IP address or user agent
 	query := `
 		INSERT INTO audit.entry_events (
 			event_id, entity_type, entity_id, event_type,
 			user_id, old_values, new_values, metadata,
 			ip_address, user_agent, occurred_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, '')::inet, NULLIF($10, ''), $11)
+		RETURNING chain_seq, prev_hash, hash
 	`
 
 	// Marshal maps to JSONB
@@ -152,7 +154,8 @@ func (r *PostgresAuditRepository) Create(ctx context.Context, event *entities.Au
 		return fmt.Errorf("failed to marshal metadata: %w", err)
 	}
 
-	_, err = r.pool.Exec(ctx, query,
+	// The hash chain link is assigned after commit by audit.link_events() (AuditChainLinker)
+	err = conn(ctx, r.pool).QueryRow(ctx, query,
 		event.EventID,
 		event.EntityType,
 		event.EntityID,
@@ -164,7 +167,7 @@ func (r *PostgresAuditRepository) Create(ctx context.Context, event *entities.Au
 		event.IPAddress,
 		event.UserAgent,
 		event.OccurredAt,
-	)
+	).Scan(&event.ChainSeq, &event.PrevHash, &event.Hash)
 
 	if err != nil {
 		return fmt.Errorf("failed to create audit event: %w", err)
@@ -188,7 +191,7 @@ func (r *PostgresAuditRepository) FindByID(ctx context.Context, eventID uuid.UUI
 	var event entities.AuditEvent
 	var oldValuesJSON, newValuesJSON, metadataJSON []byte
 
-	err := r.pool.QueryRow(ctx, query, eventID).Scan(
+	err := conn(ctx, r.pool).QueryRow(ctx, query, eventID).Scan(
 		&event.EventID,
 		&event.EntityType,
 		&event.EntityID,
@@ -242,7 +245,7 @@ func (r *PostgresAuditRepository) FindByEventType(ctx context.Context, eventType
 		LIMIT $2 OFFSET $3
 	`
 
-	rows, err := r.pool.Query(ctx, query, eventType, limit, offset)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, eventType, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find audit events by type: %w", err)
 	}
@@ -277,7 +280,7 @@ func (r *PostgresAuditRepository) FindByUserID(ctx context.Context, userID uuid.
 		LIMIT $2 OFFSET $3
 	`
 
-	rows, err := r.pool.Query(ctx, query, userID, limit, offset)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, userID, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find audit events by user: %w", err)
 	}
@@ -312,7 +315,7 @@ func (r *PostgresAuditRepository) FindByDateRange(ctx context.Context, from, to
 		LIMIT $3 OFFSET $4
 	`
 
-	rows, err := r.pool.Query(ctx, query, from, to, limit, offset)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, from, to, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find audit events by date range: %w", err)
 	}
@@ -403,7 +406,7 @@ func (r *PostgresAuditRepository) List(ctx context.Context, filters repositories
 		args = append(args, filters.Offset)
 	}
 
-	rows, err := r.pool.Query(ctx, query, args...)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list audit events: %w", err)
 	}
@@ -474,7 +477,7 @@ func (r *PostgresAuditRepository) Count(ctx context.Context, filters repositorie
 	}
 
 	var count int64
-	err := r.pool.QueryRow(ctx, query, args...).Scan(&count)
+	err := conn(ctx, r.pool).QueryRow(ctx, query, args...).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count audit events: %w", err)
 	}
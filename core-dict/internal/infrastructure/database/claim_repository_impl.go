@@ -42,7 +42,7 @@ func (r *PostgresClaimRepository) FindByID(ctx context.Context, id uuid.UUID) (*
 		LIMIT 1
 	`
 
-	rows, err := r.pool.Query(ctx, query, id)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find claim: %w", err)
 	}
@@ -62,6 +62,25 @@ func (r *PostgresClaimRepository) FindByID(ctx context.Context, id uuid.UUID) (*
 
 // Create creates a new claim
 func (r *PostgresClaimRepository) Create(ctx context.Context, claim *entities.Claim) error {
+	if _, err := r.insert(ctx, claim, ""); err != nil {
+		return fmt.Errorf("failed to create claim: %w", err)
+	}
+
+	return nil
+}
+
+// CreateIfNotExists creates a claim unless a claim with the same ID is already stored
+func (r *PostgresClaimRepository) CreateIfNotExists(ctx context.Context, claim *entities.Claim) (bool, error) {
+	inserted, err := r.insert(ctx, claim, "ON CONFLICT (id) DO NOTHING")
+	if err != nil {
+		return false, fmt.Errorf("failed to create claim: %w", err)
+	}
+
+	return inserted, nil
+}
+
+// insert inserts a claim, with an optional ON CONFLICT clause, and reports whether a row was inserted
+func (r *PostgresClaimRepository) insert(ctx context.Context, claim *entities.Claim, onConflict string) (bool, error) {
 	query := `
 		INSERT INTO core_dict.claims (
 			id, entry_key, claim_type, status,
@@ -71,9 +90,9 @@ func (r *PostgresClaimRepository) Create(ctx context.Context, claim *entities.Cl
 			completion_period_days, expires_at,
 			created_at, updated_at
 		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
-	`
+	` + onConflict
 
-	_, err := r.pool.Exec(ctx, query,
+	result, err := conn(ctx, r.pool).Exec(ctx, query,
 		claim.ID,
 		claim.EntryKey,
 		claim.ClaimType,
@@ -89,12 +108,11 @@ func (r *PostgresClaimRepository) Create(ctx context.Context, claim *entities.Cl
 		claim.CreatedAt,
 		claim.UpdatedAt,
 	)
-
 	if err != nil {
-		return fmt.Errorf("failed to create claim: %w", err)
+		return false, err
 	}
 
-	return nil
+	return result.RowsAffected() == 1, nil
 }
 
 // Update updates an existing claim
@@ -111,7 +129,7 @@ func (r *PostgresClaimRepository) Update(ctx context.Context, claim *entities.Cl
 		WHERE id = $1 AND deleted_at IS NULL
 	`
 
-	result, err := r.pool.Exec(ctx, query,
+	result, err := conn(ctx, r.pool).Exec(ctx, query,
 		claim.ID,
 		claim.Status,
 		claim.ResolutionType,
@@ -143,7 +161,7 @@ func (r *PostgresClaimRepository) Delete(ctx context.Context, claimID uuid.UUID)
 	`
 
 	now := time.Now()
-	result, err := r.pool.Exec(ctx, query, claimID, now)
+	result, err := conn(ctx, r.pool).Exec(ctx, query, claimID, now)
 
 	if err != nil {
 		return fmt.Errorf("failed to delete claim: %w", err)
@@ -173,7 +191,7 @@ func (r *PostgresClaimRepository) FindByEntryKey(ctx context.Context, entryKey s
 		ORDER BY c.created_at DESC
 	`
 
-	rows, err := r.pool.Query(ctx, query, entryKey)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, entryKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find claims by entry key: %w", err)
 	}
@@ -213,7 +231,7 @@ func (r *PostgresClaimRepository) FindByStatus(ctx context.Context, status value
 		LIMIT $2 OFFSET $3
 	`
 
-	rows, err := r.pool.Query(ctx, query, status, limit, offset)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, status, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find claims by status: %w", err)
 	}
@@ -254,7 +272,7 @@ func (r *PostgresClaimRepository) FindByParticipant(ctx context.Context, ispb st
 		LIMIT $2 OFFSET $3
 	`
 
-	rows, err := r.pool.Query(ctx, query, ispb, limit, offset)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, ispb, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find claims by participant: %w", err)
 	}
@@ -290,7 +308,7 @@ func (r *PostgresClaimRepository) FindActiveByEntryID(ctx context.Context, entry
 			c.created_at, c.updated_at,
 			c.entry_key
 		FROM core_dict.claims c
-		JOIN core_dict.dict_entries e ON c.entry_key = e.key_value
+		JOIN core_dict.dict_entries e ON c.entry_id = e.id
 		WHERE e.id = $1
 		  AND c.status IN ('OPEN', 'WAITING_RESOLUTION', 'CONFIRMED')
 		  AND c.deleted_at IS NULL
@@ -298,7 +316,7 @@ func (r *PostgresClaimRepository) FindActiveByEntryID(ctx context.Context, entry
 		LIMIT 1
 	`
 
-	rows, err := r.pool.Query(ctx, query, entryID)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, entryID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find active claim by entry ID: %w", err)
 	}
@@ -336,7 +354,7 @@ func (r *PostgresClaimRepository) FindExpired(ctx context.Context, limit int) ([
 	`
 
 	now := time.Now()
-	rows, err := r.pool.Query(ctx, query, now, limit)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, now, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find expired claims: %w", err)
 	}
@@ -375,7 +393,7 @@ func (r *PostgresClaimRepository) FindByWorkflowID(ctx context.Context, workflow
 		LIMIT 1
 	`
 
-	rows, err := r.pool.Query(ctx, query, workflowID)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, workflowID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find claim by workflow ID: %w", err)
 	}
@@ -412,7 +430,7 @@ func (r *PostgresClaimRepository) FindPendingResolution(ctx context.Context, lim
 		LIMIT $1
 	`
 
-	rows, err := r.pool.Query(ctx, query, limit)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find pending resolution claims: %w", err)
 	}
@@ -447,7 +465,7 @@ func (r *PostgresClaimRepository) ExistsActiveClaim(ctx context.Context, entryKe
 	`
 
 	var exists bool
-	err := r.pool.QueryRow(ctx, query, entryKey).Scan(&exists)
+	err := conn(ctx, r.pool).QueryRow(ctx, query, entryKey).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("failed to check active claim existence: %w", err)
 	}
@@ -529,7 +547,7 @@ func (r *PostgresClaimRepository) List(ctx context.Context, filters repositories
 		args = append(args, filters.Offset)
 	}
 
-	rows, err := r.pool.Query(ctx, query, args...)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list claims: %w", err)
 	}
@@ -600,7 +618,7 @@ func (r *PostgresClaimRepository) Count(ctx context.Context, filters repositorie
 	}
 
 	var count int64
-	err := r.pool.QueryRow(ctx, query, args...).Scan(&count)
+	err := conn(ctx, r.pool).QueryRow(ctx, query, args...).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count claims: %w", err)
 	}
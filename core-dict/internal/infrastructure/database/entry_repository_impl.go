@@ -11,124 +11,87 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/lbpay-lab/core-dict/internal/domain"
 	"github.com/lbpay-lab/core-dict/internal/domain/entities"
 	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
+	"github.com/lbpay-lab/dict-contracts/fieldcrypto"
 )
 
-// PostgresEntryRepository implements EntryRepository using PostgreSQL
+// entryColumns are the columns read by scanEntry
+const entryColumns = `
+			e.id, e.key_type, e.key_value, e.key_value_encrypted, e.status,
+			e.account_id, e.participant_ispb, e.participant_branch,
+			e.created_at, e.updated_at, e.deleted_at,
+			a.account_number, a.account_type,
+			a.holder_name, a.holder_name_encrypted,
+			a.holder_document, a.holder_document_encrypted, a.holder_document_type`
+
+// PostgresEntryRepository implements EntryRepository using PostgreSQL.
+// CPF/EMAIL/PHONE key values and the owner data are stored encrypted (see fieldcrypto);
+// equality lookups use their blind indexes.
 type PostgresEntryRepository struct {
-	pool *pgxpool.Pool
+	pool   *pgxpool.Pool
+	cipher *fieldcrypto.Cipher
 }
 
 // NewPostgresEntryRepository creates a new entry repository
-func NewPostgresEntryRepository(pool *pgxpool.Pool) repositories.EntryRepository {
+func NewPostgresEntryRepository(pool *pgxpool.Pool, cipher *fieldcrypto.Cipher) repositories.EntryRepository {
 	return &PostgresEntryRepository{
-		pool: pool,
+		pool:   pool,
+		cipher: cipher,
 	}
 }
 
 // FindByKey finds a PIX key by its value
 func (r *PostgresEntryRepository) FindByKey(ctx context.Context, keyValue string) (*entities.Entry, error) {
-	// Hash the key for LGPD-compliant search
-	hash := hashKey(keyValue)
+	// Blind index lookup; key_hash matches rows not yet migrated by the re-encryption job
+	index := r.cipher.BlindIndex(fieldKeyValue, keyValue)
 
 	query := `
-		SELECT
-			e.id, e.key_type, e.key_value, e.status,
-			e.account_id, e.participant_ispb, e.participant_branch,
-			e.created_at, e.updated_at, e.deleted_at,
-			a.account_number, a.account_type, a.holder_name,
-			a.holder_document, a.holder_document_type
+		SELECT` + entryColumns + `
 		FROM core_dict.dict_entries e
 		JOIN core_dict.accounts a ON e.account_id = a.id
-		WHERE e.key_hash = $1 AND e.deleted_at IS NULL
+		WHERE (e.key_value_index = $1 OR (e.key_value_index IS NULL AND e.key_hash = $2))
+		  AND e.deleted_at IS NULL
 		LIMIT 1
 	`
 
-	var entry entities.Entry
-
-	err := r.pool.QueryRow(ctx, query, hash).Scan(
-		&entry.ID,
-		&entry.KeyType,
-		&entry.KeyValue,
-		&entry.Status,
-		&entry.AccountID,
-		&entry.ISPB,
-		&entry.Branch,
-		&entry.CreatedAt,
-		&entry.UpdatedAt,
-		&entry.DeletedAt,
-		&entry.AccountNumber,
-		&entry.AccountType,
-		&entry.OwnerName,
-		&entry.OwnerTaxID,
-		&entry.OwnerType,
-	)
-
+	entry, err := r.scanEntry(ctx, conn(ctx, r.pool).QueryRow(ctx, query, index, hashKey(keyValue)))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("key not found: %s", keyValue)
+			return nil, domain.ErrEntryNotFound.Wrap(err)
 		}
 		return nil, fmt.Errorf("failed to find key: %w", err)
 	}
 
-	return &entry, nil
+	return entry, nil
 }
 
 // FindByID finds a PIX key by its ID
 func (r *PostgresEntryRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.Entry, error) {
 	query := `
-		SELECT
-			e.id, e.key_type, e.key_value, e.status,
-			e.account_id, e.participant_ispb, e.participant_branch,
-			e.created_at, e.updated_at, e.deleted_at,
-			a.account_number, a.account_type, a.holder_name,
-			a.holder_document, a.holder_document_type
+		SELECT` + entryColumns + `
 		FROM core_dict.dict_entries e
 		JOIN core_dict.accounts a ON e.account_id = a.id
 		WHERE e.id = $1 AND e.deleted_at IS NULL
 		LIMIT 1
 	`
 
-	var entry entities.Entry
-
-	err := r.pool.QueryRow(ctx, query, id).Scan(
-		&entry.ID,
-		&entry.KeyType,
-		&entry.KeyValue,
-		&entry.Status,
-		&entry.AccountID,
-		&entry.ISPB,
-		&entry.Branch,
-		&entry.CreatedAt,
-		&entry.UpdatedAt,
-		&entry.DeletedAt,
-		&entry.AccountNumber,
-		&entry.AccountType,
-		&entry.OwnerName,
-		&entry.OwnerTaxID,
-		&entry.OwnerType,
-	)
-
+	entry, err := r.scanEntry(ctx, conn(ctx, r.pool).QueryRow(ctx, query, id))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("entry not found: %s", id)
+			return nil, domain.ErrEntryNotFound.Wrap(err).WithMetadata("entry_id", id.String())
 		}
 		return nil, fmt.Errorf("failed to find entry: %w", err)
 	}
 
-	return &entry, nil
+	return entry, nil
 }
 
 // List lists PIX keys with pagination
 func (r *PostgresEntryRepository) List(ctx context.Context, accountID uuid.UUID, limit, offset int) ([]*entities.Entry, error) {
 	query := `
-		SELECT
-			e.id, e.key_type, e.key_value, e.status,
-			e.account_id, e.participant_ispb, e.participant_branch,
-			e.created_at, e.updated_at, e.deleted_at,
-			a.account_number, a.account_type, a.holder_name,
-			a.holder_document, a.holder_document_type
+		SELECT` + entryColumns + `
 		FROM core_dict.dict_entries e
 		JOIN core_dict.accounts a ON e.account_id = a.id
 		WHERE e.account_id = $1 AND e.deleted_at IS NULL
@@ -136,7 +99,7 @@ func (r *PostgresEntryRepository) List(ctx context.Context, accountID uuid.UUID,
 		LIMIT $2 OFFSET $3
 	`
 
-	rows, err := r.pool.Query(ctx, query, accountID, limit, offset)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, accountID, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list entries: %w", err)
 	}
@@ -144,30 +107,12 @@ func (r *PostgresEntryRepository) List(ctx context.Context, accountID uuid.UUID,
 
 	var entries []*entities.Entry
 	for rows.Next() {
-		var entry entities.Entry
-
-		err := rows.Scan(
-			&entry.ID,
-			&entry.KeyType,
-			&entry.KeyValue,
-			&entry.Status,
-			&entry.AccountID,
-			&entry.ISPB,
-			&entry.Branch,
-			&entry.CreatedAt,
-			&entry.UpdatedAt,
-			&entry.DeletedAt,
-			&entry.AccountNumber,
-			&entry.AccountType,
-			&entry.OwnerName,
-			&entry.OwnerTaxID,
-			&entry.OwnerType,
-		)
+		entry, err := r.scanEntry(ctx, rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan entry: %w", err)
 		}
 
-		entries = append(entries, &entry)
+		entries = append(entries, entry)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -186,7 +131,7 @@ func (r *PostgresEntryRepository) CountByAccount(ctx context.Context, accountID
 	`
 
 	var count int64
-	err := r.pool.QueryRow(ctx, query, accountID).Scan(&count)
+	err := conn(ctx, r.pool).QueryRow(ctx, query, accountID).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count entries: %w", err)
 	}
@@ -196,21 +141,26 @@ func (r *PostgresEntryRepository) CountByAccount(ctx context.Context, accountID
 
 // Create creates a new PIX key entry
 func (r *PostgresEntryRepository) Create(ctx context.Context, entry *entities.Entry) error {
-	keyHash := hashKey(entry.KeyValue)
+	sealed, err := sealKeyValue(ctx, r.cipher, string(entry.KeyType), entry.KeyValue)
+	if err != nil {
+		return err
+	}
 
 	query := `
 		INSERT INTO core_dict.dict_entries (
-			id, key_type, key_value, key_hash,
+			id, key_type, key_value, key_value_encrypted, key_value_index, encryption_key_id,
 			status, account_id, participant_ispb, participant_branch,
 			created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 	`
 
-	_, err := r.pool.Exec(ctx, query,
+	_, err = conn(ctx, r.pool).Exec(ctx, query,
 		entry.ID,
 		entry.KeyType,
-		entry.KeyValue,
-		keyHash,
+		sealed.plaintext,
+		sealed.encrypted,
+		sealed.index,
+		sealed.keyID,
 		entry.Status,
 		entry.AccountID,
 		entry.ISPB,
@@ -235,7 +185,7 @@ func (r *PostgresEntryRepository) Update(ctx context.Context, entry *entities.En
 		WHERE id = $1 AND deleted_at IS NULL
 	`
 
-	result, err := r.pool.Exec(ctx, query,
+	result, err := conn(ctx, r.pool).Exec(ctx, query,
 		entry.ID,
 		entry.Status,
 		entry.UpdatedAt,
@@ -263,7 +213,7 @@ func (r *PostgresEntryRepository) Delete(ctx context.Context, entryID uuid.UUID)
 	`
 
 	now := time.Now()
-	result, err := r.pool.Exec(ctx, query, entryID, entities.KeyStatusDeleted, now)
+	result, err := conn(ctx, r.pool).Exec(ctx, query, entryID, entities.KeyStatusDeleted, now)
 
 	if err != nil {
 		return fmt.Errorf("failed to delete entry: %w", err)
@@ -286,7 +236,7 @@ func (r *PostgresEntryRepository) UpdateStatus(ctx context.Context, entryID uuid
 	`
 
 	now := time.Now()
-	result, err := r.pool.Exec(ctx, query, entryID, status, now)
+	result, err := conn(ctx, r.pool).Exec(ctx, query, entryID, status, now)
 
 	if err != nil {
 		return fmt.Errorf("failed to update entry status: %w", err)
@@ -301,16 +251,20 @@ func (r *PostgresEntryRepository) UpdateStatus(ctx context.Context, entryID uuid
 
 // CountByOwnerAndType counts entries by owner tax ID and key type
 func (r *PostgresEntryRepository) CountByOwnerAndType(ctx context.Context, ownerTaxID string, keyType entities.KeyType) (int, error) {
+	// The owner is the account holder; holder_document matches accounts not yet migrated
 	query := `
 		SELECT COUNT(*)
-		FROM core_dict.dict_entries
-		WHERE owner_tax_id = $1
-		  AND key_type = $2
-		  AND deleted_at IS NULL
+		FROM core_dict.dict_entries e
+		JOIN core_dict.accounts a ON e.account_id = a.id
+		WHERE (a.holder_document_index = $1 OR (a.holder_document_index IS NULL AND a.holder_document = $2))
+		  AND e.key_type = $3
+		  AND e.deleted_at IS NULL
 	`
 
+	index := r.cipher.BlindIndex(fieldHolderDocument, ownerTaxID)
+
 	var count int
-	err := r.pool.QueryRow(ctx, query, ownerTaxID, string(keyType)).Scan(&count)
+	err := conn(ctx, r.pool).QueryRow(ctx, query, index, ownerTaxID, string(keyType)).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count entries: %w", err)
 	}
@@ -318,7 +272,50 @@ func (r *PostgresEntryRepository) CountByOwnerAndType(ctx context.Context, owner
 	return count, nil
 }
 
-// hashKey creates a SHA-256 hash of the key value (LGPD compliance)
+// scanEntry scans a row of entryColumns and decrypts its personal data
+func (r *PostgresEntryRepository) scanEntry(ctx context.Context, row pgxRows) (*entities.Entry, error) {
+	var entry entities.Entry
+	var keyValue, holderName, holderDocument *string
+	var keyValueEncrypted, holderNameEncrypted, holderDocumentEncrypted []byte
+
+	err := row.Scan(
+		&entry.ID,
+		&entry.KeyType,
+		&keyValue,
+		&keyValueEncrypted,
+		&entry.Status,
+		&entry.AccountID,
+		&entry.ISPB,
+		&entry.Branch,
+		&entry.CreatedAt,
+		&entry.UpdatedAt,
+		&entry.DeletedAt,
+		&entry.AccountNumber,
+		&entry.AccountType,
+		&holderName,
+		&holderNameEncrypted,
+		&holderDocument,
+		&holderDocumentEncrypted,
+		&entry.OwnerType,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.KeyValue, err = openColumn(ctx, r.cipher, fieldKeyValue, keyValue, keyValueEncrypted); err != nil {
+		return nil, err
+	}
+	if entry.OwnerName, err = openColumn(ctx, r.cipher, fieldHolderName, holderName, holderNameEncrypted); err != nil {
+		return nil, err
+	}
+	if entry.OwnerTaxID, err = openColumn(ctx, r.cipher, fieldHolderDocument, holderDocument, holderDocumentEncrypted); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// hashKey creates the legacy SHA-256 hash of the key value. New rows leave key_hash empty: it is
+// only used to find rows not yet migrated to key_value_index by the re-encryption job.
 func hashKey(keyValue string) string {
 	hash := sha256.Sum256([]byte(keyValue))
 	return hex.EncodeToString(hash[:])
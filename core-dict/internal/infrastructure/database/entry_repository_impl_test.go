@@ -2,7 +2,12 @@ package database_test
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,6 +20,7 @@ import (
 
 	"github.com/lbpay-lab/core-dict/internal/domain/entities"
 	"github.com/lbpay-lab/core-dict/internal/infrastructure/database"
+	"github.com/lbpay-lab/dict-contracts/fieldcrypto"
 )
 
 func setupTestDB(t *testing.T) (*pgxpool.Pool, func()) {
@@ -98,9 +104,13 @@ func createSchema(t *testing.T, pool *pgxpool.Pool) {
 			account_number VARCHAR(20) NOT NULL,
 			account_type VARCHAR(20) NOT NULL,
 			account_status VARCHAR(20) NOT NULL,
-			holder_name VARCHAR(255) NOT NULL,
-			holder_document VARCHAR(14) NOT NULL,
+			holder_name VARCHAR(255),
+			holder_name_encrypted BYTEA,
+			holder_document VARCHAR(14),
+			holder_document_encrypted BYTEA,
+			holder_document_index CHAR(64),
 			holder_document_type VARCHAR(4) NOT NULL,
+			encryption_key_id VARCHAR(255),
 			opened_at TIMESTAMP,
 			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
 			updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
@@ -115,8 +125,11 @@ func createSchema(t *testing.T, pool *pgxpool.Pool) {
 		CREATE TABLE IF NOT EXISTS core_dict.dict_entries (
 			id UUID PRIMARY KEY,
 			key_type VARCHAR(10) NOT NULL,
-			key_value VARCHAR(255) NOT NULL,
-			key_hash VARCHAR(64) NOT NULL,
+			key_value VARCHAR(255),
+			key_value_encrypted BYTEA,
+			key_value_index CHAR(64),
+			encryption_key_id VARCHAR(255),
+			key_hash VARCHAR(64),
 			status VARCHAR(20) NOT NULL,
 			account_id UUID NOT NULL,
 			participant_ispb VARCHAR(8) NOT NULL,
@@ -136,6 +149,28 @@ func createSchema(t *testing.T, pool *pgxpool.Pool) {
 	require.NoError(t, err)
 }
 
+// newTestCipher creates a field cipher backed by a temporary keyring file
+func newTestCipher(t *testing.T) *fieldcrypto.Cipher {
+	key := func(b string) string {
+		return base64.StdEncoding.EncodeToString([]byte(strings.Repeat(b, 32)))
+	}
+	data, err := json.Marshal(map[string]interface{}{
+		"current_key_id": "test",
+		"keys":           map[string]string{"test": key("k")},
+		"index_key":      key("i"),
+	})
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "keyring.json")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	provider, err := fieldcrypto.NewFileKeyProvider(path)
+	require.NoError(t, err)
+	cipher, err := fieldcrypto.NewCipher(context.Background(), provider)
+	require.NoError(t, err)
+	return cipher
+}
+
 func createTestAccount(t *testing.T, pool *pgxpool.Pool) uuid.UUID {
 	ctx := context.Background()
 	accountID := uuid.New()
@@ -172,7 +207,7 @@ func TestEntryRepo_Create_Success(t *testing.T) {
 	defer cleanup()
 
 	accountID := createTestAccount(t, pool)
-	repo := database.NewPostgresEntryRepository(pool)
+	repo := database.NewPostgresEntryRepository(pool, newTestCipher(t))
 
 	entry := &entities.Entry{
 		ID:            uuid.New(),
@@ -206,7 +241,7 @@ func TestEntryRepo_FindByID_Success(t *testing.T) {
 	defer cleanup()
 
 	accountID := createTestAccount(t, pool)
-	repo := database.NewPostgresEntryRepository(pool)
+	repo := database.NewPostgresEntryRepository(pool, newTestCipher(t))
 
 	entry := &entities.Entry{
 		ID:            uuid.New(),
@@ -235,7 +270,7 @@ func TestEntryRepo_FindByID_NotFound(t *testing.T) {
 	pool, cleanup := setupTestDB(t)
 	defer cleanup()
 
-	repo := database.NewPostgresEntryRepository(pool)
+	repo := database.NewPostgresEntryRepository(pool, newTestCipher(t))
 
 	_, err := repo.FindByID(context.Background(), uuid.New())
 	assert.Error(t, err)
@@ -247,7 +282,7 @@ func TestEntryRepo_FindByKey_Success(t *testing.T) {
 	defer cleanup()
 
 	accountID := createTestAccount(t, pool)
-	repo := database.NewPostgresEntryRepository(pool)
+	repo := database.NewPostgresEntryRepository(pool, newTestCipher(t))
 
 	keyValue := "test@example.com"
 	entry := &entities.Entry{
@@ -277,7 +312,7 @@ func TestEntryRepo_Update_Success(t *testing.T) {
 	defer cleanup()
 
 	accountID := createTestAccount(t, pool)
-	repo := database.NewPostgresEntryRepository(pool)
+	repo := database.NewPostgresEntryRepository(pool, newTestCipher(t))
 
 	entry := &entities.Entry{
 		ID:            uuid.New(),
@@ -313,7 +348,7 @@ func TestEntryRepo_Delete_SoftDelete(t *testing.T) {
 	defer cleanup()
 
 	accountID := createTestAccount(t, pool)
-	repo := database.NewPostgresEntryRepository(pool)
+	repo := database.NewPostgresEntryRepository(pool, newTestCipher(t))
 
 	entry := &entities.Entry{
 		ID:            uuid.New(),
@@ -345,7 +380,7 @@ func TestEntryRepo_List_Paginated(t *testing.T) {
 	defer cleanup()
 
 	accountID := createTestAccount(t, pool)
-	repo := database.NewPostgresEntryRepository(pool)
+	repo := database.NewPostgresEntryRepository(pool, newTestCipher(t))
 
 	// Create 5 entries
 	for i := 0; i < 5; i++ {
@@ -381,7 +416,7 @@ func TestEntryRepo_TransferOwnership(t *testing.T) {
 	defer cleanup()
 
 	accountID := createTestAccount(t, pool)
-	repo := database.NewPostgresEntryRepository(pool)
+	repo := database.NewPostgresEntryRepository(pool, newTestCipher(t))
 
 	entry := &entities.Entry{
 		ID:            uuid.New(),
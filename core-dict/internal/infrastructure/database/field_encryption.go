@@ -0,0 +1,69 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lbpay-lab/dict-contracts/fieldcrypto"
+)
+
+// Encrypted columns (AES-GCM additional data and blind index domain of each value)
+const (
+	fieldKeyValue       = "core_dict.dict_entries.key_value"
+	fieldHolderDocument = "core_dict.accounts.holder_document"
+	fieldHolderName     = "core_dict.accounts.holder_name"
+	fieldWebhookSecret  = "core_dict.webhook_endpoints.secret"
+)
+
+// isPersonalKeyType reports whether keys of this type identify a natural person (LGPD).
+// CNPJ and EVP values stay in plaintext; all key types get a blind index.
+func isPersonalKeyType(keyType string) bool {
+	switch keyType {
+	case "CPF", "EMAIL", "PHONE":
+		return true
+	default:
+		return false
+	}
+}
+
+// sealedKeyValue is the stored form of a key value
+type sealedKeyValue struct {
+	plaintext *string // CNPJ and EVP only
+	encrypted []byte  // CPF, EMAIL and PHONE
+	index     string
+	keyID     *string // KEK version of encrypted
+}
+
+// sealKeyValue encrypts personal key values and computes the blind index of every key value
+func sealKeyValue(ctx context.Context, cipher *fieldcrypto.Cipher, keyType, keyValue string) (*sealedKeyValue, error) {
+	sealed := &sealedKeyValue{index: cipher.BlindIndex(fieldKeyValue, keyValue)}
+	if !isPersonalKeyType(keyType) {
+		sealed.plaintext = &keyValue
+		return sealed, nil
+	}
+
+	encrypted, err := cipher.Encrypt(ctx, fieldKeyValue, keyValue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt key value: %w", err)
+	}
+	keyID := cipher.CurrentKeyID()
+	sealed.encrypted = encrypted
+	sealed.keyID = &keyID
+	return sealed, nil
+}
+
+// openColumn returns the value of an encrypted column, or its legacy plaintext while the
+// re-encryption job has not migrated the row yet
+func openColumn(ctx context.Context, cipher *fieldcrypto.Cipher, field string, plaintext *string, encrypted []byte) (string, error) {
+	if encrypted != nil {
+		value, err := cipher.Decrypt(ctx, field, encrypted)
+		if err != nil {
+			return "", fmt.Errorf("failed to decrypt %s: %w", field, err)
+		}
+		return value, nil
+	}
+	if plaintext != nil {
+		return *plaintext, nil
+	}
+	return "", nil
+}
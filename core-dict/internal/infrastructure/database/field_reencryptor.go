@@ -0,0 +1,266 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/lbpay-lab/dict-contracts/fieldcrypto"
+)
+
+// Defaults of the re-encryption job
+const (
+	DefaultReencryptionInterval  = 10 * time.Minute
+	DefaultReencryptionBatchSize = 500
+)
+
+// FieldReencryptor migrates the encrypted columns to the current KEK.
+//
+// Each run re-encrypts, in batches, the rows sealed under an older KEK (after a rotation) and
+// the legacy rows still in plaintext (written before encryption was enabled), filling their
+// blind indexes and clearing the plaintext. Batches are locked with FOR UPDATE SKIP LOCKED, so
+// several replicas can run the job concurrently.
+type FieldReencryptor struct {
+	pool      *pgxpool.Pool
+	cipher    *fieldcrypto.Cipher
+	interval  time.Duration
+	batchSize int
+	logger    *slog.Logger
+}
+
+// NewFieldReencryptor creates a new re-encryption job
+func NewFieldReencryptor(pool *pgxpool.Pool, cipher *fieldcrypto.Cipher, interval time.Duration, batchSize int, logger *slog.Logger) *FieldReencryptor {
+	if interval <= 0 {
+		interval = DefaultReencryptionInterval
+	}
+	if batchSize <= 0 {
+		batchSize = DefaultReencryptionBatchSize
+	}
+	return &FieldReencryptor{
+		pool:      pool,
+		cipher:    cipher,
+		interval:  interval,
+		batchSize: batchSize,
+		logger:    logger,
+	}
+}
+
+// Start runs the job immediately and then at every interval, until ctx is cancelled
+func (r *FieldReencryptor) Start(ctx context.Context) {
+	go func() {
+		r.runLogged(ctx)
+
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.runLogged(ctx)
+			}
+		}
+	}()
+}
+
+func (r *FieldReencryptor) runLogged(ctx context.Context) {
+	start := time.Now()
+	entries, accounts, err := r.RunOnce(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			r.logger.Error("Field re-encryption failed", "error", err, "entries", entries, "accounts", accounts)
+		}
+		return
+	}
+	if entries > 0 || accounts > 0 {
+		r.logger.Info("Field re-encryption completed",
+			"key_id", r.cipher.CurrentKeyID(),
+			"entries", entries,
+			"accounts", accounts,
+			"duration_ms", time.Since(start).Milliseconds(),
+		)
+	}
+}
+
+// RunOnce migrates every pending row and returns how many entries and accounts were rewritten
+func (r *FieldReencryptor) RunOnce(ctx context.Context) (entries, accounts int, err error) {
+	for {
+		n, err := r.reencryptEntries(ctx)
+		entries += n
+		if err != nil {
+			return entries, accounts, err
+		}
+		if n < r.batchSize {
+			break
+		}
+	}
+
+	for {
+		n, err := r.reencryptAccounts(ctx)
+		accounts += n
+		if err != nil {
+			return entries, accounts, err
+		}
+		if n < r.batchSize {
+			break
+		}
+	}
+
+	return entries, accounts, nil
+}
+
+// reencryptEntries migrates one batch of dict_entries (soft-deleted rows included)
+func (r *FieldReencryptor) reencryptEntries(ctx context.Context) (int, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		SELECT id, key_type, key_value, key_value_encrypted
+		FROM core_dict.dict_entries
+		WHERE key_value_index IS NULL
+		   OR (key_value_encrypted IS NOT NULL AND encryption_key_id IS DISTINCT FROM $1)
+		ORDER BY id
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	`
+
+	type pendingEntry struct {
+		id        uuid.UUID
+		keyType   string
+		plaintext *string
+		encrypted []byte
+	}
+
+	rows, err := tx.Query(ctx, query, r.cipher.CurrentKeyID(), r.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to select entries to re-encrypt: %w", err)
+	}
+	pending, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (pendingEntry, error) {
+		var e pendingEntry
+		err := row.Scan(&e.id, &e.keyType, &e.plaintext, &e.encrypted)
+		return e, err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to scan entries to re-encrypt: %w", err)
+	}
+
+	update := `
+		UPDATE core_dict.dict_entries
+		SET key_value = $2,
+			key_value_encrypted = $3,
+			key_value_index = $4,
+			encryption_key_id = $5,
+			key_hash = NULL
+		WHERE id = $1
+	`
+
+	for _, e := range pending {
+		keyValue, err := openColumn(ctx, r.cipher, fieldKeyValue, e.plaintext, e.encrypted)
+		if err != nil {
+			return 0, fmt.Errorf("entry %s: %w", e.id, err)
+		}
+		sealed, err := sealKeyValue(ctx, r.cipher, e.keyType, keyValue)
+		if err != nil {
+			return 0, fmt.Errorf("entry %s: %w", e.id, err)
+		}
+		if _, err := tx.Exec(ctx, update, e.id, sealed.plaintext, sealed.encrypted, sealed.index, sealed.keyID); err != nil {
+			return 0, fmt.Errorf("failed to re-encrypt entry %s: %w", e.id, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit entries re-encryption: %w", err)
+	}
+	return len(pending), nil
+}
+
+// reencryptAccounts migrates one batch of accounts (closed accounts included). Accounts
+// pseudonymised by a data subject erasure have nothing left to encrypt and are skipped.
+func (r *FieldReencryptor) reencryptAccounts(ctx context.Context) (int, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		SELECT id, holder_name, holder_name_encrypted, holder_document, holder_document_encrypted
+		FROM core_dict.accounts
+		WHERE (holder_document_index IS NULL OR encryption_key_id IS DISTINCT FROM $1)
+		  AND (holder_document IS NOT NULL OR holder_document_encrypted IS NOT NULL)
+		ORDER BY id
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	`
+
+	type pendingAccount struct {
+		id                uuid.UUID
+		name, document    *string
+		nameEncrypted     []byte
+		documentEncrypted []byte
+	}
+
+	keyID := r.cipher.CurrentKeyID()
+	rows, err := tx.Query(ctx, query, keyID, r.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to select accounts to re-encrypt: %w", err)
+	}
+	pending, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (pendingAccount, error) {
+		var a pendingAccount
+		err := row.Scan(&a.id, &a.name, &a.nameEncrypted, &a.document, &a.documentEncrypted)
+		return a, err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to scan accounts to re-encrypt: %w", err)
+	}
+
+	update := `
+		UPDATE core_dict.accounts
+		SET holder_name = NULL,
+			holder_name_encrypted = $2,
+			holder_document = NULL,
+			holder_document_encrypted = $3,
+			holder_document_index = $4,
+			encryption_key_id = $5
+		WHERE id = $1
+	`
+
+	for _, a := range pending {
+		name, err := openColumn(ctx, r.cipher, fieldHolderName, a.name, a.nameEncrypted)
+		if err != nil {
+			return 0, fmt.Errorf("account %s: %w", a.id, err)
+		}
+		document, err := openColumn(ctx, r.cipher, fieldHolderDocument, a.document, a.documentEncrypted)
+		if err != nil {
+			return 0, fmt.Errorf("account %s: %w", a.id, err)
+		}
+
+		nameEncrypted, err := r.cipher.Encrypt(ctx, fieldHolderName, name)
+		if err != nil {
+			return 0, fmt.Errorf("account %s: failed to encrypt holder name: %w", a.id, err)
+		}
+		documentEncrypted, err := r.cipher.Encrypt(ctx, fieldHolderDocument, document)
+		if err != nil {
+			return 0, fmt.Errorf("account %s: failed to encrypt holder document: %w", a.id, err)
+		}
+
+		_, err = tx.Exec(ctx, update, a.id, nameEncrypted, documentEncrypted,
+			r.cipher.BlindIndex(fieldHolderDocument, document), keyID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to re-encrypt account %s: %w", a.id, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit accounts re-encryption: %w", err)
+	}
+	return len(pending), nil
+}
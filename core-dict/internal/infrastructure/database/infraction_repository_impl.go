@@ -34,7 +34,7 @@ func (r *PostgresInfractionRepository) Create(ctx context.Context, infraction *e
 		)
 	`
 
-	_, err := r.pool.Exec(ctx, query,
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
 		infraction.ID,
 		infraction.EntryKey,
 		infraction.Type,
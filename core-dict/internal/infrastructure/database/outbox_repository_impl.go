@@ -0,0 +1,210 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/lbpay-lab/core-dict/internal/infrastructure/outbox"
+)
+
+// outboxRelayLockID is the pg advisory lock key held by the active outbox relay
+const outboxRelayLockID int64 = 0x636f72656f7574 // "coreout"
+
+// PostgresOutboxRepository implements the outbox table (outbox.Appender and outbox.Store)
+type PostgresOutboxRepository struct {
+	pool *pgxpool.Pool
+	tm   *TransactionManager
+}
+
+// NewPostgresOutboxRepository creates a new outbox repository
+func NewPostgresOutboxRepository(pool *pgxpool.Pool) *PostgresOutboxRepository {
+	return &PostgresOutboxRepository{
+		pool: pool,
+		tm:   NewTransactionManager(pool),
+	}
+}
+
+// Append inserts an event; it joins the transaction carried by ctx, if any
+func (r *PostgresOutboxRepository) Append(ctx context.Context, event *outbox.Event) error {
+	headers, err := json.Marshal(event.Headers)
+	if err != nil {
+		return fmt.Errorf("failed to marshal outbox headers: %w", err)
+	}
+
+	query := `
+		INSERT INTO core_dict.outbox_events (
+			event_id, event_type, aggregate_type, aggregate_id, partition_key,
+			topic, payload, headers, occurred_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at
+	`
+
+	err = conn(ctx, r.pool).QueryRow(ctx, query,
+		event.EventID,
+		event.EventType,
+		event.AggregateType,
+		event.AggregateID,
+		event.PartitionKey,
+		event.Topic,
+		event.Payload,
+		headers,
+		event.OccurredAt,
+	).Scan(&event.ID, &event.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to append outbox event: %w", err)
+	}
+
+	return nil
+}
+
+// WithRelayLock runs fn in a new transaction holding the relay advisory lock.
+// The lock is released with the transaction.
+func (r *PostgresOutboxRepository) WithRelayLock(ctx context.Context, fn func(ctx context.Context) error) (bool, error) {
+	acquired := false
+	err := r.tm.WithTransactionContext(ctx, func(txCtx context.Context) error {
+		if err := conn(txCtx, r.pool).QueryRow(txCtx, `SELECT pg_try_advisory_xact_lock($1)`, outboxRelayLockID).Scan(&acquired); err != nil {
+			return fmt.Errorf("failed to acquire outbox relay lock: %w", err)
+		}
+		if !acquired {
+			return nil
+		}
+		return fn(txCtx)
+	})
+	return acquired, err
+}
+
+// FetchPending returns up to limit unsent events in id order. An event is skipped while an event
+// of its partition key (itself included) waits for a retry, so a failing key cannot fill the batch
+// and hold back the others.
+func (r *PostgresOutboxRepository) FetchPending(ctx context.Context, limit int) ([]*outbox.Event, error) {
+	query := `
+		SELECT e.id, e.event_id, e.event_type, e.aggregate_type, e.aggregate_id, e.partition_key,
+			e.topic, e.payload, e.headers, e.occurred_at, e.created_at, e.attempts
+		FROM core_dict.outbox_events e
+		WHERE e.sent_at IS NULL
+			AND e.dead_lettered_at IS NULL
+			AND NOT EXISTS (
+				SELECT 1
+				FROM core_dict.outbox_events b
+				WHERE b.partition_key = e.partition_key
+					AND b.id <= e.id
+					AND b.sent_at IS NULL
+					AND b.dead_lettered_at IS NULL
+					AND b.next_attempt_at > NOW()
+			)
+		ORDER BY e.id
+		LIMIT $1
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pending outbox events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*outbox.Event
+	for rows.Next() {
+		var event outbox.Event
+		var headers []byte
+		if err := rows.Scan(
+			&event.ID,
+			&event.EventID,
+			&event.EventType,
+			&event.AggregateType,
+			&event.AggregateID,
+			&event.PartitionKey,
+			&event.Topic,
+			&event.Payload,
+			&headers,
+			&event.OccurredAt,
+			&event.CreatedAt,
+			&event.Attempts,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox event: %w", err)
+		}
+		if err := json.Unmarshal(headers, &event.Headers); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal outbox headers: %w", err)
+		}
+		events = append(events, &event)
+	}
+
+	return events, rows.Err()
+}
+
+// MarkSent marks the events as published. The payload is redacted: it carries the key and the
+// account holder data, which must not outlive the publish.
+func (r *PostgresOutboxRepository) MarkSent(ctx context.Context, ids []int64, sentAt time.Time) error {
+	query := `
+		UPDATE core_dict.outbox_events
+		SET sent_at = $2, attempts = attempts + 1, last_error = NULL,
+			next_attempt_at = NULL, payload = '{}'::jsonb
+		WHERE id = ANY($1)
+	`
+
+	if _, err := conn(ctx, r.pool).Exec(ctx, query, ids, sentAt); err != nil {
+		return fmt.Errorf("failed to mark outbox events as sent: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed records a failed publish attempt and schedules the retry
+func (r *PostgresOutboxRepository) MarkFailed(ctx context.Context, id int64, cause string, nextAttemptAt time.Time) error {
+	query := `
+		UPDATE core_dict.outbox_events
+		SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3
+		WHERE id = $1
+	`
+
+	if _, err := conn(ctx, r.pool).Exec(ctx, query, id, cause, nextAttemptAt); err != nil {
+		return fmt.Errorf("failed to record outbox failure: %w", err)
+	}
+	return nil
+}
+
+// MarkDeadLetter records the last failed attempt and takes the event out of the pending queue
+func (r *PostgresOutboxRepository) MarkDeadLetter(ctx context.Context, id int64, cause string, at time.Time) error {
+	query := `
+		UPDATE core_dict.outbox_events
+		SET attempts = attempts + 1, last_error = $2, next_attempt_at = NULL, dead_lettered_at = $3
+		WHERE id = $1
+	`
+
+	if _, err := conn(ctx, r.pool).Exec(ctx, query, id, cause, at); err != nil {
+		return fmt.Errorf("failed to dead-letter outbox event: %w", err)
+	}
+	return nil
+}
+
+// PurgeSent deletes the events published before sentBefore
+func (r *PostgresOutboxRepository) PurgeSent(ctx context.Context, sentBefore time.Time) (int64, error) {
+	query := `
+		DELETE FROM core_dict.outbox_events
+		WHERE sent_at IS NOT NULL AND sent_at < $1
+	`
+
+	tag, err := conn(ctx, r.pool).Exec(ctx, query, sentBefore)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge published outbox events: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// PendingStats returns the number of unsent events and the creation time of the oldest one
+func (r *PostgresOutboxRepository) PendingStats(ctx context.Context) (int64, *time.Time, error) {
+	query := `
+		SELECT COUNT(*), MIN(created_at)
+		FROM core_dict.outbox_events
+		WHERE sent_at IS NULL AND dead_lettered_at IS NULL
+	`
+
+	var pending int64
+	var oldest *time.Time
+	if err := conn(ctx, r.pool).QueryRow(ctx, query).Scan(&pending, &oldest); err != nil {
+		return 0, nil, fmt.Errorf("failed to read outbox stats: %w", err)
+	}
+	return pending, oldest, nil
+}
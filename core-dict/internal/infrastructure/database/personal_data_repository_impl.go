@@ -0,0 +1,663 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/lbpay-lab/core-dict/internal/application/services"
+	"github.com/lbpay-lab/dict-contracts/fieldcrypto"
+)
+
+// CoreDictSourceName identifies the core-dict database in data subject requests
+const CoreDictSourceName = "core-dict"
+
+// suppressAuditRowValues stops audit_entry_changes() from copying the rows being pseudonymised
+// into audit.entry_events (migration 013); it lasts until the end of the transaction
+const suppressAuditRowValues = "SET LOCAL core_dict.audit_suppress_row_values = 'on'"
+
+// Erasure reasons reported for retained records
+const (
+	retainedAccountOpen     = "account is open"
+	retainedEntries         = "account has retained PIX keys"
+	retainedKeyRegistered   = "key is registered in DICT"
+	retainedWithinRetention = "closed within the legal retention period"
+	retainedAuditTrail      = "audit event keeps the recorded values (append-only, hash-chained); kept for the legal retention period"
+	retainedOutboxPending   = "event is waiting to be published"
+)
+
+// PostgresPersonalDataRepository compiles and erases the personal data of a data subject (LGPD)
+// in the core-dict database: the accounts held by a tax ID, their entries, claims and
+// portabilities, and the audit and outbox events of those records. It implements
+// services.PersonalDataSource.
+type PostgresPersonalDataRepository struct {
+	pool   *pgxpool.Pool
+	cipher *fieldcrypto.Cipher
+}
+
+// NewPostgresPersonalDataRepository creates a new personal data repository
+func NewPostgresPersonalDataRepository(pool *pgxpool.Pool, cipher *fieldcrypto.Cipher) *PostgresPersonalDataRepository {
+	return &PostgresPersonalDataRepository{
+		pool:   pool,
+		cipher: cipher,
+	}
+}
+
+// Name returns the source name used in bundles and erasure reports
+func (r *PostgresPersonalDataRepository) Name() string {
+	return CoreDictSourceName
+}
+
+// SubjectPseudonym returns the blind index of the tax ID (the holder_document_index of its accounts)
+func (r *PostgresPersonalDataRepository) SubjectPseudonym(taxID string) string {
+	return r.cipher.BlindIndex(fieldHolderDocument, taxID)
+}
+
+// subjectAccount is an account of the data subject with the dates that drive retention
+type subjectAccount struct {
+	id        uuid.UUID
+	status    string
+	closedAt  *time.Time
+	deletedAt *time.Time
+	record    *services.PersonalDataRecord
+}
+
+// subjectEntry is an entry of one of the subject's accounts
+type subjectEntry struct {
+	id        uuid.UUID
+	accountID uuid.UUID
+	keyType   string
+	keyValue  string
+	deletedAt *time.Time
+	record    *services.PersonalDataRecord
+}
+
+// subjectOutboxEvent is an outbox event of one of the subject's records
+type subjectOutboxEvent struct {
+	id       int64
+	closedAt *time.Time // When it was published or dead-lettered (nil while pending)
+	record   *services.PersonalDataRecord
+}
+
+// CollectPersonalData returns every core-dict record tied to the tax ID
+func (r *PostgresPersonalDataRepository) CollectPersonalData(ctx context.Context, taxID string) ([]*services.PersonalDataRecord, error) {
+	var records []*services.PersonalDataRecord
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		accounts, entries, err := r.findSubjectRecords(ctx, tx, taxID, false)
+		if err != nil {
+			return err
+		}
+
+		accountIDs := make([]uuid.UUID, 0, len(accounts))
+		for _, account := range accounts {
+			accountIDs = append(accountIDs, account.id)
+			records = append(records, account.record)
+		}
+		entryIDs := make([]uuid.UUID, 0, len(entries))
+		for _, entry := range entries {
+			entryIDs = append(entryIDs, entry.id)
+			records = append(records, entry.record)
+		}
+
+		related, relatedIDs, err := r.findRelatedRecords(ctx, tx, accountIDs, entryIDs)
+		if err != nil {
+			return err
+		}
+		records = append(records, related...)
+
+		events, err := r.findAuditEvents(ctx, tx, relatedIDs)
+		if err != nil {
+			return err
+		}
+		records = append(records, events...)
+
+		outboxEvents, err := r.findOutboxEvents(ctx, tx, relatedIDs)
+		if err != nil {
+			return err
+		}
+		for _, event := range outboxEvents {
+			records = append(records, event.record)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// ErasePersonalData pseudonymises the subject's accounts and entries closed before retainAfter.
+//
+// Names, documents and key values are cleared; the blind indexes stay, so the audit trail can
+// still be tied to the (pseudonymous) records and a repeated request finds the same rows.
+// Keys still registered, open accounts, accounts with retained keys and records closed within
+// the retention period are retained, as are the audit events: the ones recorded before the
+// erasure keep the old values of the rows and cannot be changed (append-only, hash-chained), so
+// they are reported as retained until the retention period ends. Claims and portabilities only
+// reference the accounts and entries and are not changed. The payloads of their outbox events
+// published or dead-lettered before retainAfter are cleared; events still waiting to be
+// published are retained so the relay can deliver them.
+func (r *PostgresPersonalDataRepository) ErasePersonalData(ctx context.Context, taxID string, retainAfter time.Time, dryRun bool) ([]*services.ErasureAction, error) {
+	var actions []*services.ErasureAction
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		accounts, entries, err := r.findSubjectRecords(ctx, tx, taxID, true)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(ctx, suppressAuditRowValues); err != nil {
+			return fmt.Errorf("failed to suppress audit row values: %w", err)
+		}
+
+		hasRetainedEntries := make(map[uuid.UUID]bool)
+		entryIDs := make([]uuid.UUID, 0, len(entries))
+		for _, entry := range entries {
+			entryIDs = append(entryIDs, entry.id)
+
+			reason := retentionReason(entry.deletedAt, retainAfter, retainedKeyRegistered)
+			if reason != "" {
+				hasRetainedEntries[entry.accountID] = true
+				actions = append(actions, retainedAction("dict_entries", entry.id.String(), reason))
+				continue
+			}
+
+			if !dryRun {
+				_, err := tx.Exec(ctx, `
+					UPDATE core_dict.dict_entries
+					SET key_value = NULL, key_value_encrypted = NULL, key_hash = NULL,
+					    key_value_index = COALESCE(key_value_index, $2)
+					WHERE id = $1
+				`, entry.id, r.cipher.BlindIndex(fieldKeyValue, entry.keyValue))
+				if err != nil {
+					return fmt.Errorf("failed to pseudonymise entry %s: %w", entry.id, err)
+				}
+			}
+			actions = append(actions, pseudonymisedAction("dict_entries", entry.id.String()))
+		}
+
+		accountIDs := make([]uuid.UUID, 0, len(accounts))
+		for _, account := range accounts {
+			accountIDs = append(accountIDs, account.id)
+
+			var reason string
+			switch {
+			case account.closedAt == nil && account.deletedAt == nil && account.status != "CLOSED":
+				reason = retainedAccountOpen
+			case hasRetainedEntries[account.id]:
+				reason = retainedEntries
+			default:
+				reason = retentionReason(latest(account.closedAt, account.deletedAt), retainAfter, retainedAccountOpen)
+			}
+			if reason != "" {
+				actions = append(actions, retainedAction("accounts", account.id.String(), reason))
+				continue
+			}
+
+			if !dryRun {
+				_, err := tx.Exec(ctx, `
+					UPDATE core_dict.accounts
+					SET holder_name = NULL, holder_name_encrypted = NULL,
+					    holder_document = NULL, holder_document_encrypted = NULL,
+					    holder_document_index = COALESCE(holder_document_index, $2)
+					WHERE id = $1
+				`, account.id, r.SubjectPseudonym(taxID))
+				if err != nil {
+					return fmt.Errorf("failed to pseudonymise account %s: %w", account.id, err)
+				}
+			}
+			actions = append(actions, pseudonymisedAction("accounts", account.id.String()))
+		}
+
+		_, relatedIDs, err := r.findRelatedRecords(ctx, tx, accountIDs, entryIDs)
+		if err != nil {
+			return err
+		}
+
+		// Audit events are never changed; their old/new values may still hold the erased data
+		events, err := r.findAuditEvents(ctx, tx, relatedIDs)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			actions = append(actions, retainedAction(event.Table, event.RecordID, retainedAuditTrail))
+		}
+
+		outboxEvents, err := r.findOutboxEvents(ctx, tx, relatedIDs)
+		if err != nil {
+			return err
+		}
+		for _, event := range outboxEvents {
+			recordID := strconv.FormatInt(event.id, 10)
+			if reason := retentionReason(event.closedAt, retainAfter, retainedOutboxPending); reason != "" {
+				actions = append(actions, retainedAction("outbox_events", recordID, reason))
+				continue
+			}
+			if !dryRun {
+				_, err := tx.Exec(ctx, `
+					UPDATE core_dict.outbox_events
+					SET payload = '{}'::jsonb, last_error = NULL
+					WHERE id = $1
+				`, event.id)
+				if err != nil {
+					return fmt.Errorf("failed to clear outbox event %d: %w", event.id, err)
+				}
+			}
+			actions = append(actions, pseudonymisedAction("outbox_events", recordID))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return actions, nil
+}
+
+// findSubjectRecords loads the accounts held by the tax ID and all their entries, deleted ones
+// included. With forUpdate the rows are locked for the erasure.
+func (r *PostgresPersonalDataRepository) findSubjectRecords(ctx context.Context, tx pgx.Tx, taxID string, forUpdate bool) ([]*subjectAccount, []*subjectEntry, error) {
+	lock := ""
+	if forUpdate {
+		lock = " FOR UPDATE"
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT id, participant_ispb, branch_code, account_number, account_type, account_status,
+		       holder_name, holder_name_encrypted, holder_document, holder_document_encrypted,
+		       holder_document_type, opened_at, closed_at, created_at, updated_at, deleted_at
+		FROM core_dict.accounts
+		WHERE holder_document_index = $1 OR (holder_document_index IS NULL AND holder_document = $2)
+		ORDER BY created_at`+lock,
+		r.SubjectPseudonym(taxID), taxID,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find subject accounts: %w", err)
+	}
+	accounts, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*subjectAccount, error) {
+		return r.scanSubjectAccount(ctx, row)
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read subject accounts: %w", err)
+	}
+	if len(accounts) == 0 {
+		return nil, nil, nil
+	}
+
+	accountIDs := make([]uuid.UUID, 0, len(accounts))
+	for _, account := range accounts {
+		accountIDs = append(accountIDs, account.id)
+	}
+
+	rows, err = tx.Query(ctx, `
+		SELECT id, account_id, key_type, key_value, key_value_encrypted, participant_ispb,
+		       participant_branch, status, ownership_type, created_at, updated_at, deleted_at
+		FROM core_dict.dict_entries
+		WHERE account_id = ANY($1)
+		ORDER BY created_at`+lock,
+		accountIDs,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find subject entries: %w", err)
+	}
+	entries, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*subjectEntry, error) {
+		return r.scanSubjectEntry(ctx, row)
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read subject entries: %w", err)
+	}
+
+	return accounts, entries, nil
+}
+
+// scanSubjectAccount scans and decrypts an account row
+func (r *PostgresPersonalDataRepository) scanSubjectAccount(ctx context.Context, row pgx.Row) (*subjectAccount, error) {
+	account := &subjectAccount{}
+	var ispb, branch, number, accountType, documentType string
+	var holderName, holderDocument *string
+	var holderNameEncrypted, holderDocumentEncrypted []byte
+	var openedAt, createdAt, updatedAt time.Time
+
+	err := row.Scan(
+		&account.id, &ispb, &branch, &number, &accountType, &account.status,
+		&holderName, &holderNameEncrypted, &holderDocument, &holderDocumentEncrypted,
+		&documentType, &openedAt, &account.closedAt, &createdAt, &updatedAt, &account.deletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := openColumn(ctx, r.cipher, fieldHolderName, holderName, holderNameEncrypted)
+	if err != nil {
+		return nil, err
+	}
+	document, err := openColumn(ctx, r.cipher, fieldHolderDocument, holderDocument, holderDocumentEncrypted)
+	if err != nil {
+		return nil, err
+	}
+
+	account.record = &services.PersonalDataRecord{
+		Source:   CoreDictSourceName,
+		Table:    "accounts",
+		RecordID: account.id.String(),
+		Fields: map[string]interface{}{
+			"participant_ispb":     ispb,
+			"branch_code":          branch,
+			"account_number":       number,
+			"account_type":         accountType,
+			"account_status":       account.status,
+			"holder_name":          name,
+			"holder_document":      document,
+			"holder_document_type": documentType,
+			"opened_at":            openedAt,
+			"closed_at":            account.closedAt,
+			"created_at":           createdAt,
+			"updated_at":           updatedAt,
+			"deleted_at":           account.deletedAt,
+		},
+	}
+	return account, nil
+}
+
+// scanSubjectEntry scans and decrypts an entry row
+func (r *PostgresPersonalDataRepository) scanSubjectEntry(ctx context.Context, row pgx.Row) (*subjectEntry, error) {
+	entry := &subjectEntry{}
+	var keyValue, branch *string
+	var keyValueEncrypted []byte
+	var ispb, status, ownershipType string
+	var createdAt, updatedAt time.Time
+
+	err := row.Scan(
+		&entry.id, &entry.accountID, &entry.keyType, &keyValue, &keyValueEncrypted, &ispb,
+		&branch, &status, &ownershipType, &createdAt, &updatedAt, &entry.deletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := openColumn(ctx, r.cipher, fieldKeyValue, keyValue, keyValueEncrypted)
+	if err != nil {
+		return nil, err
+	}
+	entry.keyValue = value
+
+	entry.record = &services.PersonalDataRecord{
+		Source:   CoreDictSourceName,
+		Table:    "dict_entries",
+		RecordID: entry.id.String(),
+		Fields: map[string]interface{}{
+			"account_id":         entry.accountID.String(),
+			"key_type":           entry.keyType,
+			"key_value":          value,
+			"participant_ispb":   ispb,
+			"participant_branch": branch,
+			"status":             status,
+			"ownership_type":     ownershipType,
+			"created_at":         createdAt,
+			"updated_at":         updatedAt,
+			"deleted_at":         entry.deletedAt,
+		},
+	}
+	return entry, nil
+}
+
+// findRelatedRecords loads the claims and portabilities of the subject's accounts and entries.
+// It also returns the IDs of all those records (accounts and entries included), the aggregates
+// of their audit and outbox events.
+func (r *PostgresPersonalDataRepository) findRelatedRecords(ctx context.Context, tx pgx.Tx, accountIDs, entryIDs []uuid.UUID) ([]*services.PersonalDataRecord, []uuid.UUID, error) {
+	if len(accountIDs) == 0 {
+		return nil, nil, nil
+	}
+
+	var records []*services.PersonalDataRecord
+	auditedIDs := append(append([]uuid.UUID{}, accountIDs...), entryIDs...)
+
+	rows, err := tx.Query(ctx, `
+		SELECT id, entry_id, claim_type, claimer_ispb, owner_ispb, status,
+		       resolution_type, resolution_reason, expires_at, resolution_date, created_at
+		FROM core_dict.claims
+		WHERE entry_id = ANY($1) OR claimer_account_id = ANY($2) OR owner_account_id = ANY($2)
+		ORDER BY created_at
+	`, entryIDs, accountIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find subject claims: %w", err)
+	}
+	claims, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*services.PersonalDataRecord, error) {
+		var id, entryID uuid.UUID
+		var claimType, claimerISPB, ownerISPB, status string
+		var resolutionType, resolutionReason *string
+		var expiresAt, createdAt time.Time
+		var resolutionDate *time.Time
+		if err := row.Scan(&id, &entryID, &claimType, &claimerISPB, &ownerISPB, &status,
+			&resolutionType, &resolutionReason, &expiresAt, &resolutionDate, &createdAt); err != nil {
+			return nil, err
+		}
+		return &services.PersonalDataRecord{
+			Source:   CoreDictSourceName,
+			Table:    "claims",
+			RecordID: id.String(),
+			Fields: map[string]interface{}{
+				"entry_id":          entryID.String(),
+				"claim_type":        claimType,
+				"claimer_ispb":      claimerISPB,
+				"owner_ispb":        ownerISPB,
+				"status":            status,
+				"resolution_type":   resolutionType,
+				"resolution_reason": resolutionReason,
+				"expires_at":        expiresAt,
+				"resolution_date":   resolutionDate,
+				"created_at":        createdAt,
+			},
+		}, nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read subject claims: %w", err)
+	}
+	for _, claim := range claims {
+		auditedIDs = append(auditedIDs, uuid.MustParse(claim.RecordID))
+	}
+	records = append(records, claims...)
+
+	rows, err = tx.Query(ctx, `
+		SELECT id, entry_id, origin_ispb, destination_ispb, status, initiated_at, completed_at
+		FROM core_dict.portabilities
+		WHERE entry_id = ANY($1) OR origin_account_id = ANY($2) OR destination_account_id = ANY($2)
+		ORDER BY initiated_at
+	`, entryIDs, accountIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find subject portabilities: %w", err)
+	}
+	portabilities, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*services.PersonalDataRecord, error) {
+		var id, entryID uuid.UUID
+		var originISPB, destinationISPB, status string
+		var initiatedAt time.Time
+		var completedAt *time.Time
+		if err := row.Scan(&id, &entryID, &originISPB, &destinationISPB, &status, &initiatedAt, &completedAt); err != nil {
+			return nil, err
+		}
+		return &services.PersonalDataRecord{
+			Source:   CoreDictSourceName,
+			Table:    "portabilities",
+			RecordID: id.String(),
+			Fields: map[string]interface{}{
+				"entry_id":         entryID.String(),
+				"origin_ispb":      originISPB,
+				"destination_ispb": destinationISPB,
+				"status":           status,
+				"initiated_at":     initiatedAt,
+				"completed_at":     completedAt,
+			},
+		}, nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read subject portabilities: %w", err)
+	}
+	for _, portability := range portabilities {
+		auditedIDs = append(auditedIDs, uuid.MustParse(portability.RecordID))
+	}
+	records = append(records, portabilities...)
+
+	return records, auditedIDs, nil
+}
+
+// findAuditEvents loads the audit events of the given records
+func (r *PostgresPersonalDataRepository) findAuditEvents(ctx context.Context, tx pgx.Tx, entityIDs []uuid.UUID) ([]*services.PersonalDataRecord, error) {
+	if len(entityIDs) == 0 {
+		return nil, nil
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT event_id, entity_type, entity_id, event_type, old_values, new_values, metadata, occurred_at
+		FROM audit.entry_events
+		WHERE entity_id = ANY($1)
+		ORDER BY occurred_at
+	`, entityIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find subject audit events: %w", err)
+	}
+	events, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*services.PersonalDataRecord, error) {
+		var eventID, entityID uuid.UUID
+		var entityType, eventType string
+		var oldValues, newValues, metadata []byte
+		var occurredAt time.Time
+		if err := row.Scan(&eventID, &entityType, &entityID, &eventType, &oldValues, &newValues, &metadata, &occurredAt); err != nil {
+			return nil, err
+		}
+		return &services.PersonalDataRecord{
+			Source:   CoreDictSourceName,
+			Table:    "audit.entry_events",
+			RecordID: eventID.String(),
+			Fields: map[string]interface{}{
+				"entity_type": entityType,
+				"entity_id":   entityID.String(),
+				"event_type":  eventType,
+				"old_values":  json.RawMessage(nullJSON(oldValues)),
+				"new_values":  json.RawMessage(nullJSON(newValues)),
+				"metadata":    json.RawMessage(nullJSON(metadata)),
+				"occurred_at": occurredAt,
+			},
+		}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read subject audit events: %w", err)
+	}
+	return events, nil
+}
+
+// findOutboxEvents loads the outbox events of the given records. Published events are purged
+// by the relay, so the scan stays small without an index on aggregate_id.
+func (r *PostgresPersonalDataRepository) findOutboxEvents(ctx context.Context, tx pgx.Tx, aggregateIDs []uuid.UUID) ([]*subjectOutboxEvent, error) {
+	if len(aggregateIDs) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, 0, len(aggregateIDs))
+	for _, id := range aggregateIDs {
+		ids = append(ids, id.String())
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT id, event_id, event_type, aggregate_type, aggregate_id, topic, payload,
+		       occurred_at, sent_at, dead_lettered_at, attempts, last_error
+		FROM core_dict.outbox_events
+		WHERE aggregate_id = ANY($1)
+		ORDER BY id
+	`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find subject outbox events: %w", err)
+	}
+	events, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*subjectOutboxEvent, error) {
+		var id int64
+		var eventID uuid.UUID
+		var eventType, aggregateType, aggregateID, topic string
+		var payload []byte
+		var occurredAt time.Time
+		var sentAt, deadLetteredAt *time.Time
+		var attempts int
+		var lastError *string
+		if err := row.Scan(&id, &eventID, &eventType, &aggregateType, &aggregateID, &topic, &payload,
+			&occurredAt, &sentAt, &deadLetteredAt, &attempts, &lastError); err != nil {
+			return nil, err
+		}
+		return &subjectOutboxEvent{
+			id:       id,
+			closedAt: latest(sentAt, deadLetteredAt),
+			record: &services.PersonalDataRecord{
+				Source:   CoreDictSourceName,
+				Table:    "outbox_events",
+				RecordID: strconv.FormatInt(id, 10),
+				Fields: map[string]interface{}{
+					"event_id":         eventID.String(),
+					"event_type":       eventType,
+					"aggregate_type":   aggregateType,
+					"aggregate_id":     aggregateID,
+					"topic":            topic,
+					"payload":          json.RawMessage(nullJSON(payload)),
+					"occurred_at":      occurredAt,
+					"sent_at":          sentAt,
+					"dead_lettered_at": deadLetteredAt,
+					"attempts":         attempts,
+					"last_error":       lastError,
+				},
+			},
+		}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read subject outbox events: %w", err)
+	}
+	return events, nil
+}
+
+// retentionReason returns why a record closed at closedAt (nil = still active) must be kept,
+// or "" when it can be pseudonymised
+func retentionReason(closedAt *time.Time, retainAfter time.Time, activeReason string) string {
+	switch {
+	case closedAt == nil:
+		return activeReason
+	case !closedAt.Before(retainAfter):
+		return retainedWithinRetention
+	default:
+		return ""
+	}
+}
+
+// latest returns the latest of the non-nil times, or nil
+func latest(times ...*time.Time) *time.Time {
+	var result *time.Time
+	for _, t := range times {
+		if t != nil && (result == nil || t.After(*result)) {
+			result = t
+		}
+	}
+	return result
+}
+
+// nullJSON maps an empty JSONB column to a JSON null
+func nullJSON(raw []byte) []byte {
+	if len(raw) == 0 {
+		return []byte("null")
+	}
+	return raw
+}
+
+func retainedAction(table, recordID, reason string) *services.ErasureAction {
+	return &services.ErasureAction{
+		Source:   CoreDictSourceName,
+		Table:    table,
+		RecordID: recordID,
+		Outcome:  services.ErasureOutcomeRetained,
+		Reason:   reason,
+	}
+}
+
+func pseudonymisedAction(table, recordID string) *services.ErasureAction {
+	return &services.ErasureAction{
+		Source:   CoreDictSourceName,
+		Table:    table,
+		RecordID: recordID,
+		Outcome:  services.ErasureOutcomePseudonymised,
+	}
+}
@@ -0,0 +1,128 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/lbpay-lab/core-dict/internal/domain"
+	"github.com/lbpay-lab/core-dict/internal/domain/entities"
+	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
+)
+
+// PostgresPortabilityRepository implements PortabilityRepository using PostgreSQL
+type PostgresPortabilityRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresPortabilityRepository creates a new portability repository
+func NewPostgresPortabilityRepository(pool *pgxpool.Pool) repositories.PortabilityRepository {
+	return &PostgresPortabilityRepository{pool: pool}
+}
+
+// portabilityColumns are the columns read by scanPortability
+const portabilityColumns = `
+	id, entry_id, COALESCE(external_id, ''), COALESCE(workflow_id, ''),
+	origin_ispb, origin_account_id, destination_ispb, destination_account_id,
+	status, requires_otp, otp_validated_at, initiated_at, completed_at,
+	created_at, updated_at`
+
+// FindByID returns the portability with the given ID.
+// EntryKey is left empty: the key value is stored encrypted in dict_entries.
+func (r *PostgresPortabilityRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.Portability, error) {
+	query := `SELECT` + portabilityColumns + `
+		FROM core_dict.portabilities
+		WHERE id = $1
+	`
+
+	p, err := scanPortability(conn(ctx, r.pool).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrPortabilityNotFound.WithMetadata("portability_id", id.String())
+		}
+		return nil, fmt.Errorf("failed to find portability: %w", err)
+	}
+
+	return p, nil
+}
+
+// FindActiveByEntryID returns the portabilities of the entry not yet in a final status.
+// EntryKey is left empty: the key value is stored encrypted in dict_entries.
+func (r *PostgresPortabilityRepository) FindActiveByEntryID(ctx context.Context, entryID uuid.UUID) ([]*entities.Portability, error) {
+	query := `SELECT` + portabilityColumns + `
+		FROM core_dict.portabilities
+		WHERE entry_id = $1
+		  AND status IN ('INITIATED', 'PENDING_APPROVAL', 'APPROVED')
+		ORDER BY created_at
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, entryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find active portabilities: %w", err)
+	}
+	portabilities, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*entities.Portability, error) {
+		return scanPortability(row)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan portabilities: %w", err)
+	}
+
+	return portabilities, nil
+}
+
+// scanPortability scans a row selected with portabilityColumns
+func scanPortability(row pgx.Row) (*entities.Portability, error) {
+	p := &entities.Portability{Metadata: make(map[string]interface{})}
+	var status string
+	if err := row.Scan(
+		&p.ID,
+		&p.EntryID,
+		&p.BacenPortabilityID,
+		&p.WorkflowID,
+		&p.OriginParticipant.ISPB,
+		&p.OriginAccountID,
+		&p.DestinationParticipant.ISPB,
+		&p.DestinationAccountID,
+		&status,
+		&p.RequiresOTP,
+		&p.OTPValidatedAt,
+		&p.InitiatedAt,
+		&p.CompletedAt,
+		&p.CreatedAt,
+		&p.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	p.Status = entities.PortabilityStatus(status)
+	return p, nil
+}
+
+// Update updates the portability status. The table has no reason column: the rejection or
+// cancellation reason goes to the domain event.
+func (r *PostgresPortabilityRepository) Update(ctx context.Context, portability *entities.Portability) error {
+	query := `
+		UPDATE core_dict.portabilities
+		SET status = $2, completed_at = $3, otp_validated_at = $4, updated_at = $5
+		WHERE id = $1
+	`
+
+	result, err := conn(ctx, r.pool).Exec(ctx, query,
+		portability.ID,
+		string(portability.Status),
+		portability.CompletedAt,
+		portability.OTPValidatedAt,
+		portability.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update portability: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("portability not found: %s", portability.ID)
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package database
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// DefaultStatisticsRefreshInterval intervalo padrão de refresh das materialized views de estatísticas
+const DefaultStatisticsRefreshInterval = 5 * time.Minute
+
+// StatisticsCacheRefresher é implementado por GetStatisticsQueryHandler
+// (refresh da materialized view + invalidação do cache)
+type StatisticsCacheRefresher interface {
+	RefreshCache(ctx context.Context) error
+}
+
+// StatisticsRefresher atualiza periodicamente as materialized views de estatísticas
+type StatisticsRefresher struct {
+	refresher StatisticsCacheRefresher
+	interval  time.Duration
+	timeout   time.Duration
+	logger    *slog.Logger
+}
+
+// NewStatisticsRefresher cria um novo StatisticsRefresher
+func NewStatisticsRefresher(refresher StatisticsCacheRefresher, interval time.Duration, logger *slog.Logger) *StatisticsRefresher {
+	if interval <= 0 {
+		interval = DefaultStatisticsRefreshInterval
+	}
+	return &StatisticsRefresher{
+		refresher: refresher,
+		interval:  interval,
+		timeout:   interval / 2,
+		logger:    logger,
+	}
+}
+
+// Start executa um refresh imediato e depois a cada intervalo, até o contexto ser cancelado
+func (r *StatisticsRefresher) Start(ctx context.Context) {
+	go func() {
+		r.refreshOnce(ctx)
+
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.refreshOnce(ctx)
+			}
+		}
+	}()
+}
+
+// refreshOnce executa um refresh com timeout; falhas são apenas logadas
+// (as leituras continuam servindo o último snapshot da view)
+func (r *StatisticsRefresher) refreshOnce(ctx context.Context) {
+	refreshCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	start := time.Now()
+	if err := r.refresher.RefreshCache(refreshCtx); err != nil {
+		if ctx.Err() == nil {
+			r.logger.Error("Statistics refresh failed", "error", err)
+		}
+		return
+	}
+	r.logger.Debug("Statistics refreshed", "duration_ms", time.Since(start).Milliseconds())
+}
@@ -3,12 +3,18 @@ package database
 import (
 	"context"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/lbpay-lab/core-dict/internal/domain/entities"
+	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
+	"github.com/lbpay-lab/core-dict/internal/domain/valueobjects"
 )
 
-// PostgresStatisticsRepository implementa StatisticsRepository usando PostgreSQL
+// PostgresStatisticsRepository implementa StatisticsRepository usando PostgreSQL.
+// As leituras vêm das materialized views core_dict.mv_entry_statistics e
+// core_dict.mv_claim_statistics (migration 009), nunca de COUNT(*) nas tabelas.
 type PostgresStatisticsRepository struct {
 	pool *pgxpool.Pool
 }
@@ -21,46 +27,189 @@ func NewPostgresStatisticsRepository(pool *pgxpool.Pool) *PostgresStatisticsRepo
 }
 
 // GetStatistics retorna estatísticas agregadas do sistema
-func (r *PostgresStatisticsRepository) GetStatistics(ctx context.Context) (*entities.Statistics, error) {
-	stats := &entities.Statistics{}
+// A janela de tempo tem granularidade diária (UTC): inclui os dias de CreatedAfter e CreatedBefore.
+func (r *PostgresStatisticsRepository) GetStatistics(ctx context.Context, filters repositories.StatisticsFilters) (*entities.Statistics, error) {
+	stats := &entities.Statistics{
+		KeysByType:   make(map[string]int64),
+		ClaimsByType: make(map[string]int64),
+	}
+	breakdown := make(map[string]*entities.ParticipantStatistics)
 
 	// Query for entry statistics
 	entryQuery := `
-		SELECT
-			COUNT(*) as total_keys,
-			COUNT(CASE WHEN status = 'ACTIVE' THEN 1 END) as active_keys,
-			COUNT(CASE WHEN status = 'BLOCKED' THEN 1 END) as blocked_keys,
-			COUNT(CASE WHEN status = 'DELETED' THEN 1 END) as deleted_keys
-		FROM entries
+		SELECT participant_ispb, participant_branch, key_type, status, SUM(key_count)::bigint
+		FROM core_dict.mv_entry_statistics
+		WHERE ($1::timestamptz IS NULL OR created_day >= ($1::timestamptz AT TIME ZONE 'UTC')::date)
+		  AND ($2::timestamptz IS NULL OR created_day <= ($2::timestamptz AT TIME ZONE 'UTC')::date)
+		  AND ($3 = '' OR participant_ispb = $3)
+		  AND ($4 = '' OR participant_branch = $4)
+		GROUP BY participant_ispb, participant_branch, key_type, status
 	`
 
-	err := r.pool.QueryRow(ctx, entryQuery).Scan(
-		&stats.TotalKeys,
-		&stats.ActiveKeys,
-		&stats.BlockedKeys,
-		&stats.DeletedKeys,
-	)
+	rows, err := r.pool.Query(ctx, entryQuery, filters.CreatedAfter, filters.CreatedBefore, filters.ISPB, filters.Branch)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get entry statistics: %w", err)
 	}
+	for rows.Next() {
+		var ispb, branch, keyType, status string
+		var count int64
+		if err := rows.Scan(&ispb, &branch, &keyType, &status, &count); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan entry statistics: %w", err)
+		}
+		addKeyCounters(stats, keyType, status, count)
+		if p := participantBucket(breakdown, filters.GroupBy, ispb, branch); p != nil {
+			addParticipantKeyCounters(p, keyType, status, count)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate entry statistics: %w", err)
+	}
 
-	// Query for claim statistics
+	// Query for claim statistics (claims não têm agência: filtro de branch não se aplica)
 	claimQuery := `
-		SELECT
-			COUNT(*) as total_claims,
-			COUNT(CASE WHEN status = 'OPEN' THEN 1 END) as pending_claims,
-			COUNT(CASE WHEN status = 'COMPLETED' THEN 1 END) as completed_claims
-		FROM claims
+		SELECT participant_ispb, claim_type, status, SUM(claim_count)::bigint
+		FROM core_dict.mv_claim_statistics
+		WHERE ($1::timestamptz IS NULL OR created_day >= ($1::timestamptz AT TIME ZONE 'UTC')::date)
+		  AND ($2::timestamptz IS NULL OR created_day <= ($2::timestamptz AT TIME ZONE 'UTC')::date)
+		  AND ($3 = '' OR participant_ispb = $3)
+		GROUP BY participant_ispb, claim_type, status
 	`
 
-	err = r.pool.QueryRow(ctx, claimQuery).Scan(
-		&stats.TotalClaims,
-		&stats.PendingClaims,
-		&stats.CompletedClaims,
-	)
+	rows, err = r.pool.Query(ctx, claimQuery, filters.CreatedAfter, filters.CreatedBefore, filters.ISPB)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get claim statistics: %w", err)
 	}
+	for rows.Next() {
+		var ispb, claimType, status string
+		var count int64
+		if err := rows.Scan(&ispb, &claimType, &status, &count); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan claim statistics: %w", err)
+		}
+		addClaimCounters(stats, claimType, status, count)
+		if filters.GroupBy == repositories.StatisticsGroupByISPB {
+			participantBucket(breakdown, filters.GroupBy, ispb, "").TotalClaims += count
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate claim statistics: %w", err)
+	}
 
+	// Momento do último refresh da materialized view
+	err = r.pool.QueryRow(ctx, `SELECT refreshed_at FROM core_dict.statistics_refresh WHERE id = 1`).Scan(&stats.LastUpdated)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get statistics refresh time: %w", err)
+	}
+
+	stats.Breakdown = sortedBreakdown(breakdown)
 	return stats, nil
 }
+
+// RefreshStatistics recalcula as materialized views sem bloquear leituras (CONCURRENTLY)
+func (r *PostgresStatisticsRepository) RefreshStatistics(ctx context.Context) (time.Time, error) {
+	start := time.Now()
+
+	for _, view := range []string{"core_dict.mv_entry_statistics", "core_dict.mv_claim_statistics"} {
+		if _, err := r.pool.Exec(ctx, "REFRESH MATERIALIZED VIEW CONCURRENTLY "+view); err != nil {
+			return time.Time{}, fmt.Errorf("failed to refresh %s: %w", view, err)
+		}
+	}
+
+	var refreshedAt time.Time
+	err := r.pool.QueryRow(ctx, `
+		UPDATE core_dict.statistics_refresh
+		SET refreshed_at = NOW(), duration_ms = $1
+		WHERE id = 1
+		RETURNING refreshed_at
+	`, time.Since(start).Milliseconds()).Scan(&refreshedAt)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to record statistics refresh: %w", err)
+	}
+
+	return refreshedAt, nil
+}
+
+// addKeyCounters acumula contadores de chaves nos totais
+func addKeyCounters(stats *entities.Statistics, keyType, status string, count int64) {
+	stats.TotalKeys += count
+	stats.KeysByType[keyType] += count
+	switch entities.KeyStatus(status) {
+	case entities.KeyStatusActive:
+		stats.ActiveKeys += count
+	case entities.KeyStatusBlocked:
+		stats.BlockedKeys += count
+	case entities.KeyStatusDeleted:
+		stats.DeletedKeys += count
+	}
+}
+
+// addParticipantKeyCounters acumula contadores de chaves em um participante
+func addParticipantKeyCounters(p *entities.ParticipantStatistics, keyType, status string, count int64) {
+	p.TotalKeys += count
+	p.KeysByType[keyType] += count
+	switch entities.KeyStatus(status) {
+	case entities.KeyStatusActive:
+		p.ActiveKeys += count
+	case entities.KeyStatusBlocked:
+		p.BlockedKeys += count
+	case entities.KeyStatusDeleted:
+		p.DeletedKeys += count
+	}
+}
+
+// addClaimCounters acumula contadores de claims nos totais
+func addClaimCounters(stats *entities.Statistics, claimType, status string, count int64) {
+	stats.TotalClaims += count
+	stats.ClaimsByType[claimType] += count
+	switch valueobjects.ClaimStatus(status) {
+	case valueobjects.ClaimStatusOpen, valueobjects.ClaimStatusWaitingResolution:
+		stats.PendingClaims += count
+	case valueobjects.ClaimStatusCompleted:
+		stats.CompletedClaims += count
+	}
+}
+
+// participantBucket retorna (criando se necessário) o bucket da quebra por participante
+func participantBucket(breakdown map[string]*entities.ParticipantStatistics, groupBy repositories.StatisticsGroupBy, ispb, branch string) *entities.ParticipantStatistics {
+	var key string
+	switch groupBy {
+	case repositories.StatisticsGroupByISPB:
+		key, branch = ispb, ""
+	case repositories.StatisticsGroupByBranch:
+		key = ispb + "/" + branch
+	default:
+		return nil
+	}
+
+	p, ok := breakdown[key]
+	if !ok {
+		p = &entities.ParticipantStatistics{
+			ISPB:       ispb,
+			Branch:     branch,
+			KeysByType: make(map[string]int64),
+		}
+		breakdown[key] = p
+	}
+	return p
+}
+
+// sortedBreakdown ordena a quebra por ISPB e agência (saída determinística)
+func sortedBreakdown(breakdown map[string]*entities.ParticipantStatistics) []entities.ParticipantStatistics {
+	if len(breakdown) == 0 {
+		return nil
+	}
+	result := make([]entities.ParticipantStatistics, 0, len(breakdown))
+	for _, p := range breakdown {
+		result = append(result, *p)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].ISPB != result[j].ISPB {
+			return result[i].ISPB < result[j].ISPB
+		}
+		return result[i].Branch < result[j].Branch
+	})
+	return result
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -51,8 +52,13 @@ func (tm *TransactionManager) WithTransaction(ctx context.Context, fn Transactio
 }
 
 // WithTransactionContext executes a function within a transaction
-// and returns a context with the transaction
+// and returns a context with the transaction.
+// If ctx already carries a transaction, fn joins it instead of opening a new one.
 func (tm *TransactionManager) WithTransactionContext(ctx context.Context, fn func(context.Context) error) error {
+	if _, ok := GetTx(ctx); ok {
+		return fn(ctx)
+	}
+
 	tx, err := tm.pool.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
@@ -87,6 +93,22 @@ func GetTx(ctx context.Context) (pgx.Tx, bool) {
 	return tx, ok
 }
 
+// Querier is the subset of pgxpool.Pool and pgx.Tx used by the repositories
+type Querier interface {
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// conn returns the transaction carried by ctx (see WithTransactionContext) or the pool,
+// so repository writes join the caller's transaction when there is one
+func conn(ctx context.Context, pool *pgxpool.Pool) Querier {
+	if tx, ok := GetTx(ctx); ok {
+		return tx
+	}
+	return pool
+}
+
 // Savepoint creates a savepoint within a transaction
 func (tm *TransactionManager) Savepoint(ctx context.Context, tx pgx.Tx, name string) error {
 	_, err := tx.Exec(ctx, fmt.Sprintf("SAVEPOINT %s", name))
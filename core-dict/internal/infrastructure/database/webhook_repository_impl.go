@@ -0,0 +1,228 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/lbpay-lab/core-dict/internal/domain/entities"
+	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
+	"github.com/lbpay-lab/dict-contracts/fieldcrypto"
+)
+
+// PostgresWebhookRepository implements WebhookRepository using PostgreSQL.
+// Endpoint secrets are stored as fieldcrypto envelopes.
+type PostgresWebhookRepository struct {
+	pool   *pgxpool.Pool
+	cipher *fieldcrypto.Cipher
+}
+
+// NewPostgresWebhookRepository creates a new webhook repository
+func NewPostgresWebhookRepository(pool *pgxpool.Pool, cipher *fieldcrypto.Cipher) repositories.WebhookRepository {
+	return &PostgresWebhookRepository{
+		pool:   pool,
+		cipher: cipher,
+	}
+}
+
+// CreateEndpoint inserts an endpoint with its secret encrypted
+func (r *PostgresWebhookRepository) CreateEndpoint(ctx context.Context, endpoint *entities.WebhookEndpoint) error {
+	secretEncrypted, err := r.cipher.Encrypt(ctx, fieldWebhookSecret, endpoint.Secret)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt webhook secret: %w", err)
+	}
+
+	query := `
+		INSERT INTO core_dict.webhook_endpoints (
+			id, url, event_types, description, secret_encrypted, active,
+			created_by, created_at, updated_at
+		) VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7, $8, $9)
+	`
+
+	_, err = conn(ctx, r.pool).Exec(ctx, query,
+		endpoint.ID,
+		endpoint.URL,
+		endpoint.EventTypes,
+		endpoint.Description,
+		secretEncrypted,
+		endpoint.Active,
+		endpoint.CreatedBy,
+		endpoint.CreatedAt,
+		endpoint.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook endpoint: %w", err)
+	}
+
+	return nil
+}
+
+// Enqueue inserts one delivery per active endpoint subscribed to the event type, directly or
+// through the aggregate wildcard (claim.*)
+func (r *PostgresWebhookRepository) Enqueue(ctx context.Context, event *entities.WebhookEvent) (int, error) {
+	aggregate, _, _ := strings.Cut(event.EventType, ".")
+
+	query := `
+		INSERT INTO core_dict.webhook_deliveries (
+			endpoint_id, event_id, event_type, aggregate_id, payload, occurred_at
+		)
+		SELECT id, $1, $2, $3, $4, $5
+		FROM core_dict.webhook_endpoints
+		WHERE active AND ($2 = ANY(event_types) OR $6 = ANY(event_types))
+		ON CONFLICT (endpoint_id, event_id) DO NOTHING
+	`
+
+	result, err := conn(ctx, r.pool).Exec(ctx, query,
+		event.EventID,
+		event.EventType,
+		event.AggregateID,
+		event.Payload,
+		event.OccurredAt,
+		aggregate+".*",
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to enqueue webhook deliveries: %w", err)
+	}
+
+	return int(result.RowsAffected()), nil
+}
+
+// ClaimDue leases due deliveries of active endpoints (SKIP LOCKED, so replicas claim disjoint sets)
+func (r *PostgresWebhookRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*entities.WebhookDelivery, error) {
+	query := `
+		WITH due AS (
+			SELECT d.id
+			FROM core_dict.webhook_deliveries d
+			JOIN core_dict.webhook_endpoints e ON e.id = d.endpoint_id AND e.active
+			WHERE d.status = 'PENDING' AND d.next_attempt_at <= NOW()
+			ORDER BY d.next_attempt_at
+			LIMIT $1
+			FOR UPDATE OF d SKIP LOCKED
+		)
+		UPDATE core_dict.webhook_deliveries d
+		SET next_attempt_at = NOW() + make_interval(secs => $2)
+		FROM due, core_dict.webhook_endpoints e
+		WHERE d.id = due.id AND e.id = d.endpoint_id
+		RETURNING d.id, d.endpoint_id, d.event_id, d.event_type, d.aggregate_id, d.payload,
+		          d.occurred_at, d.status, d.attempts, d.next_attempt_at,
+		          COALESCE(d.last_status_code, 0), COALESCE(d.last_error, ''),
+		          d.created_at, d.updated_at, e.url, e.secret_encrypted
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+	var secrets [][]byte
+	deliveries, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*entities.WebhookDelivery, error) {
+		delivery := &entities.WebhookDelivery{Endpoint: &entities.WebhookEndpoint{Active: true}}
+		var status string
+		var secretEncrypted []byte
+		if err := row.Scan(
+			&delivery.ID,
+			&delivery.EndpointID,
+			&delivery.EventID,
+			&delivery.EventType,
+			&delivery.AggregateID,
+			&delivery.Payload,
+			&delivery.OccurredAt,
+			&status,
+			&delivery.Attempts,
+			&delivery.NextAttemptAt,
+			&delivery.LastStatusCode,
+			&delivery.LastError,
+			&delivery.CreatedAt,
+			&delivery.UpdatedAt,
+			&delivery.Endpoint.URL,
+			&secretEncrypted,
+		); err != nil {
+			return nil, err
+		}
+		delivery.Status = entities.WebhookDeliveryStatus(status)
+		delivery.Endpoint.ID = delivery.EndpointID
+		secrets = append(secrets, secretEncrypted)
+		return delivery, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan webhook deliveries: %w", err)
+	}
+
+	for i, delivery := range deliveries {
+		secret, err := r.cipher.Decrypt(ctx, fieldWebhookSecret, secrets[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt secret of webhook endpoint %s: %w", delivery.EndpointID, err)
+		}
+		delivery.Endpoint.Secret = secret
+	}
+
+	return deliveries, nil
+}
+
+// UpdateDelivery stores the outcome of an attempt
+func (r *PostgresWebhookRepository) UpdateDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	query := `
+		UPDATE core_dict.webhook_deliveries
+		SET status = $2, attempts = $3, next_attempt_at = $4, last_status_code = NULLIF($5, 0),
+		    last_error = NULLIF($6, ''), delivered_at = $7, updated_at = $8
+		WHERE id = $1
+	`
+
+	result, err := conn(ctx, r.pool).Exec(ctx, query,
+		delivery.ID,
+		string(delivery.Status),
+		delivery.Attempts,
+		delivery.NextAttemptAt,
+		delivery.LastStatusCode,
+		delivery.LastError,
+		delivery.DeliveredAt,
+		delivery.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("webhook delivery not found: %s", delivery.ID)
+	}
+
+	return nil
+}
+
+// Replay moves the matching deliveries back to PENDING with a fresh attempt budget
+func (r *PostgresWebhookRepository) Replay(ctx context.Context, filter entities.WebhookReplayFilter) (int64, error) {
+	conditions := []string{"status = $1"}
+	args := []interface{}{string(filter.Status)}
+
+	if len(filter.DeliveryIDs) > 0 {
+		args = append(args, filter.DeliveryIDs)
+		conditions = append(conditions, fmt.Sprintf("id = ANY($%d)", len(args)))
+	}
+	if filter.EndpointID != nil {
+		args = append(args, *filter.EndpointID)
+		conditions = append(conditions, fmt.Sprintf("endpoint_id = $%d", len(args)))
+	}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conditions = append(conditions, fmt.Sprintf("created_at < $%d", len(args)))
+	}
+
+	query := `
+		UPDATE core_dict.webhook_deliveries
+		SET status = 'PENDING', attempts = 0, next_attempt_at = NOW(), last_status_code = NULL,
+		    last_error = NULL, delivered_at = NULL, updated_at = NOW()
+		WHERE ` + strings.Join(conditions, " AND ")
+
+	result, err := conn(ctx, r.pool).Exec(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to replay webhook deliveries: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,87 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	connectv1 "github.com/lbpay-lab/dict-contracts/gen/proto/connect/v1"
+
+	"github.com/lbpay-lab/core-dict/internal/application/services"
+)
+
+// ConnDictSourceName identifies conn-dict in data subject requests
+const ConnDictSourceName = "conn-dict"
+
+// ConnDictPersonalDataSource reaches the personal data held by conn-dict (entries, claims,
+// infractions and their logs) through the ConnectService CollectPersonalData and
+// ErasePersonalData RPCs; conn-dict owns its tables and does the reads and the pseudonymisation.
+// It implements services.PersonalDataSource.
+type ConnDictPersonalDataSource struct {
+	client *ConnectClient
+}
+
+// NewConnDictPersonalDataSource creates a personal data source over the conn-dict client
+func NewConnDictPersonalDataSource(client *ConnectClient) *ConnDictPersonalDataSource {
+	return &ConnDictPersonalDataSource{client: client}
+}
+
+// Name returns the source name used in bundles and erasure reports
+func (s *ConnDictPersonalDataSource) Name() string {
+	return ConnDictSourceName
+}
+
+// CollectPersonalData returns every conn-dict record tied to the tax ID
+func (s *ConnDictPersonalDataSource) CollectPersonalData(ctx context.Context, taxID string) ([]*services.PersonalDataRecord, error) {
+	records, err := s.client.CollectPersonalData(ctx, taxID, uuid.New().String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect conn-dict personal data: %w", err)
+	}
+
+	result := make([]*services.PersonalDataRecord, 0, len(records))
+	for _, record := range records {
+		var fields map[string]interface{}
+		if err := json.Unmarshal(record.FieldsJson, &fields); err != nil {
+			return nil, fmt.Errorf("failed to decode conn-dict record %s/%s: %w", record.Table, record.RecordId, err)
+		}
+		result = append(result, &services.PersonalDataRecord{
+			Source:   ConnDictSourceName,
+			Table:    record.Table,
+			RecordID: record.RecordId,
+			Fields:   fields,
+		})
+	}
+	return result, nil
+}
+
+// ErasePersonalData pseudonymises the subject's conn-dict records closed before retainAfter
+func (s *ConnDictPersonalDataSource) ErasePersonalData(ctx context.Context, taxID string, retainAfter time.Time, dryRun bool) ([]*services.ErasureAction, error) {
+	actions, err := s.client.ErasePersonalData(ctx, taxID, retainAfter, dryRun, uuid.New().String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to erase conn-dict personal data: %w", err)
+	}
+
+	result := make([]*services.ErasureAction, 0, len(actions))
+	for _, action := range actions {
+		var outcome services.ErasureOutcome
+		switch action.Outcome {
+		case connectv1.PersonalDataErasureOutcome_PERSONAL_DATA_ERASURE_OUTCOME_PSEUDONYMISED:
+			outcome = services.ErasureOutcomePseudonymised
+		case connectv1.PersonalDataErasureOutcome_PERSONAL_DATA_ERASURE_OUTCOME_RETAINED:
+			outcome = services.ErasureOutcomeRetained
+		default:
+			return nil, fmt.Errorf("conn-dict returned no erasure outcome for %s/%s", action.Table, action.RecordId)
+		}
+		result = append(result, &services.ErasureAction{
+			Source:   ConnDictSourceName,
+			Table:    action.Table,
+			RecordID: action.RecordId,
+			Outcome:  outcome,
+			Reason:   action.Reason,
+		})
+	}
+	return result, nil
+}
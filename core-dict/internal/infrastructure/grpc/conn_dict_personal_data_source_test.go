@@ -0,0 +1,101 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	connectv1 "github.com/lbpay-lab/dict-contracts/gen/proto/connect/v1"
+
+	"github.com/lbpay-lab/core-dict/internal/application/services"
+)
+
+// fakePersonalDataClient answers the conn-dict personal data RPCs
+type fakePersonalDataClient struct {
+	connectv1.ConnectServiceClient
+	collect *connectv1.CollectPersonalDataResponse
+	erase   *connectv1.ErasePersonalDataResponse
+
+	eraseReq *connectv1.ErasePersonalDataRequest
+}
+
+func (f *fakePersonalDataClient) CollectPersonalData(_ context.Context, _ *connectv1.CollectPersonalDataRequest, _ ...grpc.CallOption) (*connectv1.CollectPersonalDataResponse, error) {
+	return f.collect, nil
+}
+
+func (f *fakePersonalDataClient) ErasePersonalData(_ context.Context, req *connectv1.ErasePersonalDataRequest, _ ...grpc.CallOption) (*connectv1.ErasePersonalDataResponse, error) {
+	f.eraseReq = req
+	return f.erase, nil
+}
+
+func newTestConnDictSource(fake *fakePersonalDataClient) *ConnDictPersonalDataSource {
+	return NewConnDictPersonalDataSource(&ConnectClient{
+		client:         fake,
+		circuitBreaker: NewCircuitBreaker(CircuitBreakerConfig{}),
+		retryPolicy:    NewRetryPolicy(RetryConfig{}),
+		config:         ClientConfig{Timeout: time.Second},
+	})
+}
+
+func TestConnDictPersonalDataSource_Collect(t *testing.T) {
+	source := newTestConnDictSource(&fakePersonalDataClient{
+		collect: &connectv1.CollectPersonalDataResponse{
+			Records: []*connectv1.PersonalDataRecord{{
+				Table:      "entries",
+				RecordId:   "entry-1",
+				FieldsJson: []byte(`{"key":"12345678909","owner_name":null}`),
+			}},
+		},
+	})
+
+	records, err := source.CollectPersonalData(context.Background(), "12345678909")
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, ConnDictSourceName, records[0].Source)
+	assert.Equal(t, "entries", records[0].Table)
+	assert.Equal(t, "entry-1", records[0].RecordID)
+	assert.Equal(t, "12345678909", records[0].Fields["key"])
+	assert.Contains(t, records[0].Fields, "owner_name")
+}
+
+func TestConnDictPersonalDataSource_Erase(t *testing.T) {
+	fake := &fakePersonalDataClient{
+		erase: &connectv1.ErasePersonalDataResponse{
+			Actions: []*connectv1.PersonalDataErasureAction{
+				{Table: "entries", RecordId: "entry-1", Outcome: connectv1.PersonalDataErasureOutcome_PERSONAL_DATA_ERASURE_OUTCOME_PSEUDONYMISED},
+				{Table: "claims", RecordId: "claim-1", Outcome: connectv1.PersonalDataErasureOutcome_PERSONAL_DATA_ERASURE_OUTCOME_RETAINED, Reason: "claim is open"},
+			},
+		},
+	}
+	source := newTestConnDictSource(fake)
+	retainAfter := time.Date(2021, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	actions, err := source.ErasePersonalData(context.Background(), "12345678909", retainAfter, true)
+	require.NoError(t, err)
+
+	require.NotNil(t, fake.eraseReq)
+	assert.Equal(t, retainAfter, fake.eraseReq.RetainAfter.AsTime())
+	assert.True(t, fake.eraseReq.DryRun)
+	assert.NotEmpty(t, fake.eraseReq.RequestId)
+
+	require.Len(t, actions, 2)
+	assert.Equal(t, services.ErasureOutcomePseudonymised, actions[0].Outcome)
+	assert.Equal(t, ConnDictSourceName, actions[0].Source)
+	assert.Equal(t, services.ErasureOutcomeRetained, actions[1].Outcome)
+	assert.Equal(t, "claim is open", actions[1].Reason)
+}
+
+func TestConnDictPersonalDataSource_EraseRejectsUnknownOutcome(t *testing.T) {
+	source := newTestConnDictSource(&fakePersonalDataClient{
+		erase: &connectv1.ErasePersonalDataResponse{
+			Actions: []*connectv1.PersonalDataErasureAction{{Table: "entries", RecordId: "entry-1"}},
+		},
+	})
+
+	_, err := source.ErasePersonalData(context.Background(), "12345678909", time.Now(), false)
+	assert.Error(t, err)
+}
@@ -10,6 +10,7 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/keepalive"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	commonv1 "github.com/lbpay-lab/dict-contracts/gen/proto/common/v1"
 	connectv1 "github.com/lbpay-lab/dict-contracts/gen/proto/connect/v1"
@@ -674,6 +675,67 @@ func (c *ConnectClient) ListInfractions(ctx context.Context, filters ListInfract
 	return infractions, totalCount, nil
 }
 
+// =============================================================================
+// PERSONAL DATA - LGPD (2 methods)
+// =============================================================================
+
+// CollectPersonalData returns the conn-dict records tied to a tax ID (data subject access request)
+func (c *ConnectClient) CollectPersonalData(ctx context.Context, taxID string, requestID string) ([]*connectv1.PersonalDataRecord, error) {
+	var records []*connectv1.PersonalDataRecord
+
+	err := c.executeWithRetry(ctx, func() error {
+		ctxWithTimeout, cancel := context.WithTimeout(ctx, c.config.Timeout)
+		defer cancel()
+
+		resp, err := c.client.CollectPersonalData(ctxWithTimeout, &connectv1.CollectPersonalDataRequest{
+			TaxId:     taxID,
+			RequestId: requestID,
+		})
+		if err != nil {
+			return mapGRPCError(err)
+		}
+
+		records = resp.Records
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// ErasePersonalData pseudonymises the conn-dict records of a tax ID closed before retainAfter.
+// Erasure is idempotent, so a retried call reports the same records.
+func (c *ConnectClient) ErasePersonalData(ctx context.Context, taxID string, retainAfter time.Time, dryRun bool, requestID string) ([]*connectv1.PersonalDataErasureAction, error) {
+	var actions []*connectv1.PersonalDataErasureAction
+
+	err := c.executeWithRetry(ctx, func() error {
+		ctxWithTimeout, cancel := context.WithTimeout(ctx, c.config.Timeout)
+		defer cancel()
+
+		resp, err := c.client.ErasePersonalData(ctxWithTimeout, &connectv1.ErasePersonalDataRequest{
+			TaxId:       taxID,
+			RetainAfter: timestamppb.New(retainAfter),
+			DryRun:      dryRun,
+			RequestId:   requestID,
+		})
+		if err != nil {
+			return mapGRPCError(err)
+		}
+
+		actions = resp.Actions
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return actions, nil
+}
+
 // =============================================================================
 // HEALTH CHECK (1 method)
 // =============================================================================
@@ -5,6 +5,10 @@ import (
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+
+	commonv1 "github.com/lbpay-lab/dict-contracts/gen/proto/common/v1"
+
+	"github.com/lbpay-lab/core-dict/internal/domain"
 )
 
 // Domain errors for Connect client
@@ -30,6 +34,11 @@ func mapGRPCError(err error) error {
 		return err
 	}
 
+	// Bacen rejections forwarded by Connect/Bridge keep their reason code
+	if rejection, ok := bacenRejectionFromStatus(st); ok {
+		return domain.FromBacenRejection(rejection)
+	}
+
 	switch st.Code() {
 	case codes.NotFound:
 		// Try to determine which entity wasn't found based on message
@@ -59,3 +68,25 @@ func mapGRPCError(err error) error {
 		return err
 	}
 }
+
+// bacenRejectionFromStatus extracts the common.v1.BacenError detail attached by the Bridge
+func bacenRejectionFromStatus(st *status.Status) (domain.BacenRejection, bool) {
+	for _, detail := range st.Details() {
+		bacenErr, ok := detail.(*commonv1.BacenError)
+		if !ok || bacenErr.GetBacenCode() == "" {
+			continue
+		}
+
+		rejection := domain.BacenRejection{
+			Code:       bacenErr.GetBacenCode(),
+			Message:    bacenErr.GetBacenMessage(),
+			RequestID:  bacenErr.GetBacenRequestId(),
+			HTTPStatus: int(bacenErr.GetHttpStatus()),
+		}
+		if bacenErr.GetOccurredAt() != nil {
+			rejection.OccurredAt = bacenErr.GetOccurredAt().AsTime()
+		}
+		return rejection, true
+	}
+	return domain.BacenRejection{}, false
+}
@@ -0,0 +1,131 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	commonv1 "github.com/lbpay-lab/dict-contracts/gen/proto/common/v1"
+	corev1 "github.com/lbpay-lab/dict-contracts/gen/proto/core/v1"
+
+	"github.com/lbpay-lab/core-dict/internal/domain"
+	"github.com/lbpay-lab/core-dict/internal/infrastructure/grpc/mappers"
+)
+
+// statusDetails splits the details of a gRPC error by type
+func statusDetails(t *testing.T, err error) (*errdetails.ErrorInfo, *errdetails.BadRequest, *commonv1.BusinessError, *commonv1.BacenError) {
+	t.Helper()
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+
+	var (
+		info     *errdetails.ErrorInfo
+		bad      *errdetails.BadRequest
+		business *commonv1.BusinessError
+		bacenErr *commonv1.BacenError
+	)
+	for _, d := range st.Details() {
+		switch v := d.(type) {
+		case *errdetails.ErrorInfo:
+			info = v
+		case *errdetails.BadRequest:
+			bad = v
+		case *commonv1.BusinessError:
+			business = v
+		case *commonv1.BacenError:
+			bacenErr = v
+		}
+	}
+	return info, bad, business, bacenErr
+}
+
+// A Bacen rejection sent by the Bridge (through Connect) reaches the Core DICT client
+// with the stable code, the Bacen reason code and the original BacenError
+func TestMapGRPCError_BacenRejectionEndToEnd(t *testing.T) {
+	occurredAt := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	bridgeStatus, err := status.New(codes.AlreadyExists, "key owned by another participant").WithDetails(
+		&errdetails.ErrorInfo{Reason: "EntryKeyOwnedByDifferentParticipant", Domain: "dict.pi.rsfn.net.br"},
+		&commonv1.BacenError{
+			BacenCode:      "EntryKeyOwnedByDifferentParticipant",
+			BacenMessage:   "key owned by another participant",
+			BacenRequestId: "corr-123",
+			OccurredAt:     timestamppb.New(occurredAt),
+			HttpStatus:     409,
+		},
+	)
+	require.NoError(t, err)
+
+	// Connect wraps the Bridge error before returning it
+	connectErr := fmt.Errorf("bridge CreateEntry failed: %w", bridgeStatus.Err())
+
+	domainErr := mapGRPCError(connectErr)
+	assert.True(t, errors.Is(domainErr, domain.ErrDuplicateKeyGlobal))
+
+	clientErr := mappers.MapDomainErrorToGRPC(domainErr)
+	assert.Equal(t, codes.AlreadyExists, status.Code(clientErr))
+
+	info, bad, business, bacenErr := statusDetails(t, clientErr)
+	require.NotNil(t, info)
+	assert.Equal(t, "KEY_OWNED_BY_OTHER_PARTICIPANT", info.GetReason())
+	assert.Equal(t, mappers.ErrorDomain, info.GetDomain())
+	assert.Equal(t, "EntryKeyOwnedByDifferentParticipant", info.GetMetadata()["bacen_code"])
+	assert.Nil(t, bad)
+	require.NotNil(t, business)
+	assert.Equal(t, "EntryKeyOwnedByDifferentParticipant", business.GetBacenCode())
+	require.NotNil(t, bacenErr)
+	assert.Equal(t, "corr-123", bacenErr.GetBacenRequestId())
+	assert.Equal(t, int32(409), bacenErr.GetHttpStatus())
+	assert.Equal(t, occurredAt, bacenErr.GetOccurredAt().AsTime())
+}
+
+func TestMapGRPCError_WithoutBacenDetail(t *testing.T) {
+	err := mapGRPCError(status.Error(codes.Unavailable, "connect down"))
+	assert.ErrorIs(t, err, ErrConnectUnavailable)
+}
+
+func TestMapDomainErrorToGRPC_Details(t *testing.T) {
+	err := mappers.MapDomainErrorToGRPC(domain.ErrValidation.
+		WithMessage("reason is required for blocking").
+		WithField("reason", "required"))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	info, bad, business, bacenErr := statusDetails(t, err)
+	assert.Equal(t, "VALIDATION_FAILED", info.GetReason())
+	require.Len(t, bad.GetFieldViolations(), 1)
+	assert.Equal(t, "reason", bad.GetFieldViolations()[0].GetField())
+	require.Len(t, business.GetViolations(), 1)
+	assert.Equal(t, "reason is required for blocking", business.GetMessage())
+	assert.Nil(t, bacenErr)
+
+	err = mappers.MapDomainErrorToGRPC(domain.ErrClaimExpired)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// Untyped errors stay opaque
+	err = mappers.MapDomainErrorToGRPC(errors.New("pq: connection refused"))
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.NotContains(t, err.Error(), "pq:")
+}
+
+func TestUnblockKey_InvalidStatusCarriesErrorInfo(t *testing.T) {
+	entry := activeEntry()
+	env := newHandlerTestEnv(entry)
+
+	_, err := env.handler.UnblockKey(authContext("operator", "admin"), &corev1.UnblockKeyRequest{
+		KeyId:  entry.ID.String(),
+		Reason: corev1.UnblockReason_UNBLOCK_REASON_JUDICIAL_RELEASE,
+	})
+	require.Error(t, err)
+
+	info, _, business, _ := statusDetails(t, err)
+	require.NotNil(t, info)
+	assert.Equal(t, "INVALID_ENTRY_STATUS", info.GetReason())
+	assert.Equal(t, "ACTIVE", business.GetContext()["status"])
+}
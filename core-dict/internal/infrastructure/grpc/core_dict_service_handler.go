@@ -2,6 +2,7 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"time"
@@ -12,13 +13,15 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
-	corev1 "github.com/lbpay-lab/dict-contracts/gen/proto/core/v1"
 	commonv1 "github.com/lbpay-lab/dict-contracts/gen/proto/common/v1"
+	corev1 "github.com/lbpay-lab/dict-contracts/gen/proto/core/v1"
 
 	"github.com/lbpay-lab/core-dict/internal/application/commands"
 	"github.com/lbpay-lab/core-dict/internal/application/queries"
+	"github.com/lbpay-lab/core-dict/internal/application/services"
 	"github.com/lbpay-lab/core-dict/internal/domain/entities"
 	"github.com/lbpay-lab/core-dict/internal/domain/valueobjects"
+	"github.com/lbpay-lab/core-dict/internal/infrastructure/cache"
 	"github.com/lbpay-lab/core-dict/internal/infrastructure/grpc/mappers"
 )
 
@@ -36,27 +39,48 @@ type CoreDictServiceHandler struct {
 	useMockMode bool
 
 	// ========== Command Handlers (Write Operations) ==========
-	createEntryCmd   *commands.CreateEntryCommandHandler
-	updateEntryCmd   *commands.UpdateEntryCommandHandler
-	deleteEntryCmd   *commands.DeleteEntryCommandHandler
-	blockEntryCmd    *commands.BlockEntryCommandHandler
-	unblockEntryCmd  *commands.UnblockEntryCommandHandler
-	createClaimCmd   *commands.CreateClaimCommandHandler
-	confirmClaimCmd  *commands.ConfirmClaimCommandHandler
-	cancelClaimCmd   *commands.CancelClaimCommandHandler
-	completeClaimCmd *commands.CompleteClaimCommandHandler
+	createEntryCmd        *commands.CreateEntryCommandHandler
+	updateEntryCmd        *commands.UpdateEntryCommandHandler
+	deleteEntryCmd        *commands.DeleteEntryCommandHandler
+	blockEntryCmd         *commands.BlockEntryCommandHandler
+	unblockEntryCmd       *commands.UnblockEntryCommandHandler
+	createClaimCmd        *commands.CreateClaimCommandHandler
+	confirmClaimCmd       *commands.ConfirmClaimCommandHandler
+	cancelClaimCmd        *commands.CancelClaimCommandHandler
+	completeClaimCmd      *commands.CompleteClaimCommandHandler
+	closeAccountCmd       *commands.CloseAccountCommandHandler
+	confirmPortabilityCmd *commands.ConfirmPortabilityCommandHandler
 
 	// ========== Query Handlers (Read Operations) ==========
-	getEntryQuery       *queries.GetEntryQueryHandler
-	listEntriesQuery    *queries.ListEntriesQueryHandler
-	getClaimQuery       *queries.GetClaimQueryHandler
-	listClaimsQuery     *queries.ListClaimsQueryHandler
-	getAccountQuery     *queries.GetAccountQueryHandler
-	verifyAccountQuery  *queries.VerifyAccountQueryHandler
-	healthCheckQuery    *queries.HealthCheckQueryHandler
-	getStatisticsQuery  *queries.GetStatisticsQueryHandler
+	getEntryQuery        *queries.GetEntryQueryHandler
+	listEntriesQuery     *queries.ListEntriesQueryHandler
+	getClaimQuery        *queries.GetClaimQueryHandler
+	listClaimsQuery      *queries.ListClaimsQueryHandler
+	getAccountQuery      *queries.GetAccountQueryHandler
+	verifyAccountQuery   *queries.VerifyAccountQueryHandler
+	healthCheckQuery     *queries.HealthCheckQueryHandler
+	getStatisticsQuery   *queries.GetStatisticsQueryHandler
 	listInfractionsQuery *queries.ListInfractionsQueryHandler
-	getAuditLogQuery    *queries.GetAuditLogQueryHandler
+	getAuditLogQuery     *queries.GetAuditLogQueryHandler
+	getKeyHistoryQuery   *queries.GetKeyHistoryQueryHandler
+
+	// ========== Anti-Scan (LookupKey rate limiting) ==========
+	lookupLimiter LookupRateLimiter
+
+	// ========== Key Ownership Verification (EMAIL/PHONE) ==========
+	// nil -> verification disabled (FEATURE_OTP_VALIDATION=false)
+	keyVerifier *services.KeyVerificationService
+
+	// ========== LGPD Data Subject Requests ==========
+	// nil -> ExportPersonalData/ErasePersonalData disabled (DSR_ENABLED=false)
+	dsrService *services.DataSubjectRequestService
+
+	// ========== Audit Hash Chain ==========
+	auditChainService *services.AuditChainService
+
+	// ========== Webhooks ==========
+	// nil -> RegisterWebhookEndpoint/ReplayWebhookDeliveries disabled (WEBHOOKS_ENABLED=false)
+	webhookService *services.WebhookService
 
 	// ========== Logger ==========
 	logger *slog.Logger
@@ -68,6 +92,11 @@ type CoreDictServiceHandler struct {
 //   - useMockMode: Feature flag to toggle between mock and real implementations
 //   - Command handlers: All command handlers for write operations
 //   - Query handlers: All query handlers for read operations
+//   - lookupLimiter: Anti-scan token buckets (shared with LookupRateLimitInterceptor)
+//   - keyVerifier: EMAIL/PHONE ownership verification (nil disables it)
+//   - dsrService: LGPD data subject export/erasure (nil disables it)
+//   - auditChainService: Audit log hash chain verification
+//   - webhookService: Webhook endpoint registration and delivery replay (nil disables it)
 //   - logger: Structured logger
 func NewCoreDictServiceHandler(
 	useMockMode bool,
@@ -81,6 +110,8 @@ func NewCoreDictServiceHandler(
 	confirmClaimCmd *commands.ConfirmClaimCommandHandler,
 	cancelClaimCmd *commands.CancelClaimCommandHandler,
 	completeClaimCmd *commands.CompleteClaimCommandHandler,
+	closeAccountCmd *commands.CloseAccountCommandHandler,
+	confirmPortabilityCmd *commands.ConfirmPortabilityCommandHandler,
 	// Queries
 	getEntryQuery *queries.GetEntryQueryHandler,
 	listEntriesQuery *queries.ListEntriesQueryHandler,
@@ -92,31 +123,50 @@ func NewCoreDictServiceHandler(
 	getStatisticsQuery *queries.GetStatisticsQueryHandler,
 	listInfractionsQuery *queries.ListInfractionsQueryHandler,
 	getAuditLogQuery *queries.GetAuditLogQueryHandler,
+	getKeyHistoryQuery *queries.GetKeyHistoryQueryHandler,
+	// Anti-scan
+	lookupLimiter LookupRateLimiter,
+	// Key ownership verification
+	keyVerifier *services.KeyVerificationService,
+	// LGPD data subject requests
+	dsrService *services.DataSubjectRequestService,
+	// Audit hash chain
+	auditChainService *services.AuditChainService,
+	// Webhooks
+	webhookService *services.WebhookService,
 	// Logger
 	logger *slog.Logger,
 ) *CoreDictServiceHandler {
 	return &CoreDictServiceHandler{
-		useMockMode:          useMockMode,
-		createEntryCmd:       createEntryCmd,
-		updateEntryCmd:       updateEntryCmd,
-		deleteEntryCmd:       deleteEntryCmd,
-		blockEntryCmd:        blockEntryCmd,
-		unblockEntryCmd:      unblockEntryCmd,
-		createClaimCmd:       createClaimCmd,
-		confirmClaimCmd:      confirmClaimCmd,
-		cancelClaimCmd:       cancelClaimCmd,
-		completeClaimCmd:     completeClaimCmd,
-		getEntryQuery:        getEntryQuery,
-		listEntriesQuery:     listEntriesQuery,
-		getClaimQuery:        getClaimQuery,
-		listClaimsQuery:      listClaimsQuery,
-		getAccountQuery:      getAccountQuery,
-		verifyAccountQuery:   verifyAccountQuery,
-		healthCheckQuery:     healthCheckQuery,
-		getStatisticsQuery:   getStatisticsQuery,
-		listInfractionsQuery: listInfractionsQuery,
-		getAuditLogQuery:     getAuditLogQuery,
-		logger:               logger,
+		useMockMode:           useMockMode,
+		createEntryCmd:        createEntryCmd,
+		updateEntryCmd:        updateEntryCmd,
+		deleteEntryCmd:        deleteEntryCmd,
+		blockEntryCmd:         blockEntryCmd,
+		unblockEntryCmd:       unblockEntryCmd,
+		createClaimCmd:        createClaimCmd,
+		confirmClaimCmd:       confirmClaimCmd,
+		cancelClaimCmd:        cancelClaimCmd,
+		completeClaimCmd:      completeClaimCmd,
+		closeAccountCmd:       closeAccountCmd,
+		confirmPortabilityCmd: confirmPortabilityCmd,
+		getEntryQuery:         getEntryQuery,
+		listEntriesQuery:      listEntriesQuery,
+		getClaimQuery:         getClaimQuery,
+		listClaimsQuery:       listClaimsQuery,
+		getAccountQuery:       getAccountQuery,
+		verifyAccountQuery:    verifyAccountQuery,
+		healthCheckQuery:      healthCheckQuery,
+		getStatisticsQuery:    getStatisticsQuery,
+		listInfractionsQuery:  listInfractionsQuery,
+		getAuditLogQuery:      getAuditLogQuery,
+		getKeyHistoryQuery:    getKeyHistoryQuery,
+		lookupLimiter:         lookupLimiter,
+		keyVerifier:           keyVerifier,
+		dsrService:            dsrService,
+		auditChainService:     auditChainService,
+		webhookService:        webhookService,
+		logger:                logger,
 	}
 }
 
@@ -129,6 +179,8 @@ func NewCoreDictServiceHandler(
 // HYBRID MODE:
 // - MOCK MODE (useMockMode=true): Returns mock response for Front-End testing
 // - REAL MODE (useMockMode=false): Executes business logic via CreateEntryCommandHandler
+//
+// NOTE: EMAIL/PHONE keys require a confirmed ownership verification (verification_id)
 func (h *CoreDictServiceHandler) CreateKey(ctx context.Context, req *corev1.CreateKeyRequest) (*corev1.CreateKeyResponse, error) {
 	// ========== 1. VALIDATION (always, regardless of mode) ==========
 	if req.GetKeyType() == commonv1.KeyType_KEY_TYPE_UNSPECIFIED {
@@ -176,14 +228,23 @@ func (h *CoreDictServiceHandler) CreateKey(ctx context.Context, req *corev1.Crea
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	// 3c. Execute command handler
+	// 3c. EMAIL/PHONE keys need a confirmed ownership verification (single use)
+	keyType := mappers.MapProtoKeyTypeToDomain(req.GetKeyType())
+	if h.keyVerifier != nil && services.RequiresVerification(keyType) {
+		if _, err := h.keyVerifier.Consume(ctx, req.GetVerificationId(), userID, keyType, req.GetKeyValue()); err != nil {
+			h.logger.Warn("CreateKey: key ownership not verified", "error", err, "user_id", userID, "verification_id", req.GetVerificationId())
+			return nil, mappers.MapDomainErrorToGRPC(err)
+		}
+	}
+
+	// 3d. Execute command handler
 	result, err := h.createEntryCmd.Handle(ctx, cmd)
 	if err != nil {
 		h.logger.Error("CreateKey: command failed", "error", err, "user_id", userID)
 		return nil, mappers.MapDomainErrorToGRPC(err)
 	}
 
-	// 3d. Map domain result → proto response
+	// 3e. Map domain result → proto response
 	h.logger.Info("CreateKey: success", "entry_id", result.EntryID, "user_id", userID)
 	return &corev1.CreateKeyResponse{
 		KeyId: result.EntryID.String(),
@@ -349,6 +410,259 @@ func (h *CoreDictServiceHandler) DeleteKey(ctx context.Context, req *corev1.Dele
 	}, nil
 }
 
+// ========================================================================
+// KEY VERIFICATION OPERATIONS (EMAIL/PHONE ownership)
+// ========================================================================
+
+// RequestKeyVerification sends an ownership verification code to an EMAIL or PHONE key.
+// The confirmed verification_id is required by CreateKey and ConfirmPortability.
+//
+// HYBRID MODE:
+// - MOCK MODE: Returns mock response (no code is sent)
+// - REAL MODE: Issues the code through KeyVerificationService (Redis + notifier)
+func (h *CoreDictServiceHandler) RequestKeyVerification(ctx context.Context, req *corev1.RequestKeyVerificationRequest) (*corev1.RequestKeyVerificationResponse, error) {
+	// ========== 1. VALIDATION (always, regardless of mode) ==========
+	keyType := mappers.MapProtoKeyTypeToDomain(req.GetKeyType())
+	if !services.RequiresVerification(keyType) {
+		return nil, status.Error(codes.InvalidArgument, "key_type must be EMAIL or PHONE")
+	}
+	if req.GetKeyValue() == "" {
+		return nil, status.Error(codes.InvalidArgument, "key_value is required")
+	}
+
+	// ========== 2. MOCK MODE (for Front-End integration testing) ==========
+	if h.useMockMode {
+		h.logger.Info("RequestKeyVerification: MOCK MODE", "key_type", keyType)
+		return &corev1.RequestKeyVerificationResponse{
+			VerificationId:    fmt.Sprintf("mock-verification-%d", time.Now().Unix()),
+			MaskedDestination: services.MaskDestination(keyType, req.GetKeyValue()),
+			ExpiresAt:         timestamppb.New(time.Now().Add(10 * time.Minute)),
+			MaxAttempts:       5,
+		}, nil
+	}
+
+	// ========== 3. REAL MODE (business logic) ==========
+	h.logger.Info("RequestKeyVerification: REAL MODE", "key_type", keyType)
+
+	// 3a. Extract user_id from context (set by auth interceptor)
+	userID, ok := ctx.Value("user_id").(string)
+	if !ok || userID == "" {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+
+	if h.keyVerifier == nil {
+		return nil, status.Error(codes.FailedPrecondition, "key ownership verification is disabled")
+	}
+
+	// 3b. Issue and send the code
+	verification, err := h.keyVerifier.Request(ctx, userID, keyType, req.GetKeyValue())
+	if err != nil {
+		h.logger.Error("RequestKeyVerification: failed", "error", err, "user_id", userID)
+		return nil, mappers.MapDomainErrorToGRPC(err)
+	}
+
+	// 3c. Map to proto response
+	h.logger.Info("RequestKeyVerification: code sent", "verification_id", verification.ID, "user_id", userID)
+	return &corev1.RequestKeyVerificationResponse{
+		VerificationId:    verification.ID,
+		MaskedDestination: services.MaskDestination(keyType, verification.KeyValue),
+		ExpiresAt:         timestamppb.New(verification.ExpiresAt),
+		MaxAttempts:       int32(verification.MaxAttempts),
+	}, nil
+}
+
+// ConfirmKeyVerification checks the code received by the user. Every wrong code uses one
+// attempt; the error carries the remaining attempts (ErrorInfo metadata "remaining_attempts").
+//
+// HYBRID MODE:
+// - MOCK MODE: Returns mock response (any code is accepted)
+// - REAL MODE: Checks the code through KeyVerificationService (Redis)
+func (h *CoreDictServiceHandler) ConfirmKeyVerification(ctx context.Context, req *corev1.ConfirmKeyVerificationRequest) (*corev1.ConfirmKeyVerificationResponse, error) {
+	// ========== 1. VALIDATION (always, regardless of mode) ==========
+	if req.GetVerificationId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "verification_id is required")
+	}
+	if req.GetCode() == "" {
+		return nil, status.Error(codes.InvalidArgument, "code is required")
+	}
+
+	// ========== 2. MOCK MODE (for Front-End integration testing) ==========
+	if h.useMockMode {
+		h.logger.Info("ConfirmKeyVerification: MOCK MODE", "verification_id", req.GetVerificationId())
+		return &corev1.ConfirmKeyVerificationResponse{
+			VerificationId: req.GetVerificationId(),
+			Key: &commonv1.DictKey{
+				KeyType:  commonv1.KeyType_KEY_TYPE_EMAIL,
+				KeyValue: "mock@example.com",
+			},
+			Verified:   true,
+			ValidUntil: timestamppb.New(time.Now().Add(30 * time.Minute)),
+		}, nil
+	}
+
+	// ========== 3. REAL MODE (business logic) ==========
+	h.logger.Info("ConfirmKeyVerification: REAL MODE", "verification_id", req.GetVerificationId())
+
+	// 3a. Extract user_id from context (set by auth interceptor)
+	userID, ok := ctx.Value("user_id").(string)
+	if !ok || userID == "" {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+
+	if h.keyVerifier == nil {
+		return nil, status.Error(codes.FailedPrecondition, "key ownership verification is disabled")
+	}
+
+	// 3b. Check the code
+	verification, err := h.keyVerifier.Confirm(ctx, req.GetVerificationId(), userID, req.GetCode())
+	if err != nil {
+		h.logger.Warn("ConfirmKeyVerification: failed", "error", err, "verification_id", req.GetVerificationId(), "user_id", userID)
+		return nil, mappers.MapDomainErrorToGRPC(err)
+	}
+
+	// 3c. Map to proto response
+	h.logger.Info("ConfirmKeyVerification: verified", "verification_id", verification.ID, "user_id", userID)
+	return &corev1.ConfirmKeyVerificationResponse{
+		VerificationId: verification.ID,
+		Key: &commonv1.DictKey{
+			KeyType:  mappers.MapDomainKeyTypeToProto(verification.KeyType),
+			KeyValue: verification.KeyValue,
+		},
+		Verified:   true,
+		ValidUntil: timestamppb.New(verification.ExpiresAt),
+	}, nil
+}
+
+// ========================================================================
+// BLOCK OPERATIONS (Back-office / Fraud)
+// ========================================================================
+
+// BlockKey blocks a PIX key (judicial order, fraud suspicion, infraction)
+//
+// HYBRID MODE:
+// - MOCK MODE: Returns mock response
+// - REAL MODE: Executes BlockEntryCommandHandler (persists, audits ENTRY_BLOCKED, publishes event)
+//
+// NOTE: Restricted to back-office roles (admin, support)
+func (h *CoreDictServiceHandler) BlockKey(ctx context.Context, req *corev1.BlockKeyRequest) (*corev1.BlockKeyResponse, error) {
+	// ========== 1. VALIDATION (always, regardless of mode) ==========
+	if req.GetKeyId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "key_id is required")
+	}
+	if req.GetReason() == corev1.BlockReason_BLOCK_REASON_UNSPECIFIED {
+		return nil, status.Error(codes.InvalidArgument, "reason is required")
+	}
+
+	// ========== 2. MOCK MODE (for Front-End integration testing) ==========
+	if h.useMockMode {
+		h.logger.Info("BlockKey: MOCK MODE", "key_id", req.GetKeyId(), "reason", req.GetReason())
+		return &corev1.BlockKeyResponse{
+			KeyId:     req.GetKeyId(),
+			Status:    commonv1.EntryStatus_ENTRY_STATUS_BLOCKED,
+			BlockedAt: timestamppb.Now(),
+		}, nil
+	}
+
+	// ========== 3. REAL MODE (business logic) ==========
+	h.logger.Info("BlockKey: REAL MODE", "key_id", req.GetKeyId(), "reason", req.GetReason())
+
+	// 3a. Extract user_id from context and check role (set by auth interceptor)
+	userID, ok := ctx.Value("user_id").(string)
+	if !ok || userID == "" {
+		h.logger.Warn("BlockKey: user not authenticated")
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+	if err := CheckPermission(ctx, "admin", "support"); err != nil {
+		h.logger.Warn("BlockKey: permission denied", "user_id", userID)
+		return nil, err
+	}
+
+	// 3b. Map proto request → domain command
+	cmd, err := mappers.MapProtoBlockKeyRequestToCommand(req, userID)
+	if err != nil {
+		h.logger.Error("BlockKey: mapping failed", "error", err, "user_id", userID)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// 3c. Execute command handler
+	result, err := h.blockEntryCmd.Handle(ctx, cmd)
+	if err != nil {
+		h.logger.Error("BlockKey: command failed", "error", err, "key_id", req.GetKeyId(), "user_id", userID)
+		return nil, mappers.MapDomainErrorToGRPC(err)
+	}
+
+	// 3d. Map domain result → proto response
+	h.logger.Info("BlockKey: success", "entry_id", result.EntryID, "reason", cmd.ReasonCode, "user_id", userID)
+	return &corev1.BlockKeyResponse{
+		KeyId:     result.EntryID.String(),
+		Status:    mappers.MapStringStatusToProto(string(result.Status)),
+		BlockedAt: timestamppb.New(result.BlockedAt),
+	}, nil
+}
+
+// UnblockKey unblocks a previously blocked PIX key
+//
+// HYBRID MODE:
+// - MOCK MODE: Returns mock response
+// - REAL MODE: Executes UnblockEntryCommandHandler (persists, audits ENTRY_UNBLOCKED, publishes event)
+//
+// NOTE: Restricted to back-office roles (admin, support)
+func (h *CoreDictServiceHandler) UnblockKey(ctx context.Context, req *corev1.UnblockKeyRequest) (*corev1.UnblockKeyResponse, error) {
+	// ========== 1. VALIDATION (always, regardless of mode) ==========
+	if req.GetKeyId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "key_id is required")
+	}
+	if req.GetReason() == corev1.UnblockReason_UNBLOCK_REASON_UNSPECIFIED {
+		return nil, status.Error(codes.InvalidArgument, "reason is required")
+	}
+
+	// ========== 2. MOCK MODE (for Front-End integration testing) ==========
+	if h.useMockMode {
+		h.logger.Info("UnblockKey: MOCK MODE", "key_id", req.GetKeyId(), "reason", req.GetReason())
+		return &corev1.UnblockKeyResponse{
+			KeyId:       req.GetKeyId(),
+			Status:      commonv1.EntryStatus_ENTRY_STATUS_ACTIVE,
+			UnblockedAt: timestamppb.Now(),
+		}, nil
+	}
+
+	// ========== 3. REAL MODE (business logic) ==========
+	h.logger.Info("UnblockKey: REAL MODE", "key_id", req.GetKeyId(), "reason", req.GetReason())
+
+	// 3a. Extract user_id from context and check role (set by auth interceptor)
+	userID, ok := ctx.Value("user_id").(string)
+	if !ok || userID == "" {
+		h.logger.Warn("UnblockKey: user not authenticated")
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+	if err := CheckPermission(ctx, "admin", "support"); err != nil {
+		h.logger.Warn("UnblockKey: permission denied", "user_id", userID)
+		return nil, err
+	}
+
+	// 3b. Map proto request → domain command
+	cmd, err := mappers.MapProtoUnblockKeyRequestToCommand(req, userID)
+	if err != nil {
+		h.logger.Error("UnblockKey: mapping failed", "error", err, "user_id", userID)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// 3c. Execute command handler
+	result, err := h.unblockEntryCmd.Handle(ctx, cmd)
+	if err != nil {
+		h.logger.Error("UnblockKey: command failed", "error", err, "key_id", req.GetKeyId(), "user_id", userID)
+		return nil, mappers.MapDomainErrorToGRPC(err)
+	}
+
+	// 3d. Map domain result → proto response
+	h.logger.Info("UnblockKey: success", "entry_id", result.EntryID, "reason", cmd.ReasonCode, "user_id", userID)
+	return &corev1.UnblockKeyResponse{
+		KeyId:       result.EntryID.String(),
+		Status:      mappers.MapStringStatusToProto(string(result.Status)),
+		UnblockedAt: timestamppb.New(result.UnblockedAt),
+	}, nil
+}
+
 // ========================================================================
 // CLAIM OPERATIONS (30-day ownership claims)
 // ========================================================================
@@ -388,18 +702,18 @@ func (h *CoreDictServiceHandler) GetClaimStatus(ctx context.Context, req *corev1
 	expiresAt := now.Add(29 * 24 * time.Hour)
 
 	return &corev1.GetClaimStatusResponse{
-		ClaimId:   req.GetClaimId(),
-		EntryId:   "entry-123",
+		ClaimId: req.GetClaimId(),
+		EntryId: "entry-123",
 		Key: &commonv1.DictKey{
 			KeyType:  commonv1.KeyType_KEY_TYPE_CPF,
 			KeyValue: "12345678900",
 		},
-		Status:         commonv1.ClaimStatus_CLAIM_STATUS_OPEN,
-		ClaimerIspb:    "87654321",
-		OwnerIspb:      "12345678",
-		CreatedAt:      timestamppb.New(now.Add(-24 * time.Hour)),
-		ExpiresAt:      timestamppb.New(expiresAt),
-		DaysRemaining:  29,
+		Status:        commonv1.ClaimStatus_CLAIM_STATUS_OPEN,
+		ClaimerIspb:   "87654321",
+		OwnerIspb:     "12345678",
+		CreatedAt:     timestamppb.New(now.Add(-24 * time.Hour)),
+		ExpiresAt:     timestamppb.New(expiresAt),
+		DaysRemaining: 29,
 	}, nil
 }
 
@@ -413,24 +727,63 @@ func (h *CoreDictServiceHandler) ListIncomingClaims(ctx context.Context, req *co
 		pageSize = 100
 	}
 
-	// TODO: Execute query handler
-	return &corev1.ListIncomingClaimsResponse{
-		Claims: []*corev1.ClaimSummary{
-			{
-				ClaimId: "claim-1",
-				EntryId: "entry-123",
-				Key: &commonv1.DictKey{
-					KeyType:  commonv1.KeyType_KEY_TYPE_EMAIL,
-					KeyValue: "user@example.com",
+	// ========== MOCK MODE ==========
+	if h.useMockMode {
+		h.logger.Info("ListIncomingClaims: MOCK MODE")
+		return &corev1.ListIncomingClaimsResponse{
+			Claims: []*corev1.ClaimSummary{
+				{
+					ClaimId: "claim-1",
+					EntryId: "entry-123",
+					Key: &commonv1.DictKey{
+						KeyType:  commonv1.KeyType_KEY_TYPE_EMAIL,
+						KeyValue: "user@example.com",
+					},
+					Status:        commonv1.ClaimStatus_CLAIM_STATUS_OPEN,
+					CreatedAt:     timestamppb.Now(),
+					ExpiresAt:     timestamppb.New(time.Now().Add(30 * 24 * time.Hour)),
+					DaysRemaining: 30,
 				},
-				Status:        commonv1.ClaimStatus_CLAIM_STATUS_OPEN,
-				CreatedAt:     timestamppb.Now(),
-				ExpiresAt:     timestamppb.New(time.Now().Add(30 * 24 * time.Hour)),
-				DaysRemaining: 30,
 			},
-		},
-		NextPageToken: "",
-		TotalCount:    1,
+			NextPageToken: "",
+			TotalCount:    1,
+		}, nil
+	}
+
+	// ========== REAL MODE ==========
+	// Incoming claims (we are the donor) reach the local DB through dict.claims.created,
+	// published by the Connect poller of Bacen claims
+	ispb, err := GetISPB(ctx)
+	if err != nil || ispb == "" {
+		return nil, status.Error(codes.Unauthenticated, "participant ISPB not found in context")
+	}
+	h.logger.Info("ListIncomingClaims: REAL MODE", "ispb", ispb, "page_size", pageSize)
+
+	query, err := mappers.MapProtoListIncomingClaimsRequestToQuery(req, ispb)
+	if err != nil {
+		h.logger.Error("ListIncomingClaims: mapping failed", "error", err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	result, err := h.listClaimsQuery.Handle(ctx, query)
+	if err != nil {
+		h.logger.Error("ListIncomingClaims: query failed", "error", err, "ispb", ispb)
+		return nil, mappers.MapDomainErrorToGRPC(err)
+	}
+
+	claims := make([]*corev1.ClaimSummary, 0, len(result.Claims))
+	for _, claim := range result.Claims {
+		claims = append(claims, mappers.MapDomainClaimToProtoSummary(claim))
+	}
+
+	nextPageToken := ""
+	if result.Page < result.TotalPages {
+		nextPageToken = fmt.Sprintf("page=%d", result.Page+1)
+	}
+
+	return &corev1.ListIncomingClaimsResponse{
+		Claims:        claims,
+		NextPageToken: nextPageToken,
+		TotalCount:    int32(result.TotalCount),
 	}, nil
 }
 
@@ -725,7 +1078,10 @@ func (h *CoreDictServiceHandler) StartPortability(ctx context.Context, req *core
 //
 // HYBRID MODE:
 // - MOCK MODE: Returns mock response
-// - REAL MODE: Executes business logic via UpdateEntryCommandHandler (set status to ACTIVE)
+// - REAL MODE: Executes business logic via ConfirmPortabilityCommandHandler (approves the portability)
+//
+// NOTE: The key is resolved from the portability record (a key sent in the request must match it).
+// A portability that requires OTP needs a confirmed ownership verification of its key (verification_id).
 func (h *CoreDictServiceHandler) ConfirmPortability(ctx context.Context, req *corev1.ConfirmPortabilityRequest) (*corev1.ConfirmPortabilityResponse, error) {
 	// ========== 1. VALIDATION (always, regardless of mode) ==========
 	if req.GetPortabilityId() == "" {
@@ -752,41 +1108,30 @@ func (h *CoreDictServiceHandler) ConfirmPortability(ctx context.Context, req *co
 		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
 	}
 
-	// 3b. Lookup portability record by portability_id
-	// TODO: Query PortabilityHistory table to get EntryID and verify user owns it
-	// For now, assume portability_id format is "port-<entryID>"
-
-	// Parse portability_id to extract entry_id (mock implementation)
-	// In real mode, this would query the PortabilityHistory table
-	var entryID uuid.UUID
-	// TODO: Fetch from PortabilityHistory table
-
-	requestedBy, err := uuid.Parse(userID)
+	portabilityID, err := uuid.Parse(req.GetPortabilityId())
 	if err != nil {
-		h.logger.Error("ConfirmPortability: invalid user_id", "error", err, "user_id", userID)
-		return nil, status.Error(codes.Internal, "invalid user_id format")
-	}
-
-	// 3c. Update entry status to ACTIVE (portability confirmed)
-	cmd := commands.UpdateEntryCommand{
-		EntryID:     entryID,
-		RequestedBy: requestedBy,
-		// Status: "ACTIVE" (portability completed)
+		return nil, status.Error(codes.InvalidArgument, "invalid portability_id format")
 	}
 
-	result, err := h.updateEntryCmd.Handle(ctx, cmd)
+	// 3b. Approve the portability (key from the portability record, OTP enforced by the entity)
+	result, err := h.confirmPortabilityCmd.Handle(ctx, commands.ConfirmPortabilityCommand{
+		PortabilityID:  portabilityID,
+		KeyValue:       req.GetKey().GetKeyValue(),
+		VerificationID: req.GetVerificationId(),
+		RequestedBy:    userID,
+	})
 	if err != nil {
-		h.logger.Error("ConfirmPortability: UpdateEntryCommand failed", "error", err, "user_id", userID, "portability_id", req.GetPortabilityId())
+		h.logger.Warn("ConfirmPortability: failed", "error", err, "user_id", userID, "portability_id", req.GetPortabilityId())
 		return nil, mappers.MapDomainErrorToGRPC(err)
 	}
 
-	// 3d. Map domain result → proto response
+	// 3c. Map domain result → proto response
 	h.logger.Info("ConfirmPortability: success", "entry_id", result.EntryID, "portability_id", req.GetPortabilityId(), "user_id", userID)
 	return &corev1.ConfirmPortabilityResponse{
-		PortabilityId: req.GetPortabilityId(),
+		PortabilityId: result.PortabilityID.String(),
 		KeyId:         result.EntryID.String(),
-		Status:        commonv1.EntryStatus_ENTRY_STATUS_ACTIVE, // TODO: Map from UpdateEntryResult when Status field is added
-		ConfirmedAt:   timestamppb.New(result.UpdatedAt),
+		Status:        mappers.MapStringStatusToProto(string(result.EntryStatus)),
+		ConfirmedAt:   timestamppb.New(result.ConfirmedAt),
 	}, nil
 }
 
@@ -855,6 +1200,82 @@ func (h *CoreDictServiceHandler) CancelPortability(ctx context.Context, req *cor
 	}, nil
 }
 
+// ========================================================================
+// ACCOUNT OPERATIONS
+// ========================================================================
+
+// CloseAccount closes an account and deletes all of its keys at Bacen (reason ACCOUNT_CLOSURE),
+// cancelling open claims and portabilities first
+//
+// HYBRID MODE:
+// - MOCK MODE: Returns mock response
+// - REAL MODE: Executes CloseAccountCommandHandler (per-key outcomes; retrying resumes failed keys)
+//
+// NOTE: Restricted to back-office roles (admin, support); core banking closures also arrive
+// as AccountClosed events on Pulsar
+func (h *CoreDictServiceHandler) CloseAccount(ctx context.Context, req *corev1.CloseAccountRequest) (*corev1.CloseAccountResponse, error) {
+	// ========== 1. VALIDATION (always, regardless of mode) ==========
+	if req.GetAccountId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "account_id is required")
+	}
+	accountID, err := uuid.Parse(req.GetAccountId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid account_id format")
+	}
+
+	// ========== 2. MOCK MODE (for Front-End integration testing) ==========
+	if h.useMockMode {
+		h.logger.Info("CloseAccount: MOCK MODE", "account_id", req.GetAccountId())
+		now := timestamppb.Now()
+		return &corev1.CloseAccountResponse{
+			AccountId:   req.GetAccountId(),
+			ClosureId:   uuid.New().String(),
+			Status:      corev1.AccountClosureStatus_ACCOUNT_CLOSURE_STATUS_COMPLETED,
+			StartedAt:   now,
+			CompletedAt: now,
+		}, nil
+	}
+
+	// ========== 3. REAL MODE (business logic) ==========
+	h.logger.Info("CloseAccount: REAL MODE", "account_id", req.GetAccountId())
+
+	// 3a. Extract user_id from context and check role (set by auth interceptor)
+	userID, ok := ctx.Value("user_id").(string)
+	if !ok || userID == "" {
+		h.logger.Warn("CloseAccount: user not authenticated")
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+	if err := CheckPermission(ctx, "admin", "support"); err != nil {
+		h.logger.Warn("CloseAccount: permission denied", "user_id", userID)
+		return nil, err
+	}
+
+	if h.closeAccountCmd == nil {
+		return nil, status.Error(codes.FailedPrecondition, "account closure is not configured")
+	}
+
+	// 3b. Execute command handler
+	result, err := h.closeAccountCmd.Handle(ctx, commands.CloseAccountCommand{
+		AccountID:   accountID,
+		Trigger:     entities.AccountClosureTriggerRPC,
+		RequestedBy: userID,
+		Reason:      req.GetReason(),
+	})
+	if err != nil {
+		h.logger.Error("CloseAccount: command failed", "error", err, "account_id", req.GetAccountId(), "user_id", userID)
+		return nil, mappers.MapDomainErrorToGRPC(err)
+	}
+
+	// 3c. Map domain result → proto response
+	h.logger.Info("CloseAccount: success",
+		"account_id", req.GetAccountId(),
+		"status", result.Closure.Status,
+		"keys_deleted", result.Closure.Count(entities.AccountClosureKeyDeleted),
+		"keys_failed", result.Closure.Count(entities.AccountClosureKeyFailed),
+		"user_id", userID)
+	return mappers.MapAccountClosureToProto(result.Closure), nil
+}
+
 // ========================================================================
 // QUERY OPERATIONS
 // ========================================================================
@@ -931,6 +1352,647 @@ func (h *CoreDictServiceHandler) LookupKey(ctx context.Context, req *corev1.Look
 	return resp, nil
 }
 
+// ========================================================================
+// ANTI-SCAN OPERATIONS (LookupKey rate limiting)
+// ========================================================================
+
+// GetLookupRateLimitStatus returns the anti-scan token buckets of the participant and,
+// when payer_tax_id is set, of that end user. Read-only: it never charges or refills.
+//
+// HYBRID MODE:
+// - MOCK MODE: Returns mock response
+// - REAL MODE: Reads the buckets from the shared LookupRateLimiter (Redis)
+//
+// NOTE: Restricted to support roles (admin, support) - used to explain a blocked lookup
+func (h *CoreDictServiceHandler) GetLookupRateLimitStatus(ctx context.Context, req *corev1.GetLookupRateLimitStatusRequest) (*corev1.GetLookupRateLimitStatusResponse, error) {
+	// ========== 1. MOCK MODE (for Front-End integration testing) ==========
+	if h.useMockMode {
+		h.logger.Info("GetLookupRateLimitStatus: MOCK MODE")
+		return &corev1.GetLookupRateLimitStatusResponse{
+			Buckets: []*corev1.LookupTokenBucket{
+				{
+					Scope:           corev1.LookupRateLimitScope_LOOKUP_RATE_LIMIT_SCOPE_PARTICIPANT,
+					Subject:         "12345678",
+					Tokens:          50000,
+					Capacity:        50000,
+					RefillPerMinute: 25000,
+					SuccessCost:     1,
+					NotFoundCost:    3,
+				},
+			},
+		}, nil
+	}
+
+	// ========== 2. REAL MODE (business logic) ==========
+	h.logger.Info("GetLookupRateLimitStatus: REAL MODE", "with_payer", req.GetPayerTaxId() != "")
+
+	// 2a. Check role (set by auth interceptor)
+	if err := CheckPermission(ctx, "admin", "support"); err != nil {
+		h.logger.Warn("GetLookupRateLimitStatus: permission denied")
+		return nil, err
+	}
+
+	if h.lookupLimiter == nil {
+		return nil, status.Error(codes.Unavailable, "lookup rate limiting is not configured")
+	}
+
+	// 2b. Read buckets
+	states, err := h.lookupLimiter.Status(ctx, req.GetPayerTaxId())
+	if errors.Is(err, cache.ErrInvalidPayerTaxID) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		h.logger.Error("GetLookupRateLimitStatus: failed to read buckets", "error", err)
+		return nil, status.Error(codes.Unavailable, "failed to read lookup rate limit buckets")
+	}
+
+	// 2c. Map to proto response
+	return &corev1.GetLookupRateLimitStatusResponse{
+		Buckets: mappers.MapLookupBucketStatesToProto(states),
+	}, nil
+}
+
+// NotifyPaymentSettled gives anti-scan tokens back to the participant and payer buckets
+// for a settled payment. Idempotent per end_to_end_id.
+//
+// HYBRID MODE:
+// - MOCK MODE: Returns mock response
+// - REAL MODE: Credits the buckets in the shared LookupRateLimiter (Redis)
+//
+// NOTE: Restricted to admin (called by the payments settlement integration)
+func (h *CoreDictServiceHandler) NotifyPaymentSettled(ctx context.Context, req *corev1.NotifyPaymentSettledRequest) (*corev1.NotifyPaymentSettledResponse, error) {
+	// ========== 1. VALIDATION (always, regardless of mode) ==========
+	if req.GetEndToEndId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "end_to_end_id is required")
+	}
+	if req.GetPayerTaxId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "payer_tax_id is required")
+	}
+
+	// ========== 2. MOCK MODE (for Front-End integration testing) ==========
+	if h.useMockMode {
+		h.logger.Info("NotifyPaymentSettled: MOCK MODE", "end_to_end_id", req.GetEndToEndId())
+		return &corev1.NotifyPaymentSettledResponse{Credited: true}, nil
+	}
+
+	// ========== 3. REAL MODE (business logic) ==========
+	h.logger.Info("NotifyPaymentSettled: REAL MODE", "end_to_end_id", req.GetEndToEndId())
+
+	// 3a. Check role (set by auth interceptor)
+	if err := CheckPermission(ctx, "admin"); err != nil {
+		h.logger.Warn("NotifyPaymentSettled: permission denied")
+		return nil, err
+	}
+
+	if h.lookupLimiter == nil {
+		return nil, status.Error(codes.Unavailable, "lookup rate limiting is not configured")
+	}
+
+	// 3b. Credit buckets (once per EndToEndId)
+	credited, err := h.lookupLimiter.CreditSettlement(ctx, req.GetEndToEndId(), req.GetPayerTaxId())
+	if errors.Is(err, cache.ErrInvalidPayerTaxID) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		h.logger.Error("NotifyPaymentSettled: failed to credit buckets", "error", err, "end_to_end_id", req.GetEndToEndId())
+		return nil, status.Error(codes.Unavailable, "failed to credit lookup rate limit buckets")
+	}
+
+	h.logger.Info("NotifyPaymentSettled: success", "end_to_end_id", req.GetEndToEndId(), "credited", credited)
+	return &corev1.NotifyPaymentSettledResponse{Credited: credited}, nil
+}
+
+// ========================================================================
+// AUDIT OPERATIONS (Compliance)
+// ========================================================================
+
+// ListAuditEvents lists audit events filtered by entity, actor, event type and date range
+//
+// HYBRID MODE:
+// - MOCK MODE: Returns mock response
+// - REAL MODE: Executes GetAuditLogQueryHandler.HandleList (repository List/Count with AuditFilters)
+//
+// NOTE: Restricted to compliance roles (admin, auditor)
+func (h *CoreDictServiceHandler) ListAuditEvents(ctx context.Context, req *corev1.ListAuditEventsRequest) (*corev1.ListAuditEventsResponse, error) {
+	// ========== 1. VALIDATION (always, regardless of mode) ==========
+	if req.GetPageSize() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page_size must be positive")
+	}
+	if req.GetOccurredAfter() != nil && req.GetOccurredBefore() != nil &&
+		!req.GetOccurredAfter().AsTime().Before(req.GetOccurredBefore().AsTime()) {
+		return nil, status.Error(codes.InvalidArgument, "occurred_after must be before occurred_before")
+	}
+
+	// ========== 2. MOCK MODE (for Front-End integration testing) ==========
+	if h.useMockMode {
+		h.logger.Info("ListAuditEvents: MOCK MODE")
+		return &corev1.ListAuditEventsResponse{
+			Events: []*corev1.AuditEvent{
+				{
+					EventId:    "mock-event-1",
+					EventType:  string(entities.EventTypeEntryCreated),
+					EntityType: string(entities.EntityTypeEntry),
+					EntityId:   "mock-key-id",
+					ActorId:    "mock-user-id",
+					OccurredAt: timestamppb.Now(),
+				},
+			},
+			NextPageToken: "",
+			TotalCount:    1,
+		}, nil
+	}
+
+	// ========== 3. REAL MODE (business logic) ==========
+	h.logger.Info("ListAuditEvents: REAL MODE", "entity_type", req.GetEntityType(), "entity_id", req.GetEntityId(), "event_type", req.GetEventType())
+
+	// 3a. Check role (set by auth interceptor)
+	if err := CheckPermission(ctx, "admin", "auditor"); err != nil {
+		h.logger.Warn("ListAuditEvents: permission denied")
+		return nil, err
+	}
+
+	// 3b. Map proto request → domain query
+	query, err := mappers.MapProtoListAuditEventsRequestToQuery(req)
+	if err != nil {
+		h.logger.Error("ListAuditEvents: mapping failed", "error", err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// 3c. Execute query handler
+	result, err := h.getAuditLogQuery.HandleList(ctx, query)
+	if err != nil {
+		h.logger.Error("ListAuditEvents: query failed", "error", err)
+		return nil, mappers.MapDomainErrorToGRPC(err)
+	}
+
+	// 3d. Map domain result → proto response
+	nextPageToken := ""
+	if result.Page < result.TotalPages {
+		nextPageToken = fmt.Sprintf("page=%d", result.Page+1)
+	}
+
+	h.logger.Info("ListAuditEvents: success", "count", len(result.AuditLogs), "total", result.TotalCount)
+	return &corev1.ListAuditEventsResponse{
+		Events:        mappers.MapDomainAuditLogsToProto(result.AuditLogs),
+		NextPageToken: nextPageToken,
+		TotalCount:    int32(result.TotalCount),
+	}, nil
+}
+
+// GetKeyHistory returns the full history of a PIX key: entry, claim and infraction
+// audit events merged into a single chronological timeline
+//
+// HYBRID MODE:
+// - MOCK MODE: Returns mock response
+// - REAL MODE: Executes GetKeyHistoryQueryHandler
+//
+// NOTE: Restricted to compliance roles (admin, auditor)
+func (h *CoreDictServiceHandler) GetKeyHistory(ctx context.Context, req *corev1.GetKeyHistoryRequest) (*corev1.GetKeyHistoryResponse, error) {
+	// ========== 1. VALIDATION (always, regardless of mode) ==========
+	if req.GetKeyId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "key_id is required")
+	}
+
+	// ========== 2. MOCK MODE (for Front-End integration testing) ==========
+	if h.useMockMode {
+		h.logger.Info("GetKeyHistory: MOCK MODE", "key_id", req.GetKeyId())
+		return &corev1.GetKeyHistoryResponse{
+			KeyId: req.GetKeyId(),
+			Key: &commonv1.DictKey{
+				KeyType:  commonv1.KeyType_KEY_TYPE_CPF,
+				KeyValue: "12345678900",
+			},
+			Status: commonv1.EntryStatus_ENTRY_STATUS_ACTIVE,
+			Events: []*corev1.AuditEvent{
+				{
+					EventId:    "mock-event-1",
+					EventType:  string(entities.EventTypeEntryCreated),
+					EntityType: string(entities.EntityTypeEntry),
+					EntityId:   req.GetKeyId(),
+					ActorId:    "mock-user-id",
+					OccurredAt: timestamppb.Now(),
+				},
+			},
+		}, nil
+	}
+
+	// ========== 3. REAL MODE (business logic) ==========
+	h.logger.Info("GetKeyHistory: REAL MODE", "key_id", req.GetKeyId())
+
+	// 3a. Check role (set by auth interceptor)
+	if err := CheckPermission(ctx, "admin", "auditor"); err != nil {
+		h.logger.Warn("GetKeyHistory: permission denied", "key_id", req.GetKeyId())
+		return nil, err
+	}
+
+	// 3b. Map proto request → domain query
+	entryID, err := uuid.Parse(req.GetKeyId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid key_id")
+	}
+
+	// 3c. Execute query handler
+	result, err := h.getKeyHistoryQuery.Handle(ctx, queries.GetKeyHistoryQuery{EntryID: entryID})
+	if err != nil {
+		h.logger.Error("GetKeyHistory: query failed", "error", err, "key_id", req.GetKeyId())
+		return nil, mappers.MapDomainErrorToGRPC(err)
+	}
+
+	// 3d. Map domain result → proto response
+	h.logger.Info("GetKeyHistory: success", "key_id", req.GetKeyId(), "events", len(result.Events))
+	return &corev1.GetKeyHistoryResponse{
+		KeyId: result.Entry.ID.String(),
+		Key: &commonv1.DictKey{
+			KeyType:  mappers.MapDomainKeyTypeToProto(valueobjects.KeyType(result.Entry.KeyType)),
+			KeyValue: result.Entry.KeyValue,
+		},
+		Status: mappers.MapDomainStatusToProto(valueobjects.KeyStatus(result.Entry.Status)),
+		Events: mappers.MapDomainAuditLogsToProto(result.Events),
+	}, nil
+}
+
+// VerifyAuditChain recomputes the audit log hash chains of the months overlapping the period
+// and checks their signed checkpoints, reporting the first broken link
+//
+// HYBRID MODE:
+// - MOCK MODE: Returns mock response (valid, nothing verified)
+// - REAL MODE: Executes AuditChainService.Verify
+//
+// NOTE: Restricted to compliance roles (admin, auditor)
+func (h *CoreDictServiceHandler) VerifyAuditChain(ctx context.Context, req *corev1.VerifyAuditChainRequest) (*corev1.VerifyAuditChainResponse, error) {
+	// ========== 1. VALIDATION (always, regardless of mode) ==========
+	if req.GetFrom() == nil || req.GetTo() == nil {
+		return nil, status.Error(codes.InvalidArgument, "from and to are required")
+	}
+	from, to := req.GetFrom().AsTime(), req.GetTo().AsTime()
+	if to.Before(from) {
+		return nil, status.Error(codes.InvalidArgument, "to must not be before from")
+	}
+
+	// ========== 2. MOCK MODE (for Front-End integration testing) ==========
+	if h.useMockMode {
+		h.logger.Info("VerifyAuditChain: MOCK MODE", "from", from, "to", to)
+		return &corev1.VerifyAuditChainResponse{
+			Valid:      true,
+			VerifiedAt: timestamppb.Now(),
+		}, nil
+	}
+
+	// ========== 3. REAL MODE (business logic) ==========
+	h.logger.Info("VerifyAuditChain: REAL MODE", "from", from, "to", to, "stream", req.GetStream())
+
+	// 3a. Check role (set by auth interceptor)
+	if err := CheckPermission(ctx, "admin", "auditor"); err != nil {
+		h.logger.Warn("VerifyAuditChain: permission denied")
+		return nil, err
+	}
+
+	if h.auditChainService == nil {
+		return nil, status.Error(codes.FailedPrecondition, "audit chain verification is disabled")
+	}
+
+	// 3b. Walk the chains
+	result, err := h.auditChainService.Verify(ctx, from, to, req.GetStream())
+	if err != nil {
+		h.logger.Error("VerifyAuditChain: failed", "error", err)
+		return nil, mappers.MapDomainErrorToGRPC(err)
+	}
+
+	// 3c. Map to proto response
+	if !result.Valid() {
+		h.logger.Error("VerifyAuditChain: audit chain is broken",
+			"chain_partition", result.Break.Partition,
+			"stream", result.Break.Stream,
+			"chain_seq", result.Break.Seq,
+			"reason", result.Break.Reason)
+	} else {
+		h.logger.Info("VerifyAuditChain: success",
+			"chains", result.ChainsVerified, "events", result.EventsVerified, "checkpoints", result.CheckpointsVerified)
+	}
+	return mappers.MapAuditChainVerificationToProto(result), nil
+}
+
+// ========================================================================
+// LGPD (Data Subject Requests)
+// ========================================================================
+
+// ExportPersonalData compiles the personal data of a CPF/CNPJ from core-dict and conn-dict
+// into a signed JSON or CSV bundle (LGPD access/portability request)
+//
+// HYBRID MODE:
+//   - MOCK MODE: Returns mock response (empty bundle, not signed)
+//   - REAL MODE: Executes DataSubjectRequestService.Export; the request is audited under the
+//     subject pseudonym
+//
+// NOTE: Restricted to admin and dpo roles
+func (h *CoreDictServiceHandler) ExportPersonalData(ctx context.Context, req *corev1.ExportPersonalDataRequest) (*corev1.ExportPersonalDataResponse, error) {
+	// ========== 1. VALIDATION (always, regardless of mode) ==========
+	if req.GetTaxId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "tax_id is required")
+	}
+	if req.GetTicketId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "ticket_id is required")
+	}
+	format := mappers.MapProtoExportFormatToBundleFormat(req.GetFormat())
+
+	// ========== 2. MOCK MODE (for Front-End integration testing) ==========
+	if h.useMockMode {
+		h.logger.Info("ExportPersonalData: MOCK MODE", "ticket_id", req.GetTicketId())
+		return &corev1.ExportPersonalDataResponse{
+			RequestId:    uuid.New().String(),
+			Content:      []byte(`{"records":[]}`),
+			ContentType:  format.ContentType(),
+			SigningKeyId: "mock-key-id",
+			Sources:      []string{"core-dict", "conn-dict"},
+			GeneratedAt:  timestamppb.Now(),
+		}, nil
+	}
+
+	// ========== 3. REAL MODE (business logic) ==========
+	h.logger.Info("ExportPersonalData: REAL MODE", "ticket_id", req.GetTicketId())
+
+	// 3a. Check role (set by auth interceptor)
+	if err := CheckPermission(ctx, "admin", "dpo"); err != nil {
+		h.logger.Warn("ExportPersonalData: permission denied", "ticket_id", req.GetTicketId())
+		return nil, err
+	}
+	userID, _ := GetUserID(ctx)
+
+	if h.dsrService == nil {
+		return nil, status.Error(codes.FailedPrecondition, "data subject requests are disabled")
+	}
+
+	// 3b. Compile and sign the bundle
+	bundle, err := h.dsrService.Export(ctx, services.ExportPersonalDataRequest{
+		TaxID:       req.GetTaxId(),
+		Format:      format,
+		RequestedBy: userID,
+		TicketID:    req.GetTicketId(),
+		Reason:      req.GetReason(),
+	})
+	if err != nil {
+		h.logger.Error("ExportPersonalData: failed", "error", err, "ticket_id", req.GetTicketId(), "user_id", userID)
+		return nil, mappers.MapDomainErrorToGRPC(err)
+	}
+
+	// 3c. Map to proto response
+	h.logger.Info("ExportPersonalData: success",
+		"request_id", bundle.RequestID, "ticket_id", req.GetTicketId(), "records", bundle.RecordCount)
+	return mappers.MapSignedBundleToProto(bundle), nil
+}
+
+// ErasePersonalData pseudonymises the personal data of a CPF/CNPJ closed before the legal
+// retention period (LGPD erasure request); active and retained records are reported as such.
+// The audit events recorded before the erasure keep the old values and are retained, so the
+// response is only complete when nothing was retained.
+//
+// HYBRID MODE:
+//   - MOCK MODE: Returns mock response (no actions)
+//   - REAL MODE: Executes DataSubjectRequestService.Erase (dry_run only reports the actions);
+//     the request is audited under the subject pseudonym
+//
+// NOTE: Restricted to admin and dpo roles
+func (h *CoreDictServiceHandler) ErasePersonalData(ctx context.Context, req *corev1.ErasePersonalDataRequest) (*corev1.ErasePersonalDataResponse, error) {
+	// ========== 1. VALIDATION (always, regardless of mode) ==========
+	if req.GetTaxId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "tax_id is required")
+	}
+	if req.GetTicketId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "ticket_id is required")
+	}
+
+	// ========== 2. MOCK MODE (for Front-End integration testing) ==========
+	if h.useMockMode {
+		h.logger.Info("ErasePersonalData: MOCK MODE", "ticket_id", req.GetTicketId(), "dry_run", req.GetDryRun())
+		return &corev1.ErasePersonalDataResponse{
+			RequestId:        uuid.New().String(),
+			SubjectPseudonym: "mock-pseudonym",
+			DryRun:           req.GetDryRun(),
+			RetainAfter:      timestamppb.New(time.Now().AddDate(-5, 0, 0)),
+			ProcessedAt:      timestamppb.Now(),
+		}, nil
+	}
+
+	// ========== 3. REAL MODE (business logic) ==========
+	h.logger.Info("ErasePersonalData: REAL MODE", "ticket_id", req.GetTicketId(), "dry_run", req.GetDryRun())
+
+	// 3a. Check role (set by auth interceptor)
+	if err := CheckPermission(ctx, "admin", "dpo"); err != nil {
+		h.logger.Warn("ErasePersonalData: permission denied", "ticket_id", req.GetTicketId())
+		return nil, err
+	}
+	userID, _ := GetUserID(ctx)
+
+	if h.dsrService == nil {
+		return nil, status.Error(codes.FailedPrecondition, "data subject requests are disabled")
+	}
+
+	// 3b. Pseudonymise (or simulate)
+	report, err := h.dsrService.Erase(ctx, services.ErasePersonalDataRequest{
+		TaxID:       req.GetTaxId(),
+		RequestedBy: userID,
+		TicketID:    req.GetTicketId(),
+		Reason:      req.GetReason(),
+		DryRun:      req.GetDryRun(),
+	})
+	if err != nil {
+		h.logger.Error("ErasePersonalData: failed", "error", err, "ticket_id", req.GetTicketId(), "user_id", userID)
+		return nil, mappers.MapDomainErrorToGRPC(err)
+	}
+
+	// 3c. Map to proto response
+	h.logger.Info("ErasePersonalData: success",
+		"request_id", report.RequestID, "ticket_id", req.GetTicketId(), "dry_run", report.DryRun,
+		"pseudonymised", report.Count(services.ErasureOutcomePseudonymised),
+		"retained", report.Count(services.ErasureOutcomeRetained), "complete", report.Complete())
+	return mappers.MapErasureReportToProto(report), nil
+}
+
+// ========================================================================
+// WEBHOOKS (Backend notifications)
+// ========================================================================
+
+// RegisterWebhookEndpoint registers a backend endpoint that receives signed HTTP callbacks for
+// the subscribed claim/entry/infraction events
+//
+// HYBRID MODE:
+// - MOCK MODE: Returns mock response (nothing is registered)
+// - REAL MODE: Executes WebhookService.RegisterEndpoint; the signing secret is only returned here
+//
+// NOTE: Restricted to admin role
+func (h *CoreDictServiceHandler) RegisterWebhookEndpoint(ctx context.Context, req *corev1.RegisterWebhookEndpointRequest) (*corev1.RegisterWebhookEndpointResponse, error) {
+	// ========== 1. VALIDATION (always, regardless of mode) ==========
+	if req.GetUrl() == "" {
+		return nil, status.Error(codes.InvalidArgument, "url is required")
+	}
+	if len(req.GetEventTypes()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "event_types is required")
+	}
+	for _, eventType := range req.GetEventTypes() {
+		if !entities.IsValidWebhookSubscription(eventType) {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown event type %q", eventType)
+		}
+	}
+
+	// ========== 2. MOCK MODE (for Front-End integration testing) ==========
+	if h.useMockMode {
+		h.logger.Info("RegisterWebhookEndpoint: MOCK MODE", "url", req.GetUrl())
+		return &corev1.RegisterWebhookEndpointResponse{
+			EndpointId: uuid.New().String(),
+			Url:        req.GetUrl(),
+			EventTypes: req.GetEventTypes(),
+			Secret:     "whsec_mock",
+			Active:     true,
+			CreatedAt:  timestamppb.Now(),
+		}, nil
+	}
+
+	// ========== 3. REAL MODE (business logic) ==========
+	h.logger.Info("RegisterWebhookEndpoint: REAL MODE", "url", req.GetUrl(), "event_types", req.GetEventTypes())
+
+	// 3a. Check role (set by auth interceptor)
+	if err := CheckPermission(ctx, "admin"); err != nil {
+		h.logger.Warn("RegisterWebhookEndpoint: permission denied")
+		return nil, err
+	}
+	userID, _ := GetUserID(ctx)
+
+	if h.webhookService == nil {
+		return nil, status.Error(codes.FailedPrecondition, "webhooks are disabled")
+	}
+
+	// 3b. Register the endpoint
+	endpoint, err := h.webhookService.RegisterEndpoint(ctx, services.RegisterWebhookEndpointInput{
+		URL:         req.GetUrl(),
+		EventTypes:  req.GetEventTypes(),
+		Description: req.GetDescription(),
+		CreatedBy:   userID,
+	})
+	if err != nil {
+		h.logger.Error("RegisterWebhookEndpoint: failed", "error", err, "url", req.GetUrl(), "user_id", userID)
+		return nil, mappers.MapDomainErrorToGRPC(err)
+	}
+
+	// 3c. Map to proto response
+	h.logger.Info("RegisterWebhookEndpoint: success", "endpoint_id", endpoint.ID, "user_id", userID)
+	return mappers.MapWebhookEndpointToProto(endpoint), nil
+}
+
+// ReplayWebhookDeliveries moves webhook deliveries back to the queue with a fresh attempt
+// budget (by default the dead letter queue)
+//
+// HYBRID MODE:
+// - MOCK MODE: Returns mock response (nothing replayed)
+// - REAL MODE: Executes WebhookService.Replay
+//
+// NOTE: Restricted to back-office roles (admin, support)
+func (h *CoreDictServiceHandler) ReplayWebhookDeliveries(ctx context.Context, req *corev1.ReplayWebhookDeliveriesRequest) (*corev1.ReplayWebhookDeliveriesResponse, error) {
+	// ========== 1. VALIDATION (always, regardless of mode) ==========
+	filter, err := mappers.MapProtoReplayWebhookDeliveriesRequestToFilter(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// ========== 2. MOCK MODE (for Front-End integration testing) ==========
+	if h.useMockMode {
+		h.logger.Info("ReplayWebhookDeliveries: MOCK MODE")
+		return &corev1.ReplayWebhookDeliveriesResponse{}, nil
+	}
+
+	// ========== 3. REAL MODE (business logic) ==========
+	h.logger.Info("ReplayWebhookDeliveries: REAL MODE",
+		"endpoint_id", req.GetEndpointId(), "delivery_ids", len(req.GetDeliveryIds()), "status", filter.Status)
+
+	// 3a. Check role (set by auth interceptor)
+	if err := CheckPermission(ctx, "admin", "support"); err != nil {
+		h.logger.Warn("ReplayWebhookDeliveries: permission denied")
+		return nil, err
+	}
+	userID, _ := GetUserID(ctx)
+
+	if h.webhookService == nil {
+		return nil, status.Error(codes.FailedPrecondition, "webhooks are disabled")
+	}
+
+	// 3b. Requeue the deliveries
+	replayed, err := h.webhookService.Replay(ctx, filter)
+	if err != nil {
+		h.logger.Error("ReplayWebhookDeliveries: failed", "error", err, "user_id", userID)
+		return nil, mappers.MapDomainErrorToGRPC(err)
+	}
+
+	h.logger.Info("ReplayWebhookDeliveries: success", "replayed", replayed, "user_id", userID)
+	return &corev1.ReplayWebhookDeliveriesResponse{ReplayedCount: replayed}, nil
+}
+
+// ========================================================================
+// STATISTICS (Dashboard)
+// ========================================================================
+
+// GetStatistics returns aggregated key/claim counters with optional time window
+// and ISPB/branch breakdowns
+//
+// HYBRID MODE:
+//   - MOCK MODE: Returns mock response
+//   - REAL MODE: Executes GetStatisticsQueryHandler (reads the statistics materialized
+//     view, refreshed periodically by StatisticsRefresher; same source as /metrics)
+//
+// NOTE: Restricted to backoffice roles (admin, support, auditor)
+func (h *CoreDictServiceHandler) GetStatistics(ctx context.Context, req *corev1.GetStatisticsRequest) (*corev1.GetStatisticsResponse, error) {
+	// ========== 1. VALIDATION (always, regardless of mode) ==========
+	if req.GetCreatedAfter() != nil && req.GetCreatedBefore() != nil &&
+		req.GetCreatedAfter().AsTime().After(req.GetCreatedBefore().AsTime()) {
+		return nil, status.Error(codes.InvalidArgument, "created_after must be before created_before")
+	}
+	if req.GetBranch() != "" && req.GetIspb() == "" {
+		return nil, status.Error(codes.InvalidArgument, "branch filter requires ispb")
+	}
+
+	// ========== 2. MOCK MODE (for Front-End integration testing) ==========
+	if h.useMockMode {
+		h.logger.Info("GetStatistics: MOCK MODE")
+		return &corev1.GetStatisticsResponse{
+			TotalKeys:       1000,
+			ActiveKeys:      800,
+			BlockedKeys:     50,
+			DeletedKeys:     150,
+			KeysByType:      map[string]int64{"CPF": 400, "CNPJ": 300, "EMAIL": 200, "PHONE": 100},
+			TotalClaims:     200,
+			PendingClaims:   20,
+			CompletedClaims: 180,
+			ClaimsByType:    map[string]int64{"OWNERSHIP": 120, "PORTABILITY": 80},
+			RefreshedAt:     timestamppb.Now(),
+		}, nil
+	}
+
+	// ========== 3. REAL MODE (business logic) ==========
+	h.logger.Info("GetStatistics: REAL MODE", "ispb", req.GetIspb(), "branch", req.GetBranch(), "group_by", req.GetGroupBy().String())
+
+	// 3a. Check role (set by auth interceptor)
+	if err := CheckPermission(ctx, "admin", "support", "auditor"); err != nil {
+		h.logger.Warn("GetStatistics: permission denied")
+		return nil, err
+	}
+
+	// 3b. Map proto request → domain query
+	query, err := mappers.MapProtoGetStatisticsRequestToQuery(req)
+	if err != nil {
+		h.logger.Error("GetStatistics: mapping failed", "error", err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// 3c. Execute query handler
+	stats, err := h.getStatisticsQuery.Handle(ctx, query)
+	if err != nil {
+		h.logger.Error("GetStatistics: query failed", "error", err)
+		return nil, mappers.MapDomainErrorToGRPC(err)
+	}
+
+	// 3d. Map domain result → proto response
+	h.logger.Info("GetStatistics: success", "total_keys", stats.TotalKeys, "breakdown", len(stats.Breakdown))
+	return mappers.MapDomainStatisticsToProto(stats), nil
+}
+
 // HealthCheck performs a health check of the Core DICT service
 //
 // HYBRID MODE:
@@ -0,0 +1,785 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	commonv1 "github.com/lbpay-lab/dict-contracts/gen/proto/common/v1"
+	corev1 "github.com/lbpay-lab/dict-contracts/gen/proto/core/v1"
+
+	"github.com/lbpay-lab/core-dict/internal/application/commands"
+	"github.com/lbpay-lab/core-dict/internal/application/queries"
+	"github.com/lbpay-lab/core-dict/internal/domain"
+	"github.com/lbpay-lab/core-dict/internal/domain/entities"
+	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
+)
+
+// ============================================================================
+// In-memory fakes
+// ============================================================================
+
+type fakeEntryRepo struct {
+	mu      sync.Mutex
+	entries map[uuid.UUID]*entities.Entry
+	findErr error // Returned by FindByID when set
+}
+
+func newFakeEntryRepo(entries ...*entities.Entry) *fakeEntryRepo {
+	r := &fakeEntryRepo{entries: make(map[uuid.UUID]*entities.Entry)}
+	for _, e := range entries {
+		r.entries[e.ID] = e
+	}
+	return r
+}
+
+func (r *fakeEntryRepo) Create(_ context.Context, e *entities.Entry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries[e.ID] = e
+	return nil
+}
+
+func (r *fakeEntryRepo) Update(_ context.Context, e *entities.Entry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cp := *e
+	r.entries[e.ID] = &cp
+	return nil
+}
+
+func (r *fakeEntryRepo) Delete(_ context.Context, id uuid.UUID) error { return nil }
+
+func (r *fakeEntryRepo) UpdateStatus(_ context.Context, id uuid.UUID, st entities.KeyStatus) error {
+	return nil
+}
+
+func (r *fakeEntryRepo) FindByKey(_ context.Context, keyValue string) (*entities.Entry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, e := range r.entries {
+		if e.KeyValue == keyValue {
+			cp := *e
+			return &cp, nil
+		}
+	}
+	return nil, domain.ErrEntryNotFound.Wrap(pgx.ErrNoRows)
+}
+
+func (r *fakeEntryRepo) FindByID(_ context.Context, id uuid.UUID) (*entities.Entry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.findErr != nil {
+		return nil, r.findErr
+	}
+	e, ok := r.entries[id]
+	if !ok {
+		return nil, domain.ErrEntryNotFound.Wrap(pgx.ErrNoRows)
+	}
+	cp := *e
+	return &cp, nil
+}
+
+func (r *fakeEntryRepo) List(_ context.Context, _ uuid.UUID, _, _ int) ([]*entities.Entry, error) {
+	return nil, nil
+}
+
+func (r *fakeEntryRepo) CountByAccount(_ context.Context, _ uuid.UUID) (int64, error) {
+	return 0, nil
+}
+
+func (r *fakeEntryRepo) CountByOwnerAndType(_ context.Context, _ string, _ entities.KeyType) (int, error) {
+	return 0, nil
+}
+
+type fakeAuditRepo struct {
+	mu     sync.Mutex
+	events []*entities.AuditEvent
+}
+
+func (r *fakeAuditRepo) Create(_ context.Context, e *entities.AuditEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+	return nil
+}
+
+func (r *fakeAuditRepo) FindByID(_ context.Context, _ uuid.UUID) (*entities.AuditEvent, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (r *fakeAuditRepo) FindByEntityID(_ context.Context, entityType entities.EntityType, entityID uuid.UUID, _, _ int) ([]*entities.AuditEvent, error) {
+	return r.List(context.Background(), repositories.AuditFilters{EntityType: &entityType, EntityID: &entityID})
+}
+
+func (r *fakeAuditRepo) FindByEventType(_ context.Context, _ entities.EventType, _, _ int) ([]*entities.AuditEvent, error) {
+	return nil, nil
+}
+
+func (r *fakeAuditRepo) FindByUserID(_ context.Context, _ uuid.UUID, _, _ int) ([]*entities.AuditEvent, error) {
+	return nil, nil
+}
+
+func (r *fakeAuditRepo) FindByDateRange(_ context.Context, _, _ time.Time, _, _ int) ([]*entities.AuditEvent, error) {
+	return nil, nil
+}
+
+func (r *fakeAuditRepo) matching(f repositories.AuditFilters) []*entities.AuditEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []*entities.AuditEvent
+	for _, e := range r.events {
+		switch {
+		case f.EventType != nil && e.EventType != *f.EventType,
+			f.EntityType != nil && e.EntityType != *f.EntityType,
+			f.EntityID != nil && e.EntityID != *f.EntityID,
+			f.UserID != nil && (e.UserID == nil || *e.UserID != *f.UserID),
+			f.OccurredAfter != nil && !e.OccurredAt.After(*f.OccurredAfter),
+			f.OccurredBefore != nil && !e.OccurredAt.Before(*f.OccurredBefore):
+			continue
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+func (r *fakeAuditRepo) List(_ context.Context, f repositories.AuditFilters) ([]*entities.AuditEvent, error) {
+	out := r.matching(f)
+	if f.Offset >= len(out) {
+		return nil, nil
+	}
+	out = out[f.Offset:]
+	if f.Limit > 0 && f.Limit < len(out) {
+		out = out[:f.Limit]
+	}
+	return out, nil
+}
+
+func (r *fakeAuditRepo) Count(_ context.Context, f repositories.AuditFilters) (int64, error) {
+	return int64(len(r.matching(f))), nil
+}
+
+// fakeClaimRepo implements only the lookups used by the key history query
+type fakeClaimRepo struct {
+	repositories.ClaimRepository
+	claims []*entities.Claim
+}
+
+func (r *fakeClaimRepo) FindByEntryKey(_ context.Context, entryKey string) ([]*entities.Claim, error) {
+	var out []*entities.Claim
+	for _, c := range r.claims {
+		if c.EntryKey == entryKey {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+// fakeInfractionRepo implements only the lookups used by the key history query
+type fakeInfractionRepo struct {
+	repositories.InfractionRepository
+	infractions []*entities.Infraction
+}
+
+func (r *fakeInfractionRepo) FindByEntryID(_ context.Context, _ uuid.UUID) ([]*entities.Infraction, error) {
+	return r.infractions, nil
+}
+
+type fakePortabilityRepo struct {
+	portabilities map[uuid.UUID]*entities.Portability
+}
+
+func (r *fakePortabilityRepo) FindByID(_ context.Context, id uuid.UUID) (*entities.Portability, error) {
+	p, ok := r.portabilities[id]
+	if !ok {
+		return nil, domain.ErrPortabilityNotFound
+	}
+	c := *p
+	return &c, nil
+}
+
+func (r *fakePortabilityRepo) FindActiveByEntryID(_ context.Context, _ uuid.UUID) ([]*entities.Portability, error) {
+	return nil, nil
+}
+
+func (r *fakePortabilityRepo) Update(_ context.Context, p *entities.Portability) error {
+	c := *p
+	r.portabilities[p.ID] = &c
+	return nil
+}
+
+type fakeEventPublisher struct {
+	mu     sync.Mutex
+	events []commands.DomainEvent
+}
+
+func (p *fakeEventPublisher) Publish(_ context.Context, e commands.DomainEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, e)
+	return nil
+}
+
+type fakeCache struct {
+	mu   sync.Mutex
+	data map[string]interface{}
+}
+
+func newFakeCache() *fakeCache { return &fakeCache{data: make(map[string]interface{})} }
+
+func (c *fakeCache) Get(_ context.Context, key string) (interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.data[key]
+	if !ok {
+		return nil, errors.New("cache miss")
+	}
+	return v, nil
+}
+
+func (c *fakeCache) Set(_ context.Context, key string, value interface{}, _ time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.data[key] = value
+	return nil
+}
+
+func (c *fakeCache) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.data, key)
+	return nil
+}
+
+func (c *fakeCache) Exists(_ context.Context, key string) (bool, error) {
+	_, err := c.Get(context.Background(), key)
+	return err == nil, nil
+}
+
+func (c *fakeCache) Invalidate(_ context.Context, pattern string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.data {
+		if strings.HasPrefix(key, strings.TrimSuffix(pattern, "*")) {
+			delete(c.data, key)
+		}
+	}
+	return nil
+}
+
+// fakeStatisticsRepo serves a fixed snapshot and records the filters it was queried with
+type fakeStatisticsRepo struct {
+	mu        sync.Mutex
+	stats     *entities.Statistics
+	filters   []repositories.StatisticsFilters
+	refreshes int
+}
+
+func (r *fakeStatisticsRepo) GetStatistics(_ context.Context, f repositories.StatisticsFilters) (*entities.Statistics, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.filters = append(r.filters, f)
+	return r.stats, nil
+}
+
+func (r *fakeStatisticsRepo) RefreshStatistics(_ context.Context) (time.Time, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.refreshes++
+	return time.Now(), nil
+}
+
+// ============================================================================
+// Helpers
+// ============================================================================
+
+type handlerTestEnv struct {
+	handler        *CoreDictServiceHandler
+	entryRepo      *fakeEntryRepo
+	auditRepo      *fakeAuditRepo
+	claimRepo      *fakeClaimRepo
+	infractionRepo *fakeInfractionRepo
+	portabilities  *fakePortabilityRepo
+	publisher      *fakeEventPublisher
+	statsRepo      *fakeStatisticsRepo
+	statsQuery     *queries.GetStatisticsQueryHandler
+}
+
+func newHandlerTestEnv(entry *entities.Entry) *handlerTestEnv {
+	entryRepo := newFakeEntryRepo(entry)
+	auditRepo := &fakeAuditRepo{}
+	claimRepo := &fakeClaimRepo{}
+	infractionRepo := &fakeInfractionRepo{}
+	portabilityRepo := &fakePortabilityRepo{portabilities: make(map[uuid.UUID]*entities.Portability)}
+	publisher := &fakeEventPublisher{}
+	statsRepo := &fakeStatisticsRepo{stats: &entities.Statistics{}}
+	cache := newFakeCache()
+	statsQuery := queries.NewGetStatisticsQueryHandler(statsRepo, cache)
+
+	h := NewCoreDictServiceHandler(
+		false,
+		nil, nil, nil,
+		commands.NewBlockEntryCommandHandler(entryRepo, publisher, cache, auditRepo, nil),
+		commands.NewUnblockEntryCommandHandler(entryRepo, publisher, cache, auditRepo, nil),
+		nil, nil, nil, nil, nil,
+		commands.NewConfirmPortabilityCommandHandler(portabilityRepo, entryRepo, nil),
+		queries.NewGetEntryQueryHandler(entryRepo, cache, nil),
+		nil, nil, nil, nil, nil, nil,
+		statsQuery,
+		nil,
+		queries.NewGetAuditLogQueryHandler(auditRepo, cache),
+		queries.NewGetKeyHistoryQueryHandler(entryRepo, claimRepo, infractionRepo, auditRepo),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+
+	return &handlerTestEnv{
+		handler:        h,
+		entryRepo:      entryRepo,
+		auditRepo:      auditRepo,
+		claimRepo:      claimRepo,
+		infractionRepo: infractionRepo,
+		portabilities:  portabilityRepo,
+		publisher:      publisher,
+		statsRepo:      statsRepo,
+		statsQuery:     statsQuery,
+	}
+}
+
+func authContext(userID, role string) context.Context {
+	ctx := context.WithValue(context.Background(), "user_id", userID)
+	return context.WithValue(ctx, "user_role", role)
+}
+
+func activeEntry() *entities.Entry {
+	return &entities.Entry{
+		ID:        uuid.New(),
+		KeyType:   entities.KeyTypeEmail,
+		KeyValue:  "cliente@exemplo.com.br",
+		Status:    entities.KeyStatusActive,
+		AccountID: uuid.New(),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+// ============================================================================
+// BlockKey / UnblockKey
+// ============================================================================
+
+func TestBlockKey_BlocksAndAudits(t *testing.T) {
+	entry := activeEntry()
+	env := newHandlerTestEnv(entry)
+	actor := uuid.New().String()
+	infractionID := uuid.New().String()
+
+	resp, err := env.handler.BlockKey(authContext(actor, "support"), &corev1.BlockKeyRequest{
+		KeyId:         entry.ID.String(),
+		Reason:        corev1.BlockReason_BLOCK_REASON_JUDICIAL_ORDER,
+		ReasonDetails: "Processo 0001234-56.2026.8.26.0100",
+		InfractionId:  infractionID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, entry.ID.String(), resp.GetKeyId())
+	assert.Equal(t, commonv1.EntryStatus_ENTRY_STATUS_BLOCKED, resp.GetStatus())
+	assert.NotNil(t, resp.GetBlockedAt())
+
+	stored, _ := env.entryRepo.FindByID(context.Background(), entry.ID)
+	assert.Equal(t, entities.KeyStatusBlocked, stored.Status)
+
+	require.Len(t, env.auditRepo.events, 1)
+	audit := env.auditRepo.events[0]
+	assert.Equal(t, entities.EventTypeEntryBlocked, audit.EventType)
+	assert.Equal(t, entry.ID, audit.EntityID)
+	require.NotNil(t, audit.UserID)
+	assert.Equal(t, actor, audit.UserID.String())
+	assert.Equal(t, "JUDICIAL_ORDER", audit.Metadata["reason_code"])
+	assert.Equal(t, "Processo 0001234-56.2026.8.26.0100", audit.Metadata["reason"])
+	assert.Equal(t, infractionID, audit.Metadata["infraction_id"])
+
+	require.Len(t, env.publisher.events, 1)
+	assert.Equal(t, "EntryBlocked", env.publisher.events[0].EventType)
+}
+
+func TestBlockKey_LookupReflectsBlockedState(t *testing.T) {
+	entry := activeEntry()
+	env := newHandlerTestEnv(entry)
+	lookupReq := &corev1.LookupKeyRequest{Key: &commonv1.DictKey{
+		KeyType:  commonv1.KeyType_KEY_TYPE_EMAIL,
+		KeyValue: entry.KeyValue,
+	}}
+
+	// Warm the cache with the ACTIVE entry
+	before, err := env.handler.LookupKey(context.Background(), lookupReq)
+	require.NoError(t, err)
+	assert.Equal(t, commonv1.EntryStatus_ENTRY_STATUS_ACTIVE, before.GetStatus())
+
+	ctx := authContext("backoffice-operator", "admin")
+	_, err = env.handler.BlockKey(ctx, &corev1.BlockKeyRequest{
+		KeyId:  entry.ID.String(),
+		Reason: corev1.BlockReason_BLOCK_REASON_FRAUD_SUSPICION,
+	})
+	require.NoError(t, err)
+
+	blocked, err := env.handler.LookupKey(context.Background(), lookupReq)
+	require.NoError(t, err)
+	assert.Equal(t, commonv1.EntryStatus_ENTRY_STATUS_BLOCKED, blocked.GetStatus())
+
+	_, err = env.handler.UnblockKey(ctx, &corev1.UnblockKeyRequest{
+		KeyId:  entry.ID.String(),
+		Reason: corev1.UnblockReason_UNBLOCK_REASON_FRAUD_DISMISSED,
+	})
+	require.NoError(t, err)
+
+	after, err := env.handler.LookupKey(context.Background(), lookupReq)
+	require.NoError(t, err)
+	assert.Equal(t, commonv1.EntryStatus_ENTRY_STATUS_ACTIVE, after.GetStatus())
+
+	// Non-UUID actors are kept in metadata only
+	require.Len(t, env.auditRepo.events, 2)
+	assert.Nil(t, env.auditRepo.events[0].UserID)
+	assert.Equal(t, "backoffice-operator", env.auditRepo.events[0].Metadata["actor"])
+	assert.Equal(t, "FRAUD_SUSPICION", env.auditRepo.events[0].Metadata["reason_code"])
+	assert.Equal(t, entities.EventTypeEntryUnblocked, env.auditRepo.events[1].EventType)
+	assert.Equal(t, "FRAUD_DISMISSED", env.auditRepo.events[1].Metadata["reason_code"])
+}
+
+func TestBlockKey_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		req      *corev1.BlockKeyRequest
+		wantCode codes.Code
+	}{
+		{
+			name:     "missing_reason",
+			ctx:      authContext("operator", "admin"),
+			req:      &corev1.BlockKeyRequest{KeyId: uuid.New().String()},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "other_without_details",
+			ctx:      authContext("operator", "admin"),
+			req:      &corev1.BlockKeyRequest{KeyId: uuid.New().String(), Reason: corev1.BlockReason_BLOCK_REASON_OTHER},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "unauthenticated",
+			ctx:      context.Background(),
+			req:      &corev1.BlockKeyRequest{KeyId: uuid.New().String(), Reason: corev1.BlockReason_BLOCK_REASON_INFRACTION},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "end_user_role",
+			ctx:      authContext("user-123", "user"),
+			req:      &corev1.BlockKeyRequest{KeyId: uuid.New().String(), Reason: corev1.BlockReason_BLOCK_REASON_INFRACTION},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "unknown_key",
+			ctx:      authContext("operator", "admin"),
+			req:      &corev1.BlockKeyRequest{KeyId: uuid.New().String(), Reason: corev1.BlockReason_BLOCK_REASON_INFRACTION},
+			wantCode: codes.NotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newHandlerTestEnv(activeEntry())
+			_, err := env.handler.BlockKey(tt.ctx, tt.req)
+			require.Error(t, err)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Empty(t, env.auditRepo.events)
+		})
+	}
+}
+
+func TestUnblockKey_RequiresBlockedEntry(t *testing.T) {
+	entry := activeEntry()
+	env := newHandlerTestEnv(entry)
+
+	_, err := env.handler.UnblockKey(authContext("operator", "admin"), &corev1.UnblockKeyRequest{
+		KeyId:  entry.ID.String(),
+		Reason: corev1.UnblockReason_UNBLOCK_REASON_JUDICIAL_RELEASE,
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Empty(t, env.auditRepo.events)
+}
+
+// ============================================================================
+// ListAuditEvents / GetKeyHistory
+// ============================================================================
+
+func auditEventAt(t *testing.T, eventType entities.EventType, entityType entities.EntityType, entityID uuid.UUID, actor *uuid.UUID, at time.Time) *entities.AuditEvent {
+	t.Helper()
+	e, err := entities.NewAuditEvent(eventType, entityType, entityID, nil, map[string]interface{}{"status": "X"}, actor)
+	require.NoError(t, err)
+	e.OccurredAt = at
+	return e
+}
+
+func TestListAuditEvents_FiltersAndPagination(t *testing.T) {
+	env := newHandlerTestEnv(activeEntry())
+	actor := uuid.New()
+	entryID := uuid.New()
+	base := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	env.auditRepo.events = []*entities.AuditEvent{
+		auditEventAt(t, entities.EventTypeEntryCreated, entities.EntityTypeEntry, entryID, &actor, base),
+		auditEventAt(t, entities.EventTypeEntryBlocked, entities.EntityTypeEntry, entryID, &actor, base.Add(time.Hour)),
+		auditEventAt(t, entities.EventTypeEntryUnblocked, entities.EntityTypeEntry, entryID, &actor, base.Add(2*time.Hour)),
+		auditEventAt(t, entities.EventTypeClaimCreated, entities.EntityTypeClaim, uuid.New(), nil, base.Add(3*time.Hour)),
+	}
+	ctx := authContext(actor.String(), "auditor")
+
+	// Entity + actor filter, paginated
+	page1, err := env.handler.ListAuditEvents(ctx, &corev1.ListAuditEventsRequest{
+		EntityType: "entry",
+		EntityId:   entryID.String(),
+		ActorId:    actor.String(),
+		PageSize:   2,
+	})
+	require.NoError(t, err)
+	assert.Len(t, page1.GetEvents(), 2)
+	assert.Equal(t, int32(3), page1.GetTotalCount())
+	assert.Equal(t, "page=2", page1.GetNextPageToken())
+
+	page2, err := env.handler.ListAuditEvents(ctx, &corev1.ListAuditEventsRequest{
+		EntityType: "entry",
+		EntityId:   entryID.String(),
+		ActorId:    actor.String(),
+		PageSize:   2,
+		PageToken:  page1.GetNextPageToken(),
+	})
+	require.NoError(t, err)
+	assert.Len(t, page2.GetEvents(), 1)
+	assert.Empty(t, page2.GetNextPageToken())
+
+	// Event type + date range filter
+	blocked, err := env.handler.ListAuditEvents(ctx, &corev1.ListAuditEventsRequest{
+		EventType:      string(entities.EventTypeEntryBlocked),
+		OccurredAfter:  timestamppb.New(base),
+		OccurredBefore: timestamppb.New(base.Add(90 * time.Minute)),
+	})
+	require.NoError(t, err)
+	require.Len(t, blocked.GetEvents(), 1)
+	assert.Equal(t, string(entities.EventTypeEntryBlocked), blocked.GetEvents()[0].GetEventType())
+	assert.Equal(t, actor.String(), blocked.GetEvents()[0].GetActorId())
+	assert.Equal(t, "X", blocked.GetEvents()[0].GetNewValues().GetFields()["status"].GetStringValue())
+}
+
+func TestListAuditEvents_Errors(t *testing.T) {
+	env := newHandlerTestEnv(activeEntry())
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		req      *corev1.ListAuditEventsRequest
+		wantCode codes.Code
+	}{
+		{"end_user_role", authContext("user-123", "user"), &corev1.ListAuditEventsRequest{}, codes.PermissionDenied},
+		{"no_role", context.Background(), &corev1.ListAuditEventsRequest{}, codes.PermissionDenied},
+		{"invalid_entity_id", authContext("a", "admin"), &corev1.ListAuditEventsRequest{EntityId: "nope"}, codes.InvalidArgument},
+		{"invalid_page_token", authContext("a", "admin"), &corev1.ListAuditEventsRequest{PageToken: "abc"}, codes.InvalidArgument},
+		{
+			"inverted_date_range", authContext("a", "admin"),
+			&corev1.ListAuditEventsRequest{OccurredAfter: timestamppb.New(now), OccurredBefore: timestamppb.New(now.Add(-time.Hour))},
+			codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := env.handler.ListAuditEvents(tt.ctx, tt.req)
+			require.Error(t, err)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+		})
+	}
+}
+
+func TestGetKeyHistory_MergesEntryClaimAndInfractionTimeline(t *testing.T) {
+	entry := activeEntry()
+	env := newHandlerTestEnv(entry)
+	base := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	claim := &entities.Claim{ID: uuid.New(), EntryKey: entry.KeyValue}
+	infraction := &entities.Infraction{ID: uuid.New(), EntryKey: entry.KeyValue}
+	env.claimRepo.claims = []*entities.Claim{claim, {ID: uuid.New(), EntryKey: "outra@chave.com"}}
+	env.infractionRepo.infractions = []*entities.Infraction{infraction}
+
+	env.auditRepo.events = []*entities.AuditEvent{
+		auditEventAt(t, entities.EventTypeClaimCreated, entities.EntityTypeClaim, claim.ID, nil, base.Add(2*time.Hour)),
+		auditEventAt(t, entities.EventTypeEntryCreated, entities.EntityTypeEntry, entry.ID, nil, base),
+		auditEventAt(t, entities.EventTypeInfractionReported, entities.EntityTypeInfraction, infraction.ID, nil, base.Add(time.Hour)),
+		auditEventAt(t, entities.EventTypeEntryBlocked, entities.EntityTypeEntry, entry.ID, nil, base.Add(3*time.Hour)),
+		auditEventAt(t, entities.EventTypeEntryCreated, entities.EntityTypeEntry, uuid.New(), nil, base),
+	}
+
+	resp, err := env.handler.GetKeyHistory(authContext("compliance", "auditor"), &corev1.GetKeyHistoryRequest{KeyId: entry.ID.String()})
+	require.NoError(t, err)
+	assert.Equal(t, entry.KeyValue, resp.GetKey().GetKeyValue())
+	assert.Equal(t, commonv1.EntryStatus_ENTRY_STATUS_ACTIVE, resp.GetStatus())
+
+	var types []string
+	for _, e := range resp.GetEvents() {
+		types = append(types, e.GetEventType())
+	}
+	assert.Equal(t, []string{
+		string(entities.EventTypeEntryCreated),
+		string(entities.EventTypeInfractionReported),
+		string(entities.EventTypeClaimCreated),
+		string(entities.EventTypeEntryBlocked),
+	}, types)
+}
+
+func TestGetKeyHistory_Errors(t *testing.T) {
+	env := newHandlerTestEnv(activeEntry())
+
+	_, err := env.handler.GetKeyHistory(authContext("user-123", "user"), &corev1.GetKeyHistoryRequest{KeyId: uuid.New().String()})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = env.handler.GetKeyHistory(authContext("a", "admin"), &corev1.GetKeyHistoryRequest{KeyId: "not-a-uuid"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = env.handler.GetKeyHistory(authContext("a", "admin"), &corev1.GetKeyHistoryRequest{KeyId: uuid.New().String()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// A database failure is not reported as a missing key
+	env.entryRepo.findErr = errors.New("connection refused")
+	_, err = env.handler.GetKeyHistory(authContext("a", "admin"), &corev1.GetKeyHistoryRequest{KeyId: uuid.New().String()})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+// ============================================================================
+// GetStatistics
+// ============================================================================
+
+func statisticsSnapshot() *entities.Statistics {
+	return &entities.Statistics{
+		TotalKeys:       7,
+		ActiveKeys:      5,
+		BlockedKeys:     1,
+		DeletedKeys:     1,
+		KeysByType:      map[string]int64{"CPF": 4, "EMAIL": 3},
+		TotalClaims:     2,
+		PendingClaims:   1,
+		CompletedClaims: 1,
+		ClaimsByType:    map[string]int64{"PORTABILITY": 2},
+		Breakdown: []entities.ParticipantStatistics{
+			{ISPB: "12345678", TotalKeys: 4, ActiveKeys: 4, KeysByType: map[string]int64{"CPF": 4}, TotalClaims: 2},
+			{ISPB: "87654321", TotalKeys: 3, ActiveKeys: 1, BlockedKeys: 1, DeletedKeys: 1, KeysByType: map[string]int64{"EMAIL": 3}},
+		},
+		LastUpdated: time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestGetStatistics_FiltersAndBreakdown(t *testing.T) {
+	env := newHandlerTestEnv(activeEntry())
+	env.statsRepo.stats = statisticsSnapshot()
+	after := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	resp, err := env.handler.GetStatistics(authContext("a", "auditor"), &corev1.GetStatisticsRequest{
+		CreatedAfter:  timestamppb.New(after),
+		CreatedBefore: timestamppb.New(before),
+		Ispb:          "12345678",
+		GroupBy:       corev1.GetStatisticsRequest_GROUP_BY_ISPB,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(7), resp.TotalKeys)
+	assert.Equal(t, int64(1), resp.BlockedKeys)
+	assert.Equal(t, int64(4), resp.KeysByType["CPF"])
+	assert.Equal(t, int64(1), resp.PendingClaims)
+	require.Len(t, resp.Breakdown, 2)
+	assert.Equal(t, "12345678", resp.Breakdown[0].Ispb)
+	assert.Equal(t, int64(2), resp.Breakdown[0].TotalClaims)
+	assert.Equal(t, env.statsRepo.stats.LastUpdated, resp.RefreshedAt.AsTime())
+
+	require.Len(t, env.statsRepo.filters, 1)
+	f := env.statsRepo.filters[0]
+	assert.Equal(t, "12345678", f.ISPB)
+	assert.Equal(t, repositories.StatisticsGroupByISPB, f.GroupBy)
+	assert.True(t, f.CreatedAfter.Equal(after))
+	assert.True(t, f.CreatedBefore.Equal(before))
+}
+
+func TestGetStatistics_CachedPerFilterUntilRefresh(t *testing.T) {
+	env := newHandlerTestEnv(activeEntry())
+	env.statsRepo.stats = statisticsSnapshot()
+	ctx := authContext("a", "admin")
+
+	_, err := env.handler.GetStatistics(ctx, &corev1.GetStatisticsRequest{})
+	require.NoError(t, err)
+	_, err = env.handler.GetStatistics(ctx, &corev1.GetStatisticsRequest{})
+	require.NoError(t, err)
+	_, err = env.handler.GetStatistics(ctx, &corev1.GetStatisticsRequest{GroupBy: corev1.GetStatisticsRequest_GROUP_BY_BRANCH})
+	require.NoError(t, err)
+	assert.Len(t, env.statsRepo.filters, 2, "same filters must be served from cache")
+
+	// Refresh recalcula a view e invalida todas as combinações em cache
+	require.NoError(t, env.statsQuery.RefreshCache(context.Background()))
+	assert.Equal(t, 1, env.statsRepo.refreshes)
+
+	_, err = env.handler.GetStatistics(ctx, &corev1.GetStatisticsRequest{GroupBy: corev1.GetStatisticsRequest_GROUP_BY_BRANCH})
+	require.NoError(t, err)
+	assert.Len(t, env.statsRepo.filters, 4)
+}
+
+func TestGetStatistics_Errors(t *testing.T) {
+	env := newHandlerTestEnv(activeEntry())
+
+	_, err := env.handler.GetStatistics(authContext("u", "user"), &corev1.GetStatisticsRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = env.handler.GetStatistics(authContext("a", "admin"), &corev1.GetStatisticsRequest{Branch: "0001"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = env.handler.GetStatistics(authContext("a", "admin"), &corev1.GetStatisticsRequest{Ispb: "123"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = env.handler.GetStatistics(authContext("a", "admin"), &corev1.GetStatisticsRequest{
+		CreatedAfter:  timestamppb.New(time.Now()),
+		CreatedBefore: timestamppb.New(time.Now().Add(-time.Hour)),
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Empty(t, env.statsRepo.filters)
+}
+
+func TestStatisticsExporter_ReadsSameSourceAsRPC(t *testing.T) {
+	env := newHandlerTestEnv(activeEntry())
+	env.statsRepo.stats = statisticsSnapshot()
+
+	rec := httptest.NewRecorder()
+	NewMetricsHTTPHandler(nil, NewStatisticsExporter(env.statsQuery)).ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	require.Equal(t, 200, rec.Code)
+	body := rec.Body.String()
+	assert.Contains(t, body, `dict_keys{ispb="12345678",key_type="CPF"} 4`)
+	assert.Contains(t, body, `dict_keys_by_status{ispb="87654321",status="BLOCKED"} 1`)
+	assert.Contains(t, body, `dict_claims{claim_type="PORTABILITY"} 2`)
+	assert.Contains(t, body, "dict_statistics_refreshed_timestamp_seconds 1792324800")
+
+	require.Len(t, env.statsRepo.filters, 1)
+	assert.Equal(t, repositories.StatisticsGroupByISPB, env.statsRepo.filters[0].GroupBy)
+}
@@ -0,0 +1,83 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	corev1 "github.com/lbpay-lab/dict-contracts/gen/proto/core/v1"
+	"github.com/lbpay-lab/dict-contracts/idempotency"
+
+	"github.com/lbpay-lab/core-dict/internal/infrastructure/grpc/mappers"
+)
+
+const (
+	// RequestIDMetadataKey carries the client request_id of mutating RPCs
+	RequestIDMetadataKey = idempotency.RequestIDMetadataKey
+	// IdempotentReplayMetadataKey is set on responses replayed from the idempotency store
+	IdempotentReplayMetadataKey = idempotency.ReplayMetadataKey
+)
+
+// idempotentMethods are the mutating CoreDictService RPCs covered by request_id idempotency
+var idempotentMethods = []string{
+	corev1.CoreDictService_CreateKey_FullMethodName,
+	corev1.CoreDictService_DeleteKey_FullMethodName,
+	corev1.CoreDictService_RequestKeyVerification_FullMethodName,
+	corev1.CoreDictService_ConfirmKeyVerification_FullMethodName,
+	corev1.CoreDictService_BlockKey_FullMethodName,
+	corev1.CoreDictService_UnblockKey_FullMethodName,
+	corev1.CoreDictService_StartClaim_FullMethodName,
+	corev1.CoreDictService_RespondToClaim_FullMethodName,
+	corev1.CoreDictService_CancelClaim_FullMethodName,
+	corev1.CoreDictService_StartPortability_FullMethodName,
+	corev1.CoreDictService_ConfirmPortability_FullMethodName,
+	corev1.CoreDictService_CancelPortability_FullMethodName,
+	corev1.CoreDictService_NotifyPaymentSettled_FullMethodName,
+	corev1.CoreDictService_CloseAccount_FullMethodName,
+	corev1.CoreDictService_RegisterWebhookEndpoint_FullMethodName,
+	corev1.CoreDictService_ReplayWebhookDeliveries_FullMethodName,
+	corev1.CoreDictService_ErasePersonalData_FullMethodName,
+}
+
+// IdempotencyInterceptor makes the mutating CoreDictService RPCs idempotent by request_id.
+// CoreDictService requests have no request_id field, so the key is the x-request-id metadata
+// (see idempotency.RequestID).
+type IdempotencyInterceptor = idempotency.Interceptor
+
+// IdempotencyConfig configures the request_id idempotency interceptor
+type IdempotencyConfig struct {
+	TTL     time.Duration // How long a completed response is replayed
+	LockTTL time.Duration // How long a request holds its request_id before finishing
+}
+
+// DefaultIdempotencyConfig returns the default idempotency configuration
+func DefaultIdempotencyConfig() IdempotencyConfig {
+	return IdempotencyConfig{
+		TTL:     24 * time.Hour,
+		LockTTL: 30 * time.Second,
+	}
+}
+
+// NewIdempotencyInterceptor creates a new idempotency interceptor.
+// A nil store disables it (mock mode).
+func NewIdempotencyInterceptor(store idempotency.Store, config IdempotencyConfig, logger *slog.Logger) *IdempotencyInterceptor {
+	defaults := DefaultIdempotencyConfig()
+	if config.TTL <= 0 {
+		config.TTL = defaults.TTL
+	}
+	if config.LockTTL <= 0 {
+		config.LockTTL = defaults.LockTTL
+	}
+
+	return idempotency.NewInterceptor(store, idempotentMethods, idempotency.Config{
+		TTL:         config.TTL,
+		LockTTL:     config.LockTTL,
+		ErrorDomain: mappers.ErrorDomain,
+		// The caller is part of the fingerprint so that a request_id can never replay another
+		// user's response
+		Caller: func(ctx context.Context) string {
+			userID, _ := GetUserID(ctx)
+			return userID
+		},
+	}, logger)
+}
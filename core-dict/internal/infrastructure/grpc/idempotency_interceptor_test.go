@@ -0,0 +1,267 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	commonv1 "github.com/lbpay-lab/dict-contracts/gen/proto/common/v1"
+	corev1 "github.com/lbpay-lab/dict-contracts/gen/proto/core/v1"
+	"github.com/lbpay-lab/dict-contracts/idempotency"
+
+	"github.com/lbpay-lab/core-dict/internal/infrastructure/grpc/mappers"
+)
+
+// memoryIdempotencyStore is an in-memory idempotency table
+type memoryIdempotencyStore struct {
+	mu         sync.Mutex
+	records    map[string]idempotency.Record
+	reserveErr error
+	released   []string
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{records: make(map[string]idempotency.Record)}
+}
+
+func (s *memoryIdempotencyStore) Reserve(_ context.Context, record *idempotency.Record) (*idempotency.Record, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.reserveErr != nil {
+		return nil, false, s.reserveErr
+	}
+	if existing, ok := s.records[record.Key]; ok && existing.Live(time.Now()) {
+		return &existing, false, nil
+	}
+	s.records[record.Key] = *record
+	return record, true, nil
+}
+
+func (s *memoryIdempotencyStore) Complete(_ context.Context, record *idempotency.Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[record.Key] = *record
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, key)
+	s.released = append(s.released, key)
+	return nil
+}
+
+// countingHandler counts calls and answers with a new key id each time
+type countingHandler struct {
+	calls int
+	err   error
+}
+
+func (h *countingHandler) handle(ctx context.Context, req interface{}) (interface{}, error) {
+	h.calls++
+	if h.err != nil {
+		return nil, h.err
+	}
+	return &corev1.CreateKeyResponse{
+		KeyId:  fmt.Sprintf("key-%d", h.calls),
+		Status: commonv1.EntryStatus_ENTRY_STATUS_ACTIVE,
+	}, nil
+}
+
+func createKeyRequest(accountID string) *corev1.CreateKeyRequest {
+	return &corev1.CreateKeyRequest{
+		KeyType:   commonv1.KeyType_KEY_TYPE_EVP,
+		AccountId: accountID,
+	}
+}
+
+func requestIDContext(userID, requestID string) context.Context {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDMetadataKey, requestID))
+	return context.WithValue(ctx, "user_id", userID)
+}
+
+func runIdempotencyInterceptor(store idempotency.Store, ctx context.Context, req interface{}, method string, h *countingHandler) (interface{}, error) {
+	interceptor := NewIdempotencyInterceptor(store, IdempotencyConfig{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	return interceptor.Unary()(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, h.handle)
+}
+
+func TestIdempotencyInterceptor_ReplaysCompletedResponse(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	h := &countingHandler{}
+	ctx := requestIDContext("user-1", "req-1")
+
+	first, err := runIdempotencyInterceptor(store, ctx, createKeyRequest("acc-1"), corev1.CoreDictService_CreateKey_FullMethodName, h)
+	require.NoError(t, err)
+
+	replayed, err := runIdempotencyInterceptor(store, ctx, createKeyRequest("acc-1"), corev1.CoreDictService_CreateKey_FullMethodName, h)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, h.calls, "the retry must not run the handler again")
+	assert.True(t, proto.Equal(first.(proto.Message), replayed.(proto.Message)))
+	assert.Equal(t, idempotency.StatusCompleted, store.records["req-1"].Status)
+}
+
+func TestIdempotencyInterceptor_ReplaysCloseAccount(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	h := &countingHandler{}
+	ctx := requestIDContext("user-1", "close-1")
+	req := &corev1.CloseAccountRequest{AccountId: "acc-1"}
+
+	for i := 0; i < 2; i++ {
+		_, err := runIdempotencyInterceptor(store, ctx, req, corev1.CoreDictService_CloseAccount_FullMethodName, h)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 1, h.calls, "a retried closure must not run the cascade again")
+}
+
+func TestIdempotencyInterceptor_ReplaysWebhookWrites(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	h := &countingHandler{}
+
+	register := &corev1.RegisterWebhookEndpointRequest{Url: "https://banking.example.com/dict", EventTypes: []string{"claim.*"}}
+	replay := &corev1.ReplayWebhookDeliveriesRequest{EndpointId: "endpoint-1"}
+	for i := 0; i < 2; i++ {
+		_, err := runIdempotencyInterceptor(store, requestIDContext("user-1", "register-1"), register,
+			corev1.CoreDictService_RegisterWebhookEndpoint_FullMethodName, h)
+		require.NoError(t, err)
+		_, err = runIdempotencyInterceptor(store, requestIDContext("user-1", "replay-1"), replay,
+			corev1.CoreDictService_ReplayWebhookDeliveries_FullMethodName, h)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 2, h.calls, "a retry must neither register a second endpoint nor resend the deliveries")
+}
+
+func TestIdempotencyInterceptor_ReplaysErasePersonalData(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	h := &countingHandler{}
+	ctx := requestIDContext("dpo-1", "erase-1")
+	req := &corev1.ErasePersonalDataRequest{TaxId: "12345678909", TicketId: "DSR-2026-0042", Reason: "LGPD art. 18, VI"}
+
+	for i := 0; i < 2; i++ {
+		_, err := runIdempotencyInterceptor(store, ctx, req, corev1.CoreDictService_ErasePersonalData_FullMethodName, h)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 1, h.calls, "a retried erasure must replay the first result")
+}
+
+func TestIdempotencyInterceptor_RejectsReusedRequestID(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	h := &countingHandler{}
+
+	_, err := runIdempotencyInterceptor(store, requestIDContext("user-1", "req-1"), createKeyRequest("acc-1"),
+		corev1.CoreDictService_CreateKey_FullMethodName, h)
+	require.NoError(t, err)
+
+	// Same request_id, different payload
+	_, err = runIdempotencyInterceptor(store, requestIDContext("user-1", "req-1"), createKeyRequest("acc-2"),
+		corev1.CoreDictService_CreateKey_FullMethodName, h)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	info, _, _, _ := statusDetails(t, err)
+	require.NotNil(t, info)
+	assert.Equal(t, idempotency.ReasonKeyReused, info.GetReason())
+	assert.Equal(t, mappers.ErrorDomain, info.GetDomain())
+
+	// Same request_id and payload from another user never replays the first user's response
+	_, err = runIdempotencyInterceptor(store, requestIDContext("user-2", "req-1"), createKeyRequest("acc-1"),
+		corev1.CoreDictService_CreateKey_FullMethodName, h)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, 1, h.calls)
+}
+
+func TestIdempotencyInterceptor_InProgressRequestIsAborted(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	ctx := requestIDContext("user-1", "req-1")
+	interceptor := NewIdempotencyInterceptor(store, IdempotencyConfig{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	var retryErr error
+	_, err := interceptor.Unary()(ctx, createKeyRequest("acc-1"),
+		&grpc.UnaryServerInfo{FullMethod: corev1.CoreDictService_CreateKey_FullMethodName},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			// Client retries while the first request is still running
+			_, retryErr = runIdempotencyInterceptor(store, ctx, req, corev1.CoreDictService_CreateKey_FullMethodName, &countingHandler{})
+			return &corev1.CreateKeyResponse{KeyId: "key-1"}, nil
+		})
+	require.NoError(t, err)
+
+	assert.Equal(t, codes.Aborted, status.Code(retryErr))
+	info, _, _, _ := statusDetails(t, retryErr)
+	require.NotNil(t, info)
+	assert.Equal(t, idempotency.ReasonRequestInProgress, info.GetReason())
+}
+
+func TestIdempotencyInterceptor_ErrorOutcomes(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+
+	// Business errors are stored and replayed
+	h := &countingHandler{err: status.Error(codes.AlreadyExists, "Key already registered")}
+	_, err := runIdempotencyInterceptor(store, requestIDContext("user-1", "req-1"), createKeyRequest("acc-1"),
+		corev1.CoreDictService_CreateKey_FullMethodName, h)
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	_, err = runIdempotencyInterceptor(store, requestIDContext("user-1", "req-1"), createKeyRequest("acc-1"),
+		corev1.CoreDictService_CreateKey_FullMethodName, h)
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	assert.Equal(t, "Key already registered", status.Convert(err).Message())
+	assert.Equal(t, 1, h.calls)
+
+	// Transient errors release the request_id so the retry runs again
+	h = &countingHandler{err: status.Error(codes.Unavailable, "connect unavailable")}
+	_, err = runIdempotencyInterceptor(store, requestIDContext("user-1", "req-2"), createKeyRequest("acc-1"),
+		corev1.CoreDictService_CreateKey_FullMethodName, h)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, []string{"req-2"}, store.released)
+
+	h.err = nil
+	_, err = runIdempotencyInterceptor(store, requestIDContext("user-1", "req-2"), createKeyRequest("acc-1"),
+		corev1.CoreDictService_CreateKey_FullMethodName, h)
+	require.NoError(t, err)
+	assert.Equal(t, 2, h.calls)
+}
+
+func TestIdempotencyInterceptor_Bypass(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	h := &countingHandler{}
+
+	// Read-only RPC
+	_, err := runIdempotencyInterceptor(store, requestIDContext("user-1", "req-1"), &corev1.GetKeyRequest{},
+		corev1.CoreDictService_GetKey_FullMethodName, h)
+	require.NoError(t, err)
+
+	// No request_id
+	ctx := context.WithValue(context.Background(), "user_id", "user-1")
+	for i := 0; i < 2; i++ {
+		_, err = runIdempotencyInterceptor(store, ctx, createKeyRequest("acc-1"), corev1.CoreDictService_CreateKey_FullMethodName, h)
+		require.NoError(t, err)
+	}
+
+	// Disabled (mock mode)
+	_, err = runIdempotencyInterceptor(nil, requestIDContext("user-1", "req-1"), createKeyRequest("acc-1"),
+		corev1.CoreDictService_CreateKey_FullMethodName, h)
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, h.calls)
+	assert.Empty(t, store.records)
+
+	// Store outage fails closed: the write could otherwise run twice
+	store.reserveErr = errors.New("connection refused")
+	_, err = runIdempotencyInterceptor(store, requestIDContext("user-1", "req-1"), createKeyRequest("acc-1"),
+		corev1.CoreDictService_CreateKey_FullMethodName, h)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 4, h.calls)
+}
@@ -0,0 +1,295 @@
+package grpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	commonv1 "github.com/lbpay-lab/dict-contracts/gen/proto/common/v1"
+	corev1 "github.com/lbpay-lab/dict-contracts/gen/proto/core/v1"
+
+	"github.com/lbpay-lab/core-dict/internal/application/commands"
+	"github.com/lbpay-lab/core-dict/internal/application/services"
+	"github.com/lbpay-lab/core-dict/internal/domain/entities"
+	"github.com/lbpay-lab/core-dict/internal/domain/valueobjects"
+	"github.com/lbpay-lab/core-dict/internal/infrastructure/notification"
+)
+
+const verificationTestUser = "11111111-1111-1111-1111-111111111111"
+
+// fakeVerificationStore keeps verifications in memory (no TTL, no resend cooldown)
+type fakeVerificationStore struct {
+	mu      sync.Mutex
+	records map[string]*services.KeyVerification
+}
+
+func (s *fakeVerificationStore) Save(_ context.Context, v *services.KeyVerification) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c := *v
+	s.records[v.ID] = &c
+	return nil
+}
+
+func (s *fakeVerificationStore) CheckCode(_ context.Context, id, userID, codeHash string, verifiedTTL time.Duration) (*services.KeyVerification, services.CodeCheckResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.records[id]
+	if !ok || v.UserID != userID {
+		return nil, "", services.ErrKeyVerificationNotFound
+	}
+	if v.Attempts >= v.MaxAttempts {
+		return v, services.CodeCheckLocked, nil
+	}
+	v.Attempts++
+	if v.CodeHash != codeHash {
+		return v, services.CodeCheckMismatch, nil
+	}
+	v.Status = services.KeyVerificationVerified
+	v.ExpiresAt = time.Now().Add(verifiedTTL)
+	return v, services.CodeCheckMatched, nil
+}
+
+func (s *fakeVerificationStore) Consume(_ context.Context, id, userID string, keyType valueobjects.KeyType, keyValue string) (*services.KeyVerification, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.records[id]
+	if !ok || v.UserID != userID || v.Status != services.KeyVerificationVerified || v.KeyType != keyType || v.KeyValue != keyValue {
+		return nil, services.ErrKeyVerificationNotFound
+	}
+	delete(s.records, id)
+	return v, nil
+}
+
+func (s *fakeVerificationStore) AcquireResendSlot(context.Context, string, valueobjects.KeyType, string, time.Duration) (bool, error) {
+	return true, nil
+}
+
+// withKeyVerifier enables key verification on the test handler, sending codes to a file
+func withKeyVerifier(t *testing.T, env *handlerTestEnv) (codesFile string) {
+	t.Helper()
+	codesFile = filepath.Join(t.TempDir(), "codes.jsonl")
+	notifier, err := notification.NewFileNotifier(codesFile)
+	require.NoError(t, err)
+
+	verifier, err := services.NewKeyVerificationService(
+		&fakeVerificationStore{records: make(map[string]*services.KeyVerification)},
+		notifier,
+		services.DefaultKeyVerificationConfig("handler-test-secret-0123"),
+	)
+	require.NoError(t, err)
+	env.handler.keyVerifier = verifier
+	env.handler.confirmPortabilityCmd = commands.NewConfirmPortabilityCommandHandler(env.portabilities, env.entryRepo, verifier)
+	return codesFile
+}
+
+// confirmedVerification requests and confirms a verification of an EMAIL key, returning its ID
+func confirmedVerification(t *testing.T, env *handlerTestEnv, codesFile, email string) string {
+	t.Helper()
+	ctx := authContext(verificationTestUser, "user")
+	resp, err := env.handler.RequestKeyVerification(ctx, &corev1.RequestKeyVerificationRequest{
+		KeyType:  commonv1.KeyType_KEY_TYPE_EMAIL,
+		KeyValue: email,
+	})
+	require.NoError(t, err)
+	_, err = env.handler.ConfirmKeyVerification(ctx, &corev1.ConfirmKeyVerificationRequest{
+		VerificationId: resp.GetVerificationId(),
+		Code:           lastSentCode(t, codesFile).Code,
+	})
+	require.NoError(t, err)
+	return resp.GetVerificationId()
+}
+
+// pendingPortability stores a portability of the entry that requires OTP
+func pendingPortability(env *handlerTestEnv, entry *entities.Entry) *entities.Portability {
+	p := &entities.Portability{
+		ID:                   uuid.New(),
+		EntryID:              entry.ID,
+		OriginAccountID:      entry.AccountID,
+		DestinationAccountID: uuid.New(),
+		Status:               entities.PortabilityStatusInitiated,
+		RequiresOTP:          true,
+		InitiatedAt:          time.Now(),
+	}
+	env.portabilities.portabilities[p.ID] = p
+	return p
+}
+
+// lastSentCode reads the last code written by the file notifier
+func lastSentCode(t *testing.T, codesFile string) notification.FileNotification {
+	t.Helper()
+	f, err := os.Open(codesFile)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var last notification.FileNotification
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &last))
+	}
+	require.NotEmpty(t, last.Code)
+	return last
+}
+
+func TestKeyVerification_RequestAndConfirm(t *testing.T) {
+	env := newHandlerTestEnv(activeEntry())
+	codesFile := withKeyVerifier(t, env)
+	ctx := authContext(verificationTestUser, "user")
+
+	resp, err := env.handler.RequestKeyVerification(ctx, &corev1.RequestKeyVerificationRequest{
+		KeyType:  commonv1.KeyType_KEY_TYPE_EMAIL,
+		KeyValue: "john@example.com",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "j***@example.com", resp.GetMaskedDestination())
+	assert.Equal(t, int32(5), resp.GetMaxAttempts())
+
+	sent := lastSentCode(t, codesFile)
+	assert.Equal(t, resp.GetVerificationId(), sent.VerificationID)
+	assert.Equal(t, "john@example.com", sent.Destination)
+
+	// Wrong code: INVALID_ARGUMENT with the remaining attempts
+	wrong := "000000"
+	if sent.Code == wrong {
+		wrong = "111111"
+	}
+	_, err = env.handler.ConfirmKeyVerification(ctx, &corev1.ConfirmKeyVerificationRequest{
+		VerificationId: resp.GetVerificationId(),
+		Code:           wrong,
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	info, _, _, _ := statusDetails(t, err)
+	assert.Equal(t, "VERIFICATION_CODE_INVALID", info.GetReason())
+	assert.Equal(t, "4", info.GetMetadata()["remaining_attempts"])
+
+	confirmed, err := env.handler.ConfirmKeyVerification(ctx, &corev1.ConfirmKeyVerificationRequest{
+		VerificationId: resp.GetVerificationId(),
+		Code:           sent.Code,
+	})
+	require.NoError(t, err)
+	assert.True(t, confirmed.GetVerified())
+	assert.Equal(t, "john@example.com", confirmed.GetKey().GetKeyValue())
+	assert.True(t, confirmed.GetValidUntil().AsTime().After(time.Now()))
+}
+
+func TestKeyVerification_RequestRejectsOtherKeyTypes(t *testing.T) {
+	env := newHandlerTestEnv(activeEntry())
+	withKeyVerifier(t, env)
+
+	_, err := env.handler.RequestKeyVerification(authContext(verificationTestUser, "user"), &corev1.RequestKeyVerificationRequest{
+		KeyType:  commonv1.KeyType_KEY_TYPE_CPF,
+		KeyValue: "12345678909",
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestCreateKey_EmailRequiresVerification(t *testing.T) {
+	env := newHandlerTestEnv(activeEntry())
+	withKeyVerifier(t, env)
+
+	_, err := env.handler.CreateKey(authContext(verificationTestUser, "user"), &corev1.CreateKeyRequest{
+		KeyType:   commonv1.KeyType_KEY_TYPE_EMAIL,
+		KeyValue:  "john@example.com",
+		AccountId: "22222222-2222-2222-2222-222222222222",
+	})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	info, bad, _, _ := statusDetails(t, err)
+	assert.Equal(t, "KEY_VERIFICATION_REQUIRED", info.GetReason())
+	require.Len(t, bad.GetFieldViolations(), 1)
+	assert.Equal(t, "verification_id", bad.GetFieldViolations()[0].GetField())
+}
+
+func TestConfirmPortability_VerificationMustMatchKey(t *testing.T) {
+	entry := activeEntry()
+	env := newHandlerTestEnv(entry)
+	codesFile := withKeyVerifier(t, env)
+	portability := pendingPortability(env, entry)
+
+	// Verification confirmed for another email
+	verificationID := confirmedVerification(t, env, codesFile, "mary@example.com")
+
+	_, err := env.handler.ConfirmPortability(authContext(verificationTestUser, "user"), &corev1.ConfirmPortabilityRequest{
+		PortabilityId:  portability.ID.String(),
+		VerificationId: verificationID,
+	})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	info, _, _, _ := statusDetails(t, err)
+	assert.Equal(t, "KEY_VERIFICATION_REQUIRED", info.GetReason())
+	assert.Nil(t, env.portabilities.portabilities[portability.ID].OTPValidatedAt)
+}
+
+func TestConfirmPortability_KeyComesFromPortability(t *testing.T) {
+	entry := activeEntry()
+	env := newHandlerTestEnv(entry)
+	codesFile := withKeyVerifier(t, env)
+	portability := pendingPortability(env, entry)
+	verificationID := confirmedVerification(t, env, codesFile, entry.KeyValue)
+
+	resp, err := env.handler.ConfirmPortability(authContext(verificationTestUser, "user"), &corev1.ConfirmPortabilityRequest{
+		PortabilityId:  portability.ID.String(),
+		VerificationId: verificationID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, entry.ID.String(), resp.GetKeyId())
+
+	stored := env.portabilities.portabilities[portability.ID]
+	assert.Equal(t, entities.PortabilityStatusApproved, stored.Status)
+	assert.NotNil(t, stored.OTPValidatedAt)
+}
+
+func TestConfirmPortability_RejectsMismatchedKey(t *testing.T) {
+	entry := activeEntry()
+	env := newHandlerTestEnv(entry)
+	codesFile := withKeyVerifier(t, env)
+	portability := pendingPortability(env, entry)
+
+	// The caller verified another key and sends it as the key being ported
+	verificationID := confirmedVerification(t, env, codesFile, "mary@example.com")
+
+	_, err := env.handler.ConfirmPortability(authContext(verificationTestUser, "user"), &corev1.ConfirmPortabilityRequest{
+		PortabilityId:  portability.ID.String(),
+		Key:            &commonv1.DictKey{KeyType: commonv1.KeyType_KEY_TYPE_EMAIL, KeyValue: "mary@example.com"},
+		VerificationId: verificationID,
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, entities.PortabilityStatusInitiated, env.portabilities.portabilities[portability.ID].Status)
+}
+
+func TestConfirmPortability_RequiresOTPWhenVerificationDisabled(t *testing.T) {
+	entry := activeEntry()
+	env := newHandlerTestEnv(entry)
+	portability := pendingPortability(env, entry)
+
+	_, err := env.handler.ConfirmPortability(authContext(verificationTestUser, "user"), &corev1.ConfirmPortabilityRequest{
+		PortabilityId: portability.ID.String(),
+	})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// A portability that doesn't require OTP is approved without verification
+	portability.RequiresOTP = false
+	_, err = env.handler.ConfirmPortability(authContext(verificationTestUser, "user"), &corev1.ConfirmPortabilityRequest{
+		PortabilityId: portability.ID.String(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, entities.PortabilityStatusApproved, env.portabilities.portabilities[portability.ID].Status)
+}
+
+func TestConfirmPortability_UnknownPortability(t *testing.T) {
+	env := newHandlerTestEnv(activeEntry())
+
+	_, err := env.handler.ConfirmPortability(authContext(verificationTestUser, "user"), &corev1.ConfirmPortabilityRequest{
+		PortabilityId: uuid.New().String(),
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,101 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	corev1 "github.com/lbpay-lab/dict-contracts/gen/proto/core/v1"
+
+	"github.com/lbpay-lab/core-dict/internal/infrastructure/cache"
+)
+
+// LookupRateLimiter is the anti-scan token bucket store used by LookupKey
+// (implemented by cache.LookupRateLimiter on Redis)
+type LookupRateLimiter interface {
+	Reserve(ctx context.Context, payerTaxID string) (*cache.LookupDecision, error)
+	ChargeNotFound(ctx context.Context, payerTaxID string) error
+	CreditSettlement(ctx context.Context, endToEndID, payerTaxID string) (bool, error)
+	Status(ctx context.Context, payerTaxID string) ([]cache.LookupBucketState, error)
+}
+
+// LookupRateLimitInterceptor enforces the DICT anti-scan limits on LookupKey.
+//
+// Before the handler runs, one successful lookup is charged on the participant and
+// payer buckets (the request is rejected if either is empty). When the handler answers
+// NotFound, the difference to the not-found cost is charged as well.
+//
+// Redis failures fail open: blocking every payment because the limiter is down would
+// be worse than letting lookups through for a while.
+type LookupRateLimitInterceptor struct {
+	limiter LookupRateLimiter
+	logger  *slog.Logger
+}
+
+// NewLookupRateLimitInterceptor creates a new anti-scan interceptor.
+// A nil limiter disables it (mock mode).
+func NewLookupRateLimitInterceptor(limiter LookupRateLimiter, logger *slog.Logger) *LookupRateLimitInterceptor {
+	return &LookupRateLimitInterceptor{
+		limiter: limiter,
+		logger:  logger,
+	}
+}
+
+// Unary returns a unary server interceptor for LookupKey anti-scan limits
+func (i *LookupRateLimitInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if i.limiter == nil || info.FullMethod != corev1.CoreDictService_LookupKey_FullMethodName {
+			return handler(ctx, req)
+		}
+
+		lookupReq, ok := req.(*corev1.LookupKeyRequest)
+		if !ok {
+			return handler(ctx, req)
+		}
+		payerTaxID := lookupReq.GetPayerTaxId()
+		if payerTaxID == "" {
+			return nil, status.Error(codes.InvalidArgument, "payer_tax_id is required")
+		}
+
+		decision, err := i.limiter.Reserve(ctx, payerTaxID)
+		switch {
+		case errors.Is(err, cache.ErrInvalidPayerTaxID):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case err != nil:
+			i.logger.Warn("LookupKey: anti-scan rate limiter unavailable, allowing request", "error", err)
+			return handler(ctx, req)
+		case !decision.Allowed:
+			retryAfter := int(math.Ceil(decision.RetryAfter.Seconds()))
+			_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(retryAfter)))
+			i.logger.Info("LookupKey: blocked by anti-scan rate limit",
+				"bucket", decision.BlockedBy,
+				"retry_after_seconds", retryAfter,
+			)
+			return nil, status.Errorf(
+				codes.ResourceExhausted,
+				"lookup rate limit exceeded (%s bucket), retry after %d seconds",
+				decision.BlockedBy, retryAfter,
+			)
+		}
+
+		resp, err := handler(ctx, req)
+		if status.Code(err) == codes.NotFound {
+			if chargeErr := i.limiter.ChargeNotFound(ctx, payerTaxID); chargeErr != nil {
+				i.logger.Warn("LookupKey: failed to charge not-found lookup", "error", chargeErr)
+			}
+		}
+		return resp, err
+	}
+}
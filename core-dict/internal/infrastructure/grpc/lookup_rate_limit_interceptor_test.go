@@ -0,0 +1,255 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	commonv1 "github.com/lbpay-lab/dict-contracts/gen/proto/common/v1"
+	corev1 "github.com/lbpay-lab/dict-contracts/gen/proto/core/v1"
+
+	"github.com/lbpay-lab/core-dict/internal/infrastructure/cache"
+)
+
+// fakeLookupLimiter records calls and returns canned decisions
+type fakeLookupLimiter struct {
+	mu           sync.Mutex
+	decision     *cache.LookupDecision
+	reserveErr   error
+	reserved     []string
+	notFound     []string
+	settled      map[string]bool
+	statusPayer  string
+	statusResult []cache.LookupBucketState
+}
+
+func (f *fakeLookupLimiter) Reserve(_ context.Context, payerTaxID string) (*cache.LookupDecision, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.reserved = append(f.reserved, payerTaxID)
+	if f.reserveErr != nil {
+		return nil, f.reserveErr
+	}
+	if f.decision != nil {
+		return f.decision, nil
+	}
+	return &cache.LookupDecision{Allowed: true}, nil
+}
+
+func (f *fakeLookupLimiter) ChargeNotFound(_ context.Context, payerTaxID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.notFound = append(f.notFound, payerTaxID)
+	return nil
+}
+
+func (f *fakeLookupLimiter) CreditSettlement(_ context.Context, endToEndID, payerTaxID string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if payerTaxID == "invalid" {
+		return false, cache.ErrInvalidPayerTaxID
+	}
+	if f.settled == nil {
+		f.settled = make(map[string]bool)
+	}
+	if f.settled[endToEndID] {
+		return false, nil
+	}
+	f.settled[endToEndID] = true
+	return true, nil
+}
+
+func (f *fakeLookupLimiter) Status(_ context.Context, payerTaxID string) ([]cache.LookupBucketState, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.statusPayer = payerTaxID
+	return f.statusResult, nil
+}
+
+func lookupRequest(payerTaxID string) *corev1.LookupKeyRequest {
+	return &corev1.LookupKeyRequest{
+		Key: &commonv1.DictKey{
+			KeyType:  commonv1.KeyType_KEY_TYPE_EMAIL,
+			KeyValue: "cliente@exemplo.com.br",
+		},
+		PayerTaxId: payerTaxID,
+	}
+}
+
+func runLookupInterceptor(t *testing.T, limiter LookupRateLimiter, req interface{}, method string, handlerErr error) (bool, error) {
+	t.Helper()
+	interceptor := NewLookupRateLimitInterceptor(limiter, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	called := false
+	_, err := interceptor.Unary()(context.Background(), req, &grpc.UnaryServerInfo{FullMethod: method},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			called = true
+			return &corev1.LookupKeyResponse{}, handlerErr
+		})
+	return called, err
+}
+
+func TestLookupRateLimitInterceptor_ChargesNotFoundLookups(t *testing.T) {
+	limiter := &fakeLookupLimiter{}
+
+	called, err := runLookupInterceptor(t, limiter, lookupRequest("12345678909"),
+		corev1.CoreDictService_LookupKey_FullMethodName, nil)
+	require.NoError(t, err)
+	assert.True(t, called)
+
+	_, err = runLookupInterceptor(t, limiter, lookupRequest("12345678909"),
+		corev1.CoreDictService_LookupKey_FullMethodName, status.Error(codes.NotFound, "Entry not found"))
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	assert.Equal(t, []string{"12345678909", "12345678909"}, limiter.reserved)
+	assert.Equal(t, []string{"12345678909"}, limiter.notFound, "only the not-found lookup pays the surcharge")
+}
+
+func TestLookupKey_UnknownKeyIsChargedAsNotFound(t *testing.T) {
+	env := newHandlerTestEnv(activeEntry())
+	limiter := &fakeLookupLimiter{}
+	interceptor := NewLookupRateLimitInterceptor(limiter, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	req := lookupRequest("12345678909")
+	req.Key.KeyValue = "desconhecido@exemplo.com.br"
+	_, err := interceptor.Unary()(context.Background(), req,
+		&grpc.UnaryServerInfo{FullMethod: corev1.CoreDictService_LookupKey_FullMethodName},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return env.handler.LookupKey(ctx, req.(*corev1.LookupKeyRequest))
+		})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, []string{"12345678909"}, limiter.notFound)
+}
+
+func TestLookupRateLimitInterceptor_BlocksWhenBucketEmpty(t *testing.T) {
+	limiter := &fakeLookupLimiter{decision: &cache.LookupDecision{
+		Allowed:    false,
+		BlockedBy:  cache.LookupBucketEndUser,
+		RetryAfter: 29500 * time.Millisecond,
+	}}
+
+	called, err := runLookupInterceptor(t, limiter, lookupRequest("12345678909"),
+		corev1.CoreDictService_LookupKey_FullMethodName, nil)
+
+	assert.False(t, called)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Contains(t, err.Error(), "END_USER")
+	assert.Contains(t, err.Error(), "retry after 30 seconds")
+}
+
+func TestLookupRateLimitInterceptor_Validation(t *testing.T) {
+	limiter := &fakeLookupLimiter{}
+
+	called, err := runLookupInterceptor(t, limiter, lookupRequest(""),
+		corev1.CoreDictService_LookupKey_FullMethodName, nil)
+	assert.False(t, called)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	limiter.reserveErr = cache.ErrInvalidPayerTaxID
+	called, err = runLookupInterceptor(t, limiter, lookupRequest("123"),
+		corev1.CoreDictService_LookupKey_FullMethodName, nil)
+	assert.False(t, called)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestLookupRateLimitInterceptor_FailsOpenAndSkipsOtherMethods(t *testing.T) {
+	limiter := &fakeLookupLimiter{reserveErr: errors.New("redis: connection refused")}
+
+	called, err := runLookupInterceptor(t, limiter, lookupRequest("12345678909"),
+		corev1.CoreDictService_LookupKey_FullMethodName, nil)
+	require.NoError(t, err)
+	assert.True(t, called, "limiter outage must not block lookups")
+
+	limiter.reserveErr = nil
+	limiter.reserved = nil
+	called, err = runLookupInterceptor(t, limiter, &corev1.GetKeyRequest{},
+		corev1.CoreDictService_GetKey_FullMethodName, nil)
+	require.NoError(t, err)
+	assert.True(t, called)
+	assert.Empty(t, limiter.reserved)
+
+	called, err = runLookupInterceptor(t, nil, lookupRequest(""),
+		corev1.CoreDictService_LookupKey_FullMethodName, nil)
+	require.NoError(t, err)
+	assert.True(t, called, "nil limiter disables the interceptor")
+}
+
+func TestGetLookupRateLimitStatus(t *testing.T) {
+	env := newHandlerTestEnv(activeEntry())
+	updatedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	limiter := &fakeLookupLimiter{statusResult: []cache.LookupBucketState{
+		{
+			Scope:   cache.LookupBucketParticipant,
+			Subject: "12345678",
+			Tokens:  49999.5,
+			Policy:  cache.DefaultLookupRateLimitConfig("12345678").Participant,
+		},
+		{
+			Scope:      cache.LookupBucketEndUser,
+			Subject:    "***.456.789-**",
+			Tokens:     0.25,
+			Policy:     cache.DefaultLookupRateLimitConfig("12345678").NaturalPerson,
+			Blocked:    true,
+			RetryAfter: 22500 * time.Millisecond,
+			UpdatedAt:  updatedAt,
+		},
+	}}
+	env.handler.lookupLimiter = limiter
+
+	_, err := env.handler.GetLookupRateLimitStatus(authContext("backoffice-operator", "user"), &corev1.GetLookupRateLimitStatusRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	resp, err := env.handler.GetLookupRateLimitStatus(authContext("backoffice-operator", "support"),
+		&corev1.GetLookupRateLimitStatusRequest{PayerTaxId: "12345678909"})
+	require.NoError(t, err)
+	assert.Equal(t, "12345678909", limiter.statusPayer)
+	require.Len(t, resp.GetBuckets(), 2)
+
+	endUser := resp.GetBuckets()[1]
+	assert.Equal(t, corev1.LookupRateLimitScope_LOOKUP_RATE_LIMIT_SCOPE_END_USER, endUser.GetScope())
+	assert.Equal(t, "***.456.789-**", endUser.GetSubject())
+	assert.True(t, endUser.GetBlocked())
+	assert.Equal(t, int32(23), endUser.GetRetryAfterSeconds())
+	assert.Equal(t, int32(100), endUser.GetCapacity())
+	assert.Equal(t, int32(20), endUser.GetNotFoundCost())
+	assert.Equal(t, updatedAt, endUser.GetUpdatedAt().AsTime())
+	assert.Nil(t, resp.GetBuckets()[0].GetUpdatedAt())
+}
+
+func TestNotifyPaymentSettled_CreditsOncePerEndToEndID(t *testing.T) {
+	env := newHandlerTestEnv(activeEntry())
+	env.handler.lookupLimiter = &fakeLookupLimiter{}
+	ctx := authContext("backoffice-operator", "admin")
+	req := &corev1.NotifyPaymentSettledRequest{
+		EndToEndId: "E1234567820261018120000000000001",
+		PayerTaxId: "12345678909",
+	}
+
+	resp, err := env.handler.NotifyPaymentSettled(ctx, req)
+	require.NoError(t, err)
+	assert.True(t, resp.GetCredited())
+
+	resp, err = env.handler.NotifyPaymentSettled(ctx, req)
+	require.NoError(t, err)
+	assert.False(t, resp.GetCredited())
+
+	_, err = env.handler.NotifyPaymentSettled(ctx, &corev1.NotifyPaymentSettledRequest{PayerTaxId: "12345678909"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = env.handler.NotifyPaymentSettled(ctx, &corev1.NotifyPaymentSettledRequest{EndToEndId: "E2", PayerTaxId: "invalid"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = env.handler.NotifyPaymentSettled(authContext("backoffice-operator", "user"), req)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
@@ -0,0 +1,69 @@
+package mappers
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	corev1 "github.com/lbpay-lab/dict-contracts/gen/proto/core/v1"
+
+	"github.com/lbpay-lab/core-dict/internal/domain/entities"
+)
+
+// ============================================================================
+// Domain AccountClosure → Proto CloseAccountResponse
+// ============================================================================
+
+func MapAccountClosureToProto(closure *entities.AccountClosure) *corev1.CloseAccountResponse {
+	resp := &corev1.CloseAccountResponse{
+		AccountId: closure.AccountID.String(),
+		ClosureId: closure.ID.String(),
+		Status:    mapAccountClosureStatusToProto(closure.Status),
+		Keys:      make([]*corev1.AccountClosureKeyResult, 0, len(closure.Keys)),
+		StartedAt: timestamppb.New(closure.CreatedAt),
+	}
+	if closure.CompletedAt != nil {
+		resp.CompletedAt = timestamppb.New(*closure.CompletedAt)
+	}
+
+	for _, key := range closure.Keys {
+		resp.Keys = append(resp.Keys, &corev1.AccountClosureKeyResult{
+			KeyId:                  key.EntryID.String(),
+			KeyType:                mapEntityKeyTypeToProto(key.KeyType),
+			Outcome:                mapAccountClosureKeyOutcomeToProto(key.Outcome),
+			ClaimsCancelled:        int32(key.ClaimsCancelled),
+			PortabilitiesCancelled: int32(key.PortabilitiesCancelled),
+			Error:                  key.Error,
+			Attempts:               int32(key.Attempts),
+			ProcessedAt:            timestamppb.New(key.ProcessedAt),
+		})
+	}
+
+	return resp
+}
+
+func mapAccountClosureStatusToProto(s entities.AccountClosureStatus) corev1.AccountClosureStatus {
+	switch s {
+	case entities.AccountClosureStatusInProgress:
+		return corev1.AccountClosureStatus_ACCOUNT_CLOSURE_STATUS_IN_PROGRESS
+	case entities.AccountClosureStatusAwaitingBacen:
+		return corev1.AccountClosureStatus_ACCOUNT_CLOSURE_STATUS_AWAITING_BACEN
+	case entities.AccountClosureStatusCompleted:
+		return corev1.AccountClosureStatus_ACCOUNT_CLOSURE_STATUS_COMPLETED
+	case entities.AccountClosureStatusPartial:
+		return corev1.AccountClosureStatus_ACCOUNT_CLOSURE_STATUS_PARTIAL
+	default:
+		return corev1.AccountClosureStatus_ACCOUNT_CLOSURE_STATUS_UNSPECIFIED
+	}
+}
+
+func mapAccountClosureKeyOutcomeToProto(o entities.AccountClosureKeyOutcome) corev1.AccountClosureKeyOutcome {
+	switch o {
+	case entities.AccountClosureKeyRequested:
+		return corev1.AccountClosureKeyOutcome_ACCOUNT_CLOSURE_KEY_OUTCOME_REQUESTED
+	case entities.AccountClosureKeyDeleted:
+		return corev1.AccountClosureKeyOutcome_ACCOUNT_CLOSURE_KEY_OUTCOME_DELETED
+	case entities.AccountClosureKeyFailed:
+		return corev1.AccountClosureKeyOutcome_ACCOUNT_CLOSURE_KEY_OUTCOME_FAILED
+	default:
+		return corev1.AccountClosureKeyOutcome_ACCOUNT_CLOSURE_KEY_OUTCOME_UNSPECIFIED
+	}
+}
@@ -0,0 +1,33 @@
+package mappers
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	corev1 "github.com/lbpay-lab/dict-contracts/gen/proto/core/v1"
+
+	"github.com/lbpay-lab/core-dict/internal/application/services"
+)
+
+// ============================================================================
+// Application AuditChainVerification → Proto VerifyAuditChainResponse
+// ============================================================================
+
+func MapAuditChainVerificationToProto(result *services.AuditChainVerification) *corev1.VerifyAuditChainResponse {
+	resp := &corev1.VerifyAuditChainResponse{
+		Valid:               result.Valid(),
+		ChainsVerified:      int32(result.ChainsVerified),
+		EventsVerified:      result.EventsVerified,
+		CheckpointsVerified: int32(result.CheckpointsVerified),
+		VerifiedAt:          timestamppb.New(result.VerifiedAt),
+	}
+	if result.Break != nil {
+		resp.FirstBreak = &corev1.AuditChainBreak{
+			ChainPartition: result.Break.Partition,
+			Stream:         result.Break.Stream,
+			ChainSeq:       result.Break.Seq,
+			EventId:        result.Break.EventID,
+			Reason:         result.Break.Reason,
+		}
+	}
+	return resp
+}
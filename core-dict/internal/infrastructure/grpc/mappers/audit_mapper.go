@@ -0,0 +1,137 @@
+package mappers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	corev1 "github.com/lbpay-lab/dict-contracts/gen/proto/core/v1"
+
+	"github.com/lbpay-lab/core-dict/internal/application/queries"
+	"github.com/lbpay-lab/core-dict/internal/domain/entities"
+)
+
+// ============================================================================
+// Proto ListAuditEventsRequest → Application ListAuditEventsQuery
+// ============================================================================
+
+func MapProtoListAuditEventsRequestToQuery(req *corev1.ListAuditEventsRequest) (queries.ListAuditEventsQuery, error) {
+	page, err := ParsePageToken(req.GetPageToken())
+	if err != nil {
+		return queries.ListAuditEventsQuery{}, err
+	}
+
+	query := queries.ListAuditEventsQuery{
+		EntityType: strings.ToUpper(req.GetEntityType()),
+		EventType:  strings.ToUpper(req.GetEventType()),
+		Page:       page,
+		PageSize:   int(req.GetPageSize()),
+	}
+
+	if req.GetEntityId() != "" {
+		entityID, err := parseUUID(req.GetEntityId())
+		if err != nil {
+			return queries.ListAuditEventsQuery{}, fmt.Errorf("invalid entity_id: %w", err)
+		}
+		query.EntityID = &entityID
+	}
+
+	if req.GetActorId() != "" {
+		actorID, err := parseUUID(req.GetActorId())
+		if err != nil {
+			return queries.ListAuditEventsQuery{}, fmt.Errorf("invalid actor_id: %w", err)
+		}
+		query.ActorID = &actorID
+	}
+
+	if req.GetOccurredAfter() != nil {
+		after := req.GetOccurredAfter().AsTime()
+		query.OccurredAfter = &after
+	}
+	if req.GetOccurredBefore() != nil {
+		before := req.GetOccurredBefore().AsTime()
+		query.OccurredBefore = &before
+	}
+
+	return query, nil
+}
+
+// ============================================================================
+// Domain AuditLog → Proto AuditEvent
+// ============================================================================
+
+func MapDomainAuditLogToProto(log *entities.AuditLog) *corev1.AuditEvent {
+	if log == nil {
+		return nil
+	}
+
+	// Atores que não são usuários do Core DICT ficam apenas nos metadados
+	actorID := ""
+	if log.ActorID != uuid.Nil {
+		actorID = log.ActorID.String()
+	} else if actor, ok := log.Metadata["actor"].(string); ok {
+		actorID = actor
+	}
+
+	return &corev1.AuditEvent{
+		EventId:    log.ID.String(),
+		EventType:  log.Action,
+		EntityType: log.EntityType,
+		EntityId:   log.EntityID.String(),
+		ActorId:    actorID,
+		OccurredAt: TimeToTimestamppb(log.Timestamp),
+		OldValues:  mapToStruct(log.Changes["old"]),
+		NewValues:  mapToStruct(log.Changes["new"]),
+		Diff:       mapToStruct(log.Changes["diff"]),
+		Metadata:   mapToStruct(log.Metadata),
+	}
+}
+
+func MapDomainAuditLogsToProto(logs []*entities.AuditLog) []*corev1.AuditEvent {
+	events := make([]*corev1.AuditEvent, 0, len(logs))
+	for _, log := range logs {
+		events = append(events, MapDomainAuditLogToProto(log))
+	}
+	return events
+}
+
+// ============================================================================
+// Helper: Page token ("page=N") parsing
+// ============================================================================
+
+// ParsePageToken converte o page token opaco ("page=N") em número de página (1-indexed)
+func ParsePageToken(token string) (int, error) {
+	if token == "" {
+		return 1, nil
+	}
+	page, err := strconv.Atoi(strings.TrimPrefix(token, "page="))
+	if err != nil || page < 1 || !strings.HasPrefix(token, "page=") {
+		return 0, fmt.Errorf("invalid page_token")
+	}
+	return page, nil
+}
+
+// mapToStruct converte valores arbitrários (map/JSONB) em google.protobuf.Struct.
+// O round-trip por JSON normaliza tipos não suportados por structpb (uuid, time, etc.).
+func mapToStruct(value interface{}) *structpb.Struct {
+	if value == nil {
+		return nil
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	var normalized map[string]interface{}
+	if err := json.Unmarshal(raw, &normalized); err != nil || len(normalized) == 0 {
+		return nil
+	}
+	st, err := structpb.NewStruct(normalized)
+	if err != nil {
+		return nil
+	}
+	return st
+}
@@ -263,10 +263,13 @@ func MapDomainClaimToProtoRespondToClaimResponse(claim *entities.Claim) *corev1.
 // Proto ListIncomingClaimsRequest → Application ListClaimsQuery
 // ============================================================================
 
-func MapProtoListIncomingClaimsRequestToQuery(req *corev1.ListIncomingClaimsRequest, ispb string) queries.ListClaimsQuery {
+func MapProtoListIncomingClaimsRequestToQuery(req *corev1.ListIncomingClaimsRequest, ispb string) (queries.ListClaimsQuery, error) {
 	// Proto has: optional status, page_size, page_token
 	// Page is 1-indexed (computed from page_token)
-	page := 1 // TODO: Extract from page_token
+	page, err := ParsePageToken(req.GetPageToken())
+	if err != nil {
+		return queries.ListClaimsQuery{}, err
+	}
 
 	pageSize := int(req.GetPageSize())
 	if pageSize <= 0 {
@@ -281,7 +284,7 @@ func MapProtoListIncomingClaimsRequestToQuery(req *corev1.ListIncomingClaimsRequ
 		ISPB:     ispb, // ISPB of the donor (current owner)
 		Page:     page,
 		PageSize: pageSize,
-	}
+	}, nil
 }
 
 // ============================================================================
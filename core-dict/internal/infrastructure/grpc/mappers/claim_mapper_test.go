@@ -0,0 +1,42 @@
+package mappers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "github.com/lbpay-lab/dict-contracts/gen/proto/core/v1"
+)
+
+func TestMapProtoListIncomingClaimsRequestToQuery_PageToken(t *testing.T) {
+	tests := []struct {
+		name     string
+		token    string
+		wantPage int
+	}{
+		{name: "first page without token", token: "", wantPage: 1},
+		{name: "next page token", token: "page=3", wantPage: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := MapProtoListIncomingClaimsRequestToQuery(&corev1.ListIncomingClaimsRequest{
+				PageSize:  50,
+				PageToken: tt.token,
+			}, "12345678")
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantPage, query.Page)
+			assert.Equal(t, 50, query.PageSize)
+			assert.Equal(t, "12345678", query.ISPB)
+		})
+	}
+}
+
+func TestMapProtoListIncomingClaimsRequestToQuery_InvalidPageToken(t *testing.T) {
+	for _, token := range []string{"3", "page=0", "page=abc"} {
+		_, err := MapProtoListIncomingClaimsRequestToQuery(&corev1.ListIncomingClaimsRequest{PageToken: token}, "12345678")
+		assert.Error(t, err, token)
+	}
+}
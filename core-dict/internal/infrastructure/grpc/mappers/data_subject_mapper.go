@@ -0,0 +1,69 @@
+package mappers
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	corev1 "github.com/lbpay-lab/dict-contracts/gen/proto/core/v1"
+
+	"github.com/lbpay-lab/core-dict/internal/application/services"
+)
+
+// ============================================================================
+// Proto PersonalDataExportFormat → Application BundleFormat
+// ============================================================================
+
+func MapProtoExportFormatToBundleFormat(format corev1.PersonalDataExportFormat) services.BundleFormat {
+	switch format {
+	case corev1.PersonalDataExportFormat_PERSONAL_DATA_EXPORT_FORMAT_CSV:
+		return services.BundleFormatCSV
+	default:
+		return services.BundleFormatJSON
+	}
+}
+
+// ============================================================================
+// Application SignedBundle → Proto ExportPersonalDataResponse
+// ============================================================================
+
+func MapSignedBundleToProto(bundle *services.SignedBundle) *corev1.ExportPersonalDataResponse {
+	return &corev1.ExportPersonalDataResponse{
+		RequestId:    bundle.RequestID.String(),
+		Content:      bundle.Content,
+		ContentType:  bundle.ContentType,
+		Sha256:       bundle.SHA256,
+		Signature:    bundle.Signature,
+		SigningKeyId: bundle.KeyID,
+		RecordCount:  int32(bundle.RecordCount),
+		Sources:      bundle.Sources,
+		GeneratedAt:  timestamppb.New(bundle.GeneratedAt),
+	}
+}
+
+// ============================================================================
+// Application ErasureReport → Proto ErasePersonalDataResponse
+// ============================================================================
+
+func MapErasureReportToProto(report *services.ErasureReport) *corev1.ErasePersonalDataResponse {
+	actions := make([]*corev1.PersonalDataErasureAction, 0, len(report.Actions))
+	for _, a := range report.Actions {
+		actions = append(actions, &corev1.PersonalDataErasureAction{
+			Source:   a.Source,
+			Table:    a.Table,
+			RecordId: a.RecordID,
+			Outcome:  string(a.Outcome),
+			Reason:   a.Reason,
+		})
+	}
+
+	return &corev1.ErasePersonalDataResponse{
+		RequestId:          report.RequestID.String(),
+		SubjectPseudonym:   report.SubjectPseudonym,
+		DryRun:             report.DryRun,
+		RetainAfter:        timestamppb.New(report.RetainAfter),
+		PseudonymisedCount: int32(report.Count(services.ErasureOutcomePseudonymised)),
+		RetainedCount:      int32(report.Count(services.ErasureOutcomeRetained)),
+		Actions:            actions,
+		ProcessedAt:        timestamppb.New(report.ProcessedAt),
+		Complete:           report.Complete(),
+	}
+}
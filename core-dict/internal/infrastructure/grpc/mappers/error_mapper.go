@@ -4,82 +4,149 @@ import (
 	"context"
 	"errors"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	commonv1 "github.com/lbpay-lab/dict-contracts/gen/proto/common/v1"
 
 	"github.com/lbpay-lab/core-dict/internal/domain"
 )
 
+// ErrorDomain is the google.rpc.ErrorInfo domain of Core DICT errors
+const ErrorDomain = "core-dict.lbpay.com.br"
+
 // ============================================================================
 // Domain Errors → gRPC Status Codes
 // ============================================================================
 
-// MapDomainErrorToGRPC maps domain errors to appropriate gRPC status codes
+// MapDomainErrorToGRPC maps domain errors to gRPC statuses.
+//
+// Typed domain errors (*domain.Error) carry their stable code in google.rpc.ErrorInfo,
+// field violations in google.rpc.BadRequest, a common.v1.BusinessError with the Bacen
+// reason code and, when Bacen rejected the request, the original common.v1.BacenError.
 func MapDomainErrorToGRPC(err error) error {
 	if err == nil {
 		return nil
 	}
 
-	// Map specific domain errors
-	switch {
-	// Validation Errors → InvalidArgument
-	case errors.Is(err, domain.ErrInvalidKeyType):
-		return status.Error(codes.InvalidArgument, "Invalid key type: "+err.Error())
-	case errors.Is(err, domain.ErrInvalidKeyValue):
-		return status.Error(codes.InvalidArgument, "Invalid key value: "+err.Error())
-	case errors.Is(err, domain.ErrInvalidStatus):
-		return status.Error(codes.InvalidArgument, "Invalid status: "+err.Error())
-	case errors.Is(err, domain.ErrInvalidAccount):
-		return status.Error(codes.InvalidArgument, "Invalid account: "+err.Error())
-	case errors.Is(err, domain.ErrInvalidClaim):
-		return status.Error(codes.InvalidArgument, "Invalid claim: "+err.Error())
-	case errors.Is(err, domain.ErrInvalidParticipant):
-		return status.Error(codes.InvalidArgument, "Invalid participant: "+err.Error())
-
-	// Not Found Errors → NotFound
-	case errors.Is(err, domain.ErrEntryNotFound):
-		return status.Error(codes.NotFound, "Entry not found: "+err.Error())
-	// TODO: Add when implemented in domain/errors.go
-	// case errors.Is(err, domain.ErrClaimNotFound):
-	// 	return status.Error(codes.NotFound, "Claim not found: "+err.Error())
-	// case errors.Is(err, domain.ErrAccountNotFound):
-	// 	return status.Error(codes.NotFound, "Account not found: "+err.Error())
-
-	// Conflict Errors → AlreadyExists
-	case errors.Is(err, domain.ErrDuplicateKey):
-		return status.Error(codes.AlreadyExists, "Key already registered. You may initiate a portability claim if you believe this is your key.")
-	// TODO: Add when implemented in domain/errors.go
-	// case errors.Is(err, domain.ErrDuplicateKeyGlobal):
-	// 	return status.Error(codes.AlreadyExists, "Key already registered in RSFN. You may initiate a portability claim.")
-
-	// Permission Errors → PermissionDenied
-	case errors.Is(err, domain.ErrUnauthorized):
-		return status.Error(codes.PermissionDenied, "Unauthorized: "+err.Error())
-	// TODO: Add when implemented in domain/errors.go
-	// case errors.Is(err, domain.ErrNotOwner):
-	// 	return status.Error(codes.PermissionDenied, "You are not the owner of this resource.")
-
-	// Resource Exhausted → ResourceExhausted
-	case errors.Is(err, domain.ErrMaxKeysExceeded):
-		return status.Error(codes.ResourceExhausted, "Maximum number of keys exceeded. Please delete an existing key before creating a new one.")
-
-	// Precondition Failed → FailedPrecondition
-	// TODO: Add when implemented in domain/errors.go
-	// case errors.Is(err, domain.ErrCannotDeleteActiveKey):
-	// 	return status.Error(codes.FailedPrecondition, "Cannot delete active key. Block it first or initiate proper deletion workflow.")
-	// case errors.Is(err, domain.ErrCannotBlockDeletedKey):
-	// 	return status.Error(codes.FailedPrecondition, "Cannot block a deleted key.")
-	// case errors.Is(err, domain.ErrClaimAlreadyExists):
-	// 	return status.Error(codes.FailedPrecondition, "An active claim already exists for this key.")
-
-	// Deadline Exceeded → DeadlineExceeded
-	case errors.Is(err, domain.ErrClaimExpired):
-		return status.Error(codes.DeadlineExceeded, "Claim has expired (>30 days). Please initiate a new claim.")
-
-	// Default → Internal
-	default:
+	if ctxErr := MapContextError(err); ctxErr != err {
+		return ctxErr
+	}
+
+	domainErr, ok := domain.AsError(err)
+	if !ok {
 		return status.Error(codes.Internal, "Internal server error. Please try again later.")
 	}
+
+	st := status.New(grpcCodeForKind(domainErr.Kind), userMessage(domainErr, err))
+	withDetails, detailErr := st.WithDetails(domainErrorDetails(domainErr, err)...)
+	if detailErr != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+// grpcCodeForKind maps a domain error kind to its gRPC status code
+func grpcCodeForKind(kind domain.ErrorKind) codes.Code {
+	switch kind {
+	case domain.KindInvalidArgument:
+		return codes.InvalidArgument
+	case domain.KindNotFound:
+		return codes.NotFound
+	case domain.KindAlreadyExists:
+		return codes.AlreadyExists
+	case domain.KindPermissionDenied:
+		return codes.PermissionDenied
+	case domain.KindFailedPrecondition:
+		return codes.FailedPrecondition
+	case domain.KindResourceExhausted:
+		return codes.ResourceExhausted
+	case domain.KindDeadlineExceeded:
+		return codes.DeadlineExceeded
+	case domain.KindAborted:
+		return codes.Aborted
+	default:
+		return codes.Internal
+	}
+}
+
+// userMessage returns the client-facing message of a domain error
+func userMessage(domainErr *domain.Error, err error) string {
+	switch domainErr.Code {
+	case domain.CodeKeyAlreadyRegistered:
+		return "Key already registered. You may initiate a portability claim if you believe this is your key."
+	case domain.CodeKeyOwnedByOtherParticipant:
+		return "Key already registered in RSFN. You may initiate a portability claim."
+	case domain.CodeEntryLimitExceeded:
+		return "Maximum number of keys exceeded. Please delete an existing key before creating a new one."
+	case domain.CodeClaimExpired:
+		return "Claim has expired (>30 days). Please initiate a new claim."
+	case domain.CodeNotOwner:
+		return "You are not the owner of this resource."
+	case domain.CodeClaimAlreadyExists:
+		return "An active claim already exists for this key."
+	default:
+		return err.Error()
+	}
+}
+
+// domainErrorDetails builds the status details of a domain error
+func domainErrorDetails(domainErr *domain.Error, err error) []protoadapt.MessageV1 {
+	metadata := make(map[string]string, len(domainErr.Metadata)+1)
+	for k, v := range domainErr.Metadata {
+		metadata[k] = v
+	}
+	if domainErr.BacenCode != "" {
+		metadata["bacen_code"] = domainErr.BacenCode
+	}
+
+	business := &commonv1.BusinessError{
+		ErrorCode: string(domainErr.Code),
+		Message:   err.Error(),
+		Context:   domainErr.Metadata,
+		BacenCode: domainErr.BacenCode,
+	}
+	details := []protoadapt.MessageV1{
+		&errdetails.ErrorInfo{
+			Reason:   string(domainErr.Code),
+			Domain:   ErrorDomain,
+			Metadata: metadata,
+		},
+	}
+
+	if len(domainErr.Violations) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, v := range domainErr.Violations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       v.Field,
+				Description: v.Description,
+			})
+			business.Violations = append(business.Violations, &commonv1.ValidationError{
+				Field:       v.Field,
+				Description: v.Description,
+			})
+		}
+		details = append(details, badRequest)
+	}
+	details = append(details, business)
+
+	if rejection := domainErr.Bacen; rejection != nil {
+		bacenErr := &commonv1.BacenError{
+			BacenCode:      rejection.Code,
+			BacenMessage:   rejection.Message,
+			BacenRequestId: rejection.RequestID,
+			HttpStatus:     int32(rejection.HTTPStatus),
+		}
+		if !rejection.OccurredAt.IsZero() {
+			bacenErr.OccurredAt = timestamppb.New(rejection.OccurredAt)
+		}
+		details = append(details, bacenErr)
+	}
+
+	return details
 }
 
 // ============================================================================
@@ -68,6 +68,8 @@ func MapProtoStatusToDomain(st commonv1.EntryStatus) valueobjects.KeyStatus {
 		return valueobjects.KeyStatusActive // Map confirmed portability to active
 	case commonv1.EntryStatus_ENTRY_STATUS_CLAIM_PENDING:
 		return valueobjects.KeyStatusClaimPending
+	case commonv1.EntryStatus_ENTRY_STATUS_BLOCKED:
+		return valueobjects.KeyStatusBlocked
 	case commonv1.EntryStatus_ENTRY_STATUS_DELETED:
 		return valueobjects.KeyStatusDeleted
 	default:
@@ -82,7 +84,7 @@ func MapDomainStatusToProto(st valueobjects.KeyStatus) commonv1.EntryStatus {
 	case valueobjects.KeyStatusActive:
 		return commonv1.EntryStatus_ENTRY_STATUS_ACTIVE
 	case valueobjects.KeyStatusBlocked:
-		return commonv1.EntryStatus_ENTRY_STATUS_DELETED // Map blocked to deleted
+		return commonv1.EntryStatus_ENTRY_STATUS_BLOCKED
 	case valueobjects.KeyStatusDeleted:
 		return commonv1.EntryStatus_ENTRY_STATUS_DELETED
 	case valueobjects.KeyStatusClaimPending:
@@ -102,7 +104,7 @@ func MapStringStatusToProto(status string) commonv1.EntryStatus {
 	case "active", "ACTIVE":
 		return commonv1.EntryStatus_ENTRY_STATUS_ACTIVE
 	case "blocked", "BLOCKED":
-		return commonv1.EntryStatus_ENTRY_STATUS_DELETED // Map blocked to deleted
+		return commonv1.EntryStatus_ENTRY_STATUS_BLOCKED
 	case "deleted", "DELETED":
 		return commonv1.EntryStatus_ENTRY_STATUS_DELETED
 	case "claim_pending", "CLAIM_PENDING":
@@ -344,6 +346,109 @@ func MapProtoDeleteKeyRequestToCommand(req *corev1.DeleteKeyRequest, userID stri
 	}, nil
 }
 
+// ============================================================================
+// Proto BlockKeyRequest / UnblockKeyRequest → Application Commands
+// ============================================================================
+
+func MapProtoBlockKeyRequestToCommand(req *corev1.BlockKeyRequest, userID string) (commands.BlockEntryCommand, error) {
+	entryID, err := parseUUID(req.GetKeyId())
+	if err != nil {
+		return commands.BlockEntryCommand{}, fmt.Errorf("invalid key_id: %w", err)
+	}
+
+	reasonCode := mapProtoBlockReasonToDomain(req.GetReason())
+	if reasonCode == "" {
+		return commands.BlockEntryCommand{}, fmt.Errorf("reason is required")
+	}
+
+	// Descrição: detalhes informados ou o próprio código do motivo
+	reason := req.GetReasonDetails()
+	if reason == "" {
+		if reasonCode == entities.BlockReasonOther {
+			return commands.BlockEntryCommand{}, fmt.Errorf("reason_details is required when reason is OTHER")
+		}
+		reason = string(reasonCode)
+	}
+
+	cmd := commands.BlockEntryCommand{
+		EntryID:    entryID,
+		ReasonCode: reasonCode,
+		Reason:     reason,
+		BlockedBy:  userID,
+	}
+
+	if req.GetInfractionId() != "" {
+		infractionID, err := parseUUID(req.GetInfractionId())
+		if err != nil {
+			return commands.BlockEntryCommand{}, fmt.Errorf("invalid infraction_id: %w", err)
+		}
+		cmd.InfractionID = &infractionID
+	}
+
+	return cmd, nil
+}
+
+func MapProtoUnblockKeyRequestToCommand(req *corev1.UnblockKeyRequest, userID string) (commands.UnblockEntryCommand, error) {
+	entryID, err := parseUUID(req.GetKeyId())
+	if err != nil {
+		return commands.UnblockEntryCommand{}, fmt.Errorf("invalid key_id: %w", err)
+	}
+
+	reasonCode := mapProtoUnblockReasonToDomain(req.GetReason())
+	if reasonCode == "" {
+		return commands.UnblockEntryCommand{}, fmt.Errorf("reason is required")
+	}
+
+	reason := req.GetReasonDetails()
+	if reason == "" {
+		if reasonCode == entities.UnblockReasonOther {
+			return commands.UnblockEntryCommand{}, fmt.Errorf("reason_details is required when reason is OTHER")
+		}
+		reason = string(reasonCode)
+	}
+
+	return commands.UnblockEntryCommand{
+		EntryID:     entryID,
+		ReasonCode:  reasonCode,
+		Reason:      reason,
+		UnblockedBy: userID,
+	}, nil
+}
+
+func mapProtoBlockReasonToDomain(r corev1.BlockReason) entities.BlockReason {
+	switch r {
+	case corev1.BlockReason_BLOCK_REASON_JUDICIAL_ORDER:
+		return entities.BlockReasonJudicialOrder
+	case corev1.BlockReason_BLOCK_REASON_FRAUD_SUSPICION:
+		return entities.BlockReasonFraudSuspicion
+	case corev1.BlockReason_BLOCK_REASON_INFRACTION:
+		return entities.BlockReasonInfraction
+	case corev1.BlockReason_BLOCK_REASON_REGULATORY:
+		return entities.BlockReasonRegulatory
+	case corev1.BlockReason_BLOCK_REASON_OTHER:
+		return entities.BlockReasonOther
+	default:
+		return ""
+	}
+}
+
+func mapProtoUnblockReasonToDomain(r corev1.UnblockReason) entities.UnblockReason {
+	switch r {
+	case corev1.UnblockReason_UNBLOCK_REASON_JUDICIAL_RELEASE:
+		return entities.UnblockReasonJudicialRelease
+	case corev1.UnblockReason_UNBLOCK_REASON_FRAUD_DISMISSED:
+		return entities.UnblockReasonFraudDismissed
+	case corev1.UnblockReason_UNBLOCK_REASON_INFRACTION_CLOSED:
+		return entities.UnblockReasonInfractionClosed
+	case corev1.UnblockReason_UNBLOCK_REASON_REGULATORY:
+		return entities.UnblockReasonRegulatory
+	case corev1.UnblockReason_UNBLOCK_REASON_OTHER:
+		return entities.UnblockReasonOther
+	default:
+		return ""
+	}
+}
+
 // ============================================================================
 // Proto LookupKeyRequest → Application GetEntryQuery (by key value)
 // ============================================================================
@@ -446,7 +551,7 @@ func mapEntityStatusToProto(st entities.KeyStatus) commonv1.EntryStatus {
 	case entities.KeyStatusPending:
 		return commonv1.EntryStatus_ENTRY_STATUS_PORTABILITY_PENDING
 	case entities.KeyStatusBlocked:
-		return commonv1.EntryStatus_ENTRY_STATUS_DELETED // Map blocked to deleted
+		return commonv1.EntryStatus_ENTRY_STATUS_BLOCKED
 	case entities.KeyStatusDeleted:
 		return commonv1.EntryStatus_ENTRY_STATUS_DELETED
 	default:
@@ -0,0 +1,48 @@
+package mappers
+
+import (
+	"math"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	corev1 "github.com/lbpay-lab/dict-contracts/gen/proto/core/v1"
+
+	"github.com/lbpay-lab/core-dict/internal/infrastructure/cache"
+)
+
+// ============================================================================
+// Anti-scan bucket state → Proto LookupTokenBucket
+// ============================================================================
+
+func MapLookupBucketStatesToProto(states []cache.LookupBucketState) []*corev1.LookupTokenBucket {
+	buckets := make([]*corev1.LookupTokenBucket, 0, len(states))
+	for _, s := range states {
+		bucket := &corev1.LookupTokenBucket{
+			Scope:             mapLookupBucketScopeToProto(s.Scope),
+			Subject:           s.Subject,
+			Tokens:            math.Floor(s.Tokens*100) / 100,
+			Capacity:          int32(s.Policy.Capacity),
+			RefillPerMinute:   int32(s.Policy.RefillPerMinute),
+			Blocked:           s.Blocked,
+			RetryAfterSeconds: int32(math.Ceil(s.RetryAfter.Seconds())),
+			SuccessCost:       int32(s.Policy.SuccessCost),
+			NotFoundCost:      int32(s.Policy.NotFoundCost),
+		}
+		if !s.UpdatedAt.IsZero() {
+			bucket.UpdatedAt = timestamppb.New(s.UpdatedAt)
+		}
+		buckets = append(buckets, bucket)
+	}
+	return buckets
+}
+
+func mapLookupBucketScopeToProto(scope cache.LookupBucketScope) corev1.LookupRateLimitScope {
+	switch scope {
+	case cache.LookupBucketParticipant:
+		return corev1.LookupRateLimitScope_LOOKUP_RATE_LIMIT_SCOPE_PARTICIPANT
+	case cache.LookupBucketEndUser:
+		return corev1.LookupRateLimitScope_LOOKUP_RATE_LIMIT_SCOPE_END_USER
+	default:
+		return corev1.LookupRateLimitScope_LOOKUP_RATE_LIMIT_SCOPE_UNSPECIFIED
+	}
+}
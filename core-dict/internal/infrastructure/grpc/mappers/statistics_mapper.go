@@ -0,0 +1,92 @@
+package mappers
+
+import (
+	"fmt"
+	"regexp"
+
+	corev1 "github.com/lbpay-lab/dict-contracts/gen/proto/core/v1"
+
+	"github.com/lbpay-lab/core-dict/internal/application/queries"
+	"github.com/lbpay-lab/core-dict/internal/domain/entities"
+	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
+)
+
+var ispbPattern = regexp.MustCompile(`^[0-9]{8}$`)
+
+// ============================================================================
+// Proto GetStatisticsRequest → Application GetStatisticsQuery
+// ============================================================================
+
+func MapProtoGetStatisticsRequestToQuery(req *corev1.GetStatisticsRequest) (queries.GetStatisticsQuery, error) {
+	query := queries.GetStatisticsQuery{
+		ISPB:   req.GetIspb(),
+		Branch: req.GetBranch(),
+	}
+
+	if query.ISPB != "" && !ispbPattern.MatchString(query.ISPB) {
+		return queries.GetStatisticsQuery{}, fmt.Errorf("invalid ispb: must have 8 digits")
+	}
+	if query.Branch != "" && query.ISPB == "" {
+		return queries.GetStatisticsQuery{}, fmt.Errorf("branch filter requires ispb")
+	}
+
+	switch req.GetGroupBy() {
+	case corev1.GetStatisticsRequest_GROUP_BY_UNSPECIFIED:
+		query.GroupBy = repositories.StatisticsGroupByNone
+	case corev1.GetStatisticsRequest_GROUP_BY_ISPB:
+		query.GroupBy = repositories.StatisticsGroupByISPB
+	case corev1.GetStatisticsRequest_GROUP_BY_BRANCH:
+		query.GroupBy = repositories.StatisticsGroupByBranch
+	default:
+		return queries.GetStatisticsQuery{}, fmt.Errorf("invalid group_by: %v", req.GetGroupBy())
+	}
+
+	if req.GetCreatedAfter() != nil {
+		after := req.GetCreatedAfter().AsTime()
+		query.CreatedAfter = &after
+	}
+	if req.GetCreatedBefore() != nil {
+		before := req.GetCreatedBefore().AsTime()
+		query.CreatedBefore = &before
+	}
+
+	return query, nil
+}
+
+// ============================================================================
+// Domain Statistics → Proto GetStatisticsResponse
+// ============================================================================
+
+func MapDomainStatisticsToProto(stats *entities.Statistics) *corev1.GetStatisticsResponse {
+	if stats == nil {
+		return &corev1.GetStatisticsResponse{}
+	}
+
+	breakdown := make([]*corev1.ParticipantStatistics, 0, len(stats.Breakdown))
+	for _, p := range stats.Breakdown {
+		breakdown = append(breakdown, &corev1.ParticipantStatistics{
+			Ispb:        p.ISPB,
+			Branch:      p.Branch,
+			TotalKeys:   p.TotalKeys,
+			ActiveKeys:  p.ActiveKeys,
+			BlockedKeys: p.BlockedKeys,
+			DeletedKeys: p.DeletedKeys,
+			KeysByType:  p.KeysByType,
+			TotalClaims: p.TotalClaims,
+		})
+	}
+
+	return &corev1.GetStatisticsResponse{
+		TotalKeys:       stats.TotalKeys,
+		ActiveKeys:      stats.ActiveKeys,
+		BlockedKeys:     stats.BlockedKeys,
+		DeletedKeys:     stats.DeletedKeys,
+		KeysByType:      stats.KeysByType,
+		TotalClaims:     stats.TotalClaims,
+		PendingClaims:   stats.PendingClaims,
+		CompletedClaims: stats.CompletedClaims,
+		ClaimsByType:    stats.ClaimsByType,
+		Breakdown:       breakdown,
+		RefreshedAt:     TimeToTimestamppb(stats.LastUpdated),
+	}
+}
@@ -0,0 +1,75 @@
+package mappers
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	corev1 "github.com/lbpay-lab/dict-contracts/gen/proto/core/v1"
+
+	"github.com/lbpay-lab/core-dict/internal/domain/entities"
+)
+
+// ============================================================================
+// Domain WebhookEndpoint → Proto RegisterWebhookEndpointResponse
+// ============================================================================
+
+func MapWebhookEndpointToProto(endpoint *entities.WebhookEndpoint) *corev1.RegisterWebhookEndpointResponse {
+	return &corev1.RegisterWebhookEndpointResponse{
+		EndpointId: endpoint.ID.String(),
+		Url:        endpoint.URL,
+		EventTypes: endpoint.EventTypes,
+		Secret:     endpoint.Secret,
+		Active:     endpoint.Active,
+		CreatedAt:  timestamppb.New(endpoint.CreatedAt),
+	}
+}
+
+// ============================================================================
+// Proto ReplayWebhookDeliveriesRequest → Domain WebhookReplayFilter
+// ============================================================================
+
+func MapProtoReplayWebhookDeliveriesRequestToFilter(req *corev1.ReplayWebhookDeliveriesRequest) (entities.WebhookReplayFilter, error) {
+	filter := entities.WebhookReplayFilter{
+		Status: mapProtoWebhookDeliveryStatus(req.GetStatus()),
+	}
+
+	for _, id := range req.GetDeliveryIds() {
+		deliveryID, err := uuid.Parse(id)
+		if err != nil {
+			return filter, fmt.Errorf("invalid delivery_id %q", id)
+		}
+		filter.DeliveryIDs = append(filter.DeliveryIDs, deliveryID)
+	}
+	if req.GetEndpointId() != "" {
+		endpointID, err := uuid.Parse(req.GetEndpointId())
+		if err != nil {
+			return filter, fmt.Errorf("invalid endpoint_id %q", req.GetEndpointId())
+		}
+		filter.EndpointID = &endpointID
+	}
+	if req.GetFrom() != nil {
+		from := req.GetFrom().AsTime()
+		filter.From = &from
+	}
+	if req.GetTo() != nil {
+		to := req.GetTo().AsTime()
+		filter.To = &to
+	}
+
+	return filter, nil
+}
+
+func mapProtoWebhookDeliveryStatus(s corev1.WebhookDeliveryStatus) entities.WebhookDeliveryStatus {
+	switch s {
+	case corev1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_PENDING:
+		return entities.WebhookDeliveryPending
+	case corev1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_DELIVERED:
+		return entities.WebhookDeliveryDelivered
+	case corev1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_DEAD_LETTER:
+		return entities.WebhookDeliveryDeadLetter
+	default:
+		return "" // The service defaults to DEAD_LETTER
+	}
+}
@@ -0,0 +1,116 @@
+package grpc
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lbpay-lab/core-dict/internal/application/queries"
+	"github.com/lbpay-lab/core-dict/internal/domain/entities"
+	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
+)
+
+// StatisticsExporter exports DICT business statistics in Prometheus text format.
+// It reads through GetStatisticsQueryHandler, i.e. the same materialized view
+// (and cache) that backs the GetStatistics RPC.
+type StatisticsExporter struct {
+	statsQuery *queries.GetStatisticsQueryHandler
+}
+
+// NewStatisticsExporter creates a new StatisticsExporter
+func NewStatisticsExporter(statsQuery *queries.GetStatisticsQueryHandler) *StatisticsExporter {
+	return &StatisticsExporter{statsQuery: statsQuery}
+}
+
+// PrometheusExporter exports statistics in Prometheus format (per-ISPB breakdown)
+func (e *StatisticsExporter) PrometheusExporter(ctx context.Context) (string, error) {
+	stats, err := e.statsQuery.Handle(ctx, queries.GetStatisticsQuery{GroupBy: repositories.StatisticsGroupByISPB})
+	if err != nil {
+		return "", err
+	}
+	return formatStatistics(stats), nil
+}
+
+// formatStatistics renders statistics as Prometheus gauges
+func formatStatistics(stats *entities.Statistics) string {
+	var b strings.Builder
+
+	b.WriteString("# HELP dict_keys Number of PIX keys by participant, key type and status\n")
+	b.WriteString("# TYPE dict_keys gauge\n")
+	for _, p := range stats.Breakdown {
+		for _, keyType := range sortedKeys(p.KeysByType) {
+			b.WriteString("dict_keys{ispb=\"" + p.ISPB + "\",key_type=\"" + keyType + "\"} " + toString(p.KeysByType[keyType]) + "\n")
+		}
+	}
+
+	b.WriteString("# HELP dict_keys_by_status Number of PIX keys by participant and status\n")
+	b.WriteString("# TYPE dict_keys_by_status gauge\n")
+	for _, p := range stats.Breakdown {
+		b.WriteString("dict_keys_by_status{ispb=\"" + p.ISPB + "\",status=\"ACTIVE\"} " + toString(p.ActiveKeys) + "\n")
+		b.WriteString("dict_keys_by_status{ispb=\"" + p.ISPB + "\",status=\"BLOCKED\"} " + toString(p.BlockedKeys) + "\n")
+		b.WriteString("dict_keys_by_status{ispb=\"" + p.ISPB + "\",status=\"DELETED\"} " + toString(p.DeletedKeys) + "\n")
+	}
+
+	b.WriteString("# HELP dict_claims Number of claims by type\n")
+	b.WriteString("# TYPE dict_claims gauge\n")
+	for _, claimType := range sortedKeys(stats.ClaimsByType) {
+		b.WriteString("dict_claims{claim_type=\"" + claimType + "\"} " + toString(stats.ClaimsByType[claimType]) + "\n")
+	}
+
+	b.WriteString("# HELP dict_claims_by_status Number of claims by status group\n")
+	b.WriteString("# TYPE dict_claims_by_status gauge\n")
+	b.WriteString("dict_claims_by_status{status=\"PENDING\"} " + toString(stats.PendingClaims) + "\n")
+	b.WriteString("dict_claims_by_status{status=\"COMPLETED\"} " + toString(stats.CompletedClaims) + "\n")
+
+	b.WriteString("# HELP dict_statistics_refreshed_timestamp_seconds Unix time of the last statistics view refresh\n")
+	b.WriteString("# TYPE dict_statistics_refreshed_timestamp_seconds gauge\n")
+	b.WriteString("dict_statistics_refreshed_timestamp_seconds " + toString(stats.LastUpdated.Unix()) + "\n")
+
+	return b.String()
+}
+
+// sortedKeys returns map keys in deterministic order
+func sortedKeys(m map[string]int64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// MetricsSource is a component that exports its own metrics in Prometheus text format
+// (e.g. the outbox relay)
+type MetricsSource interface {
+	PrometheusExporter() string
+}
+
+// NewMetricsHTTPHandler serves /metrics combining gRPC metrics, DICT statistics and extra sources.
+// Either metrics or stats may be nil (e.g. statistics are unavailable in mock mode).
+func NewMetricsHTTPHandler(metrics *MetricsInterceptor, stats *StatisticsExporter, sources ...MetricsSource) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var output string
+		if metrics != nil {
+			output += metrics.PrometheusExporter()
+		}
+		if stats != nil {
+			ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+			defer cancel()
+
+			statsOutput, err := stats.PrometheusExporter(ctx)
+			if err != nil {
+				http.Error(w, "failed to export statistics: "+err.Error(), http.StatusServiceUnavailable)
+				return
+			}
+			output += statsOutput
+		}
+		for _, source := range sources {
+			output += source.PrometheusExporter()
+		}
+
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_, _ = w.Write([]byte(output))
+	})
+}
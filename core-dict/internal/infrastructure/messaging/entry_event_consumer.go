@@ -13,6 +13,8 @@ import (
 
 	commonv1 "github.com/lbpay-lab/dict-contracts/gen/proto/common/v1"
 	connectv1 "github.com/lbpay-lab/dict-contracts/gen/proto/connect/v1"
+	"github.com/lbpay-lab/core-dict/internal/application/commands"
+	"github.com/lbpay-lab/core-dict/internal/domain"
 	"github.com/lbpay-lab/core-dict/internal/domain/entities"
 	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
 	"github.com/lbpay-lab/core-dict/internal/domain/valueobjects"
@@ -21,12 +23,18 @@ import (
 // ConnectEventHandler is a function that handles a specific Connect event type
 type ConnectEventHandler func(ctx context.Context, payload []byte) error
 
+// AccountClosureKeyConfirmer is implemented by commands.ConfirmAccountClosureKeyCommandHandler
+type AccountClosureKeyConfirmer interface {
+	Handle(ctx context.Context, cmd commands.ConfirmAccountClosureKeyCommand) (*commands.ConfirmAccountClosureKeyResult, error)
+}
+
 // EntryEventConsumer consumes Connect events from Pulsar and updates local database
 type EntryEventConsumer struct {
 	consumer       pulsar.Consumer
 	entryRepo      repositories.EntryRepository
 	claimRepo      repositories.ClaimRepository
 	infractionRepo repositories.InfractionRepository
+	closureKeys    AccountClosureKeyConfirmer
 	handlers       map[string]ConnectEventHandler
 	config         *EntryEventConsumerConfig
 }
@@ -37,6 +45,7 @@ func NewEntryEventConsumer(
 	entryRepo repositories.EntryRepository,
 	claimRepo repositories.ClaimRepository,
 	infractionRepo repositories.InfractionRepository,
+	closureKeys AccountClosureKeyConfirmer,
 ) (*EntryEventConsumer, error) {
 	if config == nil {
 		config = DefaultEntryEventConsumerConfig()
@@ -88,6 +97,7 @@ func NewEntryEventConsumer(
 		entryRepo:      entryRepo,
 		claimRepo:      claimRepo,
 		infractionRepo: infractionRepo,
+		closureKeys:    closureKeys,
 		handlers:       make(map[string]ConnectEventHandler),
 		config:         config,
 	}
@@ -200,6 +210,37 @@ func (c *EntryEventConsumer) handleEntryStatusChanged(ctx context.Context, paylo
 	// TODO: Add EntryRepository.UpdateStatus method to interface and implementation
 	// Example: return c.entryRepo.UpdateStatus(ctx, event.EntryId, newStatus)
 
+	// Bacen answer to a deletion: final outcome of the key when it belongs to an account closure
+	if event.CausedByType == connectv1.EntryStatusChangedEvent_CAUSED_BY_TYPE_DELETION && c.closureKeys != nil {
+		return c.confirmAccountClosureKey(ctx, event)
+	}
+
+	return nil
+}
+
+// confirmAccountClosureKey records the Bacen answer (deleted, or rejected with event.error) for the
+// key of an account closure; deletions outside a closure are ignored by the command
+func (c *EntryEventConsumer) confirmAccountClosureKey(ctx context.Context, event *connectv1.EntryStatusChangedEvent) error {
+	entryID, err := uuid.Parse(event.EntryId)
+	if err != nil {
+		return fmt.Errorf("invalid entry_id %q: %w", event.EntryId, err)
+	}
+
+	bacenErr := event.GetError()
+	if bacenErr == "" && event.NewStatus != commonv1.EntryStatus_ENTRY_STATUS_DELETED {
+		bacenErr = fmt.Sprintf("entry is %v after the deletion", event.NewStatus)
+	}
+
+	result, err := c.closureKeys.Handle(ctx, commands.ConfirmAccountClosureKeyCommand{
+		EntryID:  entryID,
+		BacenErr: bacenErr,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to confirm account closure key: %w", err)
+	}
+	if result.Confirmed {
+		log.Printf("[Handler:EntryStatusChanged] Account closure key %s confirmed (error: %q)\n", event.EntryId, bacenErr)
+	}
 	return nil
 }
 
@@ -216,12 +257,18 @@ func (c *EntryEventConsumer) handleClaimCreated(ctx context.Context, payload []b
 	log.Printf("[Handler:ClaimCreated] Claim %s created for entry %s by claimer %s\n",
 		event.ClaimId, event.EntryId, event.ClaimerIspb)
 
+	// The Bacen claim ID is the local ID: redeliveries and repeated polls find the same claim
+	claimID, err := uuid.Parse(event.ClaimId)
+	if err != nil {
+		return fmt.Errorf("invalid claim_id %q: %w", event.ClaimId, err)
+	}
+
 	// Map proto claim type to domain claim type
 	claimType := mapProtoClaimTypeToDomain(event.ClaimType)
 
 	// Create claim in local database
 	claim := &entities.Claim{
-		ID:               parseUUID(event.ClaimId),
+		ID:               claimID,
 		EntryKey:         event.KeyValue,
 		ClaimType:        claimType,
 		Status:           valueobjects.ClaimStatusOpen,
@@ -239,9 +286,14 @@ func (c *EntryEventConsumer) handleClaimCreated(ctx context.Context, payload []b
 		Metadata:             convertMetadata(event.Metadata),
 	}
 
-	if err := c.claimRepo.Create(ctx, claim); err != nil {
+	created, err := c.claimRepo.CreateIfNotExists(ctx, claim)
+	if err != nil {
 		return fmt.Errorf("failed to create claim in database: %w", err)
 	}
+	if !created {
+		log.Printf("[Handler:ClaimCreated] Claim %s already stored, ignoring duplicate event\n", event.ClaimId)
+		return nil
+	}
 
 	log.Printf("[Handler:ClaimCreated] Claim %s successfully stored in database\n", event.ClaimId)
 
@@ -254,7 +306,8 @@ func (c *EntryEventConsumer) handleClaimCreated(ctx context.Context, payload []b
 // ===================================================================
 // EVENT HANDLER 3: Claim Completed
 // ===================================================================
-// Handles: Claim completed (CONFIRMED, CANCELLED, EXPIRED)
+// Handles: Claim completed (CONFIRMED, COMPLETED, CANCELLED, EXPIRED), with the Bacen error
+// when Bacen rejected the claim
 func (c *EntryEventConsumer) handleClaimCompleted(ctx context.Context, payload []byte) error {
 	event := &connectv1.ClaimCompletedEvent{}
 	if err := proto.Unmarshal(payload, event); err != nil {
@@ -264,6 +317,19 @@ func (c *EntryEventConsumer) handleClaimCompleted(ctx context.Context, payload [
 	log.Printf("[Handler:ClaimCompleted] Claim %s completed with status %v (reason: %s)\n",
 		event.ClaimId, event.FinalStatus, event.Reason)
 
+	reason := event.Reason
+	if bacenErr := event.GetBacenError(); bacenErr != nil {
+		rejection := domain.FromBacenRejection(domain.BacenRejection{
+			Code:       bacenErr.GetBacenCode(),
+			Message:    bacenErr.GetBacenMessage(),
+			RequestID:  bacenErr.GetBacenRequestId(),
+			HTTPStatus: int(bacenErr.GetHttpStatus()),
+		})
+		log.Printf("[Handler:ClaimCompleted] Claim %s rejected by Bacen: code=%s request_id=%s: %v\n",
+			event.ClaimId, bacenErr.GetBacenCode(), bacenErr.GetBacenRequestId(), rejection)
+		reason = fmt.Sprintf("%s [%s]", rejection.Error(), bacenErr.GetBacenCode())
+	}
+
 	// Map proto claim status to domain claim status
 	finalStatus := mapProtoClaimStatusToDomain(event.FinalStatus)
 
@@ -276,13 +342,17 @@ func (c *EntryEventConsumer) handleClaimCompleted(ctx context.Context, payload [
 	// Update claim status
 	switch finalStatus {
 	case valueobjects.ClaimStatusConfirmed:
-		if err := claim.Confirm(event.Reason); err != nil {
+		if err := claim.Confirm(reason); err != nil {
 			return fmt.Errorf("failed to confirm claim: %w", err)
 		}
 	case valueobjects.ClaimStatusCancelled:
-		if err := claim.Cancel(event.Reason); err != nil {
+		if err := claim.Cancel(reason); err != nil {
 			return fmt.Errorf("failed to cancel claim: %w", err)
 		}
+	case valueobjects.ClaimStatusCompleted:
+		if err := claim.Complete(); err != nil {
+			return fmt.Errorf("failed to complete claim: %w", err)
+		}
 	case valueobjects.ClaimStatusExpired:
 		if err := claim.Expire(); err != nil {
 			return fmt.Errorf("failed to expire claim: %w", err)
@@ -0,0 +1,71 @@
+package messaging
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	connectv1 "github.com/lbpay-lab/dict-contracts/gen/proto/connect/v1"
+
+	"github.com/lbpay-lab/core-dict/internal/domain/entities"
+	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
+)
+
+// fakeClaimRepo stores claims by ID, like the ON CONFLICT (id) insert
+type fakeClaimRepo struct {
+	repositories.ClaimRepository
+	claims map[uuid.UUID]*entities.Claim
+}
+
+func (r *fakeClaimRepo) CreateIfNotExists(ctx context.Context, claim *entities.Claim) (bool, error) {
+	if _, ok := r.claims[claim.ID]; ok {
+		return false, nil
+	}
+	r.claims[claim.ID] = claim
+	return true, nil
+}
+
+func claimCreatedPayload(t *testing.T, claimID string) []byte {
+	t.Helper()
+	now := time.Now()
+	payload, err := proto.Marshal(&connectv1.ClaimCreatedEvent{
+		ClaimId:     claimID,
+		KeyValue:    "user@example.com",
+		ClaimerIspb: "12345678",
+		OwnerIspb:   "87654321",
+		ExpiresAt:   timestamppb.New(now.Add(7 * 24 * time.Hour)),
+		CreatedAt:   timestamppb.New(now),
+	})
+	require.NoError(t, err)
+	return payload
+}
+
+func TestHandleClaimCreated_DuplicateEventIsIgnored(t *testing.T) {
+	repo := &fakeClaimRepo{claims: make(map[uuid.UUID]*entities.Claim)}
+	consumer := &EntryEventConsumer{claimRepo: repo}
+	claimID := uuid.New()
+	payload := claimCreatedPayload(t, claimID.String())
+
+	// Redelivered or published again by a later poll
+	require.NoError(t, consumer.handleClaimCreated(context.Background(), payload))
+	require.NoError(t, consumer.handleClaimCreated(context.Background(), payload))
+
+	require.Len(t, repo.claims, 1)
+	assert.Equal(t, claimID.String(), repo.claims[claimID].BacenClaimID)
+}
+
+func TestHandleClaimCreated_InvalidClaimID(t *testing.T) {
+	repo := &fakeClaimRepo{claims: make(map[uuid.UUID]*entities.Claim)}
+	consumer := &EntryEventConsumer{claimRepo: repo}
+
+	err := consumer.handleClaimCreated(context.Background(), claimCreatedPayload(t, "not-a-uuid"))
+
+	assert.Error(t, err)
+	assert.Empty(t, repo.claims)
+}
@@ -12,9 +12,10 @@ import (
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
-	connectv1 "github.com/lbpay-lab/dict-contracts/proto/conn_dict/v1"
+	connectv1 "github.com/lbpay-lab/dict-contracts/gen/proto/connect/v1"
 	commonv1 "github.com/lbpay-lab/dict-contracts/gen/proto/common/v1"
 	"github.com/lbpay-lab/core-dict/internal/domain/entities"
+	"github.com/lbpay-lab/core-dict/internal/domain/valueobjects"
 )
 
 // EntryEventProducer publishes DICT Entry events to Pulsar
@@ -423,14 +424,26 @@ func mapAccountType(accountType string) commonv1.AccountType {
 	}
 }
 
-// mapDocumentType infers document type from TaxID length
+// mapDocumentType infers document type from TaxID format.
+// CNPJs may be alphanumeric (12 [0-9A-Z] + 2 check digits), so a 14-char
+// value is only treated as CNPJ when it matches that format.
 func mapDocumentType(taxID string) commonv1.DocumentType {
-	switch len(taxID) {
-	case 11:
+	switch {
+	case len(taxID) == 11 && isAllDigits(taxID):
 		return commonv1.DocumentType_DOCUMENT_TYPE_CPF
-	case 14:
+	case valueobjects.IsCNPJFormat(valueobjects.NormalizeCNPJ(taxID)):
 		return commonv1.DocumentType_DOCUMENT_TYPE_CNPJ
 	default:
 		return commonv1.DocumentType_DOCUMENT_TYPE_UNSPECIFIED
 	}
 }
+
+// isAllDigits reports whether s contains only ASCII digits
+func isAllDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,66 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/lbpay-lab/core-dict/internal/application/services"
+)
+
+// FileNotifier appends verification codes to a JSON Lines file.
+// Meant for local environments and end-to-end tests, which read the code back from the file.
+type FileNotifier struct {
+	path string
+	mu   sync.Mutex
+}
+
+// FileNotification is one line of the FileNotifier output
+type FileNotification struct {
+	VerificationID string    `json:"verification_id"`
+	KeyType        string    `json:"key_type"`
+	Destination    string    `json:"destination"`
+	Code           string    `json:"code"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	SentAt         time.Time `json:"sent_at"`
+}
+
+// NewFileNotifier creates a new FileNotifier writing to path
+func NewFileNotifier(path string) (*FileNotifier, error) {
+	if path == "" {
+		return nil, fmt.Errorf("notifier file path is required")
+	}
+	return &FileNotifier{path: path}, nil
+}
+
+// SendVerificationCode appends the verification code to the file
+func (n *FileNotifier) SendVerificationCode(ctx context.Context, msg services.VerificationCodeMessage) error {
+	line, err := json.Marshal(FileNotification{
+		VerificationID: msg.VerificationID,
+		KeyType:        string(msg.KeyType),
+		Destination:    msg.Destination,
+		Code:           msg.Code,
+		ExpiresAt:      msg.ExpiresAt,
+		SentAt:         time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	f, err := os.OpenFile(n.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open notifier file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write notification: %w", err)
+	}
+	return nil
+}
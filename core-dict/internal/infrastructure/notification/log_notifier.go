@@ -0,0 +1,31 @@
+package notification
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/lbpay-lab/core-dict/internal/application/services"
+)
+
+// LogNotifier writes verification codes to the application log.
+// Development only: the code is logged in clear text and nothing reaches the customer.
+type LogNotifier struct {
+	logger *slog.Logger
+}
+
+// NewLogNotifier creates a new LogNotifier
+func NewLogNotifier(logger *slog.Logger) *LogNotifier {
+	return &LogNotifier{logger: logger}
+}
+
+// SendVerificationCode logs the verification code
+func (n *LogNotifier) SendVerificationCode(ctx context.Context, msg services.VerificationCodeMessage) error {
+	n.logger.WarnContext(ctx, "key verification code (log notifier, development only)",
+		"verification_id", msg.VerificationID,
+		"key_type", msg.KeyType,
+		"destination", services.MaskDestination(msg.KeyType, msg.Destination),
+		"code", msg.Code,
+		"expires_at", msg.ExpiresAt,
+	)
+	return nil
+}
@@ -0,0 +1,144 @@
+package outbox_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lbpay-lab/dict-contracts/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lbpay-lab/core-dict/internal/application/commands"
+	"github.com/lbpay-lab/core-dict/internal/application/services"
+	"github.com/lbpay-lab/core-dict/internal/domain/entities"
+	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
+	"github.com/lbpay-lab/core-dict/internal/infrastructure/outbox"
+)
+
+// The entry commands write their events through the outbox; conn-dict decodes them into the
+// dict-contracts/events types. These tests run that round trip.
+
+type entryRepo struct {
+	repositories.EntryRepository
+	entries map[uuid.UUID]*entities.Entry
+}
+
+func (r *entryRepo) Create(_ context.Context, entry *entities.Entry) error {
+	c := *entry
+	r.entries[entry.ID] = &c
+	return nil
+}
+
+func (r *entryRepo) FindByID(_ context.Context, id uuid.UUID) (*entities.Entry, error) {
+	c := *r.entries[id]
+	return &c, nil
+}
+
+func (r *entryRepo) Update(ctx context.Context, entry *entities.Entry) error {
+	return r.Create(ctx, entry)
+}
+
+type noopCache struct{ services.CacheService }
+
+func (noopCache) Delete(context.Context, string) error     { return nil }
+func (noopCache) Invalidate(context.Context, string) error { return nil }
+
+type acceptAll struct{}
+
+func (acceptAll) ValidateFormat(entities.KeyType, string) error                  { return nil }
+func (acceptAll) ValidateLimits(context.Context, entities.KeyType, string) error { return nil }
+func (acceptAll) ValidateOwnership(context.Context, entities.KeyType, string, string) error {
+	return nil
+}
+func (acceptAll) IsDuplicate(context.Context, string) (bool, error) { return false, nil }
+
+// pendingPayload returns the payload of the only pending event of the given type
+func pendingPayload(t *testing.T, store *memoryStore, eventType string, into interface{}) {
+	t.Helper()
+	pending, err := store.FetchPending(context.Background(), 100)
+	require.NoError(t, err)
+	for _, event := range pending {
+		if event.EventType == eventType {
+			require.NoError(t, json.Unmarshal(event.Payload, into))
+			return
+		}
+	}
+	t.Fatalf("no pending %s event", eventType)
+}
+
+func TestEntryEvents_RoundTripToConnDictContract(t *testing.T) {
+	store := newMemoryStore()
+	publisher := outbox.NewPublisher(store, nil)
+	repo := &entryRepo{entries: make(map[uuid.UUID]*entities.Entry)}
+	ctx := context.Background()
+
+	created, err := commands.NewCreateEntryCommandHandler(repo, publisher, acceptAll{}, acceptAll{}, acceptAll{}, noopCache{}, nil, nil).
+		Handle(ctx, commands.CreateEntryCommand{
+			KeyType:       entities.KeyTypeCNPJ,
+			KeyValue:      "12345678000195",
+			AccountID:     uuid.New(),
+			AccountISPB:   "12345678",
+			AccountBranch: "0001",
+			AccountNumber: "123456",
+			AccountType:   "CACC",
+			OwnerType:     string(entities.OwnerTypeLegalEntity),
+			OwnerTaxID:    "12345678000195",
+			OwnerName:     "Empresa LTDA",
+			RequestedBy:   uuid.New(),
+		})
+	require.NoError(t, err)
+
+	var createdEvent events.EntryCreatedEvent
+	pendingPayload(t, store, "EntryCreated", &createdEvent)
+	assert.Equal(t, created.EntryID.String(), createdEvent.EntryID)
+	assert.Equal(t, "12345678000195", createdEvent.Key)
+	assert.Equal(t, "CNPJ", createdEvent.KeyType)
+	assert.Equal(t, "12345678", createdEvent.Participant)
+	assert.Equal(t, "0001", *createdEvent.AccountBranch)
+	assert.Equal(t, "Empresa LTDA", *createdEvent.OwnerName)
+	assert.Equal(t, events.OwnerTypeLegalPerson, createdEvent.OwnerType)
+	assert.NotEmpty(t, createdEvent.RequestID)
+	assert.Equal(t, createdEvent.RequestID, createdEvent.IdempotencyKey)
+
+	// Only active keys can be updated or deleted
+	repo.entries[created.EntryID].Status = entities.KeyStatusActive
+
+	_, err = commands.NewUpdateEntryCommandHandler(repo, publisher, noopCache{}, nil, nil).
+		Handle(ctx, commands.UpdateEntryCommand{
+			EntryID:       created.EntryID,
+			AccountISPB:   "87654321",
+			AccountNumber: "654321",
+			RequestedBy:   uuid.New(),
+			TwoFactorCode: "123456",
+		})
+	require.NoError(t, err)
+
+	var updatedEvent events.EntryUpdatedEvent
+	pendingPayload(t, store, "EntryUpdated", &updatedEvent)
+	assert.Equal(t, "12345678000195", updatedEvent.Key)
+	assert.Equal(t, "87654321", updatedEvent.Participant)
+	assert.Equal(t, "0001", *updatedEvent.AccountBranch)
+	assert.Equal(t, "654321", *updatedEvent.AccountNumber)
+	assert.NotEmpty(t, updatedEvent.IdempotencyKey)
+	assert.NotEqual(t, createdEvent.IdempotencyKey, updatedEvent.IdempotencyKey, "each operation has its own key")
+
+	_, err = commands.NewDeleteEntryCommandHandler(repo, publisher, noopCache{}, nil, nil).
+		Handle(ctx, commands.DeleteEntryCommand{
+			EntryID:       created.EntryID,
+			RequestedBy:   uuid.New(),
+			TwoFactorCode: "123456",
+			Reason:        "customer request",
+		})
+	require.NoError(t, err)
+
+	var deletedEvent events.EntryDeletedEvent
+	pendingPayload(t, store, "EntryDeleted", &deletedEvent)
+	assert.Equal(t, created.EntryID.String(), deletedEvent.EntryID)
+	assert.Equal(t, "12345678000195", deletedEvent.Key)
+	assert.Equal(t, "CNPJ", deletedEvent.KeyType)
+	assert.Equal(t, "customer request", deletedEvent.Reason)
+	assert.NotEmpty(t, deletedEvent.RequestID)
+	assert.NotEmpty(t, deletedEvent.IdempotencyKey)
+}
@@ -0,0 +1,132 @@
+// Package outbox implements the transactional outbox for core-dict domain events.
+//
+// Commands write their domain events into core_dict.outbox_events in the same transaction as
+// the aggregate (see Publisher). The Relay then publishes pending rows to Pulsar, in id order
+// per partition key, and marks them as sent. A crash between the write and the publish can only
+// delay an event (or publish it twice), never lose it.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/lbpay-lab/core-dict/internal/application/commands"
+)
+
+// Default Pulsar topics for outbox events
+const (
+	TopicEntryCreated = "persistent://public/default/dict.entries.created"
+	TopicEntryUpdated = "persistent://public/default/dict.entries.updated"
+	TopicEntryDeleted = "persistent://public/default/dict.entries.deleted.immediate"
+
+	TopicClaimCancelled       = "persistent://public/default/dict.claims.cancelled"
+	TopicPortabilityCancelled = "persistent://public/default/dict.portabilities.cancelled"
+
+	TopicDomainEvents = "persistent://core-dict/events/domain-events"
+)
+
+// Event is an outbox row
+type Event struct {
+	ID            int64 // Sequence (publishing order)
+	EventID       uuid.UUID
+	EventType     string
+	AggregateType string
+	AggregateID   string
+	PartitionKey  string // Pulsar message key
+	Topic         string
+	Payload       []byte // JSON
+	Headers       map[string]string
+	OccurredAt    time.Time
+	CreatedAt     time.Time
+	Attempts      int
+}
+
+// Appender writes events into the outbox, joining the transaction carried by ctx
+type Appender interface {
+	Append(ctx context.Context, event *Event) error
+}
+
+// TopicRouter maps event types to Pulsar topics
+type TopicRouter struct {
+	topics       map[string]string
+	defaultTopic string
+}
+
+// NewTopicRouter creates a router; event types not in topics go to defaultTopic
+func NewTopicRouter(topics map[string]string, defaultTopic string) *TopicRouter {
+	return &TopicRouter{topics: topics, defaultTopic: defaultTopic}
+}
+
+// DefaultTopicRouter routes entry lifecycle events and claim/portability cancellations to the
+// topics consumed by conn-dict and every other event to the core-dict domain events topic
+func DefaultTopicRouter() *TopicRouter {
+	return NewTopicRouter(map[string]string{
+		"EntryCreated":         TopicEntryCreated,
+		"EntryUpdated":         TopicEntryUpdated,
+		"EntryDeleted":         TopicEntryDeleted,
+		"ClaimCancelled":       TopicClaimCancelled,
+		"PortabilityCancelled": TopicPortabilityCancelled,
+	}, TopicDomainEvents)
+}
+
+// Topic returns the topic for the event type
+func (r *TopicRouter) Topic(eventType string) string {
+	if topic, ok := r.topics[eventType]; ok {
+		return topic
+	}
+	return r.defaultTopic
+}
+
+// Publisher implements commands.EventPublisher by appending to the outbox.
+// It must be called inside the command transaction for the event to be atomic with the aggregate.
+type Publisher struct {
+	appender Appender
+	router   *TopicRouter
+}
+
+// NewPublisher creates an outbox publisher
+func NewPublisher(appender Appender, router *TopicRouter) *Publisher {
+	if router == nil {
+		router = DefaultTopicRouter()
+	}
+	return &Publisher{appender: appender, router: router}
+}
+
+// Publish appends the domain event to the outbox
+func (p *Publisher) Publish(ctx context.Context, event commands.DomainEvent) error {
+	payload, err := json.Marshal(event.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s payload: %w", event.EventType, err)
+	}
+
+	occurredAt := event.OccurredAt
+	if occurredAt.IsZero() {
+		occurredAt = time.Now()
+	}
+
+	eventID := uuid.New()
+	return p.appender.Append(ctx, &Event{
+		EventID:       eventID,
+		EventType:     event.EventType,
+		AggregateType: event.AggregateType,
+		AggregateID:   event.AggregateID,
+		PartitionKey:  event.AggregateID,
+		Topic:         p.router.Topic(event.EventType),
+		Payload:       payload,
+		Headers: map[string]string{
+			"event_id":        eventID.String(),
+			"event_type":      event.EventType,
+			"aggregate_type":  event.AggregateType,
+			"aggregate_id":    event.AggregateID,
+			"occurred_at":     occurredAt.UTC().Format(time.RFC3339Nano),
+			"idempotency_key": eventID.String(),
+			"source":          "core-dict",
+			"version":         "v1",
+		},
+		OccurredAt: occurredAt,
+	})
+}
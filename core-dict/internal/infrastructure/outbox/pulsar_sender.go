@@ -0,0 +1,97 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+)
+
+// PulsarSender publishes outbox events to Pulsar, one producer per topic
+type PulsarSender struct {
+	client      pulsar.Client
+	sendTimeout time.Duration
+
+	mu        sync.Mutex
+	producers map[string]pulsar.Producer
+}
+
+// NewPulsarSender connects to the Pulsar broker
+func NewPulsarSender(brokerURL string, sendTimeout time.Duration) (*PulsarSender, error) {
+	client, err := pulsar.NewClient(pulsar.ClientOptions{
+		URL:                     brokerURL,
+		ConnectionTimeout:       5 * time.Second,
+		OperationTimeout:        30 * time.Second,
+		MaxConnectionsPerBroker: 10,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Pulsar client: %w", err)
+	}
+	return &PulsarSender{
+		client:      client,
+		sendTimeout: sendTimeout,
+		producers:   make(map[string]pulsar.Producer),
+	}, nil
+}
+
+// Send publishes the event synchronously (the relay only marks it sent after the broker ack).
+// The message key is the partition key, so Pulsar keeps per-key order for Key_Shared consumers.
+func (s *PulsarSender) Send(ctx context.Context, event *Event) error {
+	producer, err := s.producer(event.Topic)
+	if err != nil {
+		return err
+	}
+
+	properties := make(map[string]string, len(event.Headers))
+	for k, v := range event.Headers {
+		properties[k] = v
+	}
+
+	_, err = producer.Send(ctx, &pulsar.ProducerMessage{
+		Payload:    event.Payload,
+		Key:        event.PartitionKey,
+		EventTime:  event.OccurredAt,
+		Properties: properties,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send %s to %s: %w", event.EventType, event.Topic, err)
+	}
+	return nil
+}
+
+// producer returns the producer for topic, creating it on first use
+func (s *PulsarSender) producer(topic string) (pulsar.Producer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if producer, ok := s.producers[topic]; ok {
+		return producer, nil
+	}
+
+	producer, err := s.client.CreateProducer(pulsar.ProducerOptions{
+		Topic:           topic,
+		CompressionType: pulsar.LZ4,
+		SendTimeout:     s.sendTimeout,
+		// Batching would let a failed batch leave gaps; the relay sends one event at a time
+		DisableBatching: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Pulsar producer for %s: %w", topic, err)
+	}
+	s.producers[topic] = producer
+	return producer, nil
+}
+
+// Close closes all producers and the client
+func (s *PulsarSender) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for topic, producer := range s.producers {
+		producer.Close()
+		delete(s.producers, topic)
+	}
+	s.client.Close()
+}
@@ -0,0 +1,353 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Store is the outbox table as seen by the relay
+type Store interface {
+	// WithRelayLock runs fn in a transaction holding the relay lock, so that a single replica
+	// publishes at a time (per-key order). acquired is false when another replica holds it.
+	WithRelayLock(ctx context.Context, fn func(ctx context.Context) error) (acquired bool, err error)
+	// FetchPending returns up to limit unsent events in id order. Partition keys held back by an
+	// earlier event waiting for its retry are skipped, so they cannot fill the batch.
+	FetchPending(ctx context.Context, limit int) ([]*Event, error)
+	// MarkSent marks the events as published and redacts their payload (it holds personal data)
+	MarkSent(ctx context.Context, ids []int64, sentAt time.Time) error
+	// MarkFailed records a failed publish attempt; the event is retried from nextAttemptAt
+	MarkFailed(ctx context.Context, id int64, cause string, nextAttemptAt time.Time) error
+	// MarkDeadLetter takes the event out of the pending queue after its last failed attempt
+	MarkDeadLetter(ctx context.Context, id int64, cause string, at time.Time) error
+	// PurgeSent deletes the events published before sentBefore
+	PurgeSent(ctx context.Context, sentBefore time.Time) (int64, error)
+	// PendingStats returns the number of unsent events and the creation time of the oldest one
+	PendingStats(ctx context.Context) (pending int64, oldest *time.Time, err error)
+}
+
+// Sender publishes an outbox event to the broker
+type Sender interface {
+	Send(ctx context.Context, event *Event) error
+}
+
+// RelayConfig configures the outbox relay
+type RelayConfig struct {
+	PollInterval time.Duration // Wait between polls when the outbox is drained
+	BatchSize    int           // Events per transaction
+	// Events are moved to the dead letter queue after MaxAttempts failed attempts
+	MaxAttempts int
+	// Retry n waits BackoffBase * 2^(n-1), capped at BackoffMax
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
+	// Published events are deleted after Retention
+	Retention time.Duration
+}
+
+// DefaultRelayConfig returns the default relay configuration
+// (10 attempts spread over about 40 minutes)
+func DefaultRelayConfig() RelayConfig {
+	return RelayConfig{
+		PollInterval: time.Second,
+		BatchSize:    100,
+		MaxAttempts:  10,
+		BackoffBase:  time.Second,
+		BackoffMax:   10 * time.Minute,
+		Retention:    24 * time.Hour,
+	}
+}
+
+// purgeInterval is how often the relay deletes published events past the retention
+const purgeInterval = time.Hour
+
+// Relay publishes pending outbox events to Pulsar and marks them as sent.
+//
+// Events are published in id order. When an event fails, it is retried with exponential backoff
+// and the following events with the same partition key are held back until it goes through, so
+// consumers never see them out of order. After MaxAttempts the event is dead-lettered: it stays in
+// the table (with its payload) for inspection and the rest of its key is released.
+// Delivery is at-least-once: a crash after the publish and before the commit republishes the
+// batch (consumers deduplicate by the event_id/idempotency_key property).
+type Relay struct {
+	store   Store
+	sender  Sender
+	config  RelayConfig
+	metrics *RelayMetrics
+	logger  *slog.Logger
+	now     func() time.Time
+}
+
+// NewRelay creates a new outbox relay
+func NewRelay(store Store, sender Sender, config RelayConfig, logger *slog.Logger) *Relay {
+	defaults := DefaultRelayConfig()
+	if config.PollInterval <= 0 {
+		config.PollInterval = defaults.PollInterval
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaults.BatchSize
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaults.MaxAttempts
+	}
+	if config.BackoffBase <= 0 {
+		config.BackoffBase = defaults.BackoffBase
+	}
+	if config.BackoffMax <= 0 {
+		config.BackoffMax = defaults.BackoffMax
+	}
+	if config.Retention <= 0 {
+		config.Retention = defaults.Retention
+	}
+	return &Relay{
+		store:   store,
+		sender:  sender,
+		config:  config,
+		metrics: &RelayMetrics{},
+		logger:  logger,
+		now:     time.Now,
+	}
+}
+
+// Metrics returns the relay metrics
+func (r *Relay) Metrics() *RelayMetrics {
+	return r.metrics
+}
+
+// Start polls the outbox until the context is cancelled
+func (r *Relay) Start(ctx context.Context) {
+	go func() {
+		timer := time.NewTimer(0)
+		defer timer.Stop()
+		var lastPurge time.Time
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+			}
+
+			wait := r.config.PollInterval
+			published, err := r.RunOnce(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				r.logger.Error("Outbox relay failed", "error", err)
+			} else if published == r.config.BatchSize {
+				// Full batch: there is probably more to drain
+				wait = 0
+			}
+			r.refreshPendingStats(ctx)
+			if time.Since(lastPurge) >= purgeInterval {
+				r.purge(ctx)
+				lastPurge = time.Now()
+			}
+			timer.Reset(wait)
+		}
+	}()
+}
+
+// RunOnce publishes one batch of pending events and returns how many were sent.
+// It is a no-op when another replica holds the relay lock.
+func (r *Relay) RunOnce(ctx context.Context) (int, error) {
+	published := 0
+	acquired, err := r.store.WithRelayLock(ctx, func(ctx context.Context) error {
+		events, err := r.store.FetchPending(ctx, r.config.BatchSize)
+		if err != nil {
+			return err
+		}
+
+		blocked := make(map[string]struct{})
+		sent := make([]int64, 0, len(events))
+		for _, event := range events {
+			if _, ok := blocked[event.PartitionKey]; ok {
+				continue
+			}
+
+			if err := r.sender.Send(ctx, event); err != nil {
+				if event.Attempts+1 < r.config.MaxAttempts {
+					blocked[event.PartitionKey] = struct{}{}
+				}
+				if err := r.recordFailure(ctx, event, err); err != nil {
+					return err
+				}
+				continue
+			}
+
+			sent = append(sent, event.ID)
+			r.metrics.observePublished(time.Since(event.CreatedAt))
+		}
+
+		if len(sent) == 0 {
+			return nil
+		}
+		if err := r.store.MarkSent(ctx, sent, r.now()); err != nil {
+			return err
+		}
+		published = len(sent)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("outbox relay batch failed: %w", err)
+	}
+	if !acquired {
+		r.metrics.leader.Store(false)
+		return 0, nil
+	}
+	r.metrics.leader.Store(true)
+	return published, nil
+}
+
+// recordFailure schedules the retry of a failed event or, after its last attempt, dead-letters it
+func (r *Relay) recordFailure(ctx context.Context, event *Event, cause error) error {
+	attempts := event.Attempts + 1
+	now := r.now()
+	r.metrics.failed.Add(1)
+
+	if attempts >= r.config.MaxAttempts {
+		r.metrics.deadLettered.Add(1)
+		r.logger.Error("Outbox event moved to the dead letter queue",
+			"event_id", event.EventID, "event_type", event.EventType,
+			"topic", event.Topic, "attempts", attempts, "error", cause)
+		return r.store.MarkDeadLetter(ctx, event.ID, cause.Error(), now)
+	}
+
+	nextAttemptAt := now.Add(r.backoff(attempts))
+	r.logger.Warn("Outbox event publish failed",
+		"event_id", event.EventID, "event_type", event.EventType,
+		"topic", event.Topic, "attempts", attempts, "next_attempt_at", nextAttemptAt, "error", cause)
+	return r.store.MarkFailed(ctx, event.ID, cause.Error(), nextAttemptAt)
+}
+
+// backoff returns the wait before retry number attempt (1 = first retry)
+func (r *Relay) backoff(attempt int) time.Duration {
+	wait := r.config.BackoffBase
+	for i := 1; i < attempt && wait < r.config.BackoffMax; i++ {
+		wait *= 2
+	}
+	if wait > r.config.BackoffMax {
+		wait = r.config.BackoffMax
+	}
+	return wait
+}
+
+// purge deletes the published events older than the retention
+func (r *Relay) purge(ctx context.Context) {
+	purged, err := r.store.PurgeSent(ctx, r.now().Add(-r.config.Retention))
+	if err != nil {
+		if ctx.Err() == nil {
+			r.logger.Error("Failed to purge published outbox events", "error", err)
+		}
+		return
+	}
+	if purged > 0 {
+		r.logger.Info("Purged published outbox events", "count", purged)
+	}
+}
+
+// refreshPendingStats updates the pending/lag gauges (the oldest unsent event age is the relay lag)
+func (r *Relay) refreshPendingStats(ctx context.Context) {
+	pending, oldest, err := r.store.PendingStats(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			r.logger.Error("Failed to read outbox stats", "error", err)
+		}
+		return
+	}
+	r.metrics.setPending(pending, oldest)
+}
+
+// RelayMetrics are the outbox relay counters and gauges
+type RelayMetrics struct {
+	published    atomic.Int64
+	failed       atomic.Int64
+	deadLettered atomic.Int64
+	leader       atomic.Bool
+
+	mu            sync.Mutex
+	pending       int64
+	oldestPending *time.Time
+	lagSum        float64 // seconds from outbox write to publish
+	lagMax        float64
+}
+
+func (m *RelayMetrics) observePublished(lag time.Duration) {
+	m.published.Add(1)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lagSum += lag.Seconds()
+	m.lagMax = math.Max(m.lagMax, lag.Seconds())
+}
+
+func (m *RelayMetrics) setPending(pending int64, oldest *time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pending = pending
+	m.oldestPending = oldest
+}
+
+// Published returns the number of events published since startup
+func (m *RelayMetrics) Published() int64 {
+	return m.published.Load()
+}
+
+// Failed returns the number of failed publish attempts since startup
+func (m *RelayMetrics) Failed() int64 {
+	return m.failed.Load()
+}
+
+// DeadLettered returns the number of events moved to the dead letter queue since startup
+func (m *RelayMetrics) DeadLettered() int64 {
+	return m.deadLettered.Load()
+}
+
+// PrometheusExporter exports the relay metrics in Prometheus text format
+func (m *RelayMetrics) PrometheusExporter() string {
+	m.mu.Lock()
+	pending := m.pending
+	var oldestAge float64
+	if m.oldestPending != nil {
+		oldestAge = math.Max(0, time.Since(*m.oldestPending).Seconds())
+	}
+	lagSum, lagMax := m.lagSum, m.lagMax
+	m.mu.Unlock()
+
+	leader := 0
+	if m.leader.Load() {
+		leader = 1
+	}
+
+	var b strings.Builder
+	b.WriteString("# HELP dict_outbox_pending_events Number of outbox events not yet published\n")
+	b.WriteString("# TYPE dict_outbox_pending_events gauge\n")
+	fmt.Fprintf(&b, "dict_outbox_pending_events %d\n", pending)
+	b.WriteString("# HELP dict_outbox_oldest_pending_age_seconds Age of the oldest unpublished outbox event (relay lag)\n")
+	b.WriteString("# TYPE dict_outbox_oldest_pending_age_seconds gauge\n")
+	fmt.Fprintf(&b, "dict_outbox_oldest_pending_age_seconds %.3f\n", oldestAge)
+	b.WriteString("# HELP dict_outbox_published_total Outbox events published to Pulsar\n")
+	b.WriteString("# TYPE dict_outbox_published_total counter\n")
+	fmt.Fprintf(&b, "dict_outbox_published_total %d\n", m.published.Load())
+	b.WriteString("# HELP dict_outbox_publish_failures_total Failed outbox publish attempts\n")
+	b.WriteString("# TYPE dict_outbox_publish_failures_total counter\n")
+	fmt.Fprintf(&b, "dict_outbox_publish_failures_total %d\n", m.failed.Load())
+	b.WriteString("# HELP dict_outbox_dead_lettered_total Outbox events moved to the dead letter queue\n")
+	b.WriteString("# TYPE dict_outbox_dead_lettered_total counter\n")
+	fmt.Fprintf(&b, "dict_outbox_dead_lettered_total %d\n", m.deadLettered.Load())
+	b.WriteString("# HELP dict_outbox_publish_lag_seconds Time from outbox write to publish\n")
+	b.WriteString("# TYPE dict_outbox_publish_lag_seconds summary\n")
+	fmt.Fprintf(&b, "dict_outbox_publish_lag_seconds_sum %.3f\n", lagSum)
+	fmt.Fprintf(&b, "dict_outbox_publish_lag_seconds_count %d\n", m.published.Load())
+	b.WriteString("# HELP dict_outbox_publish_lag_max_seconds Highest publish lag since startup\n")
+	b.WriteString("# TYPE dict_outbox_publish_lag_max_seconds gauge\n")
+	fmt.Fprintf(&b, "dict_outbox_publish_lag_max_seconds %.3f\n", lagMax)
+	b.WriteString("# HELP dict_outbox_relay_leader Whether this replica held the relay lock on its last poll\n")
+	b.WriteString("# TYPE dict_outbox_relay_leader gauge\n")
+	fmt.Fprintf(&b, "dict_outbox_relay_leader %d\n", leader)
+	return b.String()
+}
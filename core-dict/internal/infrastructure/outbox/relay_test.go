@@ -0,0 +1,409 @@
+package outbox_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lbpay-lab/core-dict/internal/application/commands"
+	"github.com/lbpay-lab/core-dict/internal/infrastructure/outbox"
+)
+
+// memoryStore is an in-memory outbox table
+type memoryStore struct {
+	mu           sync.Mutex
+	nextID       int64
+	rows         map[int64]*outbox.Event
+	sent         map[int64]time.Time
+	errors       map[int64]string
+	nextAttempts map[int64]time.Time
+	deadLetters  map[int64]time.Time
+	locked       bool // Another replica holds the relay lock
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		rows:         make(map[int64]*outbox.Event),
+		sent:         make(map[int64]time.Time),
+		errors:       make(map[int64]string),
+		nextAttempts: make(map[int64]time.Time),
+		deadLetters:  make(map[int64]time.Time),
+	}
+}
+
+// pending reports whether the event is neither sent nor dead-lettered
+func (s *memoryStore) pending(id int64) bool {
+	_, sent := s.sent[id]
+	_, dead := s.deadLetters[id]
+	return !sent && !dead
+}
+
+func (s *memoryStore) Append(_ context.Context, event *outbox.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextID++
+	event.ID = s.nextID
+	event.CreatedAt = time.Now()
+	c := *event
+	s.rows[c.ID] = &c
+	return nil
+}
+
+func (s *memoryStore) WithRelayLock(ctx context.Context, fn func(ctx context.Context) error) (bool, error) {
+	if s.locked {
+		return false, nil
+	}
+	return true, fn(ctx)
+}
+
+func (s *memoryStore) FetchPending(_ context.Context, limit int) ([]*outbox.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var events []*outbox.Event
+	for id, event := range s.rows {
+		if s.pending(id) {
+			c := *event
+			events = append(events, &c)
+		}
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].ID < events[j].ID })
+
+	// Keys with an event waiting for its retry are skipped (FetchPending NOT EXISTS clause)
+	waiting := make(map[string]bool)
+	due := events[:0]
+	for _, event := range events {
+		if next, ok := s.nextAttempts[event.ID]; ok && next.After(time.Now()) {
+			waiting[event.PartitionKey] = true
+		}
+		if !waiting[event.PartitionKey] {
+			due = append(due, event)
+		}
+	}
+	events = due
+	if len(events) > limit {
+		events = events[:limit]
+	}
+	return events, nil
+}
+
+func (s *memoryStore) MarkSent(_ context.Context, ids []int64, sentAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range ids {
+		s.sent[id] = sentAt
+		s.rows[id].Attempts++
+		s.rows[id].Payload = []byte(`{}`)
+		delete(s.nextAttempts, id)
+	}
+	return nil
+}
+
+func (s *memoryStore) MarkFailed(_ context.Context, id int64, cause string, nextAttemptAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rows[id].Attempts++
+	s.errors[id] = cause
+	s.nextAttempts[id] = nextAttemptAt
+	return nil
+}
+
+func (s *memoryStore) MarkDeadLetter(_ context.Context, id int64, cause string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rows[id].Attempts++
+	s.errors[id] = cause
+	s.deadLetters[id] = at
+	delete(s.nextAttempts, id)
+	return nil
+}
+
+func (s *memoryStore) PurgeSent(_ context.Context, sentBefore time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var purged int64
+	for id, sentAt := range s.sent {
+		if sentAt.Before(sentBefore) {
+			delete(s.rows, id)
+			delete(s.sent, id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+func (s *memoryStore) rowCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.rows)
+}
+
+func (s *memoryStore) PendingStats(_ context.Context) (int64, *time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var pending int64
+	var oldest *time.Time
+	for id, event := range s.rows {
+		if !s.pending(id) {
+			continue
+		}
+		pending++
+		if oldest == nil || event.CreatedAt.Before(*oldest) {
+			createdAt := event.CreatedAt
+			oldest = &createdAt
+		}
+	}
+	return pending, oldest, nil
+}
+
+// recordingSender records sent events; sends to keys in failKeys fail
+type recordingSender struct {
+	mu       sync.Mutex
+	sent     []*outbox.Event
+	failKeys map[string]bool
+}
+
+func (s *recordingSender) Send(_ context.Context, event *outbox.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failKeys[event.PartitionKey] {
+		return errors.New("broker unavailable")
+	}
+	s.sent = append(s.sent, event)
+	return nil
+}
+
+func (s *recordingSender) eventTypes(key string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var types []string
+	for _, event := range s.sent {
+		if event.PartitionKey == key {
+			types = append(types, event.EventType)
+		}
+	}
+	return types
+}
+
+func publish(t *testing.T, publisher *outbox.Publisher, eventType, aggregateID string) {
+	t.Helper()
+	require.NoError(t, publisher.Publish(context.Background(), commands.DomainEvent{
+		EventType:     eventType,
+		AggregateID:   aggregateID,
+		AggregateType: "Entry",
+		OccurredAt:    time.Now(),
+		Payload:       map[string]interface{}{"entry_id": aggregateID},
+	}))
+}
+
+func newTestRelay(store *memoryStore, sender *recordingSender, batchSize int) *outbox.Relay {
+	return newTestRelayWithConfig(store, sender, outbox.RelayConfig{PollInterval: 10 * time.Millisecond, BatchSize: batchSize})
+}
+
+func newTestRelayWithConfig(store *memoryStore, sender *recordingSender, config outbox.RelayConfig) *outbox.Relay {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return outbox.NewRelay(store, sender, config, logger)
+}
+
+func TestPublisher_AppendsRoutedEvent(t *testing.T) {
+	store := newMemoryStore()
+	publisher := outbox.NewPublisher(store, outbox.DefaultTopicRouter())
+	entryID := uuid.NewString()
+
+	publish(t, publisher, "EntryCreated", entryID)
+	publish(t, publisher, "ClaimConfirmed", entryID)
+	publish(t, publisher, "ClaimCancelled", entryID)
+
+	events, err := store.FetchPending(context.Background(), 10)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	created := events[0]
+	assert.Equal(t, outbox.TopicEntryCreated, created.Topic)
+	assert.Equal(t, entryID, created.PartitionKey)
+	assert.Equal(t, created.EventID.String(), created.Headers["event_id"])
+	assert.Equal(t, "EntryCreated", created.Headers["event_type"])
+
+	var payload map[string]interface{}
+	require.NoError(t, json.Unmarshal(created.Payload, &payload))
+	assert.Equal(t, entryID, payload["entry_id"])
+
+	// Events without a dedicated topic go to the domain events topic
+	assert.Equal(t, outbox.TopicDomainEvents, events[1].Topic)
+	// Cancellations go to conn-dict, which cancels the claim at Bacen
+	assert.Equal(t, outbox.TopicClaimCancelled, events[2].Topic)
+}
+
+func TestRelay_PublishesInOrderAndMarksSent(t *testing.T) {
+	store := newMemoryStore()
+	sender := &recordingSender{}
+	publisher := outbox.NewPublisher(store, nil)
+
+	publish(t, publisher, "EntryCreated", "entry-1")
+	publish(t, publisher, "EntryCreated", "entry-2")
+	publish(t, publisher, "EntryUpdated", "entry-1")
+	publish(t, publisher, "EntryDeleted", "entry-1")
+
+	relay := newTestRelay(store, sender, 3)
+	published, err := relay.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, published)
+
+	published, err = relay.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, published)
+
+	assert.Equal(t, []string{"EntryCreated", "EntryUpdated", "EntryDeleted"}, sender.eventTypes("entry-1"))
+	pending, oldest, err := store.PendingStats(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, pending)
+	assert.Nil(t, oldest)
+	assert.Equal(t, int64(4), relay.Metrics().Published())
+}
+
+func TestRelay_FailureHoldsBackSameKeyOnly(t *testing.T) {
+	store := newMemoryStore()
+	sender := &recordingSender{failKeys: map[string]bool{"entry-1": true}}
+	publisher := outbox.NewPublisher(store, nil)
+
+	publish(t, publisher, "EntryCreated", "entry-1")
+	publish(t, publisher, "EntryCreated", "entry-2")
+	publish(t, publisher, "EntryUpdated", "entry-1")
+
+	// No backoff: the retry is due on the next run
+	relay := newTestRelayWithConfig(store, sender, outbox.RelayConfig{BatchSize: 10, BackoffBase: time.Nanosecond, BackoffMax: time.Nanosecond})
+	published, err := relay.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, published)
+	assert.Equal(t, []string{"EntryCreated"}, sender.eventTypes("entry-2"))
+
+	// Only the first entry-1 event was attempted; the update waits for it
+	assert.Equal(t, "broker unavailable", store.errors[1])
+	assert.Equal(t, 1, store.rows[1].Attempts)
+	assert.Zero(t, store.rows[3].Attempts)
+	assert.Equal(t, int64(1), relay.Metrics().Failed())
+
+	// Broker back: entry-1 events go out in order
+	sender.failKeys = nil
+	published, err = relay.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, published)
+	assert.Equal(t, []string{"EntryCreated", "EntryUpdated"}, sender.eventTypes("entry-1"))
+}
+
+func TestRelay_KeyWaitingForRetryDoesNotFillTheBatch(t *testing.T) {
+	store := newMemoryStore()
+	sender := &recordingSender{failKeys: map[string]bool{"entry-1": true}}
+	publisher := outbox.NewPublisher(store, nil)
+
+	publish(t, publisher, "EntryCreated", "entry-1")
+	publish(t, publisher, "EntryUpdated", "entry-1")
+	publish(t, publisher, "EntryDeleted", "entry-1")
+	publish(t, publisher, "EntryCreated", "entry-2")
+
+	relay := newTestRelayWithConfig(store, sender, outbox.RelayConfig{BatchSize: 2, BackoffBase: time.Hour, BackoffMax: time.Hour})
+	published, err := relay.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, published)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), store.nextAttempts[1], time.Minute)
+
+	// entry-1 waits for its retry: the batch goes to entry-2
+	published, err = relay.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, published)
+	assert.Equal(t, []string{"EntryCreated"}, sender.eventTypes("entry-2"))
+	assert.Equal(t, 1, store.rows[1].Attempts)
+}
+
+func TestRelay_DeadLettersAfterMaxAttempts(t *testing.T) {
+	store := newMemoryStore()
+	sender := &recordingSender{failKeys: map[string]bool{"entry-1": true}}
+	publisher := outbox.NewPublisher(store, nil)
+
+	publish(t, publisher, "EntryCreated", "entry-1")
+	publish(t, publisher, "EntryUpdated", "entry-1")
+
+	relay := newTestRelayWithConfig(store, sender, outbox.RelayConfig{
+		BatchSize: 10, MaxAttempts: 2, BackoffBase: time.Nanosecond, BackoffMax: time.Nanosecond,
+	})
+	_, err := relay.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, store.deadLetters)
+
+	// Second failure is the last attempt: the event leaves the queue and releases its key
+	_, err = relay.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Contains(t, store.deadLetters, int64(1))
+	assert.Equal(t, 2, store.rows[1].Attempts)
+	assert.Equal(t, int64(1), relay.Metrics().DeadLettered())
+	assert.Contains(t, relay.Metrics().PrometheusExporter(), "dict_outbox_dead_lettered_total 1")
+
+	// The update was attempted right after it (and failed on its own)
+	assert.Equal(t, 1, store.rows[2].Attempts)
+	pending, _, err := store.PendingStats(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), pending, "the dead letter is not pending")
+}
+
+func TestRelay_RedactsAndPurgesPublishedEvents(t *testing.T) {
+	store := newMemoryStore()
+	sender := &recordingSender{}
+	publish(t, outbox.NewPublisher(store, nil), "EntryCreated", "entry-1")
+
+	relay := newTestRelay(store, sender, 10)
+	_, err := relay.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{}`, string(store.rows[1].Payload))
+
+	relay = newTestRelayWithConfig(store, sender, outbox.RelayConfig{PollInterval: 10 * time.Millisecond, Retention: time.Nanosecond})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	relay.Start(ctx)
+
+	require.Eventually(t, func() bool { return store.rowCount() == 0 }, time.Second, 5*time.Millisecond)
+}
+
+func TestRelay_SkipsWhenAnotherReplicaHoldsLock(t *testing.T) {
+	store := newMemoryStore()
+	store.locked = true
+	sender := &recordingSender{}
+	publish(t, outbox.NewPublisher(store, nil), "EntryCreated", "entry-1")
+
+	relay := newTestRelay(store, sender, 10)
+	published, err := relay.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, published)
+	assert.Empty(t, sender.sent)
+	assert.Contains(t, relay.Metrics().PrometheusExporter(), "dict_outbox_relay_leader 0")
+}
+
+func TestRelay_StartDrainsAndExportsLag(t *testing.T) {
+	store := newMemoryStore()
+	sender := &recordingSender{}
+	publisher := outbox.NewPublisher(store, nil)
+	for i := 0; i < 5; i++ {
+		publish(t, publisher, "EntryCreated", uuid.NewString())
+	}
+
+	relay := newTestRelay(store, sender, 2)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	relay.Start(ctx)
+
+	require.Eventually(t, func() bool { return relay.Metrics().Published() == 5 }, time.Second, 5*time.Millisecond)
+
+	output := relay.Metrics().PrometheusExporter()
+	assert.Contains(t, output, "dict_outbox_published_total 5")
+	assert.Contains(t, output, "dict_outbox_publish_lag_seconds_count 5")
+	assert.Contains(t, output, "# TYPE dict_outbox_oldest_pending_age_seconds gauge")
+	assert.Contains(t, output, "dict_outbox_relay_leader 1")
+}
@@ -0,0 +1,166 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+
+	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
+)
+
+// ConsumerConfig configures the webhook event consumer
+type ConsumerConfig struct {
+	PulsarURL           string
+	Topics              []string
+	SubscriptionName    string
+	NackRedeliveryDelay time.Duration
+	EnqueueTimeout      time.Duration
+}
+
+// DefaultConsumerConfig returns the default consumer configuration: the core-dict outbox topics
+// (entries, cancellations and domain events) and the conn-dict events topic (claim and infraction workflows)
+func DefaultConsumerConfig() ConsumerConfig {
+	return ConsumerConfig{
+		PulsarURL: "pulsar://localhost:6650",
+		Topics: []string{
+			"persistent://public/default/dict.entries.created",
+			"persistent://public/default/dict.entries.updated",
+			"persistent://public/default/dict.entries.deleted.immediate",
+			"persistent://public/default/dict.claims.cancelled",
+			"persistent://public/default/dict.portabilities.cancelled",
+			"persistent://core-dict/events/domain-events",
+			"persistent://public/default/dict-events",
+		},
+		SubscriptionName:    "core-dict-webhooks",
+		NackRedeliveryDelay: 30 * time.Second,
+		EnqueueTimeout:      10 * time.Second,
+	}
+}
+
+// Consumer reads the event topics and enqueues one delivery per subscribed endpoint.
+// Enqueueing is idempotent (endpoint, event_id), so redelivered messages are harmless.
+type Consumer struct {
+	client   pulsar.Client
+	consumer pulsar.Consumer
+	repo     repositories.WebhookRepository
+	config   ConsumerConfig
+	logger   *slog.Logger
+}
+
+// NewConsumer connects to Pulsar and subscribes to the event topics
+func NewConsumer(config ConsumerConfig, repo repositories.WebhookRepository, logger *slog.Logger) (*Consumer, error) {
+	defaults := DefaultConsumerConfig()
+	if len(config.Topics) == 0 {
+		config.Topics = defaults.Topics
+	}
+	if config.NackRedeliveryDelay <= 0 {
+		config.NackRedeliveryDelay = defaults.NackRedeliveryDelay
+	}
+	if config.EnqueueTimeout <= 0 {
+		config.EnqueueTimeout = defaults.EnqueueTimeout
+	}
+
+	client, err := pulsar.NewClient(pulsar.ClientOptions{
+		URL:               config.PulsarURL,
+		ConnectionTimeout: 5 * time.Second,
+		OperationTimeout:  30 * time.Second,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Pulsar client: %w", err)
+	}
+
+	consumer, err := client.Subscribe(pulsar.ConsumerOptions{
+		Topics:                      config.Topics,
+		SubscriptionName:            config.SubscriptionName,
+		Type:                        pulsar.Shared,
+		SubscriptionInitialPosition: pulsar.SubscriptionPositionLatest,
+		NackRedeliveryDelay:         config.NackRedeliveryDelay,
+	})
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to subscribe to webhook topics: %w", err)
+	}
+
+	return &Consumer{
+		client:   client,
+		consumer: consumer,
+		repo:     repo,
+		config:   config,
+		logger:   logger,
+	}, nil
+}
+
+// Start consumes events until the context is cancelled
+func (c *Consumer) Start(ctx context.Context) {
+	go func() {
+		for {
+			msg, err := c.consumer.Receive(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				c.logger.Error("Webhook consumer: receive failed", "error", err)
+				time.Sleep(time.Second)
+				continue
+			}
+			c.process(ctx, msg)
+		}
+	}()
+}
+
+// process enqueues the deliveries of one message: ack on success, on an ignored or invalid
+// event; nack when the deliveries could not be written
+func (c *Consumer) process(ctx context.Context, msg pulsar.Message) {
+	event, ok, err := ParseEvent(Message{
+		ID:          msg.ID().String(),
+		Key:         msg.Key(),
+		Properties:  msg.Properties(),
+		Payload:     msg.Payload(),
+		EventTime:   msg.EventTime(),
+		PublishTime: msg.PublishTime(),
+	})
+	if err != nil {
+		c.logger.Error("Webhook consumer: discarding invalid message",
+			"error", err, "topic", msg.Topic(), "message_id", msg.ID().String())
+		c.ack(msg)
+		return
+	}
+	if !ok {
+		c.ack(msg)
+		return
+	}
+
+	enqueueCtx, cancel := context.WithTimeout(ctx, c.config.EnqueueTimeout)
+	defer cancel()
+
+	enqueued, err := c.repo.Enqueue(enqueueCtx, event)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			c.logger.Warn("Webhook consumer: event will be redelivered",
+				"error", err, "event_id", event.EventID, "event_type", event.EventType)
+		}
+		c.consumer.Nack(msg)
+		return
+	}
+	if enqueued > 0 {
+		c.logger.Debug("Webhook deliveries enqueued",
+			"event_id", event.EventID, "event_type", event.EventType, "deliveries", enqueued)
+	}
+	c.ack(msg)
+}
+
+func (c *Consumer) ack(msg pulsar.Message) {
+	if err := c.consumer.Ack(msg); err != nil {
+		c.logger.Error("Webhook consumer: ack failed", "error", err, "message_id", msg.ID().String())
+	}
+}
+
+// Close closes the subscription and the client
+func (c *Consumer) Close() {
+	c.consumer.Close()
+	c.client.Close()
+}
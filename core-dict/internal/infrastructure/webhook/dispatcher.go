@@ -0,0 +1,296 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lbpay-lab/core-dict/internal/domain/entities"
+	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
+)
+
+// maxErrorBody is how much of a failed response body is kept in last_error
+const maxErrorBody = 256
+
+// DispatcherConfig configures the webhook dispatcher
+type DispatcherConfig struct {
+	PollInterval   time.Duration // Wait between polls when no delivery is due
+	BatchSize      int           // Deliveries claimed per poll
+	Concurrency    int           // Requests in flight
+	RequestTimeout time.Duration
+	// Deliveries are moved to the dead letter queue after MaxAttempts failed attempts
+	MaxAttempts int
+	// Retry n waits BackoffBase * 2^(n-1), capped at BackoffMax
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
+	// How long a claimed delivery is hidden from other replicas; must exceed RequestTimeout
+	Lease time.Duration
+}
+
+// DefaultDispatcherConfig returns the default dispatcher configuration
+// (10 attempts spread over about 3 hours)
+func DefaultDispatcherConfig() DispatcherConfig {
+	return DispatcherConfig{
+		PollInterval:   2 * time.Second,
+		BatchSize:      50,
+		Concurrency:    10,
+		RequestTimeout: 10 * time.Second,
+		MaxAttempts:    10,
+		BackoffBase:    30 * time.Second,
+		BackoffMax:     time.Hour,
+		Lease:          time.Minute,
+	}
+}
+
+// deliveryBody is the JSON posted to the endpoints
+type deliveryBody struct {
+	DeliveryID  string          `json:"delivery_id"`
+	EventID     string          `json:"event_id"`
+	EventType   string          `json:"event_type"`
+	AggregateID string          `json:"aggregate_id"`
+	OccurredAt  time.Time       `json:"occurred_at"`
+	Data        json.RawMessage `json:"data"`
+}
+
+// Dispatcher posts due deliveries to their endpoints and records the outcome: DELIVERED on a 2xx
+// response, otherwise a retry with exponential backoff and, after MaxAttempts, DEAD_LETTER.
+//
+// Delivery is at-least-once: a crash after the POST and before the outcome is stored sends the
+// delivery again once its lease expires (receivers deduplicate by X-Dict-Event-Id).
+type Dispatcher struct {
+	repo    repositories.WebhookRepository
+	client  *http.Client
+	config  DispatcherConfig
+	metrics *DispatcherMetrics
+	logger  *slog.Logger
+	now     func() time.Time
+}
+
+// NewDispatcher creates a new webhook dispatcher
+func NewDispatcher(repo repositories.WebhookRepository, config DispatcherConfig, logger *slog.Logger) *Dispatcher {
+	defaults := DefaultDispatcherConfig()
+	if config.PollInterval <= 0 {
+		config.PollInterval = defaults.PollInterval
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaults.BatchSize
+	}
+	if config.Concurrency <= 0 {
+		config.Concurrency = defaults.Concurrency
+	}
+	if config.RequestTimeout <= 0 {
+		config.RequestTimeout = defaults.RequestTimeout
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaults.MaxAttempts
+	}
+	if config.BackoffBase <= 0 {
+		config.BackoffBase = defaults.BackoffBase
+	}
+	if config.BackoffMax <= 0 {
+		config.BackoffMax = defaults.BackoffMax
+	}
+	if config.Lease <= config.RequestTimeout {
+		config.Lease = config.RequestTimeout + defaults.Lease
+	}
+
+	return &Dispatcher{
+		repo: repo,
+		client: &http.Client{
+			Timeout: config.RequestTimeout,
+			// A redirect is a failed delivery: the registered URL is the only trusted target
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		config:  config,
+		metrics: &DispatcherMetrics{},
+		logger:  logger,
+		now:     time.Now,
+	}
+}
+
+// Metrics returns the dispatcher metrics
+func (d *Dispatcher) Metrics() *DispatcherMetrics {
+	return d.metrics
+}
+
+// Start polls the delivery queue until the context is cancelled
+func (d *Dispatcher) Start(ctx context.Context) {
+	go func() {
+		timer := time.NewTimer(0)
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+			}
+
+			wait := d.config.PollInterval
+			sent, err := d.RunOnce(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				d.logger.Error("Webhook dispatcher failed", "error", err)
+			} else if sent == d.config.BatchSize {
+				// Full batch: there is probably more due
+				wait = 0
+			}
+			timer.Reset(wait)
+		}
+	}()
+}
+
+// RunOnce claims one batch of due deliveries, sends them and returns how many were attempted
+func (d *Dispatcher) RunOnce(ctx context.Context) (int, error) {
+	deliveries, err := d.repo.ClaimDue(ctx, d.config.BatchSize, d.config.Lease)
+	if err != nil {
+		return 0, fmt.Errorf("webhook dispatcher batch failed: %w", err)
+	}
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, d.config.Concurrency)
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(delivery *entities.WebhookDelivery) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			d.dispatch(ctx, delivery)
+		}(delivery)
+	}
+	wg.Wait()
+
+	return len(deliveries), nil
+}
+
+// dispatch sends one delivery and stores the outcome. A failure to store it leaves the delivery
+// leased; it is sent again when the lease expires.
+func (d *Dispatcher) dispatch(ctx context.Context, delivery *entities.WebhookDelivery) {
+	statusCode, err := d.send(ctx, delivery)
+	now := d.now()
+
+	switch {
+	case err == nil:
+		delivery.MarkDelivered(statusCode, now)
+		d.metrics.delivered.Add(1)
+	case delivery.Attempts+1 >= d.config.MaxAttempts:
+		delivery.MarkDeadLetter(statusCode, err.Error(), now)
+		d.metrics.deadLettered.Add(1)
+		d.logger.Error("Webhook delivery moved to the dead letter queue",
+			"delivery_id", delivery.ID, "endpoint_id", delivery.EndpointID,
+			"event_type", delivery.EventType, "attempts", delivery.Attempts, "error", err)
+	default:
+		delivery.MarkFailed(statusCode, err.Error(), now.Add(d.backoff(delivery.Attempts+1)), now)
+		d.metrics.failed.Add(1)
+		d.logger.Warn("Webhook delivery failed",
+			"delivery_id", delivery.ID, "endpoint_id", delivery.EndpointID,
+			"event_type", delivery.EventType, "attempts", delivery.Attempts,
+			"next_attempt_at", delivery.NextAttemptAt, "error", err)
+	}
+
+	if err := d.repo.UpdateDelivery(ctx, delivery); err != nil {
+		d.logger.Error("Failed to store webhook delivery outcome", "delivery_id", delivery.ID, "error", err)
+	}
+}
+
+// send posts the signed delivery and returns the response status (0 without a response)
+func (d *Dispatcher) send(ctx context.Context, delivery *entities.WebhookDelivery) (int, error) {
+	body, err := json.Marshal(deliveryBody{
+		DeliveryID:  delivery.ID.String(),
+		EventID:     delivery.EventID,
+		EventType:   delivery.EventType,
+		AggregateID: delivery.AggregateID,
+		OccurredAt:  delivery.OccurredAt,
+		Data:        delivery.Payload,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode webhook body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "core-dict-webhooks/1.0")
+	req.Header.Set(HeaderSignature, Sign(delivery.Endpoint.Secret, d.now(), body))
+	req.Header.Set(HeaderDeliveryID, delivery.ID.String())
+	req.Header.Set(HeaderEventID, delivery.EventID)
+	req.Header.Set(HeaderEventType, delivery.EventType)
+	req.Header.Set(HeaderAttempt, strconv.Itoa(delivery.Attempts+1))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		excerpt, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return resp.StatusCode, fmt.Errorf("endpoint responded %d: %s", resp.StatusCode, strings.TrimSpace(string(excerpt)))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
+
+// backoff returns the wait before retry number attempt (1 = first retry)
+func (d *Dispatcher) backoff(attempt int) time.Duration {
+	wait := d.config.BackoffBase
+	for i := 1; i < attempt && wait < d.config.BackoffMax; i++ {
+		wait *= 2
+	}
+	if wait > d.config.BackoffMax {
+		wait = d.config.BackoffMax
+	}
+	return wait
+}
+
+// DispatcherMetrics are the webhook dispatcher counters
+type DispatcherMetrics struct {
+	delivered    atomic.Int64
+	failed       atomic.Int64
+	deadLettered atomic.Int64
+}
+
+// Delivered returns the number of deliveries acknowledged by their endpoint since startup
+func (m *DispatcherMetrics) Delivered() int64 {
+	return m.delivered.Load()
+}
+
+// Failed returns the number of failed attempts that were rescheduled since startup
+func (m *DispatcherMetrics) Failed() int64 {
+	return m.failed.Load()
+}
+
+// DeadLettered returns the number of deliveries moved to the dead letter queue since startup
+func (m *DispatcherMetrics) DeadLettered() int64 {
+	return m.deadLettered.Load()
+}
+
+// PrometheusExporter exports the dispatcher metrics in Prometheus text format
+func (m *DispatcherMetrics) PrometheusExporter() string {
+	var b strings.Builder
+	b.WriteString("# HELP dict_webhook_delivered_total Webhook deliveries acknowledged by the endpoint\n")
+	b.WriteString("# TYPE dict_webhook_delivered_total counter\n")
+	fmt.Fprintf(&b, "dict_webhook_delivered_total %d\n", m.delivered.Load())
+	b.WriteString("# HELP dict_webhook_failed_attempts_total Failed webhook attempts rescheduled with backoff\n")
+	b.WriteString("# TYPE dict_webhook_failed_attempts_total counter\n")
+	fmt.Fprintf(&b, "dict_webhook_failed_attempts_total %d\n", m.failed.Load())
+	b.WriteString("# HELP dict_webhook_dead_lettered_total Webhook deliveries moved to the dead letter queue\n")
+	b.WriteString("# TYPE dict_webhook_dead_lettered_total counter\n")
+	fmt.Fprintf(&b, "dict_webhook_dead_lettered_total %d\n", m.deadLettered.Load())
+	return b.String()
+}
@@ -0,0 +1,193 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lbpay-lab/core-dict/internal/domain/entities"
+	"github.com/lbpay-lab/core-dict/internal/domain/repositories"
+	"github.com/lbpay-lab/core-dict/internal/infrastructure/webhook"
+)
+
+const testSecret = "whsec_test"
+
+// memoryQueue is an in-memory delivery queue: ClaimDue hands out the queued deliveries once
+type memoryQueue struct {
+	repositories.WebhookRepository
+
+	mu      sync.Mutex
+	due     []*entities.WebhookDelivery
+	updated map[uuid.UUID]*entities.WebhookDelivery
+}
+
+func newMemoryQueue(deliveries ...*entities.WebhookDelivery) *memoryQueue {
+	return &memoryQueue{
+		due:     deliveries,
+		updated: make(map[uuid.UUID]*entities.WebhookDelivery),
+	}
+}
+
+func (q *memoryQueue) ClaimDue(_ context.Context, limit int, _ time.Duration) ([]*entities.WebhookDelivery, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.due) > limit {
+		claimed := q.due[:limit]
+		q.due = q.due[limit:]
+		return claimed, nil
+	}
+	claimed := q.due
+	q.due = nil
+	return claimed, nil
+}
+
+func (q *memoryQueue) UpdateDelivery(_ context.Context, delivery *entities.WebhookDelivery) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	c := *delivery
+	q.updated[delivery.ID] = &c
+	return nil
+}
+
+func newDelivery(url string, attempts int) *entities.WebhookDelivery {
+	return &entities.WebhookDelivery{
+		ID:          uuid.New(),
+		EndpointID:  uuid.New(),
+		Endpoint:    &entities.WebhookEndpoint{URL: url, Secret: testSecret, Active: true},
+		EventID:     uuid.NewString(),
+		EventType:   "claim.received",
+		AggregateID: "claim-1",
+		Payload:     []byte(`{"claim_id":"claim-1","entry_key":"+5511999999999"}`),
+		OccurredAt:  time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC),
+		Status:      entities.WebhookDeliveryPending,
+		Attempts:    attempts,
+	}
+}
+
+func newTestDispatcher(queue *memoryQueue) *webhook.Dispatcher {
+	return webhook.NewDispatcher(queue, webhook.DispatcherConfig{
+		BatchSize:   10,
+		MaxAttempts: 3,
+		BackoffBase: time.Minute,
+		BackoffMax:  10 * time.Minute,
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestDispatcher_DeliversSignedRequest(t *testing.T) {
+	var received *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	delivery := newDelivery(server.URL, 0)
+	queue := newMemoryQueue(delivery)
+	dispatcher := newTestDispatcher(queue)
+
+	sent, err := dispatcher.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+
+	require.NotNil(t, received)
+	assert.Equal(t, delivery.ID.String(), received.Header.Get(webhook.HeaderDeliveryID))
+	assert.Equal(t, delivery.EventID, received.Header.Get(webhook.HeaderEventID))
+	assert.Equal(t, "claim.received", received.Header.Get(webhook.HeaderEventType))
+	assert.Equal(t, "1", received.Header.Get(webhook.HeaderAttempt))
+	assert.NoError(t, webhook.VerifySignature(testSecret, received.Header.Get(webhook.HeaderSignature), body, time.Now(), time.Minute))
+	assert.ErrorIs(t, webhook.VerifySignature("other-secret", received.Header.Get(webhook.HeaderSignature), body, time.Now(), time.Minute), webhook.ErrInvalidSignature)
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(body, &decoded))
+	assert.Equal(t, delivery.EventID, decoded["event_id"])
+	assert.Equal(t, "claim-1", decoded["aggregate_id"])
+	assert.Equal(t, map[string]interface{}{"claim_id": "claim-1", "entry_key": "+5511999999999"}, decoded["data"])
+
+	updated := queue.updated[delivery.ID]
+	require.NotNil(t, updated)
+	assert.Equal(t, entities.WebhookDeliveryDelivered, updated.Status)
+	assert.Equal(t, 1, updated.Attempts)
+	assert.Equal(t, http.StatusNoContent, updated.LastStatusCode)
+	assert.NotNil(t, updated.DeliveredAt)
+	assert.Equal(t, int64(1), dispatcher.Metrics().Delivered())
+}
+
+func TestDispatcher_FailureSchedulesRetryWithBackoff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "database unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	first := newDelivery(server.URL, 0)
+	second := newDelivery(server.URL, 1)
+	queue := newMemoryQueue(first, second)
+	dispatcher := newTestDispatcher(queue)
+
+	before := time.Now()
+	_, err := dispatcher.RunOnce(context.Background())
+	require.NoError(t, err)
+
+	updated := queue.updated[first.ID]
+	assert.Equal(t, entities.WebhookDeliveryPending, updated.Status)
+	assert.Equal(t, 1, updated.Attempts)
+	assert.Equal(t, http.StatusServiceUnavailable, updated.LastStatusCode)
+	assert.Contains(t, updated.LastError, "database unavailable")
+	assert.WithinDuration(t, before.Add(time.Minute), updated.NextAttemptAt, 5*time.Second)
+
+	// Second retry waits twice as long
+	updated = queue.updated[second.ID]
+	assert.Equal(t, 2, updated.Attempts)
+	assert.WithinDuration(t, before.Add(2*time.Minute), updated.NextAttemptAt, 5*time.Second)
+	assert.Equal(t, int64(2), dispatcher.Metrics().Failed())
+}
+
+func TestDispatcher_LastAttemptMovesToDeadLetter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	delivery := newDelivery(server.URL, 2) // MaxAttempts = 3
+	queue := newMemoryQueue(delivery)
+	dispatcher := newTestDispatcher(queue)
+
+	_, err := dispatcher.RunOnce(context.Background())
+	require.NoError(t, err)
+
+	updated := queue.updated[delivery.ID]
+	assert.Equal(t, entities.WebhookDeliveryDeadLetter, updated.Status)
+	assert.Equal(t, 3, updated.Attempts)
+	assert.Equal(t, http.StatusBadRequest, updated.LastStatusCode)
+	assert.Equal(t, int64(1), dispatcher.Metrics().DeadLettered())
+}
+
+func TestDispatcher_RedirectIsAFailure(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("redirect must not be followed")
+	}))
+	defer target.Close()
+	server := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusFound))
+	defer server.Close()
+
+	delivery := newDelivery(server.URL, 0)
+	queue := newMemoryQueue(delivery)
+
+	_, err := newTestDispatcher(queue).RunOnce(context.Background())
+	require.NoError(t, err)
+
+	updated := queue.updated[delivery.ID]
+	assert.Equal(t, entities.WebhookDeliveryPending, updated.Status)
+	assert.Equal(t, http.StatusFound, updated.LastStatusCode)
+}
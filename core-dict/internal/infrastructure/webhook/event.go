@@ -0,0 +1,92 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lbpay-lab/core-dict/internal/domain/entities"
+)
+
+// ErrInvalidMessage marks a message that can never be enqueued (payload is not JSON);
+// it is acknowledged instead of redelivered
+var ErrInvalidMessage = errors.New("invalid webhook source message")
+
+// Message is the part of a Pulsar message used to build a webhook event
+type Message struct {
+	ID          string // Pulsar message ID, fallback event id
+	Key         string
+	Properties  map[string]string
+	Payload     []byte
+	EventTime   time.Time
+	PublishTime time.Time
+}
+
+// messagePayload holds the fields read from the payload when the properties do not carry them
+// (conn-dict producer publishes the event type inside the JSON)
+type messagePayload struct {
+	EventID   string `json:"event_id"`
+	EventType string `json:"event_type"`
+}
+
+// ParseEvent builds the webhook event of a message. Both event sources are supported:
+//   - core-dict outbox: event_id, event_type (ClaimCreated), aggregate_id and occurred_at properties
+//   - conn-dict: event_type (claim_status_changed) in the properties or in the payload, event_time property
+//
+// ok is false for events outside the webhook catalogue.
+func ParseEvent(msg Message) (event *entities.WebhookEvent, ok bool, err error) {
+	if !json.Valid(msg.Payload) {
+		return nil, false, fmt.Errorf("%w: payload is not JSON", ErrInvalidMessage)
+	}
+
+	eventID := msg.Properties["event_id"]
+	rawType := msg.Properties["event_type"]
+	if eventID == "" || rawType == "" {
+		var payload messagePayload
+		// Payloads that are not objects simply carry no event fields
+		_ = json.Unmarshal(msg.Payload, &payload)
+		if eventID == "" {
+			eventID = payload.EventID
+		}
+		if rawType == "" {
+			rawType = payload.EventType
+		}
+	}
+
+	eventType, ok := entities.NormalizeWebhookEventType(rawType)
+	if !ok {
+		return nil, false, nil
+	}
+
+	if eventID == "" {
+		eventID = msg.ID
+	}
+	aggregateID := msg.Properties["aggregate_id"]
+	if aggregateID == "" {
+		aggregateID = msg.Key
+	}
+
+	return &entities.WebhookEvent{
+		EventID:     eventID,
+		EventType:   eventType,
+		AggregateID: aggregateID,
+		OccurredAt:  occurredAt(msg),
+		Payload:     msg.Payload,
+	}, true, nil
+}
+
+// occurredAt returns the event time from the properties, the message event time or the publish time
+func occurredAt(msg Message) time.Time {
+	for _, property := range []string{"occurred_at", "event_time"} {
+		if value := msg.Properties[property]; value != "" {
+			if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+				return t
+			}
+		}
+	}
+	if !msg.EventTime.IsZero() {
+		return msg.EventTime
+	}
+	return msg.PublishTime
+}
@@ -0,0 +1,70 @@
+package webhook_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lbpay-lab/core-dict/internal/infrastructure/webhook"
+)
+
+func TestParseEvent_OutboxMessage(t *testing.T) {
+	occurredAt := time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)
+	event, ok, err := webhook.ParseEvent(webhook.Message{
+		ID:  "1:2:0",
+		Key: "claim-1",
+		Properties: map[string]string{
+			"event_id":     "6d1f4a52-3c0e-4b4e-9a55-1f0c6f3b7a10",
+			"event_type":   "ClaimCreated",
+			"aggregate_id": "claim-1",
+			"occurred_at":  occurredAt.Format(time.RFC3339Nano),
+		},
+		Payload: []byte(`{"claim_id":"claim-1"}`),
+	})
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	assert.Equal(t, "6d1f4a52-3c0e-4b4e-9a55-1f0c6f3b7a10", event.EventID)
+	assert.Equal(t, "claim.created", event.EventType)
+	assert.Equal(t, "claim-1", event.AggregateID)
+	assert.True(t, occurredAt.Equal(event.OccurredAt))
+}
+
+func TestParseEvent_ConnDictMessage(t *testing.T) {
+	publishTime := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+	event, ok, err := webhook.ParseEvent(webhook.Message{
+		ID:          "5:7:0",
+		Key:         "infraction-9",
+		Properties:  map[string]string{"producer": "conn-dict"},
+		Payload:     []byte(`{"event_type":"infraction_under_investigation","infraction_id":"infraction-9"}`),
+		PublishTime: publishTime,
+	})
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	assert.Equal(t, "infraction.under_investigation", event.EventType)
+	assert.Equal(t, "5:7:0", event.EventID, "message ID is the fallback event id")
+	assert.Equal(t, "infraction-9", event.AggregateID)
+	assert.True(t, publishTime.Equal(event.OccurredAt))
+}
+
+func TestParseEvent_IgnoresEventsOutsideCatalogue(t *testing.T) {
+	for _, eventType := range []string{"PortabilityCancelled", "vsync_completed", "donor_notified", ""} {
+		_, ok, err := webhook.ParseEvent(webhook.Message{
+			Properties: map[string]string{"event_type": eventType},
+			Payload:    []byte(`{}`),
+		})
+		assert.NoError(t, err, eventType)
+		assert.False(t, ok, eventType)
+	}
+}
+
+func TestParseEvent_InvalidPayload(t *testing.T) {
+	_, _, err := webhook.ParseEvent(webhook.Message{
+		Properties: map[string]string{"event_type": "EntryCreated"},
+		Payload:    []byte("not json"),
+	})
+	assert.ErrorIs(t, err, webhook.ErrInvalidMessage)
+}
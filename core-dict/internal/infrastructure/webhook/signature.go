@@ -0,0 +1,72 @@
+// Package webhook delivers claim, entry and infraction events to the endpoints registered by the
+// banking backends (signed HTTP callbacks with retries and a dead letter queue)
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Request headers of a webhook delivery
+const (
+	HeaderSignature  = "X-Dict-Signature"   // t=<unix seconds>,v1=<hex HMAC-SHA256>
+	HeaderDeliveryID = "X-Dict-Delivery-Id" // Same value on every attempt of the delivery
+	HeaderEventID    = "X-Dict-Event-Id"    // Receivers deduplicate by this id
+	HeaderEventType  = "X-Dict-Event-Type"
+	HeaderAttempt    = "X-Dict-Attempt"
+)
+
+// ErrInvalidSignature is returned by VerifySignature when the header does not match the body
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// Sign returns the X-Dict-Signature header value: HMAC-SHA256 with the endpoint secret over
+// "<timestamp>.<body>". The timestamp lets receivers reject replayed requests.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	return "t=" + ts + ",v1=" + computeSignature(secret, ts, body)
+}
+
+// VerifySignature checks a signature header produced by Sign, rejecting timestamps further than
+// tolerance from now. Reference implementation for the receivers.
+func VerifySignature(secret, header string, body []byte, now time.Time, tolerance time.Duration) error {
+	var ts, signature string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			ts = value
+		case "v1":
+			signature = value
+		}
+	}
+	if ts == "" || signature == "" {
+		return fmt.Errorf("%w: malformed header", ErrInvalidSignature)
+	}
+
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: malformed timestamp", ErrInvalidSignature)
+	}
+	if age := now.Sub(time.Unix(unix, 0)); age > tolerance || age < -tolerance {
+		return fmt.Errorf("%w: timestamp outside tolerance", ErrInvalidSignature)
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(computeSignature(secret, ts, body))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func computeSignature(secret, ts string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
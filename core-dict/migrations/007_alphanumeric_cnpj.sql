@@ -0,0 +1,47 @@
+-- Migration: 007_alphanumeric_cnpj
+-- Description: Accept alphanumeric CNPJ (IN RFB 2.229/2024) in CNPJ keys and holder documents
+-- Author: data-specialist-core
+-- Date: 2026-10-18
+
+-- +goose Up
+-- +goose StatementBegin
+
+-- CNPJ: 12 positions [0-9A-Z] + 2 numeric check digits (numeric CNPJ is a subset)
+ALTER TABLE core_dict.accounts DROP CONSTRAINT chk_holder_document_format;
+ALTER TABLE core_dict.accounts ADD CONSTRAINT chk_holder_document_format CHECK (
+    (holder_document_type = 'CPF' AND holder_document ~ '^[0-9]{11}$') OR
+    (holder_document_type = 'CNPJ' AND holder_document ~ '^[0-9A-Z]{12}[0-9]{2}$')
+);
+
+ALTER TABLE core_dict.dict_entries DROP CONSTRAINT chk_key_value_format;
+ALTER TABLE core_dict.dict_entries ADD CONSTRAINT chk_key_value_format CHECK (
+    (key_type = 'CPF' AND key_value ~ '^[0-9]{11}$') OR
+    (key_type = 'CNPJ' AND key_value ~ '^[0-9A-Z]{12}[0-9]{2}$') OR
+    (key_type = 'EMAIL' AND key_value ~ '^[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Z|a-z]{2,}$') OR
+    (key_type = 'PHONE' AND key_value ~ '^\+55[1-9]{2}9?[0-9]{8}$') OR
+    (key_type = 'EVP' AND LENGTH(key_value) = 36)
+);
+
+COMMENT ON COLUMN core_dict.accounts.holder_document IS 'CPF (11 digits) or CNPJ (14 chars, numeric or alphanumeric)';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE core_dict.dict_entries DROP CONSTRAINT chk_key_value_format;
+ALTER TABLE core_dict.dict_entries ADD CONSTRAINT chk_key_value_format CHECK (
+    (key_type = 'CPF' AND LENGTH(key_value) = 11) OR
+    (key_type = 'CNPJ' AND LENGTH(key_value) = 14) OR
+    (key_type = 'EMAIL' AND key_value ~ '^[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Z|a-z]{2,}$') OR
+    (key_type = 'PHONE' AND key_value ~ '^\+55[1-9]{2}9?[0-9]{8}$') OR
+    (key_type = 'EVP' AND LENGTH(key_value) = 36)
+);
+
+ALTER TABLE core_dict.accounts DROP CONSTRAINT chk_holder_document_format;
+ALTER TABLE core_dict.accounts ADD CONSTRAINT chk_holder_document_format CHECK (
+    (holder_document_type = 'CPF' AND LENGTH(holder_document) = 11) OR
+    (holder_document_type = 'CNPJ' AND LENGTH(holder_document) = 14)
+);
+
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- Migration: 008_entry_blocked_status
+-- Description: Allow BLOCKED status on dict_entries (BlockKey/UnblockKey RPCs)
+-- Author: data-specialist-core
+-- Date: 2026-10-18
+
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE core_dict.dict_entries DROP CONSTRAINT IF EXISTS dict_entries_status_check;
+ALTER TABLE core_dict.dict_entries ADD CONSTRAINT dict_entries_status_check CHECK (
+    status IN ('PENDING', 'ACTIVE', 'BLOCKED', 'PORTABILITY_REQUESTED',
+               'OWNERSHIP_CONFIRMED', 'DELETED', 'CLAIM_PENDING')
+);
+
+COMMENT ON COLUMN core_dict.dict_entries.status IS 'BLOCKED = judicial/fraud/infraction block (cannot receive PIX); see audit.entry_events for reason';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- Intentionally a no-op. The Up migration changes no rows, and every BLOCKED entry was blocked
+-- later by BlockKey under a judicial, fraud or infraction order; setting them back to ACTIVE
+-- would lift those blocks without an audit event. The constraint keeps accepting BLOCKED, which
+-- older binaries never write.
+SELECT 1;
+
+-- +goose StatementEnd
@@ -0,0 +1,65 @@
+-- Migration: 009_statistics_materialized_views
+-- Description: Materialized views backing GetStatistics RPC and Prometheus statistics exporter
+-- Author: data-specialist-core
+-- Date: 2026-10-18
+
+-- +goose Up
+-- +goose StatementBegin
+
+-- Keys aggregated by participant/branch/type/status/creation day
+CREATE MATERIALIZED VIEW core_dict.mv_entry_statistics AS
+SELECT
+    participant_ispb,
+    COALESCE(participant_branch, '')        AS participant_branch,
+    key_type,
+    status,
+    (created_at AT TIME ZONE 'UTC')::date   AS created_day,
+    COUNT(*)::bigint                        AS key_count
+FROM core_dict.dict_entries
+GROUP BY 1, 2, 3, 4, 5
+WITH DATA;
+
+-- Unique index required by REFRESH MATERIALIZED VIEW CONCURRENTLY
+CREATE UNIQUE INDEX idx_mv_entry_statistics_pk
+    ON core_dict.mv_entry_statistics (participant_ispb, participant_branch, key_type, status, created_day);
+CREATE INDEX idx_mv_entry_statistics_day ON core_dict.mv_entry_statistics (created_day);
+
+-- Claims aggregated by donor participant/type/status/creation day
+CREATE MATERIALIZED VIEW core_dict.mv_claim_statistics AS
+SELECT
+    owner_ispb                              AS participant_ispb,
+    claim_type,
+    status,
+    (created_at AT TIME ZONE 'UTC')::date   AS created_day,
+    COUNT(*)::bigint                        AS claim_count
+FROM core_dict.claims
+WHERE deleted_at IS NULL
+GROUP BY 1, 2, 3, 4
+WITH DATA;
+
+CREATE UNIQUE INDEX idx_mv_claim_statistics_pk
+    ON core_dict.mv_claim_statistics (participant_ispb, claim_type, status, created_day);
+CREATE INDEX idx_mv_claim_statistics_day ON core_dict.mv_claim_statistics (created_day);
+
+-- Last refresh bookkeeping (single row)
+CREATE TABLE core_dict.statistics_refresh (
+    id              SMALLINT PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+    refreshed_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    duration_ms     BIGINT NOT NULL DEFAULT 0
+);
+INSERT INTO core_dict.statistics_refresh (id, refreshed_at) VALUES (1, NOW());
+
+COMMENT ON MATERIALIZED VIEW core_dict.mv_entry_statistics IS 'Key counters per ISPB/branch/type/status/day (refreshed by StatisticsRefresher)';
+COMMENT ON MATERIALIZED VIEW core_dict.mv_claim_statistics IS 'Claim counters per donor ISPB/type/status/day (refreshed by StatisticsRefresher)';
+COMMENT ON TABLE core_dict.statistics_refresh IS 'Timestamp of the last statistics materialized view refresh';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS core_dict.statistics_refresh;
+DROP MATERIALIZED VIEW IF EXISTS core_dict.mv_claim_statistics;
+DROP MATERIALIZED VIEW IF EXISTS core_dict.mv_entry_statistics;
+
+-- +goose StatementEnd
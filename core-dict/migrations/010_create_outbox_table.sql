@@ -0,0 +1,42 @@
+-- Migration: 010_create_outbox_table
+-- Description: Transactional outbox for domain events (written with the aggregate, published by the outbox relay)
+-- Author: data-specialist-core
+-- Date: 2026-10-18
+
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE core_dict.outbox_events (
+    -- Sequence defines the publishing order (per partition key)
+    id              BIGSERIAL PRIMARY KEY,
+    event_id        UUID NOT NULL UNIQUE,
+    event_type      VARCHAR(100) NOT NULL,
+    aggregate_type  VARCHAR(50) NOT NULL,
+    aggregate_id    VARCHAR(100) NOT NULL,
+    -- Pulsar message key (ordering unit), usually the aggregate id
+    partition_key   VARCHAR(255) NOT NULL,
+    topic           VARCHAR(255) NOT NULL,
+    payload         JSONB NOT NULL,
+    headers         JSONB NOT NULL DEFAULT '{}'::jsonb,
+    occurred_at     TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    sent_at         TIMESTAMP WITH TIME ZONE,
+    attempts        INTEGER NOT NULL DEFAULT 0,
+    last_error      TEXT
+);
+
+-- Relay scan: pending events in order
+CREATE INDEX idx_outbox_events_pending ON core_dict.outbox_events (id) WHERE sent_at IS NULL;
+-- Cleanup of published events
+CREATE INDEX idx_outbox_events_sent_at ON core_dict.outbox_events (sent_at) WHERE sent_at IS NOT NULL;
+
+COMMENT ON TABLE core_dict.outbox_events IS 'Domain events written in the same transaction as the aggregate; published to Pulsar by the outbox relay';
+COMMENT ON COLUMN core_dict.outbox_events.partition_key IS 'Pulsar message key; events with the same key are published in id order';
+COMMENT ON COLUMN core_dict.outbox_events.sent_at IS 'Set once the event is acknowledged by Pulsar (NULL = pending)';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS core_dict.outbox_events;
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- Migration: 011_create_idempotency_keys
+-- Description: Idempotency records of mutating gRPC requests (request_id → fingerprint, status and response)
+-- Author: data-specialist-core
+-- Date: 2026-10-18
+
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE core_dict.idempotency_keys (
+    -- Client request_id (x-request-id metadata)
+    idempotency_key VARCHAR(128) PRIMARY KEY,
+    method          VARCHAR(255) NOT NULL,
+    -- SHA-256 (hex) of method, caller and request payload
+    fingerprint     CHAR(64) NOT NULL,
+    status          VARCHAR(20) NOT NULL,
+    -- Serialized google.protobuf.Any (response message or google.rpc.Status)
+    response        BYTEA,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    CONSTRAINT chk_idempotency_keys_status CHECK (status IN ('IN_PROGRESS', 'COMPLETED'))
+);
+
+-- Cleanup of expired records
+CREATE INDEX idx_idempotency_keys_expires_at ON core_dict.idempotency_keys (expires_at);
+
+COMMENT ON TABLE core_dict.idempotency_keys IS 'Outcome of mutating gRPC requests by request_id; retries replay the stored response';
+COMMENT ON COLUMN core_dict.idempotency_keys.expires_at IS 'IN_PROGRESS: lock deadline (a crashed request frees the key); COMPLETED: end of the replay window';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS core_dict.idempotency_keys;
+-- +goose StatementEnd
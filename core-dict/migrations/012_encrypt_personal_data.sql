@@ -0,0 +1,117 @@
+-- Migration: 012_encrypt_personal_data
+-- Description: Envelope encryption and HMAC blind indexes for CPF/EMAIL/PHONE key values and account holder data (LGPD)
+-- Author: data-specialist-core
+-- Date: 2026-10-18
+--
+-- New rows are written encrypted by the application. Existing rows keep their plaintext until
+-- the re-encryption job (FieldReencryptor) migrates them: it fills the *_encrypted and *_index
+-- columns and clears holder_name, holder_document, key_value (CPF/EMAIL/PHONE) and key_hash.
+-- Lookups fall back to the plaintext columns while a row has no blind index yet.
+
+-- +goose Up
+-- +goose StatementBegin
+
+-- ============================================================================
+-- DICT_ENTRIES
+-- ============================================================================
+
+ALTER TABLE core_dict.dict_entries
+    ADD COLUMN key_value_encrypted BYTEA,
+    ADD COLUMN key_value_index     CHAR(64),
+    ADD COLUMN encryption_key_id   VARCHAR(255);
+
+-- key_value stays in plaintext only for CNPJ and EVP keys; key_hash (unkeyed SHA-256) is
+-- replaced by key_value_index
+ALTER TABLE core_dict.dict_entries ALTER COLUMN key_value DROP NOT NULL;
+ALTER TABLE core_dict.dict_entries ALTER COLUMN key_hash DROP NOT NULL;
+
+-- Uniqueness moves to the blind index (plaintext rows keep the previous rule)
+ALTER TABLE core_dict.dict_entries DROP CONSTRAINT unique_active_key;
+CREATE UNIQUE INDEX uq_entries_key_value_index
+    ON core_dict.dict_entries (key_value_index, deleted_at) NULLS NOT DISTINCT
+    WHERE key_value_index IS NOT NULL;
+CREATE UNIQUE INDEX uq_entries_key_value_plaintext
+    ON core_dict.dict_entries (key_type, key_value, deleted_at) NULLS NOT DISTINCT
+    WHERE key_value IS NOT NULL;
+
+DROP INDEX IF EXISTS core_dict.idx_entries_key_type_value;
+
+COMMENT ON COLUMN core_dict.dict_entries.key_value IS 'Plaintext key value: CNPJ and EVP keys only (CPF/EMAIL/PHONE are in key_value_encrypted)';
+COMMENT ON COLUMN core_dict.dict_entries.key_hash IS 'Legacy SHA-256 of key_value, cleared by the re-encryption job (replaced by key_value_index)';
+COMMENT ON COLUMN core_dict.dict_entries.key_value_encrypted IS 'Envelope-encrypted CPF/EMAIL/PHONE key value (AES-256-GCM, DEK wrapped by the KMS)';
+COMMENT ON COLUMN core_dict.dict_entries.key_value_index IS 'Blind index (HMAC-SHA256) of the key value, for equality lookups';
+COMMENT ON COLUMN core_dict.dict_entries.encryption_key_id IS 'KMS key version (KEK) of key_value_encrypted';
+
+-- ============================================================================
+-- ACCOUNTS
+-- ============================================================================
+
+ALTER TABLE core_dict.accounts
+    ADD COLUMN holder_document_encrypted BYTEA,
+    ADD COLUMN holder_document_index     CHAR(64),
+    ADD COLUMN encryption_key_id         VARCHAR(255);
+
+ALTER TABLE core_dict.accounts ALTER COLUMN holder_name DROP NOT NULL;
+ALTER TABLE core_dict.accounts ALTER COLUMN holder_document DROP NOT NULL;
+
+DROP INDEX IF EXISTS core_dict.idx_accounts_holder_document;
+DROP INDEX IF EXISTS core_dict.idx_accounts_holder_name_trgm;
+
+CREATE INDEX idx_accounts_holder_document_index
+    ON core_dict.accounts (holder_document_index)
+    WHERE deleted_at IS NULL;
+
+-- Plaintext lookups of accounts not yet migrated (empty once the job has run)
+CREATE INDEX idx_accounts_holder_document_legacy
+    ON core_dict.accounts (holder_document)
+    WHERE holder_document_index IS NULL;
+
+COMMENT ON COLUMN core_dict.accounts.holder_name_encrypted IS 'Envelope-encrypted holder name (AES-256-GCM, DEK wrapped by the KMS)';
+COMMENT ON COLUMN core_dict.accounts.holder_document_encrypted IS 'Envelope-encrypted holder CPF/CNPJ';
+COMMENT ON COLUMN core_dict.accounts.holder_document_index IS 'Blind index (HMAC-SHA256) of the holder CPF/CNPJ, for equality lookups';
+COMMENT ON COLUMN core_dict.accounts.encryption_key_id IS 'Oldest KMS key version (KEK) among the encrypted columns of the row';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- The encrypted values cannot be decrypted in SQL: rolling back after the re-encryption job
+-- has run loses the migrated personal data. Restore from backup instead.
+
+DROP INDEX IF EXISTS core_dict.idx_accounts_holder_document_legacy;
+DROP INDEX IF EXISTS core_dict.idx_accounts_holder_document_index;
+
+ALTER TABLE core_dict.accounts
+    DROP COLUMN IF EXISTS encryption_key_id,
+    DROP COLUMN IF EXISTS holder_document_index,
+    DROP COLUMN IF EXISTS holder_document_encrypted;
+
+ALTER TABLE core_dict.accounts ALTER COLUMN holder_document SET NOT NULL;
+ALTER TABLE core_dict.accounts ALTER COLUMN holder_name SET NOT NULL;
+
+CREATE INDEX idx_accounts_holder_document
+    ON core_dict.accounts (holder_document_type, holder_document)
+    WHERE deleted_at IS NULL;
+CREATE INDEX idx_accounts_holder_name_trgm
+    ON core_dict.accounts USING gin (holder_name gin_trgm_ops);
+
+DROP INDEX IF EXISTS core_dict.uq_entries_key_value_plaintext;
+DROP INDEX IF EXISTS core_dict.uq_entries_key_value_index;
+
+ALTER TABLE core_dict.dict_entries
+    DROP COLUMN IF EXISTS encryption_key_id,
+    DROP COLUMN IF EXISTS key_value_index,
+    DROP COLUMN IF EXISTS key_value_encrypted;
+
+ALTER TABLE core_dict.dict_entries ALTER COLUMN key_hash SET NOT NULL;
+ALTER TABLE core_dict.dict_entries ALTER COLUMN key_value SET NOT NULL;
+
+ALTER TABLE core_dict.dict_entries
+    ADD CONSTRAINT unique_active_key UNIQUE NULLS NOT DISTINCT (key_type, key_value, deleted_at);
+
+CREATE INDEX idx_entries_key_type_value
+    ON core_dict.dict_entries (key_type, key_value)
+    WHERE deleted_at IS NULL;
+
+-- +goose StatementEnd
@@ -0,0 +1,103 @@
+-- Migration: 013_audit_row_values_suppression
+-- Description: Let LGPD erasures skip the row snapshots of the audit trigger
+-- Author: data-specialist-core
+-- Date: 2026-10-18
+--
+-- audit_entry_changes() stores row_to_json(OLD/NEW) for every change of entries, claims,
+-- portabilities and accounts. When a data subject request pseudonymises a row, copying the old
+-- row into audit.entry_events would keep the very data being erased. The erasure transaction
+-- sets core_dict.audit_suppress_row_values = 'on' (SET LOCAL): the change is still audited, but
+-- without the old/new values.
+--
+-- Events recorded before the erasure still hold the old/new values of the rows. audit.entry_events
+-- is append-only and hash-chained (014), so they are not changed: the erasure reports them as
+-- RETAINED for the legal retention period and the erasure is not complete while they exist.
+
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE OR REPLACE FUNCTION audit_entry_changes()
+RETURNS TRIGGER AS $$
+DECLARE
+    event_type_val VARCHAR(100);
+    suppress_values BOOLEAN;
+BEGIN
+    -- Determine event type
+    IF TG_OP = 'INSERT' THEN
+        event_type_val := 'CREATED';
+    ELSIF TG_OP = 'UPDATE' THEN
+        event_type_val := 'UPDATED';
+    ELSIF TG_OP = 'DELETE' THEN
+        event_type_val := 'DELETED';
+    END IF;
+
+    suppress_values := COALESCE(current_setting('core_dict.audit_suppress_row_values', true), '') = 'on';
+
+    -- Insert audit event
+    INSERT INTO audit.entry_events (
+        entity_type,
+        entity_id,
+        event_type,
+        old_values,
+        new_values,
+        user_id,
+        occurred_at,
+        metadata
+    ) VALUES (
+        TG_TABLE_NAME,
+        COALESCE(NEW.id, OLD.id),
+        event_type_val,
+        CASE WHEN TG_OP != 'INSERT' AND NOT suppress_values THEN row_to_json(OLD) ELSE NULL END,
+        CASE WHEN TG_OP != 'DELETE' AND NOT suppress_values THEN row_to_json(NEW) ELSE NULL END,
+        COALESCE(NEW.updated_by, NEW.created_by, OLD.updated_by),
+        NOW(),
+        CASE WHEN suppress_values THEN '{"row_values_suppressed": true}'::jsonb ELSE NULL END
+    );
+
+    RETURN COALESCE(NEW, OLD);
+END;
+$$ LANGUAGE plpgsql;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+CREATE OR REPLACE FUNCTION audit_entry_changes()
+RETURNS TRIGGER AS $$
+DECLARE
+    event_type_val VARCHAR(100);
+BEGIN
+    -- Determine event type
+    IF TG_OP = 'INSERT' THEN
+        event_type_val := 'CREATED';
+    ELSIF TG_OP = 'UPDATE' THEN
+        event_type_val := 'UPDATED';
+    ELSIF TG_OP = 'DELETE' THEN
+        event_type_val := 'DELETED';
+    END IF;
+
+    -- Insert audit event
+    INSERT INTO audit.entry_events (
+        entity_type,
+        entity_id,
+        event_type,
+        old_values,
+        new_values,
+        user_id,
+        occurred_at
+    ) VALUES (
+        TG_TABLE_NAME,
+        COALESCE(NEW.id, OLD.id),
+        event_type_val,
+        CASE WHEN TG_OP != 'INSERT' THEN row_to_json(OLD) ELSE NULL END,
+        CASE WHEN TG_OP != 'DELETE' THEN row_to_json(NEW) ELSE NULL END,
+        COALESCE(NEW.updated_by, NEW.created_by, OLD.updated_by),
+        NOW()
+    );
+
+    RETURN COALESCE(NEW, OLD);
+END;
+$$ LANGUAGE plpgsql;
+
+-- +goose StatementEnd
@@ -0,0 +1,232 @@
+-- Migration: 014_audit_hash_chain
+-- Description: Tamper-evident hash chain and signed checkpoints for audit.entry_events
+-- Author: data-specialist-core
+-- Date: 2026-10-18
+--
+-- Every event is linked to the previous event of its chain: one chain per month of occurred_at
+-- (UTC, the partitioning unit) and stream (entity_type). The insert trigger only tags the event
+-- with its chain; audit.link_events(), run by AuditChainLinker, links the committed events
+-- afterwards, assigning the sequence number, the previous hash and the event hash, so events
+-- written by the application and by audit_entry_changes() are chained alike. A rolled back
+-- transaction never reaches the linker and leaves no gap.
+--
+-- Linking after commit keeps the chain off the write path: audited transactions take no lock
+-- shared with other writers and do not wait for each other. Only one linker runs at a time
+-- (a transaction-scoped advisory lock, the other replicas skip the round); it links in batches
+-- in occurred_at order, so an event committed late is linked after the events already linked.
+-- Until it is linked an event is protected only by the append-only trigger, which lets the
+-- linker fill in the chain columns of an unlinked event and nothing else.
+--
+-- hash = sha256(payload) where payload concatenates "<octet length>:<value>" of:
+--   chain_partition, stream, chain_seq, prev_hash, event_id, entity_type, entity_id, event_type,
+--   event_subtype, user_id, old_values, new_values, diff, metadata, ip_address, user_agent,
+--   occurred_at (UTC, microseconds)
+-- with NULL as the empty string. AuditChainLink.ComputeHash (Go) recomputes it independently of
+-- this function during verification. The application signs the chain heads periodically
+-- (audit.chain_checkpoints) with a key the database does not hold.
+--
+-- Events inserted before this migration have no chain columns and are not verified.
+
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE audit.entry_events
+    ADD COLUMN chain_partition VARCHAR(7),
+    ADD COLUMN stream          VARCHAR(50),
+    ADD COLUMN chain_seq       BIGINT,
+    ADD COLUMN prev_hash       CHAR(64),
+    ADD COLUMN hash            CHAR(64);
+
+CREATE INDEX idx_entry_events_chain
+    ON audit.entry_events (chain_partition, stream, chain_seq);
+
+CREATE INDEX idx_entry_events_unlinked
+    ON audit.entry_events (occurred_at, id)
+    WHERE hash IS NULL AND chain_partition IS NOT NULL;
+
+COMMENT ON COLUMN audit.entry_events.chain_partition IS 'Hash chain month (YYYY-MM of occurred_at in UTC)';
+COMMENT ON COLUMN audit.entry_events.stream IS 'Hash chain stream (entity_type)';
+COMMENT ON COLUMN audit.entry_events.chain_seq IS 'Position in the chain, starting at 1 (NULL until linked)';
+COMMENT ON COLUMN audit.entry_events.prev_hash IS 'Hash of the previous event of the chain (64 zeros for the first)';
+COMMENT ON COLUMN audit.entry_events.hash IS 'SHA-256 of the event payload, including prev_hash';
+
+CREATE TABLE audit.chain_heads (
+    chain_partition VARCHAR(7)  NOT NULL,
+    stream          VARCHAR(50) NOT NULL,
+    last_seq        BIGINT      NOT NULL DEFAULT 0,
+    last_hash       CHAR(64)    NOT NULL DEFAULT repeat('0', 64),
+    updated_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (chain_partition, stream)
+);
+
+COMMENT ON TABLE audit.chain_heads IS 'Last event of each audit hash chain (updated by audit.link_events())';
+
+CREATE TABLE audit.chain_checkpoints (
+    id              UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    chain_partition VARCHAR(7)   NOT NULL,
+    stream          VARCHAR(50)  NOT NULL,
+    chain_seq       BIGINT       NOT NULL,
+    hash            CHAR(64)     NOT NULL,
+    key_id          VARCHAR(64)  NOT NULL,
+    signature       TEXT         NOT NULL,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    CONSTRAINT unique_chain_checkpoint UNIQUE (chain_partition, stream, chain_seq)
+);
+
+COMMENT ON TABLE audit.chain_checkpoints IS 'Ed25519-signed audit chain heads (written by AuditChainCheckpointer)';
+
+CREATE OR REPLACE FUNCTION audit.chain_field(value TEXT)
+RETURNS TEXT AS $$
+    SELECT octet_length(COALESCE(value, '')) || ':' || COALESCE(value, '');
+$$ LANGUAGE sql IMMUTABLE;
+
+-- Tags a new event with its chain; values sent by the writer are discarded
+CREATE OR REPLACE FUNCTION audit.chain_event()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.chain_partition := to_char(NEW.occurred_at AT TIME ZONE 'UTC', 'YYYY-MM');
+    NEW.stream := NEW.entity_type;
+    NEW.chain_seq := NULL;
+    NEW.prev_hash := NULL;
+    NEW.hash := NULL;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION audit.event_hash(e audit.entry_events)
+RETURNS CHAR(64) AS $$
+    SELECT encode(sha256(convert_to(
+        audit.chain_field(e.chain_partition) ||
+        audit.chain_field(e.stream) ||
+        audit.chain_field(e.chain_seq::text) ||
+        audit.chain_field(e.prev_hash) ||
+        audit.chain_field(e.event_id::text) ||
+        audit.chain_field(e.entity_type) ||
+        audit.chain_field(e.entity_id::text) ||
+        audit.chain_field(e.event_type) ||
+        audit.chain_field(e.event_subtype) ||
+        audit.chain_field(e.user_id::text) ||
+        audit.chain_field(e.old_values::text) ||
+        audit.chain_field(e.new_values::text) ||
+        audit.chain_field(e.diff::text) ||
+        audit.chain_field(e.metadata::text) ||
+        audit.chain_field(e.ip_address::text) ||
+        audit.chain_field(e.user_agent) ||
+        audit.chain_field(to_char(e.occurred_at AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS.US"Z"')),
+        'UTF8')), 'hex');
+$$ LANGUAGE sql STABLE;
+
+-- Links up to max_events committed events and returns how many were linked.
+-- Returns 0 without waiting when another linker is running.
+CREATE OR REPLACE FUNCTION audit.link_events(max_events INTEGER)
+RETURNS INTEGER AS $$
+DECLARE
+    ev audit.entry_events%ROWTYPE;
+    head audit.chain_heads%ROWTYPE;
+    linked INTEGER := 0;
+BEGIN
+    IF NOT pg_try_advisory_xact_lock(hashtext('audit.chain')) THEN
+        RETURN 0;
+    END IF;
+
+    FOR ev IN
+        SELECT *
+        FROM audit.entry_events
+        WHERE hash IS NULL AND chain_partition IS NOT NULL
+        ORDER BY occurred_at, id
+        LIMIT max_events
+    LOOP
+        INSERT INTO audit.chain_heads (chain_partition, stream)
+        VALUES (ev.chain_partition, ev.stream)
+        ON CONFLICT DO NOTHING;
+
+        SELECT * INTO head
+        FROM audit.chain_heads
+        WHERE chain_partition = ev.chain_partition AND stream = ev.stream
+        FOR UPDATE;
+
+        ev.chain_seq := head.last_seq + 1;
+        ev.prev_hash := head.last_hash;
+        ev.hash := audit.event_hash(ev);
+
+        UPDATE audit.entry_events
+        SET chain_seq = ev.chain_seq, prev_hash = ev.prev_hash, hash = ev.hash
+        WHERE id = ev.id AND occurred_at = ev.occurred_at;
+
+        UPDATE audit.chain_heads
+        SET last_seq = ev.chain_seq, last_hash = ev.hash, updated_at = NOW()
+        WHERE chain_partition = ev.chain_partition AND stream = ev.stream;
+
+        linked := linked + 1;
+    END LOOP;
+
+    RETURN linked;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER chain_entry_events
+    BEFORE INSERT ON audit.entry_events
+    FOR EACH ROW EXECUTE FUNCTION audit.chain_event();
+
+-- The audit trail is append-only
+CREATE OR REPLACE FUNCTION audit.reject_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION '% is append-only', TG_TABLE_NAME;
+END;
+$$ LANGUAGE plpgsql;
+
+-- The only change allowed to an event is audit.link_events() filling in its chain columns
+CREATE OR REPLACE FUNCTION audit.reject_event_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' AND OLD.hash IS NULL AND OLD.chain_partition IS NOT NULL
+        AND to_jsonb(NEW) - 'chain_seq' - 'prev_hash' - 'hash'
+            = to_jsonb(OLD) - 'chain_seq' - 'prev_hash' - 'hash' THEN
+        RETURN NEW;
+    END IF;
+
+    RAISE EXCEPTION '% is append-only', TG_TABLE_NAME;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER entry_events_append_only
+    BEFORE UPDATE OR DELETE ON audit.entry_events
+    FOR EACH ROW EXECUTE FUNCTION audit.reject_event_change();
+
+CREATE TRIGGER chain_checkpoints_append_only
+    BEFORE UPDATE OR DELETE ON audit.chain_checkpoints
+    FOR EACH ROW EXECUTE FUNCTION audit.reject_change();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER IF EXISTS chain_checkpoints_append_only ON audit.chain_checkpoints;
+DROP TRIGGER IF EXISTS entry_events_append_only ON audit.entry_events;
+DROP TRIGGER IF EXISTS chain_entry_events ON audit.entry_events;
+DROP FUNCTION IF EXISTS audit.reject_event_change();
+DROP FUNCTION IF EXISTS audit.reject_change();
+DROP FUNCTION IF EXISTS audit.link_events(INTEGER);
+DROP FUNCTION IF EXISTS audit.event_hash(audit.entry_events);
+DROP FUNCTION IF EXISTS audit.chain_event();
+DROP FUNCTION IF EXISTS audit.chain_field(TEXT);
+
+DROP TABLE IF EXISTS audit.chain_checkpoints;
+DROP TABLE IF EXISTS audit.chain_heads;
+
+DROP INDEX IF EXISTS audit.idx_entry_events_unlinked;
+DROP INDEX IF EXISTS audit.idx_entry_events_chain;
+
+ALTER TABLE audit.entry_events
+    DROP COLUMN IF EXISTS hash,
+    DROP COLUMN IF EXISTS prev_hash,
+    DROP COLUMN IF EXISTS chain_seq,
+    DROP COLUMN IF EXISTS stream,
+    DROP COLUMN IF EXISTS chain_partition;
+
+-- +goose StatementEnd
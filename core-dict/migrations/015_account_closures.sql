@@ -0,0 +1,55 @@
+-- Migration: 015_account_closures
+-- Description: Account closure cascade (every key of a closed account is deleted at Bacen) and per-key outcomes
+-- Author: data-specialist-core
+-- Date: 2026-10-18
+
+-- +goose Up
+-- +goose StatementBegin
+
+-- One closure per account; retrying the closure resumes it (only keys without DELETED or REQUESTED outcome are processed).
+-- A key is REQUESTED once its deletion is queued to Bacen and DELETED or FAILED when conn-dict reports the Bacen answer.
+CREATE TABLE core_dict.account_closures (
+    id              UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    account_id      UUID NOT NULL UNIQUE REFERENCES core_dict.accounts(id),
+    -- RPC (CloseAccount) or EVENT (AccountClosed from core banking)
+    trigger         VARCHAR(10) NOT NULL,
+    requested_by    VARCHAR(255) NOT NULL,
+    reason          TEXT,
+    status          VARCHAR(20) NOT NULL,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at    TIMESTAMP WITH TIME ZONE,
+
+    CONSTRAINT chk_account_closures_trigger CHECK (trigger IN ('RPC', 'EVENT')),
+    CONSTRAINT chk_account_closures_status CHECK (status IN ('IN_PROGRESS', 'AWAITING_BACEN', 'COMPLETED', 'PARTIAL'))
+);
+
+CREATE TABLE core_dict.account_closure_keys (
+    closure_id              UUID NOT NULL REFERENCES core_dict.account_closures(id) ON DELETE CASCADE,
+    entry_id                UUID NOT NULL REFERENCES core_dict.dict_entries(id),
+    key_type                VARCHAR(20) NOT NULL,
+    outcome                 VARCHAR(20) NOT NULL,
+    claims_cancelled        INT NOT NULL DEFAULT 0,
+    portabilities_cancelled INT NOT NULL DEFAULT 0,
+    error                   TEXT,
+    attempts                INT NOT NULL DEFAULT 1,
+    processed_at            TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (closure_id, entry_id),
+    CONSTRAINT chk_account_closure_keys_outcome CHECK (outcome IN ('REQUESTED', 'DELETED', 'FAILED'))
+);
+
+CREATE INDEX idx_account_closures_status ON core_dict.account_closures (status) WHERE status <> 'COMPLETED';
+-- Bacen answers (EntryStatusChanged from conn-dict) look up the closure by key
+CREATE INDEX idx_account_closure_keys_requested ON core_dict.account_closure_keys (entry_id) WHERE outcome = 'REQUESTED';
+
+COMMENT ON TABLE core_dict.account_closures IS 'Account closure cascade: keys of the closed account are deleted with reason ACCOUNT_CLOSURE';
+COMMENT ON TABLE core_dict.account_closure_keys IS 'Outcome per key of an account closure (REQUESTED: deletion queued to Bacen through the outbox; DELETED: confirmed by Bacen)';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS core_dict.account_closure_keys;
+DROP TABLE IF EXISTS core_dict.account_closures;
+-- +goose StatementEnd
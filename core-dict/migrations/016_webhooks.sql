@@ -0,0 +1,64 @@
+-- Migration: 016_webhooks
+-- Description: Outbound webhooks (claim/entry/infraction events delivered to the banking backends) and their delivery queue
+-- Author: data-specialist-core
+-- Date: 2026-10-18
+
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE core_dict.webhook_endpoints (
+    id               UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    url              TEXT NOT NULL,
+    -- Subscribed event types (claim.created, entry.deleted...) or aggregate wildcards (claim.*)
+    event_types      TEXT[] NOT NULL,
+    description      TEXT,
+    -- HMAC-SHA256 signing secret, encrypted with the field encryption keyring (fieldcrypto envelope)
+    secret_encrypted BYTEA NOT NULL,
+    active           BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by       VARCHAR(255) NOT NULL,
+    created_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_webhook_endpoints_event_types CHECK (cardinality(event_types) > 0)
+);
+
+-- One delivery per (endpoint, event); DEAD_LETTER is the webhook DLQ (attempts exhausted, replayed via ReplayWebhookDeliveries)
+CREATE TABLE core_dict.webhook_deliveries (
+    id               UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    endpoint_id      UUID NOT NULL REFERENCES core_dict.webhook_endpoints(id) ON DELETE CASCADE,
+    event_id         VARCHAR(255) NOT NULL,
+    event_type       VARCHAR(100) NOT NULL,
+    aggregate_id     VARCHAR(100) NOT NULL,
+    payload          JSONB NOT NULL,
+    occurred_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+    status           VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    attempts         INT NOT NULL DEFAULT 0,
+    next_attempt_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_status_code INT,
+    last_error       TEXT,
+    created_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    delivered_at     TIMESTAMP WITH TIME ZONE,
+
+    -- Pulsar redeliveries of the same event do not enqueue it twice
+    CONSTRAINT uq_webhook_deliveries_endpoint_event UNIQUE (endpoint_id, event_id),
+    CONSTRAINT chk_webhook_deliveries_status CHECK (status IN ('PENDING', 'DELIVERED', 'DEAD_LETTER'))
+);
+
+-- Dispatcher scan: due deliveries
+CREATE INDEX idx_webhook_deliveries_due ON core_dict.webhook_deliveries (next_attempt_at) WHERE status = 'PENDING';
+-- Replay of the dead letters of an endpoint
+CREATE INDEX idx_webhook_deliveries_dead_letter ON core_dict.webhook_deliveries (endpoint_id, created_at) WHERE status = 'DEAD_LETTER';
+CREATE INDEX idx_webhook_endpoints_active ON core_dict.webhook_endpoints USING GIN (event_types) WHERE active;
+
+COMMENT ON TABLE core_dict.webhook_endpoints IS 'Banking backend endpoints notified of claim/entry/infraction events (signed HTTP callbacks)';
+COMMENT ON TABLE core_dict.webhook_deliveries IS 'Webhook delivery queue: retried with exponential backoff, DEAD_LETTER once attempts are exhausted';
+COMMENT ON COLUMN core_dict.webhook_deliveries.next_attempt_at IS 'PENDING: when the next attempt is due (also pushed forward while a dispatcher holds the delivery)';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS core_dict.webhook_deliveries;
+DROP TABLE IF EXISTS core_dict.webhook_endpoints;
+-- +goose StatementEnd
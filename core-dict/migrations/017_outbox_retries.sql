@@ -0,0 +1,40 @@
+-- Migration: 017_outbox_retries
+-- Description: Outbox retry backoff, dead letters and redaction of published payloads
+-- Author: data-specialist-core
+-- Date: 2026-10-18
+
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE core_dict.outbox_events
+    ADD COLUMN next_attempt_at  TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN dead_lettered_at TIMESTAMP WITH TIME ZONE;
+
+-- Published payloads carry keys and account holder data: redact the ones already sent
+UPDATE core_dict.outbox_events SET payload = '{}'::jsonb WHERE sent_at IS NOT NULL;
+
+DROP INDEX IF EXISTS core_dict.idx_outbox_events_pending;
+CREATE INDEX idx_outbox_events_pending ON core_dict.outbox_events (id)
+    WHERE sent_at IS NULL AND dead_lettered_at IS NULL;
+-- Relay scan: events of the same key waiting for a retry
+CREATE INDEX idx_outbox_events_pending_key ON core_dict.outbox_events (partition_key, id)
+    WHERE sent_at IS NULL AND dead_lettered_at IS NULL;
+CREATE INDEX idx_outbox_events_dead_lettered ON core_dict.outbox_events (dead_lettered_at)
+    WHERE dead_lettered_at IS NOT NULL;
+
+COMMENT ON COLUMN core_dict.outbox_events.next_attempt_at IS 'Retry time after a failed publish; later events with the same key wait for it';
+COMMENT ON COLUMN core_dict.outbox_events.dead_lettered_at IS 'Set when the event exhausted its attempts; it is no longer published (payload kept for replay)';
+COMMENT ON COLUMN core_dict.outbox_events.payload IS 'Event JSON; redacted to {} once published';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS core_dict.idx_outbox_events_dead_lettered;
+DROP INDEX IF EXISTS core_dict.idx_outbox_events_pending_key;
+DROP INDEX IF EXISTS core_dict.idx_outbox_events_pending;
+CREATE INDEX idx_outbox_events_pending ON core_dict.outbox_events (id) WHERE sent_at IS NULL;
+ALTER TABLE core_dict.outbox_events
+    DROP COLUMN IF EXISTS dead_lettered_at,
+    DROP COLUMN IF EXISTS next_attempt_at;
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+package events
+
+import "time"
+
+// ClaimCancelledEvent is published on dict.claims.cancelled when core-dict cancels an open claim
+// (user request or closure of the key's account); conn-dict cancels it at Bacen
+type ClaimCancelledEvent struct {
+	ClaimID        string    `json:"claim_id"`
+	BacenClaimID   string    `json:"bacen_claim_id"`
+	Key            string    `json:"key"`
+	ClaimerISPB    string    `json:"claimer_ispb"`
+	DonorISPB      string    `json:"donor_ispb"`
+	Reason         string    `json:"reason"`
+	IdempotencyKey string    `json:"idempotency_key"`
+	RequestID      string    `json:"request_id"`
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+// PortabilityCancelledEvent is published on dict.portabilities.cancelled when core-dict cancels an
+// open portability; at Bacen a portability is a claim, so conn-dict cancels the claim
+// bacen_portability_id
+type PortabilityCancelledEvent struct {
+	PortabilityID      string    `json:"portability_id"`
+	BacenPortabilityID string    `json:"bacen_portability_id"`
+	EntryID            string    `json:"entry_id"`
+	Key                string    `json:"key"`
+	OriginISPB         string    `json:"origin_ispb"`
+	DestinationISPB    string    `json:"destination_ispb"`
+	Reason             string    `json:"reason"`
+	IdempotencyKey     string    `json:"idempotency_key"`
+	RequestID          string    `json:"request_id"`
+	Timestamp          time.Time `json:"timestamp"`
+}
@@ -0,0 +1,40 @@
+package events_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lbpay-lab/dict-contracts/events"
+)
+
+func TestClaimCancelledEvent_WireFormat(t *testing.T) {
+	payload := `{"claim_id":"claim-1","bacen_claim_id":"bacen-1","key":"maria@example.com","claimer_ispb":"12345678",` +
+		`"donor_ispb":"87654321","reason":"ACCOUNT_CLOSURE","idempotency_key":"idem-1","request_id":"req-1",` +
+		`"timestamp":"2026-10-18T12:00:00Z"}`
+
+	var event events.ClaimCancelledEvent
+	require.NoError(t, json.Unmarshal([]byte(payload), &event))
+	assert.Equal(t, "claim-1", event.ClaimID)
+	assert.Equal(t, "bacen-1", event.BacenClaimID)
+	assert.Equal(t, "12345678", event.ClaimerISPB)
+	assert.Equal(t, "87654321", event.DonorISPB)
+	assert.Equal(t, events.DeletionReasonAccountClosure, event.Reason)
+	assert.Equal(t, "req-1", event.RequestID)
+}
+
+func TestPortabilityCancelledEvent_WireFormat(t *testing.T) {
+	payload := `{"portability_id":"port-1","bacen_portability_id":"bacen-2","entry_id":"entry-1",` +
+		`"key":"+5511999999999","origin_ispb":"12345678","destination_ispb":"87654321","reason":"ACCOUNT_CLOSURE",` +
+		`"idempotency_key":"idem-2","request_id":"req-2","timestamp":"2026-10-18T12:00:00Z"}`
+
+	var event events.PortabilityCancelledEvent
+	require.NoError(t, json.Unmarshal([]byte(payload), &event))
+	assert.Equal(t, "port-1", event.PortabilityID)
+	assert.Equal(t, "bacen-2", event.BacenPortabilityID)
+	assert.Equal(t, "entry-1", event.EntryID)
+	assert.Equal(t, "+5511999999999", event.Key)
+	assert.Equal(t, "idem-2", event.IdempotencyKey)
+}
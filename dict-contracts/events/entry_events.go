@@ -0,0 +1,67 @@
+// Package events defines the JSON payloads of the DICT entry and claim events published by
+// core-dict (through its transactional outbox) and consumed by conn-dict. Both sides use these
+// types, so the producer and the consumer cannot drift apart.
+package events
+
+import "time"
+
+// Owner types of the account holder
+const (
+	OwnerTypeNaturalPerson = "NATURAL_PERSON"
+	OwnerTypeLegalPerson   = "LEGAL_PERSON"
+)
+
+// Deletion reasons understood by conn-dict; any other reason is a user request
+const (
+	DeletionReasonAccountClosure = "ACCOUNT_CLOSURE"
+	DeletionReasonReconciliation = "RECONCILIATION"
+	DeletionReasonFraud          = "FRAUD"
+)
+
+// EntryCreatedEvent is published on dict.entries.created when a key is registered
+type EntryCreatedEvent struct {
+	EntryID           string    `json:"entry_id"`
+	Key               string    `json:"key"`
+	KeyType           string    `json:"key_type"`
+	Participant       string    `json:"participant"` // ISPB
+	AccountBranch     *string   `json:"account_branch,omitempty"`
+	AccountNumber     *string   `json:"account_number,omitempty"`
+	AccountType       string    `json:"account_type"`
+	AccountOpenedDate *string   `json:"account_opened_date,omitempty"`
+	OwnerType         string    `json:"owner_type"`
+	OwnerName         *string   `json:"owner_name,omitempty"`
+	OwnerTaxID        *string   `json:"owner_tax_id,omitempty"`
+	IdempotencyKey    string    `json:"idempotency_key"`
+	RequestID         string    `json:"request_id"`
+	Timestamp         time.Time `json:"timestamp"`
+}
+
+// EntryUpdatedEvent is published on dict.entries.updated with the new account of the key
+type EntryUpdatedEvent struct {
+	EntryID           string    `json:"entry_id"`
+	Key               string    `json:"key"`
+	KeyType           string    `json:"key_type"`
+	Participant       string    `json:"participant"` // ISPB
+	AccountBranch     *string   `json:"account_branch,omitempty"`
+	AccountNumber     *string   `json:"account_number,omitempty"`
+	AccountType       string    `json:"account_type"`
+	AccountOpenedDate *string   `json:"account_opened_date,omitempty"`
+	OwnerType         string    `json:"owner_type"`
+	OwnerName         *string   `json:"owner_name,omitempty"`
+	OwnerTaxID        *string   `json:"owner_tax_id,omitempty"`
+	IdempotencyKey    string    `json:"idempotency_key"`
+	RequestID         string    `json:"request_id"`
+	Timestamp         time.Time `json:"timestamp"`
+}
+
+// EntryDeletedEvent is published on dict.entries.deleted.immediate when a key is deleted
+type EntryDeletedEvent struct {
+	EntryID        string    `json:"entry_id"`
+	Key            string    `json:"key"`
+	KeyType        string    `json:"key_type"`
+	Reason         string    `json:"reason"`
+	DeletedBy      string    `json:"deleted_by,omitempty"`
+	IdempotencyKey string    `json:"idempotency_key"`
+	RequestID      string    `json:"request_id"`
+	Timestamp      time.Time `json:"timestamp"`
+}
@@ -0,0 +1,58 @@
+package events_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lbpay-lab/dict-contracts/events"
+)
+
+// The field names are the wire format read by conn-dict; renaming one is a breaking change
+func TestEntryCreatedEvent_WireFormat(t *testing.T) {
+	branch, number, name := "0001", "123456", "Maria"
+	event := events.EntryCreatedEvent{
+		EntryID:        "entry-1",
+		Key:            "maria@example.com",
+		KeyType:        "EMAIL",
+		Participant:    "12345678",
+		AccountBranch:  &branch,
+		AccountNumber:  &number,
+		AccountType:    "CACC",
+		OwnerType:      events.OwnerTypeNaturalPerson,
+		OwnerName:      &name,
+		IdempotencyKey: "entry-1",
+		RequestID:      "req-1",
+		Timestamp:      time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC),
+	}
+
+	data, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &fields))
+	for _, name := range []string{"entry_id", "key", "key_type", "participant", "account_branch", "account_number",
+		"account_type", "owner_type", "owner_name", "idempotency_key", "request_id", "timestamp"} {
+		assert.Contains(t, fields, name)
+	}
+	assert.NotContains(t, fields, "owner_tax_id", "unset optional fields are omitted")
+
+	var decoded events.EntryCreatedEvent
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, event, decoded)
+}
+
+func TestEntryDeletedEvent_WireFormat(t *testing.T) {
+	payload := `{"entry_id":"entry-1","key":"+5511999999999","key_type":"PHONE","reason":"ACCOUNT_CLOSURE",` +
+		`"idempotency_key":"idem-1","request_id":"req-1","timestamp":"2026-10-18T12:00:00Z"}`
+
+	var event events.EntryDeletedEvent
+	require.NoError(t, json.Unmarshal([]byte(payload), &event))
+	assert.Equal(t, "+5511999999999", event.Key)
+	assert.Equal(t, events.DeletionReasonAccountClosure, event.Reason)
+	assert.Equal(t, "idem-1", event.IdempotencyKey)
+	assert.Equal(t, "req-1", event.RequestID)
+}
@@ -0,0 +1,161 @@
+package fieldcrypto
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+const (
+	// envelopeVersion is the first byte of every envelope
+	envelopeVersion byte = 1
+
+	// maxKeyIDLength is the maximum size of a KEK version (one length byte in the envelope)
+	maxKeyIDLength = 255
+)
+
+// ErrMalformedEnvelope is returned for ciphertexts that are not fieldcrypto envelopes
+var ErrMalformedEnvelope = errors.New("fieldcrypto: malformed envelope")
+
+// Cipher seals column values with envelope encryption and computes their blind indexes.
+//
+// Envelope layout:
+//
+//	version (1) | len(key id) (1) | key id | len(wrapped DEK) (2, big endian) | wrapped DEK | nonce (12) | ciphertext + tag
+//
+// The column name is the AES-GCM additional data, so a ciphertext copied to another column
+// does not decrypt.
+type Cipher struct {
+	provider KeyProvider
+	indexKey []byte
+}
+
+// NewCipher creates a Cipher on top of provider
+func NewCipher(ctx context.Context, provider KeyProvider) (*Cipher, error) {
+	indexKey, err := provider.IndexKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load blind index key: %w", err)
+	}
+	if len(indexKey) == 0 {
+		return nil, fmt.Errorf("blind index key is empty")
+	}
+	return &Cipher{
+		provider: provider,
+		indexKey: indexKey,
+	}, nil
+}
+
+// CurrentKeyID returns the KEK version used by Encrypt
+func (c *Cipher) CurrentKeyID() string {
+	return c.provider.CurrentKeyID()
+}
+
+// Encrypt seals the value of column field under the current KEK
+func (c *Cipher) Encrypt(ctx context.Context, field, plaintext string) ([]byte, error) {
+	keyID := c.provider.CurrentKeyID()
+
+	dek := make([]byte, keySize)
+	if _, err := rand.Read(dek); err != nil {
+		return nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+	wrapped, err := c.provider.WrapKey(ctx, keyID, dek)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap data key: %w", err)
+	}
+
+	aead, err := newAEAD(dek)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	envelope := make([]byte, 0, 4+len(keyID)+len(wrapped)+len(nonce)+len(plaintext)+aead.Overhead())
+	envelope = append(envelope, envelopeVersion, byte(len(keyID)))
+	envelope = append(envelope, keyID...)
+	envelope = binary.BigEndian.AppendUint16(envelope, uint16(len(wrapped)))
+	envelope = append(envelope, wrapped...)
+	envelope = append(envelope, nonce...)
+	return aead.Seal(envelope, nonce, []byte(plaintext), []byte(field)), nil
+}
+
+// Decrypt opens an envelope of column field
+func (c *Cipher) Decrypt(ctx context.Context, field string, envelope []byte) (string, error) {
+	parsed, err := parseEnvelope(envelope)
+	if err != nil {
+		return "", err
+	}
+
+	dek, err := c.provider.UnwrapKey(ctx, parsed.keyID, parsed.wrappedKey)
+	if err != nil {
+		return "", err
+	}
+	aead, err := newAEAD(dek)
+	if err != nil {
+		return "", err
+	}
+	if len(parsed.payload) < aead.NonceSize() {
+		return "", ErrMalformedEnvelope
+	}
+
+	nonce, sealed := parsed.payload[:aead.NonceSize()], parsed.payload[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, sealed, []byte(field))
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt %s: %w", field, err)
+	}
+	return string(plaintext), nil
+}
+
+// KeyID returns the KEK version an envelope was sealed with
+func (c *Cipher) KeyID(envelope []byte) (string, error) {
+	parsed, err := parseEnvelope(envelope)
+	if err != nil {
+		return "", err
+	}
+	return parsed.keyID, nil
+}
+
+// BlindIndex returns the blind index (hex HMAC-SHA256) of the value of column field.
+// Values must be normalized by the caller: the index only matches identical strings.
+func (c *Cipher) BlindIndex(field, value string) string {
+	mac := hmac.New(sha256.New, c.indexKey)
+	mac.Write([]byte(field))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// envelope is a parsed envelope
+type envelope struct {
+	keyID      string
+	wrappedKey []byte
+	payload    []byte // nonce || ciphertext + tag
+}
+
+func parseEnvelope(data []byte) (*envelope, error) {
+	if len(data) < 2 || data[0] != envelopeVersion {
+		return nil, ErrMalformedEnvelope
+	}
+
+	keyIDEnd := 2 + int(data[1])
+	if len(data) < keyIDEnd+2 {
+		return nil, ErrMalformedEnvelope
+	}
+	wrappedEnd := keyIDEnd + 2 + int(binary.BigEndian.Uint16(data[keyIDEnd:]))
+	if len(data) < wrappedEnd {
+		return nil, ErrMalformedEnvelope
+	}
+
+	return &envelope{
+		keyID:      string(data[2:keyIDEnd]),
+		wrappedKey: data[keyIDEnd+2 : wrappedEnd],
+		payload:    data[wrappedEnd:],
+	}, nil
+}
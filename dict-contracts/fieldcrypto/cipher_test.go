@@ -0,0 +1,151 @@
+package fieldcrypto
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testKey(b byte) string {
+	return base64.StdEncoding.EncodeToString([]byte(strings.Repeat(string(b), keySize)))
+}
+
+func testKeyring(current string) keyringFile {
+	return keyringFile{
+		CurrentKeyID: current,
+		Keys:         map[string]string{"v1": testKey('a'), "v2": testKey('b')},
+		IndexKey:     testKey('i'),
+	}
+}
+
+func newTestCipher(t *testing.T, current string) *Cipher {
+	t.Helper()
+	provider, err := newFileKeyProvider(testKeyring(current))
+	require.NoError(t, err)
+	c, err := NewCipher(context.Background(), provider)
+	require.NoError(t, err)
+	return c
+}
+
+func TestCipher_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	c := newTestCipher(t, "v1")
+
+	envelope, err := c.Encrypt(ctx, "entries.key", "12345678901")
+	require.NoError(t, err)
+	assert.NotContains(t, string(envelope), "12345678901")
+
+	plaintext, err := c.Decrypt(ctx, "entries.key", envelope)
+	require.NoError(t, err)
+	assert.Equal(t, "12345678901", plaintext)
+
+	keyID, err := c.KeyID(envelope)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", keyID)
+
+	// Fresh data key per value
+	other, err := c.Encrypt(ctx, "entries.key", "12345678901")
+	require.NoError(t, err)
+	assert.NotEqual(t, envelope, other)
+}
+
+func TestCipher_BoundToField(t *testing.T) {
+	ctx := context.Background()
+	c := newTestCipher(t, "v1")
+
+	envelope, err := c.Encrypt(ctx, "accounts.holder_document", "12345678901")
+	require.NoError(t, err)
+
+	_, err = c.Decrypt(ctx, "accounts.holder_name", envelope)
+	assert.Error(t, err)
+
+	envelope[len(envelope)-1] ^= 0xff
+	_, err = c.Decrypt(ctx, "accounts.holder_document", envelope)
+	assert.Error(t, err)
+
+	_, err = c.Decrypt(ctx, "accounts.holder_document", []byte("12345678901"))
+	assert.ErrorIs(t, err, ErrMalformedEnvelope)
+}
+
+func TestCipher_Rotation(t *testing.T) {
+	ctx := context.Background()
+	old := newTestCipher(t, "v1")
+	rotated := newTestCipher(t, "v2")
+
+	envelope, err := old.Encrypt(ctx, "accounts.holder_name", "Maria da Silva")
+	require.NoError(t, err)
+
+	// Values under the previous KEK still decrypt after rotation
+	plaintext, err := rotated.Decrypt(ctx, "accounts.holder_name", envelope)
+	require.NoError(t, err)
+	assert.Equal(t, "Maria da Silva", plaintext)
+
+	reencrypted, err := rotated.Encrypt(ctx, "accounts.holder_name", plaintext)
+	require.NoError(t, err)
+	keyID, err := rotated.KeyID(reencrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "v2", keyID)
+
+	// Retired KEK
+	provider, err := newFileKeyProvider(keyringFile{
+		CurrentKeyID: "v2",
+		Keys:         map[string]string{"v2": testKey('b')},
+		IndexKey:     testKey('i'),
+	})
+	require.NoError(t, err)
+	retired, err := NewCipher(ctx, provider)
+	require.NoError(t, err)
+	_, err = retired.Decrypt(ctx, "accounts.holder_name", envelope)
+	assert.ErrorIs(t, err, ErrUnknownKey)
+}
+
+func TestCipher_BlindIndex(t *testing.T) {
+	v1 := newTestCipher(t, "v1")
+	v2 := newTestCipher(t, "v2")
+
+	index := v1.BlindIndex("entries.key", "cliente@exemplo.com.br")
+	assert.Len(t, index, 64)
+	assert.Equal(t, index, v1.BlindIndex("entries.key", "cliente@exemplo.com.br"))
+	// Independent of the KEK rotation
+	assert.Equal(t, index, v2.BlindIndex("entries.key", "cliente@exemplo.com.br"))
+
+	assert.NotEqual(t, index, v1.BlindIndex("entries.key", "outro@exemplo.com.br"))
+	assert.NotEqual(t, index, v1.BlindIndex("accounts.holder_document", "cliente@exemplo.com.br"))
+}
+
+func TestNewFileKeyProvider(t *testing.T) {
+	write := func(t *testing.T, keyring keyringFile) string {
+		data, err := json.Marshal(keyring)
+		require.NoError(t, err)
+		path := filepath.Join(t.TempDir(), "keyring.json")
+		require.NoError(t, os.WriteFile(path, data, 0o600))
+		return path
+	}
+
+	provider, err := NewFileKeyProvider(write(t, testKeyring("v2")))
+	require.NoError(t, err)
+	assert.Equal(t, "v2", provider.CurrentKeyID())
+
+	_, err = NewFileKeyProvider(write(t, testKeyring("v3")))
+	assert.ErrorContains(t, err, "current key")
+
+	shortKey := testKeyring("v1")
+	shortKey.Keys["v1"] = base64.StdEncoding.EncodeToString([]byte("short"))
+	_, err = NewFileKeyProvider(write(t, shortKey))
+	assert.ErrorContains(t, err, "must have 32 bytes")
+
+	noIndexKey := testKeyring("v1")
+	noIndexKey.IndexKey = ""
+	_, err = NewFileKeyProvider(write(t, noIndexKey))
+	assert.ErrorContains(t, err, "index key")
+
+	_, err = NewFileKeyProvider(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,143 @@
+package fieldcrypto
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// keySize is the size of KEKs and DEKs (AES-256)
+const keySize = 32
+
+// keyringFile is the JSON layout of the local keyring:
+//
+//	{
+//	  "current_key_id": "2026-10",
+//	  "keys": {"2026-01": "<base64 32 bytes>", "2026-10": "<base64 32 bytes>"},
+//	  "index_key": "<base64 32+ bytes>"
+//	}
+type keyringFile struct {
+	CurrentKeyID string            `json:"current_key_id"`
+	Keys         map[string]string `json:"keys"`
+	IndexKey     string            `json:"index_key"`
+}
+
+// FileKeyProvider is a local KeyProvider backed by a keyring file.
+//
+// To rotate, add a new key to the file, point current_key_id to it and restart: new values use
+// the new key and the re-encryption job migrates the old ones. An old key can be removed once
+// the job reports no values left under it.
+type FileKeyProvider struct {
+	currentKeyID string
+	keks         map[string]cipher.AEAD
+	indexKey     []byte
+}
+
+// NewFileKeyProvider loads the keyring file at path
+func NewFileKeyProvider(path string) (*FileKeyProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keyring file: %w", err)
+	}
+
+	var keyring keyringFile
+	if err := json.Unmarshal(data, &keyring); err != nil {
+		return nil, fmt.Errorf("failed to parse keyring file: %w", err)
+	}
+	return newFileKeyProvider(keyring)
+}
+
+func newFileKeyProvider(keyring keyringFile) (*FileKeyProvider, error) {
+	if _, ok := keyring.Keys[keyring.CurrentKeyID]; !ok {
+		return nil, fmt.Errorf("keyring: current key %q not found", keyring.CurrentKeyID)
+	}
+
+	keks := make(map[string]cipher.AEAD, len(keyring.Keys))
+	for keyID, encoded := range keyring.Keys {
+		if keyID == "" || len(keyID) > maxKeyIDLength {
+			return nil, fmt.Errorf("keyring: key id %q must have 1 to %d characters", keyID, maxKeyIDLength)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("keyring: key %q is not valid base64: %w", keyID, err)
+		}
+		if len(key) != keySize {
+			return nil, fmt.Errorf("keyring: key %q must have %d bytes, got %d", keyID, keySize, len(key))
+		}
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, err
+		}
+		keks[keyID] = aead
+	}
+
+	indexKey, err := base64.StdEncoding.DecodeString(keyring.IndexKey)
+	if err != nil {
+		return nil, fmt.Errorf("keyring: index key is not valid base64: %w", err)
+	}
+	if len(indexKey) < keySize {
+		return nil, fmt.Errorf("keyring: index key must have at least %d bytes", keySize)
+	}
+
+	return &FileKeyProvider{
+		currentKeyID: keyring.CurrentKeyID,
+		keks:         keks,
+		indexKey:     indexKey,
+	}, nil
+}
+
+// CurrentKeyID returns the version of the KEK used for new values
+func (p *FileKeyProvider) CurrentKeyID() string {
+	return p.currentKeyID
+}
+
+// WrapKey encrypts dek with the KEK keyID (nonce || AES-GCM ciphertext, key id as AAD)
+func (p *FileKeyProvider) WrapKey(_ context.Context, keyID string, dek []byte) ([]byte, error) {
+	kek, ok := p.keks[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, keyID)
+	}
+
+	nonce := make([]byte, kek.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return kek.Seal(nonce, nonce, dek, []byte(keyID)), nil
+}
+
+// UnwrapKey decrypts a data key wrapped by WrapKey
+func (p *FileKeyProvider) UnwrapKey(_ context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	kek, ok := p.keks[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, keyID)
+	}
+	if len(wrapped) < kek.NonceSize() {
+		return nil, fmt.Errorf("wrapped key too short")
+	}
+
+	nonce, sealed := wrapped[:kek.NonceSize()], wrapped[kek.NonceSize():]
+	dek, err := kek.Open(nil, nonce, sealed, []byte(keyID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	return dek, nil
+}
+
+// IndexKey returns the HMAC key of the blind indexes
+func (p *FileKeyProvider) IndexKey(_ context.Context) ([]byte, error) {
+	return p.indexKey, nil
+}
+
+// newAEAD creates an AES-GCM cipher
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AES cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,37 @@
+// Package fieldcrypto encrypts personal data columns (LGPD) and computes their blind indexes.
+//
+// Values are sealed with envelope encryption: each value gets a random data key (DEK) that
+// encrypts it with AES-256-GCM, and the DEK is wrapped by a key encryption key (KEK) held by a
+// KeyProvider (the KMS). The envelope carries the version of the KEK, so KEKs can be rotated:
+// new values use the current KEK and a background job re-encrypts the old ones.
+//
+// Encrypted columns cannot be compared in SQL, so equality lookups go through a blind index:
+// an HMAC-SHA256 of the value under a dedicated index key, stored next to the ciphertext.
+//
+// core-dict and conn-dict both import this package, so their envelopes and blind indexes have
+// the same format; each service keeps its own keyring.
+package fieldcrypto
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrUnknownKey is returned when a value was encrypted with a KEK the provider does not hold
+var ErrUnknownKey = errors.New("fieldcrypto: unknown key encryption key")
+
+// KeyProvider is the KMS holding the key encryption keys and the blind index key
+type KeyProvider interface {
+	// CurrentKeyID returns the version of the KEK used for new values
+	CurrentKeyID() string
+
+	// WrapKey encrypts a data key with the KEK keyID
+	WrapKey(ctx context.Context, keyID string, dek []byte) ([]byte, error)
+
+	// UnwrapKey decrypts a data key wrapped by the KEK keyID
+	UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+
+	// IndexKey returns the HMAC key of the blind indexes. It is not rotated: a new index key
+	// requires recomputing every index.
+	IndexKey(ctx context.Context) ([]byte, error)
+}
@@ -0,0 +1,88 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// CachedStore caches COMPLETED records in Redis in front of the Postgres store.
+//
+// Only completed records are cached: they are immutable until they expire, so a retry can be
+// answered from Redis without touching Postgres. Reservations always go to Postgres, which is
+// the only place where two concurrent requests with the same key are serialized. Redis errors
+// fall back to the underlying store.
+type CachedStore struct {
+	store  Store
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewCachedStore wraps store with the Redis fast path. Keys are prefix+request_id, so each
+// service keeps its records apart (e.g. "core-dict:idempotency:").
+func NewCachedStore(store Store, client redis.UniversalClient, prefix string) *CachedStore {
+	return &CachedStore{
+		store:  store,
+		client: client,
+		prefix: prefix,
+	}
+}
+
+// Reserve answers from Redis when the key has a cached completed record
+func (s *CachedStore) Reserve(ctx context.Context, record *Record) (*Record, bool, error) {
+	if cached, ok := s.cached(ctx, record.Key); ok {
+		return cached, false, nil
+	}
+
+	existing, reserved, err := s.store.Reserve(ctx, record)
+	if err != nil || reserved {
+		return existing, reserved, err
+	}
+	if existing.Status == StatusCompleted {
+		s.cache(ctx, existing)
+	}
+	return existing, false, nil
+}
+
+// Complete stores the response in Postgres and caches it
+func (s *CachedStore) Complete(ctx context.Context, record *Record) error {
+	if err := s.store.Complete(ctx, record); err != nil {
+		return err
+	}
+	s.cache(ctx, record)
+	return nil
+}
+
+// Release deletes the reservation
+func (s *CachedStore) Release(ctx context.Context, key string) error {
+	return s.store.Release(ctx, key)
+}
+
+// cached returns the live completed record cached for key
+func (s *CachedStore) cached(ctx context.Context, key string) (*Record, bool) {
+	data, err := s.client.Get(ctx, s.prefix+key).Bytes()
+	if err != nil {
+		return nil, false
+	}
+
+	var record Record
+	if err := json.Unmarshal(data, &record); err != nil || record.Status != StatusCompleted || !record.Live(time.Now()) {
+		return nil, false
+	}
+	return &record, true
+}
+
+// cache stores a completed record until it expires (best effort)
+func (s *CachedStore) cache(ctx context.Context, record *Record) {
+	ttl := time.Until(record.ExpiresAt)
+	if ttl <= 0 {
+		return
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return
+	}
+	_ = s.client.Set(ctx, s.prefix+record.Key, data, ttl).Err()
+}
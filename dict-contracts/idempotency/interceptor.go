@@ -0,0 +1,310 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	// RequestIDMetadataKey carries the request_id of requests whose message has no request_id field
+	RequestIDMetadataKey = "x-request-id"
+
+	// ReasonKeyReused: the request_id was already used with a different request
+	ReasonKeyReused = "IDEMPOTENCY_KEY_REUSED"
+	// ReasonRequestInProgress: the first request with this request_id has not finished yet
+	ReasonRequestInProgress = "REQUEST_IN_PROGRESS"
+
+	// ReplayMetadataKey is set on responses replayed from the store
+	ReplayMetadataKey = "x-idempotent-replay"
+
+	requestIDField     = "request_id"
+	maxRequestIDLength = 128
+)
+
+// Config configures the idempotency interceptor
+type Config struct {
+	TTL     time.Duration // How long a completed response is replayed
+	LockTTL time.Duration // How long a request holds its request_id before finishing
+
+	// ErrorDomain is the google.rpc.ErrorInfo domain of the rejections (e.g. "core-dict.lbpay.com.br")
+	ErrorDomain string
+	// Caller identifies the authenticated caller, if any. It is part of the fingerprint, so a
+	// request_id can never replay another caller's response.
+	Caller func(ctx context.Context) string
+}
+
+// DefaultConfig returns the default idempotency configuration
+func DefaultConfig() Config {
+	return Config{
+		TTL:     24 * time.Hour,
+		LockTTL: 60 * time.Second, // Above the Bacen timeout (30s)
+	}
+}
+
+// Interceptor makes the given RPCs idempotent by request_id (see RequestID).
+//
+// The request_id is reserved before the handler runs and the outcome is stored when it returns.
+// A retry with the same request_id and payload replays the stored response (or error); a
+// request_id reused with another payload gets InvalidArgument (IDEMPOTENCY_KEY_REUSED), and a
+// retry while the first request is still running gets Aborted (REQUEST_IN_PROGRESS). Transient
+// errors (Unavailable, Internal...) are not stored, so the client can retry them.
+//
+// Requests without a request_id are not deduplicated.
+type Interceptor struct {
+	store   Store
+	methods map[string]bool
+	config  Config
+	logger  *slog.Logger
+}
+
+// NewInterceptor creates an interceptor for the given gRPC full methods.
+// A nil store disables it.
+func NewInterceptor(store Store, methods []string, config Config, logger *slog.Logger) *Interceptor {
+	defaults := DefaultConfig()
+	if config.TTL <= 0 {
+		config.TTL = defaults.TTL
+	}
+	if config.LockTTL <= 0 {
+		config.LockTTL = defaults.LockTTL
+	}
+
+	set := make(map[string]bool, len(methods))
+	for _, method := range methods {
+		set[method] = true
+	}
+	return &Interceptor{
+		store:   store,
+		methods: set,
+		config:  config,
+		logger:  logger,
+	}
+}
+
+// Unary returns a unary server interceptor for request_id idempotency
+func (i *Interceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		message, ok := req.(proto.Message)
+		if i.store == nil || !ok || !i.methods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		requestID := RequestID(ctx, message)
+		if requestID == "" {
+			return handler(ctx, req)
+		}
+		if len(requestID) > maxRequestIDLength {
+			return nil, status.Errorf(codes.InvalidArgument, "request_id must have at most %d characters", maxRequestIDLength)
+		}
+
+		hash, err := fingerprint(info.FullMethod, i.caller(ctx), message)
+		if err != nil {
+			i.logger.Error("Idempotency: failed to fingerprint request", "method", info.FullMethod, "error", err)
+			return nil, status.Error(codes.Internal, "failed to fingerprint request")
+		}
+
+		now := time.Now()
+		record := &Record{
+			Key:         requestID,
+			Method:      info.FullMethod,
+			Fingerprint: hash,
+			Status:      StatusInProgress,
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(i.config.LockTTL),
+		}
+		existing, reserved, err := i.store.Reserve(ctx, record)
+		if err != nil {
+			i.logger.Error("Idempotency: store unavailable", "method", info.FullMethod, "request_id", requestID, "error", err)
+			return nil, status.Error(codes.Unavailable, "idempotency store unavailable, please retry")
+		}
+		if !reserved {
+			return i.replay(ctx, record, existing)
+		}
+
+		resp, handlerErr := handler(ctx, req)
+		i.finish(ctx, record, resp, handlerErr)
+		return resp, handlerErr
+	}
+}
+
+// replay answers a request whose request_id is already taken
+func (i *Interceptor) replay(ctx context.Context, record, existing *Record) (interface{}, error) {
+	if existing.Fingerprint != record.Fingerprint {
+		i.logger.Warn("Idempotency: request_id reused with a different request",
+			"method", record.Method,
+			"request_id", record.Key,
+			"original_method", existing.Method,
+		)
+		return nil, i.rejection(codes.InvalidArgument, ReasonKeyReused, record.Key,
+			"request_id already used with a different request")
+	}
+	if existing.Status != StatusCompleted {
+		return nil, i.rejection(codes.Aborted, ReasonRequestInProgress, record.Key,
+			"a request with this request_id is still in progress")
+	}
+
+	resp, err := decodeOutcome(existing.Response)
+	if err != nil {
+		i.logger.Error("Idempotency: failed to decode stored response", "method", record.Method, "request_id", record.Key, "error", err)
+		return nil, status.Error(codes.Internal, "failed to decode stored response")
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(ReplayMetadataKey, "true"))
+	i.logger.Info("Idempotency: replaying stored response", "method", record.Method, "request_id", record.Key)
+	return resp.response, resp.err
+}
+
+// finish stores the outcome of the handler, or releases the request_id on transient errors
+func (i *Interceptor) finish(ctx context.Context, record *Record, resp interface{}, handlerErr error) {
+	// The outcome must be recorded even if the client went away
+	ctx = context.WithoutCancel(ctx)
+	log := i.logger.With("request_id", record.Key)
+
+	if handlerErr != nil && isRetryableCode(status.Code(handlerErr)) {
+		if err := i.store.Release(ctx, record.Key); err != nil {
+			log.Warn("Idempotency: failed to release request_id", "error", err)
+		}
+		return
+	}
+
+	response, err := encodeOutcome(resp, handlerErr)
+	if err != nil {
+		log.Error("Idempotency: failed to encode response", "error", err)
+		if err := i.store.Release(ctx, record.Key); err != nil {
+			log.Warn("Idempotency: failed to release request_id", "error", err)
+		}
+		return
+	}
+
+	record.Status = StatusCompleted
+	record.Response = response
+	record.ExpiresAt = time.Now().Add(i.config.TTL)
+	if err := i.store.Complete(ctx, record); err != nil {
+		log.Warn("Idempotency: failed to store response", "error", err)
+	}
+}
+
+// RequestID returns the idempotency key of a request: its request_id field when the message
+// declares one (BridgeService), otherwise the x-request-id metadata (CoreDictService).
+func RequestID(ctx context.Context, req proto.Message) string {
+	m := req.ProtoReflect()
+	if field := m.Descriptor().Fields().ByName(requestIDField); field != nil && field.Kind() == protoreflect.StringKind {
+		return m.Get(field).String()
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(RequestIDMetadataKey); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// caller returns the caller identity used in the fingerprint
+func (i *Interceptor) caller(ctx context.Context) string {
+	if i.config.Caller == nil {
+		return ""
+	}
+	return i.config.Caller(ctx)
+}
+
+// fingerprint hashes the method, the caller and the request payload
+func fingerprint(method, caller string, req proto.Message) (string, error) {
+	payload, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{0})
+	h.Write([]byte(caller))
+	h.Write([]byte{0})
+	h.Write(payload)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// isRetryableCode reports whether an error may succeed on retry (and so must not be replayed)
+func isRetryableCode(code codes.Code) bool {
+	switch code {
+	case codes.Canceled, codes.Unknown, codes.DeadlineExceeded, codes.ResourceExhausted,
+		codes.Aborted, codes.Internal, codes.Unavailable, codes.Unauthenticated:
+		return true
+	default:
+		return false
+	}
+}
+
+// rejection builds an idempotency rejection with its reason in google.rpc.ErrorInfo
+func (i *Interceptor) rejection(code codes.Code, reason, requestID, message string) error {
+	st, err := status.New(code, message).WithDetails(&errdetails.ErrorInfo{
+		Reason:   reason,
+		Domain:   i.config.ErrorDomain,
+		Metadata: map[string]string{"request_id": requestID},
+	})
+	if err != nil {
+		return status.Error(code, message)
+	}
+	return st.Err()
+}
+
+// encodeOutcome serializes the response, or the status of a non-retryable error, as an Any
+func encodeOutcome(resp interface{}, handlerErr error) ([]byte, error) {
+	var message proto.Message
+	if handlerErr != nil {
+		message = status.Convert(handlerErr).Proto()
+	} else {
+		m, ok := resp.(proto.Message)
+		if !ok {
+			return nil, fmt.Errorf("unexpected response type %T", resp)
+		}
+		message = m
+	}
+
+	wrapped, err := anypb.New(message)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(wrapped)
+}
+
+// outcome is a decoded stored response
+type outcome struct {
+	response proto.Message
+	err      error
+}
+
+// decodeOutcome restores a stored response or error
+func decodeOutcome(data []byte) (*outcome, error) {
+	var wrapped anypb.Any
+	if err := proto.Unmarshal(data, &wrapped); err != nil {
+		return nil, err
+	}
+	message, err := wrapped.UnmarshalNew()
+	if err != nil {
+		return nil, err
+	}
+	if st, ok := message.(*spb.Status); ok {
+		return &outcome{err: status.ErrorProto(st)}, nil
+	}
+	return &outcome{response: message}, nil
+}
@@ -0,0 +1,255 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/lbpay-lab/dict-contracts/gen/proto/bridge/v1"
+	commonv1 "github.com/lbpay-lab/dict-contracts/gen/proto/common/v1"
+	corev1 "github.com/lbpay-lab/dict-contracts/gen/proto/core/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// memoryStore is an in-memory idempotency table
+type memoryStore struct {
+	mu         sync.Mutex
+	records    map[string]Record
+	reserveErr error
+	released   []string
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{records: make(map[string]Record)}
+}
+
+func (s *memoryStore) Reserve(_ context.Context, record *Record) (*Record, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.reserveErr != nil {
+		return nil, false, s.reserveErr
+	}
+	if existing, ok := s.records[record.Key]; ok && existing.Live(time.Now()) {
+		return &existing, false, nil
+	}
+	s.records[record.Key] = *record
+	return record, true, nil
+}
+
+func (s *memoryStore) Complete(_ context.Context, record *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[record.Key] = *record
+	return nil
+}
+
+func (s *memoryStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, key)
+	s.released = append(s.released, key)
+	return nil
+}
+
+// bacenHandler counts the requests that reach Bacen
+type bacenHandler struct {
+	calls int
+	err   error
+}
+
+func (h *bacenHandler) handle(ctx context.Context, req interface{}) (interface{}, error) {
+	h.calls++
+	if h.err != nil {
+		return nil, h.err
+	}
+	return &pb.CreateEntryResponse{
+		EntryId:            fmt.Sprintf("entry-%d", h.calls),
+		Status:             commonv1.EntryStatus_ENTRY_STATUS_ACTIVE,
+		BacenTransactionId: fmt.Sprintf("bacen-tx-%d", h.calls),
+	}, nil
+}
+
+func createEntryRequest(requestID, keyValue string) *pb.CreateEntryRequest {
+	return &pb.CreateEntryRequest{
+		Key: &commonv1.DictKey{
+			KeyType:  commonv1.KeyType_KEY_TYPE_EMAIL,
+			KeyValue: keyValue,
+		},
+		RequestId: requestID,
+	}
+}
+
+const testErrorDomain = "conn-bridge.lbpay.com.br"
+
+func newTestInterceptor(store Store, methods ...string) *Interceptor {
+	config := Config{
+		ErrorDomain: testErrorDomain,
+		Caller: func(ctx context.Context) string {
+			userID, _ := ctx.Value(callerKey{}).(string)
+			return userID
+		},
+	}
+	return NewInterceptor(store, methods, config, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func runInterceptor(store Store, req interface{}, method string, h *bacenHandler) (interface{}, error) {
+	return runInterceptorContext(context.Background(), store, req, method, h)
+}
+
+func runInterceptorContext(ctx context.Context, store Store, req interface{}, method string, h *bacenHandler) (interface{}, error) {
+	interceptor := newTestInterceptor(store, pb.BridgeService_CreateEntry_FullMethodName, corev1.CoreDictService_CreateKey_FullMethodName)
+	return interceptor.Unary()(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, h.handle)
+}
+
+// callerKey carries the authenticated caller in tests
+type callerKey struct{}
+
+// metadataContext is a CoreDictService call: request_id in the metadata, authenticated caller
+func metadataContext(userID, requestID string) context.Context {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDMetadataKey, requestID))
+	return context.WithValue(ctx, callerKey{}, userID)
+}
+
+func errorReason(t *testing.T, err error) string {
+	t.Helper()
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			assert.Equal(t, testErrorDomain, info.GetDomain())
+			return info.GetReason()
+		}
+	}
+	return ""
+}
+
+func TestInterceptor_ReplaysCompletedResponse(t *testing.T) {
+	store := newMemoryStore()
+	h := &bacenHandler{}
+
+	first, err := runInterceptor(store, createEntryRequest("req-1", "cliente@exemplo.com.br"), pb.BridgeService_CreateEntry_FullMethodName, h)
+	require.NoError(t, err)
+	replayed, err := runInterceptor(store, createEntryRequest("req-1", "cliente@exemplo.com.br"), pb.BridgeService_CreateEntry_FullMethodName, h)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, h.calls, "the retry must not reach Bacen")
+	assert.True(t, proto.Equal(first.(proto.Message), replayed.(proto.Message)))
+	assert.Equal(t, StatusCompleted, store.records["req-1"].Status)
+}
+
+func TestInterceptor_RejectsReusedRequestID(t *testing.T) {
+	store := newMemoryStore()
+	h := &bacenHandler{}
+
+	_, err := runInterceptor(store, createEntryRequest("req-1", "cliente@exemplo.com.br"), pb.BridgeService_CreateEntry_FullMethodName, h)
+	require.NoError(t, err)
+
+	_, err = runInterceptor(store, createEntryRequest("req-1", "outro@exemplo.com.br"), pb.BridgeService_CreateEntry_FullMethodName, h)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, ReasonKeyReused, errorReason(t, err))
+	assert.Equal(t, 1, h.calls)
+}
+
+func TestInterceptor_InProgressRequestIsAborted(t *testing.T) {
+	store := newMemoryStore()
+	interceptor := newTestInterceptor(store, pb.BridgeService_CreateEntry_FullMethodName)
+
+	var retryErr error
+	_, err := interceptor.Unary()(context.Background(), createEntryRequest("req-1", "cliente@exemplo.com.br"),
+		&grpc.UnaryServerInfo{FullMethod: pb.BridgeService_CreateEntry_FullMethodName},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			// Connect retries while Bacen has not answered the first request yet
+			_, retryErr = runInterceptor(store, req, pb.BridgeService_CreateEntry_FullMethodName, &bacenHandler{})
+			return &pb.CreateEntryResponse{EntryId: "entry-1"}, nil
+		})
+	require.NoError(t, err)
+
+	assert.Equal(t, codes.Aborted, status.Code(retryErr))
+	assert.Equal(t, ReasonRequestInProgress, errorReason(t, retryErr))
+}
+
+func TestInterceptor_ErrorOutcomes(t *testing.T) {
+	store := newMemoryStore()
+
+	// Bacen rejections are stored and replayed
+	h := &bacenHandler{err: status.Error(codes.AlreadyExists, "EntryAlreadyExists")}
+	_, err := runInterceptor(store, createEntryRequest("req-1", "cliente@exemplo.com.br"), pb.BridgeService_CreateEntry_FullMethodName, h)
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	_, err = runInterceptor(store, createEntryRequest("req-1", "cliente@exemplo.com.br"), pb.BridgeService_CreateEntry_FullMethodName, h)
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	assert.Equal(t, "EntryAlreadyExists", status.Convert(err).Message())
+	assert.Equal(t, 1, h.calls)
+
+	// Bacen unavailable: the request_id is released and the retry goes through
+	h = &bacenHandler{err: status.Error(codes.Unavailable, "failed to send SOAP request")}
+	_, err = runInterceptor(store, createEntryRequest("req-2", "cliente@exemplo.com.br"), pb.BridgeService_CreateEntry_FullMethodName, h)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, []string{"req-2"}, store.released)
+
+	h.err = nil
+	_, err = runInterceptor(store, createEntryRequest("req-2", "cliente@exemplo.com.br"), pb.BridgeService_CreateEntry_FullMethodName, h)
+	require.NoError(t, err)
+	assert.Equal(t, 2, h.calls)
+}
+
+func TestInterceptor_Bypass(t *testing.T) {
+	store := newMemoryStore()
+	h := &bacenHandler{}
+
+	// Method not covered
+	_, err := runInterceptor(store, &pb.GetEntryRequest{RequestId: "req-1"}, pb.BridgeService_GetEntry_FullMethodName, h)
+	require.NoError(t, err)
+
+	// No request_id
+	for i := 0; i < 2; i++ {
+		_, err = runInterceptor(store, createEntryRequest("", "cliente@exemplo.com.br"), pb.BridgeService_CreateEntry_FullMethodName, h)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 3, h.calls)
+	assert.Empty(t, store.records)
+
+	// Store outage fails closed: the entry could otherwise be sent to Bacen twice
+	store.reserveErr = errors.New("connection refused")
+	_, err = runInterceptor(store, createEntryRequest("req-1", "cliente@exemplo.com.br"), pb.BridgeService_CreateEntry_FullMethodName, h)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 3, h.calls)
+}
+
+func TestInterceptor_MetadataRequestID(t *testing.T) {
+	store := newMemoryStore()
+	h := &bacenHandler{}
+	req := &corev1.CreateKeyRequest{KeyType: commonv1.KeyType_KEY_TYPE_EVP, AccountId: "acc-1"}
+
+	_, err := runInterceptorContext(metadataContext("user-1", "req-1"), store, req, corev1.CoreDictService_CreateKey_FullMethodName, h)
+	require.NoError(t, err)
+	_, err = runInterceptorContext(metadataContext("user-1", "req-1"), store, req, corev1.CoreDictService_CreateKey_FullMethodName, h)
+	require.NoError(t, err)
+	assert.Equal(t, 1, h.calls)
+
+	// Same request_id and payload from another caller never replays the first caller's response
+	_, err = runInterceptorContext(metadataContext("user-2", "req-1"), store, req, corev1.CoreDictService_CreateKey_FullMethodName, h)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, ReasonKeyReused, errorReason(t, err))
+	assert.Equal(t, 1, h.calls)
+}
+
+func TestRequestID(t *testing.T) {
+	// Messages with a request_id field use it, even when the metadata carries another one
+	ctx := metadataContext("user-1", "metadata-req")
+	assert.Equal(t, "req-1", RequestID(ctx, createEntryRequest("req-1", "cliente@exemplo.com.br")))
+	assert.Empty(t, RequestID(ctx, createEntryRequest("", "cliente@exemplo.com.br")))
+
+	// Messages without one use the x-request-id metadata
+	assert.Equal(t, "metadata-req", RequestID(ctx, &corev1.CreateKeyRequest{AccountId: "acc-1"}))
+	assert.Empty(t, RequestID(context.Background(), &commonv1.DictKey{KeyValue: "cliente@exemplo.com.br"}))
+}
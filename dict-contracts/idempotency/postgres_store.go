@@ -0,0 +1,182 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// reserveAttempts bounds the insert/select loop of Reserve (the existing record can expire or be
+// released between the two statements)
+const reserveAttempts = 3
+
+// PostgresStore implements Store on an idempotency_keys table (core_dict.idempotency_keys in
+// core-dict, idempotency_keys in conn-bridge; both created by the services' migrations)
+type PostgresStore struct {
+	pool  *pgxpool.Pool
+	table string
+}
+
+// NewPostgresStore creates a new Postgres idempotency store on the given (schema-qualified) table
+func NewPostgresStore(pool *pgxpool.Pool, table string) *PostgresStore {
+	return &PostgresStore{pool: pool, table: table}
+}
+
+// Reserve inserts the record, taking over an expired one. The primary key serializes concurrent
+// requests with the same request_id: only one of them gets reserved=true.
+func (s *PostgresStore) Reserve(ctx context.Context, record *Record) (*Record, bool, error) {
+	query := `
+		INSERT INTO ` + s.table + ` AS k (
+			idempotency_key, method, fingerprint, status, created_at, updated_at, expires_at
+		) VALUES ($1, $2, $3, $4, $5, $5, $6)
+		ON CONFLICT (idempotency_key) DO UPDATE SET
+			method = EXCLUDED.method,
+			fingerprint = EXCLUDED.fingerprint,
+			status = EXCLUDED.status,
+			response = NULL,
+			created_at = EXCLUDED.created_at,
+			updated_at = EXCLUDED.updated_at,
+			expires_at = EXCLUDED.expires_at
+		WHERE k.expires_at <= NOW()
+		RETURNING idempotency_key
+	`
+
+	for attempt := 0; attempt < reserveAttempts; attempt++ {
+		var key string
+		err := s.pool.QueryRow(ctx, query,
+			record.Key,
+			record.Method,
+			record.Fingerprint,
+			string(StatusInProgress),
+			record.CreatedAt,
+			record.ExpiresAt,
+		).Scan(&key)
+		if err == nil {
+			record.Status = StatusInProgress
+			return record, true, nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+		}
+
+		// Live record: return it
+		existing, err := s.get(ctx, record.Key)
+		if err != nil {
+			return nil, false, err
+		}
+		if existing != nil {
+			return existing, false, nil
+		}
+	}
+
+	return nil, false, fmt.Errorf("failed to reserve idempotency key %s: record changed concurrently", record.Key)
+}
+
+// Complete stores the response of an IN_PROGRESS record owned by the same request
+func (s *PostgresStore) Complete(ctx context.Context, record *Record) error {
+	query := `
+		UPDATE ` + s.table + `
+		SET status = $3, response = $4, expires_at = $5, updated_at = NOW()
+		WHERE idempotency_key = $1 AND fingerprint = $2 AND status = 'IN_PROGRESS'
+	`
+
+	tag, err := s.pool.Exec(ctx, query,
+		record.Key,
+		record.Fingerprint,
+		string(StatusCompleted),
+		record.Response,
+		record.ExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("idempotency key %s is no longer reserved (lock expired)", record.Key)
+	}
+	return nil
+}
+
+// Release deletes an IN_PROGRESS record
+func (s *PostgresStore) Release(ctx context.Context, key string) error {
+	query := `
+		DELETE FROM ` + s.table + `
+		WHERE idempotency_key = $1 AND status = 'IN_PROGRESS'
+	`
+
+	if _, err := s.pool.Exec(ctx, query, key); err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
+
+// PurgeExpired deletes the records expired before the given time
+func (s *PostgresStore) PurgeExpired(ctx context.Context, before time.Time) (int64, error) {
+	query := `
+		DELETE FROM ` + s.table + `
+		WHERE expires_at < $1
+	`
+
+	tag, err := s.pool.Exec(ctx, query, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge idempotency keys: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// get returns the live record of key, or nil
+func (s *PostgresStore) get(ctx context.Context, key string) (*Record, error) {
+	query := `
+		SELECT idempotency_key, method, fingerprint, status, response, created_at, expires_at
+		FROM ` + s.table + `
+		WHERE idempotency_key = $1 AND expires_at > NOW()
+	`
+
+	var record Record
+	var status string
+	err := s.pool.QueryRow(ctx, query, key).Scan(
+		&record.Key,
+		&record.Method,
+		&record.Fingerprint,
+		&status,
+		&record.Response,
+		&record.CreatedAt,
+		&record.ExpiresAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+	record.Status = Status(status)
+	return &record, nil
+}
+
+// RunPurge deletes expired records every interval until ctx is cancelled
+func (s *PostgresStore) RunPurge(ctx context.Context, interval time.Duration, logger *slog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := s.PurgeExpired(ctx, time.Now())
+			if err != nil {
+				if ctx.Err() == nil {
+					logger.Error("Failed to purge expired idempotency keys", "error", err)
+				}
+				continue
+			}
+			if purged > 0 {
+				logger.Info("Expired idempotency keys purged", "count", purged)
+			}
+		}
+	}
+}
@@ -0,0 +1,50 @@
+// Package idempotency makes mutating gRPC requests of the DICT services idempotent by request_id.
+//
+// The first request with a given request_id reserves it (IN_PROGRESS) and, once the handler
+// returns, stores the serialized response (COMPLETED). A retry with the same request_id and
+// payload gets the original response back without running the operation again; a request_id
+// reused with a different payload is rejected. Records live in Postgres (PostgresStore) and
+// completed ones are cached in Redis (CachedStore). core-dict and conn-bridge share this package,
+// so both derive the key with the same rule (RequestID).
+package idempotency
+
+import (
+	"context"
+	"time"
+)
+
+// Status is the state of an idempotency record
+type Status string
+
+const (
+	StatusInProgress Status = "IN_PROGRESS" // Handler running (or crashed before finishing)
+	StatusCompleted  Status = "COMPLETED"   // Response stored
+)
+
+// Record is the idempotency record of one request_id
+type Record struct {
+	Key         string // request_id
+	Method      string // gRPC full method
+	Fingerprint string // SHA-256 of the method, caller and request payload
+	Status      Status
+	Response    []byte // Serialized google.protobuf.Any (response message or google.rpc.Status)
+	CreatedAt   time.Time
+	ExpiresAt   time.Time // IN_PROGRESS: lock deadline; COMPLETED: end of the replay window
+}
+
+// Live reports whether the record still holds its key at now
+func (r *Record) Live(now time.Time) bool {
+	return now.Before(r.ExpiresAt)
+}
+
+// Store persists idempotency records
+type Store interface {
+	// Reserve inserts record (IN_PROGRESS) unless its key already has a live record, which is
+	// returned with reserved=false. Expired records, including IN_PROGRESS ones left by a crashed
+	// request, are taken over.
+	Reserve(ctx context.Context, record *Record) (existing *Record, reserved bool, err error)
+	// Complete stores the response of a reserved key (record.Status COMPLETED)
+	Complete(ctx context.Context, record *Record) error
+	// Release deletes an IN_PROGRESS reservation, so the request can be retried
+	Release(ctx context.Context, key string) error
+}